@@ -20,12 +20,15 @@
 package answercmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/conf"
+	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/cli"
+	"github.com/apache/incubator-answer/internal/cli/admin"
 	"github.com/apache/incubator-answer/internal/install"
 	"github.com/apache/incubator-answer/internal/migrations"
 	"github.com/apache/incubator-answer/plugin"
@@ -53,6 +56,23 @@ var (
 	i18nSourcePath string
 	// i18nTargetPath i18n to path
 	i18nTargetPath string
+	// the username, email, password and role used by the user management commands
+	userName     string
+	userEmail    string
+	userPassword string
+	userRole     string
+	// the role given to the user by the promote command
+	promoteRole string
+	// print the statements of the pending migrations without running them
+	migrateDryRun bool
+	// roll back the migrations after this version
+	rollbackVersion string
+	// the database the data is copied to, and the number of rows copied in one batch
+	transferDriver    string
+	transferDSN       string
+	transferBatchSize int
+	// the options of exporting the accepted questions and answers as static documents
+	exportDocsOptions = &admin.DocsExportOptions{}
 )
 
 func init() {
@@ -76,7 +96,50 @@ func init() {
 
 	i18nCmd.Flags().StringVarP(&i18nTargetPath, "target", "t", "", "i18n target path, eg: -t ./i18n/target")
 
-	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd} {
+	userCreateCmd.Flags().StringVarP(&userName, "username", "u", "", "username, eg: -u admin")
+
+	userCreateCmd.Flags().StringVarP(&userEmail, "email", "e", "", "email, eg: -e admin@example.com")
+
+	userCreateCmd.Flags().StringVarP(&userPassword, "password", "p", "", "password, eg: -p 12345678")
+
+	userCreateCmd.Flags().StringVarP(&userRole, "role", "r", "user", "role of the user: user, moderator or admin, eg: -r admin")
+
+	userPromoteCmd.Flags().StringVarP(&promoteRole, "role", "r", "admin", "role of the user: user, moderator or admin, eg: -r moderator")
+
+	userResetPasswordCmd.Flags().StringVarP(&userPassword, "password", "p", "", "new password, eg: -p 12345678")
+
+	migratePlanCmd.Flags().BoolVarP(&migrateDryRun, "dry-run", "d", false, "print the SQL of the pending migrations without running them")
+
+	migrateRollbackCmd.Flags().StringVarP(&rollbackVersion, "to", "t", "", "roll back the migrations after the version, eg: -t v1.4.45")
+
+	migrateDataCmd.Flags().StringVarP(&transferDriver, "driver", "d", "", "driver of the target database: mysql or postgres, eg: -d postgres")
+
+	migrateDataCmd.Flags().StringVarP(&transferDSN, "dsn", "s", "", "connection of the target database, eg: -s \"host=127.0.0.1 user=answer dbname=answer\"")
+
+	migrateDataCmd.Flags().IntVarP(&transferBatchSize, "batch-size", "b", migrations.DefaultTransferBatchSize, "number of rows copied in one batch")
+
+	exportDocsCmd.Flags().StringSliceVarP(&exportDocsOptions.Tags, "tags", "t", []string{}, "slug names of the exported tags, eg: -t golang,docker")
+
+	exportDocsCmd.Flags().StringVarP(&exportDocsOptions.OutputDir, "output", "o", "./docs/", "directory of the exported documents, eg: -o ./docs/")
+
+	exportDocsCmd.Flags().StringVarP(&exportDocsOptions.Format, "format", "f", admin.DocsFormatHTML, "format of the documents: html or markdown, eg: -f markdown")
+
+	exportDocsCmd.Flags().StringVarP(&exportDocsOptions.TemplateDir, "template", "p", "", "directory of the custom templates, eg: -p ./templates/")
+
+	exportDocsCmd.Flags().BoolVarP(&exportDocsOptions.Full, "full", "", false, "render all the documents again instead of only the changed ones")
+
+	exportCmd.AddCommand(exportDocsCmd)
+
+	for _, cmd := range []*cobra.Command{migrateStatusCmd, migratePlanCmd, migrateRollbackCmd, migrateDataCmd} {
+		migrateCmd.AddCommand(cmd)
+	}
+
+	for _, cmd := range []*cobra.Command{userCreateCmd, userPromoteCmd, userDeactivateCmd, userResetPasswordCmd, userPurgeCmd} {
+		userCmd.AddCommand(cmd)
+	}
+
+	for _, cmd := range []*cobra.Command{initCmd, checkCmd, runCmd, dumpCmd, upgradeCmd, buildCmd, pluginCmd, configCmd, i18nCmd,
+		userCmd, cacheCmd, reindexCmd, recountCmd, migrateCmd, exportCmd} {
 		rootCmd.AddCommand(cmd)
 	}
 }
@@ -157,6 +220,115 @@ To run answer, use:
 		},
 	}
 
+	// migrateCmd inspect and roll back the database migrations
+	migrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "manage the database migrations",
+		Long:  `Show the status of the database migrations, the pending ones, or roll back the latest ones`,
+	}
+
+	// migrateStatusCmd prints the status of all the migrations
+	migrateStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "show the status of the migrations",
+		Long:  `Show whether each migration is applied, and whether it is the same one the application has`,
+		Run: func(_ *cobra.Command, _ []string) {
+			c := readMigrateConfig()
+			status, currentDBVersion, err := migrations.MigrationStatusByConfig(c.Data.Database)
+			if err != nil {
+				exitMigrate("get migration status failed: %v", err)
+			}
+			fmt.Printf("current db version is %d, latest version is %d\n", currentDBVersion, migrations.ExpectedVersion())
+			for _, s := range status {
+				state := "pending"
+				if s.FingerprintMismatch() {
+					state = "applied, fingerprint mismatch"
+				} else if s.Applied {
+					state = "applied"
+				}
+				fmt.Printf("[%d] %s %s: %s\n", s.VersionNumber, s.Version, s.Description, state)
+			}
+		},
+	}
+
+	// migratePlanCmd prints the pending migrations
+	migratePlanCmd = &cobra.Command{
+		Use:   "plan",
+		Short: "show the pending migrations",
+		Long: `Show the migrations run by the upgrade, and print their SQL with --dry-run.
+The dry run stops each migration at its first change, so the statements after it are not shown`,
+		Run: func(_ *cobra.Command, _ []string) {
+			c := readMigrateConfig()
+			if migrateDryRun {
+				if err := migrations.DryRun(c.Data.Database, os.Stdout); err != nil {
+					exitMigrate("dry run failed: %v", err)
+				}
+				return
+			}
+			status, _, err := migrations.MigrationStatusByConfig(c.Data.Database)
+			if err != nil {
+				exitMigrate("get migration status failed: %v", err)
+			}
+			pending := 0
+			for _, s := range status {
+				if !s.Applied {
+					pending++
+					fmt.Printf("[%d] %s %s\n", s.VersionNumber, s.Version, s.Description)
+				}
+			}
+			fmt.Printf("%d migrations pending\n", pending)
+		},
+	}
+
+	// migrateRollbackCmd rolls back the latest migrations
+	migrateRollbackCmd = &cobra.Command{
+		Use:   "rollback",
+		Short: "roll back the latest migrations",
+		Long: `Roll back the migrations after the version, eg: answer migrate rollback -t v1.4.45.
+Only the migrations since v1.4.46 support rollback`,
+		Run: func(_ *cobra.Command, _ []string) {
+			c := readMigrateConfig()
+			if len(rollbackVersion) == 0 {
+				exitMigrate("the version to roll back to is required, eg: -t v1.4.45")
+			}
+			if err := migrations.Rollback(c.Debug, c.Data.Database, c.Data.Cache, rollbackVersion); err != nil {
+				exitMigrate("rollback failed: %v", err)
+			}
+			fmt.Println("rollback done")
+		},
+	}
+
+	// migrateDataCmd copies all the data to another database
+	migrateDataCmd = &cobra.Command{
+		Use:   "data",
+		Short: "copy all the data to another database",
+		Long:  `Copy all the tables from the database in the config to another empty database, eg: answer migrate data -d postgres -s "..."`,
+		Run: func(_ *cobra.Command, _ []string) {
+			c := readMigrateConfig()
+			if len(transferDriver) == 0 || len(transferDSN) == 0 {
+				exitMigrate("the driver and the connection of the target database are required")
+			}
+			target := &data.Database{Driver: transferDriver, Connection: transferDSN}
+			results, err := migrations.TransferData(c.Data.Database, target, transferBatchSize, os.Stdout)
+			if err != nil {
+				exitMigrate("migrate data failed: %v", err)
+			}
+			mismatch := 0
+			for _, r := range results {
+				state := "ok"
+				if r.SourceCount != r.TargetCount {
+					state = "mismatch"
+					mismatch++
+				}
+				fmt.Printf("%s: source %d, target %d, %s\n", r.Table, r.SourceCount, r.TargetCount, state)
+			}
+			if mismatch > 0 {
+				exitMigrate("%d tables do not match, please check the target database", mismatch)
+			}
+			fmt.Println("migrate data done, update the database in the config to use the new one")
+		},
+	}
+
 	// dumpCmd represents the dump command
 	dumpCmd = &cobra.Command{
 		Use:   "dump",
@@ -280,6 +452,138 @@ To run answer, use:
 		},
 	}
 
+	// userCmd manage the users without the admin ui
+	userCmd = &cobra.Command{
+		Use:   "user",
+		Short: "manage the users",
+		Long:  `Manage the users from the command line, e.g. when no admin can login`,
+	}
+
+	// userCreateCmd create the user
+	userCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "create a user",
+		Long:  `Create an active user with the role, eg: answer user create -u admin -e admin@example.com -p 12345678 -r admin`,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAdminToolkit("create user", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.CreateUser(ctx, userName, userEmail, userPassword, userRole)
+			})
+		},
+	}
+
+	// userPromoteCmd change the role of the user
+	userPromoteCmd = &cobra.Command{
+		Use:   "promote [username or email]",
+		Short: "change the role of a user",
+		Long:  `Change the role of the user, the user has to login again, eg: answer user promote admin@example.com -r admin`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runAdminToolkit("promote user", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.SetUserRole(ctx, args[0], promoteRole)
+			})
+		},
+	}
+
+	// userDeactivateCmd suspend the user
+	userDeactivateCmd = &cobra.Command{
+		Use:   "deactivate [username or email]",
+		Short: "deactivate a user",
+		Long:  `Suspend the user and log out all the sessions of the user`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runAdminToolkit("deactivate user", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.DeactivateUser(ctx, args[0])
+			})
+		},
+	}
+
+	// userResetPasswordCmd reset the password of the user
+	userResetPasswordCmd = &cobra.Command{
+		Use:   "reset-password [username or email]",
+		Short: "reset the password of a user",
+		Long:  `Reset the password of the user and log out all the sessions of the user`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runAdminToolkit("reset password", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.ResetUserPassword(ctx, args[0], userPassword)
+			})
+		},
+	}
+
+	// userPurgeCmd remove all the content of the spammer
+	userPurgeCmd = &cobra.Command{
+		Use:   "purge [username or email]",
+		Short: "purge the spam of a user",
+		Long:  `Suspend the user and remove all the questions, answers and comments of the user`,
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			runAdminToolkit("purge user", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.PurgeUserContent(ctx, args[0])
+			})
+		},
+	}
+
+	// cacheCmd flush the cache
+	cacheCmd = &cobra.Command{
+		Use:   "cache",
+		Short: "rebuild the cache",
+		Long:  `Flush all the cached data, they are loaded from the database again when they are used`,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAdminToolkit("rebuild cache", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.FlushCache(ctx)
+			})
+		},
+	}
+
+	// reindexCmd reindex the search
+	reindexCmd = &cobra.Command{
+		Use:   "reindex",
+		Short: "reindex the search",
+		Long:  `Send all the questions and answers to the search plugins again, and rebuild the embeddings of the semantic search`,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAdminToolkit("reindex", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.ReindexSearch(ctx)
+			})
+		},
+	}
+
+	// recountCmd recount the statistics
+	recountCmd = &cobra.Command{
+		Use:   "recount",
+		Short: "recount the statistics",
+		Long:  `Recount the answers of the questions, the questions and answers of the users, and the questions of the tags`,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAdminToolkit("recount", func(ctx context.Context, t *admin.Toolkit) error {
+				return t.RecountStatistics(ctx)
+			})
+		},
+	}
+
+	// exportCmd export the content of the site
+	exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "export the content",
+		Long:  `Export the content of the site for using outside of it`,
+	}
+
+	// exportDocsCmd export the accepted questions and answers as static documents
+	exportDocsCmd = &cobra.Command{
+		Use:   "docs",
+		Short: "export the accepted questions and answers as static documents",
+		Long: `Render the questions with accepted answers under the tags into a static html or markdown bundle,
+for offline documents or intranet mirrors. Only the changed questions are rendered again on the next export.`,
+		Run: func(_ *cobra.Command, _ []string) {
+			runAdminToolkit("export docs", func(ctx context.Context, t *admin.Toolkit) error {
+				result, err := t.ExportDocs(ctx, exportDocsOptions)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%d written, %d unchanged, %d removed\n", result.Written, result.Skipped, result.Removed)
+				return nil
+			})
+		},
+	}
+
 	// i18nCmd used to merge i18n files
 	i18nCmd = &cobra.Command{
 		Use:   "i18n",
@@ -303,6 +607,49 @@ To run answer, use:
 	}
 )
 
+// readMigrateConfig read the config for the migrate commands, the process exits if it fails
+func readMigrateConfig() (c *conf.AllConfig) {
+	log.SetLogger(log.NewStdLogger(os.Stdout))
+	cli.FormatAllPath(dataDirPath)
+	c, err := conf.ReadConfig(cli.GetConfigFilePath())
+	if err != nil {
+		exitMigrate("read config failed: %v", err)
+	}
+	return c
+}
+
+// exitMigrate print the error of the migrate command and exit with the non-zero code, so the scripts can check it
+func exitMigrate(format string, args ...interface{}) {
+	fmt.Printf(format+"\n", args...)
+	os.Exit(1)
+}
+
+// runAdminToolkit run the management command with the database and the cache in the config,
+// the process exits with the non-zero code if the command fails, so the scripts can check it
+func runAdminToolkit(name string, fn func(ctx context.Context, t *admin.Toolkit) error) {
+	if err := execAdminToolkit(fn); err != nil {
+		fmt.Printf("%s failed: %v\n", name, err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s successfully\n", name)
+}
+
+// execAdminToolkit run the function with the toolkit and close the toolkit after it
+func execAdminToolkit(fn func(ctx context.Context, t *admin.Toolkit) error) (err error) {
+	log.SetLogger(log.NewStdLogger(os.Stdout))
+	cli.FormatAllPath(dataDirPath)
+	c, err := conf.ReadConfig(cli.GetConfigFilePath())
+	if err != nil {
+		return fmt.Errorf("read config failed: %w", err)
+	}
+	toolkit, err := admin.NewToolkit(c.Data.Database, c.Data.Cache, c.ServiceConfig)
+	if err != nil {
+		return err
+	}
+	defer toolkit.Close()
+	return fn(context.Background(), toolkit)
+}
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main(). It only needs to happen once to the rootCmd.
 func Execute() {
@@ -23,17 +23,22 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/conf"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/cron"
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/base/server"
 	"github.com/apache/incubator-answer/internal/cli"
+	"github.com/apache/incubator-answer/internal/migrations"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/contrib/log/zap"
-	"github.com/segmentfault/pacman/contrib/server/http"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -71,6 +76,11 @@ func runApp() {
 	if err != nil {
 		panic(err)
 	}
+	if err = migrations.CheckPendingMigrations(c.Data.Database); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	setupReload(c)
 	app, cleanup, err := initApplication(
 		c.Debug, c.Server, c.Data.Database, c.Data.Cache, c.I18n, c.Swaggerui, c.ServiceConfig, c.UI, log.GetLogger())
 	if err != nil {
@@ -83,16 +93,59 @@ func runApp() {
 	fmt.Println("answer Version:", constant.Version, " Revision:", constant.Revision)
 
 	defer cleanup()
-	if err := app.Run(context.Background()); err != nil {
+	err = app.Run(context.Background())
+	// the in-flight requests are drained, stop the jobs and handle the queued messages before closing the database
+	ctx, cancel := context.WithTimeout(context.Background(), server.ShutdownTimeout(c.Server.HTTP))
+	lifecycle.Shutdown(ctx)
+	cancel()
+	if err != nil {
 		panic(err)
 	}
 }
 
-func newApplication(serverConf *conf.Server, server *gin.Engine, manager *cron.ScheduledTaskManager) *pacman.Application {
+// setupReload apply the runtime settings in the config, and apply them again when the process receives SIGHUP
+// or the admin asks to reload the config
+func setupReload(c *conf.AllConfig) {
+	currentLevel := logLevel
+	reload.OnApply(func(s *reload.Settings) {
+		level := s.LogLevel
+		if len(level) == 0 {
+			level = logLevel
+		}
+		if level == currentLevel {
+			return
+		}
+		currentLevel = level
+		log.SetLogger(zap.NewLogger(log.ParseLevel(level), zap.WithName("answer"), zap.WithPath(logPath)))
+		log.Infof("log level changed to %s", level)
+	})
+	reload.SetReloadFunc(func() (*reload.Settings, error) {
+		c, err := conf.ReadConfig(cli.GetConfigFilePath())
+		if err != nil {
+			return nil, err
+		}
+		return c.Runtime, nil
+	})
+	reload.Apply(c.Runtime)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for range signals {
+			if err := reload.Reload(context.Background()); err != nil {
+				log.Errorf("reload config failed: %v", err)
+			} else {
+				log.Info("config reloaded")
+			}
+		}
+	}()
+}
+
+func newApplication(serverConf *conf.Server, engine *gin.Engine, manager *cron.ScheduledTaskManager) *pacman.Application {
 	manager.Run()
 	return pacman.NewApp(
 		pacman.WithName(Name),
 		pacman.WithVersion(Version),
-		pacman.WithServer(http.NewServer(server, serverConf.HTTP.Addr)),
+		pacman.WithServer(server.NewGracefulServer(engine, serverConf.HTTP)),
 	)
 }
@@ -38,74 +38,193 @@ import (
 	"github.com/apache/incubator-answer/internal/controller_admin"
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/ai_assistant"
+	"github.com/apache/incubator-answer/internal/repo/analytics"
+	"github.com/apache/incubator-answer/internal/repo/announcement"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/answer_quality"
+	"github.com/apache/incubator-answer/internal/repo/answer_verification"
+	"github.com/apache/incubator-answer/internal/repo/article"
 	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/banned_word"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
+	"github.com/apache/incubator-answer/internal/repo/co_author"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/conversion"
+	"github.com/apache/incubator-answer/internal/repo/data_retention"
+	"github.com/apache/incubator-answer/internal/repo/dead_link"
+	"github.com/apache/incubator-answer/internal/repo/delta_sync"
+	"github.com/apache/incubator-answer/internal/repo/edit_lock"
+	"github.com/apache/incubator-answer/internal/repo/email_domain"
+	"github.com/apache/incubator-answer/internal/repo/embedding"
+	"github.com/apache/incubator-answer/internal/repo/event_stream"
+	"github.com/apache/incubator-answer/internal/repo/experiment"
 	"github.com/apache/incubator-answer/internal/repo/export"
+	"github.com/apache/incubator-answer/internal/repo/github_integration"
+	"github.com/apache/incubator-answer/internal/repo/inbound_question"
+	"github.com/apache/incubator-answer/internal/repo/invitation"
+	"github.com/apache/incubator-answer/internal/repo/ip_logging"
+	"github.com/apache/incubator-answer/internal/repo/jira_integration"
+	"github.com/apache/incubator-answer/internal/repo/leaderboard"
+	"github.com/apache/incubator-answer/internal/repo/legal_hold"
 	"github.com/apache/incubator-answer/internal/repo/limit"
+	"github.com/apache/incubator-answer/internal/repo/link_preview"
+	"github.com/apache/incubator-answer/internal/repo/list_index"
+	"github.com/apache/incubator-answer/internal/repo/login_protection"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	"github.com/apache/incubator-answer/internal/repo/moderation_rule"
+	"github.com/apache/incubator-answer/internal/repo/moderation_triage"
+	"github.com/apache/incubator-answer/internal/repo/new_contributor"
 	notification2 "github.com/apache/incubator-answer/internal/repo/notification"
+	"github.com/apache/incubator-answer/internal/repo/page"
+	"github.com/apache/incubator-answer/internal/repo/pending_deletion"
+	"github.com/apache/incubator-answer/internal/repo/permalink"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
+	"github.com/apache/incubator-answer/internal/repo/profile_field"
 	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/question_assignment"
+	"github.com/apache/incubator-answer/internal/repo/question_merge"
+	"github.com/apache/incubator-answer/internal/repo/question_poll"
+	"github.com/apache/incubator-answer/internal/repo/question_share"
+	"github.com/apache/incubator-answer/internal/repo/question_sla"
+	"github.com/apache/incubator-answer/internal/repo/question_summary"
+	"github.com/apache/incubator-answer/internal/repo/question_workflow"
 	"github.com/apache/incubator-answer/internal/repo/rank"
 	"github.com/apache/incubator-answer/internal/repo/reason"
 	"github.com/apache/incubator-answer/internal/repo/report"
 	"github.com/apache/incubator-answer/internal/repo/review"
+	"github.com/apache/incubator-answer/internal/repo/review_reminder"
 	"github.com/apache/incubator-answer/internal/repo/revision"
+	"github.com/apache/incubator-answer/internal/repo/revision_compaction"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/scheduler"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/search_log"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/sitemap"
+	"github.com/apache/incubator-answer/internal/repo/slow_query"
 	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/apache/incubator-answer/internal/repo/tag_auto_subscription"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/upload_access"
 	"github.com/apache/incubator-answer/internal/repo/user"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/repo/user_follow"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/user_onboarding"
+	"github.com/apache/incubator-answer/internal/repo/username_policy"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/internal/service/action"
 	activity2 "github.com/apache/incubator-answer/internal/service/activity"
 	activity_common2 "github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	ai_assistant2 "github.com/apache/incubator-answer/internal/service/ai_assistant"
+	analytics2 "github.com/apache/incubator-answer/internal/service/analytics"
+	announcement2 "github.com/apache/incubator-answer/internal/service/announcement"
 	"github.com/apache/incubator-answer/internal/service/answer_common"
+	answer_quality2 "github.com/apache/incubator-answer/internal/service/answer_quality"
+	answer_verification2 "github.com/apache/incubator-answer/internal/service/answer_verification"
+	article2 "github.com/apache/incubator-answer/internal/service/article"
 	auth2 "github.com/apache/incubator-answer/internal/service/auth"
+	banned_word2 "github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/apache/incubator-answer/internal/service/chat_integration"
+	co_author2 "github.com/apache/incubator-answer/internal/service/co_author"
 	collection2 "github.com/apache/incubator-answer/internal/service/collection"
 	"github.com/apache/incubator-answer/internal/service/collection_common"
 	comment2 "github.com/apache/incubator-answer/internal/service/comment"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	config2 "github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	conversion2 "github.com/apache/incubator-answer/internal/service/conversion"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	data_retention2 "github.com/apache/incubator-answer/internal/service/data_retention"
+	dead_link2 "github.com/apache/incubator-answer/internal/service/dead_link"
+	delta_sync2 "github.com/apache/incubator-answer/internal/service/delta_sync"
+	edit_lock2 "github.com/apache/incubator-answer/internal/service/edit_lock"
+	email_domain2 "github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/apache/incubator-answer/internal/service/email_reply"
+	embedding2 "github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	event_stream2 "github.com/apache/incubator-answer/internal/service/event_stream"
+	experiment2 "github.com/apache/incubator-answer/internal/service/experiment"
 	export2 "github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	github_integration2 "github.com/apache/incubator-answer/internal/service/github_integration"
+	inbound_question2 "github.com/apache/incubator-answer/internal/service/inbound_question"
+	invitation2 "github.com/apache/incubator-answer/internal/service/invitation"
+	ip_logging2 "github.com/apache/incubator-answer/internal/service/ip_logging"
+	jira_integration2 "github.com/apache/incubator-answer/internal/service/jira_integration"
+	leaderboard2 "github.com/apache/incubator-answer/internal/service/leaderboard"
+	legal_hold2 "github.com/apache/incubator-answer/internal/service/legal_hold"
+	link_preview2 "github.com/apache/incubator-answer/internal/service/link_preview"
+	list_index2 "github.com/apache/incubator-answer/internal/service/list_index"
+	login_protection2 "github.com/apache/incubator-answer/internal/service/login_protection"
+	"github.com/apache/incubator-answer/internal/service/maintenance"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
 	meta2 "github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
+	moderation_rule2 "github.com/apache/incubator-answer/internal/service/moderation_rule"
+	moderation_triage2 "github.com/apache/incubator-answer/internal/service/moderation_triage"
+	new_contributor2 "github.com/apache/incubator-answer/internal/service/new_contributor"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	"github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/oembed"
+	page2 "github.com/apache/incubator-answer/internal/service/page"
+	"github.com/apache/incubator-answer/internal/service/page_cache"
+	"github.com/apache/incubator-answer/internal/service/password_policy"
+	pending_deletion2 "github.com/apache/incubator-answer/internal/service/pending_deletion"
+	permalink2 "github.com/apache/incubator-answer/internal/service/permalink"
+	"github.com/apache/incubator-answer/internal/service/permission_policy"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	profile_field2 "github.com/apache/incubator-answer/internal/service/profile_field"
+	question_assignment2 "github.com/apache/incubator-answer/internal/service/question_assignment"
+	"github.com/apache/incubator-answer/internal/service/question_batch"
 	"github.com/apache/incubator-answer/internal/service/question_common"
+	question_merge2 "github.com/apache/incubator-answer/internal/service/question_merge"
+	question_poll2 "github.com/apache/incubator-answer/internal/service/question_poll"
+	"github.com/apache/incubator-answer/internal/service/question_quality"
+	question_share2 "github.com/apache/incubator-answer/internal/service/question_share"
+	question_sla2 "github.com/apache/incubator-answer/internal/service/question_sla"
+	question_summary2 "github.com/apache/incubator-answer/internal/service/question_summary"
+	question_workflow2 "github.com/apache/incubator-answer/internal/service/question_workflow"
 	rank2 "github.com/apache/incubator-answer/internal/service/rank"
 	reason2 "github.com/apache/incubator-answer/internal/service/reason"
 	report2 "github.com/apache/incubator-answer/internal/service/report"
 	"github.com/apache/incubator-answer/internal/service/report_handle"
 	review2 "github.com/apache/incubator-answer/internal/service/review"
+	review_reminder2 "github.com/apache/incubator-answer/internal/service/review_reminder"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
+	revision_compaction2 "github.com/apache/incubator-answer/internal/service/revision_compaction"
 	role2 "github.com/apache/incubator-answer/internal/service/role"
+	scheduler2 "github.com/apache/incubator-answer/internal/service/scheduler"
+	search_log2 "github.com/apache/incubator-answer/internal/service/search_log"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/service_config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	sitemap2 "github.com/apache/incubator-answer/internal/service/sitemap"
+	slow_query2 "github.com/apache/incubator-answer/internal/service/slow_query"
 	tag2 "github.com/apache/incubator-answer/internal/service/tag"
+	tag_auto_subscription2 "github.com/apache/incubator-answer/internal/service/tag_auto_subscription"
 	tag_common2 "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/title_quality"
+	upload_access2 "github.com/apache/incubator-answer/internal/service/upload_access"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	"github.com/apache/incubator-answer/internal/service/user_common"
 	user_external_login2 "github.com/apache/incubator-answer/internal/service/user_external_login"
+	user_follow2 "github.com/apache/incubator-answer/internal/service/user_follow"
 	user_notification_config2 "github.com/apache/incubator-answer/internal/service/user_notification_config"
+	user_onboarding2 "github.com/apache/incubator-answer/internal/service/user_onboarding"
+	username_policy2 "github.com/apache/incubator-answer/internal/service/username_policy"
 	"github.com/segmentfault/pacman"
 	"github.com/segmentfault/pacman/log"
 )
@@ -136,7 +255,8 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	siteInfoCommonService := siteinfo_common.NewSiteInfoCommonService(siteInfoRepo)
 	langController := controller.NewLangController(i18nTranslator, siteInfoCommonService)
 	authRepo := auth.NewAuthRepo(dataData)
-	authService := auth2.NewAuthService(authRepo)
+	userSessionRepo := auth.NewUserSessionRepo(dataData)
+	authService := auth2.NewAuthService(authRepo, userSessionRepo)
 	userRepo := user.NewUserRepo(dataData)
 	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
 	configRepo := config.NewConfigRepo(dataData)
@@ -145,16 +265,28 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	userRankRepo := rank.NewUserRankRepo(dataData, configService)
 	userActiveActivityRepo := activity.NewUserActiveActivityRepo(dataData, activityRepo, userRankRepo, configService)
 	emailRepo := export.NewEmailRepo(dataData)
-	emailService := export2.NewEmailService(configService, emailRepo, siteInfoCommonService)
+	emailDeliveryRepo := export.NewEmailDeliveryRepo(dataData)
+	emailTemplateRepo := export.NewEmailTemplateRepo(dataData)
+	emailService := export2.NewEmailService(configService, emailRepo, emailDeliveryRepo, emailTemplateRepo, siteInfoCommonService)
 	userRoleRelRepo := role.NewUserRoleRelRepo(dataData)
 	roleRepo := role.NewRoleRepo(dataData)
-	roleService := role2.NewRoleService(roleRepo)
+	powerRepo := role.NewPowerRepo(dataData)
+	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	roleService := role2.NewRoleService(roleRepo, powerRepo, rolePowerRelRepo, userRoleRelRepo)
 	userRoleRelService := role2.NewUserRoleRelService(userRoleRelRepo, roleService)
 	userCommon := usercommon.NewUserCommon(userRepo, userRoleRelService, authService, siteInfoCommonService)
 	userExternalLoginRepo := user_external_login.NewUserExternalLoginRepo(dataData)
 	userNotificationConfigRepo := user_notification_config.NewUserNotificationConfigRepo(dataData)
 	userNotificationConfigService := user_notification_config2.NewUserNotificationConfigService(userRepo, userNotificationConfigRepo)
-	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService)
+	eventQueueService := event_queue.NewEventQueueService()
+	blockedSignupRepo := email_domain.NewBlockedSignupRepo(dataData)
+	emailDomainService := email_domain2.NewEmailDomainService(blockedSignupRepo, siteInfoCommonService)
+	bannedWordHitRepo := banned_word.NewBannedWordHitRepo(dataData)
+	bannedWordService := banned_word2.NewBannedWordService(bannedWordHitRepo, siteInfoRepo, siteInfoCommonService)
+	usernameHistoryRepo := username_policy.NewUsernameHistoryRepo(dataData)
+	userNameChangeRepo := username_policy.NewUserNameChangeRepo(dataData)
+	usernamePolicyService := username_policy2.NewUsernamePolicyService(usernameHistoryRepo, userNameChangeRepo, userRepo, userRoleRelService, siteInfoRepo, siteInfoCommonService)
+	userExternalLoginService := user_external_login2.NewUserExternalLoginService(userRepo, userCommon, userExternalLoginRepo, emailService, siteInfoCommonService, userActiveActivityRepo, userNotificationConfigService, eventQueueService, emailDomainService)
 	questionRepo := question.NewQuestionRepo(dataData, uniqueIDRepo)
 	answerRepo := answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo)
 	voteRepo := activity_common.NewVoteRepo(dataData, activityRepo)
@@ -165,111 +297,299 @@ func initApplication(debug bool, serverConf *conf.Server, dbConf *data.Database,
 	revisionRepo := revision.NewRevisionRepo(dataData, uniqueIDRepo)
 	revisionService := revision_common.NewRevisionService(revisionRepo, userRepo)
 	activityQueueService := activity_queue.NewActivityQueueService()
-	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService)
+	tagCommonService := tag_common2.NewTagCommonService(tagCommonRepo, tagRelRepo, tagRepo, revisionService, siteInfoCommonService, activityQueueService, eventQueueService)
 	collectionRepo := collection.NewCollectionRepo(dataData, uniqueIDRepo)
 	collectionCommon := collectioncommon.NewCollectionCommon(collectionRepo)
 	answerCommon := answercommon.NewAnswerCommon(answerRepo)
 	metaRepo := meta.NewMetaRepo(dataData)
 	metaCommonService := metacommon.NewMetaCommonService(metaRepo)
 	questionCommon := questioncommon.NewQuestionCommon(questionRepo, answerRepo, voteRepo, followRepo, tagCommonService, userCommon, collectionCommon, answerCommon, metaCommonService, configService, activityQueueService, revisionRepo, dataData)
-	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon)
+	profileFieldRepo := profile_field.NewProfileFieldRepo(dataData)
+	profileFieldService := profile_field2.NewProfileFieldService(profileFieldRepo)
+	userFollowRepo := user_follow.NewUserFollowRepo(dataData)
+	userFollowService := user_follow2.NewUserFollowService(userFollowRepo, userCommon, activityRepo, questionRepo, answerRepo)
+	passwordPolicyService := password_policy.NewPasswordPolicyService(siteInfoCommonService)
+	invitationRepo := invitation.NewInvitationRepo(dataData)
+	invitationService := invitation2.NewInvitationService(invitationRepo, userRepo, userCommon, siteInfoCommonService)
+	userOnboardingRepo := user_onboarding.NewUserOnboardingRepo(dataData, userRankRepo, configService)
+	userOnboardingService := user_onboarding2.NewUserOnboardingService(userOnboardingRepo, userRepo, followRepo, configService)
+	experimentRepo := experiment.NewExperimentRepo(dataData)
+	experimentService := experiment2.NewExperimentService(experimentRepo, siteInfoRepo, siteInfoCommonService)
+	featureFlagService := feature_flag.NewFeatureFlagService(siteInfoRepo, siteInfoCommonService, userRoleRelService)
+	userService := content.NewUserService(userRepo, userActiveActivityRepo, activityRepo, emailService, authService, siteInfoCommonService, userRoleRelService, userCommon, userExternalLoginService, userNotificationConfigRepo, userNotificationConfigService, questionCommon, eventQueueService, profileFieldService, userFollowService, passwordPolicyService, invitationService, emailDomainService, userOnboardingService, experimentService, bannedWordService, usernamePolicyService)
 	captchaRepo := captcha.NewCaptchaRepo(dataData)
 	captchaService := action.NewCaptchaService(captchaRepo)
-	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService)
+	userStatsRepo := user.NewUserStatsRepo(dataData)
+	userStatsService := content.NewUserStatsService(userStatsRepo, userRepo, activityRepo, tagCommonService, dataData)
+	loginProtectionRepo := login_protection.NewLoginProtectionRepo(dataData)
+	loginProtectionService := login_protection2.NewLoginProtectionService(dataData, loginProtectionRepo, userCommon, siteInfoCommonService, emailService)
+	userController := controller.NewUserController(authService, userService, captchaService, emailService, siteInfoCommonService, userNotificationConfigService, userStatsService, emailDomainService, loginProtectionService)
 	commentRepo := comment.NewCommentRepo(dataData, uniqueIDRepo)
 	commentCommonRepo := comment.NewCommentCommonRepo(dataData, uniqueIDRepo)
-	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService)
+	articleRepo := article.NewArticleRepo(dataData, uniqueIDRepo)
+	objService := object_info.NewObjService(answerRepo, questionRepo, commentCommonRepo, tagCommonRepo, tagCommonService, articleRepo)
+	legalHoldRepo := legal_hold.NewLegalHoldRepo(dataData)
+	legalHoldService := legal_hold2.NewLegalHoldService(legalHoldRepo, objService, userCommon, userRoleRelService)
+	ipLoggingRepo := ip_logging.NewIPLoggingRepo(dataData)
+	ipLoggingService := ip_logging2.NewIPLoggingService(ipLoggingRepo, siteInfoRepo, siteInfoCommonService, userCommon)
 	notificationQueueService := notice_queue.NewNotificationQueueService()
 	externalNotificationQueueService := notice_queue.NewNewQuestionNotificationQueueService()
-	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService)
-	rolePowerRelRepo := role.NewRolePowerRelRepo(dataData)
+	pendingDeletionRepo := pending_deletion.NewPendingDeletionRepo(dataData)
+	pendingDeletionService := pending_deletion2.NewPendingDeletionService(pendingDeletionRepo, siteInfoCommonService, answerRepo, userCommon, legalHoldService, activityQueueService)
+	commentService := comment2.NewCommentService(commentRepo, commentCommonRepo, userCommon, objService, voteRepo, emailService, userRepo, notificationQueueService, externalNotificationQueueService, activityQueueService, pendingDeletionService, eventQueueService, legalHoldService, ipLoggingService)
 	rolePowerRelService := role2.NewRolePowerRelService(rolePowerRelRepo, userRoleRelService)
-	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService)
+	coAuthorRepo := co_author.NewCoAuthorRepo(dataData)
+	coAuthorService := co_author2.NewCoAuthorService(coAuthorRepo, objService, userCommon)
+	permissionPolicyService := permission_policy.NewPermissionPolicyService(siteInfoRepo, siteInfoCommonService, configService, roleService, userCommon)
+	rankService := rank2.NewRankService(userCommon, userRankRepo, objService, userRoleRelService, rolePowerRelService, configService, coAuthorService, permissionPolicyService)
 	limitRepo := limit.NewRateLimitRepo(dataData)
 	rateLimitMiddleware := middleware.NewRateLimitMiddleware(limitRepo)
-	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware)
+	contentFilterService := content_filter.NewContentFilterService(bannedWordService)
+	contentLimitService := content_limit.NewContentLimitService(siteInfoRepo, siteInfoCommonService, userRoleRelService)
+	commentController := controller.NewCommentController(commentService, rankService, captchaService, rateLimitMiddleware, contentFilterService, contentLimitService)
 	reportRepo := report.NewReportRepo(dataData, uniqueIDRepo)
 	answerActivityRepo := activity.NewAnswerActivityRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
 	answerActivityService := activity2.NewAnswerActivityService(answerActivityRepo, configService)
-	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService)
+	externalNotificationService := notification.NewExternalNotificationService(dataData, userNotificationConfigRepo, followRepo, emailService, userRepo, externalNotificationQueueService, userExternalLoginRepo, siteInfoCommonService, userFollowRepo, notificationQueueService)
 	reviewRepo := review.NewReviewRepo(dataData)
-	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService)
-	questionService := content.NewQuestionService(questionRepo, answerRepo, tagCommonService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService)
-	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService)
+	reviewService := review2.NewReviewService(reviewRepo, objService, userCommon, userRepo, questionRepo, answerRepo, userRoleRelService, externalNotificationQueueService, tagCommonService, questionCommon, notificationQueueService, siteInfoCommonService, bannedWordService)
+	moderationRuleHitRepo := moderation_rule.NewModerationRuleHitRepo(dataData)
+	moderationRuleService := moderation_rule2.NewModerationRuleService(moderationRuleHitRepo, siteInfoRepo, siteInfoCommonService, questionRepo, reviewRepo, tagCommonService, userCommon, userRoleRelService, metaCommonService, notificationQueueService, eventQueueService)
+	questionSLARepo := question_sla.NewQuestionSLARepo(dataData)
+	questionSLAService := question_sla2.NewQuestionSLAService(questionSLARepo, questionRepo, tagCommonService, userRepo, userRoleRelService, emailService, notificationQueueService)
+	questionPollRepo := question_poll.NewQuestionPollRepo(dataData)
+	questionPollService := question_poll2.NewQuestionPollService(questionPollRepo, questionRepo, featureFlagService)
+	linkPreviewRepo := link_preview.NewLinkPreviewRepo(dataData)
+	linkPreviewService := link_preview2.NewLinkPreviewService(linkPreviewRepo, siteInfoCommonService)
+	deadLinkRepo := dead_link.NewDeadLinkRepo(dataData)
+	deadLinkService := dead_link2.NewDeadLinkService(deadLinkRepo, siteInfoCommonService)
+	mediaProxyService := media_proxy.NewMediaProxyService(dataData, siteInfoRepo, siteInfoCommonService)
+	uploadAccessRepo := upload_access.NewUploadAccessRepo(dataData)
+	uploadAccessService := upload_access2.NewUploadAccessService(uploadAccessRepo, siteInfoRepo, siteInfoCommonService)
+	aiAssistantRepo := ai_assistant.NewAIAssistantRepo(dataData)
+	aiAssistantService := ai_assistant2.NewAIAssistantService(aiAssistantRepo, questionRepo, answerRepo, siteInfoRepo, siteInfoCommonService, featureFlagService)
+	questionSummaryRepo := question_summary.NewQuestionSummaryRepo(dataData)
+	questionSummaryService := question_summary2.NewQuestionSummaryService(questionSummaryRepo, questionRepo, answerRepo, aiAssistantService, siteInfoCommonService)
+	embeddingRepo := embedding.NewEmbeddingRepo(dataData)
+	embeddingService := embedding2.NewEmbeddingService(embeddingRepo, aiAssistantService)
+	questionQualityService := question_quality.NewQuestionQualityService(siteInfoCommonService)
+	questionWorkflowRepo := question_workflow.NewQuestionWorkflowRepo(dataData)
+	questionWorkflowService := question_workflow2.NewQuestionWorkflowService(questionWorkflowRepo, questionRepo, siteInfoRepo, siteInfoCommonService, userRoleRelService, activityQueueService)
+	questionService := content.NewQuestionService(questionRepo, answerRepo, tagCommonService, questionCommon, userCommon, userRepo, userRoleRelService, revisionService, metaCommonService, collectionCommon, answerActivityService, emailService, notificationQueueService, externalNotificationQueueService, activityQueueService, siteInfoCommonService, externalNotificationService, reviewService, configService, eventQueueService, questionSLAService, questionPollService, pendingDeletionService, linkPreviewService, deadLinkService, questionSummaryService, mediaProxyService, uploadAccessService, questionQualityService, questionWorkflowService, legalHoldService, ipLoggingService)
+	answerQualityRepo := answer_quality.NewAnswerQualityRepo(dataData, activityRepo)
+	answerQualityService := answer_quality2.NewAnswerQualityService(answerQualityRepo, answerRepo, siteInfoCommonService)
+	answerVerificationRepo := answer_verification.NewAnswerVerificationRepo(dataData)
+	answerVerificationService := answer_verification2.NewAnswerVerificationService(answerVerificationRepo, answerRepo, tagCommonService, userCommon, userRoleRelService, siteInfoRepo, siteInfoCommonService)
+	tagAutoSubscriptionRepo := tag_auto_subscription.NewTagAutoSubscriptionRepo(dataData)
+	tagAutoSubscriptionService := tag_auto_subscription2.NewTagAutoSubscriptionService(tagAutoSubscriptionRepo, siteInfoRepo, siteInfoCommonService, questionRepo, tagCommonService, userCommon, userRepo, userRoleRelService, answerVerificationService, notificationQueueService, emailService, eventQueueService)
+	answerService := content.NewAnswerService(answerRepo, questionRepo, questionCommon, userCommon, collectionCommon, userRepo, revisionService, answerActivityService, answerCommon, voteRepo, emailService, userRoleRelService, notificationQueueService, externalNotificationQueueService, activityQueueService, reviewService, eventQueueService, pendingDeletionService, linkPreviewService, deadLinkService, answerQualityService, siteInfoCommonService, mediaProxyService, uploadAccessService, answerVerificationService, legalHoldService, ipLoggingService)
 	reportHandle := report_handle.NewReportHandle(questionService, answerService, commentService)
 	reportService := report2.NewReportService(reportRepo, objService, userCommon, answerRepo, questionRepo, commentCommonRepo, reportHandle, configService)
 	reportController := controller.NewReportController(reportService, rankService, captchaService)
 	contentVoteRepo := activity.NewVoteRepo(dataData, activityRepo, userRankRepo, notificationQueueService)
-	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService)
+	voteService := content.NewVoteService(contentVoteRepo, configService, questionRepo, answerRepo, commentCommonRepo, objService, userCommon)
 	voteController := controller.NewVoteController(voteService, rankService, captchaService)
-	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService)
-	tagController := controller.NewTagController(tagService, tagCommonService, rankService)
+	tagService := tag2.NewTagService(tagRepo, tagCommonService, revisionService, followRepo, siteInfoCommonService, activityQueueService, eventQueueService)
+	tagStatsRepo := tag.NewTagStatsRepo(dataData)
+	tagStatsService := tag2.NewTagStatsService(tagStatsRepo, tagCommonService, userCommon, dataData)
+	userTagScoreRepo := tag.NewUserTagScoreRepo(dataData)
+	userTagScoreService := tag2.NewUserTagScoreService(userTagScoreRepo, tagCommonService, userCommon)
+	tagSuggestService := tag2.NewTagSuggestService(tagCommonService, embeddingService)
+	tagController := controller.NewTagController(tagService, tagCommonService, tagStatsService, userTagScoreService, tagSuggestService, rankService)
 	followFollowRepo := activity.NewFollowRepo(dataData, uniqueIDRepo, activityRepo)
 	followService := follow.NewFollowService(followFollowRepo, followRepo, tagCommonRepo)
-	followController := controller.NewFollowController(followService)
+	followController := controller.NewFollowController(followService, userFollowService)
 	collectionGroupRepo := collection.NewCollectionGroupRepo(dataData)
 	collectionService := collection2.NewCollectionService(collectionRepo, collectionGroupRepo, questionCommon)
 	collectionController := controller.NewCollectionController(collectionService)
-	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware)
-	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware)
+	questionShareRepo := question_share.NewQuestionShareRepo(dataData)
+	questionShareService := question_share2.NewQuestionShareService(questionShareRepo, questionRepo, tagCommonService, siteInfoCommonService)
+	newContributorRepo := new_contributor.NewNewContributorRepo(dataData)
+	newContributorService := new_contributor2.NewNewContributorService(newContributorRepo, userRepo, siteInfoCommonService)
+	questionController := controller.NewQuestionController(questionService, answerService, rankService, siteInfoCommonService, captchaService, rateLimitMiddleware, contentFilterService, questionShareService, newContributorService, contentLimitService)
+	answerController := controller.NewAnswerController(answerService, rankService, captchaService, siteInfoCommonService, rateLimitMiddleware, contentFilterService, questionShareService, newContributorService, contentLimitService)
 	searchParser := search_parser.NewSearchParser(tagCommonService, userCommon)
 	searchRepo := search_common.NewSearchRepo(dataData, uniqueIDRepo, userCommon, tagCommonService)
-	searchService := content.NewSearchService(searchParser, searchRepo)
+	searchLogRepo := search_log.NewSearchLogRepo(dataData)
+	searchLogService := search_log2.NewSearchLogService(searchLogRepo)
+	searchService := content.NewSearchService(searchParser, searchRepo, searchLogService, questionSummaryService, embeddingService)
 	searchController := controller.NewSearchController(searchService, captchaService)
 	reviewActivityRepo := activity.NewReviewActivityRepo(dataData, activityRepo, userRankRepo, configService)
-	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo)
+	contentRevisionService := content.NewRevisionService(revisionRepo, userCommon, questionCommon, answerService, objService, questionRepo, answerRepo, tagRepo, tagCommonService, notificationQueueService, activityQueueService, reportRepo, reviewService, reviewActivityRepo, questionQualityService)
 	revisionController := controller.NewRevisionController(contentRevisionService, rankService)
 	rankController := controller.NewRankController(rankService)
 	userAdminRepo := user.NewUserAdminRepo(dataData, authRepo)
-	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo)
+	userAdminService := user_admin.NewUserAdminService(userAdminRepo, userRoleRelService, authService, userCommon, userActiveActivityRepo, siteInfoCommonService, emailService, questionRepo, answerRepo, commentCommonRepo, eventQueueService, usernamePolicyService, legalHoldService)
 	userAdminController := controller_admin.NewUserAdminController(userAdminService)
 	reasonRepo := reason.NewReasonRepo(configService)
 	reasonService := reason2.NewReasonService(reasonRepo)
 	reasonController := controller.NewReasonController(reasonService)
 	themeController := controller_admin.NewThemeController()
 	siteInfoService := siteinfo.NewSiteInfoService(siteInfoRepo, siteInfoCommonService, emailService, tagCommonService, configService, questionCommon)
-	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService)
-	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService)
+	uploaderService := uploader.NewUploaderService(serviceConf, siteInfoCommonService)
+	siteInfoController := controller_admin.NewSiteInfoController(siteInfoService, uploaderService)
+	maintenanceService := maintenance.NewMaintenanceService(siteInfoRepo, siteInfoCommonService)
+	controllerSiteInfoController := controller.NewSiteInfoController(siteInfoCommonService, experimentService, featureFlagService, maintenanceService)
 	notificationRepo := notification2.NewNotificationRepo(dataData)
 	notificationCommon := notificationcommon.NewNotificationCommon(dataData, notificationRepo, userCommon, activityRepo, followRepo, objService, notificationQueueService, userExternalLoginRepo, siteInfoCommonService)
 	notificationService := notification.NewNotificationService(dataData, notificationRepo, notificationCommon, revisionService, userRepo, reportRepo, reviewService)
 	notificationController := controller.NewNotificationController(notificationService, rankService)
 	dashboardService := dashboard.NewDashboardService(questionRepo, answerRepo, commentCommonRepo, voteRepo, userRepo, reportRepo, configService, siteInfoCommonService, serviceConf, reviewService, revisionRepo, dataData)
 	dashboardController := controller.NewDashboardController(dashboardService)
-	uploaderService := uploader.NewUploaderService(serviceConf, siteInfoCommonService)
 	uploadController := controller.NewUploadController(uploaderService)
 	activityActivityRepo := activity.NewActivityRepo(dataData, configService)
 	activityCommon := activity_common2.NewActivityCommon(activityRepo, activityQueueService)
 	commentCommonService := comment_common.NewCommentCommonService(commentCommonRepo)
 	activityService := activity2.NewActivityService(activityActivityRepo, userCommon, activityCommon, tagCommonService, objService, commentCommonService, revisionService, metaCommonService, configService)
-	activityController := controller.NewActivityController(activityService)
+	activityController := controller.NewActivityController(activityService, rankService)
 	roleController := controller_admin.NewRoleController(roleService)
 	pluginConfigRepo := plugin_config.NewPluginConfigRepo(dataData)
 	pluginUserConfigRepo := plugin_config.NewPluginUserConfigRepo(dataData)
-	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, configService, dataData)
-	pluginController := controller_admin.NewPluginController(pluginCommonService)
-	permissionController := controller.NewPermissionController(rankService)
+	pluginKVStorageRepo := plugin_config.NewPluginKVStorageRepo(dataData)
+	pluginCommonService := plugin_common.NewPluginCommonService(pluginConfigRepo, pluginUserConfigRepo, pluginKVStorageRepo, configService, dataData)
+	pluginJobRepo := plugin_config.NewPluginJobRepo(dataData)
+	pluginJobService := plugin_common.NewPluginJobService(pluginJobRepo)
+	pluginController := controller_admin.NewPluginController(pluginCommonService, pluginJobService)
+	permissionController := controller.NewPermissionController(rankService, newContributorService)
 	userPluginController := controller.NewUserPluginController(pluginCommonService)
 	reviewController := controller.NewReviewController(reviewService, rankService, captchaService)
 	metaService := meta2.NewMetaService(metaCommonService, userCommon, answerRepo, questionRepo)
 	metaController := controller.NewMetaController(metaService)
-	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController)
+	analyticsRepo := analytics.NewAnalyticsRepo(dataData)
+	analyticsService := analytics2.NewAnalyticsService(analyticsRepo, tagCommonService, searchLogService)
+	analyticsController := controller.NewAnalyticsController(analyticsService, searchLogService)
+	permalinkRepo := permalink.NewPermalinkRepo(dataData)
+	permalinkService := permalink2.NewPermalinkService(permalinkRepo, questionRepo, answerRepo, userRepo, siteInfoCommonService)
+	permalinkController := controller.NewPermalinkController(permalinkService)
+	oEmbedService := oembed.NewOEmbedService(questionRepo, answerRepo, userCommon, siteInfoCommonService, permalinkService, dataData)
+	oEmbedController := controller.NewOEmbedController(oEmbedService)
+	pageRepo := page.NewPageRepo(dataData)
+	pageService := page2.NewPageService(pageRepo, eventQueueService)
+	pageController := controller.NewPageController(pageService)
+	announcementRepo := announcement.NewAnnouncementRepo(dataData)
+	announcementService := announcement2.NewAnnouncementService(announcementRepo, userRoleRelService)
+	announcementController := controller.NewAnnouncementController(announcementService)
+	invitationController := controller.NewInvitationController(invitationService)
+	emailDomainController := controller.NewEmailDomainController(emailDomainService)
+	questionAssignmentRepo := question_assignment.NewQuestionAssignmentRepo(dataData)
+	questionAssignmentService := question_assignment2.NewQuestionAssignmentService(questionAssignmentRepo, questionRepo, userCommon, siteInfoCommonService, notificationQueueService, eventQueueService)
+	questionAssignmentController := controller.NewQuestionAssignmentController(questionAssignmentService, rankService)
+	profileFieldController := controller_admin.NewProfileFieldController(profileFieldService)
+	questionSLAController := controller_admin.NewQuestionSLAController(questionSLAService)
+	schedulerRepo := scheduler.NewSchedulerRepo(dataData)
+	schedulerService := scheduler2.NewSchedulerService(schedulerRepo, siteInfoRepo, siteInfoCommonService)
+	schedulerController := controller_admin.NewSchedulerController(schedulerService)
+	permissionPolicyController := controller_admin.NewPermissionPolicyController(permissionPolicyService)
+	articleService := article2.NewArticleService(articleRepo, questionRepo, answerRepo, revisionService, userCommon, siteInfoCommonService, featureFlagService)
+	articleController := controller.NewArticleController(articleService, rankService)
+	questionPollController := controller.NewQuestionPollController(questionPollService, rankService)
+	coAuthorController := controller.NewCoAuthorController(coAuthorService, rankService)
+	leaderboardRepo := leaderboard.NewLeaderboardRepo(dataData)
+	leaderboardService := leaderboard2.NewLeaderboardService(leaderboardRepo, tagCommonService, userCommon, dataData)
+	leaderboardController := controller.NewLeaderboardController(leaderboardService)
+	pendingDeletionController := controller.NewPendingDeletionController(pendingDeletionService, questionService, answerService, commentService)
+	questionShareController := controller.NewQuestionShareController(questionShareService)
+	deadLinkController := controller.NewDeadLinkController(deadLinkService)
+	controllerAIAssistantController := controller.NewAIAssistantController(aiAssistantService, rankService)
+	aiAssistantController := controller_admin.NewAIAssistantController(aiAssistantService, embeddingService)
+	titleQualityService := title_quality.NewTitleQualityService(questionRepo, siteInfoCommonService)
+	titleQualityController := controller.NewTitleQualityController(titleQualityService)
+	reviewReminderRepo := review_reminder.NewReviewReminderRepo(dataData)
+	reviewReminderService := review_reminder2.NewReviewReminderService(reviewReminderRepo, questionRepo, answerRepo, userRepo, userCommon, userNotificationConfigRepo, siteInfoCommonService, emailService, notificationQueueService, answerService)
+	reviewReminderController := controller.NewReviewReminderController(reviewReminderService)
+	userOnboardingController := controller.NewUserOnboardingController(userOnboardingService)
+	experimentController := controller.NewExperimentController(experimentService)
+	controller_adminExperimentController := controller_admin.NewExperimentController(experimentService)
+	featureFlagController := controller_admin.NewFeatureFlagController(featureFlagService)
+	configReloadController := controller_admin.NewConfigReloadController()
+	eventOutboxRepo := event_stream.NewEventOutboxRepo(dataData)
+	eventStreamService := event_stream2.NewEventStreamService(eventOutboxRepo, siteInfoRepo, siteInfoCommonService, eventQueueService)
+	deltaSyncRepo := delta_sync.NewDeltaSyncRepo(dataData)
+	deltaSyncService := delta_sync2.NewDeltaSyncService(deltaSyncRepo, userCommon, eventQueueService)
+	eventStreamController := controller_admin.NewEventStreamController(eventStreamService)
+	conversionRepo := conversion.NewConversionRepo(dataData, uniqueIDRepo, activityRepo, userRankRepo)
+	conversionService := conversion2.NewConversionService(conversionRepo, commentCommonRepo, answerRepo, questionRepo, questionCommon, userCommon, revisionService, activityQueueService)
+	conversionController := controller.NewConversionController(conversionService)
+	questionMergeRepo := question_merge.NewQuestionMergeRepo(dataData, activityRepo)
+	questionMergeService := question_merge2.NewQuestionMergeService(questionMergeRepo, questionRepo, questionCommon, activityQueueService)
+	questionMergeController := controller.NewQuestionMergeController(questionMergeService)
+	revisionCompactionRepo := revision_compaction.NewRevisionCompactionRepo(dataData)
+	revisionCompactionService := revision_compaction2.NewRevisionCompactionService(revisionCompactionRepo, siteInfoCommonService)
+	revisionCompactionController := controller_admin.NewRevisionCompactionController(revisionCompactionService)
+	mediaProxyController := controller.NewMediaProxyController(mediaProxyService)
+	controller_adminMediaProxyController := controller_admin.NewMediaProxyController(mediaProxyService)
+	loginProtectionController := controller_admin.NewLoginProtectionController(loginProtectionService)
+	inboundQuestionRepo := inbound_question.NewInboundQuestionRepo(dataData)
+	inboundQuestionService := inbound_question2.NewInboundQuestionService(inboundQuestionRepo, userRepo, userCommon, questionService, emailService, siteInfoRepo, siteInfoCommonService, eventQueueService)
+	emailReplyService := email_reply.NewEmailReplyService(emailService, userCommon, rankService, answerService, commentService, contentFilterService, siteInfoCommonService, inboundQuestionService)
+	emailWebhookController := controller.NewEmailWebhookController(emailService, emailReplyService)
+	emailDeliveryController := controller_admin.NewEmailDeliveryController(emailService)
+	emailTemplateController := controller_admin.NewEmailTemplateController(emailService)
+	inboundQuestionController := controller.NewInboundQuestionController(inboundQuestionService)
+	controller_adminInboundQuestionController := controller_admin.NewInboundQuestionController(inboundQuestionService)
+	chatIntegrationService := chat_integration.NewChatIntegrationService(searchService, siteInfoRepo, siteInfoCommonService)
+	chatIntegrationController := controller.NewChatIntegrationController(chatIntegrationService)
+	controller_adminChatIntegrationController := controller_admin.NewChatIntegrationController(chatIntegrationService)
+	gitHubCrossReferenceRepo := github_integration.NewGitHubCrossReferenceRepo(dataData)
+	gitHubService := github_integration2.NewGitHubService(gitHubCrossReferenceRepo, objService, siteInfoRepo, siteInfoCommonService, dataData, eventQueueService)
+	gitHubController := controller.NewGitHubController(gitHubService)
+	controller_adminGitHubController := controller_admin.NewGitHubController(gitHubService)
+	jiraIssueLinkRepo := jira_integration.NewJiraIssueLinkRepo(dataData)
+	jiraService := jira_integration2.NewJiraService(jiraIssueLinkRepo, questionRepo, tagCommonService, userCommon, siteInfoRepo, siteInfoCommonService)
+	jiraController := controller.NewJiraController(jiraService)
+	controller_adminJiraController := controller_admin.NewJiraController(jiraService)
+	maintenanceController := controller_admin.NewMaintenanceController(maintenanceService)
+	slowQueryRepo := slow_query.NewSlowQueryRepo(dataData)
+	slowQueryService := slow_query2.NewSlowQueryService(slowQueryRepo)
+	slowQueryController := controller_admin.NewSlowQueryController(slowQueryService)
+	pageCacheService := page_cache.NewPageCacheService(dataData, eventQueueService)
+	pageCacheMiddleware := middleware.NewPageCacheMiddleware(pageCacheService)
+	contentLimitController := controller.NewContentLimitController(contentLimitService)
+	controller_adminContentLimitController := controller_admin.NewContentLimitController(contentLimitService)
+	bannedWordController := controller_admin.NewBannedWordController(bannedWordService)
+	moderationRuleController := controller_admin.NewModerationRuleController(moderationRuleService)
+	questionWorkflowController := controller.NewQuestionWorkflowController(questionWorkflowService)
+	controller_adminQuestionWorkflowController := controller_admin.NewQuestionWorkflowController(questionWorkflowService)
+	answerVerificationController := controller.NewAnswerVerificationController(answerVerificationService)
+	controller_adminAnswerVerificationController := controller_admin.NewAnswerVerificationController(answerVerificationService)
+	tagAutoSubscriptionController := controller_admin.NewTagAutoSubscriptionController(tagAutoSubscriptionService)
+	moderationTriageRepo := moderation_triage.NewModerationTriageRepo(dataData)
+	moderationTriageService := moderation_triage2.NewModerationTriageService(moderationTriageRepo, configService, objService, userCommon, reportService, reviewService, questionService, userAdminService)
+	moderationTriageController := controller_admin.NewModerationTriageController(moderationTriageService)
+	legalHoldController := controller_admin.NewLegalHoldController(legalHoldService)
+	ipLoggingController := controller_admin.NewIPLoggingController(ipLoggingService)
+	editLockRepo := edit_lock.NewEditLockRepo(dataData)
+	editLockService := edit_lock2.NewEditLockService(editLockRepo, objService, userCommon)
+	editLockController := controller.NewEditLockController(editLockService)
+	usernamePolicyController := controller_admin.NewUsernamePolicyController(usernamePolicyService)
+	nameChangeController := controller.NewNameChangeController(usernamePolicyService)
+	questionBatchService := question_batch.NewQuestionBatchService(questionService, answerService, commentService, rankService, questionShareService)
+	questionBatchController := controller.NewQuestionBatchController(questionBatchService)
+	deltaSyncController := controller.NewDeltaSyncController(deltaSyncService)
+	answerAPIRouter := router.NewAnswerAPIRouter(langController, userController, commentController, reportController, voteController, tagController, followController, collectionController, questionController, answerController, searchController, revisionController, rankController, userAdminController, reasonController, themeController, siteInfoController, controllerSiteInfoController, notificationController, dashboardController, uploadController, activityController, roleController, pluginController, permissionController, userPluginController, reviewController, metaController, analyticsController, permalinkController, oEmbedController, pageController, announcementController, profileFieldController, invitationController, emailDomainController, questionAssignmentController, questionSLAController, articleController, questionPollController, coAuthorController, leaderboardController, pendingDeletionController, schedulerController, permissionPolicyController, questionShareController, deadLinkController, controllerAIAssistantController, aiAssistantController, titleQualityController, reviewReminderController, conversionController, questionMergeController, revisionCompactionController, mediaProxyController, controller_adminMediaProxyController, loginProtectionController, emailWebhookController, emailDeliveryController, emailTemplateController, userOnboardingController, experimentController, controller_adminExperimentController, featureFlagController, configReloadController, eventStreamController, inboundQuestionController, controller_adminInboundQuestionController, chatIntegrationController, controller_adminChatIntegrationController, gitHubController, controller_adminGitHubController, jiraController, controller_adminJiraController, maintenanceController, slowQueryController, contentLimitController, controller_adminContentLimitController, bannedWordController, usernamePolicyController, nameChangeController, questionBatchController, deltaSyncController, moderationRuleController, questionWorkflowController, controller_adminQuestionWorkflowController, answerVerificationController, controller_adminAnswerVerificationController, tagAutoSubscriptionController, moderationTriageController, legalHoldController, ipLoggingController, editLockController, pageCacheMiddleware)
 	swaggerRouter := router.NewSwaggerRouter(swaggerConf)
 	uiRouter := router.NewUIRouter(controllerSiteInfoController, siteInfoCommonService)
-	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService)
+	authUserMiddleware := middleware.NewAuthUserMiddleware(authService, siteInfoCommonService, rolePowerRelService, permissionPolicyService, uploadAccessService)
 	avatarMiddleware := middleware.NewAvatarMiddleware(serviceConf, uploaderService)
 	shortIDMiddleware := middleware.NewShortIDMiddleware(siteInfoCommonService)
-	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, questionRepo)
+	maintenanceMiddleware := middleware.NewMaintenanceMiddleware(maintenanceService)
+	sitemapRepo := sitemap.NewSitemapRepo(dataData)
+	sitemapService := sitemap2.NewSitemapService(sitemapRepo, siteInfoCommonService, eventQueueService, dataData)
+	templateRenderController := templaterender.NewTemplateRenderController(questionService, userService, tagService, answerService, commentService, siteInfoCommonService, sitemapService, permalinkService, oEmbedService, pageService, questionShareService)
 	templateController := controller.NewTemplateController(templateRenderController, siteInfoCommonService)
 	templateRouter := router.NewTemplateRouter(templateController, templateRenderController, siteInfoController, authUserMiddleware)
 	connectorController := controller.NewConnectorController(siteInfoCommonService, emailService, userExternalLoginService)
-	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService)
+	userCenterLoginService := user_external_login2.NewUserCenterLoginService(userRepo, userCommon, userExternalLoginRepo, userActiveActivityRepo, siteInfoCommonService, eventQueueService)
 	userCenterController := controller.NewUserCenterController(userCenterLoginService, siteInfoCommonService)
 	captchaController := controller.NewCaptchaController()
 	embedController := controller.NewEmbedController()
 	pluginAPIRouter := router.NewPluginAPIRouter(connectorController, userCenterController, captchaController, embedController)
-	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, templateRouter, pluginAPIRouter, uiConf)
-	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService)
+	ginEngine := server.NewHTTPServer(debug, staticRouter, answerAPIRouter, swaggerRouter, uiRouter, authUserMiddleware, avatarMiddleware, shortIDMiddleware, maintenanceMiddleware, templateRouter, pluginAPIRouter, uiConf)
+	dataRetentionRepo := data_retention.NewDataRetentionRepo(dataData)
+	dataRetentionService := data_retention2.NewDataRetentionService(dataRetentionRepo, siteInfoCommonService, legalHoldService)
+	listIndexRepo := list_index.NewListIndexRepo(dataData)
+	listIndexService := list_index2.NewListIndexService(listIndexRepo, questionRepo, tagCommonRepo, eventQueueService)
+	scheduledTaskManager := cron.NewScheduledTaskManager(siteInfoCommonService, questionService, analyticsService, sitemapService, pluginJobService, userService, questionSLAService, leaderboardService, userTagScoreService, pendingDeletionService, dataRetentionService, deadLinkService, embeddingService, reviewReminderService, revisionCompactionService, eventStreamService, jiraService, listIndexService, slowQueryService, schedulerService, deltaSyncService, tagAutoSubscriptionService)
 	application := newApplication(serverConf, ginEngine, scheduledTaskManager)
 	return application, func() {
 		cleanup2()
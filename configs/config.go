@@ -29,3 +29,6 @@ var PathIgnore []byte
 
 //go:embed  reserved-usernames.json
 var ReservedUsernames []byte
+
+//go:embed  disposable-email-domains.json
+var DisposableEmailDomains []byte
@@ -23,8 +23,10 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reload"
 	"github.com/apache/incubator-answer/internal/base/server"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/cli"
@@ -44,6 +46,8 @@ type AllConfig struct {
 	ServiceConfig *service_config.ServiceConfig `json:"service_config" mapstructure:"service_config" yaml:"service_config"`
 	Swaggerui     *router.SwaggerConfig         `json:"swaggerui" mapstructure:"swaggerui" yaml:"swaggerui"`
 	UI            *server.UI                    `json:"ui" mapstructure:"ui" yaml:"ui"`
+	// Runtime the settings that are applied again when the config is reloaded
+	Runtime *reload.Settings `json:"runtime" mapstructure:"runtime" yaml:"runtime,omitempty"`
 }
 
 type envConfigOverrides struct {
@@ -82,7 +86,8 @@ func (c *AllConfig) SetDefault() {
 	}
 }
 
-func (c *AllConfig) SetEnvironmentOverrides() {
+// SetEnvironmentOverrides override the config by the environment variables, see envPrefix
+func (c *AllConfig) SetEnvironmentOverrides() (err error) {
 	envs := loadEnvs()
 	if envs.SiteAddr != "" {
 		c.Server.HTTP.Addr = envs.SiteAddr
@@ -93,6 +98,7 @@ func (c *AllConfig) SetEnvironmentOverrides() {
 	if envs.SwaggerAddressPort != "" {
 		c.Swaggerui.Address = envs.SwaggerAddressPort
 	}
+	return setEnvOverrides(reflect.ValueOf(c).Elem(), envPrefix)
 }
 
 // ReadConfig read config
@@ -109,7 +115,9 @@ func ReadConfig(configFilePath string) (c *AllConfig, err error) {
 		return nil, err
 	}
 	c.SetDefault()
-	c.SetEnvironmentOverrides()
+	if err = c.SetEnvironmentOverrides(); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package conf
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// envPrefix any value in the config file can be overridden by the environment variable named by the prefix
+// and the path of the value in upper case, e.g. ANSWER_DATA_DATABASE_CONNECTION for data.database.connection
+const envPrefix = "ANSWER"
+
+// envAliases the short names of the environment variables that are often used
+var envAliases = map[string]string{
+	"ANSWER_DATA_DATABASE_DRIVER":     "ANSWER_DB_DRIVER",
+	"ANSWER_DATA_DATABASE_CONNECTION": "ANSWER_DB_DSN",
+	"ANSWER_DATA_CACHE_FILE_PATH":     "ANSWER_CACHE_PATH",
+	"ANSWER_RUNTIME_LOG_LEVEL":        "ANSWER_LOG_LEVEL",
+}
+
+// lookupEnv get the environment variable by the name or its alias, the name takes precedence
+func lookupEnv(name string) (string, bool) {
+	if val, ok := os.LookupEnv(name); ok {
+		return val, true
+	}
+	if alias, ok := envAliases[name]; ok {
+		return os.LookupEnv(alias)
+	}
+	return "", false
+}
+
+// hasEnvWithPrefix whether any environment variable is set for the value under the path
+func hasEnvWithPrefix(prefix string) bool {
+	prefix += "_"
+	for _, env := range os.Environ() {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+	for name, alias := range envAliases {
+		if strings.HasPrefix(name, prefix) {
+			if _, ok := os.LookupEnv(alias); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setEnvOverrides set the fields of the struct by the environment variables, the nil struct is created
+// only if there is an environment variable for it
+func setEnvOverrides(v reflect.Value, prefix string) (err error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		if len(key) == 0 {
+			key = strings.Split(field.Tag.Get("yaml"), ",")[0]
+		}
+		if len(key) == 0 || key == "-" {
+			continue
+		}
+		name := prefix + "_" + strings.ToUpper(key)
+		fv := v.Field(i)
+
+		if fv.Kind() == reflect.Ptr && fv.Type().Elem().Kind() == reflect.Struct {
+			if fv.IsNil() {
+				if !hasEnvWithPrefix(name) {
+					continue
+				}
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			if err = setEnvOverrides(fv.Elem(), name); err != nil {
+				return err
+			}
+			continue
+		}
+		if fv.Kind() == reflect.Struct {
+			if err = setEnvOverrides(fv, name); err != nil {
+				return err
+			}
+			continue
+		}
+
+		val, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(val)
+		case reflect.Int, reflect.Int64, reflect.Int32:
+			n, err := strconv.ParseInt(val, 10, 64)
+			if err != nil {
+				return fmt.Errorf("environment variable %s is not a number: %w", name, err)
+			}
+			fv.SetInt(n)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(val)
+			if err != nil {
+				return fmt.Errorf("environment variable %s is not a bool: %w", name, err)
+			}
+			fv.SetBool(b)
+		}
+	}
+	return nil
+}
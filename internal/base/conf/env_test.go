@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package conf
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEnvOverrides(t *testing.T) {
+	t.Setenv("ANSWER_DB_DSN", "/tmp/answer.db")
+	t.Setenv("ANSWER_DATA_DATABASE_MAX_OPEN_CONN", "20")
+	t.Setenv("ANSWER_SWAGGERUI_SHOW", "false")
+	t.Setenv("ANSWER_RUNTIME_SMTP_HOST", "smtp.example.com")
+	t.Setenv("ANSWER_LOG_LEVEL", "debug")
+
+	c := &AllConfig{Data: &Data{Database: &data.Database{Driver: "sqlite3", Connection: "/data/answer.db"}}}
+	assert.NoError(t, setEnvOverrides(reflect.ValueOf(c).Elem(), envPrefix))
+	assert.Equal(t, "sqlite3", c.Data.Database.Driver)
+	assert.Equal(t, "/tmp/answer.db", c.Data.Database.Connection)
+	assert.Equal(t, 20, c.Data.Database.MaxOpenConn)
+	assert.False(t, c.Swaggerui.Show)
+	assert.Nil(t, c.I18n)
+	assert.Equal(t, "smtp.example.com", c.Runtime.SMTP.Host)
+	assert.Equal(t, "debug", c.Runtime.LogLevel)
+
+	t.Setenv("ANSWER_DATA_DATABASE_CONNECTION", "/tmp/other.db")
+	assert.NoError(t, setEnvOverrides(reflect.ValueOf(c).Elem(), envPrefix))
+	assert.Equal(t, "/tmp/other.db", c.Data.Database.Connection)
+
+	t.Setenv("ANSWER_DATA_DATABASE_MAX_IDLE_CONN", "many")
+	assert.Error(t, setEnvOverrides(reflect.ValueOf(c).Elem(), envPrefix))
+}
@@ -37,6 +37,7 @@ const (
 	ActUnPin     = "unpin"
 	ActShow      = "show"
 	ActHide      = "hide"
+	ActOutdated  = "outdated"
 )
 
 const (
@@ -56,6 +57,19 @@ const (
 	ActQuestionUnPin     ActivityTypeKey = "question.unpin"
 	ActQuestionHide      ActivityTypeKey = "question.hide"
 	ActQuestionShow      ActivityTypeKey = "question.show"
+	// ActQuestionAcceptAnswer and ActQuestionUnacceptAnswer record the history of the accepted answers
+	ActQuestionAcceptAnswer   ActivityTypeKey = "question.accept_answer"
+	ActQuestionUnacceptAnswer ActivityTypeKey = "question.unaccept_answer"
+	// ActQuestionMergedInto and ActQuestionMergedFrom record the merging on the duplicate and the canonical question
+	ActQuestionMergedInto ActivityTypeKey = "question.merged_into"
+	ActQuestionMergedFrom ActivityTypeKey = "question.merged_from"
+	// ActQuestionWorkflowChanged the workflow state of the question is changed
+	ActQuestionWorkflowChanged ActivityTypeKey = "question.workflow_changed"
+	// ActQuestionDeletionFinalized the undo window of the deletion is closed
+	ActQuestionDeletionFinalized ActivityTypeKey = "question.deletion_finalized"
+	// ActQuestionCommentDeletionFinalized and ActQuestionCommentUndeleted record the deletion of the comment on the question
+	ActQuestionCommentDeletionFinalized ActivityTypeKey = "question.comment_deletion_finalized"
+	ActQuestionCommentUndeleted         ActivityTypeKey = "question.comment_undeleted"
 )
 
 const (
@@ -68,6 +82,15 @@ const (
 	ActAnswerRollback  ActivityTypeKey = "answer.rollback"
 	ActAnswerDeleted   ActivityTypeKey = "answer.deleted"
 	ActAnswerUndeleted ActivityTypeKey = "answer.undeleted"
+	ActAnswerOutdated  ActivityTypeKey = "answer.outdated"
+	// ActAnswerConvertedFromComment and ActAnswerConvertedToComment record the conversion by the moderators
+	ActAnswerConvertedFromComment ActivityTypeKey = "answer.converted_from_comment"
+	ActAnswerConvertedToComment   ActivityTypeKey = "answer.converted_to_comment"
+	// ActAnswerDeletionFinalized the undo window of the deletion is closed
+	ActAnswerDeletionFinalized ActivityTypeKey = "answer.deletion_finalized"
+	// ActAnswerCommentDeletionFinalized and ActAnswerCommentUndeleted record the deletion of the comment on the answer
+	ActAnswerCommentDeletionFinalized ActivityTypeKey = "answer.comment_deletion_finalized"
+	ActAnswerCommentUndeleted         ActivityTypeKey = "answer.comment_undeleted"
 )
 
 const (
@@ -35,6 +35,7 @@ const (
 	UserEmailCodeCacheKey                      = "answer:user:email-code:"
 	UserEmailCodeCacheTime                     = 10 * time.Minute
 	UserLatestEmailCodeCacheKey                = "answer:user-id:email-code:"
+	UserVerificationReminderCodeCacheTime      = 3 * 24 * time.Hour
 	SiteInfoCacheKey                           = "answer:site-info:"
 	SiteInfoCacheTime                          = 1 * time.Hour
 	ConfigID2KEYCacheKeyPrefix                 = "answer:config:id:"
@@ -42,12 +43,35 @@ const (
 	ConfigCacheTime                            = 1 * time.Hour
 	ConnectorUserExternalInfoCacheKey          = "answer:connector:"
 	ConnectorUserExternalInfoCacheTime         = 10 * time.Minute
-	SiteMapQuestionCacheKeyPrefix              = "answer:sitemap:question:%d"
-	SiteMapQuestionCacheTime                   = time.Hour
+	SitemapCacheKeyPrefix                      = "answer:sitemap:%s:%d"
+	SitemapCacheTime                           = 24 * time.Hour
 	SitemapMaxSize                             = 50000
 	NewQuestionNotificationLimitCacheKeyPrefix = "answer:new-question-notification-limit:"
 	NewQuestionNotificationLimitCacheTime      = 7 * 24 * time.Hour
 	NewQuestionNotificationLimitMax            = 50
 	RateLimitCacheKeyPrefix                    = "answer:rate-limit:"
 	RateLimitCacheTime                         = 5 * time.Minute
+	TagStatsCacheKeyPrefix                     = "answer:tag-stats:"
+	TagStatsCacheTime                          = 30 * time.Minute
+	EmbedRateLimitCacheKeyPrefix               = "answer:embed-rate-limit:"
+	EmbedRateLimitCacheTime                    = 1 * time.Minute
+	EmbedRateLimitMax                          = 60
+	UserStatsCacheKeyPrefix                    = "answer:user-stats:"
+	UserStatsCacheTime                         = 1 * time.Hour
+	UserSessionActiveCacheKey                  = "answer:user-session:active:"
+	UserSessionActiveCacheTime                 = 5 * time.Minute
+	UserSessionRevokedCacheKey                 = "answer:user-session:revoked:"
+	LeaderboardCacheKeyPrefix                  = "answer:leaderboard:"
+	LeaderboardCacheTime                       = 10 * time.Minute
+	MediaProxyCacheKeyPrefix                   = "answer:media-proxy:"
+	LoginFailureCacheKeyPrefix                 = "answer:login-failure:"
+	LoginFailureCacheTime                      = 24 * time.Hour
+	GitHubIssueCacheKeyPrefix                  = "answer:github:issue:"
+	GitHubIssueCacheTime                       = 5 * time.Minute
+	PageCacheKeyPrefix                         = "answer:page-cache:"
+	PageCacheTime                              = time.Minute
+	PageGenerationCacheKeyPrefix               = "answer:page-cache:generation:"
+	PageGenerationCacheTime                    = 24 * time.Hour
+	EditLockCacheKeyPrefix                     = "answer:edit-lock:"
+	EditLockCacheTime                          = 2 * time.Minute
 )
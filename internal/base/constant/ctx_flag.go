@@ -22,4 +22,7 @@ package constant
 const (
 	AcceptLanguageFlag = "Accept-Language"
 	ShortIDFlag        = "Short-ID-Enabled"
+	ImpersonationFlag  = "Impersonation"
+	TimeZoneFlag       = "Time-Zone"
+	DateFormatFlag     = "Date-Format"
 )
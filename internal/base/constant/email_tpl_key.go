@@ -23,6 +23,24 @@ const (
 	EmailTplKeyChangeEmailTitle = "email_tpl.change_email.title"
 	EmailTplKeyChangeEmailBody  = "email_tpl.change_email.body"
 
+	EmailTplKeyAuthorizeEmailChangeTitle = "email_tpl.authorize_email_change.title"
+	EmailTplKeyAuthorizeEmailChangeBody  = "email_tpl.authorize_email_change.body"
+
+	EmailTplKeyEmailChangedTitle = "email_tpl.email_changed.title"
+	EmailTplKeyEmailChangedBody  = "email_tpl.email_changed.body"
+
+	EmailTplKeyVerificationReminderTitle = "email_tpl.verification_reminder.title"
+	EmailTplKeyVerificationReminderBody  = "email_tpl.verification_reminder.body"
+
+	EmailTplKeySuspiciousLoginTitle = "email_tpl.suspicious_login.title"
+	EmailTplKeySuspiciousLoginBody  = "email_tpl.suspicious_login.body"
+
+	EmailTplKeySLABreachedTitle = "email_tpl.sla_breached.title"
+	EmailTplKeySLABreachedBody  = "email_tpl.sla_breached.body"
+
+	EmailTplKeyQuestionReviewReminderTitle = "email_tpl.question_review_reminder.title"
+	EmailTplKeyQuestionReviewReminderBody  = "email_tpl.question_review_reminder.body"
+
 	EmailTplKeyNewAnswerTitle = "email_tpl.new_answer.title"
 	EmailTplKeyNewAnswerBody  = "email_tpl.new_answer.body"
 
@@ -43,4 +61,26 @@ const (
 
 	EmailTplKeyNewQuestionTitle = "email_tpl.new_question.title"
 	EmailTplKeyNewQuestionBody  = "email_tpl.new_question.body"
+
+	EmailTplKeyTagSubscriptionDigestTitle = "email_tpl.tag_subscription_digest.title"
+	EmailTplKeyTagSubscriptionDigestBody  = "email_tpl.tag_subscription_digest.body"
+)
+
+// the names of the email templates, the subject and body of them can be customized by the admin
+const (
+	EmailTplChangeEmail            = "change_email"
+	EmailTplAuthorizeEmailChange   = "authorize_email_change"
+	EmailTplEmailChanged           = "email_changed"
+	EmailTplVerificationReminder   = "verification_reminder"
+	EmailTplSuspiciousLogin        = "suspicious_login"
+	EmailTplSLABreached            = "sla_breached"
+	EmailTplQuestionReviewReminder = "question_review_reminder"
+	EmailTplNewAnswer              = "new_answer"
+	EmailTplNewComment             = "new_comment"
+	EmailTplPassReset              = "pass_reset"
+	EmailTplRegister               = "register"
+	EmailTplTest                   = "test"
+	EmailTplInvitedAnswer          = "invited_you_to_answer"
+	EmailTplNewQuestion            = "new_question"
+	EmailTplTagSubscriptionDigest  = "tag_subscription_digest"
 )
@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package constant
+
+type EventType string
+
+const (
+	EventQuestionCreate EventType = "question.created"
+	EventQuestionUpdate EventType = "question.updated"
+	EventQuestionDelete EventType = "question.deleted"
+	// EventQuestionStatusChange the question is hidden, shown, recovered or its status is changed by admin
+	EventQuestionStatusChange EventType = "question.status_changed"
+	EventAnswerCreate         EventType = "answer.created"
+	EventAnswerUpdate         EventType = "answer.updated"
+	EventAnswerDelete         EventType = "answer.deleted"
+	EventAnswerAccept         EventType = "answer.accepted"
+	EventCommentCreate        EventType = "comment.created"
+	EventCommentUpdate        EventType = "comment.updated"
+	EventCommentDelete        EventType = "comment.deleted"
+	EventTagCreate            EventType = "tag.created"
+	EventTagUpdate            EventType = "tag.updated"
+	EventTagDelete            EventType = "tag.deleted"
+	EventUserRegister         EventType = "user.registered"
+	EventUserUpdate           EventType = "user.updated"
+	EventUserDelete           EventType = "user.deleted"
+	// EventUserStatusChange the user is suspended or reactivated by admin
+	EventUserStatusChange EventType = "user.status_changed"
+	EventPageCreate       EventType = "page.created"
+	EventPageUpdate       EventType = "page.updated"
+	EventPageDelete       EventType = "page.deleted"
+	// EventPageStatusChange the page is published or withdrawn to draft
+	EventPageStatusChange EventType = "page.status_changed"
+)
@@ -56,6 +56,20 @@ const (
 	NotificationYourCommentWasDeleted = "notification.action.your_comment_was_deleted"
 	// NotificationInvitedYouToAnswer invited you to answer
 	NotificationInvitedYouToAnswer = "notification.action.invited_you_to_answer"
+	// NotificationFollowedUserAskedQuestion the user you follow asked a question in your following tags
+	NotificationFollowedUserAskedQuestion = "notification.action.followed_user_asked_question"
+	// NotificationAssignedYouToAnswer assigned you to answer
+	NotificationAssignedYouToAnswer = "notification.action.assigned_you_to_answer"
+	// NotificationSLAFirstResponseBreached the question is not answered within the SLA
+	NotificationSLAFirstResponseBreached = "notification.action.sla_first_response_breached"
+	// NotificationSLAResolutionBreached the question is not resolved within the SLA
+	NotificationSLAResolutionBreached = "notification.action.sla_resolution_breached"
+	// NotificationQuestionReviewReminder ask the author whether any answer solved the question
+	NotificationQuestionReviewReminder = "notification.action.question_review_reminder"
+	// NotificationModerationRuleMatched the question matches the moderation rule
+	NotificationModerationRuleMatched = "notification.action.moderation_rule_matched"
+	// NotificationTagAutoSubscribedQuestion the new question in the tags which the group of the user is subscribed to
+	NotificationTagAutoSubscribedQuestion = "notification.action.tag_auto_subscribed_question"
 )
 
 type NotificationChannelKey string
@@ -73,23 +87,30 @@ const (
 
 var (
 	NotificationMsgTypeMapping = map[string]int{
-		NotificationUpdateQuestion:         1,
-		NotificationAnswerTheQuestion:      1,
-		NotificationUpVotedTheQuestion:     2,
-		NotificationDownVotedTheQuestion:   2,
-		NotificationUpdateAnswer:           1,
-		NotificationAcceptAnswer:           1,
-		NotificationUpVotedTheAnswer:       2,
-		NotificationDownVotedTheAnswer:     2,
-		NotificationCommentQuestion:        1,
-		NotificationCommentAnswer:          1,
-		NotificationUpVotedTheComment:      2,
-		NotificationReplyToYou:             1,
-		NotificationMentionYou:             1,
-		NotificationYourQuestionIsClosed:   1,
-		NotificationYourQuestionWasDeleted: 1,
-		NotificationYourAnswerWasDeleted:   1,
-		NotificationYourCommentWasDeleted:  1,
-		NotificationInvitedYouToAnswer:     3,
+		NotificationUpdateQuestion:            1,
+		NotificationAnswerTheQuestion:         1,
+		NotificationUpVotedTheQuestion:        2,
+		NotificationDownVotedTheQuestion:      2,
+		NotificationUpdateAnswer:              1,
+		NotificationAcceptAnswer:              1,
+		NotificationUpVotedTheAnswer:          2,
+		NotificationDownVotedTheAnswer:        2,
+		NotificationCommentQuestion:           1,
+		NotificationCommentAnswer:             1,
+		NotificationUpVotedTheComment:         2,
+		NotificationReplyToYou:                1,
+		NotificationMentionYou:                1,
+		NotificationYourQuestionIsClosed:      1,
+		NotificationYourQuestionWasDeleted:    1,
+		NotificationYourAnswerWasDeleted:      1,
+		NotificationYourCommentWasDeleted:     1,
+		NotificationInvitedYouToAnswer:        3,
+		NotificationFollowedUserAskedQuestion: 1,
+		NotificationAssignedYouToAnswer:       1,
+		NotificationSLAFirstResponseBreached:  1,
+		NotificationSLAResolutionBreached:     1,
+		NotificationQuestionReviewReminder:    1,
+		NotificationModerationRuleMatched:     1,
+		NotificationTagAutoSubscribedQuestion: 1,
 	}
 )
@@ -27,6 +27,7 @@ const (
 	CollectionObjectType = "collection"
 	CommentObjectType    = "comment"
 	ReportObjectType     = "report"
+	ArticleObjectType    = "article"
 )
 
 var (
@@ -38,6 +39,7 @@ var (
 		CollectionObjectType: 6,
 		CommentObjectType:    7,
 		ReportObjectType:     8,
+		ArticleObjectType:    9,
 	}
 
 	ObjectTypeNumberMapping = map[int]string{
@@ -48,5 +50,6 @@ var (
 		6: CollectionObjectType,
 		7: CommentObjectType,
 		8: ReportObjectType,
+		9: ArticleObjectType,
 	}
 )
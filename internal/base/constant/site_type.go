@@ -20,15 +20,36 @@
 package constant
 
 const (
-	SiteTypeGeneral       = "general"
-	SiteTypeInterface     = "interface"
-	SiteTypeBranding      = "branding"
-	SiteTypeWrite         = "write"
-	SiteTypeLegal         = "legal"
-	SiteTypeSeo           = "seo"
-	SiteTypeLogin         = "login"
-	SiteTypeCustomCssHTML = "css-html"
-	SiteTypeTheme         = "theme"
-	SiteTypePrivileges    = "privileges"
-	SiteTypeUsers         = "users"
+	SiteTypeGeneral              = "general"
+	SiteTypeInterface            = "interface"
+	SiteTypeBranding             = "branding"
+	SiteTypeWrite                = "write"
+	SiteTypeLegal                = "legal"
+	SiteTypeSeo                  = "seo"
+	SiteTypeLogin                = "login"
+	SiteTypeCustomCssHTML        = "css-html"
+	SiteTypeTheme                = "theme"
+	SiteTypePrivileges           = "privileges"
+	SiteTypeUsers                = "users"
+	SiteTypeScheduler            = "scheduler"
+	SiteTypePermissionPolicy     = "permission_policy"
+	SiteTypeAIAssistant          = "ai_assistant"
+	SiteTypeMediaProxy           = "media_proxy"
+	SiteTypeUploadAccess         = "upload_access"
+	SiteTypeExperiments          = "experiments"
+	SiteTypeFeatureFlags         = "feature_flags"
+	SiteTypeEventStream          = "event_stream"
+	SiteTypeInboundQuestion      = "inbound_question"
+	SiteTypeChatIntegration      = "chat_integration"
+	SiteTypeGitHub               = "github"
+	SiteTypeJira                 = "jira"
+	SiteTypeMaintenance          = "maintenance"
+	SiteTypeContentLimits        = "content_limits"
+	SiteTypeBannedWords          = "banned_words"
+	SiteTypeUsernamePolicy       = "username_policy"
+	SiteTypeModerationRules      = "moderation_rules"
+	SiteTypeQuestionWorkflow     = "question_workflow"
+	SiteTypeAnswerVerification   = "answer_verification"
+	SiteTypeTagAutoSubscriptions = "tag_auto_subscriptions"
+	SiteTypeIPLogging            = "ip_logging"
 )
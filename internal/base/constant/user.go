@@ -30,6 +30,18 @@ const (
 	EmailStatusToBeVerified = 2
 )
 
+// DefaultDateFormat the date format is used when the user has not chosen one
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// DateFormats the date formats the user can choose, the key is the format used by the ui
+// and the value is the go layout used by the server-generated content
+var DateFormats = map[string]string{
+	"YYYY-MM-DD": "2006-01-02",
+	"MM/DD/YYYY": "01/02/2006",
+	"DD/MM/YYYY": "02/01/2006",
+	"DD.MM.YYYY": "02.01.2006",
+}
+
 func ConvertUserStatus(status, mailStatus int) string {
 	switch status {
 	case 1:
@@ -23,51 +23,137 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/apache/incubator-answer/internal/service/analytics"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/data_retention"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/apache/incubator-answer/internal/service/delta_sync"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/apache/incubator-answer/internal/service/event_stream"
+	"github.com/apache/incubator-answer/internal/service/jira_integration"
+	"github.com/apache/incubator-answer/internal/service/leaderboard"
+	"github.com/apache/incubator-answer/internal/service/list_index"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	"github.com/apache/incubator-answer/internal/service/question_sla"
+	"github.com/apache/incubator-answer/internal/service/review_reminder"
+	"github.com/apache/incubator-answer/internal/service/revision_compaction"
+	"github.com/apache/incubator-answer/internal/service/scheduler"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
-	"github.com/robfig/cron/v3"
-	"github.com/segmentfault/pacman/log"
+	"github.com/apache/incubator-answer/internal/service/sitemap"
+	"github.com/apache/incubator-answer/internal/service/slow_query"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/apache/incubator-answer/internal/service/tag_auto_subscription"
 )
 
 // ScheduledTaskManager scheduled task manager
 type ScheduledTaskManager struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionService *content.QuestionService
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	questionService     *content.QuestionService
+	analyticsService    *analytics.AnalyticsService
+	sitemapService      *sitemap.SitemapService
+	pluginJobService    *plugin_common.PluginJobService
+	userService         *content.UserService
+	slaService          *question_sla.QuestionSLAService
+	leaderboard         *leaderboard.LeaderboardService
+	userTagScore        *tag.UserTagScoreService
+	pendingDeletion     *pending_deletion.PendingDeletionService
+	dataRetention       *data_retention.DataRetentionService
+	deadLink            *dead_link.DeadLinkService
+	embedding           *embedding.EmbeddingService
+	reviewReminder      *review_reminder.ReviewReminderService
+	revisionCompaction  *revision_compaction.RevisionCompactionService
+	eventStream         *event_stream.EventStreamService
+	jira                *jira_integration.JiraService
+	listIndex           *list_index.ListIndexService
+	slowQuery           *slow_query.SlowQueryService
+	scheduler           *scheduler.SchedulerService
+	deltaSync           *delta_sync.DeltaSyncService
+	tagAutoSubscription *tag_auto_subscription.TagAutoSubscriptionService
 }
 
 // NewScheduledTaskManager new scheduled task manager
 func NewScheduledTaskManager(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	questionService *content.QuestionService,
+	analyticsService *analytics.AnalyticsService,
+	sitemapService *sitemap.SitemapService,
+	pluginJobService *plugin_common.PluginJobService,
+	userService *content.UserService,
+	slaService *question_sla.QuestionSLAService,
+	leaderboardService *leaderboard.LeaderboardService,
+	userTagScoreService *tag.UserTagScoreService,
+	pendingDeletionService *pending_deletion.PendingDeletionService,
+	dataRetentionService *data_retention.DataRetentionService,
+	deadLinkService *dead_link.DeadLinkService,
+	embeddingService *embedding.EmbeddingService,
+	reviewReminderService *review_reminder.ReviewReminderService,
+	revisionCompactionService *revision_compaction.RevisionCompactionService,
+	eventStreamService *event_stream.EventStreamService,
+	jiraService *jira_integration.JiraService,
+	listIndexService *list_index.ListIndexService,
+	slowQueryService *slow_query.SlowQueryService,
+	schedulerService *scheduler.SchedulerService,
+	deltaSyncService *delta_sync.DeltaSyncService,
+	tagAutoSubscriptionService *tag_auto_subscription.TagAutoSubscriptionService,
 ) *ScheduledTaskManager {
 	manager := &ScheduledTaskManager{
-		siteInfoService: siteInfoService,
-		questionService: questionService,
+		siteInfoService:     siteInfoService,
+		questionService:     questionService,
+		analyticsService:    analyticsService,
+		sitemapService:      sitemapService,
+		pluginJobService:    pluginJobService,
+		userService:         userService,
+		slaService:          slaService,
+		leaderboard:         leaderboardService,
+		userTagScore:        userTagScoreService,
+		pendingDeletion:     pendingDeletionService,
+		dataRetention:       dataRetentionService,
+		deadLink:            deadLinkService,
+		embedding:           embeddingService,
+		reviewReminder:      reviewReminderService,
+		revisionCompaction:  revisionCompactionService,
+		eventStream:         eventStreamService,
+		jira:                jiraService,
+		listIndex:           listIndexService,
+		slowQuery:           slowQueryService,
+		scheduler:           schedulerService,
+		deltaSync:           deltaSyncService,
+		tagAutoSubscription: tagAutoSubscriptionService,
 	}
 	return manager
 }
 
+// Run register all the built-in jobs to the scheduler and start it
 func (s *ScheduledTaskManager) Run() {
 	fmt.Println("start cron")
-	s.questionService.SitemapCron(context.Background())
-	c := cron.New()
-	_, err := c.AddFunc("0 */1 * * *", func() {
-		ctx := context.Background()
-		fmt.Println("sitemap cron execution")
-		s.questionService.SitemapCron(ctx)
-	})
-	if err != nil {
-		log.Error(err)
-	}
+	ctx := context.Background()
+	s.sitemapService.SitemapCron(ctx)
 
-	_, err = c.AddFunc("0 */1 * * *", func() {
-		ctx := context.Background()
-		fmt.Println("refresh hottest cron execution")
-		s.questionService.RefreshHottestCron(ctx)
-	})
-	if err != nil {
-		log.Error(err)
-	}
+	s.scheduler.Register("sitemap", "0 */1 * * *", s.sitemapService.SitemapCron)
+	s.scheduler.Register("refresh_hottest", "0 */1 * * *", s.questionService.RefreshHottestCron)
+	s.scheduler.Register("site_analytics_rollup", "10 */1 * * *", s.analyticsService.RollupCron)
+	s.scheduler.Register("email_verification_reminder", "20 */1 * * *", s.userService.VerificationReminderCron)
+	s.scheduler.Register("question_sla_check", "*/10 * * * *", s.slaService.SLACheckCron)
+	s.scheduler.Register("leaderboard_rollup", "30 */1 * * *", s.leaderboard.RollupCron)
+	s.scheduler.Register("user_tag_score_refresh", "40 2 * * *", s.userTagScore.RefreshScoresCron)
+	s.scheduler.Register("pending_deletion_finalize", "*/1 * * * *", s.pendingDeletion.FinalizeCron)
+	s.scheduler.Register("recycle_bin_purge", "50 3 * * *", s.pendingDeletion.PurgeCron)
+	s.scheduler.Register("data_retention", "10 4 * * *", s.dataRetention.RetentionCron)
+	s.scheduler.Register("dead_link_check", "20 */1 * * *", s.deadLink.DeadLinkCheckCron)
+	s.scheduler.Register("embedding_sync", "*/5 * * * *", s.embedding.EmbeddingSyncCron)
+	s.scheduler.Register("question_review_reminder", "30 */1 * * *", s.reviewReminder.ReviewReminderCron)
+	s.scheduler.Register("revision_compaction", "30 4 * * *", s.revisionCompaction.CompactionCron)
+	s.scheduler.Register("event_stream_delivery", "*/1 * * * *", s.eventStream.DeliveryCron)
+	s.scheduler.Register("jira_status_sync", "*/10 * * * *", s.jira.StatusSyncCron)
+	s.scheduler.Register("list_index_refresh", "*/1 * * * *", s.listIndex.RefreshCron)
+	s.scheduler.Register("slow_query_flush", "*/1 * * * *", s.slowQuery.FlushCron)
+	s.scheduler.Register("sync_change_cleanup", "40 4 * * *", s.deltaSync.SyncChangeCleanupCron)
+	s.scheduler.Register("tag_subscription_digest", "0 8 * * *", s.tagAutoSubscription.DigestCron)
+
+	s.pluginJobService.RegisterPluginJobs(s.scheduler.Cron())
 
-	c.Start()
+	s.scheduler.Start(ctx)
+	lifecycle.OnShutdown("scheduler", s.scheduler.Stop)
 }
@@ -23,6 +23,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/apache/incubator-answer/internal/base/slowquery"
 	"github.com/apache/incubator-answer/pkg/dir"
 	"github.com/apache/incubator-answer/plugin"
 	_ "github.com/go-sql-driver/mysql"
@@ -91,6 +92,7 @@ func NewDB(debug bool, dataConf *Database) (*xorm.Engine, error) {
 		engine.SetConnMaxLifetime(time.Duration(dataConf.ConnMaxLifeTime) * time.Second)
 	}
 	engine.SetColumnMapper(names.GonicMapper{})
+	engine.AddHook(slowquery.NewHook(engine))
 	return engine, nil
 }
 
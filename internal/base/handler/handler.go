@@ -35,7 +35,9 @@ func HandleResponse(ctx *gin.Context, err error, data interface{}) {
 	lang := GetLang(ctx)
 	// no error
 	if err == nil {
-		ctx.JSON(http.StatusOK, NewRespBodyData(http.StatusOK, reason.Success, data).TrMsg(lang))
+		respBody := NewRespBodyData(http.StatusOK, reason.Success, data).TrMsg(lang)
+		respBody.Impersonation = IsImpersonation(ctx)
+		ctx.JSON(http.StatusOK, respBody)
 		return
 	}
 
@@ -43,8 +45,9 @@ func HandleResponse(ctx *gin.Context, err error, data interface{}) {
 	// unknown error
 	if !errors.As(err, &myErr) {
 		log.Error(err, "\n", myErrors.LogStack(2, 5))
-		ctx.JSON(http.StatusInternalServerError, NewRespBody(
-			http.StatusInternalServerError, reason.UnknownError).TrMsg(lang))
+		respBody := NewRespBody(http.StatusInternalServerError, reason.UnknownError).TrMsg(lang)
+		respBody.ErrorCode = reason.ErrorCode(reason.UnknownError)
+		WriteErrorResponse(ctx, http.StatusInternalServerError, respBody)
 		return
 	}
 
@@ -57,7 +60,8 @@ func HandleResponse(ctx *gin.Context, err error, data interface{}) {
 	if data != nil {
 		respBody.Data = data
 	}
-	ctx.JSON(myErr.Code, respBody)
+	respBody.Impersonation = IsImpersonation(ctx)
+	WriteErrorResponse(ctx, myErr.Code, respBody)
 }
 
 // BindAndCheck bind request and check
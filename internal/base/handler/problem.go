@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// ProblemContentType the content type of the problem details defined by RFC 7807
+	ProblemContentType = "application/problem+json"
+	// ErrorCatalogPath the path of the error catalog, the type of the problem links to the code in it
+	ErrorCatalogPath = "/answer/api/v1/errors"
+)
+
+// ProblemDetails the error response defined by RFC 7807, it is returned instead of RespBody
+// if the client accepts application/problem+json
+type ProblemDetails struct {
+	// Type the uri of the error code in the error catalog
+	Type string `json:"type"`
+	// Title the http status text
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail the translated message of the error
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+	// Code the stable machine-readable error code
+	Code string `json:"code"`
+	// Reason the reason key of the error
+	Reason string `json:"reason"`
+	// Errors the field-level validation errors
+	Errors []*ProblemFieldError `json:"errors,omitempty"`
+	// Data the other data of the error
+	Data any `json:"data,omitempty"`
+}
+
+// ProblemFieldError the validation error of the field
+type ProblemFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// AcceptProblemDetails whether the client accepts the problem details
+func AcceptProblemDetails(ctx *gin.Context) bool {
+	return strings.Contains(ctx.GetHeader("Accept"), ProblemContentType)
+}
+
+// NewProblemDetails new problem details from the translated response body
+func NewProblemDetails(ctx *gin.Context, respBody *RespBody) *ProblemDetails {
+	code := reason.ErrorCode(respBody.Reason)
+	problem := &ProblemDetails{
+		Type:     ErrorCatalogPath + "#" + code,
+		Title:    http.StatusText(respBody.Code),
+		Status:   respBody.Code,
+		Detail:   respBody.Message,
+		Instance: ctx.Request.URL.Path,
+		Code:     code,
+		Reason:   respBody.Reason,
+	}
+	fields, ok := respBody.Data.([]*validator.FormErrorField)
+	if !ok {
+		problem.Data = respBody.Data
+		return problem
+	}
+	for _, field := range fields {
+		problem.Errors = append(problem.Errors, &ProblemFieldError{Field: field.ErrorField, Message: field.ErrorMsg})
+	}
+	return problem
+}
+
+// WriteErrorResponse write the error response as the problem details if the client accepts it
+func WriteErrorResponse(ctx *gin.Context, status int, respBody *RespBody) {
+	if !AcceptProblemDetails(ctx) {
+		ctx.JSON(status, respBody)
+		return
+	}
+	// the content type is kept by gin since it is set before rendering
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.JSON(status, NewProblemDetails(ctx, respBody))
+}
@@ -20,6 +20,7 @@
 package handler
 
 import (
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/i18n"
@@ -31,10 +32,14 @@ type RespBody struct {
 	Code int `json:"code"`
 	// reason key
 	Reason string `json:"reason"`
+	// the stable machine-readable error code, it is only set for the error
+	ErrorCode string `json:"error_code,omitempty"`
 	// response message
 	Message string `json:"msg"`
 	// response data
 	Data interface{} `json:"data"`
+	// whether the request is made by the admin who signs in as the user
+	Impersonation bool `json:"impersonation,omitempty"`
 }
 
 // TrMsg translate the reason cause as a message
@@ -56,9 +61,10 @@ func NewRespBody(code int, reason string) *RespBody {
 // NewRespBodyFromError new response body from error
 func NewRespBodyFromError(e *errors.Error) *RespBody {
 	return &RespBody{
-		Code:    e.Code,
-		Reason:  e.Reason,
-		Message: e.Message,
+		Code:      e.Code,
+		Reason:    e.Reason,
+		ErrorCode: reason.ErrorCode(e.Reason),
+		Message:   e.Message,
 	}
 }
 
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
+// GetTimeZoneByCtx get the time zone of the recipient, UTC is used if it is not set or invalid
+func GetTimeZoneByCtx(ctx context.Context) *time.Location {
+	timeZone, ok := ctx.Value(constant.TimeZoneFlag).(string)
+	if !ok || len(timeZone) == 0 {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// GetDateFormatByCtx get the go layout of the date format chosen by the recipient
+func GetDateFormatByCtx(ctx context.Context) string {
+	dateFormat, _ := ctx.Value(constant.DateFormatFlag).(string)
+	if layout, ok := constant.DateFormats[dateFormat]; ok {
+		return layout
+	}
+	return constant.DateFormats[constant.DefaultDateFormat]
+}
+
+// FormatTimeByCtx format the time with the time zone and the date format of the recipient
+func FormatTimeByCtx(ctx context.Context, t time.Time) string {
+	return t.In(GetTimeZoneByCtx(ctx)).Format(GetDateFormatByCtx(ctx) + " 15:04 MST")
+}
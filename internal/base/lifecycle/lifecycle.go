@@ -0,0 +1,116 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentfault/pacman/log"
+)
+
+// idleCheckInterval the interval of checking whether the background work is done
+const idleCheckInterval = 50 * time.Millisecond
+
+type shutdownHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+var (
+	draining int32
+	lock     sync.Mutex
+	hooks    []*shutdownHook
+)
+
+// OnShutdown register the hook run when the application stops after the http server is drained,
+// the hooks run in the reverse order of the registration, so the job scheduler registered last stops first
+// and the queues it sends messages to are drained after it
+func OnShutdown(name string, fn func(ctx context.Context) error) {
+	lock.Lock()
+	defer lock.Unlock()
+	hooks = append(hooks, &shutdownHook{name: name, fn: fn})
+}
+
+// StartDraining mark the application as stopping, the health check fails from now on,
+// so the load balancer stops sending new requests to it
+func StartDraining() {
+	atomic.StoreInt32(&draining, 1)
+}
+
+// Draining whether the application is stopping
+func Draining() bool {
+	return atomic.LoadInt32(&draining) == 1
+}
+
+// Shutdown run all the hooks, the hooks not finished before the deadline of the context are abandoned
+func Shutdown(ctx context.Context) {
+	StartDraining()
+	lock.Lock()
+	list := make([]*shutdownHook, len(hooks))
+	copy(list, hooks)
+	lock.Unlock()
+	for i := len(list) - 1; i >= 0; i-- {
+		hook := list[i]
+		startTime := time.Now()
+		if err := hook.fn(ctx); err != nil {
+			log.Errorf("shutdown %s failed: %v", hook.name, err)
+			continue
+		}
+		log.Infof("shutdown %s in %s", hook.name, time.Since(startTime))
+	}
+}
+
+// PendingCounter count the messages sent to the queue but not handled yet, the queue embeds it as the first field,
+// so the counter is 64-bit aligned for the atomic operations on the 32-bit platforms
+type PendingCounter struct {
+	pending int64
+}
+
+// Add count the message sent to the queue
+func (pc *PendingCounter) Add() {
+	atomic.AddInt64(&pc.pending, 1)
+}
+
+// Done count the message handled
+func (pc *PendingCounter) Done() {
+	atomic.AddInt64(&pc.pending, -1)
+}
+
+// Drain wait until all the sent messages are handled
+func (pc *PendingCounter) Drain(ctx context.Context) error {
+	return WaitIdle(ctx, func() bool { return atomic.LoadInt64(&pc.pending) == 0 })
+}
+
+// WaitIdle wait until the idle function returns true or the context is done
+func WaitIdle(ctx context.Context, idle func() bool) (err error) {
+	ticker := time.NewTicker(idleCheckInterval)
+	defer ticker.Stop()
+	for !idle() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
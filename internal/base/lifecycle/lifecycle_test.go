@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package lifecycle
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdown(t *testing.T) {
+	lock.Lock()
+	originalHooks := hooks
+	hooks = nil
+	lock.Unlock()
+	t.Cleanup(func() {
+		lock.Lock()
+		hooks = originalHooks
+		lock.Unlock()
+		atomic.StoreInt32(&draining, 0)
+	})
+
+	order := make([]string, 0)
+	OnShutdown("queue", func(ctx context.Context) error {
+		order = append(order, "queue")
+		return nil
+	})
+	OnShutdown("scheduler", func(ctx context.Context) error {
+		order = append(order, "scheduler")
+		return nil
+	})
+	assert.False(t, Draining())
+	Shutdown(context.TODO())
+	assert.True(t, Draining())
+	assert.Equal(t, []string{"scheduler", "queue"}, order)
+}
+
+func TestPendingCounter(t *testing.T) {
+	pc := &PendingCounter{}
+	pc.Add()
+	pc.Add()
+	go func() {
+		time.Sleep(idleCheckInterval)
+		pc.Done()
+		pc.Done()
+	}()
+	assert.NoError(t, pc.Drain(context.TODO()))
+}
+
+func TestWaitIdle(t *testing.T) {
+	var pending int64 = 1
+	go func() {
+		time.Sleep(2 * idleCheckInterval)
+		atomic.AddInt64(&pending, -1)
+	}()
+	err := WaitIdle(context.TODO(), func() bool { return atomic.LoadInt64(&pending) == 0 })
+	assert.NoError(t, err)
+
+	atomic.AddInt64(&pending, 1)
+	ctx, cancel := context.WithTimeout(context.TODO(), idleCheckInterval)
+	defer cancel()
+	err = WaitIdle(ctx, func() bool { return atomic.LoadInt64(&pending) == 0 })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
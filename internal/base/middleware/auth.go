@@ -24,11 +24,14 @@ import (
 	"strings"
 
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permission_policy"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/upload_access"
 	"github.com/apache/incubator-answer/ui"
 	"github.com/gin-gonic/gin"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -40,19 +43,31 @@ import (
 
 var ctxUUIDKey = "ctxUuidKey"
 
+// passwordChangeRoute the only route the user whose password is expired can access
+const passwordChangeRoute = "/user/password"
+
 // AuthUserMiddleware auth user middleware
 type AuthUserMiddleware struct {
 	authService           *auth.AuthService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	rolePowerRelService   *role.RolePowerRelService
+	policyService         *permission_policy.PermissionPolicyService
+	uploadAccessService   *upload_access.UploadAccessService
 }
 
 // NewAuthUserMiddleware new auth user middleware
 func NewAuthUserMiddleware(
 	authService *auth.AuthService,
-	siteInfoCommonService siteinfo_common.SiteInfoCommonService) *AuthUserMiddleware {
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	rolePowerRelService *role.RolePowerRelService,
+	policyService *permission_policy.PermissionPolicyService,
+	uploadAccessService *upload_access.UploadAccessService) *AuthUserMiddleware {
 	return &AuthUserMiddleware{
 		authService:           authService,
 		siteInfoCommonService: siteInfoCommonService,
+		rolePowerRelService:   rolePowerRelService,
+		policyService:         policyService,
+		uploadAccessService:   uploadAccessService,
 	}
 }
 
@@ -70,12 +85,26 @@ func (am *AuthUserMiddleware) Auth() gin.HandlerFunc {
 			return
 		}
 		if userInfo != nil {
-			ctx.Set(ctxUUIDKey, userInfo)
+			setLoginUserInfo(ctx, userInfo)
+			am.touchUserSession(ctx, token, userInfo)
 		}
 		ctx.Next()
 	}
 }
 
+// setLoginUserInfo set the login user info to context, the impersonation session is marked as well
+func setLoginUserInfo(ctx *gin.Context, userInfo *entity.UserCacheInfo) {
+	ctx.Set(ctxUUIDKey, userInfo)
+	if userInfo.IsImpersonation() {
+		ctx.Set(constant.ImpersonationFlag, true)
+	}
+}
+
+// touchUserSession record the device info and the last active time of the login session
+func (am *AuthUserMiddleware) touchUserSession(ctx *gin.Context, token string, userInfo *entity.UserCacheInfo) {
+	am.authService.TouchUserSession(ctx, userInfo.UserID, token, ctx.Request.UserAgent(), ctx.ClientIP())
+}
+
 // EjectUserBySiteInfo if admin config the site can access by nologin user, eject user.
 func (am *AuthUserMiddleware) EjectUserBySiteInfo() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
@@ -108,6 +137,7 @@ func (am *AuthUserMiddleware) EjectUserBySiteInfo() gin.HandlerFunc {
 }
 
 // MustAuthWithoutAccountAvailable auth user info, any login user can access though user is not active.
+// The password expiration is not checked either, so that the user can still log out or manage the sessions.
 func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ExtractToken(ctx)
@@ -127,7 +157,8 @@ func (am *AuthUserMiddleware) MustAuthWithoutAccountAvailable() gin.HandlerFunc
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		setLoginUserInfo(ctx, userInfo)
+		am.touchUserSession(ctx, token, userInfo)
 		ctx.Next()
 	}
 }
@@ -164,11 +195,32 @@ func (am *AuthUserMiddleware) MustAuthAndAccountAvailable() gin.HandlerFunc {
 			ctx.Abort()
 			return
 		}
-		ctx.Set(ctxUUIDKey, userInfo)
+		if abortIfPasswordExpired(ctx, userInfo, true) {
+			return
+		}
+		setLoginUserInfo(ctx, userInfo)
+		am.touchUserSession(ctx, token, userInfo)
 		ctx.Next()
 	}
 }
 
+// isPasswordChange whether the request changes the password of the login user
+func isPasswordChange(ctx *gin.Context) bool {
+	return ctx.Request.Method == http.MethodPut && strings.HasSuffix(ctx.FullPath(), passwordChangeRoute)
+}
+
+// abortIfPasswordExpired abort the request if the password of the login user is expired.
+// If allowPasswordChange is true, the request that changes the password can still go through.
+func abortIfPasswordExpired(ctx *gin.Context, userInfo *entity.UserCacheInfo, allowPasswordChange bool) (aborted bool) {
+	if !userInfo.PasswordExpired || (allowPasswordChange && isPasswordChange(ctx)) {
+		return false
+	}
+	handler.HandleResponse(ctx, errors.Forbidden(reason.PasswordExpired),
+		&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypePasswordExpired})
+	ctx.Abort()
+	return true
+}
+
 func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		token := ExtractToken(ctx)
@@ -189,12 +241,71 @@ func (am *AuthUserMiddleware) AdminAuth() gin.HandlerFunc {
 				ctx.Abort()
 				return
 			}
+			// the admin changes the expired password through the user api, not the admin one
+			if abortIfPasswordExpired(ctx, userInfo, false) {
+				return
+			}
 			ctx.Set(ctxUUIDKey, userInfo)
 		}
 		ctx.Next()
 	}
 }
 
+// MustPower the role of the login user must have the power, it should be used after the auth middleware
+func (am *AuthUserMiddleware) MustPower(powerType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		userInfo := GetUserInfoFromContext(ctx)
+		if userInfo == nil {
+			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+			ctx.Abort()
+			return
+		}
+		powers, err := am.rolePowerRelService.GetRolePowerList(ctx, userInfo.RoleID)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			ctx.Abort()
+			return
+		}
+		for _, power := range powers {
+			if power == powerType {
+				ctx.Next()
+				return
+			}
+		}
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		ctx.Abort()
+	}
+}
+
+// CheckPermissionPolicy check the rule of the route in the permission policy, it should be used after the auth middleware
+func (am *AuthUserMiddleware) CheckPermissionPolicy() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		rule := am.policyService.GetRouteRule(ctx, ctx.Request.Method, ctx.FullPath())
+		if rule == nil {
+			ctx.Next()
+			return
+		}
+		userInfo := GetUserInfoFromContext(ctx)
+		if userInfo == nil {
+			handler.HandleResponse(ctx, errors.Unauthorized(reason.UnauthorizedError), nil)
+			ctx.Abort()
+			return
+		}
+		can, err := am.policyService.CheckRouteRule(ctx, rule, userInfo.UserID, userInfo.RoleID)
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			ctx.Abort()
+			return
+		}
+		if !can {
+			handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
 func (am *AuthUserMiddleware) CheckPrivateMode() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		resp, err := am.siteInfoCommonService.GetSiteLogin(ctx)
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPasswordChange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	matched := false
+	check := func(ctx *gin.Context) {
+		matched = isPasswordChange(ctx)
+	}
+	group := r.Group("/answer/api/v1")
+	group.PUT("/user/password", check)
+	group.PUT("/user/info", check)
+	group.POST("/user/password/reset", check)
+
+	tests := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{method: http.MethodPut, path: "/answer/api/v1/user/password", want: true},
+		{method: http.MethodPut, path: "/answer/api/v1/user/info", want: false},
+		{method: http.MethodPost, path: "/answer/api/v1/user/password/reset", want: false},
+	}
+	for _, tt := range tests {
+		matched = !tt.want
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+		assert.Equal(t, tt.want, matched, tt.path)
+	}
+}
+
+func TestAbortIfPasswordExpired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name                string
+		passwordExpired     bool
+		allowPasswordChange bool
+		method              string
+		path                string
+		want                bool
+	}{
+		{name: "not expired", passwordExpired: false, method: http.MethodGet, path: "/answer/api/v1/user/info", want: false},
+		{name: "expired", passwordExpired: true, allowPasswordChange: true, method: http.MethodGet, path: "/answer/api/v1/user/info", want: true},
+		{name: "expired and change password", passwordExpired: true, allowPasswordChange: true, method: http.MethodPut, path: "/answer/api/v1/user/password", want: false},
+		{name: "expired and change password from admin", passwordExpired: true, allowPasswordChange: false, method: http.MethodPut, path: "/answer/admin/api/user/password", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			aborted := false
+			r.Handle(tt.method, tt.path, func(ctx *gin.Context) {
+				aborted = abortIfPasswordExpired(ctx, &entity.UserCacheInfo{PasswordExpired: tt.passwordExpired}, tt.allowPasswordChange)
+			})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			assert.Equal(t, tt.want, aborted)
+			if tt.want {
+				assert.Equal(t, http.StatusForbidden, w.Code)
+			}
+		})
+	}
+}
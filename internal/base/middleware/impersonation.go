@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// BanAPIForImpersonation the sensitive actions such as changing the password or email are not allowed
+// when the admin signs in as the user
+func BanAPIForImpersonation(ctx *gin.Context) {
+	userInfo := GetUserInfoFromContext(ctx)
+	if userInfo != nil && userInfo.IsImpersonation() {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.UserImpersonationForbidden), nil)
+		ctx.Abort()
+		return
+	}
+	ctx.Next()
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/maintenance"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	apiPathPrefix      = "/answer/api/"
+	adminAPIPathPrefix = "/answer/admin/api/"
+)
+
+// maintenanceAllowedPaths the write apis that still work in the read-only mode, so the users can sign in and out
+var maintenanceAllowedPaths = map[string]bool{
+	"/answer/api/v1/user/login/email": true,
+	"/answer/api/v1/user/logout":      true,
+}
+
+type MaintenanceMiddleware struct {
+	maintenanceService *maintenance.MaintenanceService
+}
+
+// NewMaintenanceMiddleware new maintenance middleware
+func NewMaintenanceMiddleware(maintenanceService *maintenance.MaintenanceService) *MaintenanceMiddleware {
+	return &MaintenanceMiddleware{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// ReadOnly reject the write requests to the api with 503 when the site is under maintenance,
+// the reads, the login and the admin api still work
+func (mm *MaintenanceMiddleware) ReadOnly() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !isMaintenanceRestricted(ctx.Request.Method, ctx.Request.URL.Path) {
+			ctx.Next()
+			return
+		}
+		readOnly, message, err := mm.maintenanceService.IsReadOnly(ctx)
+		if err != nil {
+			log.Errorf("check maintenance mode failed: %v", err)
+			ctx.Next()
+			return
+		}
+		if !readOnly {
+			ctx.Next()
+			return
+		}
+		respBody := handler.NewRespBodyFromError(errors.ServiceUnavailable(reason.SiteMaintenance)).
+			TrMsg(handler.GetLang(ctx))
+		if len(message) > 0 {
+			respBody.Message = message
+		}
+		respBody.Data = &schema.SiteMaintenanceResp{Enabled: true, Message: respBody.Message}
+		ctx.Abort()
+		handler.WriteErrorResponse(ctx, http.StatusServiceUnavailable, respBody)
+	}
+}
+
+func isMaintenanceRestricted(method, path string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	if !strings.HasPrefix(path, apiPathPrefix) || strings.HasPrefix(path, adminAPIPathPrefix) {
+		return false
+	}
+	return !maintenanceAllowedPaths[path]
+}
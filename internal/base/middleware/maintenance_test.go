@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMaintenanceRestricted(t *testing.T) {
+	assert.False(t, isMaintenanceRestricted(http.MethodGet, "/answer/api/v1/question/page"))
+	assert.False(t, isMaintenanceRestricted(http.MethodPost, "/answer/api/v1/user/login/email"))
+	assert.False(t, isMaintenanceRestricted(http.MethodPut, "/answer/admin/api/maintenance"))
+	assert.False(t, isMaintenanceRestricted(http.MethodPost, "/questions/ask"))
+	assert.True(t, isMaintenanceRestricted(http.MethodPost, "/answer/api/v1/question"))
+	assert.True(t, isMaintenanceRestricted(http.MethodDelete, "/answer/api/v1/answer"))
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/service/page_cache"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+type PageCacheMiddleware struct {
+	pageCacheService *page_cache.PageCacheService
+}
+
+// NewPageCacheMiddleware new page cache middleware
+func NewPageCacheMiddleware(pageCacheService *page_cache.PageCacheService) *PageCacheMiddleware {
+	return &PageCacheMiddleware{
+		pageCacheService: pageCacheService,
+	}
+}
+
+// AnonymousCache serve the GET requests of the anonymous users from the cache, with ETag and Last-Modified,
+// so the unchanged pages are not sent again. The questionIDParam is the query parameter of the question id if
+// the page belongs to a question, the page is refreshed when the question changes.
+func (pm *PageCacheMiddleware) AnonymousCache(questionIDParam string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet || len(GetLoginUserIDFromContext(ctx)) > 0 ||
+			reload.PageCacheTime() <= 0 {
+			ctx.Next()
+			return
+		}
+		questionID := ""
+		if len(questionIDParam) > 0 {
+			questionID = uid.DeShortID(ctx.Query(questionIDParam))
+		}
+		key := pm.pageCacheService.PageKey(ctx, questionID, string(handler.GetLang(ctx)),
+			strconv.FormatBool(handler.GetEnableShortID(ctx)), ctx.Request.URL.RequestURI())
+		if page, ok := pm.pageCacheService.GetPage(ctx, key); ok {
+			writeCachedPage(ctx, page)
+			ctx.Abort()
+			return
+		}
+
+		writer := &pageCacheWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+		if writer.Status() != http.StatusOK {
+			ctx.Writer.WriteHeader(writer.Status())
+			_, _ = ctx.Writer.Write(writer.body.Bytes())
+			return
+		}
+		page := &page_cache.CachedPage{
+			Body:         writer.body.String(),
+			ContentType:  writer.Header().Get("Content-Type"),
+			ETag:         `W/"` + encryption.MD5(writer.body.String()) + `"`,
+			LastModified: time.Now().Unix(),
+		}
+		pm.pageCacheService.SetPage(ctx, key, page)
+		writeCachedPage(ctx, page)
+	}
+}
+
+// writeCachedPage write the page, or 304 if the client has the same page
+func writeCachedPage(ctx *gin.Context, page *page_cache.CachedPage) {
+	header := ctx.Writer.Header()
+	header.Set("ETag", page.ETag)
+	header.Set("Last-Modified", time.Unix(page.LastModified, 0).UTC().Format(http.TimeFormat))
+	// the browsers must check whether the page is changed before using it
+	header.Set("Cache-Control", "no-cache")
+	header.Add("Vary", "Accept-Language, Authorization")
+	if isPageNotModified(ctx.Request, page) {
+		ctx.Status(http.StatusNotModified)
+		ctx.Writer.WriteHeaderNow()
+		return
+	}
+	ctx.Data(http.StatusOK, page.ContentType, []byte(page.Body))
+}
+
+func isPageNotModified(req *http.Request, page *page_cache.CachedPage) bool {
+	if match := req.Header.Get("If-None-Match"); len(match) > 0 {
+		return match == page.ETag || match == "*"
+	}
+	since, err := http.ParseTime(req.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return page.LastModified <= since.Unix()
+}
+
+// pageCacheWriter buffer the response, so it can be cached and sent with the validators
+type pageCacheWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *pageCacheWriter) WriteHeader(code int) {
+	w.status = code
+}
+
+func (w *pageCacheWriter) WriteHeaderNow() {}
+
+func (w *pageCacheWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *pageCacheWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *pageCacheWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *pageCacheWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *pageCacheWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
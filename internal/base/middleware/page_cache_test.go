@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/page_cache"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymousCache(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pageCacheService := page_cache.NewPageCacheService(&data.Data{Cache: memory.NewCache()},
+		event_queue.NewEventQueueService())
+	pm := NewPageCacheMiddleware(pageCacheService)
+	calls := 0
+	r := gin.New()
+	r.GET("/question/info", pm.AnonymousCache("id"), func(ctx *gin.Context) {
+		calls++
+		ctx.JSON(http.StatusOK, gin.H{"calls": calls})
+	})
+	request := func(header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/question/info?id=10010000000000001", nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := request(nil)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.NotEmpty(t, first.Header().Get("ETag"))
+	second := request(nil)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, 1, calls)
+
+	notModified := request(map[string]string{"If-None-Match": first.Header().Get("ETag")})
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.String())
+
+	_ = pageCacheService.HandleEvent(context.TODO(), &schema.EventMsg{
+		EventType: constant.EventAnswerCreate, ObjectID: "10020000000000001", QuestionID: "10010000000000001"})
+	third := request(nil)
+	assert.Equal(t, 2, calls)
+	assert.NotEqual(t, first.Header().Get("ETag"), third.Header().Get("ETag"))
+}
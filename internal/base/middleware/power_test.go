@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockRolePowerRelRepo struct {
+	powers map[int][]string
+}
+
+func (m *mockRolePowerRelRepo) GetRolePowerTypeList(_ context.Context, roleID int) (powers []string, err error) {
+	return m.powers[roleID], nil
+}
+
+func TestMustPower(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	am := &AuthUserMiddleware{
+		rolePowerRelService: role.NewRolePowerRelService(&mockRolePowerRelRepo{powers: map[int][]string{
+			4: {permission.AnalyticsView},
+		}}, nil),
+	}
+
+	tests := []struct {
+		name     string
+		userInfo *entity.UserCacheInfo
+		code     int
+		aborted  bool
+	}{
+		{name: "not login", userInfo: nil, code: http.StatusUnauthorized, aborted: true},
+		{name: "without power", userInfo: &entity.UserCacheInfo{UserID: "1", RoleID: role.RoleUserID}, code: http.StatusForbidden, aborted: true},
+		{name: "custom role with power", userInfo: &entity.UserCacheInfo{UserID: "1", RoleID: 4}, code: http.StatusOK, aborted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(recorder)
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/answer/api/v1/analytics", nil)
+			if tt.userInfo != nil {
+				setLoginUserInfo(ctx, tt.userInfo)
+			}
+			am.MustPower(permission.AnalyticsView)(ctx)
+			assert.Equal(t, tt.aborted, ctx.IsAborted())
+			assert.Equal(t, tt.code, recorder.Code)
+		})
+	}
+}
@@ -22,6 +22,7 @@ package middleware
 import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
 	"net/http"
 	"strings"
 )
@@ -44,6 +45,14 @@ func (am *AuthUserMiddleware) VisitAuth() gin.HandlerFunc {
 			return
 		}
 
+		// The file in the rendered post is signed, because the viewer's access to the post has been checked.
+		filePath := ctx.Request.URL.Path
+		if am.uploadAccessService.CheckSignedURL(ctx, filePath, ctx.Query("expires"), ctx.Query("sig")) {
+			ctx.Header("Cache-Control", "private, max-age=3600")
+			ctx.Next()
+			return
+		}
+
 		visitToken, err := ctx.Cookie(constant.UserVisitCookiesCacheKey)
 		if err != nil || len(visitToken) == 0 {
 			ctx.Abort()
@@ -51,10 +60,21 @@ func (am *AuthUserMiddleware) VisitAuth() gin.HandlerFunc {
 			return
 		}
 
-		if !am.authService.CheckUserVisitToken(ctx, visitToken) {
+		userInfo, err := am.authService.GetUserCacheInfoByVisitToken(ctx, visitToken)
+		if err != nil || userInfo == nil {
+			ctx.Abort()
+			ctx.Redirect(http.StatusFound, "/403")
+			return
+		}
+		canView, err := am.uploadAccessService.CheckFileAccess(ctx, filePath, userInfo)
+		if err != nil {
+			log.Error(err)
+		}
+		if !canView {
 			ctx.Abort()
 			ctx.Redirect(http.StatusFound, "/403")
 			return
 		}
+		ctx.Header("Cache-Control", "private, no-cache")
 	}
 }
@@ -34,6 +34,8 @@ const (
 	ForbiddenError = "base.forbidden_error"
 	// DuplicateRequestError duplicate request error
 	DuplicateRequestError = "base.duplicate_request_error"
+	// RateLimitError too many requests error
+	RateLimitError = "base.rate_limit_error"
 )
 
 const (
@@ -62,11 +64,22 @@ const (
 	UserNotFound                     = "error.user.not_found"
 	UsernameInvalid                  = "error.user.username_invalid"
 	UsernameDuplicate                = "error.user.username_duplicate"
+	UsernameLengthInvalid            = "error.user.username_length_invalid"
+	UsernameCharsetInvalid           = "error.user.username_charset_invalid"
+	UsernameReserved                 = "error.user.username_reserved"
+	UsernameImpersonation            = "error.user.username_impersonation"
+	DisplayNameImpersonation         = "error.user.display_name_impersonation"
+	UsernamePolicyInvalid            = "error.user.username_policy_invalid"
+	NameChangeTooFrequent            = "error.user.name_change_too_frequent"
+	TimeZoneInvalid                  = "error.user.time_zone_invalid"
+	DateFormatInvalid                = "error.user.date_format_invalid"
 	UserSetAvatar                    = "error.user.set_avatar"
 	EmailDuplicate                   = "error.email.duplicate"
 	EmailVerifyURLExpired            = "error.email.verify_url_expired"
 	EmailNeedToBeVerified            = "error.email.need_to_be_verified"
 	EmailIllegalDomainError          = "error.email.illegal_email_domain_error"
+	EmailDomainBlocked               = "error.email.domain_blocked"
+	EmailDisposable                  = "error.email.disposable"
 	UserSuspended                    = "error.user.suspended"
 	ObjectNotFound                   = "error.object.not_found"
 	TagNotFound                      = "error.tag.not_found"
@@ -79,6 +92,9 @@ const (
 	NoEnoughRankToOperate            = "error.rank.no_enough_rank_to_operate"
 	ThemeNotFound                    = "error.theme.not_found"
 	LangNotFound                     = "error.lang.not_found"
+	LangPackInvalid                  = "error.lang.pack_invalid"
+	LangPackCodeInvalid              = "error.lang.pack_code_invalid"
+	LangPackNotFound                 = "error.lang.pack_not_found"
 	ReportHandleFailed               = "error.report.handle_failed"
 	ReportNotFound                   = "error.report.not_found"
 	ReadConfigFailed                 = "error.config.read_config_failed"
@@ -106,6 +122,126 @@ const (
 	AddBulkUsersAmountError          = "error.user.add_bulk_users_amount_error"
 	InvalidURLError                  = "error.common.invalid_url"
 	MetaObjectNotFound               = "error.meta.object_not_found"
+	ContentRejectedByFilter          = "error.object.content_rejected_by_filter"
+	PluginJobNotFound                = "error.plugin.job_not_found"
+	PluginNotFound                   = "error.plugin.not_found"
+	PluginConfigFieldRequired        = "error.plugin.config_field_required"
+	PluginConfigFieldInvalidOption   = "error.plugin.config_field_invalid_option"
+	PluginConfigFieldInvalidNumber   = "error.plugin.config_field_invalid_number"
+	PluginConfigValidationFailed     = "error.plugin.config_validation_failed"
+	PageNotFound                     = "error.page.not_found"
+	PageSlugNameInvalid              = "error.page.slug_name_invalid"
+	PageSlugNameAlreadyExist         = "error.page.slug_name_already_exist"
+	AnnouncementNotFound             = "error.announcement.not_found"
+	AnnouncementRolesRequired        = "error.announcement.roles_required"
+	AnnouncementTimeRangeInvalid     = "error.announcement.time_range_invalid"
+	ProfileFieldNotFound             = "error.profile_field.not_found"
+	ProfileFieldNameInvalid          = "error.profile_field.name_invalid"
+	ProfileFieldNameAlreadyExist     = "error.profile_field.name_already_exist"
+	ProfileFieldOptionsRequired      = "error.profile_field.options_required"
+	ProfileFieldValueRequired        = "error.profile_field.value_required"
+	ProfileFieldValueTooLong         = "error.profile_field.value_too_long"
+	ProfileFieldValueInvalidOption   = "error.profile_field.value_invalid_option"
+	ProfileFieldValueInvalidBoolean  = "error.profile_field.value_invalid_boolean"
+	UserCannotFollowYourself         = "error.user.cannot_follow_yourself"
+	UserSessionNotFound              = "error.user.session_not_found"
+	PasswordBreached                 = "error.password.breached"
+	PasswordExpired                  = "error.password.expired"
+	InvitationRequired               = "error.invitation.required"
+	InvitationInvalid                = "error.invitation.invalid"
+	InvitationNotFound               = "error.invitation.not_found"
+	InvitationNoPermission           = "error.invitation.no_permission"
+	QuestionAssigneeNotFound         = "error.question_assignment.assignee_not_found"
+	QuestionAssignmentNotFound       = "error.question_assignment.not_found"
+	QuestionCannotAssign             = "error.question_assignment.cannot_assign"
+	SLAPolicyEmpty                   = "error.sla.policy_empty"
+	ArticleNotFound                  = "error.article.not_found"
+	ArticleAnswerVotesNotEnough      = "error.article.answer_votes_not_enough"
+	QuestionPollAlreadyExist         = "error.question_poll.already_exist"
+	QuestionPollNotFound             = "error.question_poll.not_found"
+	QuestionPollClosed               = "error.question_poll.closed"
+	QuestionPollOptionInvalid        = "error.question_poll.option_invalid"
+	QuestionPollCloseAtInvalid       = "error.question_poll.close_at_invalid"
+	CoAuthorAlreadyExist             = "error.co_author.already_exist"
+	CoAuthorNotFound                 = "error.co_author.not_found"
+	CoAuthorCannotAddAuthor          = "error.co_author.cannot_add_author"
+	CoAuthorLimitExceeded            = "error.co_author.limit_exceeded"
+	UserContentTransferSameUser      = "error.user.content_transfer_same_user"
+	UserMergeSameUser                = "error.user.merge_same_user"
+	TimelineExportFieldInvalid       = "error.timeline.export_field_invalid"
+	UndoDeleteNotAllowed             = "error.object.undo_delete_not_allowed"
+	ScheduledJobNotFound             = "error.scheduled_job.not_found"
+	ScheduledJobScheduleInvalid      = "error.scheduled_job.schedule_invalid"
+	ScheduledJobRunning              = "error.scheduled_job.running"
+	UserImpersonationNotAllowed      = "error.user.impersonation_not_allowed"
+	UserImpersonationForbidden       = "error.user.impersonation_forbidden"
+	RoleNotFound                     = "error.role.not_found"
+	RoleNameAlreadyExist             = "error.role.name_already_exist"
+	RoleCannotUpdate                 = "error.role.cannot_update"
+	RoleIsUsedCannotDelete           = "error.role.is_used_cannot_delete"
+	RolePowerInvalid                 = "error.role.power_invalid"
+	PermissionPolicyRuleInvalid      = "error.permission_policy.rule_invalid"
+	QuestionShareLinkNotFound        = "error.question_share.link_not_found"
+	QuestionShareNoPermission        = "error.question_share.no_permission"
+	QuestionNotUnlisted              = "error.question_share.not_unlisted"
+	AIAssistantDisabled              = "error.ai_assistant.disabled"
+	AIAssistantQuotaExceeded         = "error.ai_assistant.quota_exceeded"
+	AIAssistantGenerateFailed        = "error.ai_assistant.generate_failed"
+	ReviewReminderCodeInvalid        = "error.review_reminder.code_invalid"
+	ConversionAnswerAccepted         = "error.conversion.answer_accepted"
+	ConversionContentTooLong         = "error.conversion.content_too_long"
+	ConversionTargetInvalid          = "error.conversion.target_invalid"
+	QuestionMergeSameQuestion        = "error.question.merge_same_question"
+	QuestionAlreadyMerged            = "error.question.already_merged"
+	QuestionAlreadyClosed            = "error.question.already_closed"
+	MediaProxyDisabled               = "error.media_proxy.disabled"
+	MediaProxySignatureInvalid       = "error.media_proxy.signature_invalid"
+	MediaProxyFetchFailed            = "error.media_proxy.fetch_failed"
+	MediaProxyTooLarge               = "error.media_proxy.too_large"
+	LoginTemporarilyLocked           = "error.user.login_temporarily_locked"
+	EmailWebhookKeyInvalid           = "error.email.webhook_key_invalid"
+	EmailProviderUnsupported         = "error.email.provider_unsupported"
+	EmailTemplateNotFound            = "error.email_template.not_found"
+	EmailTemplateInvalid             = "error.email_template.invalid"
+	NewContributorQuestionLimit      = "error.new_contributor.question_limit"
+	NewContributorAnswerLimit        = "error.new_contributor.answer_limit"
+	ExperimentNotFound               = "error.experiment.not_found"
+	ExperimentInvalid                = "error.experiment.invalid"
+	FeatureDisabled                  = "error.feature.disabled"
+	EventStreamConfigInvalid         = "error.event_stream.config_invalid"
+	InboundQuestionDisabled          = "error.inbound_question.disabled"
+	InboundQuestionKeyInvalid        = "error.inbound_question.key_invalid"
+	InboundQuestionNotFound          = "error.inbound_question.not_found"
+	InboundQuestionReviewed          = "error.inbound_question.reviewed"
+	ChatIntegrationDisabled          = "error.chat_integration.disabled"
+	ChatIntegrationSignatureInvalid  = "error.chat_integration.signature_invalid"
+	GitHubConfigInvalid              = "error.github.config_invalid"
+	GitHubDisabled                   = "error.github.disabled"
+	JiraConfigInvalid                = "error.jira.config_invalid"
+	JiraDisabled                     = "error.jira.disabled"
+	JiraIssueAlreadyLinked           = "error.jira.issue_already_linked"
+	JiraRequestFailed                = "error.jira.request_failed"
+	SiteMaintenance                  = "error.maintenance.read_only"
+	ContentTooShort                  = "error.content_limit.too_short"
+	ContentTooLong                   = "error.content_limit.too_long"
+	ContentLimitsInvalid             = "error.content_limit.invalid"
+	BannedWordRejected               = "error.banned_word.rejected"
+	BannedWordRegexInvalid           = "error.banned_word.regex_invalid"
+	SyncCursorInvalid                = "error.sync.cursor_invalid"
+	ModerationRuleInvalid            = "error.moderation_rule.invalid"
+	QuestionWorkflowInvalid          = "error.question_workflow.invalid"
+	QuestionWorkflowDisabled         = "error.question_workflow.disabled"
+	QuestionWorkflowStateNotFound    = "error.question_workflow.state_not_found"
+	QuestionWorkflowNotAllowed       = "error.question_workflow.transition_not_allowed"
+	AnswerVerificationNotExpert      = "error.answer_verification.not_expert"
+	AnswerVerificationOwnAnswer      = "error.answer_verification.own_answer"
+	ModerationTriageActionInvalid    = "error.moderation_triage.action_invalid"
+	LegalHoldSuperAdminOnly          = "error.legal_hold.super_admin_only"
+	LegalHoldNotFound                = "error.legal_hold.not_found"
+	LegalHoldObjectNotFound          = "error.legal_hold.object_not_found"
+	LegalHoldContentFrozen           = "error.legal_hold.content_frozen"
+	IPLoggingGeoIPURLInvalid         = "error.ip_logging.geoip_url_invalid"
+	EditLockObjectNotFound           = "error.edit_lock.object_not_found"
 )
 
 // user external login reasons
@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package reload
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
+// Settings the settings in the config file that are safe to change without restarting,
+// they are applied again when the config is reloaded by SIGHUP or by the admin api
+type Settings struct {
+	LogLevel string    `json:"log_level" mapstructure:"log_level" yaml:"log_level,omitempty"`
+	SMTP     *SMTP     `json:"smtp" mapstructure:"smtp" yaml:"smtp,omitempty"`
+	CacheTTL *CacheTTL `json:"cache_ttl" mapstructure:"cache_ttl" yaml:"cache_ttl,omitempty"`
+	// Maintenance put the site into the read-only mode, it works even if the database can not be written
+	Maintenance *Maintenance `json:"maintenance" mapstructure:"maintenance" yaml:"maintenance,omitempty"`
+	// SlowQuery log the slow database queries and collect them into the admin report
+	SlowQuery *SlowQuery `json:"slow_query" mapstructure:"slow_query" yaml:"slow_query,omitempty"`
+}
+
+// SMTP the smtp server used instead of the one in the admin settings if the host is set
+type SMTP struct {
+	Host       string `json:"host" mapstructure:"host" yaml:"host"`
+	Port       int    `json:"port" mapstructure:"port" yaml:"port"`
+	Username   string `json:"username" mapstructure:"username" yaml:"username"`
+	Password   string `json:"password" mapstructure:"password" yaml:"password"`
+	Encryption string `json:"encryption" mapstructure:"encryption" yaml:"encryption"` // "" SSL TLS
+	FromEmail  string `json:"from_email" mapstructure:"from_email" yaml:"from_email"`
+	FromName   string `json:"from_name" mapstructure:"from_name" yaml:"from_name"`
+}
+
+// Maintenance the read-only mode forced by the config file, it overrides the admin setting
+type Maintenance struct {
+	Enabled bool   `json:"enabled" mapstructure:"enabled" yaml:"enabled"`
+	Message string `json:"message" mapstructure:"message" yaml:"message"`
+}
+
+// SlowQuery the queries taking longer than the threshold milliseconds are slow, zero means the default
+// and a negative value turns the logging off
+type SlowQuery struct {
+	Threshold int `json:"threshold" mapstructure:"threshold" yaml:"threshold"`
+	// SkipExplain do not query the execution plans of the slow queries
+	SkipExplain bool `json:"skip_explain" mapstructure:"skip_explain" yaml:"skip_explain"`
+}
+
+// CacheTTL the seconds the settings are cached, zero means the default
+type CacheTTL struct {
+	SiteInfo int `json:"site_info" mapstructure:"site_info" yaml:"site_info"`
+	Config   int `json:"config" mapstructure:"config" yaml:"config"`
+	// Page the responses of the public apis to the anonymous users, a negative value turns the cache off
+	Page int `json:"page" mapstructure:"page" yaml:"page"`
+}
+
+// defaultSlowQueryThreshold the queries taking longer than it are slow if the threshold is not set
+const defaultSlowQueryThreshold = time.Second
+
+var (
+	current    atomic.Value
+	lock       sync.Mutex
+	onApply    []func(s *Settings)
+	reloadFunc func() (*Settings, error)
+)
+
+// Current get the settings in use, it is never nil
+func Current() *Settings {
+	if s, ok := current.Load().(*Settings); ok {
+		return s
+	}
+	return &Settings{}
+}
+
+// Apply use the settings and notify the handlers registered by OnApply
+func Apply(s *Settings) {
+	if s == nil {
+		s = &Settings{}
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	current.Store(s)
+	for _, fn := range onApply {
+		fn(s)
+	}
+}
+
+// OnApply register the handler called when the settings are applied, e.g. to change the log level
+func OnApply(fn func(s *Settings)) {
+	lock.Lock()
+	defer lock.Unlock()
+	onApply = append(onApply, fn)
+}
+
+// SetReloadFunc set the function reading the settings from the config file
+func SetReloadFunc(fn func() (*Settings, error)) {
+	lock.Lock()
+	defer lock.Unlock()
+	reloadFunc = fn
+}
+
+// Reload read the config file again and apply the settings
+func Reload(_ context.Context) (err error) {
+	lock.Lock()
+	fn := reloadFunc
+	lock.Unlock()
+	if fn == nil {
+		return fmt.Errorf("the config can not be reloaded")
+	}
+	s, err := fn()
+	if err != nil {
+		return err
+	}
+	Apply(s)
+	return nil
+}
+
+// SiteInfoCacheTime the time the site info is cached
+func SiteInfoCacheTime() time.Duration {
+	if ttl := Current().CacheTTL; ttl != nil && ttl.SiteInfo > 0 {
+		return time.Duration(ttl.SiteInfo) * time.Second
+	}
+	return constant.SiteInfoCacheTime
+}
+
+// ConfigCacheTime the time the config is cached
+func ConfigCacheTime() time.Duration {
+	if ttl := Current().CacheTTL; ttl != nil && ttl.Config > 0 {
+		return time.Duration(ttl.Config) * time.Second
+	}
+	return constant.ConfigCacheTime
+}
+
+// PageCacheTime the time the responses to the anonymous users are cached, zero means the cache is off
+func PageCacheTime() time.Duration {
+	if ttl := Current().CacheTTL; ttl != nil && ttl.Page != 0 {
+		if ttl.Page < 0 {
+			return 0
+		}
+		return time.Duration(ttl.Page) * time.Second
+	}
+	return constant.PageCacheTime
+}
+
+// SlowQueryThreshold the time the slow query takes at least, zero means the slow queries are not logged
+func SlowQueryThreshold() time.Duration {
+	if sq := Current().SlowQuery; sq != nil && sq.Threshold != 0 {
+		if sq.Threshold < 0 {
+			return 0
+		}
+		return time.Duration(sq.Threshold) * time.Millisecond
+	}
+	return defaultSlowQueryThreshold
+}
+
+// SlowQueryExplain whether the execution plans of the slow queries are queried
+func SlowQueryExplain() bool {
+	sq := Current().SlowQuery
+	return sq == nil || !sq.SkipExplain
+}
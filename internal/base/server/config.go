@@ -22,6 +22,11 @@ package server
 // HTTP http config
 type HTTP struct {
 	Addr string `json:"addr" mapstructure:"addr"`
+	// ShutdownTimeout the seconds waiting for the in-flight requests and the background jobs when the server stops
+	ShutdownTimeout int `json:"shutdown_timeout" mapstructure:"shutdown_timeout"`
+	// DrainDelay the seconds the server keeps serving with the failing health check before it stops accepting
+	// the connections, it should be longer than the interval of the health check of the load balancer
+	DrainDelay int `json:"drain_delay" mapstructure:"drain_delay"`
 }
 
 // UI ui config
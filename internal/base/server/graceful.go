@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	DefaultShutdownTimeout = 30 * time.Second
+	// listenFDsStart the first file descriptor passed by the systemd socket activation
+	listenFDsStart = 3
+)
+
+// GracefulServer the http server drains the in-flight requests when it stops, and it uses the socket passed by
+// the systemd socket activation if there is one, so the connections are queued by the kernel while restarting
+type GracefulServer struct {
+	srv             *http.Server
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+}
+
+// NewGracefulServer new graceful http server
+func NewGracefulServer(engine *gin.Engine, conf *HTTP) *GracefulServer {
+	return &GracefulServer{
+		srv:             &http.Server{Addr: conf.Addr, Handler: engine},
+		shutdownTimeout: ShutdownTimeout(conf),
+		drainDelay:      time.Duration(conf.DrainDelay) * time.Second,
+	}
+}
+
+// ShutdownTimeout the time waiting for the requests and the background jobs when the server stops
+func ShutdownTimeout(conf *HTTP) time.Duration {
+	if conf != nil && conf.ShutdownTimeout > 0 {
+		return time.Duration(conf.ShutdownTimeout) * time.Second
+	}
+	return DefaultShutdownTimeout
+}
+
+// Start listen on the inherited socket or the address and serve until the server is shut down
+func (gs *GracefulServer) Start() (err error) {
+	listener, err := inheritedListener()
+	if err != nil {
+		return err
+	}
+	if listener != nil {
+		log.Infof("serve on the inherited socket %s", listener.Addr())
+	} else if listener, err = net.Listen("tcp", gs.srv.Addr); err != nil {
+		return err
+	}
+	if err = gs.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown fail the health check, wait for the load balancer to notice it, then stop accepting new connections
+// and wait for the in-flight requests to finish
+func (gs *GracefulServer) Shutdown() (err error) {
+	lifecycle.StartDraining()
+	if gs.drainDelay > 0 {
+		log.Infof("draining, stop accepting connections after %s", gs.drainDelay)
+		time.Sleep(gs.drainDelay)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gs.shutdownTimeout)
+	defer cancel()
+	if err = gs.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("wait for the in-flight requests failed: %w", err)
+	}
+	return nil
+}
+
+// inheritedListener get the socket passed by the systemd socket activation, it is nil if there is none
+func inheritedListener() (listener net.Listener, err error) {
+	if pid, _ := strconv.Atoi(os.Getenv("LISTEN_PID")); pid != os.Getpid() {
+		return nil, nil
+	}
+	if fds, _ := strconv.Atoi(os.Getenv("LISTEN_FDS")); fds < 1 {
+		return nil, nil
+	}
+	// the socket should not be passed to the child processes
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+	file := os.NewFile(listenFDsStart, "listener")
+	defer file.Close()
+	listener, err = net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("use the inherited socket failed: %w", err)
+	}
+	return listener, nil
+}
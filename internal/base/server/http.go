@@ -22,8 +22,10 @@ package server
 import (
 	"html/template"
 	"io/fs"
+	"net/http"
 
 	brotli "github.com/anargu/gin-brotli"
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/router"
 	"github.com/apache/incubator-answer/plugin"
@@ -40,6 +42,7 @@ func NewHTTPServer(debug bool,
 	authUserMiddleware *middleware.AuthUserMiddleware,
 	avatarMiddleware *middleware.AvatarMiddleware,
 	shortIDMiddleware *middleware.ShortIDMiddleware,
+	maintenanceMiddleware *middleware.MaintenanceMiddleware,
 	templateRouter *router.TemplateRouter,
 	pluginAPIRouter *router.PluginAPIRouter,
 	uiConf *UI,
@@ -51,8 +54,16 @@ func NewHTTPServer(debug bool,
 		gin.SetMode(gin.ReleaseMode)
 	}
 	r := gin.New()
-	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag())
-	r.GET("/healthz", func(ctx *gin.Context) { ctx.String(200, "OK") })
+	r.Use(brotli.Brotli(brotli.DefaultCompression), middleware.ExtractAndSetAcceptLanguage, shortIDMiddleware.SetShortIDFlag(),
+		maintenanceMiddleware.ReadOnly())
+	r.GET("/healthz", func(ctx *gin.Context) {
+		// the load balancer stops sending requests to the server while it is stopping
+		if lifecycle.Draining() {
+			ctx.String(http.StatusServiceUnavailable, "DRAINING")
+			return
+		}
+		ctx.String(http.StatusOK, "OK")
+	})
 
 	html, _ := fs.Sub(ui.Template, "template")
 	htmlTemplate := template.Must(template.New("").Funcs(funcMap).ParseFS(html, "*"))
@@ -82,8 +93,8 @@ func NewHTTPServer(debug bool,
 
 	// register api that must be authenticated
 	authV1 := r.Group("/answer/api/v1")
-	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable())
-	answerRouter.RegisterAnswerAPIRouter(authV1)
+	authV1.Use(authUserMiddleware.MustAuthAndAccountAvailable(), authUserMiddleware.CheckPermissionPolicy())
+	answerRouter.RegisterAnswerAPIRouter(authUserMiddleware, authV1)
 
 	adminauthV1 := r.Group("/answer/admin/api")
 	adminauthV1.Use(authUserMiddleware.AdminAuth())
@@ -61,6 +61,9 @@ var funcMap = template.FuncMap{
 	"templateHTML": func(data string) template.HTML {
 		return template.HTML(data)
 	},
+	"templateJS": func(data string) template.JS {
+		return template.JS(data)
+	},
 	"formatLinkNofollow": func(data string) template.HTML {
 		return template.HTML(FormatLinkNofollow(data))
 	},
@@ -0,0 +1,240 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package slowquery log the slow database queries with their execution plans, the queries are aggregated
+// by their statements until they are drained into the slow query report.
+package slowquery
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
+	"xorm.io/xorm/contexts"
+	"xorm.io/xorm/schemas"
+)
+
+const (
+	// maxStats the max number of the statements collected before they are drained
+	maxStats = 500
+	// maxSampleLength the max length of the sample query kept in the stat
+	maxSampleLength = 2000
+	// explainTimeout the time the query of the execution plan takes at most
+	explainTimeout = 5 * time.Second
+)
+
+// Stat the slow queries of the same statement since the last draining
+type Stat struct {
+	Fingerprint string
+	Statement   string
+	Sample      string
+	Plan        string
+	Count       int64
+	TotalTime   time.Duration
+	MaxTime     time.Duration
+	LastSeenAt  time.Time
+}
+
+var (
+	lock  sync.Mutex
+	stats = make(map[string]*Stat)
+	// plans the execution plans which are queried after the stats are drained
+	plans = make(map[string]string)
+)
+
+var (
+	stringPattern  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numberPattern  = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	listPattern    = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+	dollarArgument = regexp.MustCompile(`\$\d+`)
+)
+
+// Normalize replace the literals and the arguments of the query with "?", so the queries differing only in them
+// have the same statement
+func Normalize(query string) string {
+	query = stringPattern.ReplaceAllString(query, "?")
+	query = dollarArgument.ReplaceAllString(query, "?")
+	query = numberPattern.ReplaceAllString(query, "?")
+	query = listPattern.ReplaceAllString(query, "(?)")
+	return strings.TrimSpace(spacePattern.ReplaceAllString(query, " "))
+}
+
+// Record add the slow query to the stat of its statement, first is true if the statement is new since
+// the last draining
+func Record(query string, args []interface{}, executeTime time.Duration, now time.Time) (fingerprint string, first bool) {
+	statement := Normalize(query)
+	fingerprint = encryption.MD5(statement)
+
+	lock.Lock()
+	defer lock.Unlock()
+	stat, ok := stats[fingerprint]
+	if !ok {
+		if len(stats) >= maxStats {
+			return fingerprint, false
+		}
+		sample := query
+		if len(args) > 0 {
+			sample = fmt.Sprintf("%s %v", query, args)
+		}
+		if len(sample) > maxSampleLength {
+			sample = sample[:maxSampleLength]
+		}
+		stat = &Stat{Fingerprint: fingerprint, Statement: statement, Sample: sample}
+		stats[fingerprint] = stat
+	}
+	stat.Count++
+	stat.TotalTime += executeTime
+	if executeTime > stat.MaxTime {
+		stat.MaxTime = executeTime
+	}
+	stat.LastSeenAt = now
+	return fingerprint, !ok
+}
+
+// Drain get the stats collected since the last draining and reset them
+func Drain() []*Stat {
+	lock.Lock()
+	defer lock.Unlock()
+	list := make([]*Stat, 0, len(stats))
+	for fingerprint, stat := range stats {
+		if plan, ok := plans[fingerprint]; ok {
+			stat.Plan = plan
+			delete(plans, fingerprint)
+		}
+		list = append(list, stat)
+	}
+	stats = make(map[string]*Stat)
+	if len(plans) > maxStats {
+		plans = make(map[string]string)
+	}
+	return list
+}
+
+func setPlan(fingerprint, plan string) {
+	lock.Lock()
+	defer lock.Unlock()
+	if stat, ok := stats[fingerprint]; ok {
+		stat.Plan = plan
+		return
+	}
+	plans[fingerprint] = plan
+}
+
+// Hook the xorm hook logging the queries taking longer than the threshold in the runtime settings
+type Hook struct {
+	engine *xorm.Engine
+	// explaining is 1 when an execution plan is being queried, only one is queried at a time
+	explaining int32
+}
+
+// NewHook new slow query hook of the engine, the engine is used to query the execution plans
+func NewHook(engine *xorm.Engine) *Hook {
+	return &Hook{engine: engine}
+}
+
+// BeforeProcess do nothing
+func (h *Hook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	return c.Ctx, nil
+}
+
+// AfterProcess log the query if it is slow, and query its execution plan if its statement is new
+func (h *Hook) AfterProcess(c *contexts.ContextHook) error {
+	threshold := reload.SlowQueryThreshold()
+	if threshold <= 0 || c.ExecuteTime < threshold {
+		return nil
+	}
+	log.Warnf("slow query took %s: %s %v", c.ExecuteTime, c.SQL, c.Args)
+	fingerprint, first := Record(c.SQL, c.Args, c.ExecuteTime, time.Now())
+	if first && reload.SlowQueryExplain() {
+		h.explain(fingerprint, c.SQL, c.Args)
+	}
+	return nil
+}
+
+// explain query the execution plan of the select query in the background
+func (h *Hook) explain(fingerprint, query string, args []interface{}) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 || !strings.EqualFold(fields[0], "SELECT") {
+		return
+	}
+	var prefix string
+	switch h.engine.Dialect().URI().DBType {
+	case schemas.MYSQL, schemas.POSTGRES:
+		prefix = "EXPLAIN "
+	case schemas.SQLITE:
+		prefix = "EXPLAIN QUERY PLAN "
+	default:
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&h.explaining, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&h.explaining, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), explainTimeout)
+		defer cancel()
+		// the plain database is used, so the query of the plan does not go through the hooks
+		plan, err := queryPlan(ctx, h.engine.DB().DB, prefix+query, args)
+		if err != nil {
+			log.Warnf("explain slow query failed: %s", err)
+			return
+		}
+		setPlan(fingerprint, plan)
+	}()
+}
+
+// queryPlan run the explain query and format the rows as the tab separated lines
+func queryPlan(ctx context.Context, db *sql.DB, query string, args []interface{}) (plan string, err error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return "", err
+	}
+	lines := []string{strings.Join(columns, "\t")}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err = rows.Scan(dest...); err != nil {
+			return "", err
+		}
+		fields := make([]string, 0, len(values))
+		for _, value := range values {
+			fields = append(fields, value.String)
+		}
+		lines = append(lines, strings.Join(fields, "\t"))
+	}
+	return strings.Join(lines, "\n"), rows.Err()
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package slowquery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "SELECT * FROM `question` WHERE id IN (?) AND title = ? AND score > ? LIMIT ?",
+		Normalize("SELECT * FROM `question`\n  WHERE id IN (?, ?,?) AND title = 'it''s' AND score > 1.5 LIMIT 10"))
+	assert.Equal(t, "SELECT * FROM tag_v2 WHERE id = ? AND status = ?",
+		Normalize("SELECT * FROM tag_v2 WHERE id = $1 AND status = $2"))
+}
+
+func TestRecordAndDrain(t *testing.T) {
+	Drain()
+	now := time.Now()
+	fingerprint, first := Record("SELECT * FROM tag WHERE id IN (?)", []interface{}{1}, 2*time.Second, now)
+	assert.True(t, first)
+	sameFingerprint, first := Record("SELECT * FROM tag WHERE id IN (?,?)", []interface{}{1, 2}, time.Second, now)
+	assert.False(t, first)
+	assert.Equal(t, fingerprint, sameFingerprint)
+	setPlan(fingerprint, "plan")
+
+	stats := Drain()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, int64(2), stats[0].Count)
+	assert.Equal(t, 3*time.Second, stats[0].TotalTime)
+	assert.Equal(t, 2*time.Second, stats[0].MaxTime)
+	assert.Equal(t, "SELECT * FROM tag WHERE id IN (?) [1]", stats[0].Sample)
+	assert.Equal(t, "plan", stats[0].Plan)
+	assert.Empty(t, Drain())
+
+	// the plan queried after the draining is kept for the next stat of the statement
+	setPlan(fingerprint, "later plan")
+	Record("SELECT * FROM tag WHERE id IN (?)", []interface{}{3}, time.Second, now)
+	stats = Drain()
+	assert.Len(t, stats, 1)
+	assert.Equal(t, "later plan", stats[0].Plan)
+}
@@ -0,0 +1,204 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package translator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// languagePackDir the language packs are saved in the directory of the bundle directory
+	languagePackDir = "packs"
+	// languagePackIndex the file lists the languages and the labels of the language packs
+	languagePackIndex = "i18n.yaml"
+	// MaxLanguagePackSize the max size of the language pack file
+	MaxLanguagePackSize = 5 * 1024 * 1024
+)
+
+var (
+	languagePackCodeRegexp = regexp.MustCompile(`^[a-z]{2,3}(_[A-Za-z0-9]{2,4})?$`)
+	languagePackLock       sync.Mutex
+)
+
+// loadLanguagePacks the language pack overwrites the messages of the built-in language,
+// or adds a new language option if the language is not built in
+func (t *translation) loadLanguagePacks(bundleDir string) error {
+	packs, err := readLanguagePackIndex(bundleDir)
+	if err != nil {
+		return err
+	}
+	builtIn := make(map[string]bool, len(t.options))
+	for _, option := range t.options {
+		builtIn[option.Value] = true
+	}
+	defaultCount := countTranslationData(t.data[i18n.DefaultLanguage])
+	for _, pack := range packs {
+		lang := i18n.Language(pack.Value)
+		buf, err := os.ReadFile(languagePackPath(bundleDir, pack.Value))
+		if err != nil {
+			log.Errorf("read language pack %s failed: %s", pack.Value, err)
+			continue
+		}
+		if err = t.add(lang, buf); err != nil {
+			log.Errorf("add language pack %s failed: %s", pack.Value, err)
+			continue
+		}
+		if builtIn[pack.Value] {
+			continue
+		}
+		progress := 100
+		if defaultCount > 0 {
+			progress = countTranslationData(t.data[lang]) * 100 / defaultCount
+		}
+		if progress > 100 {
+			progress = 100
+		}
+		t.options = append(t.options, &LangOption{
+			Label:    fmt.Sprintf("%s (%d%%)", pack.Label, progress),
+			Value:    pack.Value,
+			Progress: progress,
+		})
+	}
+	return nil
+}
+
+// GetLanguagePacks get the language packs uploaded by the admin
+func (tr *Translator) GetLanguagePacks() (packs []*LangOption, err error) {
+	packs, err = readLanguagePackIndex(tr.bundleDir)
+	if err != nil {
+		return nil, errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	for _, pack := range packs {
+		pack.Progress = 0
+		for _, option := range tr.get().options {
+			if option.Value == pack.Value {
+				pack.Progress = option.Progress
+			}
+		}
+	}
+	return packs, nil
+}
+
+// SaveLanguagePack save the language pack and reload the translations, the pack of the language is replaced if it exists
+func (tr *Translator) SaveLanguagePack(lang, label string, content []byte) (err error) {
+	if !languagePackCodeRegexp.MatchString(lang) || lang == string(i18n.DefaultLanguage) {
+		return errors.BadRequest(reason.LangPackCodeInvalid)
+	}
+	if len(content) > MaxLanguagePackSize {
+		return errors.BadRequest(reason.LangPackInvalid)
+	}
+	check := newTranslation()
+	if err = check.add(i18n.Language(lang), content); err != nil {
+		return errors.BadRequest(reason.LangPackInvalid).WithError(err)
+	}
+	if countTranslationData(check.data[i18n.Language(lang)]) == 0 {
+		return errors.BadRequest(reason.LangPackInvalid)
+	}
+
+	languagePackLock.Lock()
+	defer languagePackLock.Unlock()
+	packs, err := readLanguagePackIndex(tr.bundleDir)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.MkdirAll(filepath.Join(tr.bundleDir, languagePackDir), os.ModePerm); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = os.WriteFile(languagePackPath(tr.bundleDir, lang), content, 0o644); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	newPacks := []*LangOption{{Label: label, Value: lang}}
+	for _, pack := range packs {
+		if pack.Value != lang {
+			newPacks = append(newPacks, pack)
+		}
+	}
+	return tr.saveLanguagePackIndex(newPacks)
+}
+
+// RemoveLanguagePack remove the language pack and reload the translations
+func (tr *Translator) RemoveLanguagePack(lang string) (err error) {
+	languagePackLock.Lock()
+	defer languagePackLock.Unlock()
+	packs, err := readLanguagePackIndex(tr.bundleDir)
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	newPacks := make([]*LangOption, 0, len(packs))
+	for _, pack := range packs {
+		if pack.Value != lang {
+			newPacks = append(newPacks, pack)
+		}
+	}
+	if len(newPacks) == len(packs) {
+		return errors.BadRequest(reason.LangPackNotFound)
+	}
+	if err = os.Remove(languagePackPath(tr.bundleDir, lang)); err != nil && !os.IsNotExist(err) {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return tr.saveLanguagePackIndex(newPacks)
+}
+
+func (tr *Translator) saveLanguagePackIndex(packs []*LangOption) (err error) {
+	for _, pack := range packs {
+		pack.Progress = 0
+	}
+	content, err := yaml.Marshal(map[string]any{"language_options": packs})
+	if err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	indexPath := filepath.Join(tr.bundleDir, languagePackDir, languagePackIndex)
+	if err = os.WriteFile(indexPath, content, 0o644); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	if err = tr.reload(); err != nil {
+		return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func readLanguagePackIndex(bundleDir string) (packs []*LangOption, err error) {
+	content, err := os.ReadFile(filepath.Join(bundleDir, languagePackDir, languagePackIndex))
+	if os.IsNotExist(err) {
+		return make([]*LangOption, 0), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	packs, err = parseLangOptions(content)
+	if err != nil {
+		return nil, fmt.Errorf("language pack index parsing failed: %s", err)
+	}
+	return packs, nil
+}
+
+func languagePackPath(bundleDir, lang string) string {
+	return filepath.Join(bundleDir, languagePackDir, lang+".yaml")
+}
@@ -20,20 +20,14 @@
 package translator
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-
 	"github.com/google/wire"
-	myTran "github.com/segmentfault/pacman/contrib/i18n"
 	"github.com/segmentfault/pacman/i18n"
-	"github.com/segmentfault/pacman/log"
-	"gopkg.in/yaml.v3"
 )
 
 // ProviderSet is providers.
 var ProviderSet = wire.NewSet(NewTranslator)
 var GlobalTrans i18n.Translator
+var globalTranslator *Translator
 
 // LangOption language option
 type LangOption struct {
@@ -53,75 +47,27 @@ var (
 
 // NewTranslator new a translator
 func NewTranslator(c *I18n) (tr i18n.Translator, err error) {
-	entries, err := os.ReadDir(c.BundleDir)
+	t, err := loadTranslation(c.BundleDir)
 	if err != nil {
 		return nil, err
 	}
+	globalTranslator = &Translator{bundleDir: c.BundleDir, tr: t}
+	GlobalTrans = globalTranslator
+	LanguageOptions = t.options
+	return GlobalTrans, nil
+}
 
-	// read the Bundle resources file from entries
-	for _, file := range entries {
-		// ignore directory
-		if file.IsDir() {
-			continue
-		}
-		// ignore non-YAML file
-		if filepath.Ext(file.Name()) != ".yaml" && file.Name() != "i18n.yaml" {
-			continue
-		}
-		log.Debugf("try to read file: %s", file.Name())
-		buf, err := os.ReadFile(filepath.Join(c.BundleDir, file.Name()))
-		if err != nil {
-			return nil, fmt.Errorf("read file failed: %s %s", file.Name(), err)
-		}
-
-		// parse the backend translation
-		originalTr := struct {
-			Backend map[string]map[string]interface{} `yaml:"backend"`
-			UI      map[string]interface{}            `yaml:"ui"`
-			Plugin  map[string]interface{}            `yaml:"plugin"`
-		}{}
-		if err = yaml.Unmarshal(buf, &originalTr); err != nil {
-			return nil, err
-		}
-		translation := make(map[string]interface{}, 0)
-		for k, v := range originalTr.Backend {
-			translation[k] = v
-		}
-		translation["backend"] = originalTr.Backend
-		translation["ui"] = originalTr.UI
-		translation["plugin"] = originalTr.Plugin
-
-		content, err := yaml.Marshal(translation)
-		if err != nil {
-			log.Debugf("marshal translation content failed: %s %s", file.Name(), err)
-			continue
-		}
-
-		// add translator use backend translation
-		if err = myTran.AddTranslator(content, file.Name()); err != nil {
-			log.Debugf("add translator failed: %s %s", file.Name(), err)
-			continue
-		}
-	}
-	GlobalTrans = myTran.GlobalTrans
-
-	i18nFile, err := os.ReadFile(filepath.Join(c.BundleDir, "i18n.yaml"))
-	if err != nil {
-		return nil, fmt.Errorf("read i18n file failed: %s", err)
+// GetLanguageOptions get the language options, the languages of the language packs are included
+func GetLanguageOptions() []*LangOption {
+	if globalTranslator == nil {
+		return LanguageOptions
 	}
+	return globalTranslator.get().options
+}
 
-	s := struct {
-		LangOption []*LangOption `yaml:"language_options"`
-	}{}
-	err = yaml.Unmarshal(i18nFile, &s)
-	if err != nil {
-		return nil, fmt.Errorf("i18n file parsing failed: %s", err)
-	}
-	LanguageOptions = s.LangOption
-	for _, option := range LanguageOptions {
-		option.Label = fmt.Sprintf("%s (%d%%)", option.Label, option.Progress)
-	}
-	return GlobalTrans, err
+// GetTranslator get the global translator, it is nil if the translator is not initialized
+func GetTranslator() *Translator {
+	return globalTranslator
 }
 
 // CheckLanguageIsValid check user input language is valid
@@ -129,7 +75,7 @@ func CheckLanguageIsValid(lang string) bool {
 	if lang == DefaultLangOption {
 		return true
 	}
-	for _, option := range LanguageOptions {
+	for _, option := range GetLanguageOptions() {
 		if option.Value == lang {
 			return true
 		}
@@ -137,16 +83,13 @@ func CheckLanguageIsValid(lang string) bool {
 	return false
 }
 
-// Tr use language to translate data. If this language translation is not available, return default english translation.
+// Tr use language to translate data. If this language translation is not available,
+// the languages of its fallback chain are used and then the default english translation.
 func Tr(lang i18n.Language, data string) string {
 	if GlobalTrans == nil {
 		return data
 	}
-	translation := GlobalTrans.Tr(lang, data)
-	if translation == data {
-		return GlobalTrans.Tr(i18n.DefaultLanguage, data)
-	}
-	return translation
+	return GlobalTrans.Tr(lang, data)
 }
 
 // TrWithData translate key with template data, it will replace the template data {{ .PlaceHolder }} in the translation.
@@ -154,9 +97,13 @@ func TrWithData(lang i18n.Language, key string, templateData any) string {
 	if GlobalTrans == nil {
 		return key
 	}
-	translation := GlobalTrans.TrWithData(lang, key, templateData)
-	if translation == key {
-		return GlobalTrans.TrWithData(i18n.DefaultLanguage, key, templateData)
+	return GlobalTrans.TrWithData(lang, key, templateData)
+}
+
+// TrPlural translate key with the plural form of the count, such as the `one` and `other` form in english.
+func TrPlural(lang i18n.Language, key string, count int, templateData any) string {
+	if globalTranslator == nil {
+		return TrWithData(lang, key, templateData)
 	}
-	return translation
+	return globalTranslator.TrPlural(lang, key, count, templateData)
 }
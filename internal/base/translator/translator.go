@@ -0,0 +1,327 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	goI18n "github.com/LinkinStars/go-i18n/v2/i18n"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+)
+
+// LanguageFallbacks the languages are tried in order when the message is missing in the language,
+// the languages with the same base language are tried if it is not configured, the default language is always the last one
+var LanguageFallbacks = map[i18n.Language][]i18n.Language{
+	"zh_HK": {"zh_TW", "zh_CN"},
+	"zh_TW": {"zh_CN"},
+	"pt_BR": {"pt_PT"},
+	"pt_PT": {"pt_BR"},
+}
+
+// PluralCounter the template data decides the plural form of the translation by its count
+type PluralCounter interface {
+	PluralCount() int
+}
+
+// Translator translates the server-generated content with the plural forms and the fallback chains of the languages,
+// the translations are reloaded when the language packs are changed
+type Translator struct {
+	bundleDir string
+	mu        sync.RWMutex
+	tr        *translation
+}
+
+// translation the loaded translations, it is not changed after it is loaded
+type translation struct {
+	bundle     *goI18n.Bundle
+	localizers map[i18n.Language]*goI18n.Localizer
+	data       map[i18n.Language]map[string]any
+	options    []*LangOption
+	dumps      sync.Map
+}
+
+// translationFile the structure of the translation file
+type translationFile struct {
+	Backend map[string]map[string]any `yaml:"backend"`
+	UI      map[string]any            `yaml:"ui"`
+	Plugin  map[string]any            `yaml:"plugin"`
+}
+
+func newTranslation() *translation {
+	bundle := goI18n.NewBundle(language.AmericanEnglish)
+	bundle.RegisterUnmarshalFunc("yaml", yaml.Unmarshal)
+	return &translation{
+		bundle:     bundle,
+		localizers: make(map[i18n.Language]*goI18n.Localizer),
+		data:       make(map[i18n.Language]map[string]any),
+	}
+}
+
+// add the messages of the translation file are added to the language, the existing messages are overwritten
+func (t *translation) add(lang i18n.Language, buf []byte) (err error) {
+	originalTr := &translationFile{}
+	if err = yaml.Unmarshal(buf, originalTr); err != nil {
+		return err
+	}
+	translation := make(map[string]any, 0)
+	for k, v := range originalTr.Backend {
+		translation[k] = v
+	}
+	translation["backend"] = originalTr.Backend
+	translation["ui"] = originalTr.UI
+	translation["plugin"] = originalTr.Plugin
+
+	content, err := yaml.Marshal(translation)
+	if err != nil {
+		return err
+	}
+	if _, err = t.bundle.ParseMessageFileBytes(content, string(lang)+".yaml"); err != nil {
+		return err
+	}
+	t.localizers[lang] = goI18n.NewLocalizer(t.bundle, string(lang))
+
+	data := make(map[string]any)
+	if err = yaml.Unmarshal(content, &data); err != nil {
+		return err
+	}
+	if existing, ok := t.data[lang]; ok {
+		data = mergeTranslationData(existing, data)
+	}
+	t.data[lang] = data
+	return nil
+}
+
+// fallbackChain the loaded languages are used in order to translate the message of the language
+func (t *translation) fallbackChain(lang i18n.Language) (chain []i18n.Language) {
+	candidates := []i18n.Language{lang}
+	if fallbacks, ok := LanguageFallbacks[lang]; ok {
+		candidates = append(candidates, fallbacks...)
+	} else if base, _, found := strings.Cut(string(lang), "_"); found {
+		sameBase := make([]string, 0)
+		for l := range t.localizers {
+			if strings.HasPrefix(string(l), base+"_") {
+				sameBase = append(sameBase, string(l))
+			}
+		}
+		sort.Strings(sameBase)
+		for _, l := range sameBase {
+			candidates = append(candidates, i18n.Language(l))
+		}
+	}
+	candidates = append(candidates, i18n.DefaultLanguage)
+
+	seen := make(map[i18n.Language]bool, len(candidates))
+	for _, l := range candidates {
+		if _, ok := t.localizers[l]; !ok || seen[l] {
+			continue
+		}
+		seen[l] = true
+		chain = append(chain, l)
+	}
+	return chain
+}
+
+func (t *translation) localize(lang i18n.Language, key string, templateData any, pluralCount any) string {
+	if pluralCount == nil {
+		if counter, ok := templateData.(PluralCounter); ok {
+			pluralCount = counter.PluralCount()
+		}
+	}
+	for _, l := range t.fallbackChain(lang) {
+		localizer := t.localizers[l]
+		_, tpl, err := localizer.GetMessageTemplate(key, nil)
+		if err != nil || tpl == nil {
+			continue
+		}
+		translation, err := localizer.Localize(&goI18n.LocalizeConfig{
+			MessageID: key, TemplateData: templateData, PluralCount: pluralCount})
+		if err != nil && len(translation) == 0 {
+			log.Debugf("translate %s %s failed: %s", l, key, err)
+			translation = tpl.Other
+		}
+		if len(translation) > 0 {
+			return translation
+		}
+	}
+	return key
+}
+
+// dump the translations of the language, the missing messages are filled by the fallback chain
+func (t *translation) dump(lang i18n.Language) ([]byte, error) {
+	if content, ok := t.dumps.Load(lang); ok {
+		return content.([]byte), nil
+	}
+	chain := t.fallbackChain(lang)
+	data := make(map[string]any)
+	for i := len(chain) - 1; i >= 0; i-- {
+		data = mergeTranslationData(data, t.data[chain[i]])
+	}
+	content, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	t.dumps.Store(lang, content)
+	return content, nil
+}
+
+// mergeTranslationData the values of the overlay are merged into a copy of the base recursively
+func mergeTranslationData(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		overlayMap, ok := v.(map[string]any)
+		if !ok {
+			merged[k] = v
+			continue
+		}
+		if baseMap, ok := merged[k].(map[string]any); ok {
+			merged[k] = mergeTranslationData(baseMap, overlayMap)
+		} else {
+			merged[k] = overlayMap
+		}
+	}
+	return merged
+}
+
+// countTranslationData count the messages of the translation
+func countTranslationData(data map[string]any) (count int) {
+	for _, v := range data {
+		if m, ok := v.(map[string]any); ok {
+			count += countTranslationData(m)
+		} else {
+			count++
+		}
+	}
+	return count
+}
+
+// loadTranslation load the translations of the bundle directory and the language packs
+func loadTranslation(bundleDir string) (t *translation, err error) {
+	t = newTranslation()
+	entries, err := os.ReadDir(bundleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// read the Bundle resources file from entries
+	for _, file := range entries {
+		// ignore directory
+		if file.IsDir() {
+			continue
+		}
+		// ignore non-YAML file
+		if filepath.Ext(file.Name()) != ".yaml" || file.Name() == "i18n.yaml" {
+			continue
+		}
+		log.Debugf("try to read file: %s", file.Name())
+		buf, err := os.ReadFile(filepath.Join(bundleDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read file failed: %s %s", file.Name(), err)
+		}
+		lang := i18n.Language(strings.TrimSuffix(file.Name(), filepath.Ext(file.Name())))
+		if err = t.add(lang, buf); err != nil {
+			log.Debugf("add translator failed: %s %s", file.Name(), err)
+			continue
+		}
+	}
+
+	i18nFile, err := os.ReadFile(filepath.Join(bundleDir, "i18n.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("read i18n file failed: %s", err)
+	}
+	t.options, err = parseLangOptions(i18nFile)
+	if err != nil {
+		return nil, fmt.Errorf("i18n file parsing failed: %s", err)
+	}
+	for _, option := range t.options {
+		option.Label = fmt.Sprintf("%s (%d%%)", option.Label, option.Progress)
+	}
+
+	if err = t.loadLanguagePacks(bundleDir); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func parseLangOptions(content []byte) ([]*LangOption, error) {
+	s := struct {
+		LangOption []*LangOption `yaml:"language_options"`
+	}{}
+	if err := yaml.Unmarshal(content, &s); err != nil {
+		return nil, err
+	}
+	return s.LangOption, nil
+}
+
+// Tr translate the key
+func (tr *Translator) Tr(lang i18n.Language, key string) string {
+	return tr.TrWithData(lang, key, nil)
+}
+
+// TrWithData translate the key with the template data, the plural form is decided by the template data
+// if it implements PluralCounter
+func (tr *Translator) TrWithData(lang i18n.Language, key string, templateData any) string {
+	return tr.get().localize(lang, key, templateData, nil)
+}
+
+// TrPlural translate the key with the plural form of the count
+func (tr *Translator) TrPlural(lang i18n.Language, key string, count int, templateData any) string {
+	return tr.get().localize(lang, key, templateData, count)
+}
+
+// Dump dump the translations of the language
+func (tr *Translator) Dump(lang i18n.Language) ([]byte, error) {
+	return tr.get().dump(lang)
+}
+
+// FallbackChain the languages are used in order to translate the message of the language
+func (tr *Translator) FallbackChain(lang i18n.Language) []i18n.Language {
+	return tr.get().fallbackChain(lang)
+}
+
+func (tr *Translator) get() *translation {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	return tr.tr
+}
+
+// reload load the translations again and replace the current translations
+func (tr *Translator) reload() (err error) {
+	t, err := loadTranslation(tr.bundleDir)
+	if err != nil {
+		return err
+	}
+	tr.mu.Lock()
+	tr.tr = t
+	tr.mu.Unlock()
+	LanguageOptions = t.options
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package translator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func writeBundle(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+func newTestTranslator(t *testing.T) *Translator {
+	dir := writeBundle(t, map[string]string{
+		"i18n.yaml": `language_options:
+  - label: English
+    value: en_US
+    progress: 100
+  - label: 简体中文
+    value: zh_CN
+    progress: 100
+  - label: 繁體中文
+    value: zh_TW
+    progress: 50
+`,
+		"en_US.yaml": `backend:
+  base:
+    success:
+      other: Success.
+    unknown:
+      other: Unknown error.
+    items:
+      one: "{{.Count}} item"
+      other: "{{.Count}} items"
+ui:
+  title: Answer
+  menu: Menu
+`,
+		"zh_CN.yaml": `backend:
+  base:
+    success:
+      other: 成功。
+    unknown:
+      other: 未知错误。
+ui:
+  title: 问答
+  menu: 菜单
+`,
+		"zh_TW.yaml": `backend:
+  base:
+    success:
+      other: 成功！
+ui:
+  title: 問答
+`,
+	})
+	_, err := NewTranslator(&I18n{BundleDir: dir})
+	assert.NoError(t, err)
+	return GetTranslator()
+}
+
+type itemCount struct {
+	Count int
+}
+
+func (d *itemCount) PluralCount() int {
+	return d.Count
+}
+
+func TestTranslatorFallbackChain(t *testing.T) {
+	tr := newTestTranslator(t)
+	assert.Equal(t, []i18n.Language{"zh_TW", "zh_CN", "en_US"}, tr.FallbackChain("zh_HK"))
+	assert.Equal(t, []i18n.Language{"en_US"}, tr.FallbackChain("fr_FR"))
+
+	assert.Equal(t, "成功！", tr.Tr("zh_TW", "base.success"))
+	assert.Equal(t, "未知错误。", tr.Tr("zh_TW", "base.unknown"))
+	assert.Equal(t, "未知错误。", tr.Tr("zh_HK", "base.unknown"))
+	assert.Equal(t, "Unknown error.", tr.Tr("fr_FR", "base.unknown"))
+	assert.Equal(t, "base.not_found", tr.Tr("zh_TW", "base.not_found"))
+
+	content, err := tr.Dump("zh_TW")
+	assert.NoError(t, err)
+	assert.Equal(t, "問答", gjson.GetBytes(content, "ui.title").String())
+	assert.Equal(t, "菜单", gjson.GetBytes(content, "ui.menu").String())
+}
+
+func TestTranslatorPlural(t *testing.T) {
+	tr := newTestTranslator(t)
+	assert.Equal(t, "1 item", tr.TrWithData("en_US", "base.items", &itemCount{Count: 1}))
+	assert.Equal(t, "3 items", tr.TrWithData("en_US", "base.items", &itemCount{Count: 3}))
+	assert.Equal(t, "1 item", tr.TrPlural("zh_CN", "base.items", 1, &itemCount{Count: 1}))
+	assert.Equal(t, "Success.", tr.TrWithData("en_US", "base.success", &itemCount{Count: 1}))
+}
+
+func TestTranslatorLanguagePack(t *testing.T) {
+	tr := newTestTranslator(t)
+	pack := []byte(`backend:
+  base:
+    success:
+      other: 成功啦。
+ui:
+  title: 問答（香港）
+`)
+	assert.Error(t, tr.SaveLanguagePack("zh-HK", "香港", pack))
+	assert.Error(t, tr.SaveLanguagePack("en_US", "English", pack))
+	assert.Error(t, tr.SaveLanguagePack("zh_HK", "香港", []byte("backend: [")))
+
+	assert.NoError(t, tr.SaveLanguagePack("zh_HK", "香港", pack))
+	assert.True(t, CheckLanguageIsValid("zh_HK"))
+	assert.Equal(t, "成功啦。", tr.Tr("zh_HK", "base.success"))
+	assert.Equal(t, "未知错误。", tr.Tr("zh_HK", "base.unknown"))
+	packs, err := tr.GetLanguagePacks()
+	assert.NoError(t, err)
+	assert.Len(t, packs, 1)
+	assert.Equal(t, "zh_HK", packs[0].Value)
+
+	// the pack is loaded again when the translator is created
+	_, err = NewTranslator(&I18n{BundleDir: tr.bundleDir})
+	assert.NoError(t, err)
+	tr = GetTranslator()
+	assert.Equal(t, "成功啦。", tr.Tr("zh_HK", "base.success"))
+
+	assert.NoError(t, tr.RemoveLanguagePack("zh_HK"))
+	assert.False(t, CheckLanguageIsValid("zh_HK"))
+	assert.Equal(t, "成功！", tr.Tr("zh_HK", "base.success"))
+	assert.Error(t, tr.RemoveLanguagePack("zh_HK"))
+}
@@ -0,0 +1,423 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/answer"
+	authrepo "github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/comment"
+	configrepo "github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/embedding"
+	legalholdrepo "github.com/apache/incubator-answer/internal/repo/legal_hold"
+	"github.com/apache/incubator-answer/internal/repo/plugin_config"
+	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	rolerepo "github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/search_sync"
+	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/user"
+	usernamepolicyrepo "github.com/apache/incubator-answer/internal/repo/username_policy"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/service_config"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/plugin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// reindexPageSize the number of the questions or the answers sent to the search plugin at a time
+const reindexPageSize = 100
+
+// Roles the roles that can be given to the user by the command
+var Roles = map[string]int{
+	"user":      role.RoleUserID,
+	"admin":     role.RoleAdminID,
+	"moderator": role.RoleModeratorID,
+}
+
+// Toolkit manage the users and the contents from the command line, it works directly on the database
+// and the cache, so the operators can still manage the site when the admin ui is not reachable.
+type Toolkit struct {
+	data              *data.Data
+	cleanup           func()
+	userRepo          usercommon.UserRepo
+	userAdminRepo     user_admin.UserAdminRepo
+	userRoleRelRepo   role.UserRoleRelRepo
+	usernamePolicy    *username_policy.UsernamePolicyService
+	authService       *auth.AuthService
+	questionRepo      questioncommon.QuestionRepo
+	answerRepo        answercommon.AnswerRepo
+	commentCommonRepo comment_common.CommentCommonRepo
+	configService     *config.ConfigService
+	pluginConfigRepo  plugin_common.PluginConfigRepo
+	legalHoldRepo     legal_hold.LegalHoldRepo
+	uploadPath        string
+}
+
+// NewToolkit connect to the database and the cache of the site
+func NewToolkit(dbConf *data.Database, cacheConf *data.CacheConf, serviceConf *service_config.ServiceConfig) (
+	toolkit *Toolkit, err error) {
+	db, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, fmt.Errorf("connect database failed: %w", err)
+	}
+	cache, cacheCleanup, err := data.NewCache(cacheConf)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect cache failed: %w", err)
+	}
+	dataData, dataCleanup, _ := data.NewData(db, cache)
+
+	uniqueIDRepo := unique.NewUniqueIDRepo(dataData)
+	configService := config.NewConfigService(configrepo.NewConfigRepo(dataData))
+	authRepo := authrepo.NewAuthRepo(dataData)
+	userRankRepo := rank.NewUserRankRepo(dataData, configService)
+	activityRepo := activity_common.NewActivityRepo(dataData, uniqueIDRepo, configService)
+	userRepo := user.NewUserRepo(dataData)
+	userRoleRelRepo := rolerepo.NewUserRoleRelRepo(dataData)
+	siteInfoRepo := site_info.NewSiteInfo(dataData)
+	toolkit = &Toolkit{
+		data: dataData,
+		cleanup: func() {
+			cacheCleanup()
+			dataCleanup()
+		},
+		userRepo:        userRepo,
+		userAdminRepo:   user.NewUserAdminRepo(dataData, authRepo),
+		userRoleRelRepo: userRoleRelRepo,
+		usernamePolicy: username_policy.NewUsernamePolicyService(
+			usernamepolicyrepo.NewUsernameHistoryRepo(dataData),
+			usernamepolicyrepo.NewUserNameChangeRepo(dataData),
+			userRepo,
+			role.NewUserRoleRelService(userRoleRelRepo, nil),
+			siteInfoRepo,
+			siteinfo_common.NewSiteInfoCommonService(siteInfoRepo),
+		),
+		authService:       auth.NewAuthService(authRepo, authrepo.NewUserSessionRepo(dataData)),
+		questionRepo:      question.NewQuestionRepo(dataData, uniqueIDRepo),
+		answerRepo:        answer.NewAnswerRepo(dataData, uniqueIDRepo, userRankRepo, activityRepo),
+		commentCommonRepo: comment.NewCommentCommonRepo(dataData, uniqueIDRepo),
+		configService:     configService,
+		pluginConfigRepo:  plugin_config.NewPluginConfigRepo(dataData),
+		legalHoldRepo:     legalholdrepo.NewLegalHoldRepo(dataData),
+	}
+	if serviceConf != nil {
+		toolkit.uploadPath = serviceConf.UploadPath
+	}
+	return toolkit, nil
+}
+
+// Close release the database and the cache connections
+func (t *Toolkit) Close() {
+	t.cleanup()
+}
+
+// CreateUser create the active user with the role, the username is used as the display name
+func (t *Toolkit) CreateUser(ctx context.Context, username, email, password, roleName string) (err error) {
+	roleID, ok := Roles[roleName]
+	if !ok {
+		return fmt.Errorf("role %s not support", roleName)
+	}
+	if len(username) == 0 || len(email) == 0 {
+		return fmt.Errorf("username and email are required")
+	}
+	if err = checkPassword(password); err != nil {
+		return err
+	}
+	if err = t.checkUsername(ctx, username); err != nil {
+		return err
+	}
+	if _, exist, err := t.userRepo.GetByEmail(ctx, email); err != nil {
+		return err
+	} else if exist {
+		return fmt.Errorf("email %s already exists", email)
+	}
+	hashPwd, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	userInfo := &entity.User{
+		Username:     username,
+		DisplayName:  username,
+		EMail:        email,
+		Pass:         string(hashPwd),
+		MailStatus:   entity.EmailStatusAvailable,
+		NoticeStatus: 1,
+		Status:       entity.UserStatusAvailable,
+		Rank:         1,
+	}
+	if err = t.userRepo.AddUser(ctx, userInfo); err != nil {
+		return err
+	}
+	return t.userRoleRelRepo.SaveUserRoleRel(ctx, userInfo.ID, roleID)
+}
+
+// SetUserRole set the role of the user, the user has to login again
+func (t *Toolkit) SetUserRole(ctx context.Context, usernameOrEmail, roleName string) (err error) {
+	roleID, ok := Roles[roleName]
+	if !ok {
+		return fmt.Errorf("role %s not support", roleName)
+	}
+	userInfo, err := t.getUser(ctx, usernameOrEmail)
+	if err != nil {
+		return err
+	}
+	if err = t.userRoleRelRepo.SaveUserRoleRel(ctx, userInfo.ID, roleID); err != nil {
+		return err
+	}
+	t.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	return nil
+}
+
+// DeactivateUser suspend the user and log out all the sessions of the user
+func (t *Toolkit) DeactivateUser(ctx context.Context, usernameOrEmail string) (err error) {
+	userInfo, err := t.getUser(ctx, usernameOrEmail)
+	if err != nil {
+		return err
+	}
+	return t.suspendUser(ctx, userInfo)
+}
+
+// ResetUserPassword reset the password of the user and log out all the sessions of the user
+func (t *Toolkit) ResetUserPassword(ctx context.Context, usernameOrEmail, password string) (err error) {
+	if err = checkPassword(password); err != nil {
+		return err
+	}
+	userInfo, err := t.getUser(ctx, usernameOrEmail)
+	if err != nil {
+		return err
+	}
+	hashPwd, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err = t.userRepo.UpdatePass(ctx, userInfo.ID, string(hashPwd)); err != nil {
+		return err
+	}
+	t.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	return nil
+}
+
+// PurgeUserContent suspend the spammer and remove all the questions, answers and comments of the user
+func (t *Toolkit) PurgeUserContent(ctx context.Context, usernameOrEmail string) (err error) {
+	userInfo, err := t.getUser(ctx, usernameOrEmail)
+	if err != nil {
+		return err
+	}
+	if err = t.checkLegalHold(ctx, userInfo); err != nil {
+		return err
+	}
+	if err = t.suspendUser(ctx, userInfo); err != nil {
+		return err
+	}
+	if err = t.questionRepo.RemoveAllUserQuestion(ctx, userInfo.ID); err != nil {
+		return err
+	}
+	if err = t.answerRepo.RemoveAllUserAnswer(ctx, userInfo.ID); err != nil {
+		return err
+	}
+	return t.commentCommonRepo.RemoveAllUserComment(ctx, userInfo.ID)
+}
+
+// FlushCache remove all the cached data, they are loaded from the database again when they are used
+func (t *Toolkit) FlushCache(ctx context.Context) (err error) {
+	return t.data.Cache.Flush(ctx)
+}
+
+// ReindexSearch send all the available questions and answers to the enabled search plugins again,
+// and remove the embeddings of the semantic search, so they are rebuilt when the application runs
+func (t *Toolkit) ReindexSearch(ctx context.Context) (err error) {
+	if err = t.loadPlugins(ctx); err != nil {
+		return err
+	}
+	syncer := search_sync.NewPluginSyncer(t.data)
+	err = plugin.CallSearch(func(search plugin.Search) error {
+		fmt.Printf("reindex search plugin %s\n", search.Info().SlugName)
+		for _, getPage := range []func(ctx context.Context, page, pageSize int) ([]*plugin.SearchContent, error){
+			syncer.GetQuestionsPage, syncer.GetAnswersPage,
+		} {
+			for page := 1; ; page++ {
+				contents, err := getPage(ctx, page, reindexPageSize)
+				if err != nil {
+					return err
+				}
+				for _, content := range contents {
+					if err := search.UpdateContent(ctx, content); err != nil {
+						return err
+					}
+				}
+				if len(contents) < reindexPageSize {
+					break
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return embedding.NewEmbeddingRepo(t.data).RemoveAllEmbeddings(ctx)
+}
+
+// RecountStatistics recount the answers of the questions, the questions and the answers of the users,
+// and the questions of the tags
+func (t *Toolkit) RecountStatistics(ctx context.Context) (err error) {
+	userTable := t.data.DB.Quote(entity.User{}.TableName())
+	sqls := []string{
+		fmt.Sprintf("UPDATE question SET answer_count = "+
+			"(SELECT COUNT(*) FROM answer WHERE answer.question_id = question.id AND answer.status = %d)",
+			entity.AnswerStatusAvailable),
+		fmt.Sprintf("UPDATE %s SET question_count = "+
+			"(SELECT COUNT(*) FROM question WHERE question.user_id = %s.id AND question.status < %d)",
+			userTable, userTable, entity.QuestionStatusDeleted),
+		fmt.Sprintf("UPDATE %s SET answer_count = "+
+			"(SELECT COUNT(*) FROM answer WHERE answer.user_id = %s.id AND answer.status = %d)",
+			userTable, userTable, entity.AnswerStatusAvailable),
+		fmt.Sprintf("UPDATE tag SET question_count = "+
+			"(SELECT COUNT(*) FROM tag_rel WHERE tag_rel.tag_id = tag.id AND tag_rel.status = %d)",
+			entity.TagRelStatusAvailable),
+	}
+	for _, sql := range sqls {
+		if _, err = t.data.DB.Context(ctx).Exec(sql); err != nil {
+			return fmt.Errorf("recount failed: %w", err)
+		}
+	}
+	// the counts are cached with the user and the tag info
+	return t.FlushCache(ctx)
+}
+
+// getUser get the user by the username or the email, the email is tried first if it contains @
+func (t *Toolkit) getUser(ctx context.Context, usernameOrEmail string) (userInfo *entity.User, err error) {
+	var exist bool
+	if strings.Contains(usernameOrEmail, "@") {
+		userInfo, exist, err = t.userRepo.GetByEmail(ctx, usernameOrEmail)
+	} else {
+		userInfo, exist, err = t.userRepo.GetByUsername(ctx, usernameOrEmail)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, fmt.Errorf("user %s not found", usernameOrEmail)
+	}
+	return userInfo, nil
+}
+
+func (t *Toolkit) suspendUser(ctx context.Context, userInfo *entity.User) (err error) {
+	err = t.userAdminRepo.UpdateUserStatus(ctx, userInfo.ID, entity.UserStatusSuspended, userInfo.MailStatus,
+		userInfo.EMail)
+	if err != nil {
+		return err
+	}
+	t.authService.RemoveUserAllTokens(ctx, userInfo.ID)
+	return nil
+}
+
+// checkUsername check the username as the site does when the user signs up, including the username policy
+func (t *Toolkit) checkUsername(ctx context.Context, username string) (err error) {
+	if checker.IsInvalidUsername(username) || checker.IsUsersIgnorePath(username) {
+		return fmt.Errorf("username %s is invalid", username)
+	}
+	if _, exist, err := t.userRepo.GetByUsername(ctx, username); err != nil {
+		return err
+	} else if exist {
+		return fmt.Errorf("username %s already exists", username)
+	}
+	if _, err = t.usernamePolicy.CheckUsername(ctx, "username", username, ""); err != nil {
+		return fmt.Errorf("username %s is not allowed: %w", username, err)
+	}
+	return nil
+}
+
+// checkPassword check the password as the user does when the password is set on the site
+func checkPassword(password string) (err error) {
+	if len(password) < 8 || len(password) > 32 {
+		return fmt.Errorf("the length of the password should be between 8 and 32")
+	}
+	return checker.CheckPassword(password)
+}
+
+// checkLegalHold the content of the user under the legal hold can not be purged, the attempt is recorded
+func (t *Toolkit) checkLegalHold(ctx context.Context, userInfo *entity.User) (err error) {
+	holds, err := t.legalHoldRepo.GetActiveLegalHolds(ctx, []string{userInfo.ID})
+	if err != nil {
+		return err
+	}
+	if len(holds) == 0 {
+		return nil
+	}
+	err = t.legalHoldRepo.AddLegalHoldLog(ctx, &entity.LegalHoldLog{
+		LegalHoldID: holds[0].ID,
+		ObjectID:    userInfo.ID,
+		ObjectType:  constant.UserObjectType,
+		Action:      entity.LegalHoldActionBlock,
+		Process:     entity.LegalHoldProcessCLIPurge,
+	})
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("user %s is under legal hold, release the hold in the admin ui first", userInfo.Username)
+}
+
+// loadPlugins load the status and the config of the plugins, as the application does when it starts
+func (t *Toolkit) loadPlugins(ctx context.Context) (err error) {
+	pluginStatus, err := t.configService.GetStringValue(ctx, constant.PluginStatus)
+	if err != nil {
+		return err
+	}
+	if err = plugin.StatusManager.UnmarshalJSON([]byte(pluginStatus)); err != nil {
+		return err
+	}
+	pluginConfigs, err := t.pluginConfigRepo.GetPluginConfigAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, pluginConfig := range pluginConfigs {
+		err = plugin.CallConfig(func(fn plugin.Config) error {
+			if fn.Info().SlugName == pluginConfig.PluginSlugName {
+				return fn.ConfigReceiver([]byte(pluginConfig.Value))
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("parse plugin config failed: %s %w", pluginConfig.PluginSlugName, err)
+		}
+	}
+	return nil
+}
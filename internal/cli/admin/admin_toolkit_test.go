@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestToolkit(t *testing.T) *Toolkit {
+	dir := t.TempDir()
+	dbConf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(dir, "answer.db")}
+	engine, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	err = migrations.NewMentor(context.TODO(), engine, &migrations.InitNeedUserInputData{
+		Language:      "en_US",
+		SiteName:      "ANSWER",
+		SiteURL:       "http://127.0.0.1:8080/",
+		ContactEmail:  "answer@answer.com",
+		AdminName:     "admin",
+		AdminPassword: "admin123",
+		AdminEmail:    "answer@answer.com",
+	}).InitDB()
+	require.NoError(t, err)
+	require.NoError(t, engine.Close())
+
+	toolkit, err := NewToolkit(dbConf, &data.CacheConf{FilePath: filepath.Join(dir, "cache.db")}, nil)
+	require.NoError(t, err)
+	t.Cleanup(toolkit.Close)
+	return toolkit
+}
+
+func TestToolkit_CreateUser(t *testing.T) {
+	toolkit := newTestToolkit(t)
+	ctx := context.TODO()
+
+	tests := []struct {
+		name     string
+		username string
+		email    string
+		errMsg   string
+	}{
+		{name: "duplicate username", username: "admin", email: "admin2@example.com", errMsg: "username admin already exists"},
+		{name: "invalid username", username: "Jane Doe", email: "jane@example.com", errMsg: "username Jane Doe is invalid"},
+		{name: "users path", username: "settings", email: "settings@example.com", errMsg: "username settings is invalid"},
+		{name: "reserved username", username: "root", email: "root@example.com", errMsg: "username root is not allowed"},
+		{name: "impersonate the admin", username: "adm.in", email: "adm.in@example.com", errMsg: "username adm.in is not allowed"},
+		{name: "duplicate email", username: "jane", email: "answer@answer.com", errMsg: "email answer@answer.com already exists"},
+		{name: "created", username: "jane", email: "jane@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := toolkit.CreateUser(ctx, tt.username, tt.email, "password123", "user")
+			if len(tt.errMsg) > 0 {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.errMsg)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	userInfo, err := toolkit.getUser(ctx, "jane")
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", userInfo.EMail)
+
+	err = toolkit.CreateUser(ctx, "jane", "jane2@example.com", "password123", "user")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "username jane already exists")
+	}
+}
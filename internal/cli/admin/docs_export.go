@@ -0,0 +1,576 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package admin
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+)
+
+const (
+	DocsFormatHTML     = "html"
+	DocsFormatMarkdown = "markdown"
+
+	// docsManifestName records what has been exported, so the next export only renders the changed questions
+	docsManifestName = ".answer-docs.json"
+	docsTemplateDir  = "docs_templates"
+	docsAssetsDir    = "assets"
+)
+
+//go:embed docs_templates
+var docsTemplates embed.FS
+
+// docsUploadReg matches the links of the uploaded files in the content, with or without the site url
+var docsUploadReg = regexp.MustCompile(`(?:https?://[^\s"'()<>]+)?/uploads/([^\s"'()<>?#]+)`)
+
+// DocsExportOptions the options of exporting the accepted questions and answers as static documents
+type DocsExportOptions struct {
+	// Tags the slug names of the tags to export
+	Tags []string
+	// OutputDir the directory of the bundle
+	OutputDir string
+	// Format html or markdown
+	Format string
+	// TemplateDir the directory of the custom templates, the files in it override the default ones
+	// with the same name, and its assets directory is copied into the bundle
+	TemplateDir string
+	// Full ignore the manifest of the last export and render all the documents again
+	Full bool
+}
+
+// DocsExportResult the summary of the export
+type DocsExportResult struct {
+	Written int
+	Skipped int
+	Removed int
+}
+
+type docsManifest struct {
+	Format    string                        `json:"format"`
+	Templates string                        `json:"templates"`
+	Pages     []string                      `json:"pages"`
+	Questions map[string]*docsManifestEntry `json:"questions"`
+}
+
+type docsManifestEntry struct {
+	Version string `json:"version"`
+	File    string `json:"file"`
+}
+
+type docsSite struct {
+	Name string
+	URL  string
+}
+
+type docsTag struct {
+	SlugName    string
+	DisplayName string
+	Path        string
+	Questions   []*docsQuestion
+}
+
+type docsQuestion struct {
+	ID        string
+	Title     string
+	Path      string
+	URL       string
+	Author    string
+	CreatedAt string
+	Tags      []*docsTag
+	HTML      htmltemplate.HTML
+	Markdown  string
+	Answer    *docsAnswer
+	version   string
+}
+
+type docsAnswer struct {
+	Author    string
+	CreatedAt string
+	HTML      htmltemplate.HTML
+	Markdown  string
+}
+
+// docsPage the data of the templates
+type docsPage struct {
+	Site        *docsSite
+	Root        string
+	Title       string
+	GeneratedAt string
+	Tags        []*docsTag
+	Tag         *docsTag
+	Question    *docsQuestion
+	Answer      *docsAnswer
+}
+
+type docsTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// docsExporter renders one bundle
+type docsExporter struct {
+	t         *Toolkit
+	opts      *DocsExportOptions
+	ext       string
+	site      *docsSite
+	templates map[string]docsTemplate
+	uploads   map[string]bool
+	pages     []string
+	result    *DocsExportResult
+}
+
+// ExportDocs render the questions with the accepted answers under the tags into a static html or markdown bundle.
+// The unchanged questions since the last export are skipped unless the full option is set.
+func (t *Toolkit) ExportDocs(ctx context.Context, opts *DocsExportOptions) (result *DocsExportResult, err error) {
+	if len(opts.Tags) == 0 {
+		return nil, fmt.Errorf("at least one tag is required")
+	}
+	if len(opts.OutputDir) == 0 {
+		return nil, fmt.Errorf("output directory is required")
+	}
+	e := &docsExporter{t: t, opts: opts, uploads: make(map[string]bool), result: &DocsExportResult{}}
+	switch opts.Format {
+	case DocsFormatHTML, "":
+		opts.Format, e.ext = DocsFormatHTML, ".html"
+	case DocsFormatMarkdown, "md":
+		opts.Format, e.ext = DocsFormatMarkdown, ".md"
+	default:
+		return nil, fmt.Errorf("format %s not support", opts.Format)
+	}
+	fingerprint, err := e.loadTemplates()
+	if err != nil {
+		return nil, err
+	}
+	if e.site, err = t.getDocsSite(ctx); err != nil {
+		return nil, err
+	}
+	tags, questions, err := t.getDocsContent(ctx, opts.Tags, e.site)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return nil, err
+	}
+
+	manifestPath := filepath.Join(opts.OutputDir, docsManifestName)
+	oldManifest := readDocsManifest(manifestPath)
+	if opts.Full || oldManifest.Format != opts.Format || oldManifest.Templates != fingerprint {
+		oldManifest.Questions = make(map[string]*docsManifestEntry)
+	}
+	newManifest := &docsManifest{Format: opts.Format, Templates: fingerprint, Questions: make(map[string]*docsManifestEntry)}
+
+	generatedAt := time.Now().Format(time.RFC1123)
+	for _, tag := range tags {
+		tag.Path = "tags/" + tag.SlugName + e.ext
+	}
+	for _, q := range questions {
+		q.Path = "questions/" + q.ID + "-" + htmltext.UrlTitle(q.Title) + e.ext
+		// the uploads are collected on every export, so the assets of the skipped questions are kept
+		q.HTML = htmltemplate.HTML(e.rewriteUploads(string(q.HTML), "../"))
+		q.Markdown = e.rewriteUploads(q.Markdown, "../")
+		q.Answer.HTML = htmltemplate.HTML(e.rewriteUploads(string(q.Answer.HTML), "../"))
+		q.Answer.Markdown = e.rewriteUploads(q.Answer.Markdown, "../")
+
+		newManifest.Questions[q.ID] = &docsManifestEntry{Version: q.version, File: q.Path}
+		if old, ok := oldManifest.Questions[q.ID]; ok && old.Version == q.version && old.File == q.Path &&
+			fileExists(filepath.Join(opts.OutputDir, q.Path)) {
+			e.result.Skipped++
+			continue
+		}
+		page := &docsPage{Site: e.site, Root: "../", Title: q.Title, GeneratedAt: generatedAt,
+			Tags: tags, Question: q, Answer: q.Answer}
+		if err = e.render("question", q.Path, page); err != nil {
+			return nil, err
+		}
+	}
+	for _, tag := range tags {
+		e.pages = append(e.pages, tag.Path)
+		page := &docsPage{Site: e.site, Root: "../", Title: tag.DisplayName, GeneratedAt: generatedAt, Tags: tags, Tag: tag}
+		if err = e.render("tag", tag.Path, page); err != nil {
+			return nil, err
+		}
+	}
+	e.pages = append(e.pages, "index"+e.ext)
+	page := &docsPage{Site: e.site, Title: e.site.Name, GeneratedAt: generatedAt, Tags: tags}
+	if err = e.render("index", "index"+e.ext, page); err != nil {
+		return nil, err
+	}
+	newManifest.Pages = e.pages
+
+	// remove the documents that are no longer exported or have been renamed
+	lastManifest := readDocsManifest(manifestPath)
+	stale := make([]string, 0)
+	for id, old := range lastManifest.Questions {
+		if cur, ok := newManifest.Questions[id]; !ok || cur.File != old.File {
+			stale = append(stale, old.File)
+		}
+	}
+	for _, old := range lastManifest.Pages {
+		if !containsString(e.pages, old) {
+			stale = append(stale, old)
+		}
+	}
+	for _, file := range stale {
+		if err = os.Remove(filepath.Join(opts.OutputDir, filepath.FromSlash(file))); err == nil {
+			e.result.Removed++
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	if err = e.copyAssets(); err != nil {
+		return nil, err
+	}
+	content, _ := json.MarshalIndent(newManifest, "", "  ")
+	if err = os.WriteFile(manifestPath, content, 0o644); err != nil {
+		return nil, err
+	}
+	return e.result, nil
+}
+
+// getDocsSite get the name and the url of the site
+func (t *Toolkit) getDocsSite(ctx context.Context) (site *docsSite, err error) {
+	general := &schema.SiteGeneralResp{}
+	if err = t.getSiteInfo(ctx, constant.SiteTypeGeneral, general); err != nil {
+		return nil, err
+	}
+	return &docsSite{Name: general.Name, URL: strings.TrimSuffix(general.SiteUrl, "/")}, nil
+}
+
+// getSiteInfo read the site setting directly, the site info service is not available in the toolkit
+func (t *Toolkit) getSiteInfo(ctx context.Context, siteType string, info interface{}) (err error) {
+	siteInfo := &entity.SiteInfo{}
+	exist, err := t.data.DB.Context(ctx).Where("type = ?", siteType).Get(siteInfo)
+	if err != nil || !exist {
+		return err
+	}
+	return json.Unmarshal([]byte(siteInfo.Content), info)
+}
+
+// getDocsContent get the tags and the questions with the accepted answers under them
+func (t *Toolkit) getDocsContent(ctx context.Context, slugNames []string, site *docsSite) (
+	tags []*docsTag, questions []*docsQuestion, err error) {
+	seo := &schema.SiteSeoResp{}
+	if err = t.getSiteInfo(ctx, constant.SiteTypeSeo, seo); err != nil {
+		return nil, nil, err
+	}
+
+	tagList := make([]*entity.Tag, 0)
+	err = t.data.DB.Context(ctx).In("slug_name", slugNames).
+		Where("status = ?", entity.TagStatusAvailable).Find(&tagList)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(tagList) == 0 {
+		return nil, nil, fmt.Errorf("tags %s not found", strings.Join(slugNames, ","))
+	}
+	sort.Slice(tagList, func(i, j int) bool { return tagList[i].SlugName < tagList[j].SlugName })
+	tagMapping := make(map[string]*docsTag, len(tagList))
+	tagIDs := make([]string, 0, len(tagList))
+	for _, tag := range tagList {
+		displayName := tag.DisplayName
+		if len(displayName) == 0 {
+			displayName = tag.SlugName
+		}
+		docTag := &docsTag{SlugName: tag.SlugName, DisplayName: displayName}
+		tags = append(tags, docTag)
+		tagMapping[tag.ID] = docTag
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	tagRelList := make([]*entity.TagRel, 0)
+	err = t.data.DB.Context(ctx).In("tag_id", tagIDs).
+		Where("status = ?", entity.TagRelStatusAvailable).Find(&tagRelList)
+	if err != nil {
+		return nil, nil, err
+	}
+	questionIDs := make([]string, 0, len(tagRelList))
+	for _, rel := range tagRelList {
+		questionIDs = append(questionIDs, rel.ObjectID)
+	}
+	if len(questionIDs) == 0 {
+		return tags, nil, nil
+	}
+
+	questionList := make([]*entity.Question, 0)
+	err = t.data.DB.Context(ctx).In("id", questionIDs).
+		In("status", entity.QuestionStatusAvailable, entity.QuestionStatusClosed).
+		Where("`show` = ? AND accepted_answer_id <> ?", entity.QuestionShow, "0").
+		Asc("created_at").Find(&questionList)
+	if err != nil {
+		return nil, nil, err
+	}
+	answerIDs := make([]string, 0, len(questionList))
+	userIDs := make([]string, 0, len(questionList)*2)
+	for _, q := range questionList {
+		answerIDs = append(answerIDs, q.AcceptedAnswerID)
+		userIDs = append(userIDs, q.UserID)
+	}
+	answerList := make([]*entity.Answer, 0)
+	err = t.data.DB.Context(ctx).In("id", answerIDs).
+		Where("status = ?", entity.AnswerStatusAvailable).Find(&answerList)
+	if err != nil {
+		return nil, nil, err
+	}
+	answerMapping := make(map[string]*entity.Answer, len(answerList))
+	for _, a := range answerList {
+		answerMapping[a.ID] = a
+		userIDs = append(userIDs, a.UserID)
+	}
+	userList := make([]*entity.User, 0)
+	if err = t.data.DB.Context(ctx).In("id", userIDs).Find(&userList); err != nil {
+		return nil, nil, err
+	}
+	userMapping := make(map[string]string, len(userList))
+	for _, u := range userList {
+		userMapping[u.ID] = u.DisplayName
+	}
+
+	questionMapping := make(map[string]*docsQuestion, len(questionList))
+	for _, q := range questionList {
+		a, ok := answerMapping[q.AcceptedAnswerID]
+		if !ok {
+			continue
+		}
+		docQuestion := &docsQuestion{
+			ID:        q.ID,
+			Title:     q.Title,
+			URL:       display.QuestionURL(seo.Permalink, site.URL, q.ID, q.Title),
+			Author:    userMapping[q.UserID],
+			CreatedAt: q.CreatedAt.Format("2006-01-02"),
+			HTML:      htmltemplate.HTML(q.ParsedText),
+			Markdown:  q.OriginalText,
+			Answer: &docsAnswer{
+				Author:    userMapping[a.UserID],
+				CreatedAt: a.CreatedAt.Format("2006-01-02"),
+				HTML:      htmltemplate.HTML(a.ParsedText),
+				Markdown:  a.OriginalText,
+			},
+			version: fmt.Sprintf("%d-%s-%d", q.UpdatedAt.Unix(), a.ID, a.UpdatedAt.Unix()),
+		}
+		questions = append(questions, docQuestion)
+		questionMapping[q.ID] = docQuestion
+	}
+	for _, rel := range tagRelList {
+		q, ok := questionMapping[rel.ObjectID]
+		if !ok {
+			continue
+		}
+		tag := tagMapping[rel.TagID]
+		tag.Questions = append(tag.Questions, q)
+		q.Tags = append(q.Tags, tag)
+		// the tags of the question are shown in its document, so they change its version too
+		q.version += "-" + tag.SlugName
+	}
+	return tags, questions, nil
+}
+
+// loadTemplates parse the templates of the format and return the fingerprint of them
+func (e *docsExporter) loadTemplates() (fingerprint string, err error) {
+	hash := sha256.New()
+	e.templates = make(map[string]docsTemplate)
+	suffix := ".md.tmpl"
+	if e.opts.Format == DocsFormatHTML {
+		suffix = ".html.tmpl"
+	}
+	var layout []byte
+	if e.opts.Format == DocsFormatHTML {
+		if layout, err = e.readTemplate("layout" + suffix); err != nil {
+			return "", err
+		}
+		hash.Write(layout)
+	}
+	for _, name := range []string{"index", "tag", "question"} {
+		content, err := e.readTemplate(name + suffix)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(content)
+		if e.opts.Format == DocsFormatHTML {
+			tpl, err := htmltemplate.New(name).Parse(string(layout))
+			if err == nil {
+				_, err = tpl.Parse(string(content))
+			}
+			if err != nil {
+				return "", fmt.Errorf("parse template %s failed: %w", name+suffix, err)
+			}
+			e.templates[name] = tpl
+		} else {
+			tpl, err := template.New(name).Parse(string(content))
+			if err != nil {
+				return "", fmt.Errorf("parse template %s failed: %w", name+suffix, err)
+			}
+			e.templates[name] = tpl
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readTemplate read the custom template first, then the default one
+func (e *docsExporter) readTemplate(name string) (content []byte, err error) {
+	if len(e.opts.TemplateDir) > 0 {
+		content, err = os.ReadFile(filepath.Join(e.opts.TemplateDir, name))
+		if err == nil || !os.IsNotExist(err) {
+			return content, err
+		}
+	}
+	return docsTemplates.ReadFile(docsTemplateDir + "/" + name)
+}
+
+func (e *docsExporter) render(name, filePath string, page *docsPage) (err error) {
+	var buf strings.Builder
+	if err = e.templates[name].Execute(&buf, page); err != nil {
+		return fmt.Errorf("render %s failed: %w", filePath, err)
+	}
+	fullPath := filepath.Join(e.opts.OutputDir, filepath.FromSlash(filePath))
+	if err = os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return err
+	}
+	if err = os.WriteFile(fullPath, []byte(buf.String()), 0o644); err != nil {
+		return err
+	}
+	e.result.Written++
+	return nil
+}
+
+// rewriteUploads point the links of the uploaded files to the copies in the bundle
+func (e *docsExporter) rewriteUploads(content, root string) string {
+	return docsUploadReg.ReplaceAllStringFunc(content, func(link string) string {
+		match := docsUploadReg.FindStringSubmatch(link)
+		if !strings.HasPrefix(link, "/") && !strings.HasPrefix(link, e.site.URL+"/") {
+			return link
+		}
+		subPath := filepath.ToSlash(filepath.Clean("/" + match[1]))[1:]
+		e.uploads[subPath] = true
+		return root + docsAssetsDir + "/uploads/" + subPath
+	})
+}
+
+// copyAssets copy the assets of the templates and the uploaded files referenced by the documents
+func (e *docsExporter) copyAssets() (err error) {
+	assetsDir := filepath.Join(e.opts.OutputDir, docsAssetsDir)
+	if e.opts.Format == DocsFormatHTML {
+		defaultAssets, _ := fs.Sub(docsTemplates, docsTemplateDir+"/"+docsAssetsDir)
+		if err = copyDocsFS(defaultAssets, assetsDir); err != nil {
+			return err
+		}
+	}
+	if len(e.opts.TemplateDir) > 0 {
+		customAssets := filepath.Join(e.opts.TemplateDir, docsAssetsDir)
+		if fileExists(customAssets) {
+			if err = copyDocsFS(os.DirFS(customAssets), assetsDir); err != nil {
+				return err
+			}
+		}
+	}
+	if len(e.t.uploadPath) == 0 {
+		return nil
+	}
+	for subPath := range e.uploads {
+		src := filepath.Join(e.t.uploadPath, filepath.FromSlash(subPath))
+		dst := filepath.Join(assetsDir, "uploads", filepath.FromSlash(subPath))
+		srcInfo, err := os.Stat(src)
+		if err != nil {
+			// the file may have been removed from the site, the link is kept broken as it is on the site
+			continue
+		}
+		if dstInfo, err := os.Stat(dst); err == nil && dstInfo.Size() == srcInfo.Size() &&
+			!dstInfo.ModTime().Before(srcInfo.ModTime()) {
+			continue
+		}
+		if err = copyDocsFile(os.DirFS(filepath.Dir(src)), filepath.Base(src), dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyDocsFS(src fs.FS, dstDir string) (err error) {
+	return fs.WalkDir(src, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return copyDocsFile(src, p, filepath.Join(dstDir, filepath.FromSlash(p)))
+	})
+}
+
+func copyDocsFile(src fs.FS, name, dst string) (err error) {
+	in, err := src.Open(name)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	if err = os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func readDocsManifest(manifestPath string) (manifest *docsManifest) {
+	manifest = &docsManifest{}
+	if content, err := os.ReadFile(manifestPath); err == nil {
+		_ = json.Unmarshal(content, manifest)
+	}
+	if manifest.Questions == nil {
+		manifest.Questions = make(map[string]*docsManifestEntry)
+	}
+	return manifest
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func fileExists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
@@ -35,6 +35,7 @@ const (
 	DefaultConfigFileName                  = "config.yaml"
 	DefaultCacheFileName                   = "cache.db"
 	DefaultReservedUsernamesConfigFileName = "reserved-usernames.json"
+	DefaultDisposableEmailDomainsFileName  = "disposable-email-domains.json"
 )
 
 var (
@@ -20,23 +20,36 @@
 package controller
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
 )
 
 type ActivityController struct {
 	activityService *activity.ActivityService
+	rankService     *rank.RankService
 }
 
 // NewActivityController new activity controller.
 func NewActivityController(
-	activityService *activity.ActivityService) *ActivityController {
-	return &ActivityController{activityService: activityService}
+	activityService *activity.ActivityService,
+	rankService *rank.RankService,
+) *ActivityController {
+	return &ActivityController{
+		activityService: activityService,
+		rankService:     rankService,
+	}
 }
 
 // GetObjectTimeline get object timeline
@@ -47,7 +60,7 @@ func NewActivityController(
 // @Param object_id query string false "object id"
 // @Param tag_slug_name query string false "tag slug name"
 // @Param object_type query string false "object type" Enums(question, answer, tag)
-// @Param show_vote query boolean false "is show vote"
+// @Param show_vote query boolean false "is show vote, only the user who can view voters can see the votes"
 // @Success 200 {object} handler.RespBody{data=schema.GetObjectTimelineResp}
 // @Router /answer/api/v1/activity/timeline [get]
 func (ac *ActivityController) GetObjectTimeline(ctx *gin.Context) {
@@ -61,6 +74,14 @@ func (ac *ActivityController) GetObjectTimeline(ctx *gin.Context) {
 	if userInfo := middleware.GetUserInfoFromContext(ctx); userInfo != nil {
 		req.IsAdmin = userInfo.RoleID == role.RoleAdminID
 	}
+	if req.ShowVote {
+		can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.VoteViewVoters, "")
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+		req.ShowVote = can
+	}
 
 	resp, err := ac.activityService.GetObjectTimeline(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -85,3 +106,50 @@ func (ac *ActivityController) GetObjectTimelineDetail(ctx *gin.Context) {
 	resp, err := ac.activityService.GetObjectTimelineDetail(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// ExportObjectTimeline export object timeline
+// @Summary export object timeline
+// @Description export the complete timeline of the object for audit, only admin and moderator can export it
+// @Tags Comment
+// @Produce json
+// @Produce text/csv
+// @Security ApiKeyAuth
+// @Param object_id query string true "object id"
+// @Param format query string false "export format" Enums(json, csv)
+// @Param fields query string false "the fields to export separated by comma"
+// @Param show_vote query boolean false "is show vote, only the user who can view voters can see the votes"
+// @Success 200 {object} handler.RespBody{data=schema.ExportObjectTimelineResp}
+// @Router /answer/api/v1/activity/timeline/export [get]
+func (ac *ActivityController) ExportObjectTimeline(ctx *gin.Context) {
+	req := &schema.ExportObjectTimelineReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+	if req.ShowVote {
+		can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.VoteViewVoters, "")
+		if err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+		req.ShowVote = can
+	}
+
+	resp, err := ac.activityService.ExportObjectTimeline(ctx, req)
+	if err != nil || req.Format != schema.TimelineExportFormatCSV {
+		handler.HandleResponse(ctx, err, resp)
+		return
+	}
+	data, err := activity.FormatObjectTimelineCSV(resp)
+	if err != nil {
+		handler.HandleResponse(ctx, errors.InternalServer(reason.UnknownError).WithError(err).WithStack(), nil)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=timeline-%s.csv", req.ObjectID))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AIAssistantController ai assistant controller
+type AIAssistantController struct {
+	aiAssistantService *ai_assistant.AIAssistantService
+	rankService        *rank.RankService
+}
+
+// NewAIAssistantController new controller
+func NewAIAssistantController(
+	aiAssistantService *ai_assistant.AIAssistantService,
+	rankService *rank.RankService,
+) *AIAssistantController {
+	return &AIAssistantController{
+		aiAssistantService: aiAssistantService,
+		rankService:        rankService,
+	}
+}
+
+// GenerateAnswerDraft generate answer draft
+// @Summary generate answer draft
+// @Description generate the answer draft of the question, the content is ai generated and never saved directly
+// @Tags AI
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AIAssistantReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.AIAssistantResp}
+// @Router /answer/api/v1/ai/answer-draft [post]
+func (ac *AIAssistantController) GenerateAnswerDraft(ctx *gin.Context) {
+	req := &schema.AIAssistantReq{}
+	if !ac.bindAndCheckPermission(ctx, req) {
+		return
+	}
+	resp, err := ac.aiAssistantService.GenerateAnswerDraft(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GenerateQuestionSummary generate question summary
+// @Summary generate question summary
+// @Description generate the summary of the question and its answers, the content is ai generated
+// @Tags AI
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AIAssistantReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.AIAssistantResp}
+// @Router /answer/api/v1/ai/question-summary [post]
+func (ac *AIAssistantController) GenerateQuestionSummary(ctx *gin.Context) {
+	req := &schema.AIAssistantReq{}
+	if !ac.bindAndCheckPermission(ctx, req) {
+		return
+	}
+	resp, err := ac.aiAssistantService.GenerateQuestionSummary(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// bindAndCheckPermission bind the request and check the rank of the user, return false if the request is handled
+func (ac *AIAssistantController) bindAndCheckPermission(ctx *gin.Context, req *schema.AIAssistantReq) bool {
+	if handler.BindAndCheck(ctx, req) {
+		return false
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	can, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.AIAssist, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return false
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return false
+	}
+	return true
+}
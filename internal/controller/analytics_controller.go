@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/analytics"
+	"github.com/apache/incubator-answer/internal/service/search_log"
+	"github.com/gin-gonic/gin"
+)
+
+// AnalyticsController site analytics controller
+type AnalyticsController struct {
+	analyticsService *analytics.AnalyticsService
+	searchLogService *search_log.SearchLogService
+}
+
+// NewAnalyticsController new controller
+func NewAnalyticsController(
+	analyticsService *analytics.AnalyticsService,
+	searchLogService *search_log.SearchLogService,
+) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+		searchLogService: searchLogService,
+	}
+}
+
+// GetSiteAnalytics get site analytics
+// @Summary get site analytics
+// @Description get daily/weekly/monthly active users, signups, questions, answers, acceptance rate, top tags and zero result search terms in the time range
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param range query string false "time range" Enums(week, month, quarter, year)
+// @Router /answer/admin/api/analytics [get]
+// @Success 200 {object} handler.RespBody{data=schema.GetSiteAnalyticsResp}
+func (ac *AnalyticsController) GetSiteAnalytics(ctx *gin.Context) {
+	req := &schema.GetSiteAnalyticsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.analyticsService.GetSiteAnalytics(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetSearchReport get search report
+// @Summary get search report
+// @Description get the top search queries and the queries without any result in the time range
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param range query string false "time range" Enums(week, month, quarter, year)
+// @Param size query int false "the number of queries in each list"
+// @Router /answer/admin/api/analytics/search [get]
+// @Success 200 {object} handler.RespBody{data=schema.GetSearchReportResp}
+func (ac *AnalyticsController) GetSearchReport(ctx *gin.Context) {
+	req := &schema.GetSearchReportReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.searchLogService.GetSearchReport(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/announcement"
+	"github.com/gin-gonic/gin"
+)
+
+// AnnouncementController announcement controller
+type AnnouncementController struct {
+	announcementService *announcement.AnnouncementService
+}
+
+// NewAnnouncementController new controller
+func NewAnnouncementController(announcementService *announcement.AnnouncementService) *AnnouncementController {
+	return &AnnouncementController{announcementService: announcementService}
+}
+
+// GetAnnouncementList get announcement list
+// @Summary get announcement list
+// @Description get all announcements, including the disabled and expired ones
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetAnnouncementResp}
+// @Router /answer/admin/api/announcements [get]
+func (ac *AnnouncementController) GetAnnouncementList(ctx *gin.Context) {
+	resp, err := ac.announcementService.GetAnnouncementList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddAnnouncement add announcement
+// @Summary add announcement
+// @Description add site-wide announcement banner
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddAnnouncementReq true "announcement"
+// @Success 200 {object} handler.RespBody{data=schema.AddAnnouncementResp}
+// @Router /answer/admin/api/announcement [post]
+func (ac *AnnouncementController) AddAnnouncement(ctx *gin.Context) {
+	req := &schema.AddAnnouncementReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := ac.announcementService.AddAnnouncement(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAnnouncement update announcement
+// @Summary update announcement
+// @Description update site-wide announcement banner
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateAnnouncementReq true "announcement"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/announcement [put]
+func (ac *AnnouncementController) UpdateAnnouncement(ctx *gin.Context) {
+	req := &schema.UpdateAnnouncementReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := ac.announcementService.UpdateAnnouncement(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveAnnouncement remove announcement
+// @Summary remove announcement
+// @Description remove site-wide announcement banner
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveAnnouncementReq true "announcement"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/announcement [delete]
+func (ac *AnnouncementController) RemoveAnnouncement(ctx *gin.Context) {
+	req := &schema.RemoveAnnouncementReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	err := ac.announcementService.RemoveAnnouncement(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetActiveAnnouncements get active announcements
+// @Summary get active announcements
+// @Description get the announcements which should be shown to the current user now, the dismissed ones are excluded
+// @Tags Announcement
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetActiveAnnouncementResp}
+// @Router /answer/api/v1/announcements [get]
+func (ac *AnnouncementController) GetActiveAnnouncements(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ac.announcementService.GetActiveAnnouncements(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// DismissAnnouncement dismiss announcement
+// @Summary dismiss announcement
+// @Description dismiss the announcement, it will not be shown to the current user again
+// @Tags Announcement
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.DismissAnnouncementReq true "announcement"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/announcement/dismiss [put]
+func (ac *AnnouncementController) DismissAnnouncement(ctx *gin.Context) {
+	req := &schema.DismissAnnouncementReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := ac.announcementService.DismissAnnouncement(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
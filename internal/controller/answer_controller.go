@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -32,7 +33,11 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	"github.com/apache/incubator-answer/internal/service/new_contributor"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/question_share"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -47,6 +52,10 @@ type AnswerController struct {
 	actionService         *action.CaptchaService
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
 	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	contentFilterService  *content_filter.ContentFilterService
+	questionShareService  *question_share.QuestionShareService
+	newContributorService *new_contributor.NewContributorService
+	contentLimitService   *content_limit.ContentLimitService
 }
 
 // NewAnswerController new controller
@@ -56,6 +65,10 @@ func NewAnswerController(
 	actionService *action.CaptchaService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	contentFilterService *content_filter.ContentFilterService,
+	questionShareService *question_share.QuestionShareService,
+	newContributorService *new_contributor.NewContributorService,
+	contentLimitService *content_limit.ContentLimitService,
 ) *AnswerController {
 	return &AnswerController{
 		answerService:         answerService,
@@ -63,6 +76,10 @@ func NewAnswerController(
 		actionService:         actionService,
 		siteInfoCommonService: siteInfoCommonService,
 		rateLimitMiddleware:   rateLimitMiddleware,
+		contentFilterService:  contentFilterService,
+		questionShareService:  questionShareService,
+		newContributorService: newContributorService,
+		contentLimitService:   contentLimitService,
 	}
 }
 
@@ -241,6 +258,12 @@ func (ac *AnswerController) Add(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if !isAdmin {
+		if err = ac.newContributorService.CheckPostLimit(ctx, req.UserID, constant.AnswerObjectType); err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+	}
 
 	write, err := ac.siteInfoCommonService.GetSiteWrite(ctx)
 	if err != nil {
@@ -260,6 +283,15 @@ func (ac *AnswerController) Add(ctx *gin.Context) {
 		}
 	}
 
+	if err = ac.contentFilterService.FilterAnswerAdd(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := ac.contentLimitService.CheckAnswer(ctx, req.UserID, req.Content); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
+
 	req.UserAgent = ctx.GetHeader("User-Agent")
 	req.IP = ctx.ClientIP()
 
@@ -337,14 +369,23 @@ func (ac *AnswerController) Update(ctx *gin.Context) {
 		}
 	}
 
-	objectOwner := ac.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
-	req.NoNeedReview = canList[1] || objectOwner
+	objectEditor := ac.rankService.CheckOperationObjectEditor(ctx, req.UserID, req.ID)
+	req.CanEdit = canList[0] || objectEditor
+	req.NoNeedReview = canList[1] || objectEditor
 	if !req.CanEdit {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
 
+	if err = ac.contentFilterService.FilterAnswerUpdate(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := ac.contentLimitService.CheckAnswer(ctx, req.UserID, req.Content); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
+
 	_, err = ac.answerService.Update(ctx, req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -383,18 +424,26 @@ func (ac *AnswerController) AnswerList(ctx *gin.Context) {
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
 	req.QuestionID = uid.DeShortID(req.QuestionID)
 
-	canList, err := ac.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
-		permission.AnswerEdit,
-		permission.AnswerDelete,
-		permission.AnswerUnDelete,
-	})
+	if err := ac.rankService.SetAnswerListPermission(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	canHide, err := ac.rankService.CheckOperationPermission(ctx, req.UserID, permission.QuestionHide, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	visible, err := ac.questionShareService.CheckQuestionVisible(ctx, req.QuestionID, req.UserID,
+		ctx.Query(schema.QuestionShareTokenParam), canHide)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	req.CanEdit = canList[0]
-	req.CanDelete = canList[1]
-	req.CanRecover = canList[2]
+	if !visible {
+		handler.HandleResponse(ctx, errors.NotFound(reason.QuestionNotFound), nil)
+		return
+	}
 
 	list, count, err := ac.answerService.SearchList(ctx, req)
 	if err != nil {
@@ -440,6 +489,29 @@ func (ac *AnswerController) Accepted(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// FlagAnswerOutdated flag the answer as outdated
+// @Summary flag the answer as outdated
+// @Description flag the answer as outdated or cancel the flag, the answer shows the outdated banner
+// @Description when the flags reach the threshold of the site
+// @Tags api-answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.FlagAnswerOutdatedReq true "FlagAnswerOutdatedReq"
+// @Success 200 {object} handler.RespBody{data=schema.FlagAnswerOutdatedResp}
+// @Router /answer/api/v1/answer/outdated [put]
+func (ac *AnswerController) FlagAnswerOutdated(ctx *gin.Context) {
+	req := &schema.FlagAnswerOutdatedReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := ac.answerService.FlagAnswerOutdated(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // AdminUpdateAnswerStatus update answer status
 // @Summary update answer status
 // @Description update answer status
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
+	"github.com/gin-gonic/gin"
+)
+
+// AnswerVerificationController answer verification controller
+type AnswerVerificationController struct {
+	answerVerificationService *answer_verification.AnswerVerificationService
+}
+
+// NewAnswerVerificationController new controller
+func NewAnswerVerificationController(
+	answerVerificationService *answer_verification.AnswerVerificationService) *AnswerVerificationController {
+	return &AnswerVerificationController{answerVerificationService: answerVerificationService}
+}
+
+// VerifyAnswer verify answer
+// @Summary verify answer
+// @Description verify the answer by the expert of the question's tags, the answer verified again is verified
+// @Description with the current content
+// @Tags Answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.VerifyAnswerReq true "answer"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/verification [post]
+func (ac *AnswerVerificationController) VerifyAnswer(ctx *gin.Context) {
+	req := &schema.VerifyAnswerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := ac.answerVerificationService.VerifyAnswer(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveAnswerVerification remove answer verification
+// @Summary remove answer verification
+// @Description remove the verification of the answer by the expert of the question's tags or the moderators
+// @Tags Answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveAnswerVerificationReq true "answer"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/answer/verification [delete]
+func (ac *AnswerVerificationController) RemoveAnswerVerification(ctx *gin.Context) {
+	req := &schema.RemoveAnswerVerificationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := ac.answerVerificationService.RemoveAnswerVerification(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,235 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/article"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ArticleController article controller
+type ArticleController struct {
+	articleService *article.ArticleService
+	rankService    *rank.RankService
+}
+
+// NewArticleController new controller
+func NewArticleController(
+	articleService *article.ArticleService,
+	rankService *rank.RankService,
+) *ArticleController {
+	return &ArticleController{
+		articleService: articleService,
+		rankService:    rankService,
+	}
+}
+
+// AddArticle add article
+// @Summary add article
+// @Description add knowledge base article, the article has no answers but can link to the canonical questions
+// @Tags Article
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddArticleReq true "article"
+// @Success 200 {object} handler.RespBody{data=schema.AddArticleResp}
+// @Router /answer/api/v1/article [post]
+func (ac *ArticleController) AddArticle(ctx *gin.Context) {
+	req := &schema.AddArticleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.canManage(ctx, req.UserID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := ac.articleService.AddArticle(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateArticle update article
+// @Summary update article
+// @Description update article, only the author and the user who can manage articles can update it
+// @Tags Article
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateArticleReq true "article"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/article [put]
+func (ac *ArticleController) UpdateArticle(ctx *gin.Context) {
+	req := &schema.UpdateArticleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.canManage(ctx, req.UserID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanEdit = can
+
+	err = ac.articleService.UpdateArticle(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveArticle remove article
+// @Summary remove article
+// @Description remove article, only the author and the user who can manage articles can remove it
+// @Tags Article
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveArticleReq true "article"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/article [delete]
+func (ac *ArticleController) RemoveArticle(ctx *gin.Context) {
+	req := &schema.RemoveArticleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.canManage(ctx, req.UserID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanDelete = can
+
+	err = ac.articleService.RemoveArticle(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ConvertAnswerToArticle convert answer to article
+// @Summary convert answer to article
+// @Description convert the well received answer into an article, the answerer is the author of the article
+// @Tags Article
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ConvertAnswerToArticleReq true "answer"
+// @Success 200 {object} handler.RespBody{data=schema.AddArticleResp}
+// @Router /answer/api/v1/answer/article [post]
+func (ac *ArticleController) ConvertAnswerToArticle(ctx *gin.Context) {
+	req := &schema.ConvertAnswerToArticleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.canManage(ctx, req.UserID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanConvert = can
+
+	resp, err := ac.articleService.ConvertAnswerToArticle(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetArticle get article
+// @Summary get article
+// @Description get article detail with the linked questions
+// @Tags Article
+// @Produce json
+// @Param id query string true "article id"
+// @Success 200 {object} handler.RespBody{data=schema.ArticleInfoResp}
+// @Router /answer/api/v1/article/info [get]
+func (ac *ArticleController) GetArticle(ctx *gin.Context) {
+	req := &schema.GetArticleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ID = uid.DeShortID(req.ID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	can, err := ac.canManage(ctx, req.UserID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.CanManage = can
+
+	resp, err := ac.articleService.GetArticle(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetArticlePage get article page
+// @Summary get article page
+// @Description get article page
+// @Tags Article
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param order query string false "order" Enums(newest, views)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ArticlePageResp}}
+// @Router /answer/api/v1/articles/page [get]
+func (ac *ArticleController) GetArticlePage(ctx *gin.Context) {
+	req := &schema.GetArticlePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ac.articleService.GetArticlePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetQuestionArticles get the articles linked to the question
+// @Summary get the articles linked to the question
+// @Description get the articles which take the question as one of their canonical questions
+// @Tags Article
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=[]schema.ArticlePageResp}
+// @Router /answer/api/v1/question/articles [get]
+func (ac *ArticleController) GetQuestionArticles(ctx *gin.Context) {
+	req := &schema.GetQuestionArticlesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+
+	resp, err := ac.articleService.GetQuestionArticles(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+func (ac *ArticleController) canManage(ctx *gin.Context, userID string) (bool, error) {
+	if len(userID) == 0 {
+		return false, nil
+	}
+	return ac.rankService.CheckOperationPermission(ctx, userID, permission.ArticleManage, "")
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/chat_integration"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ChatIntegrationController Slack and Teams command controller
+type ChatIntegrationController struct {
+	chatIntegrationService *chat_integration.ChatIntegrationService
+}
+
+// NewChatIntegrationController new controller
+func NewChatIntegrationController(
+	chatIntegrationService *chat_integration.ChatIntegrationService,
+) *ChatIntegrationController {
+	return &ChatIntegrationController{chatIntegrationService: chatIntegrationService}
+}
+
+// HandleSlackCommand answer the Slack slash command
+// @Summary answer the Slack slash command
+// @Description search the site or get the link to ask a question, like "/answer search kubernetes"
+// @Tags ChatIntegration
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param X-Slack-Request-Timestamp header string true "timestamp"
+// @Param X-Slack-Signature header string true "signature"
+// @Success 200 {object} schema.SlackCommandResp
+// @Router /answer/api/v1/chat/slack/command [post]
+func (cc *ChatIntegrationController) HandleSlackCommand(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	req := &schema.ChatSlackCommandReq{
+		Timestamp: ctx.GetHeader("X-Slack-Request-Timestamp"),
+		Signature: ctx.GetHeader("X-Slack-Signature"),
+		Body:      body,
+	}
+	resp, err := cc.chatIntegrationService.HandleSlackCommand(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// HandleTeamsCommand answer the message of the Teams outgoing webhook
+// @Summary answer the message of the Teams outgoing webhook
+// @Description search the site or get the link to ask a question, like "@Answer search kubernetes"
+// @Tags ChatIntegration
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "HMAC signature"
+// @Success 200 {object} schema.TeamsCommandResp
+// @Router /answer/api/v1/chat/teams/command [post]
+func (cc *ChatIntegrationController) HandleTeamsCommand(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	req := &schema.ChatTeamsCommandReq{
+		Authorization: ctx.GetHeader("Authorization"),
+		Body:          body,
+	}
+	resp, err := cc.chatIntegrationService.HandleTeamsCommand(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
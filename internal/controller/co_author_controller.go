@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/co_author"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// CoAuthorController co-author controller
+type CoAuthorController struct {
+	coAuthorService *co_author.CoAuthorService
+	rankService     *rank.RankService
+}
+
+// NewCoAuthorController new controller
+func NewCoAuthorController(
+	coAuthorService *co_author.CoAuthorService,
+	rankService *rank.RankService,
+) *CoAuthorController {
+	return &CoAuthorController{
+		coAuthorService: coAuthorService,
+		rankService:     rankService,
+	}
+}
+
+// AddCoAuthor add co-author
+// @Summary add co-author
+// @Description add the user as the co-author of the question or answer, only the author, admin and moderator can add it
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddCoAuthorReq true "co-author"
+// @Success 200 {object} handler.RespBody{data=schema.UserBasicInfo}
+// @Router /answer/api/v1/post/co-author [post]
+func (cc *CoAuthorController) AddCoAuthor(ctx *gin.Context) {
+	req := &schema.AddCoAuthorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !middleware.GetUserIsAdminModerator(ctx) &&
+		!cc.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ObjectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := cc.coAuthorService.AddCoAuthor(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveCoAuthor remove co-author
+// @Summary remove co-author
+// @Description remove the co-author of the question or answer, the co-author can remove themselves
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveCoAuthorReq true "co-author"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/post/co-author [delete]
+func (cc *CoAuthorController) RemoveCoAuthor(ctx *gin.Context) {
+	req := &schema.RemoveCoAuthorReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	if req.LoginUserID != req.UserID && !middleware.GetUserIsAdminModerator(ctx) &&
+		!cc.rankService.CheckOperationObjectOwner(ctx, req.LoginUserID, req.ObjectID) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	err := cc.coAuthorService.RemoveCoAuthor(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetCoAuthors get co-authors
+// @Summary get co-authors
+// @Description get the co-authors of the question or answer
+// @Tags Post
+// @Produce json
+// @Param object_id query string true "question or answer id"
+// @Success 200 {object} handler.RespBody{data=[]schema.UserBasicInfo}
+// @Router /answer/api/v1/post/co-authors [get]
+func (cc *CoAuthorController) GetCoAuthors(ctx *gin.Context) {
+	req := &schema.GetCoAuthorsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := cc.coAuthorService.GetCoAuthors(ctx, uid.DeShortID(req.ObjectID))
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -29,6 +30,8 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -39,10 +42,12 @@ import (
 
 // CommentController comment controller
 type CommentController struct {
-	commentService      *comment.CommentService
-	rankService         *rank.RankService
-	actionService       *action.CaptchaService
-	rateLimitMiddleware *middleware.RateLimitMiddleware
+	commentService       *comment.CommentService
+	rankService          *rank.RankService
+	actionService        *action.CaptchaService
+	rateLimitMiddleware  *middleware.RateLimitMiddleware
+	contentFilterService *content_filter.ContentFilterService
+	contentLimitService  *content_limit.ContentLimitService
 }
 
 // NewCommentController new controller
@@ -51,12 +56,16 @@ func NewCommentController(
 	rankService *rank.RankService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	contentFilterService *content_filter.ContentFilterService,
+	contentLimitService *content_limit.ContentLimitService,
 ) *CommentController {
 	return &CommentController{
-		commentService:      commentService,
-		rankService:         rankService,
-		actionService:       actionService,
-		rateLimitMiddleware: rateLimitMiddleware,
+		commentService:       commentService,
+		rankService:          rankService,
+		actionService:        actionService,
+		rateLimitMiddleware:  rateLimitMiddleware,
+		contentFilterService: contentFilterService,
+		contentLimitService:  contentLimitService,
 	}
 }
 
@@ -120,6 +129,17 @@ func (cc *CommentController) AddComment(ctx *gin.Context) {
 		return
 	}
 
+	if err = cc.contentFilterService.FilterCommentAdd(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := cc.contentLimitService.CheckComment(ctx, req.UserID, req.OriginalText); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
+
+	req.UserAgent = ctx.GetHeader("User-Agent")
+	req.IP = ctx.ClientIP()
 	resp, err := cc.commentService.AddComment(ctx, req)
 	if !isAdmin || !linkUrlLimitUser {
 		cc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionComment, req.UserID)
@@ -218,6 +238,15 @@ func (cc *CommentController) UpdateComment(ctx *gin.Context) {
 		}
 	}
 
+	if err = cc.contentFilterService.FilterCommentUpdate(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := cc.contentLimitService.CheckComment(ctx, req.UserID, req.OriginalText); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
+
 	resp, err := cc.commentService.UpdateComment(ctx, req)
 	if !req.IsAdmin || !linkUrlLimitUser {
 		cc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionEdit, req.UserID)
@@ -243,16 +272,10 @@ func (cc *CommentController) GetCommentWithPage(ctx *gin.Context) {
 	}
 	req.ObjectID = uid.DeShortID(req.ObjectID)
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	canList, err := cc.rankService.CheckOperationPermissions(ctx, req.UserID, []string{
-		permission.CommentEdit,
-		permission.CommentDelete,
-	})
-	if err != nil {
+	if err := cc.rankService.SetCommentListPermission(ctx, req); err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	req.CanEdit = canList[0]
-	req.CanDelete = canList[1]
 
 	resp, err := cc.commentService.GetCommentWithPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -305,6 +328,11 @@ func (cc *CommentController) GetComment(ctx *gin.Context) {
 	}
 	req.CanEdit = canList[0]
 	req.CanDelete = canList[1]
+	req.ActionPermissions, err = cc.rankService.GetObjectActionPermissions(ctx, req.UserID, constant.CommentObjectType)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
 
 	resp, err := cc.commentService.GetComment(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
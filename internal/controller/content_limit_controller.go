@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentLimitController content length limits controller
+type ContentLimitController struct {
+	contentLimitService *content_limit.ContentLimitService
+}
+
+// NewContentLimitController new controller
+func NewContentLimitController(contentLimitService *content_limit.ContentLimitService) *ContentLimitController {
+	return &ContentLimitController{contentLimitService: contentLimitService}
+}
+
+// GetContentLimits get the content length limits of the current user
+// @Summary get the content length limits of the current user
+// @Description get the length limits of titles, bodies and comments, the limits of the role of the user are applied
+// @Tags ContentLimit
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=schema.ContentLimitsResp}
+// @Router /answer/api/v1/content-limits [get]
+func (cc *ContentLimitController) GetContentLimits(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := cc.contentLimitService.GetUserContentLimits(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
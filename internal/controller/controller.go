@@ -51,4 +51,39 @@ var ProviderSetController = wire.NewSet(
 	NewCaptchaController,
 	NewMetaController,
 	NewEmbedController,
+	NewAnalyticsController,
+	NewPermalinkController,
+	NewOEmbedController,
+	NewPageController,
+	NewAnnouncementController,
+	NewInvitationController,
+	NewEmailDomainController,
+	NewQuestionAssignmentController,
+	NewArticleController,
+	NewQuestionPollController,
+	NewCoAuthorController,
+	NewLeaderboardController,
+	NewPendingDeletionController,
+	NewQuestionShareController,
+	NewDeadLinkController,
+	NewAIAssistantController,
+	NewTitleQualityController,
+	NewReviewReminderController,
+	NewUserOnboardingController,
+	NewExperimentController,
+	NewConversionController,
+	NewQuestionMergeController,
+	NewMediaProxyController,
+	NewEmailWebhookController,
+	NewInboundQuestionController,
+	NewChatIntegrationController,
+	NewGitHubController,
+	NewJiraController,
+	NewContentLimitController,
+	NewNameChangeController,
+	NewQuestionBatchController,
+	NewQuestionWorkflowController,
+	NewAnswerVerificationController,
+	NewDeltaSyncController,
+	NewEditLockController,
 )
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/conversion"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+// ConversionController comment and answer conversion controller
+type ConversionController struct {
+	conversionService *conversion.ConversionService
+}
+
+// NewConversionController new controller
+func NewConversionController(conversionService *conversion.ConversionService) *ConversionController {
+	return &ConversionController{conversionService: conversionService}
+}
+
+// ConvertCommentToAnswer convert comment to answer
+// @Summary convert comment to answer
+// @Description convert the misplaced comment into an answer of the question, only for the users with the power to convert
+// @Tags api-answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ConvertCommentToAnswerReq true "comment"
+// @Success 200 {object} handler.RespBody{data=schema.ConvertCommentToAnswerResp}
+// @Router /answer/api/v1/comment/answer [post]
+func (cc *ConversionController) ConvertCommentToAnswer(ctx *gin.Context) {
+	req := &schema.ConvertCommentToAnswerReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.conversionService.ConvertCommentToAnswer(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ConvertAnswerToComment convert answer to comment
+// @Summary convert answer to comment
+// @Description convert the misplaced answer into a comment, only for the users with the power to convert
+// @Tags api-answer
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ConvertAnswerToCommentReq true "answer"
+// @Success 200 {object} handler.RespBody{data=schema.ConvertAnswerToCommentResp}
+// @Router /answer/api/v1/answer/comment [post]
+func (cc *ConversionController) ConvertAnswerToComment(ctx *gin.Context) {
+	req := &schema.ConvertAnswerToCommentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.AnswerID = uid.DeShortID(req.AnswerID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := cc.conversionService.ConvertAnswerToComment(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/gin-gonic/gin"
+)
+
+// DeadLinkController dead link controller
+type DeadLinkController struct {
+	deadLinkService *dead_link.DeadLinkService
+}
+
+// NewDeadLinkController new controller
+func NewDeadLinkController(deadLinkService *dead_link.DeadLinkService) *DeadLinkController {
+	return &DeadLinkController{deadLinkService: deadLinkService}
+}
+
+// GetDeadLinkPage get dead link page
+// @Summary get dead link page
+// @Description get the dead links in the questions and answers found by the dead link checker
+// @Tags Report
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetDeadLinkResp}}
+// @Router /answer/api/v1/dead-links/page [get]
+func (dc *DeadLinkController) GetDeadLinkPage(ctx *gin.Context) {
+	req := &schema.GetDeadLinkPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := dc.deadLinkService.GetDeadLinkPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
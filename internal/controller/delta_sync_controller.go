@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/delta_sync"
+	"github.com/gin-gonic/gin"
+)
+
+// DeltaSyncController delta sync controller
+type DeltaSyncController struct {
+	deltaSyncService *delta_sync.DeltaSyncService
+}
+
+// NewDeltaSyncController new controller
+func NewDeltaSyncController(deltaSyncService *delta_sync.DeltaSyncService) *DeltaSyncController {
+	return &DeltaSyncController{deltaSyncService: deltaSyncService}
+}
+
+// GetSyncChanges get the changes since the cursor
+// @Summary get the changes of the questions, answers, comments and notifications since the cursor
+// @Description the offline-first clients keep the next_cursor and request again at once if has_more is true,
+// @Description the deleted or invisible objects are returned with only the id and deleted
+// @Tags Sync
+// @Security ApiKeyAuth
+// @Produce json
+// @Param cursor query string false "the next_cursor of the last response"
+// @Param limit query int false "the max number of the changes"
+// @Success 200 {object} handler.RespBody{data=schema.GetSyncChangesResp}
+// @Router /answer/api/v1/sync [get]
+func (dc *DeltaSyncController) GetSyncChanges(ctx *gin.Context) {
+	req := &schema.GetSyncChangesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := dc.deltaSyncService.GetSyncChanges(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/edit_lock"
+	"github.com/gin-gonic/gin"
+)
+
+// EditLockController edit lock controller
+type EditLockController struct {
+	editLockService *edit_lock.EditLockService
+}
+
+// NewEditLockController new controller
+func NewEditLockController(editLockService *edit_lock.EditLockService) *EditLockController {
+	return &EditLockController{editLockService: editLockService}
+}
+
+// GetEditLock get edit lock
+// @Summary get edit lock
+// @Description get the user who is editing the question or the answer
+// @Tags Question
+// @Produce json
+// @Security ApiKeyAuth
+// @Param object_id query string true "question id or answer id"
+// @Success 200 {object} handler.RespBody{data=schema.EditLockResp}
+// @Router /answer/api/v1/edit-lock [get]
+func (ec *EditLockController) GetEditLock(ctx *gin.Context) {
+	req := &schema.GetEditLockReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ec.editLockService.GetEditLock(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AcquireEditLock acquire edit lock
+// @Summary acquire edit lock
+// @Description acquire or renew the edit lock when the edit form is opened and then every 30 seconds as the heartbeat,
+// @Description the lock expires 2 minutes after the last heartbeat. If the post is being edited by the other user,
+// @Description the editor is returned to warn the user, and the user can take over the lock.
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.EditLockReq true "edit lock"
+// @Success 200 {object} handler.RespBody{data=schema.EditLockResp}
+// @Router /answer/api/v1/edit-lock [post]
+func (ec *EditLockController) AcquireEditLock(ctx *gin.Context) {
+	req := &schema.EditLockReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ec.editLockService.AcquireEditLock(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ReleaseEditLock release edit lock
+// @Summary release edit lock
+// @Description release the edit lock when the edit form is closed or the edit is saved
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReleaseEditLockReq true "edit lock"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/edit-lock [delete]
+func (ec *EditLockController) ReleaseEditLock(ctx *gin.Context) {
+	req := &schema.ReleaseEditLockReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := ec.editLockService.ReleaseEditLock(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
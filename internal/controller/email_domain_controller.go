@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailDomainController email domain controller
+type EmailDomainController struct {
+	emailDomainService *email_domain.EmailDomainService
+}
+
+// NewEmailDomainController new controller
+func NewEmailDomainController(emailDomainService *email_domain.EmailDomainService) *EmailDomainController {
+	return &EmailDomainController{emailDomainService: emailDomainService}
+}
+
+// GetBlockedSignupPage get blocked signup page
+// @Summary get blocked signup page
+// @Description get the signup attempts blocked by the email domain rules
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param domain query string false "email domain"
+// @Param reason query string false "blocked reason" Enums(not_allowed, blocked, disposable)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.BlockedSignupResp}}
+// @Router /answer/admin/api/blocked-signups/page [get]
+func (ec *EmailDomainController) GetBlockedSignupPage(ctx *gin.Context) {
+	req := &schema.GetBlockedSignupPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ec.emailDomainService.GetBlockedSignupPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
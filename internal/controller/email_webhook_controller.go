@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/email_reply"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// inboundEmailMaxMemory the max memory used to parse the inbound email, the attachments beyond it are stored on disk
+const inboundEmailMaxMemory = 10 << 20
+
+// EmailWebhookController email delivery and inbound webhook controller
+type EmailWebhookController struct {
+	emailService      *export.EmailService
+	emailReplyService *email_reply.EmailReplyService
+}
+
+// NewEmailWebhookController new controller
+func NewEmailWebhookController(
+	emailService *export.EmailService,
+	emailReplyService *email_reply.EmailReplyService,
+) *EmailWebhookController {
+	return &EmailWebhookController{
+		emailService:      emailService,
+		emailReplyService: emailReplyService,
+	}
+}
+
+// HandleDeliveryWebhook receive the delivery events of the email provider
+// @Summary receive the delivery events of the email provider
+// @Description the delivery, bounce and complaint events update the email send logs, the hard bounced addresses are suppressed
+// @Tags Email
+// @Accept json
+// @Produce json
+// @Param provider path string true "sendgrid mailgun ses"
+// @Param key query string true "the webhook key of the email config"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/email/webhook/{provider} [post]
+func (ec *EmailWebhookController) HandleDeliveryWebhook(ctx *gin.Context) {
+	body, err := ctx.GetRawData()
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	req := &schema.EmailDeliveryWebhookReq{
+		Provider: ctx.Param("provider"),
+		Key:      ctx.Query("key"),
+		Body:     body,
+	}
+	err = ec.emailService.HandleDeliveryWebhook(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// HandleInboundWebhook receive the reply to the notification email forwarded by the email provider
+// @Summary receive the reply to the notification email
+// @Description the reply sent to the reply address is posted as the answer or the comment of the user
+// @Tags Email
+// @Accept mpfd
+// @Produce json
+// @Param provider path string true "sendgrid mailgun"
+// @Param key query string true "the webhook key of the email config"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/email/inbound/{provider} [post]
+func (ec *EmailWebhookController) HandleInboundWebhook(ctx *gin.Context) {
+	err := ctx.Request.ParseMultipartForm(inboundEmailMaxMemory)
+	if err != nil && err != http.ErrNotMultipart {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError), nil)
+		return
+	}
+	req := &schema.EmailInboundWebhookReq{
+		Provider: ctx.Param("provider"),
+		Key:      ctx.Query("key"),
+		Form:     ctx.Request.PostForm,
+	}
+	err = ec.emailReplyService.HandleInboundEmail(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/experiment"
+	"github.com/gin-gonic/gin"
+)
+
+// ExperimentController experiment controller
+type ExperimentController struct {
+	experimentService *experiment.ExperimentService
+}
+
+// NewExperimentController new controller
+func NewExperimentController(experimentService *experiment.ExperimentService) *ExperimentController {
+	return &ExperimentController{experimentService: experimentService}
+}
+
+// AddExperimentEvent record the exposure or the conversion of the experiment
+// @Summary record the exposure or the conversion of the experiment
+// @Description record the event for the variant assigned to the login user, each event is recorded only once
+// @Tags Experiment
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddExperimentEventReq true "event"
+// @Success 200 {object} handler.RespBody{data=schema.AddExperimentEventResp}
+// @Router /answer/api/v1/experiment/event [post]
+func (ec *ExperimentController) AddExperimentEvent(ctx *gin.Context) {
+	req := &schema.AddExperimentEventReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := ec.experimentService.AddEvent(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
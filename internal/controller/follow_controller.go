@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/user_follow"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/copier"
@@ -31,12 +32,19 @@ import (
 
 // FollowController activity controller
 type FollowController struct {
-	followService *follow.FollowService
+	followService     *follow.FollowService
+	userFollowService *user_follow.UserFollowService
 }
 
 // NewFollowController new controller
-func NewFollowController(followService *follow.FollowService) *FollowController {
-	return &FollowController{followService: followService}
+func NewFollowController(
+	followService *follow.FollowService,
+	userFollowService *user_follow.UserFollowService,
+) *FollowController {
+	return &FollowController{
+		followService:     followService,
+		userFollowService: userFollowService,
+	}
 }
 
 // Follow godoc
@@ -88,3 +96,87 @@ func (fc *FollowController) UpdateFollowTags(ctx *gin.Context) {
 	err := fc.followService.UpdateFollowTags(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// FollowUser follow user or cancel follow
+// @Summary follow user or cancel follow
+// @Description follow user or cancel follow
+// @Tags Activity
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.FollowUserReq true "follow user"
+// @Success 200 {object} handler.RespBody{data=schema.FollowUserResp}
+// @Router /answer/api/v1/follow/user [post]
+func (fc *FollowController) FollowUser(ctx *gin.Context) {
+	req := &schema.FollowUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := fc.userFollowService.FollowUser(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetFollowers get the followers of user
+// @Summary get the followers of user
+// @Description get the followers of user
+// @Tags Activity
+// @Produce json
+// @Param username query string true "username"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.UserBasicInfo}}
+// @Router /answer/api/v1/personal/user/followers [get]
+func (fc *FollowController) GetFollowers(ctx *gin.Context) {
+	req := &schema.GetUserFollowPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := fc.userFollowService.GetFollowerPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetFollowing get the users followed by user
+// @Summary get the users followed by user
+// @Description get the users followed by user
+// @Tags Activity
+// @Produce json
+// @Param username query string true "username"
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.UserBasicInfo}}
+// @Router /answer/api/v1/personal/user/following [get]
+func (fc *FollowController) GetFollowing(ctx *gin.Context) {
+	req := &schema.GetUserFollowPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := fc.userFollowService.GetFollowingPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetFollowingFeed get the recent questions and answers posted by the users you follow
+// @Summary get the recent questions and answers posted by the users you follow
+// @Description get the recent questions and answers posted by the users you follow
+// @Tags Activity
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.FollowingFeedItem}}
+// @Router /answer/api/v1/following/feed [get]
+func (fc *FollowController) GetFollowingFeed(ctx *gin.Context) {
+	req := &schema.GetFollowingFeedReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := fc.userFollowService.GetFollowingFeed(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/github_integration"
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubController GitHub integration controller
+type GitHubController struct {
+	gitHubService *github_integration.GitHubService
+}
+
+// NewGitHubController new controller
+func NewGitHubController(gitHubService *github_integration.GitHubService) *GitHubController {
+	return &GitHubController{gitHubService: gitHubService}
+}
+
+// GetReferences get the GitHub issues and pull requests linked in the post
+// @Summary get the GitHub issues and pull requests linked in the post
+// @Description get the issues and pull requests linked in the question or the answer with their live status
+// @Tags GitHub
+// @Produce json
+// @Param object_id query string true "question or answer id"
+// @Success 200 {object} handler.RespBody{data=[]schema.GitHubReferenceResp}
+// @Router /answer/api/v1/github/references [get]
+func (gc *GitHubController) GetReferences(ctx *gin.Context) {
+	req := &schema.GetGitHubReferencesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := gc.gitHubService.GetReferences(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
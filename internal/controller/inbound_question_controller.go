@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/inbound_question"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// InboundQuestionController inbound question controller
+type InboundQuestionController struct {
+	inboundQuestionService *inbound_question.InboundQuestionService
+}
+
+// NewInboundQuestionController new controller
+func NewInboundQuestionController(
+	inboundQuestionService *inbound_question.InboundQuestionService,
+) *InboundQuestionController {
+	return &InboundQuestionController{inboundQuestionService: inboundQuestionService}
+}
+
+// AddInboundQuestion add the question sent by the external system to the pending queue
+// @Summary add the question sent by the external system to the pending queue
+// @Description the question is published as a question of the sender after a moderator approves it
+// @Tags InboundQuestion
+// @Accept json
+// @Produce json
+// @Param X-Inbound-Key header string true "the api key of the inbound question settings"
+// @Param data body schema.AddInboundQuestionReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.AddInboundQuestionResp}
+// @Router /answer/api/v1/inbound/question [post]
+func (iq *InboundQuestionController) AddInboundQuestion(ctx *gin.Context) {
+	req := &schema.AddInboundQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.APIKey = ctx.GetHeader("X-Inbound-Key")
+	resp, err := iq.inboundQuestionService.AddInboundQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetInboundQuestionPage get the page of the inbound questions
+// @Summary get the page of the inbound questions
+// @Description get the page of the inbound questions with the status, only for the moderators
+// @Tags InboundQuestion
+// @Produce json
+// @Security ApiKeyAuth
+// @Param status query string false "pending approved rejected" Enums(pending, approved, rejected)
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.InboundQuestionResp}}
+// @Router /answer/api/v1/inbound/question/page [get]
+func (iq *InboundQuestionController) GetInboundQuestionPage(ctx *gin.Context) {
+	req := &schema.GetInboundQuestionPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	list, total, err := iq.inboundQuestionService.GetInboundQuestionPage(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, pager.NewPageModel(total, list))
+}
+
+// ReviewInboundQuestion approve or reject the inbound question
+// @Summary approve or reject the inbound question
+// @Description the approved question is published as a question of the sender, only for the moderators
+// @Tags InboundQuestion
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReviewInboundQuestionReq true "review"
+// @Success 200 {object} handler.RespBody{data=schema.ReviewInboundQuestionResp}
+// @Router /answer/api/v1/inbound/question/review [put]
+func (iq *InboundQuestionController) ReviewInboundQuestion(ctx *gin.Context) {
+	req := &schema.ReviewInboundQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := iq.inboundQuestionService.ReviewInboundQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
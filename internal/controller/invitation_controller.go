@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/invitation"
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationController invitation controller
+type InvitationController struct {
+	invitationService *invitation.InvitationService
+}
+
+// NewInvitationController new controller
+func NewInvitationController(invitationService *invitation.InvitationService) *InvitationController {
+	return &InvitationController{invitationService: invitationService}
+}
+
+// AddInvitation add invitation
+// @Summary add invitation
+// @Description add invitation, the non-admin user can invite only if their reputation is high enough
+// @Tags Invitation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddInvitationReq true "invitation"
+// @Success 200 {object} handler.RespBody{data=schema.InvitationResp}
+// @Router /answer/api/v1/invitation [post]
+func (ic *InvitationController) AddInvitation(ctx *gin.Context) {
+	req := &schema.AddInvitationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+
+	resp, err := ic.invitationService.AddInvitation(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetMyInvitationPage get my invitation page
+// @Summary get my invitation page
+// @Description get the invitations created by the current user
+// @Tags Invitation
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.InvitationResp}}
+// @Router /answer/api/v1/invitation/page [get]
+func (ic *InvitationController) GetMyInvitationPage(ctx *gin.Context) {
+	req := &schema.GetInvitationPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := ic.invitationService.GetInvitationPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeInvitation revoke invitation
+// @Summary revoke invitation
+// @Description revoke invitation, the user can only revoke their own invitation
+// @Tags Invitation
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeInvitationReq true "invitation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/invitation [delete]
+func (ic *InvitationController) RevokeInvitation(ctx *gin.Context) {
+	req := &schema.RevokeInvitationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetIsAdminFromContext(ctx)
+
+	err := ic.invitationService.RevokeInvitation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// CheckInvitation check invitation
+// @Summary check invitation
+// @Description check whether the invitation code can be used for registration
+// @Tags Invitation
+// @Produce json
+// @Param code query string true "invitation code"
+// @Success 200 {object} handler.RespBody{data=schema.CheckInvitationResp}
+// @Router /answer/api/v1/invitation [get]
+func (ic *InvitationController) CheckInvitation(ctx *gin.Context) {
+	req := &schema.CheckInvitationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ic.invitationService.CheckInvitation(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetInvitationPage get invitation page
+// @Summary get invitation page
+// @Description get all invitations with their inviter
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.InvitationResp}}
+// @Router /answer/admin/api/invitation/page [get]
+func (ic *InvitationController) GetInvitationPage(ctx *gin.Context) {
+	req := &schema.GetInvitationPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ic.invitationService.GetInvitationPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AdminRevokeInvitation revoke invitation by admin
+// @Summary revoke invitation by admin
+// @Description revoke any invitation
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeInvitationReq true "invitation"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/invitation [delete]
+func (ic *InvitationController) AdminRevokeInvitation(ctx *gin.Context) {
+	req := &schema.RevokeInvitationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = true
+
+	err := ic.invitationService.RevokeInvitation(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetInvitationRecordPage get invitation record page
+// @Summary get invitation record page
+// @Description get the records of who invited whom
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.InvitationRecordResp}}
+// @Router /answer/admin/api/invitation/records [get]
+func (ic *InvitationController) GetInvitationRecordPage(ctx *gin.Context) {
+	req := &schema.GetInvitationRecordPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := ic.invitationService.GetInvitationRecordPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
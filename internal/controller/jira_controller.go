@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/jira_integration"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// JiraController Jira escalation controller
+type JiraController struct {
+	jiraService *jira_integration.JiraService
+}
+
+// NewJiraController new controller
+func NewJiraController(jiraService *jira_integration.JiraService) *JiraController {
+	return &JiraController{jiraService: jiraService}
+}
+
+// EscalateToJira escalate the question to a Jira issue
+// @Summary escalate the question to a Jira issue
+// @Description create a Jira issue from the question and link it to the question, only for the moderators
+// @Tags Jira
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.EscalateToJiraReq true "question"
+// @Success 200 {object} handler.RespBody{data=schema.JiraIssueLinkResp}
+// @Router /answer/api/v1/question/jira [post]
+func (jc *JiraController) EscalateToJira(ctx *gin.Context) {
+	req := &schema.EscalateToJiraReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := jc.jiraService.EscalateToJira(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetIssueLink get the Jira issue of the question
+// @Summary get the Jira issue of the question
+// @Description get the Jira issue the question is escalated to with its synced status, it is null if not escalated
+// @Tags Jira
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.JiraIssueLinkResp}
+// @Router /answer/api/v1/question/jira [get]
+func (jc *JiraController) GetIssueLink(ctx *gin.Context) {
+	req := &schema.GetJiraIssueLinkReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := jc.jiraService.GetIssueLink(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -21,11 +21,16 @@ package controller
 
 import (
 	"encoding/json"
+	"io"
+	"sort"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/i18n"
 )
 
@@ -62,7 +67,7 @@ func (u *LangController) GetLangMapping(ctx *gin.Context) {
 // @Success 200 {object} handler.RespBody{}
 // @Router /answer/admin/api/language/options [get]
 func (u *LangController) GetAdminLangOptions(ctx *gin.Context) {
-	handler.HandleResponse(ctx, nil, translator.LanguageOptions)
+	handler.HandleResponse(ctx, nil, translator.GetLanguageOptions())
 }
 
 // GetUserLangOptions Get language options
@@ -79,7 +84,7 @@ func (u *LangController) GetUserLangOptions(ctx *gin.Context) {
 		return
 	}
 
-	options := translator.LanguageOptions
+	options := translator.GetLanguageOptions()
 	if len(siteInterfaceResp.Language) > 0 {
 		defaultOption := []*translator.LangOption{
 			{Label: translator.DefaultLangOption, Value: translator.DefaultLangOption},
@@ -88,3 +93,117 @@ func (u *LangController) GetUserLangOptions(ctx *gin.Context) {
 	}
 	handler.HandleResponse(ctx, nil, options)
 }
+
+// GetLanguagePacks get the language packs uploaded by the admin
+// @Summary get the language packs uploaded by the admin
+// @Description get the language packs uploaded by the admin
+// @Tags Lang
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]translator.LangOption}
+// @Router /answer/admin/api/language/packs [get]
+func (u *LangController) GetLanguagePacks(ctx *gin.Context) {
+	packs, err := translator.GetTranslator().GetLanguagePacks()
+	handler.HandleResponse(ctx, err, packs)
+}
+
+// UploadLanguagePack upload the community translation pack, the messages missing in the pack
+// are translated by the fallback languages
+// @Summary upload the community translation pack
+// @Description upload the community translation pack, the pack of the language is replaced if it exists
+// @Tags Lang
+// @Security ApiKeyAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param language formData string true "language code, such as zh_HK"
+// @Param label formData string true "label"
+// @Param file formData file true "translation file"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/language/packs [post]
+func (u *LangController) UploadLanguagePack(ctx *gin.Context) {
+	req := &schema.UploadLanguagePackReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	file, _, err := ctx.Request.FormFile("file")
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError).WithError(err), nil)
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, translator.MaxLanguagePackSize+1))
+	if err != nil {
+		handler.HandleResponse(ctx, errors.BadRequest(reason.RequestFormatError).WithError(err), nil)
+		return
+	}
+	err = translator.GetTranslator().SaveLanguagePack(req.Language, req.Label, content)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveLanguagePack remove the language pack
+// @Summary remove the language pack
+// @Description remove the language pack
+// @Tags Lang
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param data body schema.RemoveLanguagePackReq true "language pack"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/language/packs [delete]
+func (u *LangController) RemoveLanguagePack(ctx *gin.Context) {
+	req := &schema.RemoveLanguagePackReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := translator.GetTranslator().RemoveLanguagePack(req.Language)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetErrorCatalog get the error codes of the api, the type of the problem details links to the code in it
+// @Summary get the error catalog
+// @Description get the stable machine-readable error codes and their messages, the error response is returned
+// @Description as the problem details defined by RFC 7807 if the request accepts application/problem+json
+// @Tags Lang
+// @Param Accept-Language header string false "Accept-Language"
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.ErrorCatalogItem}
+// @Router /answer/api/v1/errors [get]
+func (u *LangController) GetErrorCatalog(ctx *gin.Context) {
+	data, _ := u.translator.Dump(i18n.DefaultLanguage)
+	translation := make(map[string]any)
+	_ = json.Unmarshal(data, &translation)
+	backend, _ := translation["backend"].(map[string]any)
+
+	lang := handler.GetLang(ctx)
+	catalog := make([]*schema.ErrorCatalogItem, 0)
+	for _, prefix := range []string{"base", "error"} {
+		group, _ := backend[prefix].(map[string]any)
+		for _, reasonKey := range collectReasonKeys(prefix, group) {
+			catalog = append(catalog, &schema.ErrorCatalogItem{
+				Code:    reason.ErrorCode(reasonKey),
+				Reason:  reasonKey,
+				Message: translator.Tr(lang, reasonKey),
+			})
+		}
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Code < catalog[j].Code
+	})
+	handler.HandleResponse(ctx, nil, catalog)
+}
+
+// collectReasonKeys the keys of the messages in the translation group, the message is the map of the plural forms
+func collectReasonKeys(prefix string, group map[string]any) (keys []string) {
+	for k, v := range group {
+		child, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if _, ok := child["other"].(string); ok {
+			keys = append(keys, prefix+"."+k)
+			continue
+		}
+		keys = append(keys, collectReasonKeys(prefix+"."+k, child)...)
+	}
+	return keys
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/leaderboard"
+	"github.com/gin-gonic/gin"
+)
+
+// LeaderboardController leaderboard controller
+type LeaderboardController struct {
+	leaderboardService *leaderboard.LeaderboardService
+}
+
+// NewLeaderboardController new controller
+func NewLeaderboardController(leaderboardService *leaderboard.LeaderboardService) *LeaderboardController {
+	return &LeaderboardController{leaderboardService: leaderboardService}
+}
+
+// GetReputationLeaderboard get reputation leaderboard
+// @Summary get reputation leaderboard
+// @Description get the users who earned the most reputation in the period
+// @Tags Leaderboard
+// @Produce json
+// @Param period query string false "period" Enums(week, month, quarter, all)
+// @Success 200 {object} handler.RespBody{data=schema.LeaderboardResp}
+// @Router /answer/api/v1/leaderboard/reputation [get]
+func (lc *LeaderboardController) GetReputationLeaderboard(ctx *gin.Context) {
+	req := &schema.GetReputationLeaderboardReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := lc.leaderboardService.GetReputationLeaderboard(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetTagLeaderboard get tag leaderboard
+// @Summary get tag leaderboard
+// @Description get the users who answered the most questions of the tag in the period
+// @Tags Leaderboard
+// @Produce json
+// @Param tag_name query string true "tag slug name"
+// @Param period query string false "period" Enums(week, month, quarter, all)
+// @Success 200 {object} handler.RespBody{data=schema.LeaderboardResp}
+// @Router /answer/api/v1/leaderboard/tag [get]
+func (lc *LeaderboardController) GetTagLeaderboard(ctx *gin.Context) {
+	req := &schema.GetTagLeaderboardReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := lc.leaderboardService.GetTagLeaderboard(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateLeaderboardOptOut update leaderboard opt-out
+// @Summary update leaderboard opt-out
+// @Description the user who opts out is not shown in any leaderboard
+// @Tags Leaderboard
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateLeaderboardOptOutReq true "opt-out"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/leaderboard/opt-out [put]
+func (lc *LeaderboardController) UpdateLeaderboardOptOut(ctx *gin.Context) {
+	req := &schema.UpdateLeaderboardOptOutReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := lc.leaderboardService.UpdateLeaderboardOptOut(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// MediaProxyController media proxy controller
+type MediaProxyController struct {
+	mediaProxyService *media_proxy.MediaProxyService
+}
+
+// NewMediaProxyController new controller
+func NewMediaProxyController(mediaProxyService *media_proxy.MediaProxyService) *MediaProxyController {
+	return &MediaProxyController{mediaProxyService: mediaProxyService}
+}
+
+// ProxyMedia load the external image through the site
+// @Summary load the external image through the site
+// @Description the url in the rendered content is rewritten to this api with the signature when the media proxy is enabled
+// @Tags Media
+// @Produce image/png,image/jpeg,image/gif,image/webp
+// @Param url query string true "the external image url"
+// @Param sig query string true "the signature of the url"
+// @Success 200 {file} binary
+// @Router /answer/api/v1/media/proxy [get]
+func (mc *MediaProxyController) ProxyMedia(ctx *gin.Context) {
+	req := &schema.MediaProxyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := mc.mediaProxyService.ProxyMedia(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", resp.CacheHours*3600))
+	ctx.Header("Content-Security-Policy", "default-src 'none'")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, resp.ContentType, resp.Body)
+}
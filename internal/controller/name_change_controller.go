@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// NameChangeController user name change controller
+type NameChangeController struct {
+	usernamePolicyService *username_policy.UsernamePolicyService
+}
+
+// NewNameChangeController new controller
+func NewNameChangeController(usernamePolicyService *username_policy.UsernamePolicyService) *NameChangeController {
+	return &NameChangeController{usernamePolicyService: usernamePolicyService}
+}
+
+// GetNameChangePage get name change page
+// @Summary get name change page
+// @Description get the changes of the usernames and the display names, only for the admin and the moderators
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param username query string false "the current or the former username of the user"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.NameChangeResp}}
+// @Router /answer/api/v1/user/name-changes/page [get]
+func (nc *NameChangeController) GetNameChangePage(ctx *gin.Context) {
+	req := &schema.GetNameChangePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := nc.usernamePolicyService.GetNameChangePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
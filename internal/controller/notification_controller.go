@@ -171,3 +171,80 @@ func (nc *NotificationController) GetList(ctx *gin.Context) {
 	resp, err := nc.notificationService.GetNotificationPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetGroupList get the grouped notification list
+// @Summary get the grouped notification list
+// @Description the related notifications are grouped, like the answers to the same question, the group updated recently first
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param type query string true "type" Enums(inbox,achievement)
+// @Param inbox_type query string false "inbox_type" Enums(all,posts,invites,votes)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.NotificationGroupContent}}
+// @Router /answer/api/v1/notification/group/page [get]
+func (nc *NotificationController) GetGroupList(ctx *gin.Context) {
+	req := &schema.NotificationGroupSearch{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := nc.notificationService.GetNotificationGroupPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ClearGroupUnRead mark the notifications of the group as read
+// @Summary mark the notifications of the group as read
+// @Description mark the notifications of the group as read
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.NotificationReadGroupRequest true "NotificationReadGroupRequest"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/notification/read/state/group [put]
+func (nc *NotificationController) ClearGroupUnRead(ctx *gin.Context) {
+	req := &schema.NotificationReadGroupRequest{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := nc.notificationService.ClearGroupUnRead(ctx, req)
+	handler.HandleResponse(ctx, err, gin.H{})
+}
+
+// ClearTypeUnRead mark the notifications of the type as read
+// @Summary mark the notifications of the type as read
+// @Description mark the notifications of the type as read, the inbox can be marked by the inbox type
+// @Tags Notification
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.NotificationReadTypeRequest true "NotificationReadTypeRequest"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/notification/read/state/type [put]
+func (nc *NotificationController) ClearTypeUnRead(ctx *gin.Context) {
+	req := &schema.NotificationReadTypeRequest{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := nc.notificationService.ClearTypeUnRead(ctx, req)
+	handler.HandleResponse(ctx, err, gin.H{})
+}
+
+// GetUnreadCount get the unread amount by category
+// @Summary get the unread amount by category
+// @Description get the unread amount of the inbox by inbox type and the achievement
+// @Tags Notification
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.NotificationUnreadCountResp}
+// @Router /answer/api/v1/notification/unread/count [get]
+func (nc *NotificationController) GetUnreadCount(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := nc.notificationService.GetUnreadCount(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/oembed"
+	"github.com/gin-gonic/gin"
+)
+
+// OEmbedController oEmbed controller
+type OEmbedController struct {
+	oembedService *oembed.OEmbedService
+}
+
+// NewOEmbedController new controller
+func NewOEmbedController(oembedService *oembed.OEmbedService) *OEmbedController {
+	return &OEmbedController{oembedService: oembedService}
+}
+
+// GetOEmbed get oEmbed of question
+// @Summary get oEmbed of question
+// @Description get oEmbed of question, the response is in oEmbed format instead of the common response body
+// @Tags Embed
+// @Produce json
+// @Param url query string true "question url"
+// @Param maxwidth query int false "max width"
+// @Param maxheight query int false "max height"
+// @Param format query string false "format" Enums(json)
+// @Success 200 {object} schema.OEmbedResp
+// @Router /answer/api/v1/oembed [get]
+func (oc *OEmbedController) GetOEmbed(ctx *gin.Context) {
+	req := &schema.GetOEmbedReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+	resp, err := oc.oembedService.GetOEmbed(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetEmbedQuestion get embed question
+// @Summary get the question with its accepted answer for embedding
+// @Description get the question with its accepted answer for embedding
+// @Tags Embed
+// @Produce json
+// @Param id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.EmbedQuestionResp}
+// @Router /answer/api/v1/embed/question [get]
+func (oc *OEmbedController) GetEmbedQuestion(ctx *gin.Context) {
+	req := &schema.GetEmbedQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+	resp, err := oc.oembedService.GetEmbedQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/page"
+	"github.com/gin-gonic/gin"
+)
+
+// PageController custom page controller
+type PageController struct {
+	pageService *page.PageService
+}
+
+// NewPageController new controller
+func NewPageController(pageService *page.PageService) *PageController {
+	return &PageController{pageService: pageService}
+}
+
+// AddPage add page
+// @Summary add page
+// @Description add custom page, such as about, privacy or faq, the content is markdown
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddPageReq true "page"
+// @Success 200 {object} handler.RespBody{data=schema.AddPageResp}
+// @Router /answer/admin/api/page [post]
+func (pc *PageController) AddPage(ctx *gin.Context) {
+	req := &schema.AddPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := pc.pageService.AddPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdatePage update page
+// @Summary update page
+// @Description update custom page
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdatePageReq true "page"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/page [put]
+func (pc *PageController) UpdatePage(ctx *gin.Context) {
+	req := &schema.UpdatePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := pc.pageService.UpdatePage(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemovePage remove page
+// @Summary remove page
+// @Description remove custom page
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemovePageReq true "page"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/page [delete]
+func (pc *PageController) RemovePage(ctx *gin.Context) {
+	req := &schema.RemovePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := pc.pageService.RemovePage(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetAdminPage get page
+// @Summary get page
+// @Description get custom page by id, including the draft page
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query int true "page id"
+// @Success 200 {object} handler.RespBody{data=schema.GetPageResp}
+// @Router /answer/admin/api/page [get]
+func (pc *PageController) GetAdminPage(ctx *gin.Context) {
+	req := &schema.GetAdminPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := pc.pageService.GetAdminPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetAdminPageList get page list
+// @Summary get page list
+// @Description get all custom pages, including the draft pages
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPageResp}
+// @Router /answer/admin/api/pages [get]
+func (pc *PageController) GetAdminPageList(ctx *gin.Context) {
+	resp, err := pc.pageService.GetAdminPageList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetPage get published page
+// @Summary get published page
+// @Description get published custom page by slug name
+// @Tags Page
+// @Produce json
+// @Param slug_name query string true "slug name"
+// @Success 200 {object} handler.RespBody{data=schema.GetPageResp}
+// @Router /answer/api/v1/page [get]
+func (pc *PageController) GetPage(ctx *gin.Context) {
+	req := &schema.GetPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := pc.pageService.GetPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetNavPageList get navigation pages
+// @Summary get navigation pages
+// @Description get the published custom pages shown in the site navigation, order by nav order
+// @Tags Page
+// @Produce json
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPageNavResp}
+// @Router /answer/api/v1/pages [get]
+func (pc *PageController) GetNavPageList(ctx *gin.Context) {
+	resp, err := pc.pageService.GetNavPageList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
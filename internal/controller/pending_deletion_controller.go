@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+// PendingDeletionController pending deletion controller
+type PendingDeletionController struct {
+	pendingDeletionService *pending_deletion.PendingDeletionService
+	questionService        *content.QuestionService
+	answerService          *content.AnswerService
+	commentService         *comment.CommentService
+}
+
+// NewPendingDeletionController new controller
+func NewPendingDeletionController(
+	pendingDeletionService *pending_deletion.PendingDeletionService,
+	questionService *content.QuestionService,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+) *PendingDeletionController {
+	return &PendingDeletionController{
+		pendingDeletionService: pendingDeletionService,
+		questionService:        questionService,
+		answerService:          answerService,
+		commentService:         commentService,
+	}
+}
+
+// UndoDelete undo delete
+// @Summary undo delete
+// @Description the user who deleted the question, answer or comment can undo it within the undo window
+// @Tags Post
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UndoDeleteReq true "deleted object"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/undo-delete [post]
+func (pc *PendingDeletionController) UndoDelete(ctx *gin.Context) {
+	req := &schema.UndoDeleteReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	pendingDeletion, err := pc.pendingDeletionService.GetUndoableDeletion(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if err = pc.recoverObject(ctx, pendingDeletion, req.UserID); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+
+	err = pc.pendingDeletionService.MarkUndone(ctx, pendingDeletion)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetRecycleBinPage get recycle bin page
+// @Summary get the deleted questions, answers and comments
+// @Description get the deleted questions, answers and comments with the deleter and the deletion reason
+// @Tags Admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param object_type query string false "object type" Enums(question, answer, comment)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.RecycleBinItem}}
+// @Router /answer/admin/api/recycle-bin/page [get]
+func (pc *PendingDeletionController) GetRecycleBinPage(ctx *gin.Context) {
+	req := &schema.GetRecycleBinPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := pc.pendingDeletionService.GetRecycleBinPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RestoreDeleted restore deleted
+// @Summary restore the deleted question, answer or comment from the recycle bin
+// @Description restore the deleted question, answer or comment from the recycle bin
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RestoreDeletedReq true "deleted object"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/recycle-bin/restore [post]
+func (pc *PendingDeletionController) RestoreDeleted(ctx *gin.Context) {
+	req := &schema.RestoreDeletedReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	pendingDeletion, err := pc.pendingDeletionService.GetRestorableDeletion(ctx, req)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	err = pc.recoverObject(ctx, pendingDeletion, req.UserID)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// recoverObject recover the deleted question, answer or comment
+func (pc *PendingDeletionController) recoverObject(ctx *gin.Context,
+	pendingDeletion *entity.PendingDeletion, userID string) (err error) {
+	switch pendingDeletion.ObjectType {
+	case constant.QuestionObjectType:
+		return pc.questionService.RecoverQuestion(ctx, &schema.QuestionRecoverReq{
+			QuestionID: pendingDeletion.ObjectID,
+			UserID:     userID,
+		})
+	case constant.AnswerObjectType:
+		return pc.answerService.RecoverAnswer(ctx, &schema.RecoverAnswerReq{
+			AnswerID: pendingDeletion.ObjectID,
+			UserID:   userID,
+		})
+	case constant.CommentObjectType:
+		return pc.commentService.RecoverComment(ctx, pendingDeletion.ObjectID, userID)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permalink"
+	"github.com/gin-gonic/gin"
+)
+
+// PermalinkController permalink controller
+type PermalinkController struct {
+	permalinkService *permalink.PermalinkService
+}
+
+// NewPermalinkController new controller
+func NewPermalinkController(permalinkService *permalink.PermalinkService) *PermalinkController {
+	return &PermalinkController{permalinkService: permalinkService}
+}
+
+// GetShareLink get share link
+// @Summary get the short link of question or answer for sharing
+// @Description get the short link of question or answer for sharing, the link contains the referral of the login user
+// @Tags Permalink
+// @Produce json
+// @Param object_id query string true "question or answer id"
+// @Success 200 {object} handler.RespBody{data=schema.GetShareLinkResp}
+// @Router /answer/api/v1/share/link [get]
+func (pc *PermalinkController) GetShareLink(ctx *gin.Context) {
+	req := &schema.GetShareLinkReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := pc.permalinkService.GetShareLink(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -23,17 +23,25 @@ import (
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/new_contributor"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/gin-gonic/gin"
 )
 
 type PermissionController struct {
-	rankService *rank.RankService
+	rankService           *rank.RankService
+	newContributorService *new_contributor.NewContributorService
 }
 
 // NewPermissionController new language controller.
-func NewPermissionController(rankService *rank.RankService) *PermissionController {
-	return &PermissionController{rankService: rankService}
+func NewPermissionController(
+	rankService *rank.RankService,
+	newContributorService *new_contributor.NewContributorService,
+) *PermissionController {
+	return &PermissionController{
+		rankService:           rankService,
+		newContributorService: newContributorService,
+	}
 }
 
 // GetPermission check user permission
@@ -66,5 +74,6 @@ func (u *PermissionController) GetPermission(ctx *gin.Context) {
 		t.TrTip(lang, requireRanks[i])
 		mapping[action] = t
 	}
+	err = u.newContributorService.DecoratePermission(ctx, lang, userID, mapping)
 	handler.HandleResponse(ctx, err, mapping)
 }
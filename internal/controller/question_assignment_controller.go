@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/question_assignment"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionAssignmentController question assignment controller
+type QuestionAssignmentController struct {
+	questionAssignmentService *question_assignment.QuestionAssignmentService
+	rankService               *rank.RankService
+}
+
+// NewQuestionAssignmentController new controller
+func NewQuestionAssignmentController(
+	questionAssignmentService *question_assignment.QuestionAssignmentService,
+	rankService *rank.RankService,
+) *QuestionAssignmentController {
+	return &QuestionAssignmentController{
+		questionAssignmentService: questionAssignmentService,
+		rankService:               rankService,
+	}
+}
+
+// AssignQuestion assign question
+// @Summary assign question
+// @Description assign the question to the users, the assignees will be notified
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AssignQuestionReq true "assignment"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionAssignmentResp}
+// @Router /answer/api/v1/question/assignment [post]
+func (qc *QuestionAssignmentController) AssignQuestion(ctx *gin.Context) {
+	req := &schema.AssignQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.canAssign(ctx, req.UserID) {
+		return
+	}
+
+	resp, err := qc.questionAssignmentService.AssignQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AutoAssignQuestion auto assign question
+// @Summary auto assign question
+// @Description assign the question to the users who are good at the tags of the question
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AutoAssignQuestionReq true "assignment"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionAssignmentResp}
+// @Router /answer/api/v1/question/assignment/auto [post]
+func (qc *QuestionAssignmentController) AutoAssignQuestion(ctx *gin.Context) {
+	req := &schema.AutoAssignQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.canAssign(ctx, req.UserID) {
+		return
+	}
+
+	resp, err := qc.questionAssignmentService.AutoAssignQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UnassignQuestion unassign question
+// @Summary unassign question
+// @Description cancel the pending assignment of the question to the user
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UnassignQuestionReq true "assignment"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/assignment [delete]
+func (qc *QuestionAssignmentController) UnassignQuestion(ctx *gin.Context) {
+	req := &schema.UnassignQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.canAssign(ctx, req.UserID) {
+		return
+	}
+
+	err := qc.questionAssignmentService.UnassignQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetQuestionAssignments get question assignments
+// @Summary get question assignments
+// @Description get the users assigned to the question and the state of the assignments
+// @Tags Question
+// @Produce json
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionAssignmentResp}
+// @Router /answer/api/v1/question/assignments [get]
+func (qc *QuestionAssignmentController) GetQuestionAssignments(ctx *gin.Context) {
+	req := &schema.GetQuestionAssignmentsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+
+	resp, err := qc.questionAssignmentService.GetQuestionAssignments(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetMyAssignmentPage get my assignment page
+// @Summary get my assignment page
+// @Description get the questions assigned to me, the pending ones with the earliest deadline first
+// @Tags Question
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "assignment status" Enums(pending, answered)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.MyAssignmentResp}}
+// @Router /answer/api/v1/question/assigned/page [get]
+func (qc *QuestionAssignmentController) GetMyAssignmentPage(ctx *gin.Context) {
+	req := &schema.GetMyAssignmentPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := qc.questionAssignmentService.GetMyAssignmentPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// canAssign check whether the user can assign questions, the response is written if not
+func (qc *QuestionAssignmentController) canAssign(ctx *gin.Context, userID string) bool {
+	can, err := qc.rankService.CheckOperationPermission(ctx, userID, permission.QuestionAssign, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return false
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_batch"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionBatchController the batched read api of the questions, the clients with high latency such as
+// the mobile apps get the content of a page in one round trip
+type QuestionBatchController struct {
+	questionBatchService *question_batch.QuestionBatchService
+}
+
+// NewQuestionBatchController new controller
+func NewQuestionBatchController(questionBatchService *question_batch.QuestionBatchService) *QuestionBatchController {
+	return &QuestionBatchController{questionBatchService: questionBatchService}
+}
+
+// BatchGetQuestions get the questions by ids
+// @Summary get the questions by ids
+// @Description get the questions by ids, the questions that do not exist or are invisible are returned in not_found
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Param data body schema.BatchGetQuestionsReq true "question ids"
+// @Success 200 {object} handler.RespBody{data=schema.BatchGetQuestionsResp}
+// @Router /answer/api/v1/question/batch [post]
+func (qc *QuestionBatchController) BatchGetQuestions(ctx *gin.Context) {
+	req := &schema.BatchGetQuestionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := qc.questionBatchService.BatchGetQuestions(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetQuestionDetail get the question with the answers and the comments
+// @Summary get the question with the answers and the comments
+// @Description get the question, the first page of the answers and the first page of the comments of the question
+// @Description and each answer in one call, the votes and the states of the user are in the question and the answers
+// @Tags Question
+// @Produce json
+// @Param id query string true "question id"
+// @Param answer_order query string false "answer order" Enums(default, updated, created, vote)
+// @Param answer_page_size query int false "answer page size"
+// @Param comment_page_size query int false "comment page size of each object"
+// @Param share_token query string false "share token of the unlisted question"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionDetailResp}
+// @Router /answer/api/v1/question/detail [get]
+func (qc *QuestionBatchController) GetQuestionDetail(ctx *gin.Context) {
+	req := &schema.GetQuestionDetailReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := qc.questionBatchService.GetQuestionDetail(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -22,6 +22,7 @@ package controller
 import (
 	"net/http"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/base/pager"
@@ -32,7 +33,11 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	"github.com/apache/incubator-answer/internal/service/new_contributor"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/question_share"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/uid"
@@ -43,12 +48,16 @@ import (
 
 // QuestionController question controller
 type QuestionController struct {
-	questionService     *content.QuestionService
-	answerService       *content.AnswerService
-	rankService         *rank.RankService
-	siteInfoService     siteinfo_common.SiteInfoCommonService
-	actionService       *action.CaptchaService
-	rateLimitMiddleware *middleware.RateLimitMiddleware
+	questionService       *content.QuestionService
+	answerService         *content.AnswerService
+	rankService           *rank.RankService
+	siteInfoService       siteinfo_common.SiteInfoCommonService
+	actionService         *action.CaptchaService
+	rateLimitMiddleware   *middleware.RateLimitMiddleware
+	contentFilterService  *content_filter.ContentFilterService
+	questionShareService  *question_share.QuestionShareService
+	newContributorService *new_contributor.NewContributorService
+	contentLimitService   *content_limit.ContentLimitService
 }
 
 // NewQuestionController new controller
@@ -59,14 +68,22 @@ func NewQuestionController(
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	actionService *action.CaptchaService,
 	rateLimitMiddleware *middleware.RateLimitMiddleware,
+	contentFilterService *content_filter.ContentFilterService,
+	questionShareService *question_share.QuestionShareService,
+	newContributorService *new_contributor.NewContributorService,
+	contentLimitService *content_limit.ContentLimitService,
 ) *QuestionController {
 	return &QuestionController{
-		questionService:     questionService,
-		answerService:       answerService,
-		rankService:         rankService,
-		siteInfoService:     siteInfoService,
-		actionService:       actionService,
-		rateLimitMiddleware: rateLimitMiddleware,
+		questionService:       questionService,
+		answerService:         answerService,
+		rankService:           rankService,
+		siteInfoService:       siteInfoService,
+		actionService:         actionService,
+		rateLimitMiddleware:   rateLimitMiddleware,
+		contentFilterService:  contentFilterService,
+		questionShareService:  questionShareService,
+		newContributorService: newContributorService,
+		contentLimitService:   contentLimitService,
 	}
 }
 
@@ -220,6 +237,13 @@ func (qc *QuestionController) ReopenQuestion(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// AddQuestionPV add the page view of the question before the question is read,
+// the view is counted even if the question is served from the cache
+func (qc *QuestionController) AddQuestionPV(ctx *gin.Context) {
+	qc.questionService.AddQuestionPV(ctx, uid.DeShortID(ctx.Query("id")))
+	ctx.Next()
+}
+
 // GetQuestion get question details
 // @Summary get question details
 // @Description get question details
@@ -234,37 +258,15 @@ func (qc *QuestionController) GetQuestion(ctx *gin.Context) {
 	id := ctx.Query("id")
 	id = uid.DeShortID(id)
 	userID := middleware.GetLoginUserIDFromContext(ctx)
-	req := schema.QuestionPermission{}
-	canList, err := qc.rankService.CheckOperationPermissions(ctx, userID, []string{
-		permission.QuestionEdit,
-		permission.QuestionDelete,
-		permission.QuestionClose,
-		permission.QuestionReopen,
-		permission.QuestionPin,
-		permission.QuestionUnPin,
-		permission.QuestionHide,
-		permission.QuestionShow,
-		permission.AnswerInviteSomeoneToAnswer,
-		permission.QuestionUnDelete,
-	})
+	req, err := qc.rankService.GetQuestionPermission(ctx, userID)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, userID, id)
+	req = qc.rankService.WithQuestionEditor(ctx, req, userID, id)
+	req.CanViewUnlisted = qc.questionShareService.CheckShareToken(ctx, id, ctx.Query(schema.QuestionShareTokenParam))
 
-	req.CanEdit = canList[0] || objectOwner
-	req.CanDelete = canList[1]
-	req.CanClose = canList[2]
-	req.CanReopen = canList[3]
-	req.CanPin = canList[4]
-	req.CanUnPin = canList[5]
-	req.CanHide = canList[6]
-	req.CanShow = canList[7]
-	req.CanInviteOtherToAnswer = canList[8]
-	req.CanRecover = canList[9]
-
-	info, err := qc.questionService.GetQuestionAndAddPV(ctx, id, userID, req)
+	info, err := qc.questionService.GetQuestion(ctx, id, userID, req)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
@@ -407,6 +409,21 @@ func (qc *QuestionController) AddQuestion(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
 		return
 	}
+	if !isAdmin {
+		if err = qc.newContributorService.CheckPostLimit(ctx, req.UserID, constant.QuestionObjectType); err != nil {
+			handler.HandleResponse(ctx, err, nil)
+			return
+		}
+	}
+
+	if err = qc.contentFilterService.FilterQuestionAdd(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := qc.contentLimitService.CheckQuestion(ctx, req.UserID, req.Title, req.Content); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
 
 	// can add tag
 	hasNewTag, err := qc.questionService.HasNewTag(ctx, req.Tags)
@@ -516,6 +533,21 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.Forbidden(reason.RequestFormatError), nil)
 		return
 	}
+	if err = qc.contentFilterService.FilterQuestionAdd(ctx, questionReq); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	answerFilterReq := &schema.AnswerAddReq{Content: req.AnswerContent, HTML: req.AnswerHTML, UserID: req.UserID}
+	if err = qc.contentFilterService.FilterAnswerAdd(ctx, answerFilterReq); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.AnswerContent, req.AnswerHTML = answerFilterReq.Content, answerFilterReq.HTML
+	if limitErrFields, err := qc.contentLimitService.CheckQuestionByAnswer(ctx, req.UserID,
+		questionReq.Title, questionReq.Content, req.AnswerContent); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
 	errList, err := qc.questionService.CheckAddQuestion(ctx, questionReq)
 	if err != nil {
 		errlist, ok := errList.([]*validator.FormErrorField)
@@ -529,8 +561,8 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 		return
 	}
 
-	req.UserAgent = ctx.GetHeader("User-Agent")
-	req.IP = ctx.ClientIP()
+	questionReq.UserAgent = ctx.GetHeader("User-Agent")
+	questionReq.IP = ctx.ClientIP()
 	resp, err := qc.questionService.AddQuestion(ctx, questionReq)
 	if err != nil {
 		errlist, ok := resp.([]*validator.FormErrorField)
@@ -555,6 +587,8 @@ func (qc *QuestionController) AddQuestionByAnswer(ctx *gin.Context) {
 		answerReq.UserID = middleware.GetLoginUserIDFromContext(ctx)
 		answerReq.Content = req.AnswerContent
 		answerReq.HTML = req.AnswerHTML
+		answerReq.UserAgent = questionReq.UserAgent
+		answerReq.IP = questionReq.IP
 		answerID, err := qc.answerService.Insert(ctx, answerReq)
 		if err != nil {
 			handler.HandleResponse(ctx, err, nil)
@@ -623,10 +657,10 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 		}
 	}
 
-	objectOwner := qc.rankService.CheckOperationObjectOwner(ctx, req.UserID, req.ID)
-	req.CanEdit = canList[0] || objectOwner
+	objectEditor := qc.rankService.CheckOperationObjectEditor(ctx, req.UserID, req.ID)
+	req.CanEdit = canList[0] || objectEditor
 	req.CanDelete = canList[1]
-	req.NoNeedReview = canList[2] || objectOwner
+	req.NoNeedReview = canList[2] || objectEditor
 	req.CanUseReservedTag = canList[3]
 	req.CanAddTag = canList[4]
 	if !req.CanEdit {
@@ -634,6 +668,15 @@ func (qc *QuestionController) UpdateQuestion(ctx *gin.Context) {
 		return
 	}
 
+	if err = qc.contentFilterService.FilterQuestionUpdate(ctx, req); err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if limitErrFields, err := qc.contentLimitService.CheckQuestion(ctx, req.UserID, req.Title, req.Content); err != nil {
+		handler.HandleResponse(ctx, err, limitErrFields)
+		return
+	}
+
 	errlist, err := qc.questionService.UpdateQuestionCheckTags(ctx, req)
 	if err != nil {
 		errFields = append(errFields, errlist...)
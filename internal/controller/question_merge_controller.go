@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_merge"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionMergeController question merge controller
+type QuestionMergeController struct {
+	questionMergeService *question_merge.QuestionMergeService
+}
+
+// NewQuestionMergeController new controller
+func NewQuestionMergeController(questionMergeService *question_merge.QuestionMergeService) *QuestionMergeController {
+	return &QuestionMergeController{questionMergeService: questionMergeService}
+}
+
+// MergeQuestion merge question
+// @Summary merge the duplicate question into the canonical question
+// @Description move the answers, comments, votes and followers of the duplicate question into the canonical question,
+// @Description the duplicate question is left as a redirect stub, only for the admin and moderator
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.MergeQuestionReq true "question"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/merge [post]
+func (qc *QuestionMergeController) MergeQuestion(ctx *gin.Context) {
+	req := &schema.MergeQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	if !middleware.GetUserIsAdminModerator(ctx) {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := qc.questionMergeService.MergeQuestion(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
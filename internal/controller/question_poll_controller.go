@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/question_poll"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionPollController question poll controller
+type QuestionPollController struct {
+	questionPollService *question_poll.QuestionPollService
+	rankService         *rank.RankService
+}
+
+// NewQuestionPollController new controller
+func NewQuestionPollController(
+	questionPollService *question_poll.QuestionPollService,
+	rankService *rank.RankService,
+) *QuestionPollController {
+	return &QuestionPollController{
+		questionPollService: questionPollService,
+		rankService:         rankService,
+	}
+}
+
+// AddQuestionPoll add question poll
+// @Summary add question poll
+// @Description attach a poll to the question, only the author and the user who can edit the question can add it
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddQuestionPollReq true "poll"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionPollResp}
+// @Router /answer/api/v1/question/poll [post]
+func (qc *QuestionPollController) AddQuestionPoll(ctx *gin.Context) {
+	req := &schema.AddQuestionPollReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.canEditQuestion(ctx, req.UserID, req.QuestionID) {
+		return
+	}
+
+	resp, err := qc.questionPollService.AddQuestionPoll(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveQuestionPoll remove question poll
+// @Summary remove question poll
+// @Description remove the poll of the question with all votes
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveQuestionPollReq true "poll"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/poll [delete]
+func (qc *QuestionPollController) RemoveQuestionPoll(ctx *gin.Context) {
+	req := &schema.RemoveQuestionPollReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	if !qc.canEditQuestion(ctx, req.UserID, req.QuestionID) {
+		return
+	}
+
+	err := qc.questionPollService.RemoveQuestionPoll(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// VoteQuestionPoll vote question poll
+// @Summary vote question poll
+// @Description vote the poll of the question, the previous choices are replaced
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.VoteQuestionPollReq true "vote"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionPollResp}
+// @Router /answer/api/v1/question/poll/vote [post]
+func (qc *QuestionPollController) VoteQuestionPoll(ctx *gin.Context) {
+	req := &schema.VoteQuestionPollReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := qc.questionPollService.VoteQuestionPoll(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// canEditQuestion check whether the user can edit the question, the response is written if not
+func (qc *QuestionPollController) canEditQuestion(ctx *gin.Context, userID, questionID string) bool {
+	can, err := qc.rankService.CheckOperationPermission(ctx, userID, permission.QuestionEdit, questionID)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return false
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return false
+	}
+	return true
+}
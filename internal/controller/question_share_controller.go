@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_share"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionShareController question share controller
+type QuestionShareController struct {
+	questionShareService *question_share.QuestionShareService
+}
+
+// NewQuestionShareController new controller
+func NewQuestionShareController(questionShareService *question_share.QuestionShareService) *QuestionShareController {
+	return &QuestionShareController{questionShareService: questionShareService}
+}
+
+// UpdateQuestionVisibility update question visibility
+// @Summary update question visibility
+// @Description make the question public or unlisted, the unlisted question only can be viewed by the share link
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateQuestionVisibilityReq true "visibility"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/visibility [put]
+func (qc *QuestionShareController) UpdateQuestionVisibility(ctx *gin.Context) {
+	req := &schema.UpdateQuestionVisibilityReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	err := qc.questionShareService.UpdateQuestionVisibility(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// AddQuestionShareLink add question share link
+// @Summary add question share link
+// @Description add the share link of the unlisted question
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddQuestionShareLinkReq true "share link"
+// @Success 200 {object} handler.RespBody{data=schema.QuestionShareLinkResp}
+// @Router /answer/api/v1/question/share-link [post]
+func (qc *QuestionShareController) AddQuestionShareLink(ctx *gin.Context) {
+	req := &schema.AddQuestionShareLinkReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	resp, err := qc.questionShareService.AddQuestionShareLink(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetQuestionShareLinks get question share links
+// @Summary get question share links
+// @Description get the share links of the question
+// @Tags Question
+// @Produce json
+// @Security ApiKeyAuth
+// @Param question_id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=[]schema.QuestionShareLinkResp}
+// @Router /answer/api/v1/question/share-links [get]
+func (qc *QuestionShareController) GetQuestionShareLinks(ctx *gin.Context) {
+	req := &schema.GetQuestionShareLinksReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.QuestionID = uid.DeShortID(req.QuestionID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	resp, err := qc.questionShareService.GetQuestionShareLinks(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveQuestionShareLink revoke question share link
+// @Summary revoke question share link
+// @Description revoke the share link, the link can not be used to view the question anymore
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveQuestionShareLinkReq true "share link"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/share-link [delete]
+func (qc *QuestionShareController) RemoveQuestionShareLink(ctx *gin.Context) {
+	req := &schema.RemoveQuestionShareLinkReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsModerator = middleware.GetUserIsAdminModerator(ctx)
+
+	err := qc.questionShareService.RemoveQuestionShareLink(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
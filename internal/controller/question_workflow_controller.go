@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_workflow"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionWorkflowController question workflow controller
+type QuestionWorkflowController struct {
+	questionWorkflowService *question_workflow.QuestionWorkflowService
+}
+
+// NewQuestionWorkflowController new controller
+func NewQuestionWorkflowController(
+	questionWorkflowService *question_workflow.QuestionWorkflowService) *QuestionWorkflowController {
+	return &QuestionWorkflowController{questionWorkflowService: questionWorkflowService}
+}
+
+// GetQuestionWorkflowState get question workflow state
+// @Summary get question workflow state
+// @Description get the workflow state of the question, the states the login user can move it to and the history
+// @Tags Question
+// @Produce json
+// @Param id query string true "question id"
+// @Success 200 {object} handler.RespBody{data=schema.GetQuestionWorkflowStateResp}
+// @Router /answer/api/v1/question/workflow-state [get]
+func (qc *QuestionWorkflowController) GetQuestionWorkflowState(ctx *gin.Context) {
+	req := &schema.GetQuestionWorkflowStateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := qc.questionWorkflowService.GetQuestionWorkflowState(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateQuestionWorkflowState update question workflow state
+// @Summary update question workflow state
+// @Description move the question to the workflow state if the transition is allowed for the role of the user
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateQuestionWorkflowStateReq true "workflow state"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/question/workflow-state [put]
+func (qc *QuestionWorkflowController) UpdateQuestionWorkflowState(ctx *gin.Context) {
+	req := &schema.UpdateQuestionWorkflowStateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := qc.questionWorkflowService.UpdateQuestionWorkflowState(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
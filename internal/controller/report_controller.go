@@ -121,7 +121,12 @@ func (rc *ReportController) GetUnreviewedReportPostPage(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	can, err := rc.rankService.CheckOperationPermission(ctx, req.UserID, permission.ReportReview, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	req.IsAdmin = can
 
 	resp, err := rc.reportService.GetUnreviewedReportPostPage(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
@@ -145,11 +150,6 @@ func (rc *ReportController) ReviewReport(ctx *gin.Context) {
 	}
 
 	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
-	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
-	if !req.IsAdmin {
-		handler.HandleResponse(ctx, errors.Forbidden(reason.ForbiddenError), nil)
-		return
-	}
 
 	err := rc.reportService.ReviewReport(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/review_reminder"
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewReminderController question review reminder controller
+type ReviewReminderController struct {
+	reviewReminderService *review_reminder.ReviewReminderService
+}
+
+// NewReviewReminderController new controller
+func NewReviewReminderController(reviewReminderService *review_reminder.ReviewReminderService) *ReviewReminderController {
+	return &ReviewReminderController{reviewReminderService: reviewReminderService}
+}
+
+// AcceptAnswerByReminder accept the answer through the review reminder
+// @Summary accept the answer through the review reminder
+// @Description accept the answer by the one-click link in the reminder email, the code of the link identifies the author
+// @Tags api-answer
+// @Accept json
+// @Produce json
+// @Param data body schema.AcceptAnswerByReminderReq true "AcceptAnswerByReminderReq"
+// @Success 200 {object} handler.RespBody{data=schema.AcceptAnswerByReminderResp}
+// @Router /answer/api/v1/question/review-reminder/acceptance [put]
+func (rc *ReviewReminderController) AcceptAnswerByReminder(ctx *gin.Context) {
+	req := &schema.AcceptAnswerByReminderReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.reviewReminderService.AcceptAnswerByReminder(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
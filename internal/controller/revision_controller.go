@@ -207,6 +207,7 @@ func (rc *RevisionController) GetReviewingType(ctx *gin.Context) {
 		permission.QuestionAudit,
 		permission.AnswerAudit,
 		permission.TagAudit,
+		permission.ReportReview,
 	})
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
@@ -215,6 +216,7 @@ func (rc *RevisionController) GetReviewingType(ctx *gin.Context) {
 	req.CanReviewQuestion = canList[0]
 	req.CanReviewAnswer = canList[1]
 	req.CanReviewTag = canList[2]
+	req.CanReviewReport = canList[3]
 	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
 
 	resp, err := rc.revisionListService.GetReviewingType(ctx, req)
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -92,6 +93,25 @@ func (sc *SearchController) Search(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// SearchClick record the clicked search result
+// @Summary record the clicked search result
+// @Description record the clicked search result, only aggregated counts are stored
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param data body schema.SearchClickReq true "search click"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/search/click [post]
+func (sc *SearchController) SearchClick(ctx *gin.Context) {
+	req := &schema.SearchClickReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	err := sc.searchService.RecordClick(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // SearchDesc get search description
 // @Summary get search description
 // @Description get search description
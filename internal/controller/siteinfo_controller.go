@@ -25,19 +25,33 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/experiment"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	"github.com/apache/incubator-answer/internal/service/maintenance"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/log"
 )
 
 type SiteInfoController struct {
-	siteInfoService siteinfo_common.SiteInfoCommonService
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	experimentService  *experiment.ExperimentService
+	featureFlagService *feature_flag.FeatureFlagService
+	maintenanceService *maintenance.MaintenanceService
 }
 
 // NewSiteInfoController new site info controller.
-func NewSiteInfoController(siteInfoService siteinfo_common.SiteInfoCommonService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	experimentService *experiment.ExperimentService,
+	featureFlagService *feature_flag.FeatureFlagService,
+	maintenanceService *maintenance.MaintenanceService,
+) *SiteInfoController {
 	return &SiteInfoController{
-		siteInfoService: siteInfoService,
+		siteInfoService:    siteInfoService,
+		experimentService:  experimentService,
+		featureFlagService: featureFlagService,
+		maintenanceService: maintenanceService,
 	}
 }
 
@@ -91,6 +105,16 @@ func (sc *SiteInfoController) GetSiteInfo(ctx *gin.Context) {
 	if err != nil {
 		log.Error(err)
 	}
+	resp.Maintenance, err = sc.maintenanceService.GetMaintenance(ctx)
+	if err != nil {
+		log.Error(err)
+	}
+	resp.Experiments = sc.experimentService.GetRunningExperiments(ctx)
+	if featureFlags, err := sc.featureFlagService.GetFeatureFlags(ctx); err != nil {
+		log.Error(err)
+	} else {
+		resp.FeatureFlags = featureFlags.Flags
+	}
 
 	handler.HandleResponse(ctx, nil, resp)
 }
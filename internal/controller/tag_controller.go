@@ -34,18 +34,31 @@ import (
 
 // TagController tag controller
 type TagController struct {
-	tagService       *tag.TagService
-	tagCommonService *tag_common.TagCommonService
-	rankService      *rank.RankService
+	tagService          *tag.TagService
+	tagCommonService    *tag_common.TagCommonService
+	tagStatsService     *tag.TagStatsService
+	userTagScoreService *tag.UserTagScoreService
+	tagSuggestService   *tag.TagSuggestService
+	rankService         *rank.RankService
 }
 
 // NewTagController new controller
 func NewTagController(
 	tagService *tag.TagService,
 	tagCommonService *tag_common.TagCommonService,
+	tagStatsService *tag.TagStatsService,
+	userTagScoreService *tag.UserTagScoreService,
+	tagSuggestService *tag.TagSuggestService,
 	rankService *rank.RankService,
 ) *TagController {
-	return &TagController{tagService: tagService, tagCommonService: tagCommonService, rankService: rankService}
+	return &TagController{
+		tagService:          tagService,
+		tagCommonService:    tagCommonService,
+		tagStatsService:     tagStatsService,
+		userTagScoreService: userTagScoreService,
+		tagSuggestService:   tagSuggestService,
+		rankService:         rankService,
+	}
 }
 
 // SearchTagLike get tag list
@@ -249,6 +262,65 @@ func (tc *TagController) GetTagInfo(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetTagStats get tag statistics
+// @Summary get tag statistics
+// @Description get questions per day/week, answer rate, median time to first answer and top contributors of the tag
+// @Tags Tag
+// @Produce json
+// @Param slug path string true "tag slug name"
+// @Param range query string false "time range" Enums(week, month, quarter, year)
+// @Success 200 {object} handler.RespBody{data=schema.GetTagStatsResp}
+// @Router /answer/api/v1/tags/{slug}/stats [get]
+func (tc *TagController) GetTagStats(ctx *gin.Context) {
+	req := &schema.GetTagStatsReq{SlugName: ctx.Param("slug")}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.tagStatsService.GetTagStats(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetTagSuggestions get tag suggestions
+// @Summary get tag suggestions
+// @Description suggest the existing tags for the draft question with the confidence scores
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.GetTagSuggestionsReq true "draft question"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagSuggestionResp}
+// @Router /answer/api/v1/tag/suggestions [post]
+func (tc *TagController) GetTagSuggestions(ctx *gin.Context) {
+	req := &schema.GetTagSuggestionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := tc.tagSuggestService.GetTagSuggestions(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetTagTopAnswerers get tag top answerers
+// @Summary get tag top answerers
+// @Description get the answerers with the highest expertise scores in the tag, the scores are refreshed daily
+// @Tags Tag
+// @Produce json
+// @Param slug path string true "tag slug name"
+// @Param limit query int false "the number of answerers, default is 10"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagTopAnswerer}
+// @Router /answer/api/v1/tags/{slug}/top-answerers [get]
+func (tc *TagController) GetTagTopAnswerers(ctx *gin.Context) {
+	req := &schema.GetTagTopAnswerersReq{SlugName: ctx.Param("slug")}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := tc.userTagScoreService.GetTagTopAnswerers(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // GetTagWithPage get tag page
 // @Summary get tag page
 // @Description get tag page
@@ -20,7 +20,10 @@
 package controller
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/apache/incubator-answer/plugin"
 	"html/template"
@@ -44,6 +47,7 @@ import (
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/ui"
 	"github.com/gin-gonic/gin"
+	myErrors "github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -116,6 +120,10 @@ func (tc *TemplateController) SiteInfo(ctx *gin.Context) *schema.TemplateSiteInf
 	if err != nil {
 		log.Error(err)
 	}
+	resp.NavPages, err = tc.templateRenderController.NavPageList(ctx)
+	if err != nil {
+		log.Error(err)
+	}
 	resp.Year = fmt.Sprintf("%d", time.Now().Year())
 	return resp
 }
@@ -297,6 +305,12 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	}
 
 	siteInfo := tc.SiteInfo(ctx)
+	// the merged question is left as a redirect stub of the canonical question
+	if len(detail.MergedQuestionID) > 0 {
+		ctx.Redirect(http.StatusMovedPermanently,
+			fmt.Sprintf("%s/questions/%s", siteInfo.General.SiteUrl, detail.MergedQuestionID))
+		return
+	}
 	jump, jumpurl := tc.QuestionInfoeRdirect(ctx, siteInfo, correctTitle)
 	if jump {
 		ctx.Redirect(http.StatusFound, jumpurl)
@@ -333,6 +347,38 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	if siteInfo.SiteSeo.Permalink == constant.PermalinkQuestionID || siteInfo.SiteSeo.Permalink == constant.PermalinkQuestionIDByShortID {
 		siteInfo.Canonical = fmt.Sprintf("%s/questions/%s", siteInfo.General.SiteUrl, id)
 	}
+	siteInfo.OEmbedURL = tc.templateRenderController.OEmbedDiscoveryURL(ctx, siteInfo.Canonical)
+	if siteInfo.SiteSeo.StructuredData {
+		siteInfo.JsonLD = buildQAPageJsonLD(siteInfo, detail, answers, answerCount)
+	}
+	siteInfo.OGType = "article"
+	siteInfo.PublishedTime = time.Unix(detail.CreateTime, 0).Format(time.RFC3339)
+	if detail.PostUpdateTime > 0 {
+		siteInfo.ModifiedTime = time.Unix(detail.PostUpdateTime, 0).Format(time.RFC3339)
+	}
+
+	siteInfo.Description = htmltext.FetchExcerpt(detail.HTML, "...", 240)
+	tags := make([]string, 0)
+	for _, tag := range detail.Tags {
+		tags = append(tags, tag.DisplayName)
+	}
+	siteInfo.Keywords = strings.Replace(strings.Trim(fmt.Sprint(tags), "[]"), " ", ",", -1)
+	siteInfo.ArticleTags = tags
+	siteInfo.Title = fmt.Sprintf("%s - %s", detail.Title, siteInfo.General.Name)
+	tc.html(ctx, http.StatusOK, "question-detail.html", siteInfo, gin.H{
+		"id":       id,
+		"answerid": answerid,
+		"detail":   detail,
+		"answers":  answers,
+		"comments": comments,
+		"noindex":  detail.Show != entity.QuestionShow,
+	})
+}
+
+// buildQAPageJsonLD build the schema.org QAPage structured data of the question, the accepted answer is
+// marked as acceptedAnswer and the others are suggestedAnswer.
+func buildQAPageJsonLD(siteInfo *schema.TemplateSiteInfoResp, detail *schema.QuestionInfoResp,
+	answers []*schema.AnswerInfo, answerCount int64) string {
 	jsonLD := &schema.QAPageJsonLD{}
 	jsonLD.Context = "https://schema.org"
 	jsonLD.Type = "QAPage"
@@ -374,25 +420,11 @@ func (tc *TemplateController) QuestionInfo(ctx *gin.Context) {
 	}
 	jsonLD.MainEntity.SuggestedAnswer = answerList
 	jsonLDStr, err := json.Marshal(jsonLD)
-	if err == nil {
-		siteInfo.JsonLD = `<script data-react-helmet="true" type="application/ld+json">` + string(jsonLDStr) + ` </script>`
-	}
-
-	siteInfo.Description = htmltext.FetchExcerpt(detail.HTML, "...", 240)
-	tags := make([]string, 0)
-	for _, tag := range detail.Tags {
-		tags = append(tags, tag.DisplayName)
+	if err != nil {
+		log.Error(err)
+		return ""
 	}
-	siteInfo.Keywords = strings.Replace(strings.Trim(fmt.Sprint(tags), "[]"), " ", ",", -1)
-	siteInfo.Title = fmt.Sprintf("%s - %s", detail.Title, siteInfo.General.Name)
-	tc.html(ctx, http.StatusOK, "question-detail.html", siteInfo, gin.H{
-		"id":       id,
-		"answerid": answerid,
-		"detail":   detail,
-		"answers":  answers,
-		"comments": comments,
-		"noindex":  detail.Show == entity.QuestionHide,
-	})
+	return `<script data-react-helmet="true" type="application/ld+json">` + string(jsonLDStr) + ` </script>`
 }
 
 // TagList tags list
@@ -462,6 +494,24 @@ func (tc *TemplateController) TagInfo(ctx *gin.Context) {
 	})
 }
 
+// CustomPage custom page created by admin
+func (tc *TemplateController) CustomPage(ctx *gin.Context) {
+	slugName := ctx.Param("slug")
+	pageInfo, err := tc.templateRenderController.CustomPage(ctx, slugName)
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+
+	siteInfo := tc.SiteInfo(ctx)
+	siteInfo.Canonical = fmt.Sprintf("%s/pages/%s", siteInfo.General.SiteUrl, pageInfo.SlugName)
+	siteInfo.Description = htmltext.FetchExcerpt(pageInfo.HTML, "...", 240)
+	siteInfo.Title = fmt.Sprintf("%s - %s", pageInfo.Title, siteInfo.General.Name)
+	tc.html(ctx, http.StatusOK, "custom-page.html", siteInfo, gin.H{
+		"page": pageInfo,
+	})
+}
+
 // UserInfo user info
 func (tc *TemplateController) UserInfo(ctx *gin.Context) {
 	username := ctx.Param("username")
@@ -491,6 +541,12 @@ func (tc *TemplateController) UserInfo(ctx *gin.Context) {
 	}
 
 	siteInfo := tc.SiteInfo(ctx)
+	// the old username of the renamed user is redirected to the new one
+	if userinfo.Username != username {
+		ctx.Redirect(http.StatusMovedPermanently,
+			fmt.Sprintf("%s/users/%s", siteInfo.General.SiteUrl, userinfo.Username))
+		return
+	}
 	siteInfo.Canonical = fmt.Sprintf("%s/users/%s", siteInfo.General.SiteUrl, username)
 	siteInfo.Title = fmt.Sprintf("%s - %s", username, siteInfo.General.Name)
 	tc.html(ctx, http.StatusOK, "homepage.html", siteInfo, gin.H{
@@ -545,13 +601,33 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, siteI
 		data["title"] = siteInfo.General.Name
 	}
 	data["description"] = siteInfo.Description
+	if siteInfo.SiteSeo != nil && siteInfo.SiteSeo.SocialMeta {
+		data["socialMeta"] = true
+		data["twitterSite"] = siteInfo.SiteSeo.TwitterSite
+		if len(siteInfo.SiteSeo.TwitterSite) > 0 && !strings.HasPrefix(siteInfo.SiteSeo.TwitterSite, "@") {
+			data["twitterSite"] = "@" + siteInfo.SiteSeo.TwitterSite
+		}
+		data["shareImage"] = siteInfo.SiteSeo.ShareImage
+	}
+	data["ogType"] = siteInfo.OGType
+	if siteInfo.OGType == "" {
+		data["ogType"] = "website"
+	}
 	data["language"] = handler.GetLang(ctx)
 	data["timezone"] = siteInfo.Interface.TimeZone
 	language := strings.Replace(siteInfo.Interface.Language, "_", "-", -1)
 	data["lang"] = language
-	data["HeadCode"] = siteInfo.CustomCssHtml.CustomHead
-	data["HeaderCode"] = siteInfo.CustomCssHtml.CustomHeader
-	data["FooterCode"] = siteInfo.CustomCssHtml.CustomFooter
+	cspNonce := ""
+	if siteInfo.CustomCssHtml.EnableCSP {
+		cspNonce = newCSPNonce()
+		ctx.Header("Content-Security-Policy", fmt.Sprintf(
+			"script-src 'nonce-%s' 'strict-dynamic' 'self' https:; object-src 'none'; base-uri 'self'", cspNonce))
+	}
+	data["cspNonce"] = cspNonce
+	data["HeadCode"] = htmltext.AddScriptNonce(siteInfo.CustomCssHtml.CustomHead, cspNonce)
+	data["HeaderCode"] = htmltext.AddScriptNonce(siteInfo.CustomCssHtml.CustomHeader, cspNonce)
+	data["FooterCode"] = htmltext.AddScriptNonce(siteInfo.CustomCssHtml.CustomFooter, cspNonce)
+	data["CustomJs"] = siteInfo.CustomCssHtml.CustomJs
 	data["Version"] = constant.Version
 	data["Revision"] = constant.Revision
 	_, ok := data["path"]
@@ -562,6 +638,13 @@ func (tc *TemplateController) html(ctx *gin.Context, code int, tpl string, siteI
 	ctx.HTML(code, tpl, data)
 }
 
+// newCSPNonce generate a random nonce for the scripts allowed by Content-Security-Policy
+func newCSPNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
+
 func (tc *TemplateController) Sitemap(ctx *gin.Context) {
 	if tc.checkPrivateMode(ctx) {
 		tc.Page404(ctx)
@@ -575,26 +658,61 @@ func (tc *TemplateController) SitemapPage(ctx *gin.Context) {
 		tc.Page404(ctx)
 		return
 	}
-	page := 0
 	pageParam := ctx.Param("page")
-	pageRegexp := regexp.MustCompile(`question-(.*).xml`)
+	pageRegexp := regexp.MustCompile(`^(question|tag|user|page)-(\d+)\.xml$`)
 	pageStr := pageRegexp.FindStringSubmatch(pageParam)
-	if len(pageStr) != 2 {
+	if len(pageStr) != 3 {
 		tc.Page404(ctx)
 		return
 	}
-	page = converter.StringToInt(pageStr[1])
+	page := converter.StringToInt(pageStr[2])
 	if page == 0 {
 		tc.Page404(ctx)
 		return
 	}
-	err := tc.templateRenderController.SitemapPage(ctx, page)
+	err := tc.templateRenderController.SitemapPage(ctx, pageStr[1], page)
 	if err != nil {
 		tc.Page404(ctx)
 		return
 	}
 }
 
+// ShortLinkQuestion redirect the question short link to the question page
+func (tc *TemplateController) ShortLinkQuestion(ctx *gin.Context) {
+	tc.redirectShortLink(ctx, constant.QuestionObjectType)
+}
+
+// ShortLinkAnswer redirect the answer short link to the answer page
+func (tc *TemplateController) ShortLinkAnswer(ctx *gin.Context) {
+	tc.redirectShortLink(ctx, constant.AnswerObjectType)
+}
+
+func (tc *TemplateController) redirectShortLink(ctx *gin.Context, objectType string) {
+	permalink, err := tc.templateRenderController.ResolveShortLink(ctx, objectType, ctx.Param("id"))
+	if err != nil {
+		tc.Page404(ctx)
+		return
+	}
+	ctx.Redirect(http.StatusFound, permalink)
+}
+
+// EmbedQuestion the minimal question page for embedding in other sites
+func (tc *TemplateController) EmbedQuestion(ctx *gin.Context) {
+	question, err := tc.templateRenderController.EmbedQuestion(ctx, ctx.Param("id"))
+	if err != nil {
+		var myErr *myErrors.Error
+		if errors.As(err, &myErr) && myErr.Code == http.StatusTooManyRequests {
+			ctx.String(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+			return
+		}
+		tc.Page404(ctx)
+		return
+	}
+	ctx.HTML(http.StatusOK, "embed-question.html", gin.H{
+		"question": question,
+	})
+}
+
 func (tc *TemplateController) checkPrivateMode(ctx *gin.Context) bool {
 	resp, err := tc.siteInfoService.GetSiteLogin(ctx)
 	if err != nil {
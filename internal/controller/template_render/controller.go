@@ -23,10 +23,14 @@ import (
 	"math"
 
 	"github.com/apache/incubator-answer/internal/service/content"
-	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 
 	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/oembed"
+	"github.com/apache/incubator-answer/internal/service/page"
+	"github.com/apache/incubator-answer/internal/service/permalink"
+	"github.com/apache/incubator-answer/internal/service/question_share"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/sitemap"
 	"github.com/google/wire"
 
 	"github.com/apache/incubator-answer/internal/schema"
@@ -39,13 +43,17 @@ var ProviderSetTemplateRenderController = wire.NewSet(
 )
 
 type TemplateRenderController struct {
-	questionService *content.QuestionService
-	userService     *content.UserService
-	tagService      *tag.TagService
-	answerService   *content.AnswerService
-	commentService  *comment.CommentService
-	siteInfoService siteinfo_common.SiteInfoCommonService
-	questionRepo    questioncommon.QuestionRepo
+	questionService  *content.QuestionService
+	userService      *content.UserService
+	tagService       *tag.TagService
+	answerService    *content.AnswerService
+	commentService   *comment.CommentService
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	sitemapService   *sitemap.SitemapService
+	permalinkService *permalink.PermalinkService
+	oembedService    *oembed.OEmbedService
+	pageService      *page.PageService
+	shareService     *question_share.QuestionShareService
 }
 
 func NewTemplateRenderController(
@@ -55,16 +63,24 @@ func NewTemplateRenderController(
 	answerService *content.AnswerService,
 	commentService *comment.CommentService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
-	questionRepo questioncommon.QuestionRepo,
+	sitemapService *sitemap.SitemapService,
+	permalinkService *permalink.PermalinkService,
+	oembedService *oembed.OEmbedService,
+	pageService *page.PageService,
+	shareService *question_share.QuestionShareService,
 ) *TemplateRenderController {
 	return &TemplateRenderController{
-		questionService: questionService,
-		userService:     userService,
-		tagService:      tagService,
-		answerService:   answerService,
-		commentService:  commentService,
-		questionRepo:    questionRepo,
-		siteInfoService: siteInfoService,
+		questionService:  questionService,
+		userService:      userService,
+		tagService:       tagService,
+		answerService:    answerService,
+		commentService:   commentService,
+		siteInfoService:  siteInfoService,
+		sitemapService:   sitemapService,
+		permalinkService: permalinkService,
+		oembedService:    oembedService,
+		pageService:      pageService,
+		shareService:     shareService,
 	}
 }
 
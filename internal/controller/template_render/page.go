@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package templaterender
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// CustomPage get the published custom page by slug name
+func (t *TemplateRenderController) CustomPage(ctx context.Context, slugName string) (resp *schema.GetPageResp, err error) {
+	return t.pageService.GetPage(ctx, &schema.GetPageReq{SlugName: slugName})
+}
+
+// NavPageList get the custom pages shown in the site navigation
+func (t *TemplateRenderController) NavPageList(ctx context.Context) (resp []*schema.GetPageNavResp, err error) {
+	return t.pageService.GetNavPageList(ctx)
+}
@@ -21,12 +21,13 @@ package templaterender
 
 import (
 	"html/template"
-	"math"
 	"net/http"
+	"time"
 
-	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -35,86 +36,75 @@ func (t *TemplateRenderController) Index(ctx *gin.Context, req *schema.QuestionP
 }
 
 func (t *TemplateRenderController) QuestionDetail(ctx *gin.Context, id string) (resp *schema.QuestionInfoResp, err error) {
-	return t.questionService.GetQuestion(ctx, id, "", schema.QuestionPermission{})
+	return t.questionService.GetQuestion(ctx, id, "", schema.QuestionPermission{
+		CanViewUnlisted: t.shareService.CheckShareToken(ctx, id, ctx.Query(schema.QuestionShareTokenParam)),
+	})
 }
 
-func (t *TemplateRenderController) Sitemap(ctx *gin.Context) {
-	general, err := t.siteInfoService.GetSiteGeneral(ctx)
-	if err != nil {
-		log.Error("get site general failed:", err)
-		return
-	}
-	siteInfo, err := t.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Error("get site GetSiteSeo failed:", err)
-		return
-	}
+func (t *TemplateRenderController) ResolveShortLink(ctx *gin.Context, objectType, shortID string) (string, error) {
+	return t.permalinkService.ResolveShortLink(ctx, objectType, shortID, ctx.Query(schema.ShareLinkReferralParam))
+}
 
-	questions, err := t.questionRepo.SitemapQuestions(ctx, 1, constant.SitemapMaxSize)
-	if err != nil {
-		log.Errorf("get sitemap questions failed: %s", err)
-		return
-	}
+func (t *TemplateRenderController) EmbedQuestion(ctx *gin.Context, id string) (*schema.EmbedQuestionResp, error) {
+	return t.oembedService.GetEmbedQuestion(ctx, &schema.GetEmbedQuestionReq{ID: id, ClientIP: ctx.ClientIP()})
+}
 
-	ctx.Header("Content-Type", "application/xml")
-	if len(questions) < constant.SitemapMaxSize {
-		ctx.HTML(
-			http.StatusOK, "sitemap.xml", gin.H{
-				"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-				"list":      questions,
-				"general":   general,
-				"hastitle": siteInfo.Permalink == constant.PermalinkQuestionIDAndTitle ||
-					siteInfo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
-			},
-		)
-		return
-	}
+func (t *TemplateRenderController) OEmbedDiscoveryURL(ctx *gin.Context, pageURL string) string {
+	return t.oembedService.OEmbedDiscoveryURL(ctx, pageURL)
+}
 
-	questionNum, err := t.questionRepo.GetQuestionCount(ctx)
+func (t *TemplateRenderController) Sitemap(ctx *gin.Context) {
+	urls, lastMod, err := t.sitemapService.GetSitemapIndex(ctx)
 	if err != nil {
-		log.Error("GetQuestionCount error", err)
+		log.Errorf("get sitemap index failed: %s", err)
 		return
 	}
-	var pageList []int
-	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
-	for i := 1; i <= totalPages; i++ {
-		pageList = append(pageList, i)
+	if t.notModified(ctx, lastMod) {
+		return
 	}
+	ctx.Header("Content-Type", "application/xml")
 	ctx.HTML(
 		http.StatusOK, "sitemap-list.xml", gin.H{
 			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-			"page":      pageList,
-			"general":   general,
+			"list":      urls,
 		},
 	)
 }
 
-func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, page int) error {
-	general, err := t.siteInfoService.GetSiteGeneral(ctx)
+func (t *TemplateRenderController) SitemapPage(ctx *gin.Context, sitemapType string, page int) error {
+	urls, lastMod, exist, err := t.sitemapService.GetSitemapPage(ctx, sitemapType, page)
 	if err != nil {
-		log.Error("get site general failed:", err)
+		log.Errorf("get sitemap page failed: %s", err)
 		return err
 	}
-	siteInfo, err := t.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Error("get site GetSiteSeo failed:", err)
-		return err
+	if !exist {
+		return errors.NotFound(reason.ObjectNotFound)
 	}
-
-	questions, err := t.questionRepo.SitemapQuestions(ctx, page, constant.SitemapMaxSize)
-	if err != nil {
-		log.Errorf("get sitemap questions failed: %s", err)
-		return err
+	if t.notModified(ctx, lastMod) {
+		return nil
 	}
 	ctx.Header("Content-Type", "application/xml")
 	ctx.HTML(
 		http.StatusOK, "sitemap.xml", gin.H{
 			"xmlHeader": template.HTML(`<?xml version="1.0" encoding="UTF-8"?>`),
-			"list":      questions,
-			"general":   general,
-			"hastitle": siteInfo.Permalink == constant.PermalinkQuestionIDAndTitle ||
-				siteInfo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID,
+			"list":      urls,
 		},
 	)
 	return nil
 }
+
+// notModified set the cache headers of sitemap, and response 304 if the sitemap is not modified since the client cached
+func (t *TemplateRenderController) notModified(ctx *gin.Context, lastMod time.Time) bool {
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	if lastMod.IsZero() {
+		return false
+	}
+	lastMod = lastMod.UTC().Truncate(time.Second)
+	ctx.Header("Last-Modified", lastMod.Format(http.TimeFormat))
+	since, err := http.ParseTime(ctx.GetHeader("If-Modified-Since"))
+	if err != nil || lastMod.After(since) {
+		return false
+	}
+	ctx.Status(http.StatusNotModified)
+	return true
+}
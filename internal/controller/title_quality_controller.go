@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/title_quality"
+	"github.com/gin-gonic/gin"
+)
+
+// TitleQualityController title quality controller
+type TitleQualityController struct {
+	titleQualityService *title_quality.TitleQualityService
+}
+
+// NewTitleQualityController new controller
+func NewTitleQualityController(titleQualityService *title_quality.TitleQualityService) *TitleQualityController {
+	return &TitleQualityController{titleQualityService: titleQualityService}
+}
+
+// CheckQuestionTitle check question title
+// @Summary check question title
+// @Description check the quality of the question title before posting, return the warnings and the suggested title
+// @Tags Question
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.CheckQuestionTitleReq true "title"
+// @Success 200 {object} handler.RespBody{data=schema.CheckQuestionTitleResp}
+// @Router /answer/api/v1/question/title/check [post]
+func (tc *TitleQualityController) CheckQuestionTitle(ctx *gin.Context) {
+	req := &schema.CheckQuestionTitleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := tc.titleQualityService.CheckQuestionTitle(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -20,6 +20,7 @@
 package controller
 
 import (
+	"github.com/apache/incubator-answer/internal/service/login_protection"
 	"net/url"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -33,10 +34,10 @@ import (
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
-	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
@@ -50,6 +51,9 @@ type UserController struct {
 	emailService                  *export.EmailService
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	userStatsService              *content.UserStatsService
+	emailDomainService            *email_domain.EmailDomainService
+	loginProtectionService        *login_protection.LoginProtectionService
 }
 
 // NewUserController new controller
@@ -60,6 +64,9 @@ func NewUserController(
 	emailService *export.EmailService,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	userStatsService *content.UserStatsService,
+	emailDomainService *email_domain.EmailDomainService,
+	loginProtectionService *login_protection.LoginProtectionService,
 ) *UserController {
 	return &UserController{
 		authService:                   authService,
@@ -68,6 +75,9 @@ func NewUserController(
 		emailService:                  emailService,
 		siteInfoCommonService:         siteInfoCommonService,
 		userNotificationConfigService: userNotificationConfigService,
+		userStatsService:              userStatsService,
+		emailDomainService:            emailDomainService,
+		loginProtectionService:        loginProtectionService,
 	}
 }
 
@@ -122,6 +132,24 @@ func (uc *UserController) GetOtherUserInfoByUsername(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// GetUserStats get user contribution calendar and stats
+// @Summary get user contribution calendar and stats
+// @Description get the contributions per day of the last year, top tags by answer score and answer acceptance rate of user
+// @Tags User
+// @Produce json
+// @Param username query string true "username"
+// @Success 200 {object} handler.RespBody{data=schema.GetUserStatsResp}
+// @Router /answer/api/v1/personal/user/stats [get]
+func (uc *UserController) GetUserStats(ctx *gin.Context) {
+	req := &schema.GetUserStatsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userStatsService.GetUserStats(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UserEmailLogin godoc
 // @Summary UserEmailLogin
 // @Description UserEmailLogin
@@ -136,9 +164,19 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
+	attempt := &schema.LoginAttempt{Email: req.Email, IP: ctx.ClientIP(), UserAgent: ctx.Request.UserAgent()}
+	if lockedResp, err := uc.loginProtectionService.CheckLocked(ctx, attempt); err != nil {
+		handler.HandleResponse(ctx, err, lockedResp)
+		return
+	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
-		captchaPass := uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		var captchaPass bool
+		if uc.loginProtectionService.NeedCaptcha(ctx, req.Email) {
+			captchaPass, _ = uc.actionService.VerifyCaptcha(ctx, req.CaptchaID, req.CaptchaCode)
+		} else {
+			captchaPass = uc.actionService.ActionRecordVerifyCaptcha(ctx, entity.CaptchaActionPassword, ctx.ClientIP(), req.CaptchaID, req.CaptchaCode)
+		}
 		if !captchaPass {
 			errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 				ErrorField: "captcha_code",
@@ -152,6 +190,7 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	resp, err := uc.userService.EmailLogin(ctx, req)
 	if err != nil {
 		_, _ = uc.actionService.ActionRecordAdd(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
+		uc.loginProtectionService.LoginFailed(ctx, attempt)
 		errFields := append([]*validator.FormErrorField{}, &validator.FormErrorField{
 			ErrorField: "e_mail",
 			ErrorMsg:   translator.Tr(handler.GetLang(ctx), reason.EmailOrPasswordWrong),
@@ -162,6 +201,7 @@ func (uc *UserController) UserEmailLogin(ctx *gin.Context) {
 	if !isAdmin {
 		uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
 	}
+	uc.loginProtectionService.LoginSucceeded(ctx, attempt)
 	uc.setVisitCookies(ctx, resp.VisitToken, true)
 	handler.HandleResponse(ctx, nil, resp)
 }
@@ -218,7 +258,14 @@ func (uc *UserController) UseRePassWord(ctx *gin.Context) {
 		return
 	}
 
-	err := uc.userService.UpdatePasswordWhenForgot(ctx, req)
+	errFields, err := uc.userService.UpdatePasswordWhenForgot(ctx, req)
+	if len(errFields) > 0 {
+		for _, field := range errFields {
+			field.ErrorMsg = translator.Tr(handler.GetLang(ctx), field.ErrorMsg)
+		}
+		handler.HandleResponse(ctx, err, errFields)
+		return
+	}
 	uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionPassword, ctx.ClientIP())
 	handler.HandleResponse(ctx, err, nil)
 }
@@ -239,11 +286,68 @@ func (uc *UserController) UserLogout(ctx *gin.Context) {
 	}
 	_ = uc.authService.RemoveUserCacheInfo(ctx, accessToken)
 	_ = uc.authService.RemoveAdminUserCacheInfo(ctx, accessToken)
+	_ = uc.authService.RemoveUserSession(ctx, accessToken)
 	visitToken, _ := ctx.Cookie(constant.UserVisitCookiesCacheKey)
 	_ = uc.authService.RemoveUserVisitCacheInfo(ctx, visitToken)
 	handler.HandleResponse(ctx, nil, nil)
 }
 
+// GetUserSessions get the login sessions of current user
+// @Summary get the login sessions of current user
+// @Description get the login sessions of current user with the device info and the last active time
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetUserSessionResp}
+// @Router /answer/api/v1/user/sessions [get]
+func (uc *UserController) GetUserSessions(ctx *gin.Context) {
+	req := &schema.GetUserSessionsReq{
+		UserID:      middleware.GetLoginUserIDFromContext(ctx),
+		AccessToken: middleware.ExtractToken(ctx),
+	}
+	resp, err := uc.authService.GetUserSessions(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RevokeUserSession log out the login session of current user remotely
+// @Summary log out the login session of current user remotely
+// @Description log out the login session of current user remotely
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RevokeUserSessionReq true "session"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/session [delete]
+func (uc *UserController) RevokeUserSession(ctx *gin.Context) {
+	req := &schema.RevokeUserSessionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.authService.RevokeUserSession(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RevokeOtherUserSessions log out all the other login sessions of current user
+// @Summary log out all the other login sessions of current user
+// @Description log out everywhere except the current session
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/sessions [delete]
+func (uc *UserController) RevokeOtherUserSessions(ctx *gin.Context) {
+	req := &schema.RevokeOtherUserSessionsReq{
+		UserID:      middleware.GetLoginUserIDFromContext(ctx),
+		AccessToken: middleware.ExtractToken(ctx),
+	}
+	err := uc.authService.RevokeOtherUserSessions(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserRegisterByEmail godoc
 // @Summary UserRegisterByEmail
 // @Description UserRegisterByEmail
@@ -269,10 +373,6 @@ func (uc *UserController) UserRegisterByEmail(ctx *gin.Context) {
 	if handler.BindAndCheck(ctx, req) {
 		return
 	}
-	if !checker.EmailInAllowEmailDomain(req.Email, siteInfo.AllowEmailDomains) {
-		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailIllegalDomainError), nil)
-		return
-	}
 	req.IP = ctx.ClientIP()
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
 	if !isAdmin {
@@ -426,7 +526,14 @@ func (uc *UserController) UserModifyPassWord(ctx *gin.Context) {
 		handler.HandleResponse(ctx, errors.BadRequest(reason.NewPasswordSameAsPreviousSetting), errFields)
 		return
 	}
-	err = uc.userService.UserModifyPassword(ctx, req)
+	errFields, err := uc.userService.UserModifyPassword(ctx, req)
+	if len(errFields) > 0 {
+		for _, field := range errFields {
+			field.ErrorMsg = translator.Tr(handler.GetLang(ctx), field.ErrorMsg)
+		}
+		handler.HandleResponse(ctx, err, errFields)
+		return
+	}
 	if err == nil {
 		uc.actionService.ActionRecordDel(ctx, entity.CaptchaActionEditUserinfo, req.UserID)
 	}
@@ -458,6 +565,20 @@ func (uc *UserController) UserUpdateInfo(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, errFields)
 }
 
+// GetUserProfileFields get user profile fields
+// @Summary get user profile fields
+// @Description get the extra profile fields defined by admin with the values of the current user, for the profile edit form
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetUserProfileFieldFormResp}
+// @Router /answer/api/v1/user/profile-fields [get]
+func (uc *UserController) GetUserProfileFields(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userService.GetUserProfileFieldForm(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UserUpdateInterface update user interface config
 // @Summary UserUpdateInterface update user interface config
 // @Description UserUpdateInterface update user interface config
@@ -479,6 +600,40 @@ func (uc *UserController) UserUpdateInterface(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// GetUserPreferences get the locale preferences of the user
+// @Summary get the locale preferences of the user
+// @Description get the language, time zone and date format of the user
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.GetUserPreferencesResp}
+// @Router /answer/api/v1/user/preferences [get]
+func (uc *UserController) GetUserPreferences(ctx *gin.Context) {
+	userID := middleware.GetLoginUserIDFromContext(ctx)
+	resp, err := uc.userService.GetUserPreferences(ctx, userID)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateUserPreferences update the locale preferences of the user
+// @Summary update the locale preferences of the user
+// @Description update the language, time zone and date format of the user
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateUserPreferencesReq true "UpdateUserPreferencesReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/api/v1/user/preferences [put]
+func (uc *UserController) UpdateUserPreferences(ctx *gin.Context) {
+	req := &schema.UpdateUserPreferencesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := uc.userService.UpdateUserPreferences(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // ActionRecord godoc
 // @Summary ActionRecord
 // @Description ActionRecord
@@ -567,13 +722,13 @@ func (uc *UserController) UserChangeEmailSendCode(ctx *gin.Context) {
 		return
 	}
 	// check whether email allow register or not
-	siteInfo, err := uc.siteInfoCommonService.GetSiteLogin(ctx)
+	errReason, err := uc.emailDomainService.CheckEmail(ctx, req.Email)
 	if err != nil {
 		handler.HandleResponse(ctx, err, nil)
 		return
 	}
-	if !checker.EmailInAllowEmailDomain(req.Email, siteInfo.AllowEmailDomains) {
-		handler.HandleResponse(ctx, errors.BadRequest(reason.EmailIllegalDomainError), nil)
+	if len(errReason) > 0 {
+		handler.HandleResponse(ctx, errors.BadRequest(errReason), nil)
 		return
 	}
 	isAdmin := middleware.GetUserIsAdminModerator(ctx)
@@ -630,6 +785,31 @@ func (uc *UserController) UserChangeEmailVerify(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, resp)
 }
 
+// UserChangeEmailAuthorize the old email authorize the email change
+// @Summary the old email authorize the email change, then the confirmation email will be sent to the new email
+// @Description the old email authorize the email change, then the confirmation email will be sent to the new email
+// @Tags User
+// @Accept json
+// @Produce json
+// @Param data body schema.UserChangeEmailAuthorizeReq true "UserChangeEmailAuthorizeReq"
+// @Success 200 {object} handler.RespBody{}
+// @Router /answer/api/v1/user/email/change/authorization [put]
+func (uc *UserController) UserChangeEmailAuthorize(ctx *gin.Context) {
+	req := &schema.UserChangeEmailAuthorizeReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.Content = uc.emailService.VerifyUrlExpired(ctx, req.Code)
+	if len(req.Content) == 0 {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.EmailVerifyURLExpired),
+			&schema.ForbiddenResp{Type: schema.ForbiddenReasonTypeURLExpired})
+		return
+	}
+
+	err := uc.userService.UserChangeEmailAuthorize(ctx, req.Content)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // UserRanking get user ranking
 // @Summary get user ranking
 // @Description get user ranking
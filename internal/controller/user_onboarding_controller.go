@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/user_onboarding"
+	"github.com/gin-gonic/gin"
+)
+
+// UserOnboardingController user onboarding controller
+type UserOnboardingController struct {
+	userOnboardingService *user_onboarding.UserOnboardingService
+}
+
+// NewUserOnboardingController new controller
+func NewUserOnboardingController(userOnboardingService *user_onboarding.UserOnboardingService) *UserOnboardingController {
+	return &UserOnboardingController{userOnboardingService: userOnboardingService}
+}
+
+// GetUserOnboarding get the onboarding checklist of the login user
+// @Summary get the onboarding checklist of the login user
+// @Description get the onboarding checklist of the login user, the frontend polls it to update the progress
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserOnboardingResp}
+// @Router /answer/api/v1/user/onboarding [get]
+func (uc *UserOnboardingController) GetUserOnboarding(ctx *gin.Context) {
+	req := &schema.GetUserOnboardingReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userOnboardingService.GetUserOnboarding(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// TakeOnboardingTour mark the tour of the login user as taken
+// @Summary mark the tour of the login user as taken
+// @Description mark the tour of the login user as taken
+// @Tags User
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.UserOnboardingResp}
+// @Router /answer/api/v1/user/onboarding/tour [put]
+func (uc *UserOnboardingController) TakeOnboardingTour(ctx *gin.Context) {
+	req := &schema.TakeOnboardingTourReq{}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	resp, err := uc.userOnboardingService.TakeOnboardingTour(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
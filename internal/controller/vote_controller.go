@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/action"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/gin-gonic/gin"
@@ -184,3 +185,37 @@ func (vc *VoteController) UserVotes(ctx *gin.Context) {
 	resp, err := vc.VoteService.ListUserVotes(ctx, req)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// ObjectVotes list the voters of the question or answer
+// @Summary list the voters of the question or answer
+// @Description list the votes which are not cancelled, only the user who has the permission can see them
+// @Tags Activity
+// @Security ApiKeyAuth
+// @Accept json
+// @Produce json
+// @Param object_id query string true "question or answer id"
+// @Param page query int false "page size"
+// @Param page_size query int false "page size"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetObjectVotesResp}}
+// @Router /answer/api/v1/vote/voters/page [get]
+func (vc *VoteController) ObjectVotes(ctx *gin.Context) {
+	req := &schema.GetObjectVotesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	can, err := vc.rankService.CheckOperationPermission(ctx, req.UserID, permission.VoteViewVoters, "")
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	if !can {
+		handler.HandleResponse(ctx, errors.Forbidden(reason.RankFailToMeetTheCondition), nil)
+		return
+	}
+
+	resp, err := vc.VoteService.ListObjectVotes(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
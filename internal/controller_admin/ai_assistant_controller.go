@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/gin-gonic/gin"
+)
+
+// AIAssistantController ai assistant settings controller
+type AIAssistantController struct {
+	aiAssistantService *ai_assistant.AIAssistantService
+	embeddingService   *embedding.EmbeddingService
+}
+
+// NewAIAssistantController new controller
+func NewAIAssistantController(
+	aiAssistantService *ai_assistant.AIAssistantService,
+	embeddingService *embedding.EmbeddingService,
+) *AIAssistantController {
+	return &AIAssistantController{
+		aiAssistantService: aiAssistantService,
+		embeddingService:   embeddingService,
+	}
+}
+
+// GetAIAssistant get ai assistant settings
+// @Summary get ai assistant settings
+// @Description get the settings of the built-in OpenAI-compatible provider and the daily quota
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteAIAssistantResp}
+// @Router /answer/admin/api/ai-assistant [get]
+func (ac *AIAssistantController) GetAIAssistant(ctx *gin.Context) {
+	resp, err := ac.aiAssistantService.GetAIAssistant(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAIAssistant update ai assistant settings
+// @Summary update ai assistant settings
+// @Description update the settings of the built-in OpenAI-compatible provider and the daily quota
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteAIAssistantReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/ai-assistant [put]
+func (ac *AIAssistantController) UpdateAIAssistant(ctx *gin.Context) {
+	req := &schema.SiteAIAssistantReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ac.aiAssistantService.UpdateAIAssistant(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ReindexEmbeddings reindex embeddings
+// @Summary reindex embeddings
+// @Description remove all the embeddings after the embedding model is changed, the questions and answers
+// @Description are embedded again by the embedding_sync job
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/embedding/reindex [post]
+func (ac *AIAssistantController) ReindexEmbeddings(ctx *gin.Context) {
+	err := ac.embeddingService.ReindexEmbeddings(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
+	"github.com/gin-gonic/gin"
+)
+
+// AnswerVerificationController answer verification controller
+type AnswerVerificationController struct {
+	answerVerificationService *answer_verification.AnswerVerificationService
+}
+
+// NewAnswerVerificationController new controller
+func NewAnswerVerificationController(
+	answerVerificationService *answer_verification.AnswerVerificationService) *AnswerVerificationController {
+	return &AnswerVerificationController{answerVerificationService: answerVerificationService}
+}
+
+// GetAnswerVerificationConfig get answer verification config
+// @Summary get answer verification config
+// @Description get the expiration and the re-verification after the edit of the answer verification
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteAnswerVerificationReq}
+// @Router /answer/admin/api/answer-verification [get]
+func (ac *AnswerVerificationController) GetAnswerVerificationConfig(ctx *gin.Context) {
+	resp, err := ac.answerVerificationService.GetAnswerVerificationConfig(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateAnswerVerificationConfig update answer verification config
+// @Summary update answer verification config
+// @Description update the expiration and the re-verification after the edit of the answer verification
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteAnswerVerificationReq true "config"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/answer-verification [put]
+func (ac *AnswerVerificationController) UpdateAnswerVerificationConfig(ctx *gin.Context) {
+	req := &schema.SiteAnswerVerificationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ac.answerVerificationService.UpdateAnswerVerificationConfig(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetTagExperts get tag experts
+// @Summary get tag experts
+// @Description get the experts who can verify the answers of the questions with the tag
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param tag_id query string true "tag id"
+// @Success 200 {object} handler.RespBody{data=[]schema.TagExpertResp}
+// @Router /answer/admin/api/tag/experts [get]
+func (ac *AnswerVerificationController) GetTagExperts(ctx *gin.Context) {
+	req := &schema.GetTagExpertsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ac.answerVerificationService.GetTagExperts(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddTagExpert add tag expert
+// @Summary add tag expert
+// @Description add the user to the experts of the tag
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddTagExpertReq true "expert"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/experts [post]
+func (ac *AnswerVerificationController) AddTagExpert(ctx *gin.Context) {
+	req := &schema.AddTagExpertReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ac.answerVerificationService.AddTagExpert(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTagExpert remove tag expert
+// @Summary remove tag expert
+// @Description remove the user from the experts of the tag, the verifications by the user are kept
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveTagExpertReq true "expert"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag/experts [delete]
+func (ac *AnswerVerificationController) RemoveTagExpert(ctx *gin.Context) {
+	req := &schema.RemoveTagExpertReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ac.answerVerificationService.RemoveTagExpert(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
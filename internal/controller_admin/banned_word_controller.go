@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/gin-gonic/gin"
+)
+
+// BannedWordController banned word controller
+type BannedWordController struct {
+	bannedWordService *banned_word.BannedWordService
+}
+
+// NewBannedWordController new controller
+func NewBannedWordController(bannedWordService *banned_word.BannedWordService) *BannedWordController {
+	return &BannedWordController{bannedWordService: bannedWordService}
+}
+
+// GetBannedWords get banned word rules
+// @Summary get banned word rules
+// @Description get the banned word rules applied to the contents and the user names
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteBannedWordsReq}
+// @Router /answer/admin/api/banned-words [get]
+func (bc *BannedWordController) GetBannedWords(ctx *gin.Context) {
+	resp, err := bc.bannedWordService.GetBannedWords(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateBannedWords update banned word rules
+// @Summary update banned word rules
+// @Description update the banned word rules, the rules are applied in order
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteBannedWordsReq true "rules"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/banned-words [put]
+func (bc *BannedWordController) UpdateBannedWords(ctx *gin.Context) {
+	req := &schema.SiteBannedWordsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := bc.bannedWordService.UpdateBannedWords(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetBannedWordHitPage get banned word hit page
+// @Summary get banned word hit page
+// @Description get the contents and the user names which trigger the banned word rules
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param object_type query string false "object type" Enums(question, answer, comment, user)
+// @Param action query string false "action" Enums(reject, replace, review)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.BannedWordHitResp}}
+// @Router /answer/admin/api/banned-words/hits/page [get]
+func (bc *BannedWordController) GetBannedWordHitPage(ctx *gin.Context) {
+	req := &schema.GetBannedWordHitPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := bc.bannedWordService.GetBannedWordHitPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
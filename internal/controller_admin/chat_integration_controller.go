@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/chat_integration"
+	"github.com/gin-gonic/gin"
+)
+
+// ChatIntegrationController chat integration settings controller
+type ChatIntegrationController struct {
+	chatIntegrationService *chat_integration.ChatIntegrationService
+}
+
+// NewChatIntegrationController new controller
+func NewChatIntegrationController(
+	chatIntegrationService *chat_integration.ChatIntegrationService,
+) *ChatIntegrationController {
+	return &ChatIntegrationController{chatIntegrationService: chatIntegrationService}
+}
+
+// GetChatIntegration get chat integration settings
+// @Summary get chat integration settings
+// @Description get the secrets of the Slack slash command and the Teams outgoing webhook
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteChatIntegrationResp}
+// @Router /answer/admin/api/chat-integration [get]
+func (cc *ChatIntegrationController) GetChatIntegration(ctx *gin.Context) {
+	resp, err := cc.chatIntegrationService.GetChatIntegration(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateChatIntegration update chat integration settings
+// @Summary update chat integration settings
+// @Description update the secrets of the Slack slash command and the Teams outgoing webhook
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteChatIntegrationReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/chat-integration [put]
+func (cc *ChatIntegrationController) UpdateChatIntegration(ctx *gin.Context) {
+	req := &schema.SiteChatIntegrationReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := cc.chatIntegrationService.UpdateChatIntegration(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
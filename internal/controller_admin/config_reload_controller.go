@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/gin-gonic/gin"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// ConfigReloadController reload the config file controller
+type ConfigReloadController struct {
+}
+
+// NewConfigReloadController new controller
+func NewConfigReloadController() *ConfigReloadController {
+	return &ConfigReloadController{}
+}
+
+// ReloadConfig reload the config file
+// @Summary reload the config file
+// @Description read the config file again and apply the log level, the smtp server and the cache ttl without restarting
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/config/reload [post]
+func (cc *ConfigReloadController) ReloadConfig(ctx *gin.Context) {
+	if err := reload.Reload(ctx); err != nil {
+		handler.HandleResponse(ctx, errors.InternalServer(reason.UnknownError).WithError(err).WithStack(), nil)
+		return
+	}
+	handler.HandleResponse(ctx, nil, nil)
+}
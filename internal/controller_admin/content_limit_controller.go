@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentLimitController content length limits controller
+type ContentLimitController struct {
+	contentLimitService *content_limit.ContentLimitService
+}
+
+// NewContentLimitController new controller
+func NewContentLimitController(contentLimitService *content_limit.ContentLimitService) *ContentLimitController {
+	return &ContentLimitController{contentLimitService: contentLimitService}
+}
+
+// GetContentLimits get content length limits
+// @Summary get content length limits
+// @Description get the length limits of titles, bodies and comments and the overrides of the roles
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteContentLimitsReq}
+// @Router /answer/admin/api/content-limits [get]
+func (cc *ContentLimitController) GetContentLimits(ctx *gin.Context) {
+	resp, err := cc.contentLimitService.GetContentLimits(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateContentLimits update content length limits
+// @Summary update content length limits
+// @Description update the length limits of titles, bodies and comments and the overrides of the roles
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteContentLimitsReq true "limits"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/content-limits [put]
+func (cc *ContentLimitController) UpdateContentLimits(ctx *gin.Context) {
+	req := &schema.SiteContentLimitsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := cc.contentLimitService.UpdateContentLimits(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
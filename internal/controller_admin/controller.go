@@ -28,4 +28,34 @@ var ProviderSetController = wire.NewSet(
 	NewSiteInfoController,
 	NewRoleController,
 	NewPluginController,
+	NewProfileFieldController,
+	NewQuestionSLAController,
+	NewSchedulerController,
+	NewRevisionCompactionController,
+	NewMediaProxyController,
+	NewExperimentController,
+	NewFeatureFlagController,
+	NewConfigReloadController,
+	NewEventStreamController,
+	NewInboundQuestionController,
+	NewChatIntegrationController,
+	NewGitHubController,
+	NewJiraController,
+	NewMaintenanceController,
+	NewSlowQueryController,
+	NewContentLimitController,
+	NewBannedWordController,
+	NewUsernamePolicyController,
+	NewModerationRuleController,
+	NewQuestionWorkflowController,
+	NewAnswerVerificationController,
+	NewTagAutoSubscriptionController,
+	NewModerationTriageController,
+	NewLegalHoldController,
+	NewIPLoggingController,
+	NewLoginProtectionController,
+	NewEmailDeliveryController,
+	NewEmailTemplateController,
+	NewPermissionPolicyController,
+	NewAIAssistantController,
 )
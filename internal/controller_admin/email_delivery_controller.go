@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailDeliveryController email delivery controller
+type EmailDeliveryController struct {
+	emailService *export.EmailService
+}
+
+// NewEmailDeliveryController new controller
+func NewEmailDeliveryController(emailService *export.EmailService) *EmailDeliveryController {
+	return &EmailDeliveryController{emailService: emailService}
+}
+
+// GetEmailSendLogPage get email send log page
+// @Summary get email send log page
+// @Description get the emails sent by the site with the delivery status, the latest first
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param email query string false "only the emails sent to this address"
+// @Param status query string false "sent failed suppressed delivered bounced complained"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetEmailSendLogResp}}
+// @Router /answer/admin/api/email/logs/page [get]
+func (ec *EmailDeliveryController) GetEmailSendLogPage(ctx *gin.Context) {
+	req := &schema.GetEmailSendLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.emailService.GetEmailSendLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetEmailSuppressionPage get email suppression page
+// @Summary get email suppression page
+// @Description get the addresses which no email is sent to, the latest first
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param email query string false "only this address"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetEmailSuppressionResp}}
+// @Router /answer/admin/api/email/suppressions/page [get]
+func (ec *EmailDeliveryController) GetEmailSuppressionPage(ctx *gin.Context) {
+	req := &schema.GetEmailSuppressionPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.emailService.GetEmailSuppressionPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// RemoveEmailSuppression remove email suppression
+// @Summary remove email suppression
+// @Description remove the suppressed address, the emails are sent to it again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveEmailSuppressionReq true "suppression"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/email/suppression [delete]
+func (ec *EmailDeliveryController) RemoveEmailSuppression(ctx *gin.Context) {
+	req := &schema.RemoveEmailSuppressionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.emailService.RemoveEmailSuppression(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/gin-gonic/gin"
+)
+
+// EmailTemplateController email template controller
+type EmailTemplateController struct {
+	emailService *export.EmailService
+}
+
+// NewEmailTemplateController new controller
+func NewEmailTemplateController(emailService *export.EmailService) *EmailTemplateController {
+	return &EmailTemplateController{emailService: emailService}
+}
+
+// GetEmailTemplateList get email template list
+// @Summary get email template list
+// @Description get the email templates which can be customized with their variables
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetEmailTemplateListResp}
+// @Router /answer/admin/api/email/templates [get]
+func (ec *EmailTemplateController) GetEmailTemplateList(ctx *gin.Context) {
+	resp, err := ec.emailService.GetEmailTemplateList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetEmailTemplate get email template
+// @Summary get email template
+// @Description get the customized and the default email template of the language
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param key query string true "template key"
+// @Param language query string true "language"
+// @Success 200 {object} handler.RespBody{data=schema.GetEmailTemplateResp}
+// @Router /answer/admin/api/email/template [get]
+func (ec *EmailTemplateController) GetEmailTemplate(ctx *gin.Context) {
+	req := &schema.GetEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.emailService.GetEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveEmailTemplate save email template
+// @Summary save email template
+// @Description customize the email template of the language, empty subject and body mean the default template is used
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SaveEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/email/template [put]
+func (ec *EmailTemplateController) SaveEmailTemplate(ctx *gin.Context) {
+	req := &schema.SaveEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.emailService.SaveEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveEmailTemplate remove email template
+// @Summary remove email template
+// @Description remove the customized email template of the language, the default template is used again
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/email/template [delete]
+func (ec *EmailTemplateController) RemoveEmailTemplate(ctx *gin.Context) {
+	req := &schema.RemoveEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.emailService.RemoveEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// PreviewEmailTemplate preview email template
+// @Summary preview email template
+// @Description render the email template with the sample data, the email is also sent to the test recipient if it is set
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.PreviewEmailTemplateReq true "email template"
+// @Success 200 {object} handler.RespBody{data=schema.PreviewEmailTemplateResp}
+// @Router /answer/admin/api/email/template/preview [post]
+func (ec *EmailTemplateController) PreviewEmailTemplate(ctx *gin.Context) {
+	req := &schema.PreviewEmailTemplateReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.emailService.PreviewEmailTemplate(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_stream"
+	"github.com/gin-gonic/gin"
+)
+
+// EventStreamController event stream settings controller
+type EventStreamController struct {
+	eventStreamService *event_stream.EventStreamService
+}
+
+// NewEventStreamController new controller
+func NewEventStreamController(eventStreamService *event_stream.EventStreamService) *EventStreamController {
+	return &EventStreamController{eventStreamService: eventStreamService}
+}
+
+// GetEventStream get event stream settings
+// @Summary get event stream settings
+// @Description get the message broker and the events published to it
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteEventStreamResp}
+// @Router /answer/admin/api/event-stream [get]
+func (ec *EventStreamController) GetEventStream(ctx *gin.Context) {
+	resp, err := ec.eventStreamService.GetEventStream(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateEventStream update event stream settings
+// @Summary update event stream settings
+// @Description update the message broker and the events published to it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteEventStreamReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/event-stream [put]
+func (ec *EventStreamController) UpdateEventStream(ctx *gin.Context) {
+	req := &schema.SiteEventStreamReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.eventStreamService.UpdateEventStream(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
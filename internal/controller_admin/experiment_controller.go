@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/experiment"
+	"github.com/gin-gonic/gin"
+)
+
+// ExperimentController experiment settings controller
+type ExperimentController struct {
+	experimentService *experiment.ExperimentService
+}
+
+// NewExperimentController new controller
+func NewExperimentController(experimentService *experiment.ExperimentService) *ExperimentController {
+	return &ExperimentController{experimentService: experimentService}
+}
+
+// GetExperiments get experiments settings
+// @Summary get experiments settings
+// @Description get the experiments with the variants and the traffic weights
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteExperimentsResp}
+// @Router /answer/admin/api/experiments [get]
+func (ec *ExperimentController) GetExperiments(ctx *gin.Context) {
+	resp, err := ec.experimentService.GetExperiments(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateExperiments update experiments settings
+// @Summary update experiments settings
+// @Description update the experiments with the variants and the traffic weights
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteExperimentsReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/experiments [put]
+func (ec *ExperimentController) UpdateExperiments(ctx *gin.Context) {
+	req := &schema.SiteExperimentsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ec.experimentService.UpdateExperiments(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetExperimentStats get experiment statistics
+// @Summary get experiment statistics
+// @Description get the number of the exposed users and the converted users of each variant
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param experiment_key query string true "experiment key"
+// @Success 200 {object} handler.RespBody{data=schema.GetExperimentStatsResp}
+// @Router /answer/admin/api/experiment/stats [get]
+func (ec *ExperimentController) GetExperimentStats(ctx *gin.Context) {
+	req := &schema.GetExperimentStatsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ec.experimentService.GetExperimentStats(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	"github.com/gin-gonic/gin"
+)
+
+// FeatureFlagController feature flags settings controller
+type FeatureFlagController struct {
+	featureFlagService *feature_flag.FeatureFlagService
+}
+
+// NewFeatureFlagController new controller
+func NewFeatureFlagController(featureFlagService *feature_flag.FeatureFlagService) *FeatureFlagController {
+	return &FeatureFlagController{featureFlagService: featureFlagService}
+}
+
+// GetFeatureFlags get feature flags settings
+// @Summary get feature flags settings
+// @Description get whether each feature is on, and the roles it is available to
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteFeatureFlagsResp}
+// @Router /answer/admin/api/feature-flags [get]
+func (fc *FeatureFlagController) GetFeatureFlags(ctx *gin.Context) {
+	resp, err := fc.featureFlagService.GetFeatureFlags(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateFeatureFlags update feature flags settings
+// @Summary update feature flags settings
+// @Description turn the features on or off, for the whole site or for some roles
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteFeatureFlagsReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/feature-flags [put]
+func (fc *FeatureFlagController) UpdateFeatureFlags(ctx *gin.Context) {
+	req := &schema.SiteFeatureFlagsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := fc.featureFlagService.UpdateFeatureFlags(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/github_integration"
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubController GitHub integration settings controller
+type GitHubController struct {
+	gitHubService *github_integration.GitHubService
+}
+
+// NewGitHubController new controller
+func NewGitHubController(gitHubService *github_integration.GitHubService) *GitHubController {
+	return &GitHubController{gitHubService: gitHubService}
+}
+
+// GetGitHub get GitHub integration settings
+// @Summary get GitHub integration settings
+// @Description get the GitHub App and whether the links are unfurled and cross-referenced
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteGitHubResp}
+// @Router /answer/admin/api/github [get]
+func (gc *GitHubController) GetGitHub(ctx *gin.Context) {
+	resp, err := gc.gitHubService.GetGitHub(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateGitHub update GitHub integration settings
+// @Summary update GitHub integration settings
+// @Description update the GitHub App and whether the links are unfurled and cross-referenced
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteGitHubReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/github [put]
+func (gc *GitHubController) UpdateGitHub(ctx *gin.Context) {
+	req := &schema.SiteGitHubReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := gc.gitHubService.UpdateGitHub(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
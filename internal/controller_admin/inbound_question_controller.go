@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/inbound_question"
+	"github.com/gin-gonic/gin"
+)
+
+// InboundQuestionController inbound question settings controller
+type InboundQuestionController struct {
+	inboundQuestionService *inbound_question.InboundQuestionService
+}
+
+// NewInboundQuestionController new controller
+func NewInboundQuestionController(
+	inboundQuestionService *inbound_question.InboundQuestionService,
+) *InboundQuestionController {
+	return &InboundQuestionController{inboundQuestionService: inboundQuestionService}
+}
+
+// GetInboundQuestionSetting get inbound question settings
+// @Summary get inbound question settings
+// @Description get the api key and the mailbox of the questions sent by the external systems
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteInboundQuestionResp}
+// @Router /answer/admin/api/inbound-question [get]
+func (ic *InboundQuestionController) GetInboundQuestionSetting(ctx *gin.Context) {
+	resp, err := ic.inboundQuestionService.GetInboundQuestionSetting(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateInboundQuestionSetting update inbound question settings
+// @Summary update inbound question settings
+// @Description update the api key and the mailbox of the questions sent by the external systems
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteInboundQuestionReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/inbound-question [put]
+func (ic *InboundQuestionController) UpdateInboundQuestionSetting(ctx *gin.Context) {
+	req := &schema.SiteInboundQuestionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ic.inboundQuestionService.UpdateInboundQuestionSetting(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/gin-gonic/gin"
+)
+
+// IPLoggingController ip logging controller
+type IPLoggingController struct {
+	ipLoggingService *ip_logging.IPLoggingService
+}
+
+// NewIPLoggingController new controller
+func NewIPLoggingController(ipLoggingService *ip_logging.IPLoggingService) *IPLoggingController {
+	return &IPLoggingController{ipLoggingService: ipLoggingService}
+}
+
+// GetIPLogging get the ip logging setting
+// @Summary get the ip logging setting
+// @Description get the ip logging setting
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteIPLoggingReq}
+// @Router /answer/admin/api/ip-logging [get]
+func (ic *IPLoggingController) GetIPLogging(ctx *gin.Context) {
+	resp, err := ic.ipLoggingService.GetIPLogging(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateIPLogging update the ip logging setting
+// @Summary update the ip logging setting
+// @Description update the ip logging setting, the records follow the ip retention policy of the legal settings
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteIPLoggingReq true "ip logging"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/ip-logging [put]
+func (ic *IPLoggingController) UpdateIPLogging(ctx *gin.Context) {
+	req := &schema.SiteIPLoggingReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := ic.ipLoggingService.UpdateIPLogging(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPostIPRecordPage get post ip record page
+// @Summary get post ip record page
+// @Description get the ip addresses, user agents and locations recorded when the posts are created
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Param ip query string false "ip address"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.PostIPRecordResp}}
+// @Router /answer/admin/api/ip-records [get]
+func (ic *IPLoggingController) GetPostIPRecordPage(ctx *gin.Context) {
+	req := &schema.GetPostIPRecordPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ic.ipLoggingService.GetPostIPRecordPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetRelatedAccounts get related accounts
+// @Summary get the accounts sharing the ip addresses or the user agents with the user
+// @Description get the accounts sharing the ip addresses or the user agents of the posts and the login sessions
+// @Description with the user, it helps to investigate the sockpuppets
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param user_id query string true "user id"
+// @Success 200 {object} handler.RespBody{data=[]schema.RelatedAccountResp}
+// @Router /answer/admin/api/ip-records/related-accounts [get]
+func (ic *IPLoggingController) GetRelatedAccounts(ctx *gin.Context) {
+	req := &schema.GetRelatedAccountsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := ic.ipLoggingService.GetRelatedAccounts(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
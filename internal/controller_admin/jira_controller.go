@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/jira_integration"
+	"github.com/gin-gonic/gin"
+)
+
+// JiraController Jira integration settings controller
+type JiraController struct {
+	jiraService *jira_integration.JiraService
+}
+
+// NewJiraController new controller
+func NewJiraController(jiraService *jira_integration.JiraService) *JiraController {
+	return &JiraController{jiraService: jiraService}
+}
+
+// GetJira get Jira integration settings
+// @Summary get Jira integration settings
+// @Description get the Jira site, the project and the field mapping of the escalated questions
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteJiraResp}
+// @Router /answer/admin/api/jira [get]
+func (jc *JiraController) GetJira(ctx *gin.Context) {
+	resp, err := jc.jiraService.GetJira(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateJira update Jira integration settings
+// @Summary update Jira integration settings
+// @Description update the Jira site, the project and the field mapping of the escalated questions
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteJiraReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/jira [put]
+func (jc *JiraController) UpdateJira(ctx *gin.Context) {
+	req := &schema.SiteJiraReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := jc.jiraService.UpdateJira(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
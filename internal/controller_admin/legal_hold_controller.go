@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/gin-gonic/gin"
+)
+
+// LegalHoldController legal hold controller
+type LegalHoldController struct {
+	legalHoldService *legal_hold.LegalHoldService
+}
+
+// NewLegalHoldController new controller
+func NewLegalHoldController(legalHoldService *legal_hold.LegalHoldService) *LegalHoldController {
+	return &LegalHoldController{legalHoldService: legalHoldService}
+}
+
+// GetLegalHoldPage get legal hold page
+// @Summary get legal hold page
+// @Description get legal hold page
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param status query string false "status" Enums(active, released)
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.LegalHoldResp}}
+// @Router /answer/admin/api/legal-holds [get]
+func (lc *LegalHoldController) GetLegalHoldPage(ctx *gin.Context) {
+	req := &schema.GetLegalHoldPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := lc.legalHoldService.GetLegalHoldPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// PlaceLegalHold place legal hold
+// @Summary place legal hold
+// @Description place a legal hold on the user or the post, only the super admin can do it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.PlaceLegalHoldReq true "legal hold"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/legal-hold [post]
+func (lc *LegalHoldController) PlaceLegalHold(ctx *gin.Context) {
+	req := &schema.PlaceLegalHoldReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := lc.legalHoldService.PlaceLegalHold(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// ReleaseLegalHold release legal hold
+// @Summary release legal hold
+// @Description release the legal hold, only the super admin can do it
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.ReleaseLegalHoldReq true "legal hold"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/legal-hold/release [put]
+func (lc *LegalHoldController) ReleaseLegalHold(ctx *gin.Context) {
+	req := &schema.ReleaseLegalHoldReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	err := lc.legalHoldService.ReleaseLegalHold(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetLegalHoldLogPage get legal hold log page
+// @Summary get legal hold log page
+// @Description get the records of placing, releasing and blocked deletions of the legal holds
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param object_id query string false "object id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.LegalHoldLogResp}}
+// @Router /answer/admin/api/legal-hold/logs [get]
+func (lc *LegalHoldController) GetLegalHoldLogPage(ctx *gin.Context) {
+	req := &schema.GetLegalHoldLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := lc.legalHoldService.GetLegalHoldLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/login_protection"
+	"github.com/gin-gonic/gin"
+)
+
+// LoginProtectionController login protection controller
+type LoginProtectionController struct {
+	loginProtectionService *login_protection.LoginProtectionService
+}
+
+// NewLoginProtectionController new controller
+func NewLoginProtectionController(
+	loginProtectionService *login_protection.LoginProtectionService,
+) *LoginProtectionController {
+	return &LoginProtectionController{loginProtectionService: loginProtectionService}
+}
+
+// GetLoginFailurePage get login failure page
+// @Summary get login failure page
+// @Description get the recent failed sign-in attempts, the latest first
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param email query string false "only the attempts with this email"
+// @Param ip query string false "only the attempts from this ip address"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetLoginFailureResp}}
+// @Router /answer/admin/api/user/login-failures/page [get]
+func (lc *LoginProtectionController) GetLoginFailurePage(ctx *gin.Context) {
+	req := &schema.GetLoginFailurePageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := lc.loginProtectionService.GetLoginFailurePage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
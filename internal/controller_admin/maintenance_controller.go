@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/maintenance"
+	"github.com/gin-gonic/gin"
+)
+
+// MaintenanceController maintenance mode controller
+type MaintenanceController struct {
+	maintenanceService *maintenance.MaintenanceService
+}
+
+// NewMaintenanceController new controller
+func NewMaintenanceController(maintenanceService *maintenance.MaintenanceService) *MaintenanceController {
+	return &MaintenanceController{maintenanceService: maintenanceService}
+}
+
+// GetMaintenance get maintenance mode
+// @Summary get maintenance mode
+// @Description get whether the site is read-only and the message shown to the users
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteMaintenanceResp}
+// @Router /answer/admin/api/maintenance [get]
+func (mc *MaintenanceController) GetMaintenance(ctx *gin.Context) {
+	resp, err := mc.maintenanceService.GetMaintenance(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateMaintenance update maintenance mode
+// @Summary update maintenance mode
+// @Description turn the read-only mode of the site on or off
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteMaintenanceReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/maintenance [put]
+func (mc *MaintenanceController) UpdateMaintenance(ctx *gin.Context) {
+	req := &schema.SiteMaintenanceReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := mc.maintenanceService.UpdateMaintenance(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
+	"github.com/gin-gonic/gin"
+)
+
+// MediaProxyController media proxy settings controller
+type MediaProxyController struct {
+	mediaProxyService *media_proxy.MediaProxyService
+}
+
+// NewMediaProxyController new controller
+func NewMediaProxyController(mediaProxyService *media_proxy.MediaProxyService) *MediaProxyController {
+	return &MediaProxyController{mediaProxyService: mediaProxyService}
+}
+
+// GetMediaProxy get media proxy settings
+// @Summary get media proxy settings
+// @Description get whether the external images are loaded through the site, the max size and the cache hours
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteMediaProxyResp}
+// @Router /answer/admin/api/media-proxy [get]
+func (mc *MediaProxyController) GetMediaProxy(ctx *gin.Context) {
+	resp, err := mc.mediaProxyService.GetMediaProxy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateMediaProxy update media proxy settings
+// @Summary update media proxy settings
+// @Description update whether the external images are loaded through the site, the max size and the cache hours
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteMediaProxyReq true "settings"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/media-proxy [put]
+func (mc *MediaProxyController) UpdateMediaProxy(ctx *gin.Context) {
+	req := &schema.SiteMediaProxyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := mc.mediaProxyService.UpdateMediaProxy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/moderation_rule"
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationRuleController moderation rule controller
+type ModerationRuleController struct {
+	moderationRuleService *moderation_rule.ModerationRuleService
+}
+
+// NewModerationRuleController new controller
+func NewModerationRuleController(moderationRuleService *moderation_rule.ModerationRuleService) *ModerationRuleController {
+	return &ModerationRuleController{moderationRuleService: moderationRuleService}
+}
+
+// GetModerationRules get moderation rules
+// @Summary get moderation rules
+// @Description get the moderation rules evaluated when the question is asked or edited
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteModerationRulesReq}
+// @Router /answer/admin/api/moderation-rules [get]
+func (mc *ModerationRuleController) GetModerationRules(ctx *gin.Context) {
+	resp, err := mc.moderationRuleService.GetModerationRules(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateModerationRules update moderation rules
+// @Summary update moderation rules
+// @Description update the moderation rules, the actions of all the matched rules are applied
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteModerationRulesReq true "rules"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/moderation-rules [put]
+func (mc *ModerationRuleController) UpdateModerationRules(ctx *gin.Context) {
+	req := &schema.SiteModerationRulesReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := mc.moderationRuleService.UpdateModerationRules(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetModerationRuleHitPage get moderation rule hit page
+// @Summary get moderation rule hit page
+// @Description get the questions which match the moderation rules, including the hits of the dry run rules
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param rule_name query string false "rule name"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.ModerationRuleHitResp}}
+// @Router /answer/admin/api/moderation-rules/hits/page [get]
+func (mc *ModerationRuleController) GetModerationRuleHitPage(ctx *gin.Context) {
+	req := &schema.GetModerationRuleHitPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := mc.moderationRuleService.GetModerationRuleHitPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/middleware"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/moderation_triage"
+	"github.com/gin-gonic/gin"
+)
+
+// ModerationTriageController moderation triage controller
+type ModerationTriageController struct {
+	moderationTriageService *moderation_triage.ModerationTriageService
+}
+
+// NewModerationTriageController new controller
+func NewModerationTriageController(
+	moderationTriageService *moderation_triage.ModerationTriageService) *ModerationTriageController {
+	return &ModerationTriageController{moderationTriageService: moderationTriageService}
+}
+
+// GetTriageDashboard get triage dashboard
+// @Summary get triage dashboard
+// @Description get the amount and the latest items of the flagged posts, close and reopen requests, queued posts,
+// @Description questions breaching the SLA and spam suspects
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param limit query int false "the number of the latest items of each section"
+// @Success 200 {object} handler.RespBody{data=schema.TriageDashboardResp}
+// @Router /answer/admin/api/triage [get]
+func (mc *ModerationTriageController) GetTriageDashboard(ctx *gin.Context) {
+	req := &schema.GetTriageDashboardReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := mc.moderationTriageService.GetTriageDashboard(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// TriageAction handle the triage item
+// @Summary handle the triage item
+// @Description handle the item of the triage dashboard with the quick action
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.TriageActionReq true "action"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/triage/action [put]
+func (mc *ModerationTriageController) TriageAction(ctx *gin.Context) {
+	req := &schema.TriageActionReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.UserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IsAdmin = middleware.GetUserIsAdminModerator(ctx)
+	err := mc.moderationTriageService.TriageAction(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permission_policy"
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionPolicyController permission policy controller
+type PermissionPolicyController struct {
+	policyService *permission_policy.PermissionPolicyService
+}
+
+// NewPermissionPolicyController new controller
+func NewPermissionPolicyController(policyService *permission_policy.PermissionPolicyService) *PermissionPolicyController {
+	return &PermissionPolicyController{policyService: policyService}
+}
+
+// GetPermissionPolicy get permission policy
+// @Summary get permission policy
+// @Description get the rules of the routes and the actions in the permission policy
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SitePermissionPolicyResp}
+// @Router /answer/admin/api/permission-policy [get]
+func (pc *PermissionPolicyController) GetPermissionPolicy(ctx *gin.Context) {
+	resp, err := pc.policyService.GetPermissionPolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdatePermissionPolicy update permission policy
+// @Summary update permission policy
+// @Description replace all the rules of the permission policy, it takes effect immediately
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SitePermissionPolicyReq true "policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/permission-policy [put]
+func (pc *PermissionPolicyController) UpdatePermissionPolicy(ctx *gin.Context) {
+	req := &schema.SitePermissionPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := pc.policyService.UpdatePermissionPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
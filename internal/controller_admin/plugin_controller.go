@@ -20,8 +20,6 @@
 package controller_admin
 
 import (
-	"encoding/json"
-
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
@@ -32,11 +30,18 @@ import (
 // PluginController role controller
 type PluginController struct {
 	pluginCommonService *plugin_common.PluginCommonService
+	pluginJobService    *plugin_common.PluginJobService
 }
 
 // NewPluginController new controller
-func NewPluginController(pluginCommonService *plugin_common.PluginCommonService) *PluginController {
-	return &PluginController{pluginCommonService: pluginCommonService}
+func NewPluginController(
+	pluginCommonService *plugin_common.PluginCommonService,
+	pluginJobService *plugin_common.PluginJobService,
+) *PluginController {
+	return &PluginController{
+		pluginCommonService: pluginCommonService,
+		pluginJobService:    pluginJobService,
+	}
 }
 
 // GetAllPluginStatus get all plugins status
@@ -96,6 +101,7 @@ func (pc *PluginController) GetPluginList(ctx *gin.Context) {
 			Version:     info.Version,
 			Enabled:     plugin.StatusManager.IsEnabled(info.SlugName),
 			HaveConfig:  pluginConfigMapping[info.SlugName],
+			HaveJob:     pc.pluginJobService.HasPluginJob(info.SlugName),
 			Link:        info.Link,
 		})
 		return nil
@@ -208,18 +214,49 @@ func (pc *PluginController) UpdatePluginConfig(ctx *gin.Context) {
 		return
 	}
 
-	configFields, _ := json.Marshal(req.ConfigFields)
-	err := plugin.CallConfig(func(fn plugin.Config) error {
-		if fn.Info().SlugName == req.PluginSlugName {
-			return fn.ConfigReceiver(configFields)
-		}
-		return nil
-	})
-	if err != nil {
-		handler.HandleResponse(ctx, err, nil)
+	errFields, err := pc.pluginCommonService.UpdatePluginConfig(ctx, req)
+	if len(errFields) > 0 {
+		handler.HandleResponse(ctx, err, errFields)
+		return
+	}
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// GetPluginJobList get plugin scheduled job list
+// @Summary get plugin scheduled job list
+// @Description get plugin scheduled job list with the last run status
+// @Tags AdminPlugin
+// @Security ApiKeyAuth
+// @Produce  json
+// @Param plugin_slug_name query string true "plugin_slug_name"
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPluginJobResp}
+// @Router /answer/admin/api/plugin/jobs [get]
+func (pc *PluginController) GetPluginJobList(ctx *gin.Context) {
+	req := &schema.GetPluginJobListReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := pc.pluginJobService.GetPluginJobList(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdatePluginJobStatus update plugin scheduled job status
+// @Summary update plugin scheduled job status
+// @Description enable or disable the plugin scheduled job
+// @Tags AdminPlugin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdatePluginJobStatusReq true "UpdatePluginJobStatusReq"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/plugin/job/status [put]
+func (pc *PluginController) UpdatePluginJobStatus(ctx *gin.Context) {
+	req := &schema.UpdatePluginJobStatusReq{}
+	if handler.BindAndCheck(ctx, req) {
 		return
 	}
 
-	err = pc.pluginCommonService.UpdatePluginConfig(ctx, req)
+	err := pc.pluginJobService.UpdatePluginJobStatus(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
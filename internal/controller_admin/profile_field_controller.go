@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/profile_field"
+	"github.com/gin-gonic/gin"
+)
+
+// ProfileFieldController profile field controller
+type ProfileFieldController struct {
+	profileFieldService *profile_field.ProfileFieldService
+}
+
+// NewProfileFieldController new controller
+func NewProfileFieldController(profileFieldService *profile_field.ProfileFieldService) *ProfileFieldController {
+	return &ProfileFieldController{profileFieldService: profileFieldService}
+}
+
+// GetProfileFieldList get profile field list
+// @Summary get profile field list
+// @Description get the extra user profile fields
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetProfileFieldResp}
+// @Router /answer/admin/api/profile-fields [get]
+func (pc *ProfileFieldController) GetProfileFieldList(ctx *gin.Context) {
+	resp, err := pc.profileFieldService.GetProfileFieldList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddProfileField add profile field
+// @Summary add profile field
+// @Description add extra user profile field
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddProfileFieldReq true "profile field"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/profile-field [post]
+func (pc *ProfileFieldController) AddProfileField(ctx *gin.Context) {
+	req := &schema.AddProfileFieldReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := pc.profileFieldService.AddProfileField(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateProfileField update profile field
+// @Summary update profile field
+// @Description update extra user profile field
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateProfileFieldReq true "profile field"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/profile-field [put]
+func (pc *ProfileFieldController) UpdateProfileField(ctx *gin.Context) {
+	req := &schema.UpdateProfileFieldReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := pc.profileFieldService.UpdateProfileField(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveProfileField remove profile field
+// @Summary remove profile field
+// @Description remove extra user profile field and the values of all users
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveProfileFieldReq true "profile field"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/profile-field [delete]
+func (pc *ProfileFieldController) RemoveProfileField(ctx *gin.Context) {
+	req := &schema.RemoveProfileFieldReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := pc.profileFieldService.RemoveProfileField(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
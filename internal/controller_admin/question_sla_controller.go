@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_sla"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionSLAController question SLA controller
+type QuestionSLAController struct {
+	questionSLAService *question_sla.QuestionSLAService
+}
+
+// NewQuestionSLAController new controller
+func NewQuestionSLAController(questionSLAService *question_sla.QuestionSLAService) *QuestionSLAController {
+	return &QuestionSLAController{questionSLAService: questionSLAService}
+}
+
+// GetTagSLAPolicies get tag SLA policies
+// @Summary get tag SLA policies
+// @Description get the SLA policies of all tags
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.TagSLAPolicyResp}
+// @Router /answer/admin/api/sla/policies [get]
+func (qc *QuestionSLAController) GetTagSLAPolicies(ctx *gin.Context) {
+	resp, err := qc.questionSLAService.GetTagSLAPolicies(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// SaveTagSLAPolicy save tag SLA policy
+// @Summary save tag SLA policy
+// @Description add the SLA policy of the tag or replace the existing one
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SaveTagSLAPolicyReq true "policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/sla/policy [put]
+func (qc *QuestionSLAController) SaveTagSLAPolicy(ctx *gin.Context) {
+	req := &schema.SaveTagSLAPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := qc.questionSLAService.SaveTagSLAPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveTagSLAPolicy remove tag SLA policy
+// @Summary remove tag SLA policy
+// @Description remove tag SLA policy
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveTagSLAPolicyReq true "policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/sla/policy [delete]
+func (qc *QuestionSLAController) RemoveTagSLAPolicy(ctx *gin.Context) {
+	req := &schema.RemoveTagSLAPolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := qc.questionSLAService.RemoveTagSLAPolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_workflow"
+	"github.com/gin-gonic/gin"
+)
+
+// QuestionWorkflowController question workflow controller
+type QuestionWorkflowController struct {
+	questionWorkflowService *question_workflow.QuestionWorkflowService
+}
+
+// NewQuestionWorkflowController new controller
+func NewQuestionWorkflowController(
+	questionWorkflowService *question_workflow.QuestionWorkflowService) *QuestionWorkflowController {
+	return &QuestionWorkflowController{questionWorkflowService: questionWorkflowService}
+}
+
+// GetQuestionWorkflow get question workflow
+// @Summary get question workflow
+// @Description get the workflow states of the questions and the transitions allowed for the roles
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteQuestionWorkflowReq}
+// @Router /answer/admin/api/question-workflow [get]
+func (qc *QuestionWorkflowController) GetQuestionWorkflow(ctx *gin.Context) {
+	resp, err := qc.questionWorkflowService.GetQuestionWorkflow(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateQuestionWorkflow update question workflow
+// @Summary update question workflow
+// @Description update the workflow states of the questions and the transitions allowed for the roles
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteQuestionWorkflowReq true "workflow"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/question-workflow [put]
+func (qc *QuestionWorkflowController) UpdateQuestionWorkflow(ctx *gin.Context) {
+	req := &schema.SiteQuestionWorkflowReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := qc.questionWorkflowService.UpdateQuestionWorkflow(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/service/revision_compaction"
+	"github.com/gin-gonic/gin"
+)
+
+// RevisionCompactionController revision compaction controller
+type RevisionCompactionController struct {
+	revisionCompactionService *revision_compaction.RevisionCompactionService
+}
+
+// NewRevisionCompactionController new controller
+func NewRevisionCompactionController(
+	revisionCompactionService *revision_compaction.RevisionCompactionService,
+) *RevisionCompactionController {
+	return &RevisionCompactionController{revisionCompactionService: revisionCompactionService}
+}
+
+// GetCompactionReport get revision compaction report
+// @Summary get revision compaction report
+// @Description get the total pruned and compacted revisions, the reclaimed bytes and the recent runs
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.RevisionCompactionReportResp}
+// @Router /answer/admin/api/revision/compaction/report [get]
+func (rc *RevisionCompactionController) GetCompactionReport(ctx *gin.Context) {
+	resp, err := rc.revisionCompactionService.GetCompactionReport(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -51,3 +51,91 @@ func (rc *RoleController) GetRoleList(ctx *gin.Context) {
 	resp, err := rc.roleService.GetRoleList(ctx)
 	handler.HandleResponse(ctx, err, resp)
 }
+
+// GetRole get role detail
+// @Summary get role detail
+// @Description get role detail with the powers granted to it
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param id query int true "role id"
+// @Success 200 {object} handler.RespBody{data=schema.GetRoleDetailResp}
+// @Router /answer/admin/api/role [get]
+func (rc *RoleController) GetRole(ctx *gin.Context) {
+	req := &schema.GetRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := rc.roleService.GetRole(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetPowerList get power list
+// @Summary get power list
+// @Description get all powers that can be granted to the role
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetPowerResp}
+// @Router /answer/admin/api/powers [get]
+func (rc *RoleController) GetPowerList(ctx *gin.Context) {
+	resp, err := rc.roleService.GetPowerList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// AddRole add role
+// @Summary add role
+// @Description add custom role with powers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.AddRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [post]
+func (rc *RoleController) AddRole(ctx *gin.Context) {
+	req := &schema.AddRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.AddRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateRole update role
+// @Summary update role
+// @Description update custom role and replace its powers
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [put]
+func (rc *RoleController) UpdateRole(ctx *gin.Context) {
+	req := &schema.UpdateRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.UpdateRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RemoveRole remove role
+// @Summary remove role
+// @Description remove custom role that is not assigned to any user
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RemoveRoleReq true "role"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/role [delete]
+func (rc *RoleController) RemoveRole(ctx *gin.Context) {
+	req := &schema.RemoveRoleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := rc.roleService.RemoveRole(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/scheduler"
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerController scheduler controller
+type SchedulerController struct {
+	schedulerService *scheduler.SchedulerService
+}
+
+// NewSchedulerController new controller
+func NewSchedulerController(schedulerService *scheduler.SchedulerService) *SchedulerController {
+	return &SchedulerController{schedulerService: schedulerService}
+}
+
+// GetScheduledJobList get scheduled job list
+// @Summary get scheduled job list
+// @Description get the built-in scheduled jobs with the schedule, the last run status and the next run time
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=[]schema.GetScheduledJobResp}
+// @Router /answer/admin/api/scheduled-jobs [get]
+func (sc *SchedulerController) GetScheduledJobList(ctx *gin.Context) {
+	resp, err := sc.schedulerService.GetScheduledJobList(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateScheduledJobStatus update scheduled job status
+// @Summary update scheduled job status
+// @Description enable or disable the built-in scheduled job
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateScheduledJobStatusReq true "job"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/scheduled-job/status [put]
+func (sc *SchedulerController) UpdateScheduledJobStatus(ctx *gin.Context) {
+	req := &schema.UpdateScheduledJobStatusReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.schedulerService.UpdateScheduledJobStatus(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// UpdateScheduledJobSchedule update scheduled job schedule
+// @Summary update scheduled job schedule
+// @Description override the cron expression of the built-in scheduled job, empty means the default one
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.UpdateScheduledJobScheduleReq true "job"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/scheduled-job/schedule [put]
+func (sc *SchedulerController) UpdateScheduledJobSchedule(ctx *gin.Context) {
+	req := &schema.UpdateScheduledJobScheduleReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.schedulerService.UpdateScheduledJobSchedule(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// RunScheduledJob run scheduled job
+// @Summary run scheduled job now
+// @Description run the built-in scheduled job now in the background
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.RunScheduledJobReq true "job"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/scheduled-job/run [post]
+func (sc *SchedulerController) RunScheduledJob(ctx *gin.Context) {
+	req := &schema.RunScheduledJobReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := sc.schedulerService.RunScheduledJob(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
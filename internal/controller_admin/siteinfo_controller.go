@@ -27,18 +27,24 @@ import (
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
+	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/gin-gonic/gin"
 )
 
 // SiteInfoController site info controller
 type SiteInfoController struct {
 	siteInfoService *siteinfo.SiteInfoService
+	uploaderService uploader.UploaderService
 }
 
 // NewSiteInfoController new site info controller
-func NewSiteInfoController(siteInfoService *siteinfo.SiteInfoService) *SiteInfoController {
+func NewSiteInfoController(
+	siteInfoService *siteinfo.SiteInfoService,
+	uploaderService uploader.UploaderService,
+) *SiteInfoController {
 	return &SiteInfoController{
 		siteInfoService: siteInfoService,
+		uploaderService: uploaderService,
 	}
 }
 
@@ -278,6 +284,31 @@ func (sc *SiteInfoController) UpdateBranding(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// UploadBrandingFile upload the logo or icon of the site
+// @Summary upload the logo or icon of the site
+// @Description upload the file and use it as the logo, mobile logo, square icon or favicon of the site
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param type formData string true "branding file type" Enums(logo, mobile_logo, square_icon, favicon)
+// @Param file formData file true "file"
+// @Success 200 {object} handler.RespBody{data=schema.SiteBrandingResp}
+// @Router /answer/admin/api/siteinfo/branding/file [post]
+func (sc *SiteInfoController) UploadBrandingFile(ctx *gin.Context) {
+	req := &schema.UploadSiteBrandingFileReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	url, err := sc.uploaderService.UploadBrandingFile(ctx)
+	if err != nil {
+		handler.HandleResponse(ctx, err, nil)
+		return
+	}
+	resp, err := sc.siteInfoService.SaveSiteBrandingFile(ctx, req.Type, url)
+	handler.HandleResponse(ctx, err, resp)
+}
+
 // UpdateSiteWrite update site write info
 // @Summary update site write info
 // @Description update site write info
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/slow_query"
+	"github.com/gin-gonic/gin"
+)
+
+// SlowQueryController slow query controller
+type SlowQueryController struct {
+	slowQueryService *slow_query.SlowQueryService
+}
+
+// NewSlowQueryController new controller
+func NewSlowQueryController(slowQueryService *slow_query.SlowQueryService) *SlowQueryController {
+	return &SlowQueryController{slowQueryService: slowQueryService}
+}
+
+// GetSlowQueryPage get slow query page
+// @Summary get slow query page
+// @Description get the database queries slower than the threshold in the config, aggregated by the statement
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param order_by query string false "total max count latest"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetSlowQueryResp}}
+// @Router /answer/admin/api/slow-queries/page [get]
+func (sc *SlowQueryController) GetSlowQueryPage(ctx *gin.Context) {
+	req := &schema.GetSlowQueryPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	resp, err := sc.slowQueryService.GetSlowQueryPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// ClearSlowQueries clear slow queries
+// @Summary clear slow queries
+// @Description remove all the slow queries from the report
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/slow-queries [delete]
+func (sc *SlowQueryController) ClearSlowQueries(ctx *gin.Context) {
+	err := sc.slowQueryService.ClearSlowQueries(ctx)
+	handler.HandleResponse(ctx, err, nil)
+}
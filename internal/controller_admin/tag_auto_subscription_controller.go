@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/tag_auto_subscription"
+	"github.com/gin-gonic/gin"
+)
+
+// TagAutoSubscriptionController tag auto subscription controller
+type TagAutoSubscriptionController struct {
+	tagAutoSubscriptionService *tag_auto_subscription.TagAutoSubscriptionService
+}
+
+// NewTagAutoSubscriptionController new controller
+func NewTagAutoSubscriptionController(
+	tagAutoSubscriptionService *tag_auto_subscription.TagAutoSubscriptionService) *TagAutoSubscriptionController {
+	return &TagAutoSubscriptionController{tagAutoSubscriptionService: tagAutoSubscriptionService}
+}
+
+// GetTagAutoSubscriptions get tag auto subscriptions
+// @Summary get tag auto subscriptions
+// @Description get the groups which are subscribed to the new questions in the tags automatically
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteTagAutoSubscriptionsReq}
+// @Router /answer/admin/api/tag-auto-subscriptions [get]
+func (tc *TagAutoSubscriptionController) GetTagAutoSubscriptions(ctx *gin.Context) {
+	resp, err := tc.tagAutoSubscriptionService.GetTagAutoSubscriptions(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateTagAutoSubscriptions update tag auto subscriptions
+// @Summary update tag auto subscriptions
+// @Description update the groups which are subscribed to the new questions in the tags automatically
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteTagAutoSubscriptionsReq true "groups"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/tag-auto-subscriptions [put]
+func (tc *TagAutoSubscriptionController) UpdateTagAutoSubscriptions(ctx *gin.Context) {
+	req := &schema.SiteTagAutoSubscriptionsReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := tc.tagAutoSubscriptionService.UpdateTagAutoSubscriptions(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
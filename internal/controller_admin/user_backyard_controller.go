@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/gin-gonic/gin"
 	"github.com/segmentfault/pacman/errors"
@@ -153,6 +154,51 @@ func (uc *UserAdminController) UpdateUserPassword(ctx *gin.Context) {
 	handler.HandleResponse(ctx, err, nil)
 }
 
+// TransferUserContent transfer user content
+// @Summary transfer the content of one user to another
+// @Description reattribute the questions, answers, comments, revisions, activities and reputation of the user to another user,
+// @Description or only one question or answer if object_id is given
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.TransferUserContentReq true "transfer"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/content/transfer [put]
+func (uc *UserAdminController) TransferUserContent(ctx *gin.Context) {
+	req := &schema.TransferUserContentReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.ObjectID = uid.DeShortID(req.ObjectID)
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.TransferUserContent(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
+// MergeUser merge user
+// @Summary merge the duplicate user into the primary user
+// @Description move the content, votes, reputation and follows of the duplicate user to the primary user,
+// @Description then delete the duplicate user
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.MergeUserReq true "merge"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/user/merge [put]
+func (uc *UserAdminController) MergeUser(ctx *gin.Context) {
+	req := &schema.MergeUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+
+	err := uc.userService.MergeUser(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
+
 // EditUserProfile edit user profile
 // @Summary edit user profile
 // @Description edit user profile
@@ -242,3 +288,46 @@ func (uc *UserAdminController) SendUserActivation(ctx *gin.Context) {
 	err := uc.userService.SendUserActivation(ctx, req)
 	handler.HandleResponse(ctx, err, nil)
 }
+
+// ImpersonateUser impersonate user
+// @Summary sign in as the user
+// @Description sign in as the user with a short-lived session, the sensitive actions are not allowed in it
+// @Security ApiKeyAuth
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param data body schema.ImpersonateUserReq true "user"
+// @Success 200 {object} handler.RespBody{data=schema.ImpersonateUserResp}
+// @Router /answer/admin/api/user/impersonation [post]
+func (uc *UserAdminController) ImpersonateUser(ctx *gin.Context) {
+	req := &schema.ImpersonateUserReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	req.LoginUserID = middleware.GetLoginUserIDFromContext(ctx)
+	req.IP = ctx.ClientIP()
+
+	resp, err := uc.userService.ImpersonateUser(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// GetUserImpersonationLogPage get user impersonation log page
+// @Summary get the records of the admins signing in as the users
+// @Description get the records of the admins signing in as the users
+// @Security ApiKeyAuth
+// @Tags admin
+// @Produce json
+// @Param page query int false "page"
+// @Param page_size query int false "page size"
+// @Param user_id query string false "user id"
+// @Success 200 {object} handler.RespBody{data=pager.PageModel{list=[]schema.GetUserImpersonationLogResp}}
+// @Router /answer/admin/api/user/impersonation/logs/page [get]
+func (uc *UserAdminController) GetUserImpersonationLogPage(ctx *gin.Context) {
+	req := &schema.GetUserImpersonationLogPageReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+
+	resp, err := uc.userService.GetUserImpersonationLogPage(ctx, req)
+	handler.HandleResponse(ctx, err, resp)
+}
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package controller_admin
+
+import (
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
+	"github.com/gin-gonic/gin"
+)
+
+// UsernamePolicyController username policy controller
+type UsernamePolicyController struct {
+	usernamePolicyService *username_policy.UsernamePolicyService
+}
+
+// NewUsernamePolicyController new controller
+func NewUsernamePolicyController(usernamePolicyService *username_policy.UsernamePolicyService) *UsernamePolicyController {
+	return &UsernamePolicyController{usernamePolicyService: usernamePolicyService}
+}
+
+// GetUsernamePolicy get username policy
+// @Summary get username policy
+// @Description get the rules of the usernames and the display names
+// @Tags admin
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} handler.RespBody{data=schema.SiteUsernamePolicyReq}
+// @Router /answer/admin/api/username-policy [get]
+func (uc *UsernamePolicyController) GetUsernamePolicy(ctx *gin.Context) {
+	resp, err := uc.usernamePolicyService.GetUsernamePolicy(ctx)
+	handler.HandleResponse(ctx, err, resp)
+}
+
+// UpdateUsernamePolicy update username policy
+// @Summary update username policy
+// @Description update the rules of the usernames and the display names, they are checked at signup and rename
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security ApiKeyAuth
+// @Param data body schema.SiteUsernamePolicyReq true "policy"
+// @Success 200 {object} handler.RespBody
+// @Router /answer/admin/api/username-policy [put]
+func (uc *UsernamePolicyController) UpdateUsernamePolicy(ctx *gin.Context) {
+	req := &schema.SiteUsernamePolicyReq{}
+	if handler.BindAndCheck(ctx, req) {
+		return
+	}
+	err := uc.usernamePolicyService.UpdateUsernamePolicy(ctx, req)
+	handler.HandleResponse(ctx, err, nil)
+}
@@ -26,15 +26,15 @@ const (
 	ActivityCancelled = 1
 )
 
-// Activity activity
+// Activity activity, the object id is before the created time for the column order of the object_created index
 type Activity struct {
 	ID               string    `xorm:"not null pk autoincr BIGINT(20) id"`
-	CreatedAt        time.Time `xorm:"created TIMESTAMP created_at"`
+	ObjectID         string    `xorm:"not null default 0 index INDEX(object_created) BIGINT(20) object_id"`
+	CreatedAt        time.Time `xorm:"created INDEX(object_created) TIMESTAMP created_at"`
 	UpdatedAt        time.Time `xorm:"updated TIMESTAMP updated_at"`
 	CancelledAt      time.Time `xorm:"TIMESTAMP cancelled_at"`
 	UserID           string    `xorm:"not null index BIGINT(20) user_id"`
 	TriggerUserID    int64     `xorm:"not null default 0 index BIGINT(20) trigger_user_id"`
-	ObjectID         string    `xorm:"not null default 0 index BIGINT(20) object_id"`
 	OriginalObjectID string    `xorm:"not null default 0 BIGINT(20) original_object_id"`
 	ActivityType     int       `xorm:"not null INT(11) activity_type"`
 	Cancelled        int       `xorm:"not null default 0 TINYINT(4) cancelled"`
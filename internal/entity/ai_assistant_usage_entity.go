@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// AIAssistantUsage the usage of the ai assistant, it is used to track the quota of the user
+type AIAssistantUsage struct {
+	ID               int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt        time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID           string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	ObjectID         string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	Action           string    `xorm:"not null default '' VARCHAR(50) action"`
+	Provider         string    `xorm:"not null default '' VARCHAR(100) provider"`
+	Model            string    `xorm:"not null default '' VARCHAR(100) model"`
+	PromptTokens     int       `xorm:"not null default 0 INT(11) prompt_tokens"`
+	CompletionTokens int       `xorm:"not null default 0 INT(11) completion_tokens"`
+}
+
+// TableName ai assistant usage table name
+func (AIAssistantUsage) TableName() string {
+	return "ai_assistant_usage"
+}
@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	AnnouncementStatusEnabled  = 1
+	AnnouncementStatusDisabled = 2
+)
+
+const (
+	AnnouncementStyleInfo    = "info"
+	AnnouncementStyleWarning = "warning"
+)
+
+const (
+	AnnouncementAudienceAll      = "all"
+	AnnouncementAudienceLoggedIn = "logged_in"
+	AnnouncementAudienceRoles    = "roles"
+)
+
+// Announcement site-wide banner managed by admin
+type Announcement struct {
+	ID           int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated TIMESTAMP updated_at"`
+	OriginalText string    `xorm:"not null TEXT original_text"`
+	ParsedText   string    `xorm:"not null TEXT parsed_text"`
+	Style        string    `xorm:"not null default 'info' VARCHAR(20) style"`
+	Audience     string    `xorm:"not null default 'all' VARCHAR(20) audience"`
+	// RoleIDs the comma separated role ids, only for the roles audience
+	RoleIDs   string    `xorm:"not null default '' VARCHAR(100) role_ids"`
+	StartTime time.Time `xorm:"TIMESTAMP start_time"`
+	EndTime   time.Time `xorm:"TIMESTAMP end_time"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) user_id"`
+}
+
+// TableName announcement table name
+func (Announcement) TableName() string {
+	return "announcement"
+}
+
+// AnnouncementDismissal the announcement dismissed by user
+type AnnouncementDismissal struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	AnnouncementID int       `xorm:"not null default 0 INT(11) UNIQUE(s) announcement_id"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) user_id"`
+}
+
+// TableName announcement dismissal table name
+func (AnnouncementDismissal) TableName() string {
+	return "announcement_dismissal"
+}
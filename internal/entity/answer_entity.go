@@ -53,15 +53,22 @@ type Answer struct {
 	CommentCount   int       `xorm:"not null default 0 INT(11) comment_count"`
 	VoteCount      int       `xorm:"not null default 0 INT(11) vote_count"`
 	RevisionID     string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	OutdatedCount  int       `xorm:"not null default 0 INT(11) outdated_count"`
 }
 
 type AnswerSearch struct {
 	Answer
 	IncludeDeleted bool   `json:"include_deleted"`
 	LoginUserID    string `json:"login_user_id"`
-	Order          string `json:"order_by"`                   // default or updated
-	Page           int    `json:"page" form:"page"`           // Query number of pages
-	PageSize       int    `json:"page_size" form:"page_size"` // Search page size
+	// EnvironmentName only the answers that declare this environment
+	EnvironmentName string `json:"environment_name"`
+	// EnvironmentVersion only the answers that work with this version of the environment
+	EnvironmentVersion string `json:"environment_version"`
+	// OutdatedThreshold hide the answers flagged as outdated by at least this many users, 0 means no limit
+	OutdatedThreshold int    `json:"outdated_threshold"`
+	Order             string `json:"order_by"`                   // default or updated
+	Page              int    `json:"page" form:"page"`           // Query number of pages
+	PageSize          int    `json:"page_size" form:"page_size"` // Search page size
 }
 
 type PersonalAnswerPageQueryCond struct {
@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// AnswerEnvironment the software version that the answer declares it works with
+type AnswerEnvironment struct {
+	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	AnswerID  string    `xorm:"not null default 0 BIGINT(20) INDEX answer_id"`
+	Name      string    `xorm:"not null default '' VARCHAR(100) INDEX name"`
+	Version   string    `xorm:"not null default '' VARCHAR(100) version"`
+}
+
+// TableName answer environment table name
+func (AnswerEnvironment) TableName() string {
+	return "answer_environment"
+}
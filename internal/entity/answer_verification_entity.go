@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagExpert the user designated as the subject-matter expert of the tag
+type TagExpert struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	TagID     string    `xorm:"not null default '0' BIGINT(20) UNIQUE(tag_user) tag_id"`
+	UserID    string    `xorm:"not null default '0' BIGINT(20) UNIQUE(tag_user) INDEX user_id"`
+}
+
+// TableName tag expert table name
+func (TagExpert) TableName() string {
+	return "tag_expert"
+}
+
+// AnswerVerification the verification of the answer by the expert, the content hash is the hash of the
+// verified content which tells whether the answer is edited after the verification
+type AnswerVerification struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	AnswerID    string    `xorm:"not null default '0' BIGINT(20) UNIQUE answer_id"`
+	QuestionID  string    `xorm:"not null default '0' BIGINT(20) INDEX question_id"`
+	UserID      string    `xorm:"not null default '0' BIGINT(20) user_id"`
+	ContentHash string    `xorm:"not null default '' VARCHAR(64) content_hash"`
+}
+
+// TableName answer verification table name
+func (AnswerVerification) TableName() string {
+	return "answer_verification"
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	ArticleStatusAvailable = 1
+	ArticleStatusDeleted   = 10
+)
+
+// Article knowledge base article, it has no answers but only the body and the comments
+type Article struct {
+	ID             string    `xorm:"not null pk BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated_at TIMESTAMP"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	LastEditUserID string    `xorm:"not null default 0 BIGINT(20) last_edit_user_id"`
+	Title          string    `xorm:"not null default '' VARCHAR(150) title"`
+	OriginalText   string    `xorm:"not null MEDIUMTEXT original_text"`
+	ParsedText     string    `xorm:"not null MEDIUMTEXT parsed_text"`
+	Status         int       `xorm:"not null default 1 INT(11) status"`
+	ViewCount      int       `xorm:"not null default 0 INT(11) view_count"`
+	// SourceAnswerID the answer this article is converted from, 0 means it is written directly
+	SourceAnswerID string `xorm:"not null default 0 BIGINT(20) source_answer_id"`
+	RevisionID     string `xorm:"not null default 0 BIGINT(20) revision_id"`
+}
+
+// TableName article table name
+func (Article) TableName() string {
+	return "article"
+}
+
+// ArticleQuestionRel the canonical questions linked to the article
+type ArticleQuestionRel struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	ArticleID  string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) article_id"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) INDEX question_id"`
+}
+
+// TableName article question relation table name
+func (ArticleQuestionRel) TableName() string {
+	return "article_question_rel"
+}
@@ -27,4 +27,15 @@ type UserCacheInfo struct {
 	RoleID      int    `json:"role_id"`
 	ExternalID  string `json:"external_id"`
 	VisitToken  string `json:"visit_token"`
+	// PasswordExpired the user must change the password before doing anything else
+	PasswordExpired bool `json:"password_expired"`
+	// ImpersonatorID the admin who signs in as this user, empty means it is not an impersonation session
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+	// ImpersonationExpiredAt the impersonation session expires at this unix time
+	ImpersonationExpiredAt int64 `json:"impersonation_expired_at,omitempty"`
+}
+
+// IsImpersonation whether the session is signed in by the admin as this user
+func (u *UserCacheInfo) IsImpersonation() bool {
+	return len(u.ImpersonatorID) > 0
 }
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	BannedWordObjectTypeUser = "user"
+)
+
+// BannedWordHit the content or the user name which triggers the banned word rule
+type BannedWordHit struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	Word       string    `xorm:"not null default '' VARCHAR(200) word"`
+	Action     string    `xorm:"not null default '' VARCHAR(20) INDEX action"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) INDEX object_type"`
+	ObjectID   string    `xorm:"not null default '0' BIGINT(20) object_id"`
+	UserID     string    `xorm:"not null default '0' BIGINT(20) user_id"`
+	Matched    string    `xorm:"not null default '' VARCHAR(200) matched"`
+}
+
+// TableName banned word hit table name
+func (BannedWordHit) TableName() string {
+	return "banned_word_hit"
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	BlockedSignupReasonNotAllowed = "not_allowed"
+	BlockedSignupReasonBlocked    = "blocked"
+	BlockedSignupReasonDisposable = "disposable"
+)
+
+// BlockedSignup the signup attempt blocked by the email domain rules
+type BlockedSignup struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	EMail     string    `xorm:"not null default '' VARCHAR(100) e_mail"`
+	Domain    string    `xorm:"not null default '' VARCHAR(100) INDEX domain"`
+	Reason    string    `xorm:"not null default '' VARCHAR(20) reason"`
+	IP        string    `xorm:"not null default '' VARCHAR(100) ip"`
+}
+
+// TableName blocked signup table name
+func (BlockedSignup) TableName() string {
+	return "blocked_signup"
+}
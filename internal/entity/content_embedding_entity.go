@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ContentEmbedding the embedding of the question or answer, the vector is empty if it is saved in the
+// vector store plugin
+type ContentEmbedding struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ObjectID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE object_id"`
+	ObjectType  string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	QuestionID  string    `xorm:"not null default 0 BIGINT(20) question_id"`
+	Model       string    `xorm:"not null default '' VARCHAR(200) INDEX model"`
+	ContentHash string    `xorm:"not null default '' VARCHAR(64) content_hash"`
+	// Vector the base64 encoded little-endian float32 vector
+	Vector string `xorm:"MEDIUMTEXT vector"`
+}
+
+// TableName content embedding table name
+func (ContentEmbedding) TableName() string {
+	return "content_embedding"
+}
@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// DeadLink the outbound link in the question or answer which can not be reached
+type DeadLink struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CheckedAt  time.Time `xorm:"created TIMESTAMP checked_at"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) question_id"`
+	URL        string    `xorm:"not null TEXT url"`
+	StatusCode int       `xorm:"not null default 0 INT(11) status_code"`
+	Reason     string    `xorm:"not null default '' VARCHAR(255) reason"`
+}
+
+// TableName dead link table name
+func (DeadLink) TableName() string {
+	return "dead_link"
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	// EmailSendStatusSent the email is accepted by the provider
+	EmailSendStatusSent = "sent"
+	// EmailSendStatusFailed the provider refused the email
+	EmailSendStatusFailed = "failed"
+	// EmailSendStatusSuppressed the email is not sent because the recipient is suppressed
+	EmailSendStatusSuppressed = "suppressed"
+	// EmailSendStatusDelivered the provider reported the email is delivered
+	EmailSendStatusDelivered = "delivered"
+	// EmailSendStatusBounced the provider reported the email is bounced
+	EmailSendStatusBounced = "bounced"
+	// EmailSendStatusComplained the recipient reported the email as spam
+	EmailSendStatusComplained = "complained"
+)
+
+const (
+	// EmailSuppressionReasonHardBounce the address does not exist or the mailbox refuses all emails
+	EmailSuppressionReasonHardBounce = "hard_bounce"
+	// EmailSuppressionReasonComplaint the recipient reported the email as spam
+	EmailSuppressionReasonComplaint = "complaint"
+)
+
+// EmailSendLog the email sent by the site, the status is updated by the delivery webhook of the provider
+type EmailSendLog struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Provider  string    `xorm:"not null default '' VARCHAR(32) provider"`
+	// MessageID the message id returned by the provider, it is used to match the webhook events
+	MessageID string `xorm:"not null default '' VARCHAR(255) INDEX message_id"`
+	ToEmail   string `xorm:"not null default '' VARCHAR(255) INDEX to_email"`
+	Subject   string `xorm:"not null default '' VARCHAR(255) subject"`
+	Status    string `xorm:"not null default '' VARCHAR(32) INDEX status"`
+	// Error the error of the failed sending or the bounce reason
+	Error string `xorm:"not null default '' VARCHAR(512) error"`
+}
+
+// TableName email send log table name
+func (EmailSendLog) TableName() string {
+	return "email_send_log"
+}
+
+// EmailSuppression the address which no email is sent to
+type EmailSuppression struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	Email     string    `xorm:"not null default '' VARCHAR(255) UNIQUE email"`
+	Reason    string    `xorm:"not null default '' VARCHAR(32) reason"`
+	Provider  string    `xorm:"not null default '' VARCHAR(32) provider"`
+}
+
+// TableName email suppression table name
+func (EmailSuppression) TableName() string {
+	return "email_suppression"
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	EventOutboxStatusPending = 1
+	EventOutboxStatusSent    = 2
+)
+
+// EventOutbox the event waiting to be published to the message broker, it is kept
+// until the broker accepts it, so every event is delivered at least once
+type EventOutbox struct {
+	ID            int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt     time.Time `xorm:"updated TIMESTAMP updated_at"`
+	EventType     string    `xorm:"not null default '' VARCHAR(100) event_type"`
+	Topic         string    `xorm:"not null default '' VARCHAR(255) topic"`
+	Payload       string    `xorm:"not null TEXT payload"`
+	Status        int       `xorm:"not null default 1 INT(11) INDEX(status_next) status"`
+	Attempts      int       `xorm:"not null default 0 INT(11) attempts"`
+	NextAttemptAt time.Time `xorm:"TIMESTAMP INDEX(status_next) next_attempt_at"`
+	LastError     string    `xorm:"not null default '' VARCHAR(500) last_error"`
+}
+
+// TableName event outbox table name
+func (EventOutbox) TableName() string {
+	return "event_outbox"
+}
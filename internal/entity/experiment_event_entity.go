@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ExperimentEvent the exposure or the conversion of the user in the experiment,
+// each event is recorded only once for the user
+type ExperimentEvent struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	ExperimentKey string    `xorm:"not null default '' VARCHAR(100) UNIQUE(user_event) experiment_key"`
+	UserID        string    `xorm:"not null default 0 BIGINT(20) UNIQUE(user_event) user_id"`
+	EventType     string    `xorm:"not null default '' VARCHAR(20) UNIQUE(user_event) event_type"`
+	Name          string    `xorm:"not null default '' VARCHAR(100) UNIQUE(user_event) name"`
+	Variant       string    `xorm:"not null default '' VARCHAR(100) variant"`
+}
+
+// TableName experiment event table name
+func (ExperimentEvent) TableName() string {
+	return "experiment_event"
+}
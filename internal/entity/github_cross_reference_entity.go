@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// GitHubCrossReference the cross-reference comment posted on the GitHub issue or pull request
+// referenced by the question, it is posted once for each question
+type GitHubCrossReference struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE(question_issue) question_id"`
+	IssueKey   string    `xorm:"not null default '' VARCHAR(255) UNIQUE(question_issue) issue_key"`
+	CommentURL string    `xorm:"not null default '' VARCHAR(512) comment_url"`
+}
+
+// TableName github cross reference table name
+func (GitHubCrossReference) TableName() string {
+	return "github_cross_reference"
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	InboundQuestionStatusPending  = 1
+	InboundQuestionStatusApproved = 2
+	InboundQuestionStatusRejected = 3
+)
+
+const (
+	InboundQuestionChannelAPI   = "api"
+	InboundQuestionChannelEmail = "email"
+)
+
+// InboundQuestion the question sent by an external system or by email, it is published
+// as a question of the sender after a moderator approves it
+type InboundQuestion struct {
+	ID          int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Channel     string    `xorm:"not null default '' VARCHAR(20) channel"`
+	SenderEmail string    `xorm:"not null default '' VARCHAR(100) sender_email"`
+	SenderName  string    `xorm:"not null default '' VARCHAR(100) sender_name"`
+	Title       string    `xorm:"not null default '' VARCHAR(150) title"`
+	Content     string    `xorm:"not null MEDIUMTEXT content"`
+	Tags        string    `xorm:"not null default '' VARCHAR(500) tags"`
+	Status      int       `xorm:"not null default 1 INT(11) INDEX status"`
+	UserID      string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	QuestionID  string    `xorm:"not null default 0 BIGINT(20) question_id"`
+	ReviewerID  string    `xorm:"not null default 0 BIGINT(20) reviewer_id"`
+}
+
+// TableName inbound question table name
+func (InboundQuestion) TableName() string {
+	return "inbound_question"
+}
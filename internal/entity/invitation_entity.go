@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	InvitationStatusAvailable = 1
+	InvitationStatusRevoked   = 10
+)
+
+// Invitation the invitation code used for registration
+type Invitation struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Code      string    `xorm:"not null VARCHAR(64) UNIQUE code"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	// MaxUses the max times the invitation can be used, 0 means unlimited
+	MaxUses   int       `xorm:"not null default 1 INT(11) max_uses"`
+	UsedCount int       `xorm:"not null default 0 INT(11) used_count"`
+	ExpiredAt time.Time `xorm:"TIMESTAMP expired_at"`
+	Status    int       `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName invitation table name
+func (Invitation) TableName() string {
+	return "invitation"
+}
+
+// IsUsable whether the invitation can be used for registration now
+func (i *Invitation) IsUsable() bool {
+	if i.Status != InvitationStatusAvailable {
+		return false
+	}
+	if i.MaxUses > 0 && i.UsedCount >= i.MaxUses {
+		return false
+	}
+	return i.ExpiredAt.IsZero() || time.Now().Before(i.ExpiredAt)
+}
+
+// InvitationRecord the record of who invited whom
+type InvitationRecord struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	InvitationID  int       `xorm:"not null default 0 INT(11) INDEX invitation_id"`
+	InviterUserID string    `xorm:"not null default 0 BIGINT(20) INDEX inviter_user_id"`
+	UserID        string    `xorm:"not null default 0 BIGINT(20) UNIQUE user_id"`
+}
+
+// TableName invitation record table name
+func (InvitationRecord) TableName() string {
+	return "invitation_record"
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// JiraIssueLink the Jira issue the question is escalated to, the status of the issue is synced back periodically
+type JiraIssueLink struct {
+	ID             int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	IssueKey       string    `xorm:"not null default '' VARCHAR(100) issue_key"`
+	IssueURL       string    `xorm:"not null default '' VARCHAR(512) issue_url"`
+	Status         string    `xorm:"not null default '' VARCHAR(100) status"`
+	StatusCategory string    `xorm:"not null default '' VARCHAR(50) status_category"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	SyncedAt       time.Time `xorm:"TIMESTAMP INDEX synced_at"`
+}
+
+// TableName jira issue link table name
+func (JiraIssueLink) TableName() string {
+	return "jira_issue_link"
+}
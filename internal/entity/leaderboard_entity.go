@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	LeaderboardBoardReputation  = "reputation"
+	LeaderboardBoardTagAnswerer = "tag_answerer"
+)
+
+// Leaderboard one user in the leaderboard of the period, which is rebuilt by the rollup job
+type Leaderboard struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	Period      string    `xorm:"not null default '' VARCHAR(20) INDEX(board) period"`
+	Board       string    `xorm:"not null default '' VARCHAR(20) INDEX(board) board"`
+	TagID       string    `xorm:"not null default 0 BIGINT(20) INDEX(board) tag_id"`
+	PeriodStart time.Time `xorm:"TIMESTAMP period_start"`
+	Position    int       `xorm:"not null default 0 INT(11) position"`
+	UserID      string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Score       int       `xorm:"not null default 0 INT(11) score"`
+}
+
+// TableName leaderboard table name
+func (Leaderboard) TableName() string {
+	return "leaderboard"
+}
+
+// LeaderboardScore the score of the user, tag id is empty for the reputation leaderboard
+type LeaderboardScore struct {
+	TagID  string `xorm:"tag_id"`
+	UserID string `xorm:"user_id"`
+	Score  int    `xorm:"score"`
+}
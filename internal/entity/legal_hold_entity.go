@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	LegalHoldStatusActive   = 1
+	LegalHoldStatusReleased = 2
+
+	LegalHoldActionPlace   = "place"
+	LegalHoldActionRelease = "release"
+	// LegalHoldActionBlock the deletion or the anonymization is refused because of the legal hold
+	LegalHoldActionBlock = "block"
+
+	LegalHoldProcessQuestionDelete  = "question.delete"
+	LegalHoldProcessAnswerDelete    = "answer.delete"
+	LegalHoldProcessCommentDelete   = "comment.delete"
+	LegalHoldProcessUserDelete      = "user.delete"
+	LegalHoldProcessUserContentWipe = "user.remove_all_content"
+	LegalHoldProcessUserMerge       = "user.merge"
+	LegalHoldProcessRecycleBinPurge = "recycle_bin.purge"
+	LegalHoldProcessCLIPurge        = "cli.purge_user_content"
+)
+
+// LegalHold the user or the post which must not be deleted or anonymized by any process until it is released
+type LegalHold struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ObjectID       string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	ObjectType     string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	Reason         string    `xorm:"not null default '' VARCHAR(500) reason"`
+	OperatorUserID string    `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+	ReleasedUserID string    `xorm:"not null default 0 BIGINT(20) released_user_id"`
+	ReleasedAt     time.Time `xorm:"TIMESTAMP released_at"`
+	Status         int       `xorm:"not null default 1 INT(11) INDEX status"`
+}
+
+// TableName legal hold table name
+func (LegalHold) TableName() string {
+	return "legal_hold"
+}
+
+// LegalHoldLog the audit log of placing, releasing and enforcing the legal holds
+type LegalHoldLog struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	LegalHoldID int       `xorm:"not null default 0 INT(11) INDEX legal_hold_id"`
+	ObjectID    string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	ObjectType  string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	Action      string    `xorm:"not null default '' VARCHAR(20) action"`
+	// Process the process which is blocked, it is empty when the hold is placed or released
+	Process string `xorm:"not null default '' VARCHAR(50) process"`
+	// OperatorUserID the user who operates, 0 means the operation is started by the system
+	OperatorUserID string `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+	Reason         string `xorm:"not null default '' VARCHAR(500) reason"`
+}
+
+// TableName legal hold log table name
+func (LegalHoldLog) TableName() string {
+	return "legal_hold_log"
+}
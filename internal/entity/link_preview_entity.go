@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	LinkPreviewStatusAvailable = 1
+	LinkPreviewStatusFailed    = 2
+)
+
+// LinkPreview the metadata of the external link in the post
+type LinkPreview struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	URLHash     string    `xorm:"not null VARCHAR(64) UNIQUE url_hash"`
+	URL         string    `xorm:"not null TEXT url"`
+	Title       string    `xorm:"not null default '' VARCHAR(255) title"`
+	Description string    `xorm:"TEXT description"`
+	ImageURL    string    `xorm:"TEXT image_url"`
+	SiteName    string    `xorm:"not null default '' VARCHAR(255) site_name"`
+	Status      int       `xorm:"not null default 1 INT(11) status"`
+}
+
+// TableName link preview table name
+func (LinkPreview) TableName() string {
+	return "link_preview"
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// LoginFailure the failed sign-in attempt with the email and password, the email is recorded as it is entered
+// because the attempts to the non-existent accounts are also tracked
+type LoginFailure struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	Email     string    `xorm:"not null default '' VARCHAR(100) INDEX email"`
+	IP        string    `xorm:"not null default '' VARCHAR(64) INDEX ip"`
+	UserAgent string    `xorm:"not null default '' VARCHAR(512) user_agent"`
+	// LockedMinutes the account or the ip address is locked for these minutes after this attempt, 0 means not locked
+	LockedMinutes int `xorm:"not null default 0 INT(11) locked_minutes"`
+}
+
+// TableName login failure table name
+func (LoginFailure) TableName() string {
+	return "login_failure"
+}
@@ -24,9 +24,11 @@ import "time"
 const (
 	QuestionEditSummaryKey = "question.edit.summary"
 	QuestionCloseReasonKey = "question.close.reason"
-	AnswerEditSummaryKey   = "answer.edit.summary"
-	TagEditSummaryKey      = "tag.edit.summary"
-	ObjectReactSummaryKey  = "object.react.summary"
+	// QuestionMergedIntoKey the canonical question which the duplicate question is merged into
+	QuestionMergedIntoKey = "question.merged.into"
+	AnswerEditSummaryKey  = "answer.edit.summary"
+	TagEditSummaryKey     = "tag.edit.summary"
+	ObjectReactSummaryKey = "object.react.summary"
 )
 
 // Meta meta
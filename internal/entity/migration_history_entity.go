@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// MigrationHistory the migration applied to the database. The checksum column keeps the fingerprint of
+// the migration, which tells whether the migration at the version is renamed or reordered, but not whether its
+// body is changed
+type MigrationHistory struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	VersionNumber int64     `xorm:"not null default 0 INT(11) UNIQUE version_number"`
+	Version       string    `xorm:"not null default '' VARCHAR(50) version"`
+	Description   string    `xorm:"not null default '' VARCHAR(255) description"`
+	Checksum      string    `xorm:"not null default '' VARCHAR(64) checksum"`
+}
+
+// TableName migration history table name
+func (MigrationHistory) TableName() string {
+	return "migration_history"
+}
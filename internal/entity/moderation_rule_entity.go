@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// ModerationRuleHit the question which matches the moderation rule
+type ModerationRuleHit struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	RuleName   string    `xorm:"not null default '' VARCHAR(100) INDEX rule_name"`
+	QuestionID string    `xorm:"not null default '0' BIGINT(20) question_id"`
+	UserID     string    `xorm:"not null default '0' BIGINT(20) user_id"`
+	Trigger    string    `xorm:"not null default '' VARCHAR(20) trigger"`
+	Actions    string    `xorm:"not null default '' VARCHAR(100) actions"`
+	DryRun     bool      `xorm:"not null default false BOOL dry_run"`
+}
+
+// TableName moderation rule hit table name
+func (ModerationRuleHit) TableName() string {
+	return "moderation_rule_hit"
+}
@@ -26,13 +26,15 @@ type Notification struct {
 	ID        string    `xorm:"not null pk autoincr BIGINT(20) id"`
 	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
 	UpdatedAt time.Time `xorm:"TIMESTAMP updated_at"`
-	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) INDEX INDEX(user_type_group) user_id"`
 	ObjectID  string    `xorm:"not null default 0 INDEX BIGINT(20) object_id"`
 	Content   string    `xorm:"not null TEXT content"`
-	Type      int       `xorm:"not null default 0 INT(11) type"`
+	Type      int       `xorm:"not null default 0 INT(11) INDEX(user_type_group) type"`
 	MsgType   int       `xorm:"not null default 0 INT(11) msg_type"`
 	IsRead    int       `xorm:"not null default 1 INT(11) is_read"`
 	Status    int       `xorm:"not null default 1 INT(11) status"`
+	// GroupKey the related notifications have the same group key, like the answers to the same question
+	GroupKey string `xorm:"not null default '' VARCHAR(128) INDEX(user_type_group) group_key"`
 }
 
 // TableName notification table name
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	PageStatusDraft     = 1
+	PageStatusPublished = 2
+)
+
+var PageStatusDisplayMapping = map[int]string{
+	PageStatusDraft:     "draft",
+	PageStatusPublished: "published",
+}
+
+// Page custom static page managed by admin, such as about, privacy or faq
+type Page struct {
+	ID           int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Title        string    `xorm:"not null default '' VARCHAR(150) title"`
+	SlugName     string    `xorm:"not null default '' unique VARCHAR(100) slug_name"`
+	OriginalText string    `xorm:"not null MEDIUMTEXT original_text"`
+	ParsedText   string    `xorm:"not null MEDIUMTEXT parsed_text"`
+	Status       int       `xorm:"not null default 1 INT(11) status"`
+	ShowInNav    bool      `xorm:"not null default false BOOL show_in_nav"`
+	NavOrder     int       `xorm:"not null default 0 INT(11) nav_order"`
+	UserID       string    `xorm:"not null default 0 BIGINT(20) user_id"`
+}
+
+// TableName page table name
+func (Page) TableName() string {
+	return "page"
+}
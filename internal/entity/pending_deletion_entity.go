@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	PendingDeletionStatusPending   = 1
+	PendingDeletionStatusUndone    = 2
+	PendingDeletionStatusFinalized = 3
+	// PendingDeletionStatusRestored the object is restored from the recycle bin
+	PendingDeletionStatusRestored = 4
+	// PendingDeletionStatusPurged the object is purged permanently after the retention days
+	PendingDeletionStatusPurged = 5
+)
+
+// PendingDeletion the deleted object which can be undone by the actor before it expires,
+// and it stays in the recycle bin until it is restored or purged.
+type PendingDeletion struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) INDEX object_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Reason     string    `xorm:"not null default '' VARCHAR(500) reason"`
+	Status     int       `xorm:"not null default 1 INT(11) INDEX(s) status"`
+	ExpiredAt  time.Time `xorm:"not null TIMESTAMP INDEX(s) expired_at"`
+}
+
+// TableName pending deletion table name
+func (PendingDeletion) TableName() string {
+	return "pending_deletion"
+}
+
+// DeletedObject the brief of the deleted question, answer or comment
+type DeletedObject struct {
+	ObjectID   string
+	UserID     string
+	QuestionID string
+	// ParentID the question or answer which the comment belongs to
+	ParentID   string
+	Title      string
+	ParsedText string
+}
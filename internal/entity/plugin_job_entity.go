@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	PluginJobRunStatusSuccess = "success"
+	PluginJobRunStatusFailed  = "failed"
+)
+
+// PluginJob the status of the scheduled job provided by plugin
+type PluginJob struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	PluginSlugName string    `xorm:"not null default '' VARCHAR(128) UNIQUE(plugin_job) plugin_slug_name"`
+	JobName        string    `xorm:"not null default '' VARCHAR(128) UNIQUE(plugin_job) job_name"`
+	Disabled       bool      `xorm:"not null default false BOOL disabled"`
+	LastRunAt      time.Time `xorm:"TIMESTAMP last_run_at"`
+	LastDuration   int64     `xorm:"not null default 0 BIGINT(20) last_duration"`
+	LastStatus     string    `xorm:"not null default '' VARCHAR(20) last_status"`
+	LastError      string    `xorm:"TEXT last_error"`
+}
+
+// TableName plugin job table name
+func (PluginJob) TableName() string {
+	return "plugin_job"
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// PluginKVStorage the key-value data of plugins
+type PluginKVStorage struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	PluginSlugName string    `xorm:"not null default '' VARCHAR(128) UNIQUE(plugin_kv) plugin_slug_name"`
+	Group          string    `xorm:"not null default '' VARCHAR(128) UNIQUE(plugin_kv) kv_group"`
+	Key            string    `xorm:"not null default '' VARCHAR(128) UNIQUE(plugin_kv) kv_key"`
+	Value          string    `xorm:"TEXT value"`
+}
+
+// TableName plugin kv storage table name
+func (PluginKVStorage) TableName() string {
+	return "plugin_kv_storage"
+}
+
+// PluginTableVersion the migrated version of the plugin tables
+type PluginTableVersion struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	PluginSlugName string    `xorm:"not null default '' VARCHAR(128) UNIQUE plugin_slug_name"`
+	Version        int       `xorm:"not null default 0 INT(11) version"`
+}
+
+// TableName plugin table version table name
+func (PluginTableVersion) TableName() string {
+	return "plugin_table_version"
+}
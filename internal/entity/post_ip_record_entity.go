@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// PostIPRecord the ip address and the device of the user when the post is created,
+// it is used to investigate the accounts operated by the same person
+type PostIPRecord struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) INDEX user_id"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) object_id"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	IP         string    `xorm:"not null default '' VARCHAR(64) INDEX ip"`
+	UserAgent  string    `xorm:"not null default '' VARCHAR(512) user_agent"`
+	// Country Region City the location of the ip address, they are empty if the geoip enrichment is disabled
+	Country string `xorm:"not null default '' VARCHAR(64) country"`
+	Region  string `xorm:"not null default '' VARCHAR(128) region"`
+	City    string `xorm:"not null default '' VARCHAR(128) city"`
+}
+
+// TableName post ip record table name
+func (PostIPRecord) TableName() string {
+	return "post_ip_record"
+}
@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	ProfileFieldTypeText    = "text"
+	ProfileFieldTypeURL     = "url"
+	ProfileFieldTypeSelect  = "select"
+	ProfileFieldTypeBoolean = "boolean"
+)
+
+const (
+	// ProfileFieldVisibilityPublic everyone can see the field value
+	ProfileFieldVisibilityPublic = "public"
+	// ProfileFieldVisibilityLoggedIn only the logged-in users can see the field value
+	ProfileFieldVisibilityLoggedIn = "logged_in"
+	// ProfileFieldVisibilityPrivate only the user themselves, the administrators and the moderators can see the field value
+	ProfileFieldVisibilityPrivate = "private"
+)
+
+// ProfileField the extra user profile field defined by admin
+type ProfileField struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Name       string    `xorm:"not null default '' unique VARCHAR(50) name"`
+	Label      string    `xorm:"not null default '' VARCHAR(100) label"`
+	Type       string    `xorm:"not null default 'text' VARCHAR(20) type"`
+	Options    string    `xorm:"not null TEXT options"`
+	Required   bool      `xorm:"not null default false BOOL required"`
+	Visibility string    `xorm:"not null default 'public' VARCHAR(20) visibility"`
+	Sort       int       `xorm:"not null default 0 INT(11) sort"`
+}
+
+// TableName profile field table name
+func (ProfileField) TableName() string {
+	return "profile_field"
+}
+
+// UserProfileField the value of the extra profile field of user
+type UserProfileField struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) user_id"`
+	FieldID   int       `xorm:"not null default 0 INT(11) UNIQUE(s) field_id"`
+	Value     string    `xorm:"not null default '' VARCHAR(1000) value"`
+}
+
+// TableName user profile field table name
+func (UserProfileField) TableName() string {
+	return "user_profile_field"
+}
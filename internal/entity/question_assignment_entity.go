@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	QuestionAssignmentStatusPending   = 1
+	QuestionAssignmentStatusAnswered  = 2
+	QuestionAssignmentStatusCancelled = 10
+)
+
+// QuestionAssignment the question assigned to the user to answer
+type QuestionAssignment struct {
+	ID             int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt      time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt      time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) question_id"`
+	UserID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) INDEX user_id"`
+	AssignerUserID string    `xorm:"not null default 0 BIGINT(20) assigner_user_id"`
+	// AutoAssigned whether the assignee is picked by the tag expertise scores
+	AutoAssigned bool `xorm:"not null default false BOOL auto_assigned"`
+	Status       int  `xorm:"not null default 1 INT(11) status"`
+	// DueAt the assignee is expected to answer before it, zero means no deadline
+	DueAt      time.Time `xorm:"TIMESTAMP due_at"`
+	AnsweredAt time.Time `xorm:"TIMESTAMP answered_at"`
+}
+
+// TableName question assignment table name
+func (QuestionAssignment) TableName() string {
+	return "question_assignment"
+}
+
+// IsOverdue whether the pending assignment has passed its deadline
+func (q *QuestionAssignment) IsOverdue() bool {
+	return q.Status == QuestionAssignmentStatusPending && !q.DueAt.IsZero() && time.Now().After(q.DueAt)
+}
+
+// QuestionExpertScore the expertise score of the user in the tags of the question
+type QuestionExpertScore struct {
+	UserID string  `xorm:"user_id"`
+	Score  float64 `xorm:"score"`
+}
@@ -32,6 +32,8 @@ const (
 	QuestionPin             = 2
 	QuestionShow            = 1
 	QuestionHide            = 2
+	// QuestionUnlisted the question is excluded from all the lists and only can be viewed by the share link
+	QuestionUnlisted = 3
 )
 
 var AdminQuestionSearchStatus = map[string]int{
@@ -67,12 +69,15 @@ type Question struct {
 	VoteCount        int       `xorm:"not null default 0 INT(11) vote_count"`
 	AnswerCount      int       `xorm:"not null default 0 INT(11) answer_count"`
 	HotScore         int       `xorm:"not null default 0 INT(11) hot_score"`
+	QualityScore     int       `xorm:"not null default 0 INT(11) quality_score"`
 	CollectionCount  int       `xorm:"not null default 0 INT(11) collection_count"`
 	FollowCount      int       `xorm:"not null default 0 INT(11) follow_count"`
 	AcceptedAnswerID string    `xorm:"not null default 0 BIGINT(20) accepted_answer_id"`
 	LastAnswerID     string    `xorm:"not null default 0 BIGINT(20) last_answer_id"`
 	PostUpdateTime   time.Time `xorm:"post_update_time TIMESTAMP"`
 	RevisionID       string    `xorm:"not null default 0 BIGINT(20) revision_id"`
+	// WorkflowState the key of the workflow state, empty means the initial state of the site workflow
+	WorkflowState string `xorm:"not null default '' VARCHAR(50) INDEX workflow_state"`
 }
 
 // TableName question table name
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// QuestionListIndexSize the number of the questions kept in each precomputed question list,
+// the pages after them are queried from the question table.
+const QuestionListIndexSize = 1000
+
+// QuestionListSortOrders the sort orders of the question lists which are precomputed
+var QuestionListSortOrders = map[string]bool{
+	"newest":     true,
+	"active":     true,
+	"hot":        true,
+	"score":      true,
+	"unanswered": true,
+}
+
+// QuestionListPartition one precomputed question list, the list of the homepage has the empty list key,
+// the list of the tag has the list key of the tag and its synonyms like ",1,2,".
+type QuestionListPartition struct {
+	ID           int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt    time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt    time.Time `xorm:"updated TIMESTAMP updated_at"`
+	ListKey      string    `xorm:"not null default '' VARCHAR(500) UNIQUE(partition) list_key"`
+	SortOrder    string    `xorm:"not null default '' VARCHAR(20) UNIQUE(partition) sort_order"`
+	InDays       int       `xorm:"not null default 0 INT(11) UNIQUE(partition) in_days"`
+	QualityBlend int       `xorm:"not null default 0 INT(11) UNIQUE(partition) quality_blend"`
+	Total        int64     `xorm:"not null default 0 BIGINT(20) total"`
+	Dirty        bool      `xorm:"not null default false BOOL dirty"`
+	RefreshedAt  time.Time `xorm:"TIMESTAMP refreshed_at"`
+	LastReadAt   time.Time `xorm:"TIMESTAMP last_read_at"`
+}
+
+// TableName question list partition table name
+func (QuestionListPartition) TableName() string {
+	return "question_list_partition"
+}
+
+// QuestionListKey get the list key of the tags, the key does not depend on the order of the tags
+func QuestionListKey(tagIDs []string) string {
+	if len(tagIDs) == 0 {
+		return ""
+	}
+	ids := make([]string, len(tagIDs))
+	copy(ids, tagIDs)
+	sort.Strings(ids)
+	return "," + strings.Join(ids, ",") + ","
+}
+
+// QuestionListTagIDs get the tags of the list key
+func QuestionListTagIDs(listKey string) []string {
+	listKey = strings.Trim(listKey, ",")
+	if len(listKey) == 0 {
+		return nil
+	}
+	return strings.Split(listKey, ",")
+}
+
+// QuestionListIndex the question at the position of the precomputed question list
+type QuestionListIndex struct {
+	ID          int    `xorm:"not null pk autoincr INT(11) id"`
+	PartitionID int    `xorm:"not null default 0 INT(11) INDEX(position) partition_id"`
+	Position    int    `xorm:"not null default 0 INT(11) INDEX(position) position"`
+	QuestionID  string `xorm:"not null default 0 BIGINT(20) question_id"`
+}
+
+// TableName question list index table name
+func (QuestionListIndex) TableName() string {
+	return "question_list_index"
+}
+
+// TagSummary the positions of the available main tag in the tag lists of each sort order
+type TagSummary struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	TagID           string    `xorm:"not null default 0 BIGINT(20) UNIQUE tag_id"`
+	QuestionCount   int       `xorm:"not null default 0 INT(11) question_count"`
+	PopularPosition int       `xorm:"not null default 0 INT(11) INDEX popular_position"`
+	NamePosition    int       `xorm:"not null default 0 INT(11) INDEX name_position"`
+	NewestPosition  int       `xorm:"not null default 0 INT(11) INDEX newest_position"`
+}
+
+// TableName tag summary table name
+func (TagSummary) TableName() string {
+	return "tag_summary"
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+const (
+	QuestionPollResultAlways     = "always"
+	QuestionPollResultAfterVote  = "after_vote"
+	QuestionPollResultAfterClose = "after_close"
+)
+
+// QuestionPoll the poll attached to the question
+type QuestionPoll struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	// Multiple whether the voter can choose more than one option
+	Multiple bool `xorm:"not null default false BOOL multiple"`
+	// ResultPolicy when the results are shown to the users, always, after_vote or after_close
+	ResultPolicy string `xorm:"not null default 'always' VARCHAR(20) result_policy"`
+	// CloseAt the poll can not be voted after it, zero means never close
+	CloseAt time.Time `xorm:"TIMESTAMP close_at"`
+}
+
+// TableName question poll table name
+func (QuestionPoll) TableName() string {
+	return "question_poll"
+}
+
+// IsClosed whether the poll has passed its close date
+func (q *QuestionPoll) IsClosed() bool {
+	return !q.CloseAt.IsZero() && time.Now().After(q.CloseAt)
+}
+
+// QuestionPollOption the option of the question poll
+type QuestionPollOption struct {
+	ID      int    `xorm:"not null pk autoincr INT(11) id"`
+	PollID  int    `xorm:"not null default 0 INT(11) INDEX poll_id"`
+	Content string `xorm:"not null default '' VARCHAR(200) content"`
+	Sort    int    `xorm:"not null default 0 INT(11) sort"`
+}
+
+// TableName question poll option table name
+func (QuestionPollOption) TableName() string {
+	return "question_poll_option"
+}
+
+// QuestionPollVote the option chosen by the user
+type QuestionPollVote struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	PollID    int       `xorm:"not null default 0 INT(11) INDEX poll_id"`
+	OptionID  int       `xorm:"not null default 0 INT(11) UNIQUE(s) option_id"`
+	UserID    string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) user_id"`
+}
+
+// TableName question poll vote table name
+func (QuestionPollVote) TableName() string {
+	return "question_poll_vote"
+}
+
+// QuestionPollOptionCount the vote count of the poll option
+type QuestionPollOptionCount struct {
+	OptionID int `xorm:"option_id"`
+	Count    int `xorm:"count"`
+}
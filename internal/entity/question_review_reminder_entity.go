@@ -0,0 +1,38 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// QuestionReviewReminder the reminder asking the author whether any answer solved the question
+type QuestionReviewReminder struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created not null default CURRENT_TIMESTAMP TIMESTAMP created_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	// Code the token of the one-click accept links in the reminder
+	Code      string    `xorm:"not null default '' VARCHAR(64) UNIQUE code"`
+	ExpiredAt time.Time `xorm:"expired_at TIMESTAMP"`
+}
+
+// TableName question review reminder table name
+func (QuestionReviewReminder) TableName() string {
+	return "question_review_reminder"
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// QuestionShareLink the share link of the unlisted question
+type QuestionShareLink struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID string    `xorm:"not null default 0 BIGINT(20) INDEX question_id"`
+	UserID     string    `xorm:"not null default 0 BIGINT(20) user_id"`
+	Token      string    `xorm:"not null VARCHAR(64) UNIQUE token"`
+	ExpiredAt  time.Time `xorm:"TIMESTAMP expired_at"`
+}
+
+// TableName question share link table name
+func (QuestionShareLink) TableName() string {
+	return "question_share_link"
+}
+
+// IsValid whether the share link is not expired
+func (l *QuestionShareLink) IsValid() bool {
+	return l.ExpiredAt.IsZero() || time.Now().Before(l.ExpiredAt)
+}
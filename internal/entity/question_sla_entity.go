@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+const (
+	SLAEscalationChannelInbox = "inbox"
+	SLAEscalationChannelEmail = "email"
+)
+
+// TagSLAPolicy the service level agreement of the questions in the tag
+type TagSLAPolicy struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt time.Time `xorm:"updated TIMESTAMP updated_at"`
+	TagID     string    `xorm:"not null default 0 BIGINT(20) UNIQUE tag_id"`
+	// FirstResponseHours the question should be answered within these hours, 0 means no limit
+	FirstResponseHours int `xorm:"not null default 0 INT(11) first_response_hours"`
+	// ResolutionHours the question should have an accepted answer within these hours, 0 means no limit
+	ResolutionHours int `xorm:"not null default 0 INT(11) resolution_hours"`
+	// EscalationChannels the comma separated channels to notify the staff when the SLA is breached
+	EscalationChannels string `xorm:"not null default '' VARCHAR(100) escalation_channels"`
+}
+
+// TableName tag sla policy table name
+func (TagSLAPolicy) TableName() string {
+	return "tag_sla_policy"
+}
+
+// QuestionSLA the SLA state of the open question
+type QuestionSLA struct {
+	ID                 int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt          time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt          time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	FirstResponseDueAt time.Time `xorm:"TIMESTAMP first_response_due_at"`
+	ResolutionDueAt    time.Time `xorm:"TIMESTAMP resolution_due_at"`
+	// the time when the breach is detected and escalated, zero means not breached
+	FirstResponseBreachedAt time.Time `xorm:"TIMESTAMP first_response_breached_at"`
+	ResolutionBreachedAt    time.Time `xorm:"TIMESTAMP resolution_breached_at"`
+}
+
+// TableName question sla table name
+func (QuestionSLA) TableName() string {
+	return "question_sla"
+}
+
+// SLAQuestion the open question in the tag with the SLA policy
+type SLAQuestion struct {
+	QuestionID  string    `xorm:"question_id"`
+	TagID       string    `xorm:"tag_id"`
+	CreatedAt   time.Time `xorm:"created_at"`
+	AnswerCount int       `xorm:"answer_count"`
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// QuestionSummary the summary of the question and its accepted or top answer, it is generated again
+// when the question or the answer is revised
+type QuestionSummary struct {
+	ID                 int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt          time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt          time.Time `xorm:"updated TIMESTAMP updated_at"`
+	QuestionID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE question_id"`
+	QuestionRevisionID string    `xorm:"not null default 0 BIGINT(20) question_revision_id"`
+	AnswerID           string    `xorm:"not null default 0 BIGINT(20) answer_id"`
+	AnswerRevisionID   string    `xorm:"not null default 0 BIGINT(20) answer_revision_id"`
+	Content            string    `xorm:"not null TEXT content"`
+	Provider           string    `xorm:"not null default '' VARCHAR(100) provider"`
+	AIGenerated        bool      `xorm:"not null default false BOOL ai_generated"`
+}
+
+// TableName question summary table name
+func (QuestionSummary) TableName() string {
+	return "question_summary"
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// RevisionCompactionLog the record of each run of the revision compaction job
+type RevisionCompactionLog struct {
+	ID        int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt time.Time `xorm:"created TIMESTAMP created_at"`
+	// PrunedCount the number of the old revisions deleted by the retention policy
+	PrunedCount int `xorm:"not null default 0 INT(11) pruned_count"`
+	// CompactedCount the number of the old revisions stored as the delta
+	CompactedCount int `xorm:"not null default 0 INT(11) compacted_count"`
+	// ReclaimedBytes the size of the revision content reclaimed by this run
+	ReclaimedBytes int64 `xorm:"not null default 0 BIGINT(20) reclaimed_bytes"`
+}
+
+// TableName revision compaction log table name
+func (RevisionCompactionLog) TableName() string {
+	return "revision_compaction_log"
+}
@@ -34,6 +34,13 @@ const (
 	RevisionReviewRejectStatus = 3
 )
 
+const (
+	// RevisionContentFull the content of the revision is stored as it is
+	RevisionContentFull = 0
+	// RevisionContentDelta the content of the revision is stored as the delta of the base revision
+	RevisionContentDelta = 1
+)
+
 // Revision revision
 type Revision struct {
 	ID           string    `xorm:"not null pk autoincr BIGINT(20) id"`
@@ -47,6 +54,9 @@ type Revision struct {
 	Log          string    `xorm:"VARCHAR(255) log"`
 	Status       int       `xorm:"not null default 1 INT(11) status"`
 	ReviewUserID int64     `xorm:"not null default 0 BIGINT(20) review_user_id"`
+	// ContentFormat the compacted old revision only stores the delta of its base revision
+	ContentFormat  int    `xorm:"not null default 0 INT(11) content_format"`
+	BaseRevisionID string `xorm:"not null default 0 BIGINT(20) base_revision_id"`
 }
 
 // TableName revision table name
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SearchLog aggregated search query log of one day, no user information is recorded
+type SearchLog struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Date            string    `xorm:"not null default '' VARCHAR(10) UNIQUE(s) date"`
+	Query           string    `xorm:"not null default '' VARCHAR(100) UNIQUE(s) query"`
+	SearchCount     int       `xorm:"not null default 0 INT(11) search_count"`
+	ZeroResultCount int       `xorm:"not null default 0 INT(11) zero_result_count"`
+	ResultCount     int64     `xorm:"not null default 0 BIGINT(20) result_count"`
+	ClickCount      int       `xorm:"not null default 0 INT(11) click_count"`
+}
+
+// TableName search log table name
+func (SearchLog) TableName() string {
+	return "search_log"
+}
+
+// SearchClickLog aggregated click count of the search result of one day
+type SearchClickLog struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt  time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Date       string    `xorm:"not null default '' VARCHAR(10) UNIQUE(s) date"`
+	Query      string    `xorm:"not null default '' VARCHAR(100) UNIQUE(s) query"`
+	ObjectID   string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) object_id"`
+	ClickCount int       `xorm:"not null default 0 INT(11) click_count"`
+}
+
+// TableName search click log table name
+func (SearchClickLog) TableName() string {
+	return "search_click_log"
+}
+
+// SearchLogQueryStat search statistics of the query
+type SearchLogQueryStat struct {
+	Query           string `xorm:"query"`
+	SearchCount     int    `xorm:"search_count"`
+	ZeroResultCount int    `xorm:"zero_result_count"`
+	ClickCount      int    `xorm:"click_count"`
+}
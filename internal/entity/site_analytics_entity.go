@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SiteAnalyticsDaily site analytics rollup of one day
+type SiteAnalyticsDaily struct {
+	ID                    int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt             time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt             time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Date                  string    `xorm:"not null default '' VARCHAR(10) UNIQUE date"`
+	DailyActiveUsers      int       `xorm:"not null default 0 INT(11) daily_active_users"`
+	WeeklyActiveUsers     int       `xorm:"not null default 0 INT(11) weekly_active_users"`
+	MonthlyActiveUsers    int       `xorm:"not null default 0 INT(11) monthly_active_users"`
+	NewUserCount          int       `xorm:"not null default 0 INT(11) new_user_count"`
+	QuestionCount         int       `xorm:"not null default 0 INT(11) question_count"`
+	AcceptedQuestionCount int       `xorm:"not null default 0 INT(11) accepted_question_count"`
+	AnswerCount           int       `xorm:"not null default 0 INT(11) answer_count"`
+	CommentCount          int       `xorm:"not null default 0 INT(11) comment_count"`
+}
+
+// TableName site analytics daily table name
+func (SiteAnalyticsDaily) TableName() string {
+	return "site_analytics_daily"
+}
+
+// SiteAnalyticsTagDaily question count of each tag in one day
+type SiteAnalyticsTagDaily struct {
+	ID            int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt     time.Time `xorm:"created TIMESTAMP created_at"`
+	Date          string    `xorm:"not null default '' VARCHAR(10) UNIQUE(s) date"`
+	TagID         string    `xorm:"not null default 0 BIGINT(20) UNIQUE(s) tag_id"`
+	QuestionCount int       `xorm:"not null default 0 INT(11) question_count"`
+}
+
+// TableName site analytics tag daily table name
+func (SiteAnalyticsTagDaily) TableName() string {
+	return "site_analytics_tag_daily"
+}
+
+// SiteAnalyticsTagCount question count of tag
+type SiteAnalyticsTagCount struct {
+	TagID         string `xorm:"tag_id"`
+	QuestionCount int    `xorm:"question_count"`
+}
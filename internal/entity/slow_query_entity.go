@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SlowQuery the slow queries of the same statement, they are aggregated from all the instances
+type SlowQuery struct {
+	ID          int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt   time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt   time.Time `xorm:"updated TIMESTAMP updated_at"`
+	Fingerprint string    `xorm:"not null default '' VARCHAR(32) UNIQUE fingerprint"`
+	Statement   string    `xorm:"not null TEXT statement"`
+	Sample      string    `xorm:"not null TEXT sample"`
+	Plan        string    `xorm:"not null TEXT plan"`
+	Count       int64     `xorm:"not null default 0 BIGINT(20) count"`
+	TotalTime   int64     `xorm:"not null default 0 BIGINT(20) INDEX total_time"`
+	MaxTime     int64     `xorm:"not null default 0 BIGINT(20) max_time"`
+	LastSeenAt  time.Time `xorm:"TIMESTAMP last_seen_at"`
+}
+
+// TableName slow query table name
+func (SlowQuery) TableName() string {
+	return "slow_query"
+}
@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// SyncChange the change of the question, answer or comment, the id is the cursor of the delta sync
+type SyncChange struct {
+	ID         int64     `xorm:"not null pk autoincr BIGINT(20) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP INDEX created_at"`
+	ObjectType string    `xorm:"not null default '' VARCHAR(20) object_type"`
+	ObjectID   string    `xorm:"not null default '0' BIGINT(20) object_id"`
+	QuestionID string    `xorm:"not null default '0' BIGINT(20) question_id"`
+}
+
+// TableName sync change table name
+func (SyncChange) TableName() string {
+	return "sync_change"
+}
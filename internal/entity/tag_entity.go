@@ -38,13 +38,13 @@ type Tag struct {
 	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
 	MainTagID       int64     `xorm:"not null default 0 BIGINT(20) main_tag_id"`
 	MainTagSlugName string    `xorm:"not null default '' VARCHAR(35) main_tag_slug_name"`
-	SlugName        string    `xorm:"not null default '' unique VARCHAR(35) slug_name"`
+	Status          int       `xorm:"not null default 1 INT(11) INDEX(status_slug_name) status"`
+	SlugName        string    `xorm:"not null default '' unique INDEX(status_slug_name) VARCHAR(35) slug_name"`
 	DisplayName     string    `xorm:"not null default '' VARCHAR(35) display_name"`
 	OriginalText    string    `xorm:"not null MEDIUMTEXT original_text"`
 	ParsedText      string    `xorm:"not null MEDIUMTEXT parsed_text"`
 	FollowCount     int       `xorm:"not null default 0 INT(11) follow_count"`
 	QuestionCount   int       `xorm:"not null default 0 INT(11) question_count"`
-	Status          int       `xorm:"not null default 1 INT(11) status"`
 	Recommend       bool      `xorm:"not null default false BOOL recommend"`
 	Reserved        bool      `xorm:"not null default false BOOL reserved"`
 	RevisionID      string    `xorm:"not null default 0 BIGINT(20) revision_id"`
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// TagSubscriptionDigest the new question waiting for the digest email of the user whose group is subscribed
+// to the tags of the question
+type TagSubscriptionDigest struct {
+	ID         int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt  time.Time `xorm:"created TIMESTAMP created_at"`
+	UserID     string    `xorm:"not null default '0' BIGINT(20) INDEX user_id"`
+	QuestionID string    `xorm:"not null default '0' BIGINT(20) question_id"`
+	GroupName  string    `xorm:"not null default '' VARCHAR(100) group_name"`
+}
+
+// TableName tag subscription digest table name
+func (TagSubscriptionDigest) TableName() string {
+	return "tag_subscription_digest"
+}
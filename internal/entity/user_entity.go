@@ -44,6 +44,7 @@ type User struct {
 	SuspendedAt    time.Time `xorm:"TIMESTAMP suspended_at"`
 	DeletedAt      time.Time `xorm:"TIMESTAMP deleted_at"`
 	LastLoginDate  time.Time `xorm:"TIMESTAMP last_login_date"`
+	PassUpdatedAt  time.Time `xorm:"TIMESTAMP pass_updated_at"`
 	Username       string    `xorm:"not null default '' VARCHAR(50) UNIQUE username"`
 	Pass           string    `xorm:"not null default '' VARCHAR(255) pass"`
 	EMail          string    `xorm:"not null VARCHAR(100) e_mail"`
@@ -66,6 +67,14 @@ type User struct {
 	IsAdmin        bool      `xorm:"not null default false BOOL is_admin"`
 	Language       string    `xorm:"not null default '' VARCHAR(100) language"`
 	ColorScheme    string    `xorm:"not null default '' VARCHAR(100) color_scheme"`
+	TimeZone       string    `xorm:"not null default '' VARCHAR(64) time_zone"`
+	DateFormat     string    `xorm:"not null default '' VARCHAR(20) date_format"`
+
+	// the last time and the times of sending the email verification reminder
+	VerificationRemindedAt    time.Time `xorm:"TIMESTAMP verification_reminded_at"`
+	VerificationReminderCount int       `xorm:"not null default 0 INT(11) verification_reminder_count"`
+	// LeaderboardOptOut the user is not shown in the leaderboards
+	LeaderboardOptOut bool `xorm:"not null default false BOOL leaderboard_opt_out"`
 }
 
 // TableName user table name
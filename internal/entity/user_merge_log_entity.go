@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package entity
+
+import "time"
+
+// UserMergeLog the record of merging the duplicate user into the primary user
+type UserMergeLog struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	PrimaryUserID   string    `xorm:"not null default 0 BIGINT(20) INDEX primary_user_id"`
+	DuplicateUserID string    `xorm:"not null default 0 BIGINT(20) INDEX duplicate_user_id"`
+	OperatorUserID  string    `xorm:"not null default 0 BIGINT(20) operator_user_id"`
+	// DuplicateUsername the username of the duplicate user when it was merged
+	DuplicateUsername string `xorm:"not null default '' VARCHAR(50) duplicate_username"`
+}
+
+// TableName user merge log table name
+func (UserMergeLog) TableName() string {
+	return "user_merge_log"
+}
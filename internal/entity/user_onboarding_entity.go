@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package entity
+
+import "time"
+
+// UserOnboarding the onboarding progress of the user, the time of each step is recorded when it is completed
+type UserOnboarding struct {
+	ID              int       `xorm:"not null pk autoincr INT(11) id"`
+	CreatedAt       time.Time `xorm:"created TIMESTAMP created_at"`
+	UpdatedAt       time.Time `xorm:"updated TIMESTAMP updated_at"`
+	UserID          string    `xorm:"not null default 0 BIGINT(20) UNIQUE user_id"`
+	EmailVerifiedAt time.Time `xorm:"TIMESTAMP email_verified_at"`
+	AvatarSetAt     time.Time `xorm:"TIMESTAMP avatar_set_at"`
+	TagsFollowedAt  time.Time `xorm:"TIMESTAMP tags_followed_at"`
+	FirstPostAt     time.Time `xorm:"TIMESTAMP first_post_at"`
+	TourTakenAt     time.Time `xorm:"TIMESTAMP tour_taken_at"`
+	// CompletedAt the time all the steps are completed, the user is awarded the badge since then
+	CompletedAt time.Time `xorm:"TIMESTAMP completed_at"`
+}
+
+// TableName user onboarding table name
+func (UserOnboarding) TableName() string {
+	return "user_onboarding"
+}
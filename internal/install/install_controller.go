@@ -48,7 +48,7 @@ import (
 // @Success 200 {object} handler.RespBody{data=[]translator.LangOption}
 // @Router /installation/language/options [get]
 func LangOptions(ctx *gin.Context) {
-	handler.HandleResponse(ctx, nil, translator.LanguageOptions)
+	handler.HandleResponse(ctx, nil, translator.GetLanguageOptions())
 }
 
 // CheckConfigFileAndRedirectToInstallPage if config file not exist try to redirect to install page
@@ -63,6 +63,7 @@ func (m *Mentor) InitDB() error {
 	m.do("check table exist", m.checkTableExist)
 	m.do("sync table", m.syncTable)
 	m.do("init version table", m.initVersionTable)
+	m.do("init migration history", m.initMigrationHistory)
 	m.do("init admin user", m.initAdminUser)
 	m.do("init config", m.initConfig)
 	m.do("init default privileges config", m.initDefaultRankPrivileges)
@@ -78,6 +79,7 @@ func (m *Mentor) InitDB() error {
 	m.do("init site info user config", m.initSiteInfoUsersConfig)
 	m.do("init site info privilege rank", m.initSiteInfoPrivilegeRank)
 	m.do("init site info write", m.initSiteInfoWrite)
+	m.do("init site info legal", m.initSiteInfoLegal)
 	m.do("init default content", m.initDefaultContent)
 	return m.err
 }
@@ -107,6 +109,14 @@ func (m *Mentor) initVersionTable() {
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.Version{ID: 1, VersionNumber: ExpectedVersion()})
 }
 
+func (m *Mentor) initMigrationHistory() {
+	for i, migration := range migrations {
+		if m.err = recordMigration(m.engine, int64(i+1), migration); m.err != nil {
+			return
+		}
+	}
+}
+
 func (m *Mentor) initAdminUser() {
 	generateFromPassword, _ := bcrypt.GenerateFromPassword([]byte(m.userData.AdminPassword), bcrypt.DefaultCost)
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.User{
@@ -208,8 +218,10 @@ func (m *Mentor) initSiteInfoThemeConfig() {
 
 func (m *Mentor) initSiteInfoSEOConfig() {
 	seoData := map[string]interface{}{
-		"permalink": constant.PermalinkQuestionID,
-		"robots":    defaultSEORobotTxt + m.userData.SiteURL + "/sitemap.xml",
+		"permalink":       constant.PermalinkQuestionID,
+		"robots":          defaultSEORobotTxt + m.userData.SiteURL + "/sitemap.xml",
+		"structured_data": true,
+		"social_meta":     true,
 	}
 	seoDataBytes, _ := json.Marshal(seoData)
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.SiteInfo{
@@ -252,7 +264,8 @@ func (m *Mentor) initSiteInfoPrivilegeRank() {
 
 func (m *Mentor) initSiteInfoWrite() {
 	writeData := map[string]interface{}{
-		"restrict_answer": true,
+		"restrict_answer":     true,
+		"delete_undo_minutes": schema.DefaultDeleteUndoMinutes,
 	}
 	writeDataBytes, _ := json.Marshal(writeData)
 	_, m.err = m.engine.Context(m.ctx).Insert(&entity.SiteInfo{
@@ -262,6 +275,18 @@ func (m *Mentor) initSiteInfoWrite() {
 	})
 }
 
+func (m *Mentor) initSiteInfoLegal() {
+	legalData := map[string]interface{}{
+		"read_notification_retention_days": schema.DefaultReadNotificationRetentionDays,
+	}
+	legalDataBytes, _ := json.Marshal(legalData)
+	_, m.err = m.engine.Context(m.ctx).Insert(&entity.SiteInfo{
+		Type:    "legal",
+		Content: string(legalDataBytes),
+		Status:  1,
+	})
+}
+
 func (m *Mentor) initDefaultContent() {
 	uniqueIDRepo := unique.NewUniqueIDRepo(&data.Data{DB: m.engine})
 	now := time.Now()
@@ -60,6 +60,7 @@ var (
 		&entity.Uniqid{},
 		&entity.User{},
 		&entity.Version{},
+		&entity.MigrationHistory{},
 		&entity.Role{},
 		&entity.RolePowerRel{},
 		&entity.Power{},
@@ -69,6 +70,74 @@ var (
 		&entity.UserNotificationConfig{},
 		&entity.PluginUserConfig{},
 		&entity.Review{},
+		&entity.SiteAnalyticsDaily{},
+		&entity.SiteAnalyticsTagDaily{},
+		&entity.SearchLog{},
+		&entity.SearchClickLog{},
+		&entity.ShareReferral{},
+		&entity.PluginJob{},
+		&entity.PluginKVStorage{},
+		&entity.PluginTableVersion{},
+		&entity.Page{},
+		&entity.Announcement{},
+		&entity.AnnouncementDismissal{},
+		&entity.ProfileField{},
+		&entity.UserProfileField{},
+		&entity.UserFollow{},
+		&entity.UserSession{},
+		&entity.Invitation{},
+		&entity.InvitationRecord{},
+		&entity.BlockedSignup{},
+		&entity.QuestionAssignment{},
+		&entity.TagSLAPolicy{},
+		&entity.QuestionSLA{},
+		&entity.Article{},
+		&entity.ArticleQuestionRel{},
+		&entity.QuestionPoll{},
+		&entity.QuestionPollOption{},
+		&entity.QuestionPollVote{},
+		&entity.CoAuthor{},
+		&entity.UserMergeLog{},
+		&entity.Leaderboard{},
+		&entity.UserTagScore{},
+		&entity.PendingDeletion{},
+		&entity.ScheduledJob{},
+		&entity.UserImpersonationLog{},
+		&entity.QuestionShareLink{},
+		&entity.LinkPreview{},
+		&entity.DeadLink{},
+		&entity.AIAssistantUsage{},
+		&entity.QuestionSummary{},
+		&entity.ContentEmbedding{},
+		&entity.AnswerEnvironment{},
+		&entity.QuestionReviewReminder{},
+		&entity.RevisionCompactionLog{},
+		&entity.LoginFailure{},
+		&entity.EmailSendLog{},
+		&entity.EmailSuppression{},
+		&entity.EmailTemplate{},
+		&entity.UserOnboarding{},
+		&entity.ExperimentEvent{},
+		&entity.EventOutbox{},
+		&entity.InboundQuestion{},
+		&entity.GitHubCrossReference{},
+		&entity.JiraIssueLink{},
+		&entity.QuestionListPartition{},
+		&entity.QuestionListIndex{},
+		&entity.TagSummary{},
+		&entity.SlowQuery{},
+		&entity.BannedWordHit{},
+		&entity.UsernameHistory{},
+		&entity.UserNameChange{},
+		&entity.SyncChange{},
+		&entity.ModerationRuleHit{},
+		&entity.QuestionWorkflowTransition{},
+		&entity.TagExpert{},
+		&entity.AnswerVerification{},
+		&entity.TagSubscriptionDigest{},
+		&entity.LegalHold{},
+		&entity.LegalHoldLog{},
+		&entity.PostIPRecord{},
 	}
 
 	roles = []*entity.Role{
@@ -119,6 +188,13 @@ var (
 		{ID: 39, Name: "recover answer", PowerType: permission.AnswerUnDelete, Description: "recover deleted answer"},
 		{ID: 40, Name: "recover question", PowerType: permission.QuestionUnDelete, Description: "recover deleted question"},
 		{ID: 41, Name: "recover tag", PowerType: permission.TagUnDelete, Description: "recover deleted tag"},
+		{ID: 42, Name: "question assign", PowerType: permission.QuestionAssign, Description: "assign the question to users"},
+		{ID: 43, Name: "article manage", PowerType: permission.ArticleManage, Description: "write articles and convert answers into articles"},
+		{ID: 44, Name: "vote view voters", PowerType: permission.VoteViewVoters, Description: "view the voters of the question or answer"},
+		{ID: 45, Name: "report review", PowerType: permission.ReportReview, Description: "review the flagged posts"},
+		{ID: 46, Name: "analytics view", PowerType: permission.AnalyticsView, Description: "view the site analytics"},
+		{ID: 47, Name: "ai assist", PowerType: permission.AIAssist, Description: "generate the answer draft and the question summary"},
+		{ID: 48, Name: "answer convert", PowerType: permission.AnswerConvert, Description: "convert the comments into answers and the answers into comments"},
 	}
 
 	rolePowerRels = []*entity.RolePowerRel{
@@ -164,6 +240,13 @@ var (
 		{RoleID: 2, PowerType: permission.AnswerUnDelete},
 		{RoleID: 2, PowerType: permission.QuestionUnDelete},
 		{RoleID: 2, PowerType: permission.TagUnDelete},
+		{RoleID: 2, PowerType: permission.QuestionAssign},
+		{RoleID: 2, PowerType: permission.ArticleManage},
+		{RoleID: 2, PowerType: permission.VoteViewVoters},
+		{RoleID: 2, PowerType: permission.ReportReview},
+		{RoleID: 2, PowerType: permission.AnalyticsView},
+		{RoleID: 2, PowerType: permission.AIAssist},
+		{RoleID: 2, PowerType: permission.AnswerConvert},
 
 		{RoleID: 3, PowerType: permission.QuestionAdd},
 		{RoleID: 3, PowerType: permission.QuestionEdit},
@@ -206,6 +289,12 @@ var (
 		{RoleID: 3, PowerType: permission.AnswerUnDelete},
 		{RoleID: 3, PowerType: permission.QuestionUnDelete},
 		{RoleID: 3, PowerType: permission.TagUnDelete},
+		{RoleID: 3, PowerType: permission.QuestionAssign},
+		{RoleID: 3, PowerType: permission.ArticleManage},
+		{RoleID: 3, PowerType: permission.VoteViewVoters},
+		{RoleID: 3, PowerType: permission.ReportReview},
+		{RoleID: 3, PowerType: permission.AIAssist},
+		{RoleID: 3, PowerType: permission.AnswerConvert},
 	}
 
 	adminUserRoleRel = &entity.UserRoleRel{
@@ -343,5 +432,27 @@ var (
 		{ID: 128, Key: "rank.answer.undeleted", Value: `-1`},
 		{ID: 129, Key: "rank.question.undeleted", Value: `-1`},
 		{ID: 130, Key: "rank.tag.undeleted", Value: `-1`},
+		{ID: 131, Key: "rank.question.assign", Value: `-1`},
+		{ID: 132, Key: "rank.article.manage", Value: `-1`},
+		{ID: 133, Key: "rank.vote.view_voters", Value: `-1`},
+		{ID: 134, Key: "rank.report.review", Value: `-1`},
+		{ID: 135, Key: "rank.analytics.view", Value: `-1`},
+		{ID: 136, Key: "rank.ai.assist", Value: `500`},
+		{ID: 137, Key: "answer.outdated", Value: `0`},
+		{ID: 138, Key: "question.accept_answer", Value: `0`},
+		{ID: 139, Key: "question.unaccept_answer", Value: `0`},
+		{ID: 140, Key: "answer.converted_from_comment", Value: `0`},
+		{ID: 141, Key: "answer.converted_to_comment", Value: `0`},
+		{ID: 142, Key: "question.merged_into", Value: `0`},
+		{ID: 143, Key: "question.merged_from", Value: `0`},
+		{ID: 144, Key: "user.onboarded", Value: `5`},
+		{ID: 145, Key: "question.workflow_changed", Value: `0`},
+		{ID: 146, Key: "question.deletion_finalized", Value: `0`},
+		{ID: 147, Key: "answer.deletion_finalized", Value: `0`},
+		{ID: 148, Key: "question.comment_deletion_finalized", Value: `0`},
+		{ID: 149, Key: "answer.comment_deletion_finalized", Value: `0`},
+		{ID: 150, Key: "question.comment_undeleted", Value: `0`},
+		{ID: 151, Key: "answer.comment_undeleted", Value: `0`},
+		{ID: 152, Key: "rank.answer.convert", Value: `-1`},
 	}
 )
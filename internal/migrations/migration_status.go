@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/contexts"
+)
+
+// MigrationStatus the status of the migration in the database
+type MigrationStatus struct {
+	VersionNumber int64
+	Version       string
+	Description   string
+	Applied       bool
+	CanRollback   bool
+	// Fingerprint the fingerprint of the migration in the application
+	Fingerprint string
+	// AppliedFingerprint the fingerprint recorded when the migration was applied, it is empty if it is not recorded
+	AppliedFingerprint string
+}
+
+// FingerprintMismatch whether the applied migration is not the one at the same position in the application,
+// for example the migrations are reordered or renamed. The changes in the body of a migration are not detected.
+func (s *MigrationStatus) FingerprintMismatch() bool {
+	return s.Applied && len(s.AppliedFingerprint) > 0 && s.AppliedFingerprint != s.Fingerprint
+}
+
+// recordMigration record the migration applied as the version number
+func recordMigration(engine *xorm.Engine, versionNumber int64, m Migration) (err error) {
+	if _, err = engine.Delete(&entity.MigrationHistory{VersionNumber: versionNumber}); err != nil {
+		return err
+	}
+	_, err = engine.Insert(&entity.MigrationHistory{
+		VersionNumber: versionNumber,
+		Version:       m.Version(),
+		Description:   m.Description(),
+		Checksum:      m.Fingerprint(),
+	})
+	return err
+}
+
+// GetMigrationStatus get the status of all the migrations and the current db version
+func GetMigrationStatus(engine *xorm.Engine) (status []*MigrationStatus, currentDBVersion int64, err error) {
+	currentDBVersion, err = GetCurrentDBVersion(engine)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = engine.Sync(new(entity.MigrationHistory)); err != nil {
+		return nil, 0, fmt.Errorf("sync migration history failed: %v", err)
+	}
+	histories := make([]*entity.MigrationHistory, 0)
+	if err = engine.Find(&histories); err != nil {
+		return nil, 0, fmt.Errorf("get migration history failed: %v", err)
+	}
+	fingerprints := make(map[int64]string, len(histories))
+	for _, history := range histories {
+		fingerprints[history.VersionNumber] = history.Checksum
+	}
+	for i, m := range migrations {
+		versionNumber := int64(i + 1)
+		status = append(status, &MigrationStatus{
+			VersionNumber:      versionNumber,
+			Version:            m.Version(),
+			Description:        m.Description(),
+			Applied:            versionNumber <= currentDBVersion,
+			CanRollback:        m.CanRollback(),
+			Fingerprint:        m.Fingerprint(),
+			AppliedFingerprint: fingerprints[versionNumber],
+		})
+	}
+	return status, currentDBVersion, nil
+}
+
+// MigrationStatusByConfig get the status of all the migrations of the database in the config
+func MigrationStatusByConfig(dbConf *data.Database) (status []*MigrationStatus, currentDBVersion int64, err error) {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer engine.Close()
+	return GetMigrationStatus(engine)
+}
+
+// CheckPendingMigrations return the error if the database is not upgraded to the version of the application
+func CheckPendingMigrations(dbConf *data.Database) (err error) {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+	currentDBVersion, err := GetCurrentDBVersion(engine)
+	if err != nil {
+		return err
+	}
+	if currentDBVersion < ExpectedVersion() {
+		return fmt.Errorf("the database version is %d but the application needs %d, "+
+			"please run `answer upgrade` first", currentDBVersion, ExpectedVersion())
+	}
+	return nil
+}
+
+// errDryRun the statement changing the database is not executed in the dry run
+var errDryRun = errors.New("dry run")
+
+// dryRunHook print all the statements and stop at the first one that changes the database
+type dryRunHook struct {
+	out io.Writer
+}
+
+// BeforeProcess print the statement, and abort it if it changes the database
+func (h *dryRunHook) BeforeProcess(c *contexts.ContextHook) (context.Context, error) {
+	if len(c.Args) > 0 {
+		fmt.Fprintf(h.out, "%s %v\n", c.SQL, c.Args)
+	} else {
+		fmt.Fprintln(h.out, c.SQL)
+	}
+	if !isReadOnlySQL(c.SQL) {
+		return c.Ctx, errDryRun
+	}
+	return c.Ctx, nil
+}
+
+// AfterProcess do nothing
+func (h *dryRunHook) AfterProcess(_ *contexts.ContextHook) error {
+	return nil
+}
+
+func isReadOnlySQL(sql string) bool {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return true
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "SHOW", "PRAGMA", "DESCRIBE", "DESC", "EXPLAIN", "BEGIN", "COMMIT", "ROLLBACK":
+		return true
+	}
+	return false
+}
+
+// DryRun print the statements of the pending migrations without changing the database. As the statements
+// of a migration depend on its earlier changes, each migration is only shown until its first change,
+// so the output is not the full SQL of the upgrade.
+func DryRun(dbConf *data.Database, out io.Writer) (err error) {
+	engine, err := data.NewDB(false, dbConf)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+	currentDBVersion, err := GetCurrentDBVersion(engine)
+	if err != nil {
+		return err
+	}
+	engine.AddHook(&dryRunHook{out: out})
+	for i := currentDBVersion; i < ExpectedVersion(); i++ {
+		m := migrations[i]
+		fmt.Fprintf(out, "-- [%d] %s %s\n", i+1, m.Version(), m.Description())
+		err = m.Migrate(context.Background(), engine)
+		if err != nil && !strings.Contains(err.Error(), errDryRun.Error()) {
+			return fmt.Errorf("dry run migration %s failed: %w", m.Version(), err)
+		}
+		if err != nil {
+			fmt.Fprintln(out, "-- the following statements depend on the change above and are not shown")
+		}
+	}
+	return nil
+}
+
+// Rollback revert the migrations after the version, so the database is at the version.
+// Only the migrations created by NewMigrationWithRollback, which are v1.4.46 and later, can be rolled back.
+// Nothing is reverted if any of the migrations can not be rolled back.
+func Rollback(debug bool, dbConf *data.Database, cacheConf *data.CacheConf, toVersion string) (err error) {
+	engine, err := data.NewDB(debug, dbConf)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+	if err = engine.Sync(new(entity.MigrationHistory)); err != nil {
+		return fmt.Errorf("sync migration history failed: %v", err)
+	}
+	currentDBVersion, err := GetCurrentDBVersion(engine)
+	if err != nil {
+		return err
+	}
+	targetVersion := int64(-1)
+	for i, m := range migrations {
+		if m.Version() == toVersion {
+			targetVersion = int64(i + 1)
+			break
+		}
+	}
+	if targetVersion < 0 {
+		return fmt.Errorf("version %s not found", toVersion)
+	}
+	if targetVersion >= currentDBVersion {
+		return fmt.Errorf("the database version %d is not after %s", currentDBVersion, toVersion)
+	}
+	for i := currentDBVersion; i > targetVersion; i-- {
+		if !migrations[i-1].CanRollback() {
+			return fmt.Errorf("rollback is not supported by migration %s, only the migrations since %s "+
+				"can be rolled back", migrations[i-1].Version(), firstRollbackVersion())
+		}
+	}
+
+	shouldCleanCache := false
+	for ; currentDBVersion > targetVersion; currentDBVersion-- {
+		m := migrations[currentDBVersion-1]
+		fmt.Printf("[rollback] try to roll back Answer version %s, description: %s\n", m.Version(), m.Description())
+		if err = m.Rollback(context.Background(), engine); err != nil {
+			return fmt.Errorf("roll back %s failed: %w", m.Version(), err)
+		}
+		if _, err = engine.Update(&entity.Version{ID: 1, VersionNumber: currentDBVersion - 1}); err != nil {
+			return fmt.Errorf("update version failed: %w", err)
+		}
+		if _, err = engine.Delete(&entity.MigrationHistory{VersionNumber: currentDBVersion}); err != nil {
+			return fmt.Errorf("remove migration history failed: %w", err)
+		}
+		shouldCleanCache = shouldCleanCache || m.ShouldCleanCache()
+		fmt.Printf("[rollback] roll back to db version %d success\n", currentDBVersion-1)
+	}
+	if shouldCleanCache {
+		cache, cacheCleanup, err := data.NewCache(cacheConf)
+		if err != nil {
+			return err
+		}
+		defer cacheCleanup()
+		if err = cache.Flush(context.Background()); err != nil {
+			fmt.Printf("[rollback] flush cache failed: %s\n", err.Error())
+		}
+	}
+	return nil
+}
+
+// firstRollbackVersion the version of the first migration since which all the migrations can be rolled back
+func firstRollbackVersion() string {
+	version := ""
+	for i := len(migrations) - 1; i >= 0 && migrations[i].CanRollback(); i-- {
+		version = migrations[i].Version()
+	}
+	return version
+}
@@ -21,7 +21,11 @@ package migrations
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"reflect"
+	"runtime"
 
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/entity"
@@ -36,12 +40,19 @@ type Migration interface {
 	Description() string
 	Migrate(ctx context.Context, x *xorm.Engine) error
 	ShouldCleanCache() bool
+	// CanRollback whether the migration can be reverted by Rollback
+	CanRollback() bool
+	Rollback(ctx context.Context, x *xorm.Engine) error
+	// Fingerprint identifies the migration by its version, description and function name. It tells
+	// whether the migrations are reordered or renamed, but not whether the body of a migration is changed.
+	Fingerprint() string
 }
 
 type migration struct {
 	version          string
 	description      string
 	migrate          func(ctx context.Context, x *xorm.Engine) error
+	rollback         func(ctx context.Context, x *xorm.Engine) error
 	shouldCleanCache bool
 }
 
@@ -65,11 +76,38 @@ func (m *migration) ShouldCleanCache() bool {
 	return m.shouldCleanCache
 }
 
+// CanRollback whether the migration has the rollback function
+func (m *migration) CanRollback() bool {
+	return m.rollback != nil
+}
+
+// Rollback reverts the migration
+func (m *migration) Rollback(ctx context.Context, x *xorm.Engine) error {
+	if m.rollback == nil {
+		return fmt.Errorf("rollback is not supported by migration %s", m.version)
+	}
+	return m.rollback(ctx, x)
+}
+
+// Fingerprint returns the hash of the version, the description and the name of the migration function
+func (m *migration) Fingerprint() string {
+	name := runtime.FuncForPC(reflect.ValueOf(m.migrate).Pointer()).Name()
+	sum := sha256.Sum256([]byte(m.version + ":" + m.description + ":" + name))
+	return hex.EncodeToString(sum[:])
+}
+
 // NewMigration creates a new migration
 func NewMigration(version, desc string, fn func(ctx context.Context, x *xorm.Engine) error, shouldCleanCache bool) Migration {
 	return &migration{version: version, description: desc, migrate: fn, shouldCleanCache: shouldCleanCache}
 }
 
+// NewMigrationWithRollback creates a new migration that can be rolled back
+func NewMigrationWithRollback(version, desc string, fn, rollback func(ctx context.Context, x *xorm.Engine) error,
+	shouldCleanCache bool) Migration {
+	return &migration{version: version, description: desc, migrate: fn, rollback: rollback,
+		shouldCleanCache: shouldCleanCache}
+}
+
 // Use noopMigration when there is a migration that has been no-oped
 var noopMigration = func(_ context.Context, _ *xorm.Engine) error { return nil }
 
@@ -97,6 +135,85 @@ var migrations = []Migration{
 	NewMigration("v1.2.5", "add notification plugin and theme config", addNotificationPluginAndThemeConfig, true),
 	NewMigration("v1.3.0", "add review", addReview, false),
 	NewMigration("v1.3.6", "add hot score to question table", addQuestionHotScore, true),
+	NewMigration("v1.4.0", "add site analytics", addSiteAnalytics, false),
+	NewMigration("v1.4.1", "add search log", addSearchLog, false),
+	NewMigration("v1.4.2", "add seo meta config", addSeoMetaConfig, false),
+	NewMigration("v1.4.3", "add share referral", addShareReferral, false),
+	NewMigration("v1.4.4", "add plugin job", addPluginJob, false),
+	NewMigration("v1.4.5", "add plugin storage", addPluginStorage, false),
+	NewMigration("v1.4.6", "add page", addPage, false),
+	NewMigration("v1.4.7", "add announcement", addAnnouncement, false),
+	NewMigration("v1.4.8", "add user profile field", addProfileField, false),
+	NewMigration("v1.4.9", "add user follow", addUserFollow, false),
+	NewMigration("v1.4.10", "add user session", addUserSession, false),
+	NewMigration("v1.4.11", "add user password updated time", addUserPassUpdatedAt, false),
+	NewMigration("v1.4.12", "add user email verification reminder", addUserVerificationReminder, false),
+	NewMigration("v1.4.13", "add invitation", addInvitation, false),
+	NewMigration("v1.4.14", "add blocked signup", addBlockedSignup, false),
+	NewMigration("v1.4.15", "add question assignment", addQuestionAssignment, false),
+	NewMigration("v1.4.16", "add question sla", addQuestionSLA, false),
+	NewMigration("v1.4.17", "add article", addArticle, false),
+	NewMigration("v1.4.18", "add question poll", addQuestionPoll, false),
+	NewMigration("v1.4.19", "add co author", addCoAuthor, false),
+	NewMigration("v1.4.20", "add user merge log", addUserMergeLog, false),
+	NewMigration("v1.4.21", "add vote view voters permission", addVoteViewVotersPermission, true),
+	NewMigration("v1.4.22", "add leaderboard", addLeaderboard, false),
+	NewMigration("v1.4.23", "add user tag score", addUserTagScore, false),
+	NewMigration("v1.4.24", "add pending deletion", addPendingDeletion, false),
+	NewMigration("v1.4.25", "add pending deletion reason", addPendingDeletionReason, false),
+	NewMigration("v1.4.26", "add scheduled job", addScheduledJob, false),
+	NewMigration("v1.4.27", "add user impersonation log", addUserImpersonationLog, false),
+	NewMigration("v1.4.28", "add custom role permissions", addCustomRolePermissions, true),
+	NewMigration("v1.4.29", "add question share link", addQuestionShareLink, false),
+	NewMigration("v1.4.30", "add link preview", addLinkPreview, false),
+	NewMigration("v1.4.31", "add dead link", addDeadLink, false),
+	NewMigration("v1.4.32", "add ai assistant", addAIAssistant, true),
+	NewMigration("v1.4.33", "add question summary", addQuestionSummary, false),
+	NewMigration("v1.4.34", "add content embedding", addContentEmbedding, false),
+	NewMigration("v1.4.35", "add answer quality signals", addAnswerQualitySignals, true),
+	NewMigration("v1.4.36", "add question review reminder", addQuestionReviewReminder, false),
+	NewMigration("v1.4.37", "add accepted answer history", addAcceptedAnswerHistory, true),
+	NewMigration("v1.4.38", "add content conversion activity", addContentConversionActivity, true),
+	NewMigration("v1.4.39", "add question merge activity", addQuestionMergeActivity, true),
+	NewMigration("v1.4.40", "add revision compaction", addRevisionCompaction, false),
+	NewMigration("v1.4.41", "add login failure", addLoginFailure, false),
+	NewMigration("v1.4.42", "add email delivery", addEmailDelivery, false),
+	NewMigration("v1.4.43", "add email template", addEmailTemplate, false),
+	NewMigration("v1.4.44", "add notification group key", addNotificationGroupKey, false),
+	NewMigration("v1.4.45", "add read notification retention", addReadNotificationRetention, false),
+	NewMigrationWithRollback("v1.4.46", "add user onboarding", addUserOnboarding, removeUserOnboarding, false),
+	NewMigrationWithRollback("v1.4.47", "add question quality score", addQuestionQualityScore,
+		removeQuestionQualityScore, true),
+	NewMigrationWithRollback("v1.4.48", "add experiment event", addExperimentEvent, removeExperimentEvent, false),
+	NewMigrationWithRollback("v1.4.49", "add event outbox", addEventOutbox, removeEventOutbox, false),
+	NewMigrationWithRollback("v1.4.50", "add inbound question", addInboundQuestion,
+		removeInboundQuestion, false),
+	NewMigrationWithRollback("v1.4.51", "add github cross reference", addGitHubCrossReference,
+		removeGitHubCrossReference, false),
+	NewMigrationWithRollback("v1.4.52", "add jira issue link", addJiraIssueLink, removeJiraIssueLink, false),
+	NewMigrationWithRollback("v1.4.53", "add question list index", addQuestionListIndex,
+		removeQuestionListIndex, false),
+	NewMigrationWithRollback("v1.4.54", "add slow query and composite indexes", addSlowQueryAndIndexes,
+		removeSlowQueryAndIndexes, false),
+	NewMigrationWithRollback("v1.4.55", "add banned word hit", addBannedWordHit, removeBannedWordHit, false),
+	NewMigrationWithRollback("v1.4.56", "add username history", addUsernameHistory, removeUsernameHistory, false),
+	NewMigrationWithRollback("v1.4.57", "add user name change", addUserNameChange, removeUserNameChange, false),
+	NewMigrationWithRollback("v1.4.58", "add user locale preferences", addUserLocalePreferences,
+		removeUserLocalePreferences, false),
+	NewMigrationWithRollback("v1.4.59", "add sync change", addSyncChange, removeSyncChange, false),
+	NewMigrationWithRollback("v1.4.60", "add moderation rule hit", addModerationRuleHit,
+		removeModerationRuleHit, false),
+	NewMigrationWithRollback("v1.4.61", "add question workflow", addQuestionWorkflow,
+		removeQuestionWorkflow, false),
+	NewMigrationWithRollback("v1.4.62", "add answer verification", addAnswerVerification,
+		removeAnswerVerification, false),
+	NewMigrationWithRollback("v1.4.63", "add tag subscription digest", addTagSubscriptionDigest,
+		removeTagSubscriptionDigest, false),
+	NewMigrationWithRollback("v1.4.64", "add legal hold", addLegalHold, removeLegalHold, false),
+	NewMigrationWithRollback("v1.4.65", "add post ip record", addPostIPRecord, removePostIPRecord, false),
+	NewMigrationWithRollback("v1.4.66", "add deletion activity", addDeletionActivity, removeDeletionActivity, false),
+	NewMigrationWithRollback("v1.4.67", "add answer convert power", addAnswerConvertPower,
+		removeAnswerConvertPower, false),
 }
 
 func GetMigrations() []Migration {
@@ -146,6 +263,9 @@ func Migrate(debug bool, dbConf *data.Database, cacheConf *data.CacheConf, upgra
 	if err != nil {
 		return err
 	}
+	if err = engine.Sync(new(entity.MigrationHistory)); err != nil {
+		return fmt.Errorf("sync migration history failed: %v", err)
+	}
 	expectedVersion := ExpectedVersion()
 	if len(upgradeToSpecificVersion) > 0 {
 		fmt.Printf("[migrate] user set upgrade to version: %s\n", upgradeToSpecificVersion)
@@ -176,6 +296,10 @@ func Migrate(debug bool, dbConf *data.Database, cacheConf *data.CacheConf, upgra
 			fmt.Printf("[migrate] migrate to db version %d, update failed: %s", currentDBVersion+1, err.Error())
 			return err
 		}
+		if err := recordMigration(engine, currentDBVersion+1, migrationFunc); err != nil {
+			fmt.Printf("[migrate] record migration %s failed: %s\n", migrationFunc.Version(), err.Error())
+			return err
+		}
 		currentDBVersion++
 	}
 	if cache != nil {
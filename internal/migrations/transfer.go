@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+// DefaultTransferBatchSize the default number of rows copied in one batch
+const DefaultTransferBatchSize = 200
+
+// transferFirst the tables other tables refer to, they are copied before the others
+var transferFirst = []interface{}{
+	&entity.Role{},
+	&entity.Power{},
+	&entity.User{},
+	&entity.Tag{},
+	&entity.Question{},
+	&entity.Answer{},
+	&entity.Article{},
+	&entity.Comment{},
+	&entity.QuestionPoll{},
+	&entity.QuestionPollOption{},
+	&entity.Invitation{},
+}
+
+// TransferResult the number of rows of the table in the source and the target database
+type TransferResult struct {
+	Table       string
+	SourceCount int64
+	TargetCount int64
+}
+
+// transferTables get all the tables in the order they are copied
+func transferTables(engine *xorm.Engine) []interface{} {
+	ordered := make([]interface{}, 0, len(tables))
+	added := make(map[string]bool, len(tables))
+	for _, beans := range [][]interface{}{transferFirst, tables} {
+		for _, bean := range beans {
+			name := engine.TableName(bean)
+			if added[name] {
+				continue
+			}
+			added[name] = true
+			ordered = append(ordered, bean)
+		}
+	}
+	return ordered
+}
+
+// TransferData copy the data of all the tables from the source database to the empty target database,
+// for example from SQLite to MySQL or Postgres. The tables are created in the target database from the
+// entities, so the column types are mapped to the ones of the target database engine.
+func TransferData(source, target *data.Database, batchSize int, out io.Writer) (results []*TransferResult, err error) {
+	if source.Driver == target.Driver && source.Connection == target.Connection {
+		return nil, fmt.Errorf("the source and the target database are the same")
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultTransferBatchSize
+	}
+	sourceEngine, err := data.NewDB(false, source)
+	if err != nil {
+		return nil, fmt.Errorf("connect to the source database failed: %w", err)
+	}
+	defer sourceEngine.Close()
+	targetEngine, err := data.NewDB(false, target)
+	if err != nil {
+		return nil, fmt.Errorf("connect to the target database failed: %w", err)
+	}
+	defer targetEngine.Close()
+
+	currentDBVersion, err := GetCurrentDBVersion(sourceEngine)
+	if err != nil {
+		return nil, err
+	}
+	if currentDBVersion != ExpectedVersion() {
+		return nil, fmt.Errorf("the source database version is %d but the application needs %d, "+
+			"please run `answer upgrade` first", currentDBVersion, ExpectedVersion())
+	}
+
+	beans := transferTables(sourceEngine)
+	if err = checkTargetEmpty(targetEngine, beans); err != nil {
+		return nil, err
+	}
+	if err = targetEngine.Sync(beans...); err != nil {
+		return nil, fmt.Errorf("create tables in the target database failed: %w", err)
+	}
+	warnUnknownTables(sourceEngine, beans, out)
+
+	for _, bean := range beans {
+		tableName := sourceEngine.TableName(bean)
+		fmt.Fprintf(out, "[transfer] copy table %s\n", tableName)
+		if err = transferTable(sourceEngine, targetEngine, bean, batchSize); err != nil {
+			return nil, fmt.Errorf("copy table %s failed: %w", tableName, err)
+		}
+	}
+	return verifyTransfer(sourceEngine, targetEngine, beans)
+}
+
+// checkTargetEmpty make sure no data in the target database would be mixed with the copied one
+func checkTargetEmpty(engine *xorm.Engine, beans []interface{}) error {
+	for _, bean := range beans {
+		exist, err := engine.IsTableExist(bean)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			continue
+		}
+		count, err := engine.Unscoped().Count(bean)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("table %s in the target database is not empty", engine.TableName(bean))
+		}
+	}
+	return nil
+}
+
+// warnUnknownTables print the tables in the source database which are not copied, such as the plugin tables
+func warnUnknownTables(engine *xorm.Engine, beans []interface{}, out io.Writer) {
+	metas, err := engine.DBMetas()
+	if err != nil {
+		fmt.Fprintf(out, "[transfer] get the tables of the source database failed: %s\n", err.Error())
+		return
+	}
+	known := make(map[string]bool, len(beans))
+	for _, bean := range beans {
+		known[engine.TableName(bean)] = true
+	}
+	for _, meta := range metas {
+		if !known[meta.Name] {
+			fmt.Fprintf(out, "[transfer] table %s is not managed by answer and is not copied\n", meta.Name)
+		}
+	}
+}
+
+// transferTable copy the rows of the table in batches, ordered by the primary key
+func transferTable(source, target *xorm.Engine, bean interface{}, batchSize int) error {
+	tableInfo, err := source.TableInfo(bean)
+	if err != nil {
+		return err
+	}
+	orderBy := make([]string, 0, len(tableInfo.PrimaryKeys))
+	for _, pk := range tableInfo.PrimaryKeys {
+		orderBy = append(orderBy, source.Quote(pk))
+	}
+	sliceType := reflect.SliceOf(reflect.TypeOf(bean).Elem())
+
+	for offset := 0; ; offset += batchSize {
+		rows := reflect.New(sliceType)
+		session := source.Unscoped().Limit(batchSize, offset)
+		if len(orderBy) > 0 {
+			session = session.OrderBy(strings.Join(orderBy, ","))
+		}
+		err = session.Find(rows.Interface())
+		if err != nil {
+			return err
+		}
+		if rows.Elem().Len() == 0 {
+			break
+		}
+		_, err = target.Transaction(func(session *xorm.Session) (interface{}, error) {
+			return session.NoAutoTime().Insert(rows.Elem().Interface())
+		})
+		if err != nil {
+			return err
+		}
+		if rows.Elem().Len() < batchSize {
+			break
+		}
+	}
+	return resetSequence(target, tableInfo)
+}
+
+// resetSequence move the sequence of the auto increment column after the copied ids in Postgres,
+// otherwise the new rows would get the ids already used.
+func resetSequence(engine *xorm.Engine, tableInfo *schemas.Table) error {
+	if engine.Dialect().URI().DBType != schemas.POSTGRES || len(tableInfo.AutoIncrement) == 0 {
+		return nil
+	}
+	_, err := engine.Exec(fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%s', '%s'), MAX(%s)) FROM %s HAVING MAX(%s) IS NOT NULL",
+		tableInfo.Name, tableInfo.AutoIncrement, engine.Quote(tableInfo.AutoIncrement),
+		engine.Quote(tableInfo.Name), engine.Quote(tableInfo.AutoIncrement)))
+	return err
+}
+
+// verifyTransfer compare the number of rows of each table in the source and the target database
+func verifyTransfer(source, target *xorm.Engine, beans []interface{}) (results []*TransferResult, err error) {
+	for _, bean := range beans {
+		result := &TransferResult{Table: source.TableName(bean)}
+		if result.SourceCount, err = source.Unscoped().Count(bean); err != nil {
+			return nil, err
+		}
+		if result.TargetCount, err = target.Unscoped().Count(bean); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTransferSource create the initialized SQLite database with some questions and a plugin table
+func newTestTransferSource(t *testing.T, questionCount int) *data.Database {
+	dbConf := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "source.db")}
+	engine, err := data.NewDB(false, dbConf)
+	require.NoError(t, err)
+	defer engine.Close()
+	err = NewMentor(context.TODO(), engine, &InitNeedUserInputData{
+		Language:      "en_US",
+		SiteName:      "ANSWER",
+		SiteURL:       "http://127.0.0.1:8080/",
+		ContactEmail:  "answer@answer.com",
+		AdminName:     "admin",
+		AdminPassword: "admin123",
+		AdminEmail:    "answer@answer.com",
+	}).InitDB()
+	require.NoError(t, err)
+
+	for i := 1; i <= questionCount; i++ {
+		_, err = engine.Insert(&entity.Question{ID: fmt.Sprintf("%d", 10010000000000900+i), UserID: "1",
+			Title: fmt.Sprintf("question %d", i), OriginalText: "question", ParsedText: "<p>question</p>",
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow, RevisionID: "0",
+			LastEditUserID: "0", LastAnswerID: "0", AcceptedAnswerID: "0"})
+		require.NoError(t, err)
+	}
+	_, err = engine.Exec("CREATE TABLE plugin_demo (id INTEGER PRIMARY KEY)")
+	require.NoError(t, err)
+	return dbConf
+}
+
+func TestTransferData(t *testing.T) {
+	source := newTestTransferSource(t, 5)
+	target := &data.Database{Driver: "sqlite3", Connection: filepath.Join(t.TempDir(), "target.db")}
+	out := &bytes.Buffer{}
+
+	// the small batch makes the questions copied in several batches
+	results, err := TransferData(source, target, 2, out)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		assert.Equal(t, result.SourceCount, result.TargetCount, "table %s", result.Table)
+		counts[result.Table] = result.TargetCount
+	}
+	// the sample questions are created by the init as well
+	assert.Greater(t, counts["question"], int64(5))
+	assert.Equal(t, int64(1), counts["user"])
+	assert.Contains(t, out.String(), "table plugin_demo is not managed by answer and is not copied")
+
+	engine, err := data.NewDB(false, target)
+	require.NoError(t, err)
+	defer engine.Close()
+	question := &entity.Question{}
+	exist, err := engine.ID("10010000000000903").Get(question)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, "question 3", question.Title)
+	currentDBVersion, err := GetCurrentDBVersion(engine)
+	require.NoError(t, err)
+	assert.Equal(t, ExpectedVersion(), currentDBVersion)
+
+	// the data is never mixed with the existing one
+	_, err = TransferData(source, target, 2, out)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "in the target database is not empty")
+	}
+}
+
+func TestTransferDataSameDatabase(t *testing.T) {
+	source := newTestTransferSource(t, 0)
+	_, err := TransferData(source, &data.Database{Driver: source.Driver, Connection: source.Connection},
+		0, &bytes.Buffer{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "the source and the target database are the same")
+	}
+}
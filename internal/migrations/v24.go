@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"xorm.io/xorm"
+)
+
+func addSeoMetaConfig(ctx context.Context, x *xorm.Engine) error {
+	seoSiteInfo := &entity.SiteInfo{
+		Type: constant.SiteTypeSeo,
+	}
+	exist, err := x.Context(ctx).Get(seoSiteInfo)
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if !exist {
+		return nil
+	}
+	content := &schema.SiteSeoReq{}
+	_ = json.Unmarshal([]byte(seoSiteInfo.Content), content)
+	content.StructuredData = true
+	content.SocialMeta = true
+	data, _ := json.Marshal(content)
+	seoSiteInfo.Content = string(data)
+	_, err = x.Context(ctx).ID(seoSiteInfo.ID).Cols("content").Update(seoSiteInfo)
+	if err != nil {
+		return fmt.Errorf("update site info failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"xorm.io/xorm"
+)
+
+func addQuestionAssignment(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.QuestionAssignment)); err != nil {
+		return fmt.Errorf("sync question assignment table failed: %w", err)
+	}
+
+	power := &entity.Power{ID: 42, Name: "question assign", PowerType: permission.QuestionAssign, Description: "assign the question to users"}
+	exist, err := x.Context(ctx).Get(&entity.Power{ID: power.ID})
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = x.Context(ctx).ID(power.ID).Update(power)
+	} else {
+		_, err = x.Context(ctx).Insert(power)
+	}
+	if err != nil {
+		return err
+	}
+
+	rolePowerRels := []*entity.RolePowerRel{
+		{RoleID: 2, PowerType: permission.QuestionAssign},
+		{RoleID: 3, PowerType: permission.QuestionAssign},
+	}
+	for _, rel := range rolePowerRels {
+		exist, err := x.Context(ctx).Get(&entity.RolePowerRel{RoleID: rel.RoleID, PowerType: rel.PowerType})
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		if _, err = x.Context(ctx).Insert(rel); err != nil {
+			return err
+		}
+	}
+
+	rankConfig := &entity.Config{ID: 131, Key: "rank.question.assign", Value: `-1`}
+	exist, err = x.Context(ctx).Get(&entity.Config{ID: rankConfig.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		_, err = x.Context(ctx).Update(rankConfig, &entity.Config{ID: rankConfig.ID})
+	} else {
+		_, err = x.Context(ctx).Insert(rankConfig)
+	}
+	if err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
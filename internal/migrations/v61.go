@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addQuestionMergeActivity(ctx context.Context, x *xorm.Engine) error {
+	defaultConfigTable := []*entity.Config{
+		{ID: 142, Key: "question.merged_into", Value: `0`},
+		{ID: 143, Key: "question.merged_from", Value: `0`},
+	}
+	for _, c := range defaultConfigTable {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			_, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID})
+		} else {
+			_, err = x.Context(ctx).Insert(c)
+		}
+		if err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"xorm.io/xorm"
+)
+
+func addNotificationGroupKey(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.Notification)); err != nil {
+		return fmt.Errorf("sync notification table failed: %w", err)
+	}
+
+	// fill the group key of the existing notifications in batches, the table may be large
+	const batchSize = 1000
+	lastID := "0"
+	for {
+		notifications := make([]*entity.Notification, 0, batchSize)
+		err := x.Context(ctx).Where("id > ?", lastID).And("group_key = ?", "").
+			Cols("id", "type", "content").Asc("id").Limit(batchSize).Find(&notifications)
+		if err != nil {
+			return fmt.Errorf("get notifications failed: %w", err)
+		}
+		for _, notification := range notifications {
+			content := &schema.NotificationContent{}
+			if err := json.Unmarshal([]byte(notification.Content), content); err != nil {
+				content.ObjectInfo.ObjectID = notification.ID
+			}
+			content.Type = notification.Type
+			_, err = x.Context(ctx).ID(notification.ID).Cols("group_key").
+				Update(&entity.Notification{GroupKey: content.GroupKey()})
+			if err != nil {
+				return fmt.Errorf("update notification group key failed: %w", err)
+			}
+			lastID = notification.ID
+		}
+		if len(notifications) < batchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addUserOnboarding(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.UserOnboarding)); err != nil {
+		return fmt.Errorf("sync user onboarding table failed: %w", err)
+	}
+
+	onboardedConfig := &entity.Config{ID: 144, Key: "user.onboarded", Value: `5`}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: onboardedConfig.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		_, err = x.Context(ctx).Update(onboardedConfig, &entity.Config{ID: onboardedConfig.ID})
+	} else {
+		_, err = x.Context(ctx).Insert(onboardedConfig)
+	}
+	if err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
+
+func removeUserOnboarding(ctx context.Context, x *xorm.Engine) error {
+	if _, err := x.Context(ctx).Delete(&entity.Config{ID: 144}); err != nil {
+		return fmt.Errorf("remove config failed: %w", err)
+	}
+	if err := x.Context(ctx).DropTable(new(entity.UserOnboarding)); err != nil {
+		return fmt.Errorf("drop user onboarding table failed: %w", err)
+	}
+	return nil
+}
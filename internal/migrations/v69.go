@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/question_quality"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+func addQuestionQualityScore(ctx context.Context, x *xorm.Engine) error {
+	type Question struct {
+		QualityScore int `xorm:"not null default 0 INT(11) quality_score"`
+	}
+	if err := x.Context(ctx).Sync(new(Question)); err != nil {
+		return fmt.Errorf("sync question table failed: %w", err)
+	}
+
+	// compute the quality score of the existing questions by the default weights
+	const batchSize = 1000
+	lastID := "0"
+	for {
+		questions := make([]*entity.Question, 0)
+		err := x.Context(ctx).Cols("id", "title", "original_text").
+			Where(builder.Gt{"id": lastID}).Asc("id").Limit(batchSize).Find(&questions)
+		if err != nil {
+			return fmt.Errorf("get questions failed: %w", err)
+		}
+		for _, question := range questions {
+			lastID = question.ID
+			tagCount, err := x.Context(ctx).Where("object_id = ?", question.ID).
+				And("status = ?", entity.TagRelStatusAvailable).Count(&entity.TagRel{})
+			if err != nil {
+				return fmt.Errorf("count question tags failed: %w", err)
+			}
+			question.QualityScore = question_quality.ComputeQualityScore(schema.DefaultQuestionQualityWeights,
+				question.Title, question.OriginalText, int(tagCount))
+			_, err = x.Context(ctx).ID(question.ID).Cols("quality_score").Update(question)
+			if err != nil {
+				return fmt.Errorf("update question quality score failed: %w", err)
+			}
+		}
+		if len(questions) < batchSize {
+			return nil
+		}
+	}
+}
+
+func removeQuestionQualityScore(ctx context.Context, x *xorm.Engine) error {
+	_, err := x.Context(ctx).Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s",
+		x.Quote(entity.Question{}.TableName()), x.Quote("quality_score")))
+	if err != nil {
+		return fmt.Errorf("drop question quality score failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addQuestionListIndex(ctx context.Context, x *xorm.Engine) error {
+	err := x.Context(ctx).Sync(new(entity.QuestionListPartition), new(entity.QuestionListIndex), new(entity.TagSummary))
+	if err != nil {
+		return fmt.Errorf("sync question list index tables failed: %w", err)
+	}
+	return nil
+}
+
+func removeQuestionListIndex(ctx context.Context, x *xorm.Engine) error {
+	for _, table := range []interface{}{
+		new(entity.QuestionListPartition), new(entity.QuestionListIndex), new(entity.TagSummary)} {
+		if err := x.Context(ctx).DropTable(table); err != nil {
+			return fmt.Errorf("drop question list index tables failed: %w", err)
+		}
+	}
+	return nil
+}
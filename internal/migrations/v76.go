@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+func addSlowQueryAndIndexes(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).Sync(new(entity.SlowQuery)); err != nil {
+		return fmt.Errorf("sync slow query table failed: %w", err)
+	}
+
+	// the existing indexes are declared too, otherwise they are dropped by the sync
+	type Tag struct {
+		ID       string `xorm:"not null pk comment('tag_id') BIGINT(20) id"`
+		Status   int    `xorm:"not null default 1 INT(11) INDEX(status_slug_name) status"`
+		SlugName string `xorm:"not null default '' unique INDEX(status_slug_name) VARCHAR(35) slug_name"`
+	}
+	type Activity struct {
+		ID            string    `xorm:"not null pk autoincr BIGINT(20) id"`
+		ObjectID      string    `xorm:"not null default 0 index INDEX(object_created) BIGINT(20) object_id"`
+		CreatedAt     time.Time `xorm:"created INDEX(object_created) TIMESTAMP created_at"`
+		UserID        string    `xorm:"not null index BIGINT(20) user_id"`
+		TriggerUserID int64     `xorm:"not null default 0 index BIGINT(20) trigger_user_id"`
+	}
+	if err := x.Context(ctx).Sync(new(Tag), new(Activity)); err != nil {
+		return fmt.Errorf("add composite indexes failed: %w", err)
+	}
+	return nil
+}
+
+func removeSlowQueryAndIndexes(ctx context.Context, x *xorm.Engine) error {
+	if err := x.Context(ctx).DropTable(new(entity.SlowQuery)); err != nil {
+		return fmt.Errorf("drop slow query table failed: %w", err)
+	}
+	for table, index := range map[string]string{"tag": "status_slug_name", "activity": "object_created"} {
+		if _, err := x.Context(ctx).Exec(x.Dialect().DropIndexSQL(table, schemas.NewIndex(index, schemas.IndexType))); err != nil {
+			return fmt.Errorf("drop index %s of %s failed: %w", index, table, err)
+		}
+	}
+	return nil
+}
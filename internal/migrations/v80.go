@@ -0,0 +1,50 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+func addUserLocalePreferences(ctx context.Context, x *xorm.Engine) error {
+	type User struct {
+		TimeZone   string `xorm:"not null default '' VARCHAR(64) time_zone"`
+		DateFormat string `xorm:"not null default '' VARCHAR(20) date_format"`
+	}
+	if err := x.Context(ctx).Sync(new(User)); err != nil {
+		return fmt.Errorf("sync user table failed: %w", err)
+	}
+	return nil
+}
+
+func removeUserLocalePreferences(ctx context.Context, x *xorm.Engine) error {
+	for _, column := range []string{"time_zone", "date_format"} {
+		_, err := x.Context(ctx).Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s",
+			x.Quote(entity.User{}.TableName()), x.Quote(column)))
+		if err != nil {
+			return fmt.Errorf("drop user %s failed: %w", column, err)
+		}
+	}
+	return nil
+}
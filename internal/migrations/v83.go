@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+	"xorm.io/xorm/schemas"
+)
+
+func addQuestionWorkflow(ctx context.Context, x *xorm.Engine) error {
+	type Question struct {
+		WorkflowState string `xorm:"not null default '' VARCHAR(50) INDEX workflow_state"`
+	}
+	if err := x.Context(ctx).Sync(new(Question)); err != nil {
+		return fmt.Errorf("sync question table failed: %w", err)
+	}
+	if err := x.Context(ctx).Sync(new(entity.QuestionWorkflowTransition)); err != nil {
+		return fmt.Errorf("sync question workflow transition table failed: %w", err)
+	}
+
+	workflowConfig := &entity.Config{ID: 145, Key: "question.workflow_changed", Value: `0`}
+	exist, err := x.Context(ctx).Get(&entity.Config{ID: workflowConfig.ID})
+	if err != nil {
+		return fmt.Errorf("get config failed: %w", err)
+	}
+	if exist {
+		_, err = x.Context(ctx).Update(workflowConfig, &entity.Config{ID: workflowConfig.ID})
+	} else {
+		_, err = x.Context(ctx).Insert(workflowConfig)
+	}
+	if err != nil {
+		return fmt.Errorf("add config failed: %w", err)
+	}
+	return nil
+}
+
+func removeQuestionWorkflow(ctx context.Context, x *xorm.Engine) error {
+	if _, err := x.Context(ctx).Delete(&entity.Config{ID: 145}); err != nil {
+		return fmt.Errorf("remove config failed: %w", err)
+	}
+	if err := x.Context(ctx).DropTable(new(entity.QuestionWorkflowTransition)); err != nil {
+		return fmt.Errorf("drop question workflow transition table failed: %w", err)
+	}
+	// the index of the column is dropped before the column for sqlite
+	index := schemas.NewIndex("workflow_state", schemas.IndexType)
+	if _, err := x.Context(ctx).Exec(x.Dialect().DropIndexSQL(entity.Question{}.TableName(), index)); err != nil {
+		return fmt.Errorf("drop question workflow_state index failed: %w", err)
+	}
+	_, err := x.Context(ctx).Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s",
+		x.Quote(entity.Question{}.TableName()), x.Quote("workflow_state")))
+	if err != nil {
+		return fmt.Errorf("drop question workflow_state failed: %w", err)
+	}
+	return nil
+}
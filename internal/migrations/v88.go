@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"xorm.io/xorm"
+)
+
+// deletionActivityConfigs the activity types which record the finalized deletions and the undone comment deletions
+var deletionActivityConfigs = []*entity.Config{
+	{ID: 146, Key: "question.deletion_finalized", Value: `0`},
+	{ID: 147, Key: "answer.deletion_finalized", Value: `0`},
+	{ID: 148, Key: "question.comment_deletion_finalized", Value: `0`},
+	{ID: 149, Key: "answer.comment_deletion_finalized", Value: `0`},
+	{ID: 150, Key: "question.comment_undeleted", Value: `0`},
+	{ID: 151, Key: "answer.comment_undeleted", Value: `0`},
+}
+
+func addDeletionActivity(ctx context.Context, x *xorm.Engine) error {
+	for _, c := range deletionActivityConfigs {
+		exist, err := x.Context(ctx).Get(&entity.Config{ID: c.ID})
+		if err != nil {
+			return fmt.Errorf("get config failed: %w", err)
+		}
+		if exist {
+			_, err = x.Context(ctx).Update(c, &entity.Config{ID: c.ID})
+		} else {
+			_, err = x.Context(ctx).Insert(c)
+		}
+		if err != nil {
+			return fmt.Errorf("add config failed: %w", err)
+		}
+	}
+	return nil
+}
+
+func removeDeletionActivity(ctx context.Context, x *xorm.Engine) error {
+	for _, c := range deletionActivityConfigs {
+		if _, err := x.Context(ctx).Delete(&entity.Config{ID: c.ID}); err != nil {
+			return fmt.Errorf("remove config failed: %w", err)
+		}
+	}
+	return nil
+}
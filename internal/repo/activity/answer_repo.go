@@ -141,6 +141,47 @@ func (ar *AnswerActivityRepo) SaveCancelAcceptAnswerActivity(ctx context.Context
 	return nil
 }
 
+// TransferAcceptAnswerActivity move the question author's rewarded accept activity from the answer to the other
+// accepted answer, the unrewarded accept activity of the other answer is replaced. Nothing is moved if the activity
+// has no rank or the other answer has been rewarded already, then the rank is rolled back when it is cancelled.
+func (ar *AnswerActivityRepo) TransferAcceptAnswerActivity(ctx context.Context, activityType int,
+	questionUserID, fromAnswerID, toAnswerID string) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		from := &entity.Activity{}
+		exist, err := session.Where(builder.Eq{"object_id": fromAnswerID}).
+			And(builder.Eq{"user_id": questionUserID}).
+			And(builder.Eq{"activity_type": activityType}).
+			And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			Get(from)
+		if err != nil || !exist || from.Rank == 0 {
+			return nil, err
+		}
+		rewarded, err := session.Where(builder.Eq{"object_id": toAnswerID}).
+			And(builder.Eq{"user_id": questionUserID}).
+			And(builder.Eq{"activity_type": activityType}).
+			And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			And(builder.Eq{"has_rank": 1}).
+			Exist(&entity.Activity{})
+		if err != nil || rewarded {
+			return nil, err
+		}
+		_, err = session.Where(builder.Eq{"object_id": toAnswerID}).
+			And(builder.Eq{"user_id": questionUserID}).
+			And(builder.Eq{"activity_type": activityType}).
+			Delete(&entity.Activity{})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(from.ID).Cols("object_id").Update(&entity.Activity{ObjectID: toAnswerID})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 func (ar *AnswerActivityRepo) acquireUserInfo(session *xorm.Session, userIDs []string) (map[string]*entity.User, error) {
 	us := make([]*entity.User, 0)
 	err := session.In("id", userIDs).ForUpdate().Find(&us)
@@ -34,6 +34,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
 
 	"xorm.io/builder"
 
@@ -207,6 +208,26 @@ func (vr *VoteRepo) ListUserVotes(ctx context.Context, userID string,
 	return
 }
 
+// ListObjectVotes list the votes of the object which are not cancelled
+func (vr *VoteRepo) ListObjectVotes(ctx context.Context, objectID string,
+	page int, pageSize int, activityTypes []int) (voteList []*entity.Activity, total int64, err error) {
+	session := vr.data.DB.Context(ctx)
+	cond := builder.
+		And(
+			builder.Eq{"object_id": uid.DeShortID(objectID)},
+			builder.Eq{"cancelled": 0},
+			builder.In("activity_type", activityTypes),
+		)
+
+	session.Where(cond).Desc("updated_at")
+
+	total, err = pager.Help(page, pageSize, &voteList, &entity.Activity{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 func (vr *VoteRepo) votePreCheck(ctx context.Context, op *schema.VoteOperationInfo) (noNeedToVote bool, err error) {
 	activities, err := vr.getExistActivity(ctx, op)
 	if err != nil {
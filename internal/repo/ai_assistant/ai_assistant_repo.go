@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ai_assistant
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// aiAssistantRepo ai assistant repository
+type aiAssistantRepo struct {
+	data *data.Data
+}
+
+// NewAIAssistantRepo new repository
+func NewAIAssistantRepo(data *data.Data) ai_assistant.AIAssistantRepo {
+	return &aiAssistantRepo{
+		data: data,
+	}
+}
+
+// AddUsage add the usage record of the generation
+func (ar *aiAssistantRepo) AddUsage(ctx context.Context, usage *entity.AIAssistantUsage) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(usage)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserUsageSince count the generations of the user since the time
+func (ar *aiAssistantRepo) CountUserUsageSince(ctx context.Context, userID string, since time.Time) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("user_id = ?", userID).
+		And("created_at >= ?", since).
+		Count(&entity.AIAssistantUsage{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
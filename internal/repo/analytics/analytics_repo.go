@@ -0,0 +1,207 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/analytics"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// analyticsRepo site analytics repository
+type analyticsRepo struct {
+	data *data.Data
+}
+
+// NewAnalyticsRepo new repository
+func NewAnalyticsRepo(data *data.Data) analytics.AnalyticsRepo {
+	return &analyticsRepo{
+		data: data,
+	}
+}
+
+// CountNewUsers count the users registered in the time range
+func (ar *analyticsRepo) CountNewUsers(ctx context.Context, startTime, endTime time.Time) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		And("status <> ?", entity.UserStatusDeleted).
+		Count(&entity.User{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountQuestions count the questions asked in the time range and how many of them have an accepted answer
+func (ar *analyticsRepo) CountQuestions(ctx context.Context, startTime, endTime time.Time) (
+	count, acceptedCount int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		Count(&entity.Question{})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	acceptedCount, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("accepted_answer_id <> ?", 0).
+		Count(&entity.Question{})
+	if err != nil {
+		return 0, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, acceptedCount, nil
+}
+
+// CountAnswers count the answers created in the time range
+func (ar *analyticsRepo) CountAnswers(ctx context.Context, startTime, endTime time.Time) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountComments count the comments created in the time range
+func (ar *analyticsRepo) CountComments(ctx context.Context, startTime, endTime time.Time) (count int64, err error) {
+	count, err = ar.data.DB.Context(ctx).
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		And("status = ?", entity.CommentStatusAvailable).
+		Count(&entity.Comment{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountActiveUsers count the users who have any activity or logged in during the time range
+func (ar *analyticsRepo) CountActiveUsers(ctx context.Context, startTime, endTime time.Time) (count int64, err error) {
+	triggerUserIDs := make([]string, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).
+		Distinct("trigger_user_id").
+		Where("created_at >= ? AND created_at < ?", startTime, endTime).
+		And("trigger_user_id <> ?", 0).
+		Find(&triggerUserIDs)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	loginUserIDs := make([]string, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.User{}.TableName()).
+		Cols("id").
+		Where("last_login_date >= ? AND last_login_date < ?", startTime, endTime).
+		Find(&loginUserIDs)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	userIDs := make(map[string]bool, len(triggerUserIDs)+len(loginUserIDs))
+	for _, userID := range triggerUserIDs {
+		userIDs[userID] = true
+	}
+	for _, userID := range loginUserIDs {
+		userIDs[userID] = true
+	}
+	return int64(len(userIDs)), nil
+}
+
+// GetTagQuestionCounts get the question count of each tag in the time range
+func (ar *analyticsRepo) GetTagQuestionCounts(ctx context.Context, startTime, endTime time.Time) (
+	tagCounts []*entity.SiteAnalyticsTagCount, err error) {
+	tagCounts = make([]*entity.SiteAnalyticsTagCount, 0)
+	err = ar.data.DB.Context(ctx).Table("tag_rel").
+		Select("tag_rel.tag_id AS tag_id, COUNT(*) AS question_count").
+		Join("INNER", "question", "question.id = tag_rel.object_id").
+		Where("tag_rel.status = ?", entity.TagRelStatusAvailable).
+		In("question.status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("question.created_at >= ? AND question.created_at < ?", startTime, endTime).
+		GroupBy("tag_rel.tag_id").
+		Find(&tagCounts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveDaily replace the rollup data of the day
+func (ar *analyticsRepo) SaveDaily(ctx context.Context, daily *entity.SiteAnalyticsDaily,
+	tagDailyList []*entity.SiteAnalyticsTagDaily) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("date = ?", daily.Date).Delete(&entity.SiteAnalyticsDaily{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Where("date = ?", daily.Date).Delete(&entity.SiteAnalyticsTagDaily{}); err != nil {
+			return nil, err
+		}
+		if _, err = session.Insert(daily); err != nil {
+			return nil, err
+		}
+		if len(tagDailyList) > 0 {
+			if _, err = session.Insert(tagDailyList); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDailyByDateRange get the rollup data between the dates, both inclusive, order by date
+func (ar *analyticsRepo) GetDailyByDateRange(ctx context.Context, startDate, endDate string) (
+	dailyList []*entity.SiteAnalyticsDaily, err error) {
+	dailyList = make([]*entity.SiteAnalyticsDaily, 0)
+	err = ar.data.DB.Context(ctx).
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		Asc("date").
+		Find(&dailyList)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTopTagsByDateRange get the tags with the most questions between the dates, both inclusive
+func (ar *analyticsRepo) GetTopTagsByDateRange(ctx context.Context, startDate, endDate string, limit int) (
+	tagCounts []*entity.SiteAnalyticsTagCount, err error) {
+	tagCounts = make([]*entity.SiteAnalyticsTagCount, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.SiteAnalyticsTagDaily{}.TableName()).
+		Select("tag_id, SUM(question_count) AS question_count").
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		GroupBy("tag_id").
+		OrderBy("question_count DESC").
+		Limit(limit).
+		Find(&tagCounts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package announcement
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/announcement"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// announcementRepo announcement repository
+type announcementRepo struct {
+	data *data.Data
+}
+
+// NewAnnouncementRepo new repository
+func NewAnnouncementRepo(data *data.Data) announcement.AnnouncementRepo {
+	return &announcementRepo{
+		data: data,
+	}
+}
+
+// AddAnnouncement add announcement
+func (ar *announcementRepo) AddAnnouncement(ctx context.Context, announcement *entity.Announcement) (err error) {
+	_, err = ar.data.DB.Context(ctx).Insert(announcement)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateAnnouncement update announcement
+func (ar *announcementRepo) UpdateAnnouncement(ctx context.Context, announcement *entity.Announcement) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(announcement.ID).
+		Cols("original_text", "parsed_text", "style", "audience", "role_ids", "start_time", "end_time", "status").
+		Update(announcement)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveAnnouncement remove announcement and its dismissal records
+func (ar *announcementRepo) RemoveAnnouncement(ctx context.Context, id int) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("announcement_id = ?", id).Delete(&entity.AnnouncementDismissal{}); err != nil {
+			return nil, err
+		}
+		return session.ID(id).Delete(&entity.Announcement{})
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnnouncement get announcement by id
+func (ar *announcementRepo) GetAnnouncement(ctx context.Context, id int) (
+	announcement *entity.Announcement, exist bool, err error) {
+	announcement = &entity.Announcement{}
+	exist, err = ar.data.DB.Context(ctx).ID(id).Get(announcement)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnnouncementList get all announcements, the newest first
+func (ar *announcementRepo) GetAnnouncementList(ctx context.Context) (announcements []*entity.Announcement, err error) {
+	announcements = make([]*entity.Announcement, 0)
+	err = ar.data.DB.Context(ctx).Desc("id").Find(&announcements)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEnabledAnnouncementList get the enabled announcements, the newest first
+func (ar *announcementRepo) GetEnabledAnnouncementList(ctx context.Context) (announcements []*entity.Announcement, err error) {
+	announcements = make([]*entity.Announcement, 0)
+	err = ar.data.DB.Context(ctx).Where("status = ?", entity.AnnouncementStatusEnabled).Desc("id").Find(&announcements)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddDismissal record the announcement dismissed by user, dismissing twice is ignored
+func (ar *announcementRepo) AddDismissal(ctx context.Context, announcementID int, userID string) (err error) {
+	dismissal := &entity.AnnouncementDismissal{AnnouncementID: announcementID, UserID: userID}
+	exist, err := ar.data.DB.Context(ctx).Exist(dismissal)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = ar.data.DB.Context(ctx).Insert(dismissal)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDismissedAnnouncementIDs get the ids of announcements dismissed by user
+func (ar *announcementRepo) GetDismissedAnnouncementIDs(ctx context.Context, userID string) (announcementIDs []int, err error) {
+	announcementIDs = make([]int, 0)
+	err = ar.data.DB.Context(ctx).Table(new(entity.AnnouncementDismissal).TableName()).
+		Where("user_id = ?", userID).Cols("announcement_id").Find(&announcementIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
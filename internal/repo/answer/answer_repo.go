@@ -38,6 +38,7 @@ import (
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/builder"
 )
 
 // answerRepo answer repository
@@ -198,7 +199,7 @@ func (ar *answerRepo) GetAnswerList(ctx context.Context, answer *entity.Answer)
 	answerList = make([]*entity.Answer, 0)
 	answer.ID = uid.DeShortID(answer.ID)
 	answer.QuestionID = uid.DeShortID(answer.QuestionID)
-	err = ar.data.DB.Context(ctx).Find(answerList, answer)
+	err = ar.data.DB.Context(ctx).Find(&answerList, answer)
 	if err != nil {
 		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -255,6 +256,31 @@ func (ar *answerRepo) UpdateAcceptedStatus(ctx context.Context, acceptedAnswerID
 	return nil
 }
 
+// UpdateAnswerAccepted update the accepted status of the answer only, the other answers are not changed
+func (ar *answerRepo) UpdateAnswerAccepted(ctx context.Context, answerID string, accepted int) error {
+	answerID = uid.DeShortID(answerID)
+	_, err := ar.data.DB.Context(ctx).Where("id = ?", answerID).Cols("adopted").Update(&entity.Answer{
+		Accepted: accepted,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_ = ar.updateSearch(ctx, answerID)
+	return nil
+}
+
+// GetAcceptedAnswers get the accepted answers of the question
+func (ar *answerRepo) GetAcceptedAnswers(ctx context.Context, questionID string) (answerList []*entity.Answer, err error) {
+	answerList = make([]*entity.Answer, 0)
+	err = ar.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).
+		And("adopted = ?", schema.AnswerAcceptedEnable).
+		Asc("id").Find(&answerList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return answerList, nil
+}
+
 // GetByID
 func (ar *answerRepo) GetByID(ctx context.Context, answerID string) (*entity.Answer, bool, error) {
 	var resp entity.Answer
@@ -270,6 +296,26 @@ func (ar *answerRepo) GetByID(ctx context.Context, answerID string) (*entity.Ans
 	return &resp, has, nil
 }
 
+// GetAnswersByIDs get the answers by the ids
+func (ar *answerRepo) GetAnswersByIDs(ctx context.Context, answerIDs []string) (answerList []*entity.Answer, err error) {
+	ids := make([]string, 0, len(answerIDs))
+	for _, id := range answerIDs {
+		ids = append(ids, uid.DeShortID(id))
+	}
+	answerList = make([]*entity.Answer, 0)
+	err = ar.data.DB.Context(ctx).In("id", ids).Find(&answerList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, answer := range answerList {
+			answer.ID = uid.EnShortID(answer.ID)
+			answer.QuestionID = uid.EnShortID(answer.QuestionID)
+		}
+	}
+	return answerList, nil
+}
+
 func (ar *answerRepo) GetCountByQuestionID(ctx context.Context, questionID string) (int64, error) {
 	questionID = uid.DeShortID(questionID)
 	var resp = new(entity.Answer)
@@ -333,6 +379,18 @@ func (ar *answerRepo) SearchList(ctx context.Context, search *entity.AnswerSearc
 	if len(search.UserID) > 0 {
 		session = session.And("user_id = ?", search.UserID)
 	}
+	if len(search.EnvironmentName) > 0 {
+		var environmentCond builder.Cond = builder.Eq{"name": search.EnvironmentName}
+		if len(search.EnvironmentVersion) > 0 {
+			// the answer without the version works with any version
+			environmentCond = environmentCond.And(builder.In("version", search.EnvironmentVersion, ""))
+		}
+		session = session.In("id", builder.Select("answer_id").
+			From(new(entity.AnswerEnvironment).TableName()).Where(environmentCond))
+	}
+	if search.OutdatedThreshold > 0 {
+		session = session.And("outdated_count < ?", search.OutdatedThreshold)
+	}
 	switch search.Order {
 	case entity.AnswerSearchOrderByTime:
 		session = session.OrderBy("created_at desc")
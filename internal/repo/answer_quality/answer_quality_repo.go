@@ -0,0 +1,216 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_quality
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/answer_quality"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// answerQualityRepo answer quality repository
+type answerQualityRepo struct {
+	data         *data.Data
+	activityRepo activity_common.ActivityRepo
+}
+
+// NewAnswerQualityRepo new repository
+func NewAnswerQualityRepo(
+	data *data.Data,
+	activityRepo activity_common.ActivityRepo,
+) answer_quality.AnswerQualityRepo {
+	return &answerQualityRepo{
+		data:         data,
+		activityRepo: activityRepo,
+	}
+}
+
+// SaveEnvironments replace the environments of the answer
+func (ar *answerQualityRepo) SaveEnvironments(ctx context.Context, answerID string,
+	environments []*entity.AnswerEnvironment) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("answer_id = ?", answerID).Delete(&entity.AnswerEnvironment{}); err != nil {
+			return nil, err
+		}
+		if len(environments) == 0 {
+			return nil, nil
+		}
+		for _, environment := range environments {
+			environment.AnswerID = answerID
+		}
+		_, err = session.Insert(environments)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetEnvironmentsByAnswerIDs get the environments of the answers
+func (ar *answerQualityRepo) GetEnvironmentsByAnswerIDs(ctx context.Context, answerIDs []string) (
+	environments []*entity.AnswerEnvironment, err error) {
+	environments = make([]*entity.AnswerEnvironment, 0)
+	if len(answerIDs) == 0 {
+		return environments, nil
+	}
+	err = ar.data.DB.Context(ctx).In("answer_id", answerIDs).Asc("id").Find(&environments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// FlagOutdated flag the answer as outdated by the user
+func (ar *answerQualityRepo) FlagOutdated(ctx context.Context, answerID, userID string) (err error) {
+	activityType, err := ar.activityRepo.GetActivityTypeByObjectType(ctx, constant.AnswerObjectType, constant.ActOutdated)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		existsActivity := &entity.Activity{}
+		has, err := session.Where(builder.Eq{"activity_type": activityType}).
+			And(builder.Eq{"user_id": userID}).
+			And(builder.Eq{"object_id": answerID}).
+			Get(existsActivity)
+		if err != nil {
+			return nil, err
+		}
+		if has && existsActivity.Cancelled == entity.ActivityAvailable {
+			return nil, nil
+		}
+
+		if has {
+			_, err = session.ID(existsActivity.ID).Cols("cancelled").
+				Update(&entity.Activity{Cancelled: entity.ActivityAvailable})
+		} else {
+			_, err = session.Insert(&entity.Activity{
+				UserID:           userID,
+				ObjectID:         answerID,
+				OriginalObjectID: answerID,
+				ActivityType:     activityType,
+				Cancelled:        entity.ActivityAvailable,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(answerID).Incr("outdated_count", 1).Update(&entity.Answer{})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// CancelOutdatedFlag cancel the outdated flag of the user
+func (ar *answerQualityRepo) CancelOutdatedFlag(ctx context.Context, answerID, userID string) (err error) {
+	activityType, err := ar.activityRepo.GetActivityTypeByObjectType(ctx, constant.AnswerObjectType, constant.ActOutdated)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		existsActivity := &entity.Activity{}
+		has, err := session.Where(builder.Eq{"activity_type": activityType}).
+			And(builder.Eq{"user_id": userID}).
+			And(builder.Eq{"object_id": answerID}).
+			Get(existsActivity)
+		if err != nil || !has || existsActivity.Cancelled == entity.ActivityCancelled {
+			return nil, err
+		}
+
+		_, err = session.ID(existsActivity.ID).Cols("cancelled", "cancelled_at").
+			Update(&entity.Activity{Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(answerID).Where("outdated_count > 0").
+			Decr("outdated_count", 1).Update(&entity.Answer{})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetOutdatedFlaggedAnswerIDs get the ids of the answers which the user flagged as outdated
+func (ar *answerQualityRepo) GetOutdatedFlaggedAnswerIDs(ctx context.Context, userID string, answerIDs []string) (
+	flaggedIDs []string, err error) {
+	flaggedIDs = make([]string, 0)
+	if len(userID) == 0 || len(answerIDs) == 0 {
+		return flaggedIDs, nil
+	}
+	activityType, err := ar.activityRepo.GetActivityTypeByObjectType(ctx, constant.AnswerObjectType, constant.ActOutdated)
+	if err != nil {
+		return nil, err
+	}
+	err = ar.data.DB.Context(ctx).Table(new(entity.Activity).TableName()).
+		Where(builder.Eq{"activity_type": activityType}).
+		And(builder.Eq{"user_id": userID}).
+		And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+		In("object_id", answerIDs).
+		Cols("object_id").Find(&flaggedIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ResetOutdatedFlags cancel all the outdated flags of the answer
+func (ar *answerQualityRepo) ResetOutdatedFlags(ctx context.Context, answerID string) (err error) {
+	activityType, err := ar.activityRepo.GetActivityTypeByObjectType(ctx, constant.AnswerObjectType, constant.ActOutdated)
+	if err != nil {
+		return err
+	}
+
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where(builder.Eq{"activity_type": activityType}).
+			And(builder.Eq{"object_id": answerID}).
+			And(builder.Eq{"cancelled": entity.ActivityAvailable}).
+			Cols("cancelled", "cancelled_at").
+			Update(&entity.Activity{Cancelled: entity.ActivityCancelled, CancelledAt: time.Now()})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(answerID).Cols("outdated_count").Update(&entity.Answer{OutdatedCount: 0})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
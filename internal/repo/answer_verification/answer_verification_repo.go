@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_verification
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// answerVerificationRepo answer verification repository
+type answerVerificationRepo struct {
+	data *data.Data
+}
+
+// NewAnswerVerificationRepo new repository
+func NewAnswerVerificationRepo(data *data.Data) answer_verification.AnswerVerificationRepo {
+	return &answerVerificationRepo{
+		data: data,
+	}
+}
+
+// AddTagExpert add the tag expert, it is ignored if the user is already the expert of the tag
+func (ar *answerVerificationRepo) AddTagExpert(ctx context.Context, expert *entity.TagExpert) (err error) {
+	exist, err := ar.data.DB.Context(ctx).Exist(&entity.TagExpert{TagID: expert.TagID, UserID: expert.UserID})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	if _, err = ar.data.DB.Context(ctx).Insert(expert); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveTagExpert remove the tag expert
+func (ar *answerVerificationRepo) RemoveTagExpert(ctx context.Context, tagID, userID string) (err error) {
+	_, err = ar.data.DB.Context(ctx).Where("tag_id = ?", tagID).And("user_id = ?", userID).
+		Delete(&entity.TagExpert{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagExperts get the experts of the tag
+func (ar *answerVerificationRepo) GetTagExperts(ctx context.Context, tagID string) (
+	experts []*entity.TagExpert, err error) {
+	experts = make([]*entity.TagExpert, 0)
+	err = ar.data.DB.Context(ctx).Where("tag_id = ?", tagID).Asc("id").Find(&experts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsTagExpert whether the user is the expert of any of the tags
+func (ar *answerVerificationRepo) IsTagExpert(ctx context.Context, userID string, tagIDs []string) (
+	isExpert bool, err error) {
+	isExpert, err = ar.data.DB.Context(ctx).Where("user_id = ?", userID).In("tag_id", tagIDs).
+		Exist(&entity.TagExpert{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetExpertUserIDsByTagIDs get the distinct ids of the experts of any of the tags
+func (ar *answerVerificationRepo) GetExpertUserIDsByTagIDs(ctx context.Context, tagIDs []string) (
+	userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	err = ar.data.DB.Context(ctx).Table(entity.TagExpert{}.TableName()).Distinct("user_id").
+		In("tag_id", tagIDs).Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveVerification replace the verification of the answer
+func (ar *answerVerificationRepo) SaveVerification(ctx context.Context,
+	verification *entity.AnswerVerification) (err error) {
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("answer_id = ?", verification.AnswerID).Delete(&entity.AnswerVerification{})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(verification)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveVerification remove the verification of the answer
+func (ar *answerVerificationRepo) RemoveVerification(ctx context.Context, answerID string) (err error) {
+	_, err = ar.data.DB.Context(ctx).Where("answer_id = ?", answerID).Delete(&entity.AnswerVerification{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetVerificationsByAnswerIDs get the verifications of the answers
+func (ar *answerVerificationRepo) GetVerificationsByAnswerIDs(ctx context.Context, answerIDs []string) (
+	verifications []*entity.AnswerVerification, err error) {
+	verifications = make([]*entity.AnswerVerification, 0)
+	err = ar.data.DB.Context(ctx).Where(builder.In("answer_id", answerIDs)).Find(&verifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
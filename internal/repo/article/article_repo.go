@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package article
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/article"
+	"github.com/apache/incubator-answer/internal/service/unique"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// articleRepo article repository
+type articleRepo struct {
+	data         *data.Data
+	uniqueIDRepo unique.UniqueIDRepo
+}
+
+// NewArticleRepo new repository
+func NewArticleRepo(data *data.Data, uniqueIDRepo unique.UniqueIDRepo) article.ArticleRepo {
+	return &articleRepo{
+		data:         data,
+		uniqueIDRepo: uniqueIDRepo,
+	}
+}
+
+// AddArticle add article
+func (ar *articleRepo) AddArticle(ctx context.Context, article *entity.Article) (err error) {
+	article.ID, err = ar.uniqueIDRepo.GenUniqueIDStr(ctx, article.TableName())
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = ar.data.DB.Context(ctx).Insert(article)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateArticle update article
+func (ar *articleRepo) UpdateArticle(ctx context.Context, article *entity.Article, cols ...string) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(uid.DeShortID(article.ID)).Cols(cols...).Update(article)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateArticleViewCount increase the view count of the article
+func (ar *articleRepo) UpdateArticleViewCount(ctx context.Context, id string) (err error) {
+	_, err = ar.data.DB.Context(ctx).ID(uid.DeShortID(id)).Incr("view_count", 1).Update(&entity.Article{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetArticle get article by id, including the deleted one
+func (ar *articleRepo) GetArticle(ctx context.Context, id string) (article *entity.Article, exist bool, err error) {
+	article = &entity.Article{}
+	exist, err = ar.data.DB.Context(ctx).ID(uid.DeShortID(id)).Get(article)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetArticleBySourceAnswerID get the available article converted from the answer
+func (ar *articleRepo) GetArticleBySourceAnswerID(ctx context.Context, answerID string) (
+	article *entity.Article, exist bool, err error) {
+	article = &entity.Article{}
+	exist, err = ar.data.DB.Context(ctx).Where("source_answer_id = ?", uid.DeShortID(answerID)).
+		And("status = ?", entity.ArticleStatusAvailable).Get(article)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetArticlePage get the available article page
+func (ar *articleRepo) GetArticlePage(ctx context.Context, page, pageSize int, orderCond string) (
+	articles []*entity.Article, total int64, err error) {
+	articles = make([]*entity.Article, 0)
+	session := ar.data.DB.Context(ctx).Where("status = ?", entity.ArticleStatusAvailable)
+	switch orderCond {
+	case "views":
+		session.Desc("view_count")
+	}
+	session.Desc("created_at")
+	total, err = pager.Help(page, pageSize, &articles, &entity.Article{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetArticleQuestionIDs get the ids of the questions linked to the article
+func (ar *articleRepo) GetArticleQuestionIDs(ctx context.Context, articleID string) (questionIDs []string, err error) {
+	rels := make([]*entity.ArticleQuestionRel, 0)
+	err = ar.data.DB.Context(ctx).Where("article_id = ?", uid.DeShortID(articleID)).Asc("id").Find(&rels)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	questionIDs = make([]string, 0, len(rels))
+	for _, rel := range rels {
+		questionIDs = append(questionIDs, rel.QuestionID)
+	}
+	return questionIDs, nil
+}
+
+// SetArticleQuestionIDs replace the questions linked to the article
+func (ar *articleRepo) SetArticleQuestionIDs(ctx context.Context, articleID string, questionIDs []string) (err error) {
+	articleID = uid.DeShortID(articleID)
+	_, err = ar.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("article_id = ?", articleID).Delete(&entity.ArticleQuestionRel{}); err != nil {
+			return nil, err
+		}
+		for _, questionID := range questionIDs {
+			rel := &entity.ArticleQuestionRel{ArticleID: articleID, QuestionID: uid.DeShortID(questionID)}
+			if _, err := session.Insert(rel); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionArticles get the available articles linked to the question
+func (ar *articleRepo) GetQuestionArticles(ctx context.Context, questionID string) (articles []*entity.Article, err error) {
+	articles = make([]*entity.Article, 0)
+	articleIDs := builder.Select("article_id").From(entity.ArticleQuestionRel{}.TableName()).
+		Where(builder.Eq{"question_id": uid.DeShortID(questionID)})
+	err = ar.data.DB.Context(ctx).Where(builder.In("id", articleIDs)).
+		And("status = ?", entity.ArticleStatusAvailable).Desc("view_count").Find(&articles)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -236,3 +236,41 @@ func (ar *authRepo) RemoveUserTokens(ctx context.Context, userID string, remainT
 		log.Error(err)
 	}
 }
+
+// GetUserSessionActiveMark check whether the session has been marked as active recently
+func (ar *authRepo) GetUserSessionActiveMark(ctx context.Context, tokenHash string) (exist bool, err error) {
+	_, exist, err = ar.data.Cache.GetString(ctx, constant.UserSessionActiveCacheKey+tokenHash)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return exist, nil
+}
+
+// SetUserSessionActiveMark mark the session as active, the mark will expire after a while
+func (ar *authRepo) SetUserSessionActiveMark(ctx context.Context, tokenHash string) (err error) {
+	err = ar.data.Cache.SetString(ctx, constant.UserSessionActiveCacheKey+tokenHash, "1",
+		constant.UserSessionActiveCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// SetUserSessionRevoked mark the session as revoked until the access token expired
+func (ar *authRepo) SetUserSessionRevoked(ctx context.Context, tokenHash string) (err error) {
+	err = ar.data.Cache.SetString(ctx, constant.UserSessionRevokedCacheKey+tokenHash, "1",
+		constant.UserTokenCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IsUserSessionRevoked check whether the session is revoked
+func (ar *authRepo) IsUserSessionRevoked(ctx context.Context, tokenHash string) (revoked bool, err error) {
+	_, revoked, err = ar.data.Cache.GetString(ctx, constant.UserSessionRevokedCacheKey+tokenHash)
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return revoked, nil
+}
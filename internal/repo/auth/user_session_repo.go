@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package auth
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userSessionRepo user session repository
+type userSessionRepo struct {
+	data *data.Data
+}
+
+// NewUserSessionRepo new repository
+func NewUserSessionRepo(data *data.Data) auth.UserSessionRepo {
+	return &userSessionRepo{
+		data: data,
+	}
+}
+
+// SaveUserSession add the session or update the device info and the last active time of the exist one
+func (ur *userSessionRepo) SaveUserSession(ctx context.Context, session *entity.UserSession) (err error) {
+	old := &entity.UserSession{}
+	exist, err := ur.data.DB.Context(ctx).Where("token_hash = ?", session.TokenHash).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		_, err = ur.data.DB.Context(ctx).ID(old.ID).
+			Cols("user_agent", "ip", "last_active_at").Update(session)
+	} else {
+		_, err = ur.data.DB.Context(ctx).Insert(session)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserSession get session by id
+func (ur *userSessionRepo) GetUserSession(ctx context.Context, id int) (
+	session *entity.UserSession, exist bool, err error) {
+	session = &entity.UserSession{}
+	exist, err = ur.data.DB.Context(ctx).ID(id).Get(session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserSessions get all sessions of user, the recently active first
+func (ur *userSessionRepo) GetUserSessions(ctx context.Context, userID string) (
+	sessions []*entity.UserSession, err error) {
+	sessions = make([]*entity.UserSession, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("last_active_at").Find(&sessions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserSession remove session by id
+func (ur *userSessionRepo) RemoveUserSession(ctx context.Context, id int) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(id).Delete(&entity.UserSession{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserSessionByTokenHash remove session by the hash of access token
+func (ur *userSessionRepo) RemoveUserSessionByTokenHash(ctx context.Context, tokenHash string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("token_hash = ?", tokenHash).Delete(&entity.UserSession{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveUserSessions remove all sessions of user except the one with the given token hash
+func (ur *userSessionRepo) RemoveUserSessions(ctx context.Context, userID, exceptTokenHash string) (err error) {
+	session := ur.data.DB.Context(ctx).Where("user_id = ?", userID)
+	if len(exceptTokenHash) > 0 {
+		session.And("token_hash <> ?", exceptTokenHash)
+	}
+	_, err = session.Delete(&entity.UserSession{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
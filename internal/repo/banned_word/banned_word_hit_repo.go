@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package banned_word
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// bannedWordHitRepo banned word hit repository
+type bannedWordHitRepo struct {
+	data *data.Data
+}
+
+// NewBannedWordHitRepo new repository
+func NewBannedWordHitRepo(data *data.Data) banned_word.BannedWordHitRepo {
+	return &bannedWordHitRepo{
+		data: data,
+	}
+}
+
+// AddBannedWordHits add banned word hits
+func (br *bannedWordHitRepo) AddBannedWordHits(ctx context.Context, hits []*entity.BannedWordHit) (err error) {
+	_, err = br.data.DB.Context(ctx).Insert(hits)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBannedWordHitPage get banned word hit page, the newest first
+func (br *bannedWordHitRepo) GetBannedWordHitPage(ctx context.Context, page, pageSize int, cond *entity.BannedWordHit) (
+	hits []*entity.BannedWordHit, total int64, err error) {
+	hits = make([]*entity.BannedWordHit, 0)
+	session := br.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &hits, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package co_author
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/co_author"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// coAuthorRepo co-author repository
+type coAuthorRepo struct {
+	data *data.Data
+}
+
+// NewCoAuthorRepo new repository
+func NewCoAuthorRepo(data *data.Data) co_author.CoAuthorRepo {
+	return &coAuthorRepo{
+		data: data,
+	}
+}
+
+// AddCoAuthor add co-author
+func (cr *coAuthorRepo) AddCoAuthor(ctx context.Context, coAuthor *entity.CoAuthor) (err error) {
+	coAuthor.ObjectID = uid.DeShortID(coAuthor.ObjectID)
+	_, err = cr.data.DB.Context(ctx).Insert(coAuthor)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveCoAuthor remove co-author
+func (cr *coAuthorRepo) RemoveCoAuthor(ctx context.Context, objectID, userID string) (err error) {
+	_, err = cr.data.DB.Context(ctx).Where("object_id = ? AND user_id = ?", uid.DeShortID(objectID), userID).
+		Delete(&entity.CoAuthor{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCoAuthor get co-author
+func (cr *coAuthorRepo) GetCoAuthor(ctx context.Context, objectID, userID string) (
+	coAuthor *entity.CoAuthor, exist bool, err error) {
+	coAuthor = &entity.CoAuthor{}
+	exist, err = cr.data.DB.Context(ctx).Where("object_id = ? AND user_id = ?", uid.DeShortID(objectID), userID).
+		Get(coAuthor)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCoAuthors get the co-authors of the object ordered by the time they were added
+func (cr *coAuthorRepo) GetCoAuthors(ctx context.Context, objectID string) (coAuthors []*entity.CoAuthor, err error) {
+	coAuthors = make([]*entity.CoAuthor, 0)
+	err = cr.data.DB.Context(ctx).Where("object_id = ?", uid.DeShortID(objectID)).Asc("id").Find(&coAuthors)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -79,6 +79,16 @@ func (cr *commentRepo) RemoveComment(ctx context.Context, commentID string) (err
 	return
 }
 
+// RecoverComment recover deleted comment
+func (cr *commentRepo) RecoverComment(ctx context.Context, commentID string) (err error) {
+	_, err = cr.data.DB.Context(ctx).ID(commentID).And("status = ?", entity.CommentStatusDeleted).
+		Cols("status").Update(&entity.Comment{Status: entity.CommentStatusAvailable})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // UpdateCommentContent update comment
 func (cr *commentRepo) UpdateCommentContent(
 	ctx context.Context, commentID string, originalText string, parsedText string) (err error) {
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/reload"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/segmentfault/pacman/errors"
@@ -66,7 +67,7 @@ func (cr configRepo) GetConfigByID(ctx context.Context, id int) (c *entity.Confi
 	}
 
 	// update cache
-	if err := cr.data.Cache.SetString(ctx, cacheKey, c.JsonString(), constant.ConfigCacheTime); err != nil {
+	if err := cr.data.Cache.SetString(ctx, cacheKey, c.JsonString(), reload.ConfigCacheTime()); err != nil {
 		log.Error(err)
 	}
 	return c, nil
@@ -93,7 +94,7 @@ func (cr configRepo) GetConfigByKey(ctx context.Context, key string) (c *entity.
 	}
 
 	// update cache
-	if err := cr.data.Cache.SetString(ctx, cacheKey, c.JsonString(), constant.ConfigCacheTime); err != nil {
+	if err := cr.data.Cache.SetString(ctx, cacheKey, c.JsonString(), reload.ConfigCacheTime()); err != nil {
 		log.Error(err)
 	}
 	return c, nil
@@ -120,11 +121,11 @@ func (cr configRepo) UpdateConfig(ctx context.Context, key string, value string)
 	cacheVal := oldConfig.JsonString()
 	// update cache
 	if err := cr.data.Cache.SetString(ctx,
-		constant.ConfigKEY2ContentCacheKeyPrefix+key, cacheVal, constant.ConfigCacheTime); err != nil {
+		constant.ConfigKEY2ContentCacheKeyPrefix+key, cacheVal, reload.ConfigCacheTime()); err != nil {
 		log.Error(err)
 	}
 	if err := cr.data.Cache.SetString(ctx,
-		fmt.Sprintf("%s%d", constant.ConfigID2KEYCacheKeyPrefix, oldConfig.ID), cacheVal, constant.ConfigCacheTime); err != nil {
+		fmt.Sprintf("%s%d", constant.ConfigID2KEYCacheKeyPrefix, oldConfig.ID), cacheVal, reload.ConfigCacheTime()); err != nil {
 		log.Error(err)
 	}
 	return
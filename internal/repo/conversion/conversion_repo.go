@@ -0,0 +1,190 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package conversion
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/conversion"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/unique"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// conversionRepo conversion repository
+type conversionRepo struct {
+	data         *data.Data
+	uniqueIDRepo unique.UniqueIDRepo
+	activityRepo activity_common.ActivityRepo
+	userRankRepo rank.UserRankRepo
+}
+
+// NewConversionRepo new repository
+func NewConversionRepo(
+	data *data.Data,
+	uniqueIDRepo unique.UniqueIDRepo,
+	activityRepo activity_common.ActivityRepo,
+	userRankRepo rank.UserRankRepo,
+) conversion.ConversionRepo {
+	return &conversionRepo{
+		data:         data,
+		uniqueIDRepo: uniqueIDRepo,
+		activityRepo: activityRepo,
+		userRankRepo: userRankRepo,
+	}
+}
+
+// voteActivityTypes the activity types of the votes of the answer and the likes of the comment
+type voteActivityTypes struct {
+	voteUp, voteDown, votedUp, votedDown, commentVoteUp int
+}
+
+// ConvertCommentToAnswer add the answer converted from the comment and delete the comment in one transaction,
+// the created time of the answer is kept as it is.
+func (cr *conversionRepo) ConvertCommentToAnswer(ctx context.Context, commentID string, answer *entity.Answer) (
+	err error) {
+	answer.ID, err = cr.uniqueIDRepo.GenUniqueIDStr(ctx, answer.TableName())
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = cr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.NoAutoTime().Insert(answer); err != nil {
+			return nil, err
+		}
+		_, err := session.ID(commentID).Cols("status").Update(&entity.Comment{Status: entity.CommentStatusDeleted})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// ConvertAnswerToComment add the comment converted from the answer and delete the answer in one transaction.
+// The comments of the answer are moved to the object of the comment. The up votes of the answer become the likes
+// of the comment, and the reputation which the votes brought to the author and the voters is rolled back.
+func (cr *conversionRepo) ConvertAnswerToComment(ctx context.Context, answerID string, comment *entity.Comment) (
+	err error) {
+	types, err := cr.getActivityTypes(ctx)
+	if err != nil {
+		return err
+	}
+	comment.ID, err = cr.uniqueIDRepo.GenUniqueIDStr(ctx, comment.TableName())
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = cr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		likes, err := cr.moveVotes(ctx, session, answerID, comment.ID, types)
+		if err != nil {
+			return nil, err
+		}
+		comment.VoteCount = likes
+		comment.UpdatedAt = time.Now()
+		if _, err = session.NoAutoTime().Insert(comment); err != nil {
+			return nil, err
+		}
+		_, err = session.Where("object_id = ?", answerID).Cols("object_id").
+			Update(&entity.Comment{ObjectID: comment.ObjectID})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(answerID).Cols("status").Update(&entity.Answer{Status: entity.AnswerStatusDeleted})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (cr *conversionRepo) getActivityTypes(ctx context.Context) (types *voteActivityTypes, err error) {
+	types = &voteActivityTypes{}
+	for key, activityType := range map[string]*int{
+		activity_type.AnswerVoteUp:    &types.voteUp,
+		activity_type.AnswerVoteDown:  &types.voteDown,
+		activity_type.AnswerVotedUp:   &types.votedUp,
+		activity_type.AnswerVotedDown: &types.votedDown,
+		activity_type.CommentVoteUp:   &types.commentVoteUp,
+	} {
+		if *activityType, err = cr.activityRepo.GetActivityTypeByConfigKey(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	return types, nil
+}
+
+// moveVotes cancel the vote activities of the answer and roll back the reputation they brought,
+// the up votes are added again as the likes of the comment which bring no reputation.
+func (cr *conversionRepo) moveVotes(ctx context.Context, session *xorm.Session, answerID, commentID string,
+	types *voteActivityTypes) (likes int, err error) {
+	activities := make([]*entity.Activity, 0)
+	err = session.Where(builder.Eq{"object_id": answerID, "cancelled": entity.ActivityAvailable}).
+		And(builder.In("activity_type", types.voteUp, types.voteDown, types.votedUp, types.votedDown)).
+		Find(&activities)
+	if err != nil || len(activities) == 0 {
+		return 0, err
+	}
+
+	activityIDs := make([]string, 0, len(activities))
+	for _, act := range activities {
+		activityIDs = append(activityIDs, act.ID)
+		if act.Rank != 0 {
+			user := &entity.User{}
+			exist, err := session.ID(act.UserID).Get(user)
+			if err != nil {
+				return 0, err
+			}
+			if exist {
+				if err = cr.userRankRepo.ChangeUserRank(ctx, session, act.UserID, user.Rank, -act.Rank); err != nil {
+					return 0, err
+				}
+			}
+		}
+		if act.ActivityType != types.voteUp {
+			continue
+		}
+		_, err = session.Insert(&entity.Activity{
+			ObjectID:         commentID,
+			OriginalObjectID: commentID,
+			UserID:           act.UserID,
+			ActivityType:     types.commentVoteUp,
+			Cancelled:        entity.ActivityAvailable,
+		})
+		if err != nil {
+			return 0, err
+		}
+		likes++
+	}
+	_, err = session.In("id", activityIDs).Cols("cancelled", "cancelled_at").Update(&entity.Activity{
+		Cancelled:   entity.ActivityCancelled,
+		CancelledAt: time.Now(),
+	})
+	return likes, err
+}
@@ -0,0 +1,236 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data_retention
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/data_retention"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// ipBatchSize the number of rows whose ip address is updated in one batch
+const ipBatchSize = 500
+
+// notificationBatchSize the number of notifications deleted in one batch,
+// the small batches avoid locking the notification table for a long time
+const notificationBatchSize = 1000
+
+// ipColumn the column which records the ip address and the time it is recorded,
+// the user column is used to skip the users under the legal hold
+type ipColumn struct {
+	table      string
+	column     string
+	timeColumn string
+	userColumn string
+}
+
+// ipColumns all the ip addresses recorded by the site
+var ipColumns = []ipColumn{
+	{table: "user", column: "ip_info", timeColumn: "created_at", userColumn: "id"},
+	{table: "user_session", column: "ip", timeColumn: "last_active_at", userColumn: "user_id"},
+	{table: "blocked_signup", column: "ip", timeColumn: "created_at"},
+	{table: "login_failure", column: "ip", timeColumn: "created_at"},
+	{table: "post_ip_record", column: "ip", timeColumn: "created_at", userColumn: "user_id"},
+}
+
+// ipRecord the ip address of the row
+type ipRecord struct {
+	ID int64  `xorm:"id"`
+	IP string `xorm:"ip"`
+}
+
+// dataRetentionRepo data retention repository
+type dataRetentionRepo struct {
+	data *data.Data
+}
+
+// NewDataRetentionRepo new repository
+func NewDataRetentionRepo(data *data.Data) data_retention.DataRetentionRepo {
+	return &dataRetentionRepo{
+		data: data,
+	}
+}
+
+// UpdateIPs convert the ip addresses recorded before the time
+func (dr *dataRetentionRepo) UpdateIPs(ctx context.Context, before time.Time, convert func(ip string) string,
+	heldUserIDs []string) (affected int64, err error) {
+	for _, c := range ipColumns {
+		var lastID int64
+		for {
+			records := make([]*ipRecord, 0)
+			cond := builder.NewCond()
+			if len(c.userColumn) > 0 {
+				cond = notHeld(c.userColumn, heldUserIDs)
+			}
+			err = dr.data.DB.Context(ctx).Table(c.table).
+				Select("id, "+c.column+" AS ip").
+				Where(c.timeColumn+" < ?", before).
+				And(c.column+" <> ''").
+				And("id > ?", lastID).
+				And(cond).
+				Asc("id").
+				Limit(ipBatchSize).
+				Find(&records)
+			if err != nil {
+				return affected, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			for _, record := range records {
+				lastID = record.ID
+				ip := convert(record.IP)
+				if ip == record.IP {
+					continue
+				}
+				_, err = dr.data.DB.Context(ctx).Table(c.table).
+					Where("id = ?", record.ID).
+					Update(map[string]interface{}{c.column: ip})
+				if err != nil {
+					return affected, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+				}
+				affected++
+			}
+			if len(records) < ipBatchSize {
+				break
+			}
+		}
+	}
+	return affected, nil
+}
+
+// DeleteNotifications delete the notifications created before the time
+func (dr *dataRetentionRepo) DeleteNotifications(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	return dr.deleteNotificationsInBatches(ctx, builder.And(
+		builder.Lt{"created_at": before},
+		notHeld("user_id", heldUserIDs),
+	))
+}
+
+// DeleteReadNotifications delete the read notifications which are not updated since the time
+func (dr *dataRetentionRepo) DeleteReadNotifications(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	return dr.deleteNotificationsInBatches(ctx, builder.And(
+		builder.Eq{"is_read": schema.NotificationRead},
+		builder.Lt{"updated_at": before},
+		notHeld("user_id", heldUserIDs),
+	))
+}
+
+// deleteNotificationsInBatches delete the notifications matching the condition batch by batch
+func (dr *dataRetentionRepo) deleteNotificationsInBatches(ctx context.Context, cond builder.Cond) (
+	affected int64, err error) {
+	for {
+		ids := make([]string, 0)
+		err = dr.data.DB.Context(ctx).Table(entity.Notification{}.TableName()).
+			Cols("id").Where(cond).Asc("id").Limit(notificationBatchSize).Find(&ids)
+		if err != nil {
+			return affected, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if len(ids) == 0 {
+			return affected, nil
+		}
+		deleted, err := dr.data.DB.Context(ctx).In("id", ids).Delete(&entity.Notification{})
+		if err != nil {
+			return affected, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		affected += deleted
+		if len(ids) < notificationBatchSize {
+			return affected, nil
+		}
+	}
+}
+
+// DeleteUserSessions delete the login sessions which are inactive since the time
+func (dr *dataRetentionRepo) DeleteUserSessions(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).Where("last_active_at < ?", before).
+		And(notHeld("user_id", heldUserIDs)).
+		Delete(&entity.UserSession{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AnonymizeUserSessions remove the device info of the login sessions which are inactive since the time
+func (dr *dataRetentionRepo) AnonymizeUserSessions(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).
+		Where("last_active_at < ?", before).
+		And("(ip <> '' OR user_agent <> '')").
+		And(notHeld("user_id", heldUserIDs)).
+		Cols("ip", "user_agent").
+		Update(&entity.UserSession{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeletePostIPRecords delete the post ip records created before the time
+func (dr *dataRetentionRepo) DeletePostIPRecords(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).Where("created_at < ?", before).
+		And(notHeld("user_id", heldUserIDs)).
+		Delete(&entity.PostIPRecord{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AnonymizePostIPRecords remove the user agent and the precise location of the post ip records created before
+// the time, the country is kept as the anonymized ip address still tells it
+func (dr *dataRetentionRepo) AnonymizePostIPRecords(ctx context.Context, before time.Time, heldUserIDs []string) (
+	affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).
+		Where("created_at < ?", before).
+		And("(user_agent <> '' OR region <> '' OR city <> '')").
+		And(notHeld("user_id", heldUserIDs)).
+		Cols("user_agent", "region", "city").
+		Update(&entity.PostIPRecord{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// DeleteLoginFailures delete the failed sign-in attempts created before the time
+func (dr *dataRetentionRepo) DeleteLoginFailures(ctx context.Context, before time.Time) (affected int64, err error) {
+	affected, err = dr.data.DB.Context(ctx).Where("created_at < ?", before).Delete(&entity.LoginFailure{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// notHeld the condition excluding the users under the legal hold
+func notHeld(userColumn string, heldUserIDs []string) builder.Cond {
+	if len(heldUserIDs) == 0 {
+		return builder.NewCond()
+	}
+	return builder.NotIn(userColumn, heldUserIDs)
+}
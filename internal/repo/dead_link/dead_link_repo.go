@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package dead_link
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// deadLinkRepo dead link repository
+type deadLinkRepo struct {
+	data *data.Data
+}
+
+// NewDeadLinkRepo new repository
+func NewDeadLinkRepo(data *data.Data) dead_link.DeadLinkRepo {
+	return &deadLinkRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsAfter get the available and closed questions whose id is greater than the id
+func (dr *deadLinkRepo) GetQuestionsAfter(ctx context.Context, id string, limit int) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = dr.data.DB.Context(ctx).Cols("id", "parsed_text").
+		Where("id > ?", id).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		Asc("id").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersAfter get the available answers whose id is greater than the id
+func (dr *deadLinkRepo) GetAnswersAfter(ctx context.Context, id string, limit int) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = dr.data.DB.Context(ctx).Cols("id", "question_id", "parsed_text").
+		Where("id > ?", id).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Asc("id").Limit(limit).Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ReplaceDeadLinks replace the dead links of the object with the latest check result
+func (dr *deadLinkRepo) ReplaceDeadLinks(ctx context.Context, objectID string, deadLinks []*entity.DeadLink) (err error) {
+	_, err = dr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("object_id = ?", objectID).Delete(&entity.DeadLink{}); err != nil {
+			return nil, err
+		}
+		if len(deadLinks) > 0 {
+			_, err = session.Insert(deadLinks)
+		}
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeadLinksByObjectIDs get the dead links of the objects
+func (dr *deadLinkRepo) GetDeadLinksByObjectIDs(ctx context.Context, objectIDs []string) (
+	deadLinks []*entity.DeadLink, err error) {
+	deadLinks = make([]*entity.DeadLink, 0)
+	ids := make([]string, 0, len(objectIDs))
+	for _, objectID := range objectIDs {
+		ids = append(ids, uid.DeShortID(objectID))
+	}
+	err = dr.data.DB.Context(ctx).In("object_id", ids).Find(&deadLinks)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeadLinkPage get the dead links, the latest checked first
+func (dr *deadLinkRepo) GetDeadLinkPage(ctx context.Context, page, pageSize int) (
+	deadLinks []*entity.DeadLink, total int64, err error) {
+	deadLinks = make([]*entity.DeadLink, 0)
+	session := dr.data.DB.Context(ctx).Desc("checked_at", "id")
+	total, err = pager.Help(page, pageSize, &deadLinks, &entity.DeadLink{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
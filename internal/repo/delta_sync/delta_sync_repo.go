@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package delta_sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/delta_sync"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// deltaSyncRepo delta sync repository
+type deltaSyncRepo struct {
+	data *data.Data
+}
+
+// NewDeltaSyncRepo new repository
+func NewDeltaSyncRepo(data *data.Data) delta_sync.DeltaSyncRepo {
+	return &deltaSyncRepo{
+		data: data,
+	}
+}
+
+// AddSyncChanges add the changes of the content
+func (dr *deltaSyncRepo) AddSyncChanges(ctx context.Context, changes []*entity.SyncChange) (err error) {
+	_, err = dr.data.DB.Context(ctx).Insert(changes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSyncChanges get the changes after the id, in the order they are added
+func (dr *deltaSyncRepo) GetSyncChanges(ctx context.Context, afterID int64, limit int) (
+	changes []*entity.SyncChange, err error) {
+	changes = make([]*entity.SyncChange, 0)
+	err = dr.data.DB.Context(ctx).Where("id > ?", afterID).Asc("id").Limit(limit).Find(&changes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOldestSyncChangeID get the id of the oldest change kept
+func (dr *deltaSyncRepo) GetOldestSyncChangeID(ctx context.Context) (id int64, exist bool, err error) {
+	change := &entity.SyncChange{}
+	exist, err = dr.data.DB.Context(ctx).Asc("id").Get(change)
+	if err != nil {
+		return 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return change.ID, exist, nil
+}
+
+// RemoveSyncChanges remove the changes added before the time
+func (dr *deltaSyncRepo) RemoveSyncChanges(ctx context.Context, before time.Time) (err error) {
+	_, err = dr.data.DB.Context(ctx).Where("created_at < ?", before).Delete(&entity.SyncChange{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionsByIDs get the questions by ids
+func (dr *deltaSyncRepo) GetQuestionsByIDs(ctx context.Context, ids []string) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	if len(ids) == 0 {
+		return questions, nil
+	}
+	err = dr.data.DB.Context(ctx).In("id", ids).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersByIDs get the answers by ids
+func (dr *deltaSyncRepo) GetAnswersByIDs(ctx context.Context, ids []string) (answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	if len(ids) == 0 {
+		return answers, nil
+	}
+	err = dr.data.DB.Context(ctx).In("id", ids).Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCommentsByIDs get the comments by ids
+func (dr *deltaSyncRepo) GetCommentsByIDs(ctx context.Context, ids []string) (comments []*entity.Comment, err error) {
+	comments = make([]*entity.Comment, 0)
+	if len(ids) == 0 {
+		return comments, nil
+	}
+	err = dr.data.DB.Context(ctx).In("id", ids).Find(&comments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNotificationsAfter get the notifications of the user after the id, in the order they are added
+func (dr *deltaSyncRepo) GetNotificationsAfter(ctx context.Context, userID string, afterID int64, limit int) (
+	notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	err = dr.data.DB.Context(ctx).
+		Where("user_id = ? AND id > ? AND status = ?", userID, afterID, schema.NotificationStatusNormal).
+		Asc("id").Limit(limit).Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package edit_lock
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/service/edit_lock"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// editLockRepo edit lock repository, the locks are kept in the cache only
+type editLockRepo struct {
+	data *data.Data
+}
+
+// NewEditLockRepo new repository
+func NewEditLockRepo(data *data.Data) edit_lock.EditLockRepo {
+	return &editLockRepo{
+		data: data,
+	}
+}
+
+// GetEditLock get the edit lock of the post
+func (er *editLockRepo) GetEditLock(ctx context.Context, objectID string) (
+	lock *edit_lock.EditLock, exist bool, err error) {
+	cacheData, exist, err := er.data.Cache.GetString(ctx, constant.EditLockCacheKeyPrefix+objectID)
+	if err != nil {
+		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		return nil, false, nil
+	}
+	lock = &edit_lock.EditLock{}
+	if err = json.Unmarshal([]byte(cacheData), lock); err != nil {
+		return nil, false, nil
+	}
+	return lock, true, nil
+}
+
+// SetEditLock set the edit lock of the post, it expires if it is not renewed
+func (er *editLockRepo) SetEditLock(ctx context.Context, lock *edit_lock.EditLock) (err error) {
+	cacheData, _ := json.Marshal(lock)
+	err = er.data.Cache.SetString(ctx, constant.EditLockCacheKeyPrefix+lock.ObjectID, string(cacheData),
+		constant.EditLockCacheTime)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// RemoveEditLock remove the edit lock of the post
+func (er *editLockRepo) RemoveEditLock(ctx context.Context, objectID string) (err error) {
+	if err = er.data.Cache.Del(ctx, constant.EditLockCacheKeyPrefix+objectID); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
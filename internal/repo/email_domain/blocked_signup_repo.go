@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_domain
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// blockedSignupRepo blocked signup repository
+type blockedSignupRepo struct {
+	data *data.Data
+}
+
+// NewBlockedSignupRepo new repository
+func NewBlockedSignupRepo(data *data.Data) email_domain.BlockedSignupRepo {
+	return &blockedSignupRepo{
+		data: data,
+	}
+}
+
+// AddBlockedSignup add blocked signup
+func (br *blockedSignupRepo) AddBlockedSignup(ctx context.Context, blockedSignup *entity.BlockedSignup) (err error) {
+	_, err = br.data.DB.Context(ctx).Insert(blockedSignup)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetBlockedSignupPage get blocked signup page, the newest first
+func (br *blockedSignupRepo) GetBlockedSignupPage(ctx context.Context, page, pageSize int, cond *entity.BlockedSignup) (
+	blockedSignups []*entity.BlockedSignup, total int64, err error) {
+	blockedSignups = make([]*entity.BlockedSignup, 0)
+	session := br.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &blockedSignups, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package embedding
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// embeddingRepo embedding repository
+type embeddingRepo struct {
+	data *data.Data
+}
+
+// NewEmbeddingRepo new repository
+func NewEmbeddingRepo(data *data.Data) embedding.EmbeddingRepo {
+	return &embeddingRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsAfter get the public questions whose id is greater than the id
+func (er *embeddingRepo) GetQuestionsAfter(ctx context.Context, id string, limit int) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	err = er.data.DB.Context(ctx).Cols("id", "title", "parsed_text").
+		Where("id > ?", id).
+		And("`show` = ?", entity.QuestionShow).
+		In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		Asc("id").Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersAfter get the available answers whose id is greater than the id
+func (er *embeddingRepo) GetAnswersAfter(ctx context.Context, id string, limit int) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = er.data.DB.Context(ctx).Cols("id", "question_id", "parsed_text").
+		Where("id > ?", id).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Asc("id").Limit(limit).Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmbeddingsByObjectIDs get the embeddings of the objects
+func (er *embeddingRepo) GetEmbeddingsByObjectIDs(ctx context.Context, objectIDs []string) (
+	embeddings []*entity.ContentEmbedding, err error) {
+	embeddings = make([]*entity.ContentEmbedding, 0)
+	if len(objectIDs) == 0 {
+		return embeddings, nil
+	}
+	err = er.data.DB.Context(ctx).Omit("vector").In("object_id", objectIDs).Find(&embeddings)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmbeddingsAfter get the embeddings of the model whose id is greater than the id
+func (er *embeddingRepo) GetEmbeddingsAfter(ctx context.Context, id int, model string, limit int) (
+	embeddings []*entity.ContentEmbedding, err error) {
+	embeddings = make([]*entity.ContentEmbedding, 0)
+	err = er.data.DB.Context(ctx).
+		Where("id > ?", id).
+		And("model = ?", model).
+		Asc("id").Limit(limit).Find(&embeddings)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveEmbedding add the embedding of the object or replace the existing one
+func (er *embeddingRepo) SaveEmbedding(ctx context.Context, embedding *entity.ContentEmbedding) (err error) {
+	old := &entity.ContentEmbedding{}
+	exist, err := er.data.DB.Context(ctx).Cols("id").Where("object_id = ?", embedding.ObjectID).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		embedding.ID = old.ID
+		_, err = er.data.DB.Context(ctx).ID(old.ID).
+			Cols("object_type", "question_id", "model", "content_hash", "vector").Update(embedding)
+	} else {
+		_, err = er.data.DB.Context(ctx).Insert(embedding)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveAllEmbeddings remove all the embeddings, so all the contents are embedded again
+func (er *embeddingRepo) RemoveAllEmbeddings(ctx context.Context) (err error) {
+	_, err = er.data.DB.Context(ctx).Where("1 = 1").Delete(&entity.ContentEmbedding{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
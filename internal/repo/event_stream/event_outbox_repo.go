@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_stream
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/event_stream"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// eventOutboxRepo event outbox repository
+type eventOutboxRepo struct {
+	data *data.Data
+}
+
+// NewEventOutboxRepo new repository
+func NewEventOutboxRepo(data *data.Data) event_stream.EventOutboxRepo {
+	return &eventOutboxRepo{
+		data: data,
+	}
+}
+
+// AddEvent add the event to the outbox
+func (er *eventOutboxRepo) AddEvent(ctx context.Context, event *entity.EventOutbox) (err error) {
+	_, err = er.data.DB.Context(ctx).Insert(event)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDueEvents get the pending events whose next attempt is due, in the order they are added
+func (er *eventOutboxRepo) GetDueEvents(ctx context.Context, now time.Time, limit int) (
+	events []*entity.EventOutbox, err error) {
+	events = make([]*entity.EventOutbox, 0)
+	err = er.data.DB.Context(ctx).
+		Where("status = ?", entity.EventOutboxStatusPending).
+		And("next_attempt_at <= ?", now).
+		OrderBy("id ASC").
+		Limit(limit).
+		Find(&events)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// MarkEventsSent mark the events accepted by the broker as sent
+func (er *eventOutboxRepo) MarkEventsSent(ctx context.Context, ids []int64) (err error) {
+	_, err = er.data.DB.Context(ctx).In("id", ids).
+		Cols("status", "last_error").
+		Update(&entity.EventOutbox{Status: entity.EventOutboxStatusSent})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateEventAttempt update the attempts, the next attempt time and the last error of the event
+func (er *eventOutboxRepo) UpdateEventAttempt(ctx context.Context, event *entity.EventOutbox) (err error) {
+	_, err = er.data.DB.Context(ctx).ID(event.ID).
+		Cols("attempts", "next_attempt_at", "last_error").
+		Update(event)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveSentEvents remove the sent events added before the time
+func (er *eventOutboxRepo) RemoveSentEvents(ctx context.Context, before time.Time) (err error) {
+	_, err = er.data.DB.Context(ctx).
+		Where("status = ?", entity.EventOutboxStatusSent).
+		And("created_at < ?", before).
+		Delete(&entity.EventOutbox{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package experiment
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/experiment"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// experimentRepo experiment event repository
+type experimentRepo struct {
+	data *data.Data
+}
+
+// NewExperimentRepo new repository
+func NewExperimentRepo(data *data.Data) experiment.ExperimentRepo {
+	return &experimentRepo{
+		data: data,
+	}
+}
+
+// AddEvent add the event if the user has not had the same event in the experiment
+func (er *experimentRepo) AddEvent(ctx context.Context, event *entity.ExperimentEvent) (err error) {
+	exist, err := er.data.DB.Context(ctx).Exist(&entity.ExperimentEvent{
+		ExperimentKey: event.ExperimentKey,
+		UserID:        event.UserID,
+		EventType:     event.EventType,
+		Name:          event.Name,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = er.data.DB.Context(ctx).Insert(event)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetEventCounts get the number of the distinct users of each variant, event type and name
+func (er *experimentRepo) GetEventCounts(ctx context.Context, experimentKey string) (
+	counts []*schema.ExperimentEventCount, err error) {
+	counts = make([]*schema.ExperimentEventCount, 0)
+	err = er.data.DB.Context(ctx).Table(entity.ExperimentEvent{}.TableName()).
+		Select("variant, event_type, name, COUNT(DISTINCT user_id) AS users").
+		Where("experiment_key = ?", experimentKey).
+		GroupBy("variant, event_type, name").
+		OrderBy("variant, event_type, name").
+		Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
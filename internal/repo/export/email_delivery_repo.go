@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// emailDeliveryRepo email send log and suppression repository
+type emailDeliveryRepo struct {
+	data *data.Data
+}
+
+// NewEmailDeliveryRepo new repository
+func NewEmailDeliveryRepo(data *data.Data) export.EmailDeliveryRepo {
+	return &emailDeliveryRepo{
+		data: data,
+	}
+}
+
+// AddEmailSendLog add the email send log
+func (er *emailDeliveryRepo) AddEmailSendLog(ctx context.Context, sendLog *entity.EmailSendLog) (err error) {
+	_, err = er.data.DB.Context(ctx).Insert(sendLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateEmailSendLogStatus update the status of the email with the message id of the provider
+func (er *emailDeliveryRepo) UpdateEmailSendLogStatus(ctx context.Context, provider, messageID, status, detail string) (
+	err error) {
+	_, err = er.data.DB.Context(ctx).Where("provider = ? AND message_id = ?", provider, messageID).
+		Cols("status", "error").Update(&entity.EmailSendLog{Status: status, Error: detail})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmailSendLogPage get the email send logs, the latest first
+func (er *emailDeliveryRepo) GetEmailSendLogPage(ctx context.Context, page, pageSize int, email, status string) (
+	sendLogs []*entity.EmailSendLog, total int64, err error) {
+	sendLogs = make([]*entity.EmailSendLog, 0)
+	session := er.data.DB.Context(ctx).Desc("id")
+	if len(email) > 0 {
+		session.And("to_email = ?", email)
+	}
+	if len(status) > 0 {
+		session.And("status = ?", status)
+	}
+	total, err = pager.Help(page, pageSize, &sendLogs, &entity.EmailSendLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsEmailSuppressed whether the address is suppressed
+func (er *emailDeliveryRepo) IsEmailSuppressed(ctx context.Context, email string) (suppressed bool, err error) {
+	suppressed, err = er.data.DB.Context(ctx).Where("email = ?", email).Exist(&entity.EmailSuppression{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddEmailSuppression suppress the address, nothing is changed if it is already suppressed
+func (er *emailDeliveryRepo) AddEmailSuppression(ctx context.Context, suppression *entity.EmailSuppression) (err error) {
+	exist, err := er.IsEmailSuppressed(ctx, suppression.Email)
+	if err != nil || exist {
+		return err
+	}
+	_, err = er.data.DB.Context(ctx).Insert(suppression)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetEmailSuppressionPage get the suppressed addresses, the latest first
+func (er *emailDeliveryRepo) GetEmailSuppressionPage(ctx context.Context, page, pageSize int, email string) (
+	suppressions []*entity.EmailSuppression, total int64, err error) {
+	suppressions = make([]*entity.EmailSuppression, 0)
+	session := er.data.DB.Context(ctx).Desc("id")
+	if len(email) > 0 {
+		session.And("email = ?", email)
+	}
+	total, err = pager.Help(page, pageSize, &suppressions, &entity.EmailSuppression{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveEmailSuppression remove the suppressed address
+func (er *emailDeliveryRepo) RemoveEmailSuppression(ctx context.Context, email string) (err error) {
+	_, err = er.data.DB.Context(ctx).Where("email = ?", email).Delete(&entity.EmailSuppression{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package github_integration
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/github_integration"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// gitHubCrossReferenceRepo GitHub cross reference repository
+type gitHubCrossReferenceRepo struct {
+	data *data.Data
+}
+
+// NewGitHubCrossReferenceRepo new repository
+func NewGitHubCrossReferenceRepo(data *data.Data) github_integration.GitHubCrossReferenceRepo {
+	return &gitHubCrossReferenceRepo{
+		data: data,
+	}
+}
+
+// AddCrossReference add the cross-reference comment posted on the issue
+func (gr *gitHubCrossReferenceRepo) AddCrossReference(ctx context.Context,
+	reference *entity.GitHubCrossReference) (err error) {
+	_, err = gr.data.DB.Context(ctx).Insert(reference)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ExistCrossReference whether the cross-reference comment of the question is posted on the issue
+func (gr *gitHubCrossReferenceRepo) ExistCrossReference(ctx context.Context, questionID, issueKey string) (
+	exist bool, err error) {
+	exist, err = gr.data.DB.Context(ctx).
+		Where("question_id = ?", questionID).
+		And("issue_key = ?", issueKey).
+		Exist(&entity.GitHubCrossReference{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
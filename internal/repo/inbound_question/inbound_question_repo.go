@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_question
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/inbound_question"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// inboundQuestionRepo inbound question repository
+type inboundQuestionRepo struct {
+	data *data.Data
+}
+
+// NewInboundQuestionRepo new repository
+func NewInboundQuestionRepo(data *data.Data) inbound_question.InboundQuestionRepo {
+	return &inboundQuestionRepo{
+		data: data,
+	}
+}
+
+// AddInboundQuestion add inbound question
+func (ir *inboundQuestionRepo) AddInboundQuestion(ctx context.Context, question *entity.InboundQuestion) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(question)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInboundQuestion get inbound question by id
+func (ir *inboundQuestionRepo) GetInboundQuestion(ctx context.Context, id int64) (
+	question *entity.InboundQuestion, exist bool, err error) {
+	question = &entity.InboundQuestion{}
+	exist, err = ir.data.DB.Context(ctx).ID(id).Get(question)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInboundQuestionPage get the page of the inbound questions with the status, the oldest first
+func (ir *inboundQuestionRepo) GetInboundQuestionPage(ctx context.Context, page, pageSize, status int) (
+	questions []*entity.InboundQuestion, total int64, err error) {
+	questions = make([]*entity.InboundQuestion, 0)
+	session := ir.data.DB.Context(ctx)
+	session = session.Where("status = ?", status)
+	session = session.OrderBy("id ASC")
+	total, err = pager.Help(page, pageSize, &questions, &entity.InboundQuestion{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateInboundQuestionReviewed update the status, the reviewer and the published question of the inbound question
+func (ir *inboundQuestionRepo) UpdateInboundQuestionReviewed(ctx context.Context,
+	question *entity.InboundQuestion) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(question.ID).
+		Cols("status", "reviewer_id", "user_id", "question_id", "tags").
+		Update(question)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package invitation
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/invitation"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// invitationRepo invitation repository
+type invitationRepo struct {
+	data *data.Data
+}
+
+// NewInvitationRepo new repository
+func NewInvitationRepo(data *data.Data) invitation.InvitationRepo {
+	return &invitationRepo{
+		data: data,
+	}
+}
+
+// AddInvitation add invitation
+func (ir *invitationRepo) AddInvitation(ctx context.Context, invitation *entity.Invitation) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(invitation)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInvitation get invitation by id
+func (ir *invitationRepo) GetInvitation(ctx context.Context, id int) (
+	invitation *entity.Invitation, exist bool, err error) {
+	invitation = &entity.Invitation{}
+	exist, err = ir.data.DB.Context(ctx).ID(id).Get(invitation)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInvitationByCode get invitation by code
+func (ir *invitationRepo) GetInvitationByCode(ctx context.Context, code string) (
+	invitation *entity.Invitation, exist bool, err error) {
+	invitation = &entity.Invitation{}
+	exist, err = ir.data.DB.Context(ctx).Where("code = ?", code).Get(invitation)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetInvitationPage get invitation page, the newest first. If user id is empty, get all invitations.
+func (ir *invitationRepo) GetInvitationPage(ctx context.Context, page, pageSize int, userID string) (
+	invitations []*entity.Invitation, total int64, err error) {
+	invitations = make([]*entity.Invitation, 0)
+	session := ir.data.DB.Context(ctx)
+	if len(userID) > 0 {
+		session.Where("user_id = ?", userID)
+	}
+	session.Desc("id")
+	total, err = pager.Help(page, pageSize, &invitations, &entity.Invitation{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateInvitationStatus update invitation status
+func (ir *invitationRepo) UpdateInvitationStatus(ctx context.Context, id, status int) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(id).Cols("status").Update(&entity.Invitation{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddInvitedUser add the user registered with the invitation, the invitation is used once and the record
+// of who invited whom is added in the same transaction. It returns false and adds nothing if the invitation
+// is not available or has been used up by others at the same time.
+func (ir *invitationRepo) AddInvitedUser(ctx context.Context, invitation *entity.Invitation, user *entity.User) (
+	ok bool, err error) {
+	_, err = ir.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		affected, err := session.ID(invitation.ID).
+			Where("status = ?", entity.InvitationStatusAvailable).
+			And("(max_uses = 0 OR used_count < max_uses)").
+			Incr("used_count").
+			Update(&entity.Invitation{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected == 0 {
+			return nil, nil
+		}
+		exist, err := session.Where("username = ?", user.Username).Exist(&entity.User{})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if exist {
+			return nil, errors.InternalServer(reason.UsernameDuplicate)
+		}
+		if _, err = session.Insert(user); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		_, err = session.Insert(&entity.InvitationRecord{
+			InvitationID:  invitation.ID,
+			InviterUserID: invitation.UserID,
+			UserID:        user.ID,
+		})
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		ok = true
+		return nil, nil
+	})
+	return ok, err
+}
+
+// GetInvitationRecordPage get invitation record page, the newest first
+func (ir *invitationRepo) GetInvitationRecordPage(ctx context.Context, page, pageSize int) (
+	records []*entity.InvitationRecord, total int64, err error) {
+	records = make([]*entity.InvitationRecord, 0)
+	session := ir.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &records, &entity.InvitationRecord{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
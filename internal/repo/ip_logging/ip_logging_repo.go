@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ip_logging
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// fingerprintLimit the max number of the latest fingerprints read from each table
+const fingerprintLimit = 1000
+
+// ipLoggingRepo ip logging repository
+type ipLoggingRepo struct {
+	data *data.Data
+}
+
+// NewIPLoggingRepo new repository
+func NewIPLoggingRepo(data *data.Data) ip_logging.IPLoggingRepo {
+	return &ipLoggingRepo{
+		data: data,
+	}
+}
+
+// AddPostIPRecord add the post ip record
+func (ir *ipLoggingRepo) AddPostIPRecord(ctx context.Context, record *entity.PostIPRecord) (err error) {
+	_, err = ir.data.DB.Context(ctx).Insert(record)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePostIPRecordLocation update the location of the post ip record
+func (ir *ipLoggingRepo) UpdatePostIPRecordLocation(ctx context.Context, record *entity.PostIPRecord) (err error) {
+	_, err = ir.data.DB.Context(ctx).ID(record.ID).Cols("country", "region", "city").Update(record)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPostIPRecordPage get the post ip records page, they are filtered by the user and the ip address if not empty
+func (ir *ipLoggingRepo) GetPostIPRecordPage(ctx context.Context, page, pageSize int, userID, ip string) (
+	records []*entity.PostIPRecord, total int64, err error) {
+	records = make([]*entity.PostIPRecord, 0)
+	session := ir.data.DB.Context(ctx)
+	if len(userID) > 0 {
+		session.And("user_id = ?", userID)
+	}
+	if len(ip) > 0 {
+		session.And("ip = ?", ip)
+	}
+	session.Desc("id")
+	total, err = pager.Help(page, pageSize, &records, &entity.PostIPRecord{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserFingerprints get the latest ip addresses and user agents of the user from the posts and the login sessions
+func (ir *ipLoggingRepo) GetUserFingerprints(ctx context.Context, userID string) (
+	fingerprints []*ip_logging.Fingerprint, err error) {
+	return ir.getFingerprints(ctx, builder.Eq{"user_id": userID})
+}
+
+// GetFingerprintsByIPsOrUserAgents get the latest fingerprints of the other users which use the ip addresses
+// or the user agents
+func (ir *ipLoggingRepo) GetFingerprintsByIPsOrUserAgents(ctx context.Context, ips, userAgents []string,
+	excludeUserID string) (fingerprints []*ip_logging.Fingerprint, err error) {
+	matchCond := builder.NewCond()
+	if len(ips) > 0 {
+		matchCond = matchCond.Or(builder.In("ip", ips))
+	}
+	if len(userAgents) > 0 {
+		matchCond = matchCond.Or(builder.In("user_agent", userAgents))
+	}
+	if !matchCond.IsValid() {
+		return make([]*ip_logging.Fingerprint, 0), nil
+	}
+	return ir.getFingerprints(ctx, builder.And(matchCond, builder.Neq{"user_id": excludeUserID}))
+}
+
+// getFingerprints get the latest fingerprints matching the condition from the posts and the login sessions
+func (ir *ipLoggingRepo) getFingerprints(ctx context.Context, cond builder.Cond) (
+	fingerprints []*ip_logging.Fingerprint, err error) {
+	records := make([]*entity.PostIPRecord, 0)
+	err = ir.data.DB.Context(ctx).Where(cond).Desc("id").Limit(fingerprintLimit).Find(&records)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	sessions := make([]*entity.UserSession, 0)
+	err = ir.data.DB.Context(ctx).Where(cond).Desc("id").Limit(fingerprintLimit).Find(&sessions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	fingerprints = make([]*ip_logging.Fingerprint, 0, len(records)+len(sessions))
+	for _, record := range records {
+		fingerprints = append(fingerprints, &ip_logging.Fingerprint{
+			UserID:    record.UserID,
+			IP:        record.IP,
+			UserAgent: record.UserAgent,
+			SeenAt:    record.CreatedAt,
+		})
+	}
+	for _, session := range sessions {
+		fingerprints = append(fingerprints, &ip_logging.Fingerprint{
+			UserID:    session.UserID,
+			IP:        session.IP,
+			UserAgent: session.UserAgent,
+			SeenAt:    session.LastActiveAt,
+		})
+	}
+	return fingerprints, nil
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira_integration
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/jira_integration"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// jiraIssueLinkRepo Jira issue link repository
+type jiraIssueLinkRepo struct {
+	data *data.Data
+}
+
+// NewJiraIssueLinkRepo new repository
+func NewJiraIssueLinkRepo(data *data.Data) jira_integration.JiraIssueLinkRepo {
+	return &jiraIssueLinkRepo{
+		data: data,
+	}
+}
+
+// AddIssueLink add the Jira issue of the question
+func (jr *jiraIssueLinkRepo) AddIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error) {
+	_, err = jr.data.DB.Context(ctx).Insert(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetIssueLinkByQuestionID get the Jira issue of the question
+func (jr *jiraIssueLinkRepo) GetIssueLinkByQuestionID(ctx context.Context, questionID string) (
+	link *entity.JiraIssueLink, exist bool, err error) {
+	link = &entity.JiraIssueLink{}
+	exist, err = jr.data.DB.Context(ctx).Where("question_id = ?", questionID).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUnresolvedIssueLinks get the unresolved Jira issues synced before the time, the least recently synced first
+func (jr *jiraIssueLinkRepo) GetUnresolvedIssueLinks(ctx context.Context, syncedBefore time.Time, limit int) (
+	links []*entity.JiraIssueLink, err error) {
+	links = make([]*entity.JiraIssueLink, 0)
+	err = jr.data.DB.Context(ctx).
+		Where("status_category <> ?", schema.JiraStatusCategoryDone).
+		And("synced_at < ?", syncedBefore).
+		OrderBy("synced_at ASC").
+		Limit(limit).
+		Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateIssueStatus update the status and the synced time of the Jira issue
+func (jr *jiraIssueLinkRepo) UpdateIssueStatus(ctx context.Context, link *entity.JiraIssueLink) (err error) {
+	_, err = jr.data.DB.Context(ctx).ID(link.ID).
+		Cols("status", "status_category", "synced_at").
+		Update(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,156 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package leaderboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/leaderboard"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// leaderboardRepo leaderboard repository
+type leaderboardRepo struct {
+	data *data.Data
+}
+
+// NewLeaderboardRepo new repository
+func NewLeaderboardRepo(data *data.Data) leaderboard.LeaderboardRepo {
+	return &leaderboardRepo{
+		data: data,
+	}
+}
+
+// GetReputationScores get the reputation earned by the users since the start time, zero start time for all time
+func (lr *leaderboardRepo) GetReputationScores(ctx context.Context, startTime time.Time, limit int) (
+	scores []*entity.LeaderboardScore, err error) {
+	scores = make([]*entity.LeaderboardScore, 0)
+	session := lr.data.DB.Context(ctx).Table("activity").
+		Select("activity.user_id, SUM(activity.`rank`) AS score").
+		Join("INNER", "`user`", "`user`.id = activity.user_id").
+		Where("activity.has_rank = ?", 1).
+		And("activity.cancelled = ?", entity.ActivityAvailable)
+	if !startTime.IsZero() {
+		session.And("activity.created_at >= ?", startTime)
+	}
+	lr.filterUser(session)
+	err = session.GroupBy("activity.user_id").
+		Having("SUM(activity.`rank`) > 0").
+		OrderBy("score DESC").
+		Limit(limit).
+		Find(&scores)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return scores, nil
+}
+
+// GetTagAnswererScores get the number of answers of each user in each tag since the start time
+func (lr *leaderboardRepo) GetTagAnswererScores(ctx context.Context, startTime time.Time) (
+	scores []*entity.LeaderboardScore, err error) {
+	scores = make([]*entity.LeaderboardScore, 0)
+	session := lr.data.DB.Context(ctx).Table("answer").
+		Select("tag_rel.tag_id, answer.user_id, COUNT(*) AS score").
+		Join("INNER", "tag_rel", "tag_rel.object_id = answer.question_id").
+		Join("INNER", "`user`", "`user`.id = answer.user_id").
+		Where("answer.status = ?", entity.AnswerStatusAvailable).
+		And("tag_rel.status = ?", entity.TagRelStatusAvailable)
+	if !startTime.IsZero() {
+		session.And("answer.created_at >= ?", startTime)
+	}
+	lr.filterUser(session)
+	err = session.GroupBy("tag_rel.tag_id, answer.user_id").Find(&scores)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return scores, nil
+}
+
+// filterUser only the available users who do not opt out are in the leaderboards
+func (lr *leaderboardRepo) filterUser(session *xorm.Session) {
+	session.And("`user`.status = ?", entity.UserStatusAvailable).
+		And("`user`.leaderboard_opt_out = ?", false)
+}
+
+// SaveLeaderboard replace the leaderboard of the period with the new one
+func (lr *leaderboardRepo) SaveLeaderboard(ctx context.Context, period, board string,
+	leaderboard []*entity.Leaderboard) (err error) {
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("period = ? AND board = ?", period, board).Delete(&entity.Leaderboard{})
+		if err != nil {
+			return nil, err
+		}
+		if len(leaderboard) == 0 {
+			return nil, nil
+		}
+		_, err = session.Insert(leaderboard)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetLeaderboard get the leaderboard of the period ordered by position
+func (lr *leaderboardRepo) GetLeaderboard(ctx context.Context, period, board, tagID string) (
+	leaderboard []*entity.Leaderboard, err error) {
+	leaderboard = make([]*entity.Leaderboard, 0)
+	err = lr.data.DB.Context(ctx).
+		Where("period = ? AND board = ? AND tag_id = ?", period, board, tagID).
+		Asc("position", "id").
+		Find(&leaderboard)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return leaderboard, nil
+}
+
+// UpdateUserOptOut update the opt-out of the user, the user who opts out is removed from the leaderboards,
+// the removed rows are returned
+func (lr *leaderboardRepo) UpdateUserOptOut(ctx context.Context, userID string, optOut bool) (
+	removed []*entity.Leaderboard, err error) {
+	removed = make([]*entity.Leaderboard, 0)
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		_, err = session.ID(userID).Cols("leaderboard_opt_out").
+			Update(&entity.User{LeaderboardOptOut: optOut})
+		if err != nil {
+			return nil, err
+		}
+		if !optOut {
+			return nil, nil
+		}
+		if err = session.Where("user_id = ?", userID).Find(&removed); err != nil {
+			return nil, err
+		}
+		_, err = session.Where("user_id = ?", userID).Delete(&entity.Leaderboard{})
+		return nil, err
+	})
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return removed, nil
+}
@@ -0,0 +1,172 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package legal_hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// legalHoldRepo legal hold repository
+type legalHoldRepo struct {
+	data *data.Data
+}
+
+// NewLegalHoldRepo new repository
+func NewLegalHoldRepo(data *data.Data) legal_hold.LegalHoldRepo {
+	return &legalHoldRepo{
+		data: data,
+	}
+}
+
+// AddLegalHold add the legal hold and the log of placing it
+func (lr *legalHoldRepo) AddLegalHold(ctx context.Context, hold *entity.LegalHold) (err error) {
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Insert(hold); err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.LegalHoldLog{
+			LegalHoldID:    hold.ID,
+			ObjectID:       hold.ObjectID,
+			ObjectType:     hold.ObjectType,
+			Action:         entity.LegalHoldActionPlace,
+			OperatorUserID: hold.OperatorUserID,
+			Reason:         hold.Reason,
+		})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ReleaseLegalHold release the active legal hold and add the log of releasing it with the reason
+func (lr *legalHoldRepo) ReleaseLegalHold(ctx context.Context, hold *entity.LegalHold, userID, releaseReason string) (
+	err error) {
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		_, err = session.ID(hold.ID).And("status = ?", entity.LegalHoldStatusActive).
+			Cols("status", "released_user_id", "released_at").
+			Update(&entity.LegalHold{
+				Status:         entity.LegalHoldStatusReleased,
+				ReleasedUserID: userID,
+				ReleasedAt:     time.Now(),
+			})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.LegalHoldLog{
+			LegalHoldID:    hold.ID,
+			ObjectID:       hold.ObjectID,
+			ObjectType:     hold.ObjectType,
+			Action:         entity.LegalHoldActionRelease,
+			OperatorUserID: userID,
+			Reason:         releaseReason,
+		})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddLegalHoldLog add the log of the legal hold
+func (lr *legalHoldRepo) AddLegalHoldLog(ctx context.Context, holdLog *entity.LegalHoldLog) (err error) {
+	if _, err = lr.data.DB.Context(ctx).Insert(holdLog); err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLegalHold get the legal hold by id
+func (lr *legalHoldRepo) GetLegalHold(ctx context.Context, id int) (hold *entity.LegalHold, exist bool, err error) {
+	hold = &entity.LegalHold{}
+	exist, err = lr.data.DB.Context(ctx).ID(id).Get(hold)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveLegalHolds get the active legal holds of the objects
+func (lr *legalHoldRepo) GetActiveLegalHolds(ctx context.Context, objectIDs []string) (
+	holds []*entity.LegalHold, err error) {
+	holds = make([]*entity.LegalHold, 0)
+	if len(objectIDs) == 0 {
+		return holds, nil
+	}
+	err = lr.data.DB.Context(ctx).In("object_id", objectIDs).And("status = ?", entity.LegalHoldStatusActive).
+		Asc("id").Find(&holds)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetActiveHeldObjectIDs get the ids of the objects of the type which are under the active legal holds
+func (lr *legalHoldRepo) GetActiveHeldObjectIDs(ctx context.Context, objectType string) (objectIDs []string, err error) {
+	objectIDs = make([]string, 0)
+	err = lr.data.DB.Context(ctx).Table(entity.LegalHold{}.TableName()).Distinct("object_id").
+		Where("object_type = ?", objectType).And("status = ?", entity.LegalHoldStatusActive).
+		Find(&objectIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLegalHoldPage get the legal holds page with the status
+func (lr *legalHoldRepo) GetLegalHoldPage(ctx context.Context, page, pageSize, status int) (
+	holds []*entity.LegalHold, total int64, err error) {
+	holds = make([]*entity.LegalHold, 0)
+	session := lr.data.DB.Context(ctx).Where("status = ?", status).Desc("id")
+	total, err = pager.Help(page, pageSize, &holds, &entity.LegalHold{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLegalHoldLogPage get the legal hold logs page, they are filtered by the object if it is not empty
+func (lr *legalHoldRepo) GetLegalHoldLogPage(ctx context.Context, page, pageSize int, objectID string) (
+	holdLogs []*entity.LegalHoldLog, total int64, err error) {
+	holdLogs = make([]*entity.LegalHoldLog, 0)
+	session := lr.data.DB.Context(ctx)
+	if len(objectID) > 0 {
+		session.Where("object_id = ?", objectID)
+	}
+	session.Desc("id")
+	total, err = pager.Help(page, pageSize, &holdLogs, &entity.LegalHoldLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
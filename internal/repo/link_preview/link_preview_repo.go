@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package link_preview
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/link_preview"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// linkPreviewRepo link preview repository
+type linkPreviewRepo struct {
+	data *data.Data
+}
+
+// NewLinkPreviewRepo new repository
+func NewLinkPreviewRepo(data *data.Data) link_preview.LinkPreviewRepo {
+	return &linkPreviewRepo{
+		data: data,
+	}
+}
+
+// GetLinkPreviewsByHashes get link previews by the hash of the url
+func (lr *linkPreviewRepo) GetLinkPreviewsByHashes(ctx context.Context, urlHashes []string) (
+	previews []*entity.LinkPreview, err error) {
+	previews = make([]*entity.LinkPreview, 0)
+	if len(urlHashes) == 0 {
+		return previews, nil
+	}
+	err = lr.data.DB.Context(ctx).In("url_hash", urlHashes).Find(&previews)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveLinkPreview add the link preview or update it if the url already exists
+func (lr *linkPreviewRepo) SaveLinkPreview(ctx context.Context, preview *entity.LinkPreview) (err error) {
+	old := &entity.LinkPreview{}
+	exist, err := lr.data.DB.Context(ctx).Where("url_hash = ?", preview.URLHash).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		preview.ID = old.ID
+		_, err = lr.data.DB.Context(ctx).ID(old.ID).
+			Cols("title", "description", "image_url", "site_name", "status").Update(preview)
+	} else {
+		_, err = lr.data.DB.Context(ctx).Insert(preview)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package list_index
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/list_index"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// listIndexRepo list index repository
+type listIndexRepo struct {
+	data *data.Data
+}
+
+// NewListIndexRepo new repository
+func NewListIndexRepo(data *data.Data) list_index.ListIndexRepo {
+	return &listIndexRepo{
+		data: data,
+	}
+}
+
+// MarkQuestionListsDirty mark the homepage lists and the lists of the tags of the question dirty,
+// the tags removed from the question are included because the question is in their lists before
+func (lr *listIndexRepo) MarkQuestionListsDirty(ctx context.Context, questionID string) (err error) {
+	tagRelList := make([]*entity.TagRel, 0)
+	err = lr.data.DB.Context(ctx).Cols("tag_id").Where("object_id = ?", questionID).Find(&tagRelList)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	cond := builder.Or(builder.Eq{"list_key": ""})
+	for _, tagRel := range tagRelList {
+		cond = cond.Or(builder.Like{"list_key", "," + tagRel.TagID + ","})
+	}
+	_, err = lr.data.DB.Context(ctx).Where(cond).Cols("dirty").
+		Update(&entity.QuestionListPartition{Dirty: true})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetDuePartitions get the lists which are dirty or built before the stale time
+func (lr *listIndexRepo) GetDuePartitions(ctx context.Context, staleBefore time.Time, limit int) (
+	partitions []*entity.QuestionListPartition, err error) {
+	partitions = make([]*entity.QuestionListPartition, 0)
+	err = lr.data.DB.Context(ctx).
+		Where("dirty = ? OR refreshed_at IS NULL OR refreshed_at < ?", true, staleBefore).
+		Asc("refreshed_at", "id").
+		Limit(limit).
+		Find(&partitions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return partitions, nil
+}
+
+// RemoveUnreadPartitions remove the tag lists which are not read since the time, the homepage lists are kept
+func (lr *listIndexRepo) RemoveUnreadPartitions(ctx context.Context, before time.Time) (count int64, err error) {
+	partitions := make([]*entity.QuestionListPartition, 0)
+	err = lr.data.DB.Context(ctx).Cols("id").
+		Where("list_key <> ? AND last_read_at < ?", "", before).
+		Find(&partitions)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if len(partitions) == 0 {
+		return 0, nil
+	}
+	ids := make([]int, 0, len(partitions))
+	for _, partition := range partitions {
+		ids = append(ids, partition.ID)
+	}
+	_, err = lr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		if _, err = session.In("partition_id", ids).Delete(&entity.QuestionListIndex{}); err != nil {
+			return nil, err
+		}
+		count, err = session.In("id", ids).Delete(&entity.QuestionListPartition{})
+		return nil, err
+	})
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return count, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package login_protection
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/login_protection"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// loginProtectionRepo login protection repository
+type loginProtectionRepo struct {
+	data *data.Data
+}
+
+// NewLoginProtectionRepo new repository
+func NewLoginProtectionRepo(data *data.Data) login_protection.LoginProtectionRepo {
+	return &loginProtectionRepo{
+		data: data,
+	}
+}
+
+// AddLoginFailure add the failed sign-in attempt
+func (lr *loginProtectionRepo) AddLoginFailure(ctx context.Context, failure *entity.LoginFailure) (err error) {
+	_, err = lr.data.DB.Context(ctx).Insert(failure)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetLoginFailurePage get the failed sign-in attempts, the latest first
+func (lr *loginProtectionRepo) GetLoginFailurePage(ctx context.Context, page, pageSize int, email, ip string) (
+	failures []*entity.LoginFailure, total int64, err error) {
+	failures = make([]*entity.LoginFailure, 0)
+	session := lr.data.DB.Context(ctx).Desc("id")
+	if len(email) > 0 {
+		session.And("email = ?", email)
+	}
+	if len(ip) > 0 {
+		session.And("ip = ?", ip)
+	}
+	total, err = pager.Help(page, pageSize, &failures, &entity.LoginFailure{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
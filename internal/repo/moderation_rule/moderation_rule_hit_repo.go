@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_rule
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/moderation_rule"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// moderationRuleHitRepo moderation rule hit repository
+type moderationRuleHitRepo struct {
+	data *data.Data
+}
+
+// NewModerationRuleHitRepo new repository
+func NewModerationRuleHitRepo(data *data.Data) moderation_rule.ModerationRuleHitRepo {
+	return &moderationRuleHitRepo{
+		data: data,
+	}
+}
+
+// AddModerationRuleHits add moderation rule hits
+func (mr *moderationRuleHitRepo) AddModerationRuleHits(ctx context.Context, hits []*entity.ModerationRuleHit) (
+	err error) {
+	_, err = mr.data.DB.Context(ctx).Insert(hits)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetModerationRuleHitPage get moderation rule hit page, the newest first
+func (mr *moderationRuleHitRepo) GetModerationRuleHitPage(ctx context.Context, page, pageSize int,
+	cond *entity.ModerationRuleHit) (hits []*entity.ModerationRuleHit, total int64, err error) {
+	hits = make([]*entity.ModerationRuleHit, 0)
+	session := mr.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &hits, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_triage
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/moderation_triage"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// moderationTriageRepo moderation triage repository
+type moderationTriageRepo struct {
+	data *data.Data
+}
+
+// NewModerationTriageRepo new repository
+func NewModerationTriageRepo(data *data.Data) moderation_triage.ModerationTriageRepo {
+	return &moderationTriageRepo{
+		data: data,
+	}
+}
+
+// GetPendingReports get the latest pending reports and the total, the reports are filtered by the object type and
+// the report types if they are not empty
+func (mr *moderationTriageRepo) GetPendingReports(ctx context.Context, objectType int, reportTypes []int,
+	limit int) (reports []*entity.Report, total int64, err error) {
+	reports = make([]*entity.Report, 0)
+	session := mr.data.DB.Context(ctx).Where("status = ?", entity.ReportStatusPending)
+	if objectType > 0 {
+		session.And("object_type = ?", objectType)
+	}
+	if len(reportTypes) > 0 {
+		session.In("report_type", reportTypes)
+	}
+	total, err = session.Desc("id").Limit(limit).FindAndCount(&reports)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReopenCandidates get the latest closed questions which are edited after they are closed and the total
+func (mr *moderationTriageRepo) GetReopenCandidates(ctx context.Context, closedActivityType int, limit int) (
+	questions []*entity.Question, total int64, err error) {
+	questions = make([]*entity.Question, 0)
+	total, err = mr.data.DB.Context(ctx).
+		Where("question.status = ?", entity.QuestionStatusClosed).
+		And("EXISTS (SELECT 1 FROM revision WHERE revision.object_id = question.id AND revision.status = ? "+
+			"AND revision.created_at > (SELECT MAX(activity.created_at) FROM activity "+
+			"WHERE activity.object_id = question.id AND activity.activity_type = ?))",
+			entity.RevisionReviewPassStatus, closedActivityType).
+		Desc("question.updated_at").Limit(limit).
+		FindAndCount(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingReviews get the latest pending reviews and the total
+func (mr *moderationTriageRepo) GetPendingReviews(ctx context.Context, limit int) (
+	reviews []*entity.Review, total int64, err error) {
+	reviews = make([]*entity.Review, 0)
+	total, err = mr.data.DB.Context(ctx).Where("status = ?", entity.ReviewStatusPending).
+		Desc("id").Limit(limit).FindAndCount(&reviews)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSLABreachedQuestions get the latest open questions breaching the SLA and the total
+func (mr *moderationTriageRepo) GetSLABreachedQuestions(ctx context.Context, limit int) (
+	questions []*entity.Question, total int64, err error) {
+	questions = make([]*entity.Question, 0)
+	total, err = mr.data.DB.Context(ctx).
+		Join("INNER", entity.QuestionSLA{}.TableName(), "question_sla.question_id = question.id").
+		Where("question.status = ?", entity.QuestionStatusAvailable).
+		And("question.accepted_answer_id = ?", "0").
+		And("((question_sla.first_response_breached_at IS NOT NULL AND question.answer_count = 0) " +
+			"OR question_sla.resolution_breached_at IS NOT NULL)").
+		Asc("question.created_at").Limit(limit).
+		FindAndCount(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSpamSuspects get the users with the most pending spam flags and the total of the users
+func (mr *moderationTriageRepo) GetSpamSuspects(ctx context.Context, spamReportType int, limit int) (
+	suspects []*entity.SpamSuspect, total int64, err error) {
+	suspects = make([]*entity.SpamSuspect, 0)
+	err = mr.data.DB.Context(ctx).Table(entity.Report{}.TableName()).
+		Select("reported_user_id, COUNT(*) AS flag_count").
+		Where("status = ?", entity.ReportStatusPending).
+		And("report_type = ?", spamReportType).
+		And("reported_user_id <> ?", "0").
+		GroupBy("reported_user_id").
+		OrderBy("flag_count DESC").Limit(limit).
+		Find(&suspects)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = mr.data.DB.Context(ctx).Table(entity.Report{}.TableName()).
+		Select("COUNT(DISTINCT reported_user_id)").
+		Where("status = ?", entity.ReportStatusPending).
+		And("report_type = ?", spamReportType).
+		And("reported_user_id <> ?", "0").
+		Get(&total)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateUserPendingReportsStatus update the status of the pending reports of the user with the report type
+func (mr *moderationTriageRepo) UpdateUserPendingReportsStatus(ctx context.Context, reportedUserID string,
+	reportType, status int) (err error) {
+	_, err = mr.data.DB.Context(ctx).
+		Where("reported_user_id = ?", reportedUserID).
+		And("report_type = ?", reportType).
+		And("status = ?", entity.ReportStatusPending).
+		Cols("status").Update(&entity.Report{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package new_contributor
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/new_contributor"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// newContributorRepo new contributor repository
+type newContributorRepo struct {
+	data *data.Data
+}
+
+// NewNewContributorRepo new repository
+func NewNewContributorRepo(data *data.Data) new_contributor.NewContributorRepo {
+	return &newContributorRepo{
+		data: data,
+	}
+}
+
+// CountUserQuestionsSince count the questions asked by the user since the time,
+// the deleted ones are counted as well, so deleting and asking again does not get around the limit
+func (nr *newContributorRepo) CountUserQuestionsSince(ctx context.Context, userID string, since time.Time) (
+	count int64, err error) {
+	count, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("created_at >= ?", since).
+		Count(&entity.Question{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserAnswersSince count the answers posted by the user since the time, the deleted ones are counted as well
+func (nr *newContributorRepo) CountUserAnswersSince(ctx context.Context, userID string, since time.Time) (
+	count int64, err error) {
+	count, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("created_at >= ?", since).
+		Count(&entity.Answer{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
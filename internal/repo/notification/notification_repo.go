@@ -21,8 +21,10 @@ package notification
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -31,6 +33,7 @@ import (
 	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
 )
 
 // notificationRepo notification repository
@@ -129,3 +132,87 @@ func (nr *notificationRepo) GetNotificationPage(ctx context.Context, searchCond
 	}
 	return
 }
+
+// GetByIDs get the notifications by the ids
+func (nr *notificationRepo) GetByIDs(ctx context.Context, ids []string) (notifications []*entity.Notification, err error) {
+	notifications = make([]*entity.Notification, 0)
+	if len(ids) == 0 {
+		return notifications, nil
+	}
+	err = nr.data.DB.Context(ctx).In("id", ids).Find(&notifications)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNotificationGroupPage get the groups of the notifications, the group updated recently first
+func (nr *notificationRepo) GetNotificationGroupPage(ctx context.Context, searchCond *schema.NotificationGroupSearch) (
+	groups []*schema.NotificationGroup, total int64, err error) {
+	groups = make([]*schema.NotificationGroup, 0)
+	if searchCond.UserID == "" {
+		return groups, 0, nil
+	}
+	page, pageSize := searchCond.Page, searchCond.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = constant.DefaultPageSize
+	}
+
+	cond := builder.Eq{"user_id": searchCond.UserID, "type": searchCond.Type}
+	if searchCond.InboxType > 0 {
+		cond["msg_type"] = searchCond.InboxType
+	}
+	total, err = nr.data.DB.Context(ctx).Table(entity.Notification{}.TableName()).Where(cond).
+		Select("COUNT(DISTINCT group_key)").Count()
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	err = nr.data.DB.Context(ctx).Table(entity.Notification{}.TableName()).Where(cond).
+		Select(fmt.Sprintf("group_key, MAX(id) AS latest_id, COUNT(*) AS amount, "+
+			"SUM(CASE WHEN is_read = %d THEN 1 ELSE 0 END) AS unread_amount", schema.NotificationNotRead)).
+		GroupBy("group_key").OrderBy("MAX(updated_at) DESC, MAX(id) DESC").
+		Limit(pageSize, (page-1)*pageSize).Find(&groups)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClearGroupUnRead mark the notifications of the group as read
+func (nr *notificationRepo) ClearGroupUnRead(ctx context.Context, userID, groupKey string) (err error) {
+	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("group_key = ?", groupKey).
+		And("is_read = ?", schema.NotificationNotRead).Cols("is_read").
+		Update(&entity.Notification{IsRead: schema.NotificationRead})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// ClearInboxTypeUnRead mark the inbox notifications of the inbox type as read
+func (nr *notificationRepo) ClearInboxTypeUnRead(ctx context.Context, userID string, inboxType int) (err error) {
+	_, err = nr.data.DB.Context(ctx).Where("user_id = ?", userID).And("type = ?", schema.NotificationTypeInbox).
+		And("msg_type = ?", inboxType).And("is_read = ?", schema.NotificationNotRead).Cols("is_read").
+		Update(&entity.Notification{IsRead: schema.NotificationRead})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUnreadCount get the unread amount of the user by the notification type and inbox type
+func (nr *notificationRepo) GetUnreadCount(ctx context.Context, userID string) (
+	counts []*schema.NotificationUnreadCount, err error) {
+	counts = make([]*schema.NotificationUnreadCount, 0)
+	err = nr.data.DB.Context(ctx).Table(entity.Notification{}.TableName()).
+		Select("type, msg_type, COUNT(*) AS unread_amount").
+		Where("user_id = ?", userID).And("is_read = ?", schema.NotificationNotRead).
+		GroupBy("type, msg_type").Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,113 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package page
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/page"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// pageRepo page repository
+type pageRepo struct {
+	data *data.Data
+}
+
+// NewPageRepo new repository
+func NewPageRepo(data *data.Data) page.PageRepo {
+	return &pageRepo{
+		data: data,
+	}
+}
+
+// AddPage add page
+func (pr *pageRepo) AddPage(ctx context.Context, page *entity.Page) (err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(page)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePage update page
+func (pr *pageRepo) UpdatePage(ctx context.Context, page *entity.Page) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(page.ID).
+		Cols("title", "slug_name", "original_text", "parsed_text", "status", "show_in_nav", "nav_order").
+		Update(page)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemovePage remove page
+func (pr *pageRepo) RemovePage(ctx context.Context, id int) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(id).Delete(&entity.Page{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPage get page by id
+func (pr *pageRepo) GetPage(ctx context.Context, id int) (page *entity.Page, exist bool, err error) {
+	page = &entity.Page{}
+	exist, err = pr.data.DB.Context(ctx).ID(id).Get(page)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPageBySlugName get page by slug name
+func (pr *pageRepo) GetPageBySlugName(ctx context.Context, slugName string) (page *entity.Page, exist bool, err error) {
+	page = &entity.Page{}
+	exist, err = pr.data.DB.Context(ctx).Where("slug_name = ?", slugName).Get(page)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPageList get all pages
+func (pr *pageRepo) GetPageList(ctx context.Context) (pages []*entity.Page, err error) {
+	pages = make([]*entity.Page, 0)
+	err = pr.data.DB.Context(ctx).Asc("nav_order", "id").Find(&pages)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetNavPageList get the published pages shown in the site navigation, order by nav order
+func (pr *pageRepo) GetNavPageList(ctx context.Context) (pages []*entity.Page, err error) {
+	pages = make([]*entity.Page, 0)
+	err = pr.data.DB.Context(ctx).Cols("id", "title", "slug_name", "nav_order").
+		Where("status = ?", entity.PageStatusPublished).And("show_in_nav = ?", true).
+		Asc("nav_order", "id").Find(&pages)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,356 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package pending_deletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// recycleBinStatus the deleted objects which are neither restored nor purged are in the recycle bin
+var recycleBinStatus = []int{entity.PendingDeletionStatusPending, entity.PendingDeletionStatusFinalized}
+
+// pendingDeletionRepo pending deletion repository
+type pendingDeletionRepo struct {
+	data *data.Data
+}
+
+// NewPendingDeletionRepo new repository
+func NewPendingDeletionRepo(data *data.Data) pending_deletion.PendingDeletionRepo {
+	return &pendingDeletionRepo{
+		data: data,
+	}
+}
+
+// AddPendingDeletion add pending deletion
+func (pr *pendingDeletionRepo) AddPendingDeletion(ctx context.Context, pendingDeletion *entity.PendingDeletion) (
+	err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(pendingDeletion)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetPendingDeletion get the latest pending deletion of the object
+func (pr *pendingDeletionRepo) GetPendingDeletion(ctx context.Context, objectID string) (
+	pendingDeletion *entity.PendingDeletion, exist bool, err error) {
+	pendingDeletion = &entity.PendingDeletion{}
+	exist, err = pr.data.DB.Context(ctx).
+		Where("object_id = ?", objectID).
+		And("status = ?", entity.PendingDeletionStatusPending).
+		Desc("id").
+		Get(pendingDeletion)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdatePendingDeletionStatus update pending deletion status
+func (pr *pendingDeletionRepo) UpdatePendingDeletionStatus(ctx context.Context, id int, status int) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(id).Cols("status").Update(&entity.PendingDeletion{Status: status})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// FinalizeExpiredPendingDeletions finalize the pending deletions which have expired,
+// the deletions undone at the same time are not finalized
+func (pr *pendingDeletionRepo) FinalizeExpiredPendingDeletions(ctx context.Context, now time.Time) (
+	finalized []*entity.PendingDeletion, err error) {
+	expired := make([]*entity.PendingDeletion, 0)
+	err = pr.data.DB.Context(ctx).
+		Where("status = ?", entity.PendingDeletionStatusPending).
+		And("expired_at <= ?", now).
+		Asc("id").
+		Find(&expired)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	finalized = make([]*entity.PendingDeletion, 0, len(expired))
+	for _, pendingDeletion := range expired {
+		affected, err := pr.data.DB.Context(ctx).
+			ID(pendingDeletion.ID).
+			And("status = ?", entity.PendingDeletionStatusPending).
+			Cols("status").
+			Update(&entity.PendingDeletion{Status: entity.PendingDeletionStatusFinalized})
+		if err != nil {
+			return finalized, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		if affected > 0 {
+			pendingDeletion.Status = entity.PendingDeletionStatusFinalized
+			finalized = append(finalized, pendingDeletion)
+		}
+	}
+	return finalized, nil
+}
+
+// GetRecycleBinDeletion get the deletion of the object in the recycle bin
+func (pr *pendingDeletionRepo) GetRecycleBinDeletion(ctx context.Context, objectID string) (
+	pendingDeletion *entity.PendingDeletion, exist bool, err error) {
+	pendingDeletion = &entity.PendingDeletion{}
+	exist, err = pr.data.DB.Context(ctx).
+		Where("object_id = ?", objectID).
+		In("status", recycleBinStatus).
+		Desc("id").
+		Get(pendingDeletion)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetRecycleBinPage get the deletions in the recycle bin, the latest first
+func (pr *pendingDeletionRepo) GetRecycleBinPage(ctx context.Context, page, pageSize int, objectType string) (
+	pendingDeletions []*entity.PendingDeletion, total int64, err error) {
+	pendingDeletions = make([]*entity.PendingDeletion, 0)
+	session := pr.data.DB.Context(ctx).In("status", recycleBinStatus)
+	if len(objectType) > 0 {
+		session.And("object_type = ?", objectType)
+	}
+	session.Desc("id")
+	total, err = pager.Help(page, pageSize, &pendingDeletions, &entity.PendingDeletion{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// MarkObjectRestored the object is not deleted any more, so it leaves the recycle bin
+func (pr *pendingDeletionRepo) MarkObjectRestored(ctx context.Context, objectID string) (err error) {
+	_, err = pr.data.DB.Context(ctx).
+		Where("object_id = ?", objectID).
+		In("status", recycleBinStatus).
+		Cols("status").
+		Update(&entity.PendingDeletion{Status: entity.PendingDeletionStatusRestored})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDeletedObjects get the brief of the deleted objects, the key is object id
+func (pr *pendingDeletionRepo) GetDeletedObjects(ctx context.Context, questionIDs, answerIDs, commentIDs []string) (
+	objects map[string]*entity.DeletedObject, err error) {
+	objects = make(map[string]*entity.DeletedObject)
+	if len(questionIDs) > 0 {
+		questions := make([]*entity.Question, 0)
+		err = pr.data.DB.Context(ctx).Select("id, user_id, title, parsed_text").In("id", questionIDs).Find(&questions)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, question := range questions {
+			objects[question.ID] = &entity.DeletedObject{
+				ObjectID:   question.ID,
+				UserID:     question.UserID,
+				QuestionID: question.ID,
+				Title:      question.Title,
+				ParsedText: question.ParsedText,
+			}
+		}
+	}
+	if len(answerIDs) > 0 {
+		answers := make([]*entity.Answer, 0)
+		err = pr.data.DB.Context(ctx).Select("id, user_id, question_id, parsed_text").In("id", answerIDs).Find(&answers)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, answer := range answers {
+			objects[answer.ID] = &entity.DeletedObject{
+				ObjectID:   answer.ID,
+				UserID:     answer.UserID,
+				QuestionID: answer.QuestionID,
+				ParsedText: answer.ParsedText,
+			}
+		}
+	}
+	if len(commentIDs) > 0 {
+		comments := make([]*entity.Comment, 0)
+		err = pr.data.DB.Context(ctx).Select("id, user_id, question_id, object_id, parsed_text").
+			In("id", commentIDs).Find(&comments)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, comment := range comments {
+			objects[comment.ID] = &entity.DeletedObject{
+				ObjectID:   comment.ID,
+				UserID:     comment.UserID,
+				QuestionID: comment.QuestionID,
+				ParentID:   comment.ObjectID,
+				ParsedText: comment.ParsedText,
+			}
+		}
+	}
+
+	// the answers and comments show the title of the question
+	titleQuestionIDs := make([]string, 0)
+	for _, object := range objects {
+		if len(object.Title) == 0 && len(object.QuestionID) > 0 {
+			titleQuestionIDs = append(titleQuestionIDs, object.QuestionID)
+		}
+	}
+	if len(titleQuestionIDs) == 0 {
+		return objects, nil
+	}
+	questions := make([]*entity.Question, 0)
+	err = pr.data.DB.Context(ctx).Select("id, title").In("id", titleQuestionIDs).Find(&questions)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	titleMapping := make(map[string]string, len(questions))
+	for _, question := range questions {
+		titleMapping[question.ID] = question.Title
+	}
+	for _, object := range objects {
+		if len(object.Title) == 0 {
+			object.Title = titleMapping[object.QuestionID]
+		}
+	}
+	return objects, nil
+}
+
+// GetPurgeableDeletions get the finalized deletions which are created before the time, after the id
+func (pr *pendingDeletionRepo) GetPurgeableDeletions(ctx context.Context, before time.Time, afterID, limit int) (
+	pendingDeletions []*entity.PendingDeletion, err error) {
+	pendingDeletions = make([]*entity.PendingDeletion, 0)
+	err = pr.data.DB.Context(ctx).
+		Where("status = ?", entity.PendingDeletionStatusFinalized).
+		And("created_at < ?", before).
+		And("id > ?", afterID).
+		Asc("id").
+		Limit(limit).
+		Find(&pendingDeletions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// PurgeDeletedObject delete the object and the content belongs to it permanently, together with the activities,
+// votes, revisions and collections of them. If the object is not deleted any more, it is marked as restored instead.
+// The authors of the purged answers are returned to refresh their answer count.
+func (pr *pendingDeletionRepo) PurgeDeletedObject(ctx context.Context, pendingDeletion *entity.PendingDeletion) (
+	purged bool, answerUserIDs []string, err error) {
+	answerUserIDs = make([]string, 0)
+	_, err = pr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		objectID := pendingDeletion.ObjectID
+		var bean any
+		var deletedStatus int
+		switch pendingDeletion.ObjectType {
+		case constant.QuestionObjectType:
+			bean, deletedStatus = &entity.Question{}, entity.QuestionStatusDeleted
+		case constant.AnswerObjectType:
+			bean, deletedStatus = &entity.Answer{}, entity.AnswerStatusDeleted
+		case constant.CommentObjectType:
+			bean, deletedStatus = &entity.Comment{}, entity.CommentStatusDeleted
+		default:
+			return nil, nil
+		}
+		affected, err := session.Where("id = ? AND status = ?", objectID, deletedStatus).Delete(bean)
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			// the object which is gone already has been purged along with its question or answer
+			exist, err := session.ID(objectID).Exist(bean)
+			if err != nil {
+				return nil, err
+			}
+			status := entity.PendingDeletionStatusPurged
+			if exist {
+				status = entity.PendingDeletionStatusRestored
+			}
+			_, err = session.ID(pendingDeletion.ID).Cols("status").Update(&entity.PendingDeletion{Status: status})
+			return nil, err
+		}
+		purged = true
+
+		// the answers of the question and the comments of them are purged together
+		objectIDs := []string{objectID}
+		if pendingDeletion.ObjectType == constant.QuestionObjectType {
+			answers := make([]*entity.Answer, 0)
+			if err = session.Select("id, user_id").Where("question_id = ?", objectID).Find(&answers); err != nil {
+				return nil, err
+			}
+			for _, answer := range answers {
+				objectIDs = append(objectIDs, answer.ID)
+				answerUserIDs = append(answerUserIDs, answer.UserID)
+			}
+			if _, err = session.Where("question_id = ?", objectID).Delete(&entity.Answer{}); err != nil {
+				return nil, err
+			}
+			if _, err = session.Where("object_id = ?", objectID).Delete(&entity.TagRel{}); err != nil {
+				return nil, err
+			}
+		}
+		if pendingDeletion.ObjectType != constant.CommentObjectType {
+			commentIDs := make([]string, 0)
+			err = session.Table(&entity.Comment{}).Where(builder.In("object_id", objectIDs)).
+				Cols("id").Find(&commentIDs)
+			if err != nil {
+				return nil, err
+			}
+			if _, err = session.Where(builder.In("object_id", objectIDs)).Delete(&entity.Comment{}); err != nil {
+				return nil, err
+			}
+			objectIDs = append(objectIDs, commentIDs...)
+		}
+		if err = purgeObjectRelations(session, objectIDs); err != nil {
+			return nil, err
+		}
+
+		// the deletions of the answers and comments purged together leave the recycle bin as well
+		_, err = session.Where(builder.In("object_id", objectIDs)).In("status", recycleBinStatus).
+			Cols("status").Update(&entity.PendingDeletion{Status: entity.PendingDeletionStatusPurged})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.ID(pendingDeletion.ID).Cols("status").
+			Update(&entity.PendingDeletion{Status: entity.PendingDeletionStatusPurged})
+		return nil, err
+	})
+	if err != nil {
+		return false, nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return purged, answerUserIDs, nil
+}
+
+// purgeObjectRelations delete the activities including the votes, the revisions and the collections of the objects
+func purgeObjectRelations(session *xorm.Session, objectIDs []string) (err error) {
+	for _, bean := range []any{&entity.Activity{}, &entity.Revision{}, &entity.Collection{}} {
+		if _, err = session.Where(builder.In("object_id", objectIDs)).Delete(bean); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permalink
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/permalink"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// permalinkRepo permalink repository
+type permalinkRepo struct {
+	data *data.Data
+}
+
+// NewPermalinkRepo new repository
+func NewPermalinkRepo(data *data.Data) permalink.PermalinkRepo {
+	return &permalinkRepo{
+		data: data,
+	}
+}
+
+// IncrShareVisit increase the visit count of the object shared by the user
+func (pr *permalinkRepo) IncrShareVisit(ctx context.Context, objectID, userID string) (err error) {
+	affected, err := pr.data.DB.Context(ctx).Where("object_id = ? AND user_id = ?", objectID, userID).
+		Incr("visit_count").
+		Update(&entity.ShareReferral{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected > 0 {
+		return nil
+	}
+	_, err = pr.data.DB.Context(ctx).Insert(&entity.ShareReferral{
+		ObjectID:   objectID,
+		UserID:     userID,
+		VisitCount: 1,
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
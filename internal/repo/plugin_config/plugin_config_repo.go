@@ -58,6 +58,16 @@ func (ur *pluginConfigRepo) SavePluginConfig(ctx context.Context, pluginSlugName
 	return nil
 }
 
+func (ur *pluginConfigRepo) GetPluginConfig(ctx context.Context, pluginSlugName string) (
+	pluginConfig *entity.PluginConfig, exist bool, err error) {
+	pluginConfig = &entity.PluginConfig{PluginSlugName: pluginSlugName}
+	exist, err = ur.data.DB.Context(ctx).Get(pluginConfig)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return pluginConfig, exist, err
+}
+
 func (ur *pluginConfigRepo) GetPluginConfigAll(ctx context.Context) (pluginConfigs []*entity.PluginConfig, err error) {
 	pluginConfigs = make([]*entity.PluginConfig, 0)
 	err = ur.data.DB.Context(ctx).Find(&pluginConfigs)
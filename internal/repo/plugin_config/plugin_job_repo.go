@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_config
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type pluginJobRepo struct {
+	data *data.Data
+}
+
+// NewPluginJobRepo new repository
+func NewPluginJobRepo(data *data.Data) plugin_common.PluginJobRepo {
+	return &pluginJobRepo{
+		data: data,
+	}
+}
+
+func (pr *pluginJobRepo) GetPluginJob(ctx context.Context, pluginSlugName, jobName string) (
+	job *entity.PluginJob, exist bool, err error) {
+	job = &entity.PluginJob{}
+	exist, err = pr.data.DB.Context(ctx).Where("plugin_slug_name = ? AND job_name = ?", pluginSlugName, jobName).Get(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return job, exist, err
+}
+
+func (pr *pluginJobRepo) GetPluginJobList(ctx context.Context, pluginSlugName string) (
+	jobs []*entity.PluginJob, err error) {
+	jobs = make([]*entity.PluginJob, 0)
+	err = pr.data.DB.Context(ctx).Where("plugin_slug_name = ?", pluginSlugName).Find(&jobs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return jobs, err
+}
+
+func (pr *pluginJobRepo) UpdatePluginJobStatus(ctx context.Context, pluginSlugName, jobName string, disabled bool) (err error) {
+	old, exist, err := pr.GetPluginJob(ctx, pluginSlugName, jobName)
+	if err != nil {
+		return err
+	}
+	if exist {
+		old.Disabled = disabled
+		_, err = pr.data.DB.Context(ctx).ID(old.ID).Cols("disabled").Update(old)
+	} else {
+		_, err = pr.data.DB.Context(ctx).Insert(&entity.PluginJob{
+			PluginSlugName: pluginSlugName,
+			JobName:        jobName,
+			Disabled:       disabled,
+		})
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (pr *pluginJobRepo) UpdatePluginJobLastRun(ctx context.Context, job *entity.PluginJob) (err error) {
+	old, exist, err := pr.GetPluginJob(ctx, job.PluginSlugName, job.JobName)
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = pr.data.DB.Context(ctx).ID(old.ID).
+			Cols("last_run_at", "last_duration", "last_status", "last_error").Update(job)
+	} else {
+		_, err = pr.data.DB.Context(ctx).Insert(job)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_config
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type pluginKVStorageRepo struct {
+	data *data.Data
+}
+
+// NewPluginKVStorageRepo new repository
+func NewPluginKVStorageRepo(data *data.Data) plugin_common.PluginKVStorageRepo {
+	return &pluginKVStorageRepo{
+		data: data,
+	}
+}
+
+func (pr *pluginKVStorageRepo) GetValue(ctx context.Context, pluginSlugName, group, key string) (
+	value string, exist bool, err error) {
+	item := &entity.PluginKVStorage{}
+	exist, err = pr.data.DB.Context(ctx).
+		Where("plugin_slug_name = ? AND kv_group = ? AND kv_key = ?", pluginSlugName, group, key).Get(item)
+	if err != nil {
+		return "", false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return item.Value, exist, nil
+}
+
+func (pr *pluginKVStorageRepo) GetValuesByGroup(ctx context.Context, pluginSlugName, group string) (
+	values map[string]string, err error) {
+	items := make([]*entity.PluginKVStorage, 0)
+	err = pr.data.DB.Context(ctx).Where("plugin_slug_name = ? AND kv_group = ?", pluginSlugName, group).Find(&items)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	values = make(map[string]string, len(items))
+	for _, item := range items {
+		values[item.Key] = item.Value
+	}
+	return values, nil
+}
+
+func (pr *pluginKVStorageRepo) SetValue(ctx context.Context, pluginSlugName, group, key, value string) (err error) {
+	old := &entity.PluginKVStorage{}
+	exist, err := pr.data.DB.Context(ctx).
+		Where("plugin_slug_name = ? AND kv_group = ? AND kv_key = ?", pluginSlugName, group, key).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		old.Value = value
+		_, err = pr.data.DB.Context(ctx).ID(old.ID).Cols("value").Update(old)
+	} else {
+		_, err = pr.data.DB.Context(ctx).Insert(&entity.PluginKVStorage{
+			PluginSlugName: pluginSlugName,
+			Group:          group,
+			Key:            key,
+			Value:          value,
+		})
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (pr *pluginKVStorageRepo) DelValue(ctx context.Context, pluginSlugName, group, key string) (err error) {
+	session := pr.data.DB.Context(ctx).Where("plugin_slug_name = ? AND kv_group = ?", pluginSlugName, group)
+	if len(key) > 0 {
+		session.And("kv_key = ?", key)
+	}
+	_, err = session.Delete(&entity.PluginKVStorage{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (pr *pluginKVStorageRepo) GetTableVersion(ctx context.Context, pluginSlugName string) (version int, err error) {
+	item := &entity.PluginTableVersion{}
+	_, err = pr.data.DB.Context(ctx).Where("plugin_slug_name = ?", pluginSlugName).Get(item)
+	if err != nil {
+		return 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return item.Version, nil
+}
+
+func (pr *pluginKVStorageRepo) UpdateTableVersion(ctx context.Context, pluginSlugName string, version int) (err error) {
+	old := &entity.PluginTableVersion{}
+	exist, err := pr.data.DB.Context(ctx).Where("plugin_slug_name = ?", pluginSlugName).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		old.Version = version
+		_, err = pr.data.DB.Context(ctx).ID(old.ID).Cols("version").Update(old)
+	} else {
+		_, err = pr.data.DB.Context(ctx).Insert(&entity.PluginTableVersion{PluginSlugName: pluginSlugName, Version: version})
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package profile_field
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/profile_field"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// profileFieldRepo profile field repository
+type profileFieldRepo struct {
+	data *data.Data
+}
+
+// NewProfileFieldRepo new repository
+func NewProfileFieldRepo(data *data.Data) profile_field.ProfileFieldRepo {
+	return &profileFieldRepo{
+		data: data,
+	}
+}
+
+// AddProfileField add profile field
+func (pr *profileFieldRepo) AddProfileField(ctx context.Context, field *entity.ProfileField) (err error) {
+	_, err = pr.data.DB.Context(ctx).Insert(field)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateProfileField update profile field
+func (pr *profileFieldRepo) UpdateProfileField(ctx context.Context, field *entity.ProfileField) (err error) {
+	_, err = pr.data.DB.Context(ctx).ID(field.ID).
+		Cols("name", "label", "type", "options", "required", "visibility", "sort").Update(field)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveProfileField remove profile field and the values of all users
+func (pr *profileFieldRepo) RemoveProfileField(ctx context.Context, id int) (err error) {
+	_, err = pr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("field_id = ?", id).Delete(&entity.UserProfileField{}); err != nil {
+			return nil, err
+		}
+		return session.ID(id).Delete(&entity.ProfileField{})
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetProfileField get profile field by id
+func (pr *profileFieldRepo) GetProfileField(ctx context.Context, id int) (
+	field *entity.ProfileField, exist bool, err error) {
+	field = &entity.ProfileField{}
+	exist, err = pr.data.DB.Context(ctx).ID(id).Get(field)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetProfileFieldByName get profile field by name
+func (pr *profileFieldRepo) GetProfileFieldByName(ctx context.Context, name string) (
+	field *entity.ProfileField, exist bool, err error) {
+	field = &entity.ProfileField{}
+	exist, err = pr.data.DB.Context(ctx).Where("name = ?", name).Get(field)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetProfileFieldList get all profile fields order by sort
+func (pr *profileFieldRepo) GetProfileFieldList(ctx context.Context) (fields []*entity.ProfileField, err error) {
+	fields = make([]*entity.ProfileField, 0)
+	err = pr.data.DB.Context(ctx).Asc("sort", "id").Find(&fields)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserProfileFields get the profile field values of user
+func (pr *profileFieldRepo) GetUserProfileFields(ctx context.Context, userID string) (
+	values []*entity.UserProfileField, err error) {
+	values = make([]*entity.UserProfileField, 0)
+	err = pr.data.DB.Context(ctx).Where("user_id = ?", userID).Find(&values)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveUserProfileFields replace all the profile field values of user
+func (pr *profileFieldRepo) SaveUserProfileFields(ctx context.Context, userID string,
+	values []*entity.UserProfileField) (err error) {
+	_, err = pr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("user_id = ?", userID).Delete(&entity.UserProfileField{}); err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return session.Insert(values)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -23,32 +23,84 @@ import (
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/repo/activity"
 	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/ai_assistant"
+	"github.com/apache/incubator-answer/internal/repo/analytics"
+	"github.com/apache/incubator-answer/internal/repo/announcement"
 	"github.com/apache/incubator-answer/internal/repo/answer"
+	"github.com/apache/incubator-answer/internal/repo/answer_quality"
+	"github.com/apache/incubator-answer/internal/repo/answer_verification"
+	"github.com/apache/incubator-answer/internal/repo/article"
 	"github.com/apache/incubator-answer/internal/repo/auth"
+	"github.com/apache/incubator-answer/internal/repo/banned_word"
 	"github.com/apache/incubator-answer/internal/repo/captcha"
+	"github.com/apache/incubator-answer/internal/repo/co_author"
 	"github.com/apache/incubator-answer/internal/repo/collection"
 	"github.com/apache/incubator-answer/internal/repo/comment"
 	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/conversion"
+	"github.com/apache/incubator-answer/internal/repo/data_retention"
+	"github.com/apache/incubator-answer/internal/repo/dead_link"
+	"github.com/apache/incubator-answer/internal/repo/delta_sync"
+	"github.com/apache/incubator-answer/internal/repo/edit_lock"
+	"github.com/apache/incubator-answer/internal/repo/email_domain"
+	"github.com/apache/incubator-answer/internal/repo/embedding"
+	"github.com/apache/incubator-answer/internal/repo/event_stream"
+	"github.com/apache/incubator-answer/internal/repo/experiment"
 	"github.com/apache/incubator-answer/internal/repo/export"
+	"github.com/apache/incubator-answer/internal/repo/github_integration"
+	"github.com/apache/incubator-answer/internal/repo/inbound_question"
+	"github.com/apache/incubator-answer/internal/repo/invitation"
+	"github.com/apache/incubator-answer/internal/repo/ip_logging"
+	"github.com/apache/incubator-answer/internal/repo/jira_integration"
+	"github.com/apache/incubator-answer/internal/repo/leaderboard"
+	"github.com/apache/incubator-answer/internal/repo/legal_hold"
 	"github.com/apache/incubator-answer/internal/repo/limit"
+	"github.com/apache/incubator-answer/internal/repo/link_preview"
+	"github.com/apache/incubator-answer/internal/repo/list_index"
+	"github.com/apache/incubator-answer/internal/repo/login_protection"
 	"github.com/apache/incubator-answer/internal/repo/meta"
+	"github.com/apache/incubator-answer/internal/repo/moderation_rule"
+	"github.com/apache/incubator-answer/internal/repo/moderation_triage"
+	"github.com/apache/incubator-answer/internal/repo/new_contributor"
 	"github.com/apache/incubator-answer/internal/repo/notification"
+	"github.com/apache/incubator-answer/internal/repo/page"
+	"github.com/apache/incubator-answer/internal/repo/pending_deletion"
+	"github.com/apache/incubator-answer/internal/repo/permalink"
 	"github.com/apache/incubator-answer/internal/repo/plugin_config"
+	"github.com/apache/incubator-answer/internal/repo/profile_field"
 	"github.com/apache/incubator-answer/internal/repo/question"
+	"github.com/apache/incubator-answer/internal/repo/question_assignment"
+	"github.com/apache/incubator-answer/internal/repo/question_merge"
+	"github.com/apache/incubator-answer/internal/repo/question_poll"
+	"github.com/apache/incubator-answer/internal/repo/question_share"
+	"github.com/apache/incubator-answer/internal/repo/question_sla"
+	"github.com/apache/incubator-answer/internal/repo/question_summary"
+	"github.com/apache/incubator-answer/internal/repo/question_workflow"
 	"github.com/apache/incubator-answer/internal/repo/rank"
 	"github.com/apache/incubator-answer/internal/repo/reason"
 	"github.com/apache/incubator-answer/internal/repo/report"
 	"github.com/apache/incubator-answer/internal/repo/review"
+	"github.com/apache/incubator-answer/internal/repo/review_reminder"
 	"github.com/apache/incubator-answer/internal/repo/revision"
+	"github.com/apache/incubator-answer/internal/repo/revision_compaction"
 	"github.com/apache/incubator-answer/internal/repo/role"
+	"github.com/apache/incubator-answer/internal/repo/scheduler"
 	"github.com/apache/incubator-answer/internal/repo/search_common"
+	"github.com/apache/incubator-answer/internal/repo/search_log"
 	"github.com/apache/incubator-answer/internal/repo/site_info"
+	"github.com/apache/incubator-answer/internal/repo/sitemap"
+	"github.com/apache/incubator-answer/internal/repo/slow_query"
 	"github.com/apache/incubator-answer/internal/repo/tag"
+	"github.com/apache/incubator-answer/internal/repo/tag_auto_subscription"
 	"github.com/apache/incubator-answer/internal/repo/tag_common"
 	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/repo/upload_access"
 	"github.com/apache/incubator-answer/internal/repo/user"
 	"github.com/apache/incubator-answer/internal/repo/user_external_login"
+	"github.com/apache/incubator-answer/internal/repo/user_follow"
 	"github.com/apache/incubator-answer/internal/repo/user_notification_config"
+	"github.com/apache/incubator-answer/internal/repo/user_onboarding"
+	"github.com/apache/incubator-answer/internal/repo/username_policy"
 	"github.com/google/wire"
 )
 
@@ -80,13 +132,19 @@ var ProviderSetRepo = wire.NewSet(
 	tag.NewTagRepo,
 	tag_common.NewTagCommonRepo,
 	tag.NewTagRelRepo,
+	tag.NewTagStatsRepo,
+	tag.NewUserTagScoreRepo,
+	user.NewUserStatsRepo,
 	collection.NewCollectionRepo,
 	collection.NewCollectionGroupRepo,
 	auth.NewAuthRepo,
+	auth.NewUserSessionRepo,
 	revision.NewRevisionRepo,
 	search_common.NewSearchRepo,
 	meta.NewMetaRepo,
 	export.NewEmailRepo,
+	export.NewEmailDeliveryRepo,
+	export.NewEmailTemplateRepo,
 	reason.NewReasonRepo,
 	site_info.NewSiteInfo,
 	notification.NewNotificationRepo,
@@ -99,5 +157,60 @@ var ProviderSetRepo = wire.NewSet(
 	user_notification_config.NewUserNotificationConfigRepo,
 	limit.NewRateLimitRepo,
 	plugin_config.NewPluginUserConfigRepo,
+	plugin_config.NewPluginJobRepo,
+	plugin_config.NewPluginKVStorageRepo,
 	review.NewReviewRepo,
+	analytics.NewAnalyticsRepo,
+	search_log.NewSearchLogRepo,
+	sitemap.NewSitemapRepo,
+	permalink.NewPermalinkRepo,
+	page.NewPageRepo,
+	announcement.NewAnnouncementRepo,
+	profile_field.NewProfileFieldRepo,
+	user_follow.NewUserFollowRepo,
+	invitation.NewInvitationRepo,
+	email_domain.NewBlockedSignupRepo,
+	question_assignment.NewQuestionAssignmentRepo,
+	question_sla.NewQuestionSLARepo,
+	article.NewArticleRepo,
+	question_poll.NewQuestionPollRepo,
+	co_author.NewCoAuthorRepo,
+	leaderboard.NewLeaderboardRepo,
+	list_index.NewListIndexRepo,
+	slow_query.NewSlowQueryRepo,
+	banned_word.NewBannedWordHitRepo,
+	moderation_rule.NewModerationRuleHitRepo,
+	question_workflow.NewQuestionWorkflowRepo,
+	answer_verification.NewAnswerVerificationRepo,
+	tag_auto_subscription.NewTagAutoSubscriptionRepo,
+	moderation_triage.NewModerationTriageRepo,
+	legal_hold.NewLegalHoldRepo,
+	ip_logging.NewIPLoggingRepo,
+	edit_lock.NewEditLockRepo,
+	username_policy.NewUsernameHistoryRepo,
+	username_policy.NewUserNameChangeRepo,
+	pending_deletion.NewPendingDeletionRepo,
+	data_retention.NewDataRetentionRepo,
+	scheduler.NewSchedulerRepo,
+	question_share.NewQuestionShareRepo,
+	link_preview.NewLinkPreviewRepo,
+	dead_link.NewDeadLinkRepo,
+	ai_assistant.NewAIAssistantRepo,
+	question_summary.NewQuestionSummaryRepo,
+	embedding.NewEmbeddingRepo,
+	answer_quality.NewAnswerQualityRepo,
+	review_reminder.NewReviewReminderRepo,
+	user_onboarding.NewUserOnboardingRepo,
+	experiment.NewExperimentRepo,
+	event_stream.NewEventOutboxRepo,
+	inbound_question.NewInboundQuestionRepo,
+	github_integration.NewGitHubCrossReferenceRepo,
+	jira_integration.NewJiraIssueLinkRepo,
+	new_contributor.NewNewContributorRepo,
+	question_merge.NewQuestionMergeRepo,
+	conversion.NewConversionRepo,
+	revision_compaction.NewRevisionCompactionRepo,
+	login_protection.NewLoginProtectionRepo,
+	upload_access.NewUploadAccessRepo,
+	delta_sync.NewDeltaSyncRepo,
 )
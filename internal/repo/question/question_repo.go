@@ -21,7 +21,6 @@ package question
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -36,7 +35,6 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/unique"
-	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/errors"
@@ -229,6 +227,7 @@ func (qr *questionRepo) GetQuestionsByTitle(ctx context.Context, title string, p
 	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
 	session.Where("status != ?", entity.QuestionStatusDeleted)
+	session.Where("question.show != ?", entity.QuestionUnlisted)
 	session.Where("title like ?", "%"+title+"%")
 	session.Limit(pageSize)
 	err = session.Find(&questionList)
@@ -294,61 +293,40 @@ func (qr *questionRepo) GetUserQuestionCount(ctx context.Context, userID string,
 	return
 }
 
-func (qr *questionRepo) SitemapQuestions(ctx context.Context, page, pageSize int) (
-	questionIDList []*schema.SiteMapQuestionInfo, err error) {
-	page = page - 1
-	questionIDList = make([]*schema.SiteMapQuestionInfo, 0)
-
-	// try to get sitemap data from cache
-	cacheKey := fmt.Sprintf(constant.SiteMapQuestionCacheKeyPrefix, page)
-	cacheData, exist, err := qr.data.Cache.GetString(ctx, cacheKey)
-	if err == nil && exist {
-		_ = json.Unmarshal([]byte(cacheData), &questionIDList)
-		return questionIDList, nil
+// GetQuestionPage query question page, the quality score is blended into the hot score by the percent of qualityBlend.
+// The public lists are read from the precomputed question list index when it is built.
+func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
+	tagIDs []string, userID, orderCond string, inDays, qualityBlend int, showHidden, showPending bool,
+	workflowStates []string) (questionList []*entity.Question, total int64, err error) {
+	if len(userID) == 0 && !showPending && len(workflowStates) == 0 {
+		questionList, total, ok, err := qr.getQuestionPageFromIndex(ctx, page, pageSize,
+			tagIDs, orderCond, inDays, qualityBlend)
+		if err != nil || ok {
+			return questionList, total, err
+		}
 	}
 
-	// get sitemap data from db
-	rows := make([]*entity.Question, 0)
+	questionList = make([]*entity.Question, 0)
 	session := qr.data.DB.Context(ctx)
-	session.Select("id,title,created_at,post_update_time")
-	session.Where("`show` = ?", entity.QuestionShow)
-	session.Where("status = ? OR status = ?", entity.QuestionStatusAvailable, entity.QuestionStatusClosed)
-	session.Limit(pageSize, page*pageSize)
-	session.Asc("created_at")
-	err = session.Find(&rows)
+	qr.buildQuestionPageCond(session, tagIDs, userID, orderCond, inDays, qualityBlend, showHidden, showPending)
+	if len(workflowStates) > 0 {
+		session.In("question.workflow_state", workflowStates)
+	}
+	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
 	if err != nil {
-		return questionIDList, err
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
-
-	// warp data
-	for _, question := range rows {
-		item := &schema.SiteMapQuestionInfo{ID: question.ID}
-		if handler.GetEnableShortID(ctx) {
-			item.ID = uid.EnShortID(question.ID)
-		}
-		item.Title = htmltext.UrlTitle(question.Title)
-		if question.PostUpdateTime.IsZero() {
-			item.UpdateTime = question.CreatedAt.Format(time.RFC3339)
-		} else {
-			item.UpdateTime = question.PostUpdateTime.Format(time.RFC3339)
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
 		}
-		questionIDList = append(questionIDList, item)
-	}
-
-	// set sitemap data to cache
-	cacheDataByte, _ := json.Marshal(questionIDList)
-	if err := qr.data.Cache.SetString(ctx, cacheKey, string(cacheDataByte), constant.SiteMapQuestionCacheTime); err != nil {
-		log.Error(err)
 	}
-	return questionIDList, nil
+	return questionList, total, err
 }
 
-// GetQuestionPage query question page
-func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
-	tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
-	questionList []*entity.Question, total int64, err error) {
-	questionList = make([]*entity.Question, 0)
-	session := qr.data.DB.Context(ctx)
+// buildQuestionPageCond build the conditions and the order of the question page
+func (qr *questionRepo) buildQuestionPageCond(session *xorm.Session, tagIDs []string, userID, orderCond string,
+	inDays, qualityBlend int, showHidden, showPending bool) {
 	status := []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}
 	if showPending {
 		status = append(status, entity.QuestionStatusPending)
@@ -381,24 +359,19 @@ func (qr *questionRepo) GetQuestionPage(ctx context.Context, page, pageSize int,
 		session.And("question.post_update_time > ?", time.Now().AddDate(0, 0, -90))
 		session.OrderBy("question.pin desc,question.post_update_time DESC, question.updated_at DESC")
 	case "hot":
-		session.OrderBy("question.pin desc,question.hot_score DESC")
+		if qualityBlend > 0 {
+			// the hot score is boosted by up to qualityBlend percent for the question of full quality score
+			session.OrderBy(fmt.Sprintf("question.pin desc,question.hot_score * (1 + %.4f * question.quality_score) DESC",
+				float64(qualityBlend)/10000))
+		} else {
+			session.OrderBy("question.pin desc,question.hot_score DESC")
+		}
 	case "score":
 		session.OrderBy("question.pin desc,question.vote_count DESC, question.view_count DESC")
 	case "unanswered":
 		session.Where("question.last_answer_id = 0")
 		session.OrderBy("question.pin desc,question.created_at DESC")
 	}
-
-	total, err = pager.Help(page, pageSize, &questionList, &entity.Question{}, session)
-	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
-	}
-	if handler.GetEnableShortID(ctx) {
-		for _, item := range questionList {
-			item.ID = uid.EnShortID(item.ID)
-		}
-	}
-	return questionList, total, err
 }
 
 func (qr *questionRepo) AdminQuestionPage(ctx context.Context, search *schema.AdminQuestionPageReq) ([]*entity.Question, int64, error) {
@@ -556,3 +529,145 @@ func (qr *questionRepo) RemoveAllUserQuestion(ctx context.Context, userID string
 	}
 	return nil
 }
+
+// getQuestionPageFromIndex read the public question page from the precomputed question list index.
+// It is not ok when the page is out of the index or the list is not built yet, the missing list is added
+// to be built by the refresh job. The questions removed since the last building are skipped.
+func (qr *questionRepo) getQuestionPageFromIndex(ctx context.Context, page, pageSize int,
+	tagIDs []string, orderCond string, inDays, qualityBlend int) (
+	questionList []*entity.Question, total int64, ok bool, err error) {
+	if !entity.QuestionListSortOrders[orderCond] {
+		return nil, 0, false, nil
+	}
+	page, pageSize = pager.ValPageAndPageSize(page, pageSize)
+	if page*pageSize > entity.QuestionListIndexSize {
+		return nil, 0, false, nil
+	}
+
+	partition := &entity.QuestionListPartition{}
+	listKey := entity.QuestionListKey(tagIDs)
+	exist, err := qr.data.DB.Context(ctx).
+		Where("list_key = ? AND sort_order = ? AND in_days = ? AND quality_blend = ?",
+			listKey, orderCond, inDays, qualityBlend).
+		Get(partition)
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if !exist {
+		_, err = qr.data.DB.Context(ctx).Insert(&entity.QuestionListPartition{
+			ListKey:      listKey,
+			SortOrder:    orderCond,
+			InDays:       inDays,
+			QualityBlend: qualityBlend,
+			Dirty:        true,
+			LastReadAt:   time.Now(),
+		})
+		if err != nil {
+			// the list may be added by the concurrent request
+			log.Debugf("add question list partition failed: %s", err)
+		}
+		return nil, 0, false, nil
+	}
+	if partition.RefreshedAt.IsZero() {
+		return nil, 0, false, nil
+	}
+	if time.Since(partition.LastReadAt) > time.Hour {
+		_, err = qr.data.DB.Context(ctx).ID(partition.ID).Cols("last_read_at").
+			Update(&entity.QuestionListPartition{LastReadAt: time.Now()})
+		if err != nil {
+			log.Errorf("update question list partition read time failed: %s", err)
+		}
+	}
+
+	start := (page - 1) * pageSize
+	indexes := make([]*entity.QuestionListIndex, 0)
+	err = qr.data.DB.Context(ctx).
+		Where("partition_id = ? AND position > ? AND position <= ?", partition.ID, start, start+pageSize).
+		Asc("position").
+		Find(&indexes)
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	questionIDs := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		questionIDs = append(questionIDs, index.QuestionID)
+	}
+
+	questionList = make([]*entity.Question, 0, len(questionIDs))
+	if len(questionIDs) > 0 {
+		questions := make([]*entity.Question, 0, len(questionIDs))
+		err = qr.data.DB.Context(ctx).In("id", questionIDs).
+			In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+			And("question.show = ?", entity.QuestionShow).
+			Find(&questions)
+		if err != nil {
+			return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		questionMapping := make(map[string]*entity.Question, len(questions))
+		for _, question := range questions {
+			questionMapping[question.ID] = question
+		}
+		for _, questionID := range questionIDs {
+			if question, ok := questionMapping[questionID]; ok {
+				questionList = append(questionList, question)
+			}
+		}
+	}
+	if handler.GetEnableShortID(ctx) {
+		for _, item := range questionList {
+			item.ID = uid.EnShortID(item.ID)
+		}
+	}
+	return questionList, partition.Total, true, nil
+}
+
+// RefreshQuestionListIndex rebuild the precomputed question list of the partition with the top questions
+func (qr *questionRepo) RefreshQuestionListIndex(ctx context.Context, partition *entity.QuestionListPartition) (err error) {
+	// clear the dirty flag before the query, so the changes during the building mark the list dirty again
+	_, err = qr.data.DB.Context(ctx).ID(partition.ID).Cols("dirty").
+		Update(&entity.QuestionListPartition{Dirty: false})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	questionList := make([]*entity.Question, 0)
+	session := qr.data.DB.Context(ctx).Cols("question.id")
+	qr.buildQuestionPageCond(session, entity.QuestionListTagIDs(partition.ListKey), "", partition.SortOrder,
+		partition.InDays, partition.QualityBlend, false, false)
+	total, err := session.Limit(entity.QuestionListIndexSize).FindAndCount(&questionList, &entity.Question{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	indexes := make([]*entity.QuestionListIndex, 0, len(questionList))
+	for i, question := range questionList {
+		indexes = append(indexes, &entity.QuestionListIndex{
+			PartitionID: partition.ID,
+			Position:    i + 1,
+			QuestionID:  question.ID,
+		})
+	}
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		_, err = session.Where("partition_id = ?", partition.ID).Delete(&entity.QuestionListIndex{})
+		if err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(indexes); start += 200 {
+			end := start + 200
+			if end > len(indexes) {
+				end = len(indexes)
+			}
+			if _, err = session.Insert(indexes[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.ID(partition.ID).Cols("total", "refreshed_at").
+			Update(&entity.QuestionListPartition{Total: total, RefreshedAt: time.Now()})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
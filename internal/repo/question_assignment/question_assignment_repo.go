@@ -0,0 +1,159 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_assignment"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// questionAssignmentRepo question assignment repository
+type questionAssignmentRepo struct {
+	data *data.Data
+}
+
+// NewQuestionAssignmentRepo new repository
+func NewQuestionAssignmentRepo(data *data.Data) question_assignment.QuestionAssignmentRepo {
+	return &questionAssignmentRepo{
+		data: data,
+	}
+}
+
+// AddQuestionAssignment add question assignment
+func (qr *questionAssignmentRepo) AddQuestionAssignment(ctx context.Context, assignment *entity.QuestionAssignment) (err error) {
+	assignment.QuestionID = uid.DeShortID(assignment.QuestionID)
+	_, err = qr.data.DB.Context(ctx).Insert(assignment)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateQuestionAssignment update question assignment
+func (qr *questionAssignmentRepo) UpdateQuestionAssignment(ctx context.Context,
+	assignment *entity.QuestionAssignment, cols ...string) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(assignment.ID).Cols(cols...).Update(assignment)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionAssignment get the assignment of the question to the user
+func (qr *questionAssignmentRepo) GetQuestionAssignment(ctx context.Context, questionID, userID string) (
+	assignment *entity.QuestionAssignment, exist bool, err error) {
+	assignment = &entity.QuestionAssignment{}
+	exist, err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND user_id = ?", uid.DeShortID(questionID), userID).Get(assignment)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionAssignments get all assignments of the question except the cancelled ones
+func (qr *questionAssignmentRepo) GetQuestionAssignments(ctx context.Context, questionID string) (
+	assignments []*entity.QuestionAssignment, err error) {
+	assignments = make([]*entity.QuestionAssignment, 0)
+	err = qr.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).
+		And("status <> ?", entity.QuestionAssignmentStatusCancelled).Asc("id").Find(&assignments)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserQuestionAssignmentPage get the assignments of the user, the pending ones with the earliest deadline first.
+// If status is 0, get all assignments except the cancelled ones.
+func (qr *questionAssignmentRepo) GetUserQuestionAssignmentPage(ctx context.Context, page, pageSize int,
+	userID string, status int) (assignments []*entity.QuestionAssignment, total int64, err error) {
+	assignments = make([]*entity.QuestionAssignment, 0)
+	session := qr.data.DB.Context(ctx).Where("user_id = ?", userID)
+	if status > 0 {
+		session.And("status = ?", status)
+	} else {
+		session.And("status <> ?", entity.QuestionAssignmentStatusCancelled)
+	}
+	session.Asc("status").Asc("due_at").Desc("id")
+	total, err = pager.Help(page, pageSize, &assignments, &entity.QuestionAssignment{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// MarkQuestionAssignmentAnswered mark the pending assignment of the question to the user as answered
+func (qr *questionAssignmentRepo) MarkQuestionAssignmentAnswered(ctx context.Context, questionID, userID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).
+		Where("question_id = ? AND user_id = ?", uid.DeShortID(questionID), userID).
+		And("status = ?", entity.QuestionAssignmentStatusPending).
+		Cols("status", "answered_at").
+		Update(&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusAnswered, AnsweredAt: time.Now()})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CancelQuestionAssignments cancel all pending assignments of the question
+func (qr *questionAssignmentRepo) CancelQuestionAssignments(ctx context.Context, questionID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).
+		And("status = ?", entity.QuestionAssignmentStatusPending).
+		Cols("status").
+		Update(&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusCancelled})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionExperts get the users with the highest expertise scores in the tags of the question,
+// the score of the user is summed over all the tags of the question. The users who have already
+// answered the question are not experts to be assigned.
+func (qr *questionAssignmentRepo) GetQuestionExperts(ctx context.Context, questionID string, limit int) (
+	experts []*entity.QuestionExpertScore, err error) {
+	experts = make([]*entity.QuestionExpertScore, 0)
+	questionID = uid.DeShortID(questionID)
+	tagIDs := builder.Select("tag_id").From(entity.TagRel{}.TableName()).
+		Where(builder.Eq{"object_id": questionID, "status": entity.TagRelStatusAvailable})
+	answererIDs := builder.Select("user_id").From(entity.Answer{}.TableName()).
+		Where(builder.Eq{"question_id": questionID, "status": entity.AnswerStatusAvailable})
+	err = qr.data.DB.Context(ctx).Table(entity.UserTagScore{}.TableName()).
+		Select("user_id, SUM(score) AS score").
+		Where(builder.In("tag_id", tagIDs)).
+		And(builder.NotIn("user_id", answererIDs)).
+		GroupBy("user_id").
+		OrderBy("score DESC").
+		Limit(limit).
+		Find(&experts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,233 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_merge
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/question_merge"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// questionMergeRepo question merge repository
+type questionMergeRepo struct {
+	data         *data.Data
+	activityRepo activity_common.ActivityRepo
+}
+
+// NewQuestionMergeRepo new repository
+func NewQuestionMergeRepo(data *data.Data, activityRepo activity_common.ActivityRepo) question_merge.QuestionMergeRepo {
+	return &questionMergeRepo{
+		data:         data,
+		activityRepo: activityRepo,
+	}
+}
+
+// mergeActivityTypes the activity types of the question which are moved while merging
+type mergeActivityTypes struct {
+	voteUp, voteDown, votedUp, votedDown, follow int
+}
+
+// MergeQuestion move the answers, comments, votes and followers of the duplicate question to the canonical question,
+// the duplicate question is closed and hidden, and left as a redirect stub.
+func (qr *questionMergeRepo) MergeQuestion(ctx context.Context, fromQuestionID, toQuestionID string) (err error) {
+	types, err := qr.getActivityTypes(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if err := qr.moveAnswers(session, fromQuestionID, toQuestionID); err != nil {
+			return nil, err
+		}
+		if err := qr.moveComments(session, fromQuestionID, toQuestionID); err != nil {
+			return nil, err
+		}
+		if err := qr.moveActivities(session, fromQuestionID, toQuestionID,
+			[]int{types.voteUp, types.voteDown}, []int{types.votedUp, types.votedDown}); err != nil {
+			return nil, err
+		}
+		if err := qr.moveActivities(session, fromQuestionID, toQuestionID, []int{types.follow}, nil); err != nil {
+			return nil, err
+		}
+		for _, questionID := range []string{fromQuestionID, toQuestionID} {
+			if err := qr.recountQuestion(session, questionID, types); err != nil {
+				return nil, err
+			}
+		}
+		_, err := session.ID(fromQuestionID).Cols("status", "show", "accepted_answer_id").Update(&entity.Question{
+			Status:           entity.QuestionStatusClosed,
+			Show:             entity.QuestionHide,
+			AcceptedAnswerID: "0",
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(&entity.Meta{
+			ObjectID: fromQuestionID,
+			Key:      entity.QuestionMergedIntoKey,
+			Value:    toQuestionID,
+		})
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+func (qr *questionMergeRepo) getActivityTypes(ctx context.Context) (types *mergeActivityTypes, err error) {
+	types = &mergeActivityTypes{}
+	for key, activityType := range map[string]*int{
+		activity_type.QuestionVoteUp:    &types.voteUp,
+		activity_type.QuestionVoteDown:  &types.voteDown,
+		activity_type.QuestionVotedUp:   &types.votedUp,
+		activity_type.QuestionVotedDown: &types.votedDown,
+	} {
+		if *activityType, err = qr.activityRepo.GetActivityTypeByConfigKey(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	types.follow, err = qr.activityRepo.GetActivityTypeByObjectType(ctx, constant.QuestionObjectType, constant.ActFollow)
+	if err != nil {
+		return nil, err
+	}
+	return types, nil
+}
+
+// moveAnswers move the answers, the accepted answers of the duplicate question are kept
+// only when the canonical question has not accepted any answer.
+func (qr *questionMergeRepo) moveAnswers(session *xorm.Session, fromQuestionID, toQuestionID string) (err error) {
+	toQuestion := &entity.Question{}
+	if _, err = session.ID(toQuestionID).Get(toQuestion); err != nil {
+		return err
+	}
+	fromQuestion := &entity.Question{}
+	if _, err = session.ID(fromQuestionID).Get(fromQuestion); err != nil {
+		return err
+	}
+	if toQuestion.AcceptedAnswerID != "0" {
+		_, err = session.Where("question_id = ?", fromQuestionID).Cols("adopted").
+			Update(&entity.Answer{Accepted: schema.AnswerAcceptedFailed})
+	} else {
+		_, err = session.ID(toQuestionID).Cols("accepted_answer_id").
+			Update(&entity.Question{AcceptedAnswerID: fromQuestion.AcceptedAnswerID})
+	}
+	if err != nil {
+		return err
+	}
+	_, err = session.Where("question_id = ?", fromQuestionID).Cols("question_id").
+		Update(&entity.Answer{QuestionID: toQuestionID})
+	return err
+}
+
+// moveComments move the comments of the question itself and update the question of the comments of the answers
+func (qr *questionMergeRepo) moveComments(session *xorm.Session, fromQuestionID, toQuestionID string) (err error) {
+	_, err = session.Where("object_id = ?", fromQuestionID).Cols("object_id").
+		Update(&entity.Comment{ObjectID: toQuestionID})
+	if err != nil {
+		return err
+	}
+	_, err = session.Where("question_id = ?", fromQuestionID).Cols("question_id").
+		Update(&entity.Comment{QuestionID: toQuestionID})
+	return err
+}
+
+// moveActivities move the available activities of the users, the user_id of the userTypes and the trigger_user_id
+// of the triggerTypes identify the user. If the user already has the activity of these types on the canonical
+// question, the activities are left on the duplicate question so that they are not counted twice.
+func (qr *questionMergeRepo) moveActivities(session *xorm.Session, fromQuestionID, toQuestionID string,
+	userTypes, triggerTypes []int) (err error) {
+	existUserIDs := make([]string, 0)
+	err = session.Table(entity.Activity{}.TableName()).
+		Where(builder.Eq{"object_id": toQuestionID}.And(builder.In("activity_type", userTypes))).
+		Distinct("user_id").Find(&existUserIDs)
+	if err != nil {
+		return err
+	}
+	moved := map[string]interface{}{"object_id": toQuestionID, "original_object_id": toQuestionID}
+	fromCond := builder.Eq{"object_id": fromQuestionID, "cancelled": entity.ActivityAvailable}
+	_, err = session.Table(entity.Activity{}.TableName()).
+		Where(fromCond.And(builder.In("activity_type", userTypes), builder.NotIn("user_id", existUserIDs))).
+		Update(moved)
+	if err != nil || len(triggerTypes) == 0 {
+		return err
+	}
+	_, err = session.Table(entity.Activity{}.TableName()).
+		Where(fromCond.And(builder.In("activity_type", triggerTypes), builder.NotIn("trigger_user_id", existUserIDs))).
+		Update(moved)
+	return err
+}
+
+// recountQuestion recalculate the answer, vote and follow count and the last answer of the question
+func (qr *questionMergeRepo) recountQuestion(session *xorm.Session, questionID string,
+	types *mergeActivityTypes) (err error) {
+	question := &entity.Question{LastAnswerID: "0"}
+	answerCount, err := session.Where("question_id = ? AND status = ?", questionID, entity.AnswerStatusAvailable).
+		Count(&entity.Answer{})
+	if err != nil {
+		return err
+	}
+	question.AnswerCount = int(answerCount)
+	lastAnswer := &entity.Answer{}
+	exist, err := session.Where("question_id = ? AND status = ?", questionID, entity.AnswerStatusAvailable).
+		Desc("created_at").Get(lastAnswer)
+	if err != nil {
+		return err
+	}
+	if exist {
+		question.LastAnswerID = lastAnswer.ID
+	}
+
+	countActivity := func(activityType int) (int64, error) {
+		return session.Where(builder.Eq{
+			"object_id":     questionID,
+			"activity_type": activityType,
+			"cancelled":     entity.ActivityAvailable,
+		}).Count(&entity.Activity{})
+	}
+	voteUp, err := countActivity(types.voteUp)
+	if err != nil {
+		return err
+	}
+	voteDown, err := countActivity(types.voteDown)
+	if err != nil {
+		return err
+	}
+	question.VoteCount = int(voteUp - voteDown)
+	followCount, err := countActivity(types.follow)
+	if err != nil {
+		return err
+	}
+	question.FollowCount = int(followCount)
+
+	_, err = session.ID(questionID).Cols("answer_count", "last_answer_id", "vote_count", "follow_count").
+		Update(question)
+	return err
+}
@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package question_poll
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_poll"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// questionPollRepo question poll repository
+type questionPollRepo struct {
+	data *data.Data
+}
+
+// NewQuestionPollRepo new repository
+func NewQuestionPollRepo(data *data.Data) question_poll.QuestionPollRepo {
+	return &questionPollRepo{
+		data: data,
+	}
+}
+
+// AddQuestionPoll add question poll with its options
+func (qr *questionPollRepo) AddQuestionPoll(ctx context.Context, poll *entity.QuestionPoll,
+	options []*entity.QuestionPollOption) (err error) {
+	poll.QuestionID = uid.DeShortID(poll.QuestionID)
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Insert(poll); err != nil {
+			return nil, err
+		}
+		for _, option := range options {
+			option.PollID = poll.ID
+		}
+		if _, err := session.Insert(options); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveQuestionPoll remove question poll with its options and votes
+func (qr *questionPollRepo) RemoveQuestionPoll(ctx context.Context, pollID int) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.Where("poll_id = ?", pollID).Delete(&entity.QuestionPollVote{}); err != nil {
+			return nil, err
+		}
+		if _, err := session.Where("poll_id = ?", pollID).Delete(&entity.QuestionPollOption{}); err != nil {
+			return nil, err
+		}
+		if _, err := session.ID(pollID).Delete(&entity.QuestionPoll{}); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionPoll get the poll of the question
+func (qr *questionPollRepo) GetQuestionPoll(ctx context.Context, questionID string) (
+	poll *entity.QuestionPoll, exist bool, err error) {
+	poll = &entity.QuestionPoll{}
+	exist, err = qr.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).Get(poll)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionPollOptions get the options of the poll
+func (qr *questionPollRepo) GetQuestionPollOptions(ctx context.Context, pollID int) (
+	options []*entity.QuestionPollOption, err error) {
+	options = make([]*entity.QuestionPollOption, 0)
+	err = qr.data.DB.Context(ctx).Where("poll_id = ?", pollID).Asc("sort").Find(&options)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionPollOptionCounts get the vote count of each option of the poll
+func (qr *questionPollRepo) GetQuestionPollOptionCounts(ctx context.Context, pollID int) (
+	counts []*entity.QuestionPollOptionCount, err error) {
+	counts = make([]*entity.QuestionPollOptionCount, 0)
+	err = qr.data.DB.Context(ctx).Table(entity.QuestionPollVote{}.TableName()).
+		Select("option_id, COUNT(*) AS count").
+		Where("poll_id = ?", pollID).
+		GroupBy("option_id").
+		Find(&counts)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountQuestionPollVoters count the users who voted the poll
+func (qr *questionPollRepo) CountQuestionPollVoters(ctx context.Context, pollID int) (count int64, err error) {
+	count, err = qr.data.DB.Context(ctx).Where("poll_id = ?", pollID).
+		Distinct("user_id").Count(&entity.QuestionPollVote{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserQuestionPollVotes get the options of the poll chosen by the user
+func (qr *questionPollRepo) GetUserQuestionPollVotes(ctx context.Context, pollID int, userID string) (
+	optionIDs []int, err error) {
+	votes := make([]*entity.QuestionPollVote, 0)
+	err = qr.data.DB.Context(ctx).Where("poll_id = ? AND user_id = ?", pollID, userID).Find(&votes)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	optionIDs = make([]int, 0, len(votes))
+	for _, vote := range votes {
+		optionIDs = append(optionIDs, vote.OptionID)
+	}
+	return optionIDs, nil
+}
+
+// SetUserQuestionPollVotes replace the options of the poll chosen by the user
+func (qr *questionPollRepo) SetUserQuestionPollVotes(ctx context.Context, pollID int, userID string,
+	optionIDs []int) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		_, err := session.Where("poll_id = ? AND user_id = ?", pollID, userID).Delete(&entity.QuestionPollVote{})
+		if err != nil {
+			return nil, err
+		}
+		votes := make([]*entity.QuestionPollVote, 0, len(optionIDs))
+		for _, optionID := range optionIDs {
+			votes = append(votes, &entity.QuestionPollVote{PollID: pollID, OptionID: optionID, UserID: userID})
+		}
+		if _, err = session.Insert(votes); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_share
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_share"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionShareRepo question share link repository
+type questionShareRepo struct {
+	data *data.Data
+}
+
+// NewQuestionShareRepo new repository
+func NewQuestionShareRepo(data *data.Data) question_share.QuestionShareRepo {
+	return &questionShareRepo{
+		data: data,
+	}
+}
+
+// AddQuestionShareLink add question share link
+func (qr *questionShareRepo) AddQuestionShareLink(ctx context.Context, link *entity.QuestionShareLink) (err error) {
+	link.QuestionID = uid.DeShortID(link.QuestionID)
+	_, err = qr.data.DB.Context(ctx).Insert(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionShareLink get question share link by id
+func (qr *questionShareRepo) GetQuestionShareLink(ctx context.Context, id int) (
+	link *entity.QuestionShareLink, exist bool, err error) {
+	link = &entity.QuestionShareLink{}
+	exist, err = qr.data.DB.Context(ctx).ID(id).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionShareLinkByToken get question share link by token
+func (qr *questionShareRepo) GetQuestionShareLinkByToken(ctx context.Context, token string) (
+	link *entity.QuestionShareLink, exist bool, err error) {
+	link = &entity.QuestionShareLink{}
+	exist, err = qr.data.DB.Context(ctx).Where("token = ?", token).Get(link)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionShareLinks get all share links of the question
+func (qr *questionShareRepo) GetQuestionShareLinks(ctx context.Context, questionID string) (
+	links []*entity.QuestionShareLink, err error) {
+	links = make([]*entity.QuestionShareLink, 0)
+	err = qr.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).
+		Desc("id").Find(&links)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveQuestionShareLink remove question share link
+func (qr *questionShareRepo) RemoveQuestionShareLink(ctx context.Context, id int) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Delete(&entity.QuestionShareLink{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveQuestionShareLinks remove all share links of the question
+func (qr *questionShareRepo) RemoveQuestionShareLinks(ctx context.Context, questionID string) (err error) {
+	_, err = qr.data.DB.Context(ctx).Where("question_id = ?", uid.DeShortID(questionID)).
+		Delete(&entity.QuestionShareLink{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_sla
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_sla"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionSLARepo question SLA repository
+type questionSLARepo struct {
+	data *data.Data
+}
+
+// NewQuestionSLARepo new repository
+func NewQuestionSLARepo(data *data.Data) question_sla.QuestionSLARepo {
+	return &questionSLARepo{
+		data: data,
+	}
+}
+
+// GetTagSLAPolicies get all tag SLA policies
+func (qr *questionSLARepo) GetTagSLAPolicies(ctx context.Context) (policies []*entity.TagSLAPolicy, err error) {
+	policies = make([]*entity.TagSLAPolicy, 0)
+	err = qr.data.DB.Context(ctx).Asc("id").Find(&policies)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagSLAPolicyByTagID get tag SLA policy by tag id
+func (qr *questionSLARepo) GetTagSLAPolicyByTagID(ctx context.Context, tagID string) (
+	policy *entity.TagSLAPolicy, exist bool, err error) {
+	policy = &entity.TagSLAPolicy{}
+	exist, err = qr.data.DB.Context(ctx).Where("tag_id = ?", tagID).Get(policy)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddTagSLAPolicy add tag SLA policy
+func (qr *questionSLARepo) AddTagSLAPolicy(ctx context.Context, policy *entity.TagSLAPolicy) (err error) {
+	_, err = qr.data.DB.Context(ctx).Insert(policy)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateTagSLAPolicy update tag SLA policy
+func (qr *questionSLARepo) UpdateTagSLAPolicy(ctx context.Context, policy *entity.TagSLAPolicy) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(policy.ID).
+		Cols("first_response_hours", "resolution_hours", "escalation_channels").Update(policy)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveTagSLAPolicy remove tag SLA policy
+func (qr *questionSLARepo) RemoveTagSLAPolicy(ctx context.Context, id int) (err error) {
+	_, err = qr.data.DB.Context(ctx).ID(id).Delete(&entity.TagSLAPolicy{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOpenSLAQuestions get the available questions without accepted answer in the tags
+func (qr *questionSLARepo) GetOpenSLAQuestions(ctx context.Context, tagIDs []string) (
+	questions []*entity.SLAQuestion, err error) {
+	questions = make([]*entity.SLAQuestion, 0)
+	if len(tagIDs) == 0 {
+		return questions, nil
+	}
+	err = qr.data.DB.Context(ctx).Table(entity.Question{}.TableName()).
+		Select("question.id AS question_id, tag_rel.tag_id AS tag_id, "+
+			"question.created_at AS created_at, question.answer_count AS answer_count").
+		Join("INNER", entity.TagRel{}.TableName(), "tag_rel.object_id = question.id").
+		In("tag_rel.tag_id", tagIDs).
+		And("tag_rel.status = ?", entity.TagRelStatusAvailable).
+		And("question.status = ?", entity.QuestionStatusAvailable).
+		And("question.accepted_answer_id = ?", "0").
+		Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetQuestionSLAs get the SLA state of the questions
+func (qr *questionSLARepo) GetQuestionSLAs(ctx context.Context, questionIDs []string) (
+	slas []*entity.QuestionSLA, err error) {
+	slas = make([]*entity.QuestionSLA, 0)
+	if len(questionIDs) == 0 {
+		return slas, nil
+	}
+	err = qr.data.DB.Context(ctx).In("question_id", questionIDs).Find(&slas)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveQuestionSLA add the SLA state of the question if it is new, otherwise update it
+func (qr *questionSLARepo) SaveQuestionSLA(ctx context.Context, sla *entity.QuestionSLA) (err error) {
+	if sla.ID == 0 {
+		_, err = qr.data.DB.Context(ctx).Insert(sla)
+	} else {
+		_, err = qr.data.DB.Context(ctx).ID(sla.ID).Cols("first_response_due_at", "resolution_due_at",
+			"first_response_breached_at", "resolution_breached_at").Update(sla)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
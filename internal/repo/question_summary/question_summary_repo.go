@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_summary
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_summary"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// questionSummaryRepo question summary repository
+type questionSummaryRepo struct {
+	data *data.Data
+}
+
+// NewQuestionSummaryRepo new repository
+func NewQuestionSummaryRepo(data *data.Data) question_summary.QuestionSummaryRepo {
+	return &questionSummaryRepo{
+		data: data,
+	}
+}
+
+// GetSummary get the summary of the question
+func (qr *questionSummaryRepo) GetSummary(ctx context.Context, questionID string) (
+	summary *entity.QuestionSummary, exist bool, err error) {
+	summary = &entity.QuestionSummary{}
+	exist, err = qr.data.DB.Context(ctx).Where("question_id = ?", questionID).Get(summary)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSummariesByQuestionIDs get the summaries of the questions
+func (qr *questionSummaryRepo) GetSummariesByQuestionIDs(ctx context.Context, questionIDs []string) (
+	summaries []*entity.QuestionSummary, err error) {
+	summaries = make([]*entity.QuestionSummary, 0)
+	if len(questionIDs) == 0 {
+		return summaries, nil
+	}
+	err = qr.data.DB.Context(ctx).In("question_id", questionIDs).Find(&summaries)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SaveSummary add the summary of the question or replace the existing one
+func (qr *questionSummaryRepo) SaveSummary(ctx context.Context, summary *entity.QuestionSummary) (err error) {
+	old := &entity.QuestionSummary{}
+	exist, err := qr.data.DB.Context(ctx).Where("question_id = ?", summary.QuestionID).Get(old)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		summary.ID = old.ID
+		_, err = qr.data.DB.Context(ctx).ID(old.ID).
+			Cols("question_revision_id", "answer_id", "answer_revision_id", "content", "provider", "ai_generated").
+			Update(summary)
+	} else {
+		_, err = qr.data.DB.Context(ctx).Insert(summary)
+	}
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
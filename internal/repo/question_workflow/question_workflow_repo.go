@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_workflow
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/question_workflow"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// questionWorkflowRepo question workflow repository
+type questionWorkflowRepo struct {
+	data *data.Data
+}
+
+// NewQuestionWorkflowRepo new repository
+func NewQuestionWorkflowRepo(data *data.Data) question_workflow.QuestionWorkflowRepo {
+	return &questionWorkflowRepo{
+		data: data,
+	}
+}
+
+// UpdateWorkflowState update the workflow state of the question and add the transition in one transaction
+func (qr *questionWorkflowRepo) UpdateWorkflowState(ctx context.Context,
+	transition *entity.QuestionWorkflowTransition) (err error) {
+	_, err = qr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		_, err := session.ID(transition.QuestionID).Cols("workflow_state").
+			Update(&entity.Question{WorkflowState: transition.ToState})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(transition)
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTransitions get the transitions of the question, the oldest first
+func (qr *questionWorkflowRepo) GetTransitions(ctx context.Context, questionID string) (
+	transitions []*entity.QuestionWorkflowTransition, err error) {
+	transitions = make([]*entity.QuestionWorkflowTransition, 0)
+	err = qr.data.DB.Context(ctx).Where("question_id = ?", questionID).Asc("id").Find(&transitions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
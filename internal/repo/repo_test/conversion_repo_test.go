@@ -0,0 +1,135 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/conversion"
+	"github.com/apache/incubator-answer/internal/repo/rank"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_conversionRepo_ConvertAnswerToComment(t *testing.T) {
+	ctx := context.TODO()
+	uniqueIDRepo := unique.NewUniqueIDRepo(testDataSource)
+	cs := configService.NewConfigService(config.NewConfigRepo(testDataSource))
+	activityRepo := activity_common.NewActivityRepo(testDataSource, uniqueIDRepo, cs)
+	conversionRepo := conversion.NewConversionRepo(testDataSource, uniqueIDRepo, activityRepo,
+		rank.NewUserRankRepo(testDataSource, cs))
+
+	author := &entity.User{Username: "conversion_author", EMail: "conversion_author@example.com", Rank: 100}
+	upVoter := &entity.User{Username: "conversion_up_voter", EMail: "conversion_up_voter@example.com", Rank: 100}
+	downVoter := &entity.User{Username: "conversion_down_voter", EMail: "conversion_down_voter@example.com", Rank: 100}
+	for _, user := range []*entity.User{author, upVoter, downVoter} {
+		_, err := testDataSource.DB.Context(ctx).Insert(user)
+		require.NoError(t, err)
+	}
+
+	answerID, err := uniqueIDRepo.GenUniqueIDStr(ctx, entity.Answer{}.TableName())
+	require.NoError(t, err)
+	_, err = testDataSource.DB.Context(ctx).Insert(&entity.Answer{
+		ID: answerID, QuestionID: "10010000000000001", UserID: author.ID, OriginalText: "answer",
+		ParsedText: "<p>answer</p>", VoteCount: 0, Status: entity.AnswerStatusAvailable,
+		RevisionID: "0", LastEditUserID: "0",
+	})
+	require.NoError(t, err)
+	childComment := &entity.Comment{ID: "10040000000000101", UserID: upVoter.ID, ObjectID: answerID,
+		QuestionID: "10010000000000001", Status: entity.CommentStatusAvailable}
+	_, err = testDataSource.DB.Context(ctx).Insert(childComment)
+	require.NoError(t, err)
+
+	activityTypes := make(map[string]int)
+	for _, key := range []string{activity_type.AnswerVoteUp, activity_type.AnswerVotedUp,
+		activity_type.AnswerVoteDown, activity_type.AnswerVotedDown, activity_type.CommentVoteUp} {
+		activityTypes[key], err = activityRepo.GetActivityTypeByConfigKey(ctx, key)
+		require.NoError(t, err)
+	}
+	votes := []*entity.Activity{
+		{UserID: upVoter.ID, ActivityType: activityTypes[activity_type.AnswerVoteUp]},
+		{UserID: author.ID, TriggerUserID: 1, ActivityType: activityTypes[activity_type.AnswerVotedUp], Rank: 10,
+			HasRank: 1},
+		{UserID: downVoter.ID, ActivityType: activityTypes[activity_type.AnswerVoteDown], Rank: -1, HasRank: 1},
+		{UserID: author.ID, TriggerUserID: 2, ActivityType: activityTypes[activity_type.AnswerVotedDown], Rank: -2,
+			HasRank: 1},
+	}
+	for _, vote := range votes {
+		vote.ObjectID, vote.OriginalObjectID = answerID, answerID
+		_, err = testDataSource.DB.Context(ctx).Insert(vote)
+		require.NoError(t, err)
+	}
+
+	createdAt := time.Date(2024, 1, 30, 8, 0, 0, 0, time.UTC)
+	comment := &entity.Comment{
+		UserID:       author.ID,
+		ObjectID:     "10010000000000001",
+		QuestionID:   "10010000000000001",
+		OriginalText: "answer",
+		ParsedText:   "<p>answer</p>",
+		Status:       entity.CommentStatusAvailable,
+		CreatedAt:    createdAt,
+	}
+	err = conversionRepo.ConvertAnswerToComment(ctx, answerID, comment)
+	require.NoError(t, err)
+
+	gotComment := &entity.Comment{}
+	exist, err := testDataSource.DB.Context(ctx).ID(comment.ID).Get(gotComment)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, 1, gotComment.VoteCount)
+	assert.Equal(t, createdAt.Unix(), gotComment.CreatedAt.Unix())
+
+	movedComment := &entity.Comment{}
+	_, err = testDataSource.DB.Context(ctx).ID(childComment.ID).Get(movedComment)
+	require.NoError(t, err)
+	assert.Equal(t, "10010000000000001", movedComment.ObjectID)
+
+	gotAnswer := &entity.Answer{}
+	_, err = testDataSource.DB.Context(ctx).ID(answerID).Get(gotAnswer)
+	require.NoError(t, err)
+	assert.Equal(t, entity.AnswerStatusDeleted, gotAnswer.Status)
+
+	availableVotes, err := testDataSource.DB.Context(ctx).
+		Where("object_id = ? AND cancelled = ?", answerID, entity.ActivityAvailable).Count(&entity.Activity{})
+	require.NoError(t, err)
+	assert.Zero(t, availableVotes)
+	like := &entity.Activity{}
+	exist, err = testDataSource.DB.Context(ctx).Where("object_id = ? AND user_id = ? AND activity_type = ?",
+		comment.ID, upVoter.ID, activityTypes[activity_type.CommentVoteUp]).Get(like)
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	// the reputation of the up vote and the down vote is rolled back
+	for user, wantRank := range map[*entity.User]int{author: 92, upVoter: 100, downVoter: 101} {
+		got := &entity.User{}
+		_, err = testDataSource.DB.Context(ctx).ID(user.ID).Get(got)
+		require.NoError(t, err)
+		assert.Equal(t, wantRank, got.Rank, user.Username)
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/invitation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_invitationRepo_AddInvitedUser(t *testing.T) {
+	ctx := context.TODO()
+	invitationRepo := invitation.NewInvitationRepo(testDataSource)
+	invitationInfo := &entity.Invitation{Code: "invited-user-code", UserID: "1", MaxUses: 1,
+		Status: entity.InvitationStatusAvailable}
+	require.NoError(t, invitationRepo.AddInvitation(ctx, invitationInfo))
+
+	// the invitation is not used if the user can not be added
+	_, err := invitationRepo.AddInvitedUser(ctx, invitationInfo, &entity.User{Username: "admin",
+		EMail: "invitee@example.com", Status: entity.UserStatusAvailable, DisplayName: "invitee"})
+	assert.Error(t, err)
+	got, _, err := invitationRepo.GetInvitation(ctx, invitationInfo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.UsedCount)
+
+	invitee := &entity.User{Username: "invitee", EMail: "invitee@example.com",
+		Status: entity.UserStatusAvailable, DisplayName: "invitee"}
+	ok, err := invitationRepo.AddInvitedUser(ctx, invitationInfo, invitee)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	got, _, err = invitationRepo.GetInvitation(ctx, invitationInfo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.UsedCount)
+	exist, err := testDataSource.DB.Context(ctx).Exist(&entity.InvitationRecord{
+		InvitationID: invitationInfo.ID, InviterUserID: "1", UserID: invitee.ID})
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	// the invitation is used up, so the user is not added
+	ok, err = invitationRepo.AddInvitedUser(ctx, invitationInfo, &entity.User{Username: "invitee2",
+		EMail: "invitee2@example.com", Status: entity.UserStatusAvailable, DisplayName: "invitee2"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	exist, err = testDataSource.DB.Context(ctx).Exist(&entity.User{Username: "invitee2"})
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/pending_deletion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pendingDeletionRepo_PurgeDeletedObject(t *testing.T) {
+	ctx := context.TODO()
+	pendingDeletionRepo := pending_deletion.NewPendingDeletionRepo(testDataSource)
+
+	questionID, answerID, commentID := "10010000000000901", "10020000000000901", "10070000000000901"
+	beans := []any{
+		&entity.Question{ID: questionID, UserID: "1", Title: "purged question", OriginalText: "question",
+			ParsedText: "<p>question</p>", Status: entity.QuestionStatusDeleted, RevisionID: "0",
+			LastEditUserID: "0", LastAnswerID: "0", AcceptedAnswerID: "0"},
+		&entity.Answer{ID: answerID, QuestionID: questionID, UserID: "2", OriginalText: "answer",
+			ParsedText: "<p>answer</p>", Status: entity.AnswerStatusDeleted, RevisionID: "0", LastEditUserID: "0"},
+		&entity.Comment{ID: commentID, UserID: "1", ObjectID: answerID, QuestionID: questionID,
+			Status: entity.CommentStatusAvailable},
+		&entity.Activity{UserID: "1", ObjectID: questionID, OriginalObjectID: questionID, ActivityType: 1},
+		&entity.Activity{UserID: "1", ObjectID: answerID, OriginalObjectID: answerID, ActivityType: 1},
+		&entity.Activity{UserID: "1", ObjectID: commentID, OriginalObjectID: commentID, ActivityType: 1},
+		&entity.Revision{UserID: "1", ObjectID: questionID, Content: "{}"},
+		&entity.Revision{UserID: "2", ObjectID: answerID, Content: "{}"},
+		&entity.Collection{ID: "10000000000000901", UserID: "2", ObjectID: questionID},
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+	questionDeletion := &entity.PendingDeletion{ObjectID: questionID, ObjectType: constant.QuestionObjectType,
+		UserID: "1", Status: entity.PendingDeletionStatusFinalized}
+	answerDeletion := &entity.PendingDeletion{ObjectID: answerID, ObjectType: constant.AnswerObjectType,
+		UserID: "2", Status: entity.PendingDeletionStatusFinalized}
+	for _, pendingDeletion := range []*entity.PendingDeletion{answerDeletion, questionDeletion} {
+		require.NoError(t, pendingDeletionRepo.AddPendingDeletion(ctx, pendingDeletion))
+	}
+
+	purged, answerUserIDs, err := pendingDeletionRepo.PurgeDeletedObject(ctx, questionDeletion)
+	require.NoError(t, err)
+	assert.True(t, purged)
+	assert.Equal(t, []string{"2"}, answerUserIDs)
+
+	objectIDs := []string{questionID, answerID, commentID}
+	for _, bean := range []any{&entity.Question{}, &entity.Answer{}, &entity.Comment{}} {
+		exist, err := testDataSource.DB.Context(ctx).In("id", objectIDs).Exist(bean)
+		require.NoError(t, err)
+		assert.False(t, exist)
+	}
+	for _, bean := range []any{&entity.Activity{}, &entity.Revision{}, &entity.Collection{}} {
+		exist, err := testDataSource.DB.Context(ctx).In("object_id", objectIDs).Exist(bean)
+		require.NoError(t, err)
+		assert.False(t, exist)
+	}
+
+	// the answer purged together with its question leaves the recycle bin as purged, not restored
+	pendingDeletion := &entity.PendingDeletion{}
+	_, err = testDataSource.DB.Context(ctx).ID(answerDeletion.ID).Get(pendingDeletion)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PendingDeletionStatusPurged, pendingDeletion.Status)
+
+	purged, _, err = pendingDeletionRepo.PurgeDeletedObject(ctx, answerDeletion)
+	require.NoError(t, err)
+	assert.False(t, purged)
+	pendingDeletion = &entity.PendingDeletion{}
+	_, err = testDataSource.DB.Context(ctx).ID(answerDeletion.ID).Get(pendingDeletion)
+	require.NoError(t, err)
+	assert.Equal(t, entity.PendingDeletionStatusPurged, pendingDeletion.Status)
+}
@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/question_assignment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_questionAssignmentRepo_GetQuestionExperts(t *testing.T) {
+	ctx := context.TODO()
+	questionAssignmentRepo := question_assignment.NewQuestionAssignmentRepo(testDataSource)
+
+	questionID, tagID, otherTagID := "10010000000000911", "10030000000000911", "10030000000000912"
+	beans := []any{
+		&entity.TagRel{ObjectID: questionID, TagID: tagID, Status: entity.TagRelStatusAvailable},
+		&entity.UserTagScore{UserID: "911", TagID: tagID, Score: 10},
+		&entity.UserTagScore{UserID: "912", TagID: tagID, Score: 5},
+		&entity.UserTagScore{UserID: "913", TagID: tagID, Score: 20},
+		&entity.UserTagScore{UserID: "914", TagID: otherTagID, Score: 50},
+		// the user who has answered the question is skipped, the deleted answer is not counted
+		&entity.Answer{ID: "10020000000000911", QuestionID: questionID, UserID: "913", OriginalText: "answer",
+			ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable, RevisionID: "0", LastEditUserID: "0"},
+		&entity.Answer{ID: "10020000000000912", QuestionID: questionID, UserID: "912", OriginalText: "answer",
+			ParsedText: "<p>answer</p>", Status: entity.AnswerStatusDeleted, RevisionID: "0", LastEditUserID: "0"},
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	experts, err := questionAssignmentRepo.GetQuestionExperts(ctx, questionID, 10)
+	require.NoError(t, err)
+	userIDs := make([]string, 0, len(experts))
+	for _, expert := range experts {
+		userIDs = append(userIDs, expert.UserID)
+	}
+	assert.Equal(t, []string{"911", "912"}, userIDs)
+}
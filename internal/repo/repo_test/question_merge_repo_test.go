@@ -0,0 +1,185 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/activity_common"
+	"github.com/apache/incubator-answer/internal/repo/config"
+	"github.com/apache/incubator-answer/internal/repo/question_merge"
+	"github.com/apache/incubator-answer/internal/repo/unique"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	configService "github.com/apache/incubator-answer/internal/service/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergedQuestion(id, acceptedAnswerID string) *entity.Question {
+	return &entity.Question{ID: id, UserID: "1", Title: "merged question " + id, OriginalText: "question",
+		ParsedText: "<p>question</p>", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow,
+		RevisionID: "0", LastEditUserID: "0", LastAnswerID: "0", AcceptedAnswerID: acceptedAnswerID}
+}
+
+func newMergedAnswer(id, questionID string, accepted int) *entity.Answer {
+	return &entity.Answer{ID: id, QuestionID: questionID, UserID: "1", OriginalText: "answer",
+		ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable, Accepted: accepted,
+		RevisionID: "0", LastEditUserID: "0"}
+}
+
+func Test_questionMergeRepo_MergeQuestion(t *testing.T) {
+	ctx := context.TODO()
+	cs := configService.NewConfigService(config.NewConfigRepo(testDataSource))
+	activityRepo := activity_common.NewActivityRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource), cs)
+	questionMergeRepo := question_merge.NewQuestionMergeRepo(testDataSource, activityRepo)
+
+	voteUp, err := activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.QuestionVoteUp)
+	require.NoError(t, err)
+	votedUp, err := activityRepo.GetActivityTypeByConfigKey(ctx, activity_type.QuestionVotedUp)
+	require.NoError(t, err)
+	follow, err := activityRepo.GetActivityTypeByObjectType(ctx, constant.QuestionObjectType, constant.ActFollow)
+	require.NoError(t, err)
+
+	toQuestionID, fromQuestionID := "10010000000000921", "10010000000000922"
+	toAnswerID, fromAcceptedAnswerID, fromAnswerID := "10020000000000921", "10020000000000922", "10020000000000923"
+	questionCommentID, answerCommentID := "10070000000000921", "10070000000000922"
+	beans := []any{
+		newMergedQuestion(toQuestionID, toAnswerID),
+		newMergedQuestion(fromQuestionID, fromAcceptedAnswerID),
+		newMergedAnswer(toAnswerID, toQuestionID, schema.AnswerAcceptedEnable),
+		newMergedAnswer(fromAcceptedAnswerID, fromQuestionID, schema.AnswerAcceptedEnable),
+		newMergedAnswer(fromAnswerID, fromQuestionID, schema.AnswerAcceptedFailed),
+		&entity.Comment{ID: questionCommentID, UserID: "1", ObjectID: fromQuestionID, QuestionID: fromQuestionID,
+			Status: entity.CommentStatusAvailable},
+		&entity.Comment{ID: answerCommentID, UserID: "1", ObjectID: fromAnswerID, QuestionID: fromQuestionID,
+			Status: entity.CommentStatusAvailable},
+		// the user 921 only voted the duplicate question, the vote is moved
+		&entity.Activity{UserID: "921", ObjectID: fromQuestionID, OriginalObjectID: fromQuestionID,
+			ActivityType: voteUp},
+		&entity.Activity{UserID: "1", TriggerUserID: 921, ObjectID: fromQuestionID,
+			OriginalObjectID: fromQuestionID, ActivityType: votedUp},
+		// the user 922 voted both questions, the vote is left on the duplicate question
+		&entity.Activity{UserID: "922", ObjectID: toQuestionID, OriginalObjectID: toQuestionID, ActivityType: voteUp},
+		&entity.Activity{UserID: "922", ObjectID: fromQuestionID, OriginalObjectID: fromQuestionID,
+			ActivityType: voteUp},
+		&entity.Activity{UserID: "923", ObjectID: fromQuestionID, OriginalObjectID: fromQuestionID,
+			ActivityType: follow},
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	err = questionMergeRepo.MergeQuestion(ctx, fromQuestionID, toQuestionID)
+	require.NoError(t, err)
+
+	toQuestion := &entity.Question{}
+	_, err = testDataSource.DB.Context(ctx).ID(toQuestionID).Get(toQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, toAnswerID, toQuestion.AcceptedAnswerID)
+	assert.Equal(t, 3, toQuestion.AnswerCount)
+	assert.Equal(t, 2, toQuestion.VoteCount)
+	assert.Equal(t, 1, toQuestion.FollowCount)
+
+	fromQuestion := &entity.Question{}
+	_, err = testDataSource.DB.Context(ctx).ID(fromQuestionID).Get(fromQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, entity.QuestionStatusClosed, fromQuestion.Status)
+	assert.Equal(t, entity.QuestionHide, fromQuestion.Show)
+	assert.Equal(t, "0", fromQuestion.AcceptedAnswerID)
+	assert.Equal(t, "0", fromQuestion.LastAnswerID)
+	assert.Equal(t, 0, fromQuestion.AnswerCount)
+	assert.Equal(t, 1, fromQuestion.VoteCount)
+	assert.Equal(t, 0, fromQuestion.FollowCount)
+
+	// the canonical question has accepted an answer, so the accepted answer of the duplicate question is not kept
+	for answerID, accepted := range map[string]int{
+		fromAcceptedAnswerID: schema.AnswerAcceptedFailed,
+		fromAnswerID:         schema.AnswerAcceptedFailed,
+	} {
+		answer := &entity.Answer{}
+		_, err = testDataSource.DB.Context(ctx).ID(answerID).Get(answer)
+		require.NoError(t, err)
+		assert.Equal(t, toQuestionID, answer.QuestionID)
+		assert.Equal(t, accepted, answer.Accepted)
+	}
+
+	questionComment := &entity.Comment{}
+	_, err = testDataSource.DB.Context(ctx).ID(questionCommentID).Get(questionComment)
+	require.NoError(t, err)
+	assert.Equal(t, toQuestionID, questionComment.ObjectID)
+	assert.Equal(t, toQuestionID, questionComment.QuestionID)
+	answerComment := &entity.Comment{}
+	_, err = testDataSource.DB.Context(ctx).ID(answerCommentID).Get(answerComment)
+	require.NoError(t, err)
+	assert.Equal(t, fromAnswerID, answerComment.ObjectID)
+	assert.Equal(t, toQuestionID, answerComment.QuestionID)
+
+	votedUpCount, err := testDataSource.DB.Context(ctx).
+		Where("object_id = ? AND activity_type = ?", toQuestionID, votedUp).Count(&entity.Activity{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), votedUpCount)
+
+	meta := &entity.Meta{}
+	exist, err := testDataSource.DB.Context(ctx).
+		Where("object_id = ? AND `key` = ?", fromQuestionID, entity.QuestionMergedIntoKey).Get(meta)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, toQuestionID, meta.Value)
+}
+
+func Test_questionMergeRepo_MergeQuestion_KeepAcceptedAnswer(t *testing.T) {
+	ctx := context.TODO()
+	cs := configService.NewConfigService(config.NewConfigRepo(testDataSource))
+	activityRepo := activity_common.NewActivityRepo(testDataSource, unique.NewUniqueIDRepo(testDataSource), cs)
+	questionMergeRepo := question_merge.NewQuestionMergeRepo(testDataSource, activityRepo)
+
+	toQuestionID, fromQuestionID, fromAnswerID := "10010000000000931", "10010000000000932", "10020000000000932"
+	beans := []any{
+		newMergedQuestion(toQuestionID, "0"),
+		newMergedQuestion(fromQuestionID, fromAnswerID),
+		newMergedAnswer(fromAnswerID, fromQuestionID, schema.AnswerAcceptedEnable),
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	err := questionMergeRepo.MergeQuestion(ctx, fromQuestionID, toQuestionID)
+	require.NoError(t, err)
+
+	// the canonical question has not accepted any answer, so the accepted answer of the duplicate question is kept
+	toQuestion := &entity.Question{}
+	_, err = testDataSource.DB.Context(ctx).ID(toQuestionID).Get(toQuestion)
+	require.NoError(t, err)
+	assert.Equal(t, fromAnswerID, toQuestion.AcceptedAnswerID)
+	assert.Equal(t, fromAnswerID, toQuestion.LastAnswerID)
+	assert.Equal(t, 1, toQuestion.AnswerCount)
+
+	answer := &entity.Answer{}
+	_, err = testDataSource.DB.Context(ctx).ID(fromAnswerID).Get(answer)
+	require.NoError(t, err)
+	assert.Equal(t, toQuestionID, answer.QuestionID)
+	assert.Equal(t, schema.AnswerAcceptedEnable, answer.Accepted)
+}
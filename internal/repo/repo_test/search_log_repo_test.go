@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/search_log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_searchLogRepo_IncrSearchAndClick(t *testing.T) {
+	ctx := context.TODO()
+	searchLogRepo := search_log.NewSearchLogRepo(testDataSource)
+	date := "2024-01-02"
+
+	require.NoError(t, searchLogRepo.IncrSearch(ctx, date, "golang", 3))
+	require.NoError(t, searchLogRepo.IncrSearch(ctx, date, "golang", 0))
+	require.NoError(t, searchLogRepo.IncrClick(ctx, date, "golang", "10010000000000001"))
+	require.NoError(t, searchLogRepo.IncrClick(ctx, date, "golang", "10010000000000001"))
+	// the click of the query which is not searched in the day is ignored
+	require.NoError(t, searchLogRepo.IncrClick(ctx, date, "never searched", "10010000000000001"))
+
+	searchLog := &entity.SearchLog{}
+	exist, err := testDataSource.DB.Context(ctx).Where("date = ? AND query = ?", date, "golang").Get(searchLog)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, searchLog.SearchCount)
+	assert.Equal(t, 1, searchLog.ZeroResultCount)
+	assert.Equal(t, int64(3), searchLog.ResultCount)
+	assert.Equal(t, 2, searchLog.ClickCount)
+
+	clickLog := &entity.SearchClickLog{}
+	exist, err = testDataSource.DB.Context(ctx).Where("date = ? AND query = ?", date, "golang").Get(clickLog)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 2, clickLog.ClickCount)
+
+	for _, bean := range []any{&entity.SearchLog{}, &entity.SearchClickLog{}} {
+		exist, err = testDataSource.DB.Context(ctx).Where("query = ?", "never searched").Exist(bean)
+		require.NoError(t, err)
+		assert.False(t, exist)
+	}
+}
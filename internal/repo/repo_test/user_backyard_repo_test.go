@@ -22,11 +22,14 @@ package repo_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/auth"
 	"github.com/apache/incubator-answer/internal/repo/user"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_userAdminRepo_GetUserInfo(t *testing.T) {
@@ -70,3 +73,194 @@ func Test_userAdminRepo_UpdateUserStatus(t *testing.T) {
 	assert.True(t, exist)
 	assert.Equal(t, entity.UserStatusAvailable, got.Status)
 }
+
+func Test_userAdminRepo_TransferUserContent(t *testing.T) {
+	ctx := context.TODO()
+	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	fromUser := &entity.User{Username: "transfer_from", EMail: "transfer_from@example.com", Rank: 30}
+	toUser := &entity.User{Username: "transfer_to", EMail: "transfer_to@example.com", Rank: 5}
+	for _, u := range []*entity.User{fromUser, toUser} {
+		_, err := testDataSource.DB.Context(ctx).Insert(u)
+		require.NoError(t, err)
+	}
+
+	answerID, otherAnswerID := "10020000000000801", "10020000000000802"
+	beans := []any{
+		&entity.Answer{ID: answerID, QuestionID: "10010000000000001", UserID: fromUser.ID, OriginalText: "answer",
+			ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable, RevisionID: "0", LastEditUserID: "0"},
+		&entity.Answer{ID: otherAnswerID, QuestionID: "10010000000000001", UserID: fromUser.ID, OriginalText: "other",
+			ParsedText: "<p>other</p>", Status: entity.AnswerStatusAvailable, RevisionID: "0", LastEditUserID: "0"},
+		&entity.Revision{UserID: fromUser.ID, ObjectID: answerID, Content: "{}"},
+		&entity.Activity{UserID: fromUser.ID, ObjectID: answerID, OriginalObjectID: answerID, ActivityType: 1,
+			Rank: 10, HasRank: 1},
+		&entity.Activity{UserID: fromUser.ID, ObjectID: otherAnswerID, OriginalObjectID: otherAnswerID,
+			ActivityType: 1, Rank: 10, HasRank: 1},
+		// the new author can not be the co-author of the answer any more
+		&entity.CoAuthor{ObjectID: answerID, UserID: toUser.ID, AddedByUserID: fromUser.ID},
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	err := userAdminRepo.TransferUserContent(ctx, fromUser.ID, toUser.ID, answerID)
+	require.NoError(t, err)
+
+	answer := &entity.Answer{}
+	_, err = testDataSource.DB.Context(ctx).ID(answerID).Get(answer)
+	require.NoError(t, err)
+	assert.Equal(t, toUser.ID, answer.UserID)
+	answer = &entity.Answer{}
+	_, err = testDataSource.DB.Context(ctx).ID(otherAnswerID).Get(answer)
+	require.NoError(t, err)
+	assert.Equal(t, fromUser.ID, answer.UserID)
+
+	for _, bean := range []any{&entity.Revision{}, &entity.Activity{}} {
+		exist, err := testDataSource.DB.Context(ctx).Where("object_id = ? AND user_id = ?", answerID, toUser.ID).
+			Exist(bean)
+		require.NoError(t, err)
+		assert.True(t, exist)
+	}
+	exist, err := testDataSource.DB.Context(ctx).Where("object_id = ?", answerID).Exist(&entity.CoAuthor{})
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	got, _, err := userAdminRepo.GetUserInfo(ctx, fromUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 20, got.Rank)
+	got, _, err = userAdminRepo.GetUserInfo(ctx, toUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 15, got.Rank)
+}
+
+func Test_userAdminRepo_MergeUser(t *testing.T) {
+	ctx := context.TODO()
+	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	primaryUser := &entity.User{Username: "merge_primary", EMail: "merge_primary@example.com", Rank: 1}
+	duplicateUser := &entity.User{Username: "merge_duplicate", EMail: "merge_duplicate@example.com", Rank: 1}
+	for _, u := range []*entity.User{primaryUser, duplicateUser} {
+		_, err := testDataSource.DB.Context(ctx).Insert(u)
+		require.NoError(t, err)
+	}
+	primaryGroup := &entity.CollectionGroup{UserID: primaryUser.ID, Name: "default", DefaultGroup: schema.CGDefault}
+	duplicateGroup := &entity.CollectionGroup{UserID: duplicateUser.ID, Name: "default", DefaultGroup: schema.CGDefault}
+	for _, group := range []*entity.CollectionGroup{primaryGroup, duplicateGroup} {
+		_, err := testDataSource.DB.Context(ctx).Insert(group)
+		require.NoError(t, err)
+	}
+
+	bothCollectedID, collectedID := "10010000000000701", "10010000000000702"
+	beans := []any{
+		&entity.Question{ID: bothCollectedID, UserID: "1", Title: "both collected", OriginalText: "question",
+			ParsedText: "<p>question</p>", Status: entity.QuestionStatusAvailable, RevisionID: "0",
+			LastEditUserID: "0", LastAnswerID: "0", AcceptedAnswerID: "0", CollectionCount: 2},
+		&entity.Collection{ID: "10060000000000701", UserID: primaryUser.ID, ObjectID: bothCollectedID,
+			UserCollectionGroupID: primaryGroup.ID},
+		&entity.Collection{ID: "10060000000000702", UserID: duplicateUser.ID, ObjectID: bothCollectedID,
+			UserCollectionGroupID: duplicateGroup.ID},
+		&entity.Collection{ID: "10060000000000703", UserID: duplicateUser.ID, ObjectID: collectedID,
+			UserCollectionGroupID: duplicateGroup.ID},
+		&entity.UserFollow{UserID: primaryUser.ID, FollowUserID: "1"},
+		&entity.UserFollow{UserID: duplicateUser.ID, FollowUserID: "1"},
+		&entity.QuestionPollVote{PollID: 1, OptionID: 1, UserID: primaryUser.ID},
+		&entity.QuestionPollVote{PollID: 1, OptionID: 2, UserID: duplicateUser.ID},
+		&entity.QuestionPollVote{PollID: 2, OptionID: 3, UserID: duplicateUser.ID},
+		&entity.TagExpert{TagID: "10030000000000701", UserID: duplicateUser.ID},
+		&entity.Notification{UserID: duplicateUser.ID, ObjectID: bothCollectedID, Content: "{}"},
+		&entity.UserOnboarding{UserID: primaryUser.ID, AvatarSetAt: time.Unix(1700000200, 0)},
+		&entity.UserOnboarding{UserID: duplicateUser.ID, EmailVerifiedAt: time.Unix(1700000300, 0),
+			AvatarSetAt: time.Unix(1700000100, 0)},
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	err := userAdminRepo.MergeUser(ctx, &entity.UserMergeLog{
+		PrimaryUserID:     primaryUser.ID,
+		DuplicateUserID:   duplicateUser.ID,
+		OperatorUserID:    "1",
+		DuplicateUsername: duplicateUser.Username,
+	}, "merge_duplicate@example.com.1")
+	require.NoError(t, err)
+
+	collections := make([]*entity.Collection, 0)
+	err = testDataSource.DB.Context(ctx).Where("user_id = ?", primaryUser.ID).Asc("object_id").Find(&collections)
+	require.NoError(t, err)
+	require.Len(t, collections, 2)
+	for i, objectID := range []string{bothCollectedID, collectedID} {
+		assert.Equal(t, objectID, collections[i].ObjectID)
+		assert.Equal(t, primaryGroup.ID, collections[i].UserCollectionGroupID)
+	}
+	exist, err := testDataSource.DB.Context(ctx).ID(duplicateGroup.ID).Exist(&entity.CollectionGroup{})
+	require.NoError(t, err)
+	assert.False(t, exist)
+	question := &entity.Question{}
+	_, err = testDataSource.DB.Context(ctx).ID(bothCollectedID).Get(question)
+	require.NoError(t, err)
+	assert.Equal(t, 1, question.CollectionCount)
+
+	counts := []struct {
+		bean  any
+		count int64
+	}{
+		{&entity.UserFollow{}, 1},
+		{&entity.QuestionPollVote{}, 2},
+		{&entity.TagExpert{}, 1},
+		{&entity.Notification{}, 1},
+	}
+	for _, c := range counts {
+		count, err := testDataSource.DB.Context(ctx).Where("user_id = ?", primaryUser.ID).Count(c.bean)
+		require.NoError(t, err)
+		assert.Equal(t, c.count, count)
+		count, err = testDataSource.DB.Context(ctx).Where("user_id = ?", duplicateUser.ID).Count(c.bean)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	}
+
+	onboarding := &entity.UserOnboarding{}
+	exist, err = testDataSource.DB.Context(ctx).Where("user_id = ?", primaryUser.ID).Get(onboarding)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, int64(1700000300), onboarding.EmailVerifiedAt.Unix())
+	assert.Equal(t, int64(1700000100), onboarding.AvatarSetAt.Unix())
+	assert.True(t, onboarding.CompletedAt.IsZero())
+	exist, err = testDataSource.DB.Context(ctx).Where("user_id = ?", duplicateUser.ID).Exist(&entity.UserOnboarding{})
+	require.NoError(t, err)
+	assert.False(t, exist)
+
+	got, _, err := userAdminRepo.GetUserInfo(ctx, duplicateUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.UserStatusDeleted, got.Status)
+}
+
+func Test_userAdminRepo_MergeUserOnboarding(t *testing.T) {
+	ctx := context.TODO()
+	userAdminRepo := user.NewUserAdminRepo(testDataSource, auth.NewAuthRepo(testDataSource))
+	primaryUser := &entity.User{Username: "merge_onboarding_primary", EMail: "merge_onboarding_primary@example.com", Rank: 1}
+	duplicateUser := &entity.User{Username: "merge_onboarding_duplicate", EMail: "merge_onboarding_duplicate@example.com", Rank: 1}
+	for _, u := range []*entity.User{primaryUser, duplicateUser} {
+		_, err := testDataSource.DB.Context(ctx).Insert(u)
+		require.NoError(t, err)
+	}
+	_, err := testDataSource.DB.Context(ctx).Insert(&entity.UserOnboarding{UserID: duplicateUser.ID,
+		TourTakenAt: time.Unix(1700000100, 0)})
+	require.NoError(t, err)
+
+	err = userAdminRepo.MergeUser(ctx, &entity.UserMergeLog{
+		PrimaryUserID:     primaryUser.ID,
+		DuplicateUserID:   duplicateUser.ID,
+		OperatorUserID:    "1",
+		DuplicateUsername: duplicateUser.Username,
+	}, "merge_onboarding_duplicate@example.com.1")
+	require.NoError(t, err)
+
+	onboarding := &entity.UserOnboarding{}
+	exist, err := testDataSource.DB.Context(ctx).Where("user_id = ?", primaryUser.ID).Get(onboarding)
+	require.NoError(t, err)
+	require.True(t, exist)
+	assert.Equal(t, int64(1700000100), onboarding.TourTakenAt.Unix())
+	exist, err = testDataSource.DB.Context(ctx).Where("user_id = ?", duplicateUser.ID).Exist(&entity.UserOnboarding{})
+	require.NoError(t, err)
+	assert.False(t, exist)
+}
@@ -0,0 +1,86 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/repo/user_follow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userFollowRepo_GetFollowingActivityPage(t *testing.T) {
+	ctx := context.TODO()
+	userFollowRepo := user_follow.NewUserFollowRepo(testDataSource)
+	askedType, answeredType := 1001, 1002
+
+	shownQuestionID, hiddenQuestionID := "10010000000000941", "10010000000000942"
+	answerID, deletedAnswerID, hiddenAnswerID := "10020000000000941", "10020000000000942", "10020000000000943"
+	newQuestion := func(id string, show int) *entity.Question {
+		return &entity.Question{ID: id, UserID: "942", Title: "followed question", OriginalText: "question",
+			ParsedText: "<p>question</p>", Status: entity.QuestionStatusAvailable, Show: show, RevisionID: "0",
+			LastEditUserID: "0", LastAnswerID: "0", AcceptedAnswerID: "0"}
+	}
+	newAnswer := func(id, questionID string, status int) *entity.Answer {
+		return &entity.Answer{ID: id, QuestionID: questionID, UserID: "942", OriginalText: "answer",
+			ParsedText: "<p>answer</p>", Status: status, RevisionID: "0", LastEditUserID: "0"}
+	}
+	beans := []any{
+		&entity.UserFollow{UserID: "941", FollowUserID: "942"},
+		newQuestion(shownQuestionID, entity.QuestionShow),
+		newQuestion(hiddenQuestionID, entity.QuestionHide),
+		newAnswer(answerID, shownQuestionID, entity.AnswerStatusAvailable),
+		newAnswer(deletedAnswerID, shownQuestionID, entity.AnswerStatusDeleted),
+		newAnswer(hiddenAnswerID, hiddenQuestionID, entity.AnswerStatusAvailable),
+	}
+	// the hidden question, the deleted answer and the answer of the hidden question are not in the feed
+	for _, act := range []struct {
+		objectID     string
+		activityType int
+	}{
+		{shownQuestionID, askedType},
+		{hiddenQuestionID, askedType},
+		{answerID, answeredType},
+		{deletedAnswerID, answeredType},
+		{hiddenAnswerID, answeredType},
+	} {
+		beans = append(beans, &entity.Activity{UserID: "942", ObjectID: act.objectID,
+			OriginalObjectID: act.objectID, ActivityType: act.activityType})
+	}
+	for _, bean := range beans {
+		_, err := testDataSource.DB.Context(ctx).Insert(bean)
+		require.NoError(t, err)
+	}
+
+	activities, total, err := userFollowRepo.GetFollowingActivityPage(ctx, "941", askedType, answeredType, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, activities, 1)
+	activities, _, err = userFollowRepo.GetFollowingActivityPage(ctx, "941", askedType, answeredType, 1, 10)
+	require.NoError(t, err)
+	objectIDs := make([]string, 0, len(activities))
+	for _, act := range activities {
+		objectIDs = append(objectIDs, act.ObjectID)
+	}
+	assert.ElementsMatch(t, []string{shownQuestionID, answerID}, objectIDs)
+}
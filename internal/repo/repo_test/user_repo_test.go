@@ -21,11 +21,14 @@ package repo_test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/repo/user"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_userRepo_AddUser(t *testing.T) {
@@ -137,3 +140,43 @@ func Test_userRepo_UpdatePass(t *testing.T) {
 	err := userRepo.UpdatePass(context.TODO(), "1", "admin")
 	assert.NoError(t, err)
 }
+
+func Test_userRepo_GetUnverifiedUsersToRemind(t *testing.T) {
+	ctx := context.TODO()
+	userRepo := user.NewUserRepo(testDataSource)
+	now := time.Now()
+	createdAt := now.AddDate(0, 0, -10)
+	newUser := func(name string, mailStatus, reminderCount int, remindedAt time.Time) *entity.User {
+		return &entity.User{Username: name, EMail: name + "@example.com", DisplayName: name, CreatedAt: createdAt,
+			MailStatus: mailStatus, Status: entity.UserStatusAvailable,
+			VerificationReminderCount: reminderCount, VerificationRemindedAt: remindedAt}
+	}
+	neverReminded := newUser("remind_never", entity.EmailStatusToBeVerified, 0, time.Time{})
+	remindedLongAgo := newUser("remind_long_ago", entity.EmailStatusToBeVerified, 1, now.AddDate(0, 0, -8))
+	remindedRecently := newUser("remind_recently", entity.EmailStatusToBeVerified, 1, now.AddDate(0, 0, -1))
+	remindedEnough := newUser("remind_enough", entity.EmailStatusToBeVerified, 3, now.AddDate(0, 0, -8))
+	verified := newUser("remind_verified", entity.EmailStatusAvailable, 0, time.Time{})
+	for _, userInfo := range []*entity.User{neverReminded, remindedLongAgo, remindedRecently, remindedEnough, verified} {
+		_, err := testDataSource.DB.Context(ctx).NoAutoTime().Insert(userInfo)
+		require.NoError(t, err)
+	}
+	findReminded := func() []string {
+		userList, err := userRepo.GetUnverifiedUsersToRemind(ctx, now.AddDate(0, 0, -7), 3, 100)
+		require.NoError(t, err)
+		usernames := make([]string, 0)
+		for _, userInfo := range userList {
+			if strings.HasPrefix(userInfo.Username, "remind_") {
+				usernames = append(usernames, userInfo.Username)
+			}
+		}
+		return usernames
+	}
+	assert.Equal(t, []string{"remind_never", "remind_long_ago"}, findReminded())
+
+	require.NoError(t, userRepo.UpdateVerificationReminded(ctx, neverReminded.ID))
+	got, exist, err := userRepo.GetByUserID(ctx, neverReminded.ID)
+	require.NoError(t, err)
+	assert.True(t, exist)
+	assert.Equal(t, 1, got.VerificationReminderCount)
+	assert.Equal(t, []string{"remind_long_ago"}, findReminded())
+}
@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review_reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/review_reminder"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// reviewReminderRepo question review reminder repository
+type reviewReminderRepo struct {
+	data *data.Data
+}
+
+// NewReviewReminderRepo new repository
+func NewReviewReminderRepo(data *data.Data) review_reminder.ReviewReminderRepo {
+	return &reviewReminderRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsToRemind get the open questions without the accepted answer, which received the first answer
+// within the time range and have not been reminded
+func (rr *reviewReminderRepo) GetQuestionsToRemind(ctx context.Context, answeredFrom, answeredTo time.Time,
+	limit int) (questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	answeredBefore := func(t time.Time) *builder.Builder {
+		return builder.Select("question_id").From(entity.Answer{}.TableName()).
+			Where(builder.Eq{"status": entity.AnswerStatusAvailable}.And(builder.Lt{"created_at": t}))
+	}
+	reminded := builder.Select("question_id").From(entity.QuestionReviewReminder{}.TableName())
+
+	err = rr.data.DB.Context(ctx).
+		Where("status = ?", entity.QuestionStatusAvailable).
+		And("accepted_answer_id = ?", "0").
+		And("answer_count > 0").
+		And(builder.In("id", answeredBefore(answeredTo))).
+		And(builder.NotIn("id", answeredBefore(answeredFrom))).
+		And(builder.NotIn("id", reminded)).
+		Asc("id").Limit(limit).
+		Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddReminder add the reminder of the question
+func (rr *reviewReminderRepo) AddReminder(ctx context.Context, reminder *entity.QuestionReviewReminder) (err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(reminder)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetReminderByCode get the reminder by the code of the accept links
+func (rr *reviewReminderRepo) GetReminderByCode(ctx context.Context, code string) (
+	reminder *entity.QuestionReviewReminder, exist bool, err error) {
+	reminder = &entity.QuestionReviewReminder{}
+	exist, err = rr.data.DB.Context(ctx).Where("code = ?", code).Get(reminder)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -30,12 +30,16 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision"
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/delta"
 	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
 	"xorm.io/xorm"
 )
 
+// maxRevisionDeltaDepth guard against the broken chain of the compacted revisions
+const maxRevisionDeltaDepth = 1000
+
 // revisionRepo revision repository
 type revisionRepo struct {
 	data         *data.Data
@@ -61,6 +65,8 @@ func (rr *revisionRepo) AddRevision(ctx context.Context, revision *entity.Revisi
 	}
 
 	revision.ObjectType = objectTypeNumber
+	revision.ContentFormat = entity.RevisionContentFull
+	revision.BaseRevisionID = "0"
 	if !rr.allowRecord(revision.ObjectType) {
 		return nil
 	}
@@ -126,6 +132,9 @@ func (rr *revisionRepo) GetRevision(ctx context.Context, id string) (
 	if err != nil {
 		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	if exist {
+		err = rr.decodeContent(ctx, revision, nil)
+	}
 	return
 }
 
@@ -137,6 +146,9 @@ func (rr *revisionRepo) GetRevisionByID(ctx context.Context, revisionID string)
 	if err != nil {
 		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	if exist {
+		err = rr.decodeContent(ctx, revision, nil)
+	}
 	return
 }
 
@@ -159,6 +171,9 @@ func (rr *revisionRepo) GetLastRevisionByObjectID(ctx context.Context, objectID
 	if err != nil {
 		return nil, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
+	if exist {
+		err = rr.decodeContent(ctx, revision, nil)
+	}
 	return
 }
 
@@ -169,11 +184,55 @@ func (rr *revisionRepo) GetRevisionList(ctx context.Context, revision *entity.Re
 		"object_id": revision.ObjectID,
 	}).OrderBy("created_at DESC").Find(&revisionList)
 	if err != nil {
-		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	loaded := make(map[string]*entity.Revision, len(revisionList))
+	for i := range revisionList {
+		loaded[revisionList[i].ID] = &revisionList[i]
+	}
+	for i := range revisionList {
+		if err = rr.decodeContent(ctx, &revisionList[i], loaded); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
+// decodeContent restore the content of the compacted revision by applying the deltas from its full base revision,
+// the base revisions are looked up in the loaded revisions first
+func (rr *revisionRepo) decodeContent(ctx context.Context, revision *entity.Revision,
+	loaded map[string]*entity.Revision) (err error) {
+	chain := make([]*entity.Revision, 0)
+	current := revision
+	for current.ContentFormat == entity.RevisionContentDelta {
+		if len(chain) >= maxRevisionDeltaDepth {
+			return errors.InternalServer(reason.DatabaseError).WithMsg("revision delta chain is too long")
+		}
+		chain = append(chain, current)
+		base, ok := loaded[current.BaseRevisionID]
+		if !ok {
+			base = &entity.Revision{}
+			exist, err := rr.data.DB.Context(ctx).ID(current.BaseRevisionID).Get(base)
+			if err != nil {
+				return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+			}
+			if !exist {
+				return errors.InternalServer(reason.DatabaseError).WithMsg("base revision not found")
+			}
+		}
+		current = base
+	}
+	content := current.Content
+	for i := len(chain) - 1; i >= 0; i-- {
+		if content, err = delta.Decode(content, chain[i].Content); err != nil {
+			return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		chain[i].Content = content
+		chain[i].ContentFormat = entity.RevisionContentFull
+	}
+	return nil
+}
+
 // allowRecord check the object type can record revision or not
 func (rr *revisionRepo) allowRecord(objectType int) (ok bool) {
 	switch objectType {
@@ -183,6 +242,8 @@ func (rr *revisionRepo) allowRecord(objectType int) (ok bool) {
 		return true
 	case constant.ObjectTypeStrMapping["tag"]:
 		return true
+	case constant.ObjectTypeStrMapping["article"]:
+		return true
 	default:
 		return false
 	}
@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package revision_compaction
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/revision_compaction"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// revisionCompactionRepo revision compaction repository
+type revisionCompactionRepo struct {
+	data *data.Data
+}
+
+// NewRevisionCompactionRepo new repository
+func NewRevisionCompactionRepo(data *data.Data) revision_compaction.RevisionCompactionRepo {
+	return &revisionCompactionRepo{
+		data: data,
+	}
+}
+
+// GetRevisedObjectIDs get the objects which have more than one revision, ordered by the object id
+func (rr *revisionCompactionRepo) GetRevisedObjectIDs(ctx context.Context, afterObjectID string, limit int) (
+	objectIDs []string, err error) {
+	objectIDs = make([]string, 0)
+	err = rr.data.DB.Context(ctx).Table(entity.Revision{}.TableName()).Select("object_id").
+		Where("object_id > ?", afterObjectID).
+		GroupBy("object_id").Having("COUNT(*) > 1").
+		Asc("object_id").Limit(limit).Find(&objectIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetObjectRevisions get all the revisions of the object as they are stored, the newest first,
+// and the current revision of the object
+func (rr *revisionCompactionRepo) GetObjectRevisions(ctx context.Context, objectID string) (
+	revisions []*entity.Revision, currentRevisionID string, err error) {
+	tableName, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return nil, "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	_, err = rr.data.DB.Context(ctx).Table(tableName).Select("revision_id").
+		Where("id = ?", objectID).Get(&currentRevisionID)
+	if err != nil {
+		return nil, "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	revisions = make([]*entity.Revision, 0)
+	err = rr.data.DB.Context(ctx).Where("object_id = ?", objectID).Desc("created_at", "id").Find(&revisions)
+	if err != nil {
+		return nil, "", errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return revisions, currentRevisionID, nil
+}
+
+// GetReferencedRevisionIDs get the revisions which are referenced by the activities, such as the edits,
+// so they are shown in the timeline of the object
+func (rr *revisionCompactionRepo) GetReferencedRevisionIDs(ctx context.Context, revisionIDs []string) (
+	referencedIDs []string, err error) {
+	referencedIDs = make([]string, 0)
+	if len(revisionIDs) == 0 {
+		return referencedIDs, nil
+	}
+	err = rr.data.DB.Context(ctx).Table(entity.Activity{}.TableName()).
+		Where(builder.In("revision_id", revisionIDs)).Distinct("revision_id").Find(&referencedIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CompactRevisions delete the pruned revisions and save the re-encoded content of the kept revisions
+func (rr *revisionCompactionRepo) CompactRevisions(ctx context.Context, removedIDs []string,
+	updated []*entity.Revision) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if len(removedIDs) > 0 {
+			if _, err := session.Where(builder.In("id", removedIDs)).Delete(&entity.Revision{}); err != nil {
+				return nil, err
+			}
+		}
+		for _, revision := range updated {
+			_, err := session.ID(revision.ID).NoAutoTime().
+				Cols("content", "content_format", "base_revision_id").Update(revision)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// AddCompactionLog add the record of the compaction run
+func (rr *revisionCompactionRepo) AddCompactionLog(ctx context.Context, compactionLog *entity.RevisionCompactionLog) (
+	err error) {
+	_, err = rr.data.DB.Context(ctx).Insert(compactionLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetCompactionLogs get the latest records of the compaction runs
+func (rr *revisionCompactionRepo) GetCompactionLogs(ctx context.Context, limit int) (
+	logs []*entity.RevisionCompactionLog, err error) {
+	logs = make([]*entity.RevisionCompactionLog, 0)
+	err = rr.data.DB.Context(ctx).Desc("id").Limit(limit).Find(&logs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// SumCompactionLogs sum up all the compaction runs
+func (rr *revisionCompactionRepo) SumCompactionLogs(ctx context.Context) (
+	total *entity.RevisionCompactionLog, err error) {
+	sums, err := rr.data.DB.Context(ctx).SumsInt(&entity.RevisionCompactionLog{},
+		"pruned_count", "compacted_count", "reclaimed_bytes")
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return &entity.RevisionCompactionLog{
+		PrunedCount:    int(sums[0]),
+		CompactedCount: int(sums[1]),
+		ReclaimedBytes: sums[2],
+	}, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	service "github.com/apache/incubator-answer/internal/service/role"
 	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
 )
 
 // roleRepo role repository
@@ -63,3 +64,74 @@ func (rr *roleRepo) GetRoleAllMapping(ctx context.Context) (roleMapping map[int]
 	}
 	return roleMapping, nil
 }
+
+// GetRole get role by id
+func (rr *roleRepo) GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error) {
+	role = &entity.Role{}
+	exist, err = rr.data.DB.Context(ctx).ID(roleID).Get(role)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// AddRole add role with its power types
+func (rr *roleRepo) AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Insert(role); err != nil {
+			return nil, err
+		}
+		return nil, saveRolePowerTypes(session, role.ID, powerTypes)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// UpdateRole update role and replace its power types
+func (rr *roleRepo) UpdateRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.ID(role.ID).Cols("name", "description").Update(role); err != nil {
+			return nil, err
+		}
+		return nil, saveRolePowerTypes(session, role.ID, powerTypes)
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveRole remove role and its power types
+func (rr *roleRepo) RemoveRole(ctx context.Context, roleID int) (err error) {
+	_, err = rr.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("role_id = ?", roleID).Delete(&entity.RolePowerRel{}); err != nil {
+			return nil, err
+		}
+		_, err = session.ID(roleID).Delete(&entity.Role{})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+func saveRolePowerTypes(session *xorm.Session, roleID int, powerTypes []string) (err error) {
+	if _, err = session.Where("role_id = ?", roleID).Delete(&entity.RolePowerRel{}); err != nil {
+		return err
+	}
+	if len(powerTypes) == 0 {
+		return nil
+	}
+	rels := make([]*entity.RolePowerRel, 0, len(powerTypes))
+	for _, powerType := range powerTypes {
+		rels = append(rels, &entity.RolePowerRel{RoleID: roleID, PowerType: powerType})
+	}
+	_, err = session.Insert(rels)
+	return err
+}
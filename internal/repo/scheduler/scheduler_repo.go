@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/scheduler"
+	"github.com/segmentfault/pacman/errors"
+)
+
+type schedulerRepo struct {
+	data *data.Data
+}
+
+// NewSchedulerRepo new repository
+func NewSchedulerRepo(data *data.Data) scheduler.SchedulerRepo {
+	return &schedulerRepo{
+		data: data,
+	}
+}
+
+func (sr *schedulerRepo) GetScheduledJob(ctx context.Context, name string) (
+	job *entity.ScheduledJob, exist bool, err error) {
+	job = &entity.ScheduledJob{}
+	exist, err = sr.data.DB.Context(ctx).Where("name = ?", name).Get(job)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return job, exist, err
+}
+
+func (sr *schedulerRepo) GetScheduledJobList(ctx context.Context) (jobs []*entity.ScheduledJob, err error) {
+	jobs = make([]*entity.ScheduledJob, 0)
+	err = sr.data.DB.Context(ctx).Find(&jobs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return jobs, err
+}
+
+func (sr *schedulerRepo) UpdateScheduledJobStatus(ctx context.Context, name string, disabled bool) (err error) {
+	return sr.saveScheduledJob(ctx, &entity.ScheduledJob{Name: name, Disabled: disabled}, "disabled")
+}
+
+func (sr *schedulerRepo) UpdateScheduledJobLastRun(ctx context.Context, job *entity.ScheduledJob) (err error) {
+	return sr.saveScheduledJob(ctx, job, "last_run_at", "last_duration", "last_status", "last_error", "next_run_at")
+}
+
+func (sr *schedulerRepo) UpdateScheduledJobNextRun(ctx context.Context, name string, nextRunAt time.Time) (err error) {
+	return sr.saveScheduledJob(ctx, &entity.ScheduledJob{Name: name, NextRunAt: nextRunAt}, "next_run_at")
+}
+
+// saveScheduledJob update the columns of the job, the job is added if it does not exist
+func (sr *schedulerRepo) saveScheduledJob(ctx context.Context, job *entity.ScheduledJob, cols ...string) (err error) {
+	old, exist, err := sr.GetScheduledJob(ctx, job.Name)
+	if err != nil {
+		return err
+	}
+	if exist {
+		_, err = sr.data.DB.Context(ctx).ID(old.ID).Cols(cols...).Update(job)
+	} else {
+		_, err = sr.data.DB.Context(ctx).Insert(job)
+	}
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
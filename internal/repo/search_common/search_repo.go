@@ -73,6 +73,19 @@ var (
 		"`answer`.`status` as `status`",
 		"`answer`.`created_at` as `post_update_time`",
 	}
+	arFields = []string{
+		"`article`.`id` as `id`",
+		"0 as `question_id`",
+		"`title`",
+		"`parsed_text`",
+		"`article`.`created_at` as `created_at`",
+		"`user_id`",
+		"0 as `vote_count`",
+		"0 as `answer_count`",
+		"0 as `accepted`",
+		"`article`.`status` as `status`",
+		"`article`.`created_at` as `post_update_time`",
+	}
 )
 
 // searchRepo tag repository
@@ -201,6 +214,18 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	}
 	sql := fmt.Sprintf("(%s UNION ALL %s)", bSQL, ubSQL)
 
+	// articles have no tags and votes, so they are only included when not filtered by them
+	argsAr := []interface{}{}
+	if len(tagIDs) == 0 && votes <= 0 {
+		var arb *builder.Builder
+		arb, argsAr = sr.buildArticleSearch(words, userID, order)
+		arbSQL, _, err := arb.ToSQL()
+		if err != nil {
+			return nil, 0, err
+		}
+		sql = fmt.Sprintf("(%s UNION ALL %s UNION ALL %s)", bSQL, ubSQL, arbSQL)
+	}
+
 	countSQL, _, err := builder.MySQL().Select("count(*) total").From(sql, "c").ToSQL()
 	if err != nil {
 		return
@@ -217,10 +242,12 @@ func (sr *searchRepo) SearchContents(ctx context.Context, words []string, tagIDs
 	queryArgs = append(queryArgs, querySQL)
 	queryArgs = append(queryArgs, argsQ...)
 	queryArgs = append(queryArgs, argsA...)
+	queryArgs = append(queryArgs, argsAr...)
 
 	countArgs = append(countArgs, countSQL)
 	countArgs = append(countArgs, argsQ...)
 	countArgs = append(countArgs, argsA...)
+	countArgs = append(countArgs, argsAr...)
 
 	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
 	if err != nil {
@@ -444,6 +471,71 @@ func (sr *searchRepo) SearchAnswers(ctx context.Context, words []string, tagIDs
 	return
 }
 
+// SearchArticles search article data
+func (sr *searchRepo) SearchArticles(ctx context.Context, words []string, userID string, page, size int, order string) (resp []*schema.SearchResult, total int64, err error) {
+	words = filterWords(words)
+	if order == "relevance" && len(words) == 0 {
+		order = "newest"
+	}
+	b, args := sr.buildArticleSearch(words, userID, order)
+
+	countSQL, _, err := builder.MySQL().Select("count(*) total").From(b, "c").ToSQL()
+	if err != nil {
+		return
+	}
+	querySQL, _, err := b.OrderBy(sr.parseOrder(ctx, order)).Limit(size, page-1).ToSQL()
+	if err != nil {
+		return
+	}
+
+	queryArgs := append([]interface{}{querySQL}, args...)
+	countArgs := append([]interface{}{countSQL}, args...)
+
+	res, err := sr.data.DB.Context(ctx).Query(queryArgs...)
+	if err != nil {
+		return
+	}
+	tr, err := sr.data.DB.Context(ctx).Query(countArgs...)
+	if err != nil {
+		return
+	}
+	if len(tr) != 0 {
+		total = converter.StringToInt64(string(tr[0]["total"]))
+	}
+	resp, err = sr.parseResult(ctx, res, words)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// buildArticleSearch build the sql of searching available articles, return the builder and its args
+func (sr *searchRepo) buildArticleSearch(words []string, userID, order string) (b *builder.Builder, args []interface{}) {
+	afs := arFields
+	args = []interface{}{}
+	if order == "relevance" && len(words) > 0 {
+		afs, args = addRelevanceField([]string{"title", "original_text"}, words, afs)
+	}
+
+	b = builder.MySQL().Select(afs...).From("`article`")
+	b.Where(builder.Eq{"`article`.`status`": entity.ArticleStatusAvailable})
+	args = append(args, entity.ArticleStatusAvailable)
+
+	likeCon := builder.NewCond()
+	for _, word := range words {
+		likeCon = likeCon.Or(builder.Like{"title", word}).
+			Or(builder.Like{"original_text", word})
+		args = append(args, "%"+word+"%", "%"+word+"%")
+	}
+	b.Where(likeCon)
+
+	if userID != "" {
+		b.Where(builder.Eq{"article.user_id": userID})
+		args = append(args, userID)
+	}
+	return b, args
+}
+
 func (sr *searchRepo) parseOrder(ctx context.Context, order string) (res string) {
 	switch order {
 	case "newest":
@@ -471,12 +563,17 @@ func (sr *searchRepo) ParseSearchPluginResult(ctx context.Context, sres []plugin
 		switch r.Type {
 		case "question":
 			b = builder.MySQL().Select(qFields...).From("question").Where(builder.Eq{"id": r.ID}).
-				And(builder.Lt{"`status`": entity.QuestionStatusDeleted})
+				And(builder.Lt{"`status`": entity.QuestionStatusDeleted}).And(builder.Eq{"`show`": entity.QuestionShow})
 		case "answer":
 			b = builder.MySQL().Select(aFields...).From("answer").LeftJoin("`question`", "`question`.`id` = `answer`.`question_id`").
 				Where(builder.Eq{"`answer`.`id`": r.ID}).
 				And(builder.Lt{"`question`.`status`": entity.QuestionStatusDeleted}).
 				And(builder.Lt{"`answer`.`status`": entity.AnswerStatusDeleted}).And(builder.Eq{"`question`.`show`": entity.QuestionShow})
+		case "article":
+			b = builder.MySQL().Select(arFields...).From("article").Where(builder.Eq{"id": r.ID}).
+				And(builder.Eq{"`status`": entity.ArticleStatusAvailable})
+		default:
+			continue
 		}
 		qres, err = sr.data.DB.Context(ctx).Query(b)
 		if err != nil || len(qres) == 0 {
@@ -540,6 +637,8 @@ func (sr *searchRepo) parseResult(ctx context.Context, res []map[string][]byte,
 					break
 				}
 			}
+		case "article":
+			object.QuestionID = ""
 		}
 
 		resultList = append(resultList, &schema.SearchResult{
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_log
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/search_log"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// searchLogRepo search log repository
+type searchLogRepo struct {
+	data *data.Data
+}
+
+// NewSearchLogRepo new repository
+func NewSearchLogRepo(data *data.Data) search_log.SearchLogRepo {
+	return &searchLogRepo{
+		data: data,
+	}
+}
+
+// IncrSearch increase the search count of the query in the day
+func (sr *searchLogRepo) IncrSearch(ctx context.Context, date, query string, resultCount int64) (err error) {
+	zeroResultCount := 0
+	if resultCount == 0 {
+		zeroResultCount = 1
+	}
+	incr := func() (int64, error) {
+		return sr.data.DB.Context(ctx).Where("date = ? AND query = ?", date, query).
+			Incr("search_count").
+			Incr("zero_result_count", zeroResultCount).
+			Incr("result_count", resultCount).
+			Update(&entity.SearchLog{})
+	}
+	err = sr.incrOrInsert(incr, func() error {
+		_, err := sr.data.DB.Context(ctx).Insert(&entity.SearchLog{
+			Date:            date,
+			Query:           query,
+			SearchCount:     1,
+			ZeroResultCount: zeroResultCount,
+			ResultCount:     resultCount,
+		})
+		return err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// IncrClick increase the click count of the query and the clicked object in the day,
+// the click is ignored if the query is not searched in the day
+func (sr *searchLogRepo) IncrClick(ctx context.Context, date, query, objectID string) (err error) {
+	affected, err := sr.data.DB.Context(ctx).Where("date = ? AND query = ?", date, query).
+		Incr("click_count").
+		Update(&entity.SearchLog{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if affected == 0 {
+		return nil
+	}
+	incr := func() (int64, error) {
+		return sr.data.DB.Context(ctx).Where("date = ? AND query = ? AND object_id = ?", date, query, objectID).
+			Incr("click_count").
+			Update(&entity.SearchClickLog{})
+	}
+	err = sr.incrOrInsert(incr, func() error {
+		_, err := sr.data.DB.Context(ctx).Insert(&entity.SearchClickLog{
+			Date:       date,
+			Query:      query,
+			ObjectID:   objectID,
+			ClickCount: 1,
+		})
+		return err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// incrOrInsert increase the counts of the existing row or insert the row. If the insert fails because the row
+// is inserted by the concurrent request in the meantime, the counts of that row are increased instead.
+func (sr *searchLogRepo) incrOrInsert(incr func() (int64, error), insert func() error) (err error) {
+	affected, err := incr()
+	if err != nil || affected > 0 {
+		return err
+	}
+	if err = insert(); err == nil {
+		return nil
+	}
+	if affected, e := incr(); e == nil && affected > 0 {
+		return nil
+	}
+	return err
+}
+
+// GetTopQueries get the most searched queries between the dates, both inclusive
+func (sr *searchLogRepo) GetTopQueries(ctx context.Context, startDate, endDate string, limit int) (
+	stats []*entity.SearchLogQueryStat, err error) {
+	stats = make([]*entity.SearchLogQueryStat, 0)
+	err = sr.data.DB.Context(ctx).Table(entity.SearchLog{}.TableName()).
+		Select("query, SUM(search_count) AS search_count, SUM(zero_result_count) AS zero_result_count, SUM(click_count) AS click_count").
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		GroupBy("query").
+		OrderBy("search_count DESC").
+		Limit(limit).
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetZeroResultQueries get the queries that have no result most frequently between the dates, both inclusive
+func (sr *searchLogRepo) GetZeroResultQueries(ctx context.Context, startDate, endDate string, limit int) (
+	stats []*entity.SearchLogQueryStat, err error) {
+	stats = make([]*entity.SearchLogQueryStat, 0)
+	err = sr.data.DB.Context(ctx).Table(entity.SearchLog{}.TableName()).
+		Select("query, SUM(search_count) AS search_count, SUM(zero_result_count) AS zero_result_count, SUM(click_count) AS click_count").
+		Where("date >= ? AND date <= ?", startDate, endDate).
+		And("zero_result_count > ?", 0).
+		GroupBy("query").
+		OrderBy("zero_result_count DESC").
+		Limit(limit).
+		Find(&stats)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
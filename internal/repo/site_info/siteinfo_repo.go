@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/reload"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
@@ -96,7 +97,7 @@ func (sr *siteInfoRepo) getCache(ctx context.Context, siteType string) (siteInfo
 func (sr *siteInfoRepo) setCache(ctx context.Context, siteType string, siteInfo *entity.SiteInfo) {
 	siteInfoCache, _ := json.Marshal(siteInfo)
 	err := sr.data.Cache.SetString(ctx,
-		constant.SiteInfoCacheKey+siteType, string(siteInfoCache), constant.SiteInfoCacheTime)
+		constant.SiteInfoCacheKey+siteType, string(siteInfoCache), reload.SiteInfoCacheTime())
 	if err != nil {
 		log.Error(err)
 	}
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sitemap
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/sitemap"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// sitemapRepo sitemap repository
+type sitemapRepo struct {
+	data *data.Data
+}
+
+// NewSitemapRepo new repository
+func NewSitemapRepo(data *data.Data) sitemap.SitemapRepo {
+	return &sitemapRepo{
+		data: data,
+	}
+}
+
+// CountSitemapItems count the objects which should be in the sitemap
+func (sr *sitemapRepo) CountSitemapItems(ctx context.Context, sitemapType string) (count int64, err error) {
+	session, bean := sr.sitemapSession(ctx, sitemapType)
+	count, err = session.Count(bean)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountSitemapItemsBefore count the objects before the object, it is the position of the object in the sitemap
+func (sr *sitemapRepo) CountSitemapItemsBefore(ctx context.Context, sitemapType, objectID string) (count int64, err error) {
+	session, bean := sr.sitemapSession(ctx, sitemapType)
+	count, err = session.And("id < ?", objectID).Count(bean)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetSitemapItems get the objects of the sitemap page, order by id so the existing pages are stable
+func (sr *sitemapRepo) GetSitemapItems(ctx context.Context, sitemapType string, page, pageSize int) (
+	items []*schema.SitemapItem, err error) {
+	items = make([]*schema.SitemapItem, 0)
+	session, _ := sr.sitemapSession(ctx, sitemapType)
+	session.Asc("id").Limit(pageSize, (page-1)*pageSize)
+
+	switch sitemapType {
+	case schema.SitemapTypeQuestion:
+		rows := make([]*entity.Question, 0)
+		if err = session.Cols("id", "title", "created_at", "post_update_time").Find(&rows); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, row := range rows {
+			item := &schema.SitemapItem{ID: row.ID, Title: row.Title, UpdateTime: row.PostUpdateTime}
+			if row.PostUpdateTime.IsZero() {
+				item.UpdateTime = row.CreatedAt
+			}
+			items = append(items, item)
+		}
+	case schema.SitemapTypeTag:
+		rows := make([]*entity.Tag, 0)
+		if err = session.Cols("id", "slug_name", "updated_at").Find(&rows); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, row := range rows {
+			items = append(items, &schema.SitemapItem{ID: row.SlugName, UpdateTime: row.UpdatedAt})
+		}
+	case schema.SitemapTypeUser:
+		rows := make([]*entity.User, 0)
+		if err = session.Cols("id", "username", "updated_at").Find(&rows); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, row := range rows {
+			items = append(items, &schema.SitemapItem{ID: row.Username, UpdateTime: row.UpdatedAt})
+		}
+	case schema.SitemapTypePage:
+		rows := make([]*entity.Page, 0)
+		if err = session.Cols("id", "slug_name", "title", "updated_at").Find(&rows); err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		for _, row := range rows {
+			items = append(items, &schema.SitemapItem{ID: row.SlugName, Title: row.Title, UpdateTime: row.UpdatedAt})
+		}
+	}
+	return items, nil
+}
+
+// sitemapSession the conditions of the objects which should be in the sitemap
+func (sr *sitemapRepo) sitemapSession(ctx context.Context, sitemapType string) (session *xorm.Session, bean any) {
+	session = sr.data.DB.Context(ctx)
+	switch sitemapType {
+	case schema.SitemapTypeTag:
+		session.Where("status = ?", entity.TagStatusAvailable).And("main_tag_id = ?", 0)
+		return session, &entity.Tag{}
+	case schema.SitemapTypeUser:
+		session.Where("status = ?", entity.UserStatusAvailable)
+		return session, &entity.User{}
+	case schema.SitemapTypePage:
+		session.Where("status = ?", entity.PageStatusPublished)
+		return session, &entity.Page{}
+	default:
+		session.Where("`show` = ?", entity.QuestionShow).
+			In("status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed})
+		return session, &entity.Question{}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package slow_query
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/slow_query"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// slowQueryRepo slow query repository
+type slowQueryRepo struct {
+	data *data.Data
+}
+
+// NewSlowQueryRepo new repository
+func NewSlowQueryRepo(data *data.Data) slow_query.SlowQueryRepo {
+	return &slowQueryRepo{
+		data: data,
+	}
+}
+
+// AddSlowQueries add the slow queries to the ones of the same statements
+func (sr *slowQueryRepo) AddSlowQueries(ctx context.Context, queries []*entity.SlowQuery) (err error) {
+	if len(queries) == 0 {
+		return nil
+	}
+	fingerprints := make([]string, 0, len(queries))
+	for _, query := range queries {
+		fingerprints = append(fingerprints, query.Fingerprint)
+	}
+	_, err = sr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		existing := make([]*entity.SlowQuery, 0)
+		if err = session.In("fingerprint", fingerprints).Find(&existing); err != nil {
+			return nil, err
+		}
+		existingMapping := make(map[string]*entity.SlowQuery, len(existing))
+		for _, query := range existing {
+			existingMapping[query.Fingerprint] = query
+		}
+		for _, query := range queries {
+			old, ok := existingMapping[query.Fingerprint]
+			if !ok {
+				if _, err = session.Insert(query); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			old.Sample = query.Sample
+			old.Count += query.Count
+			old.TotalTime += query.TotalTime
+			if query.MaxTime > old.MaxTime {
+				old.MaxTime = query.MaxTime
+			}
+			old.LastSeenAt = query.LastSeenAt
+			cols := []string{"sample", "count", "total_time", "max_time", "last_seen_at"}
+			if len(query.Plan) > 0 {
+				old.Plan = query.Plan
+				cols = append(cols, "plan")
+			}
+			if _, err = session.ID(old.ID).Cols(cols...).Update(old); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetSlowQueryPage get the slow queries ordered by the total time, the max time, the count or the last time
+func (sr *slowQueryRepo) GetSlowQueryPage(ctx context.Context, page, pageSize int, orderBy string) (
+	queries []*entity.SlowQuery, total int64, err error) {
+	queries = make([]*entity.SlowQuery, 0)
+	session := sr.data.DB.Context(ctx)
+	switch orderBy {
+	case "max":
+		session.Desc("max_time")
+	case "count":
+		session.Desc("count")
+	case "latest":
+		session.Desc("last_seen_at")
+	default:
+		session.Desc("total_time")
+	}
+	session.Desc("id")
+	total, err = pager.Help(page, pageSize, &queries, &entity.SlowQuery{}, session)
+	if err != nil {
+		return nil, 0, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return queries, total, nil
+}
+
+// ClearSlowQueries remove all the slow queries
+func (sr *slowQueryRepo) ClearSlowQueries(ctx context.Context) (err error) {
+	_, err = sr.data.DB.Context(ctx).Where("1 = 1").Delete(&entity.SlowQuery{})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
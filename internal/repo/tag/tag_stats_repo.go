@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const tagStatsBatchSize = 500
+
+// tagStatsRepo tag stats repository
+type tagStatsRepo struct {
+	data *data.Data
+}
+
+// NewTagStatsRepo new repository
+func NewTagStatsRepo(data *data.Data) tag.TagStatsRepo {
+	return &tagStatsRepo{
+		data: data,
+	}
+}
+
+// GetTagQuestionsByTime get the questions of tag which created in the time range
+func (tr *tagStatsRepo) GetTagQuestionsByTime(ctx context.Context, tagID string, startTime, endTime time.Time) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	session := tr.data.DB.Context(ctx).Table("question").
+		Select("question.id, question.created_at, question.answer_count, question.accepted_answer_id").
+		Join("INNER", "tag_rel", "question.id = tag_rel.object_id").
+		Where("tag_rel.tag_id = ?", tagID).
+		And("tag_rel.status = ?", entity.TagRelStatusAvailable).
+		In("question.status", []int{entity.QuestionStatusAvailable, entity.QuestionStatusClosed}).
+		And("question.show = ?", entity.QuestionShow).
+		And("question.created_at >= ?", startTime).
+		And("question.created_at <= ?", endTime)
+	err = session.Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetAnswersByQuestionIDs get available answers of the questions
+func (tr *tagStatsRepo) GetAnswersByQuestionIDs(ctx context.Context, questionIDs []string) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	for start := 0; start < len(questionIDs); start += tagStatsBatchSize {
+		end := start + tagStatsBatchSize
+		if end > len(questionIDs) {
+			end = len(questionIDs)
+		}
+		batch := make([]*entity.Answer, 0)
+		err = tr.data.DB.Context(ctx).
+			Select("id, question_id, user_id, created_at, vote_count, adopted").
+			In("question_id", questionIDs[start:end]).
+			And("status = ?", entity.AnswerStatusAvailable).
+			Find(&batch)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		answers = append(answers, batch...)
+	}
+	return answers, nil
+}
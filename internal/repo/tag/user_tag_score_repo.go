@@ -0,0 +1,124 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package tag
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+const userTagScoreInsertBatchSize = 500
+
+// userTagScoreRepo user tag score repository
+type userTagScoreRepo struct {
+	data *data.Data
+}
+
+// NewUserTagScoreRepo new repository
+func NewUserTagScoreRepo(data *data.Data) tag.UserTagScoreRepo {
+	return &userTagScoreRepo{
+		data: data,
+	}
+}
+
+// GetAnswersAfterID get the available answers whose id is greater than the last id, ordered by id
+func (ur *userTagScoreRepo) GetAnswersAfterID(ctx context.Context, lastID string, limit int) (
+	answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = ur.data.DB.Context(ctx).
+		Select("id, question_id, user_id, created_at, vote_count, adopted").
+		Where("id > ?", converter.StringToInt64(lastID)).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Asc("id").
+		Limit(limit).
+		Find(&answers)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return answers, nil
+}
+
+// GetQuestionTagIDs get the available tag ids of the questions, the key is question id
+func (ur *userTagScoreRepo) GetQuestionTagIDs(ctx context.Context, questionIDs []string) (
+	tagIDsMapping map[string][]string, err error) {
+	tagIDsMapping = make(map[string][]string)
+	if len(questionIDs) == 0 {
+		return tagIDsMapping, nil
+	}
+	tagRelList := make([]*entity.TagRel, 0)
+	err = ur.data.DB.Context(ctx).
+		Select("object_id, tag_id").
+		In("object_id", questionIDs).
+		And("status = ?", entity.TagRelStatusAvailable).
+		Find(&tagRelList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	for _, tagRel := range tagRelList {
+		tagIDsMapping[tagRel.ObjectID] = append(tagIDsMapping[tagRel.ObjectID], tagRel.TagID)
+	}
+	return tagIDsMapping, nil
+}
+
+// ReplaceUserTagScores replace all the scores with the new ones
+func (ur *userTagScoreRepo) ReplaceUserTagScores(ctx context.Context, scores []*entity.UserTagScore) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+		if _, err = session.Where("1 = 1").Delete(&entity.UserTagScore{}); err != nil {
+			return nil, err
+		}
+		for start := 0; start < len(scores); start += userTagScoreInsertBatchSize {
+			end := start + userTagScoreInsertBatchSize
+			if end > len(scores) {
+				end = len(scores)
+			}
+			if _, err = session.Insert(scores[start:end]); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// GetTagTopScores get the highest scores in the tag
+func (ur *userTagScoreRepo) GetTagTopScores(ctx context.Context, tagID string, limit int) (
+	scores []*entity.UserTagScore, err error) {
+	scores = make([]*entity.UserTagScore, 0)
+	err = ur.data.DB.Context(ctx).
+		Where("tag_id = ?", tagID).
+		Desc("score").
+		Asc("user_id").
+		Limit(limit).
+		Find(&scores)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return scores, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_auto_subscription
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/tag_auto_subscription"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// tagAutoSubscriptionRepo tag auto subscription repository
+type tagAutoSubscriptionRepo struct {
+	data *data.Data
+}
+
+// NewTagAutoSubscriptionRepo new repository
+func NewTagAutoSubscriptionRepo(data *data.Data) tag_auto_subscription.TagAutoSubscriptionRepo {
+	return &tagAutoSubscriptionRepo{
+		data: data,
+	}
+}
+
+// AddDigestItems add the questions waiting for the digest
+func (tr *tagAutoSubscriptionRepo) AddDigestItems(ctx context.Context, items []*entity.TagSubscriptionDigest) (err error) {
+	if _, err = tr.data.DB.Context(ctx).Insert(items); err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDigestUserIDs get the ids of the users who have the questions waiting for the digest
+func (tr *tagAutoSubscriptionRepo) GetDigestUserIDs(ctx context.Context) (userIDs []string, err error) {
+	userIDs = make([]string, 0)
+	err = tr.data.DB.Context(ctx).Table(entity.TagSubscriptionDigest{}.TableName()).Distinct("user_id").
+		Find(&userIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetDigestItems get the questions waiting for the digest of the user
+func (tr *tagAutoSubscriptionRepo) GetDigestItems(ctx context.Context, userID string) (
+	items []*entity.TagSubscriptionDigest, err error) {
+	items = make([]*entity.TagSubscriptionDigest, 0)
+	err = tr.data.DB.Context(ctx).Where("user_id = ?", userID).Asc("id").Find(&items)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// RemoveDigestItems remove the sent digest items
+func (tr *tagAutoSubscriptionRepo) RemoveDigestItems(ctx context.Context, ids []int) (err error) {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err = tr.data.DB.Context(ctx).In("id", ids).Delete(&entity.TagSubscriptionDigest{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -22,6 +22,7 @@ package tag_common
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/unique"
 	"github.com/segmentfault/pacman/errors"
 	"xorm.io/builder"
+	"xorm.io/xorm"
 )
 
 // tagCommonRepo tag repository
@@ -167,6 +169,14 @@ func (tr *tagCommonRepo) GetTagByID(ctx context.Context, tagID string, includeDe
 func (tr *tagCommonRepo) GetTagPage(ctx context.Context, page, pageSize int, tag *entity.Tag, queryCond string) (
 	tagList []*entity.Tag, total int64, err error,
 ) {
+	if len(tag.SlugName) == 0 && len(tag.DisplayName) == 0 {
+		var ok bool
+		tagList, total, ok, err = tr.getTagPageFromSummary(ctx, page, pageSize, queryCond)
+		if err != nil || ok {
+			return tagList, total, err
+		}
+	}
+
 	tagList = make([]*entity.Tag, 0)
 	session := tr.data.DB.Context(ctx)
 
@@ -220,6 +230,137 @@ func (tr *tagCommonRepo) GetTagPage(ctx context.Context, page, pageSize int, tag
 	return
 }
 
+// tagSummaryPositions the position column of the tag summary of each sort order
+var tagSummaryPositions = map[string]string{
+	"popular": "popular_position",
+	"name":    "name_position",
+	"newest":  "newest_position",
+}
+
+// getTagPageFromSummary read the tag page of the available main tags from the precomputed tag summary,
+// it is not ok when the summary is not built yet
+func (tr *tagCommonRepo) getTagPageFromSummary(ctx context.Context, page, pageSize int, queryCond string) (
+	tagList []*entity.Tag, total int64, ok bool, err error) {
+	positionColumn, ok := tagSummaryPositions[queryCond]
+	if !ok {
+		return nil, 0, false, nil
+	}
+	total, err = tr.data.DB.Context(ctx).Count(&entity.TagSummary{})
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if total == 0 {
+		return nil, 0, false, nil
+	}
+
+	page, pageSize = pager.ValPageAndPageSize(page, pageSize)
+	summaries := make([]*entity.TagSummary, 0)
+	err = tr.data.DB.Context(ctx).Asc(positionColumn).Limit(pageSize, (page-1)*pageSize).Find(&summaries)
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	tagIDs := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		tagIDs = append(tagIDs, summary.TagID)
+	}
+
+	tagList = make([]*entity.Tag, 0, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return tagList, total, true, nil
+	}
+	tags := make([]*entity.Tag, 0, len(tagIDs))
+	err = tr.data.DB.Context(ctx).In("id", tagIDs).
+		Where(builder.Eq{"status": entity.TagStatusAvailable, "main_tag_id": 0}).
+		Find(&tags)
+	if err != nil {
+		return nil, 0, false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tags))
+	for _, t := range tags {
+		tagMapping[t.ID] = t
+	}
+	for _, tagID := range tagIDs {
+		if t, exist := tagMapping[tagID]; exist {
+			tagList = append(tagList, t)
+		}
+	}
+	return tagList, total, true, nil
+}
+
+// RefreshTagSummary rebuild the tag summary with the available main tags, only the changed tags are written
+func (tr *tagCommonRepo) RefreshTagSummary(ctx context.Context) (err error) {
+	tags := make([]*entity.Tag, 0)
+	err = tr.data.DB.Context(ctx).Cols("id", "slug_name", "question_count", "created_at").
+		Where(builder.Eq{"status": entity.TagStatusAvailable, "main_tag_id": 0}).
+		Find(&tags)
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	summaries := make(map[string]*entity.TagSummary, len(tags))
+	for _, t := range tags {
+		summaries[t.ID] = &entity.TagSummary{TagID: t.ID, QuestionCount: t.QuestionCount}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		if tags[i].QuestionCount != tags[j].QuestionCount {
+			return tags[i].QuestionCount > tags[j].QuestionCount
+		}
+		return tags[i].SlugName < tags[j].SlugName
+	})
+	for i, t := range tags {
+		summaries[t.ID].PopularPosition = i + 1
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].SlugName < tags[j].SlugName
+	})
+	for i, t := range tags {
+		summaries[t.ID].NamePosition = i + 1
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].CreatedAt.After(tags[j].CreatedAt)
+	})
+	for i, t := range tags {
+		summaries[t.ID].NewestPosition = i + 1
+	}
+
+	_, err = tr.data.DB.Transaction(func(session *xorm.Session) (result interface{}, err error) {
+		session = session.Context(ctx)
+		existing := make([]*entity.TagSummary, 0)
+		if err = session.Find(&existing); err != nil {
+			return nil, err
+		}
+		for _, old := range existing {
+			summary, ok := summaries[old.TagID]
+			if !ok {
+				if _, err = session.ID(old.ID).Delete(&entity.TagSummary{}); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			delete(summaries, old.TagID)
+			if summary.QuestionCount == old.QuestionCount && summary.PopularPosition == old.PopularPosition &&
+				summary.NamePosition == old.NamePosition && summary.NewestPosition == old.NewestPosition {
+				continue
+			}
+			_, err = session.ID(old.ID).
+				Cols("question_count", "popular_position", "name_position", "newest_position").
+				Update(summary)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, summary := range summaries {
+			if _, err = session.Insert(summary); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 // AddTagList add tag
 func (tr *tagCommonRepo) AddTagList(ctx context.Context, tagList []*entity.Tag) (err error) {
 	addTags := make([]*entity.Tag, 0)
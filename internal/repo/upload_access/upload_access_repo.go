@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package upload_access
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/upload_access"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// uploadAccessRepo upload access repository
+type uploadAccessRepo struct {
+	data *data.Data
+}
+
+// NewUploadAccessRepo new repository
+func NewUploadAccessRepo(data *data.Data) upload_access.UploadAccessRepo {
+	return &uploadAccessRepo{
+		data: data,
+	}
+}
+
+// GetQuestionsByUploadPath get the questions which contain the file in the question, the answers or the comments
+func (ur *uploadAccessRepo) GetQuestionsByUploadPath(ctx context.Context, filePath string, limit int) (
+	questions []*entity.Question, err error) {
+	questions = make([]*entity.Question, 0)
+	pattern := "%" + filePath + "%"
+	questionIDs := make([]string, 0)
+	for _, table := range []struct {
+		name, column string
+	}{
+		{name: "question", column: "id"},
+		{name: "answer", column: "question_id"},
+		{name: "comment", column: "question_id"},
+	} {
+		ids := make([]string, 0)
+		err = ur.data.DB.Context(ctx).Table(table.name).Cols(table.column).
+			Where("original_text LIKE ?", pattern).Limit(limit).Find(&ids)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		questionIDs = append(questionIDs, ids...)
+	}
+	if len(questionIDs) == 0 {
+		return questions, nil
+	}
+	err = ur.data.DB.Context(ctx).In("id", questionIDs).Limit(limit).Find(&questions)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
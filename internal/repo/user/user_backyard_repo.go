@@ -26,14 +26,20 @@ import (
 
 	"xorm.io/builder"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/data"
 	"github.com/apache/incubator-answer/internal/base/pager"
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/auth"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/plugin"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
+	"xorm.io/xorm"
 )
 
 // userAdminRepo user repository
@@ -107,6 +113,419 @@ func (ur *userAdminRepo) UpdateUserPassword(ctx context.Context, userID string,
 	return
 }
 
+// TransferUserContent transfer the content and the reputation gained from it to another user,
+// all the content of the user is transferred if the object id is empty.
+func (ur *userAdminRepo) TransferUserContent(ctx context.Context, fromUserID, toUserID, objectID string) (err error) {
+	contentTables := []string{entity.Question{}.TableName(), entity.Answer{}.TableName(), (&entity.Comment{}).TableName(),
+		entity.Article{}.TableName()}
+	if len(objectID) > 0 {
+		objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+		if err != nil {
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+		switch objectType {
+		case constant.QuestionObjectType:
+			contentTables = []string{entity.Question{}.TableName()}
+		case constant.AnswerObjectType:
+			contentTables = []string{entity.Answer{}.TableName()}
+		case constant.ArticleObjectType:
+			contentTables = []string{entity.Article{}.TableName()}
+		default:
+			return errors.BadRequest(reason.ObjectNotFound)
+		}
+	}
+
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		return nil, ur.transferUserContent(session, fromUserID, toUserID, objectID, contentTables)
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// MergeUser move everything of the duplicate user to the primary user, then delete the duplicate user
+func (ur *userAdminRepo) MergeUser(ctx context.Context, mergeLog *entity.UserMergeLog, duplicateEmail string) (err error) {
+	fromUserID, toUserID := mergeLog.DuplicateUserID, mergeLog.PrimaryUserID
+	contentTables := []string{entity.Question{}.TableName(), entity.Answer{}.TableName(), (&entity.Comment{}).TableName(),
+		entity.Article{}.TableName()}
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if err := ur.cancelConflictActivities(session, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+		if err := ur.transferUserContent(session, fromUserID, toUserID, "", contentTables); err != nil {
+			return nil, err
+		}
+		if err := ur.transferUserFollow(session, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+		if err := ur.transferCollection(session, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+		if err := ur.transferUserRelations(session, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+		if err := ur.transferUserOnboarding(session, fromUserID, toUserID); err != nil {
+			return nil, err
+		}
+		_, err := session.ID(fromUserID).Update(&entity.User{
+			Status:    entity.UserStatusDeleted,
+			EMail:     duplicateEmail,
+			DeletedAt: time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = session.Insert(mergeLog)
+		return nil, err
+	})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+
+	userCacheInfo := &entity.UserCacheInfo{UserID: fromUserID, UserStatus: entity.UserStatusDeleted}
+	if err = ur.authRepo.SetUserStatus(ctx, fromUserID, userCacheInfo); err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
+// transferUserContent transfer the content with its revisions, activities, reputation and co-authors,
+// only the given object is transferred if the object id is not empty.
+func (ur *userAdminRepo) transferUserContent(session *xorm.Session, fromUserID, toUserID, objectID string,
+	contentTables []string) (err error) {
+	contentCond := builder.Eq{"user_id": fromUserID}
+	objectCond := builder.Eq{"user_id": fromUserID}
+	if len(objectID) > 0 {
+		contentCond = builder.Eq{"id": objectID, "user_id": fromUserID}
+		objectCond = builder.Eq{"object_id": objectID, "user_id": fromUserID}
+	}
+
+	for _, table := range contentTables {
+		_, err = session.Table(table).Where(contentCond).Update(map[string]interface{}{"user_id": toUserID})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = session.Table(entity.Revision{}.TableName()).Where(objectCond).
+		Update(map[string]interface{}{"user_id": toUserID})
+	if err != nil {
+		return err
+	}
+
+	// the reputation follows the activities which gained it
+	rank, err := session.Table(entity.Activity{}.TableName()).Where(objectCond).
+		And("has_rank = 1 AND cancelled = 0").SumInt(&entity.Activity{}, "`rank`")
+	if err != nil {
+		return err
+	}
+	_, err = session.Table(entity.Activity{}.TableName()).Where(objectCond).
+		Update(map[string]interface{}{"user_id": toUserID})
+	if err != nil {
+		return err
+	}
+	if len(objectID) == 0 {
+		_, err = session.Table(entity.Activity{}.TableName()).Where("trigger_user_id = ?", fromUserID).
+			Update(map[string]interface{}{"trigger_user_id": toUserID})
+		if err != nil {
+			return err
+		}
+	}
+	if err = ur.transferUserRank(session, fromUserID, toUserID, int(rank)); err != nil {
+		return err
+	}
+	return ur.transferCoAuthor(session, fromUserID, toUserID, objectID)
+}
+
+// cancelConflictActivities cancel the activities of the duplicate user which the primary user also has,
+// such as voting or following the same object, so that they are not counted twice after merging.
+func (ur *userAdminRepo) cancelConflictActivities(session *xorm.Session, fromUserID, toUserID string) (err error) {
+	activities := make([]*entity.Activity, 0)
+	err = session.Where("cancelled = ?", entity.ActivityAvailable).
+		And(builder.Or(builder.Eq{"user_id": fromUserID}, builder.Eq{"trigger_user_id": fromUserID})).
+		Find(&activities)
+	if err != nil {
+		return err
+	}
+	fromTriggerID, toTriggerID := converter.StringToInt64(fromUserID), converter.StringToInt64(toUserID)
+	for _, act := range activities {
+		cond := builder.Eq{
+			"object_id":       act.ObjectID,
+			"activity_type":   act.ActivityType,
+			"cancelled":       entity.ActivityAvailable,
+			"user_id":         act.UserID,
+			"trigger_user_id": act.TriggerUserID,
+		}
+		if act.UserID == fromUserID {
+			cond["user_id"] = toUserID
+		}
+		if act.TriggerUserID == fromTriggerID {
+			cond["trigger_user_id"] = toTriggerID
+		}
+		exist, err := session.Where(cond).Exist(&entity.Activity{})
+		if err != nil {
+			return err
+		}
+		if !exist {
+			continue
+		}
+		_, err = session.ID(act.ID).Cols("cancelled", "cancelled_at").Update(&entity.Activity{
+			Cancelled:   entity.ActivityCancelled,
+			CancelledAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		// the reputation gained by other users is taken back, the reputation of the duplicate user is not transferred
+		if act.UserID != fromUserID && act.HasRank == 1 && act.Rank != 0 && !plugin.RankAgentEnabled() {
+			user := &entity.User{}
+			if _, err = session.ID(act.UserID).Cols("`rank`").Get(user); err != nil {
+				return err
+			}
+			userRank := user.Rank - act.Rank
+			if userRank < 1 {
+				userRank = 1
+			}
+			if _, err = session.ID(act.UserID).Cols("`rank`").Update(&entity.User{Rank: userRank}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// transferUserFollow transfer the users followed by the user and the followers of the user,
+// the relations which the primary user already has or which point to the primary user self are removed.
+func (ur *userAdminRepo) transferUserFollow(session *xorm.Session, fromUserID, toUserID string) (err error) {
+	toFollows := make([]*entity.UserFollow, 0)
+	err = session.Where("user_id = ? OR follow_user_id = ?", toUserID, toUserID).Find(&toFollows)
+	if err != nil {
+		return err
+	}
+	followingIDs, followerIDs := []string{toUserID}, []string{toUserID}
+	for _, follow := range toFollows {
+		if follow.UserID == toUserID {
+			followingIDs = append(followingIDs, follow.FollowUserID)
+		} else {
+			followerIDs = append(followerIDs, follow.UserID)
+		}
+	}
+	_, err = session.Where("user_id = ?", fromUserID).In("follow_user_id", followingIDs).Delete(&entity.UserFollow{})
+	if err != nil {
+		return err
+	}
+	_, err = session.Where("follow_user_id = ?", fromUserID).In("user_id", followerIDs).Delete(&entity.UserFollow{})
+	if err != nil {
+		return err
+	}
+	_, err = session.Table(entity.UserFollow{}.TableName()).Where("user_id = ?", fromUserID).
+		Update(map[string]interface{}{"user_id": toUserID})
+	if err != nil {
+		return err
+	}
+	_, err = session.Table(entity.UserFollow{}.TableName()).Where("follow_user_id = ?", fromUserID).
+		Update(map[string]interface{}{"follow_user_id": toUserID})
+	return err
+}
+
+// transferCollection transfer the collections and the collection groups. The collections of the objects which the
+// primary user already collected are removed, and the default group of the duplicate user is merged into the primary one.
+func (ur *userAdminRepo) transferCollection(session *xorm.Session, fromUserID, toUserID string) (err error) {
+	collectedIDs := make([]string, 0)
+	err = session.Table(&entity.Collection{}).Where("user_id = ?", toUserID).Cols("object_id").Find(&collectedIDs)
+	if err != nil {
+		return err
+	}
+	if len(collectedIDs) > 0 {
+		duplicateIDs := make([]string, 0)
+		err = session.Table(&entity.Collection{}).Where("user_id = ?", fromUserID).In("object_id", collectedIDs).
+			Cols("object_id").Find(&duplicateIDs)
+		if err != nil {
+			return err
+		}
+		if len(duplicateIDs) > 0 {
+			_, err = session.Where("user_id = ?", fromUserID).In("object_id", duplicateIDs).Delete(&entity.Collection{})
+			if err != nil {
+				return err
+			}
+			_, err = session.In("id", duplicateIDs).Decr("collection_count").Update(&entity.Question{})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	toDefaultGroup := &entity.CollectionGroup{}
+	exist, err := session.Where("user_id = ? AND default_group = ?", toUserID, schema.CGDefault).Get(toDefaultGroup)
+	if err != nil {
+		return err
+	}
+	if exist {
+		fromDefaultGroups := make([]*entity.CollectionGroup, 0)
+		err = session.Where("user_id = ? AND default_group = ?", fromUserID, schema.CGDefault).Find(&fromDefaultGroups)
+		if err != nil {
+			return err
+		}
+		for _, group := range fromDefaultGroups {
+			_, err = session.Table(&entity.Collection{}).Where("user_collection_group_id = ?", group.ID).
+				Update(map[string]interface{}{"user_collection_group_id": toDefaultGroup.ID})
+			if err != nil {
+				return err
+			}
+			if _, err = session.ID(group.ID).Delete(&entity.CollectionGroup{}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, bean := range []interface{}{&entity.CollectionGroup{}, &entity.Collection{}} {
+		_, err = session.Table(bean).Where("user_id = ?", fromUserID).Update(map[string]interface{}{"user_id": toUserID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferUserRelations transfer the other records of the user, such as the notifications, the reports, the poll votes
+// and the assignments. For the tables which allow one record for each user and key, the records of the duplicate user
+// which the primary user already has are removed.
+func (ur *userAdminRepo) transferUserRelations(session *xorm.Session, fromUserID, toUserID string) (err error) {
+	userColumns := []struct {
+		bean   interface{}
+		column string
+	}{
+		{&entity.Notification{}, "user_id"},
+		{&entity.Report{}, "user_id"},
+		{&entity.Report{}, "reported_user_id"},
+		{&entity.QuestionPoll{}, "user_id"},
+		{&entity.AnswerVerification{}, "user_id"},
+		{&entity.QuestionShareLink{}, "user_id"},
+		{&entity.TagSubscriptionDigest{}, "user_id"},
+	}
+	for _, c := range userColumns {
+		_, err = session.Table(c.bean).Where(builder.Eq{c.column: fromUserID}).
+			Update(map[string]interface{}{c.column: toUserID})
+		if err != nil {
+			return err
+		}
+	}
+
+	uniqueKeys := []struct {
+		bean interface{}
+		key  string
+	}{
+		{&entity.QuestionPollVote{}, "poll_id"},
+		{&entity.QuestionAssignment{}, "question_id"},
+		{&entity.TagExpert{}, "tag_id"},
+		{&entity.UserTagScore{}, "tag_id"},
+	}
+	for _, u := range uniqueKeys {
+		keys := make([]string, 0)
+		if err = session.Table(u.bean).Where("user_id = ?", toUserID).Cols(u.key).Find(&keys); err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			if _, err = session.Where("user_id = ?", fromUserID).In(u.key, keys).Delete(u.bean); err != nil {
+				return err
+			}
+		}
+		_, err = session.Table(u.bean).Where("user_id = ?", fromUserID).Update(map[string]interface{}{"user_id": toUserID})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transferUserOnboarding transfer the onboarding of the user. If both users have one, a step is completed
+// when either of them completed it, and the earliest time is kept.
+func (ur *userAdminRepo) transferUserOnboarding(session *xorm.Session, fromUserID, toUserID string) (err error) {
+	fromOnboarding := &entity.UserOnboarding{}
+	exist, err := session.Where("user_id = ?", fromUserID).Get(fromOnboarding)
+	if err != nil || !exist {
+		return err
+	}
+	toOnboarding := &entity.UserOnboarding{}
+	exist, err = session.Where("user_id = ?", toUserID).Get(toOnboarding)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		_, err = session.ID(fromOnboarding.ID).Cols("user_id").Update(&entity.UserOnboarding{UserID: toUserID})
+		return err
+	}
+
+	toOnboarding.EmailVerifiedAt = earliestTime(toOnboarding.EmailVerifiedAt, fromOnboarding.EmailVerifiedAt)
+	toOnboarding.AvatarSetAt = earliestTime(toOnboarding.AvatarSetAt, fromOnboarding.AvatarSetAt)
+	toOnboarding.TagsFollowedAt = earliestTime(toOnboarding.TagsFollowedAt, fromOnboarding.TagsFollowedAt)
+	toOnboarding.FirstPostAt = earliestTime(toOnboarding.FirstPostAt, fromOnboarding.FirstPostAt)
+	toOnboarding.TourTakenAt = earliestTime(toOnboarding.TourTakenAt, fromOnboarding.TourTakenAt)
+	toOnboarding.CompletedAt = earliestTime(toOnboarding.CompletedAt, fromOnboarding.CompletedAt)
+	_, err = session.ID(toOnboarding.ID).Cols("email_verified_at", "avatar_set_at", "tags_followed_at",
+		"first_post_at", "tour_taken_at", "completed_at").Update(toOnboarding)
+	if err != nil {
+		return err
+	}
+	_, err = session.ID(fromOnboarding.ID).Delete(&entity.UserOnboarding{})
+	return err
+}
+
+// earliestTime return the earlier one of the two times, the zero time means not set
+func earliestTime(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
+
+// transferUserRank move the rank from one user to another, the rank of the user is at least 1
+func (ur *userAdminRepo) transferUserRank(session *xorm.Session, fromUserID, toUserID string, rank int) (err error) {
+	// IMPORTANT: If user center enabled the rank agent, then we should not change user rank.
+	if plugin.RankAgentEnabled() || rank == 0 {
+		return nil
+	}
+	fromUser := &entity.User{}
+	exist, err := session.ID(fromUserID).Cols("`rank`").Get(fromUser)
+	if err != nil || !exist {
+		return err
+	}
+	fromRank := fromUser.Rank - rank
+	if fromRank < 1 {
+		fromRank = 1
+	}
+	if _, err = session.ID(fromUserID).Cols("`rank`").Update(&entity.User{Rank: fromRank}); err != nil {
+		return err
+	}
+	_, err = session.ID(toUserID).Incr("`rank`", rank).Update(&entity.User{})
+	return err
+}
+
+// transferCoAuthor the user can not be the co-author of the content which is transferred to the user
+func (ur *userAdminRepo) transferCoAuthor(session *xorm.Session, fromUserID, toUserID, objectID string) (err error) {
+	if len(objectID) > 0 {
+		_, err = session.Where("object_id = ? AND user_id = ?", objectID, toUserID).Delete(&entity.CoAuthor{})
+		return err
+	}
+	coAuthors := make([]*entity.CoAuthor, 0)
+	if err = session.Where("user_id = ?", toUserID).Find(&coAuthors); err != nil {
+		return err
+	}
+	if len(coAuthors) > 0 {
+		objectIDs := make([]string, 0, len(coAuthors))
+		for _, coAuthor := range coAuthors {
+			objectIDs = append(objectIDs, coAuthor.ObjectID)
+		}
+		_, err = session.Where("user_id = ?", fromUserID).In("object_id", objectIDs).Delete(&entity.CoAuthor{})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = session.Where("user_id = ?", fromUserID).Cols("user_id").Update(&entity.CoAuthor{UserID: toUserID})
+	return err
+}
+
 // GetUserInfo get user info
 func (ur *userAdminRepo) GetUserInfo(ctx context.Context, userID string) (user *entity.User, exist bool, err error) {
 	user = &entity.User{}
@@ -175,3 +594,27 @@ func (ur *userAdminRepo) GetUserPage(ctx context.Context, page, pageSize int, us
 	tryToDecorateUserListFromUserCenter(ctx, ur.data, users)
 	return
 }
+
+// AddUserImpersonationLog add the record of the admin signing in as the user
+func (ur *userAdminRepo) AddUserImpersonationLog(ctx context.Context, impersonationLog *entity.UserImpersonationLog) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(impersonationLog)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserImpersonationLogPage get the records of the admins signing in as the users, the latest first
+func (ur *userAdminRepo) GetUserImpersonationLogPage(ctx context.Context, page, pageSize int, userID string) (
+	impersonationLogs []*entity.UserImpersonationLog, total int64, err error) {
+	impersonationLogs = make([]*entity.UserImpersonationLog, 0)
+	session := ur.data.DB.Context(ctx).Desc("id")
+	if len(userID) > 0 {
+		session.Where("user_id = ?", userID)
+	}
+	total, err = pager.Help(page, pageSize, &impersonationLogs, &entity.UserImpersonationLog{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -140,7 +140,8 @@ func (ur *userRepo) UpdateNoticeStatus(ctx context.Context, userID string, notic
 }
 
 func (ur *userRepo) UpdatePass(ctx context.Context, userID, pass string) error {
-	_, err := ur.data.DB.Context(ctx).Where("id = ?", userID).Cols("pass").Update(&entity.User{Pass: pass})
+	_, err := ur.data.DB.Context(ctx).Where("id = ?", userID).Cols("pass", "pass_updated_at").
+		Update(&entity.User{Pass: pass, PassUpdatedAt: time.Now()})
 	if err != nil {
 		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
 	}
@@ -155,6 +156,38 @@ func (ur *userRepo) UpdateEmail(ctx context.Context, userID, email string) (err
 	return
 }
 
+// GetUnverifiedUsersToRemind get the available users whose email is not verified
+// and have not been reminded since remindedBefore
+func (ur *userRepo) GetUnverifiedUsersToRemind(ctx context.Context, remindedBefore time.Time, maxReminderCount, limit int) (
+	userList []*entity.User, err error) {
+	userList = make([]*entity.User, 0)
+	session := ur.data.DB.Context(ctx)
+	session.Where("mail_status = ?", entity.EmailStatusToBeVerified)
+	session.Where("status = ?", entity.UserStatusAvailable)
+	session.Where("created_at < ?", remindedBefore)
+	session.Where("verification_reminder_count < ?", maxReminderCount)
+	session.Where("(verification_reminded_at IS NULL OR verification_reminded_at < ?)", remindedBefore)
+	session.OrderBy("id ASC")
+	session.Limit(limit)
+	err = session.Find(&userList)
+	if err != nil {
+		return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return userList, nil
+}
+
+// UpdateVerificationReminded record the user has been reminded to verify the email
+func (ur *userRepo) UpdateVerificationReminded(ctx context.Context, userID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("id = ?", userID).
+		Incr("verification_reminder_count").
+		Cols("verification_reminded_at").
+		Update(&entity.User{VerificationRemindedAt: time.Now()})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return nil
+}
+
 func (ur *userRepo) UpdateUserInterface(ctx context.Context, userID, language, colorSchema string) (err error) {
 	session := ur.data.DB.Context(ctx).Where("id = ?", userID)
 	_, err = session.Cols("language", "color_scheme").Update(&entity.User{Language: language, ColorScheme: colorSchema})
@@ -164,6 +197,16 @@ func (ur *userRepo) UpdateUserInterface(ctx context.Context, userID, language, c
 	return
 }
 
+// UpdateUserPreferences update the language, time zone and date format of the user
+func (ur *userRepo) UpdateUserPreferences(ctx context.Context, userID, language, timeZone, dateFormat string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("id = ?", userID).Cols("language", "time_zone", "date_format").
+		Update(&entity.User{Language: language, TimeZone: timeZone, DateFormat: dateFormat})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
 // UpdateInfo update user info
 func (ur *userRepo) UpdateInfo(ctx context.Context, userInfo *entity.User) (err error) {
 	_, err = ur.data.DB.Context(ctx).Where("id = ?", userInfo.ID).
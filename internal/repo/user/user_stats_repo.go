@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const userStatsBatchSize = 500
+
+// userStatsRepo user stats repository
+type userStatsRepo struct {
+	data *data.Data
+}
+
+// NewUserStatsRepo new repository
+func NewUserStatsRepo(data *data.Data) content.UserStatsRepo {
+	return &userStatsRepo{
+		data: data,
+	}
+}
+
+// GetUserActivities get the activities of user created after the start time
+func (ur *userStatsRepo) GetUserActivities(ctx context.Context, userID string, activityTypes []int, startTime time.Time) (
+	activities []*entity.Activity, err error) {
+	activities = make([]*entity.Activity, 0)
+	err = ur.data.DB.Context(ctx).Cols("activity_type", "created_at").
+		Where("user_id = ?", userID).
+		And("cancelled = ?", entity.ActivityAvailable).
+		And("created_at >= ?", startTime).
+		In("activity_type", activityTypes).
+		Find(&activities)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserAnswers get the available answers of user
+func (ur *userStatsRepo) GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error) {
+	answers = make([]*entity.Answer, 0)
+	err = ur.data.DB.Context(ctx).Cols("id", "question_id", "vote_count", "adopted").
+		Where("user_id = ?", userID).
+		And("status = ?", entity.AnswerStatusAvailable).
+		Find(&answers)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetTagRelsByQuestionIDs get the available tag relations of the questions
+func (ur *userStatsRepo) GetTagRelsByQuestionIDs(ctx context.Context, questionIDs []string) (
+	tagRels []*entity.TagRel, err error) {
+	tagRels = make([]*entity.TagRel, 0)
+	for start := 0; start < len(questionIDs); start += userStatsBatchSize {
+		end := start + userStatsBatchSize
+		if end > len(questionIDs) {
+			end = len(questionIDs)
+		}
+		batch := make([]*entity.TagRel, 0)
+		err = ur.data.DB.Context(ctx).Cols("object_id", "tag_id").
+			In("object_id", questionIDs[start:end]).
+			And("status = ?", entity.TagRelStatusAvailable).
+			Find(&batch)
+		if err != nil {
+			return nil, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+		}
+		tagRels = append(tagRels, batch...)
+	}
+	return tagRels, nil
+}
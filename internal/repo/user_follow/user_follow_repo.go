@@ -0,0 +1,162 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_follow
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/user_follow"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+)
+
+// userFollowRepo user follow repository
+type userFollowRepo struct {
+	data *data.Data
+}
+
+// NewUserFollowRepo new repository
+func NewUserFollowRepo(data *data.Data) user_follow.UserFollowRepo {
+	return &userFollowRepo{
+		data: data,
+	}
+}
+
+// Follow add the follow relation, do nothing if it already exists
+func (ur *userFollowRepo) Follow(ctx context.Context, userID, followUserID string) (err error) {
+	exist, err := ur.data.DB.Context(ctx).Exist(&entity.UserFollow{UserID: userID, FollowUserID: followUserID})
+	if err != nil {
+		return errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	if exist {
+		return nil
+	}
+	_, err = ur.data.DB.Context(ctx).Insert(&entity.UserFollow{UserID: userID, FollowUserID: followUserID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// Unfollow remove the follow relation
+func (ur *userFollowRepo) Unfollow(ctx context.Context, userID, followUserID string) (err error) {
+	_, err = ur.data.DB.Context(ctx).Where("user_id = ? AND follow_user_id = ?", userID, followUserID).
+		Delete(&entity.UserFollow{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// IsFollowing check whether the user is following the other user
+func (ur *userFollowRepo) IsFollowing(ctx context.Context, userID, followUserID string) (following bool, err error) {
+	following, err = ur.data.DB.Context(ctx).Exist(&entity.UserFollow{UserID: userID, FollowUserID: followUserID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountFollowers count the followers of user
+func (ur *userFollowRepo) CountFollowers(ctx context.Context, userID string) (count int64, err error) {
+	count, err = ur.data.DB.Context(ctx).Count(&entity.UserFollow{FollowUserID: userID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountFollowing count the users followed by user
+func (ur *userFollowRepo) CountFollowing(ctx context.Context, userID string) (count int64, err error) {
+	count, err = ur.data.DB.Context(ctx).Count(&entity.UserFollow{UserID: userID})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetFollowerPage get the followers of user by page
+func (ur *userFollowRepo) GetFollowerPage(ctx context.Context, userID string, page, pageSize int) (
+	follows []*entity.UserFollow, total int64, err error) {
+	follows = make([]*entity.UserFollow, 0)
+	session := ur.data.DB.Context(ctx).Where("follow_user_id = ?", userID).Desc("id")
+	total, err = pager.Help(page, pageSize, &follows, &entity.UserFollow{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetFollowingPage get the users followed by user by page
+func (ur *userFollowRepo) GetFollowingPage(ctx context.Context, userID string, page, pageSize int) (
+	follows []*entity.UserFollow, total int64, err error) {
+	follows = make([]*entity.UserFollow, 0)
+	session := ur.data.DB.Context(ctx).Where("user_id = ?", userID).Desc("id")
+	total, err = pager.Help(page, pageSize, &follows, &entity.UserFollow{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetFollowerIDs get all the follower ids of user
+func (ur *userFollowRepo) GetFollowerIDs(ctx context.Context, userID string) (followerIDs []string, err error) {
+	followerIDs = make([]string, 0)
+	err = ur.data.DB.Context(ctx).Table(entity.UserFollow{}.TableName()).
+		Select("user_id").Where("follow_user_id = ?", userID).Find(&followerIDs)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetFollowingActivityPage get the questions asked and the answers answered by the users followed by user by page,
+// the newest first. Only the available answers of the available and shown questions are included.
+func (ur *userFollowRepo) GetFollowingActivityPage(ctx context.Context, userID string, askedType, answeredType int,
+	page, pageSize int) (activities []*entity.Activity, total int64, err error) {
+	activities = make([]*entity.Activity, 0)
+	followingCond := builder.Select("follow_user_id").
+		From(entity.UserFollow{}.TableName()).
+		Where(builder.Eq{"user_id": userID})
+	shownQuestionCond := builder.Eq{
+		"question.status": entity.QuestionStatusAvailable,
+		"question.show":   entity.QuestionShow,
+	}
+	askedCond := builder.Eq{"activity_type": askedType}.And(builder.In("object_id",
+		builder.Select("question.id").From(entity.Question{}.TableName()).Where(shownQuestionCond)))
+	answeredCond := builder.Eq{"activity_type": answeredType}.And(builder.In("object_id",
+		builder.Select("answer.id").From(entity.Answer{}.TableName()).
+			InnerJoin(entity.Question{}.TableName(), "question.id = answer.question_id").
+			Where(builder.Eq{"answer.status": entity.AnswerStatusAvailable}.And(shownQuestionCond))))
+	session := ur.data.DB.Context(ctx).
+		Where(builder.In("user_id", followingCond)).
+		And("cancelled = ?", entity.ActivityAvailable).
+		And(builder.Or(askedCond, answeredCond)).
+		Desc("created_at")
+	total, err = pager.Help(page, pageSize, &activities, &entity.Activity{}, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
@@ -0,0 +1,153 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_onboarding
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/user_onboarding"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/builder"
+	"xorm.io/xorm"
+)
+
+// userOnboardingRepo user onboarding repository
+type userOnboardingRepo struct {
+	data          *data.Data
+	userRankRepo  rank.UserRankRepo
+	configService *config.ConfigService
+}
+
+// NewUserOnboardingRepo new repository
+func NewUserOnboardingRepo(
+	data *data.Data,
+	userRankRepo rank.UserRankRepo,
+	configService *config.ConfigService,
+) user_onboarding.UserOnboardingRepo {
+	return &userOnboardingRepo{
+		data:          data,
+		userRankRepo:  userRankRepo,
+		configService: configService,
+	}
+}
+
+// GetOnboarding get the onboarding progress of the user
+func (ur *userOnboardingRepo) GetOnboarding(ctx context.Context, userID string) (
+	onboarding *entity.UserOnboarding, exist bool, err error) {
+	onboarding = &entity.UserOnboarding{}
+	exist, err = ur.data.DB.Context(ctx).Where("user_id = ?", userID).Get(onboarding)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetOrAddOnboarding get the onboarding progress of the user, add it if not exist
+func (ur *userOnboardingRepo) GetOrAddOnboarding(ctx context.Context, userID string) (
+	onboarding *entity.UserOnboarding, err error) {
+	onboarding, exist, err := ur.GetOnboarding(ctx, userID)
+	if err != nil || exist {
+		return onboarding, err
+	}
+	onboarding = &entity.UserOnboarding{UserID: userID}
+	if _, err = ur.data.DB.Context(ctx).Insert(onboarding); err == nil {
+		return onboarding, nil
+	}
+	// the concurrent request may add it first
+	onboarding, exist, err = ur.GetOnboarding(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.InternalServer(reason.DatabaseError).WithStack()
+	}
+	return onboarding, nil
+}
+
+// UpdateOnboardingSteps update the completed time of the steps
+func (ur *userOnboardingRepo) UpdateOnboardingSteps(ctx context.Context, onboarding *entity.UserOnboarding) (err error) {
+	_, err = ur.data.DB.Context(ctx).ID(onboarding.ID).
+		Cols("email_verified_at", "avatar_set_at", "tags_followed_at", "first_post_at", "tour_taken_at").
+		Update(onboarding)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CompleteOnboarding mark the onboarding as completed and grant the reputation bonus in the same transaction
+func (ur *userOnboardingRepo) CompleteOnboarding(ctx context.Context, userID string, completedAt time.Time) (
+	completed bool, err error) {
+	cfg, err := ur.configService.GetConfigByKey(ctx, user_onboarding.UserOnboarded)
+	if err != nil {
+		return false, err
+	}
+	addActivity := &entity.Activity{
+		UserID:           userID,
+		ObjectID:         "0",
+		OriginalObjectID: "0",
+		ActivityType:     cfg.ID,
+		Rank:             cfg.GetIntValue(),
+		HasRank:          1,
+	}
+
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (result any, err error) {
+		session = session.Context(ctx)
+
+		user := &entity.User{}
+		exist, err := session.ID(userID).ForUpdate().Get(user)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, fmt.Errorf("user not exist")
+		}
+
+		affected, err := session.Where(builder.Eq{"user_id": userID}).And(builder.IsNull{"completed_at"}).
+			Cols("completed_at").Update(&entity.UserOnboarding{CompletedAt: completedAt})
+		if err != nil {
+			return nil, err
+		}
+		if affected == 0 {
+			return nil, nil
+		}
+		completed = true
+
+		if addActivity.Rank != 0 {
+			err = ur.userRankRepo.ChangeUserRank(ctx, session, addActivity.UserID, user.Rank, addActivity.Rank)
+			if err != nil {
+				return nil, err
+			}
+		}
+		_, err = session.Insert(addActivity)
+		return nil, err
+	})
+	if err != nil {
+		return false, errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return completed, nil
+}
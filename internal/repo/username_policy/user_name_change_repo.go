@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package username_policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// userNameChangeRepo user name change repository
+type userNameChangeRepo struct {
+	data *data.Data
+}
+
+// NewUserNameChangeRepo new repository
+func NewUserNameChangeRepo(data *data.Data) username_policy.UserNameChangeRepo {
+	return &userNameChangeRepo{
+		data: data,
+	}
+}
+
+// AddUserNameChanges add user name changes
+func (ur *userNameChangeRepo) AddUserNameChanges(ctx context.Context, changes []*entity.UserNameChange) (err error) {
+	_, err = ur.data.DB.Context(ctx).Insert(changes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// CountUserSelfNameChanges count the changes made by the user since the time, the changes made by others are excluded
+func (ur *userNameChangeRepo) CountUserSelfNameChanges(ctx context.Context, userID string, since time.Time) (
+	count int64, err error) {
+	count, err = ur.data.DB.Context(ctx).
+		Where("user_id = ? AND operator_user_id = ? AND created_at >= ?", userID, userID, since).
+		Count(&entity.UserNameChange{})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserNameChangesSince get the changes of the user since the time, the newest first
+func (ur *userNameChangeRepo) GetUserNameChangesSince(ctx context.Context, userID string, since time.Time) (
+	changes []*entity.UserNameChange, err error) {
+	changes = make([]*entity.UserNameChange, 0)
+	err = ur.data.DB.Context(ctx).Where("user_id = ? AND created_at >= ?", userID, since).
+		Desc("id").Find(&changes)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUserNameChangePage get user name change page, the newest first
+func (ur *userNameChangeRepo) GetUserNameChangePage(ctx context.Context, page, pageSize int,
+	cond *entity.UserNameChange) (changes []*entity.UserNameChange, total int64, err error) {
+	changes = make([]*entity.UserNameChange, 0)
+	session := ur.data.DB.Context(ctx).Desc("id")
+	total, err = pager.Help(page, pageSize, &changes, cond, session)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
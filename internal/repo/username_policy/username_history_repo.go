@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package username_policy
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
+	"github.com/segmentfault/pacman/errors"
+	"xorm.io/xorm"
+)
+
+// usernameHistoryRepo username history repository
+type usernameHistoryRepo struct {
+	data *data.Data
+}
+
+// NewUsernameHistoryRepo new repository
+func NewUsernameHistoryRepo(data *data.Data) username_policy.UsernameHistoryRepo {
+	return &usernameHistoryRepo{
+		data: data,
+	}
+}
+
+// SaveUsernameHistory keep the old username of the user, the new username is removed from the history
+// because the user gets it back
+func (ur *usernameHistoryRepo) SaveUsernameHistory(ctx context.Context, userID, oldUsername, newUsername string) (err error) {
+	_, err = ur.data.DB.Transaction(func(session *xorm.Session) (interface{}, error) {
+		session = session.Context(ctx)
+		if _, err := session.In("username", []string{oldUsername, newUsername}).Delete(&entity.UsernameHistory{}); err != nil {
+			return nil, err
+		}
+		_, err := session.Insert(&entity.UsernameHistory{UserID: userID, Username: oldUsername})
+		return nil, err
+	})
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
+
+// GetUsernameHistory get the history of the username
+func (ur *usernameHistoryRepo) GetUsernameHistory(ctx context.Context, username string) (
+	history *entity.UsernameHistory, exist bool, err error) {
+	history = &entity.UsernameHistory{}
+	exist, err = ur.data.DB.Context(ctx).Where("username = ?", username).Get(history)
+	if err != nil {
+		err = errors.InternalServer(reason.DatabaseError).WithError(err).WithStack()
+	}
+	return
+}
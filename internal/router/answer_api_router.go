@@ -23,38 +23,105 @@ import (
 	"github.com/apache/incubator-answer/internal/base/middleware"
 	"github.com/apache/incubator-answer/internal/controller"
 	"github.com/apache/incubator-answer/internal/controller_admin"
+	"github.com/apache/incubator-answer/internal/service/permission"
 	"github.com/gin-gonic/gin"
 )
 
 type AnswerAPIRouter struct {
-	langController          *controller.LangController
-	userController          *controller.UserController
-	commentController       *controller.CommentController
-	reportController        *controller.ReportController
-	voteController          *controller.VoteController
-	tagController           *controller.TagController
-	followController        *controller.FollowController
-	collectionController    *controller.CollectionController
-	questionController      *controller.QuestionController
-	answerController        *controller.AnswerController
-	searchController        *controller.SearchController
-	revisionController      *controller.RevisionController
-	rankController          *controller.RankController
-	adminUserController     *controller_admin.UserAdminController
-	reasonController        *controller.ReasonController
-	themeController         *controller_admin.ThemeController
-	adminSiteInfoController *controller_admin.SiteInfoController
-	siteInfoController      *controller.SiteInfoController
-	notificationController  *controller.NotificationController
-	dashboardController     *controller.DashboardController
-	uploadController        *controller.UploadController
-	activityController      *controller.ActivityController
-	roleController          *controller_admin.RoleController
-	pluginController        *controller_admin.PluginController
-	permissionController    *controller.PermissionController
-	userPluginController    *controller.UserPluginController
-	reviewController        *controller.ReviewController
-	metaController          *controller.MetaController
+	langController              *controller.LangController
+	userController              *controller.UserController
+	commentController           *controller.CommentController
+	reportController            *controller.ReportController
+	voteController              *controller.VoteController
+	tagController               *controller.TagController
+	followController            *controller.FollowController
+	collectionController        *controller.CollectionController
+	questionController          *controller.QuestionController
+	answerController            *controller.AnswerController
+	searchController            *controller.SearchController
+	revisionController          *controller.RevisionController
+	rankController              *controller.RankController
+	adminUserController         *controller_admin.UserAdminController
+	reasonController            *controller.ReasonController
+	themeController             *controller_admin.ThemeController
+	adminSiteInfoController     *controller_admin.SiteInfoController
+	siteInfoController          *controller.SiteInfoController
+	notificationController      *controller.NotificationController
+	dashboardController         *controller.DashboardController
+	uploadController            *controller.UploadController
+	activityController          *controller.ActivityController
+	roleController              *controller_admin.RoleController
+	pluginController            *controller_admin.PluginController
+	permissionController        *controller.PermissionController
+	userPluginController        *controller.UserPluginController
+	reviewController            *controller.ReviewController
+	metaController              *controller.MetaController
+	analyticsController         *controller.AnalyticsController
+	permalinkController         *controller.PermalinkController
+	oembedController            *controller.OEmbedController
+	pageController              *controller.PageController
+	announcementController      *controller.AnnouncementController
+	profileFieldController      *controller_admin.ProfileFieldController
+	invitationController        *controller.InvitationController
+	emailDomainController       *controller.EmailDomainController
+	assignmentController        *controller.QuestionAssignmentController
+	questionSLAController       *controller_admin.QuestionSLAController
+	articleController           *controller.ArticleController
+	questionPollController      *controller.QuestionPollController
+	coAuthorController          *controller.CoAuthorController
+	leaderboardController       *controller.LeaderboardController
+	pendingDeletionCtrl         *controller.PendingDeletionController
+	schedulerController         *controller_admin.SchedulerController
+	permissionPolicyCtrl        *controller_admin.PermissionPolicyController
+	questionShareController     *controller.QuestionShareController
+	deadLinkController          *controller.DeadLinkController
+	aiAssistantController       *controller.AIAssistantController
+	aiAssistantAdminCtrl        *controller_admin.AIAssistantController
+	titleQualityController      *controller.TitleQualityController
+	reviewReminderCtrl          *controller.ReviewReminderController
+	conversionController        *controller.ConversionController
+	questionMergeController     *controller.QuestionMergeController
+	revisionCompactionCtrl      *controller_admin.RevisionCompactionController
+	mediaProxyController        *controller.MediaProxyController
+	mediaProxyAdminCtrl         *controller_admin.MediaProxyController
+	loginProtectionCtrl         *controller_admin.LoginProtectionController
+	emailWebhookController      *controller.EmailWebhookController
+	emailDeliveryCtrl           *controller_admin.EmailDeliveryController
+	emailTemplateCtrl           *controller_admin.EmailTemplateController
+	userOnboardingCtrl          *controller.UserOnboardingController
+	experimentController        *controller.ExperimentController
+	experimentAdminCtrl         *controller_admin.ExperimentController
+	featureFlagAdminCtrl        *controller_admin.FeatureFlagController
+	configReloadCtrl            *controller_admin.ConfigReloadController
+	eventStreamCtrl             *controller_admin.EventStreamController
+	inboundQuestionCtrl         *controller.InboundQuestionController
+	inboundQuestionAdmin        *controller_admin.InboundQuestionController
+	chatIntegrationCtrl         *controller.ChatIntegrationController
+	chatIntegrationAdmin        *controller_admin.ChatIntegrationController
+	gitHubCtrl                  *controller.GitHubController
+	gitHubAdminCtrl             *controller_admin.GitHubController
+	jiraCtrl                    *controller.JiraController
+	jiraAdminCtrl               *controller_admin.JiraController
+	maintenanceCtrl             *controller_admin.MaintenanceController
+	slowQueryCtrl               *controller_admin.SlowQueryController
+	contentLimitCtrl            *controller.ContentLimitController
+	contentLimitAdminCtrl       *controller_admin.ContentLimitController
+	bannedWordCtrl              *controller_admin.BannedWordController
+	usernamePolicyCtrl          *controller_admin.UsernamePolicyController
+	nameChangeCtrl              *controller.NameChangeController
+	questionBatchCtrl           *controller.QuestionBatchController
+	deltaSyncCtrl               *controller.DeltaSyncController
+	moderationRuleCtrl          *controller_admin.ModerationRuleController
+	questionWorkflowCtrl        *controller.QuestionWorkflowController
+	adminQuestionWorkflowCtrl   *controller_admin.QuestionWorkflowController
+	answerVerificationCtrl      *controller.AnswerVerificationController
+	adminAnswerVerificationCtrl *controller_admin.AnswerVerificationController
+	tagAutoSubscriptionCtrl     *controller_admin.TagAutoSubscriptionController
+	moderationTriageCtrl        *controller_admin.ModerationTriageController
+	legalHoldCtrl               *controller_admin.LegalHoldController
+	ipLoggingCtrl               *controller_admin.IPLoggingController
+	editLockCtrl                *controller.EditLockController
+	pageCache                   *middleware.PageCacheMiddleware
 }
 
 func NewAnswerAPIRouter(
@@ -86,36 +153,168 @@ func NewAnswerAPIRouter(
 	userPluginController *controller.UserPluginController,
 	reviewController *controller.ReviewController,
 	metaController *controller.MetaController,
+	analyticsController *controller.AnalyticsController,
+	permalinkController *controller.PermalinkController,
+	oembedController *controller.OEmbedController,
+	pageController *controller.PageController,
+	announcementController *controller.AnnouncementController,
+	profileFieldController *controller_admin.ProfileFieldController,
+	invitationController *controller.InvitationController,
+	emailDomainController *controller.EmailDomainController,
+	assignmentController *controller.QuestionAssignmentController,
+	questionSLAController *controller_admin.QuestionSLAController,
+	articleController *controller.ArticleController,
+	questionPollController *controller.QuestionPollController,
+	coAuthorController *controller.CoAuthorController,
+	leaderboardController *controller.LeaderboardController,
+	pendingDeletionCtrl *controller.PendingDeletionController,
+	schedulerController *controller_admin.SchedulerController,
+	permissionPolicyCtrl *controller_admin.PermissionPolicyController,
+	questionShareController *controller.QuestionShareController,
+	deadLinkController *controller.DeadLinkController,
+	aiAssistantController *controller.AIAssistantController,
+	aiAssistantAdminCtrl *controller_admin.AIAssistantController,
+	titleQualityController *controller.TitleQualityController,
+	reviewReminderCtrl *controller.ReviewReminderController,
+	conversionController *controller.ConversionController,
+	questionMergeController *controller.QuestionMergeController,
+	revisionCompactionCtrl *controller_admin.RevisionCompactionController,
+	mediaProxyController *controller.MediaProxyController,
+	mediaProxyAdminCtrl *controller_admin.MediaProxyController,
+	loginProtectionCtrl *controller_admin.LoginProtectionController,
+	emailWebhookController *controller.EmailWebhookController,
+	emailDeliveryCtrl *controller_admin.EmailDeliveryController,
+	emailTemplateCtrl *controller_admin.EmailTemplateController,
+	userOnboardingCtrl *controller.UserOnboardingController,
+	experimentController *controller.ExperimentController,
+	experimentAdminCtrl *controller_admin.ExperimentController,
+	featureFlagAdminCtrl *controller_admin.FeatureFlagController,
+	configReloadCtrl *controller_admin.ConfigReloadController,
+	eventStreamCtrl *controller_admin.EventStreamController,
+	inboundQuestionCtrl *controller.InboundQuestionController,
+	inboundQuestionAdmin *controller_admin.InboundQuestionController,
+	chatIntegrationCtrl *controller.ChatIntegrationController,
+	chatIntegrationAdmin *controller_admin.ChatIntegrationController,
+	gitHubCtrl *controller.GitHubController,
+	gitHubAdminCtrl *controller_admin.GitHubController,
+	jiraCtrl *controller.JiraController,
+	jiraAdminCtrl *controller_admin.JiraController,
+	maintenanceCtrl *controller_admin.MaintenanceController,
+	slowQueryCtrl *controller_admin.SlowQueryController,
+	contentLimitCtrl *controller.ContentLimitController,
+	contentLimitAdminCtrl *controller_admin.ContentLimitController,
+	bannedWordCtrl *controller_admin.BannedWordController,
+	usernamePolicyCtrl *controller_admin.UsernamePolicyController,
+	nameChangeCtrl *controller.NameChangeController,
+	questionBatchCtrl *controller.QuestionBatchController,
+	deltaSyncCtrl *controller.DeltaSyncController,
+	moderationRuleCtrl *controller_admin.ModerationRuleController,
+	questionWorkflowCtrl *controller.QuestionWorkflowController,
+	adminQuestionWorkflowCtrl *controller_admin.QuestionWorkflowController,
+	answerVerificationCtrl *controller.AnswerVerificationController,
+	adminAnswerVerificationCtrl *controller_admin.AnswerVerificationController,
+	tagAutoSubscriptionCtrl *controller_admin.TagAutoSubscriptionController,
+	moderationTriageCtrl *controller_admin.ModerationTriageController,
+	legalHoldCtrl *controller_admin.LegalHoldController,
+	ipLoggingCtrl *controller_admin.IPLoggingController,
+	editLockCtrl *controller.EditLockController,
+	pageCache *middleware.PageCacheMiddleware,
 ) *AnswerAPIRouter {
 	return &AnswerAPIRouter{
-		langController:          langController,
-		userController:          userController,
-		commentController:       commentController,
-		reportController:        reportController,
-		voteController:          voteController,
-		tagController:           tagController,
-		followController:        followController,
-		collectionController:    collectionController,
-		questionController:      questionController,
-		answerController:        answerController,
-		searchController:        searchController,
-		revisionController:      revisionController,
-		rankController:          rankController,
-		adminUserController:     adminUserController,
-		reasonController:        reasonController,
-		themeController:         themeController,
-		adminSiteInfoController: adminSiteInfoController,
-		notificationController:  notificationController,
-		siteInfoController:      siteInfoController,
-		dashboardController:     dashboardController,
-		uploadController:        uploadController,
-		activityController:      activityController,
-		roleController:          roleController,
-		pluginController:        pluginController,
-		permissionController:    permissionController,
-		userPluginController:    userPluginController,
-		reviewController:        reviewController,
-		metaController:          metaController,
+		langController:              langController,
+		userController:              userController,
+		commentController:           commentController,
+		reportController:            reportController,
+		voteController:              voteController,
+		tagController:               tagController,
+		followController:            followController,
+		collectionController:        collectionController,
+		questionController:          questionController,
+		answerController:            answerController,
+		searchController:            searchController,
+		revisionController:          revisionController,
+		rankController:              rankController,
+		adminUserController:         adminUserController,
+		reasonController:            reasonController,
+		themeController:             themeController,
+		adminSiteInfoController:     adminSiteInfoController,
+		notificationController:      notificationController,
+		siteInfoController:          siteInfoController,
+		dashboardController:         dashboardController,
+		uploadController:            uploadController,
+		activityController:          activityController,
+		roleController:              roleController,
+		pluginController:            pluginController,
+		permissionController:        permissionController,
+		userPluginController:        userPluginController,
+		reviewController:            reviewController,
+		metaController:              metaController,
+		analyticsController:         analyticsController,
+		permalinkController:         permalinkController,
+		oembedController:            oembedController,
+		pageController:              pageController,
+		announcementController:      announcementController,
+		profileFieldController:      profileFieldController,
+		invitationController:        invitationController,
+		emailDomainController:       emailDomainController,
+		assignmentController:        assignmentController,
+		questionSLAController:       questionSLAController,
+		articleController:           articleController,
+		questionPollController:      questionPollController,
+		coAuthorController:          coAuthorController,
+		leaderboardController:       leaderboardController,
+		pendingDeletionCtrl:         pendingDeletionCtrl,
+		schedulerController:         schedulerController,
+		permissionPolicyCtrl:        permissionPolicyCtrl,
+		questionShareController:     questionShareController,
+		deadLinkController:          deadLinkController,
+		aiAssistantController:       aiAssistantController,
+		aiAssistantAdminCtrl:        aiAssistantAdminCtrl,
+		titleQualityController:      titleQualityController,
+		reviewReminderCtrl:          reviewReminderCtrl,
+		conversionController:        conversionController,
+		questionMergeController:     questionMergeController,
+		revisionCompactionCtrl:      revisionCompactionCtrl,
+		mediaProxyController:        mediaProxyController,
+		mediaProxyAdminCtrl:         mediaProxyAdminCtrl,
+		loginProtectionCtrl:         loginProtectionCtrl,
+		emailWebhookController:      emailWebhookController,
+		emailDeliveryCtrl:           emailDeliveryCtrl,
+		emailTemplateCtrl:           emailTemplateCtrl,
+		userOnboardingCtrl:          userOnboardingCtrl,
+		experimentController:        experimentController,
+		experimentAdminCtrl:         experimentAdminCtrl,
+		featureFlagAdminCtrl:        featureFlagAdminCtrl,
+		configReloadCtrl:            configReloadCtrl,
+		eventStreamCtrl:             eventStreamCtrl,
+		inboundQuestionCtrl:         inboundQuestionCtrl,
+		inboundQuestionAdmin:        inboundQuestionAdmin,
+		chatIntegrationCtrl:         chatIntegrationCtrl,
+		chatIntegrationAdmin:        chatIntegrationAdmin,
+		gitHubCtrl:                  gitHubCtrl,
+		gitHubAdminCtrl:             gitHubAdminCtrl,
+		jiraCtrl:                    jiraCtrl,
+		jiraAdminCtrl:               jiraAdminCtrl,
+		maintenanceCtrl:             maintenanceCtrl,
+		slowQueryCtrl:               slowQueryCtrl,
+		contentLimitCtrl:            contentLimitCtrl,
+		contentLimitAdminCtrl:       contentLimitAdminCtrl,
+		bannedWordCtrl:              bannedWordCtrl,
+		usernamePolicyCtrl:          usernamePolicyCtrl,
+		nameChangeCtrl:              nameChangeCtrl,
+		questionBatchCtrl:           questionBatchCtrl,
+		deltaSyncCtrl:               deltaSyncCtrl,
+		moderationRuleCtrl:          moderationRuleCtrl,
+		questionWorkflowCtrl:        questionWorkflowCtrl,
+		adminQuestionWorkflowCtrl:   adminQuestionWorkflowCtrl,
+		answerVerificationCtrl:      answerVerificationCtrl,
+		adminAnswerVerificationCtrl: adminAnswerVerificationCtrl,
+		tagAutoSubscriptionCtrl:     tagAutoSubscriptionCtrl,
+		moderationTriageCtrl:        moderationTriageCtrl,
+		legalHoldCtrl:               legalHoldCtrl,
+		ipLoggingCtrl:               ipLoggingCtrl,
+		editLockCtrl:                editLockCtrl,
+		pageCache:                   pageCache,
 	}
 }
 
@@ -123,6 +322,8 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	// i18n
 	r.GET("/language/config", a.langController.GetLangMapping)
 	r.GET("/language/options", a.langController.GetUserLangOptions)
+	// error catalog
+	r.GET("/errors", a.langController.GetErrorCatalog)
 
 	// siteinfo
 	r.GET("/siteinfo", a.siteInfoController.GetSiteInfo)
@@ -134,34 +335,75 @@ func (a *AnswerAPIRouter) RegisterMustUnAuthAnswerAPIRouter(authUserMiddleware *
 	routerGroup := r.Group("", middleware.BanAPIForUserCenter)
 	routerGroup.POST("/user/login/email", a.userController.UserEmailLogin)
 	routerGroup.POST("/user/register/email", a.userController.UserRegisterByEmail)
+	routerGroup.GET("/invitation", a.invitationController.CheckInvitation)
 	routerGroup.POST("/user/email/verification", a.userController.UserVerifyEmail)
 	routerGroup.PUT("/user/email", a.userController.UserChangeEmailVerify)
+	routerGroup.PUT("/user/email/change/authorization", a.userController.UserChangeEmailAuthorize)
 	routerGroup.POST("/user/password/reset", a.userController.RetrievePassWord)
 	routerGroup.POST("/user/password/replacement", a.userController.UseRePassWord)
 	routerGroup.PUT("/user/notification/unsubscribe", a.userController.UserUnsubscribeNotification)
 
+	// question review reminder
+	r.PUT("/question/review-reminder/acceptance", a.reviewReminderCtrl.AcceptAnswerByReminder)
+
 	// plugins
 	r.GET("/plugin/status", a.pluginController.GetAllPluginStatus)
+
+	// media proxy
+	r.GET("/media/proxy", a.mediaProxyController.ProxyMedia)
+
+	// email delivery webhook
+	r.POST("/email/webhook/:provider", a.emailWebhookController.HandleDeliveryWebhook)
+	// reply by email
+	r.POST("/email/inbound/:provider", a.emailWebhookController.HandleInboundWebhook)
+
+	// ask by api, the external systems are authorized by the api key of the inbound question settings
+	r.POST("/inbound/question", a.inboundQuestionCtrl.AddInboundQuestion)
+
+	// chat integration, the commands are verified by the signatures of Slack and Teams
+	r.POST("/chat/slack/command", a.chatIntegrationCtrl.HandleSlackCommand)
+	r.POST("/chat/teams/command", a.chatIntegrationCtrl.HandleTeamsCommand)
 }
 
 func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	// user
 	r.GET("/personal/user/info", a.userController.GetOtherUserInfoByUsername)
+	r.GET("/personal/user/stats", a.userController.GetUserStats)
+	r.GET("/personal/user/followers", a.followController.GetFollowers)
+	r.GET("/personal/user/following", a.followController.GetFollowing)
 	r.GET("/user/ranking", a.userController.UserRanking)
 	r.GET("/user/staff", a.userController.UserStaff)
 
 	// answer
 	r.GET("/answer/info", a.answerController.Get)
-	r.GET("/answer/page", a.answerController.AnswerList)
+	r.GET("/answer/page", a.pageCache.AnonymousCache("question_id"), a.answerController.AnswerList)
 	r.GET("/personal/answer/page", a.questionController.PersonalAnswerPage)
 
 	// question
-	r.GET("/question/info", a.questionController.GetQuestion)
+	r.GET("/question/info", a.questionController.AddQuestionPV, a.pageCache.AnonymousCache("id"),
+		a.questionController.GetQuestion)
 	r.GET("/question/invite", a.questionController.GetQuestionInviteUserInfo)
-	r.GET("/question/page", a.questionController.QuestionPage)
+	r.GET("/question/page", a.pageCache.AnonymousCache(""), a.questionController.QuestionPage)
 	r.GET("/question/similar/tag", a.questionController.SimilarQuestion)
+	r.POST("/question/batch", a.questionBatchCtrl.BatchGetQuestions)
+	r.GET("/question/detail", a.questionController.AddQuestionPV, a.pageCache.AnonymousCache("id"),
+		a.questionBatchCtrl.GetQuestionDetail)
+	r.GET("/question/workflow-state", a.questionWorkflowCtrl.GetQuestionWorkflowState)
 	r.GET("/personal/qa/top", a.questionController.UserTop)
 	r.GET("/personal/question/page", a.questionController.PersonalQuestionPage)
+	r.GET("/question/assignments", a.assignmentController.GetQuestionAssignments)
+
+	// article
+	r.GET("/article/info", a.articleController.GetArticle)
+	r.GET("/articles/page", a.articleController.GetArticlePage)
+	r.GET("/question/articles", a.articleController.GetQuestionArticles)
+
+	// co-author
+	r.GET("/post/co-authors", a.coAuthorController.GetCoAuthors)
+
+	// leaderboard
+	r.GET("/leaderboard/reputation", a.leaderboardController.GetReputationLeaderboard)
+	r.GET("/leaderboard/tag", a.leaderboardController.GetTagLeaderboard)
 
 	// comment
 	r.GET("/comment/page", a.commentController.GetCommentWithPage)
@@ -172,30 +414,63 @@ func (a *AnswerAPIRouter) RegisterUnAuthAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/revisions", a.revisionController.GetRevisionList)
 
 	// tag
-	r.GET("/tags/page", a.tagController.GetTagWithPage)
+	r.GET("/tags/page", a.pageCache.AnonymousCache(""), a.tagController.GetTagWithPage)
 	r.GET("/tags/following", a.tagController.GetFollowingTags)
-	r.GET("/tag", a.tagController.GetTagInfo)
+	r.GET("/tag", a.pageCache.AnonymousCache(""), a.tagController.GetTagInfo)
 	r.GET("/tags", a.tagController.GetTagsBySlugName)
 	r.GET("/tag/synonyms", a.tagController.GetTagSynonyms)
+	r.GET("/tags/:slug/stats", a.tagController.GetTagStats)
+	r.GET("/tags/:slug/top-answerers", a.tagController.GetTagTopAnswerers)
 
 	// search
 	r.GET("/search", a.searchController.Search)
 	r.GET("/search/desc", a.searchController.SearchDesc)
+	r.POST("/search/click", a.searchController.SearchClick)
 
 	// rank
 	r.GET("/personal/rank/page", a.rankController.GetRankPersonalWithPage)
 
 	// reaction
 	r.GET("/meta/reaction", a.metaController.GetReaction)
+
+	// share
+	r.GET("/share/link", a.permalinkController.GetShareLink)
+
+	// embed
+	r.GET("/oembed", a.oembedController.GetOEmbed)
+	r.GET("/embed/question", a.oembedController.GetEmbedQuestion)
+
+	// github
+	r.GET("/github/references", a.gitHubCtrl.GetReferences)
+
+	// jira
+	r.GET("/question/jira", a.jiraCtrl.GetIssueLink)
+
+	// page
+	r.GET("/pages", a.pageController.GetNavPageList)
+	r.GET("/page", a.pageController.GetPage)
+
+	// announcement
+	r.GET("/announcements", a.announcementController.GetActiveAnnouncements)
+
+	// content limit
+	r.GET("/content-limits", a.contentLimitCtrl.GetContentLimits)
 }
 
 func (a *AnswerAPIRouter) RegisterAuthUserWithAnyStatusAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/user/logout", a.userController.UserLogout)
-	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, a.userController.UserChangeEmailSendCode)
-	r.POST("/user/email/verification/send", middleware.BanAPIForUserCenter, a.userController.UserVerifyEmailSend)
+	r.POST("/user/email/change/code", middleware.BanAPIForUserCenter, middleware.BanAPIForImpersonation,
+		a.userController.UserChangeEmailSendCode)
+	r.POST("/user/email/verification/send", middleware.BanAPIForUserCenter, middleware.BanAPIForImpersonation,
+		a.userController.UserVerifyEmailSend)
+
+	// login sessions
+	r.GET("/user/sessions", a.userController.GetUserSessions)
+	r.DELETE("/user/session", middleware.BanAPIForImpersonation, a.userController.RevokeUserSession)
+	r.DELETE("/user/sessions", middleware.BanAPIForImpersonation, a.userController.RevokeOtherUserSessions)
 }
 
-func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
+func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(authUserMiddleware *middleware.AuthUserMiddleware, r *gin.RouterGroup) {
 	// revisions
 	r.GET("/revisions/unreviewed", a.revisionController.GetUnreviewedRevisionList)
 	r.PUT("/revisions/audit", a.revisionController.RevisionAudit)
@@ -210,11 +485,15 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// report
 	r.POST("/report", a.reportController.AddReport)
 	r.GET("/report/unreviewed/post", a.reportController.GetUnreviewedReportPostPage)
-	r.PUT("/report/review", a.reportController.ReviewReport)
+	r.PUT("/report/review", authUserMiddleware.MustPower(permission.ReportReview), a.reportController.ReviewReport)
+	r.GET("/dead-links/page", authUserMiddleware.MustPower(permission.ReportReview), a.deadLinkController.GetDeadLinkPage)
 
 	// review
 	r.GET("/review/pending/post/page", a.reviewController.GetUnreviewedPostPage)
 	r.PUT("/review/pending/post", a.reviewController.UpdateReview)
+	r.GET("/inbound/question/page", a.inboundQuestionCtrl.GetInboundQuestionPage)
+	r.POST("/question/jira", a.jiraCtrl.EscalateToJira)
+	r.PUT("/inbound/question/review", a.inboundQuestionCtrl.ReviewInboundQuestion)
 
 	// vote
 	r.POST("/vote/up", a.voteController.VoteUp)
@@ -223,9 +502,12 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// follow
 	r.POST("/follow", a.followController.Follow)
 	r.PUT("/follow/tags", a.followController.UpdateFollowTags)
+	r.POST("/follow/user", a.followController.FollowUser)
+	r.GET("/following/feed", a.followController.GetFollowingFeed)
 
 	// tag
 	r.GET("/question/tags", a.tagController.SearchTagLike)
+	r.POST("/tag/suggestions", a.tagController.GetTagSuggestions)
 	r.POST("/tag", a.tagController.AddTag)
 	r.PUT("/tag", a.tagController.UpdateTag)
 	r.POST("/tag/recover", a.tagController.RecoverTag)
@@ -244,9 +526,62 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.DELETE("/question", a.questionController.RemoveQuestion)
 	r.PUT("/question/status", a.questionController.CloseQuestion)
 	r.PUT("/question/operation", a.questionController.OperationQuestion)
+	r.PUT("/question/workflow-state", a.questionWorkflowCtrl.UpdateQuestionWorkflowState)
 	r.PUT("/question/reopen", a.questionController.ReopenQuestion)
 	r.GET("/question/similar", a.questionController.GetSimilarQuestions)
 	r.POST("/question/recover", a.questionController.QuestionRecover)
+	r.POST("/question/merge", a.questionMergeController.MergeQuestion)
+
+	// question assignment
+	r.POST("/question/assignment", a.assignmentController.AssignQuestion)
+	r.POST("/question/assignment/auto", a.assignmentController.AutoAssignQuestion)
+	r.DELETE("/question/assignment", a.assignmentController.UnassignQuestion)
+	r.GET("/question/assigned/page", a.assignmentController.GetMyAssignmentPage)
+
+	// article
+	r.POST("/article", a.articleController.AddArticle)
+	r.PUT("/article", a.articleController.UpdateArticle)
+	r.DELETE("/article", a.articleController.RemoveArticle)
+	r.POST("/answer/article", a.articleController.ConvertAnswerToArticle)
+
+	// conversion
+	r.POST("/comment/answer", authUserMiddleware.MustPower(permission.AnswerConvert),
+		a.conversionController.ConvertCommentToAnswer)
+	r.POST("/answer/comment", authUserMiddleware.MustPower(permission.AnswerConvert),
+		a.conversionController.ConvertAnswerToComment)
+
+	// question poll
+	r.POST("/question/poll", a.questionPollController.AddQuestionPoll)
+	r.DELETE("/question/poll", a.questionPollController.RemoveQuestionPoll)
+	r.POST("/question/poll/vote", a.questionPollController.VoteQuestionPoll)
+
+	// co-author
+	r.POST("/post/co-author", a.coAuthorController.AddCoAuthor)
+	r.DELETE("/post/co-author", a.coAuthorController.RemoveCoAuthor)
+
+	// question share
+	r.PUT("/question/visibility", a.questionShareController.UpdateQuestionVisibility)
+	r.GET("/question/share-links", a.questionShareController.GetQuestionShareLinks)
+	r.POST("/question/share-link", a.questionShareController.AddQuestionShareLink)
+	r.DELETE("/question/share-link", a.questionShareController.RemoveQuestionShareLink)
+	r.POST("/question/title/check", a.titleQualityController.CheckQuestionTitle)
+
+	// ai assistant
+	r.POST("/ai/answer-draft", a.aiAssistantController.GenerateAnswerDraft)
+	r.POST("/ai/question-summary", a.aiAssistantController.GenerateQuestionSummary)
+
+	// leaderboard
+	r.PUT("/user/leaderboard/opt-out", a.leaderboardController.UpdateLeaderboardOptOut)
+
+	// onboarding
+	r.GET("/user/onboarding", a.userOnboardingCtrl.GetUserOnboarding)
+	r.PUT("/user/onboarding/tour", a.userOnboardingCtrl.TakeOnboardingTour)
+
+	// experiment
+	r.POST("/experiment/event", a.experimentController.AddExperimentEvent)
+
+	// undo delete
+	r.POST("/undo-delete", a.pendingDeletionCtrl.UndoDelete)
 
 	// answer
 	r.POST("/answer", a.answerController.Add)
@@ -254,17 +589,35 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.POST("/answer/acceptance", a.answerController.Accepted)
 	r.DELETE("/answer", a.answerController.RemoveAnswer)
 	r.POST("/answer/recover", a.answerController.RecoverAnswer)
+	r.PUT("/answer/outdated", a.answerController.FlagAnswerOutdated)
+	r.POST("/answer/verification", a.answerVerificationCtrl.VerifyAnswer)
+	r.DELETE("/answer/verification", a.answerVerificationCtrl.RemoveAnswerVerification)
+
+	// edit lock
+	r.GET("/edit-lock", a.editLockCtrl.GetEditLock)
+	r.POST("/edit-lock", a.editLockCtrl.AcquireEditLock)
+	r.DELETE("/edit-lock", a.editLockCtrl.ReleaseEditLock)
 
 	// user
-	r.PUT("/user/password", middleware.BanAPIForUserCenter, a.userController.UserModifyPassWord)
+	// the user whose password is expired can still change it, see MustAuthAndAccountAvailable
+	r.PUT("/user/password", middleware.BanAPIForUserCenter, middleware.BanAPIForImpersonation,
+		a.userController.UserModifyPassWord)
 	r.PUT("/user/info", a.userController.UserUpdateInfo)
+	r.GET("/user/profile-fields", a.userController.GetUserProfileFields)
 	r.PUT("/user/interface", a.userController.UserUpdateInterface)
+	r.GET("/user/preferences", a.userController.GetUserPreferences)
+	r.PUT("/user/preferences", a.userController.UpdateUserPreferences)
 	r.GET("/user/notification/config", a.userController.GetUserNotificationConfig)
 	r.PUT("/user/notification/config", a.userController.UpdateUserNotificationConfig)
 	r.GET("/user/info/search", a.userController.SearchUserListByName)
+	r.GET("/user/name-changes/page", a.nameChangeCtrl.GetNameChangePage)
+
+	// delta sync
+	r.GET("/sync", a.deltaSyncCtrl.GetSyncChanges)
 
 	// vote
 	r.GET("/personal/vote/page", a.voteController.UserVotes)
+	r.GET("/vote/voters/page", a.voteController.ObjectVotes)
 
 	// reason
 	r.GET("/reasons", a.reasonController.Reasons)
@@ -278,6 +631,10 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	r.GET("/notification/page", a.notificationController.GetList)
 	r.PUT("/notification/read/state/all", a.notificationController.ClearUnRead)
 	r.PUT("/notification/read/state", a.notificationController.ClearIDUnRead)
+	r.GET("/notification/group/page", a.notificationController.GetGroupList)
+	r.PUT("/notification/read/state/group", a.notificationController.ClearGroupUnRead)
+	r.PUT("/notification/read/state/type", a.notificationController.ClearTypeUnRead)
+	r.GET("/notification/unread/count", a.notificationController.GetUnreadCount)
 
 	// upload file
 	r.POST("/file", a.uploadController.UploadFile)
@@ -286,6 +643,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 	// activity
 	r.GET("/activity/timeline", a.activityController.GetObjectTimeline)
 	r.GET("/activity/timeline/detail", a.activityController.GetObjectTimelineDetail)
+	r.GET("/activity/timeline/export", a.activityController.ExportObjectTimeline)
 
 	// plugin
 	r.GET("/user/plugin/configs", a.userPluginController.GetUserPluginList)
@@ -294,6 +652,19 @@ func (a *AnswerAPIRouter) RegisterAnswerAPIRouter(r *gin.RouterGroup) {
 
 	// meta
 	r.PUT("/meta/reaction", a.metaController.AddOrUpdateReaction)
+
+	// announcement
+	r.PUT("/announcement/dismiss", a.announcementController.DismissAnnouncement)
+
+	// invitation
+	r.POST("/invitation", a.invitationController.AddInvitation)
+	r.GET("/invitation/page", a.invitationController.GetMyInvitationPage)
+	r.DELETE("/invitation", a.invitationController.RevokeInvitation)
+
+	// analytics, for the roles that have the power to view analytics without the admin access
+	analytics := r.Group("", authUserMiddleware.MustPower(permission.AnalyticsView))
+	analytics.GET("/analytics", a.analyticsController.GetSiteAnalytics)
+	analytics.GET("/analytics/search", a.analyticsController.GetSearchReport)
 }
 
 func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
@@ -312,12 +683,130 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.POST("/users", a.adminUserController.AddUsers)
 	r.PUT("/user/password", a.adminUserController.UpdateUserPassword)
 	r.PUT("/user/profile", a.adminUserController.EditUserProfile)
+	r.PUT("/user/content/transfer", a.adminUserController.TransferUserContent)
+	r.PUT("/user/merge", a.adminUserController.MergeUser)
+	r.POST("/user/impersonation", a.adminUserController.ImpersonateUser)
+	r.GET("/user/impersonation/logs/page", a.adminUserController.GetUserImpersonationLogPage)
+	r.GET("/user/login-failures/page", a.loginProtectionCtrl.GetLoginFailurePage)
+
+	// profile field
+	r.GET("/profile-fields", a.profileFieldController.GetProfileFieldList)
+	r.POST("/profile-field", a.profileFieldController.AddProfileField)
+	r.PUT("/profile-field", a.profileFieldController.UpdateProfileField)
+	r.DELETE("/profile-field", a.profileFieldController.RemoveProfileField)
+
+	// question sla
+	r.GET("/sla/policies", a.questionSLAController.GetTagSLAPolicies)
+	r.PUT("/sla/policy", a.questionSLAController.SaveTagSLAPolicy)
+	r.DELETE("/sla/policy", a.questionSLAController.RemoveTagSLAPolicy)
+
+	// ai assistant
+	r.GET("/ai-assistant", a.aiAssistantAdminCtrl.GetAIAssistant)
+	r.PUT("/ai-assistant", a.aiAssistantAdminCtrl.UpdateAIAssistant)
+
+	// media proxy
+	r.GET("/media-proxy", a.mediaProxyAdminCtrl.GetMediaProxy)
+	r.PUT("/media-proxy", a.mediaProxyAdminCtrl.UpdateMediaProxy)
+
+	// experiments
+	r.GET("/experiments", a.experimentAdminCtrl.GetExperiments)
+	r.PUT("/experiments", a.experimentAdminCtrl.UpdateExperiments)
+	r.GET("/experiment/stats", a.experimentAdminCtrl.GetExperimentStats)
+
+	// feature flags
+	r.GET("/feature-flags", a.featureFlagAdminCtrl.GetFeatureFlags)
+	r.PUT("/feature-flags", a.featureFlagAdminCtrl.UpdateFeatureFlags)
+
+	// config
+	r.POST("/config/reload", a.configReloadCtrl.ReloadConfig)
+
+	// event stream
+	r.GET("/event-stream", a.eventStreamCtrl.GetEventStream)
+	r.PUT("/event-stream", a.eventStreamCtrl.UpdateEventStream)
+
+	// inbound question
+	r.GET("/inbound-question", a.inboundQuestionAdmin.GetInboundQuestionSetting)
+	r.PUT("/inbound-question", a.inboundQuestionAdmin.UpdateInboundQuestionSetting)
+
+	// chat integration
+	r.GET("/chat-integration", a.chatIntegrationAdmin.GetChatIntegration)
+	r.PUT("/chat-integration", a.chatIntegrationAdmin.UpdateChatIntegration)
+
+	// github
+	r.GET("/github", a.gitHubAdminCtrl.GetGitHub)
+	r.PUT("/github", a.gitHubAdminCtrl.UpdateGitHub)
+
+	// jira
+	r.GET("/jira", a.jiraAdminCtrl.GetJira)
+	r.PUT("/jira", a.jiraAdminCtrl.UpdateJira)
+
+	// maintenance
+	r.GET("/maintenance", a.maintenanceCtrl.GetMaintenance)
+	r.PUT("/maintenance", a.maintenanceCtrl.UpdateMaintenance)
+
+	// slow query
+	r.GET("/slow-queries/page", a.slowQueryCtrl.GetSlowQueryPage)
+	r.DELETE("/slow-queries", a.slowQueryCtrl.ClearSlowQueries)
+
+	// content limit
+	r.GET("/content-limits", a.contentLimitAdminCtrl.GetContentLimits)
+	r.PUT("/content-limits", a.contentLimitAdminCtrl.UpdateContentLimits)
+
+	// banned word
+	r.GET("/banned-words", a.bannedWordCtrl.GetBannedWords)
+	r.PUT("/banned-words", a.bannedWordCtrl.UpdateBannedWords)
+	r.GET("/banned-words/hits/page", a.bannedWordCtrl.GetBannedWordHitPage)
+
+	// moderation rule
+	r.GET("/moderation-rules", a.moderationRuleCtrl.GetModerationRules)
+	r.PUT("/moderation-rules", a.moderationRuleCtrl.UpdateModerationRules)
+	r.GET("/moderation-rules/hits/page", a.moderationRuleCtrl.GetModerationRuleHitPage)
+	r.GET("/question-workflow", a.adminQuestionWorkflowCtrl.GetQuestionWorkflow)
+	r.PUT("/question-workflow", a.adminQuestionWorkflowCtrl.UpdateQuestionWorkflow)
+	r.GET("/answer-verification", a.adminAnswerVerificationCtrl.GetAnswerVerificationConfig)
+	r.PUT("/answer-verification", a.adminAnswerVerificationCtrl.UpdateAnswerVerificationConfig)
+	r.GET("/tag/experts", a.adminAnswerVerificationCtrl.GetTagExperts)
+	r.POST("/tag/experts", a.adminAnswerVerificationCtrl.AddTagExpert)
+	r.DELETE("/tag/experts", a.adminAnswerVerificationCtrl.RemoveTagExpert)
+
+	// tag auto subscriptions
+	r.GET("/tag-auto-subscriptions", a.tagAutoSubscriptionCtrl.GetTagAutoSubscriptions)
+	r.PUT("/tag-auto-subscriptions", a.tagAutoSubscriptionCtrl.UpdateTagAutoSubscriptions)
+
+	// moderation triage
+	r.GET("/triage", a.moderationTriageCtrl.GetTriageDashboard)
+	r.PUT("/triage/action", a.moderationTriageCtrl.TriageAction)
+
+	// legal hold
+	r.GET("/legal-holds", a.legalHoldCtrl.GetLegalHoldPage)
+	r.POST("/legal-hold", a.legalHoldCtrl.PlaceLegalHold)
+	r.PUT("/legal-hold/release", a.legalHoldCtrl.ReleaseLegalHold)
+	r.GET("/legal-hold/logs", a.legalHoldCtrl.GetLegalHoldLogPage)
+
+	// recycle bin
+	r.GET("/recycle-bin/page", a.pendingDeletionCtrl.GetRecycleBinPage)
+	r.POST("/recycle-bin/restore", a.pendingDeletionCtrl.RestoreDeleted)
+
+	// ip logging
+	r.GET("/ip-logging", a.ipLoggingCtrl.GetIPLogging)
+	r.PUT("/ip-logging", a.ipLoggingCtrl.UpdateIPLogging)
+	r.GET("/ip-records", a.ipLoggingCtrl.GetPostIPRecordPage)
+	r.GET("/ip-records/related-accounts", a.ipLoggingCtrl.GetRelatedAccounts)
+
+	// username policy
+	r.GET("/username-policy", a.usernamePolicyCtrl.GetUsernamePolicy)
+	r.PUT("/username-policy", a.usernamePolicyCtrl.UpdateUsernamePolicy)
+
+	r.POST("/embedding/reindex", a.aiAssistantAdminCtrl.ReindexEmbeddings)
 
 	// reason
 	r.GET("/reasons", a.reasonController.Reasons)
 
 	// language
 	r.GET("/language/options", a.langController.GetAdminLangOptions)
+	r.GET("/language/packs", a.langController.GetLanguagePacks)
+	r.POST("/language/packs", a.langController.UploadLanguagePack)
+	r.DELETE("/language/packs", a.langController.RemoveLanguagePack)
 
 	// theme
 	r.GET("/theme/options", a.themeController.GetThemeOptions)
@@ -329,6 +818,7 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/interface", a.adminSiteInfoController.UpdateInterface)
 	r.GET("/siteinfo/branding", a.adminSiteInfoController.GetSiteBranding)
 	r.PUT("/siteinfo/branding", a.adminSiteInfoController.UpdateBranding)
+	r.POST("/siteinfo/branding/file", a.adminSiteInfoController.UploadBrandingFile)
 	r.GET("/siteinfo/write", a.adminSiteInfoController.GetSiteWrite)
 	r.PUT("/siteinfo/write", a.adminSiteInfoController.UpdateSiteWrite)
 	r.GET("/siteinfo/legal", a.adminSiteInfoController.GetSiteLegal)
@@ -345,18 +835,71 @@ func (a *AnswerAPIRouter) RegisterAnswerAdminAPIRouter(r *gin.RouterGroup) {
 	r.PUT("/siteinfo/users", a.adminSiteInfoController.UpdateSiteUsers)
 	r.GET("/setting/smtp", a.adminSiteInfoController.GetSMTPConfig)
 	r.PUT("/setting/smtp", a.adminSiteInfoController.UpdateSMTPConfig)
+	r.GET("/email/logs/page", a.emailDeliveryCtrl.GetEmailSendLogPage)
+	r.GET("/email/suppressions/page", a.emailDeliveryCtrl.GetEmailSuppressionPage)
+	r.DELETE("/email/suppression", a.emailDeliveryCtrl.RemoveEmailSuppression)
+	r.GET("/email/templates", a.emailTemplateCtrl.GetEmailTemplateList)
+	r.GET("/email/template", a.emailTemplateCtrl.GetEmailTemplate)
+	r.PUT("/email/template", a.emailTemplateCtrl.SaveEmailTemplate)
+	r.DELETE("/email/template", a.emailTemplateCtrl.RemoveEmailTemplate)
+	r.POST("/email/template/preview", a.emailTemplateCtrl.PreviewEmailTemplate)
 	r.GET("/setting/privileges", a.adminSiteInfoController.GetPrivilegesConfig)
 	r.PUT("/setting/privileges", a.adminSiteInfoController.UpdatePrivilegesConfig)
 
 	// dashboard
 	r.GET("/dashboard", a.dashboardController.DashboardInfo)
 
+	// analytics
+	r.GET("/analytics", a.analyticsController.GetSiteAnalytics)
+	r.GET("/analytics/search", a.analyticsController.GetSearchReport)
+
+	// page
+	r.GET("/pages", a.pageController.GetAdminPageList)
+	r.GET("/page", a.pageController.GetAdminPage)
+	r.POST("/page", a.pageController.AddPage)
+	r.PUT("/page", a.pageController.UpdatePage)
+	r.DELETE("/page", a.pageController.RemovePage)
+
+	// announcement
+	r.GET("/announcements", a.announcementController.GetAnnouncementList)
+	r.POST("/announcement", a.announcementController.AddAnnouncement)
+	r.PUT("/announcement", a.announcementController.UpdateAnnouncement)
+	r.DELETE("/announcement", a.announcementController.RemoveAnnouncement)
+
+	// invitation
+	r.GET("/invitation/page", a.invitationController.GetInvitationPage)
+	r.DELETE("/invitation", a.invitationController.AdminRevokeInvitation)
+	r.GET("/invitation/records", a.invitationController.GetInvitationRecordPage)
+
+	// blocked signup
+	r.GET("/blocked-signups/page", a.emailDomainController.GetBlockedSignupPage)
+
 	// roles
 	r.GET("/roles", a.roleController.GetRoleList)
+	r.GET("/role", a.roleController.GetRole)
+	r.POST("/role", a.roleController.AddRole)
+	r.PUT("/role", a.roleController.UpdateRole)
+	r.DELETE("/role", a.roleController.RemoveRole)
+	r.GET("/powers", a.roleController.GetPowerList)
 
 	// plugin
 	r.GET("/plugins", a.pluginController.GetPluginList)
 	r.PUT("/plugin/status", a.pluginController.UpdatePluginStatus)
 	r.GET("/plugin/config", a.pluginController.GetPluginConfig)
 	r.PUT("/plugin/config", a.pluginController.UpdatePluginConfig)
+	r.GET("/plugin/jobs", a.pluginController.GetPluginJobList)
+	r.PUT("/plugin/job/status", a.pluginController.UpdatePluginJobStatus)
+
+	// scheduled jobs
+	r.GET("/scheduled-jobs", a.schedulerController.GetScheduledJobList)
+	r.PUT("/scheduled-job/status", a.schedulerController.UpdateScheduledJobStatus)
+	r.PUT("/scheduled-job/schedule", a.schedulerController.UpdateScheduledJobSchedule)
+	r.POST("/scheduled-job/run", a.schedulerController.RunScheduledJob)
+
+	// revision compaction
+	r.GET("/revision/compaction/report", a.revisionCompactionCtrl.GetCompactionReport)
+
+	// permission policy
+	r.GET("/permission-policy", a.permissionPolicyCtrl.GetPermissionPolicy)
+	r.PUT("/permission-policy", a.permissionPolicyCtrl.UpdatePermissionPolicy)
 }
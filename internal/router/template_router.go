@@ -59,6 +59,7 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 	seoNoAuth.GET("/custom.css", a.siteInfoController.GetCss)
 
 	seoNoAuth.GET("/404", a.templateController.Page404)
+	seoNoAuth.GET("/embed/questions/:id", a.templateController.EmbedQuestion)
 
 	seo := r.Group(baseURLPath)
 	seo.Use(a.authUserMiddleware.CheckPrivateMode())
@@ -70,4 +71,7 @@ func (a *TemplateRouter) RegisterTemplateRouter(r *gin.RouterGroup, baseURLPath
 	seo.GET("/tags", a.templateController.TagList)
 	seo.GET("/tags/:tag", a.templateController.TagInfo)
 	seo.GET("/users/:username", a.templateController.UserInfo)
+	seo.GET("/pages/:slug", a.templateController.CustomPage)
+	seo.GET("/q/:id", a.templateController.ShortLinkQuestion)
+	seo.GET("/a/:id", a.templateController.ShortLinkAnswer)
 }
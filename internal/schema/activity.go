@@ -71,6 +71,46 @@ type ActObjectInfo struct {
 	MainTagSlugName string `json:"main_tag_slug_name"`
 }
 
+const (
+	TimelineExportFormatJSON = "json"
+	TimelineExportFormatCSV  = "csv"
+)
+
+// TimelineExportFields all the fields of the exported timeline in order
+var TimelineExportFields = []string{
+	"activity_id",
+	"created_at",
+	"activity_type",
+	"object_id",
+	"object_type",
+	"revision_id",
+	"user_id",
+	"username",
+	"display_name",
+	"cancelled",
+	"cancelled_at",
+	"comment",
+}
+
+// ExportObjectTimelineReq export object timeline request
+type ExportObjectTimelineReq struct {
+	ObjectID string `validate:"required,gt=0,lte=100" form:"object_id"`
+	// export format, default is json
+	Format string `validate:"omitempty,oneof=json csv" form:"format"`
+	// the fields to export separated by comma, default is all the fields
+	Fields   string `validate:"omitempty,lte=500" form:"fields"`
+	ShowVote bool   `validate:"omitempty" form:"show_vote"`
+	UserID   string `json:"-"`
+}
+
+// ExportObjectTimelineResp export object timeline response
+type ExportObjectTimelineResp struct {
+	ObjectInfo *ActObjectInfo `json:"object_info"`
+	Fields     []string       `json:"fields"`
+	// every activity only contains the requested fields, ordered by time
+	Timeline []map[string]interface{} `json:"timeline"`
+}
+
 // GetObjectTimelineDetailReq get object timeline detail request
 type GetObjectTimelineDetailReq struct {
 	NewRevisionID string `validate:"required,gt=0,lte=100" form:"new_revision_id"`
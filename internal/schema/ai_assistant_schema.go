@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// AIAssistantProviderBuiltIn the provider name of the built-in OpenAI-compatible provider
+	AIAssistantProviderBuiltIn = "built_in"
+	// AIAssistantProviderExtractive the provider name of the extractive summary
+	AIAssistantProviderExtractive = "extractive"
+)
+
+// SiteAIAssistantReq site ai assistant settings request
+type SiteAIAssistantReq struct {
+	// Enabled enable the built-in provider, the enabled assistant plugin is always used first
+	Enabled bool `json:"enabled"`
+	// Endpoint the base url of any OpenAI-compatible API, e.g. https://api.openai.com/v1
+	Endpoint  string `validate:"omitempty,url,lte=512" json:"endpoint"`
+	APIKey    string `validate:"omitempty,lte=512" json:"api_key"`
+	Model     string `validate:"omitempty,lte=100" json:"model"`
+	MaxTokens int    `validate:"omitempty,min=0,max=32000" json:"max_tokens"`
+	// DailyQuota the max number of the generations of each user per day, 0 means unlimited
+	DailyQuota int `validate:"omitempty,min=0,max=10000" json:"daily_quota"`
+	// AutoSummary summarize the long threads automatically, the extractive summary is used
+	// if neither the assistant plugin nor the built-in provider is available
+	AutoSummary bool `json:"auto_summary"`
+	// SemanticSearch blend the semantically similar contents into the search results, it needs the
+	// embedder plugin or the embedding model of the built-in provider
+	SemanticSearch bool   `json:"semantic_search"`
+	EmbeddingModel string `validate:"omitempty,lte=100" json:"embedding_model"`
+}
+
+// SiteAIAssistantResp site ai assistant settings response
+type SiteAIAssistantResp SiteAIAssistantReq
+
+// AIAssistantReq generate the answer draft or the summary of the question request
+type AIAssistantReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// AIAssistantResp ai assistant response
+type AIAssistantResp struct {
+	// Content the generated markdown text, it is a suggestion and should be reviewed before posting
+	Content string `json:"content"`
+	// AIGenerated always true, so the content can be marked as ai generated
+	AIGenerated bool   `json:"ai_generated"`
+	Provider    string `json:"provider"`
+	Model       string `json:"model"`
+	// RemainingQuota the remaining generations of the user today, -1 means unlimited
+	RemainingQuota int `json:"remaining_quota"`
+}
+
+// QuestionSummaryResp question summary response
+type QuestionSummaryResp struct {
+	Content     string `json:"content"`
+	Provider    string `json:"provider"`
+	AIGenerated bool   `json:"ai_generated"`
+}
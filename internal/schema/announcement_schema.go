@@ -0,0 +1,147 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AddAnnouncementReq add announcement request
+type AddAnnouncementReq struct {
+	// content in markdown
+	Content string `validate:"required,notblank,lte=1000" json:"content"`
+	// html
+	HTML string `json:"-"`
+	// style
+	Style string `validate:"required,oneof=info warning" json:"style" enums:"info,warning"`
+	// target audience
+	Audience string `validate:"required,oneof=all logged_in roles" json:"audience" enums:"all,logged_in,roles"`
+	// role ids, required when the audience is roles
+	RoleIDs []int `validate:"omitempty,dive,min=1" json:"role_ids"`
+	// start time in unix seconds, 0 means showing immediately
+	StartTime int64 `validate:"omitempty,min=0" json:"start_time"`
+	// end time in unix seconds, 0 means never ending
+	EndTime int64 `validate:"omitempty,min=0" json:"end_time"`
+	// whether the announcement is enabled
+	Enabled bool `json:"enabled"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (req *AddAnnouncementReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return checkAnnouncement(req.Audience, req.RoleIDs, req.StartTime, req.EndTime)
+}
+
+// AddAnnouncementResp add announcement response
+type AddAnnouncementResp struct {
+	ID int `json:"id"`
+}
+
+// UpdateAnnouncementReq update announcement request
+type UpdateAnnouncementReq struct {
+	// announcement id
+	ID int `validate:"required,min=1" json:"id"`
+	// content in markdown
+	Content string `validate:"required,notblank,lte=1000" json:"content"`
+	// html
+	HTML string `json:"-"`
+	// style
+	Style string `validate:"required,oneof=info warning" json:"style" enums:"info,warning"`
+	// target audience
+	Audience string `validate:"required,oneof=all logged_in roles" json:"audience" enums:"all,logged_in,roles"`
+	// role ids, required when the audience is roles
+	RoleIDs []int `validate:"omitempty,dive,min=1" json:"role_ids"`
+	// start time in unix seconds, 0 means showing immediately
+	StartTime int64 `validate:"omitempty,min=0" json:"start_time"`
+	// end time in unix seconds, 0 means never ending
+	EndTime int64 `validate:"omitempty,min=0" json:"end_time"`
+	// whether the announcement is enabled
+	Enabled bool `json:"enabled"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (req *UpdateAnnouncementReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return checkAnnouncement(req.Audience, req.RoleIDs, req.StartTime, req.EndTime)
+}
+
+func checkAnnouncement(audience string, roleIDs []int, startTime, endTime int64) (
+	errFields []*validator.FormErrorField, err error) {
+	if audience == entity.AnnouncementAudienceRoles && len(roleIDs) == 0 {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "role_ids",
+			ErrorMsg:   reason.AnnouncementRolesRequired,
+		})
+		return errFields, errors.BadRequest(reason.AnnouncementRolesRequired)
+	}
+	if startTime > 0 && endTime > 0 && endTime <= startTime {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "end_time",
+			ErrorMsg:   reason.AnnouncementTimeRangeInvalid,
+		})
+		return errFields, errors.BadRequest(reason.AnnouncementTimeRangeInvalid)
+	}
+	return nil, nil
+}
+
+// RemoveAnnouncementReq remove announcement request
+type RemoveAnnouncementReq struct {
+	// announcement id
+	ID int `validate:"required,min=1" json:"id"`
+}
+
+// DismissAnnouncementReq dismiss announcement request
+type DismissAnnouncementReq struct {
+	// announcement id
+	ID int `validate:"required,min=1" json:"id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetAnnouncementResp get announcement response for admin
+type GetAnnouncementResp struct {
+	ID        int    `json:"id"`
+	Content   string `json:"content"`
+	HTML      string `json:"html"`
+	Style     string `json:"style"`
+	Audience  string `json:"audience"`
+	RoleIDs   []int  `json:"role_ids"`
+	StartTime int64  `json:"start_time"`
+	EndTime   int64  `json:"end_time"`
+	Enabled   bool   `json:"enabled"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// GetActiveAnnouncementResp the announcement which should be shown to the current user
+type GetActiveAnnouncementResp struct {
+	ID    int    `json:"id"`
+	HTML  string `json:"html"`
+	Style string `json:"style"`
+	// whether the current user can dismiss it, only the logged-in user can dismiss the announcement
+	Dismissible bool  `json:"dismissible"`
+	EndTime     int64 `json:"end_time"`
+}
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AnswerEnvironment the software version that the answer works with
+type AnswerEnvironment struct {
+	// Name the name of the software, e.g. "go" or "mysql"
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// Version the version of the software, e.g. "1.21", empty means any version
+	Version string `validate:"omitempty,lte=100" json:"version"`
+}
+
+// FlagAnswerOutdatedReq flag the answer as outdated request
+type FlagAnswerOutdatedReq struct {
+	// answer id
+	AnswerID string `validate:"required" json:"answer_id"`
+	// is cancel
+	IsCancel bool   `validate:"omitempty" json:"is_cancel"`
+	UserID   string `json:"-"`
+}
+
+// FlagAnswerOutdatedResp flag the answer as outdated response
+type FlagAnswerOutdatedResp struct {
+	// the number of the users who flagged the answer as outdated
+	OutdatedCount int `json:"outdated_count"`
+	// the outdated flags reach the threshold
+	Outdated bool `json:"outdated"`
+	// if the current user flagged the answer will be true, otherwise false
+	OutdatedFlagged bool `json:"outdated_flagged"`
+}
@@ -31,6 +31,8 @@ type RemoveAnswerReq struct {
 	CanDelete   bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	// the reason of the deletion shown in the recycle bin
+	Reason string `validate:"omitempty,lte=500" json:"reason"`
 }
 
 // RecoverAnswerReq recover answer request
@@ -46,7 +48,7 @@ const (
 
 type AnswerAddReq struct {
 	QuestionID  string `json:"question_id"`
-	Content     string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content     string `validate:"required,notblank,lte=65535" json:"content"`
 	HTML        string `json:"-"`
 	UserID      string `json:"-"`
 	CanEdit     bool   `json:"-"`
@@ -56,6 +58,8 @@ type AnswerAddReq struct {
 	CaptchaCode string `json:"captcha_code"`
 	IP          string `json:"-"`
 	UserAgent   string `json:"-"`
+	// Environments the software versions that the answer works with
+	Environments []*AnswerEnvironment `validate:"omitempty,lte=10,dive" json:"environments"`
 }
 
 func (req *AnswerAddReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -67,7 +71,7 @@ type AnswerUpdateReq struct {
 	ID           string `json:"id"`
 	QuestionID   string `json:"question_id"`
 	Title        string `json:"title"`
-	Content      string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content      string `validate:"required,notblank,lte=65535" json:"content"`
 	EditSummary  string `validate:"omitempty" json:"edit_summary"`
 	HTML         string `json:"-"`
 	UserID       string `json:"-"`
@@ -75,6 +79,8 @@ type AnswerUpdateReq struct {
 	CanEdit      bool   `json:"-"`
 	CaptchaID    string `json:"captcha_id"`
 	CaptchaCode  string `json:"captcha_code"`
+	// Environments the software versions that the answer works with, nil means unchanged
+	Environments []*AnswerEnvironment `validate:"omitempty,lte=10,dive" json:"environments"`
 }
 
 func (req *AnswerUpdateReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -92,11 +98,19 @@ type AnswerListReq struct {
 	Order      string `json:"order" form:"order"`
 	Page       int    `json:"page" form:"page"`
 	PageSize   int    `json:"page_size" form:"page_size"`
-	UserID     string `json:"-"`
-	IsAdmin    bool   `json:"-"`
-	CanEdit    bool   `json:"-"`
-	CanDelete  bool   `json:"-"`
-	CanRecover bool   `json:"-"`
+	// Environment only the answers that declare this environment, e.g. "go"
+	Environment string `validate:"omitempty,lte=100" json:"environment" form:"environment"`
+	// Version only the answers that work with this version of the environment
+	Version string `validate:"omitempty,lte=100" json:"version" form:"version"`
+	// HideOutdated hide the answers marked as outdated by the community
+	HideOutdated bool   `json:"hide_outdated" form:"hide_outdated"`
+	UserID       string `json:"-"`
+	IsAdmin      bool   `json:"-"`
+	CanEdit      bool   `json:"-"`
+	CanDelete    bool   `json:"-"`
+	CanRecover   bool   `json:"-"`
+	// the permissions to resolve the allowed actions of the answers
+	ActionPermissions *ObjectActionPermissions `json:"-"`
 }
 
 type AnswerInfo struct {
@@ -116,9 +130,23 @@ type AnswerInfo struct {
 	VoteCount      int               `json:"vote_count"`
 	QuestionInfo   *QuestionInfoResp `json:"question_info,omitempty"`
 	Status         int               `json:"status"`
+	// LinkPreviews the preview cards of the external links in the answer
+	LinkPreviews []*LinkPreviewResp `json:"link_previews,omitempty"`
+	// Environments the software versions that the answer works with
+	Environments []*AnswerEnvironment `json:"environments"`
+	// OutdatedCount the number of the users who flagged the answer as outdated
+	OutdatedCount int `json:"outdated_count"`
+	// Outdated the outdated flags reach the threshold, the outdated banner should be shown
+	Outdated bool `json:"outdated"`
+	// OutdatedFlagged the current user flagged the answer as outdated
+	OutdatedFlagged bool `json:"outdated_flagged"`
+	// Verification the verification by the expert of the question's tags
+	Verification *AnswerVerificationResp `json:"verification,omitempty"`
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
+	// AllowedActions the actions the current user can do on the answer
+	AllowedActions *AllowedActions `json:"allowed_actions,omitempty"`
 }
 
 type AdminAnswerInfo struct {
@@ -140,7 +168,9 @@ type AdminAnswerInfo struct {
 type AcceptAnswerReq struct {
 	QuestionID string `validate:"required,gt=0,lte=30" json:"question_id"`
 	AnswerID   string `validate:"omitempty" json:"answer_id"`
-	UserID     string `json:"-"`
+	// IsCancel withdraw the acceptance of the answer only, the other accepted answers are kept
+	IsCancel bool   `validate:"omitempty" json:"is_cancel"`
+	UserID   string `json:"-"`
 }
 
 func (req *AcceptAnswerReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -0,0 +1,77 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteAnswerVerificationReq site answer verification request
+type SiteAnswerVerificationReq struct {
+	// ExpireDays the verification expires after the days and the answer needs to be verified again, 0 means never
+	ExpireDays int `validate:"omitempty,min=0,max=3650" json:"expire_days"`
+	// KeepAfterEdit the verification is kept after the answer is edited, otherwise the answer needs to be
+	// verified again
+	KeepAfterEdit bool `json:"keep_after_edit"`
+}
+
+// VerifyAnswerReq verify answer request
+type VerifyAnswerReq struct {
+	AnswerID string `validate:"required" json:"answer_id"`
+	UserID   string `json:"-"`
+}
+
+// RemoveAnswerVerificationReq remove answer verification request
+type RemoveAnswerVerificationReq struct {
+	AnswerID string `validate:"required" json:"answer_id"`
+	UserID   string `json:"-"`
+}
+
+// AnswerVerificationResp the verification of the answer by the expert, it is distinct from the acceptance
+type AnswerVerificationResp struct {
+	Verifier   *UserBasicInfo `json:"verifier"`
+	VerifiedAt int64          `json:"verified_at"`
+	// ExpiresAt 0 means the verification never expires
+	ExpiresAt int64 `json:"expires_at"`
+	Expired   bool  `json:"expired"`
+	// Edited the answer is edited after the verification
+	Edited bool `json:"edited"`
+	// Valid the verification is neither expired nor invalidated by the edit
+	Valid bool `json:"valid"`
+}
+
+// GetTagExpertsReq get tag experts request
+type GetTagExpertsReq struct {
+	TagID string `validate:"required" form:"tag_id"`
+}
+
+// TagExpertResp tag expert response
+type TagExpertResp struct {
+	CreatedAt int64          `json:"created_at"`
+	User      *UserBasicInfo `json:"user"`
+}
+
+// AddTagExpertReq add tag expert request
+type AddTagExpertReq struct {
+	TagID    string `validate:"required" json:"tag_id"`
+	Username string `validate:"required,lte=100" json:"username"`
+}
+
+// RemoveTagExpertReq remove tag expert request
+type RemoveTagExpertReq struct {
+	TagID  string `validate:"required" json:"tag_id"`
+	UserID string `validate:"required" json:"user_id"`
+}
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+import (
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/pkg/converter"
+)
+
+// AddArticleReq add article request
+type AddArticleReq struct {
+	Title   string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	HTML    string `json:"-"`
+	// QuestionIDs the canonical questions linked to the article
+	QuestionIDs []string `validate:"omitempty,lte=10,dive,required" json:"question_ids"`
+	UserID      string   `json:"-"`
+}
+
+func (req *AddArticleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return nil, nil
+}
+
+// UpdateArticleReq update article request
+type UpdateArticleReq struct {
+	ID          string   `validate:"required" json:"id"`
+	Title       string   `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Content     string   `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	HTML        string   `json:"-"`
+	QuestionIDs []string `validate:"omitempty,lte=10,dive,required" json:"question_ids"`
+	EditSummary string   `validate:"omitempty" json:"edit_summary"`
+	UserID      string   `json:"-"`
+	CanEdit     bool     `json:"-"`
+}
+
+func (req *UpdateArticleReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return nil, nil
+}
+
+// RemoveArticleReq remove article request
+type RemoveArticleReq struct {
+	ID        string `validate:"required" json:"id"`
+	UserID    string `json:"-"`
+	CanDelete bool   `json:"-"`
+}
+
+// ConvertAnswerToArticleReq convert answer to article request
+type ConvertAnswerToArticleReq struct {
+	AnswerID string `validate:"required" json:"answer_id"`
+	// Title default is the title of the question
+	Title      string `validate:"omitempty,gte=6,lte=150" json:"title"`
+	UserID     string `json:"-"`
+	CanConvert bool   `json:"-"`
+}
+
+// AddArticleResp add article response
+type AddArticleResp struct {
+	ID string `json:"id"`
+}
+
+// GetArticleReq get article request
+type GetArticleReq struct {
+	ID        string `validate:"required" form:"id"`
+	UserID    string `json:"-"`
+	CanManage bool   `json:"-"`
+}
+
+// GetArticlePageReq get article page request
+type GetArticlePageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// order by(newest,views)
+	OrderCond string `validate:"omitempty,oneof=newest views" form:"order"`
+}
+
+// GetQuestionArticlesReq get the articles linked to the question request
+type GetQuestionArticlesReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// ArticleLinkedQuestion the canonical question linked to the article
+type ArticleLinkedQuestion struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	URLTitle string `json:"url_title"`
+}
+
+// ArticleInfoResp article info response
+type ArticleInfoResp struct {
+	ID             string                    `json:"id"`
+	Title          string                    `json:"title"`
+	URLTitle       string                    `json:"url_title"`
+	Content        string                    `json:"content"`
+	HTML           string                    `json:"html"`
+	ViewCount      int                       `json:"view_count"`
+	SourceAnswerID string                    `json:"source_answer_id"`
+	CreatedAt      int64                     `json:"created_at"`
+	UpdatedAt      int64                     `json:"updated_at"`
+	UserInfo       *UserBasicInfo            `json:"user_info,omitempty"`
+	LastEditUser   *UserBasicInfo            `json:"last_edit_user,omitempty"`
+	Questions      []*ArticleLinkedQuestion  `json:"questions"`
+	MemberActions  []*PermissionMemberAction `json:"member_actions"`
+}
+
+// ArticlePageResp article page response
+type ArticlePageResp struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	URLTitle    string         `json:"url_title"`
+	Description string         `json:"description"`
+	ViewCount   int            `json:"view_count"`
+	CreatedAt   int64          `json:"created_at"`
+	UpdatedAt   int64          `json:"updated_at"`
+	UserInfo    *UserBasicInfo `json:"user_info,omitempty"`
+}
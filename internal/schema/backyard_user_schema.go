@@ -87,6 +87,10 @@ type GetUserPageResp struct {
 	RoleID int `json:"role_id"`
 	// role name
 	RoleName string `json:"role_name"`
+	// the last time of sending the email verification reminder, only for inactive user
+	VerificationRemindedAt int64 `json:"verification_reminded_at"`
+	// the times of sending the email verification reminder, only for inactive user
+	VerificationReminderCount int `json:"verification_reminder_count"`
 }
 
 // GetUserInfoReq get user request
@@ -196,6 +200,57 @@ type UpdateUserPasswordReq struct {
 	LoginUserID string `json:"-"`
 }
 
+// TransferUserContentReq transfer user content request
+type TransferUserContentReq struct {
+	FromUserID string `validate:"required" json:"from_user_id"`
+	ToUserID   string `validate:"required" json:"to_user_id"`
+	// ObjectID only transfer this question or answer, transfer all the content of the user if empty
+	ObjectID    string `validate:"omitempty" json:"object_id"`
+	LoginUserID string `json:"-"`
+}
+
+// MergeUserReq merge user request
+type MergeUserReq struct {
+	PrimaryUserID string `validate:"required" json:"primary_user_id"`
+	// DuplicateUserID this user is deleted after all its content is moved to the primary user
+	DuplicateUserID string `validate:"required" json:"duplicate_user_id"`
+	LoginUserID     string `json:"-"`
+}
+
+// ImpersonateUserReq sign in as the user request
+type ImpersonateUserReq struct {
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+	IP          string `json:"-"`
+}
+
+// ImpersonateUserResp sign in as the user response
+type ImpersonateUserResp struct {
+	UserID      string `json:"user_id"`
+	Username    string `json:"username"`
+	AccessToken string `json:"access_token"`
+	VisitToken  string `json:"visit_token"`
+	// the impersonation session expires at this unix time
+	ExpiredAt int64 `json:"expired_at"`
+}
+
+// GetUserImpersonationLogPageReq get the records of the admins signing in as the users request
+type GetUserImpersonationLogPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `validate:"omitempty" form:"user_id"`
+}
+
+// GetUserImpersonationLogResp the record of the admin signing in as the user
+type GetUserImpersonationLogResp struct {
+	ID        int            `json:"id"`
+	Operator  *UserBasicInfo `json:"operator"`
+	User      *UserBasicInfo `json:"user"`
+	IP        string         `json:"ip"`
+	CreatedAt int64          `json:"created_at"`
+	ExpiredAt int64          `json:"expired_at"`
+}
+
 // GetUserActivationReq get user activation
 type GetUserActivationReq struct {
 	UserID string `validate:"required" form:"user_id"`
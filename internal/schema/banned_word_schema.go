@@ -0,0 +1,73 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// BannedWordActionReject the content is not saved
+	BannedWordActionReject = "reject"
+	// BannedWordActionReplace the word is replaced and the content is saved
+	BannedWordActionReplace = "replace"
+	// BannedWordActionReview the new question or answer needs to be reviewed by the moderators,
+	// the other content which can't be reviewed is rejected
+	BannedWordActionReview = "review"
+)
+
+// BannedWordRule the banned word rule
+type BannedWordRule struct {
+	// Word the word is matched case-insensitively, and as the whole word if it starts or ends with a letter
+	// or a digit. If Regex is true, it is the regular expression matched as it is.
+	Word   string `validate:"required,notblank,lte=200" json:"word"`
+	Regex  bool   `json:"regex"`
+	Action string `validate:"required,oneof=reject replace review" json:"action"`
+	// Replacement the text used by the replace action, empty means the asterisks of the same length
+	Replacement string `validate:"omitempty,lte=100" json:"replacement"`
+}
+
+// SiteBannedWordsReq site banned words request
+type SiteBannedWordsReq struct {
+	Rules []*BannedWordRule `validate:"omitempty,lte=1000,dive" json:"rules"`
+}
+
+// BannedWordTrTplData the template data of the banned word error
+type BannedWordTrTplData struct {
+	Word string
+}
+
+// GetBannedWordHitPageReq get banned word hit page request
+type GetBannedWordHitPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// object type(question,answer,comment,user), empty means all
+	ObjectType string `validate:"omitempty,oneof=question answer comment user" form:"object_type"`
+	// action(reject,replace,review), empty means all
+	Action string `validate:"omitempty,oneof=reject replace review" form:"action"`
+}
+
+// BannedWordHitResp banned word hit response
+type BannedWordHitResp struct {
+	ID         int    `json:"id"`
+	CreatedAt  int64  `json:"created_at"`
+	Word       string `json:"word"`
+	Action     string `json:"action"`
+	ObjectType string `json:"object_type"`
+	ObjectID   string `json:"object_id"`
+	UserID     string `json:"user_id"`
+	Matched    string `json:"matched"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteChatIntegrationReq site chat integration settings request
+type SiteChatIntegrationReq struct {
+	SlackEnabled bool `json:"slack_enabled"`
+	// SlackSigningSecret the signing secret of the Slack app, used to verify the slash commands
+	SlackSigningSecret string `validate:"omitempty,lte=256" json:"slack_signing_secret"`
+	TeamsEnabled       bool   `json:"teams_enabled"`
+	// TeamsSecurityToken the security token of the Teams outgoing webhook, used to verify the messages
+	TeamsSecurityToken string `validate:"omitempty,lte=256" json:"teams_security_token"`
+	// ResultLimit the max number of the search results in the reply, 5 by default
+	ResultLimit int `validate:"omitempty,min=1,max=10" json:"result_limit"`
+}
+
+// SiteChatIntegrationResp site chat integration settings response
+type SiteChatIntegrationResp SiteChatIntegrationReq
+
+// ChatSlackCommandReq the slash command sent by Slack
+type ChatSlackCommandReq struct {
+	Timestamp string
+	Signature string
+	Body      []byte
+}
+
+// ChatTeamsCommandReq the message sent by the Teams outgoing webhook
+type ChatTeamsCommandReq struct {
+	Authorization string
+	Body          []byte
+}
+
+// SlackCommandResp the reply to the Slack slash command
+type SlackCommandResp struct {
+	ResponseType string        `json:"response_type"`
+	Text         string        `json:"text"`
+	Blocks       []*SlackBlock `json:"blocks,omitempty"`
+}
+
+// SlackBlock the block of the Slack message
+type SlackBlock struct {
+	Type     string       `json:"type"`
+	Text     *SlackText   `json:"text,omitempty"`
+	Elements []*SlackText `json:"elements,omitempty"`
+}
+
+// SlackText the text object of the Slack message
+type SlackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// TeamsCommandResp the reply to the Teams outgoing webhook
+type TeamsCommandResp struct {
+	Type        string             `json:"type"`
+	Text        string             `json:"text"`
+	Attachments []*TeamsAttachment `json:"attachments,omitempty"`
+}
+
+// TeamsAttachment the attachment of the Teams message
+type TeamsAttachment struct {
+	ContentType string        `json:"contentType"`
+	Content     *AdaptiveCard `json:"content"`
+}
+
+// AdaptiveCard the adaptive card shown in Teams
+type AdaptiveCard struct {
+	Schema  string                `json:"$schema"`
+	Type    string                `json:"type"`
+	Version string                `json:"version"`
+	Body    []*AdaptiveCardText   `json:"body"`
+	Actions []*AdaptiveCardAction `json:"actions,omitempty"`
+}
+
+// AdaptiveCardText the text block of the adaptive card
+type AdaptiveCardText struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Wrap     bool   `json:"wrap"`
+	Weight   string `json:"weight,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+}
+
+// AdaptiveCardAction the action of the adaptive card
+type AdaptiveCardAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+// AddCoAuthorReq add co-author request
+type AddCoAuthorReq struct {
+	// question or answer id
+	ObjectID string `validate:"required" json:"object_id"`
+	Username string `validate:"required,gt=0,lte=100" json:"username"`
+	UserID   string `json:"-"`
+}
+
+// RemoveCoAuthorReq remove co-author request
+type RemoveCoAuthorReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	// the user id of the co-author
+	UserID      string `validate:"required" json:"user_id"`
+	LoginUserID string `json:"-"`
+}
+
+// GetCoAuthorsReq get co-authors request
+type GetCoAuthorsReq struct {
+	ObjectID string `validate:"required" form:"object_id"`
+}
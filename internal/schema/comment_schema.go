@@ -33,7 +33,7 @@ type AddCommentReq struct {
 	// reply comment id
 	ReplyCommentID string `validate:"omitempty" json:"reply_comment_id"`
 	// original comment content
-	OriginalText string `validate:"required,notblank,gte=2,lte=600" json:"original_text"`
+	OriginalText string `validate:"required,notblank,lte=65535" json:"original_text"`
 	// parsed comment content
 	ParsedText string `json:"-"`
 	// @ user id list
@@ -42,7 +42,9 @@ type AddCommentReq struct {
 	CaptchaCode         string   `json:"captcha_code"`
 
 	// user id
-	UserID string `json:"-"`
+	UserID    string `json:"-"`
+	IP        string `json:"-"`
+	UserAgent string `json:"-"`
 	// whether user can add it
 	CanAdd bool `json:"-"`
 	// whether user can edit it
@@ -64,6 +66,8 @@ type RemoveCommentReq struct {
 	UserID      string `json:"-"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
+	// the reason of the deletion shown in the recycle bin
+	Reason string `validate:"omitempty,lte=500" json:"reason"`
 }
 
 // UpdateCommentReq update comment request
@@ -71,7 +75,7 @@ type UpdateCommentReq struct {
 	// comment id
 	CommentID string `validate:"required" json:"comment_id"`
 	// original comment content
-	OriginalText string `validate:"required,notblank,gte=2,lte=600" json:"original_text"`
+	OriginalText string `validate:"required,notblank,lte=65535" json:"original_text"`
 	// parsed comment content
 	ParsedText string `json:"-"`
 	// user id
@@ -138,6 +142,8 @@ type GetCommentWithPageReq struct {
 	CanEdit bool `json:"-"`
 	// whether user can delete it
 	CanDelete bool `json:"-"`
+	// the permissions to resolve the allowed actions of the comments
+	ActionPermissions *ObjectActionPermissions `json:"-"`
 }
 
 // GetCommentReq get comment list page request
@@ -150,6 +156,8 @@ type GetCommentReq struct {
 	CanEdit bool `json:"-"`
 	// whether user can delete it
 	CanDelete bool `json:"-"`
+	// the permissions to resolve the allowed actions of the comment
+	ActionPermissions *ObjectActionPermissions `json:"-"`
 }
 
 // GetCommentResp comment response
@@ -194,6 +202,8 @@ type GetCommentResp struct {
 
 	// MemberActions
 	MemberActions []*PermissionMemberAction `json:"member_actions"`
+	// AllowedActions the actions the current user can do on the comment
+	AllowedActions *AllowedActions `json:"allowed_actions,omitempty"`
 }
 
 func (r *GetCommentResp) SetFromComment(comment *entity.Comment) {
@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// ContentLimitTitleCap the title column holds 150 characters at most
+	ContentLimitTitleCap = 150
+	// ContentLimitBodyCap the max length of the question and the answer
+	ContentLimitBodyCap = 65535
+	// ContentLimitCommentCap the max length of the comment
+	ContentLimitCommentCap = 65535
+)
+
+// ContentLengthLimit the min and the max number of the characters, the characters are counted as the users
+// see them, so the emoji sequence or the Hangul syllable in the conjoining jamo is one character
+type ContentLengthLimit struct {
+	Min int `validate:"min=0" json:"min"`
+	Max int `validate:"min=1" json:"max"`
+}
+
+// SiteContentLimitsReq site content limits settings request
+type SiteContentLimitsReq struct {
+	Title *ContentLengthLimit `validate:"required" json:"title"`
+	// Body the limit of the question and the answer
+	Body    *ContentLengthLimit `validate:"required" json:"body"`
+	Comment *ContentLengthLimit `validate:"required" json:"comment"`
+	// RoleOverrides the limits of the users of the role, the limit not set falls back to the site one
+	RoleOverrides []*ContentLimitsRoleOverride `validate:"omitempty,lte=20,dive" json:"role_overrides"`
+}
+
+// ContentLimitsRoleOverride the limits of the users of the role
+type ContentLimitsRoleOverride struct {
+	RoleID  int                 `validate:"required,min=1" json:"role_id"`
+	Title   *ContentLengthLimit `validate:"omitempty" json:"title,omitempty"`
+	Body    *ContentLengthLimit `validate:"omitempty" json:"body,omitempty"`
+	Comment *ContentLengthLimit `validate:"omitempty" json:"comment,omitempty"`
+}
+
+// ContentLimitsResp the content limits of the user
+type ContentLimitsResp struct {
+	Title   *ContentLengthLimit `json:"title"`
+	Body    *ContentLengthLimit `json:"body"`
+	Comment *ContentLengthLimit `json:"comment"`
+}
+
+// DefaultContentLimits the content limits before they are changed by the admin
+func DefaultContentLimits() *SiteContentLimitsReq {
+	return &SiteContentLimitsReq{
+		Title:   &ContentLengthLimit{Min: 6, Max: ContentLimitTitleCap},
+		Body:    &ContentLengthLimit{Min: 6, Max: ContentLimitBodyCap},
+		Comment: &ContentLengthLimit{Min: 2, Max: 600},
+	}
+}
+
+// ContentLengthTrTplData the template data of the content length error
+type ContentLengthTrTplData struct {
+	Min int
+	Max int
+}
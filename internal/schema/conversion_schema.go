@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// ConvertCommentToAnswerReq convert the comment into an answer of the question
+type ConvertCommentToAnswerReq struct {
+	CommentID string `validate:"required" json:"comment_id"`
+	UserID    string `json:"-"`
+}
+
+// ConvertCommentToAnswerResp convert comment to answer response
+type ConvertCommentToAnswerResp struct {
+	AnswerID string `json:"answer_id"`
+}
+
+// ConvertAnswerToCommentReq convert the answer into a comment
+type ConvertAnswerToCommentReq struct {
+	AnswerID string `validate:"required" json:"answer_id"`
+	// ObjectID the question or another answer of the same question the comment belongs to, default is the question
+	ObjectID string `validate:"omitempty" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// ConvertAnswerToCommentResp convert answer to comment response
+type ConvertAnswerToCommentResp struct {
+	CommentID string `json:"comment_id"`
+}
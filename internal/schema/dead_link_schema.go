@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetDeadLinkPageReq get dead link page request
+type GetDeadLinkPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+}
+
+// GetDeadLinkResp the dead link in the question or answer
+type GetDeadLinkResp struct {
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	QuestionID string `json:"question_id"`
+	URL        string `json:"url"`
+	// StatusCode the http status code of the link, 0 means the link can not be requested
+	StatusCode int    `json:"status_code"`
+	Reason     string `json:"reason"`
+	CheckedAt  int64  `json:"checked_at"`
+}
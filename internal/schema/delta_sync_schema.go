@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "encoding/json"
+
+const (
+	// DefaultSyncLimit the default number of the changes returned in one sync request
+	DefaultSyncLimit = 100
+	// MaxSyncLimit the max number of the changes returned in one sync request
+	MaxSyncLimit = 500
+)
+
+// GetSyncChangesReq get the changes since the cursor request
+type GetSyncChangesReq struct {
+	// Cursor the next_cursor of the last response, empty means all the changes kept on the server
+	Cursor string `validate:"omitempty,lte=100" form:"cursor"`
+	// Limit the max number of the changes of the content and the notifications
+	Limit  int    `validate:"omitempty,min=1,max=500" form:"limit"`
+	UserID string `json:"-"`
+}
+
+// GetSyncChangesResp the changes since the cursor, the deleted or invisible objects are returned
+// with only the id and deleted, the client upserts the others by id
+type GetSyncChangesResp struct {
+	Questions     []*SyncQuestion     `json:"questions"`
+	Answers       []*SyncAnswer       `json:"answers"`
+	Comments      []*SyncComment      `json:"comments"`
+	Notifications []*SyncNotification `json:"notifications"`
+	// NextCursor the cursor of the next sync request
+	NextCursor string `json:"next_cursor"`
+	// HasMore there are more changes after the next cursor, the client should request again at once
+	HasMore bool `json:"has_more"`
+	// Reset the changes since the cursor are no longer kept, the client should reload all the content
+	Reset bool `json:"reset"`
+}
+
+// SyncQuestion the compact question in the sync response
+type SyncQuestion struct {
+	ID               string `json:"id"`
+	Deleted          bool   `json:"deleted,omitempty"`
+	Title            string `json:"title,omitempty"`
+	Content          string `json:"content,omitempty"`
+	Username         string `json:"username,omitempty"`
+	Closed           bool   `json:"closed,omitempty"`
+	VoteCount        int    `json:"vote_count,omitempty"`
+	AnswerCount      int    `json:"answer_count,omitempty"`
+	AcceptedAnswerID string `json:"accepted_answer_id,omitempty"`
+	CreatedAt        int64  `json:"created_at,omitempty"`
+	UpdatedAt        int64  `json:"updated_at,omitempty"`
+}
+
+// SyncAnswer the compact answer in the sync response
+type SyncAnswer struct {
+	ID         string `json:"id"`
+	Deleted    bool   `json:"deleted,omitempty"`
+	QuestionID string `json:"question_id,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Username   string `json:"username,omitempty"`
+	Accepted   bool   `json:"accepted,omitempty"`
+	VoteCount  int    `json:"vote_count,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at,omitempty"`
+}
+
+// SyncComment the compact comment in the sync response
+type SyncComment struct {
+	ID         string `json:"id"`
+	Deleted    bool   `json:"deleted,omitempty"`
+	ObjectID   string `json:"object_id,omitempty"`
+	QuestionID string `json:"question_id,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Username   string `json:"username,omitempty"`
+	VoteCount  int    `json:"vote_count,omitempty"`
+	CreatedAt  int64  `json:"created_at,omitempty"`
+	UpdatedAt  int64  `json:"updated_at,omitempty"`
+}
+
+// SyncNotification the new notification of the user in the sync response
+type SyncNotification struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Content the same content as the notification list
+	Content   json.RawMessage `json:"content"`
+	IsRead    bool            `json:"is_read"`
+	CreatedAt int64           `json:"created_at"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// EditLockReq acquire or keep the edit lock of the post, it is sent when the edit form is opened
+// and then periodically as the heartbeat
+type EditLockReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	// Takeover take over the lock held by the other editor
+	Takeover bool   `json:"takeover"`
+	UserID   string `json:"-"`
+}
+
+// ReleaseEditLockReq release the edit lock when the edit form is closed
+type ReleaseEditLockReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// GetEditLockReq get the edit lock of the post
+type GetEditLockReq struct {
+	ObjectID string `validate:"required" form:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// EditLockResp edit lock response
+type EditLockResp struct {
+	ObjectID string `json:"object_id"`
+	// Locked the post is being edited by the other user, the editor is returned to warn the user
+	Locked bool           `json:"locked"`
+	Editor *UserBasicInfo `json:"editor,omitempty"`
+	// IsOwner the lock is held by the user
+	IsOwner    bool  `json:"is_owner"`
+	AcquiredAt int64 `json:"acquired_at"`
+	ExpiresAt  int64 `json:"expires_at"`
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "net/url"
+
+// GetEmailSendLogPageReq get the email send logs request
+type GetEmailSendLogPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// Email only the emails sent to this address
+	Email string `validate:"omitempty,lte=255" form:"email"`
+	// Status sent failed suppressed delivered bounced complained
+	Status string `validate:"omitempty,oneof=sent failed suppressed delivered bounced complained" form:"status"`
+}
+
+// GetEmailSendLogResp the email sent by the site
+type GetEmailSendLogResp struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	Provider  string `json:"provider"`
+	MessageID string `json:"message_id"`
+	ToEmail   string `json:"to_email"`
+	Subject   string `json:"subject"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+}
+
+// GetEmailSuppressionPageReq get the suppressed addresses request
+type GetEmailSuppressionPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// Email only this address
+	Email string `validate:"omitempty,lte=255" form:"email"`
+}
+
+// GetEmailSuppressionResp the address which no email is sent to
+type GetEmailSuppressionResp struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	Email     string `json:"email"`
+	Reason    string `json:"reason"`
+	Provider  string `json:"provider"`
+}
+
+// RemoveEmailSuppressionReq remove the suppressed address request, the emails are sent to it again
+type RemoveEmailSuppressionReq struct {
+	Email string `validate:"required,email,lte=255" json:"email"`
+}
+
+// EmailDeliveryWebhookReq the delivery events posted by the provider
+type EmailDeliveryWebhookReq struct {
+	// Provider sendgrid mailgun ses
+	Provider string
+	// Key the webhook key of the email config
+	Key  string
+	Body []byte
+}
+
+// EmailInboundWebhookReq the email forwarded by the provider when it is sent to the reply address
+type EmailInboundWebhookReq struct {
+	// Provider sendgrid mailgun
+	Provider string
+	// Key the webhook key of the email config
+	Key  string
+	Form url.Values
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetBlockedSignupPageReq get blocked signup page request
+type GetBlockedSignupPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	Domain   string `validate:"omitempty,lte=100" form:"domain"`
+	// blocked reason(not_allowed,blocked,disposable), empty means all
+	Reason string `validate:"omitempty,oneof=not_allowed blocked disposable" form:"reason"`
+}
+
+// BlockedSignupResp blocked signup response
+type BlockedSignupResp struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	EMail     string `json:"e_mail"`
+	Domain    string `json:"domain"`
+	Reason    string `json:"reason"`
+	IP        string `json:"ip"`
+}
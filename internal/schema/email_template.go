@@ -30,6 +30,8 @@ const (
 	ConfirmNewEmailSourceType   EmailSourceType = "password-reset"
 	UnsubscribeSourceType       EmailSourceType = "unsubscribe"
 	BindingSourceType           EmailSourceType = "binding"
+	// AuthorizeEmailChangeSourceType the code sent to the old email address to authorize the email change
+	AuthorizeEmailChangeSourceType EmailSourceType = "authorize-email-change"
 )
 
 type EmailSourceType string
@@ -44,6 +46,8 @@ type EmailCodeContent struct {
 	BindingKey string `json:"binding_key,omitempty"`
 	// Skip the validation of the latest code
 	SkipValidationLatestCode bool `json:"skip_validation_latest_code"`
+	// Used for email change, the change is only valid when the user email is still the old email
+	OldEmail string `json:"old_e_mail,omitempty"`
 }
 
 func (r *EmailCodeContent) ToJSONString() string {
@@ -70,6 +74,75 @@ type ChangeEmailTemplateData struct {
 	ChangeEmailUrl string
 }
 
+type AuthorizeEmailChangeTemplateData struct {
+	SiteName     string
+	NewEmail     string
+	AuthorizeUrl string
+}
+
+type EmailChangedTemplateData struct {
+	SiteName string
+	NewEmail string
+}
+
+type VerificationReminderTemplateData struct {
+	SiteName    string
+	RegisterUrl string
+}
+
+type SuspiciousLoginTemplateData struct {
+	SiteName    string
+	FailedCount int
+	IP          string
+	// Time the time of the last failed attempt in the time zone of the user
+	Time string
+	// SignedIn the account is signed in after the failed attempts, otherwise it is locked
+	SignedIn         bool
+	ResetPasswordUrl string
+}
+
+type QuestionReviewReminderTemplateData struct {
+	SiteName      string
+	QuestionTitle string
+	QuestionUrl   string
+	Answers       []*QuestionReviewReminderAnswer
+}
+
+// QuestionReviewReminderAnswer the answer with the one-click accept link in the reminder
+type QuestionReviewReminderAnswer struct {
+	DisplayName string
+	Excerpt     string
+	AcceptUrl   string
+}
+
+// TagSubscriptionDigestTemplateData the new questions in the tags which the group of the user is subscribed to
+type TagSubscriptionDigestTemplateData struct {
+	SiteName  string
+	SiteUrl   string
+	Questions []*TagSubscriptionDigestQuestion
+}
+
+// TagSubscriptionDigestQuestion the question in the digest
+type TagSubscriptionDigestQuestion struct {
+	QuestionID    string
+	QuestionTitle string
+	QuestionUrl   string
+	Tags          string
+}
+
+type SLABreachedTemplateData struct {
+	SiteName      string
+	QuestionTitle string
+	QuestionUrl   string
+	FirstResponse bool
+	Hours         int
+}
+
+// PluralCount the plural form of the hours is used in the translation
+func (d *SLABreachedTemplateData) PluralCount() int {
+	return d.Hours
+}
+
 type TestTemplateData struct {
 	SiteName string
 }
@@ -142,3 +215,64 @@ type NewQuestionTemplateData struct {
 	Tags           string
 	UnsubscribeUrl string
 }
+
+// GetEmailTemplateListResp the email template which can be customized
+type GetEmailTemplateListResp struct {
+	Key string `json:"key"`
+	// Variables the variables which can be used in the subject and body, like {{.SiteName}}
+	Variables []string `json:"variables"`
+	// CustomizedLanguages the languages which the template is customized for
+	CustomizedLanguages []string `json:"customized_languages"`
+}
+
+// GetEmailTemplateReq get the email template of the language request
+type GetEmailTemplateReq struct {
+	Key      string `validate:"required,lte=64" form:"key"`
+	Language string `validate:"required,lte=32" form:"language"`
+}
+
+// GetEmailTemplateResp the email template of the language
+type GetEmailTemplateResp struct {
+	Key      string `json:"key"`
+	Language string `json:"language"`
+	// Subject the customized subject, empty means the default subject is used
+	Subject string `json:"subject"`
+	// Body the customized body, empty means the default body is used
+	Body           string   `json:"body"`
+	DefaultSubject string   `json:"default_subject"`
+	DefaultBody    string   `json:"default_body"`
+	Customized     bool     `json:"customized"`
+	Variables      []string `json:"variables"`
+}
+
+// SaveEmailTemplateReq customize the email template of the language request
+type SaveEmailTemplateReq struct {
+	Key      string `validate:"required,lte=64" json:"key"`
+	Language string `validate:"required,lte=32" json:"language"`
+	Subject  string `validate:"omitempty,lte=512" json:"subject"`
+	Body     string `validate:"omitempty,lte=65535" json:"body"`
+}
+
+// RemoveEmailTemplateReq remove the customized email template, the default template is used again
+type RemoveEmailTemplateReq struct {
+	Key      string `validate:"required,lte=64" json:"key"`
+	Language string `validate:"required,lte=32" json:"language"`
+}
+
+// PreviewEmailTemplateReq preview the email template with the sample data request
+type PreviewEmailTemplateReq struct {
+	Key      string `validate:"required,lte=64" json:"key"`
+	Language string `validate:"required,lte=32" json:"language"`
+	// Subject the subject to preview, empty means the default subject is used
+	Subject string `validate:"omitempty,lte=512" json:"subject"`
+	// Body the body to preview, empty means the default body is used
+	Body string `validate:"omitempty,lte=65535" json:"body"`
+	// TestEmailRecipient the rendered email is also sent to this address
+	TestEmailRecipient string `validate:"omitempty,email" json:"test_email_recipient"`
+}
+
+// PreviewEmailTemplateResp the email template rendered with the sample data
+type PreviewEmailTemplateResp struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/base/constant"
+
+const (
+	// EventStreamDriverKafka publish the events through the Kafka REST proxy
+	EventStreamDriverKafka = "kafka"
+	// EventStreamDriverNATS publish the events to the NATS server
+	EventStreamDriverNATS = "nats"
+)
+
+// DefaultStreamEvents the events published if no event is chosen
+var DefaultStreamEvents = []constant.EventType{
+	constant.EventQuestionCreate,
+	constant.EventAnswerAccept,
+	constant.EventUserRegister,
+}
+
+// SiteEventStreamReq site event stream settings request
+type SiteEventStreamReq struct {
+	Enabled bool   `json:"enabled"`
+	Driver  string `validate:"omitempty,oneof=kafka nats" json:"driver"`
+	// Endpoint the url of the Kafka REST proxy, e.g. http://127.0.0.1:8082,
+	// or the address of the NATS server, e.g. nats://127.0.0.1:4222
+	Endpoint string `validate:"omitempty,gt=0,lte=512" json:"endpoint"`
+	Username string `validate:"omitempty,lte=256" json:"username"`
+	Password string `validate:"omitempty,lte=256" json:"password"`
+	// TopicPrefix the topic of the event is the prefix followed by the event type, e.g. answer.question.created
+	TopicPrefix string `validate:"omitempty,lte=100" json:"topic_prefix"`
+	// Events the event types published, empty means DefaultStreamEvents
+	Events []string `validate:"omitempty,max=50,dive,gt=0,lte=100" json:"events"`
+}
+
+// SiteEventStreamResp site event stream settings response
+type SiteEventStreamResp SiteEventStreamReq
+
+// StreamEvent the message published to the broker, the consumers should skip the id they have
+// handled since the same event may be delivered more than once
+type StreamEvent struct {
+	ID         string `json:"id"`
+	EventType  string `json:"event_type"`
+	UserID     string `json:"user_id,omitempty"`
+	ObjectID   string `json:"object_id,omitempty"`
+	QuestionID string `json:"question_id,omitempty"`
+	CreatedAt  int64  `json:"created_at"`
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// ExperimentEventExposure the user sees the variant of the experiment
+	ExperimentEventExposure = "exposure"
+	// ExperimentEventConversion the user completes the goal of the experiment
+	ExperimentEventConversion = "conversion"
+)
+
+// SiteExperimentsReq site experiments settings request
+type SiteExperimentsReq struct {
+	Experiments []*Experiment `validate:"omitempty,max=50,dive" json:"experiments"`
+}
+
+// SiteExperimentsResp site experiments settings response
+type SiteExperimentsResp SiteExperimentsReq
+
+// Experiment the experiment of the ranking or the ui, the users are split into the variants by the weights
+type Experiment struct {
+	// Key the unique key used by the frontend, e.g. question_list_ranking
+	Key         string `validate:"required,gt=0,lte=100" json:"key"`
+	Description string `validate:"omitempty,lte=500" json:"description"`
+	// Enabled only the enabled experiment assigns the variants and records the events
+	Enabled bool `json:"enabled"`
+	// Variants the first variant is usually the control group
+	Variants []*ExperimentVariant `validate:"required,min=2,max=10,dive" json:"variants"`
+}
+
+// ExperimentVariant the variant of the experiment
+type ExperimentVariant struct {
+	Key string `validate:"required,gt=0,lte=100" json:"key"`
+	// Weight the share of the traffic is the weight divided by the sum of the weights of all the variants
+	Weight int `validate:"omitempty,min=0,max=100" json:"weight"`
+}
+
+// SiteExperimentInfo the running experiment shown in the site info
+type SiteExperimentInfo struct {
+	Key      string   `json:"key"`
+	Variants []string `json:"variants"`
+}
+
+// AddExperimentEventReq record the exposure or the conversion of the login user request
+type AddExperimentEventReq struct {
+	ExperimentKey string `validate:"required,gt=0,lte=100" json:"experiment_key"`
+	EventType     string `validate:"required,oneof=exposure conversion" json:"event_type"`
+	// Name the name of the goal of the conversion, e.g. question_asked, empty means the default goal
+	Name   string `validate:"omitempty,lte=100" json:"name"`
+	UserID string `json:"-"`
+}
+
+// AddExperimentEventResp the variant the event is recorded for
+type AddExperimentEventResp struct {
+	Variant string `json:"variant"`
+}
+
+// GetExperimentStatsReq get the statistics of the experiment request
+type GetExperimentStatsReq struct {
+	ExperimentKey string `validate:"required,gt=0,lte=100" form:"experiment_key"`
+}
+
+// GetExperimentStatsResp the statistics of the experiment
+type GetExperimentStatsResp struct {
+	ExperimentKey string                    `json:"experiment_key"`
+	Variants      []*ExperimentVariantStats `json:"variants"`
+}
+
+// ExperimentVariantStats the number of the users exposed to the variant and the users converted
+type ExperimentVariantStats struct {
+	Variant     string                       `json:"variant"`
+	Exposures   int64                        `json:"exposures"`
+	Conversions []*ExperimentConversionStats `json:"conversions"`
+}
+
+// ExperimentConversionStats the number of the exposed users who complete the goal, and the rate in percent
+type ExperimentConversionStats struct {
+	Name  string  `json:"name"`
+	Users int64   `json:"users"`
+	Rate  float64 `json:"rate"`
+}
+
+// ExperimentEventCount the number of the distinct users of the event
+type ExperimentEventCount struct {
+	Variant   string `xorm:"variant"`
+	EventType string `xorm:"event_type"`
+	Name      string `xorm:"name"`
+	Users     int64  `xorm:"users"`
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// FeatureQuestionPoll the polls attached to the questions
+	FeatureQuestionPoll = "question_poll"
+	// FeatureAIAssistant the answer drafts and the question summaries generated by the ai assistant
+	FeatureAIAssistant = "ai_assistant"
+	// FeatureArticle the articles written by the users
+	FeatureArticle = "article"
+)
+
+// Features all the features that can be turned on or off, they are all on by default
+var Features = []string{FeatureQuestionPoll, FeatureAIAssistant, FeatureArticle}
+
+// SiteFeatureFlagsReq site feature flags settings request
+type SiteFeatureFlagsReq struct {
+	Flags []*FeatureFlag `validate:"omitempty,dive" json:"flags"`
+}
+
+// SiteFeatureFlagsResp site feature flags settings response, every feature is returned
+type SiteFeatureFlagsResp SiteFeatureFlagsReq
+
+// FeatureFlag the flag of the feature
+type FeatureFlag struct {
+	Key     string `validate:"required,oneof=question_poll ai_assistant article" json:"key"`
+	Enabled bool   `json:"enabled"`
+	// RoleIDs if it is not empty, the enabled feature is only available to the users with these roles
+	RoleIDs []int `validate:"omitempty,max=10" json:"role_ids"`
+}
+
+// IsAvailable whether the feature is available to the user with the role
+func (f *FeatureFlag) IsAvailable(roleID int) bool {
+	if !f.Enabled {
+		return false
+	}
+	if len(f.RoleIDs) == 0 {
+		return true
+	}
+	for _, id := range f.RoleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
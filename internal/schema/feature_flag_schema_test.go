@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureFlag_IsAvailable(t *testing.T) {
+	assert.True(t, (&FeatureFlag{Enabled: true}).IsAvailable(1))
+	assert.False(t, (&FeatureFlag{Enabled: false}).IsAvailable(1))
+	assert.True(t, (&FeatureFlag{Enabled: true, RoleIDs: []int{2, 3}}).IsAvailable(2))
+	assert.False(t, (&FeatureFlag{Enabled: true, RoleIDs: []int{2, 3}}).IsAvailable(1))
+	assert.False(t, (&FeatureFlag{Enabled: false, RoleIDs: []int{2}}).IsAvailable(2))
+}
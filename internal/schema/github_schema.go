@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	GitHubIssueStateOpen   = "open"
+	GitHubIssueStateClosed = "closed"
+	GitHubIssueStateMerged = "merged"
+)
+
+// SiteGitHubReq site GitHub integration settings request
+type SiteGitHubReq struct {
+	Enabled bool `json:"enabled"`
+	// APIURL the url of the GitHub api, https://api.github.com by default, or the api of the GitHub Enterprise Server
+	APIURL string `validate:"omitempty,url,lte=512" json:"api_url"`
+	// AppID, InstallationID and PrivateKey the GitHub App installed on the repositories, the issues of the
+	// public repositories can be unfurled without the app, but with a lower rate limit
+	AppID          int64  `validate:"omitempty,min=1" json:"app_id"`
+	InstallationID int64  `validate:"omitempty,min=1" json:"installation_id"`
+	PrivateKey     string `validate:"omitempty,lte=8192" json:"private_key"`
+	// UnfurlEnabled whether the issue and pull request links in the posts are shown with their live status
+	UnfurlEnabled bool `json:"unfurl_enabled"`
+	// CrossReferenceEnabled whether a comment is posted on the linked issue when the question gets an accepted answer
+	CrossReferenceEnabled bool `json:"cross_reference_enabled"`
+}
+
+// SiteGitHubResp site GitHub integration settings response
+type SiteGitHubResp SiteGitHubReq
+
+// GetGitHubReferencesReq get the GitHub references of the post request
+type GetGitHubReferencesReq struct {
+	ObjectID string `validate:"required" form:"object_id"`
+}
+
+// GitHubReferenceResp the issue or pull request linked in the post
+type GitHubReferenceResp struct {
+	URL           string `json:"url"`
+	Owner         string `json:"owner"`
+	Repo          string `json:"repo"`
+	Number        int    `json:"number"`
+	IsPullRequest bool   `json:"is_pull_request"`
+	Title         string `json:"title"`
+	// State open, closed or merged
+	State string `json:"state"`
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	InboundQuestionStatusPending  = "pending"
+	InboundQuestionStatusApproved = "approved"
+	InboundQuestionStatusRejected = "rejected"
+
+	InboundQuestionOperationApprove = "approve"
+	InboundQuestionOperationReject  = "reject"
+)
+
+// SiteInboundQuestionReq site inbound question settings request
+type SiteInboundQuestionReq struct {
+	Enabled bool `json:"enabled"`
+	// APIKey the key sent in the X-Inbound-Key header by the external systems, it is generated if empty
+	APIKey string `validate:"omitempty,gte=16,lte=128" json:"api_key"`
+	// MailboxEnabled whether the emails sent to the mailbox at the reply domain are queued as questions,
+	// the mailbox is served by the inbound email webhook, so reply by email should be enabled
+	MailboxEnabled bool   `json:"mailbox_enabled"`
+	MailboxName    string `validate:"omitempty,gt=0,lte=64" json:"mailbox_name"`
+	// DefaultTags the tags of the question sent without tags
+	DefaultTags []string `validate:"omitempty,max=5,dive,gt=0,lte=35" json:"default_tags"`
+}
+
+// SiteInboundQuestionResp site inbound question settings response
+type SiteInboundQuestionResp SiteInboundQuestionReq
+
+// AddInboundQuestionReq add inbound question request
+type AddInboundQuestionReq struct {
+	Title       string   `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Content     string   `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Tags        []string `validate:"omitempty,max=5,dive,gt=0,lte=35" json:"tags"`
+	SenderEmail string   `validate:"required,email,gt=0,lte=100" json:"sender_email"`
+	SenderName  string   `validate:"omitempty,lte=30" json:"sender_name"`
+	APIKey      string   `json:"-"`
+}
+
+// AddInboundQuestionResp add inbound question response
+type AddInboundQuestionResp struct {
+	ID int64 `json:"id"`
+}
+
+// GetInboundQuestionPageReq get inbound question page request
+type GetInboundQuestionPageReq struct {
+	Status   string `validate:"omitempty,oneof=pending approved rejected" form:"status"`
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// InboundQuestionResp inbound question response
+type InboundQuestionResp struct {
+	ID          int64    `json:"id"`
+	Channel     string   `json:"channel"`
+	SenderEmail string   `json:"sender_email"`
+	SenderName  string   `json:"sender_name"`
+	Title       string   `json:"title"`
+	Content     string   `json:"content"`
+	Tags        []string `json:"tags"`
+	Status      string   `json:"status"`
+	UserID      string   `json:"user_id"`
+	QuestionID  string   `json:"question_id"`
+	CreatedAt   int64    `json:"created_at"`
+}
+
+// ReviewInboundQuestionReq review inbound question request
+type ReviewInboundQuestionReq struct {
+	ID        int64  `validate:"required" json:"id"`
+	Operation string `validate:"required,oneof=approve reject" json:"operation"`
+	// Tags replace the tags of the question when it is approved
+	Tags   []string `validate:"omitempty,max=5,dive,gt=0,lte=35" json:"tags"`
+	UserID string   `json:"-"`
+}
+
+// ReviewInboundQuestionResp review inbound question response
+type ReviewInboundQuestionResp struct {
+	QuestionID string `json:"question_id"`
+}
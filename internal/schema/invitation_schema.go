@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	InvitationStatusAvailable = "available"
+	InvitationStatusUsedUp    = "used_up"
+	InvitationStatusExpired   = "expired"
+	InvitationStatusRevoked   = "revoked"
+)
+
+// AddInvitationReq add invitation request
+type AddInvitationReq struct {
+	// MaxUses the max times the invitation can be used, 0 means unlimited
+	MaxUses int `validate:"omitempty,min=0,max=1000" json:"max_uses"`
+	// ExpireDays the invitation expires after these days, 0 means never expire
+	ExpireDays int    `validate:"omitempty,min=0,max=365" json:"expire_days"`
+	UserID     string `json:"-"`
+	IsAdmin    bool   `json:"-"`
+}
+
+// InvitationResp invitation response
+type InvitationResp struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	URL       string `json:"url"`
+	MaxUses   int    `json:"max_uses"`
+	UsedCount int    `json:"used_count"`
+	ExpiredAt int64  `json:"expired_at"`
+	// invitation status(available,used_up,expired,revoked)
+	Status    string         `json:"status"`
+	CreatedAt int64          `json:"created_at"`
+	Inviter   *UserBasicInfo `json:"inviter,omitempty"`
+}
+
+// GetInvitationPageReq get invitation page request
+type GetInvitationPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// RevokeInvitationReq revoke invitation request
+type RevokeInvitationReq struct {
+	ID      int    `validate:"required" json:"id"`
+	UserID  string `json:"-"`
+	IsAdmin bool   `json:"-"`
+}
+
+// CheckInvitationReq check invitation request
+type CheckInvitationReq struct {
+	Code string `validate:"required,gt=0,lte=64" form:"code"`
+}
+
+// CheckInvitationResp check invitation response
+type CheckInvitationResp struct {
+	Valid   bool           `json:"valid"`
+	Inviter *UserBasicInfo `json:"inviter,omitempty"`
+}
+
+// GetInvitationRecordPageReq get invitation record page request
+type GetInvitationRecordPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+}
+
+// InvitationRecordResp the record of who invited whom
+type InvitationRecordResp struct {
+	ID           int            `json:"id"`
+	InvitationID int            `json:"invitation_id"`
+	CreatedAt    int64          `json:"created_at"`
+	Inviter      *UserBasicInfo `json:"inviter"`
+	Invitee      *UserBasicInfo `json:"invitee"`
+}
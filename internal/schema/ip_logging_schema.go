@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteIPLoggingReq site ip logging request, the records follow the ip retention policy of the legal settings
+type SiteIPLoggingReq struct {
+	// Enabled record the ip address and the user agent when the question, the answer or the comment is posted
+	Enabled bool `json:"enabled"`
+	// GeoIPURL the url of the geoip lookup service, {ip} is replaced with the ip address and the service must
+	// respond the json with the country, region and city, empty means the geoip enrichment is disabled
+	GeoIPURL string `validate:"omitempty,url,lte=512" json:"geoip_url"`
+}
+
+// GetPostIPRecordPageReq get post ip record page request
+type GetPostIPRecordPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1" form:"page_size"`
+	UserID   string `validate:"omitempty" form:"user_id"`
+	IP       string `validate:"omitempty,lte=64" form:"ip"`
+}
+
+// PostIPRecordResp post ip record response
+type PostIPRecordResp struct {
+	ID         int            `json:"id"`
+	User       *UserBasicInfo `json:"user,omitempty"`
+	ObjectID   string         `json:"object_id"`
+	ObjectType string         `json:"object_type"`
+	IP         string         `json:"ip"`
+	UserAgent  string         `json:"user_agent"`
+	Country    string         `json:"country"`
+	Region     string         `json:"region"`
+	City       string         `json:"city"`
+	CreatedAt  int64          `json:"created_at"`
+}
+
+// GetRelatedAccountsReq get the accounts sharing the ip addresses or the user agents with the user
+type GetRelatedAccountsReq struct {
+	UserID string `validate:"required" form:"user_id"`
+}
+
+// RelatedAccountResp the account sharing the ip addresses or the user agents with the user,
+// the posts and the login sessions are both matched
+type RelatedAccountResp struct {
+	User             *UserBasicInfo `json:"user"`
+	SharedIPs        []string       `json:"shared_ips"`
+	SharedUserAgents []string       `json:"shared_user_agents"`
+	LastSeenAt       int64          `json:"last_seen_at"`
+}
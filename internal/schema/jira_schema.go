@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// JiraStatusCategoryDone the key of the status category of the resolved Jira issues
+	JiraStatusCategoryDone = "done"
+	DefaultJiraIssueType   = "Task"
+)
+
+// SiteJiraReq site Jira integration settings request
+type SiteJiraReq struct {
+	Enabled bool `json:"enabled"`
+	// BaseURL the url of the Jira site, e.g. https://example.atlassian.net
+	BaseURL string `validate:"omitempty,url,lte=512" json:"base_url"`
+	// Email the account of the api token for Jira Cloud, the api token is used as the personal access token
+	// of Jira Server and Data Center if it is empty
+	Email      string   `validate:"omitempty,email,lte=256" json:"email"`
+	APIToken   string   `validate:"omitempty,lte=512" json:"api_token"`
+	ProjectKey string   `validate:"omitempty,lte=100" json:"project_key"`
+	IssueType  string   `validate:"omitempty,lte=100" json:"issue_type"`
+	Labels     []string `validate:"omitempty,max=10,dive,gt=0,lte=100" json:"labels"`
+	// FieldMapping set the fields of the issue to the question, the key is the field id, like customfield_10010,
+	// the value is the template with the placeholders {title}, {url}, {author} and {tags}
+	FieldMapping map[string]string `validate:"omitempty,max=20,dive,keys,gt=0,lte=100,endkeys,lte=1000" json:"field_mapping"`
+}
+
+// SiteJiraResp site Jira integration settings response
+type SiteJiraResp SiteJiraReq
+
+// EscalateToJiraReq escalate the question to Jira request
+type EscalateToJiraReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// GetJiraIssueLinkReq get the Jira issue of the question request
+type GetJiraIssueLinkReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// JiraIssueLinkResp the Jira issue the question is escalated to, it is shown as a banner on the question page
+type JiraIssueLinkResp struct {
+	IssueKey       string `json:"issue_key"`
+	IssueURL       string `json:"issue_url"`
+	Status         string `json:"status"`
+	StatusCategory string `json:"status_category"`
+	Resolved       bool   `json:"resolved"`
+	CreatedAt      int64  `json:"created_at"`
+	SyncedAt       int64  `json:"synced_at"`
+}
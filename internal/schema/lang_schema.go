@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// UploadLanguagePackReq upload language pack request, the translation file is uploaded as the file field
+type UploadLanguagePackReq struct {
+	// language code, such as zh_HK
+	Language string `validate:"required,gt=1,lte=20" form:"language"`
+	// label shown in the language options
+	Label string `validate:"required,gt=0,lte=100" form:"label"`
+}
+
+// RemoveLanguagePackReq remove language pack request
+type RemoveLanguagePackReq struct {
+	Language string `validate:"required,gt=1,lte=20" json:"language"`
+}
+
+// ErrorCatalogItem the error code in the error catalog
+type ErrorCatalogItem struct {
+	// Code the stable machine-readable error code
+	Code string `json:"code"`
+	// Reason the reason key of the error
+	Reason string `json:"reason"`
+	// Message the translated message of the error
+	Message string `json:"message"`
+}
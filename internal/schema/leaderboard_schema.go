@@ -0,0 +1,72 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+const (
+	LeaderboardPeriodWeek    = "week"
+	LeaderboardPeriodMonth   = "month"
+	LeaderboardPeriodQuarter = "quarter"
+	LeaderboardPeriodAll     = "all"
+)
+
+// LeaderboardPeriods all the periods of the leaderboards
+var LeaderboardPeriods = []string{
+	LeaderboardPeriodWeek,
+	LeaderboardPeriodMonth,
+	LeaderboardPeriodQuarter,
+	LeaderboardPeriodAll,
+}
+
+// GetReputationLeaderboardReq get reputation leaderboard request
+type GetReputationLeaderboardReq struct {
+	// period, default is week
+	Period string `validate:"omitempty,oneof=week month quarter all" form:"period"`
+}
+
+// GetTagLeaderboardReq get the top answerers of the tag request
+type GetTagLeaderboardReq struct {
+	// tag slug name
+	SlugName string `validate:"required,gt=0,lte=35" form:"tag_name"`
+	// period, default is week
+	Period string `validate:"omitempty,oneof=week month quarter all" form:"period"`
+}
+
+// LeaderboardResp leaderboard response
+type LeaderboardResp struct {
+	Period string `json:"period"`
+	// the start time of the period, 0 for all time
+	PeriodStart int64  `json:"period_start"`
+	TagID       string `json:"tag_id,omitempty"`
+	SlugName    string `json:"slug_name,omitempty"`
+	// the reputation earned in the period, or the number of answers in the tag in the period
+	List []*LeaderboardItem `json:"list"`
+}
+
+// LeaderboardItem the user in the leaderboard
+type LeaderboardItem struct {
+	Position int            `json:"position"`
+	Score    int            `json:"score"`
+	UserInfo *UserBasicInfo `json:"user_info"`
+}
+
+// UpdateLeaderboardOptOutReq update leaderboard opt-out request
+type UpdateLeaderboardOptOutReq struct {
+	OptOut bool   `json:"opt_out"`
+	UserID string `json:"-"`
+}
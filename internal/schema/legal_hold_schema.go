@@ -0,0 +1,76 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// PlaceLegalHoldReq place legal hold request
+type PlaceLegalHoldReq struct {
+	ObjectID    string `validate:"required" json:"object_id"`
+	ObjectType  string `validate:"required,oneof=user question answer comment" json:"object_type"`
+	Reason      string `validate:"required,notblank,lte=500" json:"reason"`
+	LoginUserID string `json:"-"`
+}
+
+// ReleaseLegalHoldReq release legal hold request, the reason is recorded in the audit log
+type ReleaseLegalHoldReq struct {
+	ID          int    `validate:"required" json:"id"`
+	Reason      string `validate:"required,notblank,lte=500" json:"reason"`
+	LoginUserID string `json:"-"`
+}
+
+// GetLegalHoldPageReq get legal hold page request
+type GetLegalHoldPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// status, default is active
+	Status string `validate:"omitempty,oneof=active released" form:"status"`
+}
+
+// LegalHoldResp legal hold response
+type LegalHoldResp struct {
+	ID           int            `json:"id"`
+	ObjectID     string         `json:"object_id"`
+	ObjectType   string         `json:"object_type"`
+	Reason       string         `json:"reason"`
+	Operator     *UserBasicInfo `json:"operator,omitempty"`
+	CreatedAt    int64          `json:"created_at"`
+	ReleasedUser *UserBasicInfo `json:"released_user,omitempty"`
+	ReleasedAt   int64          `json:"released_at"`
+}
+
+// GetLegalHoldLogPageReq get legal hold log page request
+type GetLegalHoldLogPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// object id, default is all
+	ObjectID string `validate:"omitempty" form:"object_id"`
+}
+
+// LegalHoldLogResp legal hold log response
+type LegalHoldLogResp struct {
+	ID          int            `json:"id"`
+	LegalHoldID int            `json:"legal_hold_id"`
+	ObjectID    string         `json:"object_id"`
+	ObjectType  string         `json:"object_type"`
+	Action      string         `json:"action"`
+	Process     string         `json:"process"`
+	Operator    *UserBasicInfo `json:"operator,omitempty"`
+	Reason      string         `json:"reason"`
+	CreatedAt   int64          `json:"created_at"`
+}
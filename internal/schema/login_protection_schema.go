@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// DefaultLoginLockoutThreshold the default number of the failed sign-in attempts which locks the account
+const DefaultLoginLockoutThreshold = 5
+
+// DefaultLoginLockoutMinutes the default minutes of the first lockout
+const DefaultLoginLockoutMinutes = 5
+
+// LoginAttempt the sign-in attempt with the email and password
+type LoginAttempt struct {
+	Email     string
+	IP        string
+	UserAgent string
+}
+
+// LoginLockedResp the account or the ip address is locked
+type LoginLockedResp struct {
+	// LockedUntil the sign-in can be tried again after this time
+	LockedUntil int64 `json:"locked_until"`
+}
+
+// GetLoginFailurePageReq get the failed sign-in attempts request
+type GetLoginFailurePageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// Email only the attempts with this email
+	Email string `validate:"omitempty,lte=100" form:"email"`
+	// IP only the attempts from this ip address
+	IP string `validate:"omitempty,lte=64" form:"ip"`
+}
+
+// GetLoginFailureResp the failed sign-in attempt
+type GetLoginFailureResp struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	Email     string `json:"email"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	// LockedMinutes the account or the ip address is locked for these minutes after this attempt
+	LockedMinutes int `json:"locked_minutes"`
+	// UserInfo the user of the email, it is empty if the email is not registered
+	UserInfo *UserBasicInfo `json:"user_info,omitempty"`
+}
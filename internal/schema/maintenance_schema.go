@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteMaintenanceReq site maintenance settings request
+type SiteMaintenanceReq struct {
+	// Enabled put the api into the read-only mode, the reads, the login and the admin api still work
+	Enabled bool `json:"enabled"`
+	// Message shown to the users when they try to change anything, the default message is used if it is empty
+	Message string `validate:"omitempty,sanitizer,lte=500" json:"message"`
+}
+
+// SiteMaintenanceResp site maintenance settings response
+type SiteMaintenanceResp struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message"`
+	// ForcedByConfig the read-only mode is turned on by the config file, it can not be turned off by the admin
+	ForcedByConfig bool `json:"forced_by_config"`
+}
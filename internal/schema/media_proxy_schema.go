@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteMediaProxyReq site media proxy settings request
+type SiteMediaProxyReq struct {
+	// Enabled rewrite the external images in the rendered content to be loaded through the site,
+	// so the reader's IP is not exposed to the external sites and the http images do not break https pages
+	Enabled bool `json:"enabled"`
+	// MaxSizeMB the image larger than it is not proxied, 0 means the default size
+	MaxSizeMB int `validate:"omitempty,min=0,max=50" json:"max_size_mb"`
+	// CacheHours the proxied image is cached for these hours, 0 means the default hours
+	CacheHours int `validate:"omitempty,min=0,max=720" json:"cache_hours"`
+}
+
+// SiteMediaProxyResp site media proxy settings response
+type SiteMediaProxyResp SiteMediaProxyReq
+
+// DefaultMediaProxyMaxSizeMB the default max size of the proxied image
+const DefaultMediaProxyMaxSizeMB = 5
+
+// DefaultMediaProxyCacheHours the default cache hours of the proxied image
+const DefaultMediaProxyCacheHours = 24
+
+// MediaProxyReq load the external image through the proxy request
+type MediaProxyReq struct {
+	URL string `validate:"required,url" form:"url"`
+	// Sig the HMAC signature of the url, it is generated when the content is rendered
+	Sig string `validate:"required" form:"sig"`
+}
+
+// MediaProxyResp the proxied image
+type MediaProxyResp struct {
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+	// CacheHours the browser may also cache the image for these hours
+	CacheHours int `json:"-"`
+}
@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// ModerationActionHold the question is held for review by the moderators
+	ModerationActionHold = "hold"
+	// ModerationActionTag the tags of the rule are added to the question
+	ModerationActionTag = "tag"
+	// ModerationActionClose the question is closed with the close reason of the rule
+	ModerationActionClose = "close"
+	// ModerationActionNotify the admins and moderators are notified
+	ModerationActionNotify = "notify"
+
+	// ModerationTriggerCreate the rule is evaluated when the question is asked
+	ModerationTriggerCreate = "create"
+	// ModerationTriggerEdit the rule is evaluated when the question is edited
+	ModerationTriggerEdit = "edit"
+)
+
+// ModerationRule the moderation rule of the questions, the rule is matched if all the set conditions are matched
+type ModerationRule struct {
+	Name    string `validate:"required,notblank,lte=100" json:"name"`
+	Enabled bool   `json:"enabled"`
+	// DryRun the hits of the rule are logged but the actions are not applied
+	DryRun bool `json:"dry_run"`
+	// Triggers when the rule is evaluated (create,edit), empty means both
+	Triggers []string `validate:"omitempty,dive,oneof=create edit" json:"triggers"`
+	// AuthorRankBelow matched if the rank of the author is lower than it, 0 means no condition
+	AuthorRankBelow int `validate:"omitempty,min=0" json:"author_rank_below"`
+	// BodyRegex the regular expression matched with the title and the content
+	BodyRegex string `validate:"omitempty,lte=500" json:"body_regex"`
+	// MinLinkCount matched if the content has at least the number of links, 0 means no condition
+	MinLinkCount int `validate:"omitempty,min=0" json:"min_link_count"`
+	// Tags matched if the question has any of the tags
+	Tags    []string `validate:"omitempty,lte=20,dive,required,lte=35" json:"tags"`
+	Actions []string `validate:"required,min=1,dive,oneof=hold tag close notify" json:"actions"`
+	// AddTags the tags added by the tag action, the tags must exist
+	AddTags []string `validate:"omitempty,lte=5,dive,required,lte=35" json:"add_tags"`
+	// CloseType the id of the close reason used by the close action
+	CloseType int `validate:"omitempty,min=0" json:"close_type"`
+	// CloseMsg the message of the close action
+	CloseMsg string `validate:"omitempty,lte=500" json:"close_msg"`
+}
+
+// HasCondition whether any condition of the rule is set
+func (r *ModerationRule) HasCondition() bool {
+	return r.AuthorRankBelow > 0 || len(r.BodyRegex) > 0 || r.MinLinkCount > 0 || len(r.Tags) > 0
+}
+
+// HasAction whether the rule has the action
+func (r *ModerationRule) HasAction(action string) bool {
+	for _, item := range r.Actions {
+		if item == action {
+			return true
+		}
+	}
+	return false
+}
+
+// SiteModerationRulesReq site moderation rules request
+type SiteModerationRulesReq struct {
+	Rules []*ModerationRule `validate:"omitempty,lte=100,dive" json:"rules"`
+}
+
+// GetModerationRuleHitPageReq get moderation rule hit page request
+type GetModerationRuleHitPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// rule name, empty means all
+	RuleName string `validate:"omitempty,lte=100" form:"rule_name"`
+}
+
+// ModerationRuleHitResp moderation rule hit response
+type ModerationRuleHitResp struct {
+	ID         int    `json:"id"`
+	CreatedAt  int64  `json:"created_at"`
+	RuleName   string `json:"rule_name"`
+	QuestionID string `json:"question_id"`
+	UserID     string `json:"user_id"`
+	Trigger    string `json:"trigger"`
+	// Actions the actions of the rule, they are not applied in the dry run
+	Actions []string `json:"actions"`
+	DryRun  bool     `json:"dry_run"`
+}
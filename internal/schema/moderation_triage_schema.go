@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	TriageSectionFlaggedPosts = "flagged_posts"
+	TriageSectionCloseVotes   = "close_votes"
+	TriageSectionReopenVotes  = "reopen_votes"
+	TriageSectionReviewQueue  = "review_queue"
+	TriageSectionSLABreached  = "sla_breached"
+	TriageSectionSpamSuspects = "spam_suspects"
+
+	TriageActionApprove = "approve"
+	TriageActionReject  = "reject"
+	TriageActionReopen  = "reopen"
+	TriageActionSuspend = "suspend"
+	TriageActionDismiss = "dismiss"
+)
+
+// GetTriageDashboardReq get triage dashboard request
+type GetTriageDashboardReq struct {
+	// the number of the latest items of each section
+	Limit int `validate:"omitempty,min=1,max=50" form:"limit" json:"limit"`
+}
+
+// TriageDashboardResp triage dashboard response
+type TriageDashboardResp struct {
+	TodoAmount int64            `json:"todo_amount"`
+	Sections   []*TriageSection `json:"sections"`
+}
+
+// TriageSection the section of the items needing attention of the same kind
+type TriageSection struct {
+	Name       string        `json:"name"`
+	TodoAmount int64         `json:"todo_amount"`
+	Items      []*TriageItem `json:"items"`
+}
+
+// TriageItem the item needing attention
+type TriageItem struct {
+	// ID the id used by the quick action, it is the report id, the review id, the question id or the user id
+	ID         string         `json:"id"`
+	ObjectID   string         `json:"object_id"`
+	ObjectType string         `json:"object_type"`
+	QuestionID string         `json:"question_id"`
+	Title      string         `json:"title"`
+	Reason     *ReasonItem    `json:"reason,omitempty"`
+	Content    string         `json:"content"`
+	User       *UserBasicInfo `json:"user,omitempty"`
+	// FlagCount the number of the pending spam flags of the spam suspect
+	FlagCount int64    `json:"flag_count,omitempty"`
+	CreatedAt int64    `json:"created_at"`
+	Actions   []string `json:"actions"`
+}
+
+// TriageActionReq triage quick action request
+type TriageActionReq struct {
+	Section   string `validate:"required,oneof=flagged_posts close_votes reopen_votes review_queue spam_suspects" json:"section"`
+	ID        string `validate:"required" json:"id"`
+	Action    string `validate:"required" json:"action"`
+	CloseType int    `validate:"omitempty" json:"close_type"`
+	CloseMsg  string `validate:"omitempty" json:"close_msg"`
+	UserID    string `json:"-"`
+	IsAdmin   bool   `json:"-"`
+}
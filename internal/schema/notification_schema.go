@@ -19,6 +19,12 @@
 
 package schema
 
+import (
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+)
+
 const (
 	NotificationTypeInbox        = 1
 	NotificationTypeAchievement  = 2
@@ -57,6 +63,26 @@ type NotificationContent struct {
 	UpdateTime         int64          `json:"update_time"`
 }
 
+// GroupKey the related notifications are grouped by the action and the post they are about,
+// like the answers to the same question or the votes on the same answer
+func (n *NotificationContent) GroupKey() string {
+	target := n.ObjectInfo.ObjectID
+	switch n.NotificationAction {
+	case constant.NotificationAnswerTheQuestion, constant.NotificationUpdateQuestion,
+		constant.NotificationCommentQuestion, constant.NotificationUpVotedTheQuestion,
+		constant.NotificationDownVotedTheQuestion, constant.NotificationInvitedYouToAnswer:
+		if questionID := n.ObjectInfo.ObjectMap["question"]; len(questionID) > 0 {
+			target = questionID
+		}
+	case constant.NotificationUpdateAnswer, constant.NotificationCommentAnswer,
+		constant.NotificationUpVotedTheAnswer, constant.NotificationDownVotedTheAnswer:
+		if answerID := n.ObjectInfo.ObjectMap["answer"]; len(answerID) > 0 {
+			target = answerID
+		}
+	}
+	return fmt.Sprintf("%d:%s:%s", n.Type, n.NotificationAction, target)
+}
+
 type GetRedDot struct {
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
@@ -123,3 +149,66 @@ type NotificationClearIDRequest struct {
 	UserID string `json:"-"`
 	ID     string `json:"id" form:"id"`
 }
+
+// NotificationGroupSearch get the grouped notifications request
+type NotificationGroupSearch struct {
+	Page         int    `validate:"omitempty,min=1" form:"page"`
+	PageSize     int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	TypeStr      string `validate:"required,oneof=inbox achievement" form:"type"`
+	InboxTypeStr string `validate:"omitempty,oneof=all posts invites votes" form:"inbox_type"`
+	Type         int    `json:"-"`
+	InboxType    int    `json:"-"`
+	UserID       string `json:"-"`
+}
+
+// NotificationGroup the amount of the notifications with the same group key
+type NotificationGroup struct {
+	GroupKey     string `xorm:"group_key"`
+	LatestID     string `xorm:"latest_id"`
+	Amount       int64  `xorm:"amount"`
+	UnreadAmount int64  `xorm:"unread_amount"`
+}
+
+// NotificationGroupContent the group of the related notifications, the content is the latest notification of the group
+type NotificationGroupContent struct {
+	*NotificationContent
+	GroupKey     string `json:"group_key"`
+	Amount       int64  `json:"amount"`
+	UnreadAmount int64  `json:"unread_amount"`
+	// GroupAction the summary of the group, like "3 new answers", it is empty if the group has one notification
+	GroupAction string `json:"group_action,omitempty"`
+}
+
+// NotificationReadGroupRequest mark the notifications of the group as read request
+type NotificationReadGroupRequest struct {
+	UserID   string `json:"-"`
+	GroupKey string `validate:"required,lte=128" json:"group_key"`
+}
+
+// NotificationReadTypeRequest mark the notifications of the type as read request
+type NotificationReadTypeRequest struct {
+	UserID       string `json:"-"`
+	TypeStr      string `validate:"required,oneof=inbox achievement" json:"type"`
+	InboxTypeStr string `validate:"omitempty,oneof=all posts invites votes" json:"inbox_type"`
+}
+
+// NotificationUnreadCount the unread amount of the notification type and inbox type
+type NotificationUnreadCount struct {
+	Type         int   `xorm:"type"`
+	MsgType      int   `xorm:"msg_type"`
+	UnreadAmount int64 `xorm:"unread_amount"`
+}
+
+// NotificationUnreadCountResp the unread amount by category
+type NotificationUnreadCountResp struct {
+	Inbox       *NotificationInboxUnreadCount `json:"inbox"`
+	Achievement int64                         `json:"achievement"`
+}
+
+// NotificationInboxUnreadCount the unread amount of the inbox by inbox type
+type NotificationInboxUnreadCount struct {
+	All     int64 `json:"all"`
+	Posts   int64 `json:"posts"`
+	Votes   int64 `json:"votes"`
+	Invites int64 `json:"invites"`
+}
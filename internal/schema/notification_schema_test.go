@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationContent_GroupKey(t *testing.T) {
+	objectMap := map[string]string{"question": "10010000000000001", "answer": "10020000000000001"}
+	answer := &NotificationContent{
+		Type:               NotificationTypeInbox,
+		NotificationAction: constant.NotificationAnswerTheQuestion,
+		ObjectInfo:         ObjectInfo{ObjectID: "10020000000000001", ObjectMap: objectMap},
+	}
+	otherAnswer := &NotificationContent{
+		Type:               NotificationTypeInbox,
+		NotificationAction: constant.NotificationAnswerTheQuestion,
+		ObjectInfo: ObjectInfo{ObjectID: "10020000000000002",
+			ObjectMap: map[string]string{"question": "10010000000000001", "answer": "10020000000000002"}},
+	}
+	assert.Equal(t, answer.GroupKey(), otherAnswer.GroupKey())
+	assert.Equal(t, "1:notification.action.answer_the_question:10010000000000001", answer.GroupKey())
+
+	vote := &NotificationContent{
+		Type:               NotificationTypeInbox,
+		NotificationAction: constant.NotificationUpVotedTheAnswer,
+		ObjectInfo:         ObjectInfo{ObjectID: "10020000000000001", ObjectMap: objectMap},
+	}
+	assert.Equal(t, "1:notification.action.up_voted_answer:10020000000000001", vote.GroupKey())
+
+	mention := &NotificationContent{
+		Type:               NotificationTypeInbox,
+		NotificationAction: constant.NotificationMentionYou,
+		ObjectInfo:         ObjectInfo{ObjectID: "10030000000000001", ObjectMap: objectMap},
+	}
+	assert.Equal(t, "1:notification.action.mention_you:10030000000000001", mention.GroupKey())
+}
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// EmbedQuestionPrefix the path prefix of the embeddable question page
+	EmbedQuestionPrefix = "/embed/questions/"
+	EmbedDefaultWidth   = 600
+	EmbedDefaultHeight  = 400
+	// EmbedCacheAge the suggested cache lifetime of the oEmbed response in seconds
+	EmbedCacheAge = 3600
+)
+
+// GetEmbedQuestionReq get embed question request
+type GetEmbedQuestionReq struct {
+	ID       string `validate:"required" form:"id"`
+	ClientIP string `json:"-"`
+}
+
+// EmbedQuestionResp the minimal representation of the question for embedding
+type EmbedQuestionResp struct {
+	ID             string           `json:"id"`
+	Title          string           `json:"title"`
+	Excerpt        string           `json:"excerpt"`
+	URL            string           `json:"url"`
+	VoteCount      int              `json:"vote_count"`
+	AnswerCount    int              `json:"answer_count"`
+	CreateTime     int64            `json:"create_time"`
+	Author         *UserBasicInfo   `json:"author"`
+	AcceptedAnswer *EmbedAnswerItem `json:"accepted_answer"`
+	SiteName       string           `json:"site_name"`
+	SiteURL        string           `json:"site_url"`
+}
+
+// EmbedAnswerItem the minimal representation of the answer for embedding
+type EmbedAnswerItem struct {
+	ID         string         `json:"id"`
+	Excerpt    string         `json:"excerpt"`
+	URL        string         `json:"url"`
+	VoteCount  int            `json:"vote_count"`
+	CreateTime int64          `json:"create_time"`
+	Author     *UserBasicInfo `json:"author"`
+}
+
+// GetOEmbedReq get oEmbed request, see https://oembed.com
+type GetOEmbedReq struct {
+	URL       string `validate:"required,url" form:"url"`
+	MaxWidth  int    `validate:"omitempty,min=1" form:"maxwidth"`
+	MaxHeight int    `validate:"omitempty,min=1" form:"maxheight"`
+	// only json format is supported
+	Format   string `validate:"omitempty,oneof=json" form:"format"`
+	ClientIP string `json:"-"`
+}
+
+// OEmbedResp oEmbed response of rich type
+type OEmbedResp struct {
+	Type         string `json:"type"`
+	Version      string `json:"version"`
+	Title        string `json:"title"`
+	AuthorName   string `json:"author_name,omitempty"`
+	AuthorURL    string `json:"author_url,omitempty"`
+	ProviderName string `json:"provider_name"`
+	ProviderURL  string `json:"provider_url"`
+	CacheAge     int    `json:"cache_age"`
+	HTML         string `json:"html"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
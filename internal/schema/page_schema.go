@@ -0,0 +1,145 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// pageSlugNameRegexp page slug name only contains lowercase letters, numbers and hyphens
+var pageSlugNameRegexp = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// AddPageReq add page request
+type AddPageReq struct {
+	// title
+	Title string `validate:"required,notblank,lte=150" json:"title"`
+	// slug name, the page is served at /pages/{slug_name}
+	SlugName string `validate:"required,notblank,lte=100" json:"slug_name"`
+	// content in markdown
+	Content string `validate:"required,notblank,lte=65536" json:"content"`
+	// html
+	HTML string `json:"-"`
+	// status
+	Status string `validate:"required,oneof=draft published" json:"status" enums:"draft,published"`
+	// whether to show the page link in the site navigation
+	ShowInNav bool `json:"show_in_nav"`
+	// the order in the site navigation, ascending
+	NavOrder int `validate:"omitempty,min=0" json:"nav_order"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (req *AddPageReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.SlugName = strings.ToLower(strings.TrimSpace(req.SlugName))
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return checkPageSlugName(req.SlugName)
+}
+
+// AddPageResp add page response
+type AddPageResp struct {
+	ID       int    `json:"id"`
+	SlugName string `json:"slug_name"`
+}
+
+// UpdatePageReq update page request
+type UpdatePageReq struct {
+	// page id
+	ID int `validate:"required,min=1" json:"id"`
+	// title
+	Title string `validate:"required,notblank,lte=150" json:"title"`
+	// slug name
+	SlugName string `validate:"required,notblank,lte=100" json:"slug_name"`
+	// content in markdown
+	Content string `validate:"required,notblank,lte=65536" json:"content"`
+	// html
+	HTML string `json:"-"`
+	// status
+	Status string `validate:"required,oneof=draft published" json:"status" enums:"draft,published"`
+	// whether to show the page link in the site navigation
+	ShowInNav bool `json:"show_in_nav"`
+	// the order in the site navigation, ascending
+	NavOrder int `validate:"omitempty,min=0" json:"nav_order"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (req *UpdatePageReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.SlugName = strings.ToLower(strings.TrimSpace(req.SlugName))
+	req.HTML = converter.Markdown2HTML(req.Content)
+	return checkPageSlugName(req.SlugName)
+}
+
+func checkPageSlugName(slugName string) (errFields []*validator.FormErrorField, err error) {
+	if pageSlugNameRegexp.MatchString(slugName) {
+		return nil, nil
+	}
+	errFields = append(errFields, &validator.FormErrorField{
+		ErrorField: "slug_name",
+		ErrorMsg:   reason.PageSlugNameInvalid,
+	})
+	return errFields, errors.BadRequest(reason.PageSlugNameInvalid)
+}
+
+// RemovePageReq remove page request
+type RemovePageReq struct {
+	// page id
+	ID int `validate:"required,min=1" json:"id"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetAdminPageReq get page by id request for admin
+type GetAdminPageReq struct {
+	// page id
+	ID int `validate:"required,min=1" form:"id"`
+}
+
+// GetPageReq get published page by slug name request
+type GetPageReq struct {
+	// slug name
+	SlugName string `validate:"required,notblank,lte=100" form:"slug_name"`
+}
+
+// GetPageResp get page response
+type GetPageResp struct {
+	ID        int    `json:"id"`
+	Title     string `json:"title"`
+	SlugName  string `json:"slug_name"`
+	Content   string `json:"content"`
+	HTML      string `json:"html"`
+	Status    string `json:"status"`
+	ShowInNav bool   `json:"show_in_nav"`
+	NavOrder  int    `json:"nav_order"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// GetPageNavResp the link of page in the site navigation
+type GetPageNavResp struct {
+	Title    string `json:"title"`
+	SlugName string `json:"slug_name"`
+	URL      string `json:"url"`
+}
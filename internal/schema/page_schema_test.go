@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddPageReqCheck(t *testing.T) {
+	req := &AddPageReq{SlugName: " Privacy-Policy ", Content: "# Privacy"}
+	errFields, err := req.Check()
+	assert.NoError(t, err)
+	assert.Empty(t, errFields)
+	assert.Equal(t, "privacy-policy", req.SlugName)
+	assert.Contains(t, req.HTML, "<h1")
+
+	for _, slugName := range []string{"about us", "-faq", "faq-", "faq--2", "about/us"} {
+		req = &AddPageReq{SlugName: slugName}
+		errFields, err = req.Check()
+		assert.Error(t, err, slugName)
+		assert.Len(t, errFields, 1, slugName)
+	}
+}
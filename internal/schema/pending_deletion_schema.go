@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+// UndoDeleteReq undo the deletion of the question, answer or comment request
+type UndoDeleteReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// GetRecycleBinPageReq get the deleted content page request
+type GetRecycleBinPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// object type, default is all
+	ObjectType string `validate:"omitempty,oneof=question answer comment" form:"object_type"`
+}
+
+// RecycleBinItem the deleted content in the recycle bin
+type RecycleBinItem struct {
+	ObjectID   string `json:"object_id"`
+	ObjectType string `json:"object_type"`
+	QuestionID string `json:"question_id"`
+	// the title of the question which the content belongs to
+	Title   string         `json:"title"`
+	Excerpt string         `json:"excerpt"`
+	Author  *UserBasicInfo `json:"author"`
+	Deleter *UserBasicInfo `json:"deleter"`
+	Reason  string         `json:"reason"`
+	// deleted time
+	DeletedAt int64 `json:"deleted_at"`
+	// the content is purged permanently at this time, 0 means never
+	PurgeAt int64 `json:"purge_at"`
+}
+
+// RestoreDeletedReq restore the deleted content from the recycle bin request
+type RestoreDeletedReq struct {
+	ObjectID string `validate:"required" json:"object_id"`
+	UserID   string `json:"-"`
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// ShareLinkQuestionPrefix the path prefix of the question short link
+	ShareLinkQuestionPrefix = "/q/"
+	// ShareLinkAnswerPrefix the path prefix of the answer short link
+	ShareLinkAnswerPrefix = "/a/"
+	// ShareLinkReferralParam the query param of the username who shared the link
+	ShareLinkReferralParam = "shareUserId"
+)
+
+// GetShareLinkReq get share link request
+type GetShareLinkReq struct {
+	// question or answer id
+	ObjectID string `validate:"required" form:"object_id"`
+	UserID   string `json:"-"`
+}
+
+// GetShareLinkResp get share link response
+type GetShareLinkResp struct {
+	ObjectType string `json:"object_type"`
+	ShortID    string `json:"short_id"`
+	// URL the short link, with the referral param if the user is logged in
+	URL string `json:"url"`
+	// Permalink the full url of the object
+	Permalink string `json:"permalink"`
+}
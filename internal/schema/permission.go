@@ -21,6 +21,7 @@ package schema
 
 import (
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
@@ -58,16 +59,82 @@ type GetPermissionResp struct {
 	HasPermission bool `json:"has_permission"`
 	// only not allow, will return this tip
 	NoPermissionTip string `json:"no_permission_tip"`
+	// the guidance shown to the new contributor before posting
+	Guidance string `json:"guidance,omitempty"`
 }
 
 func (r *GetPermissionResp) TrTip(lang i18n.Language, requireRank int) {
 	if r.HasPermission {
 		return
 	}
+	r.NoPermissionTip = NoPermissionTip(lang, requireRank)
+}
+
+// NoPermissionTip the tip of the action denied by the rank, the required rank is shown if it is known
+func NoPermissionTip(lang i18n.Language, requireRank int) string {
 	if requireRank <= 0 {
-		r.NoPermissionTip = translator.Tr(lang, reason.RankFailToMeetTheCondition)
+		return translator.Tr(lang, reason.RankFailToMeetTheCondition)
+	}
+	return translator.TrWithData(lang, reason.NoEnoughRankToOperate, &PermissionTrTplData{Rank: requireRank})
+}
+
+const (
+	AllowedActionEdit   = "can_edit"
+	AllowedActionDelete = "can_delete"
+	AllowedActionClose  = "can_close"
+	AllowedActionVote   = "can_vote"
+)
+
+// ActionPermission the permission of the action granted by the role and the rank of the user
+type ActionPermission struct {
+	Can         bool
+	RequireRank int
+}
+
+// ObjectActionPermissions the permissions of the actions on the objects of the same type,
+// the close permission is nil if the object can not be closed
+type ObjectActionPermissions struct {
+	Edit   *ActionPermission
+	Delete *ActionPermission
+	Close  *ActionPermission
+	Vote   *ActionPermission
+}
+
+// AllowedActionsObject the state of the object which decides the allowed actions
+type AllowedActionsObject struct {
+	CreatorUserID string
+	Deleted       bool
+	Closed        bool
+	// OwnerEditDeadline the owner can not edit the object after the deadline, zero means no deadline
+	OwnerEditDeadline time.Time
+}
+
+// AllowedActions the actions the current user can do on the object, they are resolved by the server
+// so the frontend does not need to compute the permissions again
+type AllowedActions struct {
+	CanEdit   bool `json:"can_edit"`
+	CanDelete bool `json:"can_delete"`
+	CanClose  bool `json:"can_close"`
+	CanVote   bool `json:"can_vote"`
+	// Reasons the reasons of the denied actions, the key is the action such as can_vote
+	Reasons map[string]string `json:"reasons"`
+}
+
+// Set allow the action or deny it with the reason
+func (a *AllowedActions) Set(action string, can bool, reason string) {
+	switch action {
+	case AllowedActionEdit:
+		a.CanEdit = can
+	case AllowedActionDelete:
+		a.CanDelete = can
+	case AllowedActionClose:
+		a.CanClose = can
+	case AllowedActionVote:
+		a.CanVote = can
+	}
+	if can {
+		delete(a.Reasons, action)
 	} else {
-		r.NoPermissionTip = translator.TrWithData(
-			lang, reason.NoEnoughRankToOperate, &PermissionTrTplData{Rank: requireRank})
+		a.Reasons[action] = reason
 	}
 }
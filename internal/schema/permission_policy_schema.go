@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// PermissionPolicyRule the rule of the permission policy, either the route or the action should be set
+type PermissionPolicyRule struct {
+	// Route the api which requires login with the method, such as "POST /answer/api/v1/question"
+	Route string `validate:"omitempty,lte=200" json:"route"`
+	// Action the permission action, such as "comment.add", the rule is checked wherever the action is checked
+	Action string `validate:"omitempty,lte=100" json:"action"`
+	// MinRank the minimum reputation required, -1 means nobody can do it by reputation.
+	// nil means no limit for the route and the reputation in the privileges for the action.
+	MinRank *int `validate:"omitempty,min=-1" json:"min_rank"`
+	// RoleIDs only the users with these roles are allowed, empty means all roles
+	RoleIDs []int `validate:"omitempty,dive,min=1" json:"role_ids"`
+}
+
+// SitePermissionPolicyReq the permission policy request
+type SitePermissionPolicyReq struct {
+	Rules []*PermissionPolicyRule `validate:"omitempty,dive" json:"rules"`
+}
+
+// SitePermissionPolicyResp the permission policy response
+type SitePermissionPolicyResp SitePermissionPolicyReq
@@ -43,6 +43,7 @@ type GetPluginListResp struct {
 	Version     string `json:"version"`
 	Enabled     bool   `json:"enabled"`
 	HaveConfig  bool   `json:"have_config"`
+	HaveJob     bool   `json:"have_job"`
 	Link        string `json:"link"`
 }
 
@@ -167,3 +168,24 @@ type UpdatePluginConfigReq struct {
 	PluginSlugName string         `validate:"required,gt=1,lte=100" json:"plugin_slug_name"`
 	ConfigFields   map[string]any `json:"config_fields"`
 }
+
+type GetPluginJobListReq struct {
+	PluginSlugName string `validate:"required,gt=1,lte=100" form:"plugin_slug_name"`
+}
+
+type GetPluginJobResp struct {
+	Name         string `json:"name"`
+	Title        string `json:"title"`
+	Schedule     string `json:"schedule"`
+	Enabled      bool   `json:"enabled"`
+	LastRunAt    int64  `json:"last_run_at"`
+	LastDuration int64  `json:"last_duration"`
+	LastStatus   string `json:"last_status"`
+	LastError    string `json:"last_error"`
+}
+
+type UpdatePluginJobStatusReq struct {
+	PluginSlugName string `validate:"required,gt=1,lte=100" json:"plugin_slug_name"`
+	JobName        string `validate:"required,gt=1,lte=100" json:"job_name"`
+	Enabled        bool   `json:"enabled"`
+}
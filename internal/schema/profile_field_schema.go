@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// profileFieldNameRegexp profile field name only contains lowercase letters, numbers and underscores
+var profileFieldNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// AddProfileFieldReq add profile field request
+type AddProfileFieldReq struct {
+	// field name, the key of the value in the profile api
+	Name string `validate:"required,notblank,lte=50" json:"name"`
+	// label shown in the profile page
+	Label string `validate:"required,notblank,lte=100" json:"label"`
+	// type
+	Type string `validate:"required,oneof=text url select boolean" json:"type" enums:"text,url,select,boolean"`
+	// options of the select field
+	Options []string `validate:"omitempty,dive,required,lte=100" json:"options"`
+	// whether the user must fill in the field
+	Required bool `json:"required"`
+	// visibility
+	Visibility string `validate:"required,oneof=public logged_in private" json:"visibility" enums:"public,logged_in,private"`
+	// sort, ascending
+	Sort int `validate:"omitempty,min=0" json:"sort"`
+}
+
+func (req *AddProfileFieldReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	return checkProfileField(req.Name, req.Type, req.Options)
+}
+
+// UpdateProfileFieldReq update profile field request
+type UpdateProfileFieldReq struct {
+	// field id
+	ID int `validate:"required,min=1" json:"id"`
+	// field name, the key of the value in the profile api
+	Name string `validate:"required,notblank,lte=50" json:"name"`
+	// label shown in the profile page
+	Label string `validate:"required,notblank,lte=100" json:"label"`
+	// type
+	Type string `validate:"required,oneof=text url select boolean" json:"type" enums:"text,url,select,boolean"`
+	// options of the select field
+	Options []string `validate:"omitempty,dive,required,lte=100" json:"options"`
+	// whether the user must fill in the field
+	Required bool `json:"required"`
+	// visibility
+	Visibility string `validate:"required,oneof=public logged_in private" json:"visibility" enums:"public,logged_in,private"`
+	// sort, ascending
+	Sort int `validate:"omitempty,min=0" json:"sort"`
+}
+
+func (req *UpdateProfileFieldReq) Check() (errFields []*validator.FormErrorField, err error) {
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	return checkProfileField(req.Name, req.Type, req.Options)
+}
+
+func checkProfileField(name, fieldType string, options []string) (errFields []*validator.FormErrorField, err error) {
+	if !profileFieldNameRegexp.MatchString(name) {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "name",
+			ErrorMsg:   reason.ProfileFieldNameInvalid,
+		})
+		return errFields, errors.BadRequest(reason.ProfileFieldNameInvalid)
+	}
+	if fieldType == entity.ProfileFieldTypeSelect && len(options) == 0 {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "options",
+			ErrorMsg:   reason.ProfileFieldOptionsRequired,
+		})
+		return errFields, errors.BadRequest(reason.ProfileFieldOptionsRequired)
+	}
+	return nil, nil
+}
+
+// RemoveProfileFieldReq remove profile field request
+type RemoveProfileFieldReq struct {
+	// field id
+	ID int `validate:"required,min=1" json:"id"`
+}
+
+// GetProfileFieldResp get profile field response
+type GetProfileFieldResp struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Label      string   `json:"label"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options"`
+	Required   bool     `json:"required"`
+	Visibility string   `json:"visibility"`
+	Sort       int      `json:"sort"`
+}
+
+// GetUserProfileFieldFormResp the profile field and its value of the current user, for the profile edit form
+type GetUserProfileFieldFormResp struct {
+	*GetProfileFieldResp
+	Value string `json:"value"`
+}
+
+// UserProfileFieldResp the visible profile field value of user
+type UserProfileFieldResp struct {
+	Name  string `json:"name"`
+	Label string `json:"label"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
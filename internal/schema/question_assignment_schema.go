@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	QuestionAssignmentStatusPending   = "pending"
+	QuestionAssignmentStatusOverdue   = "overdue"
+	QuestionAssignmentStatusAnswered  = "answered"
+	QuestionAssignmentStatusCancelled = "cancelled"
+)
+
+// AssignQuestionReq assign question request
+type AssignQuestionReq struct {
+	QuestionID string   `validate:"required" json:"question_id"`
+	Usernames  []string `validate:"required,gt=0,lte=10,dive,required" json:"usernames"`
+	// DueHours override the default deadline configured by admin, 0 means using the default one
+	DueHours int    `validate:"omitempty,min=0,max=8760" json:"due_hours"`
+	UserID   string `json:"-"`
+}
+
+// AutoAssignQuestionReq auto assign question request
+type AutoAssignQuestionReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	// Count the number of the experts to be assigned, default is 1
+	Count    int    `validate:"omitempty,min=1,max=5" json:"count"`
+	DueHours int    `validate:"omitempty,min=0,max=8760" json:"due_hours"`
+	UserID   string `json:"-"`
+}
+
+// UnassignQuestionReq unassign question request
+type UnassignQuestionReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	Username   string `validate:"required" json:"username"`
+	UserID     string `json:"-"`
+}
+
+// GetQuestionAssignmentsReq get question assignments request
+type GetQuestionAssignmentsReq struct {
+	QuestionID string `validate:"required" form:"question_id"`
+}
+
+// QuestionAssignmentResp question assignment response
+type QuestionAssignmentResp struct {
+	ID           int            `json:"id"`
+	Assignee     *UserBasicInfo `json:"assignee"`
+	Assigner     *UserBasicInfo `json:"assigner,omitempty"`
+	AutoAssigned bool           `json:"auto_assigned"`
+	// assignment status(pending,overdue,answered,cancelled)
+	Status     string `json:"status"`
+	CreatedAt  int64  `json:"created_at"`
+	DueAt      int64  `json:"due_at"`
+	AnsweredAt int64  `json:"answered_at"`
+}
+
+// GetMyAssignmentPageReq get my assignment page request
+type GetMyAssignmentPageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// assignment status(pending,answered), empty means all
+	Status string `validate:"omitempty,oneof=pending answered" form:"status"`
+	UserID string `json:"-"`
+}
+
+// MyAssignmentResp the question assigned to me
+type MyAssignmentResp struct {
+	QuestionAssignmentResp
+	QuestionID    string `json:"question_id"`
+	QuestionTitle string `json:"question_title"`
+	URLTitle      string `json:"url_title"`
+	AnswerCount   int    `json:"answer_count"`
+}
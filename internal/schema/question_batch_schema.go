@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import "github.com/apache/incubator-answer/internal/base/pager"
+
+const (
+	// MaxBatchGetQuestions the max number of the questions fetched in one batch request
+	MaxBatchGetQuestions = 50
+	// DefaultQuestionDetailAnswerPageSize the default number of the answers in the question detail
+	DefaultQuestionDetailAnswerPageSize = 20
+	// DefaultQuestionDetailCommentPageSize the default number of the comments of each object in the question detail
+	DefaultQuestionDetailCommentPageSize = 5
+)
+
+// BatchGetQuestionsReq batch get questions request
+type BatchGetQuestionsReq struct {
+	// IDs the ids of the questions, the short ids are supported
+	IDs    []string `validate:"required,min=1,max=50,dive,required" json:"ids"`
+	UserID string   `json:"-"`
+}
+
+// BatchGetQuestionsResp batch get questions response
+type BatchGetQuestionsResp struct {
+	// List the questions in the order of the requested ids
+	List []*QuestionInfoResp `json:"list"`
+	// NotFound the requested ids of the questions that do not exist or are invisible to the user
+	NotFound []string `json:"not_found"`
+}
+
+// GetQuestionDetailReq get question detail request
+type GetQuestionDetailReq struct {
+	// ID question id
+	ID string `validate:"required" form:"id"`
+	// AnswerOrder the order of the answers, same as the answer page
+	AnswerOrder string `validate:"omitempty,oneof=default updated created vote" form:"answer_order"`
+	// AnswerPageSize the number of the answers
+	AnswerPageSize int `validate:"omitempty,min=1,max=100" form:"answer_page_size"`
+	// CommentPageSize the number of the comments of the question and each answer
+	CommentPageSize int `validate:"omitempty,min=1,max=50" form:"comment_page_size"`
+	// ShareToken the share token of the unlisted question
+	ShareToken string `validate:"omitempty" form:"share_token"`
+	UserID     string `json:"-"`
+}
+
+// GetQuestionDetailResp question detail with the answers and the comments, the votes and the states of the user
+// are in the question and the answers
+type GetQuestionDetailResp struct {
+	Question    *QuestionInfoResp `json:"question"`
+	Answers     []*AnswerInfo     `json:"answers"`
+	AnswerCount int64             `json:"answer_count"`
+	// Comments the first page of the comments of the question and each answer, keyed by the object id
+	Comments map[string]*pager.PageModel `json:"comments"`
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package schema
+
+// AddQuestionPollReq add question poll request
+type AddQuestionPollReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	// Multiple whether the voter can choose more than one option
+	Multiple bool `json:"multiple"`
+	// result policy(always,after_vote,after_close), default is always
+	ResultPolicy string `validate:"omitempty,oneof=always after_vote after_close" json:"result_policy"`
+	// CloseAt unix timestamp, 0 means never close
+	CloseAt int64    `validate:"omitempty,min=0" json:"close_at"`
+	Options []string `validate:"required,gte=2,lte=10,dive,required,notblank,lte=200" json:"options"`
+	UserID  string   `json:"-"`
+}
+
+// RemoveQuestionPollReq remove question poll request
+type RemoveQuestionPollReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	UserID     string `json:"-"`
+}
+
+// VoteQuestionPollReq vote question poll request
+type VoteQuestionPollReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	OptionIDs  []int  `validate:"required,gt=0,dive,gt=0" json:"option_ids"`
+	UserID     string `json:"-"`
+}
+
+// QuestionPollResp question poll response
+type QuestionPollResp struct {
+	ID           int    `json:"id"`
+	Multiple     bool   `json:"multiple"`
+	ResultPolicy string `json:"result_policy"`
+	CloseAt      int64  `json:"close_at"`
+	Closed       bool   `json:"closed"`
+	// ShowResult whether the vote counts are visible to the current user, they are all 0 if not
+	ShowResult     bool                      `json:"show_result"`
+	VoterCount     int64                     `json:"voter_count"`
+	VotedOptionIDs []int                     `json:"voted_option_ids"`
+	Options        []*QuestionPollOptionResp `json:"options"`
+}
+
+// QuestionPollOptionResp question poll option response
+type QuestionPollOptionResp struct {
+	ID        int    `json:"id"`
+	Content   string `json:"content"`
+	VoteCount int    `json:"vote_count"`
+}
@@ -44,6 +44,8 @@ type RemoveQuestionReq struct {
 	IsAdmin     bool   `json:"-"`
 	CaptchaID   string `json:"captcha_id"` // captcha_id
 	CaptchaCode string `json:"captcha_code"`
+	// the reason of the deletion shown in the recycle bin
+	Reason string `validate:"omitempty,lte=500" json:"reason"`
 }
 
 type CloseQuestionReq struct {
@@ -74,9 +76,9 @@ type ReopenQuestionReq struct {
 
 type QuestionAdd struct {
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,lte=150" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,lte=65535" json:"content"`
 	// html
 	HTML string `json:"-"`
 	// tags
@@ -102,12 +104,12 @@ func (req *QuestionAdd) Check() (errFields []*validator.FormErrorField, err erro
 
 type QuestionAddByAnswer struct {
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,lte=150" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,lte=65535" json:"content"`
 	// html
 	HTML          string `json:"-"`
-	AnswerContent string `validate:"required,notblank,gte=6,lte=65535" json:"answer_content"`
+	AnswerContent string `validate:"required,notblank,lte=65535" json:"answer_content"`
 	AnswerHTML    string `json:"-"`
 	// tags
 	Tags []*TagItem `validate:"required,dive" json:"tags"`
@@ -155,6 +157,10 @@ type QuestionPermission struct {
 	CanInviteOtherToAnswer bool `json:"-"`
 	CanAddTag              bool `json:"-"`
 	CanRecover             bool `json:"-"`
+	// whether user can view the unlisted question by the share link
+	CanViewUnlisted bool `json:"-"`
+	// the permissions to resolve the allowed actions of the question
+	ActionPermissions *ObjectActionPermissions `json:"-"`
 }
 
 type CheckCanQuestionUpdate struct {
@@ -169,9 +175,9 @@ type QuestionUpdate struct {
 	// question id
 	ID string `validate:"required" json:"id"`
 	// question title
-	Title string `validate:"required,notblank,gte=6,lte=150" json:"title"`
+	Title string `validate:"required,notblank,lte=150" json:"title"`
 	// content
-	Content string `validate:"required,notblank,gte=6,lte=65535" json:"content"`
+	Content string `validate:"required,notblank,lte=65535" json:"content"`
 	// html
 	HTML       string   `json:"-"`
 	InviteUser []string `validate:"omitempty"  json:"invite_user"`
@@ -253,10 +259,20 @@ type QuestionInfoResp struct {
 	Collected            bool           `json:"collected"`
 	VoteStatus           string         `json:"vote_status"`
 	IsFollowed           bool           `json:"is_followed"`
+	// Poll the poll attached to the question, it is empty if the question has no poll
+	Poll *QuestionPollResp `json:"poll,omitempty"`
+	// LinkPreviews the preview cards of the external links in the question
+	LinkPreviews []*LinkPreviewResp `json:"link_previews,omitempty"`
+	// Summary the short summary of the long thread, it is empty until the summary is generated
+	Summary *QuestionSummaryResp `json:"summary,omitempty"`
+	// MergedQuestionID the question is a redirect stub which was merged into this question
+	MergedQuestionID string `json:"merged_question_id,omitempty"`
 
 	// MemberActions
 	MemberActions  []*PermissionMemberAction `json:"member_actions"`
 	ExtendsActions []*PermissionMemberAction `json:"extends_actions"`
+	// AllowedActions the actions the current user can do on the question
+	AllowedActions *AllowedActions `json:"allowed_actions,omitempty"`
 }
 
 // UpdateQuestionResp update question resp
@@ -359,6 +375,8 @@ type QuestionPageReq struct {
 	Tag       string `validate:"omitempty,gt=0,lte=100" form:"tag"`
 	Username  string `validate:"omitempty,gt=0,lte=100" form:"username"`
 	InDays    int    `validate:"omitempty,min=1" form:"in_days"`
+	// WorkflowState filter by the key of the workflow state, it is ignored if the workflow is not enabled
+	WorkflowState string `validate:"omitempty,lte=50" form:"workflow_state"`
 
 	LoginUserID      string `json:"-"`
 	UserIDBeSearched string `json:"-"`
@@ -401,6 +419,13 @@ type QuestionPageResp struct {
 	OperatedAt    int64                     `json:"operated_at"`
 	Operator      *QuestionPageRespOperator `json:"operator"`
 	OperationType string                    `json:"operation_type"`
+
+	// SLA the SLA state, only for the question in the tag with the SLA policy
+	SLA *QuestionSLAResp `json:"sla,omitempty"`
+	// WorkflowState the workflow state, only if the question workflow is enabled
+	WorkflowState *QuestionWorkflowState `json:"workflow_state,omitempty"`
+	// WorkflowStateKey the raw workflow state of the question
+	WorkflowStateKey string `json:"-"`
 }
 
 type QuestionPageRespOperator struct {
@@ -0,0 +1,69 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	QuestionVisibilityPublic   = "public"
+	QuestionVisibilityUnlisted = "unlisted"
+	// QuestionShareTokenParam the query param of the share token in the link of the unlisted question
+	QuestionShareTokenParam = "share_token"
+)
+
+// UpdateQuestionVisibilityReq update question visibility request
+type UpdateQuestionVisibilityReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	// Visibility the unlisted question is excluded from all the lists and only can be viewed by the share link
+	Visibility  string `validate:"required,oneof=public unlisted" json:"visibility" enums:"public,unlisted"`
+	UserID      string `json:"-"`
+	IsModerator bool   `json:"-"`
+}
+
+// AddQuestionShareLinkReq add question share link request
+type AddQuestionShareLinkReq struct {
+	QuestionID string `validate:"required" json:"question_id"`
+	// ExpireDays the link expires after these days, 0 means never
+	ExpireDays  int    `validate:"omitempty,min=0,max=365" json:"expire_days"`
+	UserID      string `json:"-"`
+	IsModerator bool   `json:"-"`
+}
+
+// GetQuestionShareLinksReq get question share links request
+type GetQuestionShareLinksReq struct {
+	QuestionID  string `validate:"required" form:"question_id"`
+	UserID      string `json:"-"`
+	IsModerator bool   `json:"-"`
+}
+
+// RemoveQuestionShareLinkReq remove question share link request
+type RemoveQuestionShareLinkReq struct {
+	ID          int    `validate:"required,min=1" json:"id"`
+	UserID      string `json:"-"`
+	IsModerator bool   `json:"-"`
+}
+
+// QuestionShareLinkResp question share link response
+type QuestionShareLinkResp struct {
+	ID        int    `json:"id"`
+	URL       string `json:"url"`
+	Token     string `json:"token"`
+	CreatedAt int64  `json:"created_at"`
+	// ExpiredAt 0 means never expires
+	ExpiredAt int64 `json:"expired_at"`
+}
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	QuestionSLAStatusOnTrack  = "on_track"
+	QuestionSLAStatusBreached = "breached"
+	QuestionSLAStatusResolved = "resolved"
+)
+
+// SaveTagSLAPolicyReq save tag SLA policy request, the policy of the tag will be replaced if exists
+type SaveTagSLAPolicyReq struct {
+	TagSlugName string `validate:"required,gt=0,lte=35" json:"tag_slug_name"`
+	// FirstResponseHours the question should be answered within these hours, 0 means no limit
+	FirstResponseHours int `validate:"omitempty,min=0,max=8760" json:"first_response_hours"`
+	// ResolutionHours the question should have an accepted answer within these hours, 0 means no limit
+	ResolutionHours int `validate:"omitempty,min=0,max=8760" json:"resolution_hours"`
+	// EscalationChannels the channels(inbox,email) to notify the admins and moderators when the SLA is breached
+	EscalationChannels []string `validate:"omitempty,dive,oneof=inbox email" json:"escalation_channels"`
+}
+
+// RemoveTagSLAPolicyReq remove tag SLA policy request
+type RemoveTagSLAPolicyReq struct {
+	ID int `validate:"required" json:"id"`
+}
+
+// TagSLAPolicyResp tag SLA policy response
+type TagSLAPolicyResp struct {
+	ID                 int      `json:"id"`
+	TagSlugName        string   `json:"tag_slug_name"`
+	TagDisplayName     string   `json:"tag_display_name"`
+	FirstResponseHours int      `json:"first_response_hours"`
+	ResolutionHours    int      `json:"resolution_hours"`
+	EscalationChannels []string `json:"escalation_channels"`
+	UpdatedAt          int64    `json:"updated_at"`
+}
+
+// QuestionSLAResp the SLA state of the question
+type QuestionSLAResp struct {
+	// sla status(on_track,breached,resolved)
+	Status                string `json:"status"`
+	FirstResponseDueAt    int64  `json:"first_response_due_at"`
+	ResolutionDueAt       int64  `json:"resolution_due_at"`
+	FirstResponseBreached bool   `json:"first_response_breached"`
+	ResolutionBreached    bool   `json:"resolution_breached"`
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// QuestionWorkflowState the state of the question workflow
+type QuestionWorkflowState struct {
+	Key  string `validate:"required,notblank,lte=50" json:"key"`
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+}
+
+// QuestionWorkflowTransition the allowed move from a state to another state
+type QuestionWorkflowTransition struct {
+	From string `validate:"required,lte=50" json:"from"`
+	To   string `validate:"required,lte=50" json:"to"`
+	// RoleIDs the roles which are allowed to make the transition, empty means the admins and the moderators.
+	// The admins are always allowed.
+	RoleIDs []int `validate:"omitempty,dive,min=1" json:"role_ids"`
+}
+
+// SiteQuestionWorkflowReq site question workflow request, such as new → triaged → answered → verified → archived
+// for the internal knowledge base. The workflow state is independent of the status of the question.
+type SiteQuestionWorkflowReq struct {
+	Enabled bool `json:"enabled"`
+	// InitialState the state of the new questions and the questions asked before the workflow is set
+	InitialState string                        `validate:"omitempty,lte=50" json:"initial_state"`
+	States       []*QuestionWorkflowState      `validate:"omitempty,lte=20,dive" json:"states"`
+	Transitions  []*QuestionWorkflowTransition `validate:"omitempty,lte=100,dive" json:"transitions"`
+}
+
+// GetState get the state by the key, the empty key is the initial state
+func (w *SiteQuestionWorkflowReq) GetState(key string) *QuestionWorkflowState {
+	if len(key) == 0 {
+		key = w.InitialState
+	}
+	for _, state := range w.States {
+		if state.Key == key {
+			return state
+		}
+	}
+	return nil
+}
+
+// GetQuestionWorkflowStateReq get question workflow state request
+type GetQuestionWorkflowStateReq struct {
+	ID     string `validate:"required" form:"id"`
+	UserID string `json:"-"`
+}
+
+// GetQuestionWorkflowStateResp get question workflow state response
+type GetQuestionWorkflowStateResp struct {
+	State *QuestionWorkflowState `json:"state"`
+	// NextStates the states which the login user is allowed to move the question to
+	NextStates []*QuestionWorkflowState          `json:"next_states"`
+	History    []*QuestionWorkflowTransitionResp `json:"history"`
+}
+
+// QuestionWorkflowTransitionResp the transition in the history of the question
+type QuestionWorkflowTransitionResp struct {
+	CreatedAt int64  `json:"created_at"`
+	UserID    string `json:"user_id"`
+	FromState string `json:"from_state"`
+	ToState   string `json:"to_state"`
+}
+
+// UpdateQuestionWorkflowStateReq update question workflow state request
+type UpdateQuestionWorkflowStateReq struct {
+	ID     string `validate:"required" json:"id"`
+	State  string `validate:"required,lte=50" json:"state"`
+	UserID string `json:"-"`
+}
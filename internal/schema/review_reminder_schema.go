@@ -0,0 +1,36 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// AcceptAnswerByReminderReq accept the answer through the one-click link in the review reminder
+type AcceptAnswerByReminderReq struct {
+	// the code of the accept link
+	Code string `validate:"required,lte=64" json:"code"`
+	// the answer which solved the question
+	AnswerID string `validate:"required" json:"answer_id"`
+}
+
+// AcceptAnswerByReminderResp accept the answer through the review reminder response
+type AcceptAnswerByReminderResp struct {
+	QuestionID string `json:"question_id"`
+	AnswerID   string `json:"answer_id"`
+	// QuestionURL the page of the question for the redirection after accepting
+	QuestionURL string `json:"question_url"`
+}
@@ -0,0 +1,37 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// RevisionCompactionReportResp the space reclaimed by the revision compaction
+type RevisionCompactionReportResp struct {
+	// PrunedCount CompactedCount and ReclaimedBytes the totals of all the runs
+	PrunedCount    int                          `json:"pruned_count"`
+	CompactedCount int                          `json:"compacted_count"`
+	ReclaimedBytes int64                        `json:"reclaimed_bytes"`
+	RecentRuns     []*RevisionCompactionRunResp `json:"recent_runs"`
+}
+
+// RevisionCompactionRunResp the result of one compaction run
+type RevisionCompactionRunResp struct {
+	CreatedAt      int64 `json:"created_at"`
+	PrunedCount    int   `json:"pruned_count"`
+	CompactedCount int   `json:"compacted_count"`
+	ReclaimedBytes int64 `json:"reclaimed_bytes"`
+}
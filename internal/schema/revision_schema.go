@@ -110,6 +110,7 @@ type GetReviewingTypeReq struct {
 	CanReviewQuestion bool   `json:"-"`
 	CanReviewAnswer   bool   `json:"-"`
 	CanReviewTag      bool   `json:"-"`
+	CanReviewReport   bool   `json:"-"`
 	IsAdmin           bool   `json:"-"`
 	UserID            string `json:"-"`
 }
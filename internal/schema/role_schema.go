@@ -24,4 +24,45 @@ type GetRoleResp struct {
 	ID          int    `json:"id"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	// built-in roles can not be updated or removed
+	BuiltIn bool `json:"built_in"`
+}
+
+// GetRoleReq get role request
+type GetRoleReq struct {
+	ID int `validate:"required,min=1" form:"id"`
+}
+
+// GetRoleDetailResp get role detail response
+type GetRoleDetailResp struct {
+	GetRoleResp
+	// the power types granted to the role
+	PowerTypes []string `json:"power_types"`
+}
+
+// GetPowerResp get power response
+type GetPowerResp struct {
+	Name        string `json:"name"`
+	PowerType   string `json:"power_type"`
+	Description string `json:"description"`
+}
+
+// AddRoleReq add role request
+type AddRoleReq struct {
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	PowerTypes  []string `validate:"omitempty,dive,required,lte=100" json:"power_types"`
+}
+
+// UpdateRoleReq update role request
+type UpdateRoleReq struct {
+	ID          int      `validate:"required,min=1" json:"id"`
+	Name        string   `validate:"required,notblank,lte=50" json:"name"`
+	Description string   `validate:"omitempty,lte=200" json:"description"`
+	PowerTypes  []string `validate:"omitempty,dive,required,lte=100" json:"power_types"`
+}
+
+// RemoveRoleReq remove role request
+type RemoveRoleReq struct {
+	ID int `validate:"required,min=1" json:"id"`
 }
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SiteSchedulerReq the cron expressions which override the default schedules of the built-in jobs
+type SiteSchedulerReq struct {
+	// JobSchedules the key is the job name, the value is the cron expression
+	JobSchedules map[string]string `json:"job_schedules"`
+}
+
+// GetScheduledJobResp get the built-in scheduled job response
+type GetScheduledJobResp struct {
+	Name            string `json:"name"`
+	Schedule        string `json:"schedule"`
+	DefaultSchedule string `json:"default_schedule"`
+	Enabled         bool   `json:"enabled"`
+	Running         bool   `json:"running"`
+	LastRunAt       int64  `json:"last_run_at"`
+	// LastDuration the duration of the last run in milliseconds
+	LastDuration int64  `json:"last_duration"`
+	LastStatus   string `json:"last_status"`
+	LastError    string `json:"last_error"`
+	NextRunAt    int64  `json:"next_run_at"`
+}
+
+// UpdateScheduledJobStatusReq enable or disable the built-in scheduled job request
+type UpdateScheduledJobStatusReq struct {
+	Name    string `validate:"required,gt=1,lte=100" json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// UpdateScheduledJobScheduleReq override the schedule of the built-in scheduled job request
+type UpdateScheduledJobScheduleReq struct {
+	Name string `validate:"required,gt=1,lte=100" json:"name"`
+	// Schedule the cron expression, empty means the default schedule is used
+	Schedule string `validate:"omitempty,lte=100" json:"schedule"`
+}
+
+// RunScheduledJobReq run the built-in scheduled job now request
+type RunScheduledJobReq struct {
+	Name string `validate:"required,gt=1,lte=100" json:"name"`
+}
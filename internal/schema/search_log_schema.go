@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// SearchClickReq search result click request
+type SearchClickReq struct {
+	// search query
+	Query string `validate:"required,gte=1,lte=100" json:"q"`
+	// clicked question or answer id
+	ObjectID string `validate:"required" json:"object_id"`
+}
+
+// GetSearchReportReq get search report request
+type GetSearchReportReq struct {
+	// time range
+	Range string `validate:"omitempty,oneof=week month quarter year" form:"range"`
+	// the number of queries in each list
+	Size int `validate:"omitempty,min=1,max=100" form:"size"`
+}
+
+// GetSearchReportResp get search report response
+type GetSearchReportResp struct {
+	Range             string                   `json:"range"`
+	StartDate         string                   `json:"start_date"`
+	EndDate           string                   `json:"end_date"`
+	TopQueries        []*SearchReportQueryItem `json:"top_queries"`
+	ZeroResultQueries []*SearchReportQueryItem `json:"zero_result_queries"`
+}
+
+// SearchReportQueryItem search statistics of the query
+type SearchReportQueryItem struct {
+	Query           string `json:"query"`
+	SearchCount     int    `json:"search_count"`
+	ZeroResultCount int    `json:"zero_result_count"`
+	ClickCount      int    `json:"click_count"`
+	// ClickThroughRate is ClickCount / SearchCount
+	ClickThroughRate float64 `json:"click_through_rate"`
+}
@@ -33,6 +33,7 @@ type SearchDTO struct {
 	Page        int    `validate:"omitempty,min=1" form:"page,default=1"`
 	Size        int    `validate:"omitempty,min=1,max=50" form:"size,default=30"`
 	Order       string `validate:"required,oneof=newest active score relevance" form:"order,default=relevance" enums:"newest,active,score,relevance"`
+	Mode        string `validate:"omitempty,oneof=keyword hybrid" form:"mode,default=hybrid" enums:"keyword,hybrid"`
 	CaptchaID   string `form:"captcha_id"`
 	CaptchaCode string `form:"captcha_code"`
 	UserID      string `json:"-"`
@@ -70,7 +71,7 @@ func ReplaceSearchContent(content string) (string, []string) {
 }
 
 type SearchCondition struct {
-	// search target type: all/question/answer
+	// search target type: all/question/answer/article
 	TargetType string
 	// search query user id
 	UserID string
@@ -107,6 +108,17 @@ func (s *SearchCondition) SearchAnswer() bool {
 	return s.TargetType == constant.AnswerObjectType
 }
 
+// HasFilters check if the search has any condition besides the keywords and the target type
+func (s *SearchCondition) HasFilters() bool {
+	return len(s.UserID) > 0 || s.VoteAmount != 0 || s.NotAccepted || s.Views != 0 || s.AnswerAmount != 0 ||
+		s.Accepted || len(s.QuestionID) > 0 || len(s.Tags) > 0
+}
+
+// SearchArticle check if search only need article
+func (s *SearchCondition) SearchArticle() bool {
+	return s.TargetType == constant.ArticleObjectType
+}
+
 // Convert2PluginSearchCond convert to plugin search condition
 func (s *SearchCondition) Convert2PluginSearchCond(page, pageSize int, order string) *plugin.SearchBasicCond {
 	basic := &plugin.SearchBasicCond{
@@ -150,6 +162,8 @@ type SearchObject struct {
 	Tags []*TagResp `json:"tags"`
 	// Status
 	StatusStr string `json:"status"`
+	// Summary the short summary of the question, it is only available for the long thread
+	Summary string `json:"summary,omitempty"`
 }
 
 type SearchObjectUser struct {
@@ -35,6 +35,8 @@ type SimpleObjectInfo struct {
 	CommentID           string `json:"comment_id"`
 	CommentStatus       int    `json:"comment_status"`
 	TagID               string `json:"tag_id"`
+	ArticleID           string `json:"article_id"`
+	ArticleStatus       int    `json:"article_status"`
 	ObjectType          string `json:"object_type"`
 	Title               string `json:"title"`
 	Content             string `json:"content"`
@@ -49,6 +51,8 @@ func (s *SimpleObjectInfo) IsDeleted() bool {
 		return s.AnswerStatus == entity.AnswerStatusDeleted
 	case constant.CommentObjectType:
 		return s.CommentStatus == entity.CommentStatusDeleted
+	case constant.ArticleObjectType:
+		return s.ArticleStatus == entity.ArticleStatusDeleted
 	}
 	return false
 }
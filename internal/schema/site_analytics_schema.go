@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetSiteAnalyticsReq get site analytics request
+type GetSiteAnalyticsReq struct {
+	// time range
+	Range string `validate:"omitempty,oneof=week month quarter year" form:"range"`
+}
+
+// GetSiteAnalyticsResp get site analytics response
+type GetSiteAnalyticsResp struct {
+	Range     string `json:"range"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	// DailyActiveUsers, WeeklyActiveUsers and MonthlyActiveUsers are the values of the latest day
+	DailyActiveUsers   int `json:"daily_active_users"`
+	WeeklyActiveUsers  int `json:"weekly_active_users"`
+	MonthlyActiveUsers int `json:"monthly_active_users"`
+	NewUserCount       int `json:"new_user_count"`
+	QuestionCount      int `json:"question_count"`
+	AnswerCount        int `json:"answer_count"`
+	CommentCount       int `json:"comment_count"`
+	// AcceptanceRate is the rate of questions asked in the range that have an accepted answer
+	AcceptanceRate float64                   `json:"acceptance_rate"`
+	Daily          []*SiteAnalyticsDailyItem `json:"daily"`
+	TopTags        []*SiteAnalyticsTagItem   `json:"top_tags"`
+	// ZeroResultSearchTerms are the search queries without any result
+	ZeroResultSearchTerms []*SearchReportQueryItem `json:"zero_result_search_terms"`
+}
+
+// SiteAnalyticsDailyItem site analytics of one day
+type SiteAnalyticsDailyItem struct {
+	Date               string `json:"date"`
+	DailyActiveUsers   int    `json:"daily_active_users"`
+	WeeklyActiveUsers  int    `json:"weekly_active_users"`
+	MonthlyActiveUsers int    `json:"monthly_active_users"`
+	NewUserCount       int    `json:"new_user_count"`
+	QuestionCount      int    `json:"question_count"`
+	AnswerCount        int    `json:"answer_count"`
+	CommentCount       int    `json:"comment_count"`
+}
+
+// SiteAnalyticsTagItem site analytics tag item
+type SiteAnalyticsTagItem struct {
+	TagID         string `json:"tag_id"`
+	SlugName      string `json:"slug_name"`
+	DisplayName   string `json:"display_name"`
+	QuestionCount int    `json:"question_count"`
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -68,6 +69,23 @@ type SiteBrandingReq struct {
 	MobileLogo string `validate:"omitempty,gt=0,lte=512" form:"mobile_logo" json:"mobile_logo"`
 	SquareIcon string `validate:"omitempty,gt=0,lte=512" form:"square_icon" json:"square_icon"`
 	Favicon    string `validate:"omitempty,gt=0,lte=512" form:"favicon" json:"favicon"`
+	// PrimaryColor and SecondaryColor are the brand colors in hex, e.g. #0033ff
+	PrimaryColor   string `validate:"omitempty,hexcolor" form:"primary_color" json:"primary_color"`
+	SecondaryColor string `validate:"omitempty,hexcolor" form:"secondary_color" json:"secondary_color"`
+	// FooterText and FooterLinks are shown in the site footer
+	FooterText  string            `validate:"omitempty,gt=0,lte=1024" form:"footer_text" json:"footer_text"`
+	FooterLinks []*SiteFooterLink `validate:"omitempty,lte=20,dive" json:"footer_links"`
+}
+
+// SiteFooterLink site footer link
+type SiteFooterLink struct {
+	Label string `validate:"required,gt=0,lte=64" json:"label"`
+	URL   string `validate:"required,url,lte=512" json:"url"`
+}
+
+// UploadSiteBrandingFileReq upload the branding file and use it as the logo or icon
+type UploadSiteBrandingFileReq struct {
+	Type string `validate:"required,oneof=logo mobile_logo square_icon favicon" form:"type"`
 }
 
 // SiteWriteReq site write request
@@ -76,9 +94,99 @@ type SiteWriteReq struct {
 	RequiredTag    bool            `validate:"omitempty" json:"required_tag"`
 	RecommendTags  []*SiteWriteTag `validate:"omitempty,dive" json:"recommend_tags"`
 	ReservedTags   []*SiteWriteTag `validate:"omitempty,dive" json:"reserved_tags"`
-	UserID         string          `json:"-"`
+	// AssignmentSLAHours the assignee is expected to answer the assigned question within these hours, 0 means no deadline
+	AssignmentSLAHours int `validate:"omitempty,min=0,max=8760" json:"assignment_sla_hours"`
+	// ArticleMinAnswerVotes only the answer with at least these votes can be converted into an article
+	ArticleMinAnswerVotes int `validate:"omitempty,min=0" json:"article_min_answer_votes"`
+	// DeleteUndoMinutes the actor can undo the deletion of the question, answer or comment within these minutes,
+	// 0 means the deletion can not be undone
+	DeleteUndoMinutes int `validate:"omitempty,min=0,max=1440" json:"delete_undo_minutes"`
+	// RecycleBinRetentionDays the deleted content is purged permanently after these days, 0 means never
+	RecycleBinRetentionDays int `validate:"omitempty,min=0,max=3650" json:"recycle_bin_retention_days"`
+	// DeadLinkAnnotation mark the dead links found by the dead link checker in the rendered content
+	DeadLinkAnnotation bool `validate:"omitempty" json:"dead_link_annotation"`
+	// AnswerSimilarityCheck the new answer which is nearly the same as an existing answer of the question
+	// is held for the moderators to review
+	AnswerSimilarityCheck bool `validate:"omitempty" json:"answer_similarity_check"`
+	// AnswerSimilarityThreshold the similarity percent from which the answer is regarded as a near-duplicate,
+	// 0 means the default threshold
+	AnswerSimilarityThreshold int `validate:"omitempty,min=0,max=100" json:"answer_similarity_threshold"`
+	// TitleQualityCheck check the quality of the question title before posting
+	TitleQualityCheck bool `validate:"omitempty" json:"title_quality_check"`
+	// TitleMinLength the title shorter than it is warned, 0 means the default length
+	TitleMinLength int `validate:"omitempty,min=0,max=150" json:"title_min_length"`
+	// TitleBannedPhrases the phrases which do not describe the problem, e.g. "please help",
+	// empty means the default phrases
+	TitleBannedPhrases []string `validate:"omitempty,dive,gt=0,lte=50" json:"title_banned_phrases"`
+	// AnswerOutdatedThreshold the answer flagged as outdated by at least these users shows the outdated banner,
+	// 0 means the default threshold
+	AnswerOutdatedThreshold int `validate:"omitempty,min=0,max=1000" json:"answer_outdated_threshold"`
+	// QuestionReviewReminderDays ask the author whether any answer solved the question after these days
+	// since the question received the first answer, 0 means no reminder
+	QuestionReviewReminderDays int `validate:"omitempty,min=0,max=365" json:"question_review_reminder_days"`
+	// MultipleAcceptedAnswers the author can accept more than one answer of the question,
+	// e.g. the answers work on the different platforms
+	MultipleAcceptedAnswers bool `validate:"omitempty" json:"multiple_accepted_answers"`
+	// RevisionKeepCount and RevisionKeepMonths the retention policy of the non-current revisions,
+	// the revision is kept if it is one of the last N or created in the last M months, 0 means no limit
+	RevisionKeepCount  int `validate:"omitempty,min=0,max=1000" json:"revision_keep_count"`
+	RevisionKeepMonths int `validate:"omitempty,min=0,max=600" json:"revision_keep_months"`
+	// RevisionCompaction store the non-current revisions as the delta of the newer revision
+	RevisionCompaction bool `validate:"omitempty" json:"revision_compaction"`
+	// NewContributorPostThreshold the user with fewer questions and answers than it is marked as the new contributor,
+	// 0 means no user is marked
+	NewContributorPostThreshold int `validate:"omitempty,min=0,max=1000" json:"new_contributor_post_threshold"`
+	// NewContributorDays the user is marked as the new contributor within these days since sign-up,
+	// 0 means the default days
+	NewContributorDays int `validate:"omitempty,min=0,max=3650" json:"new_contributor_days"`
+	// NewContributorQuestionLimit and NewContributorAnswerLimit the max number of the questions and answers
+	// the new contributor can post per day, 0 means no limit
+	NewContributorQuestionLimit int `validate:"omitempty,min=0,max=1000" json:"new_contributor_question_limit"`
+	NewContributorAnswerLimit   int `validate:"omitempty,min=0,max=1000" json:"new_contributor_answer_limit"`
+	// NewContributorGuidance the guidance in markdown shown to the new contributor before posting
+	NewContributorGuidance string `validate:"omitempty,lte=5000" json:"new_contributor_guidance"`
+	// QuestionQualityWeights the weights of the factors of the quality score computed when the question is saved,
+	// nil or all zero means the default weights
+	QuestionQualityWeights *QuestionQualityWeights `validate:"omitempty" json:"question_quality_weights"`
+	// QuestionQualityBlend the percent of the quality score blended into the sorting of the hot questions,
+	// 0 means the quality score is not used for sorting
+	QuestionQualityBlend int    `validate:"omitempty,min=0,max=100" json:"question_quality_blend"`
+	UserID               string `json:"-"`
+}
+
+// DefaultDeleteUndoMinutes the default undo window of the deletion
+const DefaultDeleteUndoMinutes = 5
+
+// QuestionQualityWeights the weights of the factors of the question quality score
+type QuestionQualityWeights struct {
+	// Title the title is long enough to describe the problem
+	Title int `validate:"omitempty,min=0,max=100" json:"title"`
+	// Body the body is detailed and structured in paragraphs, lists or headings
+	Body int `validate:"omitempty,min=0,max=100" json:"body"`
+	// Code the code in the body is formatted as code
+	Code int `validate:"omitempty,min=0,max=100" json:"code"`
+	// Tags the question has enough tags
+	Tags int `validate:"omitempty,min=0,max=100" json:"tags"`
 }
 
+// DefaultQuestionQualityWeights the default weights of the factors of the question quality score
+var DefaultQuestionQualityWeights = &QuestionQualityWeights{Title: 25, Body: 35, Code: 15, Tags: 25}
+
+// DefaultNewContributorDays the default days the user is marked as the new contributor since sign-up
+const DefaultNewContributorDays = 30
+
+// DefaultAnswerSimilarityThreshold the default similarity percent of the near-duplicate answer
+const DefaultAnswerSimilarityThreshold = 80
+
+// DefaultTitleMinLength the default min length of the question title
+const DefaultTitleMinLength = 15
+
+// DefaultTitleBannedPhrases the default phrases which should not be in the question title
+var DefaultTitleBannedPhrases = []string{"please help", "help me", "need help", "urgent", "asap"}
+
+// DefaultAnswerOutdatedThreshold the default number of the outdated flags which marks the answer as outdated
+const DefaultAnswerOutdatedThreshold = 3
+
 // SiteWriteTag site write response tag
 type SiteWriteTag struct {
 	SlugName    string `validate:"required" json:"slug_name"`
@@ -91,8 +199,29 @@ type SiteLegalReq struct {
 	TermsOfServiceParsedText   string `json:"terms_of_service_parsed_text"`
 	PrivacyPolicyOriginalText  string `json:"privacy_policy_original_text"`
 	PrivacyPolicyParsedText    string `json:"privacy_policy_parsed_text"`
+	// IPRetentionDays the ip addresses are deleted or anonymized after these days, 0 means they are kept forever
+	IPRetentionDays   int    `validate:"omitempty,min=0,max=3650" json:"ip_retention_days"`
+	IPRetentionAction string `validate:"omitempty,oneof=delete anonymize" json:"ip_retention_action"`
+	// NotificationRetentionDays the notifications are deleted after these days, 0 means they are kept forever
+	NotificationRetentionDays int `validate:"omitempty,min=0,max=3650" json:"notification_retention_days"`
+	// ReadNotificationRetentionDays the read notifications are deleted after these days, 0 means they are kept forever
+	ReadNotificationRetentionDays int `validate:"omitempty,min=0,max=3650" json:"read_notification_retention_days"`
+	// LoginLogRetentionDays the login sessions which are inactive for these days are deleted or anonymized,
+	// and the failed sign-in attempts are deleted, 0 means they are kept forever
+	LoginLogRetentionDays   int    `validate:"omitempty,min=0,max=3650" json:"login_log_retention_days"`
+	LoginLogRetentionAction string `validate:"omitempty,oneof=delete anonymize" json:"login_log_retention_action"`
 }
 
+// DefaultReadNotificationRetentionDays the default retention days of the read notifications
+const DefaultReadNotificationRetentionDays = 90
+
+const (
+	// DataRetentionActionDelete the expired data is deleted, it is the default action
+	DataRetentionActionDelete = "delete"
+	// DataRetentionActionAnonymize the expired data is kept without the personal information
+	DataRetentionActionAnonymize = "anonymize"
+)
+
 // GetSiteLegalInfoReq site site legal request
 type GetSiteLegalInfoReq struct {
 	InfoType string `validate:"required,oneof=tos privacy" form:"info_type"`
@@ -133,6 +262,32 @@ type SiteLoginReq struct {
 	AllowPasswordLogin      bool     `json:"allow_password_login"`
 	LoginRequired           bool     `json:"login_required"`
 	AllowEmailDomains       []string `json:"allow_email_domains"`
+	// PasswordMinLength the minimum length of password, the default 8 is used if it is 0
+	PasswordMinLength        int  `validate:"omitempty,min=8,max=32" json:"password_min_length"`
+	PasswordRequireUppercase bool `json:"password_require_uppercase"`
+	PasswordRequireLowercase bool `json:"password_require_lowercase"`
+	PasswordRequireNumber    bool `json:"password_require_number"`
+	PasswordRequireSymbol    bool `json:"password_require_symbol"`
+	// PasswordRotationDays user must change the password after these days, 0 means never expire
+	PasswordRotationDays int `validate:"omitempty,min=0,max=3650" json:"password_rotation_days"`
+	// PasswordBreachCheck reject the passwords found in the known data breaches
+	PasswordBreachCheck bool `json:"password_breach_check"`
+	// EmailVerificationReminderDays remind the unverified users to verify their email every these days, 0 means never remind
+	EmailVerificationReminderDays int `validate:"omitempty,min=0,max=365" json:"email_verification_reminder_days"`
+	// InviteOnly new users must register with a valid invitation
+	InviteOnly bool `json:"invite_only"`
+	// InvitationMinRank the users whose reputation reaches it can invite others, 0 means only admin can invite
+	InvitationMinRank int `validate:"omitempty,min=0" json:"invitation_min_rank"`
+	// BlockedEmailDomains the email from these domains and their subdomains can not be used
+	BlockedEmailDomains []string `json:"blocked_email_domains"`
+	// BlockDisposableEmail reject the email from the known disposable email providers
+	BlockDisposableEmail bool `json:"block_disposable_email"`
+	// LoginLockoutThreshold the account is locked temporarily after these failed sign-in attempts,
+	// 0 means the default threshold
+	LoginLockoutThreshold int `validate:"omitempty,min=0,max=100" json:"login_lockout_threshold"`
+	// LoginLockoutMinutes the first lockout lasts these minutes and it is doubled by each further failed attempt,
+	// 0 means the default minutes
+	LoginLockoutMinutes int `validate:"omitempty,min=0,max=1440" json:"login_lockout_minutes"`
 }
 
 // SiteCustomCssHTMLReq site custom css html
@@ -142,6 +297,10 @@ type SiteCustomCssHTMLReq struct {
 	CustomHeader  string `validate:"omitempty,gt=0,lte=65536" json:"custom_header"`
 	CustomFooter  string `validate:"omitempty,gt=0,lte=65536" json:"custom_footer"`
 	CustomSideBar string `validate:"omitempty,gt=0,lte=65536" json:"custom_sidebar"`
+	// CustomJs the javascript code run in every page, without the script tag
+	CustomJs string `validate:"omitempty,gt=0,lte=65536" json:"custom_js"`
+	// EnableCSP send the Content-Security-Policy header, only the scripts with nonce are allowed to run
+	EnableCSP bool `json:"enable_csp"`
 }
 
 // SiteThemeReq site theme config
@@ -154,6 +313,16 @@ type SiteThemeReq struct {
 type SiteSeoReq struct {
 	Permalink int    `validate:"required,lte=4,gte=0" form:"permalink" json:"permalink"`
 	Robots    string `validate:"required" form:"robots" json:"robots"`
+	// StructuredData output the schema.org QAPage JSON-LD in question page
+	StructuredData bool `validate:"omitempty" form:"structured_data" json:"structured_data"`
+	// SocialMeta output the Open Graph and Twitter Card meta tags
+	SocialMeta bool `validate:"omitempty" form:"social_meta" json:"social_meta"`
+	// TwitterSite the twitter username of the site, such as @answer
+	TwitterSite string `validate:"omitempty,gt=0,lte=32" form:"twitter_site" json:"twitter_site"`
+	// ShareImage the default image shown in social sharing previews
+	ShareImage string `validate:"omitempty,gt=0,lte=512" form:"share_image" json:"share_image"`
+	// Embed allow other sites to embed the questions by oEmbed
+	Embed bool `validate:"omitempty" form:"embed" json:"embed"`
 }
 
 func (s *SiteSeoResp) IsShortLink() bool {
@@ -173,6 +342,21 @@ type SiteBrandingResp SiteBrandingReq
 // SiteLoginResp site login response
 type SiteLoginResp SiteLoginReq
 
+// GetPasswordPolicy get the password policy configured by admin
+func (s *SiteLoginResp) GetPasswordPolicy() checker.PasswordPolicy {
+	policy := checker.PasswordPolicy{
+		MinLength:        s.PasswordMinLength,
+		RequireUppercase: s.PasswordRequireUppercase,
+		RequireLowercase: s.PasswordRequireLowercase,
+		RequireNumber:    s.PasswordRequireNumber,
+		RequireSymbol:    s.PasswordRequireSymbol,
+	}
+	if policy.MinLength == 0 {
+		policy.MinLength = 8
+	}
+	return policy
+}
+
 // SiteCustomCssHTMLResp site custom css html response
 type SiteCustomCssHTMLResp SiteCustomCssHTMLReq
 
@@ -224,8 +408,14 @@ type SiteInfoResp struct {
 	SiteSeo       *SiteSeoResp           `json:"site_seo"`
 	SiteUsers     *SiteUsersResp         `json:"site_users"`
 	Write         *SiteWriteResp         `json:"site_write"`
-	Version       string                 `json:"version"`
-	Revision      string                 `json:"revision"`
+	// Maintenance the ui shows the message and hides the editors when the site is read-only
+	Maintenance *SiteMaintenanceResp `json:"maintenance"`
+	// Experiments the running experiments, the variants assigned to the login user are in the user info
+	Experiments []*SiteExperimentInfo `json:"experiments"`
+	// FeatureFlags the features are checked with the role of the login user
+	FeatureFlags []*FeatureFlag `json:"feature_flags"`
+	Version      string         `json:"version"`
+	Revision     string         `json:"revision"`
 }
 type TemplateSiteInfoResp struct {
 	General       *SiteGeneralResp       `json:"general"`
@@ -239,6 +429,16 @@ type TemplateSiteInfoResp struct {
 	JsonLD        string
 	Keywords      string
 	Description   string
+	// OGType the Open Graph type of the page, default is website
+	OGType string
+	// PublishedTime and ModifiedTime are the article times in RFC3339 format
+	PublishedTime string
+	ModifiedTime  string
+	ArticleTags   []string
+	// OEmbedURL the oEmbed discovery url of the page
+	OEmbedURL string
+	// NavPages the custom pages shown in the site navigation
+	NavPages []*GetPageNavResp
 }
 
 // UpdateSMTPConfigReq get smtp config request
@@ -251,6 +451,18 @@ type UpdateSMTPConfigReq struct {
 	SMTPUsername       string `validate:"omitempty,gt=0,lte=256" json:"smtp_username"`
 	SMTPPassword       string `validate:"omitempty,gt=0,lte=256" json:"smtp_password"`
 	SMTPAuthentication bool   `validate:"omitempty" json:"smtp_authentication"`
+	// Provider smtp sendgrid mailgun ses, empty means smtp
+	Provider           string `validate:"omitempty,oneof=smtp sendgrid mailgun ses" json:"provider"`
+	APIKey             string `validate:"omitempty,gt=0,lte=256" json:"api_key"`
+	MailgunDomain      string `validate:"omitempty,gt=0,lte=256" json:"mailgun_domain"`
+	MailgunRegion      string `validate:"omitempty,oneof=us eu" json:"mailgun_region"`
+	SESRegion          string `validate:"omitempty,gt=0,lte=64" json:"ses_region"`
+	SESAccessKeyID     string `validate:"omitempty,gt=0,lte=256" json:"ses_access_key_id"`
+	SESSecretAccessKey string `validate:"omitempty,gt=0,lte=256" json:"ses_secret_access_key"`
+	// ReplyByEmail the replies to the notification emails are posted as the answers or the comments
+	ReplyByEmail bool `json:"reply_by_email"`
+	// ReplyDomain the domain of the reply addresses, which is routed to the inbound webhook by the provider
+	ReplyDomain        string `validate:"omitempty,hostname_rfc1123,lte=256" json:"reply_domain"`
 	TestEmailRecipient string `validate:"omitempty,email" json:"test_email_recipient"`
 }
 
@@ -275,6 +487,19 @@ type GetSMTPConfigResp struct {
 	SMTPUsername       string `json:"smtp_username"`
 	SMTPPassword       string `json:"smtp_password"`
 	SMTPAuthentication bool   `json:"smtp_authentication"`
+	Provider           string `json:"provider"`
+	APIKey             string `json:"api_key"`
+	MailgunDomain      string `json:"mailgun_domain"`
+	MailgunRegion      string `json:"mailgun_region"`
+	SESRegion          string `json:"ses_region"`
+	SESAccessKeyID     string `json:"ses_access_key_id"`
+	SESSecretAccessKey string `json:"ses_secret_access_key"`
+	// WebhookURL the url of the delivery webhook which is set in the provider, it is empty for the smtp
+	WebhookURL   string `json:"webhook_url"`
+	ReplyByEmail bool   `json:"reply_by_email"`
+	ReplyDomain  string `json:"reply_domain"`
+	// InboundWebhookURL the url which the provider forwards the emails sent to the reply domain to
+	InboundWebhookURL string `json:"inbound_webhook_url"`
 }
 
 // GetManifestJsonResp get manifest json response
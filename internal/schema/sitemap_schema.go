@@ -19,17 +19,35 @@
 
 package schema
 
-type SiteMapList struct {
-	QuestionIDs []*SiteMapQuestionInfo `json:"question_ids"`
-	MaxPageNum  []int                  `json:"max_page_num"`
+import "time"
+
+const (
+	SitemapTypeQuestion = "question"
+	SitemapTypeTag      = "tag"
+	SitemapTypeUser     = "user"
+	SitemapTypePage     = "page"
+)
+
+// SitemapTypes all sitemap types in the order of the sitemap index
+var SitemapTypes = []string{SitemapTypeQuestion, SitemapTypeTag, SitemapTypeUser, SitemapTypePage}
+
+// SitemapPage the cached sitemap page
+type SitemapPage struct {
+	Items   []*SitemapItem `json:"items"`
+	LastMod time.Time      `json:"last_mod"`
 }
 
-type SiteMapPageList struct {
-	PageData []*SiteMapQuestionInfo `json:"page_data"`
+// SitemapItem the object in the sitemap page. The url is not cached,
+// it is built when rendering, so the change of permalink setting takes effect immediately.
+type SitemapItem struct {
+	// ID is question id, tag slug name, username or page slug name
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	UpdateTime time.Time `json:"update_time"`
 }
 
-type SiteMapQuestionInfo struct {
-	ID         string `json:"id"`
-	Title      string `json:"title"`
-	UpdateTime string `json:"time"`
+// SitemapURL the url of the sitemap page or the sitemap index
+type SitemapURL struct {
+	Loc     string
+	LastMod string
 }
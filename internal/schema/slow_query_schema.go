@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetSlowQueryPageReq get the slow query report request
+type GetSlowQueryPageReq struct {
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// OrderBy total max count latest, the total time by default
+	OrderBy string `validate:"omitempty,oneof=total max count latest" form:"order_by"`
+}
+
+// GetSlowQueryResp the slow queries of the same statement, the times are in milliseconds
+type GetSlowQueryResp struct {
+	Fingerprint string `json:"fingerprint"`
+	Statement   string `json:"statement"`
+	Sample      string `json:"sample"`
+	Plan        string `json:"plan"`
+	Count       int64  `json:"count"`
+	TotalTime   int64  `json:"total_time"`
+	AvgTime     int64  `json:"avg_time"`
+	MaxTime     int64  `json:"max_time"`
+	LastSeenAt  int64  `json:"last_seen_at"`
+}
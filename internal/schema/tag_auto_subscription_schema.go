@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// TagSubscriptionDeliveryImmediate the members are notified through the inbox and the email at once
+	TagSubscriptionDeliveryImmediate = "immediate"
+	// TagSubscriptionDeliveryDigest the members receive the new questions in the daily digest email
+	TagSubscriptionDeliveryDigest = "digest"
+)
+
+// TagAutoSubscriptionGroup the group of the users who are automatically subscribed to the new questions
+// in the tags, the members are the users of the usernames, the roles and the experts of the tags
+type TagAutoSubscriptionGroup struct {
+	Name string `validate:"required,notblank,lte=100" json:"name"`
+	// Tags the slug names of the tags, the group is subscribed to the questions with any of them
+	Tags      []string `validate:"required,min=1,lte=20,dive,required,lte=35" json:"tags"`
+	Usernames []string `validate:"omitempty,lte=200,dive,required,lte=100" json:"usernames"`
+	RoleIDs   []int    `validate:"omitempty,dive,min=1" json:"role_ids"`
+	// IncludeTagExperts the experts of the tags of the question are the members
+	IncludeTagExperts bool   `json:"include_tag_experts"`
+	Delivery          string `validate:"required,oneof=immediate digest" json:"delivery"`
+}
+
+// SiteTagAutoSubscriptionsReq site tag auto subscriptions request
+type SiteTagAutoSubscriptionsReq struct {
+	Groups []*TagAutoSubscriptionGroup `validate:"omitempty,lte=100,dive" json:"groups"`
+}
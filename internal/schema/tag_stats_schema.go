@@ -0,0 +1,95 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	StatsRangeWeek    = "week"
+	StatsRangeMonth   = "month"
+	StatsRangeQuarter = "quarter"
+	StatsRangeYear    = "year"
+)
+
+// StatsRangeDays the number of days covered by each time range
+var StatsRangeDays = map[string]int{
+	StatsRangeWeek:    7,
+	StatsRangeMonth:   30,
+	StatsRangeQuarter: 90,
+	StatsRangeYear:    365,
+}
+
+// GetTagStatsReq get tag stats request
+type GetTagStatsReq struct {
+	// tag slug name
+	SlugName string `validate:"required,gt=0,lte=35" form:"-"`
+	// time range
+	Range string `validate:"omitempty,oneof=week month quarter year" form:"range"`
+}
+
+// GetTagStatsResp get tag stats response
+type GetTagStatsResp struct {
+	TagID       string `json:"tag_id"`
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	Range       string `json:"range"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+	// QuestionCount is the number of questions asked in the range
+	QuestionCount int `json:"question_count"`
+	// AnsweredCount is the number of questions that have at least one answer
+	AnsweredCount int `json:"answered_count"`
+	// AnswerRate is AnsweredCount / QuestionCount
+	AnswerRate float64 `json:"answer_rate"`
+	// MedianFirstAnswerSeconds is the median time from asking to the first answer
+	MedianFirstAnswerSeconds int64                  `json:"median_first_answer_seconds"`
+	QuestionsPerDay          []*TagStatsPoint       `json:"questions_per_day"`
+	QuestionsPerWeek         []*TagStatsPoint       `json:"questions_per_week"`
+	TopContributors          []*TagStatsContributor `json:"top_contributors"`
+}
+
+// TagStatsPoint question count of one day or week, date is the first day of the period
+type TagStatsPoint struct {
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+// TagStatsContributor tag stats contributor
+type TagStatsContributor struct {
+	UserInfo      *UserBasicInfo `json:"user_info"`
+	AnswerCount   int            `json:"answer_count"`
+	AcceptedCount int            `json:"accepted_count"`
+	VoteCount     int            `json:"vote_count"`
+}
+
+// GetTagTopAnswerersReq get the top answerers of the tag by expertise score request
+type GetTagTopAnswerersReq struct {
+	// tag slug name
+	SlugName string `validate:"required,gt=0,lte=35" form:"-"`
+	// the number of answerers, default is 10
+	Limit int `validate:"omitempty,min=1,max=50" form:"limit"`
+}
+
+// TagTopAnswerer the answerer with the expertise score in the tag
+type TagTopAnswerer struct {
+	UserInfo      *UserBasicInfo `json:"user_info"`
+	Score         float64        `json:"score"`
+	AnswerCount   int            `json:"answer_count"`
+	AcceptedCount int            `json:"accepted_count"`
+	VoteCount     int            `json:"vote_count"`
+}
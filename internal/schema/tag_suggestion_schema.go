@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetTagSuggestionsReq get tag suggestions request
+type GetTagSuggestionsReq struct {
+	// the title of the draft question
+	Title string `validate:"required,notblank,lte=150" json:"title"`
+	// the html content of the draft question
+	Content string `validate:"omitempty,lte=65535" json:"content"`
+	// the max number of the suggestions, default is 5
+	Limit  int    `validate:"omitempty,min=1,max=10" json:"limit"`
+	UserID string `json:"-"`
+}
+
+// TagSuggestionResp tag suggestion response
+type TagSuggestionResp struct {
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	Recommend   bool   `json:"recommend"`
+	Reserved    bool   `json:"reserved"`
+	// Score the confidence of the suggestion, from 0 to 1
+	Score float64 `json:"score"`
+}
@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	TitleQualityCodeTooShort             = "too_short"
+	TitleQualityCodeAllCaps              = "all_caps"
+	TitleQualityCodeBannedPhrase         = "banned_phrase"
+	TitleQualityCodeExcessivePunctuation = "excessive_punctuation"
+	TitleQualityCodeDuplicate            = "duplicate"
+)
+
+// CheckQuestionTitleReq check question title request
+type CheckQuestionTitleReq struct {
+	Title string `validate:"required,notblank,lte=150" json:"title"`
+	// the question being edited, it is not regarded as the duplicate of itself
+	QuestionID string `validate:"omitempty" json:"question_id"`
+}
+
+// TitleQualityWarning title quality warning
+type TitleQualityWarning struct {
+	// Code too_short, all_caps, banned_phrase, excessive_punctuation or duplicate
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Question the existing question with the same title, only for the duplicate warning
+	Question *QuestionBaseInfo `json:"question,omitempty"`
+}
+
+// CheckQuestionTitleResp check question title response
+type CheckQuestionTitleResp struct {
+	Warnings []*TitleQualityWarning `json:"warnings"`
+	// SuggestedTitle the rewritten title without the problems which can be fixed automatically,
+	// it is empty if the title can not be improved
+	SuggestedTitle string `json:"suggested_title"`
+}
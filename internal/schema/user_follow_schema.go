@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// FollowUserReq follow user or cancel follow request
+type FollowUserReq struct {
+	// the username of the user to follow
+	Username string `validate:"required,gt=0,lte=100" json:"username"`
+	// is cancel
+	IsCancel bool   `validate:"omitempty" json:"is_cancel"`
+	UserID   string `json:"-"`
+}
+
+// FollowUserResp follow user response
+type FollowUserResp struct {
+	// the number of users following this user
+	FollowerCount int64 `json:"follower_count"`
+	// whether the current user is following this user
+	IsFollowed bool `json:"is_followed"`
+}
+
+// GetUserFollowPageReq get the followers or the following users of user request
+type GetUserFollowPageReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	Username string `validate:"required,gt=0,lte=100" form:"username"`
+}
+
+// GetFollowingFeedReq get the feed of the following users request
+type GetFollowingFeedReq struct {
+	Page     int    `validate:"omitempty,min=1" form:"page"`
+	PageSize int    `validate:"omitempty,min=1,max=100" form:"page_size"`
+	UserID   string `json:"-"`
+}
+
+// FollowingFeedItem the question or answer posted by the following user
+type FollowingFeedItem struct {
+	// question or answer
+	ObjectType string `json:"object_type"`
+	ObjectID   string `json:"object_id"`
+	QuestionID string `json:"question_id"`
+	Title      string `json:"title"`
+	UrlTitle   string `json:"url_title"`
+	Excerpt    string `json:"excerpt"`
+	CreatedAt  int64  `json:"created_at"`
+	// the user who posted it
+	UserInfo *UserBasicInfo `json:"user_info"`
+}
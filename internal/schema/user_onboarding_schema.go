@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// OnboardingStepVerifyEmail the email of the user is verified
+	OnboardingStepVerifyEmail = "verify_email"
+	// OnboardingStepSetAvatar the user sets the gravatar or uploads an avatar
+	OnboardingStepSetAvatar = "set_avatar"
+	// OnboardingStepFollowTags the user follows some tags
+	OnboardingStepFollowTags = "follow_tags"
+	// OnboardingStepFirstPost the user asks the first question or posts the first answer
+	OnboardingStepFirstPost = "first_post"
+	// OnboardingStepTakeTour the user takes the tour of the site
+	OnboardingStepTakeTour = "take_tour"
+)
+
+// OnboardingFollowTagAmount the number of tags the user should follow to complete the step
+const OnboardingFollowTagAmount = 3
+
+// GetUserOnboardingReq get the onboarding progress of the login user request
+type GetUserOnboardingReq struct {
+	UserID string `json:"-"`
+}
+
+// TakeOnboardingTourReq mark the tour of the login user as taken request
+type TakeOnboardingTourReq struct {
+	UserID string `json:"-"`
+}
+
+// UserOnboardingStep the step of the onboarding checklist
+type UserOnboardingStep struct {
+	Key         string `json:"key"`
+	Completed   bool   `json:"completed"`
+	CompletedAt int64  `json:"completed_at"`
+}
+
+// UserOnboardingResp the onboarding progress of the user
+type UserOnboardingResp struct {
+	// the steps in the order they are shown
+	Steps []*UserOnboardingStep `json:"steps"`
+	// the number of the completed steps
+	CompletedAmount int `json:"completed_amount"`
+	// all the steps are completed, and the user is awarded the badge and the reputation bonus
+	Completed   bool  `json:"completed"`
+	CompletedAt int64 `json:"completed_at"`
+	// the reputation granted when all the steps are completed
+	RankBonus int `json:"rank_bonus"`
+}
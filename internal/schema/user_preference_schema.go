@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// GetUserPreferencesResp get user preferences response
+type GetUserPreferencesResp struct {
+	// language
+	Language string `json:"language"`
+	// time zone, empty means the time zone of the site
+	TimeZone string `json:"time_zone"`
+	// date format
+	DateFormat string `json:"date_format"`
+	// the date formats the user can choose
+	DateFormatOptions []string `json:"date_format_options"`
+}
+
+// UpdateUserPreferencesReq update user preferences request
+type UpdateUserPreferencesReq struct {
+	// language
+	Language string `validate:"required,gt=1,lte=100" json:"language"`
+	// time zone, empty means the time zone of the site
+	TimeZone string `validate:"omitempty,lte=64" json:"time_zone"`
+	// date format
+	DateFormat string `validate:"omitempty,lte=20" json:"date_format"`
+	// user id
+	UserID string `json:"-"`
+}
+
+func (req *UpdateUserPreferencesReq) Check() (errFields []*validator.FormErrorField, err error) {
+	if !translator.CheckLanguageIsValid(req.Language) {
+		return nil, errors.BadRequest(reason.LangNotFound)
+	}
+	if len(req.TimeZone) > 0 {
+		if _, err := time.LoadLocation(req.TimeZone); err != nil {
+			return append(errFields, &validator.FormErrorField{
+				ErrorField: "time_zone",
+				ErrorMsg:   reason.TimeZoneInvalid,
+			}), errors.BadRequest(reason.TimeZoneInvalid)
+		}
+	}
+	if len(req.DateFormat) == 0 {
+		req.DateFormat = constant.DefaultDateFormat
+	}
+	if _, ok := constant.DateFormats[req.DateFormat]; !ok {
+		return append(errFields, &validator.FormErrorField{
+			ErrorField: "date_format",
+			ErrorMsg:   reason.DateFormatInvalid,
+		}), errors.BadRequest(reason.DateFormatInvalid)
+	}
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUserPreferencesReqCheck(t *testing.T) {
+	req := &UpdateUserPreferencesReq{Language: translator.DefaultLangOption, TimeZone: "Asia/Shanghai"}
+	errFields, err := req.Check()
+	assert.NoError(t, err)
+	assert.Empty(t, errFields)
+	assert.Equal(t, constant.DefaultDateFormat, req.DateFormat)
+
+	req = &UpdateUserPreferencesReq{Language: translator.DefaultLangOption, TimeZone: "Mars/Olympus"}
+	errFields, err = req.Check()
+	assert.Error(t, err)
+	assert.Equal(t, "time_zone", errFields[0].ErrorField)
+
+	req = &UpdateUserPreferencesReq{Language: translator.DefaultLangOption, DateFormat: "YY/M/D"}
+	errFields, err = req.Check()
+	assert.Error(t, err)
+	assert.Equal(t, "date_format", errFields[0].ErrorField)
+}
@@ -86,6 +86,10 @@ type UserLoginResp struct {
 	Language string `json:"language"`
 	// Color scheme
 	ColorScheme string `json:"color_scheme"`
+	// time zone, empty means the time zone of the site
+	TimeZone string `json:"time_zone"`
+	// date format
+	DateFormat string `json:"date_format"`
 	// access token
 	AccessToken string `json:"access_token"`
 	// role id
@@ -96,6 +100,10 @@ type UserLoginResp struct {
 	HavePassword bool `json:"have_password"`
 	// visit token
 	VisitToken string `json:"visit_token"`
+	// password expired, user must change the password
+	PasswordExpired bool `json:"password_expired"`
+	// the user is not shown in the leaderboards
+	LeaderboardOptOut bool `json:"leaderboard_opt_out"`
 }
 
 func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -109,6 +117,8 @@ func (r *UserLoginResp) ConvertFromUserEntity(userInfo *entity.User) {
 type GetCurrentLoginUserInfoResp struct {
 	*UserLoginResp
 	Avatar *AvatarInfo `json:"avatar"`
+	// Experiments the variants assigned to the user, key is the experiment key
+	Experiments map[string]string `json:"experiments"`
 }
 
 func (r *GetCurrentLoginUserInfoResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -156,6 +166,18 @@ type GetOtherUserInfoByUsernameResp struct {
 	Location  string `json:"location"`
 	Status    string `json:"status"`
 	StatusMsg string `json:"status_msg,omitempty"`
+	// extra profile fields visible to the current user
+	ProfileFields []*UserProfileFieldResp `json:"profile_fields"`
+	// the number of users following this user
+	FollowerCount int64 `json:"follower_count"`
+	// the number of users this user is following
+	FollowingCount int64 `json:"following_count"`
+	// whether the current user is following this user
+	IsFollowed bool `json:"is_followed"`
+	// whether the user has completed the onboarding and been awarded the badge
+	OnboardingBadge bool `json:"onboarding_badge"`
+	// the usernames and the display names the user used recently, "formerly known as"
+	FormerNames []string `json:"former_names"`
 }
 
 func (r *GetOtherUserInfoByUsernameResp) ConvertFromUserEntity(userInfo *entity.User) {
@@ -203,7 +225,9 @@ type UserRegisterReq struct {
 	Pass        string `validate:"required,gte=8,lte=32" json:"pass"`
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
-	IP          string `json:"-" `
+	// InvitationCode required if the site is invite-only
+	InvitationCode string `validate:"omitempty,lte=64" json:"invitation_code"`
+	IP             string `json:"-" `
 }
 
 func (u *UserRegisterReq) Check() (errFields []*validator.FormErrorField, err error) {
@@ -239,14 +263,17 @@ func (u *UserModifyPasswordReq) Check() (errFields []*validator.FormErrorField,
 
 type UpdateInfoRequest struct {
 	DisplayName string     `validate:"omitempty,gt=0,lte=30" json:"display_name"`
-	Username    string     `validate:"omitempty,gt=3,lte=30" json:"username"`
+	Username    string     `validate:"omitempty,gte=2,lte=30" json:"username"`
 	Avatar      AvatarInfo `json:"avatar"`
 	Bio         string     `validate:"omitempty,gt=0,lte=4096" json:"bio"`
 	BioHTML     string     `json:"-"`
 	Website     string     `validate:"omitempty,gt=0,lte=500" json:"website"`
 	Location    string     `validate:"omitempty,gt=0,lte=100" json:"location"`
-	UserID      string     `json:"-"`
-	IsAdmin     bool       `json:"-"`
+	// ProfileFields the values of the extra profile fields, key is the field name.
+	// The values are not changed if it is nil.
+	ProfileFields map[string]string `json:"profile_fields"`
+	UserID        string            `json:"-"`
+	IsAdmin       bool              `json:"-"`
 }
 
 type AvatarInfo struct {
@@ -357,6 +384,8 @@ type UserBasicInfo struct {
 	Location    string `json:"location"`
 	Language    string `json:"language"`
 	Status      string `json:"status"`
+	// NewContributor the user has few posts and signed up recently
+	NewContributor bool `json:"new_contributor"`
 }
 
 type GetOtherUserInfoByUsernameReq struct {
@@ -381,6 +410,12 @@ type UserChangeEmailVerifyReq struct {
 	Content string `json:"-"`
 }
 
+// UserChangeEmailAuthorizeReq the old email authorize the email change request
+type UserChangeEmailAuthorizeReq struct {
+	Code    string `validate:"required,gt=0,lte=500" json:"code"`
+	Content string `json:"-"`
+}
+
 type UserVerifyEmailSendReq struct {
 	CaptchaID   string `json:"captcha_id"`
 	CaptchaCode string `json:"captcha_code"`
@@ -428,3 +463,42 @@ type GetUserStaffResp struct {
 	// avatar
 	Avatar string `json:"avatar"`
 }
+
+// GetUserStatsReq get user stats request
+type GetUserStatsReq struct {
+	Username string `validate:"required,gt=0,lte=500" form:"username"`
+}
+
+// GetUserStatsResp the contribution calendar and the summary of user
+type GetUserStatsResp struct {
+	// the start and end date of the contribution calendar, format: 2006-01-02
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	// Contributions the contributions of each day in the calendar, only the days with contributions are included
+	Contributions      []*UserContributionDay `json:"contributions"`
+	TotalContributions int                    `json:"total_contributions"`
+	// TopTags the tags of the questions the user answered, order by score
+	TopTags             []*UserTopTag `json:"top_tags"`
+	AnswerCount         int           `json:"answer_count"`
+	AcceptedAnswerCount int           `json:"accepted_answer_count"`
+	// AcceptanceRate accepted answer count / answer count
+	AcceptanceRate float64 `json:"acceptance_rate"`
+}
+
+// UserContributionDay the contributions of user in one day
+type UserContributionDay struct {
+	Date          string `json:"date"`
+	QuestionCount int    `json:"question_count"`
+	AnswerCount   int    `json:"answer_count"`
+	EditCount     int    `json:"edit_count"`
+	Total         int    `json:"total"`
+}
+
+// UserTopTag the score of user in the tag
+type UserTopTag struct {
+	SlugName    string `json:"slug_name"`
+	DisplayName string `json:"display_name"`
+	// Score the sum of the votes of the answers in the tag
+	Score       int `json:"score"`
+	AnswerCount int `json:"answer_count"`
+}
@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+// GetUserSessionsReq get user login sessions request
+type GetUserSessionsReq struct {
+	UserID      string `json:"-"`
+	AccessToken string `json:"-"`
+}
+
+// GetUserSessionResp user login session response
+type GetUserSessionResp struct {
+	ID           int    `json:"id"`
+	UserAgent    string `json:"user_agent"`
+	IP           string `json:"ip"`
+	CreatedAt    int64  `json:"created_at"`
+	LastActiveAt int64  `json:"last_active_at"`
+	// whether it is the session of the current request
+	IsCurrent bool `json:"is_current"`
+}
+
+// RevokeUserSessionReq revoke user login session request
+type RevokeUserSessionReq struct {
+	SessionID int    `validate:"required,min=1" json:"session_id"`
+	UserID    string `json:"-"`
+}
+
+// RevokeOtherUserSessionsReq revoke all the other user login sessions request
+type RevokeOtherUserSessionsReq struct {
+	UserID      string `json:"-"`
+	AccessToken string `json:"-"`
+}
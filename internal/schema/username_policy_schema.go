@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package schema
+
+const (
+	// UsernameCharsetStandard lowercase letters, digits and ".", "_", "-"
+	UsernameCharsetStandard = "standard"
+	// UsernameCharsetAlphanumeric lowercase letters and digits
+	UsernameCharsetAlphanumeric = "alphanumeric"
+)
+
+// SiteUsernamePolicyReq site username policy request
+type SiteUsernamePolicyReq struct {
+	MinLength int    `validate:"required,min=2,max=30" json:"min_length"`
+	MaxLength int    `validate:"required,min=2,max=30" json:"max_length"`
+	Charset   string `validate:"required,oneof=standard alphanumeric" json:"charset"`
+	// ReservedNames the names can't be used by the users besides the built-in ones, the name is matched
+	// regardless of the case, the separators and the look-alike digits, so "Sup-p0rt" is the same as "support"
+	ReservedNames []string `validate:"omitempty,lte=1000,dive,required,lte=30" json:"reserved_names"`
+	// CheckImpersonation reject the username and the display name which look the same as the ones of the staff
+	CheckImpersonation bool `json:"check_impersonation"`
+	// ChangeLimit the times the user can change the username or the display name in ChangeLimitDays,
+	// 0 means unlimited. The changes made by the admin are not counted.
+	ChangeLimit     int `validate:"omitempty,min=0,max=100" json:"change_limit"`
+	ChangeLimitDays int `validate:"omitempty,min=0,max=365" json:"change_limit_days"`
+	// FormerNameDays the former names are shown on the profile for the days after the change, 0 means not shown
+	FormerNameDays int `validate:"omitempty,min=0,max=3650" json:"former_name_days"`
+}
+
+// DefaultUsernamePolicy the username policy before it is changed by the admin
+func DefaultUsernamePolicy() *SiteUsernamePolicyReq {
+	return &SiteUsernamePolicyReq{
+		MinLength:          4,
+		MaxLength:          30,
+		Charset:            UsernameCharsetStandard,
+		ReservedNames:      make([]string, 0),
+		CheckImpersonation: true,
+		ChangeLimit:        3,
+		ChangeLimitDays:    30,
+		FormerNameDays:     30,
+	}
+}
+
+// UsernameLengthTrTplData the template data of the username length error
+type UsernameLengthTrTplData struct {
+	Min int
+	Max int
+}
+
+// NameChangeLimitTrTplData the template data of the name change limit error
+type NameChangeLimitTrTplData struct {
+	Limit int
+	Days  int
+}
+
+// PluralCount the plural form of the limit is used in the translation
+func (d *NameChangeLimitTrTplData) PluralCount() int {
+	return d.Limit
+}
+
+// GetNameChangePageReq get name change page request
+type GetNameChangePageReq struct {
+	Page     int `validate:"omitempty,min=1" form:"page"`
+	PageSize int `validate:"omitempty,min=1,max=100" form:"page_size"`
+	// the changes of the user, empty means all
+	Username string `validate:"omitempty,lte=50" form:"username"`
+}
+
+// NameChangeResp name change response
+type NameChangeResp struct {
+	ID        int    `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	UserID    string `json:"user_id"`
+	// field(username,display_name)
+	Field          string `json:"field"`
+	OldName        string `json:"old_name"`
+	NewName        string `json:"new_name"`
+	OperatorUserID string `json:"operator_user_id"`
+}
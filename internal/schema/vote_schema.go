@@ -96,3 +96,24 @@ type GetVoteWithPageResp struct {
 	// vote type
 	VoteType string `json:"vote_type"`
 }
+
+// GetObjectVotesReq get the votes of the question or answer request
+type GetObjectVotesReq struct {
+	// question or answer id
+	ObjectID string `validate:"required" form:"object_id"`
+	// page
+	Page int `validate:"omitempty,min=1" form:"page"`
+	// page size
+	PageSize int `validate:"omitempty,min=1" form:"page_size"`
+	// user id
+	UserID string `json:"-"`
+}
+
+// GetObjectVotesResp the vote of the question or answer
+type GetObjectVotesResp struct {
+	// vote type, vote_up or vote_down
+	VoteType string `json:"vote_type"`
+	// the time when the user voted
+	VotedAt int64          `json:"voted_at"`
+	Voter   *UserBasicInfo `json:"voter"`
+}
@@ -31,6 +31,8 @@ import (
 type AnswerActivityRepo interface {
 	SaveAcceptAnswerActivity(ctx context.Context, op *schema.AcceptAnswerOperationInfo) (err error)
 	SaveCancelAcceptAnswerActivity(ctx context.Context, op *schema.AcceptAnswerOperationInfo) (err error)
+	TransferAcceptAnswerActivity(ctx context.Context, activityType int, questionUserID,
+		fromAnswerID, toAnswerID string) (err error)
 }
 
 // AnswerActivityService answer activity service
@@ -61,6 +63,20 @@ func (as *AnswerActivityService) AcceptAnswer(ctx context.Context,
 	return as.answerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo)
 }
 
+// AcceptAdditionalAnswer accept one more answer in the multiple accepted answers mode,
+// the question author is only rewarded for accepting the first answer
+func (as *AnswerActivityService) AcceptAdditionalAnswer(ctx context.Context,
+	loginUserID, answerObjID, questionObjID, questionUserID, answerUserID string, isSelf bool) (err error) {
+	operationInfo := as.createAcceptAnswerOperationInfo(ctx, loginUserID,
+		answerObjID, questionObjID, questionUserID, answerUserID, isSelf)
+	for _, activity := range operationInfo.Activities {
+		if activity.OriginalObjectID == questionObjID {
+			activity.Rank = 0
+		}
+	}
+	return as.answerActivityRepo.SaveAcceptAnswerActivity(ctx, operationInfo)
+}
+
 // CancelAcceptAnswer cancel accept answer change activity
 func (as *AnswerActivityService) CancelAcceptAnswer(ctx context.Context,
 	loginUserID, answerObjID, questionObjID, questionUserID, answerUserID string) (err error) {
@@ -69,6 +85,17 @@ func (as *AnswerActivityService) CancelAcceptAnswer(ctx context.Context,
 	return as.answerActivityRepo.SaveCancelAcceptAnswerActivity(ctx, operationInfo)
 }
 
+// TransferAcceptAnswer move the reward of the question author from the answer which is no longer accepted
+// to the answer which is still accepted in the multiple accepted answers mode
+func (as *AnswerActivityService) TransferAcceptAnswer(ctx context.Context,
+	questionUserID, fromAnswerID, toAnswerID string) (err error) {
+	cfg, err := as.configService.GetConfigByKey(ctx, activity_type.AnswerAccept)
+	if err != nil {
+		return err
+	}
+	return as.answerActivityRepo.TransferAcceptAnswerActivity(ctx, cfg.ID, questionUserID, fromAnswerID, toAnswerID)
+}
+
 func (as *AnswerActivityService) createAcceptAnswerOperationInfo(ctx context.Context, loginUserID,
 	answerObjID, questionObjID, questionUserID, answerUserID string, isSelf bool) *schema.AcceptAnswerOperationInfo {
 	operationInfo := &schema.AcceptAnswerOperationInfo{
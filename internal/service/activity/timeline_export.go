@@ -0,0 +1,183 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package activity
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ExportObjectTimeline export the complete timeline of the object for audit.
+// Unlike the timeline page, the vote activities of the receivers are kept and the down voters are not hidden.
+func (as *ActivityService) ExportObjectTimeline(ctx context.Context, req *schema.ExportObjectTimelineReq) (
+	resp *schema.ExportObjectTimelineResp, err error) {
+	fields, err := parseTimelineExportFields(req.Fields)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.ExportObjectTimelineResp{
+		Fields:   fields,
+		Timeline: make([]map[string]interface{}, 0),
+	}
+	resp.ObjectInfo, err = as.getTimelineMainObjInfo(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	activityList, err := as.activityRepo.GetObjectAllActivity(ctx, req.ObjectID, req.ShowVote)
+	if err != nil {
+		return nil, err
+	}
+	timeline := make([]*schema.ActObjectTimeline, 0, len(activityList))
+	// the activities are ordered by id desc, the audit reads them in time order
+	for i := len(activityList) - 1; i >= 0; i-- {
+		act := activityList[i]
+		item := &schema.ActObjectTimeline{
+			ActivityID: act.ID,
+			RevisionID: converter.IntToString(act.RevisionID),
+			CreatedAt:  act.CreatedAt.Unix(),
+			Cancelled:  act.Cancelled == entity.ActivityCancelled,
+			ObjectID:   act.ObjectID,
+			UserInfo:   &schema.UserBasicInfo{ID: act.UserID},
+		}
+		item.ObjectType, _ = obj.GetObjectTypeStrByObjectID(act.ObjectID)
+		if item.Cancelled {
+			item.CancelledAt = act.CancelledAt.Unix()
+		}
+		if (item.ObjectType == constant.QuestionObjectType || item.ObjectType == constant.AnswerObjectType) &&
+			handler.GetEnableShortID(ctx) {
+			item.ObjectID = uid.EnShortID(act.ObjectID)
+		}
+		if act.TriggerUserID > 0 {
+			item.UserInfo.ID = fmt.Sprintf("%d", act.TriggerUserID)
+		}
+
+		cfg, err := as.configService.GetConfigByID(ctx, act.ActivityType)
+		if err != nil {
+			log.Errorf("fail to get config by id: %d, err: %v, act id is: %s", act.ActivityType, err, act.ID)
+		} else {
+			_, item.ActivityType, _ = strings.Cut(cfg.Key, ".")
+			if isHidden, formattedActivityType := formatActivity(item.ActivityType); !isHidden {
+				item.ActivityType = formattedActivityType
+			}
+		}
+		item.Comment = as.getTimelineActivityComment(ctx, item.ObjectID, item.ObjectType, item.ActivityType, item.RevisionID)
+		timeline = append(timeline, item)
+	}
+	as.formatTimelineUserInfo(ctx, timeline)
+
+	for _, item := range timeline {
+		resp.Timeline = append(resp.Timeline, timelineExportRow(item, fields))
+	}
+	return resp, nil
+}
+
+// FormatObjectTimelineCSV format the exported timeline as csv, the first line is the header
+func FormatObjectTimelineCSV(resp *schema.ExportObjectTimelineResp) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	if err := w.Write(resp.Fields); err != nil {
+		return nil, err
+	}
+	for _, row := range resp.Timeline {
+		record := make([]string, 0, len(resp.Fields))
+		for _, field := range resp.Fields {
+			record = append(record, fmt.Sprint(row[field]))
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseTimelineExportFields parse the fields separated by comma, all the fields are exported if it is empty
+func parseTimelineExportFields(fieldsStr string) (fields []string, err error) {
+	if len(strings.TrimSpace(fieldsStr)) == 0 {
+		return schema.TimelineExportFields, nil
+	}
+	allowed := make(map[string]bool, len(schema.TimelineExportFields))
+	for _, field := range schema.TimelineExportFields {
+		allowed[field] = true
+	}
+	fields = make([]string, 0)
+	for _, field := range strings.Split(fieldsStr, ",") {
+		field = strings.TrimSpace(field)
+		if !allowed[field] {
+			return nil, errors.BadRequest(reason.TimelineExportFieldInvalid)
+		}
+		fields = append(fields, field)
+	}
+	return converter.UniqueArray(fields), nil
+}
+
+func timelineExportRow(item *schema.ActObjectTimeline, fields []string) (row map[string]interface{}) {
+	userInfo := item.UserInfo
+	if userInfo == nil {
+		userInfo = &schema.UserBasicInfo{}
+	}
+	row = make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "activity_id":
+			row[field] = item.ActivityID
+		case "created_at":
+			row[field] = item.CreatedAt
+		case "activity_type":
+			row[field] = item.ActivityType
+		case "object_id":
+			row[field] = item.ObjectID
+		case "object_type":
+			row[field] = item.ObjectType
+		case "revision_id":
+			row[field] = item.RevisionID
+		case "user_id":
+			row[field] = userInfo.ID
+		case "username":
+			row[field] = userInfo.Username
+		case "display_name":
+			row[field] = userInfo.DisplayName
+		case "cancelled":
+			row[field] = item.Cancelled
+		case "cancelled_at":
+			row[field] = item.CancelledAt
+		case "comment":
+			row[field] = item.Comment
+		}
+	}
+	return row
+}
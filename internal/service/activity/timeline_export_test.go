@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package activity
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimelineExportFields(t *testing.T) {
+	fields, err := parseTimelineExportFields("")
+	assert.NoError(t, err)
+	assert.Equal(t, schema.TimelineExportFields, fields)
+
+	fields, err = parseTimelineExportFields("created_at, username,created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"created_at", "username"}, fields)
+
+	_, err = parseTimelineExportFields("created_at,email")
+	assert.Error(t, err)
+}
+
+func TestFormatObjectTimelineCSV(t *testing.T) {
+	fields := []string{"activity_type", "username", "comment"}
+	resp := &schema.ExportObjectTimelineResp{
+		Fields: fields,
+		Timeline: []map[string]interface{}{
+			timelineExportRow(&schema.ActObjectTimeline{
+				ActivityType: "downvote",
+				Comment:      "a, b",
+				UserInfo:     &schema.UserBasicInfo{Username: "jane"},
+			}, fields),
+			timelineExportRow(&schema.ActObjectTimeline{ActivityType: "voted_up"}, fields),
+		},
+	}
+	data, err := FormatObjectTimelineCSV(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "activity_type,username,comment\ndownvote,jane,\"a, b\"\nvoted_up,,\n", string(data))
+}
@@ -22,21 +22,25 @@ package activity_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
 
+//go:generate mockgen -source=./activity_queue.go -destination=../mock/activity_queue_service_mock.go -package=mock
 type ActivityQueueService interface {
 	Send(ctx context.Context, msg *schema.ActivityMsg)
 	RegisterHandler(handler func(ctx context.Context, msg *schema.ActivityMsg) error)
 }
 
 type activityQueueService struct {
+	lifecycle.PendingCounter
 	Queue   chan *schema.ActivityMsg
 	Handler func(ctx context.Context, msg *schema.ActivityMsg) error
 }
 
 func (ns *activityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {
+	ns.Add()
 	ns.Queue <- msg
 }
 
@@ -48,22 +52,28 @@ func (ns *activityQueueService) RegisterHandler(
 func (ns *activityQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
-			log.Debugf("received activity %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for activity")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+			ns.handle(msg)
+			ns.Done()
 		}
 	}()
 }
 
+func (ns *activityQueueService) handle(msg *schema.ActivityMsg) {
+	log.Debugf("received activity %+v", msg)
+	if ns.Handler == nil {
+		log.Warnf("no handler for activity")
+		return
+	}
+	if err := ns.Handler(context.Background(), msg); err != nil {
+		log.Error(err)
+	}
+}
+
 // NewActivityQueueService create a new activity queue service
 func NewActivityQueueService() ActivityQueueService {
 	ns := &activityQueueService{}
 	ns.Queue = make(chan *schema.ActivityMsg, 128)
 	ns.working()
+	lifecycle.OnShutdown("activity queue", ns.Drain)
 	return ns
 }
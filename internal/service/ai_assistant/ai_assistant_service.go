@@ -0,0 +1,238 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ai_assistant
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// the max length of the question and each answer sent to the provider
+	assistantQuestionMaxLen = 4000
+	assistantAnswerMaxLen   = 1500
+	// the max number of the answers used for the summary, the answers with more votes first
+	assistantSummaryAnswers = 10
+)
+
+// AIAssistantRepo ai assistant repository
+type AIAssistantRepo interface {
+	AddUsage(ctx context.Context, usage *entity.AIAssistantUsage) (err error)
+	CountUserUsageSince(ctx context.Context, userID string, since time.Time) (count int64, err error)
+}
+
+// AIAssistantService generate the answer draft or the summary of the question on demand
+type AIAssistantService struct {
+	aiAssistantRepo    AIAssistantRepo
+	questionRepo       questioncommon.QuestionRepo
+	answerRepo         answercommon.AnswerRepo
+	siteInfoRepo       siteinfo_common.SiteInfoRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	featureFlagService *feature_flag.FeatureFlagService
+}
+
+// NewAIAssistantService new ai assistant service
+func NewAIAssistantService(
+	aiAssistantRepo AIAssistantRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	featureFlagService *feature_flag.FeatureFlagService,
+) *AIAssistantService {
+	return &AIAssistantService{
+		aiAssistantRepo:    aiAssistantRepo,
+		questionRepo:       questionRepo,
+		answerRepo:         answerRepo,
+		siteInfoRepo:       siteInfoRepo,
+		siteInfoService:    siteInfoService,
+		featureFlagService: featureFlagService,
+	}
+}
+
+// GetAIAssistant get the ai assistant settings
+func (as *AIAssistantService) GetAIAssistant(ctx context.Context) (resp *schema.SiteAIAssistantResp, err error) {
+	resp = &schema.SiteAIAssistantResp{}
+	if err = as.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeAIAssistant, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateAIAssistant update the ai assistant settings
+func (as *AIAssistantService) UpdateAIAssistant(ctx context.Context, req *schema.SiteAIAssistantReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAIAssistant,
+		Content: string(content),
+	}
+	return as.siteInfoRepo.SaveByType(ctx, constant.SiteTypeAIAssistant, data)
+}
+
+// GenerateAnswerDraft generate the answer draft of the question
+func (as *AIAssistantService) GenerateAnswerDraft(ctx context.Context, req *schema.AIAssistantReq) (
+	resp *schema.AIAssistantResp, err error) {
+	return as.generate(ctx, plugin.AssistantActionAnswerDraft, req)
+}
+
+// GenerateQuestionSummary generate the summary of the question and its answers
+func (as *AIAssistantService) GenerateQuestionSummary(ctx context.Context, req *schema.AIAssistantReq) (
+	resp *schema.AIAssistantResp, err error) {
+	return as.generate(ctx, plugin.AssistantActionQuestionSummary, req)
+}
+
+// GetAssistant get the enabled assistant plugin, or the built-in provider if it is configured,
+// nil is returned if neither of them is available
+func GetAssistant(config *schema.SiteAIAssistantResp) (assistant plugin.Assistant) {
+	_ = plugin.CallAssistant(func(p plugin.Assistant) error {
+		if assistant == nil {
+			assistant = p
+		}
+		return nil
+	})
+	if assistant == nil && config.Enabled && len(config.Endpoint) > 0 && len(config.Model) > 0 {
+		assistant = newOpenAIProvider(config)
+	}
+	return assistant
+}
+
+func (as *AIAssistantService) generate(ctx context.Context, action plugin.AssistantAction, req *schema.AIAssistantReq) (
+	resp *schema.AIAssistantResp, err error) {
+	if err = as.featureFlagService.CheckFeatureAvailable(ctx, schema.FeatureAIAssistant, req.UserID); err != nil {
+		return nil, err
+	}
+	config, err := as.GetAIAssistant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assistant := GetAssistant(config)
+	if assistant == nil {
+		return nil, errors.BadRequest(reason.AIAssistantDisabled)
+	}
+	provider := assistant.Info().SlugName
+
+	remaining := -1
+	if config.DailyQuota > 0 {
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		used, err := as.aiAssistantRepo.CountUserUsageSince(ctx, req.UserID, today)
+		if err != nil {
+			return nil, err
+		}
+		if used >= int64(config.DailyQuota) {
+			return nil, errors.BadRequest(reason.AIAssistantQuotaExceeded)
+		}
+		remaining = config.DailyQuota - int(used) - 1
+	}
+
+	assistantReq, err := as.buildAssistantRequest(ctx, action, req)
+	if err != nil {
+		return nil, err
+	}
+	result, err := assistant.Generate(assistantReq)
+	if err != nil {
+		log.Errorf("ai assistant %s failed to generate %s: %v", provider, action, err)
+		return nil, errors.BadRequest(reason.AIAssistantGenerateFailed)
+	}
+	if result == nil || len(result.Content) == 0 {
+		return nil, errors.BadRequest(reason.AIAssistantGenerateFailed)
+	}
+
+	err = as.aiAssistantRepo.AddUsage(ctx, &entity.AIAssistantUsage{
+		UserID:           req.UserID,
+		ObjectID:         req.QuestionID,
+		Action:           string(action),
+		Provider:         provider,
+		Model:            result.Model,
+		PromptTokens:     result.PromptTokens,
+		CompletionTokens: result.CompletionTokens,
+	})
+	if err != nil {
+		log.Error(err)
+	}
+	return &schema.AIAssistantResp{
+		Content:        result.Content,
+		AIGenerated:    true,
+		Provider:       provider,
+		Model:          result.Model,
+		RemainingQuota: remaining,
+	}, nil
+}
+
+// buildAssistantRequest build the request with the plain text of the question and its answers
+func (as *AIAssistantService) buildAssistantRequest(ctx context.Context, action plugin.AssistantAction,
+	req *schema.AIAssistantReq) (assistantReq *plugin.AssistantRequest, err error) {
+	question, exist, err := as.questionRepo.GetQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || (question.Status != entity.QuestionStatusAvailable && question.Status != entity.QuestionStatusClosed) {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	assistantReq = &plugin.AssistantRequest{
+		Action:   action,
+		Title:    question.Title,
+		Content:  htmltext.FetchExcerpt(question.ParsedText, "...", assistantQuestionMaxLen),
+		Language: string(handler.GetLangByCtx(ctx)),
+		UserID:   req.UserID,
+	}
+	if action != plugin.AssistantActionQuestionSummary {
+		return assistantReq, nil
+	}
+
+	answers, err := as.answerRepo.GetAnswerList(ctx, &entity.Answer{
+		QuestionID: question.ID,
+		Status:     entity.AnswerStatusAvailable,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(answers, func(i, j int) bool {
+		if answers[i].Accepted != answers[j].Accepted {
+			return answers[i].Accepted == schema.AnswerAcceptedEnable
+		}
+		return answers[i].VoteCount > answers[j].VoteCount
+	})
+	for i, answer := range answers {
+		if i >= assistantSummaryAnswers {
+			break
+		}
+		assistantReq.Answers = append(assistantReq.Answers,
+			htmltext.FetchExcerpt(answer.ParsedText, "...", assistantAnswerMaxLen))
+	}
+	return assistantReq, nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ai_assistant
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+const (
+	openAITimeout = 60 * time.Second
+	// openAIMaxRespSize the max size of the response body read from the provider
+	openAIMaxRespSize = 1 << 20
+)
+
+// openAIProvider the built-in assistant which works with any OpenAI-compatible chat completions API
+type openAIProvider struct {
+	config     *schema.SiteAIAssistantResp
+	httpClient *http.Client
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatReq struct {
+	Model     string           `json:"model"`
+	Messages  []*openAIMessage `json:"messages"`
+	MaxTokens int              `json:"max_tokens,omitempty"`
+}
+
+type openAIChatResp struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newOpenAIProvider(config *schema.SiteAIAssistantResp) *openAIProvider {
+	return &openAIProvider{
+		config:     config,
+		httpClient: &http.Client{Timeout: openAITimeout},
+	}
+}
+
+func (p *openAIProvider) Info() plugin.Info {
+	return plugin.Info{SlugName: schema.AIAssistantProviderBuiltIn}
+}
+
+// Generate send the prompt to the chat completions API and return the first choice
+func (p *openAIProvider) Generate(req *plugin.AssistantRequest) (resp *plugin.AssistantResponse, err error) {
+	systemPrompt, userPrompt := buildAssistantPrompt(req)
+	body, _ := json.Marshal(&openAIChatReq{
+		Model: p.config.Model,
+		Messages: []*openAIMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		MaxTokens: p.config.MaxTokens,
+	})
+	endpoint := strings.TrimSuffix(p.config.Endpoint, "/") + "/chat/completions"
+	httpReq, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(p.config.APIKey) > 0 {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+	httpResp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, openAIMaxRespSize))
+	if err != nil {
+		return nil, err
+	}
+	return parseOpenAIChatResp(httpResp.StatusCode, respBody)
+}
+
+func parseOpenAIChatResp(statusCode int, body []byte) (resp *plugin.AssistantResponse, err error) {
+	chatResp := &openAIChatResp{}
+	if err = json.Unmarshal(body, chatResp); err != nil {
+		return nil, fmt.Errorf("status %d: invalid response: %w", statusCode, err)
+	}
+	if chatResp.Error != nil {
+		return nil, fmt.Errorf("status %d: %s", statusCode, chatResp.Error.Message)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", statusCode)
+	}
+	if len(chatResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in the response")
+	}
+	return &plugin.AssistantResponse{
+		Content:          strings.TrimSpace(chatResp.Choices[0].Message.Content),
+		Model:            chatResp.Model,
+		PromptTokens:     chatResp.Usage.PromptTokens,
+		CompletionTokens: chatResp.Usage.CompletionTokens,
+	}, nil
+}
+
+// buildAssistantPrompt build the system and user prompt of the action
+func buildAssistantPrompt(req *plugin.AssistantRequest) (systemPrompt, userPrompt string) {
+	language := req.Language
+	if len(language) == 0 {
+		language = "en_US"
+	}
+	sb := &strings.Builder{}
+	sb.WriteString("Title: " + req.Title + "\n\n")
+	sb.WriteString("Question:\n" + req.Content + "\n")
+
+	switch req.Action {
+	case plugin.AssistantActionQuestionSummary:
+		systemPrompt = "You summarize questions and their answers on a Q&A site. " +
+			"Write a short markdown summary of the problem and the suggested solutions, " +
+			"do not add any information which is not in the question or the answers. " +
+			"Write in the language of the locale " + language + "."
+		for i, answer := range req.Answers {
+			sb.WriteString(fmt.Sprintf("\nAnswer %d:\n%s\n", i+1, answer))
+		}
+	default:
+		systemPrompt = "You help users write answers on a Q&A site. " +
+			"Write a clear and correct answer draft to the question in markdown, " +
+			"say so if the question cannot be answered with the given information. " +
+			"Write in the language of the locale " + language + "."
+	}
+	return systemPrompt, sb.String()
+}
@@ -0,0 +1,89 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ai_assistant
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenAIProvider_Generate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		req := &openAIChatReq{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		assert.Equal(t, "test-model", req.Model)
+		assert.Len(t, req.Messages, 2)
+		_, _ = w.Write([]byte(`{"model":"test-model-1","choices":[{"message":{"role":"assistant","content":" draft "}}],` +
+			`"usage":{"prompt_tokens":10,"completion_tokens":5}}`))
+	}))
+	defer server.Close()
+
+	p := newOpenAIProvider(&schema.SiteAIAssistantResp{
+		Endpoint: server.URL + "/v1/",
+		APIKey:   "key",
+		Model:    "test-model",
+	})
+	resp, err := p.Generate(&plugin.AssistantRequest{Action: plugin.AssistantActionAnswerDraft, Title: "title"})
+	assert.NoError(t, err)
+	assert.Equal(t, "draft", resp.Content)
+	assert.Equal(t, "test-model-1", resp.Model)
+	assert.Equal(t, 10, resp.PromptTokens)
+	assert.Equal(t, 5, resp.CompletionTokens)
+}
+
+func TestParseOpenAIChatResp(t *testing.T) {
+	_, err := parseOpenAIChatResp(http.StatusUnauthorized, []byte(`{"error":{"message":"invalid api key"}}`))
+	assert.EqualError(t, err, "status 401: invalid api key")
+
+	_, err = parseOpenAIChatResp(http.StatusOK, []byte(`{"choices":[]}`))
+	assert.Error(t, err)
+
+	_, err = parseOpenAIChatResp(http.StatusBadGateway, []byte(`bad gateway`))
+	assert.Error(t, err)
+}
+
+func TestBuildAssistantPrompt(t *testing.T) {
+	_, userPrompt := buildAssistantPrompt(&plugin.AssistantRequest{
+		Action:  plugin.AssistantActionQuestionSummary,
+		Title:   "title",
+		Content: "content",
+		Answers: []string{"first", "second"},
+	})
+	assert.Contains(t, userPrompt, "Answer 1:\nfirst")
+	assert.Contains(t, userPrompt, "Answer 2:\nsecond")
+
+	_, userPrompt = buildAssistantPrompt(&plugin.AssistantRequest{
+		Action:  plugin.AssistantActionAnswerDraft,
+		Title:   "title",
+		Content: "content",
+		Answers: []string{"first"},
+	})
+	assert.NotContains(t, userPrompt, "first")
+}
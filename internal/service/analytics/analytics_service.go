@@ -0,0 +1,259 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/search_log"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	analyticsDateFormat            = "2006-01-02"
+	analyticsTopTagsLimit          = 10
+	analyticsZeroResultSearchLimit = 10
+	analyticsBackfillDays          = 30
+	analyticsWeeklyDays            = 7
+	analyticsMonthlyDays           = 30
+	analyticsRollupRecents         = 2
+)
+
+// AnalyticsRepo site analytics repository
+type AnalyticsRepo interface {
+	CountNewUsers(ctx context.Context, startTime, endTime time.Time) (count int64, err error)
+	CountQuestions(ctx context.Context, startTime, endTime time.Time) (count, acceptedCount int64, err error)
+	CountAnswers(ctx context.Context, startTime, endTime time.Time) (count int64, err error)
+	CountComments(ctx context.Context, startTime, endTime time.Time) (count int64, err error)
+	CountActiveUsers(ctx context.Context, startTime, endTime time.Time) (count int64, err error)
+	GetTagQuestionCounts(ctx context.Context, startTime, endTime time.Time) (tagCounts []*entity.SiteAnalyticsTagCount, err error)
+	SaveDaily(ctx context.Context, daily *entity.SiteAnalyticsDaily, tagDailyList []*entity.SiteAnalyticsTagDaily) (err error)
+	GetDailyByDateRange(ctx context.Context, startDate, endDate string) (dailyList []*entity.SiteAnalyticsDaily, err error)
+	GetTopTagsByDateRange(ctx context.Context, startDate, endDate string, limit int) (tagCounts []*entity.SiteAnalyticsTagCount, err error)
+}
+
+// AnalyticsService site analytics service
+type AnalyticsService struct {
+	analyticsRepo    AnalyticsRepo
+	tagCommonService *tagcommonser.TagCommonService
+	searchLogService *search_log.SearchLogService
+}
+
+// NewAnalyticsService new site analytics service
+func NewAnalyticsService(
+	analyticsRepo AnalyticsRepo,
+	tagCommonService *tagcommonser.TagCommonService,
+	searchLogService *search_log.SearchLogService,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo:    analyticsRepo,
+		tagCommonService: tagCommonService,
+		searchLogService: searchLogService,
+	}
+}
+
+// GetSiteAnalytics get site analytics from the rollup tables
+func (as *AnalyticsService) GetSiteAnalytics(ctx context.Context, req *schema.GetSiteAnalyticsReq) (
+	resp *schema.GetSiteAnalyticsResp, err error) {
+	if len(req.Range) == 0 {
+		req.Range = schema.StatsRangeMonth
+	}
+	now := time.Now()
+	endDate := now.Format(analyticsDateFormat)
+	startDate := now.AddDate(0, 0, 1-schema.StatsRangeDays[req.Range]).Format(analyticsDateFormat)
+	resp = &schema.GetSiteAnalyticsResp{
+		Range:     req.Range,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Daily:     make([]*schema.SiteAnalyticsDailyItem, 0),
+		TopTags:   make([]*schema.SiteAnalyticsTagItem, 0),
+	}
+
+	dailyList, err := as.analyticsRepo.GetDailyByDateRange(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	acceptedCount := 0
+	for _, daily := range dailyList {
+		resp.Daily = append(resp.Daily, &schema.SiteAnalyticsDailyItem{
+			Date:               daily.Date,
+			DailyActiveUsers:   daily.DailyActiveUsers,
+			WeeklyActiveUsers:  daily.WeeklyActiveUsers,
+			MonthlyActiveUsers: daily.MonthlyActiveUsers,
+			NewUserCount:       daily.NewUserCount,
+			QuestionCount:      daily.QuestionCount,
+			AnswerCount:        daily.AnswerCount,
+			CommentCount:       daily.CommentCount,
+		})
+		resp.NewUserCount += daily.NewUserCount
+		resp.QuestionCount += daily.QuestionCount
+		resp.AnswerCount += daily.AnswerCount
+		resp.CommentCount += daily.CommentCount
+		acceptedCount += daily.AcceptedQuestionCount
+	}
+	if len(dailyList) > 0 {
+		latest := dailyList[len(dailyList)-1]
+		resp.DailyActiveUsers = latest.DailyActiveUsers
+		resp.WeeklyActiveUsers = latest.WeeklyActiveUsers
+		resp.MonthlyActiveUsers = latest.MonthlyActiveUsers
+	}
+	if resp.QuestionCount > 0 {
+		resp.AcceptanceRate = float64(acceptedCount) / float64(resp.QuestionCount)
+	}
+
+	resp.TopTags, err = as.getTopTags(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	resp.ZeroResultSearchTerms, err = as.searchLogService.GetZeroResultQueries(ctx, startDate, endDate, analyticsZeroResultSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (as *AnalyticsService) getTopTags(ctx context.Context, startDate, endDate string) (
+	topTags []*schema.SiteAnalyticsTagItem, err error) {
+	topTags = make([]*schema.SiteAnalyticsTagItem, 0)
+	tagCounts, err := as.analyticsRepo.GetTopTagsByDateRange(ctx, startDate, endDate, analyticsTopTagsLimit)
+	if err != nil {
+		return nil, err
+	}
+	if len(tagCounts) == 0 {
+		return topTags, nil
+	}
+	tagIDs := make([]string, 0, len(tagCounts))
+	for _, tagCount := range tagCounts {
+		tagIDs = append(tagIDs, tagCount.TagID)
+	}
+	tagList, err := as.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		tagMapping[tag.ID] = tag
+	}
+	for _, tagCount := range tagCounts {
+		tag, ok := tagMapping[tagCount.TagID]
+		if !ok {
+			continue
+		}
+		topTags = append(topTags, &schema.SiteAnalyticsTagItem{
+			TagID:         tag.ID,
+			SlugName:      tag.SlugName,
+			DisplayName:   tag.DisplayName,
+			QuestionCount: tagCount.QuestionCount,
+		})
+	}
+	return topTags, nil
+}
+
+// RollupCron roll up the site analytics into the summary tables.
+// Today and yesterday are always recalculated, other days in the backfill window are only calculated when missing.
+func (as *AnalyticsService) RollupCron(ctx context.Context) (err error) {
+	today := truncateToDay(time.Now())
+	startDate := today.AddDate(0, 0, 1-analyticsBackfillDays).Format(analyticsDateFormat)
+	dailyList, err := as.analyticsRepo.GetDailyByDateRange(ctx, startDate, today.Format(analyticsDateFormat))
+	if err != nil {
+		return err
+	}
+	existDates := make(map[string]bool, len(dailyList))
+	for _, daily := range dailyList {
+		existDates[daily.Date] = true
+	}
+
+	for i := analyticsBackfillDays - 1; i >= 0; i-- {
+		day := today.AddDate(0, 0, -i)
+		if i >= analyticsRollupRecents && existDates[day.Format(analyticsDateFormat)] {
+			continue
+		}
+		if e := as.rollupDay(ctx, day); e != nil {
+			log.Errorf("roll up site analytics of %s failed: %s", day.Format(analyticsDateFormat), e)
+			err = e
+		}
+	}
+	return err
+}
+
+func (as *AnalyticsService) rollupDay(ctx context.Context, day time.Time) (err error) {
+	date := day.Format(analyticsDateFormat)
+	startTime, endTime := day, day.AddDate(0, 0, 1)
+	daily := &entity.SiteAnalyticsDaily{Date: date}
+
+	activeUsers, err := as.analyticsRepo.CountActiveUsers(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	daily.DailyActiveUsers = int(activeUsers)
+	activeUsers, err = as.analyticsRepo.CountActiveUsers(ctx, endTime.AddDate(0, 0, -analyticsWeeklyDays), endTime)
+	if err != nil {
+		return err
+	}
+	daily.WeeklyActiveUsers = int(activeUsers)
+	activeUsers, err = as.analyticsRepo.CountActiveUsers(ctx, endTime.AddDate(0, 0, -analyticsMonthlyDays), endTime)
+	if err != nil {
+		return err
+	}
+	daily.MonthlyActiveUsers = int(activeUsers)
+
+	newUserCount, err := as.analyticsRepo.CountNewUsers(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	daily.NewUserCount = int(newUserCount)
+	questionCount, acceptedCount, err := as.analyticsRepo.CountQuestions(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	daily.QuestionCount, daily.AcceptedQuestionCount = int(questionCount), int(acceptedCount)
+	answerCount, err := as.analyticsRepo.CountAnswers(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	daily.AnswerCount = int(answerCount)
+	commentCount, err := as.analyticsRepo.CountComments(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	daily.CommentCount = int(commentCount)
+
+	tagCounts, err := as.analyticsRepo.GetTagQuestionCounts(ctx, startTime, endTime)
+	if err != nil {
+		return err
+	}
+	tagDailyList := make([]*entity.SiteAnalyticsTagDaily, 0, len(tagCounts))
+	for _, tagCount := range tagCounts {
+		tagDailyList = append(tagDailyList, &entity.SiteAnalyticsTagDaily{
+			Date:          date,
+			TagID:         tagCount.TagID,
+			QuestionCount: tagCount.QuestionCount,
+		})
+	}
+	return as.analyticsRepo.SaveDaily(ctx, daily, tagDailyList)
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
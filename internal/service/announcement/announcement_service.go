@@ -0,0 +1,263 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package announcement
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// AnnouncementRepo announcement repository
+type AnnouncementRepo interface {
+	AddAnnouncement(ctx context.Context, announcement *entity.Announcement) (err error)
+	UpdateAnnouncement(ctx context.Context, announcement *entity.Announcement) (err error)
+	RemoveAnnouncement(ctx context.Context, id int) (err error)
+	GetAnnouncement(ctx context.Context, id int) (announcement *entity.Announcement, exist bool, err error)
+	GetAnnouncementList(ctx context.Context) (announcements []*entity.Announcement, err error)
+	GetEnabledAnnouncementList(ctx context.Context) (announcements []*entity.Announcement, err error)
+	AddDismissal(ctx context.Context, announcementID int, userID string) (err error)
+	GetDismissedAnnouncementIDs(ctx context.Context, userID string) (announcementIDs []int, err error)
+}
+
+// AnnouncementService announcement service
+type AnnouncementService struct {
+	announcementRepo   AnnouncementRepo
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewAnnouncementService new announcement service
+func NewAnnouncementService(
+	announcementRepo AnnouncementRepo,
+	userRoleRelService *role.UserRoleRelService,
+) *AnnouncementService {
+	return &AnnouncementService{
+		announcementRepo:   announcementRepo,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// AddAnnouncement add announcement
+func (as *AnnouncementService) AddAnnouncement(ctx context.Context, req *schema.AddAnnouncementReq) (
+	resp *schema.AddAnnouncementResp, err error) {
+	announcement := &entity.Announcement{
+		OriginalText: req.Content,
+		ParsedText:   req.HTML,
+		Style:        req.Style,
+		Audience:     req.Audience,
+		RoleIDs:      joinRoleIDs(req.Audience, req.RoleIDs),
+		StartTime:    unixToTime(req.StartTime),
+		EndTime:      unixToTime(req.EndTime),
+		Status:       announcementStatus(req.Enabled),
+		UserID:       req.UserID,
+	}
+	if err = as.announcementRepo.AddAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+	return &schema.AddAnnouncementResp{ID: announcement.ID}, nil
+}
+
+// UpdateAnnouncement update announcement
+func (as *AnnouncementService) UpdateAnnouncement(ctx context.Context, req *schema.UpdateAnnouncementReq) (err error) {
+	announcement, exist, err := as.announcementRepo.GetAnnouncement(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.AnnouncementNotFound)
+	}
+	announcement.OriginalText = req.Content
+	announcement.ParsedText = req.HTML
+	announcement.Style = req.Style
+	announcement.Audience = req.Audience
+	announcement.RoleIDs = joinRoleIDs(req.Audience, req.RoleIDs)
+	announcement.StartTime = unixToTime(req.StartTime)
+	announcement.EndTime = unixToTime(req.EndTime)
+	announcement.Status = announcementStatus(req.Enabled)
+	return as.announcementRepo.UpdateAnnouncement(ctx, announcement)
+}
+
+// RemoveAnnouncement remove announcement and its dismissal records
+func (as *AnnouncementService) RemoveAnnouncement(ctx context.Context, req *schema.RemoveAnnouncementReq) (err error) {
+	_, exist, err := as.announcementRepo.GetAnnouncement(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.AnnouncementNotFound)
+	}
+	return as.announcementRepo.RemoveAnnouncement(ctx, req.ID)
+}
+
+// GetAnnouncementList get all announcements for admin
+func (as *AnnouncementService) GetAnnouncementList(ctx context.Context) (resp []*schema.GetAnnouncementResp, err error) {
+	announcements, err := as.announcementRepo.GetAnnouncementList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetAnnouncementResp, 0, len(announcements))
+	for _, announcement := range announcements {
+		resp = append(resp, &schema.GetAnnouncementResp{
+			ID:        announcement.ID,
+			Content:   announcement.OriginalText,
+			HTML:      announcement.ParsedText,
+			Style:     announcement.Style,
+			Audience:  announcement.Audience,
+			RoleIDs:   splitRoleIDs(announcement.RoleIDs),
+			StartTime: timeToUnix(announcement.StartTime),
+			EndTime:   timeToUnix(announcement.EndTime),
+			Enabled:   announcement.Status == entity.AnnouncementStatusEnabled,
+			CreatedAt: announcement.CreatedAt.Unix(),
+			UpdatedAt: announcement.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// GetActiveAnnouncements get the announcements which should be shown to the user now.
+// The user id is empty if the user is not logged in.
+func (as *AnnouncementService) GetActiveAnnouncements(ctx context.Context, userID string) (
+	resp []*schema.GetActiveAnnouncementResp, err error) {
+	resp = make([]*schema.GetActiveAnnouncementResp, 0)
+	announcements, err := as.announcementRepo.GetEnabledAnnouncementList(ctx)
+	if err != nil || len(announcements) == 0 {
+		return resp, err
+	}
+
+	dismissed := make(map[int]bool)
+	userRoleID := 0
+	if len(userID) > 0 {
+		dismissedIDs, err := as.announcementRepo.GetDismissedAnnouncementIDs(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range dismissedIDs {
+			dismissed[id] = true
+		}
+		userRoleID, err = as.userRoleRelService.GetUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	for _, announcement := range announcements {
+		if dismissed[announcement.ID] {
+			continue
+		}
+		if !announcement.StartTime.IsZero() && now.Before(announcement.StartTime) {
+			continue
+		}
+		if !announcement.EndTime.IsZero() && !now.Before(announcement.EndTime) {
+			continue
+		}
+		if !matchAudience(announcement, userID, userRoleID) {
+			continue
+		}
+		resp = append(resp, &schema.GetActiveAnnouncementResp{
+			ID:          announcement.ID,
+			HTML:        announcement.ParsedText,
+			Style:       announcement.Style,
+			Dismissible: len(userID) > 0,
+			EndTime:     timeToUnix(announcement.EndTime),
+		})
+	}
+	return resp, nil
+}
+
+// DismissAnnouncement the user dismiss the announcement, it will not be shown to the user again
+func (as *AnnouncementService) DismissAnnouncement(ctx context.Context, req *schema.DismissAnnouncementReq) (err error) {
+	_, exist, err := as.announcementRepo.GetAnnouncement(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.AnnouncementNotFound)
+	}
+	return as.announcementRepo.AddDismissal(ctx, req.ID, req.UserID)
+}
+
+func matchAudience(announcement *entity.Announcement, userID string, userRoleID int) bool {
+	switch announcement.Audience {
+	case entity.AnnouncementAudienceLoggedIn:
+		return len(userID) > 0
+	case entity.AnnouncementAudienceRoles:
+		if len(userID) == 0 {
+			return false
+		}
+		for _, roleID := range splitRoleIDs(announcement.RoleIDs) {
+			if roleID == userRoleID {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func announcementStatus(enabled bool) int {
+	if enabled {
+		return entity.AnnouncementStatusEnabled
+	}
+	return entity.AnnouncementStatusDisabled
+}
+
+func joinRoleIDs(audience string, roleIDs []int) string {
+	if audience != entity.AnnouncementAudienceRoles {
+		return ""
+	}
+	ids := make([]string, 0, len(roleIDs))
+	for _, roleID := range roleIDs {
+		ids = append(ids, strconv.Itoa(roleID))
+	}
+	return strings.Join(ids, ",")
+}
+
+func splitRoleIDs(roleIDs string) []int {
+	ids := make([]int, 0)
+	for _, id := range strings.Split(roleIDs, ",") {
+		if roleID := converter.StringToInt(id); roleID > 0 {
+			ids = append(ids, roleID)
+		}
+	}
+	return ids
+}
+
+func unixToTime(t int64) time.Time {
+	if t <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(t, 0)
+}
+
+func timeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package announcement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAnnouncementRepo struct {
+	AnnouncementRepo
+	announcements []*entity.Announcement
+}
+
+func (r *testAnnouncementRepo) GetEnabledAnnouncementList(_ context.Context) ([]*entity.Announcement, error) {
+	return r.announcements, nil
+}
+
+func TestGetActiveAnnouncements(t *testing.T) {
+	now := time.Now()
+	repo := &testAnnouncementRepo{announcements: []*entity.Announcement{
+		{ID: 1, Audience: entity.AnnouncementAudienceAll},
+		{ID: 2, Audience: entity.AnnouncementAudienceAll, StartTime: now.Add(time.Hour)},
+		{ID: 3, Audience: entity.AnnouncementAudienceAll, EndTime: now.Add(-time.Hour)},
+		{ID: 4, Audience: entity.AnnouncementAudienceAll, StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		{ID: 5, Audience: entity.AnnouncementAudienceLoggedIn},
+		{ID: 6, Audience: entity.AnnouncementAudienceRoles, RoleIDs: "2,3"},
+	}}
+	as := NewAnnouncementService(repo, nil)
+
+	resp, err := as.GetActiveAnnouncements(context.TODO(), "")
+	assert.NoError(t, err)
+	ids := make([]int, 0)
+	for _, item := range resp {
+		ids = append(ids, item.ID)
+		assert.False(t, item.Dismissible)
+	}
+	assert.Equal(t, []int{1, 4}, ids)
+}
+
+func TestMatchAudience(t *testing.T) {
+	announcement := &entity.Announcement{Audience: entity.AnnouncementAudienceRoles, RoleIDs: "2,3"}
+	assert.True(t, matchAudience(announcement, "1", 2))
+	assert.False(t, matchAudience(announcement, "1", 1))
+	assert.False(t, matchAudience(announcement, "", 0))
+
+	announcement = &entity.Announcement{Audience: entity.AnnouncementAudienceLoggedIn}
+	assert.True(t, matchAudience(announcement, "1", 1))
+	assert.False(t, matchAudience(announcement, "", 0))
+}
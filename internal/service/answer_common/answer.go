@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-answer/pkg/uid"
 )
 
+//go:generate mockgen -source=./answer.go -destination=../mock/answer_repo_mock.go -package=mock
 type AnswerRepo interface {
 	AddAnswer(ctx context.Context, answer *entity.Answer) (err error)
 	RemoveAnswer(ctx context.Context, id string) (err error)
@@ -38,7 +39,10 @@ type AnswerRepo interface {
 	GetAnswerList(ctx context.Context, answer *entity.Answer) (answerList []*entity.Answer, err error)
 	GetAnswerPage(ctx context.Context, page, pageSize int, answer *entity.Answer) (answerList []*entity.Answer, total int64, err error)
 	UpdateAcceptedStatus(ctx context.Context, acceptedAnswerID string, questionID string) error
+	UpdateAnswerAccepted(ctx context.Context, answerID string, accepted int) error
+	GetAcceptedAnswers(ctx context.Context, questionID string) (answerList []*entity.Answer, err error)
 	GetByID(ctx context.Context, answerID string) (*entity.Answer, bool, error)
+	GetAnswersByIDs(ctx context.Context, answerIDs []string) (answerList []*entity.Answer, err error)
 	GetCountByQuestionID(ctx context.Context, questionID string) (int64, error)
 	GetCountByUserID(ctx context.Context, userID string) (int64, error)
 	GetIDsByUserIDAndQuestionID(ctx context.Context, userID string, questionID string) ([]string, error)
@@ -104,6 +108,7 @@ func (as *AnswerCommon) ShowFormat(ctx context.Context, data *entity.Answer) *sc
 	info.HTML = data.ParsedText
 	info.Accepted = data.Accepted
 	info.VoteCount = data.VoteCount
+	info.OutdatedCount = data.OutdatedCount
 	info.CreateTime = data.CreatedAt.Unix()
 	info.UpdateTime = data.UpdatedAt.Unix()
 	if data.UpdatedAt.Unix() < 1 {
@@ -0,0 +1,196 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_quality
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AnswerQualityRepo answer quality repository
+type AnswerQualityRepo interface {
+	SaveEnvironments(ctx context.Context, answerID string, environments []*entity.AnswerEnvironment) (err error)
+	GetEnvironmentsByAnswerIDs(ctx context.Context, answerIDs []string) (environments []*entity.AnswerEnvironment, err error)
+	FlagOutdated(ctx context.Context, answerID, userID string) (err error)
+	CancelOutdatedFlag(ctx context.Context, answerID, userID string) (err error)
+	GetOutdatedFlaggedAnswerIDs(ctx context.Context, userID string, answerIDs []string) (flaggedIDs []string, err error)
+	ResetOutdatedFlags(ctx context.Context, answerID string) (err error)
+}
+
+// AnswerQualityService the versioned environments and the community outdated flags of the answers
+type AnswerQualityService struct {
+	answerQualityRepo AnswerQualityRepo
+	answerRepo        answercommon.AnswerRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+}
+
+// NewAnswerQualityService new answer quality service
+func NewAnswerQualityService(
+	answerQualityRepo AnswerQualityRepo,
+	answerRepo answercommon.AnswerRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *AnswerQualityService {
+	return &AnswerQualityService{
+		answerQualityRepo: answerQualityRepo,
+		answerRepo:        answerRepo,
+		siteInfoService:   siteInfoService,
+	}
+}
+
+// SaveEnvironments replace the environments of the answer
+func (as *AnswerQualityService) SaveEnvironments(ctx context.Context, answerID string,
+	environments []*schema.AnswerEnvironment) (err error) {
+	normalized := NormalizeEnvironments(environments)
+	list := make([]*entity.AnswerEnvironment, 0, len(normalized))
+	for _, environment := range normalized {
+		list = append(list, &entity.AnswerEnvironment{
+			Name:    environment.Name,
+			Version: environment.Version,
+		})
+	}
+	return as.answerQualityRepo.SaveEnvironments(ctx, uid.DeShortID(answerID), list)
+}
+
+// FlagOutdated flag the answer as outdated or cancel the flag
+func (as *AnswerQualityService) FlagOutdated(ctx context.Context, req *schema.FlagAnswerOutdatedReq) (
+	resp *schema.FlagAnswerOutdatedResp, err error) {
+	answerID := uid.DeShortID(req.AnswerID)
+	answerInfo, exist, err := as.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answerInfo.Status != entity.AnswerStatusAvailable {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+
+	if req.IsCancel {
+		err = as.answerQualityRepo.CancelOutdatedFlag(ctx, answerID, req.UserID)
+	} else {
+		err = as.answerQualityRepo.FlagOutdated(ctx, answerID, req.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	answerInfo, _, err = as.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	return &schema.FlagAnswerOutdatedResp{
+		OutdatedCount:   answerInfo.OutdatedCount,
+		Outdated:        answerInfo.OutdatedCount >= as.GetOutdatedThreshold(ctx),
+		OutdatedFlagged: !req.IsCancel,
+	}, nil
+}
+
+// ResetOutdatedFlags the answer is revised, so the outdated flags are no longer relevant
+func (as *AnswerQualityService) ResetOutdatedFlags(ctx context.Context, answerID string) {
+	if err := as.answerQualityRepo.ResetOutdatedFlags(ctx, uid.DeShortID(answerID)); err != nil {
+		log.Errorf("reset outdated flags of answer %s failed: %v", answerID, err)
+	}
+}
+
+// GetOutdatedThreshold get the number of the outdated flags which marks the answer as outdated
+func (as *AnswerQualityService) GetOutdatedThreshold(ctx context.Context) int {
+	siteWrite, err := as.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return schema.DefaultAnswerOutdatedThreshold
+	}
+	if siteWrite.AnswerOutdatedThreshold <= 0 {
+		return schema.DefaultAnswerOutdatedThreshold
+	}
+	return siteWrite.AnswerOutdatedThreshold
+}
+
+// FillAnswerQuality fill the environments and the outdated status of the answers
+func (as *AnswerQualityService) FillAnswerQuality(ctx context.Context, answers []*schema.AnswerInfo, userID string) {
+	if len(answers) == 0 {
+		return
+	}
+	answerIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		answerIDs = append(answerIDs, uid.DeShortID(answer.ID))
+	}
+
+	environmentMapping := make(map[string][]*schema.AnswerEnvironment)
+	environments, err := as.answerQualityRepo.GetEnvironmentsByAnswerIDs(ctx, answerIDs)
+	if err != nil {
+		log.Error(err)
+	}
+	for _, environment := range environments {
+		environmentMapping[environment.AnswerID] = append(environmentMapping[environment.AnswerID],
+			&schema.AnswerEnvironment{Name: environment.Name, Version: environment.Version})
+	}
+
+	flaggedMapping := make(map[string]bool)
+	flaggedIDs, err := as.answerQualityRepo.GetOutdatedFlaggedAnswerIDs(ctx, userID, answerIDs)
+	if err != nil {
+		log.Error(err)
+	}
+	for _, id := range flaggedIDs {
+		flaggedMapping[id] = true
+	}
+
+	threshold := as.GetOutdatedThreshold(ctx)
+	for i, answer := range answers {
+		answer.Environments = environmentMapping[answerIDs[i]]
+		if answer.Environments == nil {
+			answer.Environments = make([]*schema.AnswerEnvironment, 0)
+		}
+		answer.Outdated = answer.OutdatedCount >= threshold
+		answer.OutdatedFlagged = flaggedMapping[answerIDs[i]]
+	}
+}
+
+// NormalizeEnvironmentName the environment name is case-insensitive
+func NormalizeEnvironmentName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeEnvironments trim the environments and remove the duplicate ones
+func NormalizeEnvironments(environments []*schema.AnswerEnvironment) []*schema.AnswerEnvironment {
+	list := make([]*schema.AnswerEnvironment, 0, len(environments))
+	seen := make(map[schema.AnswerEnvironment]bool)
+	for _, environment := range environments {
+		if environment == nil {
+			continue
+		}
+		item := schema.AnswerEnvironment{
+			Name:    NormalizeEnvironmentName(environment.Name),
+			Version: strings.TrimSpace(environment.Version),
+		}
+		if len(item.Name) == 0 || seen[item] {
+			continue
+		}
+		seen[item] = true
+		list = append(list, &item)
+	}
+	return list
+}
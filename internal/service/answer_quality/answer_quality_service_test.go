@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_quality
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEnvironments(t *testing.T) {
+	list := NormalizeEnvironments([]*schema.AnswerEnvironment{
+		{Name: " Go ", Version: " 1.21 "},
+		{Name: "go", Version: "1.21"},
+		{Name: "GO", Version: "1.22"},
+		nil,
+		{Name: "  ", Version: "8.0"},
+		{Name: "MySQL"},
+	})
+	assert.Equal(t, []*schema.AnswerEnvironment{
+		{Name: "go", Version: "1.21"},
+		{Name: "go", Version: "1.22"},
+		{Name: "mysql", Version: ""},
+	}, list)
+
+	assert.Empty(t, NormalizeEnvironments(nil))
+}
@@ -0,0 +1,326 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_verification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// AnswerVerificationRepo answer verification repository
+type AnswerVerificationRepo interface {
+	AddTagExpert(ctx context.Context, expert *entity.TagExpert) (err error)
+	RemoveTagExpert(ctx context.Context, tagID, userID string) (err error)
+	GetTagExperts(ctx context.Context, tagID string) (experts []*entity.TagExpert, err error)
+	IsTagExpert(ctx context.Context, userID string, tagIDs []string) (isExpert bool, err error)
+	GetExpertUserIDsByTagIDs(ctx context.Context, tagIDs []string) (userIDs []string, err error)
+	SaveVerification(ctx context.Context, verification *entity.AnswerVerification) (err error)
+	RemoveVerification(ctx context.Context, answerID string) (err error)
+	GetVerificationsByAnswerIDs(ctx context.Context, answerIDs []string) (
+		verifications []*entity.AnswerVerification, err error)
+}
+
+// AnswerVerificationService the answers are verified by the experts designated for the tags of the question,
+// the verification is distinct from the acceptance by the asker
+type AnswerVerificationService struct {
+	answerVerificationRepo AnswerVerificationRepo
+	answerRepo             answercommon.AnswerRepo
+	tagCommon              *tagcommon.TagCommonService
+	userCommon             *usercommon.UserCommon
+	userRoleRelService     *role.UserRoleRelService
+	siteInfoRepo           siteinfo_common.SiteInfoRepo
+	siteInfoService        siteinfo_common.SiteInfoCommonService
+}
+
+// NewAnswerVerificationService new answer verification service
+func NewAnswerVerificationService(
+	answerVerificationRepo AnswerVerificationRepo,
+	answerRepo answercommon.AnswerRepo,
+	tagCommon *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *AnswerVerificationService {
+	return &AnswerVerificationService{
+		answerVerificationRepo: answerVerificationRepo,
+		answerRepo:             answerRepo,
+		tagCommon:              tagCommon,
+		userCommon:             userCommon,
+		userRoleRelService:     userRoleRelService,
+		siteInfoRepo:           siteInfoRepo,
+		siteInfoService:        siteInfoService,
+	}
+}
+
+// GetAnswerVerificationConfig get the answer verification config of the site
+func (as *AnswerVerificationService) GetAnswerVerificationConfig(ctx context.Context) (
+	resp *schema.SiteAnswerVerificationReq, err error) {
+	resp = &schema.SiteAnswerVerificationReq{}
+	if err = as.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeAnswerVerification, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateAnswerVerificationConfig update the answer verification config of the site
+func (as *AnswerVerificationService) UpdateAnswerVerificationConfig(ctx context.Context,
+	req *schema.SiteAnswerVerificationReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeAnswerVerification,
+		Content: string(content),
+	}
+	return as.siteInfoRepo.SaveByType(ctx, constant.SiteTypeAnswerVerification, data)
+}
+
+// GetTagExperts get the experts of the tag
+func (as *AnswerVerificationService) GetTagExperts(ctx context.Context, req *schema.GetTagExpertsReq) (
+	resp []*schema.TagExpertResp, err error) {
+	experts, err := as.answerVerificationRepo.GetTagExperts(ctx, req.TagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(experts))
+	for _, expert := range experts {
+		userIDs = append(userIDs, expert.UserID)
+	}
+	userMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagExpertResp, 0, len(experts))
+	for _, expert := range experts {
+		userInfo, ok := userMapping[expert.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.TagExpertResp{
+			CreatedAt: expert.CreatedAt.Unix(),
+			User:      userInfo,
+		})
+	}
+	return resp, nil
+}
+
+// AddTagExpert add the user to the experts of the tag
+func (as *AnswerVerificationService) AddTagExpert(ctx context.Context, req *schema.AddTagExpertReq) (err error) {
+	_, exist, err := as.tagCommon.GetTagByID(ctx, req.TagID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+	userInfo, exist, err := as.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	return as.answerVerificationRepo.AddTagExpert(ctx, &entity.TagExpert{
+		TagID:  req.TagID,
+		UserID: userInfo.ID,
+	})
+}
+
+// RemoveTagExpert remove the user from the experts of the tag
+func (as *AnswerVerificationService) RemoveTagExpert(ctx context.Context, req *schema.RemoveTagExpertReq) (
+	err error) {
+	return as.answerVerificationRepo.RemoveTagExpert(ctx, req.TagID, req.UserID)
+}
+
+// GetTagExpertUserIDs get the ids of the experts of any of the tags
+func (as *AnswerVerificationService) GetTagExpertUserIDs(ctx context.Context, tagIDs []string) (
+	userIDs []string, err error) {
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+	return as.answerVerificationRepo.GetExpertUserIDsByTagIDs(ctx, tagIDs)
+}
+
+// VerifyAnswer verify the answer by the expert of the question's tags, the verification of the answer
+// is replaced if it is verified again
+func (as *AnswerVerificationService) VerifyAnswer(ctx context.Context, req *schema.VerifyAnswerReq) (err error) {
+	answerInfo, err := as.getAvailableAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return err
+	}
+	if answerInfo.UserID == req.UserID {
+		return errors.BadRequest(reason.AnswerVerificationOwnAnswer)
+	}
+	isExpert, err := as.isQuestionExpert(ctx, req.UserID, answerInfo.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !isExpert {
+		return errors.Forbidden(reason.AnswerVerificationNotExpert)
+	}
+	return as.answerVerificationRepo.SaveVerification(ctx, &entity.AnswerVerification{
+		AnswerID:    answerInfo.ID,
+		QuestionID:  answerInfo.QuestionID,
+		UserID:      req.UserID,
+		ContentHash: contentHash(answerInfo.OriginalText),
+	})
+}
+
+// RemoveAnswerVerification remove the verification by the expert of the question's tags or the moderators
+func (as *AnswerVerificationService) RemoveAnswerVerification(ctx context.Context,
+	req *schema.RemoveAnswerVerificationReq) (err error) {
+	answerInfo, err := as.getAvailableAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return err
+	}
+	roleID, err := as.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	if roleID != role.RoleAdminID && roleID != role.RoleModeratorID {
+		isExpert, err := as.isQuestionExpert(ctx, req.UserID, answerInfo.QuestionID)
+		if err != nil {
+			return err
+		}
+		if !isExpert {
+			return errors.Forbidden(reason.AnswerVerificationNotExpert)
+		}
+	}
+	return as.answerVerificationRepo.RemoveVerification(ctx, answerInfo.ID)
+}
+
+// FillAnswerVerification fill the verification of the answers, the content of the answers is the original text
+func (as *AnswerVerificationService) FillAnswerVerification(ctx context.Context, answers []*schema.AnswerInfo) {
+	if len(answers) == 0 {
+		return
+	}
+	answerIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		answerIDs = append(answerIDs, uid.DeShortID(answer.ID))
+	}
+	verifications, err := as.answerVerificationRepo.GetVerificationsByAnswerIDs(ctx, answerIDs)
+	if err != nil {
+		log.Errorf("get answer verifications failed: %v", err)
+		return
+	}
+	if len(verifications) == 0 {
+		return
+	}
+	config, err := as.GetAnswerVerificationConfig(ctx)
+	if err != nil {
+		log.Errorf("get answer verification config failed: %v", err)
+		config = &schema.SiteAnswerVerificationReq{}
+	}
+
+	verificationMapping := make(map[string]*entity.AnswerVerification, len(verifications))
+	userIDs := make([]string, 0, len(verifications))
+	for _, verification := range verifications {
+		verificationMapping[verification.AnswerID] = verification
+		userIDs = append(userIDs, verification.UserID)
+	}
+	userMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		log.Errorf("get answer verifiers failed: %v", err)
+		return
+	}
+	now := time.Now()
+	for i, answer := range answers {
+		verification, ok := verificationMapping[answerIDs[i]]
+		if !ok {
+			continue
+		}
+		answer.Verification = formatVerification(verification, userMapping[verification.UserID],
+			contentHash(answer.Content), config, now)
+	}
+}
+
+// getAvailableAnswer get the answer which is not deleted, the ids of the answer are not short ids
+func (as *AnswerVerificationService) getAvailableAnswer(ctx context.Context, answerID string) (
+	answerInfo *entity.Answer, err error) {
+	answerInfo, exist, err := as.answerRepo.GetByID(ctx, uid.DeShortID(answerID))
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answerInfo.Status != entity.AnswerStatusAvailable {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+	answerInfo.ID = uid.DeShortID(answerInfo.ID)
+	answerInfo.QuestionID = uid.DeShortID(answerInfo.QuestionID)
+	return answerInfo, nil
+}
+
+// isQuestionExpert whether the user is the expert of any tag of the question or the main tag of the synonyms
+func (as *AnswerVerificationService) isQuestionExpert(ctx context.Context, userID, questionID string) (
+	isExpert bool, err error) {
+	tags, err := as.tagCommon.GetObjectEntityTag(ctx, questionID)
+	if err != nil {
+		return false, err
+	}
+	tagIDs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+		if tag.MainTagID > 0 {
+			tagIDs = append(tagIDs, fmt.Sprintf("%d", tag.MainTagID))
+		}
+	}
+	if len(tagIDs) == 0 {
+		return false, nil
+	}
+	return as.answerVerificationRepo.IsTagExpert(ctx, userID, tagIDs)
+}
+
+// formatVerification the verification is invalid if it is expired or the answer is edited after it
+func formatVerification(verification *entity.AnswerVerification, verifier *schema.UserBasicInfo,
+	currentHash string, config *schema.SiteAnswerVerificationReq, now time.Time) *schema.AnswerVerificationResp {
+	resp := &schema.AnswerVerificationResp{
+		Verifier:   verifier,
+		VerifiedAt: verification.CreatedAt.Unix(),
+		Edited:     verification.ContentHash != currentHash,
+	}
+	if config.ExpireDays > 0 {
+		expiresAt := verification.CreatedAt.AddDate(0, 0, config.ExpireDays)
+		resp.ExpiresAt = expiresAt.Unix()
+		resp.Expired = now.After(expiresAt)
+	}
+	resp.Valid = !resp.Expired && (!resp.Edited || config.KeepAfterEdit)
+	return resp
+}
+
+// contentHash the hash of the original text of the answer
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
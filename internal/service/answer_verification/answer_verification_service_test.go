@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package answer_verification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatVerification(t *testing.T) {
+	verifiedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	verification := &entity.AnswerVerification{CreatedAt: verifiedAt, ContentHash: contentHash("the answer")}
+	now := verifiedAt.AddDate(0, 0, 10)
+
+	resp := formatVerification(verification, nil, contentHash("the answer"), &schema.SiteAnswerVerificationReq{}, now)
+	assert.True(t, resp.Valid)
+	assert.Equal(t, int64(0), resp.ExpiresAt)
+	assert.Equal(t, verifiedAt.Unix(), resp.VerifiedAt)
+
+	resp = formatVerification(verification, nil, contentHash("the edited answer"),
+		&schema.SiteAnswerVerificationReq{}, now)
+	assert.True(t, resp.Edited)
+	assert.False(t, resp.Valid)
+
+	resp = formatVerification(verification, nil, contentHash("the edited answer"),
+		&schema.SiteAnswerVerificationReq{KeepAfterEdit: true}, now)
+	assert.True(t, resp.Edited)
+	assert.True(t, resp.Valid)
+
+	resp = formatVerification(verification, nil, contentHash("the answer"),
+		&schema.SiteAnswerVerificationReq{ExpireDays: 7}, now)
+	assert.True(t, resp.Expired)
+	assert.False(t, resp.Valid)
+	assert.Equal(t, verifiedAt.AddDate(0, 0, 7).Unix(), resp.ExpiresAt)
+
+	resp = formatVerification(verification, nil, contentHash("the answer"),
+		&schema.SiteAnswerVerificationReq{ExpireDays: 30}, now)
+	assert.False(t, resp.Expired)
+	assert.True(t, resp.Valid)
+}
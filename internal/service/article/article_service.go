@@ -0,0 +1,382 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package article
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ArticleRepo article repository
+type ArticleRepo interface {
+	AddArticle(ctx context.Context, article *entity.Article) (err error)
+	UpdateArticle(ctx context.Context, article *entity.Article, cols ...string) (err error)
+	UpdateArticleViewCount(ctx context.Context, id string) (err error)
+	GetArticle(ctx context.Context, id string) (article *entity.Article, exist bool, err error)
+	GetArticleBySourceAnswerID(ctx context.Context, answerID string) (article *entity.Article, exist bool, err error)
+	GetArticlePage(ctx context.Context, page, pageSize int, orderCond string) (
+		articles []*entity.Article, total int64, err error)
+	GetArticleQuestionIDs(ctx context.Context, articleID string) (questionIDs []string, err error)
+	SetArticleQuestionIDs(ctx context.Context, articleID string, questionIDs []string) (err error)
+	GetQuestionArticles(ctx context.Context, questionID string) (articles []*entity.Article, err error)
+}
+
+// ArticleService article service
+type ArticleService struct {
+	articleRepo        ArticleRepo
+	questionRepo       questioncommon.QuestionRepo
+	answerRepo         answercommon.AnswerRepo
+	revisionService    *revision_common.RevisionService
+	userCommon         *usercommon.UserCommon
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	featureFlagService *feature_flag.FeatureFlagService
+}
+
+// NewArticleService new article service
+func NewArticleService(
+	articleRepo ArticleRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	revisionService *revision_common.RevisionService,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	featureFlagService *feature_flag.FeatureFlagService,
+) *ArticleService {
+	return &ArticleService{
+		articleRepo:        articleRepo,
+		questionRepo:       questionRepo,
+		answerRepo:         answerRepo,
+		revisionService:    revisionService,
+		userCommon:         userCommon,
+		siteInfoService:    siteInfoService,
+		featureFlagService: featureFlagService,
+	}
+}
+
+// AddArticle add article
+func (as *ArticleService) AddArticle(ctx context.Context, req *schema.AddArticleReq) (
+	resp *schema.AddArticleResp, err error) {
+	if err = as.featureFlagService.CheckFeatureAvailable(ctx, schema.FeatureArticle, req.UserID); err != nil {
+		return nil, err
+	}
+	article := &entity.Article{
+		UserID:         req.UserID,
+		LastEditUserID: "0",
+		Title:          req.Title,
+		OriginalText:   req.Content,
+		ParsedText:     req.HTML,
+		Status:         entity.ArticleStatusAvailable,
+	}
+	if err = as.createArticle(ctx, article, req.QuestionIDs); err != nil {
+		return nil, err
+	}
+	return &schema.AddArticleResp{ID: as.formatID(ctx, article.ID)}, nil
+}
+
+// ConvertAnswerToArticle convert the well received answer into an article, the answerer is the author of the article
+func (as *ArticleService) ConvertAnswerToArticle(ctx context.Context, req *schema.ConvertAnswerToArticleReq) (
+	resp *schema.AddArticleResp, err error) {
+	if err = as.featureFlagService.CheckFeatureAvailable(ctx, schema.FeatureArticle, req.UserID); err != nil {
+		return nil, err
+	}
+	answer, exist, err := as.answerRepo.GetAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answer.Status != entity.AnswerStatusAvailable {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+	if !req.CanConvert && answer.UserID != req.UserID {
+		return nil, errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	question, exist, err := as.questionRepo.GetQuestion(ctx, answer.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+
+	// the answer has been converted before, just return the existing article
+	existArticle, exist, err := as.articleRepo.GetArticleBySourceAnswerID(ctx, answer.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return &schema.AddArticleResp{ID: as.formatID(ctx, existArticle.ID)}, nil
+	}
+
+	siteWrite, err := as.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if answer.VoteCount < siteWrite.ArticleMinAnswerVotes {
+		return nil, errors.BadRequest(reason.ArticleAnswerVotesNotEnough)
+	}
+
+	article := &entity.Article{
+		UserID:         answer.UserID,
+		LastEditUserID: "0",
+		Title:          question.Title,
+		OriginalText:   answer.OriginalText,
+		ParsedText:     answer.ParsedText,
+		Status:         entity.ArticleStatusAvailable,
+		SourceAnswerID: uid.DeShortID(answer.ID),
+	}
+	if len(req.Title) > 0 {
+		article.Title = req.Title
+	}
+	if answer.UserID != req.UserID {
+		article.LastEditUserID = req.UserID
+	}
+	if err = as.createArticle(ctx, article, []string{question.ID}); err != nil {
+		return nil, err
+	}
+	return &schema.AddArticleResp{ID: as.formatID(ctx, article.ID)}, nil
+}
+
+// UpdateArticle update article
+func (as *ArticleService) UpdateArticle(ctx context.Context, req *schema.UpdateArticleReq) (err error) {
+	article, err := as.getAvailableArticle(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !req.CanEdit && article.UserID != req.UserID {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	article.Title = req.Title
+	article.OriginalText = req.Content
+	article.ParsedText = req.HTML
+	article.LastEditUserID = req.UserID
+	article.UpdatedAt = time.Now()
+	if err = as.articleRepo.UpdateArticle(ctx, article,
+		"title", "original_text", "parsed_text", "last_edit_user_id", "updated_at"); err != nil {
+		return err
+	}
+	if err = as.setQuestions(ctx, article.ID, req.QuestionIDs); err != nil {
+		return err
+	}
+	return as.addRevision(ctx, article, req.UserID, req.EditSummary)
+}
+
+// RemoveArticle remove article
+func (as *ArticleService) RemoveArticle(ctx context.Context, req *schema.RemoveArticleReq) (err error) {
+	article, err := as.getAvailableArticle(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !req.CanDelete && article.UserID != req.UserID {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	article.Status = entity.ArticleStatusDeleted
+	return as.articleRepo.UpdateArticle(ctx, article, "status")
+}
+
+// GetArticle get article detail
+func (as *ArticleService) GetArticle(ctx context.Context, req *schema.GetArticleReq) (
+	resp *schema.ArticleInfoResp, err error) {
+	article, err := as.getAvailableArticle(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err = as.articleRepo.UpdateArticleViewCount(ctx, article.ID); err != nil {
+		log.Error(err)
+	}
+
+	resp = &schema.ArticleInfoResp{
+		ID:            as.formatID(ctx, article.ID),
+		Title:         article.Title,
+		URLTitle:      htmltext.UrlTitle(article.Title),
+		Content:       article.OriginalText,
+		HTML:          article.ParsedText,
+		ViewCount:     article.ViewCount,
+		CreatedAt:     article.CreatedAt.Unix(),
+		Questions:     make([]*schema.ArticleLinkedQuestion, 0),
+		MemberActions: permission.GetArticlePermission(ctx, req.UserID, article.UserID, req.CanManage),
+	}
+	if article.SourceAnswerID != "0" {
+		resp.SourceAnswerID = as.formatID(ctx, article.SourceAnswerID)
+	}
+	if !article.UpdatedAt.IsZero() {
+		resp.UpdatedAt = article.UpdatedAt.Unix()
+	}
+
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, []string{article.UserID, article.LastEditUserID})
+	if err != nil {
+		return nil, err
+	}
+	resp.UserInfo = userInfoMapping[article.UserID]
+	resp.LastEditUser = userInfoMapping[article.LastEditUserID]
+
+	questionIDs, err := as.articleRepo.GetArticleQuestionIDs(ctx, article.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(questionIDs) == 0 {
+		return resp, nil
+	}
+	questions, err := as.questionRepo.FindByID(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		if question.Status == entity.QuestionStatusDeleted || question.Show == entity.QuestionHide {
+			continue
+		}
+		resp.Questions = append(resp.Questions, &schema.ArticleLinkedQuestion{
+			ID:       question.ID,
+			Title:    question.Title,
+			URLTitle: htmltext.UrlTitle(question.Title),
+		})
+	}
+	return resp, nil
+}
+
+// GetArticlePage get article page
+func (as *ArticleService) GetArticlePage(ctx context.Context, req *schema.GetArticlePageReq) (
+	resp *pager.PageModel, err error) {
+	articles, total, err := as.articleRepo.GetArticlePage(ctx, req.Page, req.PageSize, req.OrderCond)
+	if err != nil {
+		return nil, err
+	}
+	list, err := as.formatArticleList(ctx, articles)
+	if err != nil {
+		return nil, err
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// GetQuestionArticles get the articles linked to the question
+func (as *ArticleService) GetQuestionArticles(ctx context.Context, req *schema.GetQuestionArticlesReq) (
+	resp []*schema.ArticlePageResp, err error) {
+	articles, err := as.articleRepo.GetQuestionArticles(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	return as.formatArticleList(ctx, articles)
+}
+
+func (as *ArticleService) createArticle(ctx context.Context, article *entity.Article, questionIDs []string) (err error) {
+	if err = as.articleRepo.AddArticle(ctx, article); err != nil {
+		return err
+	}
+	if err = as.setQuestions(ctx, article.ID, questionIDs); err != nil {
+		return err
+	}
+	return as.addRevision(ctx, article, article.UserID, "")
+}
+
+// setQuestions link the article to the questions, the questions which do not exist are ignored
+func (as *ArticleService) setQuestions(ctx context.Context, articleID string, questionIDs []string) (err error) {
+	ids := make([]string, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		ids = append(ids, uid.DeShortID(id))
+	}
+	linkedIDs := make([]string, 0, len(ids))
+	if len(ids) > 0 {
+		questions, err := as.questionRepo.FindByID(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, question := range questions {
+			if question.Status != entity.QuestionStatusDeleted {
+				linkedIDs = append(linkedIDs, question.ID)
+			}
+		}
+	}
+	return as.articleRepo.SetArticleQuestionIDs(ctx, articleID, linkedIDs)
+}
+
+func (as *ArticleService) addRevision(ctx context.Context, article *entity.Article, userID, editSummary string) (err error) {
+	infoJSON, _ := json.Marshal(article)
+	_, err = as.revisionService.AddRevision(ctx, &schema.AddRevisionDTO{
+		UserID:   userID,
+		ObjectID: article.ID,
+		Title:    article.Title,
+		Content:  string(infoJSON),
+		Log:      editSummary,
+	}, true)
+	return err
+}
+
+func (as *ArticleService) getAvailableArticle(ctx context.Context, id string) (article *entity.Article, err error) {
+	article, exist, err := as.articleRepo.GetArticle(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || article.Status != entity.ArticleStatusAvailable {
+		return nil, errors.NotFound(reason.ArticleNotFound)
+	}
+	return article, nil
+}
+
+func (as *ArticleService) formatArticleList(ctx context.Context, articles []*entity.Article) (
+	list []*schema.ArticlePageResp, err error) {
+	userIDs := make([]string, 0, len(articles))
+	for _, article := range articles {
+		userIDs = append(userIDs, article.UserID)
+	}
+	userInfoMapping, err := as.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	list = make([]*schema.ArticlePageResp, 0, len(articles))
+	for _, article := range articles {
+		item := &schema.ArticlePageResp{
+			ID:          as.formatID(ctx, article.ID),
+			Title:       article.Title,
+			URLTitle:    htmltext.UrlTitle(article.Title),
+			Description: htmltext.FetchExcerpt(article.ParsedText, "...", 240),
+			ViewCount:   article.ViewCount,
+			CreatedAt:   article.CreatedAt.Unix(),
+			UserInfo:    userInfoMapping[article.UserID],
+		}
+		if !article.UpdatedAt.IsZero() {
+			item.UpdatedAt = article.UpdatedAt.Unix()
+		}
+		list = append(list, item)
+	}
+	return list, nil
+}
+
+func (as *ArticleService) formatID(ctx context.Context, id string) string {
+	if handler.GetEnableShortID(ctx) {
+		return uid.EnShortID(id)
+	}
+	return id
+}
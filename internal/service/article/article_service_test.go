@@ -0,0 +1,342 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package article
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testArticleRepo struct {
+	ArticleRepo
+	articles    map[string]*entity.Article
+	questionIDs map[string][]string
+}
+
+func (r *testArticleRepo) AddArticle(_ context.Context, article *entity.Article) error {
+	article.ID = fmt.Sprintf("1009%013d", len(r.articles)+1)
+	r.articles[article.ID] = article
+	return nil
+}
+
+func (r *testArticleRepo) UpdateArticle(_ context.Context, article *entity.Article, _ ...string) error {
+	r.articles[article.ID] = article
+	return nil
+}
+
+func (r *testArticleRepo) UpdateArticleViewCount(context.Context, string) error {
+	return nil
+}
+
+func (r *testArticleRepo) GetArticle(_ context.Context, id string) (*entity.Article, bool, error) {
+	article, ok := r.articles[uid.DeShortID(id)]
+	if !ok {
+		return nil, false, nil
+	}
+	// the service changes the returned article
+	copied := *article
+	return &copied, true, nil
+}
+
+func (r *testArticleRepo) GetArticleBySourceAnswerID(_ context.Context, answerID string) (
+	*entity.Article, bool, error) {
+	for _, article := range r.articles {
+		if article.SourceAnswerID == uid.DeShortID(answerID) && article.Status == entity.ArticleStatusAvailable {
+			return article, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *testArticleRepo) GetArticleQuestionIDs(_ context.Context, articleID string) ([]string, error) {
+	return r.questionIDs[articleID], nil
+}
+
+func (r *testArticleRepo) SetArticleQuestionIDs(_ context.Context, articleID string, questionIDs []string) error {
+	ids := make([]string, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		ids = append(ids, uid.DeShortID(id))
+	}
+	r.questionIDs[uid.DeShortID(articleID)] = ids
+	return nil
+}
+
+type testRevisionRepo struct {
+	revision.RevisionRepo
+	revisions []*entity.Revision
+}
+
+func (r *testRevisionRepo) AddRevision(_ context.Context, rev *entity.Revision, _ bool) error {
+	r.revisions = append(r.revisions, rev)
+	return nil
+}
+
+// testSiteSettings the site settings returned by the site info service, the tests change them
+type testSiteSettings struct {
+	flags     *schema.SiteFeatureFlagsResp
+	siteWrite *schema.SiteWriteResp
+}
+
+const (
+	testQuestionID        = "10010000000000001"
+	testDeletedQuestionID = "10010000000000002"
+	testAnswerID          = "10020000000000001"
+)
+
+func newTestArticleService(ctl *gomock.Controller) (*ArticleService, *testArticleRepo, *testRevisionRepo,
+	*testSiteSettings) {
+	articleRepo := &testArticleRepo{
+		articles:    make(map[string]*entity.Article),
+		questionIDs: make(map[string][]string),
+	}
+
+	// the question and the answer repositories return the short ids like the real ones do
+	questions := map[string]*entity.Question{
+		testQuestionID: {ID: testQuestionID, UserID: "1", Title: "How to write an article",
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow},
+		testDeletedQuestionID: {ID: testDeletedQuestionID, UserID: "1", Title: "Deleted question",
+			Status: entity.QuestionStatusDeleted, Show: entity.QuestionShow},
+	}
+	getQuestion := func(ctx context.Context, id string) (*entity.Question, bool, error) {
+		question, ok := questions[uid.DeShortID(id)]
+		if !ok {
+			return nil, false, nil
+		}
+		copied := *question
+		if handler.GetEnableShortID(ctx) {
+			copied.ID = uid.EnShortID(copied.ID)
+		}
+		return &copied, true, nil
+	}
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(getQuestion)
+	questionRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, ids []string) ([]*entity.Question, error) {
+			questionList := make([]*entity.Question, 0)
+			for _, id := range ids {
+				if question, ok, _ := getQuestion(ctx, id); ok {
+					questionList = append(questionList, question)
+				}
+			}
+			return questionList, nil
+		})
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetAnswer(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, id string) (*entity.Answer, bool, error) {
+			if uid.DeShortID(id) != testAnswerID {
+				return nil, false, nil
+			}
+			answer := &entity.Answer{ID: testAnswerID, QuestionID: testQuestionID, UserID: "2", OriginalText: "answer",
+				ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable, VoteCount: 5}
+			if handler.GetEnableShortID(ctx) {
+				answer.ID = uid.EnShortID(answer.ID)
+				answer.QuestionID = uid.EnShortID(answer.QuestionID)
+			}
+			return answer, true, nil
+		})
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().BatchGetByID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []string) ([]*entity.User, error) {
+			users := make([]*entity.User, 0, len(ids))
+			for _, id := range ids {
+				users = append(users, &entity.User{ID: id, Username: "user" + id, Status: entity.UserStatusAvailable})
+			}
+			return users, nil
+		})
+
+	settings := &testSiteSettings{
+		flags:     &schema.SiteFeatureFlagsResp{},
+		siteWrite: &schema.SiteWriteResp{ArticleMinAnswerVotes: 5},
+	}
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteInfoByType(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _ string, resp interface{}) error {
+			content, _ := json.Marshal(settings.flags)
+			return json.Unmarshal(content, resp)
+		})
+	siteInfoService.EXPECT().GetSiteWrite(gomock.Any()).AnyTimes().
+		DoAndReturn(func(context.Context) (*schema.SiteWriteResp, error) {
+			return settings.siteWrite, nil
+		})
+	siteInfoService.EXPECT().FormatListAvatar(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userList []*entity.User) map[string]*schema.AvatarInfo {
+			mapping := make(map[string]*schema.AvatarInfo)
+			for _, user := range userList {
+				mapping[user.ID] = &schema.AvatarInfo{}
+			}
+			return mapping
+		})
+
+	revisionRepo := &testRevisionRepo{}
+	as := NewArticleService(articleRepo, questionRepo, answerRepo,
+		revision_common.NewRevisionService(revisionRepo, nil),
+		usercommon.NewUserCommon(userRepo, nil, nil, siteInfoService),
+		siteInfoService,
+		feature_flag.NewFeatureFlagService(nil, siteInfoService, nil))
+	return as, articleRepo, revisionRepo, settings
+}
+
+func assertReason(t *testing.T, wantReason string, err error) {
+	if assert.Error(t, err) {
+		assert.Equal(t, wantReason, err.(*errors.Error).Reason)
+	}
+}
+
+func TestAddArticle(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	as, articleRepo, revisionRepo, settings := newTestArticleService(ctl)
+	req := &schema.AddArticleReq{
+		Title:   "The article title",
+		Content: "article content",
+		HTML:    "<p>article content</p>",
+		// the deleted and the missing questions are not linked
+		QuestionIDs: []string{uid.EnShortID(testQuestionID), testDeletedQuestionID, "10010000000000099"},
+		UserID:      "1",
+	}
+
+	settings.flags.Flags = []*schema.FeatureFlag{{Key: schema.FeatureArticle, Enabled: false}}
+	_, err := as.AddArticle(context.TODO(), req)
+	assertReason(t, reason.FeatureDisabled, err)
+	assert.Empty(t, articleRepo.articles)
+
+	settings.flags.Flags = nil
+	resp, err := as.AddArticle(context.TODO(), req)
+	require.NoError(t, err)
+	article := articleRepo.articles[resp.ID]
+	require.NotNil(t, article)
+	assert.Equal(t, "1", article.UserID)
+	assert.Equal(t, "0", article.LastEditUserID)
+	assert.Equal(t, "The article title", article.Title)
+	assert.Equal(t, entity.ArticleStatusAvailable, article.Status)
+	assert.Equal(t, []string{testQuestionID}, articleRepo.questionIDs[resp.ID])
+	require.Len(t, revisionRepo.revisions, 1)
+	assert.Equal(t, resp.ID, revisionRepo.revisions[0].ObjectID)
+	assert.Equal(t, "1", revisionRepo.revisions[0].UserID)
+}
+
+func TestUpdateArticle(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	as, articleRepo, revisionRepo, _ := newTestArticleService(ctl)
+	resp, err := as.AddArticle(context.TODO(), &schema.AddArticleReq{
+		Title: "The article title", Content: "article content", QuestionIDs: []string{testQuestionID}, UserID: "1"})
+	require.NoError(t, err)
+
+	req := &schema.UpdateArticleReq{
+		ID:          resp.ID,
+		Title:       "The new article title",
+		Content:     "new content",
+		HTML:        "<p>new content</p>",
+		EditSummary: "fix typo",
+		UserID:      "2",
+	}
+	err = as.UpdateArticle(context.TODO(), req)
+	assertReason(t, reason.RankFailToMeetTheCondition, err)
+
+	req.CanEdit = true
+	require.NoError(t, as.UpdateArticle(context.TODO(), req))
+	article := articleRepo.articles[resp.ID]
+	assert.Equal(t, "The new article title", article.Title)
+	assert.Equal(t, "<p>new content</p>", article.ParsedText)
+	assert.Equal(t, "2", article.LastEditUserID)
+	assert.Equal(t, "1", article.UserID)
+	assert.Empty(t, articleRepo.questionIDs[resp.ID])
+	require.Len(t, revisionRepo.revisions, 2)
+	assert.Equal(t, "2", revisionRepo.revisions[1].UserID)
+	assert.Equal(t, "fix typo", revisionRepo.revisions[1].Log)
+
+	// the deleted article can not be edited
+	require.NoError(t, as.RemoveArticle(context.TODO(), &schema.RemoveArticleReq{ID: resp.ID, UserID: "1"}))
+	err = as.UpdateArticle(context.TODO(), req)
+	assertReason(t, reason.ArticleNotFound, err)
+}
+
+func TestConvertAnswerToArticle(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	as, articleRepo, _, settings := newTestArticleService(ctl)
+	ctx := context.WithValue(context.TODO(), constant.ShortIDFlag, true)
+	shortAnswerID := uid.EnShortID(testAnswerID)
+
+	_, err := as.ConvertAnswerToArticle(ctx, &schema.ConvertAnswerToArticleReq{AnswerID: shortAnswerID, UserID: "3"})
+	assertReason(t, reason.RankFailToMeetTheCondition, err)
+
+	settings.siteWrite.ArticleMinAnswerVotes = 6
+	_, err = as.ConvertAnswerToArticle(ctx, &schema.ConvertAnswerToArticleReq{AnswerID: shortAnswerID, UserID: "2"})
+	assertReason(t, reason.ArticleAnswerVotesNotEnough, err)
+
+	settings.siteWrite.ArticleMinAnswerVotes = 5
+	resp, err := as.ConvertAnswerToArticle(ctx, &schema.ConvertAnswerToArticleReq{
+		AnswerID: shortAnswerID, UserID: "3", CanConvert: true})
+	require.NoError(t, err)
+	articleID := uid.DeShortID(resp.ID)
+	article := articleRepo.articles[articleID]
+	require.NotNil(t, article)
+	// the source answer is stored with the long id even if the short id is enabled
+	assert.Equal(t, testAnswerID, article.SourceAnswerID)
+	assert.Equal(t, "2", article.UserID)
+	assert.Equal(t, "3", article.LastEditUserID)
+	assert.Equal(t, "How to write an article", article.Title)
+	assert.Equal(t, []string{testQuestionID}, articleRepo.questionIDs[articleID])
+
+	// the answer is converted only once
+	again, err := as.ConvertAnswerToArticle(ctx, &schema.ConvertAnswerToArticleReq{AnswerID: shortAnswerID, UserID: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, resp.ID, again.ID)
+	assert.Len(t, articleRepo.articles, 1)
+}
+
+func TestGetArticleShortID(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	as, _, _, _ := newTestArticleService(ctl)
+	ctx := context.WithValue(context.TODO(), constant.ShortIDFlag, true)
+	resp, err := as.ConvertAnswerToArticle(ctx, &schema.ConvertAnswerToArticleReq{
+		AnswerID: uid.EnShortID(testAnswerID), UserID: "2"})
+	require.NoError(t, err)
+
+	info, err := as.GetArticle(ctx, &schema.GetArticleReq{ID: resp.ID, UserID: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, resp.ID, info.ID)
+	// the ids are encoded only once
+	assert.Equal(t, uid.EnShortID(testAnswerID), info.SourceAnswerID)
+	require.Len(t, info.Questions, 1)
+	assert.Equal(t, uid.EnShortID(testQuestionID), info.Questions[0].ID)
+	assert.Equal(t, "user2", info.UserInfo.Username)
+}
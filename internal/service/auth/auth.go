@@ -21,10 +21,17 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
 
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
 )
 
 // AuthRepo auth repository
@@ -42,21 +49,40 @@ type AuthRepo interface {
 	RemoveAdminUserCacheInfo(ctx context.Context, accessToken string) (err error)
 	AddUserTokenMapping(ctx context.Context, userID, accessToken string) (err error)
 	RemoveUserTokens(ctx context.Context, userID string, remainToken string)
+	GetUserSessionActiveMark(ctx context.Context, tokenHash string) (exist bool, err error)
+	SetUserSessionActiveMark(ctx context.Context, tokenHash string) (err error)
+	SetUserSessionRevoked(ctx context.Context, tokenHash string) (err error)
+	IsUserSessionRevoked(ctx context.Context, tokenHash string) (revoked bool, err error)
+}
+
+// UserSessionRepo user session repository
+type UserSessionRepo interface {
+	SaveUserSession(ctx context.Context, session *entity.UserSession) (err error)
+	GetUserSession(ctx context.Context, id int) (session *entity.UserSession, exist bool, err error)
+	GetUserSessions(ctx context.Context, userID string) (sessions []*entity.UserSession, err error)
+	RemoveUserSession(ctx context.Context, id int) (err error)
+	RemoveUserSessionByTokenHash(ctx context.Context, tokenHash string) (err error)
+	RemoveUserSessions(ctx context.Context, userID, exceptTokenHash string) (err error)
 }
 
 // AuthService kit service
 type AuthService struct {
-	authRepo AuthRepo
+	authRepo        AuthRepo
+	userSessionRepo UserSessionRepo
 }
 
 // NewAuthService email service
-func NewAuthService(authRepo AuthRepo) *AuthService {
+func NewAuthService(authRepo AuthRepo, userSessionRepo UserSessionRepo) *AuthService {
 	return &AuthService{
-		authRepo: authRepo,
+		authRepo:        authRepo,
+		userSessionRepo: userSessionRepo,
 	}
 }
 
 func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error) {
+	if as.checkSessionRevoked(ctx, accessToken) {
+		return nil, nil
+	}
 	userCacheInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
 	if err != nil {
 		return nil, err
@@ -64,6 +90,12 @@ func (as *AuthService) GetUserCacheInfo(ctx context.Context, accessToken string)
 	if userCacheInfo == nil {
 		return nil, nil
 	}
+	if userCacheInfo.ImpersonationExpiredAt > 0 && time.Now().Unix() > userCacheInfo.ImpersonationExpiredAt {
+		if err := as.authRepo.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+			log.Error(err)
+		}
+		return nil, nil
+	}
 	cacheInfo, _ := as.authRepo.GetUserStatus(ctx, userCacheInfo.UserID)
 	if cacheInfo != nil {
 		userCacheInfo.UserStatus = cacheInfo.UserStatus
@@ -108,6 +140,16 @@ func (as *AuthService) CheckUserVisitToken(ctx context.Context, visitToken strin
 	return true
 }
 
+// GetUserCacheInfoByVisitToken get the user info of the visit token, nil is returned if the token is invalid
+func (as *AuthService) GetUserCacheInfoByVisitToken(ctx context.Context, visitToken string) (
+	userInfo *entity.UserCacheInfo, err error) {
+	accessToken, err := as.authRepo.GetUserVisitCacheInfo(ctx, visitToken)
+	if err != nil || len(accessToken) == 0 {
+		return nil, err
+	}
+	return as.GetUserCacheInfo(ctx, accessToken)
+}
+
 func (as *AuthService) SetUserStatus(ctx context.Context, userInfo *entity.UserCacheInfo) (err error) {
 	return as.authRepo.SetUserStatus(ctx, userInfo.UserID, userInfo)
 }
@@ -131,16 +173,154 @@ func (as *AuthService) AddUserTokenMapping(ctx context.Context, userID, accessTo
 // RemoveUserAllTokens Log out all users under this user id
 func (as *AuthService) RemoveUserAllTokens(ctx context.Context, userID string) {
 	as.authRepo.RemoveUserTokens(ctx, userID, "")
+	if err := as.userSessionRepo.RemoveUserSessions(ctx, userID, ""); err != nil {
+		log.Error(err)
+	}
 }
 
 // RemoveTokensExceptCurrentUser remove all tokens except the current user
 func (as *AuthService) RemoveTokensExceptCurrentUser(ctx context.Context, userID string, accessToken string) {
 	as.authRepo.RemoveUserTokens(ctx, userID, accessToken)
+	if err := as.userSessionRepo.RemoveUserSessions(ctx, userID, hashAccessToken(accessToken)); err != nil {
+		log.Error(err)
+	}
+}
+
+// ClearPasswordExpired clear the password expired mark of current token after the user changed the password
+func (as *AuthService) ClearPasswordExpired(ctx context.Context, accessToken string) (err error) {
+	userCacheInfo, err := as.authRepo.GetUserCacheInfo(ctx, accessToken)
+	if err != nil {
+		return err
+	}
+	if userCacheInfo == nil || !userCacheInfo.PasswordExpired {
+		return nil
+	}
+	userCacheInfo.PasswordExpired = false
+	return as.authRepo.SetUserCacheInfo(ctx, accessToken, userCacheInfo.VisitToken, userCacheInfo)
+}
+
+// TouchUserSession record the device info and the last active time of the session.
+// To avoid writing database on every request, it only updates once in a while.
+func (as *AuthService) TouchUserSession(ctx context.Context, userID, accessToken, userAgent, ip string) {
+	tokenHash := hashAccessToken(accessToken)
+	marked, err := as.authRepo.GetUserSessionActiveMark(ctx, tokenHash)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if marked {
+		return
+	}
+	if len(userAgent) > 512 {
+		userAgent = userAgent[:512]
+	}
+	err = as.userSessionRepo.SaveUserSession(ctx, &entity.UserSession{
+		UserID:       userID,
+		TokenHash:    tokenHash,
+		UserAgent:    userAgent,
+		IP:           ip,
+		LastActiveAt: time.Now(),
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if err = as.authRepo.SetUserSessionActiveMark(ctx, tokenHash); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetUserSessions get the login sessions of user
+func (as *AuthService) GetUserSessions(ctx context.Context, req *schema.GetUserSessionsReq) (
+	resp []*schema.GetUserSessionResp, err error) {
+	sessions, err := as.userSessionRepo.GetUserSessions(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	currentTokenHash := hashAccessToken(req.AccessToken)
+	resp = make([]*schema.GetUserSessionResp, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, &schema.GetUserSessionResp{
+			ID:           session.ID,
+			UserAgent:    session.UserAgent,
+			IP:           session.IP,
+			CreatedAt:    session.CreatedAt.Unix(),
+			LastActiveAt: session.LastActiveAt.Unix(),
+			IsCurrent:    session.TokenHash == currentTokenHash,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeUserSession log out the session of user remotely
+func (as *AuthService) RevokeUserSession(ctx context.Context, req *schema.RevokeUserSessionReq) (err error) {
+	session, exist, err := as.userSessionRepo.GetUserSession(ctx, req.SessionID)
+	if err != nil {
+		return err
+	}
+	if !exist || session.UserID != req.UserID {
+		return errors.BadRequest(reason.UserSessionNotFound)
+	}
+	if err = as.authRepo.SetUserSessionRevoked(ctx, session.TokenHash); err != nil {
+		return err
+	}
+	return as.userSessionRepo.RemoveUserSession(ctx, session.ID)
+}
+
+// RevokeOtherUserSessions log out all the sessions of user except the current one
+func (as *AuthService) RevokeOtherUserSessions(ctx context.Context, req *schema.RevokeOtherUserSessionsReq) (err error) {
+	sessions, err := as.userSessionRepo.GetUserSessions(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	currentTokenHash := hashAccessToken(req.AccessToken)
+	for _, session := range sessions {
+		if session.TokenHash == currentTokenHash {
+			continue
+		}
+		if err = as.authRepo.SetUserSessionRevoked(ctx, session.TokenHash); err != nil {
+			return err
+		}
+	}
+	as.RemoveTokensExceptCurrentUser(ctx, req.UserID, req.AccessToken)
+	return nil
+}
+
+// RemoveUserSession remove the session record when user logout
+func (as *AuthService) RemoveUserSession(ctx context.Context, accessToken string) (err error) {
+	return as.userSessionRepo.RemoveUserSessionByTokenHash(ctx, hashAccessToken(accessToken))
+}
+
+// checkSessionRevoked check whether the session is revoked, if so, remove the token cache
+func (as *AuthService) checkSessionRevoked(ctx context.Context, accessToken string) bool {
+	revoked, err := as.authRepo.IsUserSessionRevoked(ctx, hashAccessToken(accessToken))
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if !revoked {
+		return false
+	}
+	if err = as.authRepo.RemoveUserCacheInfo(ctx, accessToken); err != nil {
+		log.Error(err)
+	}
+	if err = as.authRepo.RemoveAdminUserCacheInfo(ctx, accessToken); err != nil {
+		log.Error(err)
+	}
+	return true
+}
+
+func hashAccessToken(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return hex.EncodeToString(hash[:])
 }
 
 //Admin
 
 func (as *AuthService) GetAdminUserCacheInfo(ctx context.Context, accessToken string) (userInfo *entity.UserCacheInfo, err error) {
+	if as.checkSessionRevoked(ctx, accessToken) {
+		return nil, nil
+	}
 	return as.authRepo.GetAdminUserCacheInfo(ctx, accessToken)
 }
 
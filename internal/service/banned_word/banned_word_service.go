@@ -0,0 +1,322 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package banned_word
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// BannedWordHitRepo banned word hit repository
+type BannedWordHitRepo interface {
+	AddBannedWordHits(ctx context.Context, hits []*entity.BannedWordHit) (err error)
+	GetBannedWordHitPage(ctx context.Context, page, pageSize int, cond *entity.BannedWordHit) (
+		hits []*entity.BannedWordHit, total int64, err error)
+}
+
+// BannedWordService check the content and the user name against the banned word rules of the site
+type BannedWordService struct {
+	bannedWordHitRepo BannedWordHitRepo
+	siteInfoRepo      siteinfo_common.SiteInfoRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+
+	lock     sync.Mutex
+	compiled *compiledRules
+}
+
+// compiledRules the rules are compiled again only when the rules of the site are changed
+type compiledRules struct {
+	key   string
+	rules []*rule
+}
+
+type rule struct {
+	*schema.BannedWordRule
+	re *regexp.Regexp
+}
+
+// NewBannedWordService new banned word service
+func NewBannedWordService(
+	bannedWordHitRepo BannedWordHitRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *BannedWordService {
+	return &BannedWordService{
+		bannedWordHitRepo: bannedWordHitRepo,
+		siteInfoRepo:      siteInfoRepo,
+		siteInfoService:   siteInfoService,
+	}
+}
+
+// GetBannedWords get the banned word rules
+func (bs *BannedWordService) GetBannedWords(ctx context.Context) (resp *schema.SiteBannedWordsReq, err error) {
+	resp = &schema.SiteBannedWordsReq{}
+	if err = bs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeBannedWords, resp); err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		resp.Rules = make([]*schema.BannedWordRule, 0)
+	}
+	return resp, nil
+}
+
+// UpdateBannedWords update the banned word rules
+func (bs *BannedWordService) UpdateBannedWords(ctx context.Context, req *schema.SiteBannedWordsReq) (err error) {
+	for _, item := range req.Rules {
+		item.Word = strings.TrimSpace(item.Word)
+		if _, err = compileRule(item); err != nil {
+			return errors.BadRequest(reason.BannedWordRegexInvalid).WithMsg(
+				fmt.Sprintf("%s: %s", item.Word, err.Error()))
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeBannedWords,
+		Content: string(content),
+	}
+	return bs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeBannedWords, data)
+}
+
+// GetBannedWordHitPage get the contents and the user names which trigger the rules
+func (bs *BannedWordService) GetBannedWordHitPage(ctx context.Context, req *schema.GetBannedWordHitPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.BannedWordHit{ObjectType: req.ObjectType, Action: req.Action}
+	hits, total, err := bs.bannedWordHitRepo.GetBannedWordHitPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.BannedWordHitResp, 0, len(hits))
+	for _, hit := range hits {
+		resp = append(resp, &schema.BannedWordHitResp{
+			ID:         hit.ID,
+			CreatedAt:  hit.CreatedAt.Unix(),
+			Word:       hit.Word,
+			Action:     hit.Action,
+			ObjectType: hit.ObjectType,
+			ObjectID:   hit.ObjectID,
+			UserID:     hit.UserID,
+			Matched:    hit.Matched,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// FilterContent apply the rules to the texts of the content, the words of the replace rules are replaced in place.
+// The content is rejected by the reject rules. If the content is reviewable, the review rules are left to the review,
+// otherwise they reject the content as well.
+func (bs *BannedWordService) FilterContent(ctx context.Context, objectType, userID string, reviewable bool,
+	texts ...*string) (err error) {
+	rules, err := bs.getRules(ctx)
+	if err != nil || len(rules) == 0 {
+		return err
+	}
+
+	hits := make([]*entity.BannedWordHit, 0)
+	rejected := ""
+	for _, r := range rules {
+		if r.Action == schema.BannedWordActionReview && reviewable {
+			continue
+		}
+		for _, text := range texts {
+			matched := r.re.FindString(*text)
+			if len(matched) == 0 {
+				continue
+			}
+			if r.Action == schema.BannedWordActionReplace {
+				hits = append(hits, newHit(r, schema.BannedWordActionReplace, objectType, "", userID, matched))
+				*text = r.replace(*text)
+				continue
+			}
+			hits = append(hits, newHit(r, schema.BannedWordActionReject, objectType, "", userID, matched))
+			if len(rejected) == 0 {
+				rejected = matched
+			}
+		}
+	}
+	bs.addHits(ctx, hits)
+	if len(rejected) > 0 {
+		return errors.BadRequest(reason.BannedWordRejected).WithMsg(bs.rejectedMsg(ctx, rejected))
+	}
+	return nil
+}
+
+// CheckReview check the new content against the review rules, the review reason is returned if the content
+// needs to be reviewed by the moderators
+func (bs *BannedWordService) CheckReview(ctx context.Context, objectType, objectID, userID string,
+	texts ...string) (reviewReason string) {
+	rules, err := bs.getRules(ctx)
+	if err != nil {
+		log.Errorf("get banned word rules failed: %v", err)
+		return ""
+	}
+
+	hits := make([]*entity.BannedWordHit, 0)
+	matchedWords := make([]string, 0)
+	for _, r := range rules {
+		if r.Action != schema.BannedWordActionReview {
+			continue
+		}
+		for _, text := range texts {
+			if matched := r.re.FindString(text); len(matched) > 0 {
+				hits = append(hits, newHit(r, schema.BannedWordActionReview, objectType, objectID, userID, matched))
+				matchedWords = append(matchedWords, fmt.Sprintf("%q", matched))
+				break
+			}
+		}
+	}
+	if len(hits) == 0 {
+		return ""
+	}
+	bs.addHits(ctx, hits)
+	return fmt.Sprintf("The content contains the banned words %s.", strings.Join(matchedWords, ", "))
+}
+
+// CheckUserName check the user name against all the rules, the user name is rejected by any matched rule
+func (bs *BannedWordService) CheckUserName(ctx context.Context, field, name, userID string) (
+	errFields []*validator.FormErrorField, err error) {
+	rules, err := bs.getRules(ctx)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	for _, r := range rules {
+		matched := r.re.FindString(name)
+		if len(matched) == 0 {
+			continue
+		}
+		bs.addHits(ctx, []*entity.BannedWordHit{
+			newHit(r, schema.BannedWordActionReject, entity.BannedWordObjectTypeUser, userID, userID, matched),
+		})
+		msg := bs.rejectedMsg(ctx, matched)
+		errFields = append(errFields, &validator.FormErrorField{ErrorField: field, ErrorMsg: msg})
+		return errFields, errors.BadRequest(reason.BannedWordRejected).WithMsg(msg)
+	}
+	return nil, nil
+}
+
+// getRules get the compiled rules, the rule which can't be compiled is ignored
+func (bs *BannedWordService) getRules(ctx context.Context) (rules []*rule, err error) {
+	setting, err := bs.GetBannedWords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var key strings.Builder
+	for _, item := range setting.Rules {
+		fmt.Fprintf(&key, "%t:%s:%s:%s\n", item.Regex, item.Action, item.Replacement, item.Word)
+	}
+
+	bs.lock.Lock()
+	defer bs.lock.Unlock()
+	if bs.compiled != nil && bs.compiled.key == key.String() {
+		return bs.compiled.rules, nil
+	}
+	rules = make([]*rule, 0, len(setting.Rules))
+	for _, item := range setting.Rules {
+		re, err := compileRule(item)
+		if err != nil {
+			log.Errorf("compile banned word %s failed: %v", item.Word, err)
+			continue
+		}
+		rules = append(rules, &rule{BannedWordRule: item, re: re})
+	}
+	bs.compiled = &compiledRules{key: key.String(), rules: rules}
+	return rules, nil
+}
+
+func (bs *BannedWordService) addHits(ctx context.Context, hits []*entity.BannedWordHit) {
+	if len(hits) == 0 {
+		return
+	}
+	if err := bs.bannedWordHitRepo.AddBannedWordHits(ctx, hits); err != nil {
+		log.Errorf("add banned word hits failed: %v", err)
+	}
+}
+
+func (bs *BannedWordService) rejectedMsg(ctx context.Context, matched string) string {
+	return translator.TrWithData(handler.GetLangByCtx(ctx), reason.BannedWordRejected,
+		&schema.BannedWordTrTplData{Word: matched})
+}
+
+// compileRule the plain word is matched case-insensitively, and the word boundary is required
+// at the end which is a letter or a digit, so "ass" doesn't match "class"
+func compileRule(item *schema.BannedWordRule) (*regexp.Regexp, error) {
+	if item.Regex {
+		return regexp.Compile(item.Word)
+	}
+	pattern := regexp.QuoteMeta(item.Word)
+	if isASCIIWordChar(item.Word[0]) {
+		pattern = `\b` + pattern
+	}
+	if isASCIIWordChar(item.Word[len(item.Word)-1]) {
+		pattern += `\b`
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func isASCIIWordChar(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// replace the matched words with the replacement, or the asterisks of the same length
+func (r *rule) replace(text string) string {
+	return r.re.ReplaceAllStringFunc(text, func(matched string) string {
+		if len(r.Replacement) > 0 {
+			return r.Replacement
+		}
+		return strings.Repeat("*", utf8.RuneCountInString(matched))
+	})
+}
+
+func newHit(r *rule, action, objectType, objectID, userID, matched string) *entity.BannedWordHit {
+	if len(objectID) == 0 {
+		objectID = "0"
+	}
+	if len(userID) == 0 {
+		userID = "0"
+	}
+	if utf8.RuneCountInString(matched) > 200 {
+		matched = string([]rune(matched)[:200])
+	}
+	return &entity.BannedWordHit{
+		Word:       r.Word,
+		Action:     action,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		UserID:     userID,
+		Matched:    matched,
+	}
+}
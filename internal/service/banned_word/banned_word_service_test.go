@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package banned_word
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileRule(t *testing.T) {
+	re, err := compileRule(&schema.BannedWordRule{Word: "spam"})
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("Buy SPAM now"))
+	assert.False(t, re.MatchString("spammer"))
+
+	re, err = compileRule(&schema.BannedWordRule{Word: "广告"})
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("这是广告内容"))
+
+	re, err = compileRule(&schema.BannedWordRule{Word: `free\s+money`, Regex: true})
+	assert.NoError(t, err)
+	assert.True(t, re.MatchString("get free   money"))
+
+	_, err = compileRule(&schema.BannedWordRule{Word: "(unclosed", Regex: true})
+	assert.Error(t, err)
+}
+
+func TestRuleReplace(t *testing.T) {
+	item := &schema.BannedWordRule{Word: "darn", Action: schema.BannedWordActionReplace}
+	re, _ := compileRule(item)
+	r := &rule{BannedWordRule: item, re: re}
+	assert.Equal(t, "oh **** it, **** again", r.replace("oh darn it, DARN again"))
+
+	item.Replacement = "[removed]"
+	assert.Equal(t, "oh [removed] it", r.replace("oh darn it"))
+}
@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package chat_integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// slackRequestMaxAge the slash command sent before it is rejected to prevent the replay
+const slackRequestMaxAge = 5 * time.Minute
+
+var (
+	teamsMentionRegexp = regexp.MustCompile(`(?s)<at>.*?</at>`)
+	htmlTagRegexp      = regexp.MustCompile(`<[^>]*>`)
+	slackEscaper       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	markdownEscaper    = strings.NewReplacer("[", "\\[", "]", "\\]")
+)
+
+// verifySlackSignature verify the X-Slack-Signature header, which is the hmac sha256 of
+// "v0:<timestamp>:<body>" signed with the signing secret
+func verifySlackSignature(secret, timestamp, signature string, body []byte, now time.Time) bool {
+	if len(secret) == 0 || !strings.HasPrefix(signature, "v0=") {
+		return false
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > slackRequestMaxAge || age < -slackRequestMaxAge {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// verifyTeamsSignature verify the Authorization header of the Teams outgoing webhook, which is "HMAC "
+// followed by the base64 hmac sha256 of the body signed with the base64 decoded security token
+func verifyTeamsSignature(securityToken, authorization string, body []byte) bool {
+	if len(securityToken) == 0 || !strings.HasPrefix(authorization, "HMAC ") {
+		return false
+	}
+	key, err := base64.StdEncoding.DecodeString(securityToken)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(authorization, "HMAC ")))
+}
+
+// cleanTeamsText remove the mention of the bot and the html tags from the text of the Teams message
+func cleanTeamsText(text string) string {
+	text = teamsMentionRegexp.ReplaceAllString(text, " ")
+	text = htmlTagRegexp.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(html.UnescapeString(text), "\u00a0", " ")
+	return strings.TrimSpace(text)
+}
+
+// formatSlackReply format the reply as the Slack blocks, it is only visible to the user who runs the command
+func formatSlackReply(reply *chatReply) *schema.SlackCommandResp {
+	resp := &schema.SlackCommandResp{
+		ResponseType: "ephemeral",
+		Text:         reply.Text,
+		Blocks: []*schema.SlackBlock{
+			{Type: "section", Text: &schema.SlackText{Type: "mrkdwn", Text: slackEscaper.Replace(reply.Text)}},
+		},
+	}
+	for _, item := range reply.Items {
+		text := fmt.Sprintf("*<%s|%s>*", item.URL, slackEscaper.Replace(item.Title))
+		if len(item.Excerpt) > 0 {
+			text += "\n" + slackEscaper.Replace(item.Excerpt)
+		}
+		resp.Blocks = append(resp.Blocks,
+			&schema.SlackBlock{Type: "section", Text: &schema.SlackText{Type: "mrkdwn", Text: text}},
+			&schema.SlackBlock{Type: "context", Elements: []*schema.SlackText{{Type: "mrkdwn", Text: item.Meta}}},
+		)
+	}
+	if reply.Action != nil {
+		resp.Blocks = append(resp.Blocks, &schema.SlackBlock{Type: "section", Text: &schema.SlackText{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("<%s|%s>", reply.Action.URL, slackEscaper.Replace(reply.Action.Title)),
+		}})
+	}
+	return resp
+}
+
+// formatTeamsReply format the reply as the adaptive card
+func formatTeamsReply(reply *chatReply) *schema.TeamsCommandResp {
+	card := &schema.AdaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Body:    []*schema.AdaptiveCardText{{Type: "TextBlock", Text: reply.Text, Wrap: true}},
+	}
+	for _, item := range reply.Items {
+		card.Body = append(card.Body, &schema.AdaptiveCardText{
+			Type:   "TextBlock",
+			Text:   fmt.Sprintf("[%s](%s)", markdownEscaper.Replace(item.Title), item.URL),
+			Wrap:   true,
+			Weight: "bolder",
+		})
+		if len(item.Excerpt) > 0 {
+			card.Body = append(card.Body, &schema.AdaptiveCardText{Type: "TextBlock", Text: item.Excerpt, Wrap: true})
+		}
+		card.Body = append(card.Body, &schema.AdaptiveCardText{
+			Type: "TextBlock", Text: item.Meta, Wrap: true, IsSubtle: true})
+	}
+	if reply.Action != nil {
+		card.Actions = []*schema.AdaptiveCardAction{{Type: "Action.OpenUrl", Title: reply.Action.Title, URL: reply.Action.URL}}
+	}
+	return &schema.TeamsCommandResp{
+		Type: "message",
+		Text: reply.Text,
+		Attachments: []*schema.TeamsAttachment{
+			{ContentType: "application/vnd.microsoft.card.adaptive", Content: card},
+		},
+	}
+}
@@ -0,0 +1,253 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package chat_integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultResultLimit = 5
+	// maxQueryLength the max length of the search query, the same as the search api
+	maxQueryLength = 60
+
+	commandSearch = "search"
+	commandAsk    = "ask"
+)
+
+// ChatIntegrationService answer the slash commands of Slack and the messages of the Teams outgoing webhook
+type ChatIntegrationService struct {
+	searchService   *content.SearchService
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewChatIntegrationService new chat integration service
+func NewChatIntegrationService(
+	searchService *content.SearchService,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *ChatIntegrationService {
+	return &ChatIntegrationService{
+		searchService:   searchService,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// chatReply the reply to the command, it is formatted as the message of the chat platform
+type chatReply struct {
+	Text   string
+	Items  []*chatItem
+	Action *chatItem
+}
+
+// chatItem the link with the description shown in the reply
+type chatItem struct {
+	Title   string
+	URL     string
+	Excerpt string
+	Meta    string
+}
+
+// GetChatIntegration get the chat integration settings
+func (cs *ChatIntegrationService) GetChatIntegration(ctx context.Context) (
+	resp *schema.SiteChatIntegrationResp, err error) {
+	resp = &schema.SiteChatIntegrationResp{}
+	if err = cs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeChatIntegration, resp); err != nil {
+		return nil, err
+	}
+	if resp.ResultLimit == 0 {
+		resp.ResultLimit = defaultResultLimit
+	}
+	return resp, nil
+}
+
+// UpdateChatIntegration update the chat integration settings
+func (cs *ChatIntegrationService) UpdateChatIntegration(ctx context.Context,
+	req *schema.SiteChatIntegrationReq) (err error) {
+	if (req.SlackEnabled && len(req.SlackSigningSecret) == 0) || (req.TeamsEnabled && len(req.TeamsSecurityToken) == 0) {
+		return errors.BadRequest(reason.RequestFormatError)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeChatIntegration,
+		Content: string(content),
+	}
+	return cs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeChatIntegration, data)
+}
+
+// HandleSlackCommand verify and answer the slash command sent by Slack
+func (cs *ChatIntegrationService) HandleSlackCommand(ctx context.Context, req *schema.ChatSlackCommandReq) (
+	resp *schema.SlackCommandResp, err error) {
+	conf, err := cs.GetChatIntegration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.SlackEnabled {
+		return nil, errors.Forbidden(reason.ChatIntegrationDisabled)
+	}
+	if !verifySlackSignature(conf.SlackSigningSecret, req.Timestamp, req.Signature, req.Body, time.Now()) {
+		return nil, errors.Unauthorized(reason.ChatIntegrationSignatureInvalid)
+	}
+	form, err := url.ParseQuery(string(req.Body))
+	if err != nil {
+		return nil, errors.BadRequest(reason.RequestFormatError)
+	}
+	reply, err := cs.runCommand(ctx, form.Get("command"), form.Get("text"), conf.ResultLimit)
+	if err != nil {
+		return nil, err
+	}
+	return formatSlackReply(reply), nil
+}
+
+// HandleTeamsCommand verify and answer the message sent by the Teams outgoing webhook
+func (cs *ChatIntegrationService) HandleTeamsCommand(ctx context.Context, req *schema.ChatTeamsCommandReq) (
+	resp *schema.TeamsCommandResp, err error) {
+	conf, err := cs.GetChatIntegration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.TeamsEnabled {
+		return nil, errors.Forbidden(reason.ChatIntegrationDisabled)
+	}
+	if !verifyTeamsSignature(conf.TeamsSecurityToken, req.Authorization, req.Body) {
+		return nil, errors.Unauthorized(reason.ChatIntegrationSignatureInvalid)
+	}
+	activity := &struct {
+		Text string `json:"text"`
+	}{}
+	if err = json.Unmarshal(req.Body, activity); err != nil {
+		return nil, errors.BadRequest(reason.RequestFormatError)
+	}
+	reply, err := cs.runCommand(ctx, "@answer", cleanTeamsText(activity.Text), conf.ResultLimit)
+	if err != nil {
+		return nil, err
+	}
+	return formatTeamsReply(reply), nil
+}
+
+// runCommand run the command in the text, like "search kubernetes" or "ask How to ... | details",
+// the usage is replied for the unknown command
+func (cs *ChatIntegrationService) runCommand(ctx context.Context, trigger, text string, limit int) (
+	reply *chatReply, err error) {
+	siteInfo, err := cs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	command, args := parseCommand(text)
+	switch {
+	case command == commandSearch && len(args) > 0:
+		return cs.search(ctx, siteInfo, args, limit)
+	case command == commandAsk && len(args) > 0:
+		return askReply(siteInfo, args), nil
+	}
+	return &chatReply{Text: fmt.Sprintf("Usage:\n%[1]s search <keywords> - search %[2]s\n"+
+		"%[1]s ask <title> | <details> - ask a question on %[2]s", trigger, siteInfo.Name)}, nil
+}
+
+func (cs *ChatIntegrationService) search(ctx context.Context, siteInfo *schema.SiteGeneralResp,
+	query string, limit int) (reply *chatReply, err error) {
+	if len([]rune(query)) > maxQueryLength {
+		query = string([]rune(query)[:maxQueryLength])
+	}
+	seoInfo, err := cs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dto := &schema.SearchDTO{Query: query, Page: 1, Size: limit, Order: "relevance", Mode: "keyword"}
+	_, _ = dto.Check()
+	resp, err := cs.searchService.Search(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	reply = &chatReply{
+		Action: &chatItem{
+			Title: "View all results",
+			URL:   siteInfo.SiteUrl + "/search?q=" + url.QueryEscape(query),
+		},
+	}
+	for _, result := range resp.SearchResults {
+		obj := result.Object
+		item := &chatItem{
+			Title:   obj.Title,
+			Excerpt: obj.Excerpt,
+			Meta:    fmt.Sprintf("%d votes", obj.VoteCount),
+		}
+		switch result.ObjectType {
+		case constant.QuestionObjectType:
+			item.URL = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, obj.QuestionID, obj.Title)
+			item.Meta += fmt.Sprintf(" · %d answers", obj.AnswerCount)
+		case constant.AnswerObjectType:
+			item.URL = display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, obj.QuestionID, obj.Title, obj.ID)
+			item.Meta = "Answer · " + item.Meta
+		default:
+			continue
+		}
+		if obj.Accepted {
+			item.Meta += " · accepted"
+		}
+		reply.Items = append(reply.Items, item)
+	}
+	if len(reply.Items) == 0 {
+		reply.Text = fmt.Sprintf("No results found for \"%s\" on %s.", query, siteInfo.Name)
+	} else {
+		reply.Text = fmt.Sprintf("Results for \"%s\" on %s:", query, siteInfo.Name)
+	}
+	log.Debugf("chat command searched %s, %d results", query, resp.Total)
+	return reply, nil
+}
+
+// askReply reply the link to the ask page filled with the title and the details
+func askReply(siteInfo *schema.SiteGeneralResp, args string) *chatReply {
+	title, details, _ := strings.Cut(args, "|")
+	title, details = strings.TrimSpace(title), strings.TrimSpace(details)
+	prefill := fmt.Sprintf("---\ntitle: %s\n---\n%s", strings.ReplaceAll(title, "\n", " "), details)
+	return &chatReply{
+		Text: fmt.Sprintf("Post your question on %s:", siteInfo.Name),
+		Action: &chatItem{
+			Title: title,
+			URL:   siteInfo.SiteUrl + "/questions/ask?prefill=" + url.QueryEscape(prefill),
+		},
+	}
+}
+
+// parseCommand split the text into the command and the arguments
+func parseCommand(text string) (command, args string) {
+	text = strings.TrimSpace(text)
+	command, args, _ = strings.Cut(text, " ")
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package chat_integration
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Now()
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("command=%2Fanswer&text=search+kubernetes")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("v0:" + ts + ":" + string(body)))
+	signature := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, verifySlackSignature("secret", ts, signature, body, now))
+	assert.False(t, verifySlackSignature("other", ts, signature, body, now))
+	assert.False(t, verifySlackSignature("secret", ts, signature, []byte("text=ask"), now))
+	assert.False(t, verifySlackSignature("secret", ts, signature, body, now.Add(10*time.Minute)))
+	assert.False(t, verifySlackSignature("", ts, signature, body, now))
+}
+
+func TestVerifyTeamsSignature(t *testing.T) {
+	token := base64.StdEncoding.EncodeToString([]byte("security token"))
+	body := []byte(`{"type":"message","text":"<at>Answer</at> search kubernetes"}`)
+	mac := hmac.New(sha256.New, []byte("security token"))
+	mac.Write(body)
+	authorization := "HMAC " + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, verifyTeamsSignature(token, authorization, body))
+	assert.False(t, verifyTeamsSignature(token, authorization, []byte(`{}`)))
+	assert.False(t, verifyTeamsSignature(token, "Bearer x", body))
+}
+
+func TestParseCommand(t *testing.T) {
+	command, args := parseCommand(cleanTeamsText("<at>Answer</at>&nbsp;Search  kubernetes pods"))
+	assert.Equal(t, commandSearch, command)
+	assert.Equal(t, "kubernetes pods", args)
+
+	command, args = parseCommand("help")
+	assert.Equal(t, "help", command)
+	assert.Equal(t, "", args)
+}
+
+func TestFormatSlackReply(t *testing.T) {
+	resp := formatSlackReply(&chatReply{
+		Text:   "Results for \"a<b\":",
+		Items:  []*chatItem{{Title: "a & b", URL: "https://example.com/questions/1", Meta: "1 votes"}},
+		Action: &chatItem{Title: "View all results", URL: "https://example.com/search?q=a"},
+	})
+	assert.Equal(t, "ephemeral", resp.ResponseType)
+	assert.Len(t, resp.Blocks, 4)
+	assert.Equal(t, "Results for \"a&lt;b\":", resp.Blocks[0].Text.Text)
+	assert.Equal(t, "*<https://example.com/questions/1|a &amp; b>*", resp.Blocks[1].Text.Text)
+}
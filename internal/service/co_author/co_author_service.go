@@ -0,0 +1,170 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package co_author
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// MaxCoAuthorsPerObject the max number of co-authors of one question or answer
+const MaxCoAuthorsPerObject = 5
+
+// CoAuthorRepo co-author repository
+type CoAuthorRepo interface {
+	AddCoAuthor(ctx context.Context, coAuthor *entity.CoAuthor) (err error)
+	RemoveCoAuthor(ctx context.Context, objectID, userID string) (err error)
+	GetCoAuthor(ctx context.Context, objectID, userID string) (coAuthor *entity.CoAuthor, exist bool, err error)
+	GetCoAuthors(ctx context.Context, objectID string) (coAuthors []*entity.CoAuthor, err error)
+}
+
+// CoAuthorService co-author service
+type CoAuthorService struct {
+	coAuthorRepo      CoAuthorRepo
+	objectInfoService *object_info.ObjService
+	userCommon        *usercommon.UserCommon
+}
+
+// NewCoAuthorService new co-author service
+func NewCoAuthorService(
+	coAuthorRepo CoAuthorRepo,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+) *CoAuthorService {
+	return &CoAuthorService{
+		coAuthorRepo:      coAuthorRepo,
+		objectInfoService: objectInfoService,
+		userCommon:        userCommon,
+	}
+}
+
+// AddCoAuthor add the user as the co-author of the question or answer
+func (cs *CoAuthorService) AddCoAuthor(ctx context.Context, req *schema.AddCoAuthorReq) (
+	resp *schema.UserBasicInfo, err error) {
+	objInfo, err := cs.getObjectInfo(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	userInfo, exist, err := cs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == constant.UserDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if userInfo.ID == objInfo.ObjectCreatorUserID {
+		return nil, errors.BadRequest(reason.CoAuthorCannotAddAuthor)
+	}
+
+	coAuthors, err := cs.coAuthorRepo.GetCoAuthors(ctx, objInfo.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, coAuthor := range coAuthors {
+		if coAuthor.UserID == userInfo.ID {
+			return nil, errors.BadRequest(reason.CoAuthorAlreadyExist)
+		}
+	}
+	if len(coAuthors) >= MaxCoAuthorsPerObject {
+		return nil, errors.BadRequest(reason.CoAuthorLimitExceeded)
+	}
+
+	err = cs.coAuthorRepo.AddCoAuthor(ctx, &entity.CoAuthor{
+		ObjectID:      objInfo.ObjectID,
+		UserID:        userInfo.ID,
+		AddedByUserID: req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userInfo, nil
+}
+
+// RemoveCoAuthor remove the co-author of the question or answer
+func (cs *CoAuthorService) RemoveCoAuthor(ctx context.Context, req *schema.RemoveCoAuthorReq) (err error) {
+	_, exist, err := cs.coAuthorRepo.GetCoAuthor(ctx, req.ObjectID, req.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.CoAuthorNotFound)
+	}
+	return cs.coAuthorRepo.RemoveCoAuthor(ctx, req.ObjectID, req.UserID)
+}
+
+// GetCoAuthors get the co-authors of the question or answer
+func (cs *CoAuthorService) GetCoAuthors(ctx context.Context, objectID string) (
+	resp []*schema.UserBasicInfo, err error) {
+	coAuthors, err := cs.coAuthorRepo.GetCoAuthors(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(coAuthors))
+	for _, coAuthor := range coAuthors {
+		userIDs = append(userIDs, coAuthor.UserID)
+	}
+	userInfoMapping, err := cs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserBasicInfo, 0, len(coAuthors))
+	for _, coAuthor := range coAuthors {
+		if userInfo, ok := userInfoMapping[coAuthor.UserID]; ok {
+			resp = append(resp, userInfo)
+		}
+	}
+	return resp, nil
+}
+
+// IsCoAuthor check whether the user is the co-author of the question or answer
+func (cs *CoAuthorService) IsCoAuthor(ctx context.Context, objectID, userID string) bool {
+	if len(userID) == 0 {
+		return false
+	}
+	_, exist, err := cs.coAuthorRepo.GetCoAuthor(ctx, objectID, userID)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return exist
+}
+
+// getObjectInfo only the question and answer which is not deleted can have co-authors
+func (cs *CoAuthorService) getObjectInfo(ctx context.Context, objectID string) (
+	objInfo *schema.SimpleObjectInfo, err error) {
+	objInfo, err = cs.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if objInfo.ObjectType != constant.QuestionObjectType && objInfo.ObjectType != constant.AnswerObjectType {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	if objInfo.IsDeleted() {
+		return nil, errors.BadRequest(reason.NewObjectAlreadyDeleted)
+	}
+	return objInfo, nil
+}
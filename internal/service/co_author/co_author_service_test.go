@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package co_author
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCoAuthorRepo struct {
+	CoAuthorRepo
+	coAuthors []*entity.CoAuthor
+}
+
+func (r *testCoAuthorRepo) AddCoAuthor(_ context.Context, coAuthor *entity.CoAuthor) error {
+	r.coAuthors = append(r.coAuthors, coAuthor)
+	return nil
+}
+
+func (r *testCoAuthorRepo) GetCoAuthor(_ context.Context, objectID, userID string) (*entity.CoAuthor, bool, error) {
+	for _, coAuthor := range r.coAuthors {
+		if coAuthor.ObjectID == objectID && coAuthor.UserID == userID {
+			return coAuthor, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+func (r *testCoAuthorRepo) GetCoAuthors(_ context.Context, objectID string) ([]*entity.CoAuthor, error) {
+	coAuthors := make([]*entity.CoAuthor, 0)
+	for _, coAuthor := range r.coAuthors {
+		if coAuthor.ObjectID == objectID {
+			coAuthors = append(coAuthors, coAuthor)
+		}
+	}
+	return coAuthors, nil
+}
+
+func newTestCoAuthorService(ctl *gomock.Controller, coAuthorRepo CoAuthorRepo) *CoAuthorService {
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().GetByUsername(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, username string) (*entity.User, bool, error) {
+			if username == "nobody" {
+				return &entity.User{}, false, nil
+			}
+			user := &entity.User{ID: username[len("user"):], Username: username, Status: entity.UserStatusAvailable}
+			if username == "user9" {
+				user.Status = entity.UserStatusDeleted
+			}
+			return user, true, nil
+		})
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().FormatAvatar(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		Return(&schema.AvatarInfo{})
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*entity.Question, bool, error) {
+			return &entity.Question{ID: id, UserID: "1", Status: entity.QuestionStatusAvailable}, true, nil
+		})
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetAnswer(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*entity.Answer, bool, error) {
+			answer := &entity.Answer{ID: id, QuestionID: "10010000000000001", UserID: "2",
+				Status: entity.AnswerStatusAvailable}
+			if id == "10020000000000002" {
+				answer.Status = entity.AnswerStatusDeleted
+			}
+			return answer, true, nil
+		})
+	return NewCoAuthorService(coAuthorRepo,
+		object_info.NewObjService(answerRepo, questionRepo, nil, nil, nil, nil),
+		usercommon.NewUserCommon(userRepo, nil, nil, siteInfoService))
+}
+
+func TestAddCoAuthor(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	cs := newTestCoAuthorService(ctl, &testCoAuthorRepo{})
+
+	const questionID, answerID = "10010000000000001", "10020000000000001"
+	tests := []struct {
+		name      string
+		objectID  string
+		username  string
+		wantError string
+	}{
+		{"add to question", questionID, "user3", ""},
+		{"add to answer", answerID, "user3", ""},
+		{"already exist", questionID, "user3", reason.CoAuthorAlreadyExist},
+		{"question author", questionID, "user1", reason.CoAuthorCannotAddAuthor},
+		{"answer author", answerID, "user2", reason.CoAuthorCannotAddAuthor},
+		{"user not found", questionID, "nobody", reason.UserNotFound},
+		{"user deleted", questionID, "user9", reason.UserNotFound},
+		{"answer deleted", "10020000000000002", "user3", reason.NewObjectAlreadyDeleted},
+		{"user is not a post", "10040000000000001", "user3", reason.ObjectNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cs.AddCoAuthor(context.TODO(), &schema.AddCoAuthorReq{
+				ObjectID: tt.objectID, Username: tt.username, UserID: "1"})
+			if len(tt.wantError) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.wantError, err.(*errors.Error).Reason)
+			}
+		})
+	}
+	assert.True(t, cs.IsCoAuthor(context.TODO(), questionID, "3"))
+	assert.True(t, cs.IsCoAuthor(context.TODO(), answerID, "3"))
+	assert.False(t, cs.IsCoAuthor(context.TODO(), questionID, "4"))
+	assert.False(t, cs.IsCoAuthor(context.TODO(), questionID, ""))
+}
+
+func TestAddCoAuthorLimit(t *testing.T) {
+	const questionID = "10010000000000001"
+	coAuthorRepo := &testCoAuthorRepo{}
+	for i := 0; i < MaxCoAuthorsPerObject; i++ {
+		coAuthorRepo.coAuthors = append(coAuthorRepo.coAuthors,
+			&entity.CoAuthor{ObjectID: questionID, UserID: fmt.Sprint(10 + i)})
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	cs := newTestCoAuthorService(ctl, coAuthorRepo)
+
+	_, err := cs.AddCoAuthor(context.TODO(), &schema.AddCoAuthorReq{ObjectID: questionID, Username: "user3"})
+	assert.Equal(t, reason.CoAuthorLimitExceeded, err.(*errors.Error).Reason)
+}
@@ -31,12 +31,18 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/jinzhu/copier"
@@ -48,6 +54,7 @@ import (
 type CommentRepo interface {
 	AddComment(ctx context.Context, comment *entity.Comment) (err error)
 	RemoveComment(ctx context.Context, commentID string) (err error)
+	RecoverComment(ctx context.Context, commentID string) (err error)
 	UpdateCommentContent(ctx context.Context, commentID string, original string, parsedText string) (err error)
 	GetComment(ctx context.Context, commentID string) (comment *entity.Comment, exist bool, err error)
 	GetCommentPage(ctx context.Context, commentQuery *CommentQuery) (
@@ -86,6 +93,10 @@ type CommentService struct {
 	notificationQueueService         notice_queue.NotificationQueueService
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
+	pendingDeletionService           *pending_deletion.PendingDeletionService
+	legalHoldService                 *legal_hold.LegalHoldService
+	ipLoggingService                 *ip_logging.IPLoggingService
+	eventQueueService                event_queue.EventQueueService
 }
 
 // NewCommentService new comment service
@@ -100,6 +111,10 @@ func NewCommentService(
 	notificationQueueService notice_queue.NotificationQueueService,
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
+	pendingDeletionService *pending_deletion.PendingDeletionService,
+	eventQueueService event_queue.EventQueueService,
+	legalHoldService *legal_hold.LegalHoldService,
+	ipLoggingService *ip_logging.IPLoggingService,
 ) *CommentService {
 	return &CommentService{
 		commentRepo:                      commentRepo,
@@ -112,6 +127,10 @@ func NewCommentService(
 		notificationQueueService:         notificationQueueService,
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
+		pendingDeletionService:           pendingDeletionService,
+		eventQueueService:                eventQueueService,
+		legalHoldService:                 legalHoldService,
+		ipLoggingService:                 ipLoggingService,
 	}
 }
 
@@ -191,6 +210,9 @@ func (cs *CommentService) AddComment(ctx context.Context, req *schema.AddComment
 		activityMsg.ActivityTypeKey = constant.ActAnswerCommented
 	}
 	cs.activityQueueService.Send(ctx, activityMsg)
+	cs.sendEvent(ctx, constant.EventCommentCreate, comment.UserID, comment)
+	cs.ipLoggingService.RecordPostIP(ctx, comment.UserID, comment.ID, constant.CommentObjectType,
+		req.IP, req.UserAgent)
 	return resp, nil
 }
 
@@ -241,7 +263,70 @@ func (cs *CommentService) addCommentNotification(
 
 // RemoveComment delete comment
 func (cs *CommentService) RemoveComment(ctx context.Context, req *schema.RemoveCommentReq) (err error) {
-	return cs.commentRepo.RemoveComment(ctx, req.CommentID)
+	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, req.CommentID)
+	if err != nil {
+		return err
+	}
+	// the comment has been deleted
+	if !exist {
+		return nil
+	}
+	err = cs.legalHoldService.CheckObjectDeletable(ctx, comment.ID, entity.LegalHoldProcessCommentDelete, req.UserID)
+	if err != nil {
+		return err
+	}
+	if err = cs.commentRepo.RemoveComment(ctx, req.CommentID); err != nil {
+		return err
+	}
+	cs.pendingDeletionService.AddPendingDeletion(ctx, comment.ID, req.UserID, req.Reason)
+	cs.sendEvent(ctx, constant.EventCommentDelete, req.UserID, comment)
+	return nil
+}
+
+// RecoverComment recover deleted comment
+func (cs *CommentService) RecoverComment(ctx context.Context, commentID, userID string) (err error) {
+	if err = cs.commentRepo.RecoverComment(ctx, commentID); err != nil {
+		return err
+	}
+	cs.pendingDeletionService.MarkRestored(ctx, commentID)
+	comment, exist, err := cs.commentCommonRepo.GetComment(ctx, commentID)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+
+	activityMsg := &schema.ActivityMsg{
+		UserID:           userID,
+		TriggerUserID:    converter.StringToInt64(userID),
+		ObjectID:         comment.ID,
+		OriginalObjectID: comment.ObjectID,
+	}
+	parentType, _ := obj.GetObjectTypeStrByObjectID(comment.ObjectID)
+	switch parentType {
+	case constant.QuestionObjectType:
+		activityMsg.ActivityTypeKey = constant.ActQuestionCommentUndeleted
+	case constant.AnswerObjectType:
+		activityMsg.ActivityTypeKey = constant.ActAnswerCommentUndeleted
+	}
+	if len(activityMsg.ActivityTypeKey) > 0 {
+		cs.activityQueueService.Send(ctx, activityMsg)
+	}
+	cs.sendEvent(ctx, constant.EventCommentUpdate, "", comment)
+	return nil
+}
+
+// sendEvent send the event of the comment to the event bus
+func (cs *CommentService) sendEvent(ctx context.Context, eventType constant.EventType, userID string,
+	comment *entity.Comment) {
+	cs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType:  eventType,
+		UserID:     userID,
+		ObjectID:   comment.ID,
+		QuestionID: comment.QuestionID,
+	})
 }
 
 // UpdateComment update comment
@@ -268,6 +353,7 @@ func (cs *CommentService) UpdateComment(ctx context.Context, req *schema.UpdateC
 	if err = cs.commentRepo.UpdateCommentContent(ctx, old.ID, req.OriginalText, req.ParsedText); err != nil {
 		return nil, err
 	}
+	cs.sendEvent(ctx, constant.EventCommentUpdate, req.UserID, old)
 	resp = &schema.UpdateCommentResp{
 		CommentID:    old.ID,
 		OriginalText: req.OriginalText,
@@ -330,6 +416,10 @@ func (cs *CommentService) GetComment(ctx context.Context, req *schema.GetComment
 
 	resp.MemberActions = permission.GetCommentPermission(ctx, req.UserID, resp.UserID,
 		comment.CreatedAt, req.CanEdit, req.CanDelete)
+	resp.AllowedActions = permission.GetAllowedActions(ctx, req.UserID, &schema.AllowedActionsObject{
+		CreatorUserID:     resp.UserID,
+		OwnerEditDeadline: comment.CreatedAt.Add(constant.CommentEditDeadline),
+	}, req.ActionPermissions)
 	return resp, nil
 }
 
@@ -426,6 +516,10 @@ func (cs *CommentService) convertCommentEntity2Resp(ctx context.Context, req *sc
 
 	commentResp.MemberActions = permission.GetCommentPermission(ctx,
 		req.UserID, commentResp.UserID, comment.CreatedAt, req.CanEdit, req.CanDelete)
+	commentResp.AllowedActions = permission.GetAllowedActions(ctx, req.UserID, &schema.AllowedActionsObject{
+		CreatorUserID:     commentResp.UserID,
+		OwnerEditDeadline: comment.CreatedAt.Add(constant.CommentEditDeadline),
+	}, req.ActionPermissions)
 	return commentResp, nil
 }
 
@@ -0,0 +1,99 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package comment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testCommentRepo struct {
+	CommentRepo
+	comments map[string]*entity.Comment
+}
+
+func (r *testCommentRepo) RecoverComment(_ context.Context, commentID string) error {
+	r.comments[commentID].Status = entity.CommentStatusAvailable
+	return nil
+}
+
+type testCommentCommonRepo struct {
+	comment_common.CommentCommonRepo
+	comments map[string]*entity.Comment
+}
+
+func (r *testCommentCommonRepo) GetComment(_ context.Context, commentID string) (*entity.Comment, bool, error) {
+	comment, ok := r.comments[commentID]
+	if !ok || comment.Status != entity.CommentStatusAvailable {
+		return nil, false, nil
+	}
+	return comment, true, nil
+}
+
+type testPendingDeletionRepo struct {
+	pending_deletion.PendingDeletionRepo
+	restored []string
+}
+
+func (r *testPendingDeletionRepo) MarkObjectRestored(_ context.Context, objectID string) error {
+	r.restored = append(r.restored, objectID)
+	return nil
+}
+
+func TestRecoverComment(t *testing.T) {
+	comments := map[string]*entity.Comment{
+		"10040000000000001": {ID: "10040000000000001", UserID: "2", ObjectID: "10010000000000001",
+			QuestionID: "10010000000000001", Status: entity.CommentStatusDeleted},
+		"10040000000000002": {ID: "10040000000000002", UserID: "2", ObjectID: "10020000000000001",
+			QuestionID: "10010000000000001", Status: entity.CommentStatusDeleted},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	pendingDeletionRepo := &testPendingDeletionRepo{}
+	activityQueue := mock.NewMockActivityQueueService(ctl)
+	gomock.InOrder(
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "1", TriggerUserID: 1,
+			ObjectID: "10040000000000001", OriginalObjectID: "10010000000000001",
+			ActivityTypeKey: constant.ActQuestionCommentUndeleted}),
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "2", TriggerUserID: 2,
+			ObjectID: "10040000000000002", OriginalObjectID: "10020000000000001",
+			ActivityTypeKey: constant.ActAnswerCommentUndeleted}),
+	)
+	eventQueue := mock.NewMockEventQueueService(ctl)
+	eventQueue.EXPECT().Send(gomock.Any(), gomock.Any()).Times(2)
+	cs := NewCommentService(&testCommentRepo{comments: comments}, &testCommentCommonRepo{comments: comments},
+		nil, nil, nil, nil, nil, nil, nil, activityQueue,
+		pending_deletion.NewPendingDeletionService(pendingDeletionRepo, nil, nil, nil, nil, nil),
+		eventQueue, nil, nil)
+
+	assert.NoError(t, cs.RecoverComment(context.TODO(), "10040000000000001", "1"))
+	assert.NoError(t, cs.RecoverComment(context.TODO(), "10040000000000002", "2"))
+
+	assert.Equal(t, []string{"10040000000000001", "10040000000000002"}, pendingDeletionRepo.restored)
+}
@@ -22,6 +22,9 @@ package content
 import (
 	"context"
 	"encoding/json"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
+	"github.com/apache/incubator-answer/internal/service/upload_access"
+	"strings"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -32,14 +35,23 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/answer_quality"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/link_preview"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/converter"
 	"github.com/apache/incubator-answer/pkg/htmltext"
@@ -67,6 +79,17 @@ type AnswerService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	activityQueueService             activity_queue.ActivityQueueService
 	reviewService                    *review.ReviewService
+	eventQueueService                event_queue.EventQueueService
+	pendingDeletionService           *pending_deletion.PendingDeletionService
+	linkPreviewService               *link_preview.LinkPreviewService
+	deadLinkService                  *dead_link.DeadLinkService
+	answerQualityService             *answer_quality.AnswerQualityService
+	answerVerificationService        *answer_verification.AnswerVerificationService
+	legalHoldService                 *legal_hold.LegalHoldService
+	ipLoggingService                 *ip_logging.IPLoggingService
+	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	mediaProxyService                *media_proxy.MediaProxyService
+	uploadAccessService              *upload_access.UploadAccessService
 }
 
 func NewAnswerService(
@@ -86,6 +109,17 @@ func NewAnswerService(
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService,
 	activityQueueService activity_queue.ActivityQueueService,
 	reviewService *review.ReviewService,
+	eventQueueService event_queue.EventQueueService,
+	pendingDeletionService *pending_deletion.PendingDeletionService,
+	linkPreviewService *link_preview.LinkPreviewService,
+	deadLinkService *dead_link.DeadLinkService,
+	answerQualityService *answer_quality.AnswerQualityService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	mediaProxyService *media_proxy.MediaProxyService,
+	uploadAccessService *upload_access.UploadAccessService,
+	answerVerificationService *answer_verification.AnswerVerificationService,
+	legalHoldService *legal_hold.LegalHoldService,
+	ipLoggingService *ip_logging.IPLoggingService,
 ) *AnswerService {
 	return &AnswerService{
 		answerRepo:                       answerRepo,
@@ -104,6 +138,17 @@ func NewAnswerService(
 		externalNotificationQueueService: externalNotificationQueueService,
 		activityQueueService:             activityQueueService,
 		reviewService:                    reviewService,
+		eventQueueService:                eventQueueService,
+		pendingDeletionService:           pendingDeletionService,
+		linkPreviewService:               linkPreviewService,
+		deadLinkService:                  deadLinkService,
+		answerQualityService:             answerQualityService,
+		answerVerificationService:        answerVerificationService,
+		legalHoldService:                 legalHoldService,
+		ipLoggingService:                 ipLoggingService,
+		siteInfoService:                  siteInfoService,
+		mediaProxyService:                mediaProxyService,
+		uploadAccessService:              uploadAccessService,
 	}
 }
 
@@ -120,6 +165,10 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if answerInfo.Status == entity.AnswerStatusDeleted {
 		return nil
 	}
+	err = as.legalHoldService.CheckObjectDeletable(ctx, answerInfo.ID, entity.LegalHoldProcessAnswerDelete, req.UserID)
+	if err != nil {
+		return err
+	}
 	roleID, err := as.roleService.GetUserRole(ctx, req.UserID)
 	if err != nil {
 		return err
@@ -148,6 +197,7 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 	if err != nil {
 		return err
 	}
+	as.pendingDeletionService.AddPendingDeletion(ctx, answerInfo.ID, req.UserID, req.Reason)
 
 	// user add question count
 	err = as.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID)
@@ -175,6 +225,12 @@ func (as *AnswerService) RemoveAnswer(ctx context.Context, req *schema.RemoveAns
 		OriginalObjectID: answerInfo.ID,
 		ActivityTypeKey:  constant.ActAnswerDeleted,
 	})
+	as.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType:  constant.EventAnswerDelete,
+		UserID:     req.UserID,
+		ObjectID:   answerInfo.ID,
+		QuestionID: answerInfo.QuestionID,
+	})
 	return
 }
 
@@ -193,6 +249,7 @@ func (as *AnswerService) RecoverAnswer(ctx context.Context, req *schema.RecoverA
 	if err = as.answerRepo.RecoverAnswer(ctx, req.AnswerID); err != nil {
 		return err
 	}
+	as.pendingDeletionService.MarkRestored(ctx, answerInfo.ID)
 
 	if err = as.questionCommon.UpdateAnswerCount(ctx, answerInfo.QuestionID); err != nil {
 		log.Errorf("update answer count failed: %s", err.Error())
@@ -241,6 +298,11 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 	if err = as.answerRepo.AddAnswer(ctx, insertData); err != nil {
 		return "", err
 	}
+	if len(req.Environments) > 0 {
+		if err = as.answerQualityService.SaveEnvironments(ctx, insertData.ID, req.Environments); err != nil {
+			return "", err
+		}
+	}
 	insertData.Status = as.reviewService.AddAnswerReview(ctx, insertData, req.IP, req.UserAgent)
 	if err := as.answerRepo.UpdateAnswerStatus(ctx, insertData.ID, insertData.Status); err != nil {
 		return "", err
@@ -295,6 +357,14 @@ func (as *AnswerService) Insert(ctx context.Context, req *schema.AnswerAddReq) (
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionAnswered,
 	})
+	as.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType:  constant.EventAnswerCreate,
+		UserID:     insertData.UserID,
+		ObjectID:   insertData.ID,
+		QuestionID: questionInfo.ID,
+	})
+	as.ipLoggingService.RecordPostIP(ctx, insertData.UserID, insertData.ID, constant.AnswerObjectType,
+		req.IP, req.UserAgent)
 	return insertData.ID, nil
 }
 
@@ -328,6 +398,13 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 		return "", errors.BadRequest(reason.AnswerCannotUpdate)
 	}
 
+	// The environments are the metadata of the answer, they are not reviewed as the content
+	if req.Environments != nil && (req.NoNeedReview || answerInfo.UserID == req.UserID) {
+		if err = as.answerQualityService.SaveEnvironments(ctx, answerInfo.ID, req.Environments); err != nil {
+			return "", err
+		}
+	}
+
 	//If the content is the same, ignore it
 	if answerInfo.OriginalText == req.Content {
 		return "", nil
@@ -366,6 +443,7 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 			return insertData.ID, err
 		}
 		as.notificationUpdateAnswer(ctx, questionInfo.UserID, insertData.ID, req.UserID)
+		as.answerQualityService.ResetOutdatedFlags(ctx, insertData.ID)
 		revisionDTO.Status = entity.RevisionReviewPassStatus
 	}
 
@@ -383,11 +461,23 @@ func (as *AnswerService) Update(ctx context.Context, req *schema.AnswerUpdateReq
 			ActivityTypeKey:  constant.ActAnswerEdited,
 			RevisionID:       revisionID,
 		})
+		as.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType:  constant.EventAnswerUpdate,
+			UserID:     req.UserID,
+			ObjectID:   insertData.ID,
+			QuestionID: questionInfo.ID,
+		})
 	}
 
 	return insertData.ID, nil
 }
 
+// FlagAnswerOutdated flag the answer as outdated or cancel the flag
+func (as *AnswerService) FlagAnswerOutdated(ctx context.Context, req *schema.FlagAnswerOutdatedReq) (
+	*schema.FlagAnswerOutdatedResp, error) {
+	return as.answerQualityService.FlagOutdated(ctx, req)
+}
+
 // AcceptAnswer accept answer
 func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAnswerReq) (err error) {
 	// find question
@@ -399,7 +489,8 @@ func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAns
 		return errors.BadRequest(reason.QuestionNotFound)
 	}
 	questionInfo.ID = uid.DeShortID(questionInfo.ID)
-	if questionInfo.AcceptedAnswerID == req.AnswerID {
+	multiple := as.isMultipleAcceptedAnswers(ctx)
+	if !multiple && !req.IsCancel && questionInfo.AcceptedAnswerID == req.AnswerID {
 		return nil
 	}
 
@@ -410,54 +501,153 @@ func (as *AnswerService) AcceptAnswer(ctx context.Context, req *schema.AcceptAns
 		if err != nil {
 			return err
 		}
-		if !exist {
+		if !exist || uid.DeShortID(acceptedAnswerInfo.QuestionID) != questionInfo.ID {
 			return errors.BadRequest(reason.AnswerNotFound)
 		}
 		acceptedAnswerInfo.ID = uid.DeShortID(acceptedAnswerInfo.ID)
 	}
 
-	// update answers status
-	if err = as.answerRepo.UpdateAcceptedStatus(ctx, req.AnswerID, req.QuestionID); err != nil {
+	oldAnswers, err := as.answerRepo.GetAcceptedAnswers(ctx, questionInfo.ID)
+	if err != nil {
 		return err
 	}
+	for _, answer := range oldAnswers {
+		answer.ID = uid.DeShortID(answer.ID)
+	}
+	isAccepted := acceptedAnswerInfo != nil && containsAnswer(oldAnswers, acceptedAnswerInfo.ID)
 
-	// update question status
-	err = as.questionCommon.UpdateAccepted(ctx, req.QuestionID, req.AnswerID)
+	// update answers status
+	var newAnswerInfo *entity.Answer
+	removedAnswers := make([]*entity.Answer, 0)
+	switch {
+	case req.IsCancel:
+		if !isAccepted {
+			return nil
+		}
+		err = as.answerRepo.UpdateAnswerAccepted(ctx, acceptedAnswerInfo.ID, schema.AnswerAcceptedFailed)
+		removedAnswers = append(removedAnswers, acceptedAnswerInfo)
+	case multiple && acceptedAnswerInfo != nil:
+		if isAccepted {
+			return nil
+		}
+		err = as.answerRepo.UpdateAnswerAccepted(ctx, acceptedAnswerInfo.ID, schema.AnswerAcceptedEnable)
+		newAnswerInfo = acceptedAnswerInfo
+	default:
+		err = as.answerRepo.UpdateAcceptedStatus(ctx, req.AnswerID, req.QuestionID)
+		for _, answer := range oldAnswers {
+			if acceptedAnswerInfo == nil || answer.ID != acceptedAnswerInfo.ID {
+				removedAnswers = append(removedAnswers, answer)
+			}
+		}
+		if !isAccepted {
+			newAnswerInfo = acceptedAnswerInfo
+		}
+	}
 	if err != nil {
-		log.Error("UpdateLastAnswer error", err.Error())
+		return err
 	}
 
-	var oldAnswerInfo *entity.Answer
-	if len(questionInfo.AcceptedAnswerID) > 1 {
-		oldAnswerInfo, _, err = as.answerRepo.GetByID(ctx, questionInfo.AcceptedAnswerID)
-		if err != nil {
-			return err
+	// update question status, the question refers to the latest accepted answer
+	remainingAnswers := make([]*entity.Answer, 0, len(oldAnswers))
+	for _, answer := range oldAnswers {
+		if !containsAnswer(removedAnswers, answer.ID) {
+			remainingAnswers = append(remainingAnswers, answer)
 		}
-		oldAnswerInfo.ID = uid.DeShortID(oldAnswerInfo.ID)
+	}
+	acceptedAnswerID := "0"
+	if newAnswerInfo != nil {
+		acceptedAnswerID = newAnswerInfo.ID
+	} else if len(remainingAnswers) > 0 {
+		acceptedAnswerID = remainingAnswers[len(remainingAnswers)-1].ID
+	}
+	err = as.questionCommon.UpdateAccepted(ctx, questionInfo.ID, acceptedAnswerID)
+	if err != nil {
+		log.Error("UpdateLastAnswer error", err.Error())
 	}
 
-	as.updateAnswerRank(ctx, req.UserID, questionInfo, acceptedAnswerInfo, oldAnswerInfo)
+	as.updateAnswerRank(ctx, req.UserID, questionInfo, newAnswerInfo, removedAnswers, remainingAnswers)
+	for _, answer := range removedAnswers {
+		as.activityQueueService.Send(ctx, &schema.ActivityMsg{
+			UserID:           req.UserID,
+			TriggerUserID:    converter.StringToInt64(req.UserID),
+			ObjectID:         answer.ID,
+			OriginalObjectID: questionInfo.ID,
+			ActivityTypeKey:  constant.ActQuestionUnacceptAnswer,
+		})
+	}
+	if newAnswerInfo != nil {
+		as.activityQueueService.Send(ctx, &schema.ActivityMsg{
+			UserID:           req.UserID,
+			TriggerUserID:    converter.StringToInt64(req.UserID),
+			ObjectID:         newAnswerInfo.ID,
+			OriginalObjectID: questionInfo.ID,
+			ActivityTypeKey:  constant.ActQuestionAcceptAnswer,
+		})
+		as.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType:  constant.EventAnswerAccept,
+			UserID:     req.UserID,
+			ObjectID:   newAnswerInfo.ID,
+			QuestionID: questionInfo.ID,
+		})
+	}
 	return nil
 }
 
+// updateAnswerRank cancel the rank of the answers which are no longer accepted, and add the rank of the new one.
+// If other answers are still accepted, the question author has been rewarded for accepting them already, so the
+// reward is moved to the answer which is still accepted and only taken back when the last one is cancelled.
 func (as *AnswerService) updateAnswerRank(ctx context.Context, userID string,
-	questionInfo *entity.Question, newAnswerInfo *entity.Answer, oldAnswerInfo *entity.Answer,
+	questionInfo *entity.Question, newAnswerInfo *entity.Answer, oldAnswers, remainingAnswers []*entity.Answer,
 ) {
-	// if this question is already been answered, should cancel old answer rank
-	if oldAnswerInfo != nil {
+	keepAccepted := len(remainingAnswers) > 0
+	for _, oldAnswerInfo := range oldAnswers {
+		if keepAccepted {
+			err := as.answerActivityService.TransferAcceptAnswer(ctx, questionInfo.UserID,
+				oldAnswerInfo.ID, remainingAnswers[len(remainingAnswers)-1].ID)
+			if err != nil {
+				log.Error(err)
+			}
+		}
 		err := as.answerActivityService.CancelAcceptAnswer(ctx, userID,
-			questionInfo.AcceptedAnswerID, questionInfo.ID, questionInfo.UserID, oldAnswerInfo.UserID)
+			oldAnswerInfo.ID, questionInfo.ID, questionInfo.UserID, oldAnswerInfo.UserID)
 		if err != nil {
 			log.Error(err)
 		}
 	}
-	if newAnswerInfo != nil {
-		err := as.answerActivityService.AcceptAnswer(ctx, userID, newAnswerInfo.ID,
-			questionInfo.ID, questionInfo.UserID, newAnswerInfo.UserID, newAnswerInfo.UserID == questionInfo.UserID)
-		if err != nil {
-			log.Error(err)
+	if newAnswerInfo == nil {
+		return
+	}
+	isSelf := newAnswerInfo.UserID == questionInfo.UserID
+	var err error
+	if keepAccepted {
+		err = as.answerActivityService.AcceptAdditionalAnswer(ctx, userID, newAnswerInfo.ID,
+			questionInfo.ID, questionInfo.UserID, newAnswerInfo.UserID, isSelf)
+	} else {
+		err = as.answerActivityService.AcceptAnswer(ctx, userID, newAnswerInfo.ID,
+			questionInfo.ID, questionInfo.UserID, newAnswerInfo.UserID, isSelf)
+	}
+	if err != nil {
+		log.Error(err)
+	}
+}
+
+// isMultipleAcceptedAnswers whether the site allows the author to accept more than one answer
+func (as *AnswerService) isMultipleAcceptedAnswers(ctx context.Context) bool {
+	siteWrite, err := as.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return siteWrite.MultipleAcceptedAnswers
+}
+
+func containsAnswer(answers []*entity.Answer, answerID string) bool {
+	for _, answer := range answers {
+		if answer.ID == answerID {
+			return true
 		}
 	}
+	return false
 }
 
 func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string) (*schema.AnswerInfo, *schema.QuestionInfoResp, bool, error) {
@@ -466,6 +656,12 @@ func (as *AnswerService) Get(ctx context.Context, answerID, loginUserID string)
 		return nil, nil, has, err
 	}
 	info := as.ShowFormat(ctx, answerInfo)
+	info.LinkPreviews = as.linkPreviewService.GetLinkPreviews(ctx, info.HTML)
+	info.HTML = as.deadLinkService.AnnotateDeadLinks(ctx, info.ID, info.HTML)
+	info.HTML = as.uploadAccessService.SignUploadURLs(ctx, info.HTML)
+	info.HTML = as.mediaProxyService.RewriteImages(ctx, info.HTML)
+	as.answerQualityService.FillAnswerQuality(ctx, []*schema.AnswerInfo{info}, loginUserID)
+	as.answerVerificationService.FillAnswerVerification(ctx, []*schema.AnswerInfo{info})
 	// todo questionFunc
 	questionInfo, err := as.questionCommon.Info(ctx, answerInfo.QuestionID, loginUserID)
 	if err != nil {
@@ -576,6 +772,11 @@ func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListR
 	dbSearch.Order = req.Order
 	dbSearch.IncludeDeleted = req.CanDelete
 	dbSearch.LoginUserID = req.UserID
+	dbSearch.EnvironmentName = answer_quality.NormalizeEnvironmentName(req.Environment)
+	dbSearch.EnvironmentVersion = strings.TrimSpace(req.Version)
+	if req.HideOutdated {
+		dbSearch.OutdatedThreshold = as.answerQualityService.GetOutdatedThreshold(ctx)
+	}
 	answerOriginalList, count, err := as.answerRepo.SearchList(ctx, &dbSearch)
 	if err != nil {
 		return list, count, err
@@ -584,6 +785,22 @@ func (as *AnswerService) SearchList(ctx context.Context, req *schema.AnswerListR
 	if err != nil {
 		return answerList, count, err
 	}
+	answerIDs := make([]string, 0, len(answerList))
+	contents := make([]string, 0, len(answerList))
+	for _, item := range answerList {
+		answerIDs = append(answerIDs, item.ID)
+		contents = append(contents, item.HTML)
+	}
+	for i, previews := range as.linkPreviewService.BatchGetLinkPreviews(ctx, contents) {
+		answerList[i].LinkPreviews = previews
+	}
+	contents = as.deadLinkService.BatchAnnotateDeadLinks(ctx, answerIDs, contents)
+	contents = as.uploadAccessService.BatchSignUploadURLs(ctx, contents)
+	for i, content := range as.mediaProxyService.BatchRewriteImages(ctx, contents) {
+		answerList[i].HTML = content
+	}
+	as.answerQualityService.FillAnswerQuality(ctx, answerList, req.UserID)
+	as.answerVerificationService.FillAnswerVerification(ctx, answerList)
 	return answerList, count, nil
 }
 
@@ -606,6 +823,10 @@ func (as *AnswerService) SearchFormatInfo(ctx context.Context, answers []*entity
 	for _, item := range list {
 		item.UserInfo = userInfoMap[item.UserID]
 		item.UpdateUserInfo = userInfoMap[item.UpdateUserID]
+		item.AllowedActions = permission.GetAllowedActions(ctx, req.UserID, &schema.AllowedActionsObject{
+			CreatorUserID: item.UserID,
+			Deleted:       item.Status == entity.AnswerStatusDeleted,
+		}, req.ActionPermissions)
 	}
 	if len(req.UserID) == 0 {
 		return list, nil
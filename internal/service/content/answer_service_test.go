@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testQuestionID = "10010000000000001"
+	testAnswerA    = "10020000000000001"
+	testAnswerB    = "10020000000000002"
+	testAsker      = "1"
+	testAnswererA  = "2"
+	testAnswererB  = "3"
+)
+
+// newTestAcceptAnswerRepo the accepted answers are kept in the given answers like the answer repository does
+func newTestAcceptAnswerRepo(ctl *gomock.Controller, answers map[string]*entity.Answer) *mock.MockAnswerRepo {
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetByID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, answerID string) (*entity.Answer, bool, error) {
+			answer, ok := answers[answerID]
+			if !ok {
+				return nil, false, nil
+			}
+			info := *answer
+			return &info, true, nil
+		})
+	answerRepo.EXPECT().GetAcceptedAnswers(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(context.Context, string) ([]*entity.Answer, error) {
+			acceptedAnswers := make([]*entity.Answer, 0)
+			for _, answer := range answers {
+				if answer.Accepted == schema.AnswerAcceptedEnable {
+					info := *answer
+					acceptedAnswers = append(acceptedAnswers, &info)
+				}
+			}
+			sort.Slice(acceptedAnswers, func(i, j int) bool { return acceptedAnswers[i].ID < acceptedAnswers[j].ID })
+			return acceptedAnswers, nil
+		})
+	answerRepo.EXPECT().UpdateAnswerAccepted(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, answerID string, accepted int) error {
+			answers[answerID].Accepted = accepted
+			return nil
+		})
+	answerRepo.EXPECT().UpdateAcceptedStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, acceptedAnswerID, _ string) error {
+			for id, answer := range answers {
+				answer.Accepted = schema.AnswerAcceptedFailed
+				if id == acceptedAnswerID {
+					answer.Accepted = schema.AnswerAcceptedEnable
+				}
+			}
+			return nil
+		})
+	return answerRepo
+}
+
+type testAcceptConfigRepo struct {
+	config.ConfigRepo
+}
+
+func (testAcceptConfigRepo) GetConfigByKey(_ context.Context, key string) (*entity.Config, error) {
+	if key == activity_type.AnswerAccept {
+		return &entity.Config{ID: 1, Key: key, Value: "2"}, nil
+	}
+	return &entity.Config{ID: 2, Key: key, Value: "15"}, nil
+}
+
+type testActivity struct {
+	objectID     string
+	userID       string
+	activityType int
+	rank         int
+	cancelled    bool
+}
+
+// testAnswerActivityRepo keep the activities and the reputation of the users like the activity repository
+type testAnswerActivityRepo struct {
+	activities []*testActivity
+	ranks      map[string]int
+}
+
+func (r *testAnswerActivityRepo) find(objectID, userID string, activityType int) *testActivity {
+	for _, act := range r.activities {
+		if act.objectID == objectID && act.userID == userID && act.activityType == activityType {
+			return act
+		}
+	}
+	return nil
+}
+
+func (r *testAnswerActivityRepo) SaveAcceptAnswerActivity(_ context.Context,
+	op *schema.AcceptAnswerOperationInfo) error {
+	for _, act := range op.Activities {
+		exist := r.find(op.AnswerObjectID, act.ActivityUserID, act.ActivityType)
+		if exist != nil && !exist.cancelled {
+			continue
+		}
+		if exist != nil {
+			exist.cancelled, exist.rank = false, act.Rank
+		} else {
+			r.activities = append(r.activities, &testActivity{objectID: op.AnswerObjectID,
+				userID: act.ActivityUserID, activityType: act.ActivityType, rank: act.Rank})
+		}
+		r.ranks[act.ActivityUserID] += act.Rank
+	}
+	return nil
+}
+
+func (r *testAnswerActivityRepo) SaveCancelAcceptAnswerActivity(_ context.Context,
+	op *schema.AcceptAnswerOperationInfo) error {
+	for _, act := range op.Activities {
+		exist := r.find(op.AnswerObjectID, act.ActivityUserID, act.ActivityType)
+		if exist == nil || exist.cancelled {
+			continue
+		}
+		exist.cancelled = true
+		r.ranks[exist.userID] -= exist.rank
+	}
+	return nil
+}
+
+func (r *testAnswerActivityRepo) TransferAcceptAnswerActivity(_ context.Context, activityType int,
+	questionUserID, fromAnswerID, toAnswerID string) error {
+	from := r.find(fromAnswerID, questionUserID, activityType)
+	if from == nil || from.cancelled || from.rank == 0 {
+		return nil
+	}
+	to := r.find(toAnswerID, questionUserID, activityType)
+	if to != nil && !to.cancelled && to.rank != 0 {
+		return nil
+	}
+	activities := make([]*testActivity, 0, len(r.activities))
+	for _, act := range r.activities {
+		if act != to {
+			activities = append(activities, act)
+		}
+	}
+	r.activities = activities
+	from.objectID = toAnswerID
+	return nil
+}
+
+func TestAcceptAnswerRank(t *testing.T) {
+	accept := func(answerID string) *schema.AcceptAnswerReq {
+		return &schema.AcceptAnswerReq{QuestionID: testQuestionID, AnswerID: answerID, UserID: testAsker}
+	}
+	cancel := func(answerID string) *schema.AcceptAnswerReq {
+		return &schema.AcceptAnswerReq{QuestionID: testQuestionID, AnswerID: answerID, UserID: testAsker,
+			IsCancel: true}
+	}
+	tests := []struct {
+		name             string
+		reqs             []*schema.AcceptAnswerReq
+		wantRanks        map[string]int
+		wantAcceptedID   string
+		wantAcceptedList []string
+	}{
+		{
+			name:             "accept the second answer",
+			reqs:             []*schema.AcceptAnswerReq{accept(testAnswerA), accept(testAnswerB)},
+			wantRanks:        map[string]int{testAsker: 2, testAnswererA: 15, testAnswererB: 15},
+			wantAcceptedID:   testAnswerB,
+			wantAcceptedList: []string{testAnswerA, testAnswerB},
+		},
+		{
+			name:             "cancel the first of two accepted answers",
+			reqs:             []*schema.AcceptAnswerReq{accept(testAnswerA), accept(testAnswerB), cancel(testAnswerA)},
+			wantRanks:        map[string]int{testAsker: 2, testAnswererA: 0, testAnswererB: 15},
+			wantAcceptedID:   testAnswerB,
+			wantAcceptedList: []string{testAnswerB},
+		},
+		{
+			name:             "cancel the second of two accepted answers",
+			reqs:             []*schema.AcceptAnswerReq{accept(testAnswerA), accept(testAnswerB), cancel(testAnswerB)},
+			wantRanks:        map[string]int{testAsker: 2, testAnswererA: 15, testAnswererB: 0},
+			wantAcceptedID:   testAnswerA,
+			wantAcceptedList: []string{testAnswerA},
+		},
+		{
+			name: "cancel the last accepted answer",
+			reqs: []*schema.AcceptAnswerReq{accept(testAnswerA), accept(testAnswerB),
+				cancel(testAnswerA), cancel(testAnswerB)},
+			wantRanks:        map[string]int{testAsker: 0, testAnswererA: 0, testAnswererB: 0},
+			wantAcceptedID:   "0",
+			wantAcceptedList: []string{},
+		},
+		{
+			name: "accept again after cancelling the last one",
+			reqs: []*schema.AcceptAnswerReq{accept(testAnswerA), accept(testAnswerB),
+				cancel(testAnswerB), cancel(testAnswerA), accept(testAnswerB)},
+			wantRanks:        map[string]int{testAsker: 2, testAnswererA: 0, testAnswererB: 15},
+			wantAcceptedID:   testAnswerB,
+			wantAcceptedList: []string{testAnswerB},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			question := &entity.Question{ID: testQuestionID, UserID: testAsker}
+			questionRepo := mock.NewMockQuestionRepo(ctl)
+			questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).AnyTimes().Return(question, true, nil)
+			questionRepo.EXPECT().UpdateAccepted(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(_ context.Context, q *entity.Question) error {
+					question.AcceptedAnswerID = q.AcceptedAnswerID
+					return nil
+				})
+			answerRepo := newTestAcceptAnswerRepo(ctl, map[string]*entity.Answer{
+				testAnswerA: {ID: testAnswerA, QuestionID: testQuestionID, UserID: testAnswererA},
+				testAnswerB: {ID: testAnswerB, QuestionID: testQuestionID, UserID: testAnswererB},
+			})
+			activityQueue := mock.NewMockActivityQueueService(ctl)
+			activityQueue.EXPECT().Send(gomock.Any(), gomock.Any()).AnyTimes()
+			eventQueue := mock.NewMockEventQueueService(ctl)
+			eventQueue.EXPECT().Send(gomock.Any(), gomock.Any()).AnyTimes()
+			siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+			siteInfoService.EXPECT().GetSiteWrite(gomock.Any()).AnyTimes().
+				Return(&schema.SiteWriteResp{MultipleAcceptedAnswers: true}, nil)
+			activityRepo := &testAnswerActivityRepo{ranks: make(map[string]int)}
+			as := &AnswerService{
+				answerRepo:   answerRepo,
+				questionRepo: questionRepo,
+				questionCommon: questioncommon.NewQuestionCommon(questionRepo, nil, nil, nil, nil, nil, nil, nil,
+					nil, nil, nil, nil, nil),
+				answerActivityService: activity.NewAnswerActivityService(activityRepo,
+					config.NewConfigService(testAcceptConfigRepo{})),
+				activityQueueService: activityQueue,
+				eventQueueService:    eventQueue,
+				siteInfoService:      siteInfoService,
+			}
+			for _, req := range tt.reqs {
+				assert.NoError(t, as.AcceptAnswer(context.TODO(), req))
+			}
+			for userID, rank := range tt.wantRanks {
+				assert.Equal(t, rank, activityRepo.ranks[userID], "rank of user %s", userID)
+			}
+			assert.Equal(t, tt.wantAcceptedID, question.AcceptedAnswerID)
+			accepted, _ := answerRepo.GetAcceptedAnswers(context.TODO(), testQuestionID)
+			acceptedIDs := make([]string, 0)
+			for _, answer := range accepted {
+				acceptedIDs = append(acceptedIDs, answer.ID)
+			}
+			assert.Equal(t, tt.wantAcceptedList, acceptedIDs)
+		})
+	}
+}
@@ -28,7 +28,7 @@ import (
 	"time"
 )
 
-func (q *QuestionService) RefreshHottestCron(ctx context.Context) {
+func (q *QuestionService) RefreshHottestCron(ctx context.Context) (err error) {
 
 	var (
 		page     = 1
@@ -41,10 +41,10 @@ func (q *QuestionService) RefreshHottestCron(ctx context.Context) {
 			page, pageSize,
 			[]string{},
 			"", "newest",
-			schema.HotInDays,
-			false, false)
+			schema.HotInDays, 0,
+			false, false, nil)
 		if err != nil {
-			return
+			return err
 		}
 
 		for _, question := range questionList {
@@ -80,6 +80,7 @@ func (q *QuestionService) RefreshHottestCron(ctx context.Context) {
 		}
 		page++
 	}
+	return nil
 }
 
 func (q *QuestionService) getScore(qViews, qAnswers, qScore, aScores, qAgeInHours, qUpdated float64) (score float64) {
@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"fmt"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
+	"github.com/apache/incubator-answer/internal/service/upload_access"
 	"strings"
 	"time"
 
@@ -38,12 +40,23 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/link_preview"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
 	"github.com/apache/incubator-answer/internal/service/permission"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_poll"
+	"github.com/apache/incubator-answer/internal/service/question_quality"
+	"github.com/apache/incubator-answer/internal/service/question_sla"
+	"github.com/apache/incubator-answer/internal/service/question_summary"
+	"github.com/apache/incubator-answer/internal/service/question_workflow"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/role"
@@ -84,6 +97,19 @@ type QuestionService struct {
 	newQuestionNotificationService   *notification.ExternalNotificationService
 	reviewService                    *review.ReviewService
 	configService                    *config.ConfigService
+	eventQueueService                event_queue.EventQueueService
+	questionSLAService               *question_sla.QuestionSLAService
+	questionPollService              *question_poll.QuestionPollService
+	pendingDeletionService           *pending_deletion.PendingDeletionService
+	linkPreviewService               *link_preview.LinkPreviewService
+	questionSummaryService           *question_summary.QuestionSummaryService
+	deadLinkService                  *dead_link.DeadLinkService
+	mediaProxyService                *media_proxy.MediaProxyService
+	uploadAccessService              *upload_access.UploadAccessService
+	questionQualityService           *question_quality.QuestionQualityService
+	questionWorkflowService          *question_workflow.QuestionWorkflowService
+	legalHoldService                 *legal_hold.LegalHoldService
+	ipLoggingService                 *ip_logging.IPLoggingService
 }
 
 func NewQuestionService(
@@ -106,6 +132,19 @@ func NewQuestionService(
 	newQuestionNotificationService *notification.ExternalNotificationService,
 	reviewService *review.ReviewService,
 	configService *config.ConfigService,
+	eventQueueService event_queue.EventQueueService,
+	questionSLAService *question_sla.QuestionSLAService,
+	questionPollService *question_poll.QuestionPollService,
+	pendingDeletionService *pending_deletion.PendingDeletionService,
+	linkPreviewService *link_preview.LinkPreviewService,
+	deadLinkService *dead_link.DeadLinkService,
+	questionSummaryService *question_summary.QuestionSummaryService,
+	mediaProxyService *media_proxy.MediaProxyService,
+	uploadAccessService *upload_access.UploadAccessService,
+	questionQualityService *question_quality.QuestionQualityService,
+	questionWorkflowService *question_workflow.QuestionWorkflowService,
+	legalHoldService *legal_hold.LegalHoldService,
+	ipLoggingService *ip_logging.IPLoggingService,
 ) *QuestionService {
 	return &QuestionService{
 		questionRepo:                     questionRepo,
@@ -127,6 +166,19 @@ func NewQuestionService(
 		newQuestionNotificationService:   newQuestionNotificationService,
 		reviewService:                    reviewService,
 		configService:                    configService,
+		eventQueueService:                eventQueueService,
+		questionSLAService:               questionSLAService,
+		questionPollService:              questionPollService,
+		pendingDeletionService:           pendingDeletionService,
+		linkPreviewService:               linkPreviewService,
+		questionSummaryService:           questionSummaryService,
+		deadLinkService:                  deadLinkService,
+		mediaProxyService:                mediaProxyService,
+		uploadAccessService:              uploadAccessService,
+		questionQualityService:           questionQualityService,
+		questionWorkflowService:          questionWorkflowService,
+		legalHoldService:                 legalHoldService,
+		ipLoggingService:                 ipLoggingService,
 	}
 }
 
@@ -326,6 +378,7 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 	question.PostUpdateTime = now
 	question.Pin = entity.QuestionUnPin
 	question.Show = entity.QuestionShow
+	question.QualityScore = qs.questionQualityService.GetQualityScore(ctx, req.Title, req.Content, len(req.Tags))
 	//question.UpdatedAt = nil
 	err = qs.questionRepo.AddQuestion(ctx, question)
 	if err != nil {
@@ -385,6 +438,13 @@ func (qs *QuestionService) AddQuestion(ctx context.Context, req *schema.Question
 		qs.externalNotificationQueueService.Send(ctx,
 			schema.CreateNewQuestionNotificationMsg(question.ID, question.Title, question.UserID, tags))
 	}
+	qs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventQuestionCreate,
+		UserID:    question.UserID,
+		ObjectID:  question.ID,
+	})
+	qs.ipLoggingService.RecordPostIP(ctx, question.UserID, question.ID, constant.QuestionObjectType,
+		req.IP, req.UserAgent)
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
 	return
@@ -399,8 +459,8 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 	if !has {
 		return nil
 	}
-	// Hidden question cannot be placed at the top
-	if questionInfo.Show == entity.QuestionHide && req.Operation == schema.QuestionOperationPin {
+	// Hidden or unlisted question cannot be placed at the top
+	if questionInfo.Show != entity.QuestionShow && req.Operation == schema.QuestionOperationPin {
 		return nil
 	}
 	// Question cannot be hidden when they are at the top
@@ -454,6 +514,13 @@ func (qs *QuestionService) OperationQuestion(ctx context.Context, req *schema.Op
 			ActivityTypeKey:  actMap[req.Operation],
 		})
 	}
+	if req.Operation == schema.QuestionOperationHide || req.Operation == schema.QuestionOperationShow {
+		qs.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType: constant.EventQuestionStatusChange,
+			UserID:    req.UserID,
+			ObjectID:  questionInfo.ID,
+		})
+	}
 
 	return nil
 }
@@ -471,6 +538,10 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 	if !has {
 		return nil
 	}
+	err = qs.legalHoldService.CheckObjectDeletable(ctx, questionInfo.ID, entity.LegalHoldProcessQuestionDelete, req.UserID)
+	if err != nil {
+		return err
+	}
 	if !req.IsAdmin {
 		if questionInfo.UserID != req.UserID {
 			return errors.BadRequest(reason.QuestionCannotDeleted)
@@ -503,6 +574,7 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 	if err != nil {
 		return err
 	}
+	qs.pendingDeletionService.AddPendingDeletion(ctx, questionInfo.ID, req.UserID, req.Reason)
 
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
 	if err != nil {
@@ -546,6 +618,11 @@ func (qs *QuestionService) RemoveQuestion(ctx context.Context, req *schema.Remov
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionDeleted,
 	})
+	qs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventQuestionDelete,
+		UserID:    req.UserID,
+		ObjectID:  questionInfo.ID,
+	})
 	return nil
 }
 
@@ -631,6 +708,7 @@ func (qs *QuestionService) RecoverQuestion(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return err
 	}
+	qs.pendingDeletionService.MarkRestored(ctx, questionInfo.ID)
 
 	// update user's question count
 	userQuestionCount, err := qs.questioncommon.GetUserQuestionCount(ctx, questionInfo.UserID)
@@ -669,6 +747,11 @@ func (qs *QuestionService) RecoverQuestion(ctx context.Context, req *schema.Ques
 		OriginalObjectID: questionInfo.ID,
 		ActivityTypeKey:  constant.ActQuestionUndeleted,
 	})
+	qs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventQuestionStatusChange,
+		UserID:    req.UserID,
+		ObjectID:  questionInfo.ID,
+	})
 	return nil
 }
 
@@ -815,6 +898,7 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 	question.PostUpdateTime = now
 	question.UserID = dbinfo.UserID
 	question.LastEditUserID = req.UserID
+	question.QualityScore = qs.questionQualityService.GetQualityScore(ctx, req.Title, req.Content, len(req.Tags))
 
 	oldTags, tagerr := qs.tagCommon.GetObjectEntityTag(ctx, question.ID)
 	if tagerr != nil {
@@ -905,7 +989,7 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 		//Direct modification
 		revisionDTO.Status = entity.RevisionReviewPassStatus
 		//update question to db
-		saveerr := qs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text", "updated_at", "post_update_time", "last_edit_user_id"})
+		saveerr := qs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text", "updated_at", "post_update_time", "last_edit_user_id", "quality_score"})
 		if saveerr != nil {
 			return questionInfo, saveerr
 		}
@@ -937,6 +1021,11 @@ func (qs *QuestionService) UpdateQuestion(ctx context.Context, req *schema.Quest
 			RevisionID:       revisionID,
 			OriginalObjectID: question.ID,
 		})
+		qs.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType: constant.EventQuestionUpdate,
+			UserID:    req.UserID,
+			ObjectID:  question.ID,
+		})
 	}
 
 	questionInfo, err = qs.GetQuestion(ctx, question.ID, question.UserID, req.QuestionPermission)
@@ -955,6 +1044,10 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		question.Status == entity.QuestionStatusPending) && !per.CanReopen && question.UserID != userID {
 		return nil, errors.NotFound(reason.QuestionNotFound)
 	}
+	// If the question is unlisted, only the moderator, the author and the visitor with share link can view it
+	if question.Show == entity.QuestionUnlisted && !per.CanHide && !per.CanViewUnlisted && question.UserID != userID {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
 	if question.Status != entity.QuestionStatusClosed {
 		per.CanReopen = false
 	}
@@ -975,6 +1068,9 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		per.CanHide = false
 		per.CanPin = false
 	}
+	if question.Show == entity.QuestionUnlisted {
+		per.CanPin = false
+	}
 
 	if question.Status == entity.QuestionStatusDeleted {
 		operation := &schema.Operation{}
@@ -995,18 +1091,28 @@ func (qs *QuestionService) GetQuestion(ctx context.Context, questionID, userID s
 		per.CanClose, per.CanReopen, per.CanPin, per.CanHide, per.CanUnPin, per.CanShow,
 		per.CanRecover)
 	question.ExtendsActions = permission.GetQuestionExtendsPermission(ctx, per.CanInviteOtherToAnswer)
+	question.AllowedActions = permission.GetAllowedActions(ctx, userID, &schema.AllowedActionsObject{
+		CreatorUserID: question.UserID,
+		Deleted:       question.Status == entity.QuestionStatusDeleted,
+		Closed:        question.Status == entity.QuestionStatusClosed,
+	}, per.ActionPermissions)
+	question.Poll, err = qs.questionPollService.GetQuestionPoll(ctx, question.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	question.LinkPreviews = qs.linkPreviewService.GetLinkPreviews(ctx, question.HTML)
+	question.Summary = qs.questionSummaryService.GetQuestionSummary(ctx, question.ID)
+	question.HTML = qs.deadLinkService.AnnotateDeadLinks(ctx, question.ID, question.HTML)
+	question.HTML = qs.uploadAccessService.SignUploadURLs(ctx, question.HTML)
+	question.HTML = qs.mediaProxyService.RewriteImages(ctx, question.HTML)
 	return question, nil
 }
 
-// GetQuestionAndAddPV get question one
-func (qs *QuestionService) GetQuestionAndAddPV(ctx context.Context, questionID, loginUserID string,
-	per schema.QuestionPermission) (
-	resp *schema.QuestionInfoResp, err error) {
-	err = qs.questioncommon.UpdatePv(ctx, questionID)
-	if err != nil {
+// AddQuestionPV add the page view of the question
+func (qs *QuestionService) AddQuestionPV(ctx context.Context, questionID string) {
+	if err := qs.questioncommon.UpdatePv(ctx, questionID); err != nil {
 		log.Error(err)
 	}
-	return qs.GetQuestion(ctx, questionID, loginUserID, per)
 }
 
 func (qs *QuestionService) InviteUserInfo(ctx context.Context, questionID string) (inviteList []*schema.UserBasicInfo, err error) {
@@ -1336,8 +1442,14 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 		req.InDays = schema.HotInDays
 	}
 
+	var qualityBlend int
+	if req.OrderCond == schema.QuestionOrderCondHot {
+		qualityBlend = qs.questionQualityService.GetQualityBlend(ctx)
+	}
+	workflowStates := qs.questionWorkflowService.GetFilterStates(ctx, req.WorkflowState)
 	questionList, total, err := qs.questionRepo.GetQuestionPage(ctx, req.Page, req.PageSize,
-		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, showHidden, req.ShowPending)
+		tagIDs, req.UserIDBeSearched, req.OrderCond, req.InDays, qualityBlend, showHidden, req.ShowPending,
+		workflowStates)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -1345,6 +1457,8 @@ func (qs *QuestionService) GetQuestionPage(ctx context.Context, req *schema.Ques
 	if err != nil {
 		return nil, 0, err
 	}
+	qs.questionSLAService.FillQuestionPageSLA(ctx, questions)
+	qs.questionWorkflowService.FillQuestionPageWorkflow(ctx, questions)
 	return questions, total, nil
 }
 
@@ -1412,6 +1526,12 @@ func (qs *QuestionService) AdminSetQuestionStatus(ctx context.Context, req *sche
 		})
 	}
 
+	qs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventQuestionStatusChange,
+		UserID:    req.UserID,
+		ObjectID:  questionInfo.ID,
+	})
+
 	if len(msg.NotificationAction) > 0 {
 		msg.ObjectID = questionInfo.ID
 		msg.Type = schema.NotificationTypeInbox
@@ -1504,13 +1624,3 @@ func (qs *QuestionService) changeQuestionToRevision(ctx context.Context, questio
 	}
 	return questionRevision, nil
 }
-
-func (qs *QuestionService) SitemapCron(ctx context.Context) {
-	siteSeo, err := qs.siteInfoService.GetSiteSeo(ctx)
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	ctx = context.WithValue(ctx, constant.ShortIDFlag, siteSeo.IsShortLink())
-	qs.questioncommon.SitemapCron(ctx)
-}
@@ -37,6 +37,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_quality"
 	"github.com/apache/incubator-answer/internal/service/report_common"
 	"github.com/apache/incubator-answer/internal/service/review"
 	"github.com/apache/incubator-answer/internal/service/revision"
@@ -68,6 +69,7 @@ type RevisionService struct {
 	reportRepo               report_common.ReportRepo
 	reviewService            *review.ReviewService
 	reviewActivity           activity.ReviewActivityRepo
+	questionQualityService   *question_quality.QuestionQualityService
 }
 
 func NewRevisionService(
@@ -85,6 +87,7 @@ func NewRevisionService(
 	reportRepo report_common.ReportRepo,
 	reviewService *review.ReviewService,
 	reviewActivity activity.ReviewActivityRepo,
+	questionQualityService *question_quality.QuestionQualityService,
 ) *RevisionService {
 	return &RevisionService{
 		revisionRepo:             revisionRepo,
@@ -101,6 +104,7 @@ func NewRevisionService(
 		reportRepo:               reportRepo,
 		reviewService:            reviewService,
 		reviewActivity:           reviewActivity,
+		questionQualityService:   questionQualityService,
 	}
 }
 
@@ -201,7 +205,9 @@ func (rs *RevisionService) revisionAuditQuestion(ctx context.Context, revisionit
 		question.UpdatedAt = time.Unix(questioninfo.UpdateTime, 0)
 		question.PostUpdateTime = PostUpdateTime
 		question.LastEditUserID = revisionitem.UserID
-		saveerr := rs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text", "updated_at", "post_update_time", "last_edit_user_id"})
+		question.QualityScore = rs.questionQualityService.GetQualityScore(ctx,
+			questioninfo.Title, questioninfo.Content, len(questioninfo.Tags))
+		saveerr := rs.questionRepo.UpdateQuestion(ctx, question, []string{"title", "original_text", "parsed_text", "updated_at", "post_update_time", "last_edit_user_id", "quality_score"})
 		if saveerr != nil {
 			return saveerr
 		}
@@ -425,6 +431,7 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 		answerInfo   *schema.AnswerInfo
 		tag          entity.Tag
 		tagInfo      *schema.GetTagResp
+		article      entity.Article
 	)
 
 	shortID := handler.GetEnableShortID(ctx)
@@ -473,6 +480,23 @@ func (rs *RevisionService) parseItem(ctx context.Context, item *schema.GetRevisi
 		}
 		tagInfo.GetExcerpt()
 		item.ContentParsed = tagInfo
+	case constant.ObjectTypeStrMapping["article"]:
+		err = json.Unmarshal([]byte(item.Content), &article)
+		if err != nil {
+			break
+		}
+		articleInfo := &schema.ArticleInfoResp{
+			ID:        article.ID,
+			Title:     article.Title,
+			URLTitle:  htmltext.UrlTitle(article.Title),
+			Content:   article.OriginalText,
+			HTML:      article.ParsedText,
+			CreatedAt: article.CreatedAt.Unix(),
+		}
+		if shortID {
+			articleInfo.ID = uid.EnShortID(articleInfo.ID)
+		}
+		item.ContentParsed = articleInfo
 	}
 
 	if err != nil {
@@ -513,7 +537,7 @@ func (rs *RevisionService) GetReviewingType(ctx context.Context, req *schema.Get
 	}
 
 	// get flag amount
-	if req.IsAdmin {
+	if req.CanReviewReport {
 		reportCount, err := rs.reportRepo.GetReportCount(ctx)
 		if err != nil {
 			log.Errorf("get report count failed: %v", err)
@@ -21,25 +21,41 @@ package content
 
 import (
 	"context"
+	"strings"
 
+	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/apache/incubator-answer/internal/service/question_summary"
 	"github.com/apache/incubator-answer/internal/service/search_common"
+	"github.com/apache/incubator-answer/internal/service/search_log"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
+	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
 )
 
 type SearchService struct {
-	searchParser *search_parser.SearchParser
-	searchRepo   search_common.SearchRepo
+	searchParser     *search_parser.SearchParser
+	searchRepo       search_common.SearchRepo
+	searchLogService *search_log.SearchLogService
+	summaryService   *question_summary.QuestionSummaryService
+	embeddingService *embedding.EmbeddingService
 }
 
 func NewSearchService(
 	searchParser *search_parser.SearchParser,
 	searchRepo search_common.SearchRepo,
+	searchLogService *search_log.SearchLogService,
+	summaryService *question_summary.QuestionSummaryService,
+	embeddingService *embedding.EmbeddingService,
 ) *SearchService {
 	return &SearchService{
-		searchParser: searchParser,
-		searchRepo:   searchRepo,
+		searchParser:     searchParser,
+		searchRepo:       searchRepo,
+		searchLogService: searchLogService,
+		summaryService:   summaryService,
+		embeddingService: embeddingService,
 	}
 }
 
@@ -55,6 +71,18 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 		}, nil
 	}
 
+	resp, err = ss.search(ctx, dto)
+	if err == nil {
+		ss.fillSummaries(ctx, resp.SearchResults)
+	}
+	// only the first page is recorded, turning pages is not a new search
+	if err == nil && dto.Page == 1 {
+		ss.searchLogService.RecordSearch(ctx, dto.Query, resp.Total)
+	}
+	return resp, err
+}
+
+func (ss *SearchService) search(ctx context.Context, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	// search type
 	cond := ss.searchParser.ParseStructure(ctx, dto)
 
@@ -66,6 +94,17 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	})
 
 	resp = &schema.SearchResp{}
+	// the search plugin does not support articles, always search them by the system
+	if cond.SearchArticle() {
+		resp.SearchResults, resp.Total, err =
+			ss.searchRepo.SearchArticles(ctx, cond.Words, cond.UserID, dto.Page, dto.Size, dto.Order)
+		return
+	}
+	defer func() {
+		if err == nil {
+			ss.blendSemanticResults(ctx, cond, dto, resp)
+		}
+	}()
 	// search plugin is not found, call system search
 	if finder == nil {
 		if cond.SearchAll() {
@@ -83,6 +122,64 @@ func (ss *SearchService) Search(ctx context.Context, dto *schema.SearchDTO) (res
 	return ss.searchByPlugin(ctx, finder, cond, dto)
 }
 
+// RecordClick record the clicked search result
+func (ss *SearchService) RecordClick(ctx context.Context, req *schema.SearchClickReq) (err error) {
+	return ss.searchLogService.RecordClick(ctx, req)
+}
+
+// blendSemanticResults blend the semantically similar questions and answers into the first page of the
+// keyword results, it only works for the plain keyword search ordered by the relevance
+func (ss *SearchService) blendSemanticResults(ctx context.Context, cond *schema.SearchCondition,
+	dto *schema.SearchDTO, resp *schema.SearchResp) {
+	if dto.Mode == "keyword" || dto.Page != 1 || dto.Order != "relevance" || cond.HasFilters() ||
+		!ss.embeddingService.IsSemanticSearchEnabled(ctx) {
+		return
+	}
+	objectTypes := []string{constant.QuestionObjectType, constant.AnswerObjectType}
+	if cond.SearchQuestion() {
+		objectTypes = []string{constant.QuestionObjectType}
+	} else if cond.SearchAnswer() {
+		objectTypes = []string{constant.AnswerObjectType}
+	}
+	matches, err := ss.embeddingService.SemanticSearch(ctx, strings.Join(cond.Words, " "), objectTypes, dto.Size)
+	if err != nil {
+		log.Errorf("semantic search failed: %v", err)
+		return
+	}
+	if len(matches) == 0 {
+		return
+	}
+	res := make([]plugin.SearchResult, 0, len(matches))
+	for _, match := range matches {
+		res = append(res, plugin.SearchResult{ID: match.ObjectID, Type: match.ObjectType})
+	}
+	semantic, err := ss.searchRepo.ParseSearchPluginResult(ctx, res, cond.Words)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	resp.SearchResults = embedding.FuseSearchResults(resp.SearchResults, semantic, dto.Size)
+	if resp.Total < int64(len(resp.SearchResults)) {
+		resp.Total = int64(len(resp.SearchResults))
+	}
+}
+
+// fillSummaries fill the summaries of the questions in the search results
+func (ss *SearchService) fillSummaries(ctx context.Context, results []*schema.SearchResult) {
+	questionIDs := make([]string, 0)
+	for _, result := range results {
+		if result.ObjectType == constant.QuestionObjectType {
+			questionIDs = append(questionIDs, uid.DeShortID(result.Object.ID))
+		}
+	}
+	summaries := ss.summaryService.BatchGetSummaries(ctx, questionIDs)
+	for _, result := range results {
+		if result.ObjectType == constant.QuestionObjectType {
+			result.Object.Summary = summaries[uid.DeShortID(result.Object.ID)]
+		}
+	}
+}
+
 func (ss *SearchService) searchByPlugin(ctx context.Context, finder plugin.Search, cond *schema.SearchCondition, dto *schema.SearchDTO) (resp *schema.SearchResp, err error) {
 	var res []plugin.SearchResult
 	resp = &schema.SearchResp{}
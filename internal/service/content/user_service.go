@@ -23,11 +23,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_onboarding"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
@@ -38,11 +41,19 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/experiment"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/invitation"
+	"github.com/apache/incubator-answer/internal/service/password_policy"
+	"github.com/apache/incubator-answer/internal/service/profile_field"
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_follow"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/google/uuid"
@@ -65,6 +76,16 @@ type UserService struct {
 	userNotificationConfigRepo    user_notification_config.UserNotificationConfigRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
 	questionService               *questioncommon.QuestionCommon
+	eventQueueService             event_queue.EventQueueService
+	profileFieldService           *profile_field.ProfileFieldService
+	userFollowService             *user_follow.UserFollowService
+	passwordPolicyService         *password_policy.PasswordPolicyService
+	invitationService             *invitation.InvitationService
+	emailDomainService            *email_domain.EmailDomainService
+	userOnboardingService         *user_onboarding.UserOnboardingService
+	experimentService             *experiment.ExperimentService
+	bannedWordService             *banned_word.BannedWordService
+	usernamePolicyService         *username_policy.UsernamePolicyService
 }
 
 func NewUserService(userRepo usercommon.UserRepo,
@@ -79,6 +100,16 @@ func NewUserService(userRepo usercommon.UserRepo,
 	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
 	questionService *questioncommon.QuestionCommon,
+	eventQueueService event_queue.EventQueueService,
+	profileFieldService *profile_field.ProfileFieldService,
+	userFollowService *user_follow.UserFollowService,
+	passwordPolicyService *password_policy.PasswordPolicyService,
+	invitationService *invitation.InvitationService,
+	emailDomainService *email_domain.EmailDomainService,
+	userOnboardingService *user_onboarding.UserOnboardingService,
+	experimentService *experiment.ExperimentService,
+	bannedWordService *banned_word.BannedWordService,
+	usernamePolicyService *username_policy.UsernamePolicyService,
 ) *UserService {
 	return &UserService{
 		userCommonService:             userCommonService,
@@ -93,6 +124,16 @@ func NewUserService(userRepo usercommon.UserRepo,
 		userNotificationConfigRepo:    userNotificationConfigRepo,
 		userNotificationConfigService: userNotificationConfigService,
 		questionService:               questionService,
+		eventQueueService:             eventQueueService,
+		profileFieldService:           profileFieldService,
+		userFollowService:             userFollowService,
+		passwordPolicyService:         passwordPolicyService,
+		invitationService:             invitationService,
+		emailDomainService:            emailDomainService,
+		userOnboardingService:         userOnboardingService,
+		experimentService:             experimentService,
+		bannedWordService:             bannedWordService,
+		usernamePolicyService:         usernamePolicyService,
 	}
 }
 
@@ -119,6 +160,10 @@ func (us *UserService) GetUserInfoByUserID(ctx context.Context, token, userID st
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status)
 	resp.AccessToken = token
 	resp.HavePassword = len(userInfo.Pass) > 0
+	if userCacheInfo, _ := us.authService.GetUserCacheInfo(ctx, token); userCacheInfo != nil {
+		resp.PasswordExpired = userCacheInfo.PasswordExpired
+	}
+	resp.Experiments = us.experimentService.GetUserVariants(ctx, userInfo.ID)
 	return resp, nil
 }
 
@@ -128,11 +173,28 @@ func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *sche
 	if err != nil {
 		return nil, err
 	}
+	if !exist {
+		// the user is renamed, the user info with the new username is returned
+		renamedUserID, renamed, err := us.usernamePolicyService.GetRenamedUserID(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if renamed {
+			userInfo, exist, err = us.userRepo.GetByUserID(ctx, renamedUserID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 	if !exist {
 		return nil, errors.NotFound(reason.UserNotFound)
 	}
 	resp = &schema.GetOtherUserInfoByUsernameResp{}
 	resp.ConvertFromUserEntity(userInfo)
+	resp.FormerNames, err = us.usernamePolicyService.GetFormerNames(ctx, userInfo)
+	if err != nil {
+		return nil, err
+	}
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
 
 	// Only the user himself and the administrator can see the hidden questions
@@ -141,9 +203,31 @@ func (us *UserService) GetOtherUserInfoByUsername(ctx context.Context, req *sche
 		return nil, err
 	}
 	resp.QuestionCount = int(questionCount)
+
+	resp.ProfileFields, err = us.profileFieldService.GetVisibleUserProfileFields(ctx, userInfo.ID, req.UserID, req.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.FollowerCount, resp.FollowingCount, resp.IsFollowed, err =
+		us.userFollowService.GetFollowStats(ctx, userInfo.ID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.OnboardingBadge, err = us.userOnboardingService.HasOnboardingBadge(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
 	return resp, nil
 }
 
+// GetUserProfileFieldForm get the extra profile fields with the values of the user
+func (us *UserService) GetUserProfileFieldForm(ctx context.Context, userID string) (
+	resp []*schema.GetUserProfileFieldFormResp, err error) {
+	return us.profileFieldService.GetUserProfileFieldForm(ctx, userID)
+}
+
 // EmailLogin email login
 func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLoginReq) (resp *schema.UserLoginResp, err error) {
 	siteLogin, err := us.siteInfoService.GetSiteLogin(ctx)
@@ -185,12 +269,14 @@ func (us *UserService) EmailLogin(ctx context.Context, req *schema.UserEmailLogi
 	resp.ConvertFromUserEntity(userInfo)
 	resp.Avatar = us.siteInfoService.FormatAvatar(ctx, userInfo.Avatar, userInfo.EMail, userInfo.Status).GetURL()
 	userCacheInfo := &entity.UserCacheInfo{
-		UserID:      userInfo.ID,
-		EmailStatus: userInfo.MailStatus,
-		UserStatus:  userInfo.Status,
-		RoleID:      roleID,
-		ExternalID:  externalID,
-	}
+		UserID:          userInfo.ID,
+		EmailStatus:     userInfo.MailStatus,
+		UserStatus:      userInfo.Status,
+		RoleID:          roleID,
+		ExternalID:      externalID,
+		PasswordExpired: us.passwordPolicyService.IsPasswordExpired(ctx, userInfo, req.Pass),
+	}
+	resp.PasswordExpired = userCacheInfo.PasswordExpired
 	resp.AccessToken, resp.VisitToken, err = us.authService.SetUserCacheInfo(ctx, userCacheInfo)
 	if err != nil {
 		return nil, err
@@ -232,31 +318,36 @@ func (us *UserService) RetrievePassWord(ctx context.Context, req *schema.UserRet
 }
 
 // UpdatePasswordWhenForgot update user password when user forgot password
-func (us *UserService) UpdatePasswordWhenForgot(ctx context.Context, req *schema.UserRePassWordRequest) (err error) {
+func (us *UserService) UpdatePasswordWhenForgot(ctx context.Context, req *schema.UserRePassWordRequest) (
+	errFields []*validator.FormErrorField, err error) {
 	data := &schema.EmailCodeContent{}
 	err = data.FromJSONString(req.Content)
 	if err != nil {
-		return errors.BadRequest(reason.EmailVerifyURLExpired)
+		return nil, errors.BadRequest(reason.EmailVerifyURLExpired)
 	}
 
 	userInfo, exist, err := us.userRepo.GetByEmail(ctx, data.Email)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exist {
-		return errors.BadRequest(reason.UserNotFound)
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	errFields, err = us.passwordPolicyService.CheckPassword(ctx, "pass", req.Pass)
+	if err != nil {
+		return errFields, err
 	}
 	enpass, err := us.encryptPassword(ctx, req.Pass)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	err = us.userRepo.UpdatePass(ctx, userInfo.ID, enpass)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// When the user changes the password, all the current user's tokens are invalid.
 	us.authService.RemoveUserAllTokens(ctx, userInfo.ID)
-	return nil
+	return nil, nil
 }
 
 func (us *UserService) UserModifyPassWordVerification(ctx context.Context, req *schema.UserModifyPasswordReq) (bool, error) {
@@ -276,30 +367,38 @@ func (us *UserService) UserModifyPassWordVerification(ctx context.Context, req *
 }
 
 // UserModifyPassword user modify password
-func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserModifyPasswordReq) error {
+func (us *UserService) UserModifyPassword(ctx context.Context, req *schema.UserModifyPasswordReq) (
+	errFields []*validator.FormErrorField, err error) {
+	errFields, err = us.passwordPolicyService.CheckPassword(ctx, "pass", req.Pass)
+	if err != nil {
+		return errFields, err
+	}
 	enpass, err := us.encryptPassword(ctx, req.Pass)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	userInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !exist {
-		return errors.BadRequest(reason.UserNotFound)
+		return nil, errors.BadRequest(reason.UserNotFound)
 	}
 
 	isPass := us.verifyPassword(ctx, req.OldPass, userInfo.Pass)
 	if !isPass {
-		return errors.BadRequest(reason.OldPasswordVerificationFailed)
+		return nil, errors.BadRequest(reason.OldPasswordVerificationFailed)
 	}
 	err = us.userRepo.UpdatePass(ctx, userInfo.ID, enpass)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	us.authService.RemoveTokensExceptCurrentUser(ctx, userInfo.ID, req.AccessToken)
-	return nil
+	if err = us.authService.ClearPasswordExpired(ctx, req.AccessToken); err != nil {
+		log.Error(err)
+	}
+	return nil, nil
 }
 
 // UpdateInfo update user info
@@ -310,26 +409,28 @@ func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoReq
 		return nil, err
 	}
 
-	if siteUsers.AllowUpdateUsername && len(req.Username) > 0 {
-		if checker.IsInvalidUsername(req.Username) {
-			return append(errFields, &validator.FormErrorField{
-				ErrorField: "username",
-				ErrorMsg:   reason.UsernameInvalid,
-			}), errors.BadRequest(reason.UsernameInvalid)
+	oldUserInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+
+	// the username and the display name are checked only when they are changed
+	usernameChanged := siteUsers.AllowUpdateUsername && len(req.Username) > 0 && req.Username != oldUserInfo.Username
+	displayNameChanged := siteUsers.AllowUpdateDisplayName && len(req.DisplayName) > 0 &&
+		req.DisplayName != oldUserInfo.DisplayName
+	if !req.IsAdmin && (usernameChanged || displayNameChanged) {
+		field := "display_name"
+		if usernameChanged {
+			field = "username"
 		}
-		// admin can use reserved username
-		if !req.IsAdmin && checker.IsReservedUsername(req.Username) {
-			return append(errFields, &validator.FormErrorField{
-				ErrorField: "username",
-				ErrorMsg:   reason.UsernameInvalid,
-			}), errors.BadRequest(reason.UsernameInvalid)
-		} else if req.IsAdmin && checker.IsUsersIgnorePath(req.Username) {
-			return append(errFields, &validator.FormErrorField{
-				ErrorField: "username",
-				ErrorMsg:   reason.UsernameInvalid,
-			}), errors.BadRequest(reason.UsernameInvalid)
+		if errFields, err = us.usernamePolicyService.CheckChangeLimit(ctx, field, req.UserID); err != nil {
+			return errFields, err
 		}
-
+	}
+	if usernameChanged {
 		userInfo, exist, err := us.userRepo.GetByUsername(ctx, req.Username)
 		if err != nil {
 			return nil, err
@@ -340,19 +441,61 @@ func (us *UserService) UpdateInfo(ctx context.Context, req *schema.UpdateInfoReq
 				ErrorMsg:   reason.UsernameDuplicate,
 			}), errors.BadRequest(reason.UsernameDuplicate)
 		}
+		if errFields, err = us.checkNewUsername(ctx, req); err != nil {
+			return errFields, err
+		}
+	}
+	if !req.IsAdmin && displayNameChanged {
+		errFields, err = us.usernamePolicyService.CheckDisplayName(ctx, "display_name", req.DisplayName, req.UserID)
+		if err != nil {
+			return errFields, err
+		}
+		errFields, err = us.bannedWordService.CheckUserName(ctx, "display_name", req.DisplayName, req.UserID)
+		if err != nil {
+			return errFields, err
+		}
 	}
 
-	oldUserInfo, exist, err := us.userRepo.GetByUserID(ctx, req.UserID)
+	if req.ProfileFields != nil {
+		errFields, err = us.profileFieldService.UpdateUserProfileFields(ctx, req.UserID, req.ProfileFields)
+		if err != nil {
+			return errFields, err
+		}
+	}
+
+	cond := us.formatUserInfoForUpdateInfo(oldUserInfo, req, siteUsers)
+	err = us.userRepo.UpdateInfo(ctx, cond)
 	if err != nil {
 		return nil, err
 	}
-	if !exist {
-		return nil, errors.BadRequest(reason.UserNotFound)
+	if err = us.usernamePolicyService.SaveNameChanges(ctx, req.UserID, oldUserInfo, cond); err != nil {
+		log.Errorf("save user name changes failed: %v", err)
 	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserUpdate,
+		UserID:    req.UserID,
+		ObjectID:  req.UserID,
+	})
+	return nil, nil
+}
 
-	cond := us.formatUserInfoForUpdateInfo(oldUserInfo, req, siteUsers)
-	err = us.userRepo.UpdateInfo(ctx, cond)
-	return nil, err
+// checkNewUsername the admin can use the username which is not allowed by the policy, such as the reserved one
+func (us *UserService) checkNewUsername(ctx context.Context, req *schema.UpdateInfoRequest) (
+	errFields []*validator.FormErrorField, err error) {
+	if req.IsAdmin {
+		if checker.IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username) {
+			return append(errFields, &validator.FormErrorField{
+				ErrorField: "username",
+				ErrorMsg:   reason.UsernameInvalid,
+			}), errors.BadRequest(reason.UsernameInvalid)
+		}
+		return nil, nil
+	}
+	errFields, err = us.usernamePolicyService.CheckUsername(ctx, "username", req.Username, req.UserID)
+	if err != nil {
+		return errFields, err
+	}
+	return us.bannedWordService.CheckUserName(ctx, "username", req.Username, req.UserID)
 }
 
 func (us *UserService) formatUserInfoForUpdateInfo(
@@ -396,6 +539,37 @@ func (us *UserService) UserUpdateInterface(ctx context.Context, req *schema.Upda
 	return us.userRepo.UpdateUserInterface(ctx, req.UserId, req.Language, req.ColorScheme)
 }
 
+// GetUserPreferences get the language, time zone and date format of the user
+func (us *UserService) GetUserPreferences(ctx context.Context, userID string) (
+	resp *schema.GetUserPreferencesResp, err error) {
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	resp = &schema.GetUserPreferencesResp{
+		Language:          userInfo.Language,
+		TimeZone:          userInfo.TimeZone,
+		DateFormat:        userInfo.DateFormat,
+		DateFormatOptions: make([]string, 0, len(constant.DateFormats)),
+	}
+	if len(resp.DateFormat) == 0 {
+		resp.DateFormat = constant.DefaultDateFormat
+	}
+	for dateFormat := range constant.DateFormats {
+		resp.DateFormatOptions = append(resp.DateFormatOptions, dateFormat)
+	}
+	sort.Strings(resp.DateFormatOptions)
+	return resp, nil
+}
+
+// UpdateUserPreferences update the language, time zone and date format of the user
+func (us *UserService) UpdateUserPreferences(ctx context.Context, req *schema.UpdateUserPreferencesReq) (err error) {
+	return us.userRepo.UpdateUserPreferences(ctx, req.UserID, req.Language, req.TimeZone, req.DateFormat)
+}
+
 // UserRegisterByEmail user register
 func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo *schema.UserRegisterReq) (
 	resp *schema.UserLoginResp, errFields []*validator.FormErrorField, err error,
@@ -411,14 +585,27 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 		})
 		return nil, errFields, errors.BadRequest(reason.EmailDuplicate)
 	}
-
-	userInfo := &entity.User{}
-	userInfo.EMail = registerUserInfo.Email
-	userInfo.DisplayName = registerUserInfo.Name
-	userInfo.Pass, err = us.encryptPassword(ctx, registerUserInfo.Pass)
+	errFields, err = us.emailDomainService.CheckSignupEmail(ctx, registerUserInfo.Email, registerUserInfo.IP)
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(errFields) > 0 {
+		return nil, errFields, errors.BadRequest(errFields[0].ErrorMsg)
+	}
+	errFields, err = us.passwordPolicyService.CheckPassword(ctx, "pass", registerUserInfo.Pass)
+	if err != nil {
+		return nil, errFields, err
+	}
+	errFields, err = us.bannedWordService.CheckUserName(ctx, "name", registerUserInfo.Name, "")
+	if err != nil {
+		return nil, errFields, err
+	}
+	errFields, err = us.usernamePolicyService.CheckDisplayName(ctx, "name", registerUserInfo.Name, "")
+	if err != nil {
+		return nil, errFields, err
+	}
+
+	userInfo := &entity.User{}
 	userInfo.Username, err = us.userCommonService.MakeUsername(ctx, registerUserInfo.Name)
 	if err != nil {
 		errFields = append(errFields, &validator.FormErrorField{
@@ -427,17 +614,37 @@ func (us *UserService) UserRegisterByEmail(ctx context.Context, registerUserInfo
 		})
 		return nil, errFields, err
 	}
+	errFields, err = us.usernamePolicyService.CheckUsername(ctx, "name", userInfo.Username, "")
+	if err != nil {
+		return nil, errFields, err
+	}
+	userInvitation, errFields, err := us.invitationService.GetRegisterInvitation(ctx, registerUserInfo.InvitationCode)
+	if err != nil {
+		return nil, errFields, err
+	}
+
+	userInfo.EMail = registerUserInfo.Email
+	userInfo.DisplayName = registerUserInfo.Name
+	userInfo.Pass, err = us.encryptPassword(ctx, registerUserInfo.Pass)
+	if err != nil {
+		return nil, nil, err
+	}
 	userInfo.IPInfo = registerUserInfo.IP
 	userInfo.MailStatus = entity.EmailStatusToBeVerified
 	userInfo.Status = entity.UserStatusAvailable
 	userInfo.LastLoginDate = time.Now()
-	err = us.userRepo.AddUser(ctx, userInfo)
+	errFields, err = us.invitationService.AddInvitedUser(ctx, userInvitation, userInfo)
 	if err != nil {
-		return nil, nil, err
+		return nil, errFields, err
 	}
 	if err := us.userNotificationConfigService.SetDefaultUserNotificationConfig(ctx, []string{userInfo.ID}); err != nil {
 		log.Errorf("set default user notification config failed, err: %v", err)
 	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserRegister,
+		UserID:    userInfo.ID,
+		ObjectID:  userInfo.ID,
+	})
 
 	// send email
 	data := &schema.EmailCodeContent{
@@ -604,18 +811,32 @@ func (us *UserService) UserChangeEmailSendCode(ctx context.Context, req *schema.
 		return resp, errors.BadRequest(reason.EmailDuplicate)
 	}
 
+	// If user's email already verified, the change must be authorized by the old email first,
+	// then the confirmation email will be sent to the new email.
+	if userInfo.MailStatus == entity.EmailStatusAvailable {
+		data := &schema.EmailCodeContent{
+			SourceType: schema.AuthorizeEmailChangeSourceType,
+			Email:      req.Email,
+			OldEmail:   userInfo.EMail,
+			UserID:     req.UserID,
+		}
+		code := uuid.NewString()
+		authorizeURL := fmt.Sprintf("%s/users/authorize-email-change?code=%s", us.getSiteUrl(ctx), code)
+		title, body, err := us.emailService.AuthorizeEmailChangeTemplate(ctx, req.Email, authorizeURL)
+		if err != nil {
+			return nil, err
+		}
+		go us.emailService.SendAndSaveCode(ctx, userInfo.ID, userInfo.EMail, title, body, code, data.ToJSONString())
+		return nil, nil
+	}
+
 	data := &schema.EmailCodeContent{
 		Email:  req.Email,
 		UserID: req.UserID,
 	}
 	code := uuid.NewString()
-	var title, body string
 	verifyEmailURL := fmt.Sprintf("%s/users/confirm-new-email?code=%s", us.getSiteUrl(ctx), code)
-	if userInfo.MailStatus == entity.EmailStatusToBeVerified {
-		title, body, err = us.emailService.RegisterTemplate(ctx, verifyEmailURL)
-	} else {
-		title, body, err = us.emailService.ChangeEmailTemplate(ctx, verifyEmailURL)
-	}
+	title, body, err := us.emailService.RegisterTemplate(ctx, verifyEmailURL)
 	if err != nil {
 		return nil, err
 	}
@@ -625,11 +846,53 @@ func (us *UserService) UserChangeEmailSendCode(ctx context.Context, req *schema.
 	return nil, nil
 }
 
+// UserChangeEmailAuthorize the old email authorized the email change, send the confirmation email to the new email
+func (us *UserService) UserChangeEmailAuthorize(ctx context.Context, content string) (err error) {
+	data := &schema.EmailCodeContent{}
+	err = data.FromJSONString(content)
+	if err != nil || data.SourceType != schema.AuthorizeEmailChangeSourceType {
+		return errors.BadRequest(reason.EmailVerifyURLExpired)
+	}
+
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, data.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	if userInfo.EMail != data.OldEmail {
+		return errors.BadRequest(reason.EmailVerifyURLExpired)
+	}
+	_, exist, err = us.userRepo.GetByEmail(ctx, data.Email)
+	if err != nil {
+		return err
+	}
+	if exist {
+		return errors.BadRequest(reason.EmailDuplicate)
+	}
+
+	confirmData := &schema.EmailCodeContent{
+		SourceType: schema.ConfirmNewEmailSourceType,
+		Email:      data.Email,
+		OldEmail:   data.OldEmail,
+		UserID:     data.UserID,
+	}
+	code := uuid.NewString()
+	verifyEmailURL := fmt.Sprintf("%s/users/confirm-new-email?code=%s", us.getSiteUrl(ctx), code)
+	title, body, err := us.emailService.ChangeEmailTemplate(ctx, verifyEmailURL)
+	if err != nil {
+		return err
+	}
+	go us.emailService.SendAndSaveCode(ctx, userInfo.ID, data.Email, title, body, code, confirmData.ToJSONString())
+	return nil
+}
+
 // UserChangeEmailVerify user change email verify code
 func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string) (resp *schema.UserLoginResp, err error) {
 	data := &schema.EmailCodeContent{}
 	err = data.FromJSONString(content)
-	if err != nil {
+	if err != nil || data.SourceType == schema.AuthorizeEmailChangeSourceType {
 		return nil, errors.BadRequest(reason.EmailVerifyURLExpired)
 	}
 
@@ -648,10 +911,22 @@ func (us *UserService) UserChangeEmailVerify(ctx context.Context, content string
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	// the email has been changed after the code was sent
+	if len(data.OldEmail) > 0 && userInfo.EMail != data.OldEmail {
+		return nil, errors.BadRequest(reason.EmailVerifyURLExpired)
+	}
 	err = us.userRepo.UpdateEmail(ctx, data.UserID, data.Email)
 	if err != nil {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	if len(data.OldEmail) > 0 {
+		title, body, err := us.emailService.EmailChangedTemplate(ctx, data.Email)
+		if err != nil {
+			log.Error(err)
+		} else {
+			go us.emailService.Send(ctx, data.OldEmail, title, body)
+		}
+	}
 	err = us.userRepo.UpdateEmailStatus(ctx, data.UserID, entity.EmailStatusAvailable)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEmailUserRepo(ctl *gomock.Controller) *mock.MockUserRepo {
+	users := map[string]*entity.User{
+		"1": {ID: "1", EMail: "old@example.com", MailStatus: entity.EmailStatusAvailable},
+		"2": {ID: "2", EMail: "taken@example.com", MailStatus: entity.EmailStatusAvailable},
+	}
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().GetByUserID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string) (*entity.User, bool, error) {
+			user, ok := users[userID]
+			return user, ok, nil
+		})
+	userRepo.EXPECT().GetByEmail(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, email string) (*entity.User, bool, error) {
+			for _, user := range users {
+				if user.EMail == email {
+					return user, true, nil
+				}
+			}
+			return nil, false, nil
+		})
+	return userRepo
+}
+
+func newTestEmailSiteInfoService(ctl *gomock.Controller) *mock.MockSiteInfoCommonService {
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteGeneral(gomock.Any()).AnyTimes().
+		Return(&schema.SiteGeneralResp{Name: "answer", SiteUrl: "https://example.com"}, nil)
+	return siteInfoService
+}
+
+func TestUserChangeEmailAuthorize(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us := &UserService{userRepo: newTestEmailUserRepo(ctl), siteInfoService: newTestEmailSiteInfoService(ctl)}
+	ctx := context.TODO()
+
+	cases := []struct {
+		name   string
+		data   *schema.EmailCodeContent
+		reason string
+	}{
+		{
+			name: "the confirmation code of the new email",
+			data: &schema.EmailCodeContent{SourceType: schema.ConfirmNewEmailSourceType, Email: "new@example.com",
+				OldEmail: "old@example.com", UserID: "1"},
+			reason: reason.EmailVerifyURLExpired,
+		},
+		{
+			name: "the email is changed after the code was sent",
+			data: &schema.EmailCodeContent{SourceType: schema.AuthorizeEmailChangeSourceType,
+				Email: "new@example.com", OldEmail: "older@example.com", UserID: "1"},
+			reason: reason.EmailVerifyURLExpired,
+		},
+		{
+			name: "the new email is used by another user",
+			data: &schema.EmailCodeContent{SourceType: schema.AuthorizeEmailChangeSourceType,
+				Email: "taken@example.com", OldEmail: "old@example.com", UserID: "1"},
+			reason: reason.EmailDuplicate,
+		},
+		{
+			name: "the user is not found",
+			data: &schema.EmailCodeContent{SourceType: schema.AuthorizeEmailChangeSourceType,
+				Email: "new@example.com", OldEmail: "old@example.com", UserID: "3"},
+			reason: reason.UserNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := us.UserChangeEmailAuthorize(ctx, c.data.ToJSONString())
+			require.Error(t, err)
+			assert.Equal(t, c.reason, err.(*errors.Error).Reason)
+		})
+	}
+}
+
+func TestUserChangeEmailVerify(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us := &UserService{userRepo: newTestEmailUserRepo(ctl), siteInfoService: newTestEmailSiteInfoService(ctl)}
+	ctx := context.TODO()
+
+	cases := []struct {
+		name   string
+		data   *schema.EmailCodeContent
+		reason string
+	}{
+		{
+			name: "the authorization code of the old email",
+			data: &schema.EmailCodeContent{SourceType: schema.AuthorizeEmailChangeSourceType,
+				Email: "new@example.com", OldEmail: "old@example.com", UserID: "1"},
+			reason: reason.EmailVerifyURLExpired,
+		},
+		{
+			name: "the email is changed after the code was sent",
+			data: &schema.EmailCodeContent{SourceType: schema.ConfirmNewEmailSourceType, Email: "new@example.com",
+				OldEmail: "older@example.com", UserID: "1"},
+			reason: reason.EmailVerifyURLExpired,
+		},
+		{
+			name: "the new email is used by another user",
+			data: &schema.EmailCodeContent{SourceType: schema.ConfirmNewEmailSourceType,
+				Email: "taken@example.com", OldEmail: "old@example.com", UserID: "1"},
+			reason: reason.EmailDuplicate,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := us.UserChangeEmailVerify(ctx, c.data.ToJSONString())
+			require.Error(t, err)
+			assert.Equal(t, c.reason, err.(*errors.Error).Reason)
+		})
+	}
+}
@@ -0,0 +1,285 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	userStatsCalendarDays = 365
+	userStatsTopTagsLimit = 10
+)
+
+// UserStatsRepo user stats repository
+type UserStatsRepo interface {
+	GetUserActivities(ctx context.Context, userID string, activityTypes []int, startTime time.Time) (
+		activities []*entity.Activity, err error)
+	GetUserAnswers(ctx context.Context, userID string) (answers []*entity.Answer, err error)
+	GetTagRelsByQuestionIDs(ctx context.Context, questionIDs []string) (tagRels []*entity.TagRel, err error)
+}
+
+// UserStatsService user stats service
+type UserStatsService struct {
+	userStatsRepo    UserStatsRepo
+	userRepo         usercommon.UserRepo
+	activityRepo     activity_common.ActivityRepo
+	tagCommonService *tagcommon.TagCommonService
+	data             *data.Data
+}
+
+// NewUserStatsService new user stats service
+func NewUserStatsService(
+	userStatsRepo UserStatsRepo,
+	userRepo usercommon.UserRepo,
+	activityRepo activity_common.ActivityRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	data *data.Data,
+) *UserStatsService {
+	return &UserStatsService{
+		userStatsRepo:    userStatsRepo,
+		userRepo:         userRepo,
+		activityRepo:     activityRepo,
+		tagCommonService: tagCommonService,
+		data:             data,
+	}
+}
+
+// GetUserStats get the contribution calendar of the last year, the top tags and the answer acceptance rate of user
+func (us *UserStatsService) GetUserStats(ctx context.Context, req *schema.GetUserStatsReq) (
+	resp *schema.GetUserStatsResp, err error) {
+	userInfo, exist, err := us.userRepo.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.NotFound(reason.UserNotFound)
+	}
+
+	cacheKey := constant.UserStatsCacheKeyPrefix + userInfo.ID
+	if resp = us.getFromCache(ctx, cacheKey); resp != nil {
+		return resp, nil
+	}
+
+	endTime := time.Now()
+	startTime := truncateToDay(endTime.AddDate(0, 0, -userStatsCalendarDays+1))
+	resp = &schema.GetUserStatsResp{
+		StartDate: startTime.Format("2006-01-02"),
+		EndDate:   endTime.Format("2006-01-02"),
+	}
+	resp.Contributions, err = us.getContributions(ctx, userInfo.ID, startTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, day := range resp.Contributions {
+		resp.TotalContributions += day.Total
+	}
+
+	answers, err := us.userStatsRepo.GetUserAnswers(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp.AnswerCount = len(answers)
+	for _, answer := range answers {
+		if answer.Accepted == schema.AnswerAcceptedEnable {
+			resp.AcceptedAnswerCount++
+		}
+	}
+	if resp.AnswerCount > 0 {
+		resp.AcceptanceRate = float64(resp.AcceptedAnswerCount) / float64(resp.AnswerCount)
+	}
+	resp.TopTags, err = us.getTopTags(ctx, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	us.setCache(ctx, cacheKey, resp)
+	return resp, nil
+}
+
+func (us *UserStatsService) getContributions(ctx context.Context, userID string, startTime time.Time) (
+	days []*schema.UserContributionDay, err error) {
+	activityTypeKeys := []constant.ActivityTypeKey{
+		constant.ActQuestionAsked, constant.ActAnswerAnswered, constant.ActQuestionEdited, constant.ActAnswerEdited,
+	}
+	activityTypeMapping := make(map[int]constant.ActivityTypeKey)
+	activityTypes := make([]int, 0, len(activityTypeKeys))
+	for _, key := range activityTypeKeys {
+		activityType, err := us.activityRepo.GetActivityTypeByConfigKey(ctx, string(key))
+		if err != nil {
+			return nil, err
+		}
+		activityTypeMapping[activityType] = key
+		activityTypes = append(activityTypes, activityType)
+	}
+
+	activities, err := us.userStatsRepo.GetUserActivities(ctx, userID, activityTypes, startTime)
+	if err != nil {
+		return nil, err
+	}
+	return bucketUserContributions(activities, activityTypeMapping, startTime.Location()), nil
+}
+
+func (us *UserStatsService) getTopTags(ctx context.Context, answers []*entity.Answer) (
+	topTags []*schema.UserTopTag, err error) {
+	topTags = make([]*schema.UserTopTag, 0)
+	questionAnswers := make(map[string][]*entity.Answer)
+	questionIDs := make([]string, 0)
+	for _, answer := range answers {
+		if _, ok := questionAnswers[answer.QuestionID]; !ok {
+			questionIDs = append(questionIDs, answer.QuestionID)
+		}
+		questionAnswers[answer.QuestionID] = append(questionAnswers[answer.QuestionID], answer)
+	}
+	if len(questionIDs) == 0 {
+		return topTags, nil
+	}
+
+	tagRels, err := us.userStatsRepo.GetTagRelsByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagMapping := make(map[string]*schema.UserTopTag)
+	for _, rel := range tagRels {
+		tag, ok := tagMapping[rel.TagID]
+		if !ok {
+			tag = &schema.UserTopTag{}
+			tagMapping[rel.TagID] = tag
+		}
+		for _, answer := range questionAnswers[rel.ObjectID] {
+			tag.Score += answer.VoteCount
+			tag.AnswerCount++
+		}
+	}
+
+	tagIDs := make([]string, 0, len(tagMapping))
+	for tagID := range tagMapping {
+		tagIDs = append(tagIDs, tagID)
+	}
+	sort.Slice(tagIDs, func(i, j int) bool {
+		a, b := tagMapping[tagIDs[i]], tagMapping[tagIDs[j]]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.AnswerCount != b.AnswerCount {
+			return a.AnswerCount > b.AnswerCount
+		}
+		return tagIDs[i] < tagIDs[j]
+	})
+	if len(tagIDs) > userStatsTopTagsLimit {
+		tagIDs = tagIDs[:userStatsTopTagsLimit]
+	}
+
+	tagList, err := us.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+	tagInfoMapping := make(map[string]*entity.Tag, len(tagList))
+	for _, tag := range tagList {
+		tagInfoMapping[tag.ID] = tag
+	}
+	for _, tagID := range tagIDs {
+		tagInfo, ok := tagInfoMapping[tagID]
+		if !ok {
+			continue
+		}
+		tag := tagMapping[tagID]
+		tag.SlugName = tagInfo.SlugName
+		tag.DisplayName = tagInfo.DisplayName
+		topTags = append(topTags, tag)
+	}
+	return topTags, nil
+}
+
+func (us *UserStatsService) getFromCache(ctx context.Context, cacheKey string) (resp *schema.GetUserStatsResp) {
+	cacheData, exist, err := us.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		log.Errorf("get user stats from cache failed: %s", err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+	resp = &schema.GetUserStatsResp{}
+	if err = json.Unmarshal([]byte(cacheData), resp); err != nil {
+		return nil
+	}
+	return resp
+}
+
+func (us *UserStatsService) setCache(ctx context.Context, cacheKey string, resp *schema.GetUserStatsResp) {
+	cacheData, _ := json.Marshal(resp)
+	err := us.data.Cache.SetString(ctx, cacheKey, string(cacheData), constant.UserStatsCacheTime)
+	if err != nil {
+		log.Errorf("set user stats cache failed: %s", err)
+	}
+}
+
+// bucketUserContributions count the activities by day and type, the days are in ascending order
+func bucketUserContributions(activities []*entity.Activity,
+	activityTypeMapping map[int]constant.ActivityTypeKey, loc *time.Location) []*schema.UserContributionDay {
+	dayMapping := make(map[string]*schema.UserContributionDay)
+	for _, act := range activities {
+		key, ok := activityTypeMapping[act.ActivityType]
+		if !ok {
+			continue
+		}
+		date := act.CreatedAt.In(loc).Format("2006-01-02")
+		day, ok := dayMapping[date]
+		if !ok {
+			day = &schema.UserContributionDay{Date: date}
+			dayMapping[date] = day
+		}
+		switch key {
+		case constant.ActQuestionAsked:
+			day.QuestionCount++
+		case constant.ActAnswerAnswered:
+			day.AnswerCount++
+		default:
+			day.EditCount++
+		}
+		day.Total++
+	}
+
+	days := make([]*schema.UserContributionDay, 0, len(dayMapping))
+	for _, day := range dayMapping {
+		days = append(days, day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketUserContributions(t *testing.T) {
+	mapping := map[int]constant.ActivityTypeKey{
+		1: constant.ActQuestionAsked,
+		2: constant.ActAnswerAnswered,
+		3: constant.ActAnswerEdited,
+	}
+	days := bucketUserContributions([]*entity.Activity{
+		{ActivityType: 2, CreatedAt: time.Date(2023, 10, 3, 1, 0, 0, 0, time.UTC)},
+		{ActivityType: 1, CreatedAt: time.Date(2023, 10, 1, 9, 0, 0, 0, time.UTC)},
+		{ActivityType: 3, CreatedAt: time.Date(2023, 10, 1, 23, 0, 0, 0, time.UTC)},
+		{ActivityType: 9, CreatedAt: time.Date(2023, 10, 2, 9, 0, 0, 0, time.UTC)},
+	}, mapping, time.UTC)
+	assert.Len(t, days, 2)
+	assert.Equal(t, "2023-10-01", days[0].Date)
+	assert.Equal(t, 1, days[0].QuestionCount)
+	assert.Equal(t, 1, days[0].EditCount)
+	assert.Equal(t, 2, days[0].Total)
+	assert.Equal(t, "2023-10-03", days[1].Date)
+	assert.Equal(t, 1, days[1].AnswerCount)
+	assert.Equal(t, 1, days[1].Total)
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxVerificationReminderCount stop reminding the user after sending these reminders
+	maxVerificationReminderCount  = 3
+	verificationReminderBatchSize = 100
+)
+
+// VerificationReminderCron remind the users who have not verified their email periodically.
+// The unverified users can not post anything until they verify their email.
+func (us *UserService) VerificationReminderCron(ctx context.Context) (err error) {
+	siteLogin, err := us.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return err
+	}
+	if siteLogin.EmailVerificationReminderDays <= 0 {
+		return nil
+	}
+	remindedBefore := time.Now().AddDate(0, 0, -siteLogin.EmailVerificationReminderDays)
+
+	for {
+		userList, err := us.userRepo.GetUnverifiedUsersToRemind(ctx, remindedBefore,
+			maxVerificationReminderCount, verificationReminderBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, userInfo := range userList {
+			// record first, so that the user will not be picked up again even if sending failed
+			if err := us.userRepo.UpdateVerificationReminded(ctx, userInfo.ID); err != nil {
+				return err
+			}
+			data := &schema.EmailCodeContent{
+				Email:  userInfo.EMail,
+				UserID: userInfo.ID,
+			}
+			code := uuid.NewString()
+			verifyEmailURL := fmt.Sprintf("%s/users/account-activation?code=%s", us.getSiteUrl(ctx), code)
+			title, body, err := us.emailService.VerificationReminderTemplate(ctx, verifyEmailURL)
+			if err != nil {
+				return err
+			}
+			us.emailService.SendAndSaveCodeWithTime(ctx, userInfo.ID, userInfo.EMail, title, body, code,
+				data.ToJSONString(), constant.UserVerificationReminderCodeCacheTime)
+		}
+		if len(userList) < verificationReminderBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testReminderEmailRepo struct {
+	export.EmailRepo
+	contents map[string]string
+}
+
+func (r *testReminderEmailRepo) SetCode(_ context.Context, userID, _, content string, _ time.Duration) error {
+	r.contents[userID] = content
+	return nil
+}
+
+// testReminderEmailTemplateRepo the default email templates are used
+type testReminderEmailTemplateRepo struct {
+	export.EmailTemplateRepo
+}
+
+func (testReminderEmailTemplateRepo) GetEmailTemplate(context.Context, string, string) (
+	*entity.EmailTemplate, bool, error) {
+	return nil, false, nil
+}
+
+// testReminderConfigRepo the email provider is not configured, so no email is really sent
+type testReminderConfigRepo struct {
+	config.ConfigRepo
+}
+
+func (testReminderConfigRepo) GetConfigByKey(_ context.Context, key string) (*entity.Config, error) {
+	return &entity.Config{Key: key, Value: "{}"}, nil
+}
+
+func TestVerificationReminderCron(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	users := []*entity.User{
+		{ID: "3", EMail: "a@example.com", MailStatus: entity.EmailStatusToBeVerified},
+		{ID: "4", EMail: "b@example.com", MailStatus: entity.EmailStatusToBeVerified},
+	}
+	userRepo := newTestEmailUserRepo(ctl)
+	userRepo.EXPECT().GetUnverifiedUsersToRemind(gomock.Any(), gomock.Any(), maxVerificationReminderCount,
+		verificationReminderBatchSize).Return(users, nil)
+	for _, user := range users {
+		userRepo.EXPECT().UpdateVerificationReminded(gomock.Any(), user.ID).Return(nil)
+	}
+	siteInfoService := newTestEmailSiteInfoService(ctl)
+	// the reminder is turned off at first
+	gomock.InOrder(
+		siteInfoService.EXPECT().GetSiteLogin(gomock.Any()).
+			Return(&schema.SiteLoginResp{EmailVerificationReminderDays: 0}, nil),
+		siteInfoService.EXPECT().GetSiteLogin(gomock.Any()).
+			Return(&schema.SiteLoginResp{EmailVerificationReminderDays: 7}, nil),
+	)
+	emailRepo := &testReminderEmailRepo{contents: make(map[string]string)}
+	us := &UserService{
+		userRepo:        userRepo,
+		siteInfoService: siteInfoService,
+		emailService: export.NewEmailService(config.NewConfigService(testReminderConfigRepo{}), emailRepo,
+			nil, testReminderEmailTemplateRepo{}, siteInfoService),
+	}
+	ctx := context.TODO()
+
+	require.NoError(t, us.VerificationReminderCron(ctx))
+	assert.Empty(t, emailRepo.contents)
+
+	require.NoError(t, us.VerificationReminderCron(ctx))
+	require.Len(t, emailRepo.contents, 2)
+	for _, user := range users {
+		data := &schema.EmailCodeContent{}
+		require.NoError(t, data.FromJSONString(emailRepo.contents[user.ID]))
+		assert.Equal(t, user.ID, data.UserID)
+		assert.Equal(t, user.EMail, data.Email)
+	}
+}
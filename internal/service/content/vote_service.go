@@ -41,6 +41,7 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/segmentfault/pacman/errors"
 )
 
@@ -51,6 +52,8 @@ type VoteRepo interface {
 	GetAndSaveVoteResult(ctx context.Context, objectID, objectType string) (up, down int64, err error)
 	ListUserVotes(ctx context.Context, userID string, page int, pageSize int, activityTypes []int) (
 		voteList []*entity.Activity, total int64, err error)
+	ListObjectVotes(ctx context.Context, objectID string, page int, pageSize int, activityTypes []int) (
+		voteList []*entity.Activity, total int64, err error)
 }
 
 // VoteService user service
@@ -62,6 +65,7 @@ type VoteService struct {
 	commentCommonRepo comment_common.CommentCommonRepo
 	objectService     *object_info.ObjService
 	activityRepo      activity_common.ActivityRepo
+	userCommon        *usercommon.UserCommon
 }
 
 func NewVoteService(
@@ -71,6 +75,7 @@ func NewVoteService(
 	answerRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
 	objectService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
 ) *VoteService {
 	return &VoteService{
 		voteRepo:          voteRepo,
@@ -79,6 +84,7 @@ func NewVoteService(
 		answerRepo:        answerRepo,
 		commentCommonRepo: commentCommonRepo,
 		objectService:     objectService,
+		userCommon:        userCommon,
 	}
 }
 
@@ -232,6 +238,59 @@ func (vs *VoteService) ListUserVotes(ctx context.Context, req schema.GetVoteWith
 	return pager.NewPageModel(total, votes), err
 }
 
+// ListObjectVotes list the voters of the question or answer
+func (vs *VoteService) ListObjectVotes(ctx context.Context, req *schema.GetObjectVotesReq) (
+	resp *pager.PageModel, err error) {
+	objInfo, err := vs.objectService.GetInfo(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	typeKeyMapping := map[string]string{}
+	switch objInfo.ObjectType {
+	case constant.QuestionObjectType:
+		typeKeyMapping[activity_type.QuestionVoteUp] = constant.ActVoteUp
+		typeKeyMapping[activity_type.QuestionVoteDown] = constant.ActVoteDown
+	case constant.AnswerObjectType:
+		typeKeyMapping[activity_type.AnswerVoteUp] = constant.ActVoteUp
+		typeKeyMapping[activity_type.AnswerVoteDown] = constant.ActVoteDown
+	default:
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	activityTypes := make([]int, 0)
+	voteTypeMapping := make(map[int]string, 0)
+	for typeKey, voteType := range typeKeyMapping {
+		cfg, err := vs.configService.GetConfigByKey(ctx, typeKey)
+		if err != nil {
+			continue
+		}
+		activityTypes = append(activityTypes, cfg.ID)
+		voteTypeMapping[cfg.ID] = voteType
+	}
+
+	voteList, total, err := vs.voteRepo.ListObjectVotes(ctx, req.ObjectID, req.Page, req.PageSize, activityTypes)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(voteList))
+	for _, voteInfo := range voteList {
+		userIDs = append(userIDs, voteInfo.UserID)
+	}
+	userInfoMapping, err := vs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	votes := make([]*schema.GetObjectVotesResp, 0, len(voteList))
+	for _, voteInfo := range voteList {
+		votes = append(votes, &schema.GetObjectVotesResp{
+			VoteType: voteTypeMapping[voteInfo.ActivityType],
+			VotedAt:  voteInfo.UpdatedAt.Unix(),
+			Voter:    userInfoMapping[voteInfo.UserID],
+		})
+	}
+	return pager.NewPageModel(total, votes), nil
+}
+
 func (vs *VoteService) createVoteOperationInfo(ctx context.Context,
 	userID string, voteUp bool, objectInfo *schema.SimpleObjectInfo) *schema.VoteOperationInfo {
 	// warp vote operation
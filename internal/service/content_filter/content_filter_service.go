@@ -0,0 +1,254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_filter
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ContentFilterService run the banned word rules and the content filter plugins before the content is saved
+type ContentFilterService struct {
+	bannedWordService *banned_word.BannedWordService
+}
+
+// NewContentFilterService new content filter service
+func NewContentFilterService(bannedWordService *banned_word.BannedWordService) *ContentFilterService {
+	return &ContentFilterService{bannedWordService: bannedWordService}
+}
+
+// FilterQuestionAdd filter the question before it is added
+func (cs *ContentFilterService) FilterQuestionAdd(ctx context.Context, req *schema.QuestionAdd) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.QuestionObjectType,
+		Action:     plugin.FilterActionAdd,
+		Title:      req.Title,
+		Content:    req.Content,
+		Tags:       tagSlugNames(req.Tags),
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.Title = content.Title
+	req.Content, req.HTML = applyContent(req.Content, req.HTML, content.Content)
+	req.Tags = applyTags(req.Tags, content.Tags)
+	return nil
+}
+
+// FilterQuestionUpdate filter the question before it is updated
+func (cs *ContentFilterService) FilterQuestionUpdate(ctx context.Context, req *schema.QuestionUpdate) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.QuestionObjectType,
+		Action:     plugin.FilterActionUpdate,
+		ObjectID:   req.ID,
+		Title:      req.Title,
+		Content:    req.Content,
+		Tags:       tagSlugNames(req.Tags),
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.Title = content.Title
+	req.Content, req.HTML = applyContent(req.Content, req.HTML, content.Content)
+	req.Tags = applyTags(req.Tags, content.Tags)
+	return nil
+}
+
+// FilterAnswerAdd filter the answer before it is added
+func (cs *ContentFilterService) FilterAnswerAdd(ctx context.Context, req *schema.AnswerAddReq) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.AnswerObjectType,
+		Action:     plugin.FilterActionAdd,
+		Content:    req.Content,
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.Content, req.HTML = applyContent(req.Content, req.HTML, content.Content)
+	return nil
+}
+
+// FilterAnswerUpdate filter the answer before it is updated
+func (cs *ContentFilterService) FilterAnswerUpdate(ctx context.Context, req *schema.AnswerUpdateReq) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.AnswerObjectType,
+		Action:     plugin.FilterActionUpdate,
+		ObjectID:   req.ID,
+		Content:    req.Content,
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.Content, req.HTML = applyContent(req.Content, req.HTML, content.Content)
+	return nil
+}
+
+// FilterCommentAdd filter the comment before it is added
+func (cs *ContentFilterService) FilterCommentAdd(ctx context.Context, req *schema.AddCommentReq) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.CommentObjectType,
+		Action:     plugin.FilterActionAdd,
+		Content:    req.OriginalText,
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.OriginalText, req.ParsedText = applyContent(req.OriginalText, req.ParsedText, content.Content)
+	return nil
+}
+
+// FilterCommentUpdate filter the comment before it is updated
+func (cs *ContentFilterService) FilterCommentUpdate(ctx context.Context, req *schema.UpdateCommentReq) (err error) {
+	content := &plugin.FilterContent{
+		ObjectType: constant.CommentObjectType,
+		Action:     plugin.FilterActionUpdate,
+		ObjectID:   req.CommentID,
+		Content:    req.OriginalText,
+		UserID:     req.UserID,
+	}
+	if err = cs.filter(ctx, content); err != nil {
+		return err
+	}
+	req.OriginalText, req.ParsedText = applyContent(req.OriginalText, req.ParsedText, content.Content)
+	return nil
+}
+
+// filter apply the banned word rules first, then run the content filter plugins. Only the new question
+// and answer can be sent to review, so the review rules reject the other contents.
+func (cs *ContentFilterService) filter(ctx context.Context, content *plugin.FilterContent) (err error) {
+	reviewable := content.Action == plugin.FilterActionAdd &&
+		(content.ObjectType == constant.QuestionObjectType || content.ObjectType == constant.AnswerObjectType)
+	err = cs.bannedWordService.FilterContent(ctx, content.ObjectType, content.UserID, reviewable,
+		&content.Title, &content.Content)
+	if err != nil {
+		return err
+	}
+	return cs.Filter(ctx, content)
+}
+
+// Filter run all enabled content filters in order of priority. Each filter works on a copy of the content,
+// the modification is only kept when the filter finishes normally, so a broken filter can't affect the others.
+func (cs *ContentFilterService) Filter(ctx context.Context, content *plugin.FilterContent) (err error) {
+	content.Language = string(handler.GetLangByCtx(ctx))
+
+	filters := make([]plugin.ContentFilter, 0)
+	_ = plugin.CallContentFilter(func(filter plugin.ContentFilter) error {
+		filters = append(filters, filter)
+		return nil
+	})
+	sort.SliceStable(filters, func(i, j int) bool {
+		return filters[i].FilterPriority() < filters[j].FilterPriority()
+	})
+
+	for _, filter := range filters {
+		filtered := copyFilterContent(content)
+		result, err := runFilter(filter, filtered)
+		if err != nil {
+			log.Errorf("content filter %s failed: %s", filter.Info().SlugName, err)
+			continue
+		}
+		if result != nil && result.Rejected {
+			log.Debugf("content is rejected by filter %s: %s", filter.Info().SlugName, result.Reason)
+			err = errors.BadRequest(reason.ContentRejectedByFilter)
+			if len(result.Reason) > 0 {
+				err = errors.BadRequest(reason.ContentRejectedByFilter).WithMsg(result.Reason)
+			}
+			return err
+		}
+		if len(filtered.Content) == 0 ||
+			(content.ObjectType == constant.QuestionObjectType && (len(filtered.Title) == 0 || len(filtered.Tags) == 0)) {
+			log.Errorf("content filter %s returned empty content, ignore the modification", filter.Info().SlugName)
+			continue
+		}
+		*content = *filtered
+	}
+	return nil
+}
+
+// runFilter run the filter and recover from its panic
+func runFilter(filter plugin.ContentFilter, content *plugin.FilterContent) (result *plugin.FilterResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return filter.FilterContent(content), nil
+}
+
+func copyFilterContent(content *plugin.FilterContent) *plugin.FilterContent {
+	filtered := *content
+	filtered.Tags = append([]string{}, content.Tags...)
+	return &filtered
+}
+
+func tagSlugNames(tags []*schema.TagItem) []string {
+	slugNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		slugNames = append(slugNames, tag.SlugName)
+	}
+	return slugNames
+}
+
+// applyContent render the html again if the content is modified by filters
+func applyContent(originalText, parsedText, filteredText string) (string, string) {
+	if originalText == filteredText {
+		return originalText, parsedText
+	}
+	return filteredText, converter.Markdown2HTML(filteredText)
+}
+
+// applyTags keep the tag items in the filtered slug names, the new tags added by filters
+// use the slug name as display name.
+func applyTags(tags []*schema.TagItem, slugNames []string) []*schema.TagItem {
+	mapping := make(map[string]*schema.TagItem, len(tags))
+	for _, tag := range tags {
+		mapping[tag.SlugName] = tag
+	}
+	filtered := make([]*schema.TagItem, 0, len(slugNames))
+	exist := make(map[string]bool, len(slugNames))
+	for _, slugName := range slugNames {
+		if exist[slugName] {
+			continue
+		}
+		exist[slugName] = true
+		if tag, ok := mapping[slugName]; ok {
+			filtered = append(filtered, tag)
+			continue
+		}
+		filtered = append(filtered, &schema.TagItem{SlugName: slugName, DisplayName: slugName})
+	}
+	return filtered
+}
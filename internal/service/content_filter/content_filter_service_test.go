@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_filter
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+type testFilter struct {
+	slugName string
+	priority int
+	filter   func(content *plugin.FilterContent) *plugin.FilterResult
+}
+
+func (f *testFilter) Info() plugin.Info {
+	return plugin.Info{SlugName: f.slugName}
+}
+
+func (f *testFilter) FilterPriority() int {
+	return f.priority
+}
+
+func (f *testFilter) FilterContent(content *plugin.FilterContent) *plugin.FilterResult {
+	return f.filter(content)
+}
+
+func init() {
+	filters := []*testFilter{
+		{slugName: "test_filter_suffix", priority: 20, filter: func(content *plugin.FilterContent) *plugin.FilterResult {
+			content.Content += " [suffix]"
+			return nil
+		}},
+		{slugName: "test_filter_panic", priority: 10, filter: func(content *plugin.FilterContent) *plugin.FilterResult {
+			content.Content = "broken"
+			panic("something wrong")
+		}},
+		{slugName: "test_filter_upper", priority: 0, filter: func(content *plugin.FilterContent) *plugin.FilterResult {
+			if strings.Contains(content.Content, "forbidden") {
+				return &plugin.FilterResult{Rejected: true, Reason: "forbidden word"}
+			}
+			content.Content = strings.ToUpper(content.Content)
+			return nil
+		}},
+	}
+	for _, f := range filters {
+		plugin.Register(f)
+		plugin.StatusManager.Enable(f.slugName, true)
+	}
+}
+
+func TestContentFilterService_Filter(t *testing.T) {
+	cs := NewContentFilterService(nil)
+
+	content := &plugin.FilterContent{ObjectType: constant.AnswerObjectType, Content: "hello"}
+	err := cs.Filter(context.TODO(), content)
+	assert.NoError(t, err)
+	assert.Equal(t, "HELLO [suffix]", content.Content)
+
+	content = &plugin.FilterContent{ObjectType: constant.AnswerObjectType, Content: "forbidden"}
+	err = cs.Filter(context.TODO(), content)
+	assert.Error(t, err)
+}
+
+func TestApplyTags(t *testing.T) {
+	tags := []*schema.TagItem{
+		{SlugName: "go", DisplayName: "Go"},
+		{SlugName: "java", DisplayName: "Java"},
+	}
+	filtered := applyTags(tags, []string{"go", "rust", "go"})
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "Go", filtered[0].DisplayName)
+	assert.Equal(t, "rust", filtered[1].DisplayName)
+}
@@ -0,0 +1,252 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_limit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/grapheme"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	kindTitle   = "title"
+	kindBody    = "body"
+	kindComment = "comment"
+)
+
+// content the text of the form field checked against the limit of its kind
+type content struct {
+	field string
+	kind  string
+	text  string
+}
+
+// ContentLimitService check the length of the titles, the bodies and the comments against the limits
+// set by the admin, the users of some roles may have their own limits
+type ContentLimitService struct {
+	siteInfoRepo       siteinfo_common.SiteInfoRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewContentLimitService new content limit service
+func NewContentLimitService(
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRoleRelService *role.UserRoleRelService,
+) *ContentLimitService {
+	return &ContentLimitService{
+		siteInfoRepo:       siteInfoRepo,
+		siteInfoService:    siteInfoService,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// GetContentLimits get the content limits, the default limits are used before the admin changes them
+func (cs *ContentLimitService) GetContentLimits(ctx context.Context) (resp *schema.SiteContentLimitsReq, err error) {
+	resp = &schema.SiteContentLimitsReq{}
+	if err = cs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeContentLimits, resp); err != nil {
+		return nil, err
+	}
+	defaults := schema.DefaultContentLimits()
+	if resp.Title == nil {
+		resp.Title = defaults.Title
+	}
+	if resp.Body == nil {
+		resp.Body = defaults.Body
+	}
+	if resp.Comment == nil {
+		resp.Comment = defaults.Comment
+	}
+	return resp, nil
+}
+
+// UpdateContentLimits update the content limits
+func (cs *ContentLimitService) UpdateContentLimits(ctx context.Context, req *schema.SiteContentLimitsReq) (err error) {
+	if !checkLimits(req.Title, req.Body, req.Comment) {
+		return errors.BadRequest(reason.ContentLimitsInvalid)
+	}
+	for _, override := range req.RoleOverrides {
+		if !checkLimits(override.Title, override.Body, override.Comment) {
+			return errors.BadRequest(reason.ContentLimitsInvalid)
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeContentLimits,
+		Content: string(content),
+	}
+	return cs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeContentLimits, data)
+}
+
+// GetUserContentLimits get the content limits of the user, the limits of the role override the site ones
+func (cs *ContentLimitService) GetUserContentLimits(ctx context.Context, userID string) (
+	resp *schema.ContentLimitsResp, err error) {
+	limits, err := cs.GetContentLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.ContentLimitsResp{Title: limits.Title, Body: limits.Body, Comment: limits.Comment}
+	if len(limits.RoleOverrides) == 0 || len(userID) == 0 {
+		return resp, nil
+	}
+	roleID, err := cs.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return mergeRoleOverride(resp, limits.RoleOverrides, roleID), nil
+}
+
+// CheckQuestion check the title and the body of the question
+func (cs *ContentLimitService) CheckQuestion(ctx context.Context, userID, title, body string) (
+	errFields []*validator.FormErrorField, err error) {
+	return cs.check(ctx, userID,
+		&content{field: "title", kind: kindTitle, text: title},
+		&content{field: "content", kind: kindBody, text: body})
+}
+
+// CheckQuestionByAnswer check the question and the answer posted with it
+func (cs *ContentLimitService) CheckQuestionByAnswer(ctx context.Context, userID, title, body, answer string) (
+	errFields []*validator.FormErrorField, err error) {
+	return cs.check(ctx, userID,
+		&content{field: "title", kind: kindTitle, text: title},
+		&content{field: "content", kind: kindBody, text: body},
+		&content{field: "answer_content", kind: kindBody, text: answer})
+}
+
+// CheckAnswer check the body of the answer
+func (cs *ContentLimitService) CheckAnswer(ctx context.Context, userID, body string) (
+	errFields []*validator.FormErrorField, err error) {
+	return cs.check(ctx, userID, &content{field: "content", kind: kindBody, text: body})
+}
+
+// CheckComment check the text of the comment
+func (cs *ContentLimitService) CheckComment(ctx context.Context, userID, text string) (
+	errFields []*validator.FormErrorField, err error) {
+	return cs.check(ctx, userID, &content{field: "original_text", kind: kindComment, text: text})
+}
+
+// check the contents against the limits of the user, the form errors are returned with the bad request error.
+// The empty content is left to the request validation.
+func (cs *ContentLimitService) check(ctx context.Context, userID string, contents ...*content) (
+	errFields []*validator.FormErrorField, err error) {
+	limits, err := cs.GetUserContentLimits(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	lang := handler.GetLangByCtx(ctx)
+	var errReason string
+	for _, c := range contents {
+		text := strings.TrimSpace(c.text)
+		if len(text) == 0 {
+			continue
+		}
+		limit := limitOfKind(limits, c.kind)
+		limitReason := checkLength(text, limit)
+		if len(limitReason) == 0 {
+			continue
+		}
+		if len(errReason) == 0 {
+			errReason = limitReason
+		}
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: c.field,
+			ErrorMsg: translator.TrWithData(lang, limitReason,
+				&schema.ContentLengthTrTplData{Min: limit.Min, Max: limit.Max}),
+		})
+	}
+	if len(errFields) > 0 {
+		return errFields, errors.BadRequest(errReason)
+	}
+	return nil, nil
+}
+
+// checkLength get the reason if the length of the text is out of the limit
+func checkLength(text string, limit *schema.ContentLengthLimit) string {
+	length := grapheme.Count(text)
+	if length < limit.Min {
+		return reason.ContentTooShort
+	}
+	if length > limit.Max {
+		return reason.ContentTooLong
+	}
+	return ""
+}
+
+func limitOfKind(limits *schema.ContentLimitsResp, kind string) *schema.ContentLengthLimit {
+	switch kind {
+	case kindTitle:
+		return limits.Title
+	case kindBody:
+		return limits.Body
+	default:
+		return limits.Comment
+	}
+}
+
+// mergeRoleOverride use the limits set for the role instead of the site ones
+func mergeRoleOverride(limits *schema.ContentLimitsResp, overrides []*schema.ContentLimitsRoleOverride,
+	roleID int) *schema.ContentLimitsResp {
+	for _, override := range overrides {
+		if override.RoleID != roleID {
+			continue
+		}
+		if override.Title != nil {
+			limits.Title = override.Title
+		}
+		if override.Body != nil {
+			limits.Body = override.Body
+		}
+		if override.Comment != nil {
+			limits.Comment = override.Comment
+		}
+	}
+	return limits
+}
+
+// checkLimits the min is not greater than the max and the max does not exceed the storage limit,
+// the limit not set is skipped
+func checkLimits(title, body, comment *schema.ContentLengthLimit) bool {
+	for _, item := range []struct {
+		limit *schema.ContentLengthLimit
+		cap   int
+	}{
+		{title, schema.ContentLimitTitleCap},
+		{body, schema.ContentLimitBodyCap},
+		{comment, schema.ContentLimitCommentCap},
+	} {
+		if item.limit != nil && (item.limit.Min > item.limit.Max || item.limit.Max > item.cap) {
+			return false
+		}
+	}
+	return true
+}
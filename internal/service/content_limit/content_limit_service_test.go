@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package content_limit
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckLength(t *testing.T) {
+	limit := &schema.ContentLengthLimit{Min: 3, Max: 5}
+	assert.Equal(t, reason.ContentTooShort, checkLength("ab", limit))
+	assert.Equal(t, "", checkLength("你好吗", limit))
+	assert.Equal(t, "", checkLength("👨‍👩‍👧‍👦👍🏽🇨🇳", limit))
+	assert.Equal(t, reason.ContentTooLong, checkLength("你好吗你好吗", limit))
+}
+
+func TestMergeRoleOverride(t *testing.T) {
+	defaults := schema.DefaultContentLimits()
+	overrides := []*schema.ContentLimitsRoleOverride{
+		{RoleID: 2, Body: &schema.ContentLengthLimit{Min: 0, Max: 100}},
+	}
+
+	limits := mergeRoleOverride(&schema.ContentLimitsResp{
+		Title: defaults.Title, Body: defaults.Body, Comment: defaults.Comment}, overrides, 1)
+	assert.Equal(t, defaults.Body, limits.Body)
+
+	limits = mergeRoleOverride(&schema.ContentLimitsResp{
+		Title: defaults.Title, Body: defaults.Body, Comment: defaults.Comment}, overrides, 2)
+	assert.Equal(t, 100, limits.Body.Max)
+	assert.Equal(t, defaults.Title, limits.Title)
+}
+
+func TestCheckLimits(t *testing.T) {
+	defaults := schema.DefaultContentLimits()
+	assert.True(t, checkLimits(defaults.Title, defaults.Body, defaults.Comment))
+	assert.True(t, checkLimits(nil, nil, nil))
+	assert.False(t, checkLimits(&schema.ContentLengthLimit{Min: 10, Max: 5}, nil, nil))
+	assert.False(t, checkLimits(&schema.ContentLengthLimit{Min: 1, Max: schema.ContentLimitTitleCap + 1}, nil, nil))
+}
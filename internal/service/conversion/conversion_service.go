@@ -0,0 +1,247 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/comment_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// maxCommentLength same as the length limit of the comment content
+const maxCommentLength = 600
+
+// ConversionRepo conversion repository
+type ConversionRepo interface {
+	ConvertCommentToAnswer(ctx context.Context, commentID string, answer *entity.Answer) (err error)
+	ConvertAnswerToComment(ctx context.Context, answerID string, comment *entity.Comment) (err error)
+}
+
+// ConversionService convert the misplaced comments and answers by the moderators
+type ConversionService struct {
+	conversionRepo       ConversionRepo
+	commentCommonRepo    comment_common.CommentCommonRepo
+	answerRepo           answercommon.AnswerRepo
+	questionRepo         questioncommon.QuestionRepo
+	questionCommon       *questioncommon.QuestionCommon
+	userCommon           *usercommon.UserCommon
+	revisionService      *revision_common.RevisionService
+	activityQueueService activity_queue.ActivityQueueService
+}
+
+// NewConversionService new conversion service
+func NewConversionService(
+	conversionRepo ConversionRepo,
+	commentCommonRepo comment_common.CommentCommonRepo,
+	answerRepo answercommon.AnswerRepo,
+	questionRepo questioncommon.QuestionRepo,
+	questionCommon *questioncommon.QuestionCommon,
+	userCommon *usercommon.UserCommon,
+	revisionService *revision_common.RevisionService,
+	activityQueueService activity_queue.ActivityQueueService,
+) *ConversionService {
+	return &ConversionService{
+		conversionRepo:       conversionRepo,
+		commentCommonRepo:    commentCommonRepo,
+		answerRepo:           answerRepo,
+		questionRepo:         questionRepo,
+		questionCommon:       questionCommon,
+		userCommon:           userCommon,
+		revisionService:      revisionService,
+		activityQueueService: activityQueueService,
+	}
+}
+
+// ConvertCommentToAnswer convert the comment into an answer of the question,
+// the author and the created time are kept, the likes of the comment are not votes so they are not carried.
+func (cs *ConversionService) ConvertCommentToAnswer(ctx context.Context, req *schema.ConvertCommentToAnswerReq) (
+	resp *schema.ConvertCommentToAnswerResp, err error) {
+	commentInfo, exist, err := cs.commentCommonRepo.GetComment(ctx, req.CommentID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.CommentNotFound)
+	}
+	question, exist, err := cs.questionRepo.GetQuestion(ctx, commentInfo.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+
+	answer := &entity.Answer{
+		UserID:         commentInfo.UserID,
+		QuestionID:     commentInfo.QuestionID,
+		OriginalText:   commentInfo.OriginalText,
+		ParsedText:     commentInfo.ParsedText,
+		Accepted:       schema.AnswerAcceptedFailed,
+		RevisionID:     "0",
+		LastEditUserID: "0",
+		Status:         entity.AnswerStatusAvailable,
+		CreatedAt:      commentInfo.CreatedAt,
+	}
+	if err = cs.conversionRepo.ConvertCommentToAnswer(ctx, commentInfo.ID, answer); err != nil {
+		return nil, err
+	}
+	cs.syncAnswerSearch(ctx, answer.ID, entity.AnswerStatusAvailable)
+	cs.updateAnswerCount(ctx, commentInfo.QuestionID, answer.UserID)
+
+	revisionDTO := &schema.AddRevisionDTO{
+		UserID:   answer.UserID,
+		ObjectID: answer.ID,
+	}
+	infoJSON, _ := json.Marshal(answer)
+	revisionDTO.Content = string(infoJSON)
+	revisionID, err := cs.revisionService.AddRevision(ctx, revisionDTO, true)
+	if err != nil {
+		return nil, err
+	}
+	cs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           answer.UserID,
+		ObjectID:         answer.ID,
+		OriginalObjectID: answer.ID,
+		ActivityTypeKey:  constant.ActAnswerAnswered,
+		RevisionID:       revisionID,
+	})
+	cs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         answer.ID,
+		OriginalObjectID: answer.ID,
+		ActivityTypeKey:  constant.ActAnswerConvertedFromComment,
+	})
+	resp = &schema.ConvertCommentToAnswerResp{AnswerID: answer.ID}
+	if handler.GetEnableShortID(ctx) {
+		resp.AnswerID = uid.EnShortID(answer.ID)
+	}
+	return resp, nil
+}
+
+// ConvertAnswerToComment convert the answer into a comment under the question or another answer,
+// the author and the created time are kept, the up votes become the likes of the comment and the reputation
+// of the votes is rolled back, the comments of the answer are moved along.
+func (cs *ConversionService) ConvertAnswerToComment(ctx context.Context, req *schema.ConvertAnswerToCommentReq) (
+	resp *schema.ConvertAnswerToCommentResp, err error) {
+	answer, exist, err := cs.answerRepo.GetAnswer(ctx, req.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answer.Status != entity.AnswerStatusAvailable {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+	if answer.Accepted == schema.AnswerAcceptedEnable {
+		return nil, errors.BadRequest(reason.ConversionAnswerAccepted)
+	}
+	if utf8.RuneCountInString(answer.OriginalText) > maxCommentLength {
+		return nil, errors.BadRequest(reason.ConversionContentTooLong)
+	}
+	answerID := uid.DeShortID(answer.ID)
+	questionID := uid.DeShortID(answer.QuestionID)
+	objectID, err := cs.getCommentObjectID(ctx, questionID, answerID, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	commentInfo := &entity.Comment{
+		UserID:       answer.UserID,
+		ObjectID:     objectID,
+		QuestionID:   questionID,
+		OriginalText: answer.OriginalText,
+		ParsedText:   answer.ParsedText,
+		Status:       entity.CommentStatusAvailable,
+		CreatedAt:    answer.CreatedAt,
+	}
+	if err = cs.conversionRepo.ConvertAnswerToComment(ctx, answerID, commentInfo); err != nil {
+		return nil, err
+	}
+	cs.syncAnswerSearch(ctx, answerID, entity.AnswerStatusDeleted)
+	cs.updateAnswerCount(ctx, questionID, answer.UserID)
+
+	cs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         answerID,
+		OriginalObjectID: answerID,
+		ActivityTypeKey:  constant.ActAnswerConvertedToComment,
+	})
+	return &schema.ConvertAnswerToCommentResp{CommentID: commentInfo.ID}, nil
+}
+
+// getCommentObjectID the converted comment belongs to the question or another available answer of the question
+func (cs *ConversionService) getCommentObjectID(ctx context.Context, questionID, answerID, objectID string) (
+	string, error) {
+	if len(objectID) == 0 {
+		return questionID, nil
+	}
+	objectID = uid.DeShortID(objectID)
+	if objectID == questionID {
+		return questionID, nil
+	}
+	if objectID == answerID {
+		return "", errors.BadRequest(reason.ConversionTargetInvalid)
+	}
+	target, exist, err := cs.answerRepo.GetAnswer(ctx, objectID)
+	if err != nil {
+		return "", err
+	}
+	if !exist || target.Status != entity.AnswerStatusAvailable || uid.DeShortID(target.QuestionID) != questionID {
+		return "", errors.BadRequest(reason.ConversionTargetInvalid)
+	}
+	return objectID, nil
+}
+
+// syncAnswerSearch the conversion is written in one transaction, so the answer is synced to the search plugin after it
+func (cs *ConversionService) syncAnswerSearch(ctx context.Context, answerID string, status int) {
+	if err := cs.answerRepo.UpdateAnswerStatus(ctx, answerID, status); err != nil {
+		log.Errorf("sync answer %s to search failed: %v", answerID, err)
+	}
+}
+
+func (cs *ConversionService) updateAnswerCount(ctx context.Context, questionID, userID string) {
+	if err := cs.questionCommon.UpdateAnswerCount(ctx, questionID); err != nil {
+		log.Errorf("update question answer count failed: %v", err)
+	}
+	userAnswerCount, err := cs.answerRepo.GetCountByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user answer count failed: %v", err)
+		return
+	}
+	if err = cs.userCommon.UpdateAnswerCount(ctx, userID, int(userAnswerCount)); err != nil {
+		log.Errorf("update user answer count failed: %v", err)
+	}
+}
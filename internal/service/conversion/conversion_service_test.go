@@ -0,0 +1,242 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package conversion
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/revision"
+	"github.com/apache/incubator-answer/internal/service/revision_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCreatedAt = time.Date(2024, 1, 30, 8, 0, 0, 0, time.UTC)
+
+type testConversionRepo struct {
+	convertedCommentID string
+	convertedAnswerID  string
+	answer             *entity.Answer
+	comment            *entity.Comment
+}
+
+func (r *testConversionRepo) ConvertCommentToAnswer(_ context.Context, commentID string, answer *entity.Answer) error {
+	answer.ID = "10020000000000009"
+	r.convertedCommentID, r.answer = commentID, answer
+	return nil
+}
+
+func (r *testConversionRepo) ConvertAnswerToComment(_ context.Context, answerID string, comment *entity.Comment) error {
+	comment.ID = "10040000000000009"
+	r.convertedAnswerID, r.comment = answerID, comment
+	return nil
+}
+
+type testCommentCommonRepo struct {
+	comment_common.CommentCommonRepo
+	comments map[string]*entity.Comment
+}
+
+func (r *testCommentCommonRepo) GetComment(_ context.Context, commentID string) (*entity.Comment, bool, error) {
+	comment, ok := r.comments[commentID]
+	return comment, ok, nil
+}
+
+type testRevisionRepo struct {
+	revision.RevisionRepo
+	revisions []*entity.Revision
+}
+
+func (r *testRevisionRepo) AddRevision(_ context.Context, rev *entity.Revision, _ bool) error {
+	rev.ID = "1"
+	r.revisions = append(r.revisions, rev)
+	return nil
+}
+
+// testConversion the conversion service with the changes made through the mocked repositories recorded
+type testConversion struct {
+	service        *ConversionService
+	repo           *testConversionRepo
+	revisionRepo   *testRevisionRepo
+	answerStatuses map[string]int
+	answerCounts   map[string]int
+	activityMsgs   []*schema.ActivityMsg
+}
+
+func newTestConversion(ctl *gomock.Controller) *testConversion {
+	tc := &testConversion{
+		repo:           &testConversionRepo{},
+		revisionRepo:   &testRevisionRepo{},
+		answerStatuses: make(map[string]int),
+		answerCounts:   make(map[string]int),
+	}
+	answers := map[string]*entity.Answer{
+		"10020000000000001": {ID: "10020000000000001", QuestionID: "10010000000000001", UserID: "2",
+			OriginalText: "answer", ParsedText: "<p>answer</p>", VoteCount: 3,
+			Status: entity.AnswerStatusAvailable, CreatedAt: testCreatedAt},
+		"10020000000000002": {ID: "10020000000000002", QuestionID: "10010000000000001", UserID: "3",
+			Status: entity.AnswerStatusAvailable},
+		"10020000000000003": {ID: "10020000000000003", QuestionID: "10010000000000002", UserID: "3",
+			Status: entity.AnswerStatusAvailable},
+		"10020000000000004": {ID: "10020000000000004", QuestionID: "10010000000000001", UserID: "3",
+			Accepted: schema.AnswerAcceptedEnable, Status: entity.AnswerStatusAvailable},
+		"10020000000000005": {ID: "10020000000000005", QuestionID: "10010000000000001", UserID: "3",
+			OriginalText: strings.Repeat("a", maxCommentLength+1), Status: entity.AnswerStatusAvailable},
+	}
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetAnswer(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, answerID string) (*entity.Answer, bool, error) {
+			answer, ok := answers[answerID]
+			return answer, ok, nil
+		})
+	answerRepo.EXPECT().UpdateAnswerStatus(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, answerID string, status int) error {
+			tc.answerStatuses[answerID] = status
+			return nil
+		})
+	answerRepo.EXPECT().GetCountByQuestionID(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(1), nil)
+	answerRepo.EXPECT().GetCountByUserID(gomock.Any(), gomock.Any()).AnyTimes().Return(int64(1), nil)
+
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, questionID string) (*entity.Question, bool, error) {
+			if questionID != "10010000000000001" {
+				return nil, false, nil
+			}
+			return &entity.Question{ID: questionID, Status: entity.QuestionStatusAvailable}, true, nil
+		})
+	questionRepo.EXPECT().UpdateAnswerCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, questionID string, num int) error {
+			tc.answerCounts[questionID] = num
+			return nil
+		})
+
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().UpdateAnswerCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
+	activityQueue := mock.NewMockActivityQueueService(ctl)
+	activityQueue.EXPECT().Send(gomock.Any(), gomock.Any()).AnyTimes().
+		Do(func(_ context.Context, msg *schema.ActivityMsg) {
+			tc.activityMsgs = append(tc.activityMsgs, msg)
+		})
+
+	commentCommonRepo := &testCommentCommonRepo{comments: map[string]*entity.Comment{
+		"10040000000000001": {ID: "10040000000000001", UserID: "2", ObjectID: "10010000000000001",
+			QuestionID: "10010000000000001", OriginalText: "comment", ParsedText: "<p>comment</p>",
+			CreatedAt: testCreatedAt},
+	}}
+	tc.service = NewConversionService(tc.repo, commentCommonRepo, answerRepo, questionRepo,
+		questioncommon.NewQuestionCommon(questionRepo, answerRepo, nil, nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil),
+		usercommon.NewUserCommon(userRepo, nil, nil, nil),
+		revision_common.NewRevisionService(tc.revisionRepo, nil),
+		activityQueue)
+	return tc
+}
+
+func TestConvertCommentToAnswer(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	tc := newTestConversion(ctl)
+
+	_, err := tc.service.ConvertCommentToAnswer(context.TODO(),
+		&schema.ConvertCommentToAnswerReq{CommentID: "10040000000000002", UserID: "1"})
+	assert.Error(t, err)
+	assert.Empty(t, tc.repo.convertedCommentID)
+
+	resp, err := tc.service.ConvertCommentToAnswer(context.TODO(),
+		&schema.ConvertCommentToAnswerReq{CommentID: "10040000000000001", UserID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "10020000000000009", resp.AnswerID)
+	assert.Equal(t, "10040000000000001", tc.repo.convertedCommentID)
+	assert.Equal(t, "2", tc.repo.answer.UserID)
+	assert.Equal(t, "<p>comment</p>", tc.repo.answer.ParsedText)
+	assert.Equal(t, testCreatedAt, tc.repo.answer.CreatedAt)
+
+	assert.Equal(t, entity.AnswerStatusAvailable, tc.answerStatuses["10020000000000009"])
+	assert.Equal(t, 1, tc.answerCounts["10010000000000001"])
+	assert.Len(t, tc.revisionRepo.revisions, 1)
+	require.Len(t, tc.activityMsgs, 2)
+	assert.Equal(t, constant.ActAnswerAnswered, tc.activityMsgs[0].ActivityTypeKey)
+	assert.Equal(t, "1", tc.activityMsgs[0].RevisionID)
+	assert.Equal(t, constant.ActAnswerConvertedFromComment, tc.activityMsgs[1].ActivityTypeKey)
+	assert.Equal(t, int64(1), tc.activityMsgs[1].TriggerUserID)
+}
+
+func TestConvertAnswerToComment(t *testing.T) {
+	tests := []struct {
+		name     string
+		answerID string
+		objectID string
+		wantErr  bool
+		// wantObjectID the object which the converted comment belongs to
+		wantObjectID string
+	}{
+		{name: "question by default", answerID: "10020000000000001", wantObjectID: "10010000000000001"},
+		{name: "another answer", answerID: "10020000000000001", objectID: "10020000000000002",
+			wantObjectID: "10020000000000002"},
+		{name: "answer not found", answerID: "10020000000000008", wantErr: true},
+		{name: "accepted answer", answerID: "10020000000000004", wantErr: true},
+		{name: "too long", answerID: "10020000000000005", wantErr: true},
+		{name: "the answer itself", answerID: "10020000000000001", objectID: "10020000000000001", wantErr: true},
+		{name: "answer of another question", answerID: "10020000000000001", objectID: "10020000000000003",
+			wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			tc := newTestConversion(ctl)
+			resp, err := tc.service.ConvertAnswerToComment(context.TODO(), &schema.ConvertAnswerToCommentReq{
+				AnswerID: tt.answerID,
+				ObjectID: tt.objectID,
+				UserID:   "1",
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Empty(t, tc.repo.convertedAnswerID)
+				assert.Empty(t, tc.activityMsgs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "10040000000000009", resp.CommentID)
+			assert.Equal(t, tt.answerID, tc.repo.convertedAnswerID)
+			assert.Equal(t, tt.wantObjectID, tc.repo.comment.ObjectID)
+			assert.Equal(t, "2", tc.repo.comment.UserID)
+			assert.Equal(t, testCreatedAt, tc.repo.comment.CreatedAt)
+			// the likes of the comment are counted by the repo from the up votes
+			assert.Zero(t, tc.repo.comment.VoteCount)
+
+			assert.Equal(t, entity.AnswerStatusDeleted, tc.answerStatuses[tt.answerID])
+			require.Len(t, tc.activityMsgs, 1)
+			assert.Equal(t, constant.ActAnswerConvertedToComment, tc.activityMsgs[0].ActivityTypeKey)
+		})
+	}
+}
@@ -275,7 +275,7 @@ func (ds *dashboardService) smtpStatus(ctx context.Context) (smtpStatus string)
 		log.Errorf("parsing email config failed: %s", err)
 		return "disabled"
 	}
-	if ec.SMTPHost != "" {
+	if ec.IsConfigured() {
 		smtpStatus = "enabled"
 	}
 	return smtpStatus
@@ -0,0 +1,186 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data_retention
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+// DataRetentionRepo data retention repository
+type DataRetentionRepo interface {
+	UpdateIPs(ctx context.Context, before time.Time, convert func(ip string) string, heldUserIDs []string) (
+		affected int64, err error)
+	DeleteNotifications(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	DeleteReadNotifications(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	DeleteUserSessions(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	AnonymizeUserSessions(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	DeletePostIPRecords(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	AnonymizePostIPRecords(ctx context.Context, before time.Time, heldUserIDs []string) (affected int64, err error)
+	DeleteLoginFailures(ctx context.Context, before time.Time) (affected int64, err error)
+}
+
+// DataRetentionService the personal data is deleted or anonymized after the retention days,
+// the data of the users under the legal hold is kept
+type DataRetentionService struct {
+	dataRetentionRepo DataRetentionRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+	legalHoldService  *legal_hold.LegalHoldService
+}
+
+// NewDataRetentionService new data retention service
+func NewDataRetentionService(
+	dataRetentionRepo DataRetentionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	legalHoldService *legal_hold.LegalHoldService,
+) *DataRetentionService {
+	return &DataRetentionService{
+		dataRetentionRepo: dataRetentionRepo,
+		siteInfoService:   siteInfoService,
+		legalHoldService:  legalHoldService,
+	}
+}
+
+// RetentionCron apply the retention policies of the site
+func (ds *DataRetentionService) RetentionCron(ctx context.Context) (err error) {
+	siteLegal, err := ds.siteInfoService.GetSiteLegal(ctx)
+	if err != nil {
+		return err
+	}
+	// the retention is skipped if the users under the legal hold are unknown
+	heldUserIDs, err := ds.legalHoldService.GetHeldUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+	var failed error
+	now := time.Now()
+
+	if siteLegal.IPRetentionDays > 0 {
+		before := retentionBefore(now, siteLegal.IPRetentionDays)
+		if err := ds.applyPostIPRecordRetention(ctx, before, siteLegal.IPRetentionAction, heldUserIDs); err != nil {
+			failed = err
+		}
+		convert := deleteIP
+		if siteLegal.IPRetentionAction == schema.DataRetentionActionAnonymize {
+			convert = anonymizeIP
+		}
+		affected, err := ds.dataRetentionRepo.UpdateIPs(ctx, before, convert, heldUserIDs)
+		if err != nil {
+			log.Errorf("apply ip retention failed: %s", err)
+			failed = err
+		} else if affected > 0 {
+			log.Infof("apply ip retention to %d rows", affected)
+		}
+	}
+
+	if siteLegal.NotificationRetentionDays > 0 {
+		affected, err := ds.dataRetentionRepo.DeleteNotifications(ctx,
+			retentionBefore(now, siteLegal.NotificationRetentionDays), heldUserIDs)
+		if err != nil {
+			log.Errorf("apply notification retention failed: %s", err)
+			failed = err
+		} else if affected > 0 {
+			log.Infof("delete %d expired notifications", affected)
+		}
+	}
+
+	if siteLegal.ReadNotificationRetentionDays > 0 {
+		affected, err := ds.dataRetentionRepo.DeleteReadNotifications(ctx,
+			retentionBefore(now, siteLegal.ReadNotificationRetentionDays), heldUserIDs)
+		if err != nil {
+			log.Errorf("apply read notification retention failed: %s", err)
+			failed = err
+		} else if affected > 0 {
+			log.Infof("delete %d expired read notifications", affected)
+		}
+	}
+
+	if siteLegal.LoginLogRetentionDays > 0 {
+		before := retentionBefore(now, siteLegal.LoginLogRetentionDays)
+		var affected int64
+		if siteLegal.LoginLogRetentionAction == schema.DataRetentionActionAnonymize {
+			affected, err = ds.dataRetentionRepo.AnonymizeUserSessions(ctx, before, heldUserIDs)
+		} else {
+			affected, err = ds.dataRetentionRepo.DeleteUserSessions(ctx, before, heldUserIDs)
+		}
+		if err != nil {
+			log.Errorf("apply login log retention failed: %s", err)
+			failed = err
+		} else if affected > 0 {
+			log.Infof("apply login log retention to %d rows", affected)
+		}
+		// the failed attempts are useless without the email and ip address, so they are always deleted
+		affected, err = ds.dataRetentionRepo.DeleteLoginFailures(ctx, before)
+		if err != nil {
+			log.Errorf("delete expired login failures failed: %s", err)
+			failed = err
+		} else if affected > 0 {
+			log.Infof("delete %d expired login failures", affected)
+		}
+	}
+	return failed
+}
+
+// applyPostIPRecordRetention the expired post ip records are deleted, or their user agents and precise locations
+// are removed while the ip addresses are anonymized with the other ip addresses
+func (ds *DataRetentionService) applyPostIPRecordRetention(ctx context.Context, before time.Time, action string,
+	heldUserIDs []string) (err error) {
+	var affected int64
+	if action == schema.DataRetentionActionAnonymize {
+		affected, err = ds.dataRetentionRepo.AnonymizePostIPRecords(ctx, before, heldUserIDs)
+	} else {
+		affected, err = ds.dataRetentionRepo.DeletePostIPRecords(ctx, before, heldUserIDs)
+	}
+	if err != nil {
+		log.Errorf("apply post ip record retention failed: %s", err)
+	} else if affected > 0 {
+		log.Infof("apply post ip record retention to %d rows", affected)
+	}
+	return err
+}
+
+// retentionBefore the data created before this time is expired
+func retentionBefore(now time.Time, retentionDays int) time.Time {
+	return now.AddDate(0, 0, -retentionDays)
+}
+
+// deleteIP the ip address is removed
+func deleteIP(string) string {
+	return ""
+}
+
+// anonymizeIP keep the network of the ip address only,
+// the last octet of ipv4 and the last 80 bits of ipv6 are set to zero
+func anonymizeIP(ip string) string {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ""
+	}
+	if ipv4 := parsedIP.To4(); ipv4 != nil {
+		return ipv4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return parsedIP.Mask(net.CIDRMask(48, 128)).String()
+}
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package data_retention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymizeIP(t *testing.T) {
+	assert.Equal(t, "192.168.1.0", anonymizeIP("192.168.1.23"))
+	assert.Equal(t, "2001:db8:85a3::", anonymizeIP("2001:db8:85a3:8d3:1319:8a2e:370:7348"))
+	assert.Equal(t, "", anonymizeIP("unknown"))
+	// anonymize it again makes no difference
+	assert.Equal(t, "192.168.1.0", anonymizeIP(anonymizeIP("192.168.1.23")))
+}
@@ -0,0 +1,284 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package dead_link
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/httpclient"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// deadLinkPostBatch the number of the questions and the answers checked in each run
+	deadLinkPostBatch = 100
+	// deadLinkMaxChecks the max number of the links requested in each run
+	deadLinkMaxChecks = 200
+	// deadLinkPostLinks the max number of the links checked in each post
+	deadLinkPostLinks = 20
+	// deadLinkInterval the delay between two requests, so the crawling is rate limited
+	deadLinkInterval  = time.Second
+	deadLinkTimeout   = 10 * time.Second
+	deadLinkRedirects = 5
+	deadLinkReasonLen = 255
+)
+
+// DeadLinkRepo dead link repository
+type DeadLinkRepo interface {
+	GetQuestionsAfter(ctx context.Context, id string, limit int) (questions []*entity.Question, err error)
+	GetAnswersAfter(ctx context.Context, id string, limit int) (answers []*entity.Answer, err error)
+	ReplaceDeadLinks(ctx context.Context, objectID string, deadLinks []*entity.DeadLink) (err error)
+	GetDeadLinksByObjectIDs(ctx context.Context, objectIDs []string) (deadLinks []*entity.DeadLink, err error)
+	GetDeadLinkPage(ctx context.Context, page, pageSize int) (deadLinks []*entity.DeadLink, total int64, err error)
+}
+
+// deadLinkPost the question or answer to be checked
+type deadLinkPost struct {
+	objectID   string
+	questionID string
+	html       string
+}
+
+// linkCheckResult the check result of the link
+type linkCheckResult struct {
+	statusCode int
+	reason     string
+	dead       bool
+}
+
+// DeadLinkService check the outbound links of the posts in batches, each run continues from
+// the last checked post and starts over after all the posts are checked
+type DeadLinkService struct {
+	deadLinkRepo    DeadLinkRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	httpClient      *http.Client
+	interval        time.Duration
+	lock            sync.Mutex
+	questionCursor  string
+	answerCursor    string
+}
+
+// NewDeadLinkService new dead link service
+func NewDeadLinkService(
+	deadLinkRepo DeadLinkRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *DeadLinkService {
+	return &DeadLinkService{
+		deadLinkRepo:    deadLinkRepo,
+		siteInfoService: siteInfoService,
+		httpClient:      httpclient.NewPublicClient(deadLinkTimeout, deadLinkRedirects),
+		interval:        deadLinkInterval,
+		questionCursor:  "0",
+		answerCursor:    "0",
+	}
+}
+
+// DeadLinkCheckCron check the links of the next batch of the questions and answers
+func (ds *DeadLinkService) DeadLinkCheckCron(ctx context.Context) (err error) {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	siteHost := ""
+	if siteGeneral, err := ds.siteInfoService.GetSiteGeneral(ctx); err != nil {
+		log.Error(err)
+	} else if u, err := url.Parse(siteGeneral.SiteUrl); err == nil {
+		siteHost = u.Hostname()
+	}
+
+	questions, err := ds.deadLinkRepo.GetQuestionsAfter(ctx, ds.questionCursor, deadLinkPostBatch)
+	if err != nil {
+		return err
+	}
+	answers, err := ds.deadLinkRepo.GetAnswersAfter(ctx, ds.answerCursor, deadLinkPostBatch)
+	if err != nil {
+		return err
+	}
+	questionPosts := make([]*deadLinkPost, 0, len(questions))
+	for _, question := range questions {
+		questionPosts = append(questionPosts, &deadLinkPost{
+			objectID: question.ID, questionID: question.ID, html: question.ParsedText})
+	}
+	answerPosts := make([]*deadLinkPost, 0, len(answers))
+	for _, answer := range answers {
+		answerPosts = append(answerPosts, &deadLinkPost{
+			objectID: answer.ID, questionID: answer.QuestionID, html: answer.ParsedText})
+	}
+
+	checked := make(map[string]*linkCheckResult)
+	ds.questionCursor = ds.checkPosts(ctx, siteHost, questionPosts, ds.questionCursor, checked)
+	ds.answerCursor = ds.checkPosts(ctx, siteHost, answerPosts, ds.answerCursor, checked)
+	log.Infof("dead link checker checked %d links", len(checked))
+	return nil
+}
+
+// checkPosts check the links of the posts and store the dead links, the new cursor is returned,
+// it is reset when all the posts are checked and kept at the last checked post when the run is out of budget
+func (ds *DeadLinkService) checkPosts(ctx context.Context, siteHost string, posts []*deadLinkPost,
+	cursor string, checked map[string]*linkCheckResult) string {
+	for _, post := range posts {
+		links := htmltext.ExternalLinks(post.html, siteHost, deadLinkPostLinks)
+		deadLinks := make([]*entity.DeadLink, 0)
+		for _, link := range links {
+			result, ok := checked[link]
+			if !ok {
+				if len(checked) >= deadLinkMaxChecks || !ds.wait(ctx, len(checked)) {
+					return cursor
+				}
+				result = ds.checkLink(ctx, link)
+				checked[link] = result
+			}
+			if result.dead {
+				deadLinks = append(deadLinks, &entity.DeadLink{
+					ObjectID:   post.objectID,
+					QuestionID: post.questionID,
+					URL:        link,
+					StatusCode: result.statusCode,
+					Reason:     result.reason,
+				})
+			}
+		}
+		if err := ds.deadLinkRepo.ReplaceDeadLinks(ctx, post.objectID, deadLinks); err != nil {
+			log.Error(err)
+			return cursor
+		}
+		cursor = post.objectID
+	}
+	if len(posts) < deadLinkPostBatch {
+		return "0"
+	}
+	return cursor
+}
+
+// wait the interval before the next request, false is returned if the context is done
+func (ds *DeadLinkService) wait(ctx context.Context, requested int) bool {
+	if requested == 0 || ds.interval == 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(ds.interval):
+		return true
+	}
+}
+
+// checkLink request the link, it is dead if it is not found or it can not be requested
+func (ds *DeadLinkService) checkLink(ctx context.Context, link string) (result *linkCheckResult) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return &linkCheckResult{reason: truncateReason(err.Error()), dead: true}
+	}
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	resp, err := ds.httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && urlErr.Timeout() {
+			return &linkCheckResult{reason: "timeout", dead: true}
+		}
+		return &linkCheckResult{reason: truncateReason(err.Error()), dead: true}
+	}
+	_ = resp.Body.Close()
+	return &linkCheckResult{
+		statusCode: resp.StatusCode,
+		reason:     http.StatusText(resp.StatusCode),
+		dead:       resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone,
+	}
+}
+
+// GetDeadLinkPage get the dead links found by the checker
+func (ds *DeadLinkService) GetDeadLinkPage(ctx context.Context, req *schema.GetDeadLinkPageReq) (
+	pageModel *pager.PageModel, err error) {
+	deadLinks, total, err := ds.deadLinkRepo.GetDeadLinkPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	enableShortID := handler.GetEnableShortID(ctx)
+	list := make([]*schema.GetDeadLinkResp, 0, len(deadLinks))
+	for _, deadLink := range deadLinks {
+		item := &schema.GetDeadLinkResp{
+			ObjectID:   deadLink.ObjectID,
+			QuestionID: deadLink.QuestionID,
+			URL:        deadLink.URL,
+			StatusCode: deadLink.StatusCode,
+			Reason:     deadLink.Reason,
+			CheckedAt:  deadLink.CheckedAt.Unix(),
+		}
+		item.ObjectType, _ = obj.GetObjectTypeStrByObjectID(deadLink.ObjectID)
+		if enableShortID {
+			item.ObjectID = uid.EnShortID(item.ObjectID)
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+		}
+		list = append(list, item)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// AnnotateDeadLinks mark the dead links in the html of the object if the annotation is enabled
+func (ds *DeadLinkService) AnnotateDeadLinks(ctx context.Context, objectID, content string) string {
+	return ds.BatchAnnotateDeadLinks(ctx, []string{objectID}, []string{content})[0]
+}
+
+// BatchAnnotateDeadLinks mark the dead links in the html of each object, the result is in the same order
+func (ds *DeadLinkService) BatchAnnotateDeadLinks(ctx context.Context, objectIDs, contents []string) []string {
+	siteWrite, err := ds.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return contents
+	}
+	if !siteWrite.DeadLinkAnnotation || len(objectIDs) == 0 {
+		return contents
+	}
+	deadLinks, err := ds.deadLinkRepo.GetDeadLinksByObjectIDs(ctx, objectIDs)
+	if err != nil {
+		log.Error(err)
+		return contents
+	}
+	deadLinkMapping := make(map[string][]string)
+	for _, deadLink := range deadLinks {
+		deadLinkMapping[deadLink.ObjectID] = append(deadLinkMapping[deadLink.ObjectID], deadLink.URL)
+	}
+	annotated := make([]string, len(contents))
+	for i, content := range contents {
+		annotated[i] = htmltext.MarkDeadLinks(content, deadLinkMapping[uid.DeShortID(objectIDs[i])])
+	}
+	return annotated
+}
+
+func truncateReason(reason string) string {
+	runes := []rune(reason)
+	if len(runes) <= deadLinkReasonLen {
+		return reason
+	}
+	return string(runes[:deadLinkReasonLen])
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package dead_link
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDeadLinkRepo struct {
+	DeadLinkRepo
+	deadLinks map[string][]*entity.DeadLink
+}
+
+func (m *mockDeadLinkRepo) ReplaceDeadLinks(_ context.Context, objectID string, deadLinks []*entity.DeadLink) error {
+	m.deadLinks[objectID] = deadLinks
+	return nil
+}
+
+func TestCheckPosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	repo := &mockDeadLinkRepo{deadLinks: make(map[string][]*entity.DeadLink)}
+	ds := &DeadLinkService{deadLinkRepo: repo, httpClient: server.Client()}
+	posts := []*deadLinkPost{
+		{objectID: "10010000000000001", questionID: "10010000000000001",
+			html: `<a href="` + server.URL + `/ok">ok</a><a href="` + server.URL + `/gone">gone</a>`},
+		{objectID: "10020000000000001", questionID: "10010000000000001",
+			html: `<a href="` + server.URL + `/gone">gone</a>`},
+	}
+	checked := make(map[string]*linkCheckResult)
+	cursor := ds.checkPosts(context.TODO(), "answer.dev", posts, "0", checked)
+
+	// all the posts are checked, so the cursor starts over
+	assert.Equal(t, "0", cursor)
+	assert.Len(t, checked, 2)
+	assert.Len(t, repo.deadLinks["10010000000000001"], 1)
+	assert.Equal(t, server.URL+"/gone", repo.deadLinks["10010000000000001"][0].URL)
+	assert.Equal(t, http.StatusNotFound, repo.deadLinks["10010000000000001"][0].StatusCode)
+	assert.Len(t, repo.deadLinks["10020000000000001"], 1)
+}
@@ -0,0 +1,345 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package delta_sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// syncChangeRetention the changes are removed after the retention, the clients with the older cursor reload all
+const syncChangeRetention = 30 * 24 * time.Hour
+
+// DeltaSyncRepo delta sync repository
+type DeltaSyncRepo interface {
+	AddSyncChanges(ctx context.Context, changes []*entity.SyncChange) (err error)
+	GetSyncChanges(ctx context.Context, afterID int64, limit int) (changes []*entity.SyncChange, err error)
+	GetOldestSyncChangeID(ctx context.Context) (id int64, exist bool, err error)
+	RemoveSyncChanges(ctx context.Context, before time.Time) (err error)
+	GetQuestionsByIDs(ctx context.Context, ids []string) (questions []*entity.Question, err error)
+	GetAnswersByIDs(ctx context.Context, ids []string) (answers []*entity.Answer, err error)
+	GetCommentsByIDs(ctx context.Context, ids []string) (comments []*entity.Comment, err error)
+	GetNotificationsAfter(ctx context.Context, userID string, afterID int64, limit int) (
+		notifications []*entity.Notification, err error)
+}
+
+// DeltaSyncService record the changes of the content from the event bus, the offline-first clients
+// get the changes since their cursor to stay current
+type DeltaSyncService struct {
+	deltaSyncRepo DeltaSyncRepo
+	userCommon    *usercommon.UserCommon
+}
+
+// NewDeltaSyncService new delta sync service
+func NewDeltaSyncService(
+	deltaSyncRepo DeltaSyncRepo,
+	userCommon *usercommon.UserCommon,
+	eventQueueService event_queue.EventQueueService,
+) *DeltaSyncService {
+	ds := &DeltaSyncService{
+		deltaSyncRepo: deltaSyncRepo,
+		userCommon:    userCommon,
+	}
+	eventQueueService.RegisterHandler(ds.HandleEvent)
+	return ds
+}
+
+// HandleEvent record the changes of the question, answer or comment of the event, the question of the answer
+// is changed as well since its answer count or accepted answer may be changed
+func (ds *DeltaSyncService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	changes := make([]*entity.SyncChange, 0, 2)
+	switch msg.EventType {
+	case constant.EventQuestionCreate, constant.EventQuestionUpdate, constant.EventQuestionDelete,
+		constant.EventQuestionStatusChange:
+		changes = append(changes, &entity.SyncChange{
+			ObjectType: constant.QuestionObjectType, ObjectID: msg.ObjectID, QuestionID: msg.ObjectID})
+	case constant.EventAnswerCreate, constant.EventAnswerUpdate, constant.EventAnswerDelete,
+		constant.EventAnswerAccept:
+		changes = append(changes, &entity.SyncChange{
+			ObjectType: constant.AnswerObjectType, ObjectID: msg.ObjectID, QuestionID: msg.QuestionID})
+		if len(msg.QuestionID) > 0 {
+			changes = append(changes, &entity.SyncChange{
+				ObjectType: constant.QuestionObjectType, ObjectID: msg.QuestionID, QuestionID: msg.QuestionID})
+		}
+	case constant.EventCommentCreate, constant.EventCommentUpdate, constant.EventCommentDelete:
+		changes = append(changes, &entity.SyncChange{
+			ObjectType: constant.CommentObjectType, ObjectID: msg.ObjectID, QuestionID: msg.QuestionID})
+	default:
+		return nil
+	}
+	return ds.deltaSyncRepo.AddSyncChanges(ctx, changes)
+}
+
+// GetSyncChanges get the changes of the content and the new notifications of the user since the cursor,
+// each object is returned once with its current state no matter how many times it changed
+func (ds *DeltaSyncService) GetSyncChanges(ctx context.Context, req *schema.GetSyncChangesReq) (
+	resp *schema.GetSyncChangesResp, err error) {
+	cursor, err := parseSyncCursor(req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if req.Limit == 0 {
+		req.Limit = schema.DefaultSyncLimit
+	}
+	resp = &schema.GetSyncChangesResp{
+		Questions:     make([]*schema.SyncQuestion, 0),
+		Answers:       make([]*schema.SyncAnswer, 0),
+		Comments:      make([]*schema.SyncComment, 0),
+		Notifications: make([]*schema.SyncNotification, 0),
+	}
+
+	// the changes after the cursor have been removed, return the kept ones and ask the client to reload all
+	if cursor.changeID > 0 {
+		oldestID, exist, err := ds.deltaSyncRepo.GetOldestSyncChangeID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if exist && oldestID > cursor.changeID+1 {
+			resp.Reset = true
+			cursor.changeID = oldestID - 1
+		}
+	}
+
+	changes, err := ds.deltaSyncRepo.GetSyncChanges(ctx, cursor.changeID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) > req.Limit {
+		changes = changes[:req.Limit]
+		resp.HasMore = true
+	}
+	notifications, err := ds.deltaSyncRepo.GetNotificationsAfter(ctx, req.UserID, cursor.notificationID, req.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifications) > req.Limit {
+		notifications = notifications[:req.Limit]
+		resp.HasMore = true
+	}
+
+	if err = ds.fillObjects(ctx, resp, changes); err != nil {
+		return nil, err
+	}
+	for _, notification := range notifications {
+		resp.Notifications = append(resp.Notifications, toSyncNotification(notification))
+	}
+
+	if len(changes) > 0 {
+		cursor.changeID = changes[len(changes)-1].ID
+	}
+	if len(notifications) > 0 {
+		cursor.notificationID, _ = strconv.ParseInt(notifications[len(notifications)-1].ID, 10, 64)
+	}
+	resp.NextCursor = cursor.String()
+	return resp, nil
+}
+
+// fillObjects load the current state of the changed objects
+func (ds *DeltaSyncService) fillObjects(ctx context.Context, resp *schema.GetSyncChangesResp,
+	changes []*entity.SyncChange) (err error) {
+	objectIDs := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, change := range changes {
+		key := change.ObjectType + change.ObjectID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		objectIDs[change.ObjectType] = append(objectIDs[change.ObjectType], change.ObjectID)
+	}
+
+	questions, err := ds.deltaSyncRepo.GetQuestionsByIDs(ctx, objectIDs[constant.QuestionObjectType])
+	if err != nil {
+		return err
+	}
+	answers, err := ds.deltaSyncRepo.GetAnswersByIDs(ctx, objectIDs[constant.AnswerObjectType])
+	if err != nil {
+		return err
+	}
+	comments, err := ds.deltaSyncRepo.GetCommentsByIDs(ctx, objectIDs[constant.CommentObjectType])
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]string, 0)
+	for _, question := range questions {
+		userIDs = append(userIDs, question.UserID)
+	}
+	for _, answer := range answers {
+		userIDs = append(userIDs, answer.UserID)
+	}
+	for _, comment := range comments {
+		userIDs = append(userIDs, comment.UserID)
+	}
+	users, err := ds.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	username := func(userID string) string {
+		if user, ok := users[userID]; ok {
+			return user.Username
+		}
+		return ""
+	}
+
+	questionMap := make(map[string]*entity.Question, len(questions))
+	for _, question := range questions {
+		questionMap[question.ID] = question
+	}
+	for _, id := range objectIDs[constant.QuestionObjectType] {
+		question, ok := questionMap[id]
+		if !ok || !questionVisible(question) {
+			resp.Questions = append(resp.Questions, &schema.SyncQuestion{ID: id, Deleted: true})
+			continue
+		}
+		acceptedAnswerID := question.AcceptedAnswerID
+		if acceptedAnswerID == "0" {
+			acceptedAnswerID = ""
+		}
+		resp.Questions = append(resp.Questions, &schema.SyncQuestion{
+			ID:               question.ID,
+			Title:            question.Title,
+			Content:          question.OriginalText,
+			Username:         username(question.UserID),
+			Closed:           question.Status == entity.QuestionStatusClosed,
+			VoteCount:        question.VoteCount,
+			AnswerCount:      question.AnswerCount,
+			AcceptedAnswerID: acceptedAnswerID,
+			CreatedAt:        question.CreatedAt.Unix(),
+			UpdatedAt:        question.UpdatedAt.Unix(),
+		})
+	}
+
+	answerMap := make(map[string]*entity.Answer, len(answers))
+	for _, answer := range answers {
+		answerMap[answer.ID] = answer
+	}
+	for _, id := range objectIDs[constant.AnswerObjectType] {
+		answer, ok := answerMap[id]
+		if !ok || answer.Status != entity.AnswerStatusAvailable {
+			resp.Answers = append(resp.Answers, &schema.SyncAnswer{ID: id, Deleted: true})
+			continue
+		}
+		resp.Answers = append(resp.Answers, &schema.SyncAnswer{
+			ID:         answer.ID,
+			QuestionID: answer.QuestionID,
+			Content:    answer.OriginalText,
+			Username:   username(answer.UserID),
+			Accepted:   answer.Accepted == schema.AnswerAcceptedEnable,
+			VoteCount:  answer.VoteCount,
+			CreatedAt:  answer.CreatedAt.Unix(),
+			UpdatedAt:  answer.UpdatedAt.Unix(),
+		})
+	}
+
+	commentMap := make(map[string]*entity.Comment, len(comments))
+	for _, comment := range comments {
+		commentMap[comment.ID] = comment
+	}
+	for _, id := range objectIDs[constant.CommentObjectType] {
+		comment, ok := commentMap[id]
+		if !ok || comment.Status != entity.CommentStatusAvailable {
+			resp.Comments = append(resp.Comments, &schema.SyncComment{ID: id, Deleted: true})
+			continue
+		}
+		resp.Comments = append(resp.Comments, &schema.SyncComment{
+			ID:         comment.ID,
+			ObjectID:   comment.ObjectID,
+			QuestionID: comment.QuestionID,
+			Content:    comment.OriginalText,
+			Username:   username(comment.UserID),
+			VoteCount:  comment.VoteCount,
+			CreatedAt:  comment.CreatedAt.Unix(),
+			UpdatedAt:  comment.UpdatedAt.Unix(),
+		})
+	}
+	return nil
+}
+
+// SyncChangeCleanupCron remove the changes older than the retention
+func (ds *DeltaSyncService) SyncChangeCleanupCron(ctx context.Context) (err error) {
+	return ds.deltaSyncRepo.RemoveSyncChanges(ctx, time.Now().Add(-syncChangeRetention))
+}
+
+// questionVisible whether the question is visible to everyone, the others are removed from the clients
+func questionVisible(question *entity.Question) bool {
+	return (question.Status == entity.QuestionStatusAvailable || question.Status == entity.QuestionStatusClosed) &&
+		question.Show == entity.QuestionShow
+}
+
+func toSyncNotification(notification *entity.Notification) *schema.SyncNotification {
+	resp := &schema.SyncNotification{
+		ID:        notification.ID,
+		Content:   json.RawMessage(notification.Content),
+		IsRead:    notification.IsRead == schema.NotificationRead,
+		CreatedAt: notification.CreatedAt.Unix(),
+	}
+	for name, notificationType := range schema.NotificationType {
+		if notificationType == notification.Type {
+			resp.Type = name
+		}
+	}
+	if !json.Valid(resp.Content) {
+		resp.Content = json.RawMessage("{}")
+	}
+	return resp
+}
+
+// syncCursor the position of the client in the changes of the content and the notifications
+type syncCursor struct {
+	changeID       int64
+	notificationID int64
+}
+
+// parseSyncCursor parse the cursor in the format of {change id}-{notification id}, empty means the beginning
+func parseSyncCursor(cursor string) (c syncCursor, err error) {
+	if len(cursor) == 0 {
+		return c, nil
+	}
+	changeID, notificationID, ok := strings.Cut(cursor, "-")
+	if !ok {
+		return c, errors.BadRequest(reason.SyncCursorInvalid)
+	}
+	c.changeID, err = strconv.ParseInt(changeID, 10, 64)
+	if err != nil || c.changeID < 0 {
+		return c, errors.BadRequest(reason.SyncCursorInvalid)
+	}
+	c.notificationID, err = strconv.ParseInt(notificationID, 10, 64)
+	if err != nil || c.notificationID < 0 {
+		return c, errors.BadRequest(reason.SyncCursorInvalid)
+	}
+	return c, nil
+}
+
+func (c syncCursor) String() string {
+	return fmt.Sprintf("%d-%d", c.changeID, c.notificationID)
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package delta_sync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDeltaSyncRepo struct {
+	DeltaSyncRepo
+	changes []*entity.SyncChange
+}
+
+func (f *fakeDeltaSyncRepo) AddSyncChanges(_ context.Context, changes []*entity.SyncChange) error {
+	f.changes = append(f.changes, changes...)
+	return nil
+}
+
+func TestSyncCursor(t *testing.T) {
+	c, err := parseSyncCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, syncCursor{}, c)
+
+	c, err = parseSyncCursor("12-3")
+	assert.NoError(t, err)
+	assert.Equal(t, syncCursor{changeID: 12, notificationID: 3}, c)
+	assert.Equal(t, "12-3", c.String())
+
+	for _, cursor := range []string{"12", "a-3", "12-", "-1-3"} {
+		_, err = parseSyncCursor(cursor)
+		assert.Error(t, err, cursor)
+	}
+}
+
+func TestHandleEvent(t *testing.T) {
+	repo := &fakeDeltaSyncRepo{}
+	ds := &DeltaSyncService{deltaSyncRepo: repo}
+
+	assert.NoError(t, ds.HandleEvent(context.TODO(), &schema.EventMsg{
+		EventType: constant.EventAnswerAccept, ObjectID: "20", QuestionID: "10"}))
+	assert.NoError(t, ds.HandleEvent(context.TODO(), &schema.EventMsg{
+		EventType: constant.EventCommentCreate, ObjectID: "30", QuestionID: "10"}))
+	assert.NoError(t, ds.HandleEvent(context.TODO(), &schema.EventMsg{
+		EventType: constant.EventTagCreate, ObjectID: "40"}))
+
+	assert.Equal(t, []*entity.SyncChange{
+		{ObjectType: constant.AnswerObjectType, ObjectID: "20", QuestionID: "10"},
+		{ObjectType: constant.QuestionObjectType, ObjectID: "10", QuestionID: "10"},
+		{ObjectType: constant.CommentObjectType, ObjectID: "30", QuestionID: "10"},
+	}, repo.changes)
+}
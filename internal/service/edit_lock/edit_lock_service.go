@@ -0,0 +1,180 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package edit_lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// EditLockRepo edit lock repository
+type EditLockRepo interface {
+	GetEditLock(ctx context.Context, objectID string) (lock *EditLock, exist bool, err error)
+	SetEditLock(ctx context.Context, lock *EditLock) (err error)
+	RemoveEditLock(ctx context.Context, objectID string) (err error)
+}
+
+// EditLock the user who is editing the post, the lock expires if the heartbeat stops
+type EditLock struct {
+	ObjectID   string `json:"object_id"`
+	UserID     string `json:"user_id"`
+	AcquiredAt int64  `json:"acquired_at"`
+	ExpiresAt  int64  `json:"expires_at"`
+}
+
+// EditLockService the soft lock of editing the post. The lock never blocks saving the edit, it only warns
+// the second editor who is editing the post. The editor keeps the lock by the heartbeat, the lock expires
+// after the heartbeat stops, and the other user can take it over at any time.
+type EditLockService struct {
+	editLockRepo      EditLockRepo
+	objectInfoService *object_info.ObjService
+	userCommon        *usercommon.UserCommon
+}
+
+// NewEditLockService new edit lock service
+func NewEditLockService(
+	editLockRepo EditLockRepo,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+) *EditLockService {
+	return &EditLockService{
+		editLockRepo:      editLockRepo,
+		objectInfoService: objectInfoService,
+		userCommon:        userCommon,
+	}
+}
+
+// AcquireEditLock acquire or renew the edit lock of the post, if the post is being edited by the other user
+// and the user does not take it over, the lock is not changed and the current editor is returned
+func (es *EditLockService) AcquireEditLock(ctx context.Context, req *schema.EditLockReq) (
+	resp *schema.EditLockResp, err error) {
+	objectID, err := es.checkObject(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	current, exist, err := es.editLockRepo.GetEditLock(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		current = nil
+	}
+	lock, acquired := nextEditLock(current, objectID, req.UserID, req.Takeover, time.Now())
+	if acquired {
+		if err = es.editLockRepo.SetEditLock(ctx, lock); err != nil {
+			return nil, err
+		}
+	}
+	return es.formatEditLock(ctx, lock, req.UserID)
+}
+
+// ReleaseEditLock release the edit lock of the post, it is ignored if the lock is held by the other user
+func (es *EditLockService) ReleaseEditLock(ctx context.Context, req *schema.ReleaseEditLockReq) (err error) {
+	objectID := uid.DeShortID(req.ObjectID)
+	lock, exist, err := es.editLockRepo.GetEditLock(ctx, objectID)
+	if err != nil || !exist || lock.UserID != req.UserID {
+		return err
+	}
+	return es.editLockRepo.RemoveEditLock(ctx, objectID)
+}
+
+// GetEditLock get the edit lock of the post
+func (es *EditLockService) GetEditLock(ctx context.Context, req *schema.GetEditLockReq) (
+	resp *schema.EditLockResp, err error) {
+	objectID := uid.DeShortID(req.ObjectID)
+	lock, exist, err := es.editLockRepo.GetEditLock(ctx, objectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || isExpired(lock, time.Now()) {
+		return &schema.EditLockResp{ObjectID: objectID}, nil
+	}
+	return es.formatEditLock(ctx, lock, req.UserID)
+}
+
+// checkObject only the questions and the answers can be locked
+func (es *EditLockService) checkObject(ctx context.Context, objectID string) (id string, err error) {
+	id = uid.DeShortID(objectID)
+	objInfo, err := es.objectInfoService.GetInfo(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if objInfo == nil || (objInfo.ObjectType != constant.QuestionObjectType &&
+		objInfo.ObjectType != constant.AnswerObjectType) {
+		return "", errors.NotFound(reason.EditLockObjectNotFound)
+	}
+	return id, nil
+}
+
+// formatEditLock the editor is returned only if the lock is held by the other user
+func (es *EditLockService) formatEditLock(ctx context.Context, lock *EditLock, userID string) (
+	resp *schema.EditLockResp, err error) {
+	resp = &schema.EditLockResp{
+		ObjectID:   lock.ObjectID,
+		IsOwner:    lock.UserID == userID,
+		AcquiredAt: lock.AcquiredAt,
+		ExpiresAt:  lock.ExpiresAt,
+	}
+	if resp.IsOwner {
+		return resp, nil
+	}
+	resp.Locked = true
+	editor, exist, err := es.userCommon.GetUserBasicInfoByID(ctx, lock.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		resp.Editor = editor
+	}
+	return resp, nil
+}
+
+// nextEditLock the lock is acquired if it is free, expired, held by the user or taken over, the time of acquiring
+// is kept when the user renews the lock. Otherwise the current lock is returned and not acquired.
+func nextEditLock(current *EditLock, objectID, userID string, takeover bool, now time.Time) (
+	lock *EditLock, acquired bool) {
+	free := current == nil || isExpired(current, now)
+	if !free && current.UserID != userID && !takeover {
+		return current, false
+	}
+	lock = &EditLock{
+		ObjectID:   objectID,
+		UserID:     userID,
+		AcquiredAt: now.Unix(),
+		ExpiresAt:  now.Add(constant.EditLockCacheTime).Unix(),
+	}
+	if !free && current.UserID == userID {
+		lock.AcquiredAt = current.AcquiredAt
+	}
+	return lock, true
+}
+
+// isExpired the lock is expired if the heartbeat stops, the cache may keep it a little longer
+func isExpired(lock *EditLock, now time.Time) bool {
+	return lock.ExpiresAt <= now.Unix()
+}
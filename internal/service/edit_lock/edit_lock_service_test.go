@@ -0,0 +1,60 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package edit_lock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextEditLock(t *testing.T) {
+	now := time.Now()
+	lock, acquired := nextEditLock(nil, "10010000000000001", "1", false, now)
+	assert.True(t, acquired)
+	assert.Equal(t, "1", lock.UserID)
+	assert.Equal(t, now.Add(constant.EditLockCacheTime).Unix(), lock.ExpiresAt)
+
+	// the lock is renewed by the heartbeat and the time of acquiring is kept
+	later := now.Add(30 * time.Second)
+	renewed, acquired := nextEditLock(lock, "10010000000000001", "1", false, later)
+	assert.True(t, acquired)
+	assert.Equal(t, lock.AcquiredAt, renewed.AcquiredAt)
+	assert.Equal(t, later.Add(constant.EditLockCacheTime).Unix(), renewed.ExpiresAt)
+
+	// the second editor is warned
+	current, acquired := nextEditLock(renewed, "10010000000000001", "2", false, later)
+	assert.False(t, acquired)
+	assert.Equal(t, "1", current.UserID)
+
+	// the second editor takes over the lock
+	takenOver, acquired := nextEditLock(renewed, "10010000000000001", "2", true, later)
+	assert.True(t, acquired)
+	assert.Equal(t, "2", takenOver.UserID)
+	assert.Equal(t, later.Unix(), takenOver.AcquiredAt)
+
+	// the expired lock is free
+	expired, acquired := nextEditLock(renewed, "10010000000000001", "2", false,
+		later.Add(constant.EditLockCacheTime))
+	assert.True(t, acquired)
+	assert.Equal(t, "2", expired.UserID)
+}
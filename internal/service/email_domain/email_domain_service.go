@@ -0,0 +1,141 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_domain
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/segmentfault/pacman/log"
+)
+
+// BlockedSignupRepo blocked signup repository
+type BlockedSignupRepo interface {
+	AddBlockedSignup(ctx context.Context, blockedSignup *entity.BlockedSignup) (err error)
+	GetBlockedSignupPage(ctx context.Context, page, pageSize int, cond *entity.BlockedSignup) (
+		blockedSignups []*entity.BlockedSignup, total int64, err error)
+}
+
+// EmailDomainService email domain service
+type EmailDomainService struct {
+	blockedSignupRepo BlockedSignupRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+}
+
+// NewEmailDomainService new email domain service
+func NewEmailDomainService(
+	blockedSignupRepo BlockedSignupRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *EmailDomainService {
+	return &EmailDomainService{
+		blockedSignupRepo: blockedSignupRepo,
+		siteInfoService:   siteInfoService,
+	}
+}
+
+// CheckEmail check whether the email can be used by the email domain rules.
+// If the email is not allowed, the reason key of the error message will be returned.
+func (es *EmailDomainService) CheckEmail(ctx context.Context, email string) (errReason string, err error) {
+	blockedReason, err := es.getBlockedReason(ctx, email)
+	if err != nil || len(blockedReason) == 0 {
+		return "", err
+	}
+	return blockedReasonMapping[blockedReason], nil
+}
+
+// CheckSignupEmail check whether the email can be used to sign up, the blocked attempt will be recorded
+func (es *EmailDomainService) CheckSignupEmail(ctx context.Context, email, ip string) (
+	errFields []*validator.FormErrorField, err error) {
+	blockedReason, err := es.getBlockedReason(ctx, email)
+	if err != nil || len(blockedReason) == 0 {
+		return nil, err
+	}
+
+	blockedSignup := &entity.BlockedSignup{
+		EMail:  email,
+		Domain: checker.EmailDomain(email),
+		Reason: blockedReason,
+		IP:     ip,
+	}
+	if err := es.blockedSignupRepo.AddBlockedSignup(ctx, blockedSignup); err != nil {
+		log.Errorf("add blocked signup failed: %v", err)
+	}
+	errFields = append(errFields, &validator.FormErrorField{
+		ErrorField: "e_mail",
+		ErrorMsg:   blockedReasonMapping[blockedReason],
+	})
+	return errFields, nil
+}
+
+// GetBlockedSignupPage get the signup attempts blocked by the email domain rules
+func (es *EmailDomainService) GetBlockedSignupPage(ctx context.Context, req *schema.GetBlockedSignupPageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.BlockedSignup{
+		Domain: checker.EmailDomain("@" + req.Domain),
+		Reason: req.Reason,
+	}
+	blockedSignups, total, err := es.blockedSignupRepo.GetBlockedSignupPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.BlockedSignupResp, 0, len(blockedSignups))
+	for _, blockedSignup := range blockedSignups {
+		resp = append(resp, &schema.BlockedSignupResp{
+			ID:        blockedSignup.ID,
+			CreatedAt: blockedSignup.CreatedAt.Unix(),
+			EMail:     blockedSignup.EMail,
+			Domain:    blockedSignup.Domain,
+			Reason:    blockedSignup.Reason,
+			IP:        blockedSignup.IP,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+var blockedReasonMapping = map[string]string{
+	entity.BlockedSignupReasonNotAllowed: reason.EmailIllegalDomainError,
+	entity.BlockedSignupReasonBlocked:    reason.EmailDomainBlocked,
+	entity.BlockedSignupReasonDisposable: reason.EmailDisposable,
+}
+
+// getBlockedReason get the reason why the email is blocked, empty means the email is not blocked
+func (es *EmailDomainService) getBlockedReason(ctx context.Context, email string) (blockedReason string, err error) {
+	siteLogin, err := es.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !checker.EmailInAllowEmailDomain(email, siteLogin.AllowEmailDomains) {
+		return entity.BlockedSignupReasonNotAllowed, nil
+	}
+	if checker.EmailInBlockedEmailDomain(email, siteLogin.BlockedEmailDomains) {
+		return entity.BlockedSignupReasonBlocked, nil
+	}
+	if siteLogin.BlockDisposableEmail && checker.IsDisposableEmail(email) {
+		return entity.BlockedSignupReasonDisposable, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,104 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlockedSignupRepo struct {
+	BlockedSignupRepo
+	blockedSignups []*entity.BlockedSignup
+}
+
+func (r *testBlockedSignupRepo) AddBlockedSignup(_ context.Context, blockedSignup *entity.BlockedSignup) error {
+	r.blockedSignups = append(r.blockedSignups, blockedSignup)
+	return nil
+}
+
+func newTestSiteInfoService(ctl *gomock.Controller, siteLogin *schema.SiteLoginResp) *mock.MockSiteInfoCommonService {
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteLogin(gomock.Any()).AnyTimes().Return(siteLogin, nil)
+	return siteInfoService
+}
+
+func TestCheckEmail(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	siteLogin := &schema.SiteLoginResp{
+		BlockedEmailDomains:  []string{"spam.com"},
+		BlockDisposableEmail: true,
+	}
+	es := NewEmailDomainService(&testBlockedSignupRepo{}, newTestSiteInfoService(ctl, siteLogin))
+	ctx := context.TODO()
+
+	tests := []struct {
+		email     string
+		errReason string
+	}{
+		{"user@example.com", ""},
+		{"user@spam.com", reason.EmailDomainBlocked},
+		{"user@yopmail.net", reason.EmailDisposable},
+		{"user@inbox.yopmail.net", reason.EmailDisposable},
+	}
+	for _, tt := range tests {
+		errReason, err := es.CheckEmail(ctx, tt.email)
+		require.NoError(t, err)
+		assert.Equal(t, tt.errReason, errReason, tt.email)
+	}
+
+	// the disposable email is allowed unless the admin blocks it
+	siteLogin.BlockDisposableEmail = false
+	errReason, err := es.CheckEmail(ctx, "user@yopmail.net")
+	require.NoError(t, err)
+	assert.Empty(t, errReason)
+}
+
+func TestCheckSignupEmail(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	blockedSignupRepo := &testBlockedSignupRepo{}
+	es := NewEmailDomainService(blockedSignupRepo, newTestSiteInfoService(ctl, &schema.SiteLoginResp{
+		BlockDisposableEmail: true,
+	}))
+	ctx := context.TODO()
+
+	errFields, err := es.CheckSignupEmail(ctx, "user@example.com", "127.0.0.1")
+	require.NoError(t, err)
+	assert.Empty(t, errFields)
+	assert.Empty(t, blockedSignupRepo.blockedSignups)
+
+	errFields, err = es.CheckSignupEmail(ctx, "user@YOPmail.net", "127.0.0.1")
+	require.NoError(t, err)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "e_mail", errFields[0].ErrorField)
+	assert.Equal(t, reason.EmailDisposable, errFields[0].ErrorMsg)
+	assert.Equal(t, []*entity.BlockedSignup{{EMail: "user@YOPmail.net", Domain: "yopmail.net",
+		Reason: entity.BlockedSignupReasonDisposable, IP: "127.0.0.1"}}, blockedSignupRepo.blockedSignups)
+}
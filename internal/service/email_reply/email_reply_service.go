@@ -0,0 +1,211 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package email_reply
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/comment"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/inbound_question"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/rank"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	minAnswerLength  = 6
+	minCommentLength = 2
+	maxCommentLength = 600
+)
+
+// EmailReplyService post the replies to the notification emails as the answers or the comments
+type EmailReplyService struct {
+	emailService           *export.EmailService
+	userCommon             *usercommon.UserCommon
+	rankService            *rank.RankService
+	answerService          *content.AnswerService
+	commentService         *comment.CommentService
+	contentFilterService   *content_filter.ContentFilterService
+	siteInfoCommonService  siteinfo_common.SiteInfoCommonService
+	inboundQuestionService *inbound_question.InboundQuestionService
+}
+
+// NewEmailReplyService new email reply service
+func NewEmailReplyService(
+	emailService *export.EmailService,
+	userCommon *usercommon.UserCommon,
+	rankService *rank.RankService,
+	answerService *content.AnswerService,
+	commentService *comment.CommentService,
+	contentFilterService *content_filter.ContentFilterService,
+	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	inboundQuestionService *inbound_question.InboundQuestionService,
+) *EmailReplyService {
+	return &EmailReplyService{
+		emailService:           emailService,
+		userCommon:             userCommon,
+		rankService:            rankService,
+		answerService:          answerService,
+		commentService:         commentService,
+		contentFilterService:   contentFilterService,
+		siteInfoCommonService:  siteInfoCommonService,
+		inboundQuestionService: inboundQuestionService,
+	}
+}
+
+// HandleInboundEmail post the reply forwarded by the provider. The reply is only posted if it is sent from
+// the email of the user the reply address is for. The rejected reply is ignored, so the provider does not retry it.
+// The email without a reply address is queued as a question if it is sent to the mailbox of the inbound questions.
+func (es *EmailReplyService) HandleInboundEmail(ctx context.Context, req *schema.EmailInboundWebhookReq) (err error) {
+	email, token, err := es.emailService.ParseInboundWebhook(ctx, req)
+	if err != nil || email == nil {
+		return err
+	}
+	if token == nil {
+		queued, err := es.inboundQuestionService.AddMailboxQuestion(ctx, email)
+		if err != nil || queued {
+			return err
+		}
+		log.Warnf("no reply address in the recipients %v of the inbound email", email.Recipients)
+		return nil
+	}
+	userInfo, exist, err := es.userCommon.GetByEmail(ctx, senderAddress(email.From))
+	if err != nil {
+		return err
+	}
+	if !exist || userInfo.ID != token.UserID ||
+		userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		log.Warnf("the reply from %s is not sent by the user %s", email.From, token.UserID)
+		return nil
+	}
+	text := export.StripQuotedText(email.Text)
+
+	switch token.Kind {
+	case export.EmailReplyAsAnswer:
+		err = es.addAnswer(ctx, token.UserID, token.ObjectID, text)
+	case export.EmailReplyAsComment:
+		err = es.addComment(ctx, token.UserID, token.ObjectID, text)
+	}
+	if e, ok := err.(*errors.Error); ok && !errors.IsInternalServer(e) {
+		log.Warnf("the reply of user %s to %s is rejected: %s", token.UserID, token.ObjectID, e.Reason)
+		return nil
+	}
+	return err
+}
+
+func (es *EmailReplyService) addAnswer(ctx context.Context, userID, questionID, text string) (err error) {
+	if utf8.RuneCountInString(text) < minAnswerLength {
+		return errors.BadRequest(reason.RequestFormatError)
+	}
+	can, err := es.rankService.CheckOperationPermission(ctx, userID, permission.AnswerAdd, "")
+	if err != nil {
+		return err
+	}
+	if !can {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+	write, err := es.siteInfoCommonService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if write.RestrictAnswer {
+		ids, err := es.answerService.GetCountByUserIDQuestionID(ctx, userID, questionID)
+		if err != nil {
+			return err
+		}
+		if len(ids) >= 1 {
+			return errors.Forbidden(reason.AnswerRestrictAnswer)
+		}
+	}
+
+	req := &schema.AnswerAddReq{
+		QuestionID: questionID,
+		Content:    text,
+		HTML:       converter.Markdown2HTML(text),
+		UserID:     userID,
+	}
+	if err = es.contentFilterService.FilterAnswerAdd(ctx, req); err != nil {
+		return err
+	}
+	answerID, err := es.answerService.Insert(ctx, req)
+	if err != nil {
+		return err
+	}
+	log.Infof("user %s answered question %s by email, answer id %s", userID, questionID, answerID)
+	return nil
+}
+
+func (es *EmailReplyService) addComment(ctx context.Context, userID, objectID, text string) (err error) {
+	if utf8.RuneCountInString(text) < minCommentLength {
+		return errors.BadRequest(reason.RequestFormatError)
+	}
+	if utf8.RuneCountInString(text) > maxCommentLength {
+		text = string([]rune(text)[:maxCommentLength])
+	}
+	canList, err := es.rankService.CheckOperationPermissions(ctx, userID, []string{
+		permission.CommentAdd,
+		permission.CommentEdit,
+		permission.CommentDelete,
+	})
+	if err != nil {
+		return err
+	}
+	if !canList[0] {
+		return errors.Forbidden(reason.RankFailToMeetTheCondition)
+	}
+
+	req := &schema.AddCommentReq{
+		ObjectID:     objectID,
+		OriginalText: text,
+		ParsedText:   converter.Markdown2HTML(text),
+		UserID:       userID,
+		CanAdd:       canList[0],
+		CanEdit:      canList[1],
+		CanDelete:    canList[2],
+	}
+	if err = es.contentFilterService.FilterCommentAdd(ctx, req); err != nil {
+		return err
+	}
+	resp, err := es.commentService.AddComment(ctx, req)
+	if err != nil {
+		return err
+	}
+	log.Infof("user %s commented on %s by email, comment id %s", userID, objectID, resp.CommentID)
+	return nil
+}
+
+// senderAddress get the address of the from header, like "Name <user@example.com>"
+func senderAddress(from string) string {
+	if list := export.SplitAddresses(from); len(list) > 0 {
+		return list[0]
+	}
+	return from
+}
@@ -0,0 +1,389 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package embedding
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// embeddingPostBatch the number of the questions and the answers checked in each run
+	embeddingPostBatch = 100
+	// embeddingRequestBatch the max number of the texts embedded in each request
+	embeddingRequestBatch = 16
+	// embeddingTextMaxLen the max length of the embedded text
+	embeddingTextMaxLen = 2000
+	// embeddingIndexPage the number of the embeddings loaded into the index at once
+	embeddingIndexPage = 1000
+	// embeddingMinScore the semantic match whose similarity is lower than it is dropped
+	embeddingMinScore = 0.3
+)
+
+// EmbeddingRepo embedding repository
+type EmbeddingRepo interface {
+	GetQuestionsAfter(ctx context.Context, id string, limit int) (questions []*entity.Question, err error)
+	GetAnswersAfter(ctx context.Context, id string, limit int) (answers []*entity.Answer, err error)
+	GetEmbeddingsByObjectIDs(ctx context.Context, objectIDs []string) (embeddings []*entity.ContentEmbedding, err error)
+	GetEmbeddingsAfter(ctx context.Context, id int, model string, limit int) (embeddings []*entity.ContentEmbedding, err error)
+	SaveEmbedding(ctx context.Context, embedding *entity.ContentEmbedding) (err error)
+	RemoveAllEmbeddings(ctx context.Context) (err error)
+}
+
+// embeddingPost the question or answer to be embedded
+type embeddingPost struct {
+	objectID   string
+	objectType string
+	questionID string
+	text       string
+	hash       string
+}
+
+// EmbeddingService embed the questions and answers in batches and search them semantically. The vectors
+// are saved in the vector store plugin if it is enabled, otherwise they are saved in the database and
+// searched in memory.
+type EmbeddingService struct {
+	embeddingRepo      EmbeddingRepo
+	aiAssistantService *ai_assistant.AIAssistantService
+	lock               sync.Mutex
+	questionCursor     string
+	answerCursor       string
+	index              *vectorIndex
+}
+
+// NewEmbeddingService new embedding service
+func NewEmbeddingService(
+	embeddingRepo EmbeddingRepo,
+	aiAssistantService *ai_assistant.AIAssistantService,
+) *EmbeddingService {
+	return &EmbeddingService{
+		embeddingRepo:      embeddingRepo,
+		aiAssistantService: aiAssistantService,
+		questionCursor:     "0",
+		answerCursor:       "0",
+		index:              newVectorIndex(),
+	}
+}
+
+// IsSemanticSearchEnabled check whether the semantic search is enabled and the embedder is available
+func (es *EmbeddingService) IsSemanticSearchEnabled(ctx context.Context) bool {
+	embedder, _ := es.getEmbedder(ctx)
+	return embedder != nil
+}
+
+// getEmbedder get the embedder plugin, or the built-in embedder if the embedding model is configured.
+// The model identifies the vectors, the vectors of the other models are embedded again.
+func (es *EmbeddingService) getEmbedder(ctx context.Context) (embedder plugin.Embedder, model string) {
+	config, err := es.aiAssistantService.GetAIAssistant(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil, ""
+	}
+	if !config.SemanticSearch {
+		return nil, ""
+	}
+	_ = plugin.CallEmbedder(func(p plugin.Embedder) error {
+		if embedder == nil {
+			embedder = p
+		}
+		return nil
+	})
+	if embedder != nil {
+		return embedder, embedder.Info().SlugName
+	}
+	if len(config.Endpoint) > 0 && len(config.EmbeddingModel) > 0 {
+		return newOpenAIEmbedder(config), schema.AIAssistantProviderBuiltIn + ":" + config.EmbeddingModel
+	}
+	return nil, ""
+}
+
+func getVectorStore() (store plugin.VectorStore) {
+	_ = plugin.CallVectorStore(func(p plugin.VectorStore) error {
+		if store == nil {
+			store = p
+		}
+		return nil
+	})
+	return store
+}
+
+// EmbeddingSyncCron embed the next batch of the questions and answers whose content or model is changed
+func (es *EmbeddingService) EmbeddingSyncCron(ctx context.Context) (err error) {
+	es.lock.Lock()
+	defer es.lock.Unlock()
+
+	embedder, model := es.getEmbedder(ctx)
+	if embedder == nil {
+		return nil
+	}
+
+	posts := make([]*embeddingPost, 0)
+	questions, err := es.embeddingRepo.GetQuestionsAfter(ctx, es.questionCursor, embeddingPostBatch)
+	if err != nil {
+		return err
+	}
+	for _, question := range questions {
+		posts = append(posts, newEmbeddingPost(question.ID, constant.QuestionObjectType, question.ID,
+			question.Title+"\n"+question.ParsedText))
+	}
+	answers, err := es.embeddingRepo.GetAnswersAfter(ctx, es.answerCursor, embeddingPostBatch)
+	if err != nil {
+		return err
+	}
+	for _, answer := range answers {
+		posts = append(posts, newEmbeddingPost(answer.ID, constant.AnswerObjectType, answer.QuestionID,
+			answer.ParsedText))
+	}
+
+	if err = es.embedPosts(ctx, embedder, model, posts); err != nil {
+		return err
+	}
+	// start over after all the posts are checked
+	es.questionCursor, es.answerCursor = "0", "0"
+	if len(questions) == embeddingPostBatch {
+		es.questionCursor = questions[len(questions)-1].ID
+	}
+	if len(answers) == embeddingPostBatch {
+		es.answerCursor = answers[len(answers)-1].ID
+	}
+	return nil
+}
+
+func newEmbeddingPost(objectID, objectType, questionID, content string) *embeddingPost {
+	text := htmltext.FetchExcerpt(content, "", embeddingTextMaxLen)
+	hash := sha256.Sum256([]byte(text))
+	return &embeddingPost{
+		objectID:   objectID,
+		objectType: objectType,
+		questionID: questionID,
+		text:       text,
+		hash:       hex.EncodeToString(hash[:]),
+	}
+}
+
+// embedPosts embed the posts whose content or model is changed since the last embedding
+func (es *EmbeddingService) embedPosts(ctx context.Context, embedder plugin.Embedder, model string,
+	posts []*embeddingPost) (err error) {
+	objectIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		objectIDs = append(objectIDs, post.objectID)
+	}
+	embeddings, err := es.embeddingRepo.GetEmbeddingsByObjectIDs(ctx, objectIDs)
+	if err != nil {
+		return err
+	}
+	embedded := make(map[string]*entity.ContentEmbedding, len(embeddings))
+	for _, e := range embeddings {
+		embedded[e.ObjectID] = e
+	}
+	changed := make([]*embeddingPost, 0)
+	for _, post := range posts {
+		if e, ok := embedded[post.objectID]; ok && e.Model == model && e.ContentHash == post.hash {
+			continue
+		}
+		changed = append(changed, post)
+	}
+
+	store := getVectorStore()
+	for start := 0; start < len(changed); start += embeddingRequestBatch {
+		end := start + embeddingRequestBatch
+		if end > len(changed) {
+			end = len(changed)
+		}
+		batch := changed[start:end]
+		texts := make([]string, 0, len(batch))
+		for _, post := range batch {
+			texts = append(texts, post.text)
+		}
+		resp, err := embedder.Embed(ctx, &plugin.EmbeddingRequest{Texts: texts})
+		if err != nil {
+			return err
+		}
+		if len(resp.Vectors) != len(batch) {
+			log.Errorf("embedder %s returned %d vectors for %d texts", model, len(resp.Vectors), len(batch))
+			continue
+		}
+		if store != nil {
+			records := make([]*plugin.VectorRecord, 0, len(batch))
+			for i, post := range batch {
+				records = append(records, &plugin.VectorRecord{
+					ObjectID:   post.objectID,
+					ObjectType: post.objectType,
+					QuestionID: post.questionID,
+					Model:      model,
+					Vector:     resp.Vectors[i],
+				})
+			}
+			if err = store.UpsertVectors(ctx, records); err != nil {
+				return err
+			}
+		}
+		for i, post := range batch {
+			e := &entity.ContentEmbedding{
+				ObjectID:    post.objectID,
+				ObjectType:  post.objectType,
+				QuestionID:  post.questionID,
+				Model:       model,
+				ContentHash: post.hash,
+			}
+			if store == nil {
+				e.Vector = encodeVector(resp.Vectors[i])
+				es.index.put(model, post.objectID, post.objectType, resp.Vectors[i])
+			}
+			if err = es.embeddingRepo.SaveEmbedding(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SemanticSearch search the questions and answers which are semantically similar to the query,
+// the object types limit the types of the matches, the most similar one first
+func (es *EmbeddingService) SemanticSearch(ctx context.Context, query string, objectTypes []string, limit int) (
+	matches []*plugin.VectorMatch, err error) {
+	embedder, model := es.getEmbedder(ctx)
+	if embedder == nil {
+		return nil, nil
+	}
+	resp, err := embedder.Embed(ctx, &plugin.EmbeddingRequest{Texts: []string{query}})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Vectors) == 0 {
+		return nil, nil
+	}
+
+	if store := getVectorStore(); store != nil {
+		matches, err = store.QueryVectors(ctx, model, resp.Vectors[0], limit*2)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err = es.loadIndex(ctx, model); err != nil {
+			return nil, err
+		}
+		matches = es.index.query(resp.Vectors[0], limit*2)
+	}
+
+	allowed := make(map[string]bool, len(objectTypes))
+	for _, t := range objectTypes {
+		allowed[t] = true
+	}
+	filtered := make([]*plugin.VectorMatch, 0, limit)
+	for _, match := range matches {
+		if match.Score < embeddingMinScore || !allowed[match.ObjectType] {
+			continue
+		}
+		filtered = append(filtered, match)
+		if len(filtered) >= limit {
+			break
+		}
+	}
+	return filtered, nil
+}
+
+// loadIndex load the vectors of the model from the database if they are not loaded
+func (es *EmbeddingService) loadIndex(ctx context.Context, model string) (err error) {
+	if es.index.loaded(model) {
+		return nil
+	}
+	vectors := make(map[string]*indexedVector)
+	lastID := 0
+	for {
+		embeddings, err := es.embeddingRepo.GetEmbeddingsAfter(ctx, lastID, model, embeddingIndexPage)
+		if err != nil {
+			return err
+		}
+		for _, e := range embeddings {
+			vector := decodeVector(e.Vector)
+			if len(vector) == 0 {
+				continue
+			}
+			vectors[e.ObjectID] = &indexedVector{objectType: e.ObjectType, vector: vector, norm: norm(vector)}
+		}
+		if len(embeddings) < embeddingIndexPage {
+			break
+		}
+		lastID = embeddings[len(embeddings)-1].ID
+	}
+	es.index.reset(model, vectors)
+	return nil
+}
+
+// ReindexEmbeddings remove all the embeddings, so all the contents are embedded again by the current model
+func (es *EmbeddingService) ReindexEmbeddings(ctx context.Context) (err error) {
+	es.lock.Lock()
+	defer es.lock.Unlock()
+
+	if store := getVectorStore(); store != nil {
+		if err = store.DeleteVectors(ctx, nil); err != nil {
+			return err
+		}
+	}
+	if err = es.embeddingRepo.RemoveAllEmbeddings(ctx); err != nil {
+		return err
+	}
+	es.questionCursor = "0"
+	es.answerCursor = "0"
+	es.index.reset("", nil)
+	return nil
+}
+
+// FuseSearchResults merge the keyword and semantic search results by the reciprocal rank fusion,
+// the result which is found by both of them is ranked higher
+func FuseSearchResults(keyword, semantic []*schema.SearchResult, size int) []*schema.SearchResult {
+	const k = 60
+	scores := make(map[string]float64)
+	results := make(map[string]*schema.SearchResult)
+	keys := make([]string, 0, len(keyword)+len(semantic))
+	for _, list := range [][]*schema.SearchResult{keyword, semantic} {
+		for rank, result := range list {
+			key := result.ObjectType + ":" + result.Object.ID
+			if _, ok := results[key]; !ok {
+				results[key] = result
+				keys = append(keys, key)
+			}
+			scores[key] += 1 / float64(k+rank+1)
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return scores[keys[i]] > scores[keys[j]]
+	})
+	if len(keys) > size {
+		keys = keys[:size]
+	}
+	fused := make([]*schema.SearchResult, 0, len(keys))
+	for _, key := range keys {
+		fused = append(fused, results[key])
+	}
+	return fused
+}
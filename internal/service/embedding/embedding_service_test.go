@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package embedding
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeVector(t *testing.T) {
+	vector := []float32{0.5, -1.25, 3}
+	assert.Equal(t, vector, decodeVector(encodeVector(vector)))
+	assert.Nil(t, decodeVector("invalid"))
+}
+
+func TestVectorIndex_Query(t *testing.T) {
+	index := newVectorIndex()
+	index.put("model", "1", "question", []float32{1, 0})
+	assert.False(t, index.loaded("model"))
+
+	index.reset("model", nil)
+	index.put("model", "1", "question", []float32{1, 0})
+	index.put("model", "2", "answer", []float32{1, 1})
+	index.put("model", "3", "answer", []float32{0, 1, 0})
+	index.put("other", "4", "answer", []float32{1, 0})
+	assert.True(t, index.loaded("model"))
+
+	matches := index.query([]float32{2, 0}, 10)
+	assert.Len(t, matches, 2)
+	assert.Equal(t, "1", matches[0].ObjectID)
+	assert.InDelta(t, 1, matches[0].Score, 1e-6)
+	assert.Equal(t, "2", matches[1].ObjectID)
+
+	assert.Len(t, index.query([]float32{2, 0}, 1), 1)
+}
+
+func TestFuseSearchResults(t *testing.T) {
+	result := func(objectType, id string) *schema.SearchResult {
+		return &schema.SearchResult{ObjectType: objectType, Object: &schema.SearchObject{ID: id}}
+	}
+	keyword := []*schema.SearchResult{result("question", "1"), result("question", "2"), result("answer", "3")}
+	semantic := []*schema.SearchResult{result("answer", "3"), result("question", "4")}
+
+	fused := FuseSearchResults(keyword, semantic, 3)
+	assert.Len(t, fused, 3)
+	assert.Equal(t, "3", fused[0].Object.ID)
+	assert.Equal(t, "1", fused[1].Object.ID)
+	// the ties are kept in the order of the keyword results
+	assert.Equal(t, "2", fused[2].Object.ID)
+}
+
+func TestParseOpenAIEmbeddingResp(t *testing.T) {
+	resp, err := parseOpenAIEmbeddingResp(http.StatusOK, []byte(`{"model":"m","data":[`+
+		`{"index":1,"embedding":[0.2]},{"index":0,"embedding":[0.1]}]}`), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.1}, {0.2}}, resp.Vectors)
+
+	_, err = parseOpenAIEmbeddingResp(http.StatusOK, []byte(`{"data":[{"index":0,"embedding":[0.1]}]}`), 2)
+	assert.Error(t, err)
+
+	_, err = parseOpenAIEmbeddingResp(http.StatusTooManyRequests, []byte(`{"error":{"message":"rate limit"}}`), 1)
+	assert.EqualError(t, err, "status 429: rate limit")
+}
@@ -0,0 +1,125 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+)
+
+const (
+	openAIEmbeddingTimeout = 30 * time.Second
+	// openAIEmbeddingMaxResp the max size of the response body read from the provider
+	openAIEmbeddingMaxResp = 8 << 20
+)
+
+// openAIEmbedder the built-in embedder which works with any OpenAI-compatible embeddings API
+type openAIEmbedder struct {
+	config     *schema.SiteAIAssistantResp
+	httpClient *http.Client
+}
+
+type openAIEmbeddingReq struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openAIEmbeddingResp struct {
+	Model string `json:"model"`
+	Data  []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func newOpenAIEmbedder(config *schema.SiteAIAssistantResp) *openAIEmbedder {
+	return &openAIEmbedder{
+		config:     config,
+		httpClient: &http.Client{Timeout: openAIEmbeddingTimeout},
+	}
+}
+
+func (e *openAIEmbedder) Info() plugin.Info {
+	return plugin.Info{SlugName: schema.AIAssistantProviderBuiltIn}
+}
+
+// Embed send the texts to the embeddings API and return the vectors in the same order
+func (e *openAIEmbedder) Embed(ctx context.Context, req *plugin.EmbeddingRequest) (
+	resp *plugin.EmbeddingResponse, err error) {
+	body, _ := json.Marshal(&openAIEmbeddingReq{Model: e.config.EmbeddingModel, Input: req.Texts})
+	endpoint := strings.TrimSuffix(e.config.Endpoint, "/") + "/embeddings"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if len(e.config.APIKey) > 0 {
+		httpReq.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+	httpResp, err := e.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(httpResp.Body, openAIEmbeddingMaxResp))
+	if err != nil {
+		return nil, err
+	}
+	return parseOpenAIEmbeddingResp(httpResp.StatusCode, respBody, len(req.Texts))
+}
+
+func parseOpenAIEmbeddingResp(statusCode int, body []byte, count int) (resp *plugin.EmbeddingResponse, err error) {
+	embeddingResp := &openAIEmbeddingResp{}
+	if err = json.Unmarshal(body, embeddingResp); err != nil {
+		return nil, fmt.Errorf("status %d: invalid response: %w", statusCode, err)
+	}
+	if embeddingResp.Error != nil {
+		return nil, fmt.Errorf("status %d: %s", statusCode, embeddingResp.Error.Message)
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", statusCode)
+	}
+	if len(embeddingResp.Data) != count {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(embeddingResp.Data), count)
+	}
+	resp = &plugin.EmbeddingResponse{
+		Vectors: make([][]float32, count),
+		Model:   embeddingResp.Model,
+	}
+	for _, data := range embeddingResp.Data {
+		if data.Index < 0 || data.Index >= count {
+			return nil, fmt.Errorf("invalid embedding index %d", data.Index)
+		}
+		resp.Vectors[data.Index] = data.Embedding
+	}
+	return resp, nil
+}
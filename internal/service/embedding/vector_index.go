@@ -0,0 +1,137 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package embedding
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/apache/incubator-answer/plugin"
+)
+
+type indexedVector struct {
+	objectType string
+	vector     []float32
+	norm       float64
+}
+
+// vectorIndex the in-memory index of the vectors of one model, it is searched by brute force
+type vectorIndex struct {
+	lock        sync.RWMutex
+	model       string
+	indexLoaded bool
+	vectors     map[string]*indexedVector
+}
+
+func newVectorIndex() *vectorIndex {
+	return &vectorIndex{vectors: make(map[string]*indexedVector)}
+}
+
+func (vi *vectorIndex) loaded(model string) bool {
+	vi.lock.RLock()
+	defer vi.lock.RUnlock()
+	return vi.indexLoaded && vi.model == model
+}
+
+// reset replace all the vectors of the index, the index is unloaded if the model is empty
+func (vi *vectorIndex) reset(model string, vectors map[string]*indexedVector) {
+	vi.lock.Lock()
+	defer vi.lock.Unlock()
+	if vectors == nil {
+		vectors = make(map[string]*indexedVector)
+	}
+	vi.model = model
+	vi.indexLoaded = len(model) > 0
+	vi.vectors = vectors
+}
+
+// put add or replace the vector, it is ignored if the index of the model is not loaded
+func (vi *vectorIndex) put(model, objectID, objectType string, vector []float32) {
+	vi.lock.Lock()
+	defer vi.lock.Unlock()
+	if !vi.indexLoaded || vi.model != model {
+		return
+	}
+	vi.vectors[objectID] = &indexedVector{objectType: objectType, vector: vector, norm: norm(vector)}
+}
+
+// query return the most similar vectors by the cosine similarity
+func (vi *vectorIndex) query(vector []float32, limit int) (matches []*plugin.VectorMatch) {
+	vi.lock.RLock()
+	defer vi.lock.RUnlock()
+	queryNorm := norm(vector)
+	matches = make([]*plugin.VectorMatch, 0)
+	if queryNorm == 0 {
+		return matches
+	}
+	for objectID, v := range vi.vectors {
+		if v.norm == 0 || len(v.vector) != len(vector) {
+			continue
+		}
+		var dot float64
+		for i := range vector {
+			dot += float64(vector[i]) * float64(v.vector[i])
+		}
+		matches = append(matches, &plugin.VectorMatch{
+			ObjectID:   objectID,
+			ObjectType: v.objectType,
+			Score:      dot / (queryNorm * v.norm),
+		})
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func norm(vector []float32) float64 {
+	var sum float64
+	for _, x := range vector {
+		sum += float64(x) * float64(x)
+	}
+	return math.Sqrt(sum)
+}
+
+// encodeVector encode the vector as the base64 of the little-endian float32
+func encodeVector(vector []float32) string {
+	buf := make([]byte, 4*len(vector))
+	for i, x := range vector {
+		binary.LittleEndian.PutUint32(buf[4*i:], math.Float32bits(x))
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+func decodeVector(encoded string) []float32 {
+	buf, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(buf)%4 != 0 {
+		return nil
+	}
+	vector := make([]float32, len(buf)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+	}
+	return vector
+}
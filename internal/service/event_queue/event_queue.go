@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_queue
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/log"
+)
+
+// EventQueueService the event bus, every event is dispatched to all registered handlers
+//
+//go:generate mockgen -source=./event_queue.go -destination=../mock/event_queue_service_mock.go -package=mock
+type EventQueueService interface {
+	Send(ctx context.Context, msg *schema.EventMsg)
+	RegisterHandler(handler func(ctx context.Context, msg *schema.EventMsg) error)
+}
+
+type eventQueueService struct {
+	lifecycle.PendingCounter
+	Queue    chan *schema.EventMsg
+	Handlers []func(ctx context.Context, msg *schema.EventMsg) error
+}
+
+func (es *eventQueueService) Send(ctx context.Context, msg *schema.EventMsg) {
+	es.Add()
+	es.Queue <- msg
+}
+
+func (es *eventQueueService) RegisterHandler(
+	handler func(ctx context.Context, msg *schema.EventMsg) error) {
+	es.Handlers = append(es.Handlers, handler)
+}
+
+func (es *eventQueueService) working() {
+	go func() {
+		for msg := range es.Queue {
+			es.handle(msg)
+			es.Done()
+		}
+	}()
+}
+
+func (es *eventQueueService) handle(msg *schema.EventMsg) {
+	log.Debugf("received event %+v", msg)
+	if len(es.Handlers) == 0 {
+		log.Warnf("no handler for event")
+		return
+	}
+	for _, handler := range es.Handlers {
+		if err := handler(context.Background(), msg); err != nil {
+			log.Error(err)
+		}
+	}
+}
+
+// NewEventQueueService create a new event queue service
+func NewEventQueueService() EventQueueService {
+	es := &eventQueueService{}
+	es.Queue = make(chan *schema.EventMsg, 128)
+	es.working()
+	lifecycle.OnShutdown("event queue", es.Drain)
+	return es
+}
@@ -0,0 +1,287 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_stream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+const (
+	brokerTimeout = 10 * time.Second
+	// brokerMaxRespSize the max size of the response body read from the broker
+	brokerMaxRespSize = 1 << 20
+	natsDefaultPort   = "4222"
+)
+
+// BrokerMessage the message published to the topic of the broker
+type BrokerMessage struct {
+	Topic   string
+	Key     string
+	Payload []byte
+}
+
+// Broker publish the messages to the message broker
+type Broker interface {
+	// Publish return nil only if the broker has accepted the message
+	Publish(ctx context.Context, msg *BrokerMessage) error
+	Close() error
+}
+
+// NewBroker new the broker of the driver in the settings
+func NewBroker(conf *schema.SiteEventStreamResp) (Broker, error) {
+	switch conf.Driver {
+	case schema.EventStreamDriverKafka:
+		return &kafkaBroker{
+			conf:       conf,
+			endpoint:   strings.TrimSuffix(conf.Endpoint, "/"),
+			httpClient: &http.Client{Timeout: brokerTimeout},
+		}, nil
+	case schema.EventStreamDriverNATS:
+		return &natsBroker{conf: conf}, nil
+	default:
+		return nil, fmt.Errorf("unsupported event stream driver: %s", conf.Driver)
+	}
+}
+
+// kafkaBroker publish the messages through the Kafka REST proxy v2 API
+type kafkaBroker struct {
+	conf       *schema.SiteEventStreamResp
+	endpoint   string
+	httpClient *http.Client
+}
+
+type kafkaRecord struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+type kafkaProduceReq struct {
+	Records []*kafkaRecord `json:"records"`
+}
+
+type kafkaProduceResp struct {
+	Offsets []struct {
+		ErrorCode *int    `json:"error_code"`
+		Error     *string `json:"error"`
+	} `json:"offsets"`
+	Message string `json:"message"`
+}
+
+func (b *kafkaBroker) Publish(ctx context.Context, msg *BrokerMessage) error {
+	body, _ := json.Marshal(&kafkaProduceReq{Records: []*kafkaRecord{{Key: msg.Key, Value: msg.Payload}}})
+	endpoint := fmt.Sprintf("%s/topics/%s", b.endpoint, url.PathEscape(msg.Topic))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+	if len(b.conf.Username) > 0 {
+		req.SetBasicAuth(b.conf.Username, b.conf.Password)
+	}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, brokerMaxRespSize))
+	if err != nil {
+		return err
+	}
+	produceResp := &kafkaProduceResp{}
+	_ = json.Unmarshal(respBody, produceResp)
+	if resp.StatusCode != http.StatusOK {
+		if len(produceResp.Message) > 0 {
+			return fmt.Errorf("status %d: %s", resp.StatusCode, produceResp.Message)
+		}
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	for _, offset := range produceResp.Offsets {
+		if offset.ErrorCode != nil && offset.Error != nil {
+			return fmt.Errorf("error code %d: %s", *offset.ErrorCode, *offset.Error)
+		}
+	}
+	return nil
+}
+
+func (b *kafkaBroker) Close() error {
+	b.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// natsBroker publish the messages to the NATS server in the verbose mode,
+// so every message is acknowledged by the server
+type natsBroker struct {
+	conf   *schema.SiteEventStreamResp
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+type natsServerInfo struct {
+	TLSRequired bool `json:"tls_required"`
+}
+
+type natsConnectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+}
+
+func (b *natsBroker) Publish(ctx context.Context, msg *BrokerMessage) (err error) {
+	if b.conn == nil {
+		if err = b.connect(ctx); err != nil {
+			return err
+		}
+	}
+	b.setDeadline(ctx)
+	if _, err = fmt.Fprintf(b.conn, "PUB %s %d\r\n%s\r\n", msg.Topic, len(msg.Payload), msg.Payload); err != nil {
+		_ = b.Close()
+		return err
+	}
+	if err = b.waitFor("+OK"); err != nil {
+		_ = b.Close()
+		return err
+	}
+	return nil
+}
+
+func (b *natsBroker) Close() error {
+	if b.conn == nil {
+		return nil
+	}
+	err := b.conn.Close()
+	b.conn, b.reader = nil, nil
+	return err
+}
+
+// connect dial the server, upgrade to tls if the server requires, and send the connect options
+func (b *natsBroker) connect(ctx context.Context) (err error) {
+	endpoint := b.conf.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "nats://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	host := u.Host
+	if len(u.Port()) == 0 {
+		host = net.JoinHostPort(u.Hostname(), natsDefaultPort)
+	}
+	dialer := &net.Dialer{Timeout: brokerTimeout}
+	b.conn, err = dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	b.reader = bufio.NewReader(b.conn)
+	b.setDeadline(ctx)
+
+	line, err := b.readLine()
+	if err != nil {
+		_ = b.Close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		_ = b.Close()
+		return fmt.Errorf("unexpected nats server greeting: %s", line)
+	}
+	info := &natsServerInfo{}
+	_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), info)
+	if info.TLSRequired || u.Scheme == "tls" {
+		tlsConn := tls.Client(b.conn, &tls.Config{ServerName: u.Hostname()})
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			_ = b.Close()
+			return err
+		}
+		b.conn = tlsConn
+		b.reader = bufio.NewReader(tlsConn)
+	}
+
+	options, _ := json.Marshal(&natsConnectOptions{
+		Verbose: true,
+		Name:    "answer",
+		Lang:    "go",
+		Version: "1.0.0",
+		User:    b.conf.Username,
+		Pass:    b.conf.Password,
+	})
+	if _, err = fmt.Fprintf(b.conn, "CONNECT %s\r\nPING\r\n", options); err != nil {
+		_ = b.Close()
+		return err
+	}
+	if err = b.waitFor("PONG"); err != nil {
+		_ = b.Close()
+		return err
+	}
+	return nil
+}
+
+// waitFor read the lines until the expected one, the ping of the server is answered on the way
+func (b *natsBroker) waitFor(expected string) error {
+	for {
+		line, err := b.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == expected:
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case line == "PING":
+			if _, err = io.WriteString(b.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (b *natsBroker) readLine() (string, error) {
+	line, err := b.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (b *natsBroker) setDeadline(ctx context.Context) {
+	deadline := time.Now().Add(brokerTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = b.conn.SetDeadline(deadline)
+}
@@ -0,0 +1,120 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaBroker_Publish(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/vnd.kafka.json.v2+json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		req := &kafkaProduceReq{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		assert.Equal(t, `{"id":"1"}`, string(req.Records[0].Value))
+		if r.URL.Path == "/topics/answer.fail" {
+			_, _ = w.Write([]byte(`{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"not enough replicas"}]}`))
+			return
+		}
+		assert.Equal(t, "/topics/answer.question.created", r.URL.Path)
+		_, _ = w.Write([]byte(`{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`))
+	}))
+	defer server.Close()
+
+	b, err := NewBroker(&schema.SiteEventStreamResp{Driver: schema.EventStreamDriverKafka, Endpoint: server.URL + "/"})
+	assert.NoError(t, err)
+	defer b.Close()
+	err = b.Publish(context.TODO(), &BrokerMessage{Topic: "answer.question.created", Payload: []byte(`{"id":"1"}`)})
+	assert.NoError(t, err)
+	err = b.Publish(context.TODO(), &BrokerMessage{Topic: "answer.fail", Payload: []byte(`{"id":"1"}`)})
+	assert.EqualError(t, err, "error code 50002: not enough replicas")
+}
+
+func TestNATSBroker_Publish(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		_, _ = io.WriteString(conn, "INFO {\"server_id\":\"test\"}\r\n")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				_, _ = io.WriteString(conn, "+OK\r\n")
+			case line == "PING":
+				_, _ = io.WriteString(conn, "PONG\r\n")
+			case strings.HasPrefix(line, "PUB "):
+				payload, _ := reader.ReadString('\n')
+				received <- strings.TrimPrefix(line, "PUB ") + " " + strings.TrimRight(payload, "\r\n")
+				if strings.Contains(line, "denied") {
+					_, _ = io.WriteString(conn, "-ERR 'Permissions Violation for Publish'\r\n")
+					continue
+				}
+				// the ping of the server is answered before the acknowledgement
+				_, _ = io.WriteString(conn, "PING\r\n+OK\r\n")
+			}
+		}
+	}()
+
+	b, err := NewBroker(&schema.SiteEventStreamResp{Driver: schema.EventStreamDriverNATS, Endpoint: listener.Addr().String()})
+	assert.NoError(t, err)
+	defer b.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = b.Publish(ctx, &BrokerMessage{Topic: "answer.user.registered", Payload: []byte(`{"id":"2"}`)})
+	assert.NoError(t, err)
+	assert.Equal(t, `answer.user.registered 10 {"id":"2"}`, <-received)
+
+	err = b.Publish(ctx, &BrokerMessage{Topic: "denied", Payload: []byte(`{}`)})
+	assert.EqualError(t, err, "nats server error: 'Permissions Violation for Publish'")
+	assert.Equal(t, `denied 2 {}`, <-received)
+}
+
+func TestRetryInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, retryInterval(1))
+	assert.Equal(t, 4*time.Minute, retryInterval(3))
+	assert.Equal(t, maxRetryInterval, retryInterval(20))
+}
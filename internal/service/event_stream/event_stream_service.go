@@ -0,0 +1,254 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package event_stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// deliveryBatchSize the number of the events read from the outbox at a time
+	deliveryBatchSize = 100
+	// maxRetryInterval the max interval between the attempts to publish the failed event
+	maxRetryInterval = time.Hour
+	// sentEventRetention the sent events are removed from the outbox after the retention
+	sentEventRetention = 7 * 24 * time.Hour
+)
+
+// EventOutboxRepo event outbox repository
+type EventOutboxRepo interface {
+	AddEvent(ctx context.Context, event *entity.EventOutbox) (err error)
+	GetDueEvents(ctx context.Context, now time.Time, limit int) (events []*entity.EventOutbox, err error)
+	MarkEventsSent(ctx context.Context, ids []int64) (err error)
+	UpdateEventAttempt(ctx context.Context, event *entity.EventOutbox) (err error)
+	RemoveSentEvents(ctx context.Context, before time.Time) (err error)
+}
+
+// EventStreamService publish the events of the event bus to the message broker through the outbox
+type EventStreamService struct {
+	outboxRepo      EventOutboxRepo
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	newBroker       func(conf *schema.SiteEventStreamResp) (Broker, error)
+	deliveryLock    sync.Mutex
+}
+
+// NewEventStreamService new event stream service
+func NewEventStreamService(
+	outboxRepo EventOutboxRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
+) *EventStreamService {
+	es := &EventStreamService{
+		outboxRepo:      outboxRepo,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		newBroker:       NewBroker,
+	}
+	eventQueueService.RegisterHandler(es.HandleEvent)
+	return es
+}
+
+// GetEventStream get the event stream settings
+func (es *EventStreamService) GetEventStream(ctx context.Context) (resp *schema.SiteEventStreamResp, err error) {
+	resp = &schema.SiteEventStreamResp{}
+	if err = es.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeEventStream, resp); err != nil {
+		return nil, err
+	}
+	if resp.Events == nil {
+		resp.Events = make([]string, 0)
+	}
+	return resp, nil
+}
+
+// UpdateEventStream update the event stream settings
+func (es *EventStreamService) UpdateEventStream(ctx context.Context, req *schema.SiteEventStreamReq) (err error) {
+	if req.Enabled && (len(req.Driver) == 0 || len(req.Endpoint) == 0) {
+		return errors.BadRequest(reason.EventStreamConfigInvalid)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeEventStream,
+		Content: string(content),
+	}
+	return es.siteInfoRepo.SaveByType(ctx, constant.SiteTypeEventStream, data)
+}
+
+// HandleEvent save the chosen event to the outbox and try to publish it
+func (es *EventStreamService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	conf, err := es.GetEventStream(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || !isStreamEvent(conf, msg.EventType) {
+		return nil
+	}
+	payload, _ := json.Marshal(&schema.StreamEvent{
+		EventType:  string(msg.EventType),
+		UserID:     msg.UserID,
+		ObjectID:   msg.ObjectID,
+		QuestionID: msg.QuestionID,
+		CreatedAt:  time.Now().Unix(),
+	})
+	err = es.outboxRepo.AddEvent(ctx, &entity.EventOutbox{
+		EventType:     string(msg.EventType),
+		Topic:         conf.TopicPrefix + string(msg.EventType),
+		Payload:       string(payload),
+		Status:        entity.EventOutboxStatusPending,
+		NextAttemptAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	go es.Deliver(context.Background())
+	return nil
+}
+
+// DeliveryCron publish the events in the outbox which are not sent yet and remove the old sent ones
+func (es *EventStreamService) DeliveryCron(ctx context.Context) (err error) {
+	es.Deliver(ctx)
+	return es.outboxRepo.RemoveSentEvents(ctx, time.Now().Add(-sentEventRetention))
+}
+
+// Deliver publish the due events in the outbox in order, it stops at the first failure and
+// the failed event is retried later with the increasing interval
+func (es *EventStreamService) Deliver(ctx context.Context) {
+	if !es.deliveryLock.TryLock() {
+		return
+	}
+	defer es.deliveryLock.Unlock()
+
+	conf, err := es.GetEventStream(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	broker, err := es.newBroker(conf)
+	if err != nil {
+		log.Errorf("new event stream broker failed: %v", err)
+		return
+	}
+	defer broker.Close()
+
+	for {
+		events, err := es.outboxRepo.GetDueEvents(ctx, time.Now(), deliveryBatchSize)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		sentIDs := make([]int64, 0, len(events))
+		var failed *entity.EventOutbox
+		for _, event := range events {
+			if err = broker.Publish(ctx, toBrokerMessage(event)); err != nil {
+				failed = event
+				break
+			}
+			sentIDs = append(sentIDs, event.ID)
+		}
+		if len(sentIDs) > 0 {
+			if err := es.outboxRepo.MarkEventsSent(ctx, sentIDs); err != nil {
+				log.Error(err)
+				return
+			}
+		}
+		if failed != nil {
+			log.Warnf("publish event %d to %s failed: %v", failed.ID, failed.Topic, err)
+			es.retryLater(ctx, failed, err)
+			return
+		}
+		if len(events) < deliveryBatchSize {
+			return
+		}
+	}
+}
+
+// retryLater record the failure and delay the next attempt exponentially
+func (es *EventStreamService) retryLater(ctx context.Context, event *entity.EventOutbox, publishErr error) {
+	event.Attempts++
+	event.NextAttemptAt = time.Now().Add(retryInterval(event.Attempts))
+	event.LastError = publishErr.Error()
+	if len(event.LastError) > 500 {
+		event.LastError = event.LastError[:500]
+	}
+	if err := es.outboxRepo.UpdateEventAttempt(ctx, event); err != nil {
+		log.Error(err)
+	}
+}
+
+// retryInterval the interval before the next attempt, it doubles from 1 minute up to maxRetryInterval
+func retryInterval(attempts int) time.Duration {
+	interval := time.Minute
+	for i := 1; i < attempts && interval < maxRetryInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxRetryInterval {
+		interval = maxRetryInterval
+	}
+	return interval
+}
+
+func isStreamEvent(conf *schema.SiteEventStreamResp, eventType constant.EventType) bool {
+	if len(conf.Events) == 0 {
+		for _, t := range schema.DefaultStreamEvents {
+			if t == eventType {
+				return true
+			}
+		}
+		return false
+	}
+	for _, t := range conf.Events {
+		if t == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// toBrokerMessage fill the outbox id in the payload, the consumers use it to skip the duplicated events
+func toBrokerMessage(event *entity.EventOutbox) *BrokerMessage {
+	id := strconv.FormatInt(event.ID, 10)
+	streamEvent := &schema.StreamEvent{}
+	payload := []byte(event.Payload)
+	if err := json.Unmarshal(payload, streamEvent); err == nil {
+		streamEvent.ID = id
+		payload, _ = json.Marshal(streamEvent)
+	} else {
+		log.Warnf("parse the payload of event %d failed: %v", event.ID, err)
+	}
+	return &BrokerMessage{Topic: event.Topic, Key: fmt.Sprintf("%s:%s", event.EventType, id), Payload: payload}
+}
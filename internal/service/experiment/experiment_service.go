@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package experiment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// ExperimentRepo experiment event repository
+type ExperimentRepo interface {
+	AddEvent(ctx context.Context, event *entity.ExperimentEvent) (err error)
+	GetEventCounts(ctx context.Context, experimentKey string) (counts []*schema.ExperimentEventCount, err error)
+}
+
+// ExperimentService the experiments of the ranking and the ui flags. The users are assigned to the variants
+// deterministically, so the same user always gets the same variant as long as the variants are not changed.
+type ExperimentService struct {
+	experimentRepo  ExperimentRepo
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewExperimentService new experiment service
+func NewExperimentService(
+	experimentRepo ExperimentRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *ExperimentService {
+	return &ExperimentService{
+		experimentRepo:  experimentRepo,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetExperiments get the experiments settings
+func (es *ExperimentService) GetExperiments(ctx context.Context) (resp *schema.SiteExperimentsResp, err error) {
+	resp = &schema.SiteExperimentsResp{}
+	if err = es.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeExperiments, resp); err != nil {
+		return nil, err
+	}
+	if resp.Experiments == nil {
+		resp.Experiments = make([]*schema.Experiment, 0)
+	}
+	return resp, nil
+}
+
+// UpdateExperiments update the experiments settings
+func (es *ExperimentService) UpdateExperiments(ctx context.Context, req *schema.SiteExperimentsReq) (err error) {
+	experimentKeys := make(map[string]bool)
+	for _, experiment := range req.Experiments {
+		if experimentKeys[experiment.Key] || totalWeight(experiment) <= 0 {
+			return errors.BadRequest(reason.ExperimentInvalid)
+		}
+		experimentKeys[experiment.Key] = true
+		variantKeys := make(map[string]bool)
+		for _, variant := range experiment.Variants {
+			if variantKeys[variant.Key] {
+				return errors.BadRequest(reason.ExperimentInvalid)
+			}
+			variantKeys[variant.Key] = true
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeExperiments,
+		Content: string(content),
+	}
+	return es.siteInfoRepo.SaveByType(ctx, constant.SiteTypeExperiments, data)
+}
+
+// GetRunningExperiments get the enabled experiments for the site info
+func (es *ExperimentService) GetRunningExperiments(ctx context.Context) (resp []*schema.SiteExperimentInfo) {
+	resp = make([]*schema.SiteExperimentInfo, 0)
+	for _, experiment := range es.getRunningExperiments(ctx) {
+		info := &schema.SiteExperimentInfo{Key: experiment.Key}
+		for _, variant := range experiment.Variants {
+			info.Variants = append(info.Variants, variant.Key)
+		}
+		resp = append(resp, info)
+	}
+	return resp
+}
+
+// GetUserVariants get the variants of the running experiments assigned to the user
+func (es *ExperimentService) GetUserVariants(ctx context.Context, userID string) (variants map[string]string) {
+	variants = make(map[string]string)
+	if len(userID) == 0 {
+		return variants
+	}
+	for _, experiment := range es.getRunningExperiments(ctx) {
+		variants[experiment.Key] = AssignVariant(experiment, userID)
+	}
+	return variants
+}
+
+// AddEvent record the exposure or the conversion of the user, the variant is always assigned by the server
+func (es *ExperimentService) AddEvent(ctx context.Context, req *schema.AddExperimentEventReq) (
+	resp *schema.AddExperimentEventResp, err error) {
+	experiment := es.getRunningExperiment(ctx, req.ExperimentKey)
+	if experiment == nil {
+		return nil, errors.BadRequest(reason.ExperimentNotFound)
+	}
+	event := &entity.ExperimentEvent{
+		ExperimentKey: experiment.Key,
+		UserID:        req.UserID,
+		EventType:     req.EventType,
+		Name:          req.Name,
+		Variant:       AssignVariant(experiment, req.UserID),
+	}
+	if err = es.experimentRepo.AddEvent(ctx, event); err != nil {
+		return nil, err
+	}
+	return &schema.AddExperimentEventResp{Variant: event.Variant}, nil
+}
+
+// GetExperimentStats get the number of the exposed users and the converted users of each variant
+func (es *ExperimentService) GetExperimentStats(ctx context.Context, req *schema.GetExperimentStatsReq) (
+	resp *schema.GetExperimentStatsResp, err error) {
+	counts, err := es.experimentRepo.GetEventCounts(ctx, req.ExperimentKey)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetExperimentStatsResp{
+		ExperimentKey: req.ExperimentKey,
+		Variants:      make([]*schema.ExperimentVariantStats, 0),
+	}
+
+	// keep the order of the variants in the settings, the removed variants are appended after them
+	variantStats := make(map[string]*schema.ExperimentVariantStats)
+	addVariant := func(variant string) *schema.ExperimentVariantStats {
+		if stats, ok := variantStats[variant]; ok {
+			return stats
+		}
+		stats := &schema.ExperimentVariantStats{Variant: variant, Conversions: make([]*schema.ExperimentConversionStats, 0)}
+		variantStats[variant] = stats
+		resp.Variants = append(resp.Variants, stats)
+		return stats
+	}
+	experiments, err := es.GetExperiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, experiment := range experiments.Experiments {
+		if experiment.Key != req.ExperimentKey {
+			continue
+		}
+		for _, variant := range experiment.Variants {
+			addVariant(variant.Key)
+		}
+	}
+	for _, count := range counts {
+		if count.EventType == schema.ExperimentEventExposure {
+			addVariant(count.Variant).Exposures = count.Users
+		}
+	}
+	for _, count := range counts {
+		if count.EventType != schema.ExperimentEventConversion {
+			continue
+		}
+		stats := addVariant(count.Variant)
+		conversion := &schema.ExperimentConversionStats{Name: count.Name, Users: count.Users}
+		if stats.Exposures > 0 {
+			conversion.Rate = float64(count.Users*10000/stats.Exposures) / 100
+		}
+		stats.Conversions = append(stats.Conversions, conversion)
+	}
+	return resp, nil
+}
+
+func (es *ExperimentService) getRunningExperiment(ctx context.Context, experimentKey string) *schema.Experiment {
+	for _, experiment := range es.getRunningExperiments(ctx) {
+		if experiment.Key == experimentKey {
+			return experiment
+		}
+	}
+	return nil
+}
+
+func (es *ExperimentService) getRunningExperiments(ctx context.Context) (running []*schema.Experiment) {
+	experiments, err := es.GetExperiments(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	for _, experiment := range experiments.Experiments {
+		if experiment.Enabled && totalWeight(experiment) > 0 {
+			running = append(running, experiment)
+		}
+	}
+	return running
+}
+
+// AssignVariant assign the variant to the user by the hash of the experiment key and the user id,
+// so the buckets of the different experiments are independent of each other
+func AssignVariant(experiment *schema.Experiment, userID string) string {
+	total := totalWeight(experiment)
+	if total <= 0 {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(experiment.Key + ":" + userID))
+	bucket := int(binary.BigEndian.Uint64(sum[:8]) % uint64(total))
+	for _, variant := range experiment.Variants {
+		if bucket < variant.Weight {
+			return variant.Key
+		}
+		bucket -= variant.Weight
+	}
+	return ""
+}
+
+func totalWeight(experiment *schema.Experiment) (total int) {
+	for _, variant := range experiment.Variants {
+		total += variant.Weight
+	}
+	return total
+}
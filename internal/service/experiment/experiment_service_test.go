@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package experiment
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssignVariant(t *testing.T) {
+	experiment := &schema.Experiment{
+		Key: "question_list_ranking",
+		Variants: []*schema.ExperimentVariant{
+			{Key: "control", Weight: 75},
+			{Key: "treatment", Weight: 25},
+		},
+	}
+	assert.Equal(t, AssignVariant(experiment, "1"), AssignVariant(experiment, "1"))
+
+	counts := make(map[string]int)
+	for i := 0; i < 10000; i++ {
+		counts[AssignVariant(experiment, fmt.Sprint(i))]++
+	}
+	assert.Len(t, counts, 2)
+	assert.InDelta(t, 7500, counts["control"], 300)
+	assert.InDelta(t, 2500, counts["treatment"], 300)
+
+	experiment.Variants[1].Weight = 0
+	assert.Equal(t, "control", AssignVariant(experiment, "1"))
+	experiment.Variants[0].Weight = 0
+	assert.Equal(t, "", AssignVariant(experiment, "1"))
+}
@@ -0,0 +1,410 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/httpclient"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/net/context"
+)
+
+// EmailDeliveryRepo email send log and suppression repository
+type EmailDeliveryRepo interface {
+	AddEmailSendLog(ctx context.Context, sendLog *entity.EmailSendLog) (err error)
+	UpdateEmailSendLogStatus(ctx context.Context, provider, messageID, status, detail string) (err error)
+	GetEmailSendLogPage(ctx context.Context, page, pageSize int, email, status string) (
+		sendLogs []*entity.EmailSendLog, total int64, err error)
+	IsEmailSuppressed(ctx context.Context, email string) (suppressed bool, err error)
+	AddEmailSuppression(ctx context.Context, suppression *entity.EmailSuppression) (err error)
+	GetEmailSuppressionPage(ctx context.Context, page, pageSize int, email string) (
+		suppressions []*entity.EmailSuppression, total int64, err error)
+	RemoveEmailSuppression(ctx context.Context, email string) (err error)
+}
+
+// emailDeliveryEvent the delivery event of the email reported by the provider
+type emailDeliveryEvent struct {
+	MessageID string
+	Email     string
+	Status    string
+	Detail    string
+	// SuppressReason the address is suppressed with this reason, empty means not suppressed
+	SuppressReason string
+}
+
+// GenerateWebhookKey generate the key of the delivery webhook url
+func GenerateWebhookKey() string {
+	key := make([]byte, 24)
+	_, _ = rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+// GetDeliveryWebhookURL get the url of the delivery webhook which is set in the provider,
+// it is empty for the smtp because the smtp server does not report the delivery events
+func (es *EmailService) GetDeliveryWebhookURL(ctx context.Context, ec *EmailConfig) string {
+	if ec.ProviderName() == EmailProviderSMTP || len(ec.WebhookKey) == 0 {
+		return ""
+	}
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	return fmt.Sprintf("%s/answer/api/v1/email/webhook/%s?key=%s", siteInfo.SiteUrl, ec.ProviderName(), ec.WebhookKey)
+}
+
+// HandleDeliveryWebhook update the send logs with the delivery events of the provider,
+// the address is suppressed when it is hard bounced or the recipient reported the email as spam
+func (es *EmailService) HandleDeliveryWebhook(ctx context.Context, req *schema.EmailDeliveryWebhookReq) (err error) {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if len(ec.WebhookKey) == 0 || subtle.ConstantTimeCompare([]byte(ec.WebhookKey), []byte(req.Key)) != 1 {
+		return errors.Forbidden(reason.EmailWebhookKeyInvalid)
+	}
+
+	var events []*emailDeliveryEvent
+	switch req.Provider {
+	case EmailProviderSendGrid:
+		events, err = parseSendGridEvents(req.Body)
+	case EmailProviderMailgun:
+		events, err = parseMailgunEvents(req.Body)
+	case EmailProviderSES:
+		var subscribeURL string
+		events, subscribeURL, err = parseSESEvents(req.Body)
+		if err == nil && len(subscribeURL) > 0 {
+			err = confirmSNSSubscription(ctx, subscribeURL)
+		}
+	default:
+		return errors.BadRequest(reason.EmailProviderUnsupported)
+	}
+	if err != nil {
+		return errors.BadRequest(reason.RequestFormatError).WithError(err).WithStack()
+	}
+
+	for _, event := range events {
+		if len(event.MessageID) > 0 {
+			err = es.emailDeliveryRepo.UpdateEmailSendLogStatus(ctx, req.Provider, event.MessageID,
+				event.Status, truncateString(event.Detail, 512))
+			if err != nil {
+				return err
+			}
+		}
+		if len(event.SuppressReason) > 0 && len(event.Email) > 0 {
+			log.Infof("suppress email %s because of %s", event.Email, event.SuppressReason)
+			err = es.emailDeliveryRepo.AddEmailSuppression(ctx, &entity.EmailSuppression{
+				Email:    normalizeEmail(event.Email),
+				Reason:   event.SuppressReason,
+				Provider: req.Provider,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetEmailSendLogPage get the emails sent by the site, the latest first
+func (es *EmailService) GetEmailSendLogPage(ctx context.Context, req *schema.GetEmailSendLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	sendLogs, total, err := es.emailDeliveryRepo.GetEmailSendLogPage(ctx, req.Page, req.PageSize,
+		normalizeEmail(req.Email), req.Status)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetEmailSendLogResp, 0, len(sendLogs))
+	for _, sendLog := range sendLogs {
+		list = append(list, &schema.GetEmailSendLogResp{
+			ID:        sendLog.ID,
+			CreatedAt: sendLog.CreatedAt.Unix(),
+			UpdatedAt: sendLog.UpdatedAt.Unix(),
+			Provider:  sendLog.Provider,
+			MessageID: sendLog.MessageID,
+			ToEmail:   sendLog.ToEmail,
+			Subject:   sendLog.Subject,
+			Status:    sendLog.Status,
+			Error:     sendLog.Error,
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// GetEmailSuppressionPage get the suppressed addresses, the latest first
+func (es *EmailService) GetEmailSuppressionPage(ctx context.Context, req *schema.GetEmailSuppressionPageReq) (
+	pageModel *pager.PageModel, err error) {
+	suppressions, total, err := es.emailDeliveryRepo.GetEmailSuppressionPage(ctx, req.Page, req.PageSize,
+		normalizeEmail(req.Email))
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetEmailSuppressionResp, 0, len(suppressions))
+	for _, suppression := range suppressions {
+		list = append(list, &schema.GetEmailSuppressionResp{
+			ID:        suppression.ID,
+			CreatedAt: suppression.CreatedAt.Unix(),
+			Email:     suppression.Email,
+			Reason:    suppression.Reason,
+			Provider:  suppression.Provider,
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// RemoveEmailSuppression remove the suppressed address, the emails are sent to it again
+func (es *EmailService) RemoveEmailSuppression(ctx context.Context, req *schema.RemoveEmailSuppressionReq) (err error) {
+	return es.emailDeliveryRepo.RemoveEmailSuppression(ctx, normalizeEmail(req.Email))
+}
+
+func (es *EmailService) addEmailSendLog(ctx context.Context, sendLog *entity.EmailSendLog) {
+	if err := es.emailDeliveryRepo.AddEmailSendLog(ctx, sendLog); err != nil {
+		log.Errorf("add email send log failed: %s", err)
+	}
+}
+
+type sendGridEvent struct {
+	Email       string `json:"email"`
+	Event       string `json:"event"`
+	Type        string `json:"type"`
+	Reason      string `json:"reason"`
+	SGMessageID string `json:"sg_message_id"`
+}
+
+// parseSendGridEvents parse the events of the SendGrid event webhook
+func parseSendGridEvents(body []byte) (events []*emailDeliveryEvent, err error) {
+	sgEvents := make([]*sendGridEvent, 0)
+	if err = json.Unmarshal(body, &sgEvents); err != nil {
+		return nil, err
+	}
+	for _, sgEvent := range sgEvents {
+		// the sg_message_id is the X-Message-Id of the response with the suffix of the filter
+		messageID := sgEvent.SGMessageID
+		if idx := strings.Index(messageID, ".filter"); idx >= 0 {
+			messageID = messageID[:idx]
+		}
+		event := &emailDeliveryEvent{MessageID: messageID, Email: sgEvent.Email, Detail: sgEvent.Reason}
+		switch sgEvent.Event {
+		case "delivered":
+			event.Status = entity.EmailSendStatusDelivered
+		case "bounce":
+			event.Status = entity.EmailSendStatusBounced
+			// the blocked bounce is temporary, the others are refused by the receiving server
+			if sgEvent.Type != "blocked" {
+				event.SuppressReason = entity.EmailSuppressionReasonHardBounce
+			}
+		case "dropped":
+			event.Status = entity.EmailSendStatusFailed
+		case "spamreport":
+			event.Status = entity.EmailSendStatusComplained
+			event.SuppressReason = entity.EmailSuppressionReasonComplaint
+		default:
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+type mailgunWebhook struct {
+	EventData struct {
+		Event          string `json:"event"`
+		Severity       string `json:"severity"`
+		Recipient      string `json:"recipient"`
+		Reason         string `json:"reason"`
+		DeliveryStatus struct {
+			Description string `json:"description"`
+			Message     string `json:"message"`
+		} `json:"delivery-status"`
+		Message struct {
+			Headers struct {
+				MessageID string `json:"message-id"`
+			} `json:"headers"`
+		} `json:"message"`
+	} `json:"event-data"`
+}
+
+// parseMailgunEvents parse the event of the Mailgun webhook, each request has one event
+func parseMailgunEvents(body []byte) (events []*emailDeliveryEvent, err error) {
+	webhook := &mailgunWebhook{}
+	if err = json.Unmarshal(body, webhook); err != nil {
+		return nil, err
+	}
+	data := webhook.EventData
+	event := &emailDeliveryEvent{
+		MessageID: data.Message.Headers.MessageID,
+		Email:     data.Recipient,
+		Detail:    data.DeliveryStatus.Message,
+	}
+	if len(event.Detail) == 0 {
+		event.Detail = data.DeliveryStatus.Description
+	}
+	switch data.Event {
+	case "delivered":
+		event.Status = entity.EmailSendStatusDelivered
+	case "failed":
+		// the temporary failure is retried by Mailgun
+		if data.Severity != "permanent" {
+			return nil, nil
+		}
+		event.Status = entity.EmailSendStatusBounced
+		if data.Reason == "bounce" || data.Reason == "suppress-bounce" {
+			event.SuppressReason = entity.EmailSuppressionReasonHardBounce
+		}
+	case "complained":
+		event.Status = entity.EmailSendStatusComplained
+		event.SuppressReason = entity.EmailSuppressionReasonComplaint
+	default:
+		return nil, nil
+	}
+	return []*emailDeliveryEvent{event}, nil
+}
+
+type snsMessage struct {
+	Type         string `json:"Type"`
+	Message      string `json:"Message"`
+	SubscribeURL string `json:"SubscribeURL"`
+}
+
+type sesRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Mail             struct {
+		MessageID string `json:"messageId"`
+	} `json:"mail"`
+	Bounce struct {
+		BounceType        string          `json:"bounceType"`
+		BouncedRecipients []*sesRecipient `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplainedRecipients []*sesRecipient `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery struct {
+		Recipients []string `json:"recipients"`
+	} `json:"delivery"`
+}
+
+// parseSESEvents parse the SES notification delivered by the Amazon SNS,
+// the subscribe url is returned when the SNS asks to confirm the subscription
+func parseSESEvents(body []byte) (events []*emailDeliveryEvent, subscribeURL string, err error) {
+	msg := &snsMessage{}
+	if err = json.Unmarshal(body, msg); err != nil {
+		return nil, "", err
+	}
+	switch msg.Type {
+	case "SubscriptionConfirmation":
+		return nil, msg.SubscribeURL, nil
+	case "Notification":
+	default:
+		return nil, "", nil
+	}
+
+	notification := &sesNotification{}
+	if err = json.Unmarshal([]byte(msg.Message), notification); err != nil {
+		return nil, "", err
+	}
+	notificationType := notification.NotificationType
+	if len(notificationType) == 0 {
+		notificationType = notification.EventType
+	}
+	messageID := notification.Mail.MessageID
+	switch notificationType {
+	case "Delivery":
+		for _, recipient := range notification.Delivery.Recipients {
+			events = append(events, &emailDeliveryEvent{
+				MessageID: messageID, Email: recipient, Status: entity.EmailSendStatusDelivered})
+		}
+	case "Bounce":
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			event := &emailDeliveryEvent{MessageID: messageID, Email: recipient.EmailAddress,
+				Status: entity.EmailSendStatusBounced, Detail: recipient.DiagnosticCode}
+			if notification.Bounce.BounceType == "Permanent" {
+				event.SuppressReason = entity.EmailSuppressionReasonHardBounce
+			}
+			events = append(events, event)
+		}
+	case "Complaint":
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			events = append(events, &emailDeliveryEvent{MessageID: messageID, Email: recipient.EmailAddress,
+				Status: entity.EmailSendStatusComplained, SuppressReason: entity.EmailSuppressionReasonComplaint})
+		}
+	}
+	return events, "", nil
+}
+
+// confirmSNSSubscription visit the subscribe url to confirm the subscription of the SNS topic,
+// only the url of the SNS is visited
+func confirmSNSSubscription(ctx context.Context, subscribeURL string) (err error) {
+	u, err := url.Parse(subscribeURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "https" || !strings.HasPrefix(u.Hostname(), "sns.") || !strings.HasSuffix(u.Hostname(), ".amazonaws.com") {
+		return fmt.Errorf("invalid subscribe url: %s", subscribeURL)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpclient.NewPublicClient(emailProviderTimeout, 0).Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("confirm subscription failed: status %d", resp.StatusCode)
+	}
+	log.Infof("confirm sns subscription of %s success", u.Query().Get("TopicArn"))
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// truncateString truncate the string to the max bytes without breaking the utf-8 characters
+func truncateString(s string, maxBytes int) string {
+	if len(s) <= maxBytes {
+		return s
+	}
+	for maxBytes > 0 && !utf8.RuneStart(s[maxBytes]) {
+		maxBytes--
+	}
+	return s[:maxBytes]
+}
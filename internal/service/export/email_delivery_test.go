@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSendGridEvents(t *testing.T) {
+	events, err := parseSendGridEvents([]byte(`[
+		{"email":"a@example.com","event":"delivered","sg_message_id":"id1.filterdrecv-1.0"},
+		{"email":"b@example.com","event":"bounce","type":"bounce","reason":"550 no such user","sg_message_id":"id2.filterdrecv-1.0"},
+		{"email":"c@example.com","event":"bounce","type":"blocked","sg_message_id":"id3.filterdrecv-1.0"},
+		{"email":"d@example.com","event":"open","sg_message_id":"id4.filterdrecv-1.0"}
+	]`))
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "id1", events[0].MessageID)
+	assert.Equal(t, entity.EmailSendStatusDelivered, events[0].Status)
+	assert.Equal(t, entity.EmailSuppressionReasonHardBounce, events[1].SuppressReason)
+	assert.Equal(t, "550 no such user", events[1].Detail)
+	assert.Empty(t, events[2].SuppressReason)
+}
+
+func TestParseMailgunEvents(t *testing.T) {
+	events, err := parseMailgunEvents([]byte(`{"event-data":{"event":"failed","severity":"permanent","reason":"bounce",
+		"recipient":"a@example.com","delivery-status":{"message":"550 mailbox unavailable"},
+		"message":{"headers":{"message-id":"mg-id@mg.example.com"}}}}`))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "mg-id@mg.example.com", events[0].MessageID)
+	assert.Equal(t, entity.EmailSendStatusBounced, events[0].Status)
+	assert.Equal(t, entity.EmailSuppressionReasonHardBounce, events[0].SuppressReason)
+
+	events, err = parseMailgunEvents([]byte(`{"event-data":{"event":"failed","severity":"temporary"}}`))
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestParseSESEvents(t *testing.T) {
+	events, subscribeURL, err := parseSESEvents([]byte(`{"Type":"SubscriptionConfirmation",
+		"SubscribeURL":"https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"}`))
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription", subscribeURL)
+
+	events, _, err = parseSESEvents([]byte(`{"Type":"Notification","Message":"{\"notificationType\":\"Bounce\",` +
+		`\"mail\":{\"messageId\":\"ses-id\"},\"bounce\":{\"bounceType\":\"Permanent\",` +
+		`\"bouncedRecipients\":[{\"emailAddress\":\"a@example.com\"}]}}"}`))
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "ses-id", events[0].MessageID)
+	assert.Equal(t, "a@example.com", events[0].Email)
+	assert.Equal(t, entity.EmailSuppressionReasonHardBounce, events[0].SuppressReason)
+}
+
+func TestConfirmSNSSubscription_InvalidURL(t *testing.T) {
+	assert.Error(t, confirmSNSSubscription(context.TODO(), "https://example.com/?Action=ConfirmSubscription"))
+	assert.Error(t, confirmSNSSubscription(context.TODO(), "http://sns.us-east-1.amazonaws.com/"))
+}
+
+func TestTruncateString(t *testing.T) {
+	assert.Equal(t, "abc", truncateString("abc", 5))
+	assert.Equal(t, "ab", truncateString("abcd", 2))
+	assert.Equal(t, "a", truncateString("a你好", 3))
+}
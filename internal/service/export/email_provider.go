@@ -0,0 +1,283 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/gomail.v2"
+)
+
+const (
+	EmailProviderSMTP     = "smtp"
+	EmailProviderSendGrid = "sendgrid"
+	EmailProviderMailgun  = "mailgun"
+	EmailProviderSES      = "ses"
+)
+
+const (
+	emailProviderTimeout = 30 * time.Second
+	// emailProviderMaxRespSize the max size of the response body read from the provider
+	emailProviderMaxRespSize = 1 << 20
+)
+
+// EmailMessage the email sent through the provider
+type EmailMessage struct {
+	FromEmail string
+	FromName  string
+	To        string
+	Subject   string
+	Body      string
+	// ReplyTo the address the replies are sent to, empty means the from address
+	ReplyTo string
+}
+
+// EmailProvider send the email through the smtp server or the api of the email service
+type EmailProvider interface {
+	// Name the provider name, it is recorded in the send log
+	Name() string
+	// Send send the email and return the message id which the delivery webhook events refer to
+	Send(ctx context.Context, msg *EmailMessage) (messageID string, err error)
+}
+
+// NewEmailProvider new the provider of the email config
+func NewEmailProvider(ec *EmailConfig) (EmailProvider, error) {
+	httpClient := &http.Client{Timeout: emailProviderTimeout}
+	switch ec.ProviderName() {
+	case EmailProviderSMTP:
+		return &smtpProvider{config: ec}, nil
+	case EmailProviderSendGrid:
+		return &sendGridProvider{config: ec, endpoint: "https://api.sendgrid.com", httpClient: httpClient}, nil
+	case EmailProviderMailgun:
+		endpoint := "https://api.mailgun.net"
+		if ec.MailgunRegion == "eu" {
+			endpoint = "https://api.eu.mailgun.net"
+		}
+		return &mailgunProvider{config: ec, endpoint: endpoint, httpClient: httpClient}, nil
+	case EmailProviderSES:
+		endpoint := fmt.Sprintf("https://email.%s.amazonaws.com", ec.SESRegion)
+		return &sesProvider{config: ec, endpoint: endpoint, httpClient: httpClient, now: time.Now}, nil
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", ec.Provider)
+	}
+}
+
+// smtpProvider send the email through the smtp server
+type smtpProvider struct {
+	config *EmailConfig
+}
+
+func (p *smtpProvider) Name() string {
+	return EmailProviderSMTP
+}
+
+func (p *smtpProvider) Send(_ context.Context, msg *EmailMessage) (messageID string, err error) {
+	ec := p.config
+	// the smtp server does not return the message id, so it is generated to be able to find the email in the logs
+	messageID = generateMessageID(msg.FromEmail)
+
+	m := gomail.NewMessage()
+	m.SetHeader("From", formatFromAddress(msg))
+	m.SetHeader("To", msg.To)
+	m.SetHeader("Subject", msg.Subject)
+	m.SetHeader("Message-ID", "<"+messageID+">")
+	if len(msg.ReplyTo) > 0 {
+		m.SetHeader("Reply-To", msg.ReplyTo)
+	}
+	m.SetBody("text/html", msg.Body)
+
+	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
+	if ec.IsSSL() {
+		d.SSL = true
+	}
+	if ec.IsTLS() {
+		d.SSL = false
+	}
+	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
+		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
+	}
+	if err = d.DialAndSend(m); err != nil {
+		return "", err
+	}
+	return messageID, nil
+}
+
+// sendGridProvider send the email through the SendGrid v3 mail send API
+type sendGridProvider struct {
+	config     *EmailConfig
+	endpoint   string
+	httpClient *http.Client
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To []*sendGridAddress `json:"to"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridMailReq struct {
+	Personalizations []*sendGridPersonalization `json:"personalizations"`
+	From             *sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress           `json:"reply_to,omitempty"`
+	Subject          string                     `json:"subject"`
+	Content          []*sendGridContent         `json:"content"`
+}
+
+type sendGridErrorResp struct {
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func (p *sendGridProvider) Name() string {
+	return EmailProviderSendGrid
+}
+
+func (p *sendGridProvider) Send(ctx context.Context, msg *EmailMessage) (messageID string, err error) {
+	mailReq := &sendGridMailReq{
+		Personalizations: []*sendGridPersonalization{{To: []*sendGridAddress{{Email: msg.To}}}},
+		From:             &sendGridAddress{Email: msg.FromEmail, Name: msg.FromName},
+		Subject:          msg.Subject,
+		Content:          []*sendGridContent{{Type: "text/html", Value: msg.Body}},
+	}
+	if len(msg.ReplyTo) > 0 {
+		mailReq.ReplyTo = &sendGridAddress{Email: msg.ReplyTo}
+	}
+	body, _ := json.Marshal(mailReq)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	statusCode, header, respBody, err := doEmailProviderRequest(p.httpClient, httpReq)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != http.StatusOK && statusCode != http.StatusAccepted {
+		errResp := &sendGridErrorResp{}
+		if json.Unmarshal(respBody, errResp) == nil && len(errResp.Errors) > 0 {
+			return "", fmt.Errorf("status %d: %s", statusCode, errResp.Errors[0].Message)
+		}
+		return "", fmt.Errorf("status %d", statusCode)
+	}
+	return header.Get("X-Message-Id"), nil
+}
+
+// mailgunProvider send the email through the Mailgun messages API
+type mailgunProvider struct {
+	config     *EmailConfig
+	endpoint   string
+	httpClient *http.Client
+}
+
+type mailgunMessageResp struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+func (p *mailgunProvider) Name() string {
+	return EmailProviderMailgun
+}
+
+func (p *mailgunProvider) Send(ctx context.Context, msg *EmailMessage) (messageID string, err error) {
+	form := url.Values{}
+	form.Set("from", formatFromAddress(msg))
+	form.Set("to", msg.To)
+	form.Set("subject", msg.Subject)
+	form.Set("html", msg.Body)
+	if len(msg.ReplyTo) > 0 {
+		form.Set("h:Reply-To", msg.ReplyTo)
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/%s/messages", p.endpoint, url.PathEscape(p.config.MailgunDomain))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.SetBasicAuth("api", p.config.APIKey)
+	statusCode, _, respBody, err := doEmailProviderRequest(p.httpClient, httpReq)
+	if err != nil {
+		return "", err
+	}
+	resp := &mailgunMessageResp{}
+	_ = json.Unmarshal(respBody, resp)
+	if statusCode != http.StatusOK {
+		if len(resp.Message) > 0 {
+			return "", fmt.Errorf("status %d: %s", statusCode, resp.Message)
+		}
+		return "", fmt.Errorf("status %d", statusCode)
+	}
+	// the id is returned with the angle brackets, but the webhook events refer to it without them
+	return strings.Trim(resp.ID, "<>"), nil
+}
+
+func doEmailProviderRequest(httpClient *http.Client, req *http.Request) (
+	statusCode int, header http.Header, body []byte, err error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close()
+	body, err = io.ReadAll(io.LimitReader(resp.Body, emailProviderMaxRespSize))
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	return resp.StatusCode, resp.Header, body, nil
+}
+
+func formatFromAddress(msg *EmailMessage) string {
+	fromName := mime.QEncoding.Encode("utf-8", msg.FromName)
+	return fmt.Sprintf("%s <%s>", fromName, msg.FromEmail)
+}
+
+// generateMessageID generate the message id with the domain of the from address
+func generateMessageID(fromEmail string) string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	domain := "localhost"
+	if idx := strings.LastIndex(fromEmail, "@"); idx >= 0 && idx < len(fromEmail)-1 {
+		domain = fromEmail[idx+1:]
+	}
+	return fmt.Sprintf("%d.%s@%s", time.Now().UnixNano(), hex.EncodeToString(b), domain)
+}
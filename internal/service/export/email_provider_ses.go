@@ -0,0 +1,157 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// sesProvider send the email through the Amazon SES v2 API, the request is signed with the AWS signature version 4
+type sesProvider struct {
+	config     *EmailConfig
+	endpoint   string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesSendEmailReq struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	ReplyToAddresses []string `json:"ReplyToAddresses,omitempty"`
+	Content          struct {
+		Simple struct {
+			Subject *sesContent `json:"Subject"`
+			Body    struct {
+				Html *sesContent `json:"Html"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+type sesSendEmailResp struct {
+	MessageID string `json:"MessageId"`
+	Message   string `json:"message"`
+}
+
+func (p *sesProvider) Name() string {
+	return EmailProviderSES
+}
+
+func (p *sesProvider) Send(ctx context.Context, msg *EmailMessage) (messageID string, err error) {
+	sendReq := &sesSendEmailReq{FromEmailAddress: formatFromAddress(msg)}
+	sendReq.Destination.ToAddresses = []string{msg.To}
+	if len(msg.ReplyTo) > 0 {
+		sendReq.ReplyToAddresses = []string{msg.ReplyTo}
+	}
+	sendReq.Content.Simple.Subject = &sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	sendReq.Content.Simple.Body.Html = &sesContent{Data: msg.Body, Charset: "UTF-8"}
+	body, _ := json.Marshal(sendReq)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.endpoint+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	signAWSRequest(httpReq, body, p.config.SESRegion, "ses",
+		p.config.SESAccessKeyID, p.config.SESSecretAccessKey, p.now())
+	statusCode, _, respBody, err := doEmailProviderRequest(p.httpClient, httpReq)
+	if err != nil {
+		return "", err
+	}
+	resp := &sesSendEmailResp{}
+	_ = json.Unmarshal(respBody, resp)
+	if statusCode != http.StatusOK {
+		if len(resp.Message) > 0 {
+			return "", fmt.Errorf("status %d: %s", statusCode, resp.Message)
+		}
+		return "", fmt.Errorf("status %d", statusCode)
+	}
+	return resp.MessageID, nil
+}
+
+// signAWSRequest sign the request with the AWS signature version 4,
+// only the content-type, host and x-amz-date headers are signed
+func signAWSRequest(req *http.Request, body []byte, region, service, accessKeyID, secretAccessKey string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	path := req.URL.EscapedPath()
+	if len(path) == 0 {
+		path = "/"
+	}
+	signedHeaders := "content-type;host;x-amz-date"
+	canonicalHeaders := "content-type:" + strings.TrimSpace(req.Header.Get("Content-Type")) + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailConfig_IsConfigured(t *testing.T) {
+	assert.False(t, (&EmailConfig{}).IsConfigured())
+	assert.True(t, (&EmailConfig{SMTPHost: "smtp.example.com"}).IsConfigured())
+	assert.False(t, (&EmailConfig{Provider: EmailProviderMailgun, APIKey: "key"}).IsConfigured())
+	assert.True(t, (&EmailConfig{Provider: EmailProviderMailgun, APIKey: "key", MailgunDomain: "mg.example.com"}).IsConfigured())
+	assert.False(t, (&EmailConfig{Provider: "unknown", APIKey: "key"}).IsConfigured())
+}
+
+func TestSendGridProvider_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mail/send", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		req := &sendGridMailReq{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		assert.Equal(t, "to@example.com", req.Personalizations[0].To[0].Email)
+		assert.Equal(t, "text/html", req.Content[0].Type)
+		w.Header().Set("X-Message-Id", "sg-id")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	p := &sendGridProvider{config: &EmailConfig{APIKey: "key"}, endpoint: server.URL, httpClient: server.Client()}
+	messageID, err := p.Send(context.TODO(), &EmailMessage{FromEmail: "from@example.com", To: "to@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "sg-id", messageID)
+}
+
+func TestMailgunProvider_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v3/mg.example.com/messages", r.URL.Path)
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "api", user)
+		assert.Equal(t, "key", password)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "to@example.com", r.PostForm.Get("to"))
+		if r.PostForm.Get("subject") == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"invalid recipient"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"<mg-id@mg.example.com>","message":"Queued. Thank you."}`))
+	}))
+	defer server.Close()
+
+	p := &mailgunProvider{config: &EmailConfig{APIKey: "key", MailgunDomain: "mg.example.com"},
+		endpoint: server.URL, httpClient: server.Client()}
+	messageID, err := p.Send(context.TODO(), &EmailMessage{FromEmail: "from@example.com", To: "to@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "mg-id@mg.example.com", messageID)
+
+	_, err = p.Send(context.TODO(), &EmailMessage{FromEmail: "from@example.com", To: "to@example.com", Subject: "fail"})
+	assert.EqualError(t, err, "status 400: invalid recipient")
+}
+
+func TestSignAWSRequest(t *testing.T) {
+	// the example of the AWS signature version 4 documentation
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signAWSRequest(req, nil, "us-east-1", "iam", "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	assert.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+		"SignedHeaders=content-type;host;x-amz-date, "+
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7", req.Header.Get("Authorization"))
+}
+
+func TestSESProvider_Send(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/email/outbound-emails", r.URL.Path)
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=AKID/")
+		body, _ := io.ReadAll(r.Body)
+		req := &sesSendEmailReq{}
+		assert.NoError(t, json.Unmarshal(body, req))
+		assert.Equal(t, []string{"to@example.com"}, req.Destination.ToAddresses)
+		_, _ = w.Write([]byte(`{"MessageId":"ses-id"}`))
+	}))
+	defer server.Close()
+
+	p := &sesProvider{config: &EmailConfig{SESRegion: "us-east-1", SESAccessKeyID: "AKID", SESSecretAccessKey: "secret"},
+		endpoint: server.URL, httpClient: server.Client(), now: time.Now}
+	messageID, err := p.Send(context.TODO(), &EmailMessage{FromEmail: "from@example.com", To: "to@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "ses-id", messageID)
+}
@@ -0,0 +1,279 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/mail"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/net/context"
+)
+
+const (
+	// EmailReplyAsAnswer the reply is posted as the answer of the question
+	EmailReplyAsAnswer = "a"
+	// EmailReplyAsComment the reply is posted as the comment of the question or the answer
+	EmailReplyAsComment = "c"
+
+	replyAddressPrefix = "reply+"
+	// replySignatureSize the bytes of the signature in the token, the local part of the address is limited to 64 characters
+	replySignatureSize = 8
+)
+
+// EmailReplyToken the user and the object the reply is posted to
+type EmailReplyToken struct {
+	Kind     string
+	UserID   string
+	ObjectID string
+}
+
+// InboundEmail the email received by the inbound webhook
+type InboundEmail struct {
+	From       string
+	Recipients []string
+	Subject    string
+	Text       string
+}
+
+var (
+	quoteHeaderRegexp  = regexp.MustCompile(`^On\s.+wrote:\s*$`)
+	replyFooterRegexp  = regexp.MustCompile(`(?i)^sent from my\s`)
+	lineBreakTagRegexp = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</div>`)
+	outlookHeaderLines = []string{"-----Original Message-----", "________________________________"}
+)
+
+// ReplyToAddress get the address which posts the reply of the user to the object,
+// it is empty if the reply by email is not enabled
+func (es *EmailService) ReplyToAddress(ctx context.Context, kind, userID, objectID string) string {
+	if len(userID) == 0 || len(objectID) == 0 {
+		return ""
+	}
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	if !ec.ReplyByEmail || len(ec.ReplyDomain) == 0 || len(ec.WebhookKey) == 0 {
+		return ""
+	}
+	token := &EmailReplyToken{Kind: kind, UserID: userID, ObjectID: objectID}
+	return fmt.Sprintf("%s%s@%s", replyAddressPrefix, token.Encode(ec.WebhookKey), ec.ReplyDomain)
+}
+
+// GetInboundWebhookURL get the url which the provider forwards the replies to,
+// it is empty if the reply by email is not enabled or the provider can not receive the emails
+func (es *EmailService) GetInboundWebhookURL(ctx context.Context, ec *EmailConfig) string {
+	provider := ec.ProviderName()
+	if !ec.ReplyByEmail || len(ec.WebhookKey) == 0 ||
+		(provider != EmailProviderSendGrid && provider != EmailProviderMailgun) {
+		return ""
+	}
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return ""
+	}
+	return fmt.Sprintf("%s/answer/api/v1/email/inbound/%s?key=%s", siteInfo.SiteUrl, provider, ec.WebhookKey)
+}
+
+// ParseInboundWebhook check the webhook key and parse the email forwarded by the provider,
+// the token of the first reply address in the recipients is returned
+func (es *EmailService) ParseInboundWebhook(ctx context.Context, req *schema.EmailInboundWebhookReq) (
+	email *InboundEmail, token *EmailReplyToken, err error) {
+	ec, err := es.GetEmailConfig(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ec.WebhookKey) == 0 || subtle.ConstantTimeCompare([]byte(ec.WebhookKey), []byte(req.Key)) != 1 {
+		return nil, nil, errors.Forbidden(reason.EmailWebhookKeyInvalid)
+	}
+	if !ec.ReplyByEmail {
+		return nil, nil, nil
+	}
+	switch req.Provider {
+	case EmailProviderSendGrid:
+		email = parseSendGridInbound(req)
+	case EmailProviderMailgun:
+		email = parseMailgunInbound(req)
+	default:
+		return nil, nil, errors.BadRequest(reason.EmailProviderUnsupported)
+	}
+	for _, recipient := range email.Recipients {
+		if token = ParseReplyAddress(ec.WebhookKey, recipient); token != nil {
+			return email, token, nil
+		}
+	}
+	return email, nil, nil
+}
+
+// Encode encode the token with the signature of the key
+func (t *EmailReplyToken) Encode(key string) string {
+	payload := fmt.Sprintf("%s%s.%s", t.Kind, t.UserID, t.ObjectID)
+	return payload + "." + replySignature(key, payload)
+}
+
+// ParseReplyAddress get the token of the reply address, it is nil if the address is not a valid reply address
+func ParseReplyAddress(key, address string) *EmailReplyToken {
+	if addr, err := mail.ParseAddress(address); err == nil {
+		address = addr.Address
+	}
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return nil
+	}
+	localPart := strings.ToLower(address[:at])
+	if !strings.HasPrefix(localPart, replyAddressPrefix) {
+		return nil
+	}
+	parts := strings.Split(strings.TrimPrefix(localPart, replyAddressPrefix), ".")
+	if len(parts) != 3 || len(parts[0]) < 2 || len(parts[1]) == 0 {
+		return nil
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(replySignature(key, payload)), []byte(parts[2])) {
+		return nil
+	}
+	token := &EmailReplyToken{Kind: parts[0][:1], UserID: parts[0][1:], ObjectID: parts[1]}
+	if token.Kind != EmailReplyAsAnswer && token.Kind != EmailReplyAsComment {
+		return nil
+	}
+	return token
+}
+
+func replySignature(key, payload string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil)[:replySignatureSize])
+}
+
+// parseSendGridInbound parse the email posted by the SendGrid inbound parse webhook
+func parseSendGridInbound(req *schema.EmailInboundWebhookReq) *InboundEmail {
+	email := &InboundEmail{
+		From:    req.Form.Get("from"),
+		Subject: req.Form.Get("subject"),
+		Text:    req.Form.Get("text"),
+	}
+	envelope := &struct {
+		To []string `json:"to"`
+	}{}
+	if err := json.Unmarshal([]byte(req.Form.Get("envelope")), envelope); err == nil {
+		email.Recipients = append(email.Recipients, envelope.To...)
+	}
+	email.Recipients = append(email.Recipients, SplitAddresses(req.Form.Get("to"))...)
+	if len(strings.TrimSpace(email.Text)) == 0 {
+		email.Text = htmlToText(req.Form.Get("html"))
+	}
+	return email
+}
+
+// parseMailgunInbound parse the email posted by the Mailgun route, the quotes are already stripped by Mailgun
+func parseMailgunInbound(req *schema.EmailInboundWebhookReq) *InboundEmail {
+	email := &InboundEmail{
+		From:       req.Form.Get("from"),
+		Recipients: SplitAddresses(req.Form.Get("recipient")),
+		Subject:    req.Form.Get("subject"),
+		Text:       req.Form.Get("stripped-text"),
+	}
+	if len(email.From) == 0 {
+		email.From = req.Form.Get("sender")
+	}
+	email.Recipients = append(email.Recipients, SplitAddresses(req.Form.Get("To"))...)
+	if len(strings.TrimSpace(email.Text)) == 0 {
+		email.Text = req.Form.Get("body-plain")
+	}
+	if len(strings.TrimSpace(email.Text)) == 0 {
+		email.Text = htmlToText(req.Form.Get("body-html"))
+	}
+	return email
+}
+
+// SplitAddresses get the addresses of the list, like "Name <user@example.com>, other@example.com"
+func SplitAddresses(addresses string) (list []string) {
+	if len(strings.TrimSpace(addresses)) == 0 {
+		return nil
+	}
+	if parsed, err := mail.ParseAddressList(addresses); err == nil {
+		for _, addr := range parsed {
+			list = append(list, addr.Address)
+		}
+		return list
+	}
+	for _, addr := range strings.Split(addresses, ",") {
+		list = append(list, strings.TrimSpace(addr))
+	}
+	return list
+}
+
+func htmlToText(content string) string {
+	content = lineBreakTagRegexp.ReplaceAllString(content, "\n")
+	return html.UnescapeString(bluemonday.StrictPolicy().Sanitize(content))
+}
+
+// StripQuotedText remove the quoted original email, the signature and the footer of the mail app from the reply
+func StripQuotedText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	kept := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+		if isQuoteHeader(lines, i) || line == "--" {
+			break
+		}
+		if strings.HasPrefix(trimmed, ">") || replyFooterRegexp.MatchString(trimmed) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// isQuoteHeader whether the line starts the quoted original email, like "On ... wrote:" or the Outlook header
+func isQuoteHeader(lines []string, i int) bool {
+	line := strings.TrimSpace(lines[i])
+	for _, header := range outlookHeaderLines {
+		if strings.HasPrefix(line, header) {
+			return true
+		}
+	}
+	if quoteHeaderRegexp.MatchString(line) {
+		return true
+	}
+	// the header is usually wrapped to the next line by the mail app
+	if i+1 < len(lines) && strings.HasPrefix(line, "On ") &&
+		quoteHeaderRegexp.MatchString(line+" "+strings.TrimSpace(lines[i+1])) {
+		return true
+	}
+	return strings.HasPrefix(line, "From: ") && i+1 < len(lines) &&
+		(strings.HasPrefix(strings.TrimSpace(lines[i+1]), "Sent: ") ||
+			strings.HasPrefix(strings.TrimSpace(lines[i+1]), "Date: "))
+}
@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReplyAddress(t *testing.T) {
+	token := &EmailReplyToken{Kind: EmailReplyAsComment, UserID: "1", ObjectID: "10020000000000001"}
+	address := "reply+" + token.Encode("key") + "@reply.example.com"
+	assert.LessOrEqual(t, len(address)-len("@reply.example.com"), 64)
+
+	assert.Equal(t, token, ParseReplyAddress("key", address))
+	assert.Equal(t, token, ParseReplyAddress("key", "Answer <"+address+">"))
+	assert.Nil(t, ParseReplyAddress("other-key", address))
+	assert.Nil(t, ParseReplyAddress("key", "reply+c2.10020000000000001."+replySignature("key", "c1.10020000000000001")+"@reply.example.com"))
+	assert.Nil(t, ParseReplyAddress("key", "someone@example.com"))
+
+	invalidKind := &EmailReplyToken{Kind: "x", UserID: "1", ObjectID: "10010000000000001"}
+	assert.Nil(t, ParseReplyAddress("key", "reply+"+invalidKind.Encode("key")+"@reply.example.com"))
+}
+
+func TestStripQuotedText(t *testing.T) {
+	text := "Thanks, it works now.\r\n\r\nSent from my iPhone\r\n\r\nOn Mon, Jan 1, 2024 at 10:00 AM Answer <reply+x@reply.example.com>\r\nwrote:\r\n> New answer\r\n> to your question"
+	assert.Equal(t, "Thanks, it works now.", StripQuotedText(text))
+
+	assert.Equal(t, "Try the latest version.", StripQuotedText("Try the latest version.\n-- \nJane\nDeveloper"))
+	assert.Equal(t, "Agreed\nwith it", StripQuotedText("Agreed\n> quoted\nwith it\n\nFrom: Answer\nSent: Monday\nSubject: New comment"))
+	assert.Equal(t, "Yes", StripQuotedText("Yes\n-----Original Message-----\nold"))
+}
+
+func TestParseInbound(t *testing.T) {
+	email := parseSendGridInbound(&schema.EmailInboundWebhookReq{Form: url.Values{
+		"from":     {"Jane <jane@example.com>"},
+		"to":       {"Answer <reply+a1.2.abc@reply.example.com>"},
+		"envelope": {`{"to":["reply+a1.2.abc@reply.example.com"],"from":"jane@example.com"}`},
+		"html":     {"<p>Use <b>go</b> &amp; retry</p><script>alert(1)</script>"},
+	}})
+	assert.Equal(t, "Jane <jane@example.com>", email.From)
+	assert.Equal(t, []string{"reply+a1.2.abc@reply.example.com", "reply+a1.2.abc@reply.example.com"}, email.Recipients)
+	assert.Equal(t, "Use go & retry\n", email.Text)
+
+	email = parseMailgunInbound(&schema.EmailInboundWebhookReq{Form: url.Values{
+		"sender":        {"jane@example.com"},
+		"recipient":     {"reply+a1.2.abc@reply.example.com"},
+		"body-plain":    {"Reply\n> quoted"},
+		"stripped-text": {"Reply"},
+	}})
+	assert.Equal(t, "jane@example.com", email.From)
+	assert.Equal(t, []string{"reply+a1.2.abc@reply.example.com"}, email.Recipients)
+	assert.Equal(t, "Reply", email.Text)
+}
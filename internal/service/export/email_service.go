@@ -20,33 +20,31 @@
 package export
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"github.com/apache/incubator-answer/pkg/display"
-	"mime"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
-	"github.com/apache/incubator-answer/internal/base/handler"
 	"github.com/apache/incubator-answer/internal/base/reason"
-	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/segmentfault/pacman/errors"
 	"github.com/segmentfault/pacman/log"
 	"golang.org/x/net/context"
-	"gopkg.in/gomail.v2"
 )
 
 // EmailService kit service
 type EmailService struct {
-	configService   *config.ConfigService
-	emailRepo       EmailRepo
-	siteInfoService siteinfo_common.SiteInfoCommonService
+	configService     *config.ConfigService
+	emailRepo         EmailRepo
+	emailDeliveryRepo EmailDeliveryRepo
+	emailTemplateRepo EmailTemplateRepo
+	siteInfoService   siteinfo_common.SiteInfoCommonService
 }
 
 // EmailRepo email repository
@@ -59,12 +57,16 @@ type EmailRepo interface {
 func NewEmailService(
 	configService *config.ConfigService,
 	emailRepo EmailRepo,
+	emailDeliveryRepo EmailDeliveryRepo,
+	emailTemplateRepo EmailTemplateRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 ) *EmailService {
 	return &EmailService{
-		configService:   configService,
-		emailRepo:       emailRepo,
-		siteInfoService: siteInfoService,
+		configService:     configService,
+		emailRepo:         emailRepo,
+		emailDeliveryRepo: emailDeliveryRepo,
+		emailTemplateRepo: emailTemplateRepo,
+		siteInfoService:   siteInfoService,
 	}
 }
 
@@ -78,6 +80,21 @@ type EmailConfig struct {
 	SMTPUsername       string `json:"smtp_username"`
 	SMTPPassword       string `json:"smtp_password"`
 	SMTPAuthentication bool   `json:"smtp_authentication"`
+	// Provider smtp sendgrid mailgun ses, empty means smtp
+	Provider string `json:"provider"`
+	// APIKey the api key of SendGrid or Mailgun
+	APIKey             string `json:"api_key"`
+	MailgunDomain      string `json:"mailgun_domain"`
+	MailgunRegion      string `json:"mailgun_region"` // "" us eu
+	SESRegion          string `json:"ses_region"`
+	SESAccessKeyID     string `json:"ses_access_key_id"`
+	SESSecretAccessKey string `json:"ses_secret_access_key"`
+	// WebhookKey the key in the url of the delivery webhook, the events without it are rejected
+	WebhookKey string `json:"webhook_key"`
+	// ReplyByEmail the notification emails can be replied to post the answer or the comment
+	ReplyByEmail bool `json:"reply_by_email"`
+	// ReplyDomain the domain of the reply addresses, its emails are forwarded to the inbound webhook by the provider
+	ReplyDomain string `json:"reply_domain"`
 }
 
 func (e *EmailConfig) IsSSL() bool {
@@ -88,6 +105,30 @@ func (e *EmailConfig) IsTLS() bool {
 	return e.Encryption == "TLS"
 }
 
+// ProviderName the provider of the config, the old config without the provider uses the smtp
+func (e *EmailConfig) ProviderName() string {
+	if len(e.Provider) == 0 {
+		return EmailProviderSMTP
+	}
+	return e.Provider
+}
+
+// IsConfigured whether the provider has enough settings to send the email
+func (e *EmailConfig) IsConfigured() bool {
+	switch e.ProviderName() {
+	case EmailProviderSMTP:
+		return len(e.SMTPHost) > 0
+	case EmailProviderSendGrid:
+		return len(e.APIKey) > 0
+	case EmailProviderMailgun:
+		return len(e.APIKey) > 0 && len(e.MailgunDomain) > 0
+	case EmailProviderSES:
+		return len(e.SESRegion) > 0 && len(e.SESAccessKeyID) > 0 && len(e.SESSecretAccessKey) > 0
+	default:
+		return false
+	}
+}
+
 // SaveCode save code
 func (es *EmailService) SaveCode(ctx context.Context, userID, code, codeContent string) {
 	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, constant.UserEmailCodeCacheTime)
@@ -117,41 +158,71 @@ func (es *EmailService) SendAndSaveCodeWithTime(
 	es.Send(ctx, toEmailAddr, subject, body)
 }
 
+// SendAndSaveCodeWithReplyTo send email with the reply address and save code
+func (es *EmailService) SendAndSaveCodeWithReplyTo(ctx context.Context, userID, toEmailAddr, subject, body,
+	code, codeContent string, duration time.Duration, replyTo string) {
+	err := es.emailRepo.SetCode(ctx, userID, code, codeContent, duration)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	es.send(ctx, toEmailAddr, subject, body, replyTo)
+}
+
 // Send email send
 func (es *EmailService) Send(ctx context.Context, toEmailAddr, subject, body string) {
+	es.send(ctx, toEmailAddr, subject, body, "")
+}
+
+func (es *EmailService) send(ctx context.Context, toEmailAddr, subject, body, replyTo string) {
 	log.Infof("try to send email to %s", toEmailAddr)
 	ec, err := es.GetEmailConfig(ctx)
 	if err != nil {
 		log.Errorf("get email config failed: %s", err)
 		return
 	}
-	if len(ec.SMTPHost) == 0 {
-		log.Warnf("smtp host is empty, skip send email")
+	if !ec.IsConfigured() {
+		log.Warnf("email provider %s is not configured, skip send email", ec.ProviderName())
 		return
 	}
-
-	m := gomail.NewMessage()
-	fromName := mime.QEncoding.Encode("utf-8", ec.FromName)
-	m.SetHeader("From", fmt.Sprintf("%s <%s>", fromName, ec.FromEmail))
-	m.SetHeader("To", toEmailAddr)
-	m.SetHeader("Subject", subject)
-	m.SetBody("text/html", body)
-
-	d := gomail.NewDialer(ec.SMTPHost, ec.SMTPPort, ec.SMTPUsername, ec.SMTPPassword)
-	if ec.IsSSL() {
-		d.SSL = true
+	provider, err := NewEmailProvider(ec)
+	if err != nil {
+		log.Errorf("new email provider failed: %s", err)
+		return
 	}
-	if ec.IsTLS() {
-		d.SSL = false
+
+	sendLog := &entity.EmailSendLog{
+		Provider: provider.Name(),
+		ToEmail:  normalizeEmail(toEmailAddr),
+		Subject:  truncateString(subject, 255),
 	}
-	if len(os.Getenv("SKIP_SMTP_TLS_VERIFY")) > 0 {
-		d.TLSConfig = &tls.Config{ServerName: d.Host, InsecureSkipVerify: true}
+	suppressed, err := es.emailDeliveryRepo.IsEmailSuppressed(ctx, sendLog.ToEmail)
+	if err != nil {
+		log.Errorf("check email suppression failed: %s", err)
+	} else if suppressed {
+		log.Warnf("email %s is suppressed, skip send email", toEmailAddr)
+		sendLog.Status = entity.EmailSendStatusSuppressed
+		es.addEmailSendLog(ctx, sendLog)
+		return
 	}
-	if err := d.DialAndSend(m); err != nil {
+
+	sendLog.MessageID, err = provider.Send(ctx, &EmailMessage{
+		FromEmail: ec.FromEmail,
+		FromName:  ec.FromName,
+		To:        toEmailAddr,
+		Subject:   subject,
+		Body:      body,
+		ReplyTo:   replyTo,
+	})
+	if err != nil {
 		log.Errorf("send email to %s failed: %s", toEmailAddr, err)
+		sendLog.Status = entity.EmailSendStatusFailed
+		sendLog.Error = truncateString(err.Error(), 512)
 	} else {
 		log.Infof("send email to %s success", toEmailAddr)
+		sendLog.Status = entity.EmailSendStatusSent
 	}
+	es.addEmailSendLog(ctx, sendLog)
 }
 
 // VerifyUrlExpired email send
@@ -173,9 +244,7 @@ func (es *EmailService) RegisterTemplate(ctx context.Context, registerUrl string
 		RegisterUrl: registerUrl,
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyRegisterTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyRegisterBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplRegister, templateData)
 	return title, body, nil
 }
 
@@ -187,9 +256,7 @@ func (es *EmailService) PassResetTemplate(ctx context.Context, passResetUrl stri
 
 	templateData := &schema.PassResetTemplateData{SiteName: siteInfo.Name, PassResetUrl: passResetUrl}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyPassResetTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyPassResetBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplPassReset, templateData)
 	return title, body, nil
 }
 
@@ -203,9 +270,140 @@ func (es *EmailService) ChangeEmailTemplate(ctx context.Context, changeEmailUrl
 		ChangeEmailUrl: changeEmailUrl,
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyChangeEmailBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplChangeEmail, templateData)
+	return title, body, nil
+}
+
+// AuthorizeEmailChangeTemplate the email sent to the old email address to authorize the email change
+func (es *EmailService) AuthorizeEmailChangeTemplate(ctx context.Context, newEmail, authorizeUrl string) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.AuthorizeEmailChangeTemplateData{
+		SiteName:     siteInfo.Name,
+		NewEmail:     newEmail,
+		AuthorizeUrl: authorizeUrl,
+	}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplAuthorizeEmailChange, templateData)
+	return title, body, nil
+}
+
+// EmailChangedTemplate the email sent to the old email address after the email changed
+func (es *EmailService) EmailChangedTemplate(ctx context.Context, newEmail string) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.EmailChangedTemplateData{SiteName: siteInfo.Name, NewEmail: newEmail}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplEmailChanged, templateData)
+	return title, body, nil
+}
+
+// VerificationReminderTemplate remind the user who has not verified the email
+func (es *EmailService) VerificationReminderTemplate(ctx context.Context, registerUrl string) (
+	title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.VerificationReminderTemplateData{SiteName: siteInfo.Name, RegisterUrl: registerUrl}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplVerificationReminder, templateData)
+	return title, body, nil
+}
+
+// SuspiciousLoginTemplate warn the user about the failed sign-in attempts on the account
+func (es *EmailService) SuspiciousLoginTemplate(ctx context.Context, failedCount int, ip string, attemptAt time.Time,
+	signedIn bool) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.SuspiciousLoginTemplateData{
+		SiteName:         siteInfo.Name,
+		FailedCount:      failedCount,
+		IP:               ip,
+		Time:             es.formatTime(ctx, attemptAt),
+		SignedIn:         signedIn,
+		ResetPasswordUrl: fmt.Sprintf("%s/users/account-recovery", siteInfo.SiteUrl),
+	}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplSuspiciousLogin, templateData)
+	return title, body, nil
+}
+
+// QuestionReviewReminderTemplate ask the author whether any answer solved the question
+func (es *EmailService) QuestionReviewReminderTemplate(ctx context.Context, questionID, questionTitle string,
+	answers []*schema.QuestionReviewReminderAnswer) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.QuestionReviewReminderTemplateData{
+		SiteName:      siteInfo.Name,
+		QuestionTitle: questionTitle,
+		QuestionUrl:   display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, questionTitle),
+		Answers:       answers,
+	}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplQuestionReviewReminder, templateData)
+	return title, body, nil
+}
+
+// TagSubscriptionDigestTemplate the digest of the new questions in the subscribed tags, the urls of the
+// questions are filled by the ids and the titles
+func (es *EmailService) TagSubscriptionDigestTemplate(ctx context.Context,
+	questions []*schema.TagSubscriptionDigestQuestion) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	for _, question := range questions {
+		question.QuestionUrl = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl,
+			question.QuestionID, question.QuestionTitle)
+	}
+	templateData := &schema.TagSubscriptionDigestTemplateData{
+		SiteName:  siteInfo.Name,
+		SiteUrl:   siteInfo.SiteUrl,
+		Questions: questions,
+	}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplTagSubscriptionDigest, templateData)
+	return title, body, nil
+}
+
+// SLABreachedTemplate notify the staff that the question is not answered or resolved within the SLA
+func (es *EmailService) SLABreachedTemplate(ctx context.Context, questionID, questionTitle string,
+	firstResponse bool, hours int) (title, body string, err error) {
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return
+	}
+	seoInfo, err := es.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return
+	}
+	templateData := &schema.SLABreachedTemplateData{
+		SiteName:      siteInfo.Name,
+		QuestionTitle: questionTitle,
+		QuestionUrl:   display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, questionTitle),
+		FirstResponse: firstResponse,
+		Hours:         hours,
+	}
+
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplSLABreached, templateData)
 	return title, body, nil
 }
 
@@ -217,9 +415,7 @@ func (es *EmailService) TestTemplate(ctx context.Context) (title, body string, e
 	}
 	templateData := &schema.TestTemplateData{SiteName: siteInfo.Name}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyTestTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyTestBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplTest, templateData)
 	return title, body, nil
 }
 
@@ -243,9 +439,7 @@ func (es *EmailService) NewAnswerTemplate(ctx context.Context, raw *schema.NewAn
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewAnswerBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplNewAnswer, templateData)
 	return title, body, nil
 }
 
@@ -268,9 +462,7 @@ func (es *EmailService) NewInviteAnswerTemplate(ctx context.Context, raw *schema
 		UnsubscribeUrl: fmt.Sprintf("%s/users/unsubscribe?code=%s", siteInfo.SiteUrl, raw.UnsubscribeCode),
 	}
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyInvitedAnswerBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplInvitedAnswer, templateData)
 	return title, body, nil
 }
 
@@ -295,9 +487,7 @@ func (es *EmailService) NewCommentTemplate(ctx context.Context, raw *schema.NewC
 	templateData.CommentUrl = display.CommentURL(seoInfo.Permalink,
 		siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle, raw.AnswerID, raw.CommentID)
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewCommentTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewCommentBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplNewComment, templateData)
 	return title, body, nil
 }
 
@@ -321,9 +511,7 @@ func (es *EmailService) NewQuestionTemplate(ctx context.Context, raw *schema.New
 	templateData.QuestionUrl = display.QuestionURL(
 		seoInfo.Permalink, siteInfo.SiteUrl, raw.QuestionID, raw.QuestionTitle)
 
-	lang := handler.GetLangByCtx(ctx)
-	title = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionTitle, templateData)
-	body = translator.TrWithData(lang, constant.EmailTplKeyNewQuestionBody, templateData)
+	title, body = es.renderEmailTemplate(ctx, constant.EmailTplNewQuestion, templateData)
 	return title, body, nil
 }
 
@@ -338,9 +526,30 @@ func (es *EmailService) GetEmailConfig(ctx context.Context) (ec *EmailConfig, er
 		log.Errorf("old email config format is invalid, you need to update smtp config: %v", err)
 		return nil, errors.BadRequest(reason.SiteInfoConfigNotFound)
 	}
+	ec.applySMTPOverride(reload.Current().SMTP)
 	return ec, nil
 }
 
+// applySMTPOverride use the smtp server in the config file instead of the one in the admin settings
+func (e *EmailConfig) applySMTPOverride(smtp *reload.SMTP) {
+	if smtp == nil || len(smtp.Host) == 0 {
+		return
+	}
+	e.Provider = ""
+	e.SMTPHost = smtp.Host
+	e.SMTPPort = smtp.Port
+	e.Encryption = smtp.Encryption
+	e.SMTPUsername = smtp.Username
+	e.SMTPPassword = smtp.Password
+	e.SMTPAuthentication = len(smtp.Username) > 0
+	if len(smtp.FromEmail) > 0 {
+		e.FromEmail = smtp.FromEmail
+	}
+	if len(smtp.FromName) > 0 {
+		e.FromName = smtp.FromName
+	}
+}
+
 // SetEmailConfig set email config
 func (es *EmailService) SetEmailConfig(ctx context.Context, ec *EmailConfig) (err error) {
 	data, _ := json.Marshal(ec)
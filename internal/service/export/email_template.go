@@ -0,0 +1,411 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"bytes"
+	"reflect"
+	"text/template"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/segmentfault/pacman/log"
+	"github.com/tidwall/gjson"
+	"golang.org/x/net/context"
+)
+
+// EmailTemplateRepo email template repository
+type EmailTemplateRepo interface {
+	GetEmailTemplate(ctx context.Context, key, language string) (tpl *entity.EmailTemplate, exist bool, err error)
+	GetEmailTemplateList(ctx context.Context) (tpls []*entity.EmailTemplate, err error)
+	SaveEmailTemplate(ctx context.Context, tpl *entity.EmailTemplate) (err error)
+	RemoveEmailTemplate(ctx context.Context, key, language string) (err error)
+}
+
+// emailTemplateDefinition the email template which can be customized
+type emailTemplateDefinition struct {
+	Key      string
+	TitleKey string
+	BodyKey  string
+	// sample the template data with the sample values, it is used to preview and check the customized template
+	sample func(siteName, siteURL string) any
+}
+
+var emailTemplateDefinitions = []*emailTemplateDefinition{
+	{
+		Key: constant.EmailTplRegister, TitleKey: constant.EmailTplKeyRegisterTitle, BodyKey: constant.EmailTplKeyRegisterBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.RegisterTemplateData{SiteName: siteName, RegisterUrl: siteURL + "/users/account-activation?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplPassReset, TitleKey: constant.EmailTplKeyPassResetTitle, BodyKey: constant.EmailTplKeyPassResetBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.PassResetTemplateData{SiteName: siteName, PassResetUrl: siteURL + "/users/password-reset?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplChangeEmail, TitleKey: constant.EmailTplKeyChangeEmailTitle, BodyKey: constant.EmailTplKeyChangeEmailBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.ChangeEmailTemplateData{SiteName: siteName, ChangeEmailUrl: siteURL + "/users/confirm-new-email?code=sample"}
+		},
+	},
+	{
+		Key:      constant.EmailTplAuthorizeEmailChange,
+		TitleKey: constant.EmailTplKeyAuthorizeEmailChangeTitle, BodyKey: constant.EmailTplKeyAuthorizeEmailChangeBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.AuthorizeEmailChangeTemplateData{SiteName: siteName, NewEmail: "new@example.com",
+				AuthorizeUrl: siteURL + "/users/authorize-email-change?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplEmailChanged, TitleKey: constant.EmailTplKeyEmailChangedTitle, BodyKey: constant.EmailTplKeyEmailChangedBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.EmailChangedTemplateData{SiteName: siteName, NewEmail: "new@example.com"}
+		},
+	},
+	{
+		Key:      constant.EmailTplVerificationReminder,
+		TitleKey: constant.EmailTplKeyVerificationReminderTitle, BodyKey: constant.EmailTplKeyVerificationReminderBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.VerificationReminderTemplateData{SiteName: siteName,
+				RegisterUrl: siteURL + "/users/account-activation?code=sample"}
+		},
+	},
+	{
+		Key:      constant.EmailTplSuspiciousLogin,
+		TitleKey: constant.EmailTplKeySuspiciousLoginTitle, BodyKey: constant.EmailTplKeySuspiciousLoginBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.SuspiciousLoginTemplateData{SiteName: siteName, FailedCount: 5, IP: "203.0.113.1",
+				Time: "2024-01-02 15:04 UTC", ResetPasswordUrl: siteURL + "/users/account-recovery"}
+		},
+	},
+	{
+		Key:      constant.EmailTplQuestionReviewReminder,
+		TitleKey: constant.EmailTplKeyQuestionReviewReminderTitle, BodyKey: constant.EmailTplKeyQuestionReviewReminderBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.QuestionReviewReminderTemplateData{SiteName: siteName, QuestionTitle: "Sample question",
+				QuestionUrl: siteURL + "/questions/sample",
+				Answers: []*schema.QuestionReviewReminderAnswer{
+					{DisplayName: "Sample user", Excerpt: "Sample answer", AcceptUrl: siteURL + "/questions/sample?accept=sample"},
+				}}
+		},
+	},
+	{
+		Key: constant.EmailTplSLABreached, TitleKey: constant.EmailTplKeySLABreachedTitle, BodyKey: constant.EmailTplKeySLABreachedBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.SLABreachedTemplateData{SiteName: siteName, QuestionTitle: "Sample question",
+				QuestionUrl: siteURL + "/questions/sample", FirstResponse: true, Hours: 24}
+		},
+	},
+	{
+		Key: constant.EmailTplTest, TitleKey: constant.EmailTplKeyTestTitle, BodyKey: constant.EmailTplKeyTestBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.TestTemplateData{SiteName: siteName}
+		},
+	},
+	{
+		Key: constant.EmailTplNewAnswer, TitleKey: constant.EmailTplKeyNewAnswerTitle, BodyKey: constant.EmailTplKeyNewAnswerBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.NewAnswerTemplateData{SiteName: siteName, DisplayName: "Sample user",
+				QuestionTitle: "Sample question", AnswerUrl: siteURL + "/questions/sample/sample",
+				AnswerSummary: "Sample answer", UnsubscribeUrl: siteURL + "/users/unsubscribe?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplInvitedAnswer, TitleKey: constant.EmailTplKeyInvitedAnswerTitle, BodyKey: constant.EmailTplKeyInvitedAnswerBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.NewInviteAnswerTemplateData{SiteName: siteName, DisplayName: "Sample user",
+				QuestionTitle: "Sample question", InviteUrl: siteURL + "/questions/sample",
+				UnsubscribeUrl: siteURL + "/users/unsubscribe?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplNewComment, TitleKey: constant.EmailTplKeyNewCommentTitle, BodyKey: constant.EmailTplKeyNewCommentBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.NewCommentTemplateData{SiteName: siteName, DisplayName: "Sample user",
+				QuestionTitle: "Sample question", CommentUrl: siteURL + "/questions/sample?commentId=sample",
+				CommentSummary: "Sample comment", UnsubscribeUrl: siteURL + "/users/unsubscribe?code=sample"}
+		},
+	},
+	{
+		Key: constant.EmailTplNewQuestion, TitleKey: constant.EmailTplKeyNewQuestionTitle, BodyKey: constant.EmailTplKeyNewQuestionBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.NewQuestionTemplateData{SiteName: siteName, QuestionTitle: "Sample question",
+				QuestionUrl: siteURL + "/questions/sample", Tags: "sample",
+				UnsubscribeUrl: siteURL + "/users/unsubscribe?code=sample"}
+		},
+	},
+	{
+		Key:      constant.EmailTplTagSubscriptionDigest,
+		TitleKey: constant.EmailTplKeyTagSubscriptionDigestTitle, BodyKey: constant.EmailTplKeyTagSubscriptionDigestBody,
+		sample: func(siteName, siteURL string) any {
+			return &schema.TagSubscriptionDigestTemplateData{SiteName: siteName, SiteUrl: siteURL,
+				Questions: []*schema.TagSubscriptionDigestQuestion{
+					{QuestionTitle: "Sample question", QuestionUrl: siteURL + "/questions/sample", Tags: "sample"},
+				}}
+		},
+	},
+}
+
+func getEmailTemplateDefinition(key string) *emailTemplateDefinition {
+	for _, definition := range emailTemplateDefinitions {
+		if definition.Key == key {
+			return definition
+		}
+	}
+	return nil
+}
+
+// renderEmailTemplate render the email template of the language in the context,
+// the template customized by the admin is used if it exists
+func (es *EmailService) renderEmailTemplate(ctx context.Context, key string, templateData any) (title, body string) {
+	lang := handler.GetLangByCtx(ctx)
+	definition := getEmailTemplateDefinition(key)
+	tpl, exist, err := es.emailTemplateRepo.GetEmailTemplate(ctx, key, string(lang))
+	if err != nil {
+		log.Errorf("get email template %s failed: %s", key, err)
+	}
+	if err != nil || !exist {
+		title, body, _ = renderEmailTemplate(lang, definition, "", "", templateData)
+		return title, body
+	}
+	title, body, err = renderEmailTemplate(lang, definition, tpl.Subject, tpl.Body, templateData)
+	if err != nil {
+		// the customized template is checked when it is saved, it fails only if the template data is changed
+		log.Errorf("render customized email template %s %s failed: %s", key, lang, err)
+		title, body, _ = renderEmailTemplate(lang, definition, "", "", templateData)
+	}
+	return title, body
+}
+
+// formatTime format the time in the time zone of the recipient,
+// the time zone of the site is used if the recipient has not set one
+func (es *EmailService) formatTime(ctx context.Context, t time.Time) string {
+	if timeZone, _ := ctx.Value(constant.TimeZoneFlag).(string); len(timeZone) == 0 {
+		interfaceInfo, err := es.siteInfoService.GetSiteInterface(ctx)
+		if err != nil {
+			log.Error(err)
+		} else {
+			ctx = context.WithValue(ctx, constant.TimeZoneFlag, interfaceInfo.TimeZone)
+		}
+	}
+	return handler.FormatTimeByCtx(ctx, t)
+}
+
+// renderEmailTemplate render the subject and body template, the built-in template of the language is used if it is empty
+func renderEmailTemplate(lang i18n.Language, definition *emailTemplateDefinition, subjectTpl, bodyTpl string,
+	templateData any) (title, body string, err error) {
+	if len(subjectTpl) > 0 {
+		title, err = executeEmailTemplate(subjectTpl, templateData)
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		title = translator.TrWithData(lang, definition.TitleKey, templateData)
+	}
+	if len(bodyTpl) > 0 {
+		body, err = executeEmailTemplate(bodyTpl, templateData)
+		if err != nil {
+			return "", "", err
+		}
+	} else {
+		body = translator.TrWithData(lang, definition.BodyKey, templateData)
+	}
+	return title, body, nil
+}
+
+func executeEmailTemplate(text string, templateData any) (string, error) {
+	tpl, err := template.New("email").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err = tpl.Execute(buf, templateData); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// getBuiltInEmailTemplate get the built-in template of the language without rendering
+func getBuiltInEmailTemplate(lang i18n.Language, key string) string {
+	if translator.GlobalTrans == nil {
+		return ""
+	}
+	for _, l := range []i18n.Language{lang, i18n.DefaultLanguage} {
+		data, err := translator.GlobalTrans.Dump(l)
+		if err != nil {
+			continue
+		}
+		if text := gjson.GetBytes(data, key+".other").String(); len(text) > 0 {
+			return text
+		}
+	}
+	return ""
+}
+
+// emailTemplateVariables the field names of the template data, the fields of the list items are also included
+func emailTemplateVariables(templateData any) (variables []string) {
+	t := reflect.TypeOf(templateData)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		variables = append(variables, field.Name)
+		itemType := field.Type
+		if itemType.Kind() != reflect.Slice {
+			continue
+		}
+		itemType = itemType.Elem()
+		for itemType.Kind() == reflect.Ptr {
+			itemType = itemType.Elem()
+		}
+		if itemType.Kind() != reflect.Struct {
+			continue
+		}
+		for j := 0; j < itemType.NumField(); j++ {
+			variables = append(variables, field.Name+"."+itemType.Field(j).Name)
+		}
+	}
+	return variables
+}
+
+// GetEmailTemplateList get the email templates which can be customized
+func (es *EmailService) GetEmailTemplateList(ctx context.Context) (resp []*schema.GetEmailTemplateListResp, err error) {
+	tpls, err := es.emailTemplateRepo.GetEmailTemplateList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	customizedLanguages := make(map[string][]string)
+	for _, tpl := range tpls {
+		customizedLanguages[tpl.TemplateKey] = append(customizedLanguages[tpl.TemplateKey], tpl.Language)
+	}
+	resp = make([]*schema.GetEmailTemplateListResp, 0, len(emailTemplateDefinitions))
+	for _, definition := range emailTemplateDefinitions {
+		languages := customizedLanguages[definition.Key]
+		if languages == nil {
+			languages = make([]string, 0)
+		}
+		resp = append(resp, &schema.GetEmailTemplateListResp{
+			Key:                 definition.Key,
+			Variables:           emailTemplateVariables(definition.sample("", "")),
+			CustomizedLanguages: languages,
+		})
+	}
+	return resp, nil
+}
+
+// GetEmailTemplate get the customized and built-in email template of the language
+func (es *EmailService) GetEmailTemplate(ctx context.Context, req *schema.GetEmailTemplateReq) (
+	resp *schema.GetEmailTemplateResp, err error) {
+	definition, err := checkEmailTemplateKeyAndLanguage(req.Key, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	tpl, exist, err := es.emailTemplateRepo.GetEmailTemplate(ctx, req.Key, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	lang := i18n.Language(req.Language)
+	resp = &schema.GetEmailTemplateResp{
+		Key:            definition.Key,
+		Language:       req.Language,
+		DefaultSubject: getBuiltInEmailTemplate(lang, definition.TitleKey),
+		DefaultBody:    getBuiltInEmailTemplate(lang, definition.BodyKey),
+		Customized:     exist,
+		Variables:      emailTemplateVariables(definition.sample("", "")),
+	}
+	if exist {
+		resp.Subject = tpl.Subject
+		resp.Body = tpl.Body
+	}
+	return resp, nil
+}
+
+// SaveEmailTemplate customize the email template of the language, the template is checked with the sample data
+func (es *EmailService) SaveEmailTemplate(ctx context.Context, req *schema.SaveEmailTemplateReq) (err error) {
+	definition, err := checkEmailTemplateKeyAndLanguage(req.Key, req.Language)
+	if err != nil {
+		return err
+	}
+	if len(req.Subject) == 0 && len(req.Body) == 0 {
+		return es.emailTemplateRepo.RemoveEmailTemplate(ctx, req.Key, req.Language)
+	}
+	_, _, err = renderEmailTemplate(i18n.Language(req.Language), definition, req.Subject, req.Body,
+		definition.sample("", ""))
+	if err != nil {
+		return errors.BadRequest(reason.EmailTemplateInvalid).WithMsg(err.Error())
+	}
+	return es.emailTemplateRepo.SaveEmailTemplate(ctx, &entity.EmailTemplate{
+		TemplateKey: req.Key,
+		Language:    req.Language,
+		Subject:     req.Subject,
+		Body:        req.Body,
+	})
+}
+
+// RemoveEmailTemplate remove the customized email template, the built-in template is used again
+func (es *EmailService) RemoveEmailTemplate(ctx context.Context, req *schema.RemoveEmailTemplateReq) (err error) {
+	if _, err = checkEmailTemplateKeyAndLanguage(req.Key, req.Language); err != nil {
+		return err
+	}
+	return es.emailTemplateRepo.RemoveEmailTemplate(ctx, req.Key, req.Language)
+}
+
+// PreviewEmailTemplate render the email template with the sample data, the email is also sent if the recipient is set
+func (es *EmailService) PreviewEmailTemplate(ctx context.Context, req *schema.PreviewEmailTemplateReq) (
+	resp *schema.PreviewEmailTemplateResp, err error) {
+	definition, err := checkEmailTemplateKeyAndLanguage(req.Key, req.Language)
+	if err != nil {
+		return nil, err
+	}
+	siteInfo, err := es.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	title, body, err := renderEmailTemplate(i18n.Language(req.Language), definition, req.Subject, req.Body,
+		definition.sample(siteInfo.Name, siteInfo.SiteUrl))
+	if err != nil {
+		return nil, errors.BadRequest(reason.EmailTemplateInvalid).WithMsg(err.Error())
+	}
+	if len(req.TestEmailRecipient) > 0 {
+		go es.Send(ctx, req.TestEmailRecipient, title, body)
+	}
+	return &schema.PreviewEmailTemplateResp{Subject: title, Body: body}, nil
+}
+
+func checkEmailTemplateKeyAndLanguage(key, language string) (definition *emailTemplateDefinition, err error) {
+	definition = getEmailTemplateDefinition(key)
+	if definition == nil {
+		return nil, errors.BadRequest(reason.EmailTemplateNotFound)
+	}
+	if language == translator.DefaultLangOption || !translator.CheckLanguageIsValid(language) {
+		return nil, errors.BadRequest(reason.LangNotFound)
+	}
+	return definition, nil
+}
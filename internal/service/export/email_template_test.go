@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package export
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEmailTemplate(t *testing.T) {
+	definition := getEmailTemplateDefinition(constant.EmailTplRegister)
+	data := definition.sample("Answer", "https://example.com")
+
+	title, body, err := renderEmailTemplate(i18n.DefaultLanguage, definition,
+		"Welcome to {{.SiteName}}", "<a href='{{.RegisterUrl}}'>confirm</a>", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Welcome to Answer", title)
+	assert.Equal(t, "<a href='https://example.com/users/account-activation?code=sample'>confirm</a>", body)
+
+	// the empty subject uses the built-in template
+	title, _, err = renderEmailTemplate(i18n.DefaultLanguage, definition, "", "{{.SiteName}}", data)
+	assert.NoError(t, err)
+	assert.Equal(t, constant.EmailTplKeyRegisterTitle, title)
+
+	_, _, err = renderEmailTemplate(i18n.DefaultLanguage, definition, "{{.NotExist}}", "", data)
+	assert.Error(t, err)
+	_, _, err = renderEmailTemplate(i18n.DefaultLanguage, definition, "", "{{.SiteName", data)
+	assert.Error(t, err)
+}
+
+func TestEmailTemplateVariables(t *testing.T) {
+	assert.Equal(t, []string{"SiteName", "RegisterUrl"}, emailTemplateVariables(&schema.RegisterTemplateData{}))
+	assert.Equal(t, []string{"SiteName", "QuestionTitle", "QuestionUrl", "Answers",
+		"Answers.DisplayName", "Answers.Excerpt", "Answers.AcceptUrl"},
+		emailTemplateVariables(&schema.QuestionReviewReminderTemplateData{}))
+}
+
+func TestEmailTemplateDefinitions(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, definition := range emailTemplateDefinitions {
+		assert.False(t, keys[definition.Key], definition.Key)
+		keys[definition.Key] = true
+		assert.Equal(t, "email_tpl."+definition.Key+".title", definition.TitleKey)
+		assert.Equal(t, "email_tpl."+definition.Key+".body", definition.BodyKey)
+		assert.NotNil(t, definition.sample("Answer", "https://example.com"))
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package feature_flag
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// FeatureFlagService turn the features on or off at runtime, for the whole site or for some roles.
+// The flags are stored in the site info, so they are cached and take effect without restarting.
+type FeatureFlagService struct {
+	siteInfoRepo       siteinfo_common.SiteInfoRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewFeatureFlagService new feature flag service
+func NewFeatureFlagService(
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRoleRelService *role.UserRoleRelService,
+) *FeatureFlagService {
+	return &FeatureFlagService{
+		siteInfoRepo:       siteInfoRepo,
+		siteInfoService:    siteInfoService,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// GetFeatureFlags get the flags of all the features, the feature that is not configured is enabled
+func (fs *FeatureFlagService) GetFeatureFlags(ctx context.Context) (resp *schema.SiteFeatureFlagsResp, err error) {
+	stored := &schema.SiteFeatureFlagsResp{}
+	if err = fs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeFeatureFlags, stored); err != nil {
+		return nil, err
+	}
+	flags := make(map[string]*schema.FeatureFlag, len(stored.Flags))
+	for _, flag := range stored.Flags {
+		flags[flag.Key] = flag
+	}
+	resp = &schema.SiteFeatureFlagsResp{Flags: make([]*schema.FeatureFlag, 0, len(schema.Features))}
+	for _, key := range schema.Features {
+		flag, ok := flags[key]
+		if !ok {
+			flag = &schema.FeatureFlag{Key: key, Enabled: true}
+		}
+		if flag.RoleIDs == nil {
+			flag.RoleIDs = make([]int, 0)
+		}
+		resp.Flags = append(resp.Flags, flag)
+	}
+	return resp, nil
+}
+
+// UpdateFeatureFlags update the feature flags
+func (fs *FeatureFlagService) UpdateFeatureFlags(ctx context.Context, req *schema.SiteFeatureFlagsReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeFeatureFlags,
+		Content: string(content),
+	}
+	return fs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeFeatureFlags, data)
+}
+
+// IsFeatureAvailable whether the feature is available to the user
+func (fs *FeatureFlagService) IsFeatureAvailable(ctx context.Context, feature, userID string) bool {
+	flags, err := fs.GetFeatureFlags(ctx)
+	if err != nil {
+		log.Error(err)
+		return true
+	}
+	for _, flag := range flags.Flags {
+		if flag.Key != feature {
+			continue
+		}
+		if !flag.Enabled || len(flag.RoleIDs) == 0 {
+			return flag.Enabled
+		}
+		roleID, err := fs.userRoleRelService.GetUserRole(ctx, userID)
+		if err != nil {
+			log.Error(err)
+			return false
+		}
+		return flag.IsAvailable(roleID)
+	}
+	return true
+}
+
+// CheckFeatureAvailable return the forbidden error if the feature is not available to the user
+func (fs *FeatureFlagService) CheckFeatureAvailable(ctx context.Context, feature, userID string) (err error) {
+	if !fs.IsFeatureAvailable(ctx, feature, userID) {
+		return errors.Forbidden(reason.FeatureDisabled)
+	}
+	return nil
+}
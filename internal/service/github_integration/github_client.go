@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package github_integration
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultAPIURL = "https://api.github.com"
+	// appJWTLifetime the lifetime of the jwt of the app, GitHub accepts 10 minutes at most
+	appJWTLifetime = 9 * time.Minute
+)
+
+// githubClient the client of the GitHub rest api
+type githubClient struct {
+	apiURL     string
+	httpClient *http.Client
+}
+
+// githubIssue the issue or the pull request returned by the issues api
+type githubIssue struct {
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	HTMLURL     string `json:"html_url"`
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+}
+
+// parsePrivateKey parse the pem private key of the app, GitHub gives the PKCS#1 key
+func parsePrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(privateKey)))
+	if block == nil {
+		return nil, fmt.Errorf("private key is not pem encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not a rsa key")
+	}
+	return rsaKey, nil
+}
+
+// newAppJWT create the jwt signed by the private key of the app, it is exchanged for the installation token
+func newAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	// the issued time is set in the past to allow the clock drift
+	claims, _ := json.Marshal(map[string]int64{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": appID,
+	})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// createInstallationToken exchange the jwt of the app for the token of the installation
+func (c *githubClient) createInstallationToken(ctx context.Context, appJWT string, installationID int64) (
+	token string, expiresAt time.Time, err error) {
+	resp := &struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{}
+	path := fmt.Sprintf("/app/installations/%d/access_tokens", installationID)
+	if err = c.do(ctx, http.MethodPost, path, "Bearer "+appJWT, nil, resp); err != nil {
+		return "", time.Time{}, err
+	}
+	return resp.Token, resp.ExpiresAt, nil
+}
+
+// getIssue get the issue or the pull request, the token is optional for the public repositories
+func (c *githubClient) getIssue(ctx context.Context, token string, ref *issueReference) (issue *githubIssue, err error) {
+	issue = &githubIssue{}
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", ref.Owner, ref.Repo, ref.Number)
+	if err = c.do(ctx, http.MethodGet, path, tokenAuthorization(token), nil, issue); err != nil {
+		return nil, err
+	}
+	return issue, nil
+}
+
+// createComment comment on the issue or the pull request, the url of the comment is returned
+func (c *githubClient) createComment(ctx context.Context, token string, ref *issueReference, body string) (
+	commentURL string, err error) {
+	resp := &struct {
+		HTMLURL string `json:"html_url"`
+	}{}
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments", ref.Owner, ref.Repo, ref.Number)
+	err = c.do(ctx, http.MethodPost, path, tokenAuthorization(token), map[string]string{"body": body}, resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.HTMLURL, nil
+}
+
+func (c *githubClient) do(ctx context.Context, method, path, authorization string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	req.Header.Set("User-Agent", "Answer")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if len(authorization) > 0 {
+		req.Header.Set("Authorization", authorization)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("github api %s %s returned %d: %s", method, path, resp.StatusCode, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+func tokenAuthorization(token string) string {
+	if len(token) == 0 {
+		return ""
+	}
+	return "token " + token
+}
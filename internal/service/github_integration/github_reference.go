@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package github_integration
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxReferencesPerPost the issues linked in the post beyond it are ignored
+const maxReferencesPerPost = 10
+
+// issueReference the issue or the pull request linked in the post
+type issueReference struct {
+	Owner         string
+	Repo          string
+	Number        int
+	IsPullRequest bool
+	URL           string
+}
+
+// Key the unique key of the issue, like apache/incubator-answer#123
+func (r *issueReference) Key() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// webHost get the host of the GitHub web site of the api, the GitHub Enterprise Server
+// serves the api under /api/v3 of the web site
+func webHost(apiURL string) string {
+	if len(apiURL) == 0 || apiURL == defaultAPIURL {
+		return "github.com"
+	}
+	u, err := url.Parse(apiURL)
+	if err != nil || len(u.Host) == 0 {
+		return "github.com"
+	}
+	return u.Host
+}
+
+// findReferences find the distinct issue and pull request links in the content
+func findReferences(host, content string) []*issueReference {
+	linkRegexp := regexp.MustCompile(`https?://` + regexp.QuoteMeta(host) +
+		`/([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)/(issues|pull)/([0-9]+)`)
+	refs := make([]*issueReference, 0)
+	seen := make(map[string]bool)
+	for _, match := range linkRegexp.FindAllStringSubmatch(content, -1) {
+		number, err := strconv.Atoi(match[4])
+		if err != nil || number == 0 {
+			continue
+		}
+		ref := &issueReference{
+			Owner:         match[1],
+			Repo:          strings.TrimSuffix(match[2], ".git"),
+			Number:        number,
+			IsPullRequest: match[3] == "pull",
+		}
+		ref.URL = fmt.Sprintf("https://%s/%s/%s/%s/%d", host, ref.Owner, ref.Repo, match[3], ref.Number)
+		if seen[ref.Key()] {
+			continue
+		}
+		seen[ref.Key()] = true
+		refs = append(refs, ref)
+		if len(refs) == maxReferencesPerPost {
+			break
+		}
+	}
+	return refs
+}
@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package github_integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// GitHubCrossReferenceRepo GitHub cross reference repository
+type GitHubCrossReferenceRepo interface {
+	AddCrossReference(ctx context.Context, reference *entity.GitHubCrossReference) (err error)
+	ExistCrossReference(ctx context.Context, questionID, issueKey string) (exist bool, err error)
+}
+
+// installationToken the token of the app installation, it is reused until it is about to expire
+type installationToken struct {
+	appID          int64
+	installationID int64
+	token          string
+	expiresAt      time.Time
+}
+
+// GitHubService unfurl the GitHub issues and pull requests linked in the posts, and post the cross-reference
+// comments on them when the question linking them gets an accepted answer
+type GitHubService struct {
+	crossReferenceRepo GitHubCrossReferenceRepo
+	objService         *object_info.ObjService
+	siteInfoRepo       siteinfo_common.SiteInfoRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+	data               *data.Data
+	httpClient         *http.Client
+	tokenLock          sync.Mutex
+	token              *installationToken
+}
+
+// NewGitHubService new GitHub integration service
+func NewGitHubService(
+	crossReferenceRepo GitHubCrossReferenceRepo,
+	objService *object_info.ObjService,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	data *data.Data,
+	eventQueueService event_queue.EventQueueService,
+) *GitHubService {
+	gs := &GitHubService{
+		crossReferenceRepo: crossReferenceRepo,
+		objService:         objService,
+		siteInfoRepo:       siteInfoRepo,
+		siteInfoService:    siteInfoService,
+		data:               data,
+		httpClient:         &http.Client{Timeout: 10 * time.Second},
+	}
+	eventQueueService.RegisterHandler(gs.HandleEvent)
+	return gs
+}
+
+// GetGitHub get the GitHub integration settings
+func (gs *GitHubService) GetGitHub(ctx context.Context) (resp *schema.SiteGitHubResp, err error) {
+	resp = &schema.SiteGitHubResp{}
+	if err = gs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeGitHub, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.APIURL) == 0 {
+		resp.APIURL = defaultAPIURL
+	}
+	return resp, nil
+}
+
+// UpdateGitHub update the GitHub integration settings, the app is required to post the cross-reference comments
+func (gs *GitHubService) UpdateGitHub(ctx context.Context, req *schema.SiteGitHubReq) (err error) {
+	req.APIURL = strings.TrimSuffix(req.APIURL, "/")
+	if req.CrossReferenceEnabled && (req.AppID == 0 || req.InstallationID == 0 || len(req.PrivateKey) == 0) {
+		return errors.BadRequest(reason.GitHubConfigInvalid)
+	}
+	if len(req.PrivateKey) > 0 {
+		if _, err = parsePrivateKey(req.PrivateKey); err != nil {
+			return errors.BadRequest(reason.GitHubConfigInvalid).WithError(err)
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeGitHub,
+		Content: string(content),
+	}
+	if err = gs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeGitHub, data); err != nil {
+		return err
+	}
+	gs.tokenLock.Lock()
+	gs.token = nil
+	gs.tokenLock.Unlock()
+	return nil
+}
+
+// GetReferences get the issues and pull requests linked in the post with their live status,
+// it is empty if the unfurling is disabled
+func (gs *GitHubService) GetReferences(ctx context.Context, req *schema.GetGitHubReferencesReq) (
+	resp []*schema.GitHubReferenceResp, err error) {
+	resp = make([]*schema.GitHubReferenceResp, 0)
+	conf, err := gs.GetGitHub(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled || !conf.UnfurlEnabled {
+		return resp, nil
+	}
+	objInfo, err := gs.objService.GetInfo(ctx, uid.DeShortID(req.ObjectID))
+	if err != nil {
+		return nil, err
+	}
+	if objInfo.IsDeleted() {
+		return resp, nil
+	}
+	for _, ref := range findReferences(webHost(conf.APIURL), objInfo.Content) {
+		reference, err := gs.getReference(ctx, conf, ref)
+		if err != nil {
+			log.Warnf("get github reference %s failed: %v", ref.Key(), err)
+			continue
+		}
+		resp = append(resp, reference)
+	}
+	return resp, nil
+}
+
+// getReference get the status of the issue, it is cached for a while to stay in the rate limit
+func (gs *GitHubService) getReference(ctx context.Context, conf *schema.SiteGitHubResp, ref *issueReference) (
+	reference *schema.GitHubReferenceResp, err error) {
+	cacheKey := constant.GitHubIssueCacheKeyPrefix + webHost(conf.APIURL) + "/" + ref.Key()
+	cacheData, exist, err := gs.data.Cache.GetString(ctx, cacheKey)
+	if err == nil && exist {
+		reference = &schema.GitHubReferenceResp{}
+		if json.Unmarshal([]byte(cacheData), reference) == nil {
+			return reference, nil
+		}
+	}
+
+	token, err := gs.getInstallationToken(ctx, conf)
+	if err != nil {
+		log.Warnf("get github installation token failed, get the issue without it: %v", err)
+	}
+	issue, err := gs.newClient(conf).getIssue(ctx, token, ref)
+	if err != nil {
+		return nil, err
+	}
+	reference = &schema.GitHubReferenceResp{
+		URL:           ref.URL,
+		Owner:         ref.Owner,
+		Repo:          ref.Repo,
+		Number:        ref.Number,
+		IsPullRequest: issue.PullRequest != nil,
+		Title:         issue.Title,
+		State:         issue.State,
+	}
+	if issue.PullRequest != nil && issue.PullRequest.MergedAt != nil {
+		reference.State = schema.GitHubIssueStateMerged
+	}
+	if len(issue.HTMLURL) > 0 {
+		reference.URL = issue.HTMLURL
+	}
+	content, _ := json.Marshal(reference)
+	if err = gs.data.Cache.SetString(ctx, cacheKey, string(content), constant.GitHubIssueCacheTime); err != nil {
+		log.Error(err)
+	}
+	return reference, nil
+}
+
+// HandleEvent post the cross-reference comments on the issues linked in the question when it gets an accepted answer
+func (gs *GitHubService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	if msg.EventType != constant.EventAnswerAccept {
+		return nil
+	}
+	conf, err := gs.GetGitHub(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled || !conf.CrossReferenceEnabled {
+		return nil
+	}
+	questionID := uid.DeShortID(msg.QuestionID)
+	objInfo, err := gs.objService.GetInfo(ctx, questionID)
+	if err != nil {
+		return err
+	}
+	refs := findReferences(webHost(conf.APIURL), objInfo.Content)
+	if len(refs) == 0 {
+		return nil
+	}
+	token, err := gs.getInstallationToken(ctx, conf)
+	if err != nil {
+		return err
+	}
+	body, err := gs.crossReferenceBody(ctx, objInfo.Title, questionID, uid.DeShortID(msg.ObjectID))
+	if err != nil {
+		return err
+	}
+
+	client := gs.newClient(conf)
+	for _, ref := range refs {
+		exist, err := gs.crossReferenceRepo.ExistCrossReference(ctx, questionID, ref.Key())
+		if err != nil {
+			return err
+		}
+		if exist {
+			continue
+		}
+		commentURL, err := client.createComment(ctx, token, ref, body)
+		if err != nil {
+			log.Warnf("post cross-reference comment on %s failed: %v", ref.Key(), err)
+			continue
+		}
+		err = gs.crossReferenceRepo.AddCrossReference(ctx, &entity.GitHubCrossReference{
+			QuestionID: questionID,
+			IssueKey:   ref.Key(),
+			CommentURL: commentURL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gs *GitHubService) crossReferenceBody(ctx context.Context, title, questionID, answerID string) (
+	body string, err error) {
+	siteInfo, err := gs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	seoInfo, err := gs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return "", err
+	}
+	questionURL := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, title)
+	answerURL := display.AnswerURL(seoInfo.Permalink, siteInfo.SiteUrl, questionID, title, answerID)
+	return fmt.Sprintf("This is referenced by the question [%s](%s) on [%s](%s), which has an [accepted answer](%s).",
+		title, questionURL, siteInfo.Name, siteInfo.SiteUrl, answerURL), nil
+}
+
+// getInstallationToken get the token of the app installation, it is empty if the app is not set
+func (gs *GitHubService) getInstallationToken(ctx context.Context, conf *schema.SiteGitHubResp) (
+	token string, err error) {
+	if conf.AppID == 0 || conf.InstallationID == 0 || len(conf.PrivateKey) == 0 {
+		return "", nil
+	}
+	gs.tokenLock.Lock()
+	defer gs.tokenLock.Unlock()
+	if t := gs.token; t != nil && t.appID == conf.AppID && t.installationID == conf.InstallationID &&
+		time.Until(t.expiresAt) > time.Minute {
+		return t.token, nil
+	}
+
+	key, err := parsePrivateKey(conf.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	appJWT, err := newAppJWT(conf.AppID, key, time.Now())
+	if err != nil {
+		return "", err
+	}
+	token, expiresAt, err := gs.newClient(conf).createInstallationToken(ctx, appJWT, conf.InstallationID)
+	if err != nil {
+		return "", err
+	}
+	gs.token = &installationToken{
+		appID:          conf.AppID,
+		installationID: conf.InstallationID,
+		token:          token,
+		expiresAt:      expiresAt,
+	}
+	return token, nil
+}
+
+func (gs *GitHubService) newClient(conf *schema.SiteGitHubResp) *githubClient {
+	return &githubClient{apiURL: conf.APIURL, httpClient: gs.httpClient}
+}
@@ -0,0 +1,119 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package github_integration
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindReferences(t *testing.T) {
+	content := `<p>See <a href="https://github.com/apache/incubator-answer/issues/12">#12</a>,
+https://github.com/apache/incubator-answer/pull/34 and again https://github.com/apache/incubator-answer/issues/12.
+Not https://github.com/apache/incubator-answer/wiki/12 or https://gitlab.com/a/b/issues/1</p>`
+	refs := findReferences("github.com", content)
+	require.Len(t, refs, 2)
+	assert.Equal(t, "apache/incubator-answer#12", refs[0].Key())
+	assert.False(t, refs[0].IsPullRequest)
+	assert.Equal(t, "https://github.com/apache/incubator-answer/pull/34", refs[1].URL)
+	assert.True(t, refs[1].IsPullRequest)
+
+	assert.Equal(t, "github.com", webHost(""))
+	assert.Equal(t, "github.example.com", webHost("https://github.example.com/api/v3"))
+}
+
+func TestNewAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	parsed, err := parsePrivateKey(string(keyPEM))
+	require.NoError(t, err)
+
+	now := time.Now()
+	token, err := newAppJWT(123, parsed, now)
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	claimsJSON, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := map[string]int64{}
+	require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+	assert.Equal(t, int64(123), claims["iss"])
+	assert.Greater(t, claims["exp"], now.Unix())
+
+	_, err = parsePrivateKey("not a key")
+	assert.Error(t, err)
+}
+
+func TestGitHubClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/a/b/issues/1":
+			assert.Equal(t, "token t", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"title":"Fix","state":"closed","html_url":"https://github.com/a/b/pull/1",
+"pull_request":{"merged_at":"2024-01-01T00:00:00Z"}}`))
+		case "POST /repos/a/b/issues/1/comments":
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "hello", body["body"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"html_url":"https://github.com/a/b/pull/1#issuecomment-9"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &githubClient{apiURL: server.URL, httpClient: server.Client()}
+	ref := &issueReference{Owner: "a", Repo: "b", Number: 1}
+	issue, err := client.getIssue(context.TODO(), "t", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "Fix", issue.Title)
+	require.NotNil(t, issue.PullRequest)
+	assert.NotNil(t, issue.PullRequest.MergedAt)
+
+	commentURL, err := client.createComment(context.TODO(), "t", ref, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/a/b/pull/1#issuecomment-9", commentURL)
+
+	_, err = client.getIssue(context.TODO(), "", &issueReference{Owner: "a", Repo: "b", Number: 2})
+	assert.Error(t, err)
+}
@@ -0,0 +1,401 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_question
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/mail"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	defaultMailboxName = "ask"
+	minTitleLength     = 6
+	maxTitleLength     = 150
+	minContentLength   = 6
+	defaultPageSize    = 20
+)
+
+// InboundQuestionRepo inbound question repository
+type InboundQuestionRepo interface {
+	AddInboundQuestion(ctx context.Context, question *entity.InboundQuestion) (err error)
+	GetInboundQuestion(ctx context.Context, id int64) (question *entity.InboundQuestion, exist bool, err error)
+	GetInboundQuestionPage(ctx context.Context, page, pageSize, status int) (
+		questions []*entity.InboundQuestion, total int64, err error)
+	UpdateInboundQuestionReviewed(ctx context.Context, question *entity.InboundQuestion) (err error)
+}
+
+// InboundQuestionService queue the questions sent by the external systems or by email,
+// they are published after a moderator approves them
+type InboundQuestionService struct {
+	inboundQuestionRepo InboundQuestionRepo
+	userRepo            usercommon.UserRepo
+	userCommon          *usercommon.UserCommon
+	questionService     *content.QuestionService
+	emailService        *export.EmailService
+	siteInfoRepo        siteinfo_common.SiteInfoRepo
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	eventQueueService   event_queue.EventQueueService
+	reviewLock          sync.Mutex
+}
+
+// NewInboundQuestionService new inbound question service
+func NewInboundQuestionService(
+	inboundQuestionRepo InboundQuestionRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	questionService *content.QuestionService,
+	emailService *export.EmailService,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
+) *InboundQuestionService {
+	return &InboundQuestionService{
+		inboundQuestionRepo: inboundQuestionRepo,
+		userRepo:            userRepo,
+		userCommon:          userCommon,
+		questionService:     questionService,
+		emailService:        emailService,
+		siteInfoRepo:        siteInfoRepo,
+		siteInfoService:     siteInfoService,
+		eventQueueService:   eventQueueService,
+	}
+}
+
+// GetInboundQuestionSetting get the inbound question settings
+func (qs *InboundQuestionService) GetInboundQuestionSetting(ctx context.Context) (
+	resp *schema.SiteInboundQuestionResp, err error) {
+	resp = &schema.SiteInboundQuestionResp{}
+	if err = qs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeInboundQuestion, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.MailboxName) == 0 {
+		resp.MailboxName = defaultMailboxName
+	}
+	if resp.DefaultTags == nil {
+		resp.DefaultTags = make([]string, 0)
+	}
+	return resp, nil
+}
+
+// UpdateInboundQuestionSetting update the inbound question settings, the api key is generated if it is empty
+func (qs *InboundQuestionService) UpdateInboundQuestionSetting(ctx context.Context,
+	req *schema.SiteInboundQuestionReq) (err error) {
+	if req.Enabled && len(req.APIKey) == 0 {
+		req.APIKey = export.GenerateWebhookKey()
+	}
+	req.MailboxName = strings.ToLower(strings.TrimSpace(req.MailboxName))
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeInboundQuestion,
+		Content: string(content),
+	}
+	return qs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeInboundQuestion, data)
+}
+
+// AddInboundQuestion queue the question sent by the external system
+func (qs *InboundQuestionService) AddInboundQuestion(ctx context.Context, req *schema.AddInboundQuestionReq) (
+	resp *schema.AddInboundQuestionResp, err error) {
+	conf, err := qs.GetInboundQuestionSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled {
+		return nil, errors.Forbidden(reason.InboundQuestionDisabled)
+	}
+	if len(conf.APIKey) == 0 || subtle.ConstantTimeCompare([]byte(conf.APIKey), []byte(req.APIKey)) != 1 {
+		return nil, errors.Forbidden(reason.InboundQuestionKeyInvalid)
+	}
+	tags := req.Tags
+	if len(tags) == 0 {
+		tags = conf.DefaultTags
+	}
+	question := &entity.InboundQuestion{
+		Channel:     entity.InboundQuestionChannelAPI,
+		SenderEmail: strings.TrimSpace(req.SenderEmail),
+		SenderName:  strings.TrimSpace(req.SenderName),
+		Title:       strings.TrimSpace(req.Title),
+		Content:     req.Content,
+		Tags:        strings.Join(tags, ","),
+		Status:      entity.InboundQuestionStatusPending,
+	}
+	if err = qs.inboundQuestionRepo.AddInboundQuestion(ctx, question); err != nil {
+		return nil, err
+	}
+	return &schema.AddInboundQuestionResp{ID: question.ID}, nil
+}
+
+// AddMailboxQuestion queue the email sent to the mailbox as a question,
+// queued is false if the mailbox is disabled or the email is not sent to it
+func (qs *InboundQuestionService) AddMailboxQuestion(ctx context.Context, email *export.InboundEmail) (
+	queued bool, err error) {
+	conf, err := qs.GetInboundQuestionSetting(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !conf.Enabled || !conf.MailboxEnabled {
+		return false, nil
+	}
+	ec, err := qs.emailService.GetEmailConfig(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !sentToMailbox(email.Recipients, conf.MailboxName, ec.ReplyDomain) {
+		return false, nil
+	}
+
+	sender, err := mail.ParseAddress(email.From)
+	if err != nil {
+		log.Warnf("the sender %s of the email to the mailbox is invalid", email.From)
+		return true, nil
+	}
+	title := strings.Join(strings.Fields(email.Subject), " ")
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		title = string([]rune(title)[:maxTitleLength])
+	}
+	text := export.StripQuotedText(email.Text)
+	if utf8.RuneCountInString(title) < minTitleLength || utf8.RuneCountInString(text) < minContentLength {
+		log.Warnf("the email from %s to the mailbox is too short to be a question", sender.Address)
+		return true, nil
+	}
+	question := &entity.InboundQuestion{
+		Channel:     entity.InboundQuestionChannelEmail,
+		SenderEmail: sender.Address,
+		SenderName:  sender.Name,
+		Title:       title,
+		Content:     text,
+		Tags:        strings.Join(conf.DefaultTags, ","),
+		Status:      entity.InboundQuestionStatusPending,
+	}
+	if err = qs.inboundQuestionRepo.AddInboundQuestion(ctx, question); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// GetInboundQuestionPage get the page of the inbound questions, the pending ones by default
+func (qs *InboundQuestionService) GetInboundQuestionPage(ctx context.Context, req *schema.GetInboundQuestionPageReq) (
+	list []*schema.InboundQuestionResp, total int64, err error) {
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.PageSize == 0 {
+		req.PageSize = defaultPageSize
+	}
+	status := entity.InboundQuestionStatusPending
+	for s, name := range statusNames {
+		if name == req.Status {
+			status = s
+		}
+	}
+	questions, total, err := qs.inboundQuestionRepo.GetInboundQuestionPage(ctx, req.Page, req.PageSize, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	list = make([]*schema.InboundQuestionResp, 0, len(questions))
+	for _, question := range questions {
+		item := &schema.InboundQuestionResp{
+			ID:          question.ID,
+			Channel:     question.Channel,
+			SenderEmail: question.SenderEmail,
+			SenderName:  question.SenderName,
+			Title:       question.Title,
+			Content:     question.Content,
+			Tags:        splitTags(question.Tags),
+			Status:      statusNames[question.Status],
+			CreatedAt:   question.CreatedAt.Unix(),
+		}
+		if question.Status == entity.InboundQuestionStatusApproved {
+			item.UserID = question.UserID
+			item.QuestionID = question.QuestionID
+			if handler.GetEnableShortID(ctx) {
+				item.QuestionID = uid.EnShortID(item.QuestionID)
+			}
+		}
+		list = append(list, item)
+	}
+	return list, total, nil
+}
+
+// ReviewInboundQuestion approve or reject the pending question. The approved question is published
+// as a question of the user whose verified email is the sender, or of a shadow account created for
+// the sender, which can be claimed by resetting the password.
+func (qs *InboundQuestionService) ReviewInboundQuestion(ctx context.Context, req *schema.ReviewInboundQuestionReq) (
+	resp *schema.ReviewInboundQuestionResp, err error) {
+	qs.reviewLock.Lock()
+	defer qs.reviewLock.Unlock()
+
+	question, exist, err := qs.inboundQuestionRepo.GetInboundQuestion(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.InboundQuestionNotFound)
+	}
+	if question.Status != entity.InboundQuestionStatusPending {
+		return nil, errors.BadRequest(reason.InboundQuestionReviewed)
+	}
+	question.ReviewerID = req.UserID
+	resp = &schema.ReviewInboundQuestionResp{}
+
+	if req.Operation == schema.InboundQuestionOperationReject {
+		question.Status = entity.InboundQuestionStatusRejected
+		return resp, qs.inboundQuestionRepo.UpdateInboundQuestionReviewed(ctx, question)
+	}
+
+	tags := req.Tags
+	if len(tags) == 0 {
+		tags = splitTags(question.Tags)
+	}
+	userID, err := qs.getSenderUserID(ctx, question.SenderEmail, question.SenderName)
+	if err != nil {
+		return nil, err
+	}
+	questionReq := &schema.QuestionAdd{
+		Title:   question.Title,
+		Content: question.Content,
+		UserID:  userID,
+	}
+	for _, tag := range tags {
+		questionReq.Tags = append(questionReq.Tags, &schema.TagItem{SlugName: tag, DisplayName: tag})
+	}
+	_, _ = questionReq.Check()
+	questionInfo, err := qs.questionService.AddQuestion(ctx, questionReq)
+	if err != nil {
+		return nil, err
+	}
+	info, ok := questionInfo.(*schema.QuestionInfoResp)
+	if !ok {
+		return nil, errors.InternalServer(reason.UnknownError)
+	}
+
+	question.Status = entity.InboundQuestionStatusApproved
+	question.UserID = userID
+	question.QuestionID = uid.DeShortID(info.ID)
+	question.Tags = strings.Join(tags, ",")
+	if err = qs.inboundQuestionRepo.UpdateInboundQuestionReviewed(ctx, question); err != nil {
+		return nil, err
+	}
+	resp.QuestionID = info.ID
+	return resp, nil
+}
+
+// getSenderUserID get the user with the email of the sender, a shadow account is created if there is none.
+// The unverified account is used too, since it may be the shadow account created before.
+func (qs *InboundQuestionService) getSenderUserID(ctx context.Context, email, name string) (
+	userID string, err error) {
+	userInfo, exist, err := qs.userCommon.GetByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	if exist {
+		if userInfo.Status != entity.UserStatusAvailable {
+			return "", errors.Forbidden(reason.UserSuspended)
+		}
+		return userInfo.ID, nil
+	}
+
+	userInfo = &entity.User{
+		EMail:       email,
+		DisplayName: shadowDisplayName(email, name),
+		MailStatus:  entity.EmailStatusToBeVerified,
+		Status:      entity.UserStatusAvailable,
+		Rank:        1,
+	}
+	userInfo.Username, err = qs.userCommon.MakeUsername(ctx, userInfo.DisplayName)
+	if err != nil {
+		return "", err
+	}
+	if err = qs.userRepo.AddUser(ctx, userInfo); err != nil {
+		return "", err
+	}
+	qs.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserRegister,
+		ObjectID:  userInfo.ID,
+	})
+	log.Infof("shadow account %s is created for the inbound question sender %s", userInfo.ID, email)
+	return userInfo.ID, nil
+}
+
+var statusNames = map[int]string{
+	entity.InboundQuestionStatusPending:  schema.InboundQuestionStatusPending,
+	entity.InboundQuestionStatusApproved: schema.InboundQuestionStatusApproved,
+	entity.InboundQuestionStatusRejected: schema.InboundQuestionStatusRejected,
+}
+
+// sentToMailbox whether one of the recipients is the mailbox at the domain
+func sentToMailbox(recipients []string, mailboxName, domain string) bool {
+	if len(mailboxName) == 0 || len(domain) == 0 {
+		return false
+	}
+	mailbox := strings.ToLower(mailboxName + "@" + domain)
+	for _, recipient := range recipients {
+		if addr, err := mail.ParseAddress(recipient); err == nil {
+			recipient = addr.Address
+		}
+		if strings.ToLower(recipient) == mailbox {
+			return true
+		}
+	}
+	return false
+}
+
+// shadowDisplayName the display name of the shadow account, it is the local part of the email without the name
+func shadowDisplayName(email, name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		name = email
+		if at := strings.Index(email, "@"); at > 0 {
+			name = email[:at]
+		}
+	}
+	if utf8.RuneCountInString(name) > 30 {
+		name = string([]rune(name)[:30])
+	}
+	return name
+}
+
+func splitTags(tags string) []string {
+	list := make([]string, 0)
+	for _, tag := range strings.Split(tags, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			list = append(list, tag)
+		}
+	}
+	return list
+}
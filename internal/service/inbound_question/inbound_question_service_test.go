@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package inbound_question
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentToMailbox(t *testing.T) {
+	assert.True(t, sentToMailbox([]string{"Support <ASK@reply.example.com>"}, "ask", "reply.example.com"))
+	assert.True(t, sentToMailbox([]string{"other@example.com", "ask@reply.example.com"}, "ask", "reply.example.com"))
+	assert.False(t, sentToMailbox([]string{"ask@example.com"}, "ask", "reply.example.com"))
+	assert.False(t, sentToMailbox([]string{"ask@reply.example.com"}, "ask", ""))
+}
+
+func TestShadowDisplayName(t *testing.T) {
+	assert.Equal(t, "Jane Doe", shadowDisplayName("jane@example.com", " Jane Doe "))
+	assert.Equal(t, "jane", shadowDisplayName("jane@example.com", ""))
+	assert.Equal(t, 30, len(shadowDisplayName("jane@example.com", "abcdefghijklmnopqrstuvwxyz0123456789")))
+}
+
+func TestSplitTags(t *testing.T) {
+	assert.Equal(t, []string{"go", "mysql"}, splitTags("go, ,mysql"))
+	assert.Equal(t, []string{}, splitTags(""))
+}
@@ -0,0 +1,319 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/google/uuid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// the limits of the invitation created by the non-admin users
+	maxUserInvitationUses       = 10
+	maxUserInvitationExpireDays = 30
+)
+
+// InvitationRepo invitation repository
+type InvitationRepo interface {
+	AddInvitation(ctx context.Context, invitation *entity.Invitation) (err error)
+	GetInvitation(ctx context.Context, id int) (invitation *entity.Invitation, exist bool, err error)
+	GetInvitationByCode(ctx context.Context, code string) (invitation *entity.Invitation, exist bool, err error)
+	GetInvitationPage(ctx context.Context, page, pageSize int, userID string) (
+		invitations []*entity.Invitation, total int64, err error)
+	UpdateInvitationStatus(ctx context.Context, id, status int) (err error)
+	AddInvitedUser(ctx context.Context, invitation *entity.Invitation, user *entity.User) (ok bool, err error)
+	GetInvitationRecordPage(ctx context.Context, page, pageSize int) (
+		records []*entity.InvitationRecord, total int64, err error)
+}
+
+// InvitationService invitation service
+type InvitationService struct {
+	invitationRepo  InvitationRepo
+	userRepo        usercommon.UserRepo
+	userCommon      *usercommon.UserCommon
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewInvitationService new invitation service
+func NewInvitationService(
+	invitationRepo InvitationRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *InvitationService {
+	return &InvitationService{
+		invitationRepo:  invitationRepo,
+		userRepo:        userRepo,
+		userCommon:      userCommon,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// AddInvitation add invitation. Admin can always invite others,
+// other users can invite only if their reputation reaches the rank configured by admin.
+func (is *InvitationService) AddInvitation(ctx context.Context, req *schema.AddInvitationReq) (
+	resp *schema.InvitationResp, err error) {
+	if !req.IsAdmin {
+		canInvite, err := is.canUserInvite(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if !canInvite {
+			return nil, errors.Forbidden(reason.InvitationNoPermission)
+		}
+		if req.MaxUses == 0 || req.MaxUses > maxUserInvitationUses {
+			req.MaxUses = maxUserInvitationUses
+		}
+		if req.ExpireDays == 0 || req.ExpireDays > maxUserInvitationExpireDays {
+			req.ExpireDays = maxUserInvitationExpireDays
+		}
+	}
+
+	invitation := &entity.Invitation{
+		Code:    strings.ReplaceAll(uuid.NewString(), "-", ""),
+		UserID:  req.UserID,
+		MaxUses: req.MaxUses,
+		Status:  entity.InvitationStatusAvailable,
+	}
+	if req.ExpireDays > 0 {
+		invitation.ExpiredAt = time.Now().AddDate(0, 0, req.ExpireDays)
+	}
+	if err = is.invitationRepo.AddInvitation(ctx, invitation); err != nil {
+		return nil, err
+	}
+	return is.formatInvitation(ctx, invitation), nil
+}
+
+// GetInvitationPage get invitation page. If the user id is empty, get all invitations with inviter for admin.
+func (is *InvitationService) GetInvitationPage(ctx context.Context, req *schema.GetInvitationPageReq) (
+	pageModel *pager.PageModel, err error) {
+	invitations, total, err := is.invitationRepo.GetInvitationPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	var userMapping map[string]*schema.UserBasicInfo
+	if len(req.UserID) == 0 {
+		userIDs := make([]string, 0, len(invitations))
+		for _, invitation := range invitations {
+			userIDs = append(userIDs, invitation.UserID)
+		}
+		userMapping, err = is.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := make([]*schema.InvitationResp, 0, len(invitations))
+	for _, invitation := range invitations {
+		item := is.formatInvitation(ctx, invitation)
+		item.Inviter = userMapping[invitation.UserID]
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// RevokeInvitation revoke invitation, the user can only revoke their own invitation
+func (is *InvitationService) RevokeInvitation(ctx context.Context, req *schema.RevokeInvitationReq) (err error) {
+	invitation, exist, err := is.invitationRepo.GetInvitation(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || (!req.IsAdmin && invitation.UserID != req.UserID) {
+		return errors.BadRequest(reason.InvitationNotFound)
+	}
+	if invitation.Status == entity.InvitationStatusRevoked {
+		return nil
+	}
+	return is.invitationRepo.UpdateInvitationStatus(ctx, invitation.ID, entity.InvitationStatusRevoked)
+}
+
+// CheckInvitation check whether the invitation code can be used for registration
+func (is *InvitationService) CheckInvitation(ctx context.Context, req *schema.CheckInvitationReq) (
+	resp *schema.CheckInvitationResp, err error) {
+	resp = &schema.CheckInvitationResp{}
+	invitation, exist, err := is.invitationRepo.GetInvitationByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || !invitation.IsUsable() {
+		return resp, nil
+	}
+	resp.Valid = true
+	inviter, exist, err := is.userCommon.GetUserBasicInfoByID(ctx, invitation.UserID)
+	if err != nil {
+		log.Error(err)
+	} else if exist {
+		resp.Inviter = inviter
+	}
+	return resp, nil
+}
+
+// GetRegisterInvitation get the invitation the user registers with, it is used by AddInvitedUser.
+// If the site is not invite-only, the invitation is optional and the invalid one is ignored.
+func (is *InvitationService) GetRegisterInvitation(ctx context.Context, code string) (
+	invitation *entity.Invitation, errFields []*validator.FormErrorField, err error) {
+	siteLogin, err := is.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(code) == 0 {
+		if !siteLogin.InviteOnly {
+			return nil, nil, nil
+		}
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: "invitation_code",
+			ErrorMsg:   reason.InvitationRequired,
+		})
+		return nil, errFields, errors.BadRequest(reason.InvitationRequired)
+	}
+
+	invitation, exist, err := is.invitationRepo.GetInvitationByCode(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exist && invitation.IsUsable() {
+		return invitation, nil, nil
+	}
+	if siteLogin.InviteOnly {
+		return nil, invalidInvitationFields(), errors.BadRequest(reason.InvitationInvalid)
+	}
+	return nil, nil, nil
+}
+
+// AddInvitedUser add the user registered with the invitation. The invitation is used in the same transaction
+// as the user is added, so the registration that fails does not use it up.
+func (is *InvitationService) AddInvitedUser(ctx context.Context, invitation *entity.Invitation, userInfo *entity.User) (
+	errFields []*validator.FormErrorField, err error) {
+	if invitation == nil {
+		return nil, is.userRepo.AddUser(ctx, userInfo)
+	}
+	ok, err := is.invitationRepo.AddInvitedUser(ctx, invitation, userInfo)
+	if err != nil || ok {
+		return nil, err
+	}
+	// the invitation has been used up by others at the same time
+	siteLogin, err := is.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if siteLogin.InviteOnly {
+		return invalidInvitationFields(), errors.BadRequest(reason.InvitationInvalid)
+	}
+	return nil, is.userRepo.AddUser(ctx, userInfo)
+}
+
+func invalidInvitationFields() []*validator.FormErrorField {
+	return []*validator.FormErrorField{{
+		ErrorField: "invitation_code",
+		ErrorMsg:   reason.InvitationInvalid,
+	}}
+}
+
+// GetInvitationRecordPage get the records of who invited whom for admin
+func (is *InvitationService) GetInvitationRecordPage(ctx context.Context, req *schema.GetInvitationRecordPageReq) (
+	pageModel *pager.PageModel, err error) {
+	records, total, err := is.invitationRepo.GetInvitationRecordPage(ctx, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(records)*2)
+	for _, record := range records {
+		userIDs = append(userIDs, record.InviterUserID, record.UserID)
+	}
+	userMapping, err := is.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.InvitationRecordResp, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, &schema.InvitationRecordResp{
+			ID:           record.ID,
+			InvitationID: record.InvitationID,
+			CreatedAt:    record.CreatedAt.Unix(),
+			Inviter:      userMapping[record.InviterUserID],
+			Invitee:      userMapping[record.UserID],
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// canUserInvite whether the non-admin user's reputation reaches the rank to invite others
+func (is *InvitationService) canUserInvite(ctx context.Context, userID string) (canInvite bool, err error) {
+	siteLogin, err := is.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return false, err
+	}
+	if siteLogin.InvitationMinRank <= 0 {
+		return false, nil
+	}
+	userInfo, exist, err := is.userRepo.GetByUserID(ctx, userID)
+	if err != nil || !exist {
+		return false, err
+	}
+	return userInfo.Rank >= siteLogin.InvitationMinRank, nil
+}
+
+func (is *InvitationService) formatInvitation(ctx context.Context, invitation *entity.Invitation) *schema.InvitationResp {
+	resp := &schema.InvitationResp{
+		ID:        invitation.ID,
+		Code:      invitation.Code,
+		MaxUses:   invitation.MaxUses,
+		UsedCount: invitation.UsedCount,
+		Status:    invitationStatus(invitation),
+		CreatedAt: invitation.CreatedAt.Unix(),
+	}
+	if !invitation.ExpiredAt.IsZero() {
+		resp.ExpiredAt = invitation.ExpiredAt.Unix()
+	}
+	siteGeneral, err := is.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+	} else {
+		resp.URL = fmt.Sprintf("%s/users/register?invitation_code=%s", siteGeneral.SiteUrl, invitation.Code)
+	}
+	return resp
+}
+
+func invitationStatus(invitation *entity.Invitation) string {
+	switch {
+	case invitation.Status == entity.InvitationStatusRevoked:
+		return schema.InvitationStatusRevoked
+	case invitation.MaxUses > 0 && invitation.UsedCount >= invitation.MaxUses:
+		return schema.InvitationStatusUsedUp
+	case !invitation.ExpiredAt.IsZero() && !time.Now().Before(invitation.ExpiredAt):
+		return schema.InvitationStatusExpired
+	default:
+		return schema.InvitationStatusAvailable
+	}
+}
@@ -0,0 +1,174 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package invitation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testInvitationRepo struct {
+	InvitationRepo
+	invitations map[string]*entity.Invitation
+	records     []*entity.InvitationRecord
+	users       []*entity.User
+}
+
+func (r *testInvitationRepo) GetInvitationByCode(_ context.Context, code string) (
+	invitation *entity.Invitation, exist bool, err error) {
+	invitation, exist = r.invitations[code]
+	return invitation, exist, nil
+}
+
+func (r *testInvitationRepo) AddInvitedUser(_ context.Context, invitation *entity.Invitation, user *entity.User) (
+	ok bool, err error) {
+	if !invitation.IsUsable() {
+		return false, nil
+	}
+	invitation.UsedCount++
+	r.users = append(r.users, user)
+	r.records = append(r.records, &entity.InvitationRecord{InvitationID: invitation.ID,
+		InviterUserID: invitation.UserID, UserID: user.ID})
+	return true, nil
+}
+
+func TestInvitationStatus(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		invitation *entity.Invitation
+		status     string
+	}{
+		{&entity.Invitation{Status: entity.InvitationStatusAvailable}, schema.InvitationStatusAvailable},
+		{&entity.Invitation{Status: entity.InvitationStatusAvailable, MaxUses: 2, UsedCount: 1}, schema.InvitationStatusAvailable},
+		{&entity.Invitation{Status: entity.InvitationStatusAvailable, MaxUses: 2, UsedCount: 2}, schema.InvitationStatusUsedUp},
+		{&entity.Invitation{Status: entity.InvitationStatusAvailable, ExpiredAt: now.Add(time.Hour)}, schema.InvitationStatusAvailable},
+		{&entity.Invitation{Status: entity.InvitationStatusAvailable, ExpiredAt: now.Add(-time.Hour)}, schema.InvitationStatusExpired},
+		{&entity.Invitation{Status: entity.InvitationStatusRevoked, MaxUses: 2, UsedCount: 2}, schema.InvitationStatusRevoked},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.status, invitationStatus(c.invitation))
+		assert.Equal(t, c.status == schema.InvitationStatusAvailable, c.invitation.IsUsable())
+	}
+}
+
+func TestGetRegisterInvitation(t *testing.T) {
+	repo := &testInvitationRepo{invitations: map[string]*entity.Invitation{
+		"valid":   {ID: 1, UserID: "1", Status: entity.InvitationStatusAvailable},
+		"used-up": {ID: 2, UserID: "1", Status: entity.InvitationStatusAvailable, MaxUses: 1, UsedCount: 1},
+	}}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	siteLogin := &schema.SiteLoginResp{}
+	siteInfo := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfo.EXPECT().GetSiteLogin(gomock.Any()).AnyTimes().Return(siteLogin, nil)
+	is := NewInvitationService(repo, nil, nil, siteInfo)
+	ctx := context.TODO()
+
+	for _, inviteOnly := range []bool{false, true} {
+		siteLogin.InviteOnly = inviteOnly
+
+		invitation, _, err := is.GetRegisterInvitation(ctx, "valid")
+		assert.NoError(t, err)
+		if assert.NotNil(t, invitation) {
+			// the invitation is only used when the user is added
+			assert.Equal(t, 0, invitation.UsedCount)
+		}
+
+		for _, code := range []string{"", "used-up", "unknown"} {
+			invitation, errFields, err := is.GetRegisterInvitation(ctx, code)
+			assert.Nil(t, invitation)
+			if !inviteOnly {
+				assert.NoError(t, err)
+				continue
+			}
+			if assert.Error(t, err) {
+				assert.Len(t, errFields, 1)
+				if len(code) == 0 {
+					assert.Equal(t, reason.InvitationRequired, err.(*errors.Error).Reason)
+				} else {
+					assert.Equal(t, reason.InvitationInvalid, err.(*errors.Error).Reason)
+				}
+			}
+		}
+	}
+}
+
+func TestAddInvitedUser(t *testing.T) {
+	ctx := context.TODO()
+	invitation := &entity.Invitation{ID: 1, UserID: "1", Status: entity.InvitationStatusAvailable, MaxUses: 1}
+
+	tests := []struct {
+		name        string
+		invitation  *entity.Invitation
+		inviteOnly  bool
+		usedUp      bool
+		wantReason  string
+		wantInvited bool
+		wantAdded   bool
+	}{
+		{name: "without invitation", wantAdded: true},
+		{name: "with invitation", invitation: invitation, wantInvited: true},
+		{name: "used up by others", invitation: invitation, usedUp: true, wantAdded: true},
+		{name: "used up by others on invite-only site", invitation: invitation, inviteOnly: true, usedUp: true,
+			wantReason: reason.InvitationInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			repo := &testInvitationRepo{}
+			userRepo := mock.NewMockUserRepo(ctl)
+			if tt.wantAdded {
+				userRepo.EXPECT().AddUser(gomock.Any(), &entity.User{ID: "2", Username: "invitee"}).Return(nil)
+			}
+			siteInfo := mock.NewMockSiteInfoCommonService(ctl)
+			siteInfo.EXPECT().GetSiteLogin(gomock.Any()).AnyTimes().
+				Return(&schema.SiteLoginResp{InviteOnly: tt.inviteOnly}, nil)
+			is := NewInvitationService(repo, userRepo, nil, siteInfo)
+			if tt.invitation != nil {
+				tt.invitation.UsedCount = 0
+				if tt.usedUp {
+					tt.invitation.UsedCount = tt.invitation.MaxUses
+				}
+			}
+
+			errFields, err := is.AddInvitedUser(ctx, tt.invitation, &entity.User{ID: "2", Username: "invitee"})
+			if len(tt.wantReason) > 0 {
+				if assert.Error(t, err) {
+					assert.Equal(t, tt.wantReason, err.(*errors.Error).Reason)
+					assert.Len(t, errFields, 1)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantInvited, len(repo.users) == 1)
+			assert.Equal(t, tt.wantInvited, len(repo.records) == 1)
+		})
+	}
+}
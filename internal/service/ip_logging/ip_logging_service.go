@@ -0,0 +1,405 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ip_logging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// geoIPTimeout the timeout of looking up the location of the ip address
+	geoIPTimeout = 3 * time.Second
+	// geoIPMaxBody the max size of the geoip lookup response
+	geoIPMaxBody = 64 * 1024
+	// geoIPMaxFetchers the max number of the lookups running at the same time, the record is kept without
+	// the location when there are too many lookups
+	geoIPMaxFetchers = 4
+	// geoIPPlaceholder the placeholder of the ip address in the geoip lookup url
+	geoIPPlaceholder = "{ip}"
+	// userAgentMaxLength the max length of the user agent column
+	userAgentMaxLength = 512
+	// relatedAccountsLimit the max number of the related accounts returned
+	relatedAccountsLimit = 50
+)
+
+// IPLoggingRepo ip logging repository
+type IPLoggingRepo interface {
+	AddPostIPRecord(ctx context.Context, record *entity.PostIPRecord) (err error)
+	UpdatePostIPRecordLocation(ctx context.Context, record *entity.PostIPRecord) (err error)
+	GetPostIPRecordPage(ctx context.Context, page, pageSize int, userID, ip string) (
+		records []*entity.PostIPRecord, total int64, err error)
+	GetUserFingerprints(ctx context.Context, userID string) (fingerprints []*Fingerprint, err error)
+	GetFingerprintsByIPsOrUserAgents(ctx context.Context, ips, userAgents []string, excludeUserID string) (
+		fingerprints []*Fingerprint, err error)
+}
+
+// Fingerprint the ip address and the user agent used by the user when posting or signing in
+type Fingerprint struct {
+	UserID    string
+	IP        string
+	UserAgent string
+	SeenAt    time.Time
+}
+
+// IPLoggingService the ip address and the user agent of the poster are recorded and optionally enriched with
+// the location, the moderators use them to find the accounts operated by the same person
+type IPLoggingService struct {
+	ipLoggingRepo   IPLoggingRepo
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	userCommon      *usercommon.UserCommon
+	httpClient      *http.Client
+	fetchers        chan struct{}
+}
+
+// NewIPLoggingService new ip logging service
+func NewIPLoggingService(
+	ipLoggingRepo IPLoggingRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userCommon *usercommon.UserCommon,
+) *IPLoggingService {
+	return &IPLoggingService{
+		ipLoggingRepo:   ipLoggingRepo,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		userCommon:      userCommon,
+		httpClient:      &http.Client{Timeout: geoIPTimeout},
+		fetchers:        make(chan struct{}, geoIPMaxFetchers),
+	}
+}
+
+// GetIPLogging get the ip logging setting
+func (is *IPLoggingService) GetIPLogging(ctx context.Context) (resp *schema.SiteIPLoggingReq, err error) {
+	resp = &schema.SiteIPLoggingReq{}
+	if err = is.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeIPLogging, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateIPLogging update the ip logging setting
+func (is *IPLoggingService) UpdateIPLogging(ctx context.Context, req *schema.SiteIPLoggingReq) (err error) {
+	req.GeoIPURL = strings.TrimSpace(req.GeoIPURL)
+	if len(req.GeoIPURL) > 0 && !strings.Contains(req.GeoIPURL, geoIPPlaceholder) {
+		return errors.BadRequest(reason.IPLoggingGeoIPURLInvalid)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeIPLogging,
+		Content: string(content),
+	}
+	return is.siteInfoRepo.SaveByType(ctx, constant.SiteTypeIPLogging, data)
+}
+
+// RecordPostIP record the ip address and the user agent of the new post if the ip logging is enabled,
+// the failure is logged only and never blocks posting
+func (is *IPLoggingService) RecordPostIP(ctx context.Context, userID, objectID, objectType, ip, userAgent string) {
+	if len(ip) == 0 {
+		return
+	}
+	setting, err := is.GetIPLogging(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !setting.Enabled {
+		return
+	}
+	if len(userAgent) > userAgentMaxLength {
+		userAgent = userAgent[:userAgentMaxLength]
+	}
+	record := &entity.PostIPRecord{
+		UserID:     userID,
+		ObjectID:   objectID,
+		ObjectType: objectType,
+		IP:         ip,
+		UserAgent:  userAgent,
+	}
+	if err = is.ipLoggingRepo.AddPostIPRecord(ctx, record); err != nil {
+		log.Error(err)
+		return
+	}
+	if len(setting.GeoIPURL) > 0 {
+		is.enrichInBackground(setting.GeoIPURL, record)
+	}
+}
+
+// GetPostIPRecordPage get the post ip records page, they are filtered by the user and the ip address
+func (is *IPLoggingService) GetPostIPRecordPage(ctx context.Context, req *schema.GetPostIPRecordPageReq) (
+	pageModel *pager.PageModel, err error) {
+	records, total, err := is.ipLoggingRepo.GetPostIPRecordPage(ctx, req.Page, req.PageSize, req.UserID,
+		strings.TrimSpace(req.IP))
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		userIDs = append(userIDs, record.UserID)
+	}
+	userInfoMapping, err := is.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.PostIPRecordResp, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, &schema.PostIPRecordResp{
+			ID:         record.ID,
+			User:       userInfoMapping[record.UserID],
+			ObjectID:   record.ObjectID,
+			ObjectType: record.ObjectType,
+			IP:         record.IP,
+			UserAgent:  record.UserAgent,
+			Country:    record.Country,
+			Region:     record.Region,
+			City:       record.City,
+			CreatedAt:  record.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetRelatedAccounts get the accounts sharing the ip addresses or the user agents with the user,
+// the accounts sharing more ip addresses are listed first
+func (is *IPLoggingService) GetRelatedAccounts(ctx context.Context, req *schema.GetRelatedAccountsReq) (
+	resp []*schema.RelatedAccountResp, err error) {
+	resp = make([]*schema.RelatedAccountResp, 0)
+	fingerprints, err := is.ipLoggingRepo.GetUserFingerprints(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	ips, userAgents := distinctFingerprints(fingerprints)
+	if len(ips) == 0 && len(userAgents) == 0 {
+		return resp, nil
+	}
+	matches, err := is.ipLoggingRepo.GetFingerprintsByIPsOrUserAgents(ctx, ips, userAgents, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	accounts := correlateAccounts(ips, userAgents, matches)
+	if len(accounts) > relatedAccountsLimit {
+		accounts = accounts[:relatedAccountsLimit]
+	}
+	userIDs := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		userIDs = append(userIDs, account.UserID)
+	}
+	userInfoMapping, err := is.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		userInfo, ok := userInfoMapping[account.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.RelatedAccountResp{
+			User:             userInfo,
+			SharedIPs:        account.SharedIPs,
+			SharedUserAgents: account.SharedUserAgents,
+			LastSeenAt:       account.LastSeenAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// enrichInBackground look up the location of the ip address and update the record, the lookup is skipped
+// when too many lookups are running
+func (is *IPLoggingService) enrichInBackground(geoIPURL string, record *entity.PostIPRecord) {
+	select {
+	case is.fetchers <- struct{}{}:
+	default:
+		return
+	}
+	go func() {
+		defer func() { <-is.fetchers }()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*geoIPTimeout)
+		defer cancel()
+		country, region, city, err := is.lookupGeoIP(ctx, geoIPURL, record.IP)
+		if err != nil {
+			log.Debugf("look up the location of %s failed: %s", record.IP, err)
+			return
+		}
+		record.Country, record.Region, record.City = country, region, city
+		if err = is.ipLoggingRepo.UpdatePostIPRecordLocation(ctx, record); err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// lookupGeoIP request the geoip lookup service configured by the admin
+func (is *IPLoggingService) lookupGeoIP(ctx context.Context, geoIPURL, ip string) (
+	country, region, city string, err error) {
+	link := strings.ReplaceAll(geoIPURL, geoIPPlaceholder, url.PathEscape(ip))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return "", "", "", err
+	}
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set("Accept", "application/json")
+	resp, err := is.httpClient.Do(req)
+	if err != nil {
+		return "", "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, geoIPMaxBody))
+	if err != nil {
+		return "", "", "", err
+	}
+	country, region, city = parseGeoIP(body)
+	return country, region, city, nil
+}
+
+// geoIPResp the location fields of the common geoip lookup services
+type geoIPResp struct {
+	Country     string `json:"country"`
+	CountryName string `json:"country_name"`
+	Region      string `json:"region"`
+	RegionName  string `json:"regionName"`
+	City        string `json:"city"`
+}
+
+// parseGeoIP parse the location from the geoip lookup response, the full names are preferred
+// and the values are cut to fit the columns
+func parseGeoIP(body []byte) (country, region, city string) {
+	geo := &geoIPResp{}
+	if err := json.Unmarshal(body, geo); err != nil {
+		return "", "", ""
+	}
+	country, region = geo.Country, geo.Region
+	if len(geo.CountryName) > 0 {
+		country = geo.CountryName
+	}
+	if len(geo.RegionName) > 0 {
+		region = geo.RegionName
+	}
+	return cut(country, 64), cut(region, 128), cut(geo.City, 128)
+}
+
+// cut the string to the max length in runes
+func cut(s string, maxLength int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) > maxLength {
+		return string(runes[:maxLength])
+	}
+	return string(runes)
+}
+
+// distinctFingerprints the distinct ip addresses and user agents of the fingerprints, the empty ones are ignored
+func distinctFingerprints(fingerprints []*Fingerprint) (ips, userAgents []string) {
+	ips, userAgents = make([]string, 0), make([]string, 0)
+	seenIPs, seenUserAgents := make(map[string]bool), make(map[string]bool)
+	for _, fingerprint := range fingerprints {
+		if len(fingerprint.IP) > 0 && !seenIPs[fingerprint.IP] {
+			seenIPs[fingerprint.IP] = true
+			ips = append(ips, fingerprint.IP)
+		}
+		if len(fingerprint.UserAgent) > 0 && !seenUserAgents[fingerprint.UserAgent] {
+			seenUserAgents[fingerprint.UserAgent] = true
+			userAgents = append(userAgents, fingerprint.UserAgent)
+		}
+	}
+	return ips, userAgents
+}
+
+// relatedAccount the ip addresses and the user agents shared by the account
+type relatedAccount struct {
+	UserID           string
+	SharedIPs        []string
+	SharedUserAgents []string
+	LastSeenAt       time.Time
+}
+
+// correlateAccounts group the matched fingerprints by the user, the accounts sharing more ip addresses are
+// listed first, then the ones sharing more user agents and the ones seen recently
+func correlateAccounts(ips, userAgents []string, matches []*Fingerprint) (accounts []*relatedAccount) {
+	targetIPs, targetUserAgents := make(map[string]bool), make(map[string]bool)
+	for _, ip := range ips {
+		targetIPs[ip] = true
+	}
+	for _, userAgent := range userAgents {
+		targetUserAgents[userAgent] = true
+	}
+
+	accounts = make([]*relatedAccount, 0)
+	accountMapping := make(map[string]*relatedAccount)
+	for _, match := range matches {
+		sharedIP, sharedUserAgent := targetIPs[match.IP], targetUserAgents[match.UserAgent]
+		if !sharedIP && !sharedUserAgent {
+			continue
+		}
+		account, ok := accountMapping[match.UserID]
+		if !ok {
+			account = &relatedAccount{UserID: match.UserID, SharedIPs: []string{}, SharedUserAgents: []string{}}
+			accountMapping[match.UserID] = account
+			accounts = append(accounts, account)
+		}
+		if sharedIP && !contains(account.SharedIPs, match.IP) {
+			account.SharedIPs = append(account.SharedIPs, match.IP)
+		}
+		if sharedUserAgent && !contains(account.SharedUserAgents, match.UserAgent) {
+			account.SharedUserAgents = append(account.SharedUserAgents, match.UserAgent)
+		}
+		if match.SeenAt.After(account.LastSeenAt) {
+			account.LastSeenAt = match.SeenAt
+		}
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if len(accounts[i].SharedIPs) != len(accounts[j].SharedIPs) {
+			return len(accounts[i].SharedIPs) > len(accounts[j].SharedIPs)
+		}
+		if len(accounts[i].SharedUserAgents) != len(accounts[j].SharedUserAgents) {
+			return len(accounts[i].SharedUserAgents) > len(accounts[j].SharedUserAgents)
+		}
+		return accounts[i].LastSeenAt.After(accounts[j].LastSeenAt)
+	})
+	return accounts
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
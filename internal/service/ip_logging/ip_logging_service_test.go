@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package ip_logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGeoIP(t *testing.T) {
+	country, region, city := parseGeoIP([]byte(`{"country":"US","country_name":"United States",` +
+		`"region":"California","city":"San Jose"}`))
+	assert.Equal(t, "United States", country)
+	assert.Equal(t, "California", region)
+	assert.Equal(t, "San Jose", city)
+
+	country, region, city = parseGeoIP([]byte(`{"country":"Germany","regionName":"Hesse","city":"Frankfurt"}`))
+	assert.Equal(t, "Germany", country)
+	assert.Equal(t, "Hesse", region)
+	assert.Equal(t, "Frankfurt", city)
+
+	country, region, city = parseGeoIP([]byte(`not json`))
+	assert.Empty(t, country)
+	assert.Empty(t, region)
+	assert.Empty(t, city)
+}
+
+func TestCorrelateAccounts(t *testing.T) {
+	now := time.Now()
+	ips, userAgents := distinctFingerprints([]*Fingerprint{
+		{UserID: "1", IP: "10.0.0.1", UserAgent: "ua-1"},
+		{UserID: "1", IP: "10.0.0.2", UserAgent: "ua-1"},
+		{UserID: "1", IP: "", UserAgent: ""},
+	})
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, ips)
+	assert.Equal(t, []string{"ua-1"}, userAgents)
+
+	accounts := correlateAccounts(ips, userAgents, []*Fingerprint{
+		{UserID: "2", IP: "10.0.0.9", UserAgent: "ua-1", SeenAt: now},
+		{UserID: "3", IP: "10.0.0.1", UserAgent: "ua-3", SeenAt: now.Add(-time.Hour)},
+		{UserID: "3", IP: "10.0.0.2", UserAgent: "ua-3", SeenAt: now.Add(-2 * time.Hour)},
+		{UserID: "3", IP: "10.0.0.1", UserAgent: "ua-3", SeenAt: now.Add(-3 * time.Hour)},
+		{UserID: "4", IP: "10.0.0.8", UserAgent: "ua-4", SeenAt: now},
+	})
+	assert.Len(t, accounts, 2)
+	assert.Equal(t, "3", accounts[0].UserID)
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.2"}, accounts[0].SharedIPs)
+	assert.Empty(t, accounts[0].SharedUserAgents)
+	assert.Equal(t, now.Add(-time.Hour), accounts[0].LastSeenAt)
+	assert.Equal(t, "2", accounts[1].UserID)
+	assert.Equal(t, []string{"ua-1"}, accounts[1].SharedUserAgents)
+}
@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira_integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// jiraClient the client of the Jira rest api v2, which is served by both Jira Cloud and Jira Server
+type jiraClient struct {
+	baseURL    string
+	email      string
+	apiToken   string
+	httpClient *http.Client
+}
+
+// jiraIssueStatus the status of the Jira issue
+type jiraIssueStatus struct {
+	Name           string `json:"name"`
+	StatusCategory struct {
+		Key string `json:"key"`
+	} `json:"statusCategory"`
+}
+
+// createIssue create the issue with the fields, the key of the issue is returned
+func (c *jiraClient) createIssue(ctx context.Context, fields map[string]any) (key string, err error) {
+	resp := &struct {
+		Key string `json:"key"`
+	}{}
+	if err = c.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]any{"fields": fields}, resp); err != nil {
+		return "", err
+	}
+	return resp.Key, nil
+}
+
+// getIssueStatus get the status of the issue
+func (c *jiraClient) getIssueStatus(ctx context.Context, key string) (status *jiraIssueStatus, err error) {
+	resp := &struct {
+		Fields struct {
+			Status *jiraIssueStatus `json:"status"`
+		} `json:"fields"`
+	}{}
+	path := "/rest/api/2/issue/" + url.PathEscape(key) + "?fields=status"
+	if err = c.do(ctx, http.MethodGet, path, nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Fields.Status == nil {
+		return nil, fmt.Errorf("jira issue %s has no status", key)
+	}
+	return resp.Fields.Status, nil
+}
+
+// issueURL the url of the issue page
+func (c *jiraClient) issueURL(key string) string {
+	return c.baseURL + "/browse/" + key
+}
+
+func (c *jiraClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", c.authorization())
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("jira api %s %s returned %d: %s", method, path, resp.StatusCode, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// authorization Jira Cloud uses the basic auth of the email and the api token,
+// Jira Server and Data Center use the bearer personal access token
+func (c *jiraClient) authorization() string {
+	if len(c.email) == 0 {
+		return "Bearer " + c.apiToken
+	}
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.apiToken))
+}
+
+// renderFieldTemplate replace the placeholders in the template of the field mapping
+func renderFieldTemplate(template string, values map[string]string) string {
+	pairs := make([]string, 0, len(values)*2)
+	for name, value := range values {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
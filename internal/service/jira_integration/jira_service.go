@@ -0,0 +1,299 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira_integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// statusSyncInterval the status of the unresolved issue is synced after the interval
+	statusSyncInterval  = 10 * time.Minute
+	statusSyncBatchSize = 50
+	maxSummaryLength    = 255
+)
+
+// JiraIssueLinkRepo Jira issue link repository
+type JiraIssueLinkRepo interface {
+	AddIssueLink(ctx context.Context, link *entity.JiraIssueLink) (err error)
+	GetIssueLinkByQuestionID(ctx context.Context, questionID string) (link *entity.JiraIssueLink, exist bool, err error)
+	GetUnresolvedIssueLinks(ctx context.Context, syncedBefore time.Time, limit int) (
+		links []*entity.JiraIssueLink, err error)
+	UpdateIssueStatus(ctx context.Context, link *entity.JiraIssueLink) (err error)
+}
+
+// JiraService escalate the questions to the Jira issues and sync the status of the issues back
+type JiraService struct {
+	issueLinkRepo    JiraIssueLinkRepo
+	questionRepo     questioncommon.QuestionRepo
+	tagCommonService *tagcommon.TagCommonService
+	userCommon       *usercommon.UserCommon
+	siteInfoRepo     siteinfo_common.SiteInfoRepo
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	httpClient       *http.Client
+	escalateLock     sync.Mutex
+}
+
+// NewJiraService new Jira integration service
+func NewJiraService(
+	issueLinkRepo JiraIssueLinkRepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *JiraService {
+	return &JiraService{
+		issueLinkRepo:    issueLinkRepo,
+		questionRepo:     questionRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+		siteInfoRepo:     siteInfoRepo,
+		siteInfoService:  siteInfoService,
+		httpClient:       &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// GetJira get the Jira integration settings
+func (js *JiraService) GetJira(ctx context.Context) (resp *schema.SiteJiraResp, err error) {
+	resp = &schema.SiteJiraResp{}
+	if err = js.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeJira, resp); err != nil {
+		return nil, err
+	}
+	if len(resp.IssueType) == 0 {
+		resp.IssueType = schema.DefaultJiraIssueType
+	}
+	if resp.Labels == nil {
+		resp.Labels = make([]string, 0)
+	}
+	if resp.FieldMapping == nil {
+		resp.FieldMapping = make(map[string]string)
+	}
+	return resp, nil
+}
+
+// UpdateJira update the Jira integration settings
+func (js *JiraService) UpdateJira(ctx context.Context, req *schema.SiteJiraReq) (err error) {
+	req.BaseURL = strings.TrimSuffix(req.BaseURL, "/")
+	if req.Enabled && (len(req.BaseURL) == 0 || len(req.APIToken) == 0 || len(req.ProjectKey) == 0) {
+		return errors.BadRequest(reason.JiraConfigInvalid)
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeJira,
+		Content: string(content),
+	}
+	return js.siteInfoRepo.SaveByType(ctx, constant.SiteTypeJira, data)
+}
+
+// EscalateToJira create the Jira issue from the question and link it to the question
+func (js *JiraService) EscalateToJira(ctx context.Context, req *schema.EscalateToJiraReq) (
+	resp *schema.JiraIssueLinkResp, err error) {
+	conf, err := js.GetJira(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled {
+		return nil, errors.Forbidden(reason.JiraDisabled)
+	}
+	js.escalateLock.Lock()
+	defer js.escalateLock.Unlock()
+
+	questionID := uid.DeShortID(req.QuestionID)
+	_, linked, err := js.issueLinkRepo.GetIssueLinkByQuestionID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if linked {
+		return nil, errors.BadRequest(reason.JiraIssueAlreadyLinked)
+	}
+	question, exist, err := js.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+
+	fields, err := js.issueFields(ctx, conf, question)
+	if err != nil {
+		return nil, err
+	}
+	client := js.newClient(conf)
+	key, err := client.createIssue(ctx, fields)
+	if err != nil {
+		log.Errorf("create jira issue for question %s failed: %v", questionID, err)
+		return nil, errors.BadRequest(reason.JiraRequestFailed).WithError(err)
+	}
+	link := &entity.JiraIssueLink{
+		QuestionID: questionID,
+		IssueKey:   key,
+		IssueURL:   client.issueURL(key),
+		UserID:     req.UserID,
+		SyncedAt:   time.Now(),
+	}
+	if status, err := client.getIssueStatus(ctx, key); err != nil {
+		log.Warnf("get the status of jira issue %s failed: %v", key, err)
+	} else {
+		link.Status, link.StatusCategory = status.Name, status.StatusCategory.Key
+	}
+	if err = js.issueLinkRepo.AddIssueLink(ctx, link); err != nil {
+		return nil, err
+	}
+	log.Infof("question %s is escalated to jira issue %s by user %s", questionID, key, req.UserID)
+	return convertIssueLink(link), nil
+}
+
+// GetIssueLink get the Jira issue of the question, it is nil if the question is not escalated
+func (js *JiraService) GetIssueLink(ctx context.Context, req *schema.GetJiraIssueLinkReq) (
+	resp *schema.JiraIssueLinkResp, err error) {
+	conf, err := js.GetJira(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Enabled {
+		return nil, nil
+	}
+	link, exist, err := js.issueLinkRepo.GetIssueLinkByQuestionID(ctx, uid.DeShortID(req.QuestionID))
+	if err != nil || !exist {
+		return nil, err
+	}
+	return convertIssueLink(link), nil
+}
+
+// StatusSyncCron sync the status of the unresolved Jira issues
+func (js *JiraService) StatusSyncCron(ctx context.Context) (err error) {
+	conf, err := js.GetJira(ctx)
+	if err != nil {
+		return err
+	}
+	if !conf.Enabled {
+		return nil
+	}
+	links, err := js.issueLinkRepo.GetUnresolvedIssueLinks(ctx, time.Now().Add(-statusSyncInterval), statusSyncBatchSize)
+	if err != nil {
+		return err
+	}
+	client := js.newClient(conf)
+	for _, link := range links {
+		// the synced time is updated even if it fails, so the failed issue does not block the others
+		if status, err := client.getIssueStatus(ctx, link.IssueKey); err != nil {
+			log.Warnf("sync the status of jira issue %s failed: %v", link.IssueKey, err)
+		} else {
+			link.Status, link.StatusCategory = status.Name, status.StatusCategory.Key
+		}
+		link.SyncedAt = time.Now()
+		if err = js.issueLinkRepo.UpdateIssueStatus(ctx, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueFields the fields of the issue created from the question
+func (js *JiraService) issueFields(ctx context.Context, conf *schema.SiteJiraResp, question *entity.Question) (
+	fields map[string]any, err error) {
+	siteInfo, err := js.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := js.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := js.tagCommonService.GetObjectTag(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	tagNames := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagNames = append(tagNames, tag.SlugName)
+	}
+	author := ""
+	if userInfo, exist, err := js.userCommon.GetUserBasicInfoByID(ctx, question.UserID); err == nil && exist {
+		author = userInfo.DisplayName
+	}
+	questionURL := display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, question.ID, question.Title)
+
+	summary := question.Title
+	if utf8.RuneCountInString(summary) > maxSummaryLength {
+		summary = string([]rune(summary)[:maxSummaryLength])
+	}
+	fields = map[string]any{
+		"project":     map[string]string{"key": conf.ProjectKey},
+		"issuetype":   map[string]string{"name": conf.IssueType},
+		"summary":     summary,
+		"description": fmt.Sprintf("%s\n\n----\nEscalated from %s: %s", question.OriginalText, siteInfo.Name, questionURL),
+	}
+	if len(conf.Labels) > 0 {
+		fields["labels"] = conf.Labels
+	}
+	values := map[string]string{
+		"title":  question.Title,
+		"url":    questionURL,
+		"author": author,
+		"tags":   strings.Join(tagNames, ", "),
+	}
+	for field, template := range conf.FieldMapping {
+		fields[field] = renderFieldTemplate(template, values)
+	}
+	return fields, nil
+}
+
+func (js *JiraService) newClient(conf *schema.SiteJiraResp) *jiraClient {
+	return &jiraClient{
+		baseURL:    conf.BaseURL,
+		email:      conf.Email,
+		apiToken:   conf.APIToken,
+		httpClient: js.httpClient,
+	}
+}
+
+func convertIssueLink(link *entity.JiraIssueLink) *schema.JiraIssueLinkResp {
+	return &schema.JiraIssueLinkResp{
+		IssueKey:       link.IssueKey,
+		IssueURL:       link.IssueURL,
+		Status:         link.Status,
+		StatusCategory: link.StatusCategory,
+		Resolved:       link.StatusCategory == schema.JiraStatusCategoryDone,
+		CreatedAt:      link.CreatedAt.Unix(),
+		SyncedAt:       link.SyncedAt.Unix(),
+	}
+}
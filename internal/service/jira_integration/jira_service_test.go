@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package jira_integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "bot@example.com", user)
+		assert.Equal(t, "token", pass)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/api/2/issue":
+			body := map[string]map[string]any{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "How to deploy", body["fields"]["summary"])
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"10001","key":"SUP-1"}`))
+		case "GET /rest/api/2/issue/SUP-1":
+			_, _ = w.Write([]byte(`{"fields":{"status":{"name":"Done","statusCategory":{"key":"done"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+		}
+	}))
+	defer server.Close()
+
+	client := &jiraClient{baseURL: server.URL, email: "bot@example.com", apiToken: "token", httpClient: server.Client()}
+	key, err := client.createIssue(context.TODO(), map[string]any{"summary": "How to deploy"})
+	require.NoError(t, err)
+	assert.Equal(t, "SUP-1", key)
+	assert.Equal(t, server.URL+"/browse/SUP-1", client.issueURL(key))
+
+	status, err := client.getIssueStatus(context.TODO(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "Done", status.Name)
+	assert.Equal(t, "done", status.StatusCategory.Key)
+
+	_, err = client.getIssueStatus(context.TODO(), "SUP-2")
+	assert.Error(t, err)
+}
+
+func TestJiraAuthorization(t *testing.T) {
+	assert.Equal(t, "Bearer pat", (&jiraClient{apiToken: "pat"}).authorization())
+	assert.Equal(t, "Basic YTpi", (&jiraClient{email: "a", apiToken: "b"}).authorization())
+}
+
+func TestRenderFieldTemplate(t *testing.T) {
+	values := map[string]string{"title": "How to deploy", "url": "https://example.com/questions/1"}
+	assert.Equal(t, "How to deploy (https://example.com/questions/1) {unknown}",
+		renderFieldTemplate("{title} ({url}) {unknown}", values))
+}
@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package leaderboard
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	leaderboardReputationLimit = 100
+	leaderboardTagLimit        = 10
+)
+
+// LeaderboardRepo leaderboard repository
+type LeaderboardRepo interface {
+	GetReputationScores(ctx context.Context, startTime time.Time, limit int) (scores []*entity.LeaderboardScore, err error)
+	GetTagAnswererScores(ctx context.Context, startTime time.Time) (scores []*entity.LeaderboardScore, err error)
+	SaveLeaderboard(ctx context.Context, period, board string, leaderboard []*entity.Leaderboard) (err error)
+	GetLeaderboard(ctx context.Context, period, board, tagID string) (leaderboard []*entity.Leaderboard, err error)
+	UpdateUserOptOut(ctx context.Context, userID string, optOut bool) (removed []*entity.Leaderboard, err error)
+}
+
+// LeaderboardService leaderboard service
+type LeaderboardService struct {
+	leaderboardRepo  LeaderboardRepo
+	tagCommonService *tagcommonser.TagCommonService
+	userCommon       *usercommon.UserCommon
+	data             *data.Data
+}
+
+// NewLeaderboardService new leaderboard service
+func NewLeaderboardService(
+	leaderboardRepo LeaderboardRepo,
+	tagCommonService *tagcommonser.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	data *data.Data,
+) *LeaderboardService {
+	return &LeaderboardService{
+		leaderboardRepo:  leaderboardRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+		data:             data,
+	}
+}
+
+// GetReputationLeaderboard get the users who earned the most reputation in the period
+func (ls *LeaderboardService) GetReputationLeaderboard(ctx context.Context, req *schema.GetReputationLeaderboardReq) (
+	resp *schema.LeaderboardResp, err error) {
+	if len(req.Period) == 0 {
+		req.Period = schema.LeaderboardPeriodWeek
+	}
+	return ls.getLeaderboard(ctx, req.Period, entity.LeaderboardBoardReputation, nil)
+}
+
+// GetTagLeaderboard get the users who answered the most questions of the tag in the period
+func (ls *LeaderboardService) GetTagLeaderboard(ctx context.Context, req *schema.GetTagLeaderboardReq) (
+	resp *schema.LeaderboardResp, err error) {
+	if len(req.Period) == 0 {
+		req.Period = schema.LeaderboardPeriodWeek
+	}
+	tagInfo, exist, err := ls.tagCommonService.GetTagBySlugName(ctx, req.SlugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TagNotFound)
+	}
+	return ls.getLeaderboard(ctx, req.Period, entity.LeaderboardBoardTagAnswerer, tagInfo)
+}
+
+// UpdateLeaderboardOptOut the user who opts out is removed from the leaderboards at once
+func (ls *LeaderboardService) UpdateLeaderboardOptOut(ctx context.Context, req *schema.UpdateLeaderboardOptOutReq) (
+	err error) {
+	removed, err := ls.leaderboardRepo.UpdateUserOptOut(ctx, req.UserID, req.OptOut)
+	if err != nil {
+		return err
+	}
+	// the cached leaderboards still have the user until they expire
+	removedKeys := make(map[string]bool)
+	for _, item := range removed {
+		cacheKey := leaderboardCacheKey(item.Board, item.Period, item.TagID)
+		if removedKeys[cacheKey] {
+			continue
+		}
+		removedKeys[cacheKey] = true
+		if err := ls.data.Cache.Del(ctx, cacheKey); err != nil {
+			log.Errorf("remove leaderboard cache failed: %s", err)
+		}
+	}
+	return nil
+}
+
+func (ls *LeaderboardService) getLeaderboard(ctx context.Context, period, board string, tagInfo *entity.Tag) (
+	resp *schema.LeaderboardResp, err error) {
+	// the reputation leaderboard is saved with tag id 0
+	tagID := "0"
+	if tagInfo != nil {
+		tagID = tagInfo.ID
+	}
+	cacheKey := leaderboardCacheKey(board, period, tagID)
+	if resp = ls.getFromCache(ctx, cacheKey); resp != nil {
+		return resp, nil
+	}
+
+	resp = &schema.LeaderboardResp{
+		Period: period,
+		List:   make([]*schema.LeaderboardItem, 0),
+	}
+	if tagInfo != nil {
+		resp.TagID, resp.SlugName = tagInfo.ID, tagInfo.SlugName
+	}
+	if start := periodStart(period, time.Now()); !start.IsZero() {
+		resp.PeriodStart = start.Unix()
+	}
+
+	leaderboard, err := ls.leaderboardRepo.GetLeaderboard(ctx, period, board, tagID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(leaderboard))
+	for _, item := range leaderboard {
+		userIDs = append(userIDs, item.UserID)
+	}
+	userInfoMapping, err := ls.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range leaderboard {
+		userInfo, ok := userInfoMapping[item.UserID]
+		if !ok {
+			continue
+		}
+		resp.List = append(resp.List, &schema.LeaderboardItem{
+			Position: item.Position,
+			Score:    item.Score,
+			UserInfo: userInfo,
+		})
+	}
+	ls.setCache(ctx, cacheKey, resp)
+	return resp, nil
+}
+
+// leaderboardCacheKey the cache key of the leaderboard, the reputation leaderboard has tag id 0
+func leaderboardCacheKey(board, period, tagID string) string {
+	return constant.LeaderboardCacheKeyPrefix + board + ":" + period + ":" + tagID
+}
+
+func (ls *LeaderboardService) getFromCache(ctx context.Context, cacheKey string) (resp *schema.LeaderboardResp) {
+	cacheData, exist, err := ls.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		log.Errorf("get leaderboard from cache failed: %s", err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+	resp = &schema.LeaderboardResp{}
+	if err = json.Unmarshal([]byte(cacheData), resp); err != nil {
+		return nil
+	}
+	return resp
+}
+
+func (ls *LeaderboardService) setCache(ctx context.Context, cacheKey string, resp *schema.LeaderboardResp) {
+	cacheData, _ := json.Marshal(resp)
+	err := ls.data.Cache.SetString(ctx, cacheKey, string(cacheData), constant.LeaderboardCacheTime)
+	if err != nil {
+		log.Errorf("set leaderboard cache failed: %s", err)
+	}
+}
+
+// RollupCron rebuild the leaderboards of all the periods
+func (ls *LeaderboardService) RollupCron(ctx context.Context) (err error) {
+	now := time.Now()
+	for _, period := range schema.LeaderboardPeriods {
+		if e := ls.rollupPeriod(ctx, period, periodStart(period, now)); e != nil {
+			log.Errorf("roll up leaderboard of %s failed: %s", period, e)
+			err = e
+		}
+	}
+	return err
+}
+
+func (ls *LeaderboardService) rollupPeriod(ctx context.Context, period string, startTime time.Time) (err error) {
+	scores, err := ls.leaderboardRepo.GetReputationScores(ctx, startTime, leaderboardReputationLimit)
+	if err != nil {
+		return err
+	}
+	leaderboard := buildLeaderboard(period, entity.LeaderboardBoardReputation, startTime, scores, leaderboardReputationLimit)
+	if err = ls.leaderboardRepo.SaveLeaderboard(ctx, period, entity.LeaderboardBoardReputation, leaderboard); err != nil {
+		return err
+	}
+
+	scores, err = ls.leaderboardRepo.GetTagAnswererScores(ctx, startTime)
+	if err != nil {
+		return err
+	}
+	leaderboard = buildLeaderboard(period, entity.LeaderboardBoardTagAnswerer, startTime, scores, leaderboardTagLimit)
+	return ls.leaderboardRepo.SaveLeaderboard(ctx, period, entity.LeaderboardBoardTagAnswerer, leaderboard)
+}
+
+// buildLeaderboard rank the users of each tag by score, the users with the same score share the position
+func buildLeaderboard(period, board string, startTime time.Time, scores []*entity.LeaderboardScore,
+	limit int) (leaderboard []*entity.Leaderboard) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].TagID != scores[j].TagID {
+			return scores[i].TagID < scores[j].TagID
+		}
+		return scores[i].Score > scores[j].Score
+	})
+	leaderboard = make([]*entity.Leaderboard, 0, len(scores))
+	count := 0
+	for i, score := range scores {
+		if score.Score <= 0 {
+			continue
+		}
+		if i == 0 || scores[i-1].TagID != score.TagID {
+			count = 0
+		}
+		if count >= limit {
+			continue
+		}
+		count++
+		position := count
+		if count > 1 {
+			prev := leaderboard[len(leaderboard)-1]
+			if prev.Score == score.Score {
+				position = prev.Position
+			}
+		}
+		tagID := score.TagID
+		if len(tagID) == 0 {
+			tagID = "0"
+		}
+		leaderboard = append(leaderboard, &entity.Leaderboard{
+			Period:      period,
+			Board:       board,
+			TagID:       tagID,
+			PeriodStart: startTime,
+			Position:    position,
+			UserID:      score.UserID,
+			Score:       score.Score,
+		})
+	}
+	return leaderboard
+}
+
+// periodStart the start of the calendar week, month or quarter, zero time for all time
+func periodStart(period string, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch period {
+	case schema.LeaderboardPeriodWeek:
+		// the week starts on Monday
+		return today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+	case schema.LeaderboardPeriodMonth:
+		return today.AddDate(0, 0, 1-today.Day())
+	case schema.LeaderboardPeriodQuarter:
+		month := time.Month((int(today.Month())-1)/3*3 + 1)
+		return time.Date(today.Year(), month, 1, 0, 0, 0, 0, today.Location())
+	default:
+		return time.Time{}
+	}
+}
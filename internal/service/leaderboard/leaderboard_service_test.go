@@ -0,0 +1,126 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package leaderboard
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/segmentfault/pacman/contrib/cache/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLeaderboardRepo struct {
+	LeaderboardRepo
+	rows []*entity.Leaderboard
+}
+
+func (r *testLeaderboardRepo) UpdateUserOptOut(_ context.Context, userID string, optOut bool) (
+	removed []*entity.Leaderboard, err error) {
+	if !optOut {
+		return nil, nil
+	}
+	kept := make([]*entity.Leaderboard, 0, len(r.rows))
+	for _, row := range r.rows {
+		if row.UserID == userID {
+			removed = append(removed, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	r.rows = kept
+	return removed, nil
+}
+
+func TestPeriodStart(t *testing.T) {
+	// Thursday
+	now := time.Date(2024, time.August, 15, 13, 20, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.August, 12, 0, 0, 0, 0, time.UTC), periodStart(schema.LeaderboardPeriodWeek, now))
+	assert.Equal(t, time.Date(2024, time.August, 1, 0, 0, 0, 0, time.UTC), periodStart(schema.LeaderboardPeriodMonth, now))
+	assert.Equal(t, time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC), periodStart(schema.LeaderboardPeriodQuarter, now))
+	assert.True(t, periodStart(schema.LeaderboardPeriodAll, now).IsZero())
+
+	// Sunday belongs to the week which starts on the Monday before
+	sunday := time.Date(2024, time.August, 18, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, time.August, 12, 0, 0, 0, 0, time.UTC), periodStart(schema.LeaderboardPeriodWeek, sunday))
+}
+
+func TestBuildLeaderboard(t *testing.T) {
+	scores := []*entity.LeaderboardScore{
+		{TagID: "1", UserID: "1", Score: 3},
+		{TagID: "2", UserID: "1", Score: 1},
+		{TagID: "1", UserID: "2", Score: 5},
+		{TagID: "1", UserID: "3", Score: 3},
+		{TagID: "1", UserID: "4", Score: 1},
+		{TagID: "2", UserID: "2", Score: 0},
+	}
+	leaderboard := buildLeaderboard(schema.LeaderboardPeriodAll, entity.LeaderboardBoardTagAnswerer,
+		time.Time{}, scores, 3)
+
+	type item struct {
+		TagID, UserID string
+		Position      int
+	}
+	items := make([]item, 0, len(leaderboard))
+	for _, row := range leaderboard {
+		items = append(items, item{TagID: row.TagID, UserID: row.UserID, Position: row.Position})
+	}
+	assert.Equal(t, []item{
+		{TagID: "1", UserID: "2", Position: 1},
+		{TagID: "1", UserID: "1", Position: 2},
+		{TagID: "1", UserID: "3", Position: 2},
+		{TagID: "2", UserID: "1", Position: 1},
+	}, items)
+}
+
+func TestUpdateLeaderboardOptOut(t *testing.T) {
+	repo := &testLeaderboardRepo{rows: []*entity.Leaderboard{
+		{Period: schema.LeaderboardPeriodWeek, Board: entity.LeaderboardBoardReputation, TagID: "0", UserID: "1"},
+		{Period: schema.LeaderboardPeriodAll, Board: entity.LeaderboardBoardReputation, TagID: "0", UserID: "1"},
+		{Period: schema.LeaderboardPeriodWeek, Board: entity.LeaderboardBoardTagAnswerer, TagID: "10", UserID: "1"},
+		{Period: schema.LeaderboardPeriodWeek, Board: entity.LeaderboardBoardTagAnswerer, TagID: "20", UserID: "2"},
+	}}
+	ls := NewLeaderboardService(repo, nil, nil, &data.Data{Cache: memory.NewCache()})
+	ctx := context.TODO()
+
+	cacheKeys := []string{
+		leaderboardCacheKey(entity.LeaderboardBoardReputation, schema.LeaderboardPeriodWeek, "0"),
+		leaderboardCacheKey(entity.LeaderboardBoardReputation, schema.LeaderboardPeriodAll, "0"),
+		leaderboardCacheKey(entity.LeaderboardBoardTagAnswerer, schema.LeaderboardPeriodWeek, "10"),
+		leaderboardCacheKey(entity.LeaderboardBoardTagAnswerer, schema.LeaderboardPeriodWeek, "20"),
+	}
+	for _, cacheKey := range cacheKeys {
+		assert.NoError(t, ls.data.Cache.SetString(ctx, cacheKey, "{}", constant.LeaderboardCacheTime))
+	}
+
+	err := ls.UpdateLeaderboardOptOut(ctx, &schema.UpdateLeaderboardOptOutReq{UserID: "1", OptOut: true})
+	assert.NoError(t, err)
+	for i, cacheKey := range cacheKeys {
+		_, exist, err := ls.data.Cache.GetString(ctx, cacheKey)
+		assert.NoError(t, err)
+		// only the leaderboard without the user is still cached
+		assert.Equal(t, i == 3, exist, cacheKey)
+	}
+	assert.Len(t, repo.rows, 1)
+}
@@ -0,0 +1,291 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package legal_hold
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/role"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// LegalHoldRepo legal hold repository
+type LegalHoldRepo interface {
+	AddLegalHold(ctx context.Context, hold *entity.LegalHold) (err error)
+	ReleaseLegalHold(ctx context.Context, hold *entity.LegalHold, userID, releaseReason string) (err error)
+	AddLegalHoldLog(ctx context.Context, holdLog *entity.LegalHoldLog) (err error)
+	GetLegalHold(ctx context.Context, id int) (hold *entity.LegalHold, exist bool, err error)
+	GetActiveLegalHolds(ctx context.Context, objectIDs []string) (holds []*entity.LegalHold, err error)
+	GetActiveHeldObjectIDs(ctx context.Context, objectType string) (objectIDs []string, err error)
+	GetLegalHoldPage(ctx context.Context, page, pageSize, status int) (
+		holds []*entity.LegalHold, total int64, err error)
+	GetLegalHoldLogPage(ctx context.Context, page, pageSize int, objectID string) (
+		holdLogs []*entity.LegalHoldLog, total int64, err error)
+}
+
+// LegalHoldService the users and the posts under the legal hold are frozen, they can not be deleted or anonymized
+// by the users, the admins, the retention jobs or the recycle bin purge. The content of the user under the hold is
+// frozen too. The only way to override the hold is that an administrator releases it with the reason, then the
+// content can be deleted as usual. Placing, releasing and every blocked process are recorded in the audit log.
+type LegalHoldService struct {
+	legalHoldRepo      LegalHoldRepo
+	objectInfoService  *object_info.ObjService
+	userCommon         *usercommon.UserCommon
+	userRoleRelService *role.UserRoleRelService
+}
+
+// NewLegalHoldService new legal hold service
+func NewLegalHoldService(
+	legalHoldRepo LegalHoldRepo,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+) *LegalHoldService {
+	return &LegalHoldService{
+		legalHoldRepo:      legalHoldRepo,
+		objectInfoService:  objectInfoService,
+		userCommon:         userCommon,
+		userRoleRelService: userRoleRelService,
+	}
+}
+
+// PlaceLegalHold place the legal hold on the user or the post, it is ignored if the object is already held
+func (ls *LegalHoldService) PlaceLegalHold(ctx context.Context, req *schema.PlaceLegalHoldReq) (err error) {
+	if err = ls.checkSuperAdmin(ctx, req.LoginUserID); err != nil {
+		return err
+	}
+	objectID := req.ObjectID
+	if req.ObjectType == constant.UserObjectType {
+		_, exist, err := ls.userCommon.GetUserBasicInfoByID(ctx, objectID)
+		if err != nil {
+			return err
+		}
+		if !exist {
+			return errors.BadRequest(reason.LegalHoldObjectNotFound)
+		}
+	} else {
+		objectID = uid.DeShortID(objectID)
+		objInfo, err := ls.objectInfoService.GetInfo(ctx, objectID)
+		if err != nil || objInfo == nil || objInfo.ObjectType != req.ObjectType {
+			return errors.BadRequest(reason.LegalHoldObjectNotFound)
+		}
+	}
+
+	holds, err := ls.legalHoldRepo.GetActiveLegalHolds(ctx, []string{objectID})
+	if err != nil {
+		return err
+	}
+	if len(holds) > 0 {
+		return nil
+	}
+	return ls.legalHoldRepo.AddLegalHold(ctx, &entity.LegalHold{
+		ObjectID:       objectID,
+		ObjectType:     req.ObjectType,
+		Reason:         req.Reason,
+		OperatorUserID: req.LoginUserID,
+		Status:         entity.LegalHoldStatusActive,
+	})
+}
+
+// ReleaseLegalHold release the legal hold, the reason is required and recorded in the audit log
+func (ls *LegalHoldService) ReleaseLegalHold(ctx context.Context, req *schema.ReleaseLegalHoldReq) (err error) {
+	if err = ls.checkSuperAdmin(ctx, req.LoginUserID); err != nil {
+		return err
+	}
+	hold, exist, err := ls.legalHoldRepo.GetLegalHold(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || hold.Status != entity.LegalHoldStatusActive {
+		return errors.BadRequest(reason.LegalHoldNotFound)
+	}
+	return ls.legalHoldRepo.ReleaseLegalHold(ctx, hold, req.LoginUserID, req.Reason)
+}
+
+// GetLegalHoldPage get the legal holds page
+func (ls *LegalHoldService) GetLegalHoldPage(ctx context.Context, req *schema.GetLegalHoldPageReq) (
+	pageModel *pager.PageModel, err error) {
+	status := entity.LegalHoldStatusActive
+	if req.Status == "released" {
+		status = entity.LegalHoldStatusReleased
+	}
+	holds, total, err := ls.legalHoldRepo.GetLegalHoldPage(ctx, req.Page, req.PageSize, status)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(holds)*2)
+	for _, hold := range holds {
+		userIDs = append(userIDs, hold.OperatorUserID, hold.ReleasedUserID)
+	}
+	userInfoMapping := ls.getUserInfoMapping(ctx, userIDs)
+
+	resp := make([]*schema.LegalHoldResp, 0, len(holds))
+	for _, hold := range holds {
+		item := &schema.LegalHoldResp{
+			ID:           hold.ID,
+			ObjectID:     hold.ObjectID,
+			ObjectType:   hold.ObjectType,
+			Reason:       hold.Reason,
+			Operator:     userInfoMapping[hold.OperatorUserID],
+			CreatedAt:    hold.CreatedAt.Unix(),
+			ReleasedUser: userInfoMapping[hold.ReleasedUserID],
+		}
+		if !hold.ReleasedAt.IsZero() {
+			item.ReleasedAt = hold.ReleasedAt.Unix()
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetLegalHoldLogPage get the audit log page of the legal holds
+func (ls *LegalHoldService) GetLegalHoldLogPage(ctx context.Context, req *schema.GetLegalHoldLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	objectID := req.ObjectID
+	if len(objectID) > 0 {
+		objectID = uid.DeShortID(objectID)
+	}
+	holdLogs, total, err := ls.legalHoldRepo.GetLegalHoldLogPage(ctx, req.Page, req.PageSize, objectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(holdLogs))
+	for _, holdLog := range holdLogs {
+		userIDs = append(userIDs, holdLog.OperatorUserID)
+	}
+	userInfoMapping := ls.getUserInfoMapping(ctx, userIDs)
+
+	resp := make([]*schema.LegalHoldLogResp, 0, len(holdLogs))
+	for _, holdLog := range holdLogs {
+		resp = append(resp, &schema.LegalHoldLogResp{
+			ID:          holdLog.ID,
+			LegalHoldID: holdLog.LegalHoldID,
+			ObjectID:    holdLog.ObjectID,
+			ObjectType:  holdLog.ObjectType,
+			Action:      holdLog.Action,
+			Process:     holdLog.Process,
+			Operator:    userInfoMapping[holdLog.OperatorUserID],
+			Reason:      holdLog.Reason,
+			CreatedAt:   holdLog.CreatedAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// CheckObjectDeletable the post can not be deleted if the post itself, its question or its author is held
+func (ls *LegalHoldService) CheckObjectDeletable(ctx context.Context, objectID, process, operatorUserID string) (
+	err error) {
+	objInfo, err := ls.objectInfoService.GetInfo(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	if objInfo == nil {
+		return nil
+	}
+	return ls.checkHolds(ctx, heldCandidates(objectID, objInfo), objectID, objInfo.ObjectType, process, operatorUserID)
+}
+
+// CheckUserDeletable the user can not be deleted or anonymized if the user is held
+func (ls *LegalHoldService) CheckUserDeletable(ctx context.Context, userID, process, operatorUserID string) (
+	err error) {
+	return ls.checkHolds(ctx, []string{userID}, userID, constant.UserObjectType, process, operatorUserID)
+}
+
+// GetHeldUserIDs get the ids of the users under the legal hold, their data is skipped by the retention jobs
+func (ls *LegalHoldService) GetHeldUserIDs(ctx context.Context) (userIDs []string, err error) {
+	return ls.legalHoldRepo.GetActiveHeldObjectIDs(ctx, constant.UserObjectType)
+}
+
+// checkHolds refuse the process and record it in the audit log if any of the candidates is held
+func (ls *LegalHoldService) checkHolds(ctx context.Context, candidates []string, objectID, objectType,
+	process, operatorUserID string) (err error) {
+	holds, err := ls.legalHoldRepo.GetActiveLegalHolds(ctx, candidates)
+	if err != nil {
+		return err
+	}
+	if len(holds) == 0 {
+		return nil
+	}
+	err = ls.legalHoldRepo.AddLegalHoldLog(ctx, &entity.LegalHoldLog{
+		LegalHoldID:    holds[0].ID,
+		ObjectID:       objectID,
+		ObjectType:     objectType,
+		Action:         entity.LegalHoldActionBlock,
+		Process:        process,
+		OperatorUserID: operatorUserID,
+	})
+	if err != nil {
+		log.Errorf("add legal hold log of %s failed: %v", objectID, err)
+	}
+	return errors.Forbidden(reason.LegalHoldContentFrozen)
+}
+
+// checkSuperAdmin only the administrators can place or release the legal holds, the moderators can not
+func (ls *LegalHoldService) checkSuperAdmin(ctx context.Context, userID string) (err error) {
+	roleID, err := ls.userRoleRelService.GetUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if roleID != role.RoleAdminID {
+		return errors.Forbidden(reason.LegalHoldSuperAdminOnly)
+	}
+	return nil
+}
+
+// getUserInfoMapping get the basic info of the users, the system user 0 is ignored
+func (ls *LegalHoldService) getUserInfoMapping(ctx context.Context, userIDs []string) (
+	userInfoMapping map[string]*schema.UserBasicInfo) {
+	ids := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if len(userID) > 0 && userID != "0" {
+			ids = append(ids, userID)
+		}
+	}
+	userInfoMapping = make(map[string]*schema.UserBasicInfo)
+	if len(ids) == 0 {
+		return userInfoMapping
+	}
+	userInfoMapping, err := ls.userCommon.BatchUserBasicInfoByID(ctx, ids)
+	if err != nil {
+		log.Errorf("get user basic info failed: %v", err)
+		return make(map[string]*schema.UserBasicInfo)
+	}
+	return userInfoMapping
+}
+
+// heldCandidates the ids whose legal hold freezes the post: the post, its question and its author
+func heldCandidates(objectID string, objInfo *schema.SimpleObjectInfo) (candidates []string) {
+	candidates = []string{objectID}
+	for _, id := range []string{uid.DeShortID(objInfo.QuestionID), objInfo.ObjectCreatorUserID} {
+		if len(id) > 0 && id != "0" && id != objectID {
+			candidates = append(candidates, id)
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package legal_hold
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeldCandidates(t *testing.T) {
+	candidates := heldCandidates("10020000000000001", &schema.SimpleObjectInfo{
+		QuestionID:          "10010000000000001",
+		ObjectCreatorUserID: "1",
+	})
+	assert.Equal(t, []string{"10020000000000001", "10010000000000001", "1"}, candidates)
+
+	candidates = heldCandidates("10010000000000001", &schema.SimpleObjectInfo{
+		QuestionID:          "10010000000000001",
+		ObjectCreatorUserID: "0",
+	})
+	assert.Equal(t, []string{"10010000000000001"}, candidates)
+}
@@ -0,0 +1,287 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package link_preview
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/httpclient"
+	"github.com/segmentfault/pacman/log"
+	"golang.org/x/net/html"
+)
+
+const (
+	// linkPreviewLimit the max number of the link previews of each post
+	linkPreviewLimit = 3
+	// linkPreviewCacheTime the stored preview is fetched again after this time
+	linkPreviewCacheTime = 7 * 24 * time.Hour
+	// linkPreviewRetryTime the failed preview is fetched again after this time
+	linkPreviewRetryTime = 24 * time.Hour
+	linkPreviewTimeout   = 5 * time.Second
+	linkPreviewMaxBody   = 1 << 20
+	linkPreviewRedirects = 3
+	// linkPreviewFetchers the max number of the links fetched at the same time
+	linkPreviewFetchers = 5
+
+	linkPreviewTitleMaxLen       = 255
+	linkPreviewDescriptionMaxLen = 500
+)
+
+// LinkPreviewRepo link preview repository
+type LinkPreviewRepo interface {
+	GetLinkPreviewsByHashes(ctx context.Context, urlHashes []string) (previews []*entity.LinkPreview, err error)
+	SaveLinkPreview(ctx context.Context, preview *entity.LinkPreview) (err error)
+}
+
+// LinkPreviewService unfurl the external links in the posts. The previews are fetched in the background
+// when the post is viewed and stored, so the first view of the post may not contain the previews.
+type LinkPreviewService struct {
+	linkPreviewRepo LinkPreviewRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	httpClient      *http.Client
+	fetching        sync.Map
+	fetchers        chan struct{}
+}
+
+// NewLinkPreviewService new link preview service
+func NewLinkPreviewService(
+	linkPreviewRepo LinkPreviewRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *LinkPreviewService {
+	return &LinkPreviewService{
+		linkPreviewRepo: linkPreviewRepo,
+		siteInfoService: siteInfoService,
+		httpClient:      httpclient.NewPublicClient(linkPreviewTimeout, linkPreviewRedirects),
+		fetchers:        make(chan struct{}, linkPreviewFetchers),
+	}
+}
+
+// GetLinkPreviews get the previews of the external links in the html
+func (ls *LinkPreviewService) GetLinkPreviews(ctx context.Context, content string) []*schema.LinkPreviewResp {
+	return ls.BatchGetLinkPreviews(ctx, []string{content})[0]
+}
+
+// BatchGetLinkPreviews get the previews of the external links in each html, the result is in the same order
+func (ls *LinkPreviewService) BatchGetLinkPreviews(ctx context.Context, contents []string) [][]*schema.LinkPreviewResp {
+	resp := make([][]*schema.LinkPreviewResp, len(contents))
+	for i := range resp {
+		resp[i] = make([]*schema.LinkPreviewResp, 0)
+	}
+	siteHost := ""
+	siteGeneral, err := ls.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+	} else if u, err := url.Parse(siteGeneral.SiteUrl); err == nil {
+		siteHost = u.Hostname()
+	}
+
+	linksList := make([][]string, len(contents))
+	hashes := make([]string, 0)
+	for i, content := range contents {
+		linksList[i] = htmltext.ExternalLinks(content, siteHost, linkPreviewLimit)
+		for _, link := range linksList[i] {
+			hashes = append(hashes, urlHash(link))
+		}
+	}
+	if len(hashes) == 0 {
+		return resp
+	}
+	previews, err := ls.linkPreviewRepo.GetLinkPreviewsByHashes(ctx, hashes)
+	if err != nil {
+		log.Error(err)
+		return resp
+	}
+	previewMapping := make(map[string]*entity.LinkPreview, len(previews))
+	for _, preview := range previews {
+		previewMapping[preview.URLHash] = preview
+	}
+
+	for i, links := range linksList {
+		for _, link := range links {
+			preview, ok := previewMapping[urlHash(link)]
+			if !ok || isStale(preview) {
+				ls.fetchInBackground(link)
+			}
+			if ok && preview.Status == entity.LinkPreviewStatusAvailable {
+				resp[i] = append(resp[i], &schema.LinkPreviewResp{
+					URL:         link,
+					Title:       preview.Title,
+					Description: preview.Description,
+					Image:       preview.ImageURL,
+					SiteName:    preview.SiteName,
+				})
+			}
+		}
+	}
+	return resp
+}
+
+// fetchInBackground fetch and store the preview of the link, the link is skipped when it is being fetched
+// or too many links are being fetched, it will be fetched again when the post is viewed next time
+func (ls *LinkPreviewService) fetchInBackground(link string) {
+	if _, loaded := ls.fetching.LoadOrStore(link, true); loaded {
+		return
+	}
+	select {
+	case ls.fetchers <- struct{}{}:
+	default:
+		ls.fetching.Delete(link)
+		return
+	}
+	go func() {
+		defer func() {
+			<-ls.fetchers
+			ls.fetching.Delete(link)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*linkPreviewTimeout)
+		defer cancel()
+		preview := ls.fetch(ctx, link)
+		if err := ls.linkPreviewRepo.SaveLinkPreview(ctx, preview); err != nil {
+			log.Error(err)
+		}
+	}()
+}
+
+// fetch request the link and parse the preview from the html, the failed preview is returned if
+// the link can not be requested or it is not a html page
+func (ls *LinkPreviewService) fetch(ctx context.Context, link string) (preview *entity.LinkPreview) {
+	failed := &entity.LinkPreview{URLHash: urlHash(link), URL: link, Status: entity.LinkPreviewStatusFailed}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		log.Debugf("create link preview request failed: %s", err)
+		return failed
+	}
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set("Accept", "text/html")
+	resp, err := ls.httpClient.Do(req)
+	if err != nil {
+		log.Debugf("request link preview failed: %s", err)
+		return failed
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(resp.Header.Get("Content-Type"), "text/html") {
+		return failed
+	}
+	preview = parseLinkPreview(resp.Request.URL, io.LimitReader(resp.Body, linkPreviewMaxBody))
+	if len(preview.Title) == 0 {
+		return failed
+	}
+	preview.URLHash = urlHash(link)
+	preview.URL = link
+	preview.Status = entity.LinkPreviewStatusAvailable
+	return preview
+}
+
+// parseLinkPreview parse the open graph and the basic meta tags in the head of the page
+func parseLinkPreview(pageURL *url.URL, body io.Reader) (preview *entity.LinkPreview) {
+	meta := make(map[string]string)
+	title := ""
+	tokenizer := html.NewTokenizer(body)
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			continue
+		}
+		token := tokenizer.Token()
+		if token.Data == "body" {
+			break
+		}
+		if token.Data == "title" && len(title) == 0 && tokenizer.Next() == html.TextToken {
+			title = strings.TrimSpace(string(tokenizer.Text()))
+			continue
+		}
+		if token.Data != "meta" {
+			continue
+		}
+		key, content := "", ""
+		for _, attr := range token.Attr {
+			switch attr.Key {
+			case "property", "name":
+				key = strings.ToLower(attr.Val)
+			case "content":
+				content = strings.TrimSpace(attr.Val)
+			}
+		}
+		if len(key) > 0 && len(content) > 0 && len(meta[key]) == 0 {
+			meta[key] = content
+		}
+	}
+
+	preview = &entity.LinkPreview{
+		Title:       firstNotEmpty(meta["og:title"], meta["twitter:title"], title),
+		Description: firstNotEmpty(meta["og:description"], meta["description"], meta["twitter:description"]),
+		SiteName:    firstNotEmpty(meta["og:site_name"], pageURL.Hostname()),
+	}
+	preview.Title = truncate(preview.Title, linkPreviewTitleMaxLen)
+	preview.Description = truncate(preview.Description, linkPreviewDescriptionMaxLen)
+	preview.SiteName = truncate(preview.SiteName, linkPreviewTitleMaxLen)
+	if image := firstNotEmpty(meta["og:image"], meta["twitter:image"]); len(image) > 0 {
+		if imageURL, err := pageURL.Parse(image); err == nil && checker.IsURL(imageURL.String()) {
+			preview.ImageURL = imageURL.String()
+		}
+	}
+	return preview
+}
+
+func isStale(preview *entity.LinkPreview) bool {
+	if preview.Status == entity.LinkPreviewStatusFailed {
+		return time.Since(preview.UpdatedAt) > linkPreviewRetryTime
+	}
+	return time.Since(preview.UpdatedAt) > linkPreviewCacheTime
+}
+
+func urlHash(link string) string {
+	hash := sha256.Sum256([]byte(link))
+	return hex.EncodeToString(hash[:])
+}
+
+func firstNotEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
+
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen])
+}
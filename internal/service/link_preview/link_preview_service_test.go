@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package link_preview
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkPreview(t *testing.T) {
+	pageURL, _ := url.Parse("https://example.com/posts/1")
+	body := `<html><head><title>Page title</title>
+<meta property="og:title" content="OG title">
+<meta name="description" content="Meta description">
+<meta property="og:image" content="/images/cover.png">
+</head><body><meta property="og:site_name" content="ignored"></body></html>`
+	preview := parseLinkPreview(pageURL, strings.NewReader(body))
+	assert.Equal(t, "OG title", preview.Title)
+	assert.Equal(t, "Meta description", preview.Description)
+	assert.Equal(t, "https://example.com/images/cover.png", preview.ImageURL)
+	assert.Equal(t, "example.com", preview.SiteName)
+
+	preview = parseLinkPreview(pageURL, strings.NewReader(`<title> Only title </title>
+<meta property="og:image" content="javascript:alert(1)">`))
+	assert.Equal(t, "Only title", preview.Title)
+	assert.Empty(t, preview.ImageURL)
+}
+
+func TestFetchPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<title>Internal</title>`))
+	}))
+	defer server.Close()
+
+	ls := &LinkPreviewService{httpClient: httpclient.NewPublicClient(linkPreviewTimeout, linkPreviewRedirects)}
+	preview := ls.fetch(context.TODO(), server.URL)
+	assert.Equal(t, entity.LinkPreviewStatusFailed, preview.Status)
+	assert.Empty(t, preview.Title)
+}
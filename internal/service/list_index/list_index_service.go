@@ -0,0 +1,130 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package list_index
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// refreshBatchSize the max number of the lists built in one run of the job
+	refreshBatchSize = 100
+	// staleDuration the lists are rebuilt after it even without changes, for the time windows of the sort orders
+	staleDuration = 10 * time.Minute
+	// unreadDuration the tag lists which are not read in it are removed
+	unreadDuration = 7 * 24 * time.Hour
+)
+
+// ListIndexRepo list index repository
+type ListIndexRepo interface {
+	MarkQuestionListsDirty(ctx context.Context, questionID string) (err error)
+	GetDuePartitions(ctx context.Context, staleBefore time.Time, limit int) (
+		partitions []*entity.QuestionListPartition, err error)
+	RemoveUnreadPartitions(ctx context.Context, before time.Time) (count int64, err error)
+}
+
+// ListIndexService keep the precomputed question lists and the tag summary up to date. The changes of the content
+// mark them dirty by the events, and the refresh job rebuilds the dirty ones.
+type ListIndexService struct {
+	// tagSummaryDirty is 1 when the tag summary should be rebuilt, it is rebuilt once after starting
+	tagSummaryDirty int32
+	listIndexRepo   ListIndexRepo
+	questionRepo    questioncommon.QuestionRepo
+	tagCommonRepo   tagcommon.TagCommonRepo
+}
+
+// NewListIndexService new list index service
+func NewListIndexService(
+	listIndexRepo ListIndexRepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonRepo tagcommon.TagCommonRepo,
+	eventQueueService event_queue.EventQueueService,
+) *ListIndexService {
+	ls := &ListIndexService{
+		tagSummaryDirty: 1,
+		listIndexRepo:   listIndexRepo,
+		questionRepo:    questionRepo,
+		tagCommonRepo:   tagCommonRepo,
+	}
+	eventQueueService.RegisterHandler(ls.HandleEvent)
+	return ls
+}
+
+// HandleEvent mark the lists which contain the changed question dirty
+func (ls *ListIndexService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	eventType := string(msg.EventType)
+	var questionID string
+	switch {
+	case strings.HasPrefix(eventType, "question."):
+		questionID = msg.ObjectID
+		atomic.StoreInt32(&ls.tagSummaryDirty, 1)
+	case strings.HasPrefix(eventType, "answer."):
+		questionID = msg.QuestionID
+	case strings.HasPrefix(eventType, "tag."):
+		atomic.StoreInt32(&ls.tagSummaryDirty, 1)
+	}
+	if len(questionID) == 0 {
+		return nil
+	}
+	return ls.listIndexRepo.MarkQuestionListsDirty(ctx, uid.DeShortID(questionID))
+}
+
+// RefreshCron rebuild the dirty and the stale question lists and the tag summary
+func (ls *ListIndexService) RefreshCron(ctx context.Context) (err error) {
+	partitions, err := ls.listIndexRepo.GetDuePartitions(ctx, time.Now().Add(-staleDuration), refreshBatchSize)
+	if err != nil {
+		return err
+	}
+	var failed error
+	for _, partition := range partitions {
+		if err := ls.questionRepo.RefreshQuestionListIndex(ctx, partition); err != nil {
+			log.Errorf("refresh question list %d failed: %s", partition.ID, err)
+			failed = err
+		}
+	}
+
+	if atomic.CompareAndSwapInt32(&ls.tagSummaryDirty, 1, 0) {
+		if err := ls.tagCommonRepo.RefreshTagSummary(ctx); err != nil {
+			atomic.StoreInt32(&ls.tagSummaryDirty, 1)
+			log.Errorf("refresh tag summary failed: %s", err)
+			failed = err
+		}
+	}
+
+	count, err := ls.listIndexRepo.RemoveUnreadPartitions(ctx, time.Now().Add(-unreadDuration))
+	if err != nil {
+		log.Errorf("remove unread question lists failed: %s", err)
+		failed = err
+	} else if count > 0 {
+		log.Infof("removed %d unread question lists", count)
+	}
+	return failed
+}
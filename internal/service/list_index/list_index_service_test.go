@@ -0,0 +1,102 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package list_index
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeListIndexRepo struct {
+	dirtyQuestions []string
+	partitions     []*entity.QuestionListPartition
+}
+
+func (f *fakeListIndexRepo) MarkQuestionListsDirty(_ context.Context, questionID string) error {
+	f.dirtyQuestions = append(f.dirtyQuestions, questionID)
+	return nil
+}
+
+func (f *fakeListIndexRepo) GetDuePartitions(context.Context, time.Time, int) ([]*entity.QuestionListPartition, error) {
+	return f.partitions, nil
+}
+
+func (f *fakeListIndexRepo) RemoveUnreadPartitions(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+type fakeTagCommonRepo struct {
+	tagcommon.TagCommonRepo
+	refreshed int
+}
+
+func (f *fakeTagCommonRepo) RefreshTagSummary(context.Context) error {
+	f.refreshed++
+	return nil
+}
+
+func TestListIndexService(t *testing.T) {
+	ctx := context.Background()
+	listIndexRepo := &fakeListIndexRepo{
+		partitions: []*entity.QuestionListPartition{{ID: 1}, {ID: 2}},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	var refreshed []int
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().RefreshQuestionListIndex(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, partition *entity.QuestionListPartition) error {
+			refreshed = append(refreshed, partition.ID)
+			return nil
+		})
+	tagCommonRepo := &fakeTagCommonRepo{}
+	ls := &ListIndexService{
+		tagSummaryDirty: 1,
+		listIndexRepo:   listIndexRepo,
+		questionRepo:    questionRepo,
+		tagCommonRepo:   tagCommonRepo,
+	}
+
+	// the tag summary is built once after starting
+	ls.RefreshCron(ctx)
+	ls.RefreshCron(ctx)
+	assert.Equal(t, []int{1, 2, 1, 2}, refreshed)
+	assert.Equal(t, 1, tagCommonRepo.refreshed)
+
+	assert.NoError(t, ls.HandleEvent(ctx, &schema.EventMsg{EventType: constant.EventAnswerCreate,
+		ObjectID: "10020000000000001", QuestionID: "10010000000000001"}))
+	assert.NoError(t, ls.HandleEvent(ctx, &schema.EventMsg{EventType: constant.EventUserUpdate, ObjectID: "1"}))
+	assert.Equal(t, []string{"10010000000000001"}, listIndexRepo.dirtyQuestions)
+	ls.RefreshCron(ctx)
+	assert.Equal(t, 1, tagCommonRepo.refreshed)
+
+	assert.NoError(t, ls.HandleEvent(ctx, &schema.EventMsg{EventType: constant.EventTagUpdate, ObjectID: "1"}))
+	ls.RefreshCron(ctx)
+	assert.Equal(t, 2, tagCommonRepo.refreshed)
+}
@@ -0,0 +1,332 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package login_protection
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// loginCaptchaFailures the captcha is required for the account after these failed attempts
+	loginCaptchaFailures = 3
+	// loginIPThresholdFactor the ip address is shared by many users, so it is locked after more failed attempts
+	loginIPThresholdFactor = 4
+	// loginLockoutMaxMinutes the lockout is not longer than one day however many attempts failed
+	loginLockoutMaxMinutes = 24 * 60
+	// loginFailureDelayStep and loginFailureDelayMax the response of each further failed attempt is delayed longer
+	loginFailureDelayStep = 500 * time.Millisecond
+	loginFailureDelayMax  = 3 * time.Second
+)
+
+// LoginProtectionRepo login protection repository
+type LoginProtectionRepo interface {
+	AddLoginFailure(ctx context.Context, failure *entity.LoginFailure) (err error)
+	GetLoginFailurePage(ctx context.Context, page, pageSize int, email, ip string) (
+		failures []*entity.LoginFailure, total int64, err error)
+}
+
+// loginAttemptState the failed attempts of the email or the ip address, it is cached and expires
+// after one day without any failed attempt
+type loginAttemptState struct {
+	Failures    int   `json:"failures"`
+	LockedUntil int64 `json:"locked_until"`
+}
+
+// LoginProtectionService protect the password sign-in from the brute-force attacks. The failed attempts
+// are counted by the email and the ip address, the response is delayed progressively, the captcha is
+// required and then the sign-in is locked for a doubling time.
+type LoginProtectionService struct {
+	data                *data.Data
+	loginProtectionRepo LoginProtectionRepo
+	userCommon          *usercommon.UserCommon
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	emailService        *export.EmailService
+}
+
+// NewLoginProtectionService new login protection service
+func NewLoginProtectionService(
+	data *data.Data,
+	loginProtectionRepo LoginProtectionRepo,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	emailService *export.EmailService,
+) *LoginProtectionService {
+	return &LoginProtectionService{
+		data:                data,
+		loginProtectionRepo: loginProtectionRepo,
+		userCommon:          userCommon,
+		siteInfoService:     siteInfoService,
+		emailService:        emailService,
+	}
+}
+
+// CheckLocked check whether the email or the ip address of the attempt is locked,
+// the time when the sign-in can be tried again is returned with the error
+func (ls *LoginProtectionService) CheckLocked(ctx context.Context, attempt *schema.LoginAttempt) (
+	resp *schema.LoginLockedResp, err error) {
+	now := time.Now().Unix()
+	lockedUntil := int64(0)
+	for _, cacheKey := range []string{emailCacheKey(attempt.Email), ipCacheKey(attempt.IP)} {
+		if state := ls.getState(ctx, cacheKey); state.LockedUntil > now && state.LockedUntil > lockedUntil {
+			lockedUntil = state.LockedUntil
+		}
+	}
+	if lockedUntil == 0 {
+		return nil, nil
+	}
+	return &schema.LoginLockedResp{LockedUntil: lockedUntil}, errors.Forbidden(reason.LoginTemporarilyLocked)
+}
+
+// NeedCaptcha the captcha is required for the account which has too many failed attempts,
+// no matter which ip address the attempt is from
+func (ls *LoginProtectionService) NeedCaptcha(ctx context.Context, email string) bool {
+	if !plugin.CaptchaEnabled() {
+		return false
+	}
+	threshold, _ := ls.getPolicy(ctx)
+	return ls.getState(ctx, emailCacheKey(email)).Failures >= captchaFailures(threshold)
+}
+
+// LoginFailed count and record the failed attempt, the account and the ip address are locked if
+// there are too many failed attempts, the user is notified when the account is locked at the first time
+func (ls *LoginProtectionService) LoginFailed(ctx context.Context, attempt *schema.LoginAttempt) {
+	threshold, baseMinutes := ls.getPolicy(ctx)
+	now := time.Now()
+
+	emailState := ls.getState(ctx, emailCacheKey(attempt.Email))
+	emailState.Failures++
+	emailLocked := lockoutMinutes(emailState.Failures, threshold, baseMinutes)
+	if emailLocked > 0 {
+		emailState.LockedUntil = now.Add(time.Duration(emailLocked) * time.Minute).Unix()
+	}
+	ls.setState(ctx, emailCacheKey(attempt.Email), emailState)
+
+	ipState := ls.getState(ctx, ipCacheKey(attempt.IP))
+	ipState.Failures++
+	ipLocked := lockoutMinutes(ipState.Failures, threshold*loginIPThresholdFactor, baseMinutes)
+	if ipLocked > 0 {
+		ipState.LockedUntil = now.Add(time.Duration(ipLocked) * time.Minute).Unix()
+	}
+	ls.setState(ctx, ipCacheKey(attempt.IP), ipState)
+
+	failure := &entity.LoginFailure{
+		UserID:        "0",
+		Email:         truncate(attempt.Email, 100),
+		IP:            attempt.IP,
+		UserAgent:     truncate(attempt.UserAgent, 512),
+		LockedMinutes: emailLocked,
+	}
+	if ipLocked > failure.LockedMinutes {
+		failure.LockedMinutes = ipLocked
+	}
+	userInfo, exist, err := ls.userCommon.GetByEmail(ctx, attempt.Email)
+	if err != nil {
+		log.Error(err)
+	}
+	if exist {
+		failure.UserID = userInfo.ID
+	}
+	if err = ls.loginProtectionRepo.AddLoginFailure(ctx, failure); err != nil {
+		log.Error(err)
+	}
+
+	if exist && userInfo.Status != entity.UserStatusDeleted && emailLocked > 0 && emailState.Failures == threshold {
+		ls.notify(ctx, userInfo, emailState.Failures, attempt.IP, false)
+	}
+	delay(ctx, emailState.Failures)
+}
+
+// LoginSucceeded reset the failed attempts of the account, the user is notified if the account
+// is signed in after too many failed attempts
+func (ls *LoginProtectionService) LoginSucceeded(ctx context.Context, attempt *schema.LoginAttempt) {
+	cacheKey := emailCacheKey(attempt.Email)
+	state := ls.getState(ctx, cacheKey)
+	if state.Failures == 0 {
+		return
+	}
+	threshold, _ := ls.getPolicy(ctx)
+	if state.Failures >= captchaFailures(threshold) {
+		userInfo, exist, err := ls.userCommon.GetByEmail(ctx, attempt.Email)
+		if err != nil {
+			log.Error(err)
+		}
+		if exist {
+			ls.notify(ctx, userInfo, state.Failures, attempt.IP, true)
+		}
+	}
+	if err := ls.data.Cache.Del(ctx, cacheKey); err != nil {
+		log.Error(err)
+	}
+}
+
+// GetLoginFailurePage get the recent failed sign-in attempts
+func (ls *LoginProtectionService) GetLoginFailurePage(ctx context.Context, req *schema.GetLoginFailurePageReq) (
+	pageModel *pager.PageModel, err error) {
+	failures, total, err := ls.loginProtectionRepo.GetLoginFailurePage(ctx, req.Page, req.PageSize,
+		strings.TrimSpace(req.Email), strings.TrimSpace(req.IP))
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0)
+	for _, failure := range failures {
+		if failure.UserID != "0" {
+			userIDs = append(userIDs, failure.UserID)
+		}
+	}
+	userInfoMapping, err := ls.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetLoginFailureResp, 0, len(failures))
+	for _, failure := range failures {
+		list = append(list, &schema.GetLoginFailureResp{
+			ID:            failure.ID,
+			CreatedAt:     failure.CreatedAt.Unix(),
+			Email:         failure.Email,
+			IP:            failure.IP,
+			UserAgent:     failure.UserAgent,
+			LockedMinutes: failure.LockedMinutes,
+			UserInfo:      userInfoMapping[failure.UserID],
+		})
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+func (ls *LoginProtectionService) notify(ctx context.Context, userInfo *entity.User, failures int, ip string,
+	signedIn bool) {
+	ctx = usercommon.WithUserLocale(ctx, userInfo)
+	title, body, err := ls.emailService.SuspiciousLoginTemplate(ctx, failures, ip, time.Now(), signedIn)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	go ls.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// getPolicy get the lockout threshold and the minutes of the first lockout
+func (ls *LoginProtectionService) getPolicy(ctx context.Context) (threshold, baseMinutes int) {
+	threshold, baseMinutes = schema.DefaultLoginLockoutThreshold, schema.DefaultLoginLockoutMinutes
+	siteLogin, err := ls.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if siteLogin.LoginLockoutThreshold > 0 {
+		threshold = siteLogin.LoginLockoutThreshold
+	}
+	if siteLogin.LoginLockoutMinutes > 0 {
+		baseMinutes = siteLogin.LoginLockoutMinutes
+	}
+	return
+}
+
+func (ls *LoginProtectionService) getState(ctx context.Context, cacheKey string) (state *loginAttemptState) {
+	state = &loginAttemptState{}
+	cacheData, exist, err := ls.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		log.Errorf("get login attempts from cache failed: %s", err)
+		return state
+	}
+	if exist {
+		_ = json.Unmarshal([]byte(cacheData), state)
+	}
+	return state
+}
+
+func (ls *LoginProtectionService) setState(ctx context.Context, cacheKey string, state *loginAttemptState) {
+	cacheData, _ := json.Marshal(state)
+	err := ls.data.Cache.SetString(ctx, cacheKey, string(cacheData), constant.LoginFailureCacheTime)
+	if err != nil {
+		log.Errorf("set login attempts cache failed: %s", err)
+	}
+}
+
+// lockoutMinutes the minutes the sign-in is locked after the failed attempts, the first lockout
+// lasts the base minutes and each further failed attempt doubles it, 0 means not locked
+func lockoutMinutes(failures, threshold, baseMinutes int) int {
+	if failures < threshold {
+		return 0
+	}
+	minutes := baseMinutes
+	for i := threshold; i < failures && minutes < loginLockoutMaxMinutes; i++ {
+		minutes *= 2
+	}
+	if minutes > loginLockoutMaxMinutes {
+		minutes = loginLockoutMaxMinutes
+	}
+	return minutes
+}
+
+// captchaFailures the captcha is required before the account is locked
+func captchaFailures(threshold int) int {
+	if threshold < loginCaptchaFailures {
+		return threshold
+	}
+	return loginCaptchaFailures
+}
+
+// delay slow down the response of the failed attempt, the first failed attempt is not delayed
+func delay(ctx context.Context, failures int) {
+	duration := time.Duration(failures-1) * loginFailureDelayStep
+	if duration > loginFailureDelayMax {
+		duration = loginFailureDelayMax
+	}
+	if duration <= 0 {
+		return
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func emailCacheKey(email string) string {
+	return constant.LoginFailureCacheKeyPrefix + "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipCacheKey(ip string) string {
+	return constant.LoginFailureCacheKeyPrefix + "ip:" + ip
+}
+
+func truncate(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen])
+}
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package login_protection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockoutMinutes(t *testing.T) {
+	assert.Equal(t, 0, lockoutMinutes(4, 5, 5))
+	assert.Equal(t, 5, lockoutMinutes(5, 5, 5))
+	assert.Equal(t, 10, lockoutMinutes(6, 5, 5))
+	assert.Equal(t, 40, lockoutMinutes(8, 5, 5))
+	assert.Equal(t, loginLockoutMaxMinutes, lockoutMinutes(100, 5, 5))
+}
+
+func TestCaptchaFailures(t *testing.T) {
+	assert.Equal(t, loginCaptchaFailures, captchaFailures(5))
+	assert.Equal(t, 2, captchaFailures(2))
+}
@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+)
+
+// MaintenanceService the read-only mode of the site
+type MaintenanceService struct {
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewMaintenanceService new maintenance service
+func NewMaintenanceService(
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *MaintenanceService {
+	return &MaintenanceService{
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetMaintenance get the read-only mode in use, the one in the config file takes precedence over the admin setting
+func (ms *MaintenanceService) GetMaintenance(ctx context.Context) (resp *schema.SiteMaintenanceResp, err error) {
+	setting := &schema.SiteMaintenanceReq{}
+	if err = ms.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeMaintenance, setting); err != nil {
+		return nil, err
+	}
+	return mergeMaintenance(setting, reload.Current().Maintenance), nil
+}
+
+// UpdateMaintenance turn the read-only mode on or off
+func (ms *MaintenanceService) UpdateMaintenance(ctx context.Context, req *schema.SiteMaintenanceReq) (err error) {
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeMaintenance,
+		Content: string(content),
+	}
+	return ms.siteInfoRepo.SaveByType(ctx, constant.SiteTypeMaintenance, data)
+}
+
+// IsReadOnly check whether the site is read-only, the config file is checked first, so the site can be switched
+// to the read-only mode while the database is unavailable
+func (ms *MaintenanceService) IsReadOnly(ctx context.Context) (readOnly bool, message string, err error) {
+	if forced := reload.Current().Maintenance; forced != nil && forced.Enabled {
+		return true, forced.Message, nil
+	}
+	resp, err := ms.GetMaintenance(ctx)
+	if err != nil {
+		return false, "", err
+	}
+	return resp.Enabled, resp.Message, nil
+}
+
+func mergeMaintenance(setting *schema.SiteMaintenanceReq, forced *reload.Maintenance) *schema.SiteMaintenanceResp {
+	resp := &schema.SiteMaintenanceResp{Enabled: setting.Enabled, Message: setting.Message}
+	if forced != nil && forced.Enabled {
+		resp.Enabled, resp.ForcedByConfig = true, true
+		if len(forced.Message) > 0 {
+			resp.Message = forced.Message
+		}
+	}
+	return resp
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package maintenance
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeMaintenance(t *testing.T) {
+	setting := &schema.SiteMaintenanceReq{Enabled: false, Message: "Upgrading the database"}
+
+	resp := mergeMaintenance(setting, nil)
+	assert.False(t, resp.Enabled)
+	assert.False(t, resp.ForcedByConfig)
+
+	resp = mergeMaintenance(setting, &reload.Maintenance{Enabled: true})
+	assert.True(t, resp.Enabled)
+	assert.True(t, resp.ForcedByConfig)
+	assert.Equal(t, "Upgrading the database", resp.Message)
+
+	resp = mergeMaintenance(setting, &reload.Maintenance{Enabled: true, Message: "Moving to the new server"})
+	assert.Equal(t, "Moving to the new server", resp.Message)
+
+	resp = mergeMaintenance(&schema.SiteMaintenanceReq{Enabled: true}, &reload.Maintenance{Enabled: false})
+	assert.True(t, resp.Enabled)
+	assert.False(t, resp.ForcedByConfig)
+}
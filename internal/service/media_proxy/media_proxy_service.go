@@ -0,0 +1,278 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package media_proxy
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/httpclient"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// mediaProxyPath the path of the proxy api, the api is always served under the root of the site
+	mediaProxyPath      = "/answer/api/v1/media/proxy"
+	mediaProxyTimeout   = 10 * time.Second
+	mediaProxyRedirects = 3
+)
+
+// mediaProxyConfig the stored settings, the secret key is generated when the settings are saved
+// for the first time and it is never returned to the admin
+type mediaProxyConfig struct {
+	schema.SiteMediaProxyReq
+	SecretKey string `json:"secret_key"`
+}
+
+// MediaProxyService load the external images in the rendered content through the site.
+// The proxied urls are signed, so the proxy can not be used to request any other url.
+type MediaProxyService struct {
+	data            *data.Data
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	httpClient      *http.Client
+}
+
+// NewMediaProxyService new media proxy service
+func NewMediaProxyService(
+	data *data.Data,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *MediaProxyService {
+	return &MediaProxyService{
+		data:            data,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		httpClient:      httpclient.NewPublicClient(mediaProxyTimeout, mediaProxyRedirects),
+	}
+}
+
+// GetMediaProxy get the media proxy settings
+func (ms *MediaProxyService) GetMediaProxy(ctx context.Context) (resp *schema.SiteMediaProxyResp, err error) {
+	resp = &schema.SiteMediaProxyResp{}
+	if err = ms.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeMediaProxy, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateMediaProxy update the media proxy settings, the secret key is kept
+func (ms *MediaProxyService) UpdateMediaProxy(ctx context.Context, req *schema.SiteMediaProxyReq) (err error) {
+	config, err := ms.getConfig(ctx)
+	if err != nil {
+		return err
+	}
+	config.SiteMediaProxyReq = *req
+	if len(config.SecretKey) == 0 {
+		config.SecretKey, err = generateSecretKey()
+		if err != nil {
+			return errors.InternalServer(reason.UnknownError).WithError(err).WithStack()
+		}
+	}
+	content, _ := json.Marshal(config)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeMediaProxy,
+		Content: string(content),
+	}
+	return ms.siteInfoRepo.SaveByType(ctx, constant.SiteTypeMediaProxy, data)
+}
+
+// RewriteImages rewrite the external images in the html to the proxied urls if the proxy is enabled
+func (ms *MediaProxyService) RewriteImages(ctx context.Context, content string) string {
+	return ms.BatchRewriteImages(ctx, []string{content})[0]
+}
+
+// BatchRewriteImages rewrite the external images in each html, the result is in the same order
+func (ms *MediaProxyService) BatchRewriteImages(ctx context.Context, contents []string) []string {
+	config, err := ms.getConfig(ctx)
+	if err != nil {
+		log.Error(err)
+		return contents
+	}
+	if !config.Enabled || len(config.SecretKey) == 0 {
+		return contents
+	}
+	siteHost := ""
+	siteGeneral, err := ms.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+	} else if u, err := url.Parse(siteGeneral.SiteUrl); err == nil {
+		siteHost = u.Hostname()
+	}
+
+	replace := func(src string) string {
+		if !checker.IsURL(src) {
+			return src
+		}
+		u, err := url.Parse(src)
+		if err != nil || len(u.Hostname()) == 0 || strings.EqualFold(u.Hostname(), siteHost) {
+			return src
+		}
+		return ProxyURL(config.SecretKey, src)
+	}
+	rewritten := make([]string, len(contents))
+	for i, content := range contents {
+		rewritten[i] = htmltext.ReplaceImageSources(content, replace)
+	}
+	return rewritten
+}
+
+// ProxyMedia load the image of the signed url, the image is cached
+func (ms *MediaProxyService) ProxyMedia(ctx context.Context, req *schema.MediaProxyReq) (
+	resp *schema.MediaProxyResp, err error) {
+	config, err := ms.getConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Enabled || len(config.SecretKey) == 0 {
+		return nil, errors.BadRequest(reason.MediaProxyDisabled)
+	}
+	if !hmac.Equal([]byte(sign(config.SecretKey, req.URL)), []byte(req.Sig)) {
+		return nil, errors.Forbidden(reason.MediaProxySignatureInvalid)
+	}
+	cacheHours := config.CacheHours
+	if cacheHours == 0 {
+		cacheHours = schema.DefaultMediaProxyCacheHours
+	}
+	maxSize := int64(config.MaxSizeMB)
+	if maxSize == 0 {
+		maxSize = schema.DefaultMediaProxyMaxSizeMB
+	}
+	maxSize <<= 20
+
+	cacheKey := constant.MediaProxyCacheKeyPrefix + req.Sig
+	if resp = ms.getFromCache(ctx, cacheKey); resp != nil && int64(len(resp.Body)) <= maxSize {
+		resp.CacheHours = cacheHours
+		return resp, nil
+	}
+	resp, err = ms.fetch(ctx, req.URL, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	resp.CacheHours = cacheHours
+	ms.setCache(ctx, cacheKey, resp, time.Duration(cacheHours)*time.Hour)
+	return resp, nil
+}
+
+// fetch request the image, only the raster images are allowed, because the svg may contain the scripts
+func (ms *MediaProxyService) fetch(ctx context.Context, link string, maxSize int64) (
+	resp *schema.MediaProxyResp, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link, nil)
+	if err != nil {
+		return nil, errors.BadRequest(reason.MediaProxyFetchFailed)
+	}
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set("Accept", "image/*")
+	httpResp, err := ms.httpClient.Do(req)
+	if err != nil {
+		log.Debugf("request media failed: %s", err)
+		return nil, errors.BadRequest(reason.MediaProxyFetchFailed)
+	}
+	defer httpResp.Body.Close()
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(httpResp.Header.Get("Content-Type"), ";")[0]))
+	if httpResp.StatusCode != http.StatusOK || !isAllowedContentType(contentType) {
+		return nil, errors.BadRequest(reason.MediaProxyFetchFailed)
+	}
+	if httpResp.ContentLength > maxSize {
+		return nil, errors.BadRequest(reason.MediaProxyTooLarge)
+	}
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, maxSize+1))
+	if err != nil {
+		log.Debugf("read media failed: %s", err)
+		return nil, errors.BadRequest(reason.MediaProxyFetchFailed)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, errors.BadRequest(reason.MediaProxyTooLarge)
+	}
+	return &schema.MediaProxyResp{ContentType: contentType, Body: body}, nil
+}
+
+func (ms *MediaProxyService) getConfig(ctx context.Context) (config *mediaProxyConfig, err error) {
+	config = &mediaProxyConfig{}
+	if err = ms.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeMediaProxy, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (ms *MediaProxyService) getFromCache(ctx context.Context, cacheKey string) (resp *schema.MediaProxyResp) {
+	cacheData, exist, err := ms.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		log.Errorf("get media from cache failed: %s", err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+	resp = &schema.MediaProxyResp{}
+	if err = json.Unmarshal([]byte(cacheData), resp); err != nil {
+		return nil
+	}
+	return resp
+}
+
+func (ms *MediaProxyService) setCache(ctx context.Context, cacheKey string, resp *schema.MediaProxyResp,
+	cacheTime time.Duration) {
+	cacheData, _ := json.Marshal(resp)
+	if err := ms.data.Cache.SetString(ctx, cacheKey, string(cacheData), cacheTime); err != nil {
+		log.Errorf("set media cache failed: %s", err)
+	}
+}
+
+// ProxyURL get the signed proxy url of the external image
+func ProxyURL(secretKey, link string) string {
+	return mediaProxyPath + "?url=" + url.QueryEscape(link) + "&sig=" + sign(secretKey, link)
+}
+
+func sign(secretKey, link string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(link))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func generateSecretKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(key), nil
+}
+
+func isAllowedContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/") && !strings.Contains(contentType, "svg")
+}
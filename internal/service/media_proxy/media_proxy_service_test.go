@@ -0,0 +1,75 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package media_proxy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxyURL(t *testing.T) {
+	link := "http://example.com/a.png?size=1"
+	proxyURL := ProxyURL("key", link)
+	assert.True(t, strings.HasPrefix(proxyURL, mediaProxyPath+"?url=http%3A%2F%2Fexample.com%2Fa.png%3Fsize%3D1&sig="))
+	assert.Equal(t, sign("key", link), proxyURL[strings.Index(proxyURL, "&sig=")+5:])
+	assert.NotEqual(t, sign("key", link), sign("other", link))
+	assert.NotEqual(t, sign("key", link), sign("key", link+"2"))
+}
+
+func TestIsAllowedContentType(t *testing.T) {
+	assert.True(t, isAllowedContentType("image/png"))
+	assert.True(t, isAllowedContentType("image/webp"))
+	assert.False(t, isAllowedContentType("image/svg+xml"))
+	assert.False(t, isAllowedContentType("text/html"))
+}
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte("png"))
+		case "/large.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write([]byte(strings.Repeat("x", 100)))
+		default:
+			w.Header().Set("Content-Type", "image/svg+xml")
+			_, _ = w.Write([]byte("<svg></svg>"))
+		}
+	}))
+	defer server.Close()
+	// the public client refuses the local test server
+	ms := &MediaProxyService{httpClient: server.Client()}
+
+	resp, err := ms.fetch(context.TODO(), server.URL+"/a.png", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &schema.MediaProxyResp{ContentType: "image/png", Body: []byte("png")}, resp)
+
+	_, err = ms.fetch(context.TODO(), server.URL+"/large.png", 10)
+	assert.Error(t, err)
+	_, err = ms.fetch(context.TODO(), server.URL+"/a.svg", 10)
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./activity_queue.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	schema "github.com/apache/incubator-answer/internal/schema"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockActivityQueueService is a mock of ActivityQueueService interface.
+type MockActivityQueueService struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityQueueServiceMockRecorder
+}
+
+// MockActivityQueueServiceMockRecorder is the mock recorder for MockActivityQueueService.
+type MockActivityQueueServiceMockRecorder struct {
+	mock *MockActivityQueueService
+}
+
+// NewMockActivityQueueService creates a new mock instance.
+func NewMockActivityQueueService(ctrl *gomock.Controller) *MockActivityQueueService {
+	mock := &MockActivityQueueService{ctrl: ctrl}
+	mock.recorder = &MockActivityQueueServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityQueueService) EXPECT() *MockActivityQueueServiceMockRecorder {
+	return m.recorder
+}
+
+// RegisterHandler mocks base method.
+func (m *MockActivityQueueService) RegisterHandler(handler func(context.Context, *schema.ActivityMsg) error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RegisterHandler", handler)
+}
+
+// RegisterHandler indicates an expected call of RegisterHandler.
+func (mr *MockActivityQueueServiceMockRecorder) RegisterHandler(handler interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterHandler", reflect.TypeOf((*MockActivityQueueService)(nil).RegisterHandler), handler)
+}
+
+// Send mocks base method.
+func (m *MockActivityQueueService) Send(ctx context.Context, msg *schema.ActivityMsg) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Send", ctx, msg)
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockActivityQueueServiceMockRecorder) Send(ctx, msg interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockActivityQueueService)(nil).Send), ctx, msg)
+}
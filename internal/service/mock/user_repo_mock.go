@@ -0,0 +1,389 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./user.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/apache/incubator-answer/internal/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserRepo is a mock of UserRepo interface.
+type MockUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRepoMockRecorder
+}
+
+// MockUserRepoMockRecorder is the mock recorder for MockUserRepo.
+type MockUserRepoMockRecorder struct {
+	mock *MockUserRepo
+}
+
+// NewMockUserRepo creates a new mock instance.
+func NewMockUserRepo(ctrl *gomock.Controller) *MockUserRepo {
+	mock := &MockUserRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRepo) EXPECT() *MockUserRepoMockRecorder {
+	return m.recorder
+}
+
+// AddUser mocks base method.
+func (m *MockUserRepo) AddUser(ctx context.Context, user *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUser", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUser indicates an expected call of AddUser.
+func (mr *MockUserRepoMockRecorder) AddUser(ctx, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUser", reflect.TypeOf((*MockUserRepo)(nil).AddUser), ctx, user)
+}
+
+// BatchGetByID mocks base method.
+func (m *MockUserRepo) BatchGetByID(ctx context.Context, ids []string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetByID", ctx, ids)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetByID indicates an expected call of BatchGetByID.
+func (mr *MockUserRepoMockRecorder) BatchGetByID(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetByID", reflect.TypeOf((*MockUserRepo)(nil).BatchGetByID), ctx, ids)
+}
+
+// GetByEmail mocks base method.
+func (m *MockUserRepo) GetByEmail(ctx context.Context, email string) (*entity.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserRepoMockRecorder) GetByEmail(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepo)(nil).GetByEmail), ctx, email)
+}
+
+// GetByUserID mocks base method.
+func (m *MockUserRepo) GetByUserID(ctx context.Context, userID string) (*entity.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockUserRepoMockRecorder) GetByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserRepo)(nil).GetByUserID), ctx, userID)
+}
+
+// GetByUsername mocks base method.
+func (m *MockUserRepo) GetByUsername(ctx context.Context, username string) (*entity.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsername", ctx, username)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUsername indicates an expected call of GetByUsername.
+func (mr *MockUserRepoMockRecorder) GetByUsername(ctx, username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserRepo)(nil).GetByUsername), ctx, username)
+}
+
+// GetByUsernames mocks base method.
+func (m *MockUserRepo) GetByUsernames(ctx context.Context, usernames []string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUsernames", ctx, usernames)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUsernames indicates an expected call of GetByUsernames.
+func (mr *MockUserRepoMockRecorder) GetByUsernames(ctx, usernames interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsernames", reflect.TypeOf((*MockUserRepo)(nil).GetByUsernames), ctx, usernames)
+}
+
+// GetUnverifiedUsersToRemind mocks base method.
+func (m *MockUserRepo) GetUnverifiedUsersToRemind(ctx context.Context, remindedBefore time.Time, maxReminderCount, limit int) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnverifiedUsersToRemind", ctx, remindedBefore, maxReminderCount, limit)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnverifiedUsersToRemind indicates an expected call of GetUnverifiedUsersToRemind.
+func (mr *MockUserRepoMockRecorder) GetUnverifiedUsersToRemind(ctx, remindedBefore, maxReminderCount, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnverifiedUsersToRemind", reflect.TypeOf((*MockUserRepo)(nil).GetUnverifiedUsersToRemind), ctx, remindedBefore, maxReminderCount, limit)
+}
+
+// GetUserCount mocks base method.
+func (m *MockUserRepo) GetUserCount(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCount", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCount indicates an expected call of GetUserCount.
+func (mr *MockUserRepoMockRecorder) GetUserCount(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCount", reflect.TypeOf((*MockUserRepo)(nil).GetUserCount), ctx)
+}
+
+// IncreaseAnswerCount mocks base method.
+func (m *MockUserRepo) IncreaseAnswerCount(ctx context.Context, userID string, amount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseAnswerCount", ctx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseAnswerCount indicates an expected call of IncreaseAnswerCount.
+func (mr *MockUserRepoMockRecorder) IncreaseAnswerCount(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseAnswerCount", reflect.TypeOf((*MockUserRepo)(nil).IncreaseAnswerCount), ctx, userID, amount)
+}
+
+// IncreaseQuestionCount mocks base method.
+func (m *MockUserRepo) IncreaseQuestionCount(ctx context.Context, userID string, amount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseQuestionCount", ctx, userID, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncreaseQuestionCount indicates an expected call of IncreaseQuestionCount.
+func (mr *MockUserRepoMockRecorder) IncreaseQuestionCount(ctx, userID, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseQuestionCount", reflect.TypeOf((*MockUserRepo)(nil).IncreaseQuestionCount), ctx, userID, amount)
+}
+
+// SearchUserListByName mocks base method.
+func (m *MockUserRepo) SearchUserListByName(ctx context.Context, name string, limit int, onlyStaff bool) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUserListByName", ctx, name, limit, onlyStaff)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchUserListByName indicates an expected call of SearchUserListByName.
+func (mr *MockUserRepoMockRecorder) SearchUserListByName(ctx, name, limit, onlyStaff interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUserListByName", reflect.TypeOf((*MockUserRepo)(nil).SearchUserListByName), ctx, name, limit, onlyStaff)
+}
+
+// UpdateAnswerCount mocks base method.
+func (m *MockUserRepo) UpdateAnswerCount(ctx context.Context, userID string, count int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAnswerCount", ctx, userID, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAnswerCount indicates an expected call of UpdateAnswerCount.
+func (mr *MockUserRepoMockRecorder) UpdateAnswerCount(ctx, userID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAnswerCount", reflect.TypeOf((*MockUserRepo)(nil).UpdateAnswerCount), ctx, userID, count)
+}
+
+// UpdateEmail mocks base method.
+func (m *MockUserRepo) UpdateEmail(ctx context.Context, userID, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmail", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmail indicates an expected call of UpdateEmail.
+func (mr *MockUserRepoMockRecorder) UpdateEmail(ctx, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmail", reflect.TypeOf((*MockUserRepo)(nil).UpdateEmail), ctx, userID, email)
+}
+
+// UpdateEmailStatus mocks base method.
+func (m *MockUserRepo) UpdateEmailStatus(ctx context.Context, userID string, emailStatus int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEmailStatus", ctx, userID, emailStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEmailStatus indicates an expected call of UpdateEmailStatus.
+func (mr *MockUserRepoMockRecorder) UpdateEmailStatus(ctx, userID, emailStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEmailStatus", reflect.TypeOf((*MockUserRepo)(nil).UpdateEmailStatus), ctx, userID, emailStatus)
+}
+
+// UpdateInfo mocks base method.
+func (m *MockUserRepo) UpdateInfo(ctx context.Context, userInfo *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInfo", ctx, userInfo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInfo indicates an expected call of UpdateInfo.
+func (mr *MockUserRepoMockRecorder) UpdateInfo(ctx, userInfo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInfo", reflect.TypeOf((*MockUserRepo)(nil).UpdateInfo), ctx, userInfo)
+}
+
+// UpdateLastLoginDate mocks base method.
+func (m *MockUserRepo) UpdateLastLoginDate(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastLoginDate", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastLoginDate indicates an expected call of UpdateLastLoginDate.
+func (mr *MockUserRepoMockRecorder) UpdateLastLoginDate(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastLoginDate", reflect.TypeOf((*MockUserRepo)(nil).UpdateLastLoginDate), ctx, userID)
+}
+
+// UpdateNoticeStatus mocks base method.
+func (m *MockUserRepo) UpdateNoticeStatus(ctx context.Context, userID string, noticeStatus int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNoticeStatus", ctx, userID, noticeStatus)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNoticeStatus indicates an expected call of UpdateNoticeStatus.
+func (mr *MockUserRepoMockRecorder) UpdateNoticeStatus(ctx, userID, noticeStatus interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNoticeStatus", reflect.TypeOf((*MockUserRepo)(nil).UpdateNoticeStatus), ctx, userID, noticeStatus)
+}
+
+// UpdatePass mocks base method.
+func (m *MockUserRepo) UpdatePass(ctx context.Context, userID, pass string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePass", ctx, userID, pass)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePass indicates an expected call of UpdatePass.
+func (mr *MockUserRepoMockRecorder) UpdatePass(ctx, userID, pass interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePass", reflect.TypeOf((*MockUserRepo)(nil).UpdatePass), ctx, userID, pass)
+}
+
+// UpdateQuestionCount mocks base method.
+func (m *MockUserRepo) UpdateQuestionCount(ctx context.Context, userID string, count int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuestionCount", ctx, userID, count)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuestionCount indicates an expected call of UpdateQuestionCount.
+func (mr *MockUserRepoMockRecorder) UpdateQuestionCount(ctx, userID, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuestionCount", reflect.TypeOf((*MockUserRepo)(nil).UpdateQuestionCount), ctx, userID, count)
+}
+
+// UpdateUserInterface mocks base method.
+func (m *MockUserRepo) UpdateUserInterface(ctx context.Context, userID, language, colorSchema string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserInterface", ctx, userID, language, colorSchema)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserInterface indicates an expected call of UpdateUserInterface.
+func (mr *MockUserRepoMockRecorder) UpdateUserInterface(ctx, userID, language, colorSchema interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserInterface", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserInterface), ctx, userID, language, colorSchema)
+}
+
+// UpdateUserPreferences mocks base method.
+func (m *MockUserRepo) UpdateUserPreferences(ctx context.Context, userID, language, timeZone, dateFormat string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPreferences", ctx, userID, language, timeZone, dateFormat)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPreferences indicates an expected call of UpdateUserPreferences.
+func (mr *MockUserRepoMockRecorder) UpdateUserPreferences(ctx, userID, language, timeZone, dateFormat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPreferences", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserPreferences), ctx, userID, language, timeZone, dateFormat)
+}
+
+// UpdateUserProfile mocks base method.
+func (m *MockUserRepo) UpdateUserProfile(ctx context.Context, userInfo *entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserProfile", ctx, userInfo)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserProfile indicates an expected call of UpdateUserProfile.
+func (mr *MockUserRepoMockRecorder) UpdateUserProfile(ctx, userInfo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserProfile", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserProfile), ctx, userInfo)
+}
+
+// UpdateVerificationReminded mocks base method.
+func (m *MockUserRepo) UpdateVerificationReminded(ctx context.Context, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVerificationReminded", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateVerificationReminded indicates an expected call of UpdateVerificationReminded.
+func (mr *MockUserRepoMockRecorder) UpdateVerificationReminded(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVerificationReminded", reflect.TypeOf((*MockUserRepo)(nil).UpdateVerificationReminded), ctx, userID)
+}
@@ -0,0 +1,423 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_rule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	metacommon "github.com/apache/incubator-answer/internal/service/meta_common"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/review"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// moderationRuleSubmitter the submitter of the review which is added by the moderation rules
+const moderationRuleSubmitter = "moderation_rule"
+
+// linkPattern the links in the markdown content
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://`)
+
+// ModerationRuleHitRepo moderation rule hit repository
+type ModerationRuleHitRepo interface {
+	AddModerationRuleHits(ctx context.Context, hits []*entity.ModerationRuleHit) (err error)
+	GetModerationRuleHitPage(ctx context.Context, page, pageSize int, cond *entity.ModerationRuleHit) (
+		hits []*entity.ModerationRuleHit, total int64, err error)
+}
+
+// ModerationRuleService evaluate the moderation rules of the site when the question is asked or edited,
+// and apply the actions of the matched rules
+type ModerationRuleService struct {
+	moderationRuleHitRepo    ModerationRuleHitRepo
+	siteInfoRepo             siteinfo_common.SiteInfoRepo
+	siteInfoService          siteinfo_common.SiteInfoCommonService
+	questionRepo             questioncommon.QuestionRepo
+	reviewRepo               review.ReviewRepo
+	tagCommon                *tagcommon.TagCommonService
+	userCommon               *usercommon.UserCommon
+	userRoleRelService       *role.UserRoleRelService
+	metaService              *metacommon.MetaCommonService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewModerationRuleService new moderation rule service
+func NewModerationRuleService(
+	moderationRuleHitRepo ModerationRuleHitRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	questionRepo questioncommon.QuestionRepo,
+	reviewRepo review.ReviewRepo,
+	tagCommon *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	userRoleRelService *role.UserRoleRelService,
+	metaService *metacommon.MetaCommonService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	eventQueueService event_queue.EventQueueService,
+) *ModerationRuleService {
+	ms := &ModerationRuleService{
+		moderationRuleHitRepo:    moderationRuleHitRepo,
+		siteInfoRepo:             siteInfoRepo,
+		siteInfoService:          siteInfoService,
+		questionRepo:             questionRepo,
+		reviewRepo:               reviewRepo,
+		tagCommon:                tagCommon,
+		userCommon:               userCommon,
+		userRoleRelService:       userRoleRelService,
+		metaService:              metaService,
+		notificationQueueService: notificationQueueService,
+	}
+	eventQueueService.RegisterHandler(ms.HandleEvent)
+	return ms
+}
+
+// GetModerationRules get the moderation rules
+func (ms *ModerationRuleService) GetModerationRules(ctx context.Context) (resp *schema.SiteModerationRulesReq, err error) {
+	resp = &schema.SiteModerationRulesReq{}
+	if err = ms.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeModerationRules, resp); err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		resp.Rules = make([]*schema.ModerationRule, 0)
+	}
+	return resp, nil
+}
+
+// UpdateModerationRules update the moderation rules
+func (ms *ModerationRuleService) UpdateModerationRules(ctx context.Context, req *schema.SiteModerationRulesReq) (
+	err error) {
+	for _, item := range req.Rules {
+		item.Name = strings.TrimSpace(item.Name)
+		if err = checkRule(item); err != nil {
+			return errors.BadRequest(reason.ModerationRuleInvalid).WithMsg(
+				fmt.Sprintf("%s: %s", item.Name, err.Error()))
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeModerationRules,
+		Content: string(content),
+	}
+	return ms.siteInfoRepo.SaveByType(ctx, constant.SiteTypeModerationRules, data)
+}
+
+// GetModerationRuleHitPage get the questions which match the rules
+func (ms *ModerationRuleService) GetModerationRuleHitPage(ctx context.Context,
+	req *schema.GetModerationRuleHitPageReq) (pageModel *pager.PageModel, err error) {
+	cond := &entity.ModerationRuleHit{RuleName: req.RuleName}
+	hits, total, err := ms.moderationRuleHitRepo.GetModerationRuleHitPage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.ModerationRuleHitResp, 0, len(hits))
+	for _, hit := range hits {
+		resp = append(resp, &schema.ModerationRuleHitResp{
+			ID:         hit.ID,
+			CreatedAt:  hit.CreatedAt.Unix(),
+			RuleName:   hit.RuleName,
+			QuestionID: hit.QuestionID,
+			UserID:     hit.UserID,
+			Trigger:    hit.Trigger,
+			Actions:    strings.Split(hit.Actions, ","),
+			DryRun:     hit.DryRun,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// HandleEvent evaluate the rules when the question is asked or edited
+func (ms *ModerationRuleService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	var trigger string
+	switch msg.EventType {
+	case constant.EventQuestionCreate:
+		trigger = schema.ModerationTriggerCreate
+	case constant.EventQuestionUpdate:
+		trigger = schema.ModerationTriggerEdit
+	default:
+		return nil
+	}
+	setting, err := ms.GetModerationRules(ctx)
+	if err != nil || len(setting.Rules) == 0 {
+		return err
+	}
+	question, exist, err := ms.questionRepo.GetQuestion(ctx, msg.ObjectID)
+	if err != nil || !exist {
+		return err
+	}
+	content, err := ms.getContent(ctx, question)
+	if err != nil {
+		return err
+	}
+
+	matchedRules := make([]*schema.ModerationRule, 0)
+	hits := make([]*entity.ModerationRuleHit, 0)
+	for _, r := range setting.Rules {
+		if !r.Enabled || !hasTrigger(r, trigger) || !matchRule(r, content) {
+			continue
+		}
+		hits = append(hits, &entity.ModerationRuleHit{
+			RuleName:   r.Name,
+			QuestionID: question.ID,
+			UserID:     question.UserID,
+			Trigger:    trigger,
+			Actions:    strings.Join(r.Actions, ","),
+			DryRun:     r.DryRun,
+		})
+		if !r.DryRun {
+			matchedRules = append(matchedRules, r)
+		}
+	}
+	if len(hits) == 0 {
+		return nil
+	}
+	if err = ms.moderationRuleHitRepo.AddModerationRuleHits(ctx, hits); err != nil {
+		log.Errorf("add moderation rule hits failed: %v", err)
+	}
+	ms.applyActions(ctx, question, matchedRules)
+	return nil
+}
+
+// ruleContent the question evaluated by the rules
+type ruleContent struct {
+	authorRank int
+	text       string
+	linkCount  int
+	tags       map[string]bool
+}
+
+func (ms *ModerationRuleService) getContent(ctx context.Context, question *entity.Question) (
+	content *ruleContent, err error) {
+	content = &ruleContent{
+		text:      question.Title + "\n" + question.OriginalText,
+		linkCount: len(linkPattern.FindAllStringIndex(question.OriginalText, -1)),
+		tags:      make(map[string]bool),
+	}
+	author, exist, err := ms.userCommon.GetUserBasicInfoByID(ctx, question.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		content.authorRank = author.Rank
+	}
+	tags, err := ms.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		content.tags[strings.ToLower(tag.SlugName)] = true
+	}
+	return content, nil
+}
+
+// applyActions apply the actions of the matched rules, the held question is not closed since
+// it would be visible again
+func (ms *ModerationRuleService) applyActions(ctx context.Context, question *entity.Question,
+	rules []*schema.ModerationRule) {
+	held := false
+	for _, r := range rules {
+		if r.HasAction(schema.ModerationActionHold) && !held {
+			held = ms.hold(ctx, question, r)
+		}
+	}
+	for _, r := range rules {
+		if r.HasAction(schema.ModerationActionTag) {
+			ms.addTags(ctx, question, r.AddTags)
+		}
+		if r.HasAction(schema.ModerationActionClose) && !held {
+			ms.close(ctx, question, r)
+		}
+		if r.HasAction(schema.ModerationActionNotify) {
+			ms.notifyModerators(ctx, question)
+		}
+	}
+}
+
+// hold the available question is pending until the moderators review it
+func (ms *ModerationRuleService) hold(ctx context.Context, question *entity.Question,
+	r *schema.ModerationRule) (held bool) {
+	if question.Status != entity.QuestionStatusAvailable {
+		return question.Status == entity.QuestionStatusPending
+	}
+	err := ms.reviewRepo.AddReview(ctx, &entity.Review{
+		UserID:         question.UserID,
+		ObjectID:       question.ID,
+		ObjectType:     constant.ObjectTypeStrMapping[constant.QuestionObjectType],
+		ReviewerUserID: "0",
+		Submitter:      moderationRuleSubmitter,
+		Reason:         fmt.Sprintf("The question matches the moderation rule %q.", r.Name),
+		Status:         entity.ReviewStatusPending,
+	})
+	if err != nil {
+		log.Errorf("add review failed: %v", err)
+		return false
+	}
+	if err = ms.questionRepo.UpdateQuestionStatus(ctx, question.ID, entity.QuestionStatusPending); err != nil {
+		log.Errorf("hold question %s failed: %v", question.ID, err)
+		return false
+	}
+	question.Status = entity.QuestionStatusPending
+	return true
+}
+
+// addTags add the existing tags to the question
+func (ms *ModerationRuleService) addTags(ctx context.Context, question *entity.Question, tagNames []string) {
+	tags, err := ms.tagCommon.GetTagListByNames(ctx, tagNames)
+	if err != nil || len(tags) == 0 {
+		return
+	}
+	objectTags, err := ms.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		log.Errorf("get question tags failed: %v", err)
+		return
+	}
+	tagIDs := make([]string, 0, len(objectTags)+len(tags))
+	exist := make(map[string]bool)
+	for _, tag := range append(objectTags, tags...) {
+		if !exist[tag.ID] {
+			exist[tag.ID] = true
+			tagIDs = append(tagIDs, tag.ID)
+		}
+	}
+	if len(tagIDs) == len(objectTags) {
+		return
+	}
+	if err = ms.tagCommon.CreateOrUpdateTagRelList(ctx, question.ID, tagIDs); err != nil {
+		log.Errorf("add tags to question %s failed: %v", question.ID, err)
+	}
+}
+
+// close the available question with the close reason of the rule
+func (ms *ModerationRuleService) close(ctx context.Context, question *entity.Question, r *schema.ModerationRule) {
+	if question.Status != entity.QuestionStatusAvailable {
+		return
+	}
+	if err := ms.questionRepo.UpdateQuestionStatus(ctx, question.ID, entity.QuestionStatusClosed); err != nil {
+		log.Errorf("close question %s failed: %v", question.ID, err)
+		return
+	}
+	question.Status = entity.QuestionStatusClosed
+	closeMeta, _ := json.Marshal(schema.CloseQuestionMeta{
+		CloseType: r.CloseType,
+		CloseMsg:  r.CloseMsg,
+	})
+	if err := ms.metaService.AddMeta(ctx, question.ID, entity.QuestionCloseReasonKey, string(closeMeta)); err != nil {
+		log.Errorf("add close reason of question %s failed: %v", question.ID, err)
+	}
+}
+
+// notifyModerators notify the admins and moderators through the inbox
+func (ms *ModerationRuleService) notifyModerators(ctx context.Context, question *entity.Question) {
+	rels, err := ms.userRoleRelService.GetUserByRoleID(ctx, []int{role.RoleAdminID, role.RoleModeratorID})
+	if err != nil {
+		log.Errorf("get staff failed: %v", err)
+		return
+	}
+	for _, rel := range rels {
+		ms.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+			ReceiverUserID:     rel.UserID,
+			Type:               schema.NotificationTypeInbox,
+			ObjectID:           question.ID,
+			ObjectType:         constant.QuestionObjectType,
+			NotificationAction: constant.NotificationModerationRuleMatched,
+		})
+	}
+}
+
+// checkRule the rule must have a condition and the settings of its actions
+func checkRule(r *schema.ModerationRule) error {
+	if !r.HasCondition() {
+		return fmt.Errorf("at least one condition is required")
+	}
+	if len(r.BodyRegex) > 0 {
+		if _, err := regexp.Compile(r.BodyRegex); err != nil {
+			return err
+		}
+	}
+	if r.HasAction(schema.ModerationActionTag) && len(r.AddTags) == 0 {
+		return fmt.Errorf("the tags to add are required")
+	}
+	if r.HasAction(schema.ModerationActionClose) && r.CloseType == 0 {
+		return fmt.Errorf("the close reason is required")
+	}
+	return nil
+}
+
+func hasTrigger(r *schema.ModerationRule, trigger string) bool {
+	if len(r.Triggers) == 0 {
+		return true
+	}
+	for _, item := range r.Triggers {
+		if item == trigger {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRule whether all the set conditions of the rule are matched, the rule without any condition never matches
+func matchRule(r *schema.ModerationRule, content *ruleContent) bool {
+	if !r.HasCondition() {
+		return false
+	}
+	if r.AuthorRankBelow > 0 && content.authorRank >= r.AuthorRankBelow {
+		return false
+	}
+	if r.MinLinkCount > 0 && content.linkCount < r.MinLinkCount {
+		return false
+	}
+	if len(r.Tags) > 0 {
+		matched := false
+		for _, tag := range r.Tags {
+			if content.tags[strings.ToLower(tag)] {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if len(r.BodyRegex) > 0 {
+		re, err := regexp.Compile(r.BodyRegex)
+		if err != nil {
+			log.Errorf("compile the regex of moderation rule %s failed: %v", r.Name, err)
+			return false
+		}
+		if !re.MatchString(content.text) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_rule
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRule(t *testing.T) {
+	content := &ruleContent{
+		authorRank: 5,
+		text:       "Buy cheap watches\nvisit https://a.example and http://b.example",
+		linkCount:  2,
+		tags:       map[string]bool{"go": true},
+	}
+
+	assert.True(t, matchRule(&schema.ModerationRule{AuthorRankBelow: 10, MinLinkCount: 2}, content))
+	assert.False(t, matchRule(&schema.ModerationRule{AuthorRankBelow: 5}, content))
+	assert.False(t, matchRule(&schema.ModerationRule{MinLinkCount: 3}, content))
+	assert.True(t, matchRule(&schema.ModerationRule{Tags: []string{"Go", "rust"}}, content))
+	assert.False(t, matchRule(&schema.ModerationRule{Tags: []string{"rust"}}, content))
+	assert.True(t, matchRule(&schema.ModerationRule{BodyRegex: `(?i)cheap\s+watch`}, content))
+	assert.False(t, matchRule(&schema.ModerationRule{BodyRegex: `casino`, AuthorRankBelow: 10}, content))
+	assert.False(t, matchRule(&schema.ModerationRule{}, content))
+}
+
+func TestCheckRule(t *testing.T) {
+	assert.Error(t, checkRule(&schema.ModerationRule{Actions: []string{schema.ModerationActionHold}}))
+	assert.Error(t, checkRule(&schema.ModerationRule{BodyRegex: "(", Actions: []string{schema.ModerationActionHold}}))
+	assert.Error(t, checkRule(&schema.ModerationRule{MinLinkCount: 1, Actions: []string{schema.ModerationActionTag}}))
+	assert.Error(t, checkRule(&schema.ModerationRule{MinLinkCount: 1, Actions: []string{schema.ModerationActionClose}}))
+	assert.NoError(t, checkRule(&schema.ModerationRule{MinLinkCount: 1,
+		Actions: []string{schema.ModerationActionTag, schema.ModerationActionNotify}, AddTags: []string{"spam"}}))
+}
+
+func TestHasTrigger(t *testing.T) {
+	assert.True(t, hasTrigger(&schema.ModerationRule{}, schema.ModerationTriggerEdit))
+	assert.True(t, hasTrigger(&schema.ModerationRule{Triggers: []string{schema.ModerationTriggerCreate}},
+		schema.ModerationTriggerCreate))
+	assert.False(t, hasTrigger(&schema.ModerationRule{Triggers: []string{schema.ModerationTriggerCreate}},
+		schema.ModerationTriggerEdit))
+}
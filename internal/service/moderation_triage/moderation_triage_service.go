@@ -0,0 +1,430 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_triage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/report"
+	"github.com/apache/incubator-answer/internal/service/review"
+	"github.com/apache/incubator-answer/internal/service/user_admin"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const defaultTriageLimit = 5
+
+// ModerationTriageRepo moderation triage repository
+type ModerationTriageRepo interface {
+	GetPendingReports(ctx context.Context, objectType int, reportTypes []int, limit int) (
+		reports []*entity.Report, total int64, err error)
+	GetReopenCandidates(ctx context.Context, closedActivityType int, limit int) (
+		questions []*entity.Question, total int64, err error)
+	GetPendingReviews(ctx context.Context, limit int) (reviews []*entity.Review, total int64, err error)
+	GetSLABreachedQuestions(ctx context.Context, limit int) (questions []*entity.Question, total int64, err error)
+	GetSpamSuspects(ctx context.Context, spamReportType int, limit int) (
+		suspects []*entity.SpamSuspect, total int64, err error)
+	UpdateUserPendingReportsStatus(ctx context.Context, reportedUserID string, reportType, status int) (err error)
+}
+
+// ModerationTriageService gathers the flagged posts, the close and reopen requests, the queued posts,
+// the questions breaching the SLA and the spam suspects, so that the moderators can handle them in one place
+type ModerationTriageService struct {
+	moderationTriageRepo ModerationTriageRepo
+	configService        *config.ConfigService
+	objectInfoService    *object_info.ObjService
+	userCommon           *usercommon.UserCommon
+	reportService        *report.ReportService
+	reviewService        *review.ReviewService
+	questionService      *content.QuestionService
+	userAdminService     *user_admin.UserAdminService
+}
+
+// NewModerationTriageService new moderation triage service
+func NewModerationTriageService(
+	moderationTriageRepo ModerationTriageRepo,
+	configService *config.ConfigService,
+	objectInfoService *object_info.ObjService,
+	userCommon *usercommon.UserCommon,
+	reportService *report.ReportService,
+	reviewService *review.ReviewService,
+	questionService *content.QuestionService,
+	userAdminService *user_admin.UserAdminService,
+) *ModerationTriageService {
+	return &ModerationTriageService{
+		moderationTriageRepo: moderationTriageRepo,
+		configService:        configService,
+		objectInfoService:    objectInfoService,
+		userCommon:           userCommon,
+		reportService:        reportService,
+		reviewService:        reviewService,
+		questionService:      questionService,
+		userAdminService:     userAdminService,
+	}
+}
+
+// GetTriageDashboard get the amount and the latest items of each kind of the work needing attention
+func (ms *ModerationTriageService) GetTriageDashboard(ctx context.Context, req *schema.GetTriageDashboardReq) (
+	resp *schema.TriageDashboardResp, err error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultTriageLimit
+	}
+	sectionBuilders := []func(ctx context.Context, limit int) (*schema.TriageSection, error){
+		ms.getFlaggedPosts,
+		ms.getCloseVotes,
+		ms.getReopenVotes,
+		ms.getReviewQueue,
+		ms.getSLABreached,
+		ms.getSpamSuspects,
+	}
+	resp = &schema.TriageDashboardResp{Sections: make([]*schema.TriageSection, 0, len(sectionBuilders))}
+	for _, build := range sectionBuilders {
+		section, err := build(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		resp.TodoAmount += section.TodoAmount
+		resp.Sections = append(resp.Sections, section)
+	}
+	ms.fillUsers(ctx, resp.Sections)
+	return resp, nil
+}
+
+// TriageAction handle the item of the dashboard with the quick action
+func (ms *ModerationTriageService) TriageAction(ctx context.Context, req *schema.TriageActionReq) (err error) {
+	if !isActionAllowed(req.Section, req.Action) {
+		return errors.BadRequest(reason.ModerationTriageActionInvalid)
+	}
+	switch req.Section {
+	case schema.TriageSectionFlaggedPosts, schema.TriageSectionCloseVotes:
+		return ms.reportService.ReviewReport(ctx, &schema.ReviewReportReq{
+			FlagID:        req.ID,
+			OperationType: req.Action,
+			CloseType:     req.CloseType,
+			CloseMsg:      req.CloseMsg,
+			UserID:        req.UserID,
+			IsAdmin:       req.IsAdmin,
+		})
+	case schema.TriageSectionReopenVotes:
+		return ms.questionService.ReopenQuestion(ctx, &schema.ReopenQuestionReq{
+			QuestionID: uid.DeShortID(req.ID),
+			UserID:     req.UserID,
+		})
+	case schema.TriageSectionReviewQueue:
+		reviewID, err := strconv.Atoi(req.ID)
+		if err != nil {
+			return errors.BadRequest(reason.RequestFormatError)
+		}
+		return ms.reviewService.UpdateReview(ctx, &schema.UpdateReviewReq{
+			ReviewID: reviewID,
+			Status:   req.Action,
+			UserID:   req.UserID,
+			IsAdmin:  req.IsAdmin,
+		})
+	case schema.TriageSectionSpamSuspects:
+		spamReportType, err := ms.configService.GetIDByKey(ctx, constant.ReasonSpam)
+		if err != nil {
+			return err
+		}
+		status := entity.ReportStatusIgnore
+		if req.Action == schema.TriageActionSuspend {
+			err = ms.userAdminService.UpdateUserStatus(ctx, &schema.UpdateUserStatusReq{
+				UserID:      req.ID,
+				Status:      constant.UserSuspended,
+				LoginUserID: req.UserID,
+			})
+			if err != nil {
+				return err
+			}
+			status = entity.ReportStatusCompleted
+		}
+		return ms.moderationTriageRepo.UpdateUserPendingReportsStatus(ctx, req.ID, spamReportType, status)
+	}
+	return nil
+}
+
+// getFlaggedPosts the pending flags of all the posts
+func (ms *ModerationTriageService) getFlaggedPosts(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	reports, total, err := ms.moderationTriageRepo.GetPendingReports(ctx, 0, nil, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ms.formatReports(ctx, schema.TriageSectionFlaggedPosts, reports, total), nil
+}
+
+// getCloseVotes the pending flags of the questions with the close reasons, the flags with the reason
+// "something else" are not counted as they do not ask for closing
+func (ms *ModerationTriageService) getCloseVotes(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	reasonKeys, err := ms.configService.GetArrayStringValue(ctx,
+		fmt.Sprintf("%s.%s.reasons", constant.QuestionObjectType, "close"))
+	if err != nil {
+		return nil, err
+	}
+	reportTypes := make([]int, 0, len(reasonKeys))
+	for _, reasonKey := range reasonKeys {
+		if reasonKey == constant.ReasonSomething {
+			continue
+		}
+		reportType, err := ms.configService.GetIDByKey(ctx, reasonKey)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		reportTypes = append(reportTypes, reportType)
+	}
+	section := &schema.TriageSection{Name: schema.TriageSectionCloseVotes, Items: make([]*schema.TriageItem, 0)}
+	if len(reportTypes) == 0 {
+		return section, nil
+	}
+	reports, total, err := ms.moderationTriageRepo.GetPendingReports(ctx,
+		constant.ObjectTypeStrMapping[constant.QuestionObjectType], reportTypes, limit)
+	if err != nil {
+		return nil, err
+	}
+	return ms.formatReports(ctx, schema.TriageSectionCloseVotes, reports, total), nil
+}
+
+// getReopenVotes the closed questions which are edited after they are closed
+func (ms *ModerationTriageService) getReopenVotes(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	closedActivityType, err := ms.configService.GetIDByKey(ctx, string(constant.ActQuestionClosed))
+	if err != nil {
+		return nil, err
+	}
+	questions, total, err := ms.moderationTriageRepo.GetReopenCandidates(ctx, closedActivityType, limit)
+	if err != nil {
+		return nil, err
+	}
+	return formatQuestions(schema.TriageSectionReopenVotes, questions, total), nil
+}
+
+// getReviewQueue the posts waiting for the review before they are published
+func (ms *ModerationTriageService) getReviewQueue(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	reviews, total, err := ms.moderationTriageRepo.GetPendingReviews(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	section := &schema.TriageSection{
+		Name:       schema.TriageSectionReviewQueue,
+		TodoAmount: total,
+		Items:      make([]*schema.TriageItem, 0, len(reviews)),
+	}
+	for _, r := range reviews {
+		item := &schema.TriageItem{
+			ID:         strconv.Itoa(r.ID),
+			ObjectID:   r.ObjectID,
+			ObjectType: constant.ObjectTypeNumberMapping[r.ObjectType],
+			Content:    r.Reason,
+			User:       &schema.UserBasicInfo{ID: r.UserID},
+			CreatedAt:  r.CreatedAt.Unix(),
+			Actions:    sectionActions(schema.TriageSectionReviewQueue, ""),
+		}
+		ms.fillObjectInfo(ctx, item)
+		section.Items = append(section.Items, item)
+	}
+	return section, nil
+}
+
+// getSLABreached the open questions which breach the SLA of their tags
+func (ms *ModerationTriageService) getSLABreached(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	questions, total, err := ms.moderationTriageRepo.GetSLABreachedQuestions(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return formatQuestions(schema.TriageSectionSLABreached, questions, total), nil
+}
+
+// getSpamSuspects the users with the pending spam flags
+func (ms *ModerationTriageService) getSpamSuspects(ctx context.Context, limit int) (*schema.TriageSection, error) {
+	spamReportType, err := ms.configService.GetIDByKey(ctx, constant.ReasonSpam)
+	if err != nil {
+		return nil, err
+	}
+	suspects, total, err := ms.moderationTriageRepo.GetSpamSuspects(ctx, spamReportType, limit)
+	if err != nil {
+		return nil, err
+	}
+	section := &schema.TriageSection{
+		Name:       schema.TriageSectionSpamSuspects,
+		TodoAmount: total,
+		Items:      make([]*schema.TriageItem, 0, len(suspects)),
+	}
+	for _, suspect := range suspects {
+		section.Items = append(section.Items, &schema.TriageItem{
+			ID:         suspect.ReportedUserID,
+			ObjectID:   suspect.ReportedUserID,
+			ObjectType: constant.UserObjectType,
+			User:       &schema.UserBasicInfo{ID: suspect.ReportedUserID},
+			FlagCount:  suspect.FlagCount,
+			Actions:    sectionActions(schema.TriageSectionSpamSuspects, ""),
+		})
+	}
+	return section, nil
+}
+
+// formatReports format the reports to the items of the section
+func (ms *ModerationTriageService) formatReports(ctx context.Context, sectionName string,
+	reports []*entity.Report, total int64) *schema.TriageSection {
+	lang := handler.GetLangByCtx(ctx)
+	section := &schema.TriageSection{
+		Name:       sectionName,
+		TodoAmount: total,
+		Items:      make([]*schema.TriageItem, 0, len(reports)),
+	}
+	for _, r := range reports {
+		objectType := constant.ObjectTypeNumberMapping[r.ObjectType]
+		item := &schema.TriageItem{
+			ID:         r.ID,
+			ObjectID:   r.ObjectID,
+			ObjectType: objectType,
+			Content:    r.Content,
+			User:       &schema.UserBasicInfo{ID: r.ReportedUserID},
+			CreatedAt:  r.CreatedAt.Unix(),
+			Actions:    sectionActions(sectionName, objectType),
+		}
+		if r.ReportType > 0 {
+			item.Reason = &schema.ReasonItem{ReasonType: r.ReportType}
+			cf, err := ms.configService.GetConfigByID(ctx, r.ReportType)
+			if err != nil {
+				log.Error(err)
+			} else {
+				_ = json.Unmarshal([]byte(cf.Value), item.Reason)
+				item.Reason.Translate(cf.Key, lang)
+			}
+		}
+		ms.fillObjectInfo(ctx, item)
+		section.Items = append(section.Items, item)
+	}
+	return section
+}
+
+// fillObjectInfo fill the title and the question of the object
+func (ms *ModerationTriageService) fillObjectInfo(ctx context.Context, item *schema.TriageItem) {
+	objInfo, err := ms.objectInfoService.GetInfo(ctx, item.ObjectID)
+	if err != nil {
+		log.Errorf("get object info of %s failed: %v", item.ObjectID, err)
+		return
+	}
+	if objInfo == nil {
+		return
+	}
+	item.Title = objInfo.Title
+	item.QuestionID = uid.EnShortID(objInfo.QuestionID)
+}
+
+// fillUsers fill the basic info of the users of the items
+func (ms *ModerationTriageService) fillUsers(ctx context.Context, sections []*schema.TriageSection) {
+	userIDs := make([]string, 0)
+	for _, section := range sections {
+		for _, item := range section.Items {
+			if item.User != nil {
+				userIDs = append(userIDs, item.User.ID)
+			}
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	userInfoMapping, err := ms.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		log.Errorf("get user basic info failed: %v", err)
+		return
+	}
+	for _, section := range sections {
+		for _, item := range section.Items {
+			if item.User == nil {
+				continue
+			}
+			if userInfo, ok := userInfoMapping[item.User.ID]; ok {
+				item.User = userInfo
+			} else {
+				item.User = nil
+			}
+		}
+	}
+}
+
+// formatQuestions format the questions to the items of the section
+func formatQuestions(sectionName string, questions []*entity.Question, total int64) *schema.TriageSection {
+	section := &schema.TriageSection{
+		Name:       sectionName,
+		TodoAmount: total,
+		Items:      make([]*schema.TriageItem, 0, len(questions)),
+	}
+	for _, question := range questions {
+		questionID := uid.EnShortID(question.ID)
+		section.Items = append(section.Items, &schema.TriageItem{
+			ID:         questionID,
+			ObjectID:   questionID,
+			ObjectType: constant.QuestionObjectType,
+			QuestionID: questionID,
+			Title:      question.Title,
+			User:       &schema.UserBasicInfo{ID: question.UserID},
+			CreatedAt:  question.CreatedAt.Unix(),
+			Actions:    sectionActions(sectionName, constant.QuestionObjectType),
+		})
+	}
+	return section
+}
+
+// sectionActions the quick actions available for the item of the section
+func sectionActions(sectionName, objectType string) []string {
+	switch sectionName {
+	case schema.TriageSectionFlaggedPosts:
+		if objectType == constant.QuestionObjectType {
+			return []string{constant.ReportOperationClosePost, constant.ReportOperationDeletePost,
+				constant.ReportOperationIgnoreReport}
+		}
+		return []string{constant.ReportOperationDeletePost, constant.ReportOperationIgnoreReport}
+	case schema.TriageSectionCloseVotes:
+		return []string{constant.ReportOperationClosePost, constant.ReportOperationIgnoreReport}
+	case schema.TriageSectionReopenVotes:
+		return []string{schema.TriageActionReopen}
+	case schema.TriageSectionReviewQueue:
+		return []string{schema.TriageActionApprove, schema.TriageActionReject}
+	case schema.TriageSectionSpamSuspects:
+		return []string{schema.TriageActionSuspend, schema.TriageActionDismiss}
+	}
+	return []string{}
+}
+
+// isActionAllowed whether the action is available for the items of the section, the close action of
+// the flagged posts is checked again when the report is handled
+func isActionAllowed(sectionName, action string) bool {
+	for _, a := range sectionActions(sectionName, constant.QuestionObjectType) {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
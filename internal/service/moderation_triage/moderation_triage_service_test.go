@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package moderation_triage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSectionActions(t *testing.T) {
+	assert.Contains(t, sectionActions(schema.TriageSectionFlaggedPosts, constant.QuestionObjectType),
+		constant.ReportOperationClosePost)
+	assert.NotContains(t, sectionActions(schema.TriageSectionFlaggedPosts, constant.AnswerObjectType),
+		constant.ReportOperationClosePost)
+	assert.Empty(t, sectionActions(schema.TriageSectionSLABreached, constant.QuestionObjectType))
+}
+
+func TestIsActionAllowed(t *testing.T) {
+	assert.True(t, isActionAllowed(schema.TriageSectionReviewQueue, schema.TriageActionApprove))
+	assert.True(t, isActionAllowed(schema.TriageSectionSpamSuspects, schema.TriageActionSuspend))
+	assert.False(t, isActionAllowed(schema.TriageSectionReopenVotes, constant.ReportOperationDeletePost))
+	assert.False(t, isActionAllowed(schema.TriageSectionCloseVotes, constant.ReportOperationEditPost))
+}
+
+func TestFormatQuestions(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	section := formatQuestions(schema.TriageSectionReopenVotes, []*entity.Question{
+		{ID: "10010000000000001", UserID: "1", Title: "closed question", CreatedAt: createdAt},
+	}, 3)
+	assert.Equal(t, int64(3), section.TodoAmount)
+	assert.Len(t, section.Items, 1)
+	assert.Equal(t, "closed question", section.Items[0].Title)
+	assert.Equal(t, "1", section.Items[0].User.ID)
+	assert.Equal(t, createdAt.Unix(), section.Items[0].CreatedAt)
+	assert.Equal(t, []string{schema.TriageActionReopen}, section.Items[0].Actions)
+}
@@ -0,0 +1,154 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package new_contributor
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
+)
+
+// NewContributorRepo new contributor repository
+type NewContributorRepo interface {
+	CountUserQuestionsSince(ctx context.Context, userID string, since time.Time) (count int64, err error)
+	CountUserAnswersSince(ctx context.Context, userID string, since time.Time) (count int64, err error)
+}
+
+// NewContributorService the new contributors have the distinct rate limits and the guidance before posting
+type NewContributorService struct {
+	newContributorRepo NewContributorRepo
+	userRepo           usercommon.UserRepo
+	siteInfoService    siteinfo_common.SiteInfoCommonService
+}
+
+// NewNewContributorService new contributor service
+func NewNewContributorService(
+	newContributorRepo NewContributorRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *NewContributorService {
+	return &NewContributorService{
+		newContributorRepo: newContributorRepo,
+		userRepo:           userRepo,
+		siteInfoService:    siteInfoService,
+	}
+}
+
+// CheckPostLimit check whether the new contributor has reached the daily limit of the questions or answers
+func (ns *NewContributorService) CheckPostLimit(ctx context.Context, userID, objectType string) (err error) {
+	siteWrite, isNew, err := ns.checkNewContributor(ctx, userID)
+	if err != nil || !isNew {
+		return err
+	}
+	limitReason, reached, err := ns.reachPostLimit(ctx, siteWrite, userID, objectType)
+	if err != nil {
+		return err
+	}
+	if reached {
+		return errors.BadRequest(limitReason)
+	}
+	return nil
+}
+
+// DecoratePermission show the guidance to the new contributor before posting,
+// and tell the new contributor why it can not post if the daily limit is reached
+func (ns *NewContributorService) DecoratePermission(ctx context.Context, lang i18n.Language, userID string,
+	mapping map[string]*schema.GetPermissionResp) (err error) {
+	actionObjectTypes := map[string]string{
+		permission.QuestionAdd: constant.QuestionObjectType,
+		permission.AnswerAdd:   constant.AnswerObjectType,
+	}
+	siteWrite, isNew, err := ns.checkNewContributor(ctx, userID)
+	if err != nil || !isNew {
+		return err
+	}
+	for action, objectType := range actionObjectTypes {
+		resp, ok := mapping[action]
+		if !ok || !resp.HasPermission {
+			continue
+		}
+		resp.Guidance = siteWrite.NewContributorGuidance
+		limitReason, reached, err := ns.reachPostLimit(ctx, siteWrite, userID, objectType)
+		if err != nil {
+			return err
+		}
+		if reached {
+			resp.HasPermission = false
+			resp.NoPermissionTip = translator.Tr(lang, limitReason)
+		}
+	}
+	return nil
+}
+
+// checkNewContributor get the write settings and whether the user is the new contributor
+func (ns *NewContributorService) checkNewContributor(ctx context.Context, userID string) (
+	siteWrite *schema.SiteWriteResp, isNew bool, err error) {
+	if len(userID) == 0 {
+		return nil, false, nil
+	}
+	siteWrite, err = ns.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if siteWrite.NewContributorPostThreshold <= 0 {
+		return siteWrite, false, nil
+	}
+	userInfo, exist, err := ns.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !exist {
+		return siteWrite, false, nil
+	}
+	return siteWrite, usercommon.IsNewContributor(siteWrite, userInfo, time.Now()), nil
+}
+
+// reachPostLimit whether the posts of the user in the last 24 hours reach the limit
+func (ns *NewContributorService) reachPostLimit(ctx context.Context, siteWrite *schema.SiteWriteResp,
+	userID, objectType string) (limitReason string, reached bool, err error) {
+	since := time.Now().Add(-24 * time.Hour)
+	var limit int
+	var count int64
+	switch objectType {
+	case constant.QuestionObjectType:
+		limit, limitReason = siteWrite.NewContributorQuestionLimit, reason.NewContributorQuestionLimit
+		if limit > 0 {
+			count, err = ns.newContributorRepo.CountUserQuestionsSince(ctx, userID, since)
+		}
+	case constant.AnswerObjectType:
+		limit, limitReason = siteWrite.NewContributorAnswerLimit, reason.NewContributorAnswerLimit
+		if limit > 0 {
+			count, err = ns.newContributorRepo.CountUserAnswersSince(ctx, userID, since)
+		}
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return limitReason, limit > 0 && count >= int64(limit), nil
+}
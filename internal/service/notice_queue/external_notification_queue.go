@@ -22,6 +22,7 @@ package notice_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
@@ -32,11 +33,13 @@ type ExternalNotificationQueueService interface {
 }
 
 type externalNotificationQueueService struct {
+	lifecycle.PendingCounter
 	Queue   chan *schema.ExternalNotificationMsg
 	Handler func(ctx context.Context, msg *schema.ExternalNotificationMsg) error
 }
 
 func (ns *externalNotificationQueueService) Send(ctx context.Context, msg *schema.ExternalNotificationMsg) {
+	ns.Add()
 	ns.Queue <- msg
 }
 
@@ -48,22 +51,28 @@ func (ns *externalNotificationQueueService) RegisterHandler(
 func (ns *externalNotificationQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+			ns.handle(msg)
+			ns.Done()
 		}
 	}()
 }
 
+func (ns *externalNotificationQueueService) handle(msg *schema.ExternalNotificationMsg) {
+	log.Debugf("received notification %+v", msg)
+	if ns.Handler == nil {
+		log.Warnf("no handler for notification")
+		return
+	}
+	if err := ns.Handler(context.Background(), msg); err != nil {
+		log.Error(err)
+	}
+}
+
 // NewNewQuestionNotificationQueueService create a new notification queue service
 func NewNewQuestionNotificationQueueService() ExternalNotificationQueueService {
 	ns := &externalNotificationQueueService{}
 	ns.Queue = make(chan *schema.ExternalNotificationMsg, 128)
 	ns.working()
+	lifecycle.OnShutdown("external notification queue", ns.Drain)
 	return ns
 }
@@ -22,6 +22,7 @@ package notice_queue
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/segmentfault/pacman/log"
 )
@@ -32,11 +33,13 @@ type NotificationQueueService interface {
 }
 
 type notificationQueueService struct {
+	lifecycle.PendingCounter
 	Queue   chan *schema.NotificationMsg
 	Handler func(ctx context.Context, msg *schema.NotificationMsg) error
 }
 
 func (ns *notificationQueueService) Send(ctx context.Context, msg *schema.NotificationMsg) {
+	ns.Add()
 	ns.Queue <- msg
 }
 
@@ -48,22 +51,28 @@ func (ns *notificationQueueService) RegisterHandler(
 func (ns *notificationQueueService) working() {
 	go func() {
 		for msg := range ns.Queue {
-			log.Debugf("received notification %+v", msg)
-			if ns.Handler == nil {
-				log.Warnf("no handler for notification")
-				continue
-			}
-			if err := ns.Handler(context.Background(), msg); err != nil {
-				log.Error(err)
-			}
+			ns.handle(msg)
+			ns.Done()
 		}
 	}()
 }
 
+func (ns *notificationQueueService) handle(msg *schema.NotificationMsg) {
+	log.Debugf("received notification %+v", msg)
+	if ns.Handler == nil {
+		log.Warnf("no handler for notification")
+		return
+	}
+	if err := ns.Handler(context.Background(), msg); err != nil {
+		log.Error(err)
+	}
+}
+
 // NewNotificationQueueService create a new notification queue service
 func NewNotificationQueueService() NotificationQueueService {
 	ns := &notificationQueueService{}
 	ns.Queue = make(chan *schema.NotificationMsg, 128)
 	ns.working()
+	lifecycle.OnShutdown("notification queue", ns.Drain)
 	return ns
 }
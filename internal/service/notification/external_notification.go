@@ -31,6 +31,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_follow"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
 	"github.com/segmentfault/pacman/log"
 )
@@ -44,6 +45,8 @@ type ExternalNotificationService struct {
 	notificationQueueService   notice_queue.ExternalNotificationQueueService
 	userExternalLoginRepo      user_external_login.UserExternalLoginRepo
 	siteInfoService            siteinfo_common.SiteInfoCommonService
+	userFollowRepo             user_follow.UserFollowRepo
+	inboxQueueService          notice_queue.NotificationQueueService
 }
 
 func NewExternalNotificationService(
@@ -55,6 +58,8 @@ func NewExternalNotificationService(
 	notificationQueueService notice_queue.ExternalNotificationQueueService,
 	userExternalLoginRepo user_external_login.UserExternalLoginRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userFollowRepo user_follow.UserFollowRepo,
+	inboxQueueService notice_queue.NotificationQueueService,
 ) *ExternalNotificationService {
 	n := &ExternalNotificationService{
 		data:                       data,
@@ -65,6 +70,8 @@ func NewExternalNotificationService(
 		notificationQueueService:   notificationQueueService,
 		userExternalLoginRepo:      userExternalLoginRepo,
 		siteInfoService:            siteInfoService,
+		userFollowRepo:             userFollowRepo,
+		inboxQueueService:          inboxQueueService,
 	}
 	notificationQueueService.RegisterHandler(n.Handler)
 	return n
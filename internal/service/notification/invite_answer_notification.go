@@ -23,6 +23,7 @@ import (
 	"context"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"time"
@@ -74,6 +75,7 @@ func (ns *ExternalNotificationService) sendInviteAnswerNotificationEmail(ctx con
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo := ns.emailService.ReplyToAddress(ctx, export.EmailReplyAsAnswer, userID, rawData.QuestionID)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour, replyTo)
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"time"
@@ -74,6 +75,8 @@ func (ns *ExternalNotificationService) sendNewAnswerNotificationEmail(ctx contex
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	// the question author replies to the email to comment on the answer
+	replyTo := ns.emailService.ReplyToAddress(ctx, export.EmailReplyAsComment, userID, rawData.AnswerID)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour, replyTo)
 }
@@ -23,6 +23,7 @@ import (
 	"context"
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 	"time"
@@ -73,6 +74,12 @@ func (ns *ExternalNotificationService) sendNewCommentNotificationEmail(ctx conte
 		return
 	}
 
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	// the reply is commented on the same question or answer
+	commentObjectID := rawData.AnswerID
+	if len(commentObjectID) == 0 {
+		commentObjectID = rawData.QuestionID
+	}
+	replyTo := ns.emailService.ReplyToAddress(ctx, export.EmailReplyAsComment, userID, commentObjectID)
+	ns.emailService.SendAndSaveCodeWithReplyTo(
+		ctx, userID, email, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour, replyTo)
 }
@@ -27,11 +27,12 @@ import (
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/display"
 	"github.com/apache/incubator-answer/pkg/token"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/jinzhu/copier"
-	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -69,9 +70,52 @@ func (ns *ExternalNotificationService) handleNewQuestionNotification(ctx context
 	}
 
 	ns.syncNewQuestionNotificationToPlugin(ctx, msg)
+	ns.sendFollowedUserQuestionNotification(ctx, msg)
 	return nil
 }
 
+// sendFollowedUserQuestionNotification send inbox notification to the followers of the question author
+// who are following at least one tag of the new question.
+func (ns *ExternalNotificationService) sendFollowedUserQuestionNotification(ctx context.Context,
+	msg *schema.ExternalNotificationMsg) {
+	authorUserID := msg.NewQuestionTemplateRawData.QuestionAuthorUserID
+	followerIDs, err := ns.userFollowRepo.GetFollowerIDs(ctx, authorUserID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if len(followerIDs) == 0 {
+		return
+	}
+
+	tagFollowerMapping := make(map[string]bool)
+	for _, tagID := range msg.NewQuestionTemplateRawData.TagIDs {
+		userIDs, err := ns.followRepo.GetFollowUserIDs(ctx, tagID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		for _, userID := range userIDs {
+			tagFollowerMapping[userID] = true
+		}
+	}
+
+	for _, followerID := range followerIDs {
+		if !tagFollowerMapping[followerID] {
+			continue
+		}
+		ns.inboxQueueService.Send(ctx, &schema.NotificationMsg{
+			TriggerUserID:       authorUserID,
+			ReceiverUserID:      followerID,
+			Type:                schema.NotificationTypeInbox,
+			ObjectID:            msg.NewQuestionTemplateRawData.QuestionID,
+			ObjectType:          constant.QuestionObjectType,
+			NotificationAction:  constant.NotificationFollowedUserAskedQuestion,
+			NoNeedPushAllFollow: true,
+		})
+	}
+}
+
 func (ns *ExternalNotificationService) getNewQuestionSubscribers(ctx context.Context, msg *schema.ExternalNotificationMsg) (
 	subscribers []*NewQuestionSubscriber, err error) {
 	subscribersMapping := make(map[string]*NewQuestionSubscriber)
@@ -171,10 +215,8 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 		log.Errorf("user %s not exist", userID)
 		return
 	}
-	// If receiver has set language, use it to send email.
-	if len(userInfo.Language) > 0 {
-		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
-	}
+	// If receiver has set language or time zone, use it to send email.
+	ctx = usercommon.WithUserLocale(ctx, userInfo)
 	title, body, err := ns.emailService.NewQuestionTemplate(ctx, rawData)
 	if err != nil {
 		log.Error(err)
@@ -191,8 +233,9 @@ func (ns *ExternalNotificationService) sendNewQuestionNotificationEmail(ctx cont
 		},
 		SkipValidationLatestCode: true,
 	}
-	ns.emailService.SendAndSaveCodeWithTime(
-		ctx, userInfo.ID, userInfo.EMail, title, body, rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour)
+	replyTo := ns.emailService.ReplyToAddress(ctx, export.EmailReplyAsAnswer, userInfo.ID, rawData.QuestionID)
+	ns.emailService.SendAndSaveCodeWithReplyTo(ctx, userInfo.ID, userInfo.EMail, title, body,
+		rawData.UnsubscribeCode, codeContent.ToJSONString(), 1*24*time.Hour, replyTo)
 }
 
 func (ns *ExternalNotificationService) syncNewQuestionNotificationToPlugin(ctx context.Context,
@@ -23,6 +23,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/apache/incubator-answer/internal/service/report_common"
 	"github.com/apache/incubator-answer/internal/service/review"
@@ -40,6 +41,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/pkg/uid"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
 
@@ -282,3 +284,113 @@ func (ns *NotificationService) formatNotificationPage(ctx context.Context, notif
 	}
 	return resp, nil
 }
+
+// GetNotificationGroupPage get the grouped notifications, the group updated recently first
+func (ns *NotificationService) GetNotificationGroupPage(ctx context.Context, req *schema.NotificationGroupSearch) (
+	pageModel *pager.PageModel, err error) {
+	req.Type = schema.NotificationType[req.TypeStr]
+	if req.Type == schema.NotificationTypeInbox {
+		req.InboxType = schema.NotificationInboxType[req.InboxTypeStr]
+	}
+	groups, total, err := ns.notificationRepo.GetNotificationGroupPage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	latestIDs := make([]string, 0, len(groups))
+	for _, group := range groups {
+		latestIDs = append(latestIDs, group.LatestID)
+	}
+	notifications, err := ns.notificationRepo.GetByIDs(ctx, latestIDs)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := ns.formatNotificationPage(ctx, notifications)
+	if err != nil {
+		return nil, err
+	}
+	contentMapping := make(map[string]*schema.NotificationContent, len(contents))
+	for _, content := range contents {
+		contentMapping[content.ID] = content
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	resp := make([]*schema.NotificationGroupContent, 0, len(groups))
+	for _, group := range groups {
+		content, ok := contentMapping[group.LatestID]
+		if !ok {
+			continue
+		}
+		content.IsRead = group.UnreadAmount == 0
+		item := &schema.NotificationGroupContent{
+			NotificationContent: content,
+			GroupKey:            group.GroupKey,
+			Amount:              group.Amount,
+			UnreadAmount:        group.UnreadAmount,
+		}
+		if group.Amount > 1 {
+			item.GroupAction = translateGroupAction(lang, group)
+		}
+		resp = append(resp, item)
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// translateGroupAction translate the summary of the group like "3 new answers",
+// it is empty if the action has no summary
+func translateGroupAction(lang i18n.Language, group *schema.NotificationGroup) string {
+	// the group key is type:action:target
+	parts := strings.SplitN(group.GroupKey, ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	key := strings.Replace(parts[1], "notification.action.", "notification.group_action.", 1)
+	translation := translator.TrWithData(lang, key, map[string]any{"Amount": group.Amount})
+	if translation == key {
+		return ""
+	}
+	return translation
+}
+
+// ClearGroupUnRead mark the notifications of the group as read
+func (ns *NotificationService) ClearGroupUnRead(ctx context.Context, req *schema.NotificationReadGroupRequest) error {
+	return ns.notificationRepo.ClearGroupUnRead(ctx, req.UserID, req.GroupKey)
+}
+
+// ClearTypeUnRead mark the notifications of the type as read, the inbox can be marked by the inbox type
+func (ns *NotificationService) ClearTypeUnRead(ctx context.Context, req *schema.NotificationReadTypeRequest) error {
+	notificationType := schema.NotificationType[req.TypeStr]
+	inboxType := schema.NotificationInboxType[req.InboxTypeStr]
+	if notificationType == schema.NotificationTypeInbox && inboxType != schema.NotificationInboxTypeAll {
+		return ns.notificationRepo.ClearInboxTypeUnRead(ctx, req.UserID, inboxType)
+	}
+	return ns.notificationRepo.ClearUnRead(ctx, req.UserID, notificationType)
+}
+
+// GetUnreadCount get the unread amount of the inbox by inbox type and the achievement
+func (ns *NotificationService) GetUnreadCount(ctx context.Context, userID string) (
+	resp *schema.NotificationUnreadCountResp, err error) {
+	counts, err := ns.notificationRepo.GetUnreadCount(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.NotificationUnreadCountResp{Inbox: &schema.NotificationInboxUnreadCount{}}
+	for _, count := range counts {
+		if count.Type == schema.NotificationTypeAchievement {
+			resp.Achievement += count.UnreadAmount
+			continue
+		}
+		if count.Type != schema.NotificationTypeInbox {
+			continue
+		}
+		resp.Inbox.All += count.UnreadAmount
+		switch count.MsgType {
+		case schema.NotificationInboxTypePosts:
+			resp.Inbox.Posts += count.UnreadAmount
+		case schema.NotificationInboxTypeVotes:
+			resp.Inbox.Votes += count.UnreadAmount
+		case schema.NotificationInboxTypeInvites:
+			resp.Inbox.Invites += count.UnreadAmount
+		}
+	}
+	return resp, nil
+}
@@ -54,6 +54,12 @@ type NotificationRepo interface {
 	GetByUserIdObjectIdTypeId(ctx context.Context, userID, objectID string, notificationType int) (*entity.Notification, bool, error)
 	UpdateNotificationContent(ctx context.Context, notification *entity.Notification) (err error)
 	GetById(ctx context.Context, id string) (*entity.Notification, bool, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*entity.Notification, error)
+	GetNotificationGroupPage(ctx context.Context, search *schema.NotificationGroupSearch) (
+		[]*schema.NotificationGroup, int64, error)
+	ClearGroupUnRead(ctx context.Context, userID, groupKey string) (err error)
+	ClearInboxTypeUnRead(ctx context.Context, userID string, inboxType int) (err error)
+	GetUnreadCount(ctx context.Context, userID string) ([]*schema.NotificationUnreadCount, error)
 }
 
 type NotificationCommon struct {
@@ -169,6 +175,7 @@ func (ns *NotificationCommon) AddNotification(ctx context.Context, msg *schema.N
 	info.CreatedAt = now
 	info.UpdatedAt = now
 	info.ObjectID = req.ObjectInfo.ObjectID
+	info.GroupKey = req.GroupKey()
 
 	userBasicInfo, exist, err := ns.userCommon.GetUserBasicInfoByID(ctx, req.TriggerUserID)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/article"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
@@ -41,6 +42,7 @@ type ObjService struct {
 	commentRepo  comment_common.CommentCommonRepo
 	tagRepo      tagcommon.TagCommonRepo
 	tagCommon    *tagcommon.TagCommonService
+	articleRepo  article.ArticleRepo
 }
 
 // NewObjService new object service
@@ -50,6 +52,7 @@ func NewObjService(
 	commentRepo comment_common.CommentCommonRepo,
 	tagRepo tagcommon.TagCommonRepo,
 	tagCommon *tagcommon.TagCommonService,
+	articleRepo article.ArticleRepo,
 ) *ObjService {
 	return &ObjService{
 		answerRepo:   answerRepo,
@@ -57,6 +60,7 @@ func NewObjService(
 		commentRepo:  commentRepo,
 		tagRepo:      tagRepo,
 		tagCommon:    tagCommon,
+		articleRepo:  articleRepo,
 	}
 }
 func (os *ObjService) GetUnreviewedRevisionInfo(ctx context.Context, objectID string) (objInfo *schema.UnreviewedRevisionInfoInfo, err error) {
@@ -283,6 +287,23 @@ func (os *ObjService) GetInfo(ctx context.Context, objectID string) (objInfo *sc
 			Title:      tagInfo.ParsedText,
 			Content:    tagInfo.ParsedText, // todo trim
 		}
+	case constant.ArticleObjectType:
+		articleInfo, exist, err := os.articleRepo.GetArticle(ctx, objectID)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			break
+		}
+		objInfo = &schema.SimpleObjectInfo{
+			ObjectID:            articleInfo.ID,
+			ObjectCreatorUserID: articleInfo.UserID,
+			ArticleID:           articleInfo.ID,
+			ArticleStatus:       articleInfo.Status,
+			ObjectType:          objectType,
+			Title:               articleInfo.Title,
+			Content:             articleInfo.ParsedText, // todo trim
+		}
 	}
 	if objInfo == nil {
 		err = errors.BadRequest(reason.ObjectNotFound)
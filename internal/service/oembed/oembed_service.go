@@ -0,0 +1,289 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oembed
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/permalink"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const embedExcerptLength = 300
+
+// embedQuestionPathRegexp match the question id in the question page or short link path
+var embedQuestionPathRegexp = regexp.MustCompile(`^/(?:questions|q)/([0-9A-Za-z]+)(?:/.*)?$`)
+
+// OEmbedService provide the embeddable representation of the question for other sites
+type OEmbedService struct {
+	questionRepo     questioncommon.QuestionRepo
+	answerRepo       answercommon.AnswerRepo
+	userCommon       *usercommon.UserCommon
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	permalinkService *permalink.PermalinkService
+	data             *data.Data
+}
+
+// NewOEmbedService new embed service
+func NewOEmbedService(
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	permalinkService *permalink.PermalinkService,
+	data *data.Data,
+) *OEmbedService {
+	return &OEmbedService{
+		questionRepo:     questionRepo,
+		answerRepo:       answerRepo,
+		userCommon:       userCommon,
+		siteInfoService:  siteInfoService,
+		permalinkService: permalinkService,
+		data:             data,
+	}
+}
+
+// GetEmbedQuestion get the question with its accepted answer for embedding
+func (oe *OEmbedService) GetEmbedQuestion(ctx context.Context, req *schema.GetEmbedQuestionReq) (
+	resp *schema.EmbedQuestionResp, err error) {
+	if err = oe.checkEmbedEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if err = oe.checkRateLimit(ctx, req.ClientIP); err != nil {
+		return nil, err
+	}
+	return oe.getEmbedQuestion(ctx, uid.DeShortID(req.ID))
+}
+
+// GetOEmbed get the oEmbed response of the question url
+func (oe *OEmbedService) GetOEmbed(ctx context.Context, req *schema.GetOEmbedReq) (
+	resp *schema.OEmbedResp, err error) {
+	if err = oe.checkEmbedEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if err = oe.checkRateLimit(ctx, req.ClientIP); err != nil {
+		return nil, err
+	}
+	general, err := oe.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionID, ok := parseEmbedQuestionID(general.SiteUrl, req.URL)
+	if !ok {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	question, err := oe.getEmbedQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := schema.EmbedDefaultWidth, schema.EmbedDefaultHeight
+	if req.MaxWidth > 0 && req.MaxWidth < width {
+		width = req.MaxWidth
+	}
+	if req.MaxHeight > 0 && req.MaxHeight < height {
+		height = req.MaxHeight
+	}
+	resp = &schema.OEmbedResp{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        question.Title,
+		ProviderName: general.Name,
+		ProviderURL:  general.SiteUrl,
+		CacheAge:     schema.EmbedCacheAge,
+		Width:        width,
+		Height:       height,
+	}
+	if question.Author != nil {
+		resp.AuthorName = question.Author.DisplayName
+		resp.AuthorURL = fmt.Sprintf("%s/users/%s", general.SiteUrl, question.Author.Username)
+	}
+	resp.HTML = fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" title="%s" frameborder="0" loading="lazy"></iframe>`,
+		html.EscapeString(general.SiteUrl+schema.EmbedQuestionPrefix+question.ID), width, height,
+		html.EscapeString(question.Title))
+	return resp, nil
+}
+
+// OEmbedDiscoveryURL get the oEmbed discovery url of the page, return empty if embed is disabled
+func (oe *OEmbedService) OEmbedDiscoveryURL(ctx context.Context, pageURL string) string {
+	siteSeo, err := oe.siteInfoService.GetSiteSeo(ctx)
+	if err != nil || !siteSeo.Embed {
+		return ""
+	}
+	general, err := oe.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return ""
+	}
+	return general.SiteUrl + "/answer/api/v1/oembed?" + url.Values{"url": {pageURL}, "format": {"json"}}.Encode()
+}
+
+func (oe *OEmbedService) getEmbedQuestion(ctx context.Context, questionID string) (
+	resp *schema.EmbedQuestionResp, err error) {
+	questionInfo, exist, err := oe.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || questionInfo.Show != entity.QuestionShow ||
+		(questionInfo.Status != entity.QuestionStatusAvailable && questionInfo.Status != entity.QuestionStatusClosed) {
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	general, err := oe.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questionPath, err := oe.permalinkService.GetPermalink(ctx, constant.QuestionObjectType, questionInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.EmbedQuestionResp{
+		ID:          uid.EnShortID(questionInfo.ID),
+		Title:       questionInfo.Title,
+		Excerpt:     htmltext.FetchExcerpt(questionInfo.ParsedText, "...", embedExcerptLength),
+		URL:         general.SiteUrl + questionPath,
+		VoteCount:   questionInfo.VoteCount,
+		AnswerCount: questionInfo.AnswerCount,
+		CreateTime:  questionInfo.CreatedAt.Unix(),
+		SiteName:    general.Name,
+		SiteURL:     general.SiteUrl,
+	}
+	userIDs := []string{questionInfo.UserID}
+
+	answerInfo, exist, err := oe.getAcceptedAnswer(ctx, questionInfo)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		answerPath, err := oe.permalinkService.GetPermalink(ctx, constant.AnswerObjectType, answerInfo.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp.AcceptedAnswer = &schema.EmbedAnswerItem{
+			ID:         uid.EnShortID(answerInfo.ID),
+			Excerpt:    htmltext.FetchExcerpt(answerInfo.ParsedText, "...", embedExcerptLength),
+			URL:        general.SiteUrl + answerPath,
+			VoteCount:  answerInfo.VoteCount,
+			CreateTime: answerInfo.CreatedAt.Unix(),
+		}
+		userIDs = append(userIDs, answerInfo.UserID)
+	}
+
+	userInfoMapping, err := oe.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp.Author = userInfoMapping[questionInfo.UserID]
+	if resp.AcceptedAnswer != nil {
+		resp.AcceptedAnswer.Author = userInfoMapping[answerInfo.UserID]
+	}
+	return resp, nil
+}
+
+func (oe *OEmbedService) getAcceptedAnswer(ctx context.Context, questionInfo *entity.Question) (
+	answerInfo *entity.Answer, exist bool, err error) {
+	if len(questionInfo.AcceptedAnswerID) == 0 || questionInfo.AcceptedAnswerID == "0" {
+		return nil, false, nil
+	}
+	answerInfo, exist, err = oe.answerRepo.GetAnswer(ctx, questionInfo.AcceptedAnswerID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !exist || answerInfo.Status != entity.AnswerStatusAvailable {
+		return nil, false, nil
+	}
+	return answerInfo, true, nil
+}
+
+// checkEmbedEnabled embed must be enabled in seo setting and the site is not in private mode
+func (oe *OEmbedService) checkEmbedEnabled(ctx context.Context) error {
+	siteSeo, err := oe.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return err
+	}
+	if !siteSeo.Embed {
+		return errors.NotFound(reason.ObjectNotFound)
+	}
+	siteLogin, err := oe.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return err
+	}
+	if siteLogin.LoginRequired {
+		return errors.NotFound(reason.ObjectNotFound)
+	}
+	return nil
+}
+
+// checkRateLimit limit the embed requests of each client in one minute
+func (oe *OEmbedService) checkRateLimit(ctx context.Context, clientIP string) error {
+	key := constant.EmbedRateLimitCacheKeyPrefix + clientIP
+	count, exist, err := oe.data.Cache.GetInt64(ctx, key)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	if exist && count >= constant.EmbedRateLimitMax {
+		return errors.New(http.StatusTooManyRequests, reason.RateLimitError)
+	}
+	if !exist {
+		err = oe.data.Cache.SetInt64(ctx, key, 1, constant.EmbedRateLimitCacheTime)
+	} else {
+		_, err = oe.data.Cache.Increase(ctx, key, 1)
+	}
+	if err != nil {
+		log.Error(err)
+	}
+	return nil
+}
+
+// parseEmbedQuestionID get the question id from the url of this site
+func parseEmbedQuestionID(siteURL, rawURL string) (questionID string, ok bool) {
+	site, err := url.Parse(siteURL)
+	if err != nil {
+		return "", false
+	}
+	target, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(target.Host, site.Host) {
+		return "", false
+	}
+	path := strings.TrimPrefix(target.Path, strings.TrimSuffix(site.Path, "/"))
+	matches := embedQuestionPathRegexp.FindStringSubmatch(path)
+	if len(matches) != 2 {
+		return "", false
+	}
+	return uid.DeShortID(matches[1]), true
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package oembed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseEmbedQuestionID(t *testing.T) {
+	siteURL := "https://example.com/community"
+
+	id, ok := parseEmbedQuestionID(siteURL, "https://example.com/community/questions/10010000000000001/how-to-embed")
+	assert.True(t, ok)
+	assert.Equal(t, "10010000000000001", id)
+
+	id, ok = parseEmbedQuestionID(siteURL, "https://EXAMPLE.com/community/q/10010000000000001?shareUserId=answer")
+	assert.True(t, ok)
+	assert.Equal(t, "10010000000000001", id)
+
+	_, ok = parseEmbedQuestionID(siteURL, "https://other.com/community/questions/10010000000000001")
+	assert.False(t, ok)
+
+	_, ok = parseEmbedQuestionID(siteURL, "https://example.com/community/tags/go")
+	assert.False(t, ok)
+}
@@ -0,0 +1,227 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package page
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// PageRepo page repository
+type PageRepo interface {
+	AddPage(ctx context.Context, page *entity.Page) (err error)
+	UpdatePage(ctx context.Context, page *entity.Page) (err error)
+	RemovePage(ctx context.Context, id int) (err error)
+	GetPage(ctx context.Context, id int) (page *entity.Page, exist bool, err error)
+	GetPageBySlugName(ctx context.Context, slugName string) (page *entity.Page, exist bool, err error)
+	GetPageList(ctx context.Context) (pages []*entity.Page, err error)
+	GetNavPageList(ctx context.Context) (pages []*entity.Page, err error)
+}
+
+// PageService page service
+type PageService struct {
+	pageRepo          PageRepo
+	eventQueueService event_queue.EventQueueService
+}
+
+// NewPageService new page service
+func NewPageService(
+	pageRepo PageRepo,
+	eventQueueService event_queue.EventQueueService,
+) *PageService {
+	return &PageService{
+		pageRepo:          pageRepo,
+		eventQueueService: eventQueueService,
+	}
+}
+
+// AddPage add page
+func (ps *PageService) AddPage(ctx context.Context, req *schema.AddPageReq) (resp *schema.AddPageResp, err error) {
+	if err = ps.checkSlugNameUnique(ctx, req.SlugName, 0); err != nil {
+		return nil, err
+	}
+	page := &entity.Page{
+		Title:        req.Title,
+		SlugName:     req.SlugName,
+		OriginalText: req.Content,
+		ParsedText:   req.HTML,
+		Status:       pageStatus(req.Status),
+		ShowInNav:    req.ShowInNav,
+		NavOrder:     req.NavOrder,
+		UserID:       req.UserID,
+	}
+	if err = ps.pageRepo.AddPage(ctx, page); err != nil {
+		return nil, err
+	}
+	ps.sendEvent(ctx, constant.EventPageCreate, req.UserID, page.ID)
+	return &schema.AddPageResp{ID: page.ID, SlugName: page.SlugName}, nil
+}
+
+// UpdatePage update page
+func (ps *PageService) UpdatePage(ctx context.Context, req *schema.UpdatePageReq) (err error) {
+	page, exist, err := ps.pageRepo.GetPage(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.PageNotFound)
+	}
+	if err = ps.checkSlugNameUnique(ctx, req.SlugName, req.ID); err != nil {
+		return err
+	}
+	oldStatus := page.Status
+	page.Title = req.Title
+	page.SlugName = req.SlugName
+	page.OriginalText = req.Content
+	page.ParsedText = req.HTML
+	page.Status = pageStatus(req.Status)
+	page.ShowInNav = req.ShowInNav
+	page.NavOrder = req.NavOrder
+	if err = ps.pageRepo.UpdatePage(ctx, page); err != nil {
+		return err
+	}
+	if oldStatus != page.Status {
+		ps.sendEvent(ctx, constant.EventPageStatusChange, req.UserID, page.ID)
+	} else {
+		ps.sendEvent(ctx, constant.EventPageUpdate, req.UserID, page.ID)
+	}
+	return nil
+}
+
+// RemovePage remove page
+func (ps *PageService) RemovePage(ctx context.Context, req *schema.RemovePageReq) (err error) {
+	_, exist, err := ps.pageRepo.GetPage(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.PageNotFound)
+	}
+	if err = ps.pageRepo.RemovePage(ctx, req.ID); err != nil {
+		return err
+	}
+	ps.sendEvent(ctx, constant.EventPageDelete, req.UserID, req.ID)
+	return nil
+}
+
+// GetAdminPage get page by id, including the draft page
+func (ps *PageService) GetAdminPage(ctx context.Context, req *schema.GetAdminPageReq) (resp *schema.GetPageResp, err error) {
+	page, exist, err := ps.pageRepo.GetPage(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.NotFound(reason.PageNotFound)
+	}
+	return convertPageResp(page), nil
+}
+
+// GetAdminPageList get all pages, including the draft pages
+func (ps *PageService) GetAdminPageList(ctx context.Context) (resp []*schema.GetPageResp, err error) {
+	pages, err := ps.pageRepo.GetPageList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPageResp, 0, len(pages))
+	for _, page := range pages {
+		resp = append(resp, convertPageResp(page))
+	}
+	return resp, nil
+}
+
+// GetPage get published page by slug name
+func (ps *PageService) GetPage(ctx context.Context, req *schema.GetPageReq) (resp *schema.GetPageResp, err error) {
+	page, exist, err := ps.pageRepo.GetPageBySlugName(ctx, req.SlugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || page.Status != entity.PageStatusPublished {
+		return nil, errors.NotFound(reason.PageNotFound)
+	}
+	return convertPageResp(page), nil
+}
+
+// GetNavPageList get the published pages shown in the site navigation
+func (ps *PageService) GetNavPageList(ctx context.Context) (resp []*schema.GetPageNavResp, err error) {
+	pages, err := ps.pageRepo.GetNavPageList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPageNavResp, 0, len(pages))
+	for _, page := range pages {
+		resp = append(resp, &schema.GetPageNavResp{
+			Title:    page.Title,
+			SlugName: page.SlugName,
+			URL:      "/pages/" + url.PathEscape(page.SlugName),
+		})
+	}
+	return resp, nil
+}
+
+func (ps *PageService) checkSlugNameUnique(ctx context.Context, slugName string, pageID int) (err error) {
+	page, exist, err := ps.pageRepo.GetPageBySlugName(ctx, slugName)
+	if err != nil {
+		return err
+	}
+	if exist && page.ID != pageID {
+		return errors.BadRequest(reason.PageSlugNameAlreadyExist)
+	}
+	return nil
+}
+
+func (ps *PageService) sendEvent(ctx context.Context, eventType constant.EventType, userID string, pageID int) {
+	ps.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: eventType,
+		UserID:    userID,
+		ObjectID:  strconv.Itoa(pageID),
+	})
+}
+
+func pageStatus(status string) int {
+	for key, value := range entity.PageStatusDisplayMapping {
+		if value == status {
+			return key
+		}
+	}
+	return entity.PageStatusDraft
+}
+
+func convertPageResp(page *entity.Page) *schema.GetPageResp {
+	return &schema.GetPageResp{
+		ID:        page.ID,
+		Title:     page.Title,
+		SlugName:  page.SlugName,
+		Content:   page.OriginalText,
+		HTML:      page.ParsedText,
+		Status:    entity.PageStatusDisplayMapping[page.Status],
+		ShowInNav: page.ShowInNav,
+		NavOrder:  page.NavOrder,
+		CreatedAt: page.CreatedAt.Unix(),
+		UpdatedAt: page.UpdatedAt.Unix(),
+	}
+}
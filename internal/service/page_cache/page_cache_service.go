@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package page_cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reload"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/pkg/encryption"
+	"github.com/segmentfault/pacman/log"
+)
+
+// listGeneration the generation of all the pages, it changes when any question, answer, tag or user changes
+const listGeneration = "list"
+
+// CachedPage the response cached for the anonymous users
+type CachedPage struct {
+	Body         string `json:"body"`
+	ContentType  string `json:"content_type"`
+	ETag         string `json:"etag"`
+	LastModified int64  `json:"last_modified"`
+}
+
+// PageCacheService cache the responses of the public apis to the anonymous users. The cache keys contain the
+// generations of the content, the generations are changed by the events, so the stale pages are never hit again.
+// The votes and the comments are not sent as events, they are refreshed when the pages expire.
+type PageCacheService struct {
+	data *data.Data
+}
+
+// NewPageCacheService new page cache service
+func NewPageCacheService(data *data.Data, eventQueueService event_queue.EventQueueService) *PageCacheService {
+	ps := &PageCacheService{data: data}
+	eventQueueService.RegisterHandler(ps.HandleEvent)
+	return ps
+}
+
+// PageKey get the cache key of the page, the key of the question page contains the generation of the question too
+func (ps *PageCacheService) PageKey(ctx context.Context, questionID string, parts ...string) (key string) {
+	generations := []string{ps.getGeneration(ctx, listGeneration)}
+	if len(questionID) > 0 {
+		generations = append(generations, ps.getGeneration(ctx, questionID))
+	}
+	return constant.PageCacheKeyPrefix + encryption.MD5(strings.Join(append(generations, parts...), "\n"))
+}
+
+// GetPage get the cached page
+func (ps *PageCacheService) GetPage(ctx context.Context, key string) (page *CachedPage, exist bool) {
+	content, exist, err := ps.data.Cache.GetString(ctx, key)
+	if err != nil || !exist {
+		return nil, false
+	}
+	page = &CachedPage{}
+	if err = json.Unmarshal([]byte(content), page); err != nil {
+		return nil, false
+	}
+	return page, true
+}
+
+// SetPage cache the page
+func (ps *PageCacheService) SetPage(ctx context.Context, key string, page *CachedPage) {
+	ttl := reload.PageCacheTime()
+	if ttl <= 0 {
+		return
+	}
+	content, _ := json.Marshal(page)
+	if err := ps.data.Cache.SetString(ctx, key, string(content), ttl); err != nil {
+		log.Error(err)
+	}
+}
+
+// HandleEvent change the generations of the content in the event
+func (ps *PageCacheService) HandleEvent(ctx context.Context, msg *schema.EventMsg) (err error) {
+	eventType := string(msg.EventType)
+	switch {
+	case strings.HasPrefix(eventType, "question."):
+		ps.renewGeneration(ctx, msg.ObjectID)
+	case strings.HasPrefix(eventType, "answer."):
+		ps.renewGeneration(ctx, msg.QuestionID)
+	case strings.HasPrefix(eventType, "tag."), strings.HasPrefix(eventType, "user."):
+	default:
+		return nil
+	}
+	ps.renewGeneration(ctx, listGeneration)
+	return nil
+}
+
+func (ps *PageCacheService) getGeneration(ctx context.Context, name string) string {
+	generation, exist, err := ps.data.Cache.GetInt64(ctx, constant.PageGenerationCacheKeyPrefix+name)
+	if err != nil || !exist {
+		return "0"
+	}
+	return strconv.FormatInt(generation, 10)
+}
+
+func (ps *PageCacheService) renewGeneration(ctx context.Context, name string) {
+	if len(name) == 0 {
+		return
+	}
+	err := ps.data.Cache.SetInt64(ctx, constant.PageGenerationCacheKeyPrefix+name,
+		time.Now().UnixNano(), constant.PageGenerationCacheTime)
+	if err != nil {
+		log.Error(fmt.Errorf("renew the page generation %s failed: %w", name, err))
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package password_policy
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// pwnedPasswordsRangeURL the k-anonymity range API of Pwned Passwords,
+// only the first 5 characters of the SHA-1 hash of the password are sent.
+var pwnedPasswordsRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// PasswordPolicyService password policy service
+type PasswordPolicyService struct {
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewPasswordPolicyService new password policy service
+func NewPasswordPolicyService(siteInfoService siteinfo_common.SiteInfoCommonService) *PasswordPolicyService {
+	return &PasswordPolicyService{
+		siteInfoService: siteInfoService,
+	}
+}
+
+// CheckPassword check the new password satisfies the password policy and is not found in the data breaches
+func (ps *PasswordPolicyService) CheckPassword(ctx context.Context, field, password string) (
+	errFields []*validator.FormErrorField, err error) {
+	siteLogin, err := ps.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = checker.CheckPasswordPolicy(password, siteLogin.GetPasswordPolicy()); err != nil {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: field,
+			ErrorMsg:   err.Error(),
+		})
+		return errFields, errors.BadRequest(err.Error())
+	}
+	if siteLogin.PasswordBreachCheck && ps.isPasswordBreached(ctx, password) {
+		errFields = append(errFields, &validator.FormErrorField{
+			ErrorField: field,
+			ErrorMsg:   reason.PasswordBreached,
+		})
+		return errFields, errors.BadRequest(reason.PasswordBreached)
+	}
+	return nil, nil
+}
+
+// IsPasswordExpired check whether the user must change the password after login.
+// The password is expired if it is older than the rotation days or it is found in the data breaches.
+func (ps *PasswordPolicyService) IsPasswordExpired(ctx context.Context, userInfo *entity.User, password string) bool {
+	siteLogin, err := ps.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	if siteLogin.PasswordRotationDays > 0 {
+		passUpdatedAt := userInfo.PassUpdatedAt
+		if passUpdatedAt.IsZero() {
+			passUpdatedAt = userInfo.CreatedAt
+		}
+		if time.Since(passUpdatedAt) > time.Duration(siteLogin.PasswordRotationDays)*24*time.Hour {
+			return true
+		}
+	}
+	return siteLogin.PasswordBreachCheck && ps.isPasswordBreached(ctx, password)
+}
+
+// isPasswordBreached query the Pwned Passwords API, if the API is unavailable, the password is treated as not breached
+func (ps *PasswordPolicyService) isPasswordBreached(ctx context.Context, password string) bool {
+	hash := sha1.Sum([]byte(password))
+	hashHex := strings.ToUpper(hex.EncodeToString(hash[:]))
+	prefix, suffix := hashHex[:5], hashHex[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pwnedPasswordsRangeURL+prefix, nil)
+	if err != nil {
+		log.Errorf("create pwned passwords request failed: %s", err)
+		return false
+	}
+	req.Header.Set("User-Agent", "Answer/"+constant.Version)
+	req.Header.Set("Add-Padding", "true")
+	httpClient := &http.Client{}
+	httpClient.Timeout = 5 * time.Second
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Errorf("request pwned passwords failed: %s", err)
+		return false
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("request pwned passwords failed: status %d", resp.StatusCode)
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		log.Errorf("read pwned passwords response failed: %s", err)
+		return false
+	}
+	return pwnedHashSuffixFound(string(body), suffix)
+}
+
+// pwnedHashSuffixFound check whether the hash suffix is in the range API response,
+// each line of the response is "SUFFIX:COUNT" and the padding lines have zero count.
+func pwnedHashSuffixFound(body, suffix string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		hashSuffix, count, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+		return converter.StringToInt(count) > 0
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package password_policy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPwnedHashSuffixFound(t *testing.T) {
+	body := "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" +
+		"00D4F6E8FA6EECAD2A3AA415EEC418D38EC:0\r\n" +
+		"011053FD0102E94D6AE2F8B83D76FAF94F6:13\r\n"
+	assert.True(t, pwnedHashSuffixFound(body, "0018A45C4D1DEF81644B54AB7F969B88D65"))
+	assert.True(t, pwnedHashSuffixFound(body, "011053fd0102e94d6ae2f8b83d76faf94f6"))
+	// padding entry
+	assert.False(t, pwnedHashSuffixFound(body, "00D4F6E8FA6EECAD2A3AA415EEC418D38EC"))
+	assert.False(t, pwnedHashSuffixFound(body, "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"))
+}
+
+func TestIsPasswordBreached(t *testing.T) {
+	// SHA-1 of "password" is 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	var requestPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		_, _ = w.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n"))
+	}))
+	defer server.Close()
+	origin := pwnedPasswordsRangeURL
+	pwnedPasswordsRangeURL = server.URL + "/range/"
+	defer func() { pwnedPasswordsRangeURL = origin }()
+
+	ps := &PasswordPolicyService{}
+	assert.True(t, ps.isPasswordBreached(context.Background(), "password"))
+	assert.Equal(t, "/range/5BAA6", requestPath)
+	assert.False(t, ps.isPasswordBreached(context.Background(), "correct horse battery staple"))
+}
@@ -0,0 +1,371 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package pending_deletion
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// PendingDeletionRepo pending deletion repository
+type PendingDeletionRepo interface {
+	AddPendingDeletion(ctx context.Context, pendingDeletion *entity.PendingDeletion) (err error)
+	GetPendingDeletion(ctx context.Context, objectID string) (pendingDeletion *entity.PendingDeletion, exist bool, err error)
+	UpdatePendingDeletionStatus(ctx context.Context, id int, status int) (err error)
+	FinalizeExpiredPendingDeletions(ctx context.Context, now time.Time) (
+		finalized []*entity.PendingDeletion, err error)
+	GetRecycleBinDeletion(ctx context.Context, objectID string) (pendingDeletion *entity.PendingDeletion, exist bool, err error)
+	GetRecycleBinPage(ctx context.Context, page, pageSize int, objectType string) (
+		pendingDeletions []*entity.PendingDeletion, total int64, err error)
+	MarkObjectRestored(ctx context.Context, objectID string) (err error)
+	GetDeletedObjects(ctx context.Context, questionIDs, answerIDs, commentIDs []string) (
+		objects map[string]*entity.DeletedObject, err error)
+	GetPurgeableDeletions(ctx context.Context, before time.Time, afterID, limit int) (
+		pendingDeletions []*entity.PendingDeletion, err error)
+	PurgeDeletedObject(ctx context.Context, pendingDeletion *entity.PendingDeletion) (
+		purged bool, answerUserIDs []string, err error)
+}
+
+const (
+	// deletionReasonMaxLength the max length of the deletion reason which is kept in the recycle bin
+	deletionReasonMaxLength = 200
+	// purgeBatchSize the number of deletions purged in one batch
+	purgeBatchSize = 100
+)
+
+// PendingDeletionService pending deletion service
+type PendingDeletionService struct {
+	pendingDeletionRepo  PendingDeletionRepo
+	siteInfoService      siteinfo_common.SiteInfoCommonService
+	answerRepo           answercommon.AnswerRepo
+	userCommon           *usercommon.UserCommon
+	legalHoldService     *legal_hold.LegalHoldService
+	activityQueueService activity_queue.ActivityQueueService
+}
+
+// NewPendingDeletionService new pending deletion service
+func NewPendingDeletionService(
+	pendingDeletionRepo PendingDeletionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	answerRepo answercommon.AnswerRepo,
+	userCommon *usercommon.UserCommon,
+	legalHoldService *legal_hold.LegalHoldService,
+	activityQueueService activity_queue.ActivityQueueService,
+) *PendingDeletionService {
+	return &PendingDeletionService{
+		pendingDeletionRepo:  pendingDeletionRepo,
+		siteInfoService:      siteInfoService,
+		answerRepo:           answerRepo,
+		userCommon:           userCommon,
+		legalHoldService:     legalHoldService,
+		activityQueueService: activityQueueService,
+	}
+}
+
+// AddPendingDeletion the actor can undo the deletion of the object before the undo window closes,
+// after that the object stays in the recycle bin until it is restored or purged.
+func (ps *PendingDeletionService) AddPendingDeletion(ctx context.Context, objectID, userID, deleteReason string) {
+	siteWrite, err := ps.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	status, expiredAt := entity.PendingDeletionStatusPending, time.Now()
+	if siteWrite.DeleteUndoMinutes > 0 {
+		expiredAt = expiredAt.Add(time.Duration(siteWrite.DeleteUndoMinutes) * time.Minute)
+	} else {
+		status = entity.PendingDeletionStatusFinalized
+	}
+	objectID = uid.DeShortID(objectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	err = ps.pendingDeletionRepo.AddPendingDeletion(ctx, &entity.PendingDeletion{
+		ObjectID:   objectID,
+		ObjectType: objectType,
+		UserID:     userID,
+		Reason:     htmltext.FetchExcerpt(deleteReason, "...", deletionReasonMaxLength),
+		Status:     status,
+		ExpiredAt:  expiredAt,
+	})
+	if err != nil {
+		log.Errorf("add pending deletion of %s failed: %s", objectID, err)
+	}
+}
+
+// GetUndoableDeletion get the deletion which can be undone by the user now
+func (ps *PendingDeletionService) GetUndoableDeletion(ctx context.Context, req *schema.UndoDeleteReq) (
+	pendingDeletion *entity.PendingDeletion, err error) {
+	pendingDeletion, exist, err := ps.pendingDeletionRepo.GetPendingDeletion(ctx, req.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || pendingDeletion.UserID != req.UserID || !time.Now().Before(pendingDeletion.ExpiredAt) {
+		return nil, errors.BadRequest(reason.UndoDeleteNotAllowed)
+	}
+	return pendingDeletion, nil
+}
+
+// MarkUndone the deletion has been undone
+func (ps *PendingDeletionService) MarkUndone(ctx context.Context, pendingDeletion *entity.PendingDeletion) (err error) {
+	return ps.pendingDeletionRepo.UpdatePendingDeletionStatus(ctx, pendingDeletion.ID,
+		entity.PendingDeletionStatusUndone)
+}
+
+// FinalizeCron the deletions whose undo window has closed become permanent
+func (ps *PendingDeletionService) FinalizeCron(ctx context.Context) (err error) {
+	// the deletions finalized before the failure still need the activities
+	finalized, err := ps.pendingDeletionRepo.FinalizeExpiredPendingDeletions(ctx, time.Now())
+	if len(finalized) > 0 {
+		log.Infof("finalize %d pending deletions", len(finalized))
+		ps.sendFinalizedActivities(ctx, finalized)
+	}
+	return err
+}
+
+// sendFinalizedActivities record the finalized deletions in the timeline of the question or answer,
+// the deletion of the comment is recorded in the timeline of the object it belongs to
+func (ps *PendingDeletionService) sendFinalizedActivities(ctx context.Context, finalized []*entity.PendingDeletion) {
+	var commentIDs []string
+	for _, pendingDeletion := range finalized {
+		if pendingDeletion.ObjectType == constant.CommentObjectType {
+			commentIDs = append(commentIDs, pendingDeletion.ObjectID)
+		}
+	}
+	comments := make(map[string]*entity.DeletedObject)
+	if len(commentIDs) > 0 {
+		var err error
+		comments, err = ps.pendingDeletionRepo.GetDeletedObjects(ctx, nil, nil, commentIDs)
+		if err != nil {
+			log.Errorf("get finalized comments failed: %s", err)
+		}
+	}
+
+	for _, pendingDeletion := range finalized {
+		msg := &schema.ActivityMsg{
+			UserID:           pendingDeletion.UserID,
+			TriggerUserID:    converter.StringToInt64(pendingDeletion.UserID),
+			ObjectID:         pendingDeletion.ObjectID,
+			OriginalObjectID: pendingDeletion.ObjectID,
+		}
+		switch pendingDeletion.ObjectType {
+		case constant.QuestionObjectType:
+			msg.ActivityTypeKey = constant.ActQuestionDeletionFinalized
+		case constant.AnswerObjectType:
+			msg.ActivityTypeKey = constant.ActAnswerDeletionFinalized
+		case constant.CommentObjectType:
+			comment, ok := comments[pendingDeletion.ObjectID]
+			if !ok {
+				continue
+			}
+			msg.OriginalObjectID = comment.ParentID
+			parentType, _ := obj.GetObjectTypeStrByObjectID(comment.ParentID)
+			switch parentType {
+			case constant.QuestionObjectType:
+				msg.ActivityTypeKey = constant.ActQuestionCommentDeletionFinalized
+			case constant.AnswerObjectType:
+				msg.ActivityTypeKey = constant.ActAnswerCommentDeletionFinalized
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+		ps.activityQueueService.Send(ctx, msg)
+	}
+}
+
+// MarkRestored the object is recovered, so it leaves the recycle bin
+func (ps *PendingDeletionService) MarkRestored(ctx context.Context, objectID string) {
+	objectID = uid.DeShortID(objectID)
+	if err := ps.pendingDeletionRepo.MarkObjectRestored(ctx, objectID); err != nil {
+		log.Errorf("mark deletion of %s restored failed: %s", objectID, err)
+	}
+}
+
+// GetRestorableDeletion get the deletion of the object in the recycle bin
+func (ps *PendingDeletionService) GetRestorableDeletion(ctx context.Context, req *schema.RestoreDeletedReq) (
+	pendingDeletion *entity.PendingDeletion, err error) {
+	pendingDeletion, exist, err := ps.pendingDeletionRepo.GetRecycleBinDeletion(ctx, uid.DeShortID(req.ObjectID))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	return pendingDeletion, nil
+}
+
+// GetRecycleBinPage get the deleted content page
+func (ps *PendingDeletionService) GetRecycleBinPage(ctx context.Context, req *schema.GetRecycleBinPageReq) (
+	pageModel *pager.PageModel, err error) {
+	pendingDeletions, total, err := ps.pendingDeletionRepo.GetRecycleBinPage(ctx, req.Page, req.PageSize, req.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+	siteWrite, err := ps.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var questionIDs, answerIDs, commentIDs []string
+	for _, pendingDeletion := range pendingDeletions {
+		switch pendingDeletion.ObjectType {
+		case constant.QuestionObjectType:
+			questionIDs = append(questionIDs, pendingDeletion.ObjectID)
+		case constant.AnswerObjectType:
+			answerIDs = append(answerIDs, pendingDeletion.ObjectID)
+		case constant.CommentObjectType:
+			commentIDs = append(commentIDs, pendingDeletion.ObjectID)
+		}
+	}
+	objects, err := ps.pendingDeletionRepo.GetDeletedObjects(ctx, questionIDs, answerIDs, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]string, 0, len(pendingDeletions)*2)
+	for _, pendingDeletion := range pendingDeletions {
+		userIDs = append(userIDs, pendingDeletion.UserID)
+		if object, ok := objects[pendingDeletion.ObjectID]; ok {
+			userIDs = append(userIDs, object.UserID)
+		}
+	}
+	userInfoMapping, err := ps.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	enableShortID := handler.GetEnableShortID(ctx)
+	list := make([]*schema.RecycleBinItem, 0, len(pendingDeletions))
+	for _, pendingDeletion := range pendingDeletions {
+		item := &schema.RecycleBinItem{
+			ObjectID:   pendingDeletion.ObjectID,
+			ObjectType: pendingDeletion.ObjectType,
+			Deleter:    userInfoMapping[pendingDeletion.UserID],
+			Reason:     pendingDeletion.Reason,
+			DeletedAt:  pendingDeletion.CreatedAt.Unix(),
+			PurgeAt:    recycleBinPurgeAt(pendingDeletion.CreatedAt, siteWrite.RecycleBinRetentionDays),
+		}
+		if object, ok := objects[pendingDeletion.ObjectID]; ok {
+			item.QuestionID = object.QuestionID
+			item.Title = object.Title
+			item.Excerpt = htmltext.FetchExcerpt(object.ParsedText, "...", 240)
+			item.Author = userInfoMapping[object.UserID]
+		}
+		if enableShortID {
+			if item.ObjectType != constant.CommentObjectType {
+				item.ObjectID = uid.EnShortID(item.ObjectID)
+			}
+			item.QuestionID = uid.EnShortID(item.QuestionID)
+		}
+		list = append(list, item)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// PurgeCron the deleted content is purged permanently after the retention days
+func (ps *PendingDeletionService) PurgeCron(ctx context.Context) (err error) {
+	siteWrite, err := ps.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if siteWrite.RecycleBinRetentionDays <= 0 {
+		return nil
+	}
+	before := time.Now().AddDate(0, 0, -siteWrite.RecycleBinRetentionDays)
+
+	purgedCount := 0
+	answerUserIDs := make(map[string]bool)
+	// the deletions under the legal hold are skipped and kept, so the cursor moves forward
+	lastID := 0
+	for {
+		var pendingDeletions []*entity.PendingDeletion
+		pendingDeletions, err = ps.pendingDeletionRepo.GetPurgeableDeletions(ctx, before, lastID, purgeBatchSize)
+		if err != nil {
+			break
+		}
+		for _, pendingDeletion := range pendingDeletions {
+			lastID = pendingDeletion.ID
+			err = ps.legalHoldService.CheckObjectDeletable(ctx, pendingDeletion.ObjectID,
+				entity.LegalHoldProcessRecycleBinPurge, "")
+			if err != nil {
+				log.Infof("skip purging %s: %s", pendingDeletion.ObjectID, err)
+				continue
+			}
+			purged, userIDs, err := ps.pendingDeletionRepo.PurgeDeletedObject(ctx, pendingDeletion)
+			if err != nil {
+				return err
+			}
+			if purged {
+				purgedCount++
+			}
+			for _, userID := range userIDs {
+				answerUserIDs[userID] = true
+			}
+		}
+		if len(pendingDeletions) < purgeBatchSize {
+			break
+		}
+	}
+
+	for userID := range answerUserIDs {
+		answerCount, err := ps.answerRepo.GetCountByUserID(ctx, userID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if err = ps.userCommon.UpdateAnswerCount(ctx, userID, int(answerCount)); err != nil {
+			log.Error(err)
+		}
+	}
+	if purgedCount > 0 {
+		log.Infof("purge %d deleted objects from the recycle bin", purgedCount)
+	}
+	// the answer counts of the purged objects are updated even if getting the next batch failed
+	return err
+}
+
+// recycleBinPurgeAt the time when the deleted content is purged, 0 means it is kept forever
+func recycleBinPurgeAt(deletedAt time.Time, retentionDays int) int64 {
+	if retentionDays <= 0 {
+		return 0
+	}
+	return deletedAt.AddDate(0, 0, retentionDays).Unix()
+}
@@ -0,0 +1,348 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package pending_deletion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/apache/incubator-answer/internal/service/object_info"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPendingDeletionRepo struct {
+	PendingDeletionRepo
+	pendingDeletion *entity.PendingDeletion
+	expired         []*entity.PendingDeletion
+	deletedObjects  map[string]*entity.DeletedObject
+	statuses        map[int]int
+	recycleBin      []*entity.PendingDeletion
+	purged          []string
+}
+
+func (r *testPendingDeletionRepo) GetRecycleBinPage(_ context.Context, _, _ int, objectType string) (
+	[]*entity.PendingDeletion, int64, error) {
+	var pendingDeletions []*entity.PendingDeletion
+	for _, pendingDeletion := range r.recycleBin {
+		if len(objectType) == 0 || pendingDeletion.ObjectType == objectType {
+			pendingDeletions = append(pendingDeletions, pendingDeletion)
+		}
+	}
+	return pendingDeletions, int64(len(pendingDeletions)), nil
+}
+
+func (r *testPendingDeletionRepo) GetPurgeableDeletions(_ context.Context, _ time.Time, afterID, limit int) (
+	[]*entity.PendingDeletion, error) {
+	var pendingDeletions []*entity.PendingDeletion
+	for _, pendingDeletion := range r.recycleBin {
+		if pendingDeletion.ID > afterID && len(pendingDeletions) < limit {
+			pendingDeletions = append(pendingDeletions, pendingDeletion)
+		}
+	}
+	return pendingDeletions, nil
+}
+
+func (r *testPendingDeletionRepo) PurgeDeletedObject(_ context.Context, pendingDeletion *entity.PendingDeletion) (
+	bool, []string, error) {
+	r.purged = append(r.purged, pendingDeletion.ObjectID)
+	var answerUserIDs []string
+	if pendingDeletion.ObjectType == constant.AnswerObjectType {
+		answerUserIDs = append(answerUserIDs, r.deletedObjects[pendingDeletion.ObjectID].UserID)
+	}
+	return true, answerUserIDs, nil
+}
+
+func (r *testPendingDeletionRepo) UpdatePendingDeletionStatus(_ context.Context, id int, status int) error {
+	if r.statuses == nil {
+		r.statuses = make(map[int]int)
+	}
+	r.statuses[id] = status
+	return nil
+}
+
+func (r *testPendingDeletionRepo) FinalizeExpiredPendingDeletions(_ context.Context, _ time.Time) (
+	[]*entity.PendingDeletion, error) {
+	finalized := r.expired
+	r.expired = nil
+	return finalized, nil
+}
+
+func (r *testPendingDeletionRepo) GetDeletedObjects(_ context.Context, questionIDs, answerIDs, commentIDs []string) (
+	map[string]*entity.DeletedObject, error) {
+	objects := make(map[string]*entity.DeletedObject)
+	for _, id := range append(append(questionIDs, answerIDs...), commentIDs...) {
+		if object, ok := r.deletedObjects[id]; ok {
+			objects[id] = object
+		}
+	}
+	return objects, nil
+}
+
+func (r *testPendingDeletionRepo) GetPendingDeletion(_ context.Context, objectID string) (
+	*entity.PendingDeletion, bool, error) {
+	if r.pendingDeletion == nil || r.pendingDeletion.ObjectID != objectID {
+		return nil, false, nil
+	}
+	return r.pendingDeletion, true, nil
+}
+
+func TestGetUndoableDeletion(t *testing.T) {
+	repo := &testPendingDeletionRepo{pendingDeletion: &entity.PendingDeletion{
+		ObjectID:  "10020000000000001",
+		UserID:    "1",
+		ExpiredAt: time.Now().Add(time.Minute),
+	}}
+	ps := NewPendingDeletionService(repo, nil, nil, nil, nil, nil)
+
+	_, err := ps.GetUndoableDeletion(context.TODO(), &schema.UndoDeleteReq{ObjectID: "10020000000000001", UserID: "1"})
+	assert.NoError(t, err)
+
+	// only the actor can undo it
+	_, err = ps.GetUndoableDeletion(context.TODO(), &schema.UndoDeleteReq{ObjectID: "10020000000000001", UserID: "2"})
+	assert.Error(t, err)
+
+	_, err = ps.GetUndoableDeletion(context.TODO(), &schema.UndoDeleteReq{ObjectID: "10020000000000002", UserID: "1"})
+	assert.Error(t, err)
+
+	repo.pendingDeletion.ExpiredAt = time.Now().Add(-time.Second)
+	_, err = ps.GetUndoableDeletion(context.TODO(), &schema.UndoDeleteReq{ObjectID: "10020000000000001", UserID: "1"})
+	assert.Error(t, err)
+}
+
+func TestMarkUndone(t *testing.T) {
+	repo := &testPendingDeletionRepo{}
+	ps := NewPendingDeletionService(repo, nil, nil, nil, nil, nil)
+
+	err := ps.MarkUndone(context.TODO(), &entity.PendingDeletion{ID: 3, Status: entity.PendingDeletionStatusPending})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]int{3: entity.PendingDeletionStatusUndone}, repo.statuses)
+}
+
+func TestFinalizeCron(t *testing.T) {
+	repo := &testPendingDeletionRepo{
+		expired: []*entity.PendingDeletion{
+			{ID: 1, ObjectID: "10010000000000001", ObjectType: constant.QuestionObjectType, UserID: "1"},
+			{ID: 2, ObjectID: "10020000000000001", ObjectType: constant.AnswerObjectType, UserID: "2"},
+			{ID: 3, ObjectID: "10070000000000001", ObjectType: constant.CommentObjectType, UserID: "3"},
+			{ID: 4, ObjectID: "10070000000000002", ObjectType: constant.CommentObjectType, UserID: "3"},
+			// the comment is purged already
+			{ID: 5, ObjectID: "10070000000000003", ObjectType: constant.CommentObjectType, UserID: "3"},
+		},
+		deletedObjects: map[string]*entity.DeletedObject{
+			"10070000000000001": {ObjectID: "10070000000000001", ParentID: "10010000000000002"},
+			"10070000000000002": {ObjectID: "10070000000000002", ParentID: "10020000000000002"},
+		},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	activityQueue := mock.NewMockActivityQueueService(ctl)
+	// nothing is finalized twice, so the messages are sent only once
+	gomock.InOrder(
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "1", TriggerUserID: 1,
+			ObjectID: "10010000000000001", OriginalObjectID: "10010000000000001",
+			ActivityTypeKey: constant.ActQuestionDeletionFinalized}),
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "2", TriggerUserID: 2,
+			ObjectID: "10020000000000001", OriginalObjectID: "10020000000000001",
+			ActivityTypeKey: constant.ActAnswerDeletionFinalized}),
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "3", TriggerUserID: 3,
+			ObjectID: "10070000000000001", OriginalObjectID: "10010000000000002",
+			ActivityTypeKey: constant.ActQuestionCommentDeletionFinalized}),
+		activityQueue.EXPECT().Send(gomock.Any(), &schema.ActivityMsg{UserID: "3", TriggerUserID: 3,
+			ObjectID: "10070000000000002", OriginalObjectID: "10020000000000002",
+			ActivityTypeKey: constant.ActAnswerCommentDeletionFinalized}),
+	)
+	ps := NewPendingDeletionService(repo, nil, nil, nil, nil, activityQueue)
+
+	ps.FinalizeCron(context.TODO())
+	ps.FinalizeCron(context.TODO())
+}
+
+func newTestSiteInfoService(ctl *gomock.Controller, siteWrite *schema.SiteWriteResp) *mock.MockSiteInfoCommonService {
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteWrite(gomock.Any()).AnyTimes().Return(siteWrite, nil)
+	siteInfoService.EXPECT().FormatListAvatar(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userList []*entity.User) map[string]*schema.AvatarInfo {
+			mapping := make(map[string]*schema.AvatarInfo)
+			for _, user := range userList {
+				mapping[user.ID] = &schema.AvatarInfo{}
+			}
+			return mapping
+		})
+	return siteInfoService
+}
+
+// newTestUserRepo the answer counts refreshed by the service are recorded in the given map
+func newTestUserRepo(ctl *gomock.Controller, answerCounts map[string]int) *mock.MockUserRepo {
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().BatchGetByID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []string) ([]*entity.User, error) {
+			users := make([]*entity.User, 0, len(ids))
+			for _, id := range ids {
+				users = append(users, &entity.User{ID: id, Username: "user" + id, Status: entity.UserStatusAvailable})
+			}
+			return users, nil
+		})
+	userRepo.EXPECT().UpdateAnswerCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string, count int) error {
+			answerCounts[userID] = count
+			return nil
+		})
+	return userRepo
+}
+
+type testLegalHoldRepo struct {
+	legal_hold.LegalHoldRepo
+	held map[string]bool
+}
+
+func (r *testLegalHoldRepo) GetActiveLegalHolds(_ context.Context, objectIDs []string) ([]*entity.LegalHold, error) {
+	var holds []*entity.LegalHold
+	for _, objectID := range objectIDs {
+		if r.held[objectID] {
+			holds = append(holds, &entity.LegalHold{ID: 1, ObjectID: objectID})
+		}
+	}
+	return holds, nil
+}
+
+func (r *testLegalHoldRepo) AddLegalHoldLog(context.Context, *entity.LegalHoldLog) error {
+	return nil
+}
+
+func TestGetRecycleBinPage(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := &testPendingDeletionRepo{
+		recycleBin: []*entity.PendingDeletion{
+			{ID: 1, ObjectID: "10010000000000001", ObjectType: constant.QuestionObjectType, UserID: "1",
+				Reason: "spam", CreatedAt: deletedAt},
+			{ID: 2, ObjectID: "10070000000000001", ObjectType: constant.CommentObjectType, UserID: "2",
+				CreatedAt: deletedAt},
+		},
+		deletedObjects: map[string]*entity.DeletedObject{
+			"10010000000000001": {ObjectID: "10010000000000001", QuestionID: "10010000000000001", UserID: "3",
+				Title: "title", ParsedText: "<p>content</p>"},
+		},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	siteInfoService := newTestSiteInfoService(ctl, &schema.SiteWriteResp{RecycleBinRetentionDays: 30})
+	userCommon := usercommon.NewUserCommon(newTestUserRepo(ctl, make(map[string]int)), nil, nil, siteInfoService)
+	ps := NewPendingDeletionService(repo, siteInfoService, nil, userCommon, nil, nil)
+
+	pageModel, err := ps.GetRecycleBinPage(context.TODO(), &schema.GetRecycleBinPageReq{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pageModel.Count)
+	list := pageModel.List.([]*schema.RecycleBinItem)
+	assert.Equal(t, "10010000000000001", list[0].ObjectID)
+	assert.Equal(t, "title", list[0].Title)
+	assert.Equal(t, "content", list[0].Excerpt)
+	assert.Equal(t, "spam", list[0].Reason)
+	assert.Equal(t, "user1", list[0].Deleter.Username)
+	assert.Equal(t, "user3", list[0].Author.Username)
+	assert.Equal(t, deletedAt.Unix(), list[0].DeletedAt)
+	assert.Equal(t, deletedAt.AddDate(0, 0, 30).Unix(), list[0].PurgeAt)
+	// the comment is purged already, only the deletion is listed
+	assert.Equal(t, "10070000000000001", list[1].ObjectID)
+	assert.Equal(t, "user2", list[1].Deleter.Username)
+	assert.Nil(t, list[1].Author)
+
+	pageModel, err = ps.GetRecycleBinPage(context.TODO(), &schema.GetRecycleBinPageReq{
+		ObjectType: constant.CommentObjectType})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pageModel.Count)
+}
+
+func TestPurgeCron(t *testing.T) {
+	repo := &testPendingDeletionRepo{
+		recycleBin: []*entity.PendingDeletion{
+			{ID: 1, ObjectID: "10010000000000001", ObjectType: constant.QuestionObjectType, UserID: "1"},
+			{ID: 2, ObjectID: "10020000000000001", ObjectType: constant.AnswerObjectType, UserID: "1"},
+			{ID: 3, ObjectID: "10020000000000002", ObjectType: constant.AnswerObjectType, UserID: "1"},
+		},
+		deletedObjects: map[string]*entity.DeletedObject{
+			"10020000000000001": {ObjectID: "10020000000000001", UserID: "2"},
+			"10020000000000002": {ObjectID: "10020000000000002", UserID: "3"},
+		},
+	}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	answers := map[string]*entity.Answer{
+		"10020000000000001": {ID: "10020000000000001", QuestionID: "10010000000000002", UserID: "2",
+			Status: entity.AnswerStatusDeleted},
+		"10020000000000002": {ID: "10020000000000002", QuestionID: "10010000000000002", UserID: "3",
+			Status: entity.AnswerStatusDeleted},
+		"10020000000000003": {ID: "10020000000000003", QuestionID: "10010000000000002", UserID: "2",
+			Status: entity.AnswerStatusAvailable},
+	}
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetAnswer(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*entity.Answer, bool, error) {
+			answer, ok := answers[id]
+			return answer, ok, nil
+		})
+	answerRepo.EXPECT().GetCountByUserID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string) (count int64, err error) {
+			for _, answer := range answers {
+				if answer.UserID == userID && answer.Status == entity.AnswerStatusAvailable {
+					count++
+				}
+			}
+			return count, nil
+		})
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetQuestion(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, id string) (*entity.Question, bool, error) {
+			return &entity.Question{ID: id, UserID: "1"}, true, nil
+		})
+	siteWrite := &schema.SiteWriteResp{RecycleBinRetentionDays: 30}
+	siteInfoService := newTestSiteInfoService(ctl, siteWrite)
+	answerCounts := make(map[string]int)
+	userCommon := usercommon.NewUserCommon(newTestUserRepo(ctl, answerCounts), nil, nil, siteInfoService)
+	objectInfoService := object_info.NewObjService(answerRepo, questionRepo, nil, nil, nil, nil)
+	// the question and the answers of the user 3 are held
+	legalHoldService := legal_hold.NewLegalHoldService(&testLegalHoldRepo{held: map[string]bool{
+		"10010000000000001": true,
+		"3":                 true,
+	}}, objectInfoService, userCommon, nil)
+	ps := NewPendingDeletionService(repo, siteInfoService, answerRepo, userCommon, legalHoldService, nil)
+
+	ps.PurgeCron(context.TODO())
+	assert.Equal(t, []string{"10020000000000001"}, repo.purged)
+	assert.Equal(t, map[string]int{"2": 1}, answerCounts)
+
+	// nothing is purged if the deleted content is kept forever
+	repo.purged = nil
+	siteWrite.RecycleBinRetentionDays = 0
+	ps.PurgeCron(context.TODO())
+	assert.Empty(t, repo.purged)
+}
+
+func TestRecycleBinPurgeAt(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 30, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, int64(0), recycleBinPurgeAt(deletedAt, 0))
+	assert.Equal(t, time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC).Unix(), recycleBinPurgeAt(deletedAt, 31))
+}
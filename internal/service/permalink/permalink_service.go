@@ -0,0 +1,194 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permalink
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// PermalinkRepo permalink repository
+type PermalinkRepo interface {
+	IncrShareVisit(ctx context.Context, objectID, userID string) (err error)
+}
+
+// PermalinkService generate the short links of questions and answers and resolve them to the permalinks
+type PermalinkService struct {
+	permalinkRepo   PermalinkRepo
+	questionRepo    questioncommon.QuestionRepo
+	answerRepo      answercommon.AnswerRepo
+	userRepo        usercommon.UserRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewPermalinkService new permalink service
+func NewPermalinkService(
+	permalinkRepo PermalinkRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	userRepo usercommon.UserRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *PermalinkService {
+	return &PermalinkService{
+		permalinkRepo:   permalinkRepo,
+		questionRepo:    questionRepo,
+		answerRepo:      answerRepo,
+		userRepo:        userRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetShareLink get the short link of the question or answer, the username of the login user is
+// added to the link as referral
+func (ps *PermalinkService) GetShareLink(ctx context.Context, req *schema.GetShareLinkReq) (
+	resp *schema.GetShareLinkResp, err error) {
+	objectID := uid.DeShortID(req.ObjectID)
+	objectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil {
+		return nil, errors.BadRequest(reason.ObjectNotFound)
+	}
+	general, err := ps.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	permalink, err := ps.GetPermalink(ctx, objectType, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.GetShareLinkResp{
+		ObjectType: objectType,
+		ShortID:    uid.EnShortID(objectID),
+		Permalink:  general.SiteUrl + permalink,
+	}
+	if objectType == constant.QuestionObjectType {
+		resp.URL = general.SiteUrl + schema.ShareLinkQuestionPrefix + resp.ShortID
+	} else {
+		resp.URL = general.SiteUrl + schema.ShareLinkAnswerPrefix + resp.ShortID
+	}
+
+	if len(req.UserID) > 0 {
+		userInfo, exist, err := ps.userRepo.GetByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			resp.URL += "?" + url.Values{schema.ShareLinkReferralParam: {userInfo.Username}}.Encode()
+		}
+	}
+	return resp, nil
+}
+
+// ResolveShortLink get the permalink of the short link, and record the visit if the link is shared by user
+func (ps *PermalinkService) ResolveShortLink(ctx context.Context, objectType, shortID, shareUsername string) (
+	permalink string, err error) {
+	objectID := uid.DeShortID(shortID)
+	realObjectType, err := obj.GetObjectTypeStrByObjectID(objectID)
+	if err != nil || realObjectType != objectType {
+		return "", errors.NotFound(reason.ObjectNotFound)
+	}
+	general, err := ps.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return "", err
+	}
+	permalink, err = ps.GetPermalink(ctx, objectType, objectID)
+	if err != nil {
+		return "", err
+	}
+	if len(shareUsername) > 0 {
+		ps.recordShareVisit(ctx, objectID, shareUsername)
+	}
+	return general.SiteUrl + permalink, nil
+}
+
+func (ps *PermalinkService) recordShareVisit(ctx context.Context, objectID, shareUsername string) {
+	userInfo, exist, err := ps.userRepo.GetByUsername(ctx, shareUsername)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist || userInfo.Status != entity.UserStatusAvailable {
+		return
+	}
+	if err = ps.permalinkRepo.IncrShareVisit(ctx, objectID, userInfo.ID); err != nil {
+		log.Errorf("record share visit failed: %s", err)
+	}
+}
+
+// GetPermalink get the path of the question or answer page depends on the permalink setting
+func (ps *PermalinkService) GetPermalink(ctx context.Context, objectType, objectID string) (
+	permalink string, err error) {
+	var questionID, answerID string
+	switch objectType {
+	case constant.QuestionObjectType:
+		questionID = objectID
+	case constant.AnswerObjectType:
+		answerInfo, exist, err := ps.answerRepo.GetAnswer(ctx, objectID)
+		if err != nil {
+			return "", err
+		}
+		if !exist || answerInfo.Status == entity.AnswerStatusDeleted {
+			return "", errors.NotFound(reason.AnswerNotFound)
+		}
+		questionID, answerID = answerInfo.QuestionID, answerInfo.ID
+	default:
+		return "", errors.BadRequest(reason.ObjectNotFound)
+	}
+
+	questionInfo, exist, err := ps.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return "", err
+	}
+	if !exist || questionInfo.Status == entity.QuestionStatusDeleted {
+		return "", errors.NotFound(reason.QuestionNotFound)
+	}
+	siteSeo, err := ps.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if siteSeo.IsShortLink() {
+		questionID = uid.EnShortID(questionID)
+		answerID = uid.EnShortID(answerID)
+	}
+	permalink = "/questions/" + questionID
+	if siteSeo.Permalink == constant.PermalinkQuestionIDAndTitle ||
+		siteSeo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID {
+		permalink += "/" + htmltext.UrlTitle(questionInfo.Title)
+	}
+	if len(answerID) > 0 {
+		permalink += "/" + answerID
+	}
+	return permalink, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// GetAllowedActions get the actions the user can do on the object, the rules are the same as the member actions
+// and the reason is given for each denied action. It returns nil if the permissions are not resolved.
+func GetAllowedActions(ctx context.Context, userID string, object *schema.AllowedActionsObject,
+	per *schema.ObjectActionPermissions) (allowed *schema.AllowedActions) {
+	if per == nil {
+		return nil
+	}
+	lang := handler.GetLangByCtx(ctx)
+	allowed = &schema.AllowedActions{Reasons: make(map[string]string)}
+	actions := map[string]*schema.ActionPermission{
+		schema.AllowedActionEdit:   per.Edit,
+		schema.AllowedActionDelete: per.Delete,
+		schema.AllowedActionClose:  per.Close,
+		schema.AllowedActionVote:   per.Vote,
+	}
+	denyAll := func(tip string) *schema.AllowedActions {
+		for action, actionPer := range actions {
+			if actionPer != nil {
+				allowed.Set(action, false, tip)
+			}
+		}
+		return allowed
+	}
+	if len(userID) == 0 {
+		return denyAll(translator.Tr(lang, reason.UnauthorizedError))
+	}
+	if object.Deleted {
+		return denyAll(translator.Tr(lang, reason.NewObjectAlreadyDeleted))
+	}
+
+	isOwner := userID == object.CreatorUserID
+	if per.Edit != nil {
+		ownerCanEdit := isOwner && (object.OwnerEditDeadline.IsZero() || time.Now().Before(object.OwnerEditDeadline))
+		switch {
+		case per.Edit.Can || ownerCanEdit:
+			allowed.Set(schema.AllowedActionEdit, true, "")
+		case isOwner:
+			allowed.Set(schema.AllowedActionEdit, false, translator.Tr(lang, reason.CommentCannotEditAfterDeadline))
+		default:
+			allowed.Set(schema.AllowedActionEdit, false, schema.NoPermissionTip(lang, per.Edit.RequireRank))
+		}
+	}
+	if per.Delete != nil {
+		allowed.Set(schema.AllowedActionDelete, per.Delete.Can || isOwner,
+			schema.NoPermissionTip(lang, per.Delete.RequireRank))
+	}
+	if per.Close != nil {
+		if object.Closed {
+			allowed.Set(schema.AllowedActionClose, false, translator.Tr(lang, reason.QuestionAlreadyClosed))
+		} else {
+			allowed.Set(schema.AllowedActionClose, per.Close.Can, schema.NoPermissionTip(lang, per.Close.RequireRank))
+		}
+	}
+	if per.Vote != nil {
+		if isOwner {
+			allowed.Set(schema.AllowedActionVote, false, translator.Tr(lang, reason.DisallowVoteYourSelf))
+		} else {
+			allowed.Set(schema.AllowedActionVote, per.Vote.Can, schema.NoPermissionTip(lang, per.Vote.RequireRank))
+		}
+	}
+	return allowed
+}
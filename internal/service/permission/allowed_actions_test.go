@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func newObjectActionPermissions(can bool) *schema.ObjectActionPermissions {
+	return &schema.ObjectActionPermissions{
+		Edit:   &schema.ActionPermission{Can: can},
+		Delete: &schema.ActionPermission{Can: can},
+		Close:  &schema.ActionPermission{Can: can},
+		Vote:   &schema.ActionPermission{Can: can},
+	}
+}
+
+func TestGetAllowedActions(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, GetAllowedActions(ctx, "1", &schema.AllowedActionsObject{}, nil))
+
+	allowed := GetAllowedActions(ctx, "", &schema.AllowedActionsObject{CreatorUserID: "1"},
+		newObjectActionPermissions(true))
+	assert.False(t, allowed.CanEdit || allowed.CanDelete || allowed.CanClose || allowed.CanVote)
+	assert.Equal(t, reason.UnauthorizedError, allowed.Reasons[schema.AllowedActionVote])
+
+	// the owner can edit and delete but can not vote
+	allowed = GetAllowedActions(ctx, "1", &schema.AllowedActionsObject{CreatorUserID: "1"},
+		newObjectActionPermissions(false))
+	assert.True(t, allowed.CanEdit)
+	assert.True(t, allowed.CanDelete)
+	assert.False(t, allowed.CanClose)
+	assert.Equal(t, reason.RankFailToMeetTheCondition, allowed.Reasons[schema.AllowedActionClose])
+	assert.Equal(t, reason.DisallowVoteYourSelf, allowed.Reasons[schema.AllowedActionVote])
+	assert.NotContains(t, allowed.Reasons, schema.AllowedActionEdit)
+
+	// the owner can not edit the comment after the deadline
+	per := newObjectActionPermissions(false)
+	per.Close = nil
+	allowed = GetAllowedActions(ctx, "1", &schema.AllowedActionsObject{CreatorUserID: "1",
+		OwnerEditDeadline: time.Now().Add(-time.Minute)}, per)
+	assert.False(t, allowed.CanEdit)
+	assert.Equal(t, reason.CommentCannotEditAfterDeadline, allowed.Reasons[schema.AllowedActionEdit])
+	assert.NotContains(t, allowed.Reasons, schema.AllowedActionClose)
+
+	allowed = GetAllowedActions(ctx, "2", &schema.AllowedActionsObject{CreatorUserID: "1", Closed: true},
+		newObjectActionPermissions(true))
+	assert.True(t, allowed.CanEdit && allowed.CanDelete && allowed.CanVote)
+	assert.False(t, allowed.CanClose)
+	assert.Equal(t, reason.QuestionAlreadyClosed, allowed.Reasons[schema.AllowedActionClose])
+
+	allowed = GetAllowedActions(ctx, "2", &schema.AllowedActionsObject{CreatorUserID: "1", Deleted: true},
+		newObjectActionPermissions(true))
+	assert.False(t, allowed.CanEdit || allowed.CanDelete || allowed.CanClose || allowed.CanVote)
+	assert.Equal(t, reason.NewObjectAlreadyDeleted, allowed.Reasons[schema.AllowedActionEdit])
+}
@@ -62,6 +62,13 @@ const (
 	AnswerUnDelete              = "answer.undeleted"
 	QuestionUnDelete            = "question.undeleted"
 	TagUnDelete                 = "tag.undeleted"
+	QuestionAssign              = "question.assign"
+	ArticleManage               = "article.manage"
+	VoteViewVoters              = "vote.view_voters"
+	ReportReview                = "report.review"
+	AnalyticsView               = "analytics.view"
+	AIAssist                    = "ai.assist"
+	AnswerConvert               = "answer.convert"
 )
 
 const (
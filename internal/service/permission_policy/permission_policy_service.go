@@ -0,0 +1,223 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission_policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/config"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const rankConfigPrefix = "rank."
+
+var routeMethods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodDelete: true,
+}
+
+// PermissionPolicyService the central policy engine of the permissions, the rules are configured at runtime.
+// The route rules are checked by the middleware and the action rules are checked with the privileges.
+type PermissionPolicyService struct {
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	configService   *config.ConfigService
+	roleService     *role.RoleService
+	userCommon      *usercommon.UserCommon
+}
+
+// NewPermissionPolicyService new permission policy service
+func NewPermissionPolicyService(
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	configService *config.ConfigService,
+	roleService *role.RoleService,
+	userCommon *usercommon.UserCommon,
+) *PermissionPolicyService {
+	return &PermissionPolicyService{
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		configService:   configService,
+		roleService:     roleService,
+		userCommon:      userCommon,
+	}
+}
+
+// GetPermissionPolicy get the permission policy
+func (ps *PermissionPolicyService) GetPermissionPolicy(ctx context.Context) (resp *schema.SitePermissionPolicyResp, err error) {
+	resp = &schema.SitePermissionPolicyResp{}
+	if err = ps.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypePermissionPolicy, resp); err != nil {
+		return nil, err
+	}
+	if resp.Rules == nil {
+		resp.Rules = make([]*schema.PermissionPolicyRule, 0)
+	}
+	return resp, nil
+}
+
+// UpdatePermissionPolicy check and replace all the rules of the permission policy
+func (ps *PermissionPolicyService) UpdatePermissionPolicy(ctx context.Context, req *schema.SitePermissionPolicyReq) (err error) {
+	roleMapping, err := ps.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	keys := make(map[string]bool, len(req.Rules))
+	for _, rule := range req.Rules {
+		rule.Route = normalizeRoute(rule.Route)
+		rule.Action = strings.TrimSpace(rule.Action)
+		if !ps.checkRule(ctx, rule, roleMapping) {
+			return errors.BadRequest(reason.PermissionPolicyRuleInvalid)
+		}
+		key := rule.Route + rule.Action
+		if keys[key] {
+			return errors.BadRequest(reason.PermissionPolicyRuleInvalid)
+		}
+		keys[key] = true
+	}
+
+	content, _ := json.Marshal(req)
+	return ps.siteInfoRepo.SaveByType(ctx, constant.SiteTypePermissionPolicy, &entity.SiteInfo{
+		Type:    constant.SiteTypePermissionPolicy,
+		Content: string(content),
+		Status:  1,
+	})
+}
+
+// GetActionRule get the rule of the action, nil means the action is not in the policy
+func (ps *PermissionPolicyService) GetActionRule(ctx context.Context, action string) (rule *schema.PermissionPolicyRule) {
+	return ps.getRule(ctx, func(rule *schema.PermissionPolicyRule) bool {
+		return len(rule.Action) > 0 && rule.Action == action
+	})
+}
+
+// GetRouteRule get the rule of the api route, nil means the route is not in the policy
+func (ps *PermissionPolicyService) GetRouteRule(ctx context.Context, method, path string) (rule *schema.PermissionPolicyRule) {
+	route := method + " " + path
+	return ps.getRule(ctx, func(rule *schema.PermissionPolicyRule) bool {
+		return len(rule.Route) > 0 && rule.Route == route
+	})
+}
+
+// CheckRouteRule check whether the user meets the rule of the route, the admin is always allowed
+func (ps *PermissionPolicyService) CheckRouteRule(ctx context.Context, rule *schema.PermissionPolicyRule,
+	userID string, roleID int) (can bool, err error) {
+	if roleID == role.RoleAdminID {
+		return true, nil
+	}
+	if !MatchRole(rule, roleID) {
+		return false, nil
+	}
+	if rule.MinRank == nil {
+		return true, nil
+	}
+	userInfo, exist, err := ps.userCommon.GetUserBasicInfoByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if !exist {
+		return false, nil
+	}
+	return MeetRank(*rule.MinRank, userInfo.Rank), nil
+}
+
+// MatchRole whether the role is allowed by the rule
+func MatchRole(rule *schema.PermissionPolicyRule, roleID int) bool {
+	if len(rule.RoleIDs) == 0 {
+		return true
+	}
+	for _, id := range rule.RoleIDs {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// MeetRank whether the reputation meets the minimum reputation, -1 means nobody meets it
+func MeetRank(minRank, userRank int) bool {
+	return minRank >= 0 && userRank >= minRank
+}
+
+func (ps *PermissionPolicyService) getRule(ctx context.Context,
+	match func(rule *schema.PermissionPolicyRule) bool) *schema.PermissionPolicyRule {
+	policy, err := ps.GetPermissionPolicy(ctx)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	for _, rule := range policy.Rules {
+		if match(rule) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// checkRule either the route or the action should be set, the action must be one of the privileges
+func (ps *PermissionPolicyService) checkRule(ctx context.Context, rule *schema.PermissionPolicyRule,
+	roleMapping map[int]*entity.Role) bool {
+	if (len(rule.Route) == 0) == (len(rule.Action) == 0) {
+		return false
+	}
+	if len(rule.Route) > 0 && !checkRoute(rule.Route) {
+		return false
+	}
+	if len(rule.Action) > 0 {
+		if _, err := ps.configService.GetConfigByKey(ctx, rankConfigPrefix+rule.Action); err != nil {
+			return false
+		}
+	}
+	for _, roleID := range rule.RoleIDs {
+		if roleMapping[roleID] == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeRoute format the route as "METHOD /path"
+func normalizeRoute(route string) string {
+	fields := strings.Fields(route)
+	if len(fields) != 2 {
+		return strings.TrimSpace(route)
+	}
+	return strings.ToUpper(fields[0]) + " " + fields[1]
+}
+
+func checkRoute(route string) bool {
+	fields := strings.Fields(route)
+	if len(fields) != 2 {
+		return false
+	}
+	return routeMethods[fields[0]] && strings.HasPrefix(fields[1], "/")
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package permission_policy
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRole(t *testing.T) {
+	assert.True(t, MatchRole(&schema.PermissionPolicyRule{}, 1))
+	assert.True(t, MatchRole(&schema.PermissionPolicyRule{RoleIDs: []int{3, 4}}, 4))
+	assert.False(t, MatchRole(&schema.PermissionPolicyRule{RoleIDs: []int{3, 4}}, 1))
+}
+
+func TestMeetRank(t *testing.T) {
+	assert.True(t, MeetRank(0, 1))
+	assert.True(t, MeetRank(10, 10))
+	assert.False(t, MeetRank(10, 9))
+	assert.False(t, MeetRank(-1, 1000))
+}
+
+func TestRoute(t *testing.T) {
+	assert.Equal(t, "POST /answer/api/v1/question", normalizeRoute(" post   /answer/api/v1/question "))
+	assert.True(t, checkRoute(normalizeRoute("post /answer/api/v1/question")))
+	assert.False(t, checkRoute("PATCH /answer/api/v1/question"))
+	assert.False(t, checkRoute("POST answer/api/v1/question"))
+	assert.False(t, checkRoute("/answer/api/v1/question"))
+}
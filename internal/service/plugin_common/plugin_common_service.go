@@ -22,8 +22,14 @@ package plugin_common
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
 	"github.com/apache/incubator-answer/internal/repo/search_sync"
 
 	"github.com/segmentfault/pacman/errors"
@@ -35,10 +41,12 @@ import (
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/plugin"
+	"github.com/gin-gonic/gin"
 )
 
 type PluginConfigRepo interface {
 	SavePluginConfig(ctx context.Context, pluginSlugName, configValue string) (err error)
+	GetPluginConfig(ctx context.Context, pluginSlugName string) (pluginConfig *entity.PluginConfig, exist bool, err error)
 	GetPluginConfigAll(ctx context.Context) (pluginConfigs []*entity.PluginConfig, err error)
 }
 
@@ -55,6 +63,7 @@ type PluginCommonService struct {
 	configService        *config.ConfigService
 	pluginConfigRepo     PluginConfigRepo
 	pluginUserConfigRepo PluginUserConfigRepo
+	pluginKVStorageRepo  PluginKVStorageRepo
 	data                 *data.Data
 }
 
@@ -62,6 +71,7 @@ type PluginCommonService struct {
 func NewPluginCommonService(
 	pluginConfigRepo PluginConfigRepo,
 	pluginUserConfigRepo PluginUserConfigRepo,
+	pluginKVStorageRepo PluginKVStorageRepo,
 	configService *config.ConfigService,
 	data *data.Data,
 ) *PluginCommonService {
@@ -70,6 +80,7 @@ func NewPluginCommonService(
 		configService:        configService,
 		pluginConfigRepo:     pluginConfigRepo,
 		pluginUserConfigRepo: pluginUserConfigRepo,
+		pluginKVStorageRepo:  pluginKVStorageRepo,
 		data:                 data,
 	}
 	p.initPluginData()
@@ -85,12 +96,50 @@ func (ps *PluginCommonService) UpdatePluginStatus(ctx context.Context) (err erro
 	return ps.configService.UpdateConfig(ctx, constant.PluginStatus, string(content))
 }
 
-// UpdatePluginConfig update plugin config
-func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *schema.UpdatePluginConfigReq) (err error) {
+// UpdatePluginConfig validate and update plugin config, the plugin receives the new config without restarting
+func (ps *PluginCommonService) UpdatePluginConfig(ctx *gin.Context, req *schema.UpdatePluginConfigReq) (
+	errFields []*validator.FormErrorField, err error) {
+	var pluginConfig plugin.Config
+	_ = plugin.CallConfig(func(fn plugin.Config) error {
+		if fn.Info().SlugName == req.PluginSlugName {
+			pluginConfig = fn
+		}
+		return nil
+	})
+	if pluginConfig == nil {
+		return nil, errors.BadRequest(reason.PluginNotFound)
+	}
+
 	configValue, _ := json.Marshal(req.ConfigFields)
+	errFields = checkConfigFields(ctx, pluginConfig.ConfigFields(), req.ConfigFields)
+	if configValidator, ok := pluginConfig.(plugin.ConfigValidator); ok {
+		for _, fieldErr := range configValidator.ValidateConfig(configValue) {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: fieldErr.Name,
+				ErrorMsg:   fieldErr.Message.Translate(ctx),
+			})
+		}
+	}
+	if len(errFields) > 0 {
+		return errFields, errors.BadRequest(reason.PluginConfigValidationFailed)
+	}
+
+	oldConfig, _, err := ps.pluginConfigRepo.GetPluginConfig(ctx, req.PluginSlugName)
+	if err != nil {
+		return nil, err
+	}
+	if err = pluginConfig.ConfigReceiver(configValue); err != nil {
+		return nil, errors.BadRequest(reason.PluginConfigValidationFailed).WithError(err).WithMsg(err.Error())
+	}
 	err = ps.pluginConfigRepo.SavePluginConfig(ctx, req.PluginSlugName, string(configValue))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if notifier, ok := pluginConfig.(plugin.ConfigChangeNotifier); ok {
+		if err := notifier.ConfigChanged([]byte(oldConfig.Value), configValue); err != nil {
+			log.Errorf("plugin %s handle config changed failed: %v", req.PluginSlugName, err)
+		}
 	}
 
 	_ = plugin.CallSearch(func(search plugin.Search) error {
@@ -99,7 +148,83 @@ func (ps *PluginCommonService) UpdatePluginConfig(ctx context.Context, req *sche
 		}
 		return nil
 	})
-	return nil
+	return nil, nil
+}
+
+// checkConfigFields check the config values by the definition of the config fields
+func checkConfigFields(ctx *gin.Context, fields []plugin.ConfigField, values map[string]any) (
+	errFields []*validator.FormErrorField) {
+	lang := handler.GetLang(ctx)
+	for _, field := range fields {
+		if field.Type == plugin.ConfigTypeButton || field.Type == plugin.ConfigTypeLegend {
+			continue
+		}
+		value, ok := values[field.Name]
+		if !ok || isEmptyConfigValue(value) {
+			if field.Required {
+				errFields = append(errFields, &validator.FormErrorField{
+					ErrorField: field.Name,
+					ErrorMsg:   translator.Tr(lang, reason.PluginConfigFieldRequired),
+				})
+			}
+			continue
+		}
+
+		var errReason string
+		switch field.Type {
+		case plugin.ConfigTypeSelect, plugin.ConfigTypeRadio:
+			if !isConfigOptionValue(field.Options, fmt.Sprint(value)) {
+				errReason = reason.PluginConfigFieldInvalidOption
+			}
+		case plugin.ConfigTypeCheckbox:
+			if items, ok := value.([]any); ok {
+				for _, item := range items {
+					if !isConfigOptionValue(field.Options, fmt.Sprint(item)) {
+						errReason = reason.PluginConfigFieldInvalidOption
+						break
+					}
+				}
+			}
+		case plugin.ConfigTypeInput:
+			if field.UIOptions.InputType == plugin.InputTypeNumber {
+				if _, err := strconv.ParseFloat(fmt.Sprint(value), 64); err != nil {
+					errReason = reason.PluginConfigFieldInvalidNumber
+				}
+			}
+		}
+		if len(errReason) > 0 {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: field.Name,
+				ErrorMsg:   translator.Tr(lang, errReason),
+			})
+		}
+	}
+	return errFields
+}
+
+func isEmptyConfigValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return len(strings.TrimSpace(v)) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// isConfigOptionValue check whether the value is one of the options, the field without options accepts any value
+func isConfigOptionValue(options []plugin.ConfigFieldOption, value string) bool {
+	if len(options) == 0 {
+		return true
+	}
+	for _, option := range options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
 }
 
 // UpdatePluginUserConfig update plugin config
@@ -136,6 +261,9 @@ func (ps *PluginCommonService) initPluginData() {
 		}
 	}
 
+	// init plugin storage
+	ps.initPluginStorage()
+
 	// init plugin config
 	pluginConfigs, err := ps.pluginConfigRepo.GetPluginConfigAll(context.Background())
 	if err != nil {
@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_common
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckConfigFields(t *testing.T) {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("PUT", "/", nil)
+	fields := []plugin.ConfigField{
+		{Name: "endpoint", Type: plugin.ConfigTypeInput, Required: true},
+		{Name: "timeout", Type: plugin.ConfigTypeInput, UIOptions: plugin.ConfigFieldUIOptions{InputType: plugin.InputTypeNumber}},
+		{Name: "mode", Type: plugin.ConfigTypeSelect, Options: []plugin.ConfigFieldOption{{Value: "fast"}, {Value: "safe"}}},
+		{Name: "test", Type: plugin.ConfigTypeButton, Required: true},
+	}
+
+	errFields := checkConfigFields(ctx, fields, map[string]any{
+		"endpoint": "https://example.com",
+		"timeout":  "30",
+		"mode":     "safe",
+	})
+	assert.Empty(t, errFields)
+
+	errFields = checkConfigFields(ctx, fields, map[string]any{
+		"endpoint": " ",
+		"timeout":  "thirty",
+		"mode":     "unknown",
+	})
+	assert.Len(t, errFields, 3)
+	assert.Equal(t, "endpoint", errFields[0].ErrorField)
+	assert.Equal(t, reason.PluginConfigFieldRequired, errFields[0].ErrorMsg)
+	assert.Equal(t, reason.PluginConfigFieldInvalidNumber, errFields[1].ErrorMsg)
+	assert.Equal(t, reason.PluginConfigFieldInvalidOption, errFields[2].ErrorMsg)
+}
@@ -0,0 +1,189 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/gin-gonic/gin"
+	"github.com/robfig/cron/v3"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+type PluginJobRepo interface {
+	GetPluginJob(ctx context.Context, pluginSlugName, jobName string) (job *entity.PluginJob, exist bool, err error)
+	GetPluginJobList(ctx context.Context, pluginSlugName string) (jobs []*entity.PluginJob, err error)
+	UpdatePluginJobStatus(ctx context.Context, pluginSlugName, jobName string, disabled bool) (err error)
+	UpdatePluginJobLastRun(ctx context.Context, job *entity.PluginJob) (err error)
+}
+
+// PluginJobService run the scheduled jobs provided by plugins
+type PluginJobService struct {
+	pluginJobRepo PluginJobRepo
+}
+
+// NewPluginJobService new plugin job service
+func NewPluginJobService(pluginJobRepo PluginJobRepo) *PluginJobService {
+	return &PluginJobService{
+		pluginJobRepo: pluginJobRepo,
+	}
+}
+
+// RegisterPluginJobs add all plugin jobs to the scheduler. The jobs of disabled plugins are also added,
+// whether the plugin and the job are enabled is checked every time the job is triggered.
+func (ps *PluginJobService) RegisterPluginJobs(c *cron.Cron) {
+	_ = plugin.CallBase(func(base plugin.Base) error {
+		scheduler, ok := base.(plugin.Scheduler)
+		if !ok {
+			return nil
+		}
+		slugName := base.Info().SlugName
+		for _, job := range scheduler.ScheduledJobs() {
+			job := job
+			runner := cron.NewChain(cron.SkipIfStillRunning(cron.DiscardLogger)).Then(cron.FuncJob(func() {
+				ps.runPluginJob(context.Background(), slugName, job)
+			}))
+			if _, err := c.AddJob(job.Schedule, runner); err != nil {
+				log.Errorf("add plugin job %s/%s failed: %v", slugName, job.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetPluginJobList get the scheduled jobs of the plugin with the last run status
+func (ps *PluginJobService) GetPluginJobList(ctx *gin.Context, req *schema.GetPluginJobListReq) (
+	resp []*schema.GetPluginJobResp, err error) {
+	jobs, err := ps.pluginJobRepo.GetPluginJobList(ctx, req.PluginSlugName)
+	if err != nil {
+		return nil, err
+	}
+	jobMapping := make(map[string]*entity.PluginJob, len(jobs))
+	for _, job := range jobs {
+		jobMapping[job.JobName] = job
+	}
+
+	resp = make([]*schema.GetPluginJobResp, 0)
+	for _, job := range getScheduledJobs(req.PluginSlugName) {
+		item := &schema.GetPluginJobResp{
+			Name:     job.Name,
+			Title:    job.Title.Translate(ctx),
+			Schedule: job.Schedule,
+			Enabled:  true,
+		}
+		if record, ok := jobMapping[job.Name]; ok {
+			item.Enabled = !record.Disabled
+			if !record.LastRunAt.IsZero() {
+				item.LastRunAt = record.LastRunAt.Unix()
+			}
+			item.LastDuration = record.LastDuration
+			item.LastStatus = record.LastStatus
+			item.LastError = record.LastError
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// UpdatePluginJobStatus enable or disable the plugin job
+func (ps *PluginJobService) UpdatePluginJobStatus(ctx context.Context, req *schema.UpdatePluginJobStatusReq) (err error) {
+	exist := false
+	for _, job := range getScheduledJobs(req.PluginSlugName) {
+		if job.Name == req.JobName {
+			exist = true
+			break
+		}
+	}
+	if !exist {
+		return errors.BadRequest(reason.PluginJobNotFound)
+	}
+	return ps.pluginJobRepo.UpdatePluginJobStatus(ctx, req.PluginSlugName, req.JobName, !req.Enabled)
+}
+
+// HasPluginJob check whether the plugin provides any scheduled job
+func (ps *PluginJobService) HasPluginJob(pluginSlugName string) bool {
+	return len(getScheduledJobs(pluginSlugName)) > 0
+}
+
+func (ps *PluginJobService) runPluginJob(ctx context.Context, slugName string, job *plugin.ScheduledJob) {
+	if !plugin.StatusManager.IsEnabled(slugName) {
+		return
+	}
+	record, exist, err := ps.pluginJobRepo.GetPluginJob(ctx, slugName, job.Name)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if exist && record.Disabled {
+		return
+	}
+
+	log.Debugf("plugin job %s/%s execution", slugName, job.Name)
+	startTime := time.Now()
+	err = runJobHandler(ctx, job)
+	result := &entity.PluginJob{
+		PluginSlugName: slugName,
+		JobName:        job.Name,
+		LastRunAt:      startTime,
+		LastDuration:   time.Since(startTime).Milliseconds(),
+		LastStatus:     entity.PluginJobRunStatusSuccess,
+	}
+	if err != nil {
+		log.Errorf("plugin job %s/%s failed: %v", slugName, job.Name, err)
+		result.LastStatus = entity.PluginJobRunStatusFailed
+		result.LastError = err.Error()
+	}
+	if err := ps.pluginJobRepo.UpdatePluginJobLastRun(ctx, result); err != nil {
+		log.Error(err)
+	}
+}
+
+// runJobHandler run the job handler and recover from its panic
+func runJobHandler(ctx context.Context, job *plugin.ScheduledJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	if job.Handler == nil {
+		return nil
+	}
+	return job.Handler(ctx)
+}
+
+func getScheduledJobs(pluginSlugName string) (jobs []*plugin.ScheduledJob) {
+	_ = plugin.CallBase(func(base plugin.Base) error {
+		if base.Info().SlugName != pluginSlugName {
+			return nil
+		}
+		if scheduler, ok := base.(plugin.Scheduler); ok {
+			jobs = scheduler.ScheduledJobs()
+		}
+		return nil
+	})
+	return jobs
+}
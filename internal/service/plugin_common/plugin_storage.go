@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin_common
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+type PluginKVStorageRepo interface {
+	plugin.KVStorageBackend
+	GetTableVersion(ctx context.Context, pluginSlugName string) (version int, err error)
+	UpdateTableVersion(ctx context.Context, pluginSlugName string, version int) (err error)
+}
+
+// initPluginStorage give the key-value operator to plugins, sync and migrate the tables of plugins
+func (ps *PluginCommonService) initPluginStorage() {
+	_ = plugin.CallKVStorage(func(fn plugin.KVStorage) error {
+		fn.SetOperator(plugin.NewKVOperator(fn.Info().SlugName, ps.pluginKVStorageRepo))
+		return nil
+	})
+
+	_ = plugin.CallTableStorage(func(fn plugin.TableStorage) error {
+		slugName := fn.Info().SlugName
+		if err := ps.migratePluginTables(context.Background(), fn); err != nil {
+			log.Errorf("migrate plugin tables failed: %s %v", slugName, err)
+			return nil
+		}
+		fn.TableReceiver(ps.data.DB)
+		return nil
+	})
+}
+
+func (ps *PluginCommonService) migratePluginTables(ctx context.Context, fn plugin.TableStorage) (err error) {
+	slugName := fn.Info().SlugName
+	if tables := fn.Tables(); len(tables) > 0 {
+		if err = ps.data.DB.Context(ctx).Sync(tables...); err != nil {
+			return fmt.Errorf("sync tables: %w", err)
+		}
+	}
+
+	currentVersion, err := ps.pluginKVStorageRepo.GetTableVersion(ctx, slugName)
+	if err != nil {
+		return err
+	}
+	migrations := fn.TableMigrations()
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for _, migration := range migrations {
+		if migration.Version <= currentVersion || migration.Migrate == nil {
+			continue
+		}
+		log.Infof("[plugin migrate] %s try to migrate version %d: %s", slugName, migration.Version, migration.Description)
+		if err = migration.Migrate(ctx, ps.data.DB); err != nil {
+			return fmt.Errorf("migrate version %d: %w", migration.Version, err)
+		}
+		if err = ps.pluginKVStorageRepo.UpdateTableVersion(ctx, slugName, migration.Version); err != nil {
+			return err
+		}
+		currentVersion = migration.Version
+	}
+	return nil
+}
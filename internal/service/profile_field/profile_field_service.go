@@ -0,0 +1,298 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package profile_field
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// profileFieldValueMaxLength the max length of the profile field value
+const profileFieldValueMaxLength = 500
+
+// ProfileFieldRepo profile field repository
+type ProfileFieldRepo interface {
+	AddProfileField(ctx context.Context, field *entity.ProfileField) (err error)
+	UpdateProfileField(ctx context.Context, field *entity.ProfileField) (err error)
+	RemoveProfileField(ctx context.Context, id int) (err error)
+	GetProfileField(ctx context.Context, id int) (field *entity.ProfileField, exist bool, err error)
+	GetProfileFieldByName(ctx context.Context, name string) (field *entity.ProfileField, exist bool, err error)
+	GetProfileFieldList(ctx context.Context) (fields []*entity.ProfileField, err error)
+	GetUserProfileFields(ctx context.Context, userID string) (values []*entity.UserProfileField, err error)
+	SaveUserProfileFields(ctx context.Context, userID string, values []*entity.UserProfileField) (err error)
+}
+
+// ProfileFieldService profile field service
+type ProfileFieldService struct {
+	profileFieldRepo ProfileFieldRepo
+}
+
+// NewProfileFieldService new profile field service
+func NewProfileFieldService(profileFieldRepo ProfileFieldRepo) *ProfileFieldService {
+	return &ProfileFieldService{
+		profileFieldRepo: profileFieldRepo,
+	}
+}
+
+// GetProfileFieldList get all profile fields
+func (ps *ProfileFieldService) GetProfileFieldList(ctx context.Context) (resp []*schema.GetProfileFieldResp, err error) {
+	fields, err := ps.profileFieldRepo.GetProfileFieldList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetProfileFieldResp, 0, len(fields))
+	for _, field := range fields {
+		resp = append(resp, convertProfileFieldResp(field))
+	}
+	return resp, nil
+}
+
+// AddProfileField add profile field
+func (ps *ProfileFieldService) AddProfileField(ctx context.Context, req *schema.AddProfileFieldReq) (err error) {
+	if err = ps.checkNameUnique(ctx, req.Name, 0); err != nil {
+		return err
+	}
+	field := &entity.ProfileField{
+		Name:       req.Name,
+		Label:      req.Label,
+		Type:       req.Type,
+		Options:    formatOptions(req.Type, req.Options),
+		Required:   req.Required,
+		Visibility: req.Visibility,
+		Sort:       req.Sort,
+	}
+	return ps.profileFieldRepo.AddProfileField(ctx, field)
+}
+
+// UpdateProfileField update profile field
+func (ps *ProfileFieldService) UpdateProfileField(ctx context.Context, req *schema.UpdateProfileFieldReq) (err error) {
+	field, exist, err := ps.profileFieldRepo.GetProfileField(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.ProfileFieldNotFound)
+	}
+	if err = ps.checkNameUnique(ctx, req.Name, req.ID); err != nil {
+		return err
+	}
+	field.Name = req.Name
+	field.Label = req.Label
+	field.Type = req.Type
+	field.Options = formatOptions(req.Type, req.Options)
+	field.Required = req.Required
+	field.Visibility = req.Visibility
+	field.Sort = req.Sort
+	return ps.profileFieldRepo.UpdateProfileField(ctx, field)
+}
+
+// RemoveProfileField remove profile field and the values of all users
+func (ps *ProfileFieldService) RemoveProfileField(ctx context.Context, req *schema.RemoveProfileFieldReq) (err error) {
+	_, exist, err := ps.profileFieldRepo.GetProfileField(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.ProfileFieldNotFound)
+	}
+	return ps.profileFieldRepo.RemoveProfileField(ctx, req.ID)
+}
+
+// GetUserProfileFieldForm get all profile fields with the values of the user, for the profile edit form
+func (ps *ProfileFieldService) GetUserProfileFieldForm(ctx context.Context, userID string) (
+	resp []*schema.GetUserProfileFieldFormResp, err error) {
+	fields, values, err := ps.getFieldsAndValues(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetUserProfileFieldFormResp, 0, len(fields))
+	for _, field := range fields {
+		resp = append(resp, &schema.GetUserProfileFieldFormResp{
+			GetProfileFieldResp: convertProfileFieldResp(field),
+			Value:               values[field.ID],
+		})
+	}
+	return resp, nil
+}
+
+// GetVisibleUserProfileFields get the profile field values of the user which the viewer can see.
+// The viewer id is empty if the viewer is not logged in, isAdminModerator means the viewer is an admin or a moderator.
+func (ps *ProfileFieldService) GetVisibleUserProfileFields(ctx context.Context, userID, viewerID string,
+	isAdminModerator bool) (
+	resp []*schema.UserProfileFieldResp, err error) {
+	fields, values, err := ps.getFieldsAndValues(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.UserProfileFieldResp, 0)
+	for _, field := range fields {
+		value, ok := values[field.ID]
+		if !ok || !canViewProfileField(field, userID, viewerID, isAdminModerator) {
+			continue
+		}
+		resp = append(resp, &schema.UserProfileFieldResp{
+			Name:  field.Name,
+			Label: field.Label,
+			Type:  field.Type,
+			Value: value,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateUserProfileFields check and save the profile field values of the user.
+// The values of the fields which are not in the request are removed.
+func (ps *ProfileFieldService) UpdateUserProfileFields(ctx context.Context, userID string, values map[string]string) (
+	errFields []*validator.FormErrorField, err error) {
+	fields, err := ps.profileFieldRepo.GetProfileFieldList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userValues := make([]*entity.UserProfileField, 0)
+	for _, field := range fields {
+		value, errMsg := checkProfileFieldValue(field, values[field.Name])
+		if len(errMsg) > 0 {
+			errFields = append(errFields, &validator.FormErrorField{
+				ErrorField: "profile_fields." + field.Name,
+				ErrorMsg:   errMsg,
+			})
+			continue
+		}
+		if len(value) > 0 {
+			userValues = append(userValues, &entity.UserProfileField{UserID: userID, FieldID: field.ID, Value: value})
+		}
+	}
+	if len(errFields) > 0 {
+		return errFields, errors.BadRequest(errFields[0].ErrorMsg)
+	}
+	return nil, ps.profileFieldRepo.SaveUserProfileFields(ctx, userID, userValues)
+}
+
+func (ps *ProfileFieldService) getFieldsAndValues(ctx context.Context, userID string) (
+	fields []*entity.ProfileField, values map[int]string, err error) {
+	fields, err = ps.profileFieldRepo.GetProfileFieldList(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	values = make(map[int]string)
+	if len(fields) == 0 {
+		return fields, values, nil
+	}
+	userValues, err := ps.profileFieldRepo.GetUserProfileFields(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, value := range userValues {
+		values[value.FieldID] = value.Value
+	}
+	return fields, values, nil
+}
+
+func (ps *ProfileFieldService) checkNameUnique(ctx context.Context, name string, fieldID int) (err error) {
+	field, exist, err := ps.profileFieldRepo.GetProfileFieldByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if exist && field.ID != fieldID {
+		return errors.BadRequest(reason.ProfileFieldNameAlreadyExist)
+	}
+	return nil
+}
+
+// checkProfileFieldValue check the value by the field type, return the formatted value or the error reason
+func checkProfileFieldValue(field *entity.ProfileField, value string) (formatted, errMsg string) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		if field.Required {
+			return "", reason.ProfileFieldValueRequired
+		}
+		return "", ""
+	}
+	if utf8.RuneCountInString(value) > profileFieldValueMaxLength {
+		return "", reason.ProfileFieldValueTooLong
+	}
+	switch field.Type {
+	case entity.ProfileFieldTypeURL:
+		if !checker.IsURL(value) {
+			return "", reason.InvalidURLError
+		}
+	case entity.ProfileFieldTypeSelect:
+		for _, option := range parseOptions(field.Options) {
+			if option == value {
+				return value, ""
+			}
+		}
+		return "", reason.ProfileFieldValueInvalidOption
+	case entity.ProfileFieldTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", reason.ProfileFieldValueInvalidBoolean
+		}
+		return strconv.FormatBool(b), ""
+	}
+	return value, ""
+}
+
+func canViewProfileField(field *entity.ProfileField, userID, viewerID string, isAdminModerator bool) bool {
+	switch field.Visibility {
+	case entity.ProfileFieldVisibilityLoggedIn:
+		return len(viewerID) > 0
+	case entity.ProfileFieldVisibilityPrivate:
+		return isAdminModerator || (len(viewerID) > 0 && viewerID == userID)
+	}
+	return true
+}
+
+func formatOptions(fieldType string, options []string) string {
+	if fieldType != entity.ProfileFieldTypeSelect {
+		return "[]"
+	}
+	data, _ := json.Marshal(options)
+	return string(data)
+}
+
+func parseOptions(options string) []string {
+	list := make([]string, 0)
+	_ = json.Unmarshal([]byte(options), &list)
+	return list
+}
+
+func convertProfileFieldResp(field *entity.ProfileField) *schema.GetProfileFieldResp {
+	return &schema.GetProfileFieldResp{
+		ID:         field.ID,
+		Name:       field.Name,
+		Label:      field.Label,
+		Type:       field.Type,
+		Options:    parseOptions(field.Options),
+		Required:   field.Required,
+		Visibility: field.Visibility,
+		Sort:       field.Sort,
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package profile_field
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckProfileFieldValue(t *testing.T) {
+	tests := []struct {
+		field     *entity.ProfileField
+		value     string
+		formatted string
+		errMsg    string
+	}{
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeText}, "", "", ""},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeText, Required: true}, " ", "", reason.ProfileFieldValueRequired},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeText}, " Go ", "Go", ""},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeURL}, "https://answer.apache.org", "https://answer.apache.org", ""},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeURL}, "answer", "", reason.InvalidURLError},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeSelect, Options: `["a","b"]`}, "b", "b", ""},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeSelect, Options: `["a","b"]`}, "c", "", reason.ProfileFieldValueInvalidOption},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeBoolean}, "1", "true", ""},
+		{&entity.ProfileField{Type: entity.ProfileFieldTypeBoolean}, "yes", "", reason.ProfileFieldValueInvalidBoolean},
+	}
+	for _, tt := range tests {
+		formatted, errMsg := checkProfileFieldValue(tt.field, tt.value)
+		assert.Equal(t, tt.formatted, formatted, tt.value)
+		assert.Equal(t, tt.errMsg, errMsg, tt.value)
+	}
+}
+
+func TestCanViewProfileField(t *testing.T) {
+	field := &entity.ProfileField{Visibility: entity.ProfileFieldVisibilityPrivate}
+	assert.True(t, canViewProfileField(field, "1", "1", false))
+	// the admins and the moderators can see the private fields
+	assert.True(t, canViewProfileField(field, "1", "2", true))
+	assert.False(t, canViewProfileField(field, "1", "2", false))
+	assert.False(t, canViewProfileField(field, "1", "", false))
+
+	field = &entity.ProfileField{Visibility: entity.ProfileFieldVisibilityLoggedIn}
+	assert.True(t, canViewProfileField(field, "1", "2", false))
+	assert.False(t, canViewProfileField(field, "1", "", false))
+
+	field = &entity.ProfileField{Visibility: entity.ProfileFieldVisibilityPublic}
+	assert.True(t, canViewProfileField(field, "1", "", false))
+}
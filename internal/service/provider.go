@@ -24,42 +24,109 @@ import (
 	"github.com/apache/incubator-answer/internal/service/activity"
 	"github.com/apache/incubator-answer/internal/service/activity_common"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	"github.com/apache/incubator-answer/internal/service/analytics"
+	"github.com/apache/incubator-answer/internal/service/announcement"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/answer_quality"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
+	"github.com/apache/incubator-answer/internal/service/article"
 	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
+	"github.com/apache/incubator-answer/internal/service/chat_integration"
+	"github.com/apache/incubator-answer/internal/service/co_author"
 	"github.com/apache/incubator-answer/internal/service/collection"
 	collectioncommon "github.com/apache/incubator-answer/internal/service/collection_common"
 	"github.com/apache/incubator-answer/internal/service/comment"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/content_filter"
+	"github.com/apache/incubator-answer/internal/service/content_limit"
+	"github.com/apache/incubator-answer/internal/service/conversion"
 	"github.com/apache/incubator-answer/internal/service/dashboard"
+	"github.com/apache/incubator-answer/internal/service/data_retention"
+	"github.com/apache/incubator-answer/internal/service/dead_link"
+	"github.com/apache/incubator-answer/internal/service/delta_sync"
+	"github.com/apache/incubator-answer/internal/service/edit_lock"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/apache/incubator-answer/internal/service/email_reply"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/event_stream"
+	"github.com/apache/incubator-answer/internal/service/experiment"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
 	"github.com/apache/incubator-answer/internal/service/follow"
+	"github.com/apache/incubator-answer/internal/service/github_integration"
+	"github.com/apache/incubator-answer/internal/service/inbound_question"
+	"github.com/apache/incubator-answer/internal/service/invitation"
+	"github.com/apache/incubator-answer/internal/service/ip_logging"
+	"github.com/apache/incubator-answer/internal/service/jira_integration"
+	"github.com/apache/incubator-answer/internal/service/leaderboard"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/link_preview"
+	"github.com/apache/incubator-answer/internal/service/list_index"
+	"github.com/apache/incubator-answer/internal/service/login_protection"
+	"github.com/apache/incubator-answer/internal/service/maintenance"
+	"github.com/apache/incubator-answer/internal/service/media_proxy"
 	"github.com/apache/incubator-answer/internal/service/meta"
 	"github.com/apache/incubator-answer/internal/service/meta_common"
+	"github.com/apache/incubator-answer/internal/service/moderation_rule"
+	"github.com/apache/incubator-answer/internal/service/moderation_triage"
+	"github.com/apache/incubator-answer/internal/service/new_contributor"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/notification"
 	notficationcommon "github.com/apache/incubator-answer/internal/service/notification_common"
 	"github.com/apache/incubator-answer/internal/service/object_info"
+	"github.com/apache/incubator-answer/internal/service/oembed"
+	"github.com/apache/incubator-answer/internal/service/page"
+	"github.com/apache/incubator-answer/internal/service/page_cache"
+	"github.com/apache/incubator-answer/internal/service/password_policy"
+	"github.com/apache/incubator-answer/internal/service/pending_deletion"
+	"github.com/apache/incubator-answer/internal/service/permalink"
+	"github.com/apache/incubator-answer/internal/service/permission_policy"
 	"github.com/apache/incubator-answer/internal/service/plugin_common"
+	"github.com/apache/incubator-answer/internal/service/profile_field"
+	"github.com/apache/incubator-answer/internal/service/question_assignment"
+	"github.com/apache/incubator-answer/internal/service/question_batch"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/question_merge"
+	"github.com/apache/incubator-answer/internal/service/question_poll"
+	"github.com/apache/incubator-answer/internal/service/question_quality"
+	"github.com/apache/incubator-answer/internal/service/question_share"
+	"github.com/apache/incubator-answer/internal/service/question_sla"
+	"github.com/apache/incubator-answer/internal/service/question_summary"
+	"github.com/apache/incubator-answer/internal/service/question_workflow"
 	"github.com/apache/incubator-answer/internal/service/rank"
 	"github.com/apache/incubator-answer/internal/service/reason"
 	"github.com/apache/incubator-answer/internal/service/report"
 	"github.com/apache/incubator-answer/internal/service/report_handle"
 	"github.com/apache/incubator-answer/internal/service/review"
+	"github.com/apache/incubator-answer/internal/service/review_reminder"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
+	"github.com/apache/incubator-answer/internal/service/revision_compaction"
 	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/scheduler"
+	"github.com/apache/incubator-answer/internal/service/search_log"
 	"github.com/apache/incubator-answer/internal/service/search_parser"
 	"github.com/apache/incubator-answer/internal/service/siteinfo"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/internal/service/sitemap"
+	"github.com/apache/incubator-answer/internal/service/slow_query"
 	"github.com/apache/incubator-answer/internal/service/tag"
+	"github.com/apache/incubator-answer/internal/service/tag_auto_subscription"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/internal/service/title_quality"
+	"github.com/apache/incubator-answer/internal/service/upload_access"
 	"github.com/apache/incubator-answer/internal/service/uploader"
 	"github.com/apache/incubator-answer/internal/service/user_admin"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/internal/service/user_external_login"
+	"github.com/apache/incubator-answer/internal/service/user_follow"
 	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/internal/service/user_onboarding"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
 	"github.com/google/wire"
 )
 
@@ -70,6 +137,10 @@ var ProviderSetService = wire.NewSet(
 	report.NewReportService,
 	content.NewVoteService,
 	tag.NewTagService,
+	tag.NewTagStatsService,
+	tag.NewTagSuggestService,
+	tag.NewUserTagScoreService,
+	content.NewUserStatsService,
 	follow.NewFollowService,
 	collection.NewCollectionGroupService,
 	collection.NewCollectionService,
@@ -109,6 +180,7 @@ var ProviderSetService = wire.NewSet(
 	user_external_login.NewUserExternalLoginService,
 	user_external_login.NewUserCenterLoginService,
 	plugin_common.NewPluginCommonService,
+	plugin_common.NewPluginJobService,
 	config.NewConfigService,
 	notice_queue.NewNotificationQueueService,
 	activity_queue.NewActivityQueueService,
@@ -117,4 +189,71 @@ var ProviderSetService = wire.NewSet(
 	notice_queue.NewNewQuestionNotificationQueueService,
 	review.NewReviewService,
 	meta.NewMetaService,
+	analytics.NewAnalyticsService,
+	search_log.NewSearchLogService,
+	event_queue.NewEventQueueService,
+	sitemap.NewSitemapService,
+	permalink.NewPermalinkService,
+	oembed.NewOEmbedService,
+	content_filter.NewContentFilterService,
+	page.NewPageService,
+	announcement.NewAnnouncementService,
+	profile_field.NewProfileFieldService,
+	user_follow.NewUserFollowService,
+	password_policy.NewPasswordPolicyService,
+	invitation.NewInvitationService,
+	email_domain.NewEmailDomainService,
+	question_assignment.NewQuestionAssignmentService,
+	question_sla.NewQuestionSLAService,
+	article.NewArticleService,
+	question_poll.NewQuestionPollService,
+	co_author.NewCoAuthorService,
+	leaderboard.NewLeaderboardService,
+	list_index.NewListIndexService,
+	pending_deletion.NewPendingDeletionService,
+	data_retention.NewDataRetentionService,
+	scheduler.NewSchedulerService,
+	permission_policy.NewPermissionPolicyService,
+	question_share.NewQuestionShareService,
+	question_batch.NewQuestionBatchService,
+	link_preview.NewLinkPreviewService,
+	dead_link.NewDeadLinkService,
+	ai_assistant.NewAIAssistantService,
+	question_summary.NewQuestionSummaryService,
+	embedding.NewEmbeddingService,
+	title_quality.NewTitleQualityService,
+	answer_quality.NewAnswerQualityService,
+	review_reminder.NewReviewReminderService,
+	user_onboarding.NewUserOnboardingService,
+	experiment.NewExperimentService,
+	feature_flag.NewFeatureFlagService,
+	event_stream.NewEventStreamService,
+	inbound_question.NewInboundQuestionService,
+	email_reply.NewEmailReplyService,
+	chat_integration.NewChatIntegrationService,
+	github_integration.NewGitHubService,
+	jira_integration.NewJiraService,
+	maintenance.NewMaintenanceService,
+	content_limit.NewContentLimitService,
+	banned_word.NewBannedWordService,
+	moderation_rule.NewModerationRuleService,
+	question_workflow.NewQuestionWorkflowService,
+	answer_verification.NewAnswerVerificationService,
+	tag_auto_subscription.NewTagAutoSubscriptionService,
+	moderation_triage.NewModerationTriageService,
+	legal_hold.NewLegalHoldService,
+	ip_logging.NewIPLoggingService,
+	edit_lock.NewEditLockService,
+	username_policy.NewUsernamePolicyService,
+	slow_query.NewSlowQueryService,
+	page_cache.NewPageCacheService,
+	new_contributor.NewNewContributorService,
+	question_quality.NewQuestionQualityService,
+	conversion.NewConversionService,
+	question_merge.NewQuestionMergeService,
+	revision_compaction.NewRevisionCompactionService,
+	media_proxy.NewMediaProxyService,
+	login_protection.NewLoginProtectionService,
+	upload_access.NewUploadAccessService,
+	delta_sync.NewDeltaSyncService,
 )
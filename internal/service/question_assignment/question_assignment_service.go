@@ -0,0 +1,390 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionAssignmentRepo question assignment repository
+type QuestionAssignmentRepo interface {
+	AddQuestionAssignment(ctx context.Context, assignment *entity.QuestionAssignment) (err error)
+	UpdateQuestionAssignment(ctx context.Context, assignment *entity.QuestionAssignment, cols ...string) (err error)
+	GetQuestionAssignment(ctx context.Context, questionID, userID string) (
+		assignment *entity.QuestionAssignment, exist bool, err error)
+	GetQuestionAssignments(ctx context.Context, questionID string) (assignments []*entity.QuestionAssignment, err error)
+	GetUserQuestionAssignmentPage(ctx context.Context, page, pageSize int, userID string, status int) (
+		assignments []*entity.QuestionAssignment, total int64, err error)
+	MarkQuestionAssignmentAnswered(ctx context.Context, questionID, userID string) (err error)
+	CancelQuestionAssignments(ctx context.Context, questionID string) (err error)
+	GetQuestionExperts(ctx context.Context, questionID string, limit int) (experts []*entity.QuestionExpertScore, err error)
+}
+
+// QuestionAssignmentService question assignment service
+type QuestionAssignmentService struct {
+	questionAssignmentRepo   QuestionAssignmentRepo
+	questionRepo             questioncommon.QuestionRepo
+	userCommon               *usercommon.UserCommon
+	siteInfoService          siteinfo_common.SiteInfoCommonService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewQuestionAssignmentService new question assignment service
+func NewQuestionAssignmentService(
+	questionAssignmentRepo QuestionAssignmentRepo,
+	questionRepo questioncommon.QuestionRepo,
+	userCommon *usercommon.UserCommon,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	eventQueueService event_queue.EventQueueService,
+) *QuestionAssignmentService {
+	qs := &QuestionAssignmentService{
+		questionAssignmentRepo:   questionAssignmentRepo,
+		questionRepo:             questionRepo,
+		userCommon:               userCommon,
+		siteInfoService:          siteInfoService,
+		notificationQueueService: notificationQueueService,
+	}
+	eventQueueService.RegisterHandler(qs.HandleEvent)
+	return qs
+}
+
+// AssignQuestion assign the question to the users
+func (qs *QuestionAssignmentService) AssignQuestion(ctx context.Context, req *schema.AssignQuestionReq) (
+	resp []*schema.QuestionAssignmentResp, err error) {
+	question, err := qs.getAssignableQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	userMapping, err := qs.userCommon.BatchGetUserBasicInfoByUserNames(ctx, req.Usernames)
+	if err != nil {
+		return nil, err
+	}
+	assigneeIDs := make([]string, 0, len(userMapping))
+	for _, username := range req.Usernames {
+		userInfo, ok := userMapping[username]
+		if !ok || userInfo.Status != constant.UserNormal {
+			continue
+		}
+		assigneeIDs = append(assigneeIDs, userInfo.ID)
+	}
+	if len(assigneeIDs) == 0 {
+		return nil, errors.BadRequest(reason.QuestionAssigneeNotFound)
+	}
+
+	dueAt, err := qs.getDueAt(ctx, req.DueHours)
+	if err != nil {
+		return nil, err
+	}
+	for _, assigneeID := range assigneeIDs {
+		if err := qs.assign(ctx, question, assigneeID, req.UserID, false, dueAt); err != nil {
+			return nil, err
+		}
+	}
+	return qs.GetQuestionAssignments(ctx, &schema.GetQuestionAssignmentsReq{QuestionID: question.ID})
+}
+
+// AutoAssignQuestion assign the question to the users who are good at the tags of the question
+func (qs *QuestionAssignmentService) AutoAssignQuestion(ctx context.Context, req *schema.AutoAssignQuestionReq) (
+	resp []*schema.QuestionAssignmentResp, err error) {
+	question, err := qs.getAssignableQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+
+	// skip the author and the users who have already been assigned
+	skipUserIDs := map[string]bool{question.UserID: true}
+	assignments, err := qs.questionAssignmentRepo.GetQuestionAssignments(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, assignment := range assignments {
+		skipUserIDs[assignment.UserID] = true
+	}
+	experts, err := qs.questionAssignmentRepo.GetQuestionExperts(ctx, question.ID, req.Count+len(skipUserIDs)+10)
+	if err != nil {
+		return nil, err
+	}
+	expertIDs := make([]string, 0, len(experts))
+	for _, expert := range experts {
+		if !skipUserIDs[expert.UserID] {
+			expertIDs = append(expertIDs, expert.UserID)
+		}
+	}
+	userMapping, err := qs.userCommon.BatchUserBasicInfoByID(ctx, expertIDs)
+	if err != nil {
+		return nil, err
+	}
+	assigneeIDs := make([]string, 0, req.Count)
+	for _, expertID := range expertIDs {
+		if userInfo, ok := userMapping[expertID]; ok && userInfo.Status == constant.UserNormal {
+			assigneeIDs = append(assigneeIDs, expertID)
+		}
+		if len(assigneeIDs) >= req.Count {
+			break
+		}
+	}
+	if len(assigneeIDs) == 0 {
+		return nil, errors.BadRequest(reason.QuestionAssigneeNotFound)
+	}
+
+	dueAt, err := qs.getDueAt(ctx, req.DueHours)
+	if err != nil {
+		return nil, err
+	}
+	for _, assigneeID := range assigneeIDs {
+		if err := qs.assign(ctx, question, assigneeID, req.UserID, true, dueAt); err != nil {
+			return nil, err
+		}
+	}
+	return qs.GetQuestionAssignments(ctx, &schema.GetQuestionAssignmentsReq{QuestionID: question.ID})
+}
+
+// UnassignQuestion cancel the pending assignment of the question to the user
+func (qs *QuestionAssignmentService) UnassignQuestion(ctx context.Context, req *schema.UnassignQuestionReq) (err error) {
+	userInfo, exist, err := qs.userCommon.GetUserBasicInfoByUserName(ctx, req.Username)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	assignment, exist, err := qs.questionAssignmentRepo.GetQuestionAssignment(ctx, req.QuestionID, userInfo.ID)
+	if err != nil {
+		return err
+	}
+	if !exist || assignment.Status != entity.QuestionAssignmentStatusPending {
+		return errors.BadRequest(reason.QuestionAssignmentNotFound)
+	}
+	assignment.Status = entity.QuestionAssignmentStatusCancelled
+	return qs.questionAssignmentRepo.UpdateQuestionAssignment(ctx, assignment, "status")
+}
+
+// GetQuestionAssignments get the assignments of the question
+func (qs *QuestionAssignmentService) GetQuestionAssignments(ctx context.Context, req *schema.GetQuestionAssignmentsReq) (
+	resp []*schema.QuestionAssignmentResp, err error) {
+	assignments, err := qs.questionAssignmentRepo.GetQuestionAssignments(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(assignments)*2)
+	for _, assignment := range assignments {
+		userIDs = append(userIDs, assignment.UserID, assignment.AssignerUserID)
+	}
+	userMapping, err := qs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.QuestionAssignmentResp, 0, len(assignments))
+	for _, assignment := range assignments {
+		resp = append(resp, formatQuestionAssignment(assignment, userMapping))
+	}
+	return resp, nil
+}
+
+// GetMyAssignmentPage get the questions assigned to the user
+func (qs *QuestionAssignmentService) GetMyAssignmentPage(ctx context.Context, req *schema.GetMyAssignmentPageReq) (
+	pageModel *pager.PageModel, err error) {
+	var status int
+	switch req.Status {
+	case schema.QuestionAssignmentStatusPending:
+		status = entity.QuestionAssignmentStatusPending
+	case schema.QuestionAssignmentStatusAnswered:
+		status = entity.QuestionAssignmentStatusAnswered
+	}
+	assignments, total, err := qs.questionAssignmentRepo.GetUserQuestionAssignmentPage(ctx,
+		req.Page, req.PageSize, req.UserID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	questionIDs := make([]string, 0, len(assignments))
+	userIDs := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		questionIDs = append(questionIDs, assignment.QuestionID)
+		userIDs = append(userIDs, assignment.UserID, assignment.AssignerUserID)
+	}
+	questionMapping := make(map[string]*entity.Question)
+	if len(questionIDs) > 0 {
+		questions, err := qs.questionRepo.FindByID(ctx, questionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questions {
+			questionMapping[uid.DeShortID(question.ID)] = question
+		}
+	}
+	userMapping, err := qs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.MyAssignmentResp, 0, len(assignments))
+	for _, assignment := range assignments {
+		question, ok := questionMapping[assignment.QuestionID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.MyAssignmentResp{
+			QuestionAssignmentResp: *formatQuestionAssignment(assignment, userMapping),
+			QuestionID:             question.ID,
+			QuestionTitle:          question.Title,
+			URLTitle:               htmltext.UrlTitle(question.Title),
+			AnswerCount:            question.AnswerCount,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// HandleEvent track the assignment state when the assignee answers the question or the question is deleted
+func (qs *QuestionAssignmentService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	switch msg.EventType {
+	case constant.EventAnswerCreate:
+		return qs.questionAssignmentRepo.MarkQuestionAssignmentAnswered(ctx, msg.QuestionID, msg.UserID)
+	case constant.EventQuestionDelete:
+		return qs.questionAssignmentRepo.CancelQuestionAssignments(ctx, msg.ObjectID)
+	}
+	return nil
+}
+
+func (qs *QuestionAssignmentService) getAssignableQuestion(ctx context.Context, questionID string) (
+	question *entity.Question, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	if question.Status != entity.QuestionStatusAvailable {
+		return nil, errors.BadRequest(reason.QuestionCannotAssign)
+	}
+	question.ID = uid.DeShortID(question.ID)
+	return question, nil
+}
+
+// getDueAt get the deadline of the assignment, the default one configured by admin is used if dueHours is 0
+func (qs *QuestionAssignmentService) getDueAt(ctx context.Context, dueHours int) (dueAt time.Time, err error) {
+	if dueHours == 0 {
+		siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+		if err != nil {
+			return dueAt, err
+		}
+		dueHours = siteWrite.AssignmentSLAHours
+	}
+	if dueHours == 0 {
+		return dueAt, nil
+	}
+	return time.Now().Add(time.Duration(dueHours) * time.Hour), nil
+}
+
+// assign the question to the user and notify the user, nothing to do if the user has been assigned
+func (qs *QuestionAssignmentService) assign(ctx context.Context, question *entity.Question,
+	assigneeID, assignerID string, autoAssigned bool, dueAt time.Time) (err error) {
+	assignment, exist, err := qs.questionAssignmentRepo.GetQuestionAssignment(ctx, question.ID, assigneeID)
+	if err != nil {
+		return err
+	}
+	if exist && assignment.Status != entity.QuestionAssignmentStatusCancelled {
+		return nil
+	}
+	if exist {
+		assignment.AssignerUserID = assignerID
+		assignment.AutoAssigned = autoAssigned
+		assignment.Status = entity.QuestionAssignmentStatusPending
+		assignment.DueAt = dueAt
+		err = qs.questionAssignmentRepo.UpdateQuestionAssignment(ctx, assignment,
+			"assigner_user_id", "auto_assigned", "status", "due_at")
+	} else {
+		err = qs.questionAssignmentRepo.AddQuestionAssignment(ctx, &entity.QuestionAssignment{
+			QuestionID:     question.ID,
+			UserID:         assigneeID,
+			AssignerUserID: assignerID,
+			AutoAssigned:   autoAssigned,
+			Status:         entity.QuestionAssignmentStatusPending,
+			DueAt:          dueAt,
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	qs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		ReceiverUserID:     assigneeID,
+		TriggerUserID:      assignerID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           question.ID,
+		ObjectType:         constant.QuestionObjectType,
+		NotificationAction: constant.NotificationAssignedYouToAnswer,
+	})
+	log.Debugf("question %s is assigned to user %s", question.ID, assigneeID)
+	return nil
+}
+
+func formatQuestionAssignment(assignment *entity.QuestionAssignment,
+	userMapping map[string]*schema.UserBasicInfo) *schema.QuestionAssignmentResp {
+	resp := &schema.QuestionAssignmentResp{
+		ID:           assignment.ID,
+		Assignee:     userMapping[assignment.UserID],
+		Assigner:     userMapping[assignment.AssignerUserID],
+		AutoAssigned: assignment.AutoAssigned,
+		Status:       questionAssignmentStatus(assignment),
+		CreatedAt:    assignment.CreatedAt.Unix(),
+	}
+	if !assignment.DueAt.IsZero() {
+		resp.DueAt = assignment.DueAt.Unix()
+	}
+	if !assignment.AnsweredAt.IsZero() {
+		resp.AnsweredAt = assignment.AnsweredAt.Unix()
+	}
+	return resp
+}
+
+func questionAssignmentStatus(assignment *entity.QuestionAssignment) string {
+	switch assignment.Status {
+	case entity.QuestionAssignmentStatusAnswered:
+		return schema.QuestionAssignmentStatusAnswered
+	case entity.QuestionAssignmentStatusCancelled:
+		return schema.QuestionAssignmentStatusCancelled
+	}
+	if assignment.IsOverdue() {
+		return schema.QuestionAssignmentStatusOverdue
+	}
+	return schema.QuestionAssignmentStatusPending
+}
@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_assignment
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestionAssignmentStatus(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		assignment *entity.QuestionAssignment
+		status     string
+	}{
+		{&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusPending}, schema.QuestionAssignmentStatusPending},
+		{&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusPending, DueAt: now.Add(time.Hour)}, schema.QuestionAssignmentStatusPending},
+		{&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusPending, DueAt: now.Add(-time.Hour)}, schema.QuestionAssignmentStatusOverdue},
+		{&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusAnswered, DueAt: now.Add(-time.Hour)}, schema.QuestionAssignmentStatusAnswered},
+		{&entity.QuestionAssignment{Status: entity.QuestionAssignmentStatusCancelled}, schema.QuestionAssignmentStatusCancelled},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.status, questionAssignmentStatus(c.assignment))
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_batch
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionReader read the question with the permissions of the user
+type QuestionReader interface {
+	GetQuestion(ctx context.Context, questionID, userID string, per schema.QuestionPermission) (
+		resp *schema.QuestionInfoResp, err error)
+}
+
+// AnswerLister list the answers of the question
+type AnswerLister interface {
+	SearchList(ctx context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error)
+}
+
+// CommentLister list the comments of the question or answer
+type CommentLister interface {
+	GetCommentWithPage(ctx context.Context, req *schema.GetCommentWithPageReq) (pageModel *pager.PageModel, err error)
+}
+
+// PermissionChecker resolve the permissions of the user on the questions, answers and comments
+type PermissionChecker interface {
+	GetQuestionPermission(ctx context.Context, userID string) (per schema.QuestionPermission, err error)
+	WithQuestionEditor(ctx context.Context, per schema.QuestionPermission, userID, questionID string) schema.QuestionPermission
+	SetAnswerListPermission(ctx context.Context, req *schema.AnswerListReq) (err error)
+	SetCommentListPermission(ctx context.Context, req *schema.GetCommentWithPageReq) (err error)
+}
+
+// ShareTokenChecker check the share token of the unlisted question
+type ShareTokenChecker interface {
+	CheckShareToken(ctx context.Context, questionID, token string) bool
+}
+
+// QuestionBatchService the batched read of the questions, the clients with high latency such as
+// the mobile apps get the content of a page in one round trip
+type QuestionBatchService struct {
+	questionReader    QuestionReader
+	answerLister      AnswerLister
+	commentLister     CommentLister
+	permissionChecker PermissionChecker
+	shareTokenChecker ShareTokenChecker
+}
+
+// NewQuestionBatchService new question batch service
+func NewQuestionBatchService(
+	questionReader QuestionReader,
+	answerLister AnswerLister,
+	commentLister CommentLister,
+	permissionChecker PermissionChecker,
+	shareTokenChecker ShareTokenChecker,
+) *QuestionBatchService {
+	return &QuestionBatchService{
+		questionReader:    questionReader,
+		answerLister:      answerLister,
+		commentLister:     commentLister,
+		permissionChecker: permissionChecker,
+		shareTokenChecker: shareTokenChecker,
+	}
+}
+
+// BatchGetQuestions get the questions by ids, the questions that do not exist or are invisible are returned in not_found
+func (qs *QuestionBatchService) BatchGetQuestions(ctx context.Context, req *schema.BatchGetQuestionsReq) (
+	resp *schema.BatchGetQuestionsResp, err error) {
+	per, err := qs.permissionChecker.GetQuestionPermission(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.BatchGetQuestionsResp{
+		List:     make([]*schema.QuestionInfoResp, 0, len(req.IDs)),
+		NotFound: make([]string, 0),
+	}
+	for _, id := range req.IDs {
+		questionID := uid.DeShortID(id)
+		info, err := qs.questionReader.GetQuestion(ctx, questionID, req.UserID,
+			qs.permissionChecker.WithQuestionEditor(ctx, per, req.UserID, questionID))
+		if e, ok := err.(*errors.Error); ok && errors.IsNotFound(e) {
+			resp.NotFound = append(resp.NotFound, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if handler.GetEnableShortID(ctx) {
+			info.ID = uid.EnShortID(info.ID)
+		}
+		resp.List = append(resp.List, info)
+	}
+	return resp, nil
+}
+
+// GetQuestionDetail get the question, the first page of the answers and the first page of the comments
+// of the question and each answer
+func (qs *QuestionBatchService) GetQuestionDetail(ctx context.Context, req *schema.GetQuestionDetailReq) (
+	resp *schema.GetQuestionDetailResp, err error) {
+	questionID := uid.DeShortID(req.ID)
+	if req.AnswerPageSize == 0 {
+		req.AnswerPageSize = schema.DefaultQuestionDetailAnswerPageSize
+	}
+	if req.CommentPageSize == 0 {
+		req.CommentPageSize = schema.DefaultQuestionDetailCommentPageSize
+	}
+
+	per, err := qs.permissionChecker.GetQuestionPermission(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	per = qs.permissionChecker.WithQuestionEditor(ctx, per, req.UserID, questionID)
+	per.CanViewUnlisted = qs.shareTokenChecker.CheckShareToken(ctx, questionID, req.ShareToken)
+	// the question info checks the visibility, so the answers and the comments are visible if it is returned
+	info, err := qs.questionReader.GetQuestion(ctx, questionID, req.UserID, per)
+	if err != nil {
+		return nil, err
+	}
+
+	answerReq := &schema.AnswerListReq{
+		QuestionID: questionID,
+		Order:      req.AnswerOrder,
+		Page:       1,
+		PageSize:   req.AnswerPageSize,
+		UserID:     req.UserID,
+	}
+	if err = qs.permissionChecker.SetAnswerListPermission(ctx, answerReq); err != nil {
+		return nil, err
+	}
+	answers, answerCount, err := qs.answerLister.SearchList(ctx, answerReq)
+	if err != nil {
+		return nil, err
+	}
+
+	commentReq := &schema.GetCommentWithPageReq{
+		Page:     1,
+		PageSize: req.CommentPageSize,
+		UserID:   req.UserID,
+	}
+	if err = qs.permissionChecker.SetCommentListPermission(ctx, commentReq); err != nil {
+		return nil, err
+	}
+
+	if handler.GetEnableShortID(ctx) {
+		info.ID = uid.EnShortID(info.ID)
+	}
+	resp = &schema.GetQuestionDetailResp{
+		Question:    info,
+		Answers:     answers,
+		AnswerCount: answerCount,
+		Comments:    make(map[string]*pager.PageModel, len(answers)+1),
+	}
+	objectIDs := []string{info.ID}
+	for _, answer := range answers {
+		objectIDs = append(objectIDs, answer.ID)
+	}
+	for _, objectID := range objectIDs {
+		commentReq.ObjectID = uid.DeShortID(objectID)
+		resp.Comments[objectID], err = qs.commentLister.GetCommentWithPage(ctx, commentReq)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
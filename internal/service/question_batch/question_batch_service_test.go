@@ -0,0 +1,181 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_batch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testQuestionID         = "10010000000000001"
+	testUnlistedQuestionID = "10010000000000002"
+	testAnswerID           = "10020000000000001"
+	testShareToken         = "token"
+)
+
+type testQuestionReader struct {
+	pers map[string]schema.QuestionPermission
+}
+
+func (r *testQuestionReader) GetQuestion(_ context.Context, questionID, _ string, per schema.QuestionPermission) (
+	*schema.QuestionInfoResp, error) {
+	r.pers[questionID] = per
+	switch {
+	case questionID == testQuestionID:
+	case questionID == testUnlistedQuestionID && per.CanViewUnlisted:
+	default:
+		return nil, errors.NotFound(reason.QuestionNotFound)
+	}
+	return &schema.QuestionInfoResp{ID: questionID}, nil
+}
+
+type testAnswerLister struct {
+	req *schema.AnswerListReq
+}
+
+func (l *testAnswerLister) SearchList(_ context.Context, req *schema.AnswerListReq) ([]*schema.AnswerInfo, int64, error) {
+	l.req = req
+	return []*schema.AnswerInfo{{ID: uid.EnShortID(testAnswerID), QuestionID: req.QuestionID}}, 3, nil
+}
+
+type testCommentLister struct {
+	reqs []schema.GetCommentWithPageReq
+}
+
+func (l *testCommentLister) GetCommentWithPage(_ context.Context, req *schema.GetCommentWithPageReq) (
+	*pager.PageModel, error) {
+	l.reqs = append(l.reqs, *req)
+	return pager.NewPageModel(0, []string{}), nil
+}
+
+// testPermissionChecker the user 1 is the editor of the question
+type testPermissionChecker struct{}
+
+func (c *testPermissionChecker) GetQuestionPermission(context.Context, string) (schema.QuestionPermission, error) {
+	return schema.QuestionPermission{
+		CanClose: true,
+		ActionPermissions: &schema.ObjectActionPermissions{
+			Edit: &schema.ActionPermission{RequireRank: 100},
+		},
+	}, nil
+}
+
+func (c *testPermissionChecker) WithQuestionEditor(_ context.Context, per schema.QuestionPermission,
+	userID, questionID string) schema.QuestionPermission {
+	if userID == "1" && questionID == testQuestionID {
+		per.CanEdit = true
+		edit := *per.ActionPermissions.Edit
+		edit.Can = true
+		per.ActionPermissions = &schema.ObjectActionPermissions{Edit: &edit}
+	}
+	return per
+}
+
+func (c *testPermissionChecker) SetAnswerListPermission(_ context.Context, req *schema.AnswerListReq) error {
+	req.CanEdit = true
+	return nil
+}
+
+func (c *testPermissionChecker) SetCommentListPermission(_ context.Context, req *schema.GetCommentWithPageReq) error {
+	req.CanDelete = true
+	return nil
+}
+
+type testShareTokenChecker struct{}
+
+func (c *testShareTokenChecker) CheckShareToken(_ context.Context, questionID, token string) bool {
+	return questionID == testUnlistedQuestionID && token == testShareToken
+}
+
+func newTestQuestionBatchService() (*QuestionBatchService, *testQuestionReader, *testAnswerLister, *testCommentLister) {
+	questionReader := &testQuestionReader{pers: make(map[string]schema.QuestionPermission)}
+	answerLister := &testAnswerLister{}
+	commentLister := &testCommentLister{}
+	qs := NewQuestionBatchService(questionReader, answerLister, commentLister,
+		&testPermissionChecker{}, &testShareTokenChecker{})
+	return qs, questionReader, answerLister, commentLister
+}
+
+func TestBatchGetQuestions(t *testing.T) {
+	qs, questionReader, _, _ := newTestQuestionBatchService()
+	ctx := context.WithValue(context.TODO(), constant.ShortIDFlag, true)
+
+	resp, err := qs.BatchGetQuestions(ctx, &schema.BatchGetQuestionsReq{
+		IDs:    []string{uid.EnShortID(testQuestionID), testUnlistedQuestionID, "10010000000000099"},
+		UserID: "1",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.List, 1)
+	assert.Equal(t, uid.EnShortID(testQuestionID), resp.List[0].ID)
+	// the unlisted question is not visible without the share token
+	assert.Equal(t, []string{testUnlistedQuestionID, "10010000000000099"}, resp.NotFound)
+
+	// the editor permission of one question does not leak to the others
+	assert.True(t, questionReader.pers[testQuestionID].CanEdit)
+	assert.True(t, questionReader.pers[testQuestionID].ActionPermissions.Edit.Can)
+	assert.False(t, questionReader.pers[testUnlistedQuestionID].CanEdit)
+	assert.False(t, questionReader.pers[testUnlistedQuestionID].ActionPermissions.Edit.Can)
+	assert.True(t, questionReader.pers[testUnlistedQuestionID].CanClose)
+}
+
+func TestGetQuestionDetail(t *testing.T) {
+	qs, questionReader, answerLister, commentLister := newTestQuestionBatchService()
+
+	_, err := qs.GetQuestionDetail(context.TODO(), &schema.GetQuestionDetailReq{ID: testUnlistedQuestionID})
+	assert.Error(t, err)
+
+	resp, err := qs.GetQuestionDetail(context.TODO(), &schema.GetQuestionDetailReq{
+		ID: testUnlistedQuestionID, ShareToken: testShareToken, AnswerOrder: "vote", UserID: "2"})
+	require.NoError(t, err)
+	assert.True(t, questionReader.pers[testUnlistedQuestionID].CanViewUnlisted)
+	assert.Equal(t, testUnlistedQuestionID, resp.Question.ID)
+	assert.Len(t, resp.Answers, 1)
+	assert.Equal(t, int64(3), resp.AnswerCount)
+
+	assert.Equal(t, &schema.AnswerListReq{
+		QuestionID: testUnlistedQuestionID,
+		Order:      "vote",
+		Page:       1,
+		PageSize:   schema.DefaultQuestionDetailAnswerPageSize,
+		UserID:     "2",
+		CanEdit:    true,
+	}, answerLister.req)
+
+	// the comments of the question and each answer
+	require.Len(t, commentLister.reqs, 2)
+	assert.Equal(t, testUnlistedQuestionID, commentLister.reqs[0].ObjectID)
+	assert.Equal(t, testAnswerID, commentLister.reqs[1].ObjectID)
+	for _, req := range commentLister.reqs {
+		assert.Equal(t, schema.DefaultQuestionDetailCommentPageSize, req.PageSize)
+		assert.True(t, req.CanDelete)
+	}
+	assert.Contains(t, resp.Comments, testUnlistedQuestionID)
+	assert.Contains(t, resp.Comments, uid.EnShortID(testAnswerID))
+}
@@ -22,7 +22,6 @@ package questioncommon
 import (
 	"context"
 	"encoding/json"
-	"math"
 	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
@@ -49,14 +48,16 @@ import (
 )
 
 // QuestionRepo question repository
+//
+//go:generate mockgen -source=./question.go -destination=../mock/question_repo_mock.go -package=mock
 type QuestionRepo interface {
 	AddQuestion(ctx context.Context, question *entity.Question) (err error)
 	RemoveQuestion(ctx context.Context, id string) (err error)
 	UpdateQuestion(ctx context.Context, question *entity.Question, Cols []string) (err error)
 	GetQuestion(ctx context.Context, id string) (question *entity.Question, exist bool, err error)
 	GetQuestionList(ctx context.Context, question *entity.Question) (questions []*entity.Question, err error)
-	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays int, showHidden, showPending bool) (
-		questionList []*entity.Question, total int64, err error)
+	GetQuestionPage(ctx context.Context, page, pageSize int, tagIDs []string, userID, orderCond string, inDays, qualityBlend int, showHidden, showPending bool,
+		workflowStates []string) (questionList []*entity.Question, total int64, err error)
 	UpdateQuestionStatus(ctx context.Context, questionID string, status int) (err error)
 	UpdateQuestionStatusWithOutUpdateTime(ctx context.Context, question *entity.Question) (err error)
 	RecoverQuestion(ctx context.Context, questionID string) (err error)
@@ -71,9 +72,9 @@ type QuestionRepo interface {
 	AdminQuestionPage(ctx context.Context, search *schema.AdminQuestionPageReq) ([]*entity.Question, int64, error)
 	GetQuestionCount(ctx context.Context) (count int64, err error)
 	GetUserQuestionCount(ctx context.Context, userID string, show int) (count int64, err error)
-	SitemapQuestions(ctx context.Context, page, pageSize int) (questionIDList []*schema.SiteMapQuestionInfo, err error)
 	RemoveAllUserQuestion(ctx context.Context, userID string) (err error)
 	UpdateSearch(ctx context.Context, questionID string) (err error)
+	RefreshQuestionListIndex(ctx context.Context, partition *entity.QuestionListPartition) (err error)
 }
 
 // QuestionCommon user service
@@ -235,6 +236,12 @@ func (qs *QuestionCommon) Info(ctx context.Context, questionID string, loginUser
 	}
 	resp = qs.ShowFormat(ctx, questionInfo)
 	if resp.Status == entity.QuestionStatusClosed {
+		resp.MergedQuestionID = qs.GetMergedQuestionID(ctx, questionInfo.ID)
+		if len(resp.MergedQuestionID) > 0 && handler.GetEnableShortID(ctx) {
+			resp.MergedQuestionID = uid.EnShortID(resp.MergedQuestionID)
+		}
+	}
+	if resp.Status == entity.QuestionStatusClosed && len(resp.MergedQuestionID) == 0 {
 		metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionInfo.ID, entity.QuestionCloseReasonKey)
 		if err != nil {
 			log.Error(err)
@@ -358,6 +365,7 @@ func (qs *QuestionCommon) FormatQuestionsPage(
 			LastAnswerID:     questionInfo.LastAnswerID,
 			Pin:              questionInfo.Pin,
 			Show:             questionInfo.Show,
+			WorkflowStateKey: questionInfo.WorkflowState,
 		}
 
 		questionIDs = append(questionIDs, questionInfo.ID)
@@ -510,6 +518,15 @@ func (qs *QuestionCommon) RemoveQuestion(ctx context.Context, req *schema.Remove
 	return nil
 }
 
+// GetMergedQuestionID get the canonical question which the question is merged into, empty if not merged
+func (qs *QuestionCommon) GetMergedQuestionID(ctx context.Context, questionID string) string {
+	metaInfo, err := qs.metaCommonService.GetMetaByObjectIdAndKey(ctx, questionID, entity.QuestionMergedIntoKey)
+	if err != nil {
+		return ""
+	}
+	return metaInfo.Value
+}
+
 func (qs *QuestionCommon) CloseQuestion(ctx context.Context, req *schema.CloseQuestionReq) error {
 	questionInfo, has, err := qs.questionRepo.GetQuestion(ctx, req.ID)
 	if err != nil {
@@ -570,30 +587,6 @@ func (qs *QuestionCommon) RemoveAnswer(ctx context.Context, id string) (err erro
 	return qs.answerRepo.RemoveAnswer(ctx, id)
 }
 
-func (qs *QuestionCommon) SitemapCron(ctx context.Context) {
-	questionNum, err := qs.questionRepo.GetQuestionCount(ctx)
-	if err != nil {
-		log.Error(err)
-		return
-	}
-	if questionNum <= constant.SitemapMaxSize {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, 1, int(questionNum))
-		if err != nil {
-			log.Errorf("get site map question error: %v", err)
-		}
-		return
-	}
-
-	totalPages := int(math.Ceil(float64(questionNum) / float64(constant.SitemapMaxSize)))
-	for i := 1; i <= totalPages; i++ {
-		_, err = qs.questionRepo.SitemapQuestions(ctx, i, constant.SitemapMaxSize)
-		if err != nil {
-			log.Errorf("get site map question error: %v", err)
-			return
-		}
-	}
-}
-
 func (qs *QuestionCommon) SetCache(ctx context.Context, cachekey string, info interface{}) error {
 	infoStr, err := json.Marshal(info)
 	if err != nil {
@@ -0,0 +1,108 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_merge
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionMergeRepo question merge repository
+type QuestionMergeRepo interface {
+	MergeQuestion(ctx context.Context, fromQuestionID, toQuestionID string) (err error)
+}
+
+// QuestionMergeService merge the duplicate question into the canonical one
+type QuestionMergeService struct {
+	questionMergeRepo    QuestionMergeRepo
+	questionRepo         questioncommon.QuestionRepo
+	questionCommon       *questioncommon.QuestionCommon
+	activityQueueService activity_queue.ActivityQueueService
+}
+
+// NewQuestionMergeService new question merge service
+func NewQuestionMergeService(
+	questionMergeRepo QuestionMergeRepo,
+	questionRepo questioncommon.QuestionRepo,
+	questionCommon *questioncommon.QuestionCommon,
+	activityQueueService activity_queue.ActivityQueueService,
+) *QuestionMergeService {
+	return &QuestionMergeService{
+		questionMergeRepo:    questionMergeRepo,
+		questionRepo:         questionRepo,
+		questionCommon:       questionCommon,
+		activityQueueService: activityQueueService,
+	}
+}
+
+// MergeQuestion move everything of the duplicate question into the canonical question
+func (qs *QuestionMergeService) MergeQuestion(ctx context.Context, req *schema.MergeQuestionReq) (err error) {
+	fromQuestionID, toQuestionID := uid.DeShortID(req.ID), uid.DeShortID(req.TargetID)
+	if fromQuestionID == toQuestionID {
+		return errors.BadRequest(reason.QuestionMergeSameQuestion)
+	}
+	for _, questionID := range []string{fromQuestionID, toQuestionID} {
+		question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+		if err != nil {
+			return err
+		}
+		if !exist || question.Status == entity.QuestionStatusDeleted {
+			return errors.BadRequest(reason.QuestionNotFound)
+		}
+		if len(qs.questionCommon.GetMergedQuestionID(ctx, questionID)) > 0 {
+			return errors.BadRequest(reason.QuestionAlreadyMerged)
+		}
+	}
+
+	if err = qs.questionMergeRepo.MergeQuestion(ctx, fromQuestionID, toQuestionID); err != nil {
+		return err
+	}
+	for _, questionID := range []string{fromQuestionID, toQuestionID} {
+		if err := qs.questionRepo.UpdateSearch(ctx, questionID); err != nil {
+			log.Errorf("update question search failed: %v", err)
+		}
+	}
+
+	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         fromQuestionID,
+		OriginalObjectID: fromQuestionID,
+		ActivityTypeKey:  constant.ActQuestionMergedInto,
+	})
+	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           req.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         toQuestionID,
+		OriginalObjectID: toQuestionID,
+		ActivityTypeKey:  constant.ActQuestionMergedFrom,
+	})
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package question_poll
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/feature_flag"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// QuestionPollRepo question poll repository
+type QuestionPollRepo interface {
+	AddQuestionPoll(ctx context.Context, poll *entity.QuestionPoll, options []*entity.QuestionPollOption) (err error)
+	RemoveQuestionPoll(ctx context.Context, pollID int) (err error)
+	GetQuestionPoll(ctx context.Context, questionID string) (poll *entity.QuestionPoll, exist bool, err error)
+	GetQuestionPollOptions(ctx context.Context, pollID int) (options []*entity.QuestionPollOption, err error)
+	GetQuestionPollOptionCounts(ctx context.Context, pollID int) (counts []*entity.QuestionPollOptionCount, err error)
+	CountQuestionPollVoters(ctx context.Context, pollID int) (count int64, err error)
+	GetUserQuestionPollVotes(ctx context.Context, pollID int, userID string) (optionIDs []int, err error)
+	SetUserQuestionPollVotes(ctx context.Context, pollID int, userID string, optionIDs []int) (err error)
+}
+
+// QuestionPollService question poll service
+type QuestionPollService struct {
+	questionPollRepo   QuestionPollRepo
+	questionRepo       questioncommon.QuestionRepo
+	featureFlagService *feature_flag.FeatureFlagService
+}
+
+// NewQuestionPollService new question poll service
+func NewQuestionPollService(
+	questionPollRepo QuestionPollRepo,
+	questionRepo questioncommon.QuestionRepo,
+	featureFlagService *feature_flag.FeatureFlagService,
+) *QuestionPollService {
+	return &QuestionPollService{
+		questionPollRepo:   questionPollRepo,
+		questionRepo:       questionRepo,
+		featureFlagService: featureFlagService,
+	}
+}
+
+// AddQuestionPoll attach a poll to the question
+func (qs *QuestionPollService) AddQuestionPoll(ctx context.Context, req *schema.AddQuestionPollReq) (
+	resp *schema.QuestionPollResp, err error) {
+	if err = qs.featureFlagService.CheckFeatureAvailable(ctx, schema.FeatureQuestionPoll, req.UserID); err != nil {
+		return nil, err
+	}
+	question, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	_, exist, err := qs.questionPollRepo.GetQuestionPoll(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, errors.BadRequest(reason.QuestionPollAlreadyExist)
+	}
+
+	poll := &entity.QuestionPoll{
+		QuestionID:   question.ID,
+		UserID:       req.UserID,
+		Multiple:     req.Multiple,
+		ResultPolicy: req.ResultPolicy,
+	}
+	if len(poll.ResultPolicy) == 0 {
+		poll.ResultPolicy = entity.QuestionPollResultAlways
+	}
+	if req.CloseAt > 0 {
+		poll.CloseAt = time.Unix(req.CloseAt, 0)
+		if poll.CloseAt.Before(time.Now()) {
+			return nil, errors.BadRequest(reason.QuestionPollCloseAtInvalid)
+		}
+	}
+	options := make([]*entity.QuestionPollOption, 0, len(req.Options))
+	for i, content := range req.Options {
+		options = append(options, &entity.QuestionPollOption{Content: content, Sort: i})
+	}
+	if err = qs.questionPollRepo.AddQuestionPoll(ctx, poll, options); err != nil {
+		return nil, err
+	}
+	return qs.formatQuestionPoll(ctx, poll, question, req.UserID)
+}
+
+// RemoveQuestionPoll remove the poll of the question with all votes
+func (qs *QuestionPollService) RemoveQuestionPoll(ctx context.Context, req *schema.RemoveQuestionPollReq) (err error) {
+	poll, exist, err := qs.questionPollRepo.GetQuestionPoll(ctx, req.QuestionID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionPollNotFound)
+	}
+	return qs.questionPollRepo.RemoveQuestionPoll(ctx, poll.ID)
+}
+
+// VoteQuestionPoll vote the poll, the previous choices of the user are replaced
+func (qs *QuestionPollService) VoteQuestionPoll(ctx context.Context, req *schema.VoteQuestionPollReq) (
+	resp *schema.QuestionPollResp, err error) {
+	if err = qs.featureFlagService.CheckFeatureAvailable(ctx, schema.FeatureQuestionPoll, req.UserID); err != nil {
+		return nil, err
+	}
+	question, err := qs.getQuestion(ctx, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	poll, exist, err := qs.questionPollRepo.GetQuestionPoll(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.QuestionPollNotFound)
+	}
+	if isPollClosed(poll, question) {
+		return nil, errors.BadRequest(reason.QuestionPollClosed)
+	}
+	options, err := qs.questionPollRepo.GetQuestionPollOptions(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !checkVoteOptions(poll, options, req.OptionIDs) {
+		return nil, errors.BadRequest(reason.QuestionPollOptionInvalid)
+	}
+	if err = qs.questionPollRepo.SetUserQuestionPollVotes(ctx, poll.ID, req.UserID, req.OptionIDs); err != nil {
+		return nil, err
+	}
+	return qs.formatQuestionPoll(ctx, poll, question, req.UserID)
+}
+
+// GetQuestionPoll get the poll of the question for the user, return nil if the question has no poll
+func (qs *QuestionPollService) GetQuestionPoll(ctx context.Context, questionID, userID string) (
+	resp *schema.QuestionPollResp, err error) {
+	poll, exist, err := qs.questionPollRepo.GetQuestionPoll(ctx, questionID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, poll.QuestionID)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return qs.formatQuestionPoll(ctx, poll, question, userID)
+}
+
+func (qs *QuestionPollService) getQuestion(ctx context.Context, questionID string) (
+	question *entity.Question, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	return question, nil
+}
+
+func (qs *QuestionPollService) formatQuestionPoll(ctx context.Context, poll *entity.QuestionPoll,
+	question *entity.Question, userID string) (resp *schema.QuestionPollResp, err error) {
+	options, err := qs.questionPollRepo.GetQuestionPollOptions(ctx, poll.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.QuestionPollResp{
+		ID:             poll.ID,
+		Multiple:       poll.Multiple,
+		ResultPolicy:   poll.ResultPolicy,
+		Closed:         isPollClosed(poll, question),
+		VotedOptionIDs: make([]int, 0),
+		Options:        make([]*schema.QuestionPollOptionResp, 0, len(options)),
+	}
+	if !poll.CloseAt.IsZero() {
+		resp.CloseAt = poll.CloseAt.Unix()
+	}
+	if len(userID) > 0 {
+		resp.VotedOptionIDs, err = qs.questionPollRepo.GetUserQuestionPollVotes(ctx, poll.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp.ShowResult = showPollResult(poll.ResultPolicy, len(resp.VotedOptionIDs) > 0, resp.Closed,
+		len(userID) > 0 && userID == question.UserID)
+
+	countMapping := make(map[int]int)
+	if resp.ShowResult {
+		counts, err := qs.questionPollRepo.GetQuestionPollOptionCounts(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			countMapping[count.OptionID] = count.Count
+		}
+		resp.VoterCount, err = qs.questionPollRepo.CountQuestionPollVoters(ctx, poll.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, option := range options {
+		resp.Options = append(resp.Options, &schema.QuestionPollOptionResp{
+			ID:        option.ID,
+			Content:   option.Content,
+			VoteCount: countMapping[option.ID],
+		})
+	}
+	return resp, nil
+}
+
+// isPollClosed the poll of the closed question can not be voted either
+func isPollClosed(poll *entity.QuestionPoll, question *entity.Question) bool {
+	return poll.IsClosed() || question.Status == entity.QuestionStatusClosed
+}
+
+// showPollResult the author of the question can always see the results
+func showPollResult(policy string, voted, closed, isQuestionAuthor bool) bool {
+	if isQuestionAuthor || closed {
+		return true
+	}
+	switch policy {
+	case entity.QuestionPollResultAfterVote:
+		return voted
+	case entity.QuestionPollResultAfterClose:
+		return false
+	default:
+		return true
+	}
+}
+
+// checkVoteOptions the options must belong to the poll without duplicates, and only one for the single choice poll
+func checkVoteOptions(poll *entity.QuestionPoll, options []*entity.QuestionPollOption, optionIDs []int) bool {
+	if len(optionIDs) == 0 || (!poll.Multiple && len(optionIDs) > 1) {
+		return false
+	}
+	available := make(map[int]bool, len(options))
+	for _, option := range options {
+		available[option.ID] = true
+	}
+	chosen := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !available[id] || chosen[id] {
+			return false
+		}
+		chosen[id] = true
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package question_poll
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShowPollResult(t *testing.T) {
+	assert.True(t, showPollResult(entity.QuestionPollResultAlways, false, false, false))
+	assert.False(t, showPollResult(entity.QuestionPollResultAfterVote, false, false, false))
+	assert.True(t, showPollResult(entity.QuestionPollResultAfterVote, true, false, false))
+	assert.False(t, showPollResult(entity.QuestionPollResultAfterClose, true, false, false))
+	assert.True(t, showPollResult(entity.QuestionPollResultAfterClose, false, true, false))
+	assert.True(t, showPollResult(entity.QuestionPollResultAfterClose, false, false, true))
+}
+
+func TestCheckVoteOptions(t *testing.T) {
+	options := []*entity.QuestionPollOption{{ID: 1}, {ID: 2}, {ID: 3}}
+	single := &entity.QuestionPoll{}
+	multiple := &entity.QuestionPoll{Multiple: true}
+
+	assert.True(t, checkVoteOptions(single, options, []int{2}))
+	assert.False(t, checkVoteOptions(single, options, []int{1, 2}))
+	assert.False(t, checkVoteOptions(single, options, []int{4}))
+	assert.False(t, checkVoteOptions(single, options, []int{}))
+	assert.True(t, checkVoteOptions(multiple, options, []int{1, 3}))
+	assert.False(t, checkVoteOptions(multiple, options, []int{1, 1}))
+}
@@ -0,0 +1,136 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_quality
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// qualityTitleLength the title of this length gets the full title score
+	qualityTitleLength = 40
+	// qualityBodyLength the body of this length gets the full length part of the body score
+	qualityBodyLength = 300
+	// qualityParagraphs the body with these paragraphs gets the full paragraph part of the body score
+	qualityParagraphs = 3
+	// qualityTags the question with these tags gets the full tag score
+	qualityTags = 3
+)
+
+var (
+	blankLines      = regexp.MustCompile(`\n\s*\n`)
+	structuredLine  = regexp.MustCompile(`(?m)^\s{0,3}(#{1,6}\s|[-*+]\s|\d+[.)]\s|>)`)
+	codeFence       = regexp.MustCompile("(?m)^\\s{0,3}(```|~~~)")
+	inlineCode      = regexp.MustCompile("`[^`\n]+`")
+	indentedCodeRow = regexp.MustCompile(`(?m)^(    |\t)\S`)
+)
+
+// QuestionQualityService the quality score of the question computed when it is saved
+type QuestionQualityService struct {
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewQuestionQualityService new question quality service
+func NewQuestionQualityService(siteInfoService siteinfo_common.SiteInfoCommonService) *QuestionQualityService {
+	return &QuestionQualityService{
+		siteInfoService: siteInfoService,
+	}
+}
+
+// GetQualityScore compute the quality score of the question by the weights of the site
+func (qs *QuestionQualityService) GetQualityScore(ctx context.Context, title, content string, tagCount int) int {
+	weights := schema.DefaultQuestionQualityWeights
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+	} else if siteWrite.QuestionQualityWeights != nil {
+		weights = siteWrite.QuestionQualityWeights
+	}
+	return ComputeQualityScore(weights, title, content, tagCount)
+}
+
+// GetQualityBlend get the percent of the quality score blended into the sorting of the hot questions
+func (qs *QuestionQualityService) GetQualityBlend(ctx context.Context) int {
+	siteWrite, err := qs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return 0
+	}
+	return siteWrite.QuestionQualityBlend
+}
+
+// ComputeQualityScore the weighted average of the scores of the factors, from 0 to 100
+func ComputeQualityScore(weights *schema.QuestionQualityWeights, title, content string, tagCount int) int {
+	if weights == nil || weights.Title+weights.Body+weights.Code+weights.Tags <= 0 {
+		weights = schema.DefaultQuestionQualityWeights
+	}
+	total := weights.Title + weights.Body + weights.Code + weights.Tags
+	score := float64(weights.Title)*titleScore(title) +
+		float64(weights.Body)*bodyScore(content) +
+		float64(weights.Code)*codeScore(content) +
+		float64(weights.Tags)*ratio(tagCount, qualityTags)
+	return int(math.Round(score * 100 / float64(total)))
+}
+
+// titleScore the longer title describes the problem better
+func titleScore(title string) float64 {
+	return ratio(utf8.RuneCountInString(strings.TrimSpace(title)), qualityTitleLength)
+}
+
+// bodyScore the detailed body structured in paragraphs, lists or headings is easier to read
+func bodyScore(content string) float64 {
+	content = strings.TrimSpace(content)
+	if len(content) == 0 {
+		return 0
+	}
+	paragraphs := len(blankLines.Split(content, -1))
+	score := 0.4*ratio(paragraphs, qualityParagraphs) + 0.3*ratio(utf8.RuneCountInString(content), qualityBodyLength)
+	if structuredLine.MatchString(content) {
+		score += 0.3
+	}
+	return score
+}
+
+// codeScore the code is formatted as code instead of plain text
+func codeScore(content string) float64 {
+	if codeFence.MatchString(content) || inlineCode.MatchString(content) || indentedCodeRow.MatchString(content) {
+		return 1
+	}
+	return 0
+}
+
+// ratio the ratio of the value to the full value, at most 1
+func ratio(value, full int) float64 {
+	if value >= full {
+		return 1
+	}
+	if value <= 0 {
+		return 0
+	}
+	return float64(value) / float64(full)
+}
@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_quality
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeQualityScore(t *testing.T) {
+	content := "I get an error when I build the project.\n\n" +
+		"Steps to reproduce:\n\n" +
+		"1. run `go build`\n" +
+		"2. see the error\n\n" +
+		"```\npanic: runtime error: invalid memory address or nil pointer dereference\n```\n\n" +
+		"I have tried to upgrade the go version, but the error is still there. How can I fix it? Any idea is appreciated."
+	good := ComputeQualityScore(schema.DefaultQuestionQualityWeights,
+		"Why does go build panic with a nil pointer dereference?", content, 3)
+	assert.Equal(t, 100, good)
+
+	poor := ComputeQualityScore(schema.DefaultQuestionQualityWeights, "help", "it does not work", 1)
+	assert.Less(t, poor, 30)
+	assert.Greater(t, poor, 0)
+
+	assert.Equal(t, 0, ComputeQualityScore(schema.DefaultQuestionQualityWeights, "", "", 0))
+}
+
+func TestComputeQualityScoreWeights(t *testing.T) {
+	// only the tags are counted
+	weights := &schema.QuestionQualityWeights{Tags: 10}
+	assert.Equal(t, 100, ComputeQualityScore(weights, "", "", 3))
+	assert.Equal(t, 33, ComputeQualityScore(weights, "a long title of the question", "", 1))
+
+	// all zero means the default weights
+	assert.Equal(t,
+		ComputeQualityScore(schema.DefaultQuestionQualityWeights, "title", "body", 2),
+		ComputeQualityScore(&schema.QuestionQualityWeights{}, "title", "body", 2))
+}
+
+func TestCodeScore(t *testing.T) {
+	assert.Equal(t, float64(1), codeScore("```go\nfmt.Println()\n```"))
+	assert.Equal(t, float64(1), codeScore("call `fmt.Println` here"))
+	assert.Equal(t, float64(1), codeScore("the code:\n\n    fmt.Println()"))
+	assert.Equal(t, float64(0), codeScore("no code at all"))
+}
@@ -0,0 +1,242 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_share
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/google/uuid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionShareRepo question share link repository
+type QuestionShareRepo interface {
+	AddQuestionShareLink(ctx context.Context, link *entity.QuestionShareLink) (err error)
+	GetQuestionShareLink(ctx context.Context, id int) (link *entity.QuestionShareLink, exist bool, err error)
+	GetQuestionShareLinkByToken(ctx context.Context, token string) (link *entity.QuestionShareLink, exist bool, err error)
+	GetQuestionShareLinks(ctx context.Context, questionID string) (links []*entity.QuestionShareLink, err error)
+	RemoveQuestionShareLink(ctx context.Context, id int) (err error)
+	RemoveQuestionShareLinks(ctx context.Context, questionID string) (err error)
+}
+
+// QuestionShareService the visibility and the share links of the question
+type QuestionShareService struct {
+	questionShareRepo QuestionShareRepo
+	questionRepo      questioncommon.QuestionRepo
+	tagCommon         *tagcommon.TagCommonService
+	siteInfoService   siteinfo_common.SiteInfoCommonService
+}
+
+// NewQuestionShareService new question share service
+func NewQuestionShareService(
+	questionShareRepo QuestionShareRepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommon *tagcommon.TagCommonService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *QuestionShareService {
+	return &QuestionShareService{
+		questionShareRepo: questionShareRepo,
+		questionRepo:      questionRepo,
+		tagCommon:         tagCommon,
+		siteInfoService:   siteInfoService,
+	}
+}
+
+// UpdateQuestionVisibility make the question public or unlisted, all the share links are removed when it is public
+func (qs *QuestionShareService) UpdateQuestionVisibility(ctx context.Context, req *schema.UpdateQuestionVisibilityReq) (err error) {
+	question, err := qs.getManageableQuestion(ctx, req.QuestionID, req.UserID, req.IsModerator)
+	if err != nil {
+		return err
+	}
+	// the question hidden by the moderator can not be made public by the author
+	if question.Show == entity.QuestionHide && !req.IsModerator {
+		return errors.Forbidden(reason.QuestionShareNoPermission)
+	}
+
+	show := entity.QuestionShow
+	if req.Visibility == schema.QuestionVisibilityUnlisted {
+		show = entity.QuestionUnlisted
+	}
+	if question.Show == show {
+		return nil
+	}
+	question.Show = show
+	if show == entity.QuestionUnlisted {
+		question.Pin = entity.QuestionUnPin
+		err = qs.tagCommon.HideTagRelListByObjectID(ctx, question.ID)
+	} else {
+		err = qs.tagCommon.ShowTagRelListByObjectID(ctx, question.ID)
+	}
+	if err != nil {
+		return err
+	}
+	if err = qs.tagCommon.RefreshTagCountByQuestionID(ctx, question.ID); err != nil {
+		return err
+	}
+	if err = qs.questionRepo.UpdateQuestionOperation(ctx, question); err != nil {
+		return err
+	}
+	if show == entity.QuestionShow {
+		return qs.questionShareRepo.RemoveQuestionShareLinks(ctx, question.ID)
+	}
+	return nil
+}
+
+// AddQuestionShareLink add the share link of the unlisted question
+func (qs *QuestionShareService) AddQuestionShareLink(ctx context.Context, req *schema.AddQuestionShareLinkReq) (
+	resp *schema.QuestionShareLinkResp, err error) {
+	question, err := qs.getManageableQuestion(ctx, req.QuestionID, req.UserID, req.IsModerator)
+	if err != nil {
+		return nil, err
+	}
+	if question.Show != entity.QuestionUnlisted {
+		return nil, errors.BadRequest(reason.QuestionNotUnlisted)
+	}
+
+	link := &entity.QuestionShareLink{
+		QuestionID: question.ID,
+		UserID:     req.UserID,
+		Token:      strings.ReplaceAll(uuid.NewString(), "-", ""),
+	}
+	if req.ExpireDays > 0 {
+		link.ExpiredAt = time.Now().AddDate(0, 0, req.ExpireDays)
+	}
+	if err = qs.questionShareRepo.AddQuestionShareLink(ctx, link); err != nil {
+		return nil, err
+	}
+	return qs.formatShareLink(ctx, question, link), nil
+}
+
+// GetQuestionShareLinks get all share links of the question
+func (qs *QuestionShareService) GetQuestionShareLinks(ctx context.Context, req *schema.GetQuestionShareLinksReq) (
+	resp []*schema.QuestionShareLinkResp, err error) {
+	question, err := qs.getManageableQuestion(ctx, req.QuestionID, req.UserID, req.IsModerator)
+	if err != nil {
+		return nil, err
+	}
+	links, err := qs.questionShareRepo.GetQuestionShareLinks(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.QuestionShareLinkResp, 0, len(links))
+	for _, link := range links {
+		resp = append(resp, qs.formatShareLink(ctx, question, link))
+	}
+	return resp, nil
+}
+
+// RemoveQuestionShareLink revoke the share link
+func (qs *QuestionShareService) RemoveQuestionShareLink(ctx context.Context, req *schema.RemoveQuestionShareLinkReq) (err error) {
+	link, exist, err := qs.questionShareRepo.GetQuestionShareLink(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.QuestionShareLinkNotFound)
+	}
+	if _, err = qs.getManageableQuestion(ctx, link.QuestionID, req.UserID, req.IsModerator); err != nil {
+		return err
+	}
+	return qs.questionShareRepo.RemoveQuestionShareLink(ctx, link.ID)
+}
+
+// CheckShareToken whether the share token is a valid share link of the question
+func (qs *QuestionShareService) CheckShareToken(ctx context.Context, questionID, token string) bool {
+	if len(token) == 0 {
+		return false
+	}
+	link, exist, err := qs.questionShareRepo.GetQuestionShareLinkByToken(ctx, token)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return exist && link.QuestionID == uid.DeShortID(questionID) && link.IsValid()
+}
+
+// CheckQuestionVisible whether the user can view the question, the unlisted question only can be viewed by
+// the author, the moderators and the visitors with a valid share token
+func (qs *QuestionShareService) CheckQuestionVisible(ctx context.Context, questionID, userID, token string,
+	isModerator bool) (visible bool, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		return false, err
+	}
+	if !exist || question.Show != entity.QuestionUnlisted {
+		return true, nil
+	}
+	if isModerator || question.UserID == userID {
+		return true, nil
+	}
+	return qs.CheckShareToken(ctx, question.ID, token), nil
+}
+
+// getManageableQuestion only the author and the moderators can manage the visibility of the question
+func (qs *QuestionShareService) getManageableQuestion(ctx context.Context, questionID, userID string,
+	isModerator bool) (question *entity.Question, err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, uid.DeShortID(questionID))
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	if !isModerator && question.UserID != userID {
+		return nil, errors.Forbidden(reason.QuestionShareNoPermission)
+	}
+	return question, nil
+}
+
+func (qs *QuestionShareService) formatShareLink(ctx context.Context, question *entity.Question,
+	link *entity.QuestionShareLink) (resp *schema.QuestionShareLinkResp) {
+	resp = &schema.QuestionShareLinkResp{
+		ID:        link.ID,
+		Token:     link.Token,
+		CreatedAt: link.CreatedAt.Unix(),
+	}
+	if !link.ExpiredAt.IsZero() {
+		resp.ExpiredAt = link.ExpiredAt.Unix()
+	}
+	siteGeneral, err := qs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return resp
+	}
+	siteSeo, err := qs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		log.Error(err)
+		return resp
+	}
+	resp.URL = fmt.Sprintf("%s?%s=%s",
+		display.QuestionURL(siteSeo.Permalink, siteGeneral.SiteUrl, question.ID, question.Title),
+		schema.QuestionShareTokenParam, link.Token)
+	return resp
+}
@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_share
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockQuestionShareRepo struct {
+	QuestionShareRepo
+	links map[string]*entity.QuestionShareLink
+}
+
+func (m *mockQuestionShareRepo) GetQuestionShareLinkByToken(_ context.Context, token string) (
+	link *entity.QuestionShareLink, exist bool, err error) {
+	link, exist = m.links[token]
+	return link, exist, nil
+}
+
+func TestCheckShareToken(t *testing.T) {
+	qs := &QuestionShareService{questionShareRepo: &mockQuestionShareRepo{links: map[string]*entity.QuestionShareLink{
+		"valid":   {QuestionID: "10010000000000001", Token: "valid"},
+		"expired": {QuestionID: "10010000000000001", Token: "expired", ExpiredAt: time.Now().Add(-time.Hour)},
+		"future":  {QuestionID: "10010000000000001", Token: "future", ExpiredAt: time.Now().Add(time.Hour)},
+	}}}
+	ctx := context.TODO()
+
+	assert.True(t, qs.CheckShareToken(ctx, "10010000000000001", "valid"))
+	assert.True(t, qs.CheckShareToken(ctx, "10010000000000001", "future"))
+	assert.False(t, qs.CheckShareToken(ctx, "10010000000000001", "expired"))
+	assert.False(t, qs.CheckShareToken(ctx, "10010000000000002", "valid"))
+	assert.False(t, qs.CheckShareToken(ctx, "10010000000000001", "unknown"))
+	assert.False(t, qs.CheckShareToken(ctx, "10010000000000001", ""))
+}
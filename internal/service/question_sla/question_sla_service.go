@@ -0,0 +1,384 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_sla
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// escalationWindow only the breaches happened within it are escalated,
+// so that the long overdue questions will not flood the staff when a policy is added
+const escalationWindow = 24 * time.Hour
+
+// QuestionSLARepo question SLA repository
+type QuestionSLARepo interface {
+	GetTagSLAPolicies(ctx context.Context) (policies []*entity.TagSLAPolicy, err error)
+	GetTagSLAPolicyByTagID(ctx context.Context, tagID string) (policy *entity.TagSLAPolicy, exist bool, err error)
+	AddTagSLAPolicy(ctx context.Context, policy *entity.TagSLAPolicy) (err error)
+	UpdateTagSLAPolicy(ctx context.Context, policy *entity.TagSLAPolicy) (err error)
+	RemoveTagSLAPolicy(ctx context.Context, id int) (err error)
+	GetOpenSLAQuestions(ctx context.Context, tagIDs []string) (questions []*entity.SLAQuestion, err error)
+	GetQuestionSLAs(ctx context.Context, questionIDs []string) (slas []*entity.QuestionSLA, err error)
+	SaveQuestionSLA(ctx context.Context, sla *entity.QuestionSLA) (err error)
+}
+
+// QuestionSLAService question SLA service
+type QuestionSLAService struct {
+	questionSLARepo          QuestionSLARepo
+	questionRepo             questioncommon.QuestionRepo
+	tagCommonService         *tagcommon.TagCommonService
+	userRepo                 usercommon.UserRepo
+	userRoleRelService       *role.UserRoleRelService
+	emailService             *export.EmailService
+	notificationQueueService notice_queue.NotificationQueueService
+}
+
+// NewQuestionSLAService new question SLA service
+func NewQuestionSLAService(
+	questionSLARepo QuestionSLARepo,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommonService *tagcommon.TagCommonService,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	emailService *export.EmailService,
+	notificationQueueService notice_queue.NotificationQueueService,
+) *QuestionSLAService {
+	return &QuestionSLAService{
+		questionSLARepo:          questionSLARepo,
+		questionRepo:             questionRepo,
+		tagCommonService:         tagCommonService,
+		userRepo:                 userRepo,
+		userRoleRelService:       userRoleRelService,
+		emailService:             emailService,
+		notificationQueueService: notificationQueueService,
+	}
+}
+
+// GetTagSLAPolicies get all tag SLA policies
+func (qs *QuestionSLAService) GetTagSLAPolicies(ctx context.Context) (resp []*schema.TagSLAPolicyResp, err error) {
+	policies, err := qs.questionSLARepo.GetTagSLAPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		tagIDs = append(tagIDs, policy.TagID)
+	}
+	tagMapping := make(map[string]*entity.Tag)
+	if len(tagIDs) > 0 {
+		tags, err := qs.tagCommonService.GetTagListByIDs(ctx, tagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			tagMapping[tag.ID] = tag
+		}
+	}
+
+	resp = make([]*schema.TagSLAPolicyResp, 0, len(policies))
+	for _, policy := range policies {
+		tag, ok := tagMapping[policy.TagID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.TagSLAPolicyResp{
+			ID:                 policy.ID,
+			TagSlugName:        tag.SlugName,
+			TagDisplayName:     tag.DisplayName,
+			FirstResponseHours: policy.FirstResponseHours,
+			ResolutionHours:    policy.ResolutionHours,
+			EscalationChannels: splitChannels(policy.EscalationChannels),
+			UpdatedAt:          policy.UpdatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+// SaveTagSLAPolicy add the SLA policy of the tag or replace the existing one
+func (qs *QuestionSLAService) SaveTagSLAPolicy(ctx context.Context, req *schema.SaveTagSLAPolicyReq) (err error) {
+	if req.FirstResponseHours == 0 && req.ResolutionHours == 0 {
+		return errors.BadRequest(reason.SLAPolicyEmpty)
+	}
+	tag, exist, err := qs.tagCommonService.GetTagBySlugName(ctx, strings.ToLower(req.TagSlugName))
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.TagNotFound)
+	}
+
+	policy, exist, err := qs.questionSLARepo.GetTagSLAPolicyByTagID(ctx, tag.ID)
+	if err != nil {
+		return err
+	}
+	policy.TagID = tag.ID
+	policy.FirstResponseHours = req.FirstResponseHours
+	policy.ResolutionHours = req.ResolutionHours
+	policy.EscalationChannels = strings.Join(req.EscalationChannels, ",")
+	if exist {
+		return qs.questionSLARepo.UpdateTagSLAPolicy(ctx, policy)
+	}
+	return qs.questionSLARepo.AddTagSLAPolicy(ctx, policy)
+}
+
+// RemoveTagSLAPolicy remove tag SLA policy, the SLA state of the questions is kept
+func (qs *QuestionSLAService) RemoveTagSLAPolicy(ctx context.Context, req *schema.RemoveTagSLAPolicyReq) (err error) {
+	return qs.questionSLARepo.RemoveTagSLAPolicy(ctx, req.ID)
+}
+
+// FillQuestionPageSLA fill the SLA state of the questions in the list
+func (qs *QuestionSLAService) FillQuestionPageSLA(ctx context.Context, questions []*schema.QuestionPageResp) {
+	if len(questions) == 0 {
+		return
+	}
+	questionIDs := make([]string, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, uid.DeShortID(question.ID))
+	}
+	slas, err := qs.questionSLARepo.GetQuestionSLAs(ctx, questionIDs)
+	if err != nil {
+		log.Errorf("get question sla failed: %v", err)
+		return
+	}
+	slaMapping := make(map[string]*entity.QuestionSLA, len(slas))
+	for _, sla := range slas {
+		slaMapping[sla.QuestionID] = sla
+	}
+	for _, question := range questions {
+		sla, ok := slaMapping[uid.DeShortID(question.ID)]
+		if !ok {
+			continue
+		}
+		question.SLA = formatQuestionSLA(sla, len(question.AcceptedAnswerID) > 0 && question.AcceptedAnswerID != "0")
+	}
+}
+
+// slaTarget the open question with the strictest SLA merged from the policies of its tags
+type slaTarget struct {
+	createdAt          time.Time
+	answerCount        int
+	firstResponseHours int
+	resolutionHours    int
+	channels           map[string]bool
+}
+
+// SLACheckCron compute the SLA state of the open questions and escalate the new breaches
+func (qs *QuestionSLAService) SLACheckCron(ctx context.Context) (err error) {
+	policies, err := qs.questionSLARepo.GetTagSLAPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	policyMapping := make(map[string]*entity.TagSLAPolicy, len(policies))
+	tagIDs := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		policyMapping[policy.TagID] = policy
+		tagIDs = append(tagIDs, policy.TagID)
+	}
+	questions, err := qs.questionSLARepo.GetOpenSLAQuestions(ctx, tagIDs)
+	if err != nil {
+		return err
+	}
+
+	targets := make(map[string]*slaTarget)
+	questionIDs := make([]string, 0)
+	for _, question := range questions {
+		policy := policyMapping[question.TagID]
+		target, ok := targets[question.QuestionID]
+		if !ok {
+			target = &slaTarget{
+				createdAt:   question.CreatedAt,
+				answerCount: question.AnswerCount,
+				channels:    make(map[string]bool),
+			}
+			targets[question.QuestionID] = target
+			questionIDs = append(questionIDs, question.QuestionID)
+		}
+		target.firstResponseHours = minPositive(target.firstResponseHours, policy.FirstResponseHours)
+		target.resolutionHours = minPositive(target.resolutionHours, policy.ResolutionHours)
+		for _, channel := range splitChannels(policy.EscalationChannels) {
+			target.channels[channel] = true
+		}
+	}
+
+	slas, err := qs.questionSLARepo.GetQuestionSLAs(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	slaMapping := make(map[string]*entity.QuestionSLA, len(slas))
+	for _, sla := range slas {
+		slaMapping[sla.QuestionID] = sla
+	}
+
+	now := time.Now()
+	for _, questionID := range questionIDs {
+		target := targets[questionID]
+		sla, ok := slaMapping[questionID]
+		if !ok {
+			sla = &entity.QuestionSLA{QuestionID: questionID}
+		}
+		sla.FirstResponseDueAt = dueAt(target.createdAt, target.firstResponseHours)
+		sla.ResolutionDueAt = dueAt(target.createdAt, target.resolutionHours)
+
+		if target.answerCount == 0 && isBreached(sla.FirstResponseDueAt, now) && sla.FirstResponseBreachedAt.IsZero() {
+			sla.FirstResponseBreachedAt = now
+			if now.Sub(sla.FirstResponseDueAt) < escalationWindow {
+				qs.escalate(ctx, questionID, true, target.firstResponseHours, target.channels)
+			}
+		}
+		if isBreached(sla.ResolutionDueAt, now) && sla.ResolutionBreachedAt.IsZero() {
+			sla.ResolutionBreachedAt = now
+			if now.Sub(sla.ResolutionDueAt) < escalationWindow {
+				qs.escalate(ctx, questionID, false, target.resolutionHours, target.channels)
+			}
+		}
+		if err := qs.questionSLARepo.SaveQuestionSLA(ctx, sla); err != nil {
+			log.Errorf("save sla of question %s failed: %v", questionID, err)
+		}
+	}
+	return nil
+}
+
+// escalate notify the admins and moderators through the channels that the question breaches the SLA
+func (qs *QuestionSLAService) escalate(ctx context.Context, questionID string, firstResponse bool,
+	hours int, channels map[string]bool) {
+	if len(channels) == 0 {
+		return
+	}
+	rels, err := qs.userRoleRelService.GetUserByRoleID(ctx, []int{role.RoleAdminID, role.RoleModeratorID})
+	if err != nil {
+		log.Errorf("get staff failed: %v", err)
+		return
+	}
+	staffIDs := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		staffIDs = append(staffIDs, rel.UserID)
+	}
+	log.Infof("question %s breaches the sla, escalate to %d staff", questionID, len(staffIDs))
+
+	if channels[entity.SLAEscalationChannelInbox] {
+		action := constant.NotificationSLAResolutionBreached
+		if firstResponse {
+			action = constant.NotificationSLAFirstResponseBreached
+		}
+		for _, staffID := range staffIDs {
+			qs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+				ReceiverUserID:     staffID,
+				Type:               schema.NotificationTypeInbox,
+				ObjectID:           questionID,
+				ObjectType:         constant.QuestionObjectType,
+				NotificationAction: action,
+			})
+		}
+	}
+
+	if channels[entity.SLAEscalationChannelEmail] {
+		question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+		if err != nil || !exist {
+			log.Errorf("get question %s failed: %v", questionID, err)
+			return
+		}
+		title, body, err := qs.emailService.SLABreachedTemplate(ctx, questionID, question.Title, firstResponse, hours)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		staffList, err := qs.userRepo.BatchGetByID(ctx, staffIDs)
+		if err != nil {
+			log.Errorf("get staff failed: %v", err)
+			return
+		}
+		for _, staff := range staffList {
+			if staff.Status != entity.UserStatusAvailable || staff.MailStatus != entity.EmailStatusAvailable {
+				continue
+			}
+			qs.emailService.Send(ctx, staff.EMail, title, body)
+		}
+	}
+}
+
+func formatQuestionSLA(sla *entity.QuestionSLA, resolved bool) *schema.QuestionSLAResp {
+	resp := &schema.QuestionSLAResp{
+		FirstResponseBreached: !sla.FirstResponseBreachedAt.IsZero(),
+		ResolutionBreached:    !sla.ResolutionBreachedAt.IsZero(),
+	}
+	if !sla.FirstResponseDueAt.IsZero() {
+		resp.FirstResponseDueAt = sla.FirstResponseDueAt.Unix()
+	}
+	if !sla.ResolutionDueAt.IsZero() {
+		resp.ResolutionDueAt = sla.ResolutionDueAt.Unix()
+	}
+	switch {
+	case resolved:
+		resp.Status = schema.QuestionSLAStatusResolved
+	case resp.FirstResponseBreached || resp.ResolutionBreached:
+		resp.Status = schema.QuestionSLAStatusBreached
+	default:
+		resp.Status = schema.QuestionSLAStatusOnTrack
+	}
+	return resp
+}
+
+func splitChannels(channels string) []string {
+	if len(channels) == 0 {
+		return []string{}
+	}
+	return strings.Split(channels, ",")
+}
+
+// minPositive get the smaller positive one, 0 means no limit
+func minPositive(a, b int) int {
+	if a <= 0 {
+		return b
+	}
+	if b <= 0 || a < b {
+		return a
+	}
+	return b
+}
+
+func dueAt(createdAt time.Time, hours int) time.Time {
+	if hours <= 0 {
+		return time.Time{}
+	}
+	return createdAt.Add(time.Duration(hours) * time.Hour)
+}
+
+func isBreached(dueAt, now time.Time) bool {
+	return !dueAt.IsZero() && now.After(dueAt)
+}
@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package question_sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinPositive(t *testing.T) {
+	assert.Equal(t, 0, minPositive(0, 0))
+	assert.Equal(t, 4, minPositive(0, 4))
+	assert.Equal(t, 4, minPositive(4, 0))
+	assert.Equal(t, 2, minPositive(4, 2))
+}
+
+func TestFormatQuestionSLA(t *testing.T) {
+	now := time.Now()
+	sla := &entity.QuestionSLA{FirstResponseDueAt: now.Add(time.Hour)}
+	assert.Equal(t, schema.QuestionSLAStatusOnTrack, formatQuestionSLA(sla, false).Status)
+	assert.Equal(t, schema.QuestionSLAStatusResolved, formatQuestionSLA(sla, true).Status)
+
+	sla.FirstResponseBreachedAt = now
+	resp := formatQuestionSLA(sla, false)
+	assert.Equal(t, schema.QuestionSLAStatusBreached, resp.Status)
+	assert.True(t, resp.FirstResponseBreached)
+	assert.Equal(t, int64(0), resp.ResolutionDueAt)
+}
@@ -0,0 +1,310 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_summary
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/ai_assistant"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// the question is a long thread if it has enough answers or its content is long enough
+	summaryMinAnswers       = 3
+	summaryMinQuestionLen   = 1500
+	summaryQuestionMaxLen   = 4000
+	summaryAnswerMaxLen     = 4000
+	summaryExtractiveMaxLen = 300
+	// summaryWorkers the max number of the summaries generated at the same time
+	summaryWorkers = 2
+	summaryTimeout = 2 * time.Minute
+)
+
+// QuestionSummaryRepo question summary repository
+type QuestionSummaryRepo interface {
+	GetSummary(ctx context.Context, questionID string) (summary *entity.QuestionSummary, exist bool, err error)
+	GetSummariesByQuestionIDs(ctx context.Context, questionIDs []string) (summaries []*entity.QuestionSummary, err error)
+	SaveSummary(ctx context.Context, summary *entity.QuestionSummary) (err error)
+}
+
+// QuestionSummaryService summarize the long threads. The summary is generated in the background when
+// the question is viewed, and it is generated again after the question or the summarized answer is revised.
+type QuestionSummaryService struct {
+	questionSummaryRepo QuestionSummaryRepo
+	questionRepo        questioncommon.QuestionRepo
+	answerRepo          answercommon.AnswerRepo
+	aiAssistantService  *ai_assistant.AIAssistantService
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+	generating          sync.Map
+	workers             chan struct{}
+}
+
+// NewQuestionSummaryService new question summary service
+func NewQuestionSummaryService(
+	questionSummaryRepo QuestionSummaryRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	aiAssistantService *ai_assistant.AIAssistantService,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *QuestionSummaryService {
+	return &QuestionSummaryService{
+		questionSummaryRepo: questionSummaryRepo,
+		questionRepo:        questionRepo,
+		answerRepo:          answerRepo,
+		aiAssistantService:  aiAssistantService,
+		siteInfoService:     siteInfoService,
+		workers:             make(chan struct{}, summaryWorkers),
+	}
+}
+
+// GetQuestionSummary get the summary of the question, nil is returned if the question is not a long thread
+// or the summary of the current revision is not generated yet
+func (qs *QuestionSummaryService) GetQuestionSummary(ctx context.Context, questionID string) *schema.QuestionSummaryResp {
+	if !qs.autoSummaryEnabled(ctx) {
+		return nil
+	}
+	questionID = uid.DeShortID(questionID)
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	if !exist || !isLongThread(question) {
+		return nil
+	}
+	summary, exist, err := qs.questionSummaryRepo.GetSummary(ctx, questionID)
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	if exist && qs.isUpToDate(ctx, question, summary) {
+		return &schema.QuestionSummaryResp{
+			Content:     summary.Content,
+			Provider:    summary.Provider,
+			AIGenerated: summary.AIGenerated,
+		}
+	}
+	qs.summarizeInBackground(questionID)
+	return nil
+}
+
+// BatchGetSummaries get the last generated summaries of the questions, the key is the question id
+func (qs *QuestionSummaryService) BatchGetSummaries(ctx context.Context, questionIDs []string) map[string]string {
+	summaries := make(map[string]string)
+	if len(questionIDs) == 0 || !qs.autoSummaryEnabled(ctx) {
+		return summaries
+	}
+	ids := make([]string, 0, len(questionIDs))
+	for _, id := range questionIDs {
+		ids = append(ids, uid.DeShortID(id))
+	}
+	list, err := qs.questionSummaryRepo.GetSummariesByQuestionIDs(ctx, ids)
+	if err != nil {
+		log.Error(err)
+		return summaries
+	}
+	for _, summary := range list {
+		summaries[summary.QuestionID] = summary.Content
+	}
+	return summaries
+}
+
+func (qs *QuestionSummaryService) autoSummaryEnabled(ctx context.Context) bool {
+	config, err := qs.aiAssistantService.GetAIAssistant(ctx)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return config.AutoSummary
+}
+
+// isUpToDate check whether the summary is generated from the current revision of the question and the answer
+func (qs *QuestionSummaryService) isUpToDate(ctx context.Context, question *entity.Question,
+	summary *entity.QuestionSummary) bool {
+	if summary.QuestionRevisionID != question.RevisionID {
+		return false
+	}
+	if question.AcceptedAnswerID != "0" && question.AcceptedAnswerID != summary.AnswerID {
+		return false
+	}
+	if summary.AnswerID == "0" {
+		return question.AnswerCount == 0
+	}
+	answer, exist, err := qs.answerRepo.GetAnswer(ctx, summary.AnswerID)
+	if err != nil {
+		log.Error(err)
+		return true
+	}
+	return exist && answer.Status == entity.AnswerStatusAvailable && answer.RevisionID == summary.AnswerRevisionID
+}
+
+// summarizeInBackground generate the summary of the question, the question is skipped when it is being
+// summarized or too many questions are being summarized, it will be summarized when it is viewed next time
+func (qs *QuestionSummaryService) summarizeInBackground(questionID string) {
+	if _, loaded := qs.generating.LoadOrStore(questionID, true); loaded {
+		return
+	}
+	select {
+	case qs.workers <- struct{}{}:
+	default:
+		qs.generating.Delete(questionID)
+		return
+	}
+	go func() {
+		defer func() {
+			<-qs.workers
+			qs.generating.Delete(questionID)
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+		defer cancel()
+		if err := qs.summarize(ctx, questionID); err != nil {
+			log.Errorf("summarize question %s failed: %v", questionID, err)
+		}
+	}()
+}
+
+// summarize the question and its accepted or top voted answer
+func (qs *QuestionSummaryService) summarize(ctx context.Context, questionID string) (err error) {
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil || !exist {
+		return err
+	}
+	answers, err := qs.answerRepo.GetAnswerList(ctx, &entity.Answer{
+		QuestionID: questionID,
+		Status:     entity.AnswerStatusAvailable,
+	})
+	if err != nil {
+		return err
+	}
+	req := &plugin.AssistantRequest{
+		Action:  plugin.AssistantActionQuestionSummary,
+		Title:   question.Title,
+		Content: htmltext.FetchExcerpt(question.ParsedText, "...", summaryQuestionMaxLen),
+	}
+	if siteInterface, err := qs.siteInfoService.GetSiteInterface(ctx); err == nil {
+		req.Language = siteInterface.Language
+	}
+	summary := &entity.QuestionSummary{
+		QuestionID:         questionID,
+		QuestionRevisionID: question.RevisionID,
+		AnswerID:           "0",
+		AnswerRevisionID:   "0",
+	}
+	if answer := pickSummaryAnswer(question, answers); answer != nil {
+		summary.AnswerID = answer.ID
+		summary.AnswerRevisionID = answer.RevisionID
+		req.Answers = []string{htmltext.FetchExcerpt(answer.ParsedText, "...", summaryAnswerMaxLen)}
+	}
+
+	config, err := qs.aiAssistantService.GetAIAssistant(ctx)
+	if err != nil {
+		return err
+	}
+	var assistant plugin.Assistant = &extractiveSummarizer{}
+	if ai := ai_assistant.GetAssistant(config); ai != nil {
+		assistant = ai
+		summary.AIGenerated = true
+	}
+	resp, err := assistant.Generate(req)
+	if err != nil {
+		return err
+	}
+	if len(resp.Content) == 0 {
+		return fmt.Errorf("empty summary")
+	}
+	summary.Content = resp.Content
+	summary.Provider = assistant.Info().SlugName
+	return qs.questionSummaryRepo.SaveSummary(ctx, summary)
+}
+
+// isLongThread check whether the question needs the summary
+func isLongThread(question *entity.Question) bool {
+	if question.Status != entity.QuestionStatusAvailable && question.Status != entity.QuestionStatusClosed {
+		return false
+	}
+	if question.AnswerCount >= summaryMinAnswers {
+		return true
+	}
+	return utf8.RuneCountInString(htmltext.ClearText(question.ParsedText)) >= summaryMinQuestionLen
+}
+
+// pickSummaryAnswer pick the accepted answer, or the top voted answer if no answer is accepted
+func pickSummaryAnswer(question *entity.Question, answers []*entity.Answer) (picked *entity.Answer) {
+	for _, answer := range answers {
+		if answer.ID == question.AcceptedAnswerID {
+			return answer
+		}
+		if picked == nil || answer.VoteCount > picked.VoteCount {
+			picked = answer
+		}
+	}
+	return picked
+}
+
+// extractiveSummarizer the fallback summarizer which takes the leading sentences of the question and the answer
+type extractiveSummarizer struct{}
+
+func (e *extractiveSummarizer) Info() plugin.Info {
+	return plugin.Info{SlugName: schema.AIAssistantProviderExtractive}
+}
+
+func (e *extractiveSummarizer) Generate(req *plugin.AssistantRequest) (resp *plugin.AssistantResponse, err error) {
+	parts := []string{leadingSentences(req.Content, summaryExtractiveMaxLen)}
+	for _, answer := range req.Answers {
+		parts = append(parts, leadingSentences(answer, summaryExtractiveMaxLen))
+	}
+	return &plugin.AssistantResponse{
+		Content: strings.TrimSpace(strings.Join(parts, "\n\n")),
+	}, nil
+}
+
+// leadingSentences get the complete sentences at the beginning of the text within the limit,
+// the text is cut at the limit if the first sentence is longer than the limit
+func leadingSentences(text string, limit int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	end := -1
+	for i := 0; i < limit; i++ {
+		switch runes[i] {
+		case '.', '!', '?', '。', '！', '？':
+			end = i + 1
+		}
+	}
+	if end <= 0 {
+		return strings.TrimSpace(string(runes[:limit])) + "..."
+	}
+	return strings.TrimSpace(string(runes[:end]))
+}
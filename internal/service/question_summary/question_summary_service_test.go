@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_summary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickSummaryAnswer(t *testing.T) {
+	answers := []*entity.Answer{
+		{ID: "1", VoteCount: 1},
+		{ID: "2", VoteCount: 5},
+		{ID: "3", VoteCount: 0},
+	}
+	assert.Equal(t, "2", pickSummaryAnswer(&entity.Question{AcceptedAnswerID: "0"}, answers).ID)
+	assert.Equal(t, "3", pickSummaryAnswer(&entity.Question{AcceptedAnswerID: "3"}, answers).ID)
+	assert.Nil(t, pickSummaryAnswer(&entity.Question{AcceptedAnswerID: "0"}, nil))
+}
+
+func TestIsLongThread(t *testing.T) {
+	assert.True(t, isLongThread(&entity.Question{Status: entity.QuestionStatusAvailable, AnswerCount: summaryMinAnswers}))
+	assert.False(t, isLongThread(&entity.Question{Status: entity.QuestionStatusAvailable, ParsedText: "<p>short</p>"}))
+	assert.True(t, isLongThread(&entity.Question{
+		Status:     entity.QuestionStatusClosed,
+		ParsedText: "<p>" + strings.Repeat("a", summaryMinQuestionLen) + "</p>",
+	}))
+	assert.False(t, isLongThread(&entity.Question{Status: entity.QuestionStatusDeleted, AnswerCount: 10}))
+}
+
+func TestLeadingSentences(t *testing.T) {
+	assert.Equal(t, "short text", leadingSentences(" short text ", 20))
+	assert.Equal(t, "First one. Second one!", leadingSentences("First one. Second one! Third one is long.", 30))
+	assert.Equal(t, "abcde...", leadingSentences("abcdefghij", 5))
+}
@@ -0,0 +1,303 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/converter"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// QuestionWorkflowRepo question workflow repository
+type QuestionWorkflowRepo interface {
+	UpdateWorkflowState(ctx context.Context, transition *entity.QuestionWorkflowTransition) (err error)
+	GetTransitions(ctx context.Context, questionID string) (transitions []*entity.QuestionWorkflowTransition, err error)
+}
+
+// QuestionWorkflowService the configurable workflow states of the questions, the questions are moved
+// between the states by the transitions allowed for the role of the user
+type QuestionWorkflowService struct {
+	questionWorkflowRepo QuestionWorkflowRepo
+	questionRepo         questioncommon.QuestionRepo
+	siteInfoRepo         siteinfo_common.SiteInfoRepo
+	siteInfoService      siteinfo_common.SiteInfoCommonService
+	userRoleRelService   *role.UserRoleRelService
+	activityQueueService activity_queue.ActivityQueueService
+}
+
+// NewQuestionWorkflowService new question workflow service
+func NewQuestionWorkflowService(
+	questionWorkflowRepo QuestionWorkflowRepo,
+	questionRepo questioncommon.QuestionRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	userRoleRelService *role.UserRoleRelService,
+	activityQueueService activity_queue.ActivityQueueService,
+) *QuestionWorkflowService {
+	return &QuestionWorkflowService{
+		questionWorkflowRepo: questionWorkflowRepo,
+		questionRepo:         questionRepo,
+		siteInfoRepo:         siteInfoRepo,
+		siteInfoService:      siteInfoService,
+		userRoleRelService:   userRoleRelService,
+		activityQueueService: activityQueueService,
+	}
+}
+
+// GetQuestionWorkflow get the question workflow of the site
+func (qs *QuestionWorkflowService) GetQuestionWorkflow(ctx context.Context) (
+	resp *schema.SiteQuestionWorkflowReq, err error) {
+	resp = &schema.SiteQuestionWorkflowReq{}
+	if err = qs.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeQuestionWorkflow, resp); err != nil {
+		return nil, err
+	}
+	if resp.States == nil {
+		resp.States = make([]*schema.QuestionWorkflowState, 0)
+	}
+	if resp.Transitions == nil {
+		resp.Transitions = make([]*schema.QuestionWorkflowTransition, 0)
+	}
+	return resp, nil
+}
+
+// UpdateQuestionWorkflow update the question workflow of the site
+func (qs *QuestionWorkflowService) UpdateQuestionWorkflow(ctx context.Context,
+	req *schema.SiteQuestionWorkflowReq) (err error) {
+	for _, state := range req.States {
+		state.Key = strings.TrimSpace(state.Key)
+		state.Name = strings.TrimSpace(state.Name)
+	}
+	if err = checkWorkflow(req); err != nil {
+		return errors.BadRequest(reason.QuestionWorkflowInvalid).WithMsg(err.Error())
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeQuestionWorkflow,
+		Content: string(content),
+	}
+	return qs.siteInfoRepo.SaveByType(ctx, constant.SiteTypeQuestionWorkflow, data)
+}
+
+// GetQuestionWorkflowState get the workflow state of the question, the next states allowed for the user
+// and the history of the transitions
+func (qs *QuestionWorkflowService) GetQuestionWorkflowState(ctx context.Context,
+	req *schema.GetQuestionWorkflowStateReq) (resp *schema.GetQuestionWorkflowStateResp, err error) {
+	workflow, question, err := qs.getWorkflowQuestion(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetQuestionWorkflowStateResp{
+		State:      workflow.GetState(question.WorkflowState),
+		NextStates: make([]*schema.QuestionWorkflowState, 0),
+		History:    make([]*schema.QuestionWorkflowTransitionResp, 0),
+	}
+
+	if len(req.UserID) > 0 {
+		roleID, err := qs.userRoleRelService.GetUserRole(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, transition := range workflow.Transitions {
+			if resp.State != nil && transition.From == resp.State.Key && transitionAllowed(transition, roleID) {
+				if state := workflow.GetState(transition.To); state != nil {
+					resp.NextStates = append(resp.NextStates, state)
+				}
+			}
+		}
+	}
+
+	transitions, err := qs.questionWorkflowRepo.GetTransitions(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, transition := range transitions {
+		resp.History = append(resp.History, &schema.QuestionWorkflowTransitionResp{
+			CreatedAt: transition.CreatedAt.Unix(),
+			UserID:    transition.UserID,
+			FromState: transition.FromState,
+			ToState:   transition.ToState,
+		})
+	}
+	return resp, nil
+}
+
+// UpdateQuestionWorkflowState move the question to the state if the transition is allowed for the user
+func (qs *QuestionWorkflowService) UpdateQuestionWorkflowState(ctx context.Context,
+	req *schema.UpdateQuestionWorkflowStateReq) (err error) {
+	workflow, question, err := qs.getWorkflowQuestion(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	from := workflow.GetState(question.WorkflowState)
+	if from == nil || workflow.GetState(req.State) == nil {
+		return errors.BadRequest(reason.QuestionWorkflowStateNotFound)
+	}
+	roleID, err := qs.userRoleRelService.GetUserRole(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	allowed := false
+	for _, transition := range workflow.Transitions {
+		if transition.From == from.Key && transition.To == req.State && transitionAllowed(transition, roleID) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Forbidden(reason.QuestionWorkflowNotAllowed)
+	}
+
+	err = qs.questionWorkflowRepo.UpdateWorkflowState(ctx, &entity.QuestionWorkflowTransition{
+		QuestionID: question.ID,
+		UserID:     req.UserID,
+		FromState:  from.Key,
+		ToState:    req.State,
+	})
+	if err != nil {
+		return err
+	}
+	qs.activityQueueService.Send(ctx, &schema.ActivityMsg{
+		UserID:           question.UserID,
+		TriggerUserID:    converter.StringToInt64(req.UserID),
+		ObjectID:         question.ID,
+		OriginalObjectID: question.ID,
+		ActivityTypeKey:  constant.ActQuestionWorkflowChanged,
+	})
+	return nil
+}
+
+// GetFilterStates get the workflow states of the question page filter, the questions without the state
+// are in the initial state. It returns nil if the workflow is not enabled or the state is empty.
+func (qs *QuestionWorkflowService) GetFilterStates(ctx context.Context, state string) []string {
+	if len(state) == 0 {
+		return nil
+	}
+	workflow, err := qs.GetQuestionWorkflow(ctx)
+	if err != nil {
+		log.Errorf("get question workflow failed: %v", err)
+		return nil
+	}
+	if !workflow.Enabled {
+		return nil
+	}
+	if state == workflow.InitialState {
+		return []string{state, ""}
+	}
+	return []string{state}
+}
+
+// FillQuestionPageWorkflow fill the workflow state of the questions if the workflow is enabled
+func (qs *QuestionWorkflowService) FillQuestionPageWorkflow(ctx context.Context,
+	questions []*schema.QuestionPageResp) {
+	if len(questions) == 0 {
+		return
+	}
+	workflow, err := qs.GetQuestionWorkflow(ctx)
+	if err != nil {
+		log.Errorf("get question workflow failed: %v", err)
+		return
+	}
+	if !workflow.Enabled {
+		return
+	}
+	for _, question := range questions {
+		question.WorkflowState = workflow.GetState(question.WorkflowStateKey)
+	}
+}
+
+// getWorkflowQuestion get the enabled workflow and the question
+func (qs *QuestionWorkflowService) getWorkflowQuestion(ctx context.Context, questionID string) (
+	workflow *schema.SiteQuestionWorkflowReq, question *entity.Question, err error) {
+	workflow, err = qs.GetQuestionWorkflow(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !workflow.Enabled {
+		return nil, nil, errors.BadRequest(reason.QuestionWorkflowDisabled)
+	}
+	question, exist, err := qs.questionRepo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted {
+		return nil, nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	question.ID = uid.DeShortID(question.ID)
+	return workflow, question, nil
+}
+
+// transitionAllowed the admins are always allowed, the moderators are allowed if the roles are not set
+func transitionAllowed(transition *schema.QuestionWorkflowTransition, roleID int) bool {
+	if roleID == role.RoleAdminID {
+		return true
+	}
+	if len(transition.RoleIDs) == 0 {
+		return roleID == role.RoleModeratorID
+	}
+	for _, item := range transition.RoleIDs {
+		if item == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWorkflow the keys of the states are unique and the transitions are between the states
+func checkWorkflow(workflow *schema.SiteQuestionWorkflowReq) error {
+	if workflow.Enabled && len(workflow.States) == 0 {
+		return fmt.Errorf("the states are required")
+	}
+	if len(workflow.States) == 0 {
+		return nil
+	}
+	keys := make(map[string]bool, len(workflow.States))
+	for _, state := range workflow.States {
+		if keys[state.Key] {
+			return fmt.Errorf("the state %s is duplicated", state.Key)
+		}
+		keys[state.Key] = true
+	}
+	if !keys[workflow.InitialState] {
+		return fmt.Errorf("the initial state %s does not exist", workflow.InitialState)
+	}
+	for _, transition := range workflow.Transitions {
+		if !keys[transition.From] || !keys[transition.To] {
+			return fmt.Errorf("the transition %s → %s is not between the states", transition.From, transition.To)
+		}
+		if transition.From == transition.To {
+			return fmt.Errorf("the transition %s → %s does not change the state", transition.From, transition.To)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package question_workflow
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/stretchr/testify/assert"
+)
+
+func newWorkflow() *schema.SiteQuestionWorkflowReq {
+	return &schema.SiteQuestionWorkflowReq{
+		Enabled:      true,
+		InitialState: "new",
+		States: []*schema.QuestionWorkflowState{
+			{Key: "new", Name: "New"},
+			{Key: "triaged", Name: "Triaged"},
+			{Key: "archived", Name: "Archived"},
+		},
+		Transitions: []*schema.QuestionWorkflowTransition{
+			{From: "new", To: "triaged", RoleIDs: []int{role.RoleUserID}},
+			{From: "triaged", To: "archived"},
+		},
+	}
+}
+
+func TestCheckWorkflow(t *testing.T) {
+	assert.NoError(t, checkWorkflow(newWorkflow()))
+	assert.NoError(t, checkWorkflow(&schema.SiteQuestionWorkflowReq{}))
+	assert.Error(t, checkWorkflow(&schema.SiteQuestionWorkflowReq{Enabled: true}))
+
+	workflow := newWorkflow()
+	workflow.InitialState = "verified"
+	assert.Error(t, checkWorkflow(workflow))
+
+	workflow = newWorkflow()
+	workflow.States = append(workflow.States, &schema.QuestionWorkflowState{Key: "new", Name: "Again"})
+	assert.Error(t, checkWorkflow(workflow))
+
+	workflow = newWorkflow()
+	workflow.Transitions = append(workflow.Transitions, &schema.QuestionWorkflowTransition{From: "new", To: "done"})
+	assert.Error(t, checkWorkflow(workflow))
+
+	workflow = newWorkflow()
+	workflow.Transitions = append(workflow.Transitions, &schema.QuestionWorkflowTransition{From: "new", To: "new"})
+	assert.Error(t, checkWorkflow(workflow))
+}
+
+func TestTransitionAllowed(t *testing.T) {
+	workflow := newWorkflow()
+	assert.True(t, transitionAllowed(workflow.Transitions[0], role.RoleUserID))
+	assert.False(t, transitionAllowed(workflow.Transitions[0], role.RoleModeratorID))
+	assert.True(t, transitionAllowed(workflow.Transitions[0], role.RoleAdminID))
+	assert.False(t, transitionAllowed(workflow.Transitions[1], role.RoleUserID))
+	assert.True(t, transitionAllowed(workflow.Transitions[1], role.RoleModeratorID))
+}
+
+func TestGetState(t *testing.T) {
+	workflow := newWorkflow()
+	assert.Equal(t, "new", workflow.GetState("").Key)
+	assert.Equal(t, "triaged", workflow.GetState("triaged").Key)
+	assert.Nil(t, workflow.GetState("verified"))
+}
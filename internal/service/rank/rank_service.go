@@ -30,9 +30,11 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_type"
+	"github.com/apache/incubator-answer/internal/service/co_author"
 	"github.com/apache/incubator-answer/internal/service/config"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	"github.com/apache/incubator-answer/internal/service/permission"
+	"github.com/apache/incubator-answer/internal/service/permission_policy"
 	"github.com/apache/incubator-answer/internal/service/role"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/htmltext"
@@ -64,6 +66,8 @@ type RankService struct {
 	objectInfoService *object_info.ObjService
 	roleService       *role.UserRoleRelService
 	rolePowerService  *role.RolePowerRelService
+	coAuthorService   *co_author.CoAuthorService
+	policyService     *permission_policy.PermissionPolicyService
 }
 
 // NewRankService new rank service
@@ -73,7 +77,9 @@ func NewRankService(
 	objectInfoService *object_info.ObjService,
 	roleService *role.UserRoleRelService,
 	rolePowerService *role.RolePowerRelService,
-	configService *config.ConfigService) *RankService {
+	configService *config.ConfigService,
+	coAuthorService *co_author.CoAuthorService,
+	policyService *permission_policy.PermissionPolicyService) *RankService {
 	return &RankService{
 		userCommon:        userCommon,
 		configService:     configService,
@@ -81,6 +87,8 @@ func NewRankService(
 		objectInfoService: objectInfoService,
 		roleService:       roleService,
 		rolePowerService:  rolePowerService,
+		coAuthorService:   coAuthorService,
+		policyService:     policyService,
 	}
 }
 
@@ -99,7 +107,7 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 	if !exist {
 		return false, nil
 	}
-	powerMapping := rs.getUserPowerMapping(ctx, userID)
+	roleID, powerMapping := rs.getUserPowerMapping(ctx, userID)
 	if powerMapping[action] {
 		return true, nil
 	}
@@ -116,7 +124,7 @@ func (rs *RankService) CheckOperationPermission(ctx context.Context, userID stri
 		}
 	}
 
-	can, _ = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
+	can, _ = rs.checkActionRank(ctx, userInfo.ID, userInfo.Rank, roleID, action)
 	return can, nil
 }
 
@@ -138,13 +146,13 @@ func (rs *RankService) CheckOperationPermissionsForRanks(ctx context.Context, us
 		return can, requireRanks, nil
 	}
 
-	powerMapping := rs.getUserPowerMapping(ctx, userID)
+	roleID, powerMapping := rs.getUserPowerMapping(ctx, userID)
 	for idx, action := range actions {
 		if powerMapping[action] {
 			can[idx] = true
 			continue
 		}
-		meetRank, requireRank := rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
+		meetRank, requireRank := rs.checkActionRank(ctx, userInfo.ID, userInfo.Rank, roleID, action)
 		can[idx] = meetRank
 		requireRanks[idx] = requireRank
 	}
@@ -158,6 +166,117 @@ func (rs *RankService) CheckOperationPermissions(ctx context.Context, userID str
 	return can, err
 }
 
+// GetObjectActionPermissions get the permissions of the edit, delete, close and vote actions
+// on the objects of the type, the close permission is only resolved for the question
+func (rs *RankService) GetObjectActionPermissions(ctx context.Context, userID, objectType string) (
+	per *schema.ObjectActionPermissions, err error) {
+	var actions []string
+	switch objectType {
+	case constant.QuestionObjectType:
+		actions = []string{permission.QuestionEdit, permission.QuestionDelete, permission.QuestionVoteUp,
+			permission.QuestionClose}
+	case constant.AnswerObjectType:
+		actions = []string{permission.AnswerEdit, permission.AnswerDelete, permission.AnswerVoteUp}
+	case constant.CommentObjectType:
+		actions = []string{permission.CommentEdit, permission.CommentDelete, permission.CommentVoteUp}
+	default:
+		return &schema.ObjectActionPermissions{}, nil
+	}
+	can, requireRanks, err := rs.CheckOperationPermissionsForRanks(ctx, userID, actions)
+	if err != nil {
+		return nil, err
+	}
+	per = &schema.ObjectActionPermissions{
+		Edit:   &schema.ActionPermission{Can: can[0], RequireRank: requireRanks[0]},
+		Delete: &schema.ActionPermission{Can: can[1], RequireRank: requireRanks[1]},
+		Vote:   &schema.ActionPermission{Can: can[2], RequireRank: requireRanks[2]},
+	}
+	if len(actions) > 3 {
+		per.Close = &schema.ActionPermission{Can: can[3], RequireRank: requireRanks[3]}
+	}
+	return per, nil
+}
+
+// GetQuestionPermission get the permissions of the user on the questions,
+// the permissions on one question are resolved by WithQuestionEditor
+func (rs *RankService) GetQuestionPermission(ctx context.Context, userID string) (
+	per schema.QuestionPermission, err error) {
+	canList, err := rs.CheckOperationPermissions(ctx, userID, []string{
+		permission.QuestionEdit,
+		permission.QuestionDelete,
+		permission.QuestionClose,
+		permission.QuestionReopen,
+		permission.QuestionPin,
+		permission.QuestionUnPin,
+		permission.QuestionHide,
+		permission.QuestionShow,
+		permission.AnswerInviteSomeoneToAnswer,
+		permission.QuestionUnDelete,
+	})
+	if err != nil {
+		return per, err
+	}
+	per.CanEdit = canList[0]
+	per.CanDelete = canList[1]
+	per.CanClose = canList[2]
+	per.CanReopen = canList[3]
+	per.CanPin = canList[4]
+	per.CanUnPin = canList[5]
+	per.CanHide = canList[6]
+	per.CanShow = canList[7]
+	per.CanInviteOtherToAnswer = canList[8]
+	per.CanRecover = canList[9]
+	per.ActionPermissions, err = rs.GetObjectActionPermissions(ctx, userID, constant.QuestionObjectType)
+	return per, err
+}
+
+// WithQuestionEditor the owner and the co-authors of the question can edit it. The action permissions
+// are copied, so the permissions got by GetQuestionPermission can be shared by many questions.
+func (rs *RankService) WithQuestionEditor(ctx context.Context, per schema.QuestionPermission,
+	userID, questionID string) schema.QuestionPermission {
+	objectEditor := rs.CheckOperationObjectEditor(ctx, userID, questionID)
+	per.CanEdit = per.CanEdit || objectEditor
+
+	actionPermissions := *per.ActionPermissions
+	edit := *actionPermissions.Edit
+	edit.Can = edit.Can || objectEditor
+	actionPermissions.Edit = &edit
+	per.ActionPermissions = &actionPermissions
+	return per
+}
+
+// SetAnswerListPermission set the permissions of the user on the answers in the list
+func (rs *RankService) SetAnswerListPermission(ctx context.Context, req *schema.AnswerListReq) (err error) {
+	canList, err := rs.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.AnswerEdit,
+		permission.AnswerDelete,
+		permission.AnswerUnDelete,
+	})
+	if err != nil {
+		return err
+	}
+	req.CanEdit = canList[0]
+	req.CanDelete = canList[1]
+	req.CanRecover = canList[2]
+	req.ActionPermissions, err = rs.GetObjectActionPermissions(ctx, req.UserID, constant.AnswerObjectType)
+	return err
+}
+
+// SetCommentListPermission set the permissions of the user on the comments in the list
+func (rs *RankService) SetCommentListPermission(ctx context.Context, req *schema.GetCommentWithPageReq) (err error) {
+	canList, err := rs.CheckOperationPermissions(ctx, req.UserID, []string{
+		permission.CommentEdit,
+		permission.CommentDelete,
+	})
+	if err != nil {
+		return err
+	}
+	req.CanEdit = canList[0]
+	req.CanDelete = canList[1]
+	req.ActionPermissions, err = rs.GetObjectActionPermissions(ctx, req.UserID, constant.CommentObjectType)
+	return err
+}
+
 // CheckOperationObjectOwner check operation object owner
 func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, objectID string) bool {
 	objectID = uid.DeShortID(objectID)
@@ -174,6 +293,15 @@ func (rs *RankService) CheckOperationObjectOwner(ctx context.Context, userID, ob
 	return false
 }
 
+// CheckOperationObjectEditor check whether the user is the owner or the co-author of the object,
+// both of them can edit the object without review.
+func (rs *RankService) CheckOperationObjectEditor(ctx context.Context, userID, objectID string) bool {
+	if rs.CheckOperationObjectOwner(ctx, userID, objectID) {
+		return true
+	}
+	return rs.coAuthorService.IsCoAuthor(ctx, uid.DeShortID(objectID), userID)
+}
+
 // CheckVotePermission verify that the user has vote permission
 func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID string, voteUp bool) (
 	can bool, needRank int, err error) {
@@ -214,32 +342,50 @@ func (rs *RankService) CheckVotePermission(ctx context.Context, userID, objectID
 			action = permission.CommentVoteDown
 		}
 	}
-	powerMapping := rs.getUserPowerMapping(ctx, userID)
+	roleID, powerMapping := rs.getUserPowerMapping(ctx, userID)
 	if powerMapping[action] {
 		return true, 0, nil
 	}
-	can, needRank = rs.checkUserRank(ctx, userInfo.ID, userInfo.Rank, PermissionPrefix+action)
+	can, needRank = rs.checkActionRank(ctx, userInfo.ID, userInfo.Rank, roleID, action)
 	return can, needRank, nil
 }
 
-// getUserPowerMapping get user power mapping
-func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (powerMapping map[string]bool) {
+// getUserPowerMapping get user role and power mapping
+func (rs *RankService) getUserPowerMapping(ctx context.Context, userID string) (userRole int, powerMapping map[string]bool) {
 	powerMapping = make(map[string]bool, 0)
 	userRole, err := rs.roleService.GetUserRole(ctx, userID)
 	if err != nil {
 		log.Error(err)
-		return powerMapping
+		return userRole, powerMapping
 	}
 	powers, err := rs.rolePowerService.GetRolePowerList(ctx, userRole)
 	if err != nil {
 		log.Error(err)
-		return powerMapping
+		return userRole, powerMapping
 	}
 
 	for _, power := range powers {
 		powerMapping[power] = true
 	}
-	return powerMapping
+	return userRole, powerMapping
+}
+
+// checkActionRank verify that the user meets the rule of the action in the permission policy,
+// the reputation in the privileges is used if the rule does not limit it.
+func (rs *RankService) checkActionRank(ctx context.Context, userID string, userRank, roleID int, action string) (
+	can bool, rank int) {
+	rule := rs.policyService.GetActionRule(ctx, action)
+	if rule == nil {
+		return rs.checkUserRank(ctx, userID, userRank, PermissionPrefix+action)
+	}
+	if !permission_policy.MatchRole(rule, roleID) {
+		log.Debugf("user %s want to do action %s, but role %d is not allowed", userID, action, roleID)
+		return false, -1
+	}
+	if rule.MinRank == nil {
+		return rs.checkUserRank(ctx, userID, userRank, PermissionPrefix+action)
+	}
+	return permission_policy.MeetRank(*rule.MinRank, userRank), *rule.MinRank
 }
 
 // checkUserRank verify that the user meets the prestige criteria
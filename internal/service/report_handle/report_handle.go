@@ -75,7 +75,7 @@ func (rh *ReportHandle) updateReportedQuestionReport(ctx context.Context,
 			ID: report.ObjectID, Operation: schema.QuestionOperationHide, UserID: req.UserID})
 	case constant.ReportOperationDeletePost:
 		err = rh.questionService.RemoveQuestion(ctx, &schema.RemoveQuestionReq{
-			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true})
+			ID: report.ObjectID, UserID: req.UserID, IsAdmin: true, Reason: report.Content})
 	case constant.ReportOperationClosePost:
 		err = rh.questionService.CloseQuestion(ctx, &schema.CloseQuestionReq{
 			ID:        report.ObjectID,
@@ -101,7 +101,7 @@ func (rh *ReportHandle) updateReportedAnswerReport(ctx context.Context, report *
 	switch req.OperationType {
 	case constant.ReportOperationDeletePost:
 		err = rh.answerService.RemoveAnswer(ctx, &schema.RemoveAnswerReq{
-			ID: report.ObjectID, UserID: req.UserID})
+			ID: report.ObjectID, UserID: req.UserID, Reason: report.Content})
 	case constant.ReportOperationEditPost:
 		_, err = rh.answerService.Update(ctx, &schema.AnswerUpdateReq{
 			ID:           report.ObjectID,
@@ -119,7 +119,7 @@ func (rh *ReportHandle) updateReportedCommentReport(ctx context.Context, report
 	switch req.OperationType {
 	case constant.ReportOperationDeletePost:
 		err = rh.commentService.RemoveComment(ctx, &schema.RemoveCommentReq{
-			CommentID: report.ObjectID, UserID: req.UserID})
+			CommentID: report.ObjectID, UserID: req.UserID, Reason: report.Content})
 	case constant.ReportOperationEditPost:
 		_, err = rh.commentService.UpdateComment(ctx, &schema.UpdateCommentReq{
 			CommentID:    report.ObjectID,
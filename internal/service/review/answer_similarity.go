@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/minhash"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// answerSimilaritySubmitter the submitter of the review which is added by the similarity check
+const answerSimilaritySubmitter = "answer_similarity"
+
+// checkAnswerSimilarity compare the answer with the other answers of the question, if it is a near-duplicate
+// the review is added and the answer needs to be reviewed by the moderators
+func (cs *ReviewService) checkAnswerSimilarity(ctx context.Context, answer *entity.Answer) (reviewStatus plugin.ReviewStatus) {
+	reviewStatus = plugin.ReviewStatusApproved
+	siteWrite, err := cs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+		return reviewStatus
+	}
+	if !siteWrite.AnswerSimilarityCheck {
+		return reviewStatus
+	}
+	threshold := siteWrite.AnswerSimilarityThreshold
+	if threshold <= 0 {
+		threshold = schema.DefaultAnswerSimilarityThreshold
+	}
+
+	answers, err := cs.answerRepo.GetAnswerList(ctx, &entity.Answer{
+		QuestionID: answer.QuestionID,
+		Status:     entity.AnswerStatusAvailable,
+	})
+	if err != nil {
+		log.Error(err)
+		return reviewStatus
+	}
+	similarID, similarity := findMostSimilarAnswer(answer, answers)
+	if len(similarID) == 0 || similarity*100 < float64(threshold) {
+		return reviewStatus
+	}
+
+	r := &entity.Review{
+		UserID:         answer.UserID,
+		ObjectID:       uid.DeShortID(answer.ID),
+		ObjectType:     constant.ObjectTypeStrMapping[constant.AnswerObjectType],
+		ReviewerUserID: "0",
+		Submitter:      answerSimilaritySubmitter,
+		Reason: fmt.Sprintf("The answer is %d%% similar to the answer %s of the question.",
+			int(similarity*100), uid.EnShortID(similarID)),
+		Status: entity.ReviewStatusPending,
+	}
+	if err = cs.reviewRepo.AddReview(ctx, r); err != nil {
+		log.Errorf("add review failed, err: %v", err)
+		return reviewStatus
+	}
+	return plugin.ReviewStatusNeedReview
+}
+
+// findMostSimilarAnswer find the answer which is the most similar to the answer, the empty id is returned
+// if the answer is too short to compare
+func findMostSimilarAnswer(answer *entity.Answer, answers []*entity.Answer) (similarID string, similarity float64) {
+	signature := minhash.NewSignature(minhash.Shingles(htmltext.ClearText(answer.ParsedText)))
+	if signature == nil {
+		return "", 0
+	}
+	for _, item := range answers {
+		if uid.DeShortID(item.ID) == uid.DeShortID(answer.ID) {
+			continue
+		}
+		other := minhash.NewSignature(minhash.Shingles(htmltext.ClearText(item.ParsedText)))
+		if s := signature.Similarity(other); s > similarity {
+			similarID, similarity = uid.DeShortID(item.ID), s
+		}
+	}
+	return similarID, similarity
+}
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMostSimilarAnswer(t *testing.T) {
+	answer := &entity.Answer{ID: "10020000000000003",
+		ParsedText: "<p>You can use the <code>context</code> package to cancel the goroutine when the request is finished.</p>"}
+	answers := []*entity.Answer{
+		{ID: "10020000000000001", ParsedText: "<p>Try restarting the database server and check the connection settings.</p>"},
+		{ID: "10020000000000002", ParsedText: "<p>You can use the context package to cancel the goroutine when the request is finished!</p>"},
+		{ID: "10020000000000003", ParsedText: answer.ParsedText},
+	}
+	similarID, similarity := findMostSimilarAnswer(answer, answers)
+	assert.Equal(t, "10020000000000002", similarID)
+	assert.Greater(t, similarity, 0.9)
+
+	similarID, _ = findMostSimilarAnswer(&entity.Answer{ID: "10020000000000004", ParsedText: "<p>+1</p>"}, answers)
+	assert.Empty(t, similarID)
+}
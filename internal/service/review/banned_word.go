@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/apache/incubator-answer/plugin"
+	"github.com/segmentfault/pacman/log"
+)
+
+// bannedWordSubmitter the submitter of the review which is added by the banned word rules
+const bannedWordSubmitter = "banned_word"
+
+// checkBannedWords check the new content against the review rules of the banned words, if any rule is matched
+// the review is added and the content needs to be reviewed by the moderators
+func (cs *ReviewService) checkBannedWords(ctx context.Context, userID, objectID, objectType string,
+	texts ...string) (reviewStatus plugin.ReviewStatus) {
+	objectID = uid.DeShortID(objectID)
+	reviewReason := cs.bannedWordService.CheckReview(ctx, objectType, objectID, userID, texts...)
+	if len(reviewReason) == 0 {
+		return plugin.ReviewStatusApproved
+	}
+
+	r := &entity.Review{
+		UserID:         userID,
+		ObjectID:       objectID,
+		ObjectType:     constant.ObjectTypeStrMapping[objectType],
+		ReviewerUserID: "0",
+		Submitter:      bannedWordSubmitter,
+		Reason:         reviewReason,
+		Status:         entity.ReviewStatusPending,
+	}
+	if err := cs.reviewRepo.AddReview(ctx, r); err != nil {
+		log.Errorf("add review failed, err: %v", err)
+		return plugin.ReviewStatusApproved
+	}
+	return plugin.ReviewStatusNeedReview
+}
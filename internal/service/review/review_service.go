@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/banned_word"
 	"github.com/apache/incubator-answer/internal/service/notice_queue"
 	"github.com/apache/incubator-answer/internal/service/object_info"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
@@ -67,6 +68,7 @@ type ReviewService struct {
 	externalNotificationQueueService notice_queue.ExternalNotificationQueueService
 	notificationQueueService         notice_queue.NotificationQueueService
 	siteInfoService                  siteinfo_common.SiteInfoCommonService
+	bannedWordService                *banned_word.BannedWordService
 }
 
 // NewReviewService new review service
@@ -83,6 +85,7 @@ func NewReviewService(
 	questionCommon *questioncommon.QuestionCommon,
 	notificationQueueService notice_queue.NotificationQueueService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
+	bannedWordService *banned_word.BannedWordService,
 ) *ReviewService {
 	return &ReviewService{
 		reviewRepo:                       reviewRepo,
@@ -97,6 +100,7 @@ func NewReviewService(
 		questionCommon:                   questionCommon,
 		notificationQueueService:         notificationQueueService,
 		siteInfoService:                  siteInfoService,
+		bannedWordService:                bannedWordService,
 	}
 }
 
@@ -115,6 +119,10 @@ func (cs *ReviewService) AddQuestionReview(ctx context.Context,
 	}
 	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, question.UserID)
 	reviewStatus := cs.callPluginToReview(ctx, question.UserID, question.ID, reviewContent)
+	if reviewStatus == plugin.ReviewStatusApproved {
+		reviewStatus = cs.checkBannedWords(ctx, question.UserID, question.ID, constant.QuestionObjectType,
+			question.Title, question.OriginalText)
+	}
 	switch reviewStatus {
 	case plugin.ReviewStatusApproved:
 		questionStatus = entity.QuestionStatusAvailable
@@ -139,6 +147,13 @@ func (cs *ReviewService) AddAnswerReview(ctx context.Context,
 	}
 	reviewContent.Author = cs.getReviewContentAuthorInfo(ctx, answer.UserID)
 	reviewStatus := cs.callPluginToReview(ctx, answer.UserID, answer.ID, reviewContent)
+	if reviewStatus == plugin.ReviewStatusApproved {
+		reviewStatus = cs.checkBannedWords(ctx, answer.UserID, answer.ID, constant.AnswerObjectType,
+			answer.OriginalText)
+	}
+	if reviewStatus == plugin.ReviewStatusApproved {
+		reviewStatus = cs.checkAnswerSimilarity(ctx, answer)
+	}
 	switch reviewStatus {
 	case plugin.ReviewStatusApproved:
 		answerStatus = entity.AnswerStatusAvailable
@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review_reminder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	"github.com/apache/incubator-answer/internal/service/content"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/user_notification_config"
+	"github.com/apache/incubator-answer/pkg/display"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/google/uuid"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// reminderWindow only the questions which received the first answer within it are reminded,
+	// so that the old questions will not flood the authors when the reminder is enabled
+	reminderWindow = 7 * 24 * time.Hour
+	// reminderCodeTTL the one-click accept links expire after it
+	reminderCodeTTL     = 30 * 24 * time.Hour
+	reminderBatchSize   = 100
+	reminderAnswerLimit = 5
+)
+
+// ReviewReminderRepo question review reminder repository
+type ReviewReminderRepo interface {
+	GetQuestionsToRemind(ctx context.Context, answeredFrom, answeredTo time.Time, limit int) (
+		questions []*entity.Question, err error)
+	AddReminder(ctx context.Context, reminder *entity.QuestionReviewReminder) (err error)
+	GetReminderByCode(ctx context.Context, code string) (reminder *entity.QuestionReviewReminder, exist bool, err error)
+}
+
+// ReviewReminderService ask the authors whether any answer solved their questions
+type ReviewReminderService struct {
+	reviewReminderRepo         ReviewReminderRepo
+	questionRepo               questioncommon.QuestionRepo
+	answerRepo                 answercommon.AnswerRepo
+	userRepo                   usercommon.UserRepo
+	userCommon                 *usercommon.UserCommon
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo
+	siteInfoService            siteinfo_common.SiteInfoCommonService
+	emailService               *export.EmailService
+	notificationQueueService   notice_queue.NotificationQueueService
+	answerService              *content.AnswerService
+}
+
+// NewReviewReminderService new review reminder service
+func NewReviewReminderService(
+	reviewReminderRepo ReviewReminderRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+	userRepo usercommon.UserRepo,
+	userCommon *usercommon.UserCommon,
+	userNotificationConfigRepo user_notification_config.UserNotificationConfigRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	emailService *export.EmailService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	answerService *content.AnswerService,
+) *ReviewReminderService {
+	return &ReviewReminderService{
+		reviewReminderRepo:         reviewReminderRepo,
+		questionRepo:               questionRepo,
+		answerRepo:                 answerRepo,
+		userRepo:                   userRepo,
+		userCommon:                 userCommon,
+		userNotificationConfigRepo: userNotificationConfigRepo,
+		siteInfoService:            siteInfoService,
+		emailService:               emailService,
+		notificationQueueService:   notificationQueueService,
+		answerService:              answerService,
+	}
+}
+
+// ReviewReminderCron remind the authors of the answered questions without the accepted answer
+func (rs *ReviewReminderService) ReviewReminderCron(ctx context.Context) (err error) {
+	siteWrite, err := rs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	if siteWrite.QuestionReviewReminderDays <= 0 {
+		return nil
+	}
+	answeredFrom, answeredTo := reminderRange(time.Now(), siteWrite.QuestionReviewReminderDays)
+
+	for {
+		questions, err := rs.reviewReminderRepo.GetQuestionsToRemind(ctx, answeredFrom, answeredTo, reminderBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, question := range questions {
+			if err := rs.remind(ctx, question); err != nil {
+				return fmt.Errorf("remind the author of question %s failed: %w", question.ID, err)
+			}
+		}
+		if len(questions) < reminderBatchSize {
+			return nil
+		}
+	}
+}
+
+// AcceptAnswerByReminder accept the answer on behalf of the author through the link in the reminder
+func (rs *ReviewReminderService) AcceptAnswerByReminder(ctx context.Context, req *schema.AcceptAnswerByReminderReq) (
+	resp *schema.AcceptAnswerByReminderResp, err error) {
+	reminder, exist, err := rs.reviewReminderRepo.GetReminderByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || time.Now().After(reminder.ExpiredAt) {
+		return nil, errors.BadRequest(reason.ReviewReminderCodeInvalid)
+	}
+
+	question, exist, err := rs.questionRepo.GetQuestion(ctx, reminder.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || question.Status == entity.QuestionStatusDeleted || question.UserID != reminder.UserID {
+		return nil, errors.BadRequest(reason.QuestionNotFound)
+	}
+	answerID := uid.DeShortID(req.AnswerID)
+	answer, exist, err := rs.answerRepo.GetByID(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || answer.Status != entity.AnswerStatusAvailable || uid.DeShortID(answer.QuestionID) != reminder.QuestionID {
+		return nil, errors.BadRequest(reason.AnswerNotFound)
+	}
+
+	err = rs.answerService.AcceptAnswer(ctx, &schema.AcceptAnswerReq{
+		QuestionID: reminder.QuestionID,
+		AnswerID:   answerID,
+		UserID:     reminder.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.AcceptAnswerByReminderResp{
+		QuestionID: uid.EnShortID(reminder.QuestionID),
+		AnswerID:   uid.EnShortID(answerID),
+	}
+	siteInfo, err := rs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seoInfo, err := rs.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp.QuestionURL = display.QuestionURL(seoInfo.Permalink, siteInfo.SiteUrl, reminder.QuestionID, question.Title)
+	return resp, nil
+}
+
+// remind record the reminder first, so that the question will not be picked up again even if sending failed
+func (rs *ReviewReminderService) remind(ctx context.Context, question *entity.Question) (err error) {
+	reminder := &entity.QuestionReviewReminder{
+		QuestionID: question.ID,
+		UserID:     question.UserID,
+		Code:       strings.ReplaceAll(uuid.NewString(), "-", ""),
+		ExpiredAt:  time.Now().Add(reminderCodeTTL),
+	}
+	if err = rs.reviewReminderRepo.AddReminder(ctx, reminder); err != nil {
+		return err
+	}
+
+	rs.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+		ReceiverUserID:     question.UserID,
+		Type:               schema.NotificationTypeInbox,
+		ObjectID:           question.ID,
+		ObjectType:         constant.QuestionObjectType,
+		NotificationAction: constant.NotificationQuestionReviewReminder,
+	})
+	rs.sendReminderEmail(ctx, question, reminder.Code)
+	return nil
+}
+
+// sendReminderEmail send the email with the one-click accept links of the top answers,
+// the email is sent only when the author subscribes the inbox notifications by email
+func (rs *ReviewReminderService) sendReminderEmail(ctx context.Context, question *entity.Question, code string) {
+	userInfo, exist, err := rs.userRepo.GetByUserID(ctx, question.UserID)
+	if err != nil || !exist {
+		log.Errorf("get user %s failed: %v", question.UserID, err)
+		return
+	}
+	if userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	notificationConfig, exist, err := rs.userNotificationConfigRepo.GetByUserIDAndSource(ctx, userInfo.ID, constant.InboxSource)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if !exist || !emailChannelEnabled(schema.NewNotificationChannelsFormJson(notificationConfig.Channels)) {
+		return
+	}
+
+	answers, _, err := rs.answerRepo.SearchList(ctx, &entity.AnswerSearch{
+		Answer:   entity.Answer{QuestionID: question.ID},
+		Order:    entity.AnswerSearchOrderByVote,
+		PageSize: reminderAnswerLimit,
+	})
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if len(answers) == 0 {
+		return
+	}
+	userIDs := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		userIDs = append(userIDs, answer.UserID)
+	}
+	userInfoMapping, err := rs.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	siteInfo, err := rs.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+
+	reminderAnswers := make([]*schema.QuestionReviewReminderAnswer, 0, len(answers))
+	for _, answer := range answers {
+		item := &schema.QuestionReviewReminderAnswer{
+			Excerpt: htmltext.FetchExcerpt(answer.ParsedText, "...", 240),
+			AcceptUrl: fmt.Sprintf("%s/questions/review-reminder?code=%s&answer_id=%s",
+				siteInfo.SiteUrl, code, answer.ID),
+		}
+		if answerUser, ok := userInfoMapping[answer.UserID]; ok {
+			item.DisplayName = answerUser.DisplayName
+		}
+		reminderAnswers = append(reminderAnswers, item)
+	}
+
+	// If receiver has set language or time zone, use it to send email.
+	ctx = usercommon.WithUserLocale(ctx, userInfo)
+	title, body, err := rs.emailService.QuestionReviewReminderTemplate(ctx, question.ID, question.Title, reminderAnswers)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	rs.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// reminderRange the range of the first answer time of the questions to remind
+func reminderRange(now time.Time, days int) (answeredFrom, answeredTo time.Time) {
+	answeredTo = now.AddDate(0, 0, -days)
+	return answeredTo.Add(-reminderWindow), answeredTo
+}
+
+func emailChannelEnabled(channels schema.NotificationChannels) bool {
+	for _, channel := range channels {
+		if channel.Key == constant.EmailChannel && channel.Enable {
+			return true
+		}
+	}
+	return false
+}
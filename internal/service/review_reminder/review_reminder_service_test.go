@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package review_reminder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderRange(t *testing.T) {
+	now := time.Date(2024, 5, 20, 10, 0, 0, 0, time.UTC)
+	answeredFrom, answeredTo := reminderRange(now, 3)
+	assert.Equal(t, time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC), answeredTo)
+	assert.Equal(t, time.Date(2024, 5, 10, 10, 0, 0, 0, time.UTC), answeredFrom)
+}
+
+func TestEmailChannelEnabled(t *testing.T) {
+	assert.True(t, emailChannelEnabled(schema.NotificationChannels{
+		{Key: constant.EmailChannel, Enable: true},
+	}))
+	assert.False(t, emailChannelEnabled(schema.NotificationChannels{
+		{Key: constant.EmailChannel, Enable: false},
+	}))
+	assert.False(t, emailChannelEnabled(nil))
+}
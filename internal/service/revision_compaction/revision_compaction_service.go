@@ -0,0 +1,299 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package revision_compaction
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/delta"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// compactionBatchSize the number of the objects whose revisions are compacted in one batch
+	compactionBatchSize = 100
+	// compactionReportRuns the number of the recent runs in the report
+	compactionReportRuns = 30
+)
+
+// RevisionCompactionRepo revision compaction repository
+type RevisionCompactionRepo interface {
+	GetRevisedObjectIDs(ctx context.Context, afterObjectID string, limit int) (objectIDs []string, err error)
+	GetObjectRevisions(ctx context.Context, objectID string) (
+		revisions []*entity.Revision, currentRevisionID string, err error)
+	GetReferencedRevisionIDs(ctx context.Context, revisionIDs []string) (referencedIDs []string, err error)
+	CompactRevisions(ctx context.Context, removedIDs []string, updated []*entity.Revision) (err error)
+	AddCompactionLog(ctx context.Context, compactionLog *entity.RevisionCompactionLog) (err error)
+	GetCompactionLogs(ctx context.Context, limit int) (logs []*entity.RevisionCompactionLog, err error)
+	SumCompactionLogs(ctx context.Context) (total *entity.RevisionCompactionLog, err error)
+}
+
+// RevisionCompactionService prune the old revisions by the retention policy and store the kept ones as the delta
+type RevisionCompactionService struct {
+	revisionCompactionRepo RevisionCompactionRepo
+	siteInfoService        siteinfo_common.SiteInfoCommonService
+}
+
+// NewRevisionCompactionService new revision compaction service
+func NewRevisionCompactionService(
+	revisionCompactionRepo RevisionCompactionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *RevisionCompactionService {
+	return &RevisionCompactionService{
+		revisionCompactionRepo: revisionCompactionRepo,
+		siteInfoService:        siteInfoService,
+	}
+}
+
+// compactionPolicy the revision retention policy and the compaction switch of the site
+type compactionPolicy struct {
+	keepCount  int
+	keepMonths int
+	compaction bool
+	now        time.Time
+}
+
+// CompactionCron apply the revision retention policy and the compaction to all the revised objects
+func (rs *RevisionCompactionService) CompactionCron(ctx context.Context) (err error) {
+	siteWrite, err := rs.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return err
+	}
+	policy := &compactionPolicy{
+		keepCount:  siteWrite.RevisionKeepCount,
+		keepMonths: siteWrite.RevisionKeepMonths,
+		compaction: siteWrite.RevisionCompaction,
+		now:        time.Now(),
+	}
+	// the compacted revisions are still readable, so nothing is changed without any policy
+	if policy.keepCount <= 0 && policy.keepMonths <= 0 && !policy.compaction {
+		return nil
+	}
+
+	result := &entity.RevisionCompactionLog{}
+	afterObjectID := "0"
+	for {
+		var objectIDs []string
+		objectIDs, err = rs.revisionCompactionRepo.GetRevisedObjectIDs(ctx, afterObjectID, compactionBatchSize)
+		if err != nil {
+			break
+		}
+		for _, objectID := range objectIDs {
+			if err := rs.compactObject(ctx, objectID, policy, result); err != nil {
+				log.Errorf("compact the revisions of %s failed: %v", objectID, err)
+			}
+		}
+		if len(objectIDs) < compactionBatchSize {
+			break
+		}
+		afterObjectID = objectIDs[len(objectIDs)-1]
+	}
+	// the revisions compacted before the failure are still logged
+	if result.PrunedCount == 0 && result.CompactedCount == 0 && result.ReclaimedBytes == 0 {
+		return err
+	}
+	if e := rs.revisionCompactionRepo.AddCompactionLog(ctx, result); e != nil {
+		log.Error(e)
+	}
+	log.Infof("revision compaction pruned %d and compacted %d revisions, reclaimed %d bytes",
+		result.PrunedCount, result.CompactedCount, result.ReclaimedBytes)
+	return err
+}
+
+// GetCompactionReport get the space reclaimed by the compaction
+func (rs *RevisionCompactionService) GetCompactionReport(ctx context.Context) (
+	resp *schema.RevisionCompactionReportResp, err error) {
+	total, err := rs.revisionCompactionRepo.SumCompactionLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := rs.revisionCompactionRepo.GetCompactionLogs(ctx, compactionReportRuns)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.RevisionCompactionReportResp{
+		PrunedCount:    total.PrunedCount,
+		CompactedCount: total.CompactedCount,
+		ReclaimedBytes: total.ReclaimedBytes,
+		RecentRuns:     make([]*schema.RevisionCompactionRunResp, 0, len(logs)),
+	}
+	for _, l := range logs {
+		resp.RecentRuns = append(resp.RecentRuns, &schema.RevisionCompactionRunResp{
+			CreatedAt:      l.CreatedAt.Unix(),
+			PrunedCount:    l.PrunedCount,
+			CompactedCount: l.CompactedCount,
+			ReclaimedBytes: l.ReclaimedBytes,
+		})
+	}
+	return resp, nil
+}
+
+// compactObject prune and re-encode the revisions of the object, the result is added up
+func (rs *RevisionCompactionService) compactObject(ctx context.Context, objectID string,
+	policy *compactionPolicy, result *entity.RevisionCompactionLog) (err error) {
+	revisions, currentRevisionID, err := rs.revisionCompactionRepo.GetObjectRevisions(ctx, objectID)
+	if err != nil {
+		return err
+	}
+	stored := make(map[string]entity.Revision, len(revisions))
+	for _, revision := range revisions {
+		stored[revision.ID] = *revision
+	}
+	if err = decodeRevisions(revisions); err != nil {
+		return err
+	}
+	revisionIDs := make([]string, 0, len(revisions))
+	for _, revision := range revisions {
+		revisionIDs = append(revisionIDs, revision.ID)
+	}
+	referencedIDs, err := rs.revisionCompactionRepo.GetReferencedRevisionIDs(ctx, revisionIDs)
+	if err != nil {
+		return err
+	}
+	referenced := make(map[string]bool, len(referencedIDs))
+	for _, id := range referencedIDs {
+		referenced[id] = true
+	}
+	kept, removedIDs, ok := pruneRevisions(revisions, currentRevisionID, referenced, policy)
+	if !ok {
+		return nil
+	}
+	encodeRevisions(kept, currentRevisionID, policy.compaction)
+
+	var (
+		updated        []*entity.Revision
+		compacted      int
+		reclaimedBytes int64
+	)
+	for _, revision := range kept {
+		old := stored[revision.ID]
+		if old.Content == revision.Content && old.ContentFormat == revision.ContentFormat &&
+			old.BaseRevisionID == revision.BaseRevisionID {
+			continue
+		}
+		updated = append(updated, revision)
+		reclaimedBytes += int64(len(old.Content) - len(revision.Content))
+		if revision.ContentFormat == entity.RevisionContentDelta {
+			compacted++
+		}
+	}
+	for _, id := range removedIDs {
+		reclaimedBytes += int64(len(stored[id].Content))
+	}
+	if len(updated) == 0 && len(removedIDs) == 0 {
+		return nil
+	}
+	if err = rs.revisionCompactionRepo.CompactRevisions(ctx, removedIDs, updated); err != nil {
+		return err
+	}
+	result.PrunedCount += len(removedIDs)
+	result.CompactedCount += compacted
+	result.ReclaimedBytes += reclaimedBytes
+	return nil
+}
+
+// decodeRevisions restore the content of the compacted revisions of the object in place,
+// the base revisions are all in the same object
+func decodeRevisions(revisions []*entity.Revision) (err error) {
+	loaded := make(map[string]*entity.Revision, len(revisions))
+	for _, revision := range revisions {
+		loaded[revision.ID] = revision
+	}
+	for _, revision := range revisions {
+		chain := make([]*entity.Revision, 0)
+		current := revision
+		for current.ContentFormat == entity.RevisionContentDelta {
+			base, ok := loaded[current.BaseRevisionID]
+			if !ok || len(chain) >= len(revisions) {
+				return fmt.Errorf("the base revision of %s is broken", current.ID)
+			}
+			chain = append(chain, current)
+			current = base
+		}
+		content := current.Content
+		for i := len(chain) - 1; i >= 0; i-- {
+			if content, err = delta.Decode(content, chain[i].Content); err != nil {
+				return fmt.Errorf("decode revision %s failed: %w", chain[i].ID, err)
+			}
+			chain[i].Content = content
+			chain[i].ContentFormat = entity.RevisionContentFull
+		}
+	}
+	return nil
+}
+
+// pruneRevisions split the revisions, the newest first, into the kept ones and the pruned ones.
+// The current, the unreviewed and the referenced revisions are always kept, the other revision is kept if it is one of
+// the last keepCount ones or created in the last keepMonths months. It is not ok if the current revision is unknown.
+func pruneRevisions(revisions []*entity.Revision, currentRevisionID string, referenced map[string]bool,
+	policy *compactionPolicy) (
+	kept []*entity.Revision, removedIDs []string, ok bool) {
+	for _, revision := range revisions {
+		if revision.ID == currentRevisionID {
+			ok = true
+		}
+	}
+	if !ok {
+		return nil, nil, false
+	}
+	limited := policy.keepCount > 0 || policy.keepMonths > 0
+	keepAfter := policy.now.AddDate(0, -policy.keepMonths, 0)
+	rank := 0
+	for _, revision := range revisions {
+		if revision.ID == currentRevisionID || revision.Status == entity.RevisionUnreviewedStatus ||
+			referenced[revision.ID] {
+			kept = append(kept, revision)
+			continue
+		}
+		rank++
+		if !limited || (policy.keepCount > 0 && rank <= policy.keepCount) ||
+			(policy.keepMonths > 0 && revision.CreatedAt.After(keepAfter)) {
+			kept = append(kept, revision)
+			continue
+		}
+		removedIDs = append(removedIDs, revision.ID)
+	}
+	return kept, removedIDs, true
+}
+
+// encodeRevisions set the content of the kept revisions, the newest first, to the form to be stored.
+// The revisions older than the current revision are stored as the delta of the next newer one if it is shorter,
+// so that the chain always ends at the current revision which is stored in full.
+func encodeRevisions(kept []*entity.Revision, currentRevisionID string, compaction bool) {
+	baseID, baseContent := "", ""
+	for _, revision := range kept {
+		content := revision.Content
+		revision.ContentFormat, revision.BaseRevisionID = entity.RevisionContentFull, "0"
+		if len(baseID) > 0 && compaction && revision.Status != entity.RevisionUnreviewedStatus {
+			if encoded := delta.Encode(baseContent, content); len(encoded) < len(content) {
+				revision.Content = encoded
+				revision.ContentFormat, revision.BaseRevisionID = entity.RevisionContentDelta, baseID
+			}
+		}
+		if revision.ID == currentRevisionID || len(baseID) > 0 {
+			baseID, baseContent = revision.ID, content
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package revision_compaction
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRevisions the revisions of one object, the newest first, created one month apart
+func newRevisions(now time.Time, count int) []*entity.Revision {
+	revisions := make([]*entity.Revision, 0, count)
+	for i := 0; i < count; i++ {
+		revisions = append(revisions, &entity.Revision{
+			ID:             fmt.Sprintf("%d", count-i),
+			CreatedAt:      now.AddDate(0, -i, 0),
+			Content:        strings.Repeat("the content of the post. ", 20) + fmt.Sprintf("edit %d", count-i),
+			BaseRevisionID: "0",
+		})
+	}
+	return revisions
+}
+
+func TestPruneRevisions(t *testing.T) {
+	now := time.Now()
+	revisions := newRevisions(now, 6)
+	revisions[3].Status = entity.RevisionUnreviewedStatus
+
+	_, removed, _ := pruneRevisions(revisions, "6", nil, &compactionPolicy{now: now})
+	assert.Empty(t, removed)
+
+	_, removed, _ = pruneRevisions(revisions, "6", nil, &compactionPolicy{keepCount: 2, now: now})
+	assert.Equal(t, []string{"2", "1"}, removed)
+
+	_, removed, _ = pruneRevisions(revisions, "6", nil, &compactionPolicy{keepMonths: 3, now: now})
+	assert.Equal(t, []string{"2", "1"}, removed)
+
+	// kept if it is one of the last N or created in the last M months
+	_, removed, _ = pruneRevisions(revisions, "6", nil, &compactionPolicy{keepCount: 1, keepMonths: 3, now: now})
+	assert.Equal(t, []string{"2", "1"}, removed)
+	_, removed, _ = pruneRevisions(revisions, "6", nil, &compactionPolicy{keepCount: 3, keepMonths: 1, now: now})
+	assert.Equal(t, []string{"1"}, removed)
+
+	// the revisions referenced by the activities are kept and not counted
+	_, removed, _ = pruneRevisions(revisions, "6", map[string]bool{"4": true, "1": true},
+		&compactionPolicy{keepCount: 1, now: now})
+	assert.Equal(t, []string{"2"}, removed)
+
+	_, _, ok := pruneRevisions(revisions, "0", nil, &compactionPolicy{keepCount: 1, now: now})
+	assert.False(t, ok)
+}
+
+func TestEncodeDecodeRevisions(t *testing.T) {
+	now := time.Now()
+	revisions := newRevisions(now, 5)
+	// the newest revision is not approved yet, the current one is the second
+	revisions[0].Status = entity.RevisionUnreviewedStatus
+	original := make(map[string]string)
+	for _, revision := range revisions {
+		original[revision.ID] = revision.Content
+	}
+
+	encodeRevisions(revisions, "4", true)
+	assert.Equal(t, entity.RevisionContentFull, revisions[0].ContentFormat)
+	assert.Equal(t, entity.RevisionContentFull, revisions[1].ContentFormat)
+	for _, revision := range revisions[2:] {
+		assert.Equal(t, entity.RevisionContentDelta, revision.ContentFormat)
+		assert.Less(t, len(revision.Content), len(original[revision.ID]))
+	}
+	assert.Equal(t, "4", revisions[2].BaseRevisionID)
+	assert.Equal(t, "3", revisions[3].BaseRevisionID)
+
+	assert.NoError(t, decodeRevisions(revisions))
+	for _, revision := range revisions {
+		assert.Equal(t, original[revision.ID], revision.Content)
+	}
+
+	// the deltas are expanded if the compaction is turned off
+	encodeRevisions(revisions, "4", true)
+	assert.NoError(t, decodeRevisions(revisions))
+	encodeRevisions(revisions, "4", false)
+	for _, revision := range revisions {
+		assert.Equal(t, entity.RevisionContentFull, revision.ContentFormat)
+		assert.Equal(t, original[revision.ID], revision.Content)
+	}
+}
+
+func TestDecodeRevisionsBrokenBase(t *testing.T) {
+	revisions := newRevisions(time.Now(), 2)
+	revisions[1].ContentFormat, revisions[1].BaseRevisionID = entity.RevisionContentDelta, "100"
+	assert.Error(t, decodeRevisions(revisions))
+}
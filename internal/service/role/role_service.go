@@ -21,18 +21,20 @@ package role
 
 import (
 	"context"
+	"strings"
 
 	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/base/translator"
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/jinzhu/copier"
+	"github.com/segmentfault/pacman/errors"
 )
 
 const (
-	// Since there is currently no need to edit roles to add roles and other operations,
-	// the current role information is translated directly.
-	// Later on, when the relevant ability is available, it can be adjusted by the user himself.
+	// The built-in roles can not be edited, so their information is translated directly.
+	// The roles added by the admin are shown as they are.
 
 	RoleUserID      = 1
 	RoleAdminID     = 2
@@ -55,16 +57,31 @@ const (
 type RoleRepo interface {
 	GetRoleAllList(ctx context.Context) (roles []*entity.Role, err error)
 	GetRoleAllMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error)
+	GetRole(ctx context.Context, roleID int) (role *entity.Role, exist bool, err error)
+	AddRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	UpdateRole(ctx context.Context, role *entity.Role, powerTypes []string) (err error)
+	RemoveRole(ctx context.Context, roleID int) (err error)
 }
 
 // RoleService user service
 type RoleService struct {
-	roleRepo RoleRepo
+	roleRepo         RoleRepo
+	powerRepo        PowerRepo
+	rolePowerRelRepo RolePowerRelRepo
+	userRoleRelRepo  UserRoleRelRepo
 }
 
-func NewRoleService(roleRepo RoleRepo) *RoleService {
+func NewRoleService(
+	roleRepo RoleRepo,
+	powerRepo PowerRepo,
+	rolePowerRelRepo RolePowerRelRepo,
+	userRoleRelRepo UserRoleRelRepo,
+) *RoleService {
 	return &RoleService{
-		roleRepo: roleRepo,
+		roleRepo:         roleRepo,
+		powerRepo:        powerRepo,
+		rolePowerRelRepo: rolePowerRelRepo,
+		userRoleRelRepo:  userRoleRelRepo,
 	}
 }
 
@@ -81,9 +98,150 @@ func (rs *RoleService) GetRoleList(ctx context.Context) (resp []*schema.GetRoleR
 
 	resp = []*schema.GetRoleResp{}
 	_ = copier.Copy(&resp, roles)
+	for _, item := range resp {
+		item.BuiltIn = IsBuiltInRole(item.ID)
+	}
 	return
 }
 
+// GetRole get role detail with its power types
+func (rs *RoleService) GetRole(ctx context.Context, req *schema.GetRoleReq) (resp *schema.GetRoleDetailResp, err error) {
+	role, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.RoleNotFound)
+	}
+	rs.translateRole(ctx, role)
+
+	powerTypes, err := rs.rolePowerRelRepo.GetRolePowerTypeList(ctx, role.ID)
+	if err != nil {
+		return nil, err
+	}
+	resp = &schema.GetRoleDetailResp{PowerTypes: powerTypes}
+	_ = copier.Copy(&resp.GetRoleResp, role)
+	resp.BuiltIn = IsBuiltInRole(role.ID)
+	return resp, nil
+}
+
+// GetPowerList get all powers that can be granted to the role
+func (rs *RoleService) GetPowerList(ctx context.Context) (resp []*schema.GetPowerResp, err error) {
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.GetPowerResp, 0, len(powers))
+	for _, power := range powers {
+		resp = append(resp, &schema.GetPowerResp{
+			Name:        power.Name,
+			PowerType:   power.PowerType,
+			Description: power.Description,
+		})
+	}
+	return resp, nil
+}
+
+// AddRole add custom role
+func (rs *RoleService) AddRole(ctx context.Context, req *schema.AddRoleReq) (err error) {
+	powerTypes, err := rs.checkRole(ctx, 0, req.Name, req.PowerTypes)
+	if err != nil {
+		return err
+	}
+	role := &entity.Role{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	return rs.roleRepo.AddRole(ctx, role, powerTypes)
+}
+
+// UpdateRole update custom role and replace its powers
+func (rs *RoleService) UpdateRole(ctx context.Context, req *schema.UpdateRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotUpdate)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	powerTypes, err := rs.checkRole(ctx, req.ID, req.Name, req.PowerTypes)
+	if err != nil {
+		return err
+	}
+	role := &entity.Role{
+		ID:          req.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	return rs.roleRepo.UpdateRole(ctx, role, powerTypes)
+}
+
+// RemoveRole remove custom role, the role can not be removed if it is still assigned to any user
+func (rs *RoleService) RemoveRole(ctx context.Context, req *schema.RemoveRoleReq) (err error) {
+	if IsBuiltInRole(req.ID) {
+		return errors.BadRequest(reason.RoleCannotUpdate)
+	}
+	_, exist, err := rs.roleRepo.GetRole(ctx, req.ID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
+	rels, err := rs.userRoleRelRepo.GetUserRoleRelListByRoleID(ctx, []int{req.ID})
+	if err != nil {
+		return err
+	}
+	if len(rels) > 0 {
+		return errors.BadRequest(reason.RoleIsUsedCannotDelete)
+	}
+	return rs.roleRepo.RemoveRole(ctx, req.ID)
+}
+
+// checkRole check the role name is unique and all power types exist, returns the deduplicated power types
+func (rs *RoleService) checkRole(ctx context.Context, roleID int, name string, powerTypes []string) (
+	validPowerTypes []string, err error) {
+	roles, err := rs.roleRepo.GetRoleAllList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if role.ID != roleID && strings.EqualFold(role.Name, strings.TrimSpace(name)) {
+			return nil, errors.BadRequest(reason.RoleNameAlreadyExist)
+		}
+	}
+
+	powers, err := rs.powerRepo.GetPowerList(ctx, &entity.Power{})
+	if err != nil {
+		return nil, err
+	}
+	powerMapping := make(map[string]bool, len(powers))
+	for _, power := range powers {
+		powerMapping[power.PowerType] = true
+	}
+	validPowerTypes = make([]string, 0, len(powerTypes))
+	added := make(map[string]bool, len(powerTypes))
+	for _, powerType := range powerTypes {
+		if !powerMapping[powerType] {
+			return nil, errors.BadRequest(reason.RolePowerInvalid)
+		}
+		if added[powerType] {
+			continue
+		}
+		added[powerType] = true
+		validPowerTypes = append(validPowerTypes, powerType)
+	}
+	return validPowerTypes, nil
+}
+
+// IsBuiltInRole whether the role is one of the built-in roles
+func IsBuiltInRole(roleID int) bool {
+	return roleID == RoleUserID || roleID == RoleAdminID || roleID == RoleModeratorID
+}
+
 func (rs *RoleService) GetRoleMapping(ctx context.Context) (roleMapping map[int]*entity.Role, err error) {
 	return rs.roleRepo.GetRoleAllMapping(ctx)
 }
@@ -22,7 +22,9 @@ package role
 import (
 	"context"
 
+	"github.com/apache/incubator-answer/internal/base/reason"
 	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/segmentfault/pacman/errors"
 )
 
 // UserRoleRelRepo userRoleRel repository
@@ -50,6 +52,13 @@ func NewUserRoleRelService(userRoleRelRepo UserRoleRelRepo, roleService *RoleSer
 
 // SaveUserRole save user role
 func (us *UserRoleRelService) SaveUserRole(ctx context.Context, userID string, roleID int) (err error) {
+	roleMapping, err := us.roleService.GetRoleMapping(ctx)
+	if err != nil {
+		return err
+	}
+	if roleMapping[roleID] == nil {
+		return errors.BadRequest(reason.RoleNotFound)
+	}
 	return us.userRoleRelRepo.SaveUserRoleRel(ctx, userID, roleID)
 }
 
@@ -0,0 +1,338 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/robfig/cron/v3"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// SchedulerRepo scheduled job repository
+type SchedulerRepo interface {
+	GetScheduledJob(ctx context.Context, name string) (job *entity.ScheduledJob, exist bool, err error)
+	GetScheduledJobList(ctx context.Context) (jobs []*entity.ScheduledJob, err error)
+	UpdateScheduledJobStatus(ctx context.Context, name string, disabled bool) (err error)
+	UpdateScheduledJobLastRun(ctx context.Context, job *entity.ScheduledJob) (err error)
+	UpdateScheduledJobNextRun(ctx context.Context, name string, nextRunAt time.Time) (err error)
+}
+
+// registeredJob the built-in job in the registry
+type registeredJob struct {
+	name            string
+	defaultSchedule string
+	schedule        string
+	cronSchedule    cron.Schedule
+	handler         func(ctx context.Context) error
+	entryID         cron.EntryID
+	running         int32
+}
+
+// SchedulerService the central scheduler of all the built-in jobs
+type SchedulerService struct {
+	// manualRuns the number of the jobs run by the admin and still running, it is the first field to be 64-bit aligned
+	manualRuns      int64
+	schedulerRepo   SchedulerRepo
+	siteInfoRepo    siteinfo_common.SiteInfoRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	cron            *cron.Cron
+	jobs            []*registeredJob
+	lock            sync.RWMutex
+}
+
+// NewSchedulerService new scheduler service
+func NewSchedulerService(
+	schedulerRepo SchedulerRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *SchedulerService {
+	return &SchedulerService{
+		schedulerRepo:   schedulerRepo,
+		siteInfoRepo:    siteInfoRepo,
+		siteInfoService: siteInfoService,
+		cron:            cron.New(),
+	}
+}
+
+// Register add the job to the registry, it should be called before the scheduler starts
+func (ss *SchedulerService) Register(name, schedule string, handler func(ctx context.Context) error) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	ss.jobs = append(ss.jobs, &registeredJob{
+		name:            name,
+		defaultSchedule: schedule,
+		schedule:        schedule,
+		handler:         handler,
+	})
+}
+
+// Cron the cron of the scheduler, the plugin jobs are added to it as well
+func (ss *SchedulerService) Cron() *cron.Cron {
+	return ss.cron
+}
+
+// Start schedule all the registered jobs with the overridden schedules and start the scheduler
+func (ss *SchedulerService) Start(ctx context.Context) {
+	overrides := ss.getScheduleOverrides(ctx)
+	ss.lock.Lock()
+	for _, job := range ss.jobs {
+		schedule := resolveSchedule(job.defaultSchedule, overrides[job.name])
+		if err := ss.scheduleJob(job, schedule); err != nil {
+			log.Errorf("add scheduled job %s failed: %v", job.name, err)
+			continue
+		}
+		if err := ss.schedulerRepo.UpdateScheduledJobNextRun(ctx, job.name, job.cronSchedule.Next(time.Now())); err != nil {
+			log.Error(err)
+		}
+	}
+	ss.lock.Unlock()
+	ss.cron.Start()
+}
+
+// Stop stop triggering the jobs and wait for the running jobs to finish
+func (ss *SchedulerService) Stop(ctx context.Context) (err error) {
+	stopCtx := ss.cron.Stop()
+	select {
+	case <-stopCtx.Done():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return lifecycle.WaitIdle(ctx, func() bool { return atomic.LoadInt64(&ss.manualRuns) == 0 })
+}
+
+// GetScheduledJobList get the built-in jobs with the last run status
+func (ss *SchedulerService) GetScheduledJobList(ctx context.Context) (resp []*schema.GetScheduledJobResp, err error) {
+	records, err := ss.schedulerRepo.GetScheduledJobList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordMapping := make(map[string]*entity.ScheduledJob, len(records))
+	for _, record := range records {
+		recordMapping[record.Name] = record
+	}
+
+	ss.lock.RLock()
+	defer ss.lock.RUnlock()
+	now := time.Now()
+	resp = make([]*schema.GetScheduledJobResp, 0, len(ss.jobs))
+	for _, job := range ss.jobs {
+		item := &schema.GetScheduledJobResp{
+			Name:            job.name,
+			Schedule:        job.schedule,
+			DefaultSchedule: job.defaultSchedule,
+			Enabled:         true,
+			Running:         atomic.LoadInt32(&job.running) == 1,
+		}
+		if record, ok := recordMapping[job.name]; ok {
+			item.Enabled = !record.Disabled
+			if !record.LastRunAt.IsZero() {
+				item.LastRunAt = record.LastRunAt.Unix()
+			}
+			item.LastDuration = record.LastDuration
+			item.LastStatus = record.LastStatus
+			item.LastError = record.LastError
+		}
+		if item.Enabled && job.cronSchedule != nil {
+			item.NextRunAt = job.cronSchedule.Next(now).Unix()
+		}
+		resp = append(resp, item)
+	}
+	return resp, nil
+}
+
+// UpdateScheduledJobStatus enable or disable the built-in job
+func (ss *SchedulerService) UpdateScheduledJobStatus(ctx context.Context, req *schema.UpdateScheduledJobStatusReq) (err error) {
+	if ss.getJob(req.Name) == nil {
+		return errors.BadRequest(reason.ScheduledJobNotFound)
+	}
+	return ss.schedulerRepo.UpdateScheduledJobStatus(ctx, req.Name, !req.Enabled)
+}
+
+// UpdateScheduledJobSchedule override the schedule of the built-in job in the site config and reschedule it
+func (ss *SchedulerService) UpdateScheduledJobSchedule(ctx context.Context, req *schema.UpdateScheduledJobScheduleReq) (err error) {
+	job := ss.getJob(req.Name)
+	if job == nil {
+		return errors.BadRequest(reason.ScheduledJobNotFound)
+	}
+	if len(req.Schedule) > 0 {
+		if _, err = cron.ParseStandard(req.Schedule); err != nil {
+			return errors.BadRequest(reason.ScheduledJobScheduleInvalid)
+		}
+	}
+
+	overrides := ss.getScheduleOverrides(ctx)
+	if len(req.Schedule) == 0 || req.Schedule == job.defaultSchedule {
+		delete(overrides, req.Name)
+	} else {
+		overrides[req.Name] = req.Schedule
+	}
+	content, _ := json.Marshal(&schema.SiteSchedulerReq{JobSchedules: overrides})
+	err = ss.siteInfoRepo.SaveByType(ctx, constant.SiteTypeScheduler, &entity.SiteInfo{
+		Type:    constant.SiteTypeScheduler,
+		Content: string(content),
+		Status:  1,
+	})
+	if err != nil {
+		return err
+	}
+
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if err = ss.scheduleJob(job, resolveSchedule(job.defaultSchedule, req.Schedule)); err != nil {
+		return errors.BadRequest(reason.ScheduledJobScheduleInvalid)
+	}
+	return ss.schedulerRepo.UpdateScheduledJobNextRun(ctx, job.name, job.cronSchedule.Next(time.Now()))
+}
+
+// RunScheduledJob run the built-in job now in the background, even if it is disabled
+func (ss *SchedulerService) RunScheduledJob(ctx context.Context, req *schema.RunScheduledJobReq) (err error) {
+	job := ss.getJob(req.Name)
+	if job == nil {
+		return errors.BadRequest(reason.ScheduledJobNotFound)
+	}
+	if !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+		return errors.BadRequest(reason.ScheduledJobRunning)
+	}
+	atomic.AddInt64(&ss.manualRuns, 1)
+	go func() {
+		defer atomic.AddInt64(&ss.manualRuns, -1)
+		defer atomic.StoreInt32(&job.running, 0)
+		ss.executeJob(context.Background(), job)
+	}()
+	return nil
+}
+
+// scheduleJob add the job to the cron with the schedule, the old entry of the job is removed
+func (ss *SchedulerService) scheduleJob(job *registeredJob, schedule string) (err error) {
+	cronSchedule, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return err
+	}
+	if job.entryID > 0 {
+		ss.cron.Remove(job.entryID)
+	}
+	job.entryID = ss.cron.Schedule(cronSchedule, cron.FuncJob(func() {
+		ss.triggerJob(context.Background(), job)
+	}))
+	job.schedule = schedule
+	job.cronSchedule = cronSchedule
+	return nil
+}
+
+// triggerJob run the job triggered by the cron, it is skipped if it is disabled or still running
+func (ss *SchedulerService) triggerJob(ctx context.Context, job *registeredJob) {
+	record, exist, err := ss.schedulerRepo.GetScheduledJob(ctx, job.name)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if exist && record.Disabled {
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&job.running, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&job.running, 0)
+	ss.executeJob(ctx, job)
+}
+
+// executeJob run the job and record the result
+func (ss *SchedulerService) executeJob(ctx context.Context, job *registeredJob) {
+	log.Debugf("scheduled job %s execution", job.name)
+	startTime := time.Now()
+	err := runJobHandler(ctx, job.handler)
+	result := &entity.ScheduledJob{
+		Name:         job.name,
+		LastRunAt:    startTime,
+		LastDuration: time.Since(startTime).Milliseconds(),
+		LastStatus:   entity.ScheduledJobRunStatusSuccess,
+	}
+	ss.lock.RLock()
+	if job.cronSchedule != nil {
+		result.NextRunAt = job.cronSchedule.Next(time.Now())
+	}
+	ss.lock.RUnlock()
+	if err != nil {
+		log.Errorf("scheduled job %s failed: %v", job.name, err)
+		result.LastStatus = entity.ScheduledJobRunStatusFailed
+		result.LastError = err.Error()
+	}
+	if err := ss.schedulerRepo.UpdateScheduledJobLastRun(ctx, result); err != nil {
+		log.Error(err)
+	}
+}
+
+func (ss *SchedulerService) getJob(name string) *registeredJob {
+	ss.lock.RLock()
+	defer ss.lock.RUnlock()
+	for _, job := range ss.jobs {
+		if job.name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// getScheduleOverrides get the overridden schedules from the site config
+func (ss *SchedulerService) getScheduleOverrides(ctx context.Context) map[string]string {
+	siteScheduler := &schema.SiteSchedulerReq{}
+	if err := ss.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeScheduler, siteScheduler); err != nil {
+		log.Error(err)
+	}
+	if siteScheduler.JobSchedules == nil {
+		siteScheduler.JobSchedules = make(map[string]string)
+	}
+	return siteScheduler.JobSchedules
+}
+
+// resolveSchedule the overridden schedule is used if it is a valid cron expression
+func resolveSchedule(defaultSchedule, override string) string {
+	if len(override) == 0 {
+		return defaultSchedule
+	}
+	if _, err := cron.ParseStandard(override); err != nil {
+		log.Warnf("the schedule %s is invalid, use the default schedule %s", override, defaultSchedule)
+		return defaultSchedule
+	}
+	return override
+}
+
+// runJobHandler run the job handler and recover from its panic
+func runJobHandler(ctx context.Context, handler func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx)
+}
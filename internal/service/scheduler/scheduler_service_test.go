@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSchedulerRepo struct {
+	SchedulerRepo
+	lastRun chan *entity.ScheduledJob
+}
+
+func (r *testSchedulerRepo) UpdateScheduledJobLastRun(_ context.Context, job *entity.ScheduledJob) error {
+	r.lastRun <- job
+	return nil
+}
+
+func TestResolveSchedule(t *testing.T) {
+	assert.Equal(t, "0 */1 * * *", resolveSchedule("0 */1 * * *", ""))
+	assert.Equal(t, "*/5 * * * *", resolveSchedule("0 */1 * * *", "*/5 * * * *"))
+	assert.Equal(t, "0 */1 * * *", resolveSchedule("0 */1 * * *", "every minute"))
+}
+
+func TestRunScheduledJob(t *testing.T) {
+	repo := &testSchedulerRepo{lastRun: make(chan *entity.ScheduledJob, 1)}
+	ss := NewSchedulerService(repo, nil, nil)
+	ss.Register("panic", "0 */1 * * *", func(ctx context.Context) error {
+		panic("boom")
+	})
+	ss.Register("failed", "0 */1 * * *", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	ss.Register("success", "0 */1 * * *", func(ctx context.Context) error {
+		return nil
+	})
+
+	err := ss.RunScheduledJob(context.TODO(), &schema.RunScheduledJobReq{Name: "unknown"})
+	assert.Error(t, err)
+
+	err = ss.RunScheduledJob(context.TODO(), &schema.RunScheduledJobReq{Name: "panic"})
+	assert.NoError(t, err)
+	select {
+	case job := <-repo.lastRun:
+		assert.Equal(t, "panic", job.Name)
+		assert.Equal(t, entity.ScheduledJobRunStatusFailed, job.LastStatus)
+		assert.Equal(t, "panic: boom", job.LastError)
+	case <-time.After(time.Second):
+		t.Fatal("the job is not run")
+	}
+
+	err = ss.RunScheduledJob(context.TODO(), &schema.RunScheduledJobReq{Name: "failed"})
+	assert.NoError(t, err)
+	select {
+	case job := <-repo.lastRun:
+		assert.Equal(t, "failed", job.Name)
+		assert.Equal(t, entity.ScheduledJobRunStatusFailed, job.LastStatus)
+		assert.Equal(t, "connection refused", job.LastError)
+	case <-time.After(time.Second):
+		t.Fatal("the job is not run")
+	}
+
+	err = ss.RunScheduledJob(context.TODO(), &schema.RunScheduledJobReq{Name: "success"})
+	assert.NoError(t, err)
+	select {
+	case job := <-repo.lastRun:
+		assert.Equal(t, "success", job.Name)
+		assert.Equal(t, entity.ScheduledJobRunStatusSuccess, job.LastStatus)
+		assert.Empty(t, job.LastError)
+	case <-time.After(time.Second):
+		t.Fatal("the job is not run")
+	}
+}
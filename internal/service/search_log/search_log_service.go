@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package search_log
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/pkg/obj"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	searchLogDateFormat     = "2006-01-02"
+	searchLogQueryMaxLength = 100
+	searchReportDefaultSize = 20
+)
+
+// SearchLogRepo search log repository
+type SearchLogRepo interface {
+	IncrSearch(ctx context.Context, date, query string, resultCount int64) (err error)
+	IncrClick(ctx context.Context, date, query, objectID string) (err error)
+	GetTopQueries(ctx context.Context, startDate, endDate string, limit int) (stats []*entity.SearchLogQueryStat, err error)
+	GetZeroResultQueries(ctx context.Context, startDate, endDate string, limit int) (stats []*entity.SearchLogQueryStat, err error)
+}
+
+// SearchLogService search log service
+type SearchLogService struct {
+	searchLogRepo SearchLogRepo
+}
+
+// NewSearchLogService new search log service
+func NewSearchLogService(searchLogRepo SearchLogRepo) *SearchLogService {
+	return &SearchLogService{
+		searchLogRepo: searchLogRepo,
+	}
+}
+
+// RecordSearch record the search query and its result count.
+// Only the normalized query is recorded and aggregated by day, so it can not be traced back to the user.
+func (ss *SearchLogService) RecordSearch(ctx context.Context, query string, resultCount int64) {
+	query = NormalizeQuery(query)
+	if len(query) == 0 {
+		return
+	}
+	err := ss.searchLogRepo.IncrSearch(ctx, time.Now().Format(searchLogDateFormat), query, resultCount)
+	if err != nil {
+		log.Errorf("record search log failed: %s", err)
+	}
+}
+
+// RecordClick record the clicked search result
+func (ss *SearchLogService) RecordClick(ctx context.Context, req *schema.SearchClickReq) (err error) {
+	objectType, err := obj.GetObjectTypeStrByObjectID(req.ObjectID)
+	if err != nil || (objectType != constant.QuestionObjectType && objectType != constant.AnswerObjectType) {
+		return errors.BadRequest(reason.ObjectNotFound)
+	}
+	query := NormalizeQuery(req.Query)
+	if len(query) == 0 {
+		return nil
+	}
+	return ss.searchLogRepo.IncrClick(ctx, time.Now().Format(searchLogDateFormat), query, req.ObjectID)
+}
+
+// GetSearchReport get the top queries and the zero result queries in the time range
+func (ss *SearchLogService) GetSearchReport(ctx context.Context, req *schema.GetSearchReportReq) (
+	resp *schema.GetSearchReportResp, err error) {
+	if len(req.Range) == 0 {
+		req.Range = schema.StatsRangeMonth
+	}
+	if req.Size == 0 {
+		req.Size = searchReportDefaultSize
+	}
+	now := time.Now()
+	resp = &schema.GetSearchReportResp{
+		Range:     req.Range,
+		StartDate: now.AddDate(0, 0, 1-schema.StatsRangeDays[req.Range]).Format(searchLogDateFormat),
+		EndDate:   now.Format(searchLogDateFormat),
+	}
+	topQueries, err := ss.searchLogRepo.GetTopQueries(ctx, resp.StartDate, resp.EndDate, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	resp.TopQueries = convertSearchReportQueryItems(topQueries)
+	resp.ZeroResultQueries, err = ss.GetZeroResultQueries(ctx, resp.StartDate, resp.EndDate, req.Size)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetZeroResultQueries get the queries that have no result most frequently between the dates, both inclusive
+func (ss *SearchLogService) GetZeroResultQueries(ctx context.Context, startDate, endDate string, limit int) (
+	items []*schema.SearchReportQueryItem, err error) {
+	stats, err := ss.searchLogRepo.GetZeroResultQueries(ctx, startDate, endDate, limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertSearchReportQueryItems(stats), nil
+}
+
+// NormalizeQuery lower case the query, collapse the whitespaces and limit the length
+func NormalizeQuery(query string) string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if utf8.RuneCountInString(query) > searchLogQueryMaxLength {
+		query = strings.TrimSpace(string([]rune(query)[:searchLogQueryMaxLength]))
+	}
+	return query
+}
+
+func convertSearchReportQueryItems(stats []*entity.SearchLogQueryStat) []*schema.SearchReportQueryItem {
+	items := make([]*schema.SearchReportQueryItem, 0, len(stats))
+	for _, stat := range stats {
+		item := &schema.SearchReportQueryItem{
+			Query:           stat.Query,
+			SearchCount:     stat.SearchCount,
+			ZeroResultCount: stat.ZeroResultCount,
+			ClickCount:      stat.ClickCount,
+		}
+		if stat.SearchCount > 0 {
+			item.ClickThroughRate = float64(stat.ClickCount) / float64(stat.SearchCount)
+		}
+		items = append(items, item)
+	}
+	return items
+}
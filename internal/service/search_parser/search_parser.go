@@ -91,6 +91,9 @@ func (sp *SearchParser) ParseStructure(ctx context.Context, dto *schema.SearchDT
 	if sp.parseIsAnswer(&query) {
 		cond.TargetType = constant.AnswerObjectType
 	}
+	if sp.parseIsArticle(&query) {
+		cond.TargetType = constant.ArticleObjectType
+	}
 
 	if len(strings.TrimSpace(query)) > 0 {
 		words := strings.Split(strings.TrimSpace(query), " ")
@@ -332,3 +335,19 @@ func (sp *SearchParser) parseIsAnswer(query *string) (isAnswer bool) {
 	*query = strings.TrimSpace(q)
 	return
 }
+
+// parseIsArticle check the result if only limit article or not
+func (sp *SearchParser) parseIsArticle(query *string) (isArticle bool) {
+	var (
+		q    = *query
+		expr = `is:article`
+	)
+
+	if strings.Contains(q, expr) {
+		isArticle = true
+		q = strings.ReplaceAll(q, expr, "")
+	}
+
+	*query = strings.TrimSpace(q)
+	return
+}
@@ -36,6 +36,7 @@ import (
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
 	"github.com/apache/incubator-answer/plugin"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -179,6 +180,30 @@ func (s *SiteInfoService) SaveSiteBranding(ctx context.Context, req *schema.Site
 	return s.siteInfoRepo.SaveByType(ctx, constant.SiteTypeBranding, data)
 }
 
+// SaveSiteBrandingFile use the uploaded file as the logo or icon of the site
+func (s *SiteInfoService) SaveSiteBrandingFile(ctx context.Context, fileType, fileURL string) (
+	resp *schema.SiteBrandingResp, err error) {
+	resp, err = s.siteInfoCommonService.GetSiteBranding(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch fileType {
+	case "logo":
+		resp.Logo = fileURL
+	case "mobile_logo":
+		resp.MobileLogo = fileURL
+	case "square_icon":
+		resp.SquareIcon = fileURL
+	case "favicon":
+		resp.Favicon = fileURL
+	}
+	req := schema.SiteBrandingReq(*resp)
+	if err = s.SaveSiteBranding(ctx, &req); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // SaveSiteWrite save site configuration about write
 func (s *SiteInfoService) SaveSiteWrite(ctx context.Context, req *schema.SiteWriteReq) (resp interface{}, err error) {
 	recommendTags, reservedTags := make([]string, 0), make([]string, 0)
@@ -238,6 +263,15 @@ func (s *SiteInfoService) SaveSiteLogin(ctx context.Context, req *schema.SiteLog
 
 // SaveSiteCustomCssHTML save site custom html configuration
 func (s *SiteInfoService) SaveSiteCustomCssHTML(ctx context.Context, req *schema.SiteCustomCssHTMLReq) (err error) {
+	req.CustomCss = htmltext.EscapeCloseTag(req.CustomCss)
+	req.CustomJs = htmltext.EscapeCloseTag(req.CustomJs)
+	// the inline event handlers are blocked by CSP, so remove them to keep the saved code same as what actually runs
+	if req.EnableCSP {
+		req.CustomHead = htmltext.StripInlineEventHandlers(req.CustomHead)
+		req.CustomHeader = htmltext.StripInlineEventHandlers(req.CustomHeader)
+		req.CustomFooter = htmltext.StripInlineEventHandlers(req.CustomFooter)
+		req.CustomSideBar = htmltext.StripInlineEventHandlers(req.CustomSideBar)
+	}
 	content, _ := json.Marshal(req)
 	data := &entity.SiteInfo{
 		Type:    constant.SiteTypeCustomCssHTML,
@@ -278,9 +312,21 @@ func (s *SiteInfoService) GetSMTPConfig(ctx context.Context) (resp *schema.GetSM
 	resp = &schema.GetSMTPConfigResp{}
 	_ = copier.Copy(resp, emailConfig)
 	resp.SMTPPassword = strings.Repeat("*", len(resp.SMTPPassword))
+	resp.APIKey = strings.Repeat("*", len(resp.APIKey))
+	resp.SESSecretAccessKey = strings.Repeat("*", len(resp.SESSecretAccessKey))
+	resp.WebhookURL = s.emailService.GetDeliveryWebhookURL(ctx, emailConfig)
+	resp.InboundWebhookURL = s.emailService.GetInboundWebhookURL(ctx, emailConfig)
 	return resp, nil
 }
 
+// keepMaskedSecret the secret is returned masked, so the old secret is kept if it is not changed
+func keepMaskedSecret(secret, oldSecret string) string {
+	if len(secret) > 0 && secret == strings.Repeat("*", len(secret)) {
+		return oldSecret
+	}
+	return secret
+}
+
 // UpdateSMTPConfig get smtp config
 func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.UpdateSMTPConfigReq) (err error) {
 	emailConfig, err := s.emailService.GetEmailConfig(ctx)
@@ -291,8 +337,12 @@ func (s *SiteInfoService) UpdateSMTPConfig(ctx context.Context, req *schema.Upda
 	ec := &export.EmailConfig{}
 	_ = copier.Copy(ec, req)
 
-	if len(ec.SMTPPassword) > 0 && ec.SMTPPassword == strings.Repeat("*", len(ec.SMTPPassword)) {
-		ec.SMTPPassword = emailConfig.SMTPPassword
+	ec.SMTPPassword = keepMaskedSecret(ec.SMTPPassword, emailConfig.SMTPPassword)
+	ec.APIKey = keepMaskedSecret(ec.APIKey, emailConfig.APIKey)
+	ec.SESSecretAccessKey = keepMaskedSecret(ec.SESSecretAccessKey, emailConfig.SESSecretAccessKey)
+	ec.WebhookKey = emailConfig.WebhookKey
+	if len(ec.WebhookKey) == 0 {
+		ec.WebhookKey = export.GenerateWebhookKey()
 	}
 
 	err = s.emailService.SetEmailConfig(ctx, ec)
@@ -0,0 +1,289 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package sitemap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// SitemapRepo sitemap repository
+type SitemapRepo interface {
+	CountSitemapItems(ctx context.Context, sitemapType string) (count int64, err error)
+	CountSitemapItemsBefore(ctx context.Context, sitemapType, objectID string) (count int64, err error)
+	GetSitemapItems(ctx context.Context, sitemapType string, page, pageSize int) (items []*schema.SitemapItem, err error)
+}
+
+// SitemapService sitemap service
+type SitemapService struct {
+	sitemapRepo     SitemapRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+	data            *data.Data
+}
+
+// NewSitemapService new sitemap service
+func NewSitemapService(
+	sitemapRepo SitemapRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
+	data *data.Data,
+) *SitemapService {
+	ss := &SitemapService{
+		sitemapRepo:     sitemapRepo,
+		siteInfoService: siteInfoService,
+		data:            data,
+	}
+	eventQueueService.RegisterHandler(ss.HandleEvent)
+	return ss
+}
+
+// GetSitemapIndex get the urls of all sitemap pages
+func (ss *SitemapService) GetSitemapIndex(ctx context.Context) (urls []*schema.SitemapURL, lastMod time.Time, err error) {
+	general, err := ss.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, lastMod, err
+	}
+	urls = make([]*schema.SitemapURL, 0)
+	for _, sitemapType := range schema.SitemapTypes {
+		totalPages, err := ss.getTotalPages(ctx, sitemapType)
+		if err != nil {
+			return nil, lastMod, err
+		}
+		for page := 1; page <= totalPages; page++ {
+			sitemapPage, err := ss.getSitemapPage(ctx, sitemapType, page)
+			if err != nil {
+				return nil, lastMod, err
+			}
+			if sitemapPage.LastMod.After(lastMod) {
+				lastMod = sitemapPage.LastMod
+			}
+			urls = append(urls, &schema.SitemapURL{
+				Loc:     fmt.Sprintf("%s/sitemap/%s-%d.xml", general.SiteUrl, sitemapType, page),
+				LastMod: formatSitemapTime(sitemapPage.LastMod),
+			})
+		}
+	}
+	return urls, lastMod, nil
+}
+
+// GetSitemapPage get the urls in the sitemap page
+func (ss *SitemapService) GetSitemapPage(ctx context.Context, sitemapType string, page int) (
+	urls []*schema.SitemapURL, lastMod time.Time, exist bool, err error) {
+	totalPages, err := ss.getTotalPages(ctx, sitemapType)
+	if err != nil {
+		return nil, lastMod, false, err
+	}
+	if page < 1 || page > totalPages {
+		return nil, lastMod, false, nil
+	}
+	general, err := ss.siteInfoService.GetSiteGeneral(ctx)
+	if err != nil {
+		return nil, lastMod, false, err
+	}
+	siteSeo, err := ss.siteInfoService.GetSiteSeo(ctx)
+	if err != nil {
+		return nil, lastMod, false, err
+	}
+	sitemapPage, err := ss.getSitemapPage(ctx, sitemapType, page)
+	if err != nil {
+		return nil, lastMod, false, err
+	}
+
+	urls = make([]*schema.SitemapURL, 0, len(sitemapPage.Items))
+	for _, item := range sitemapPage.Items {
+		urls = append(urls, &schema.SitemapURL{
+			Loc:     general.SiteUrl + sitemapItemPath(sitemapType, item, siteSeo),
+			LastMod: formatSitemapTime(item.UpdateTime),
+		})
+	}
+	return urls, sitemapPage.LastMod, true, nil
+}
+
+// SitemapCron generate the sitemap pages which are not in the cache.
+// The pages in the cache are kept up to date by the content change events.
+func (ss *SitemapService) SitemapCron(ctx context.Context) (err error) {
+	for _, sitemapType := range schema.SitemapTypes {
+		totalPages, e := ss.getTotalPages(ctx, sitemapType)
+		if e != nil {
+			log.Errorf("get %s sitemap total pages failed: %s", sitemapType, e)
+			err = e
+			continue
+		}
+		for page := 1; page <= totalPages; page++ {
+			if _, e = ss.getSitemapPage(ctx, sitemapType, page); e != nil {
+				log.Errorf("generate %s sitemap page %d failed: %s", sitemapType, page, e)
+				err = e
+				break
+			}
+		}
+	}
+	return err
+}
+
+// HandleEvent regenerate the sitemap pages affected by the content change.
+// Objects are sorted by id in the sitemap, so a new object only changes the last page,
+// an updated object only changes its own page, and a removed object shifts all the pages after it.
+func (ss *SitemapService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	switch msg.EventType {
+	case constant.EventQuestionCreate, constant.EventQuestionDelete, constant.EventQuestionStatusChange:
+		return ss.refreshPagesFrom(ctx, schema.SitemapTypeQuestion, msg.ObjectID)
+	case constant.EventQuestionUpdate:
+		return ss.refreshPage(ctx, schema.SitemapTypeQuestion, msg.ObjectID)
+	case constant.EventAnswerCreate, constant.EventAnswerUpdate, constant.EventAnswerDelete:
+		return ss.refreshPage(ctx, schema.SitemapTypeQuestion, msg.QuestionID)
+	case constant.EventTagCreate, constant.EventTagDelete:
+		return ss.refreshPagesFrom(ctx, schema.SitemapTypeTag, msg.ObjectID)
+	case constant.EventTagUpdate:
+		return ss.refreshPage(ctx, schema.SitemapTypeTag, msg.ObjectID)
+	case constant.EventUserRegister, constant.EventUserDelete, constant.EventUserStatusChange:
+		return ss.refreshPagesFrom(ctx, schema.SitemapTypeUser, msg.ObjectID)
+	case constant.EventUserUpdate:
+		return ss.refreshPage(ctx, schema.SitemapTypeUser, msg.ObjectID)
+	case constant.EventPageCreate, constant.EventPageDelete, constant.EventPageStatusChange:
+		return ss.refreshPagesFrom(ctx, schema.SitemapTypePage, msg.ObjectID)
+	case constant.EventPageUpdate:
+		return ss.refreshPage(ctx, schema.SitemapTypePage, msg.ObjectID)
+	}
+	return nil
+}
+
+func (ss *SitemapService) refreshPage(ctx context.Context, sitemapType, objectID string) error {
+	page, err := ss.getObjectPage(ctx, sitemapType, objectID)
+	if err != nil {
+		return err
+	}
+	_, err = ss.generateSitemapPage(ctx, sitemapType, page)
+	return err
+}
+
+func (ss *SitemapService) refreshPagesFrom(ctx context.Context, sitemapType, objectID string) error {
+	page, err := ss.getObjectPage(ctx, sitemapType, objectID)
+	if err != nil {
+		return err
+	}
+	totalPages, err := ss.getTotalPages(ctx, sitemapType)
+	if err != nil {
+		return err
+	}
+	for ; page <= totalPages; page++ {
+		if _, err = ss.generateSitemapPage(ctx, sitemapType, page); err != nil {
+			return err
+		}
+	}
+	// the number of pages may decrease after the object is removed
+	return ss.data.Cache.Del(ctx, sitemapCacheKey(sitemapType, totalPages+1))
+}
+
+func (ss *SitemapService) getObjectPage(ctx context.Context, sitemapType, objectID string) (page int, err error) {
+	// page id is a plain auto increment id, not a short id
+	if sitemapType != schema.SitemapTypePage {
+		objectID = uid.DeShortID(objectID)
+	}
+	position, err := ss.sitemapRepo.CountSitemapItemsBefore(ctx, sitemapType, objectID)
+	if err != nil {
+		return 0, err
+	}
+	return int(position)/constant.SitemapMaxSize + 1, nil
+}
+
+func (ss *SitemapService) getTotalPages(ctx context.Context, sitemapType string) (totalPages int, err error) {
+	count, err := ss.sitemapRepo.CountSitemapItems(ctx, sitemapType)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Ceil(float64(count) / float64(constant.SitemapMaxSize))), nil
+}
+
+func (ss *SitemapService) getSitemapPage(ctx context.Context, sitemapType string, page int) (
+	sitemapPage *schema.SitemapPage, err error) {
+	cacheData, exist, err := ss.data.Cache.GetString(ctx, sitemapCacheKey(sitemapType, page))
+	if err == nil && exist {
+		sitemapPage = &schema.SitemapPage{}
+		if err = json.Unmarshal([]byte(cacheData), sitemapPage); err == nil {
+			return sitemapPage, nil
+		}
+	}
+	return ss.generateSitemapPage(ctx, sitemapType, page)
+}
+
+func (ss *SitemapService) generateSitemapPage(ctx context.Context, sitemapType string, page int) (
+	sitemapPage *schema.SitemapPage, err error) {
+	items, err := ss.sitemapRepo.GetSitemapItems(ctx, sitemapType, page, constant.SitemapMaxSize)
+	if err != nil {
+		return nil, err
+	}
+	sitemapPage = &schema.SitemapPage{Items: items}
+	for _, item := range items {
+		if item.UpdateTime.After(sitemapPage.LastMod) {
+			sitemapPage.LastMod = item.UpdateTime
+		}
+	}
+
+	cacheData, _ := json.Marshal(sitemapPage)
+	err = ss.data.Cache.SetString(ctx, sitemapCacheKey(sitemapType, page), string(cacheData), constant.SitemapCacheTime)
+	if err != nil {
+		log.Errorf("set sitemap cache failed: %s", err)
+	}
+	return sitemapPage, nil
+}
+
+func sitemapCacheKey(sitemapType string, page int) string {
+	return fmt.Sprintf(constant.SitemapCacheKeyPrefix, sitemapType, page)
+}
+
+func sitemapItemPath(sitemapType string, item *schema.SitemapItem, siteSeo *schema.SiteSeoResp) string {
+	switch sitemapType {
+	case schema.SitemapTypeTag:
+		return "/tags/" + url.PathEscape(item.ID)
+	case schema.SitemapTypeUser:
+		return "/users/" + url.PathEscape(item.ID)
+	case schema.SitemapTypePage:
+		return "/pages/" + url.PathEscape(item.ID)
+	}
+	questionID := item.ID
+	if siteSeo.IsShortLink() {
+		questionID = uid.EnShortID(questionID)
+	}
+	if siteSeo.Permalink == constant.PermalinkQuestionIDAndTitle ||
+		siteSeo.Permalink == constant.PermalinkQuestionIDAndTitleByShortID {
+		return "/questions/" + questionID + "/" + htmltext.UrlTitle(item.Title)
+	}
+	return "/questions/" + questionID
+}
+
+func formatSitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,105 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package slow_query
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/lifecycle"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/slowquery"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+)
+
+// SlowQueryRepo slow query repository
+type SlowQueryRepo interface {
+	AddSlowQueries(ctx context.Context, queries []*entity.SlowQuery) (err error)
+	GetSlowQueryPage(ctx context.Context, page, pageSize int, orderBy string) (
+		queries []*entity.SlowQuery, total int64, err error)
+	ClearSlowQueries(ctx context.Context) (err error)
+}
+
+// SlowQueryService the report of the slow queries logged by the database hook of each instance
+type SlowQueryService struct {
+	slowQueryRepo SlowQueryRepo
+}
+
+// NewSlowQueryService new slow query service
+func NewSlowQueryService(slowQueryRepo SlowQueryRepo) *SlowQueryService {
+	ss := &SlowQueryService{slowQueryRepo: slowQueryRepo}
+	lifecycle.OnShutdown("slow query report", ss.FlushCron)
+	return ss
+}
+
+// FlushCron add the slow queries collected since the last flush to the report
+func (ss *SlowQueryService) FlushCron(ctx context.Context) (err error) {
+	stats := slowquery.Drain()
+	if len(stats) == 0 {
+		return nil
+	}
+	queries := make([]*entity.SlowQuery, 0, len(stats))
+	for _, stat := range stats {
+		queries = append(queries, &entity.SlowQuery{
+			Fingerprint: stat.Fingerprint,
+			Statement:   stat.Statement,
+			Sample:      stat.Sample,
+			Plan:        stat.Plan,
+			Count:       stat.Count,
+			TotalTime:   stat.TotalTime.Milliseconds(),
+			MaxTime:     stat.MaxTime.Milliseconds(),
+			LastSeenAt:  stat.LastSeenAt,
+		})
+	}
+	return ss.slowQueryRepo.AddSlowQueries(ctx, queries)
+}
+
+// GetSlowQueryPage get the slow query report
+func (ss *SlowQueryService) GetSlowQueryPage(ctx context.Context, req *schema.GetSlowQueryPageReq) (
+	pageModel *pager.PageModel, err error) {
+	queries, total, err := ss.slowQueryRepo.GetSlowQueryPage(ctx, req.Page, req.PageSize, req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*schema.GetSlowQueryResp, 0, len(queries))
+	for _, query := range queries {
+		resp := &schema.GetSlowQueryResp{
+			Fingerprint: query.Fingerprint,
+			Statement:   query.Statement,
+			Sample:      query.Sample,
+			Plan:        query.Plan,
+			Count:       query.Count,
+			TotalTime:   query.TotalTime,
+			MaxTime:     query.MaxTime,
+			LastSeenAt:  query.LastSeenAt.Unix(),
+		}
+		if query.Count > 0 {
+			resp.AvgTime = query.TotalTime / query.Count
+		}
+		list = append(list, resp)
+	}
+	return pager.NewPageModel(total, list), nil
+}
+
+// ClearSlowQueries clear the report, e.g. after the indexes are added
+func (ss *SlowQueryService) ClearSlowQueries(ctx context.Context) (err error) {
+	slowquery.Drain()
+	return ss.slowQueryRepo.ClearSlowQueries(ctx)
+}
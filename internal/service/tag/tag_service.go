@@ -26,6 +26,7 @@ import (
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
@@ -51,6 +52,7 @@ type TagService struct {
 	followCommon         activity_common.FollowRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	eventQueueService    event_queue.EventQueueService
 }
 
 // NewTagService new tag service
@@ -61,6 +63,7 @@ func NewTagService(
 	followCommon activity_common.FollowRepo,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	eventQueueService event_queue.EventQueueService,
 ) *TagService {
 	return &TagService{
 		tagRepo:              tagRepo,
@@ -69,6 +72,7 @@ func NewTagService(
 		followCommon:         followCommon,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		eventQueueService:    eventQueueService,
 	}
 }
 
@@ -103,6 +107,11 @@ func (ts *TagService) RemoveTag(ctx context.Context, req *schema.RemoveTagReq) (
 		OriginalObjectID: req.TagID,
 		ActivityTypeKey:  constant.ActTagDeleted,
 	})
+	ts.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventTagDelete,
+		UserID:    req.UserID,
+		ObjectID:  req.TagID,
+	})
 	return nil
 }
 
@@ -0,0 +1,288 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/data"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+const tagStatsTopContributorsLimit = 10
+
+// TagStatsRepo tag stats repository
+type TagStatsRepo interface {
+	GetTagQuestionsByTime(ctx context.Context, tagID string, startTime, endTime time.Time) (questions []*entity.Question, err error)
+	GetAnswersByQuestionIDs(ctx context.Context, questionIDs []string) (answers []*entity.Answer, err error)
+}
+
+// TagStatsService tag stats service
+type TagStatsService struct {
+	tagStatsRepo     TagStatsRepo
+	tagCommonService *tagcommonser.TagCommonService
+	userCommon       *usercommon.UserCommon
+	data             *data.Data
+}
+
+// NewTagStatsService new tag stats service
+func NewTagStatsService(
+	tagStatsRepo TagStatsRepo,
+	tagCommonService *tagcommonser.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	data *data.Data,
+) *TagStatsService {
+	return &TagStatsService{
+		tagStatsRepo:     tagStatsRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+		data:             data,
+	}
+}
+
+// GetTagStats get the statistics of the tag in the time range
+func (ts *TagStatsService) GetTagStats(ctx context.Context, req *schema.GetTagStatsReq) (
+	resp *schema.GetTagStatsResp, err error) {
+	if len(req.Range) == 0 {
+		req.Range = schema.StatsRangeMonth
+	}
+	tagInfo, exist, err := ts.tagCommonService.GetTagBySlugName(ctx, req.SlugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TagNotFound)
+	}
+
+	cacheKey := constant.TagStatsCacheKeyPrefix + tagInfo.ID + ":" + req.Range
+	if resp = ts.getFromCache(ctx, cacheKey); resp != nil {
+		return resp, nil
+	}
+
+	endTime := time.Now()
+	startTime := endTime.AddDate(0, 0, -schema.StatsRangeDays[req.Range])
+	resp = &schema.GetTagStatsResp{
+		TagID:           tagInfo.ID,
+		SlugName:        tagInfo.SlugName,
+		DisplayName:     tagInfo.DisplayName,
+		Range:           req.Range,
+		StartTime:       startTime.Unix(),
+		EndTime:         endTime.Unix(),
+		TopContributors: make([]*schema.TagStatsContributor, 0),
+	}
+
+	questions, err := ts.tagStatsRepo.GetTagQuestionsByTime(ctx, tagInfo.ID, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := make([]string, 0, len(questions))
+	questionCreatedTimes := make([]time.Time, 0, len(questions))
+	for _, question := range questions {
+		questionIDs = append(questionIDs, question.ID)
+		questionCreatedTimes = append(questionCreatedTimes, question.CreatedAt)
+		if question.AnswerCount > 0 {
+			resp.AnsweredCount++
+		}
+	}
+	resp.QuestionCount = len(questions)
+	if resp.QuestionCount > 0 {
+		resp.AnswerRate = float64(resp.AnsweredCount) / float64(resp.QuestionCount)
+	}
+	resp.QuestionsPerDay = bucketTagStatsByDay(questionCreatedTimes, startTime, endTime)
+	resp.QuestionsPerWeek = bucketTagStatsByWeek(questionCreatedTimes, startTime, endTime)
+
+	answers, err := ts.tagStatsRepo.GetAnswersByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp.MedianFirstAnswerSeconds = medianFirstAnswerSeconds(questions, answers)
+	resp.TopContributors, err = ts.getTopContributors(ctx, answers)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.setCache(ctx, cacheKey, resp)
+	return resp, nil
+}
+
+func (ts *TagStatsService) getTopContributors(ctx context.Context, answers []*entity.Answer) (
+	contributors []*schema.TagStatsContributor, err error) {
+	contributors = make([]*schema.TagStatsContributor, 0)
+	userMapping := make(map[string]*schema.TagStatsContributor)
+	for _, answer := range answers {
+		contributor, ok := userMapping[answer.UserID]
+		if !ok {
+			contributor = &schema.TagStatsContributor{}
+			userMapping[answer.UserID] = contributor
+		}
+		contributor.AnswerCount++
+		contributor.VoteCount += answer.VoteCount
+		if answer.Accepted == schema.AnswerAcceptedEnable {
+			contributor.AcceptedCount++
+		}
+	}
+
+	userIDs := make([]string, 0, len(userMapping))
+	for userID := range userMapping {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool {
+		a, b := userMapping[userIDs[i]], userMapping[userIDs[j]]
+		if a.AcceptedCount != b.AcceptedCount {
+			return a.AcceptedCount > b.AcceptedCount
+		}
+		if a.VoteCount != b.VoteCount {
+			return a.VoteCount > b.VoteCount
+		}
+		if a.AnswerCount != b.AnswerCount {
+			return a.AnswerCount > b.AnswerCount
+		}
+		return userIDs[i] < userIDs[j]
+	})
+	if len(userIDs) > tagStatsTopContributorsLimit {
+		userIDs = userIDs[:tagStatsTopContributorsLimit]
+	}
+
+	userInfoMapping, err := ts.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range userIDs {
+		userInfo, ok := userInfoMapping[userID]
+		if !ok {
+			continue
+		}
+		contributor := userMapping[userID]
+		contributor.UserInfo = userInfo
+		contributors = append(contributors, contributor)
+	}
+	return contributors, nil
+}
+
+func (ts *TagStatsService) getFromCache(ctx context.Context, cacheKey string) (resp *schema.GetTagStatsResp) {
+	cacheData, exist, err := ts.data.Cache.GetString(ctx, cacheKey)
+	if err != nil {
+		log.Errorf("get tag stats from cache failed: %s", err)
+		return nil
+	}
+	if !exist {
+		return nil
+	}
+	resp = &schema.GetTagStatsResp{}
+	if err = json.Unmarshal([]byte(cacheData), resp); err != nil {
+		return nil
+	}
+	return resp
+}
+
+func (ts *TagStatsService) setCache(ctx context.Context, cacheKey string, resp *schema.GetTagStatsResp) {
+	cacheData, _ := json.Marshal(resp)
+	err := ts.data.Cache.SetString(ctx, cacheKey, string(cacheData), constant.TagStatsCacheTime)
+	if err != nil {
+		log.Errorf("set tag stats cache failed: %s", err)
+	}
+}
+
+// bucketTagStatsByDay count the times by day, every day in the range has a point even if the count is zero
+func bucketTagStatsByDay(times []time.Time, startTime, endTime time.Time) []*schema.TagStatsPoint {
+	points := make([]*schema.TagStatsPoint, 0)
+	indexMapping := make(map[string]int)
+	for day := truncateToDay(startTime); !day.After(endTime); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		indexMapping[date] = len(points)
+		points = append(points, &schema.TagStatsPoint{Date: date})
+	}
+	for _, t := range times {
+		if idx, ok := indexMapping[t.In(startTime.Location()).Format("2006-01-02")]; ok {
+			points[idx].Count++
+		}
+	}
+	return points
+}
+
+// bucketTagStatsByWeek count the times by week, the week starts on Monday
+func bucketTagStatsByWeek(times []time.Time, startTime, endTime time.Time) []*schema.TagStatsPoint {
+	points := make([]*schema.TagStatsPoint, 0)
+	indexMapping := make(map[string]int)
+	for week := truncateToWeek(startTime); !week.After(endTime); week = week.AddDate(0, 0, 7) {
+		date := week.Format("2006-01-02")
+		indexMapping[date] = len(points)
+		points = append(points, &schema.TagStatsPoint{Date: date})
+	}
+	for _, t := range times {
+		week := truncateToWeek(t.In(startTime.Location())).Format("2006-01-02")
+		if idx, ok := indexMapping[week]; ok {
+			points[idx].Count++
+		}
+	}
+	return points
+}
+
+// medianFirstAnswerSeconds the median duration between the question created and its first answer created
+func medianFirstAnswerSeconds(questions []*entity.Question, answers []*entity.Answer) int64 {
+	firstAnswerTime := make(map[string]time.Time)
+	for _, answer := range answers {
+		t, ok := firstAnswerTime[answer.QuestionID]
+		if !ok || answer.CreatedAt.Before(t) {
+			firstAnswerTime[answer.QuestionID] = answer.CreatedAt
+		}
+	}
+	durations := make([]int64, 0, len(firstAnswerTime))
+	for _, question := range questions {
+		t, ok := firstAnswerTime[question.ID]
+		if !ok {
+			continue
+		}
+		seconds := int64(t.Sub(question.CreatedAt).Seconds())
+		if seconds < 0 {
+			seconds = 0
+		}
+		durations = append(durations, seconds)
+	}
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 1 {
+		return durations[mid]
+	}
+	return (durations[mid-1] + durations[mid]) / 2
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func truncateToWeek(t time.Time) time.Time {
+	day := truncateToDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
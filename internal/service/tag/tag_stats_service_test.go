@@ -0,0 +1,74 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketTagStatsByDay(t *testing.T) {
+	start := time.Date(2023, 10, 1, 8, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 10, 3, 8, 0, 0, 0, time.UTC)
+	points := bucketTagStatsByDay([]time.Time{
+		time.Date(2023, 10, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 1, 23, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 3, 1, 0, 0, 0, time.UTC),
+	}, start, end)
+	assert.Len(t, points, 3)
+	assert.Equal(t, "2023-10-01", points[0].Date)
+	assert.Equal(t, 2, points[0].Count)
+	assert.Equal(t, 0, points[1].Count)
+	assert.Equal(t, 1, points[2].Count)
+}
+
+func TestBucketTagStatsByWeek(t *testing.T) {
+	// 2023-10-04 is Wednesday
+	start := time.Date(2023, 10, 4, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC)
+	points := bucketTagStatsByWeek([]time.Time{
+		time.Date(2023, 10, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 10, 9, 0, 0, 0, 0, time.UTC),
+	}, start, end)
+	assert.Len(t, points, 2)
+	assert.Equal(t, "2023-10-02", points[0].Date)
+	assert.Equal(t, 1, points[0].Count)
+	assert.Equal(t, "2023-10-09", points[1].Date)
+	assert.Equal(t, 1, points[1].Count)
+}
+
+func TestMedianFirstAnswerSeconds(t *testing.T) {
+	base := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	questions := []*entity.Question{
+		{ID: "1", CreatedAt: base},
+		{ID: "2", CreatedAt: base},
+		{ID: "3", CreatedAt: base},
+	}
+	answers := []*entity.Answer{
+		{QuestionID: "1", CreatedAt: base.Add(30 * time.Second)},
+		{QuestionID: "1", CreatedAt: base.Add(10 * time.Second)},
+		{QuestionID: "2", CreatedAt: base.Add(50 * time.Second)},
+	}
+	assert.Equal(t, int64(30), medianFirstAnswerSeconds(questions, answers))
+	assert.Equal(t, int64(0), medianFirstAnswerSeconds(questions, nil))
+}
@@ -0,0 +1,282 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/embedding"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	tagSuggestionDefaultLimit = 5
+	// tagSuggestionMinScore the suggestion whose score is lower than it is dropped
+	tagSuggestionMinScore = 0.1
+	// tagSuggestionMaxCandidates the max number of the words looked up as the tag names
+	tagSuggestionMaxCandidates = 300
+	// tagSuggestionMaxNgram the tag name may contain up to 3 words, e.g. ruby-on-rails
+	tagSuggestionMaxNgram = 3
+	tagSlugNameMaxLen     = 35
+	// tagSuggestionSimilarQuestions the number of the similar questions whose tags are suggested
+	tagSuggestionSimilarQuestions = 10
+)
+
+// tagSuggestion the suggested tag and its score
+type tagSuggestion struct {
+	tag   *schema.TagSuggestionResp
+	score float64
+}
+
+// TagSuggestService suggest the existing tags for the draft question
+type TagSuggestService struct {
+	tagCommonService *tagcommonser.TagCommonService
+	embeddingService *embedding.EmbeddingService
+}
+
+// NewTagSuggestService new tag suggest service
+func NewTagSuggestService(
+	tagCommonService *tagcommonser.TagCommonService,
+	embeddingService *embedding.EmbeddingService,
+) *TagSuggestService {
+	return &TagSuggestService{
+		tagCommonService: tagCommonService,
+		embeddingService: embeddingService,
+	}
+}
+
+// GetTagSuggestions suggest the tags whose names appear in the title or content, and the tags of the
+// semantically similar questions if the semantic search is enabled. The tag synonyms are suggested as
+// their main tags.
+func (ts *TagSuggestService) GetTagSuggestions(ctx context.Context, req *schema.GetTagSuggestionsReq) (
+	resp []*schema.TagSuggestionResp, err error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = tagSuggestionDefaultLimit
+	}
+	content := htmltext.ClearText(req.Content)
+	suggestions := make(map[string]*tagSuggestion)
+
+	titleCounts := countTagCandidates(req.Title)
+	contentCounts := countTagCandidates(content)
+	candidates := topTagCandidates(titleCounts, contentCounts, tagSuggestionMaxCandidates)
+	if len(candidates) > 0 {
+		tags, err := ts.tagCommonService.GetTagListByNames(ctx, candidates)
+		if err != nil {
+			return nil, err
+		}
+		matched, err := ts.replaceSynonyms(ctx, tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range matched {
+			score := keywordTagScore(titleCounts[t.matchedName], contentCounts[t.matchedName])
+			addTagSuggestion(suggestions, t.tag, score)
+		}
+	}
+
+	if ts.embeddingService.IsSemanticSearchEnabled(ctx) {
+		if err := ts.addSemanticSuggestions(ctx, req.Title+"\n"+content, suggestions); err != nil {
+			log.Errorf("suggest tags by similar questions failed: %v", err)
+		}
+	}
+
+	list := make([]*tagSuggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		if s.score >= tagSuggestionMinScore {
+			list = append(list, s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].score != list[j].score {
+			return list[i].score > list[j].score
+		}
+		return list[i].tag.SlugName < list[j].tag.SlugName
+	})
+	resp = make([]*schema.TagSuggestionResp, 0, limit)
+	for _, s := range list {
+		if len(resp) >= limit {
+			break
+		}
+		s.tag.Score = math.Round(s.score*100) / 100
+		resp = append(resp, s.tag)
+	}
+	return resp, nil
+}
+
+// matchedTag the tag which is matched by the name, the name is the synonym if the tag is its main tag
+type matchedTag struct {
+	matchedName string
+	tag         *entity.Tag
+}
+
+// replaceSynonyms replace the synonyms with their main tags
+func (ts *TagSuggestService) replaceSynonyms(ctx context.Context, tags []*entity.Tag) (
+	matched []*matchedTag, err error) {
+	mainTagIDs := make([]string, 0)
+	for _, t := range tags {
+		if t.MainTagID != 0 {
+			mainTagIDs = append(mainTagIDs, strconv.FormatInt(t.MainTagID, 10))
+		}
+	}
+	mainTags := make(map[string]*entity.Tag)
+	if len(mainTagIDs) > 0 {
+		list, err := ts.tagCommonService.GetTagListByIDs(ctx, mainTagIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range list {
+			mainTags[t.ID] = t
+		}
+	}
+	for _, t := range tags {
+		if t.MainTagID == 0 {
+			matched = append(matched, &matchedTag{matchedName: t.SlugName, tag: t})
+		} else if mainTag, ok := mainTags[strconv.FormatInt(t.MainTagID, 10)]; ok {
+			matched = append(matched, &matchedTag{matchedName: t.SlugName, tag: mainTag})
+		}
+	}
+	return matched, nil
+}
+
+// addSemanticSuggestions suggest the tags of the similar questions, the score of the tag is the share of
+// the similarity of the questions with the tag
+func (ts *TagSuggestService) addSemanticSuggestions(ctx context.Context, text string,
+	suggestions map[string]*tagSuggestion) (err error) {
+	matches, err := ts.embeddingService.SemanticSearch(ctx, text,
+		[]string{constant.QuestionObjectType}, tagSuggestionSimilarQuestions)
+	if err != nil || len(matches) == 0 {
+		return err
+	}
+	questionIDs := make([]string, 0, len(matches))
+	var total float64
+	for _, match := range matches {
+		questionIDs = append(questionIDs, match.ObjectID)
+		total += match.Score
+	}
+	questionTags, err := ts.tagCommonService.BatchGetObjectTag(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	tagScores := make(map[string]float64)
+	tags := make(map[string]*schema.TagResp)
+	for _, match := range matches {
+		for _, t := range questionTags[match.ObjectID] {
+			tagScores[t.SlugName] += match.Score / total
+			tags[t.SlugName] = t
+		}
+	}
+	for slugName, score := range tagScores {
+		t := tags[slugName]
+		addTagSuggestion(suggestions, &entity.Tag{
+			SlugName:    t.SlugName,
+			DisplayName: t.DisplayName,
+			Recommend:   t.Recommend,
+			Reserved:    t.Reserved,
+		}, score)
+	}
+	return nil
+}
+
+// addTagSuggestion add the tag or combine the scores if it is already suggested, so the tag suggested by
+// both the keyword and the similar questions is more confident
+func addTagSuggestion(suggestions map[string]*tagSuggestion, t *entity.Tag, score float64) {
+	if s, ok := suggestions[t.SlugName]; ok {
+		s.score = 1 - (1-s.score)*(1-score)
+		return
+	}
+	suggestions[t.SlugName] = &tagSuggestion{
+		tag: &schema.TagSuggestionResp{
+			SlugName:    t.SlugName,
+			DisplayName: t.DisplayName,
+			Recommend:   t.Recommend,
+			Reserved:    t.Reserved,
+		},
+		score: score,
+	}
+}
+
+// keywordTagScore the tag in the title is more confident than the tag in the content
+func keywordTagScore(titleCount, contentCount int) float64 {
+	score := 0.15 * float64(contentCount)
+	if titleCount > 0 {
+		score += 0.6
+	}
+	return math.Min(score, 0.95)
+}
+
+// countTagCandidates count the words and the phrases joined by "-" which may be the tag names
+func countTagCandidates(text string) map[string]int {
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#' && r != '.' && r != '-'
+	})
+	for i := range words {
+		words[i] = strings.Trim(words[i], ".-")
+	}
+	for i := range words {
+		for n := 1; n <= tagSuggestionMaxNgram && i+n <= len(words); n++ {
+			if len(words[i+n-1]) == 0 {
+				break
+			}
+			name := strings.Join(words[i:i+n], "-")
+			if len(name) > tagSlugNameMaxLen {
+				break
+			}
+			counts[name]++
+		}
+	}
+	return counts
+}
+
+// topTagCandidates get the most frequent candidates, the ones in the title first
+func topTagCandidates(titleCounts, contentCounts map[string]int, limit int) []string {
+	scores := make(map[string]int, len(titleCounts)+len(contentCounts))
+	for name, count := range contentCounts {
+		scores[name] += count
+	}
+	for name, count := range titleCounts {
+		scores[name] += count * 100
+	}
+	candidates := make([]string, 0, len(scores))
+	for name := range scores {
+		candidates = append(candidates, name)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if scores[candidates[i]] != scores[candidates[j]] {
+			return scores[candidates[i]] > scores[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
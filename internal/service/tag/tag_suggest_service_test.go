@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountTagCandidates(t *testing.T) {
+	counts := countTagCandidates("How to use Ruby on Rails with C++ and Node.js? Rails.")
+	assert.Equal(t, 2, counts["rails"])
+	assert.Equal(t, 1, counts["ruby-on-rails"])
+	assert.Equal(t, 1, counts["c++"])
+	assert.Equal(t, 1, counts["node.js"])
+	assert.Equal(t, 1, counts["how-to-use"])
+	assert.Zero(t, counts["how-to-use-ruby"])
+}
+
+func TestTopTagCandidates(t *testing.T) {
+	candidates := topTagCandidates(map[string]int{"go": 1}, map[string]int{"java": 5, "go": 1, "rust": 2}, 2)
+	assert.Equal(t, []string{"go", "java"}, candidates)
+}
+
+func TestKeywordTagScore(t *testing.T) {
+	assert.Zero(t, keywordTagScore(0, 0))
+	assert.InDelta(t, 0.6, keywordTagScore(1, 0), 1e-9)
+	assert.InDelta(t, 0.3, keywordTagScore(0, 2), 1e-9)
+	assert.InDelta(t, 0.95, keywordTagScore(2, 10), 1e-9)
+}
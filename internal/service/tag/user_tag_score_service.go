@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package tag
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	tagcommonser "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+)
+
+const (
+	userTagScoreBatchSize = 1000
+	// userTagScoreHalfLifeDays the weight of the answer halves every 180 days
+	userTagScoreHalfLifeDays    = 180
+	userTagScoreAcceptedBonus   = 5
+	tagTopAnswerersDefaultLimit = 10
+)
+
+// UserTagScoreRepo user tag score repository
+type UserTagScoreRepo interface {
+	GetAnswersAfterID(ctx context.Context, lastID string, limit int) (answers []*entity.Answer, err error)
+	GetQuestionTagIDs(ctx context.Context, questionIDs []string) (tagIDsMapping map[string][]string, err error)
+	ReplaceUserTagScores(ctx context.Context, scores []*entity.UserTagScore) (err error)
+	GetTagTopScores(ctx context.Context, tagID string, limit int) (scores []*entity.UserTagScore, err error)
+}
+
+// UserTagScoreService user tag expertise score service
+type UserTagScoreService struct {
+	userTagScoreRepo UserTagScoreRepo
+	tagCommonService *tagcommonser.TagCommonService
+	userCommon       *usercommon.UserCommon
+}
+
+// NewUserTagScoreService new user tag score service
+func NewUserTagScoreService(
+	userTagScoreRepo UserTagScoreRepo,
+	tagCommonService *tagcommonser.TagCommonService,
+	userCommon *usercommon.UserCommon,
+) *UserTagScoreService {
+	return &UserTagScoreService{
+		userTagScoreRepo: userTagScoreRepo,
+		tagCommonService: tagCommonService,
+		userCommon:       userCommon,
+	}
+}
+
+// GetTagTopAnswerers get the answerers with the highest expertise scores in the tag
+func (us *UserTagScoreService) GetTagTopAnswerers(ctx context.Context, req *schema.GetTagTopAnswerersReq) (
+	resp []*schema.TagTopAnswerer, err error) {
+	if req.Limit == 0 {
+		req.Limit = tagTopAnswerersDefaultLimit
+	}
+	tagInfo, exist, err := us.tagCommonService.GetTagBySlugName(ctx, req.SlugName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.TagNotFound)
+	}
+
+	scores, err := us.userTagScoreRepo.GetTagTopScores(ctx, tagInfo.ID, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(scores))
+	for _, score := range scores {
+		userIDs = append(userIDs, score.UserID)
+	}
+	userInfoMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	resp = make([]*schema.TagTopAnswerer, 0, len(scores))
+	for _, score := range scores {
+		userInfo, ok := userInfoMapping[score.UserID]
+		if !ok {
+			continue
+		}
+		resp = append(resp, &schema.TagTopAnswerer{
+			UserInfo:      userInfo,
+			Score:         score.Score,
+			AnswerCount:   score.AnswerCount,
+			AcceptedCount: score.AcceptedCount,
+			VoteCount:     score.VoteCount,
+		})
+	}
+	return resp, nil
+}
+
+// RefreshScoresCron recompute the expertise scores of all the users in all the tags
+func (us *UserTagScoreService) RefreshScoresCron(ctx context.Context) (err error) {
+	return us.refreshScores(ctx, time.Now())
+}
+
+func (us *UserTagScoreService) refreshScores(ctx context.Context, now time.Time) (err error) {
+	scoreMapping := make(map[string]*entity.UserTagScore)
+	scores := make([]*entity.UserTagScore, 0)
+	lastID := "0"
+	for {
+		answers, err := us.userTagScoreRepo.GetAnswersAfterID(ctx, lastID, userTagScoreBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(answers) == 0 {
+			break
+		}
+		lastID = answers[len(answers)-1].ID
+
+		questionIDs := make([]string, 0, len(answers))
+		for _, answer := range answers {
+			questionIDs = append(questionIDs, answer.QuestionID)
+		}
+		tagIDsMapping, err := us.userTagScoreRepo.GetQuestionTagIDs(ctx, questionIDs)
+		if err != nil {
+			return err
+		}
+		for _, answer := range answers {
+			for _, tagID := range tagIDsMapping[answer.QuestionID] {
+				key := answer.UserID + ":" + tagID
+				score, ok := scoreMapping[key]
+				if !ok {
+					score = &entity.UserTagScore{UserID: answer.UserID, TagID: tagID}
+					scoreMapping[key] = score
+					scores = append(scores, score)
+				}
+				addAnswerToUserTagScore(score, answer, now)
+			}
+		}
+		if len(answers) < userTagScoreBatchSize {
+			break
+		}
+	}
+
+	result := make([]*entity.UserTagScore, 0, len(scores))
+	for _, score := range scores {
+		if score.Score <= 0 {
+			continue
+		}
+		score.Score = math.Round(score.Score*100) / 100
+		result = append(result, score)
+	}
+	return us.userTagScoreRepo.ReplaceUserTagScores(ctx, result)
+}
+
+// addAnswerToUserTagScore each answer scores 1 plus its votes, the accepted one scores 5 more,
+// and the older the answer is, the less it weighs.
+func addAnswerToUserTagScore(score *entity.UserTagScore, answer *entity.Answer, now time.Time) {
+	points := 1 + answer.VoteCount
+	score.AnswerCount++
+	score.VoteCount += answer.VoteCount
+	if answer.Accepted == schema.AnswerAcceptedEnable {
+		points += userTagScoreAcceptedBonus
+		score.AcceptedCount++
+	}
+	score.Score += float64(points) * userTagScoreDecay(now.Sub(answer.CreatedAt))
+}
+
+// userTagScoreDecay the weight of the answer with the age
+func userTagScoreDecay(age time.Duration) float64 {
+	if age <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, age.Hours()/24/userTagScoreHalfLifeDays)
+}
@@ -0,0 +1,87 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+package tag
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUserTagScoreRepo struct {
+	UserTagScoreRepo
+	answers []*entity.Answer
+	tagIDs  map[string][]string
+	scores  []*entity.UserTagScore
+}
+
+func (r *testUserTagScoreRepo) GetAnswersAfterID(_ context.Context, lastID string, limit int) (
+	[]*entity.Answer, error) {
+	answers := make([]*entity.Answer, 0)
+	for _, answer := range r.answers {
+		if answer.ID > lastID && len(answers) < limit {
+			answers = append(answers, answer)
+		}
+	}
+	return answers, nil
+}
+
+func (r *testUserTagScoreRepo) GetQuestionTagIDs(_ context.Context, _ []string) (map[string][]string, error) {
+	return r.tagIDs, nil
+}
+
+func (r *testUserTagScoreRepo) ReplaceUserTagScores(_ context.Context, scores []*entity.UserTagScore) error {
+	r.scores = scores
+	return nil
+}
+
+func TestRefreshUserTagScores(t *testing.T) {
+	now := time.Date(2024, time.August, 15, 0, 0, 0, 0, time.UTC)
+	repo := &testUserTagScoreRepo{
+		answers: []*entity.Answer{
+			{ID: "1", QuestionID: "10", UserID: "1", VoteCount: 2, CreatedAt: now},
+			{ID: "2", QuestionID: "10", UserID: "2", VoteCount: 1, CreatedAt: now,
+				Accepted: schema.AnswerAcceptedEnable},
+			{ID: "3", QuestionID: "11", UserID: "1", VoteCount: 3,
+				CreatedAt: now.AddDate(0, 0, -userTagScoreHalfLifeDays)},
+			{ID: "4", QuestionID: "11", UserID: "3", VoteCount: -2, CreatedAt: now},
+		},
+		tagIDs: map[string][]string{"10": {"100", "101"}, "11": {"100"}},
+	}
+	us := NewUserTagScoreService(repo, nil, nil)
+	assert.NoError(t, us.refreshScores(context.TODO(), now))
+
+	scores := make(map[string]*entity.UserTagScore)
+	for _, score := range repo.scores {
+		scores[score.UserID+":"+score.TagID] = score
+	}
+	assert.Len(t, scores, 4)
+	// 3 points of the new answer and half of 4 points of the old one
+	assert.Equal(t, 5.0, scores["1:100"].Score)
+	assert.Equal(t, 2, scores["1:100"].AnswerCount)
+	assert.Equal(t, 3.0, scores["1:101"].Score)
+	assert.Equal(t, 7.0, scores["2:100"].Score)
+	assert.Equal(t, 1, scores["2:101"].AcceptedCount)
+	// the answer with negative votes does not make the user an expert
+	assert.Nil(t, scores["3:100"])
+}
@@ -0,0 +1,318 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_auto_subscription
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/answer_verification"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
+	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/notice_queue"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	tagcommon "github.com/apache/incubator-answer/internal/service/tag_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/log"
+)
+
+// TagAutoSubscriptionRepo tag auto subscription repository
+type TagAutoSubscriptionRepo interface {
+	AddDigestItems(ctx context.Context, items []*entity.TagSubscriptionDigest) (err error)
+	GetDigestUserIDs(ctx context.Context) (userIDs []string, err error)
+	GetDigestItems(ctx context.Context, userID string) (items []*entity.TagSubscriptionDigest, err error)
+	RemoveDigestItems(ctx context.Context, ids []int) (err error)
+}
+
+// TagAutoSubscriptionService the groups configured by the admin are subscribed to the new questions in the tags
+// automatically, the members are notified at once or in the daily digest
+type TagAutoSubscriptionService struct {
+	tagAutoSubscriptionRepo   TagAutoSubscriptionRepo
+	siteInfoRepo              siteinfo_common.SiteInfoRepo
+	siteInfoService           siteinfo_common.SiteInfoCommonService
+	questionRepo              questioncommon.QuestionRepo
+	tagCommon                 *tagcommon.TagCommonService
+	userCommon                *usercommon.UserCommon
+	userRepo                  usercommon.UserRepo
+	userRoleRelService        *role.UserRoleRelService
+	answerVerificationService *answer_verification.AnswerVerificationService
+	notificationQueueService  notice_queue.NotificationQueueService
+	emailService              *export.EmailService
+}
+
+// NewTagAutoSubscriptionService new tag auto subscription service
+func NewTagAutoSubscriptionService(
+	tagAutoSubscriptionRepo TagAutoSubscriptionRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+	questionRepo questioncommon.QuestionRepo,
+	tagCommon *tagcommon.TagCommonService,
+	userCommon *usercommon.UserCommon,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	answerVerificationService *answer_verification.AnswerVerificationService,
+	notificationQueueService notice_queue.NotificationQueueService,
+	emailService *export.EmailService,
+	eventQueueService event_queue.EventQueueService,
+) *TagAutoSubscriptionService {
+	ts := &TagAutoSubscriptionService{
+		tagAutoSubscriptionRepo:   tagAutoSubscriptionRepo,
+		siteInfoRepo:              siteInfoRepo,
+		siteInfoService:           siteInfoService,
+		questionRepo:              questionRepo,
+		tagCommon:                 tagCommon,
+		userCommon:                userCommon,
+		userRepo:                  userRepo,
+		userRoleRelService:        userRoleRelService,
+		answerVerificationService: answerVerificationService,
+		notificationQueueService:  notificationQueueService,
+		emailService:              emailService,
+	}
+	eventQueueService.RegisterHandler(ts.HandleEvent)
+	return ts
+}
+
+// GetTagAutoSubscriptions get the tag auto subscription groups
+func (ts *TagAutoSubscriptionService) GetTagAutoSubscriptions(ctx context.Context) (
+	resp *schema.SiteTagAutoSubscriptionsReq, err error) {
+	resp = &schema.SiteTagAutoSubscriptionsReq{}
+	if err = ts.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeTagAutoSubscriptions, resp); err != nil {
+		return nil, err
+	}
+	if resp.Groups == nil {
+		resp.Groups = make([]*schema.TagAutoSubscriptionGroup, 0)
+	}
+	return resp, nil
+}
+
+// UpdateTagAutoSubscriptions update the tag auto subscription groups
+func (ts *TagAutoSubscriptionService) UpdateTagAutoSubscriptions(ctx context.Context,
+	req *schema.SiteTagAutoSubscriptionsReq) (err error) {
+	for _, group := range req.Groups {
+		group.Name = strings.TrimSpace(group.Name)
+		for i, tag := range group.Tags {
+			group.Tags[i] = strings.ToLower(strings.TrimSpace(tag))
+		}
+	}
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeTagAutoSubscriptions,
+		Content: string(content),
+	}
+	return ts.siteInfoRepo.SaveByType(ctx, constant.SiteTypeTagAutoSubscriptions, data)
+}
+
+// HandleEvent notify the members of the groups subscribed to the tags of the new question
+func (ts *TagAutoSubscriptionService) HandleEvent(ctx context.Context, msg *schema.EventMsg) error {
+	if msg.EventType != constant.EventQuestionCreate {
+		return nil
+	}
+	setting, err := ts.GetTagAutoSubscriptions(ctx)
+	if err != nil || len(setting.Groups) == 0 {
+		return err
+	}
+	question, exist, err := ts.questionRepo.GetQuestion(ctx, msg.ObjectID)
+	if err != nil || !exist {
+		return err
+	}
+	// the pending questions are not visible to the members
+	if question.Status != entity.QuestionStatusAvailable {
+		return nil
+	}
+	question.ID = uid.DeShortID(question.ID)
+	tags, err := ts.tagCommon.GetObjectEntityTag(ctx, question.ID)
+	if err != nil {
+		return err
+	}
+
+	// the immediate delivery is preferred if the user is the member of several groups
+	deliveries := make(map[string]string)
+	groupNames := make(map[string]string)
+	for _, group := range setting.Groups {
+		if !matchTags(group, tags) {
+			continue
+		}
+		for _, userID := range ts.getMemberIDs(ctx, group, tags) {
+			if userID == question.UserID || deliveries[userID] == schema.TagSubscriptionDeliveryImmediate {
+				continue
+			}
+			deliveries[userID] = group.Delivery
+			groupNames[userID] = group.Name
+		}
+	}
+
+	digestItems := make([]*entity.TagSubscriptionDigest, 0)
+	for userID, delivery := range deliveries {
+		if delivery == schema.TagSubscriptionDeliveryDigest {
+			digestItems = append(digestItems, &entity.TagSubscriptionDigest{
+				UserID:     userID,
+				QuestionID: question.ID,
+				GroupName:  groupNames[userID],
+			})
+			continue
+		}
+		ts.notificationQueueService.Send(ctx, &schema.NotificationMsg{
+			TriggerUserID:      question.UserID,
+			ReceiverUserID:     userID,
+			Type:               schema.NotificationTypeInbox,
+			ObjectID:           question.ID,
+			ObjectType:         constant.QuestionObjectType,
+			NotificationAction: constant.NotificationTagAutoSubscribedQuestion,
+		})
+		ts.sendDigestEmail(ctx, userID, []*schema.TagSubscriptionDigestQuestion{
+			{QuestionID: question.ID, QuestionTitle: question.Title, Tags: joinTagNames(tags)},
+		})
+	}
+	if len(digestItems) > 0 {
+		return ts.tagAutoSubscriptionRepo.AddDigestItems(ctx, digestItems)
+	}
+	return nil
+}
+
+// DigestCron send the digest email of the new questions to the members of the digest groups
+func (ts *TagAutoSubscriptionService) DigestCron(ctx context.Context) (err error) {
+	userIDs, err := ts.tagAutoSubscriptionRepo.GetDigestUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+	var failed error
+	for _, userID := range userIDs {
+		items, err := ts.tagAutoSubscriptionRepo.GetDigestItems(ctx, userID)
+		if err != nil {
+			log.Errorf("get tag subscription digest of user %s failed: %v", userID, err)
+			failed = err
+			continue
+		}
+		ids := make([]int, 0, len(items))
+		questions := make([]*schema.TagSubscriptionDigestQuestion, 0, len(items))
+		sent := make(map[string]bool)
+		for _, item := range items {
+			ids = append(ids, item.ID)
+			if sent[item.QuestionID] {
+				continue
+			}
+			sent[item.QuestionID] = true
+			question, exist, err := ts.questionRepo.GetQuestion(ctx, item.QuestionID)
+			if err != nil || !exist || question.Status != entity.QuestionStatusAvailable {
+				continue
+			}
+			tags, err := ts.tagCommon.GetObjectEntityTag(ctx, item.QuestionID)
+			if err != nil {
+				log.Error(err)
+			}
+			questions = append(questions, &schema.TagSubscriptionDigestQuestion{
+				QuestionID:    item.QuestionID,
+				QuestionTitle: question.Title,
+				Tags:          joinTagNames(tags),
+			})
+		}
+		if len(questions) > 0 {
+			ts.sendDigestEmail(ctx, userID, questions)
+		}
+		if err = ts.tagAutoSubscriptionRepo.RemoveDigestItems(ctx, ids); err != nil {
+			log.Errorf("remove tag subscription digest of user %s failed: %v", userID, err)
+			failed = err
+		}
+	}
+	return failed
+}
+
+// getMemberIDs get the ids of the members of the group, the experts are the experts of the tags of the question
+func (ts *TagAutoSubscriptionService) getMemberIDs(ctx context.Context, group *schema.TagAutoSubscriptionGroup,
+	tags []*entity.Tag) (userIDs []string) {
+	if len(group.Usernames) > 0 {
+		userMapping, err := ts.userCommon.BatchGetUserBasicInfoByUserNames(ctx, group.Usernames)
+		if err != nil {
+			log.Errorf("get members of group %s failed: %v", group.Name, err)
+		}
+		for _, userInfo := range userMapping {
+			userIDs = append(userIDs, userInfo.ID)
+		}
+	}
+	if len(group.RoleIDs) > 0 {
+		rels, err := ts.userRoleRelService.GetUserByRoleID(ctx, group.RoleIDs)
+		if err != nil {
+			log.Errorf("get members of group %s failed: %v", group.Name, err)
+		}
+		for _, rel := range rels {
+			userIDs = append(userIDs, rel.UserID)
+		}
+	}
+	if group.IncludeTagExperts {
+		tagIDs := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			tagIDs = append(tagIDs, tag.ID)
+		}
+		expertIDs, err := ts.answerVerificationService.GetTagExpertUserIDs(ctx, tagIDs)
+		if err != nil {
+			log.Errorf("get experts of group %s failed: %v", group.Name, err)
+		}
+		userIDs = append(userIDs, expertIDs...)
+	}
+	return userIDs
+}
+
+// sendDigestEmail send the new questions to the user if the email of the user is available
+func (ts *TagAutoSubscriptionService) sendDigestEmail(ctx context.Context, userID string,
+	questions []*schema.TagSubscriptionDigestQuestion) {
+	userInfo, exist, err := ts.userRepo.GetByUserID(ctx, userID)
+	if err != nil || !exist {
+		log.Errorf("get user %s failed: %v", userID, err)
+		return
+	}
+	if userInfo.Status != entity.UserStatusAvailable || userInfo.MailStatus != entity.EmailStatusAvailable {
+		return
+	}
+	ctx = usercommon.WithUserLocale(ctx, userInfo)
+	title, body, err := ts.emailService.TagSubscriptionDigestTemplate(ctx, questions)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	ts.emailService.Send(ctx, userInfo.EMail, title, body)
+}
+
+// matchTags whether the question has any tag of the group, the synonyms match their main tags
+func matchTags(group *schema.TagAutoSubscriptionGroup, tags []*entity.Tag) bool {
+	for _, tag := range tags {
+		for _, slugName := range group.Tags {
+			if tag.SlugName == slugName || (len(tag.MainTagSlugName) > 0 && tag.MainTagSlugName == slugName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// joinTagNames the display names of the tags
+func joinTagNames(tags []*entity.Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.DisplayName)
+	}
+	return strings.Join(names, ", ")
+}
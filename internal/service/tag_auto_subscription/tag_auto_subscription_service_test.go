@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tag_auto_subscription
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTags(t *testing.T) {
+	group := &schema.TagAutoSubscriptionGroup{Name: "backend", Tags: []string{"golang", "mysql"}}
+
+	assert.True(t, matchTags(group, []*entity.Tag{{SlugName: "docker"}, {SlugName: "golang"}}))
+	assert.True(t, matchTags(group, []*entity.Tag{{SlugName: "go", MainTagSlugName: "golang"}}))
+	assert.False(t, matchTags(group, []*entity.Tag{{SlugName: "python"}}))
+	assert.False(t, matchTags(group, nil))
+}
+
+func TestJoinTagNames(t *testing.T) {
+	assert.Equal(t, "Go, MySQL", joinTagNames([]*entity.Tag{{DisplayName: "Go"}, {DisplayName: "MySQL"}}))
+	assert.Equal(t, "", joinTagNames(nil))
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity_queue"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/revision_common"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	"github.com/apache/incubator-answer/pkg/converter"
@@ -51,6 +52,7 @@ type TagCommonRepo interface {
 	GetReservedTagList(ctx context.Context) (tagList []*entity.Tag, err error)
 	UpdateTagsAttribute(ctx context.Context, tags []string, attribute string, value bool) (err error)
 	UpdateTagQuestionCount(ctx context.Context, tagID string, questionCount int) (err error)
+	RefreshTagSummary(ctx context.Context) (err error)
 }
 
 type TagRepo interface {
@@ -86,6 +88,7 @@ type TagCommonService struct {
 	tagRepo              TagRepo
 	siteInfoService      siteinfo_common.SiteInfoCommonService
 	activityQueueService activity_queue.ActivityQueueService
+	eventQueueService    event_queue.EventQueueService
 }
 
 // NewTagCommonService new tag service
@@ -96,6 +99,7 @@ func NewTagCommonService(
 	revisionService *revision_common.RevisionService,
 	siteInfoService siteinfo_common.SiteInfoCommonService,
 	activityQueueService activity_queue.ActivityQueueService,
+	eventQueueService event_queue.EventQueueService,
 ) *TagCommonService {
 	return &TagCommonService{
 		tagCommonRepo:        tagCommonRepo,
@@ -104,6 +108,7 @@ func NewTagCommonService(
 		revisionService:      revisionService,
 		siteInfoService:      siteInfoService,
 		activityQueueService: activityQueueService,
+		eventQueueService:    eventQueueService,
 	}
 }
 
@@ -358,12 +363,27 @@ func (ts *TagCommonService) AddTag(ctx context.Context, req *schema.AddTagReq) (
 	if err != nil {
 		return nil, err
 	}
+	ts.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventTagCreate,
+		UserID:    req.UserID,
+		ObjectID:  tagInfo.ID,
+	})
 	return &schema.AddTagResp{SlugName: tagInfo.SlugName}, nil
 }
 
 // AddTagList get object tag
 func (ts *TagCommonService) AddTagList(ctx context.Context, tagList []*entity.Tag) (err error) {
-	return ts.tagCommonRepo.AddTagList(ctx, tagList)
+	if err = ts.tagCommonRepo.AddTagList(ctx, tagList); err != nil {
+		return err
+	}
+	for _, tag := range tagList {
+		ts.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType: constant.EventTagCreate,
+			UserID:    tag.UserID,
+			ObjectID:  tag.ID,
+		})
+	}
+	return nil
 }
 
 // GetTagByID get object tag
@@ -702,6 +722,11 @@ func (ts *TagCommonService) ObjectChangeTag(ctx context.Context, objectTagData *
 				ActivityTypeKey:  constant.ActTagCreated,
 				RevisionID:       revisionID,
 			})
+			ts.eventQueueService.Send(ctx, &schema.EventMsg{
+				EventType: constant.EventTagCreate,
+				UserID:    objectTagData.UserID,
+				ObjectID:  tag.ID,
+			})
 		}
 	}
 
@@ -912,6 +937,11 @@ func (ts *TagCommonService) UpdateTag(ctx context.Context, req *schema.UpdateTag
 			ActivityTypeKey:  constant.ActTagEdited,
 			RevisionID:       revisionID,
 		})
+		ts.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType: constant.EventTagUpdate,
+			UserID:    req.UserID,
+			ObjectID:  tagInfo.ID,
+		})
 	}
 
 	return
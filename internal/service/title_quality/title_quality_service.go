@@ -0,0 +1,264 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package title_quality
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+)
+
+const (
+	// titleAllCapsMinLetters the short title in capital letters is likely to be an acronym, e.g. AWS SDK
+	titleAllCapsMinLetters = 8
+	// titleDuplicateCandidates the number of the questions whose title contains the title
+	titleDuplicateCandidates = 10
+)
+
+var (
+	repeatedPunctuation = regexp.MustCompile(`([!?！？])[!?！？]+`)
+	multipleSpaces      = regexp.MustCompile(`\s+`)
+)
+
+// titleWarning the problem found in the title and the data of its message
+type titleWarning struct {
+	code string
+	key  string
+	data map[string]any
+}
+
+// TitleQualityService check the quality of the question title before posting
+type TitleQualityService struct {
+	questionRepo    questioncommon.QuestionRepo
+	siteInfoService siteinfo_common.SiteInfoCommonService
+}
+
+// NewTitleQualityService new title quality service
+func NewTitleQualityService(
+	questionRepo questioncommon.QuestionRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *TitleQualityService {
+	return &TitleQualityService{
+		questionRepo:    questionRepo,
+		siteInfoService: siteInfoService,
+	}
+}
+
+// CheckQuestionTitle check the title by the rules of the site, the warnings do not block the posting
+func (ts *TitleQualityService) CheckQuestionTitle(ctx context.Context, req *schema.CheckQuestionTitleReq) (
+	resp *schema.CheckQuestionTitleResp, err error) {
+	resp = &schema.CheckQuestionTitleResp{Warnings: make([]*schema.TitleQualityWarning, 0)}
+	siteWrite, err := ts.siteInfoService.GetSiteWrite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !siteWrite.TitleQualityCheck {
+		return resp, nil
+	}
+	minLength := siteWrite.TitleMinLength
+	if minLength == 0 {
+		minLength = schema.DefaultTitleMinLength
+	}
+	phrases := siteWrite.TitleBannedPhrases
+	if len(phrases) == 0 {
+		phrases = schema.DefaultTitleBannedPhrases
+	}
+
+	lang := handler.GetLangByCtx(ctx)
+	title := strings.TrimSpace(req.Title)
+	for _, w := range checkTitle(title, minLength, phrases) {
+		resp.Warnings = append(resp.Warnings, &schema.TitleQualityWarning{
+			Code:    w.code,
+			Message: translator.TrWithData(lang, w.key, w.data),
+		})
+	}
+
+	duplicate, err := ts.findDuplicate(ctx, title, req.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	if duplicate != nil {
+		resp.Warnings = append(resp.Warnings, &schema.TitleQualityWarning{
+			Code:    schema.TitleQualityCodeDuplicate,
+			Message: translator.Tr(lang, reason.TitleQualityDuplicate),
+			Question: &schema.QuestionBaseInfo{
+				ID:             duplicate.ID,
+				Title:          duplicate.Title,
+				UrlTitle:       htmltext.UrlTitle(duplicate.Title),
+				ViewCount:      duplicate.ViewCount,
+				AnswerCount:    duplicate.AnswerCount,
+				Status:         entity.AdminQuestionSearchStatusIntToString[duplicate.Status],
+				AcceptedAnswer: duplicate.AcceptedAnswerID != "0",
+			},
+		})
+	}
+
+	if suggested := rewriteTitle(title, phrases); suggested != title {
+		resp.SuggestedTitle = suggested
+	}
+	return resp, nil
+}
+
+// findDuplicate find the existing question whose title is the same as the title after normalization
+func (ts *TitleQualityService) findDuplicate(ctx context.Context, title, questionID string) (
+	duplicate *entity.Question, err error) {
+	questions, err := ts.questionRepo.GetQuestionsByTitle(ctx, title, titleDuplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+	normalized := normalizeTitle(title)
+	for _, question := range questions {
+		if len(questionID) > 0 && uid.DeShortID(question.ID) == uid.DeShortID(questionID) {
+			continue
+		}
+		if normalizeTitle(question.Title) == normalized {
+			return question, nil
+		}
+	}
+	return nil, nil
+}
+
+// checkTitle check the title by the rules which do not need the database
+func checkTitle(title string, minLength int, phrases []string) (warnings []*titleWarning) {
+	if utf8.RuneCountInString(title) < minLength {
+		warnings = append(warnings, &titleWarning{
+			code: schema.TitleQualityCodeTooShort,
+			key:  reason.TitleQualityTooShort,
+			data: map[string]any{"MinLength": minLength},
+		})
+	}
+	if isAllCaps(title) {
+		warnings = append(warnings, &titleWarning{
+			code: schema.TitleQualityCodeAllCaps,
+			key:  reason.TitleQualityAllCaps,
+		})
+	}
+	lower := strings.ToLower(title)
+	for _, phrase := range phrases {
+		if containsPhrase(lower, strings.ToLower(phrase)) {
+			warnings = append(warnings, &titleWarning{
+				code: schema.TitleQualityCodeBannedPhrase,
+				key:  reason.TitleQualityBannedPhrase,
+				data: map[string]any{"Phrase": phrase},
+			})
+			break
+		}
+	}
+	if repeatedPunctuation.MatchString(title) {
+		warnings = append(warnings, &titleWarning{
+			code: schema.TitleQualityCodeExcessivePunctuation,
+			key:  reason.TitleQualityExcessivePunctuation,
+		})
+	}
+	return warnings
+}
+
+// isAllCaps check whether all the letters of the title are in upper case
+func isAllCaps(title string) bool {
+	letters := 0
+	for _, r := range title {
+		if unicode.IsLower(r) {
+			return false
+		}
+		if unicode.IsUpper(r) {
+			letters++
+		}
+	}
+	return letters >= titleAllCapsMinLetters
+}
+
+// containsPhrase check whether the phrase is in the text as whole words
+func containsPhrase(text, phrase string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for start := 0; ; {
+		i := strings.Index(text[start:], phrase)
+		if i < 0 {
+			return false
+		}
+		i += start
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[i+len(phrase):])
+		if !isLetterOrDigit(before) && !isLetterOrDigit(after) {
+			return true
+		}
+		start = i + 1
+	}
+}
+
+func isLetterOrDigit(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// rewriteTitle fix the problems which can be fixed automatically: remove the banned phrases, collapse the
+// repeated punctuation marks and convert the title in capital letters into the sentence case
+func rewriteTitle(title string, phrases []string) string {
+	rewritten := title
+	for _, phrase := range phrases {
+		rewritten = removePhrase(rewritten, phrase)
+	}
+	rewritten = repeatedPunctuation.ReplaceAllString(rewritten, "$1")
+	if isAllCaps(rewritten) {
+		rewritten = strings.ToLower(rewritten)
+	}
+	rewritten = strings.TrimLeft(rewritten, " ,.:;-!")
+	rewritten = strings.TrimRight(multipleSpaces.ReplaceAllString(rewritten, " "), " ,:;-")
+	if len(rewritten) == 0 {
+		return title
+	}
+	r, size := utf8.DecodeRuneInString(rewritten)
+	return string(unicode.ToUpper(r)) + rewritten[size:]
+}
+
+// removePhrase remove the phrase as whole words and the punctuation marks after it, case-insensitively
+func removePhrase(title, phrase string) string {
+	if len(phrase) == 0 {
+		return title
+	}
+	re, err := regexp.Compile(`(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(phrase) + `[\s,.:;!]*($|[^\pL\pN])`)
+	if err != nil {
+		return title
+	}
+	return re.ReplaceAllString(title, "$1$2")
+}
+
+// normalizeTitle the titles which differ only in case, spaces or punctuation marks are the same
+func normalizeTitle(title string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if isLetterOrDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package title_quality
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTitle(t *testing.T) {
+	codes := func(warnings []*titleWarning) (list []string) {
+		for _, w := range warnings {
+			list = append(list, w.code)
+		}
+		return list
+	}
+	phrases := schema.DefaultTitleBannedPhrases
+
+	assert.Empty(t, checkTitle("How to read a file line by line in Go?", 15, phrases))
+	assert.Equal(t, []string{schema.TitleQualityCodeTooShort}, codes(checkTitle("Go file?", 15, phrases)))
+	assert.Equal(t, []string{schema.TitleQualityCodeAllCaps, schema.TitleQualityCodeExcessivePunctuation},
+		codes(checkTitle("HOW TO READ A FILE IN GO???", 15, phrases)))
+	assert.Equal(t, []string{schema.TitleQualityCodeBannedPhrase},
+		codes(checkTitle("Please help, how to read a file in Go", 15, phrases)))
+	// the phrase must be whole words
+	assert.Empty(t, checkTitle("How to detect urgentness of the tasks", 15, phrases))
+	// the acronym is not regarded as shouting
+	assert.Empty(t, checkTitle("Configure the AWS SDK", 15, phrases))
+}
+
+func TestRewriteTitle(t *testing.T) {
+	phrases := schema.DefaultTitleBannedPhrases
+	assert.Equal(t, "How to read a file in Go?", rewriteTitle("Please help, how to read a file in Go???", phrases))
+	assert.Equal(t, "How to read a file in go?", rewriteTitle("HOW TO READ A FILE IN GO?", phrases))
+	assert.Equal(t, "Read a file in Go", rewriteTitle("Read a file in Go - URGENT!!!", phrases))
+	assert.Equal(t, "Read a file in Go", rewriteTitle("Read a file in Go", phrases))
+	assert.Equal(t, "help me", rewriteTitle("help me", phrases))
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, normalizeTitle("How to read a file?"), normalizeTitle("how to  read a FILE"))
+	assert.NotEqual(t, normalizeTitle("How to read a file?"), normalizeTitle("How to write a file?"))
+}
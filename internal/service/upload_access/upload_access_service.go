@@ -0,0 +1,225 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package upload_access
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/segmentfault/pacman/log"
+)
+
+const (
+	// PostUploadPathPrefix the files uploaded in the posts, only they are protected by the question access
+	PostUploadPathPrefix = "/uploads/post/"
+	// signedURLWindow and signedURLLifetime the signed url is the same in each window, so the browser can cache
+	// the file, and it expires after the lifetime since the window began
+	signedURLWindow   = 30 * time.Minute
+	signedURLLifetime = 90 * time.Minute
+	// maxContainingQuestions only these questions which contain the file are checked
+	maxContainingQuestions = 10
+)
+
+// UploadAccessRepo upload access repository
+type UploadAccessRepo interface {
+	GetQuestionsByUploadPath(ctx context.Context, filePath string, limit int) (questions []*entity.Question, err error)
+}
+
+// uploadAccessConfig the stored secret key which signs the urls
+type uploadAccessConfig struct {
+	SecretKey string `json:"secret_key"`
+}
+
+// UploadAccessService protect the uploaded files of the private site. The files in the rendered posts are
+// signed with the short-lived urls, because the viewer's access to the post has been checked, the other
+// requests of the files are allowed only if the viewer can view the question which contains the file.
+type UploadAccessService struct {
+	uploadAccessRepo UploadAccessRepo
+	siteInfoRepo     siteinfo_common.SiteInfoRepo
+	siteInfoService  siteinfo_common.SiteInfoCommonService
+	secretKey        string
+	secretKeyLock    sync.Mutex
+}
+
+// NewUploadAccessService new upload access service
+func NewUploadAccessService(
+	uploadAccessRepo UploadAccessRepo,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *UploadAccessService {
+	return &UploadAccessService{
+		uploadAccessRepo: uploadAccessRepo,
+		siteInfoRepo:     siteInfoRepo,
+		siteInfoService:  siteInfoService,
+	}
+}
+
+// SignUploadURLs sign the urls of the uploaded images in the html if the site is private
+func (us *UploadAccessService) SignUploadURLs(ctx context.Context, content string) string {
+	return us.BatchSignUploadURLs(ctx, []string{content})[0]
+}
+
+// BatchSignUploadURLs sign the urls of the uploaded images in each html, the result is in the same order
+func (us *UploadAccessService) BatchSignUploadURLs(ctx context.Context, contents []string) []string {
+	siteLogin, err := us.siteInfoService.GetSiteLogin(ctx)
+	if err != nil {
+		log.Error(err)
+		return contents
+	}
+	if !siteLogin.LoginRequired {
+		return contents
+	}
+	secretKey, err := us.getSecretKey(ctx)
+	if err != nil {
+		log.Error(err)
+		return contents
+	}
+	siteURL := ""
+	if siteGeneral, err := us.siteInfoService.GetSiteGeneral(ctx); err != nil {
+		log.Error(err)
+	} else {
+		siteURL = siteGeneral.SiteUrl
+	}
+	expires := signedURLExpires(time.Now())
+
+	replace := func(src string) string {
+		filePath := strings.TrimPrefix(src, siteURL)
+		if !strings.HasPrefix(filePath, PostUploadPathPrefix) || strings.ContainsAny(filePath, "?#") {
+			return src
+		}
+		return src + "?" + url.Values{
+			"expires": []string{strconv.FormatInt(expires, 10)},
+			"sig":     []string{sign(secretKey, filePath, expires)},
+		}.Encode()
+	}
+	signed := make([]string, len(contents))
+	for i, content := range contents {
+		signed[i] = htmltext.ReplaceImageSources(content, replace)
+	}
+	return signed
+}
+
+// CheckSignedURL whether the url of the file is signed and not expired
+func (us *UploadAccessService) CheckSignedURL(ctx context.Context, filePath, expires, signature string) bool {
+	if len(expires) == 0 || len(signature) == 0 {
+		return false
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+	secretKey, err := us.getSecretKey(ctx)
+	if err != nil {
+		log.Error(err)
+		return false
+	}
+	return hmac.Equal([]byte(sign(secretKey, filePath, expiresAt)), []byte(signature))
+}
+
+// CheckFileAccess whether the user can view the uploaded file, the user can view the file if they can view any
+// question which contains it in the question, the answers or the comments. The file which is not contained
+// in any question, e.g. in the user's bio, can be viewed by all the users.
+func (us *UploadAccessService) CheckFileAccess(ctx context.Context, filePath string, userInfo *entity.UserCacheInfo) (
+	canView bool, err error) {
+	if userInfo == nil {
+		return false, nil
+	}
+	if !strings.HasPrefix(filePath, PostUploadPathPrefix) || userInfo.RoleID == role.RoleAdminID ||
+		userInfo.RoleID == role.RoleModeratorID {
+		return true, nil
+	}
+	questions, err := us.uploadAccessRepo.GetQuestionsByUploadPath(ctx, filePath, maxContainingQuestions)
+	if err != nil {
+		return false, err
+	}
+	if len(questions) == 0 {
+		return true, nil
+	}
+	for _, question := range questions {
+		if canViewQuestion(question, userInfo.UserID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getSecretKey get the secret key which signs the urls, it is generated at the first time
+func (us *UploadAccessService) getSecretKey(ctx context.Context) (secretKey string, err error) {
+	us.secretKeyLock.Lock()
+	defer us.secretKeyLock.Unlock()
+	if len(us.secretKey) > 0 {
+		return us.secretKey, nil
+	}
+	config := &uploadAccessConfig{}
+	if err = us.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeUploadAccess, config); err != nil {
+		return "", err
+	}
+	if len(config.SecretKey) == 0 {
+		key := make([]byte, 32)
+		if _, err = rand.Read(key); err != nil {
+			return "", err
+		}
+		config.SecretKey = hex.EncodeToString(key)
+		content, _ := json.Marshal(config)
+		data := &entity.SiteInfo{
+			Type:    constant.SiteTypeUploadAccess,
+			Content: string(content),
+		}
+		if err = us.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUploadAccess, data); err != nil {
+			return "", err
+		}
+	}
+	us.secretKey = config.SecretKey
+	return us.secretKey, nil
+}
+
+// canViewQuestion the deleted question can not be viewed, the hidden and unlisted questions only can be
+// viewed by the author, the moderators are checked before
+func canViewQuestion(question *entity.Question, userID string) bool {
+	if question.Status == entity.QuestionStatusDeleted {
+		return false
+	}
+	return question.Show == entity.QuestionShow || question.UserID == userID
+}
+
+// signedURLExpires the signed url expires at this time, it is the same in each window
+func signedURLExpires(now time.Time) int64 {
+	return now.Truncate(signedURLWindow).Add(signedURLLifetime).Unix()
+}
+
+func sign(secretKey, filePath string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(filePath + "|" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
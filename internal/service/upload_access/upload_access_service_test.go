@@ -0,0 +1,62 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package upload_access
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedURLExpires(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 5, 0, 0, time.UTC)
+	assert.Equal(t, signedURLExpires(now), signedURLExpires(now.Add(20*time.Minute)))
+	assert.NotEqual(t, signedURLExpires(now), signedURLExpires(now.Add(30*time.Minute)))
+	assert.True(t, signedURLExpires(now) >= now.Add(signedURLLifetime-signedURLWindow).Unix())
+}
+
+func TestCheckSignedURL(t *testing.T) {
+	us := &UploadAccessService{secretKey: "key"}
+	filePath := PostUploadPathPrefix + "a.png"
+	expires := time.Now().Add(time.Hour).Unix()
+	signature := sign("key", filePath, expires)
+	ctx := context.TODO()
+
+	assert.True(t, us.CheckSignedURL(ctx, filePath, strconv.FormatInt(expires, 10), signature))
+	assert.False(t, us.CheckSignedURL(ctx, PostUploadPathPrefix+"b.png", strconv.FormatInt(expires, 10), signature))
+	assert.False(t, us.CheckSignedURL(ctx, filePath, strconv.FormatInt(expires+1, 10), signature))
+	expired := time.Now().Add(-time.Minute).Unix()
+	assert.False(t, us.CheckSignedURL(ctx, filePath, strconv.FormatInt(expired, 10), sign("key", filePath, expired)))
+	assert.False(t, us.CheckSignedURL(ctx, filePath, "", ""))
+}
+
+func TestCanViewQuestion(t *testing.T) {
+	question := &entity.Question{UserID: "1", Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow}
+	assert.True(t, canViewQuestion(question, "2"))
+	question.Show = entity.QuestionHide
+	assert.False(t, canViewQuestion(question, "2"))
+	assert.True(t, canViewQuestion(question, "1"))
+	question.Status = entity.QuestionStatusDeleted
+	assert.False(t, canViewQuestion(question, "1"))
+}
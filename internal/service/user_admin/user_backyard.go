@@ -28,7 +28,9 @@ import (
 	"github.com/apache/incubator-answer/internal/base/validator"
 	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
 	"github.com/apache/incubator-answer/internal/service/comment_common"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
 	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
 	"github.com/google/uuid"
 	"net/mail"
@@ -45,6 +47,7 @@ import (
 	"github.com/apache/incubator-answer/internal/service/role"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/internal/service/username_policy"
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/jinzhu/copier"
 	"github.com/segmentfault/pacman/errors"
@@ -62,6 +65,11 @@ type UserAdminRepo interface {
 	AddUser(ctx context.Context, user *entity.User) (err error)
 	AddUsers(ctx context.Context, users []*entity.User) (err error)
 	UpdateUserPassword(ctx context.Context, userID string, password string) (err error)
+	TransferUserContent(ctx context.Context, fromUserID, toUserID, objectID string) (err error)
+	MergeUser(ctx context.Context, mergeLog *entity.UserMergeLog, duplicateEmail string) (err error)
+	AddUserImpersonationLog(ctx context.Context, impersonationLog *entity.UserImpersonationLog) (err error)
+	GetUserImpersonationLogPage(ctx context.Context, page, pageSize int, userID string) (
+		impersonationLogs []*entity.UserImpersonationLog, total int64, err error)
 }
 
 // UserAdminService user service
@@ -76,6 +84,9 @@ type UserAdminService struct {
 	questionCommonRepo    questioncommon.QuestionRepo
 	answerCommonRepo      answercommon.AnswerRepo
 	commentCommonRepo     comment_common.CommentCommonRepo
+	eventQueueService     event_queue.EventQueueService
+	usernamePolicyService *username_policy.UsernamePolicyService
+	legalHoldService      *legal_hold.LegalHoldService
 }
 
 // NewUserAdminService new user admin service
@@ -90,6 +101,9 @@ func NewUserAdminService(
 	questionCommonRepo questioncommon.QuestionRepo,
 	answerCommonRepo answercommon.AnswerRepo,
 	commentCommonRepo comment_common.CommentCommonRepo,
+	eventQueueService event_queue.EventQueueService,
+	usernamePolicyService *username_policy.UsernamePolicyService,
+	legalHoldService *legal_hold.LegalHoldService,
 ) *UserAdminService {
 	return &UserAdminService{
 		userRepo:              userRepo,
@@ -102,6 +116,9 @@ func NewUserAdminService(
 		questionCommonRepo:    questionCommonRepo,
 		answerCommonRepo:      answerCommonRepo,
 		commentCommonRepo:     commentCommonRepo,
+		eventQueueService:     eventQueueService,
+		usernamePolicyService: usernamePolicyService,
+		legalHoldService:      legalHoldService,
 	}
 }
 
@@ -122,6 +139,15 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if userInfo.Status == entity.UserStatusDeleted {
 		return nil
 	}
+	if req.IsDeleted() || req.RemoveAllContent {
+		process := entity.LegalHoldProcessUserDelete
+		if !req.IsDeleted() {
+			process = entity.LegalHoldProcessUserContentWipe
+		}
+		if err = us.legalHoldService.CheckUserDeletable(ctx, userInfo.ID, process, req.LoginUserID); err != nil {
+			return err
+		}
+	}
 
 	if req.IsInactive() {
 		userInfo.MailStatus = entity.EmailStatusToBeVerified
@@ -142,6 +168,15 @@ func (us *UserAdminService) UpdateUserStatus(ctx context.Context, req *schema.Up
 	if err != nil {
 		return err
 	}
+	eventType := constant.EventUserStatusChange
+	if req.IsDeleted() {
+		eventType = constant.EventUserDelete
+	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: eventType,
+		UserID:    req.LoginUserID,
+		ObjectID:  userInfo.ID,
+	})
 
 	// remove all content that user created, such as question, answer, comment, etc.
 	if req.RemoveAllContent {
@@ -216,6 +251,11 @@ func (us *UserAdminService) AddUser(ctx context.Context, req *schema.AddUserReq)
 	if err != nil {
 		return err
 	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserRegister,
+		UserID:    req.LoginUserID,
+		ObjectID:  userInfo.ID,
+	})
 	return
 }
 
@@ -237,7 +277,16 @@ func (us *UserAdminService) AddUsers(ctx context.Context, req *schema.AddUsersRe
 	if errData != nil {
 		return errData.GetErrField(ctx), errors.BadRequest(reason.RequestFormatError)
 	}
-	return nil, us.userRepo.AddUsers(ctx, users)
+	if err = us.userRepo.AddUsers(ctx, users); err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		us.eventQueueService.Send(ctx, &schema.EventMsg{
+			EventType: constant.EventUserRegister,
+			ObjectID:  user.ID,
+		})
+	}
+	return nil, nil
 }
 
 func (us *UserAdminService) checkUserDuplicateInner(ctx context.Context, users []*schema.AddUserReq) (
@@ -346,6 +395,196 @@ func (us *UserAdminService) UpdateUserPassword(ctx context.Context, req *schema.
 	return
 }
 
+// TransferUserContent reattribute the content of the user to another user, e.g. after merging duplicate accounts
+func (us *UserAdminService) TransferUserContent(ctx context.Context, req *schema.TransferUserContentReq) (err error) {
+	if req.FromUserID == req.ToUserID {
+		return errors.BadRequest(reason.UserContentTransferSameUser)
+	}
+	_, exist, err := us.userRepo.GetUserInfo(ctx, req.FromUserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	toUser, exist, err := us.userRepo.GetUserInfo(ctx, req.ToUserID)
+	if err != nil {
+		return err
+	}
+	if !exist || toUser.Status == entity.UserStatusDeleted {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+
+	if err = us.userRepo.TransferUserContent(ctx, req.FromUserID, req.ToUserID, req.ObjectID); err != nil {
+		return err
+	}
+	log.Infof("admin %s transferred content %s from user %s to user %s",
+		req.LoginUserID, req.ObjectID, req.FromUserID, req.ToUserID)
+
+	for _, userID := range []string{req.FromUserID, req.ToUserID} {
+		us.updateUserContentCount(ctx, userID)
+	}
+	return nil
+}
+
+// MergeUser merge the duplicate user into the primary user, the content, votes, reputation, follows, collections
+// and the other records of the duplicate user are moved to the primary user, then the duplicate user is deleted.
+func (us *UserAdminService) MergeUser(ctx context.Context, req *schema.MergeUserReq) (err error) {
+	if req.PrimaryUserID == req.DuplicateUserID {
+		return errors.BadRequest(reason.UserMergeSameUser)
+	}
+	if req.DuplicateUserID == req.LoginUserID {
+		return errors.BadRequest(reason.AdminCannotModifySelfStatus)
+	}
+	primaryUser, exist, err := us.userRepo.GetUserInfo(ctx, req.PrimaryUserID)
+	if err != nil {
+		return err
+	}
+	if !exist || primaryUser.Status == entity.UserStatusDeleted {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	duplicateUser, exist, err := us.userRepo.GetUserInfo(ctx, req.DuplicateUserID)
+	if err != nil {
+		return err
+	}
+	if !exist || duplicateUser.Status == entity.UserStatusDeleted {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+	// the duplicate user is removed after merging
+	err = us.legalHoldService.CheckUserDeletable(ctx, duplicateUser.ID, entity.LegalHoldProcessUserMerge, req.LoginUserID)
+	if err != nil {
+		return err
+	}
+
+	mergeLog := &entity.UserMergeLog{
+		PrimaryUserID:     primaryUser.ID,
+		DuplicateUserID:   duplicateUser.ID,
+		OperatorUserID:    req.LoginUserID,
+		DuplicateUsername: duplicateUser.Username,
+	}
+	// release the email of the duplicate user, so that it can be used by the primary user
+	duplicateEmail := fmt.Sprintf("%s.%d", duplicateUser.EMail, time.Now().Unix())
+	if err = us.userRepo.MergeUser(ctx, mergeLog, duplicateEmail); err != nil {
+		return err
+	}
+	log.Infof("admin %s merged user %s into user %s", req.LoginUserID, duplicateUser.ID, primaryUser.ID)
+
+	us.authService.RemoveUserAllTokens(ctx, duplicateUser.ID)
+	for _, userID := range []string{primaryUser.ID, duplicateUser.ID} {
+		us.updateUserContentCount(ctx, userID)
+	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserDelete,
+		UserID:    req.LoginUserID,
+		ObjectID:  duplicateUser.ID,
+	})
+	return nil
+}
+
+// userImpersonationTime the impersonation session expires after this time
+const userImpersonationTime = time.Hour
+
+// ImpersonateUser sign in as the user. The session is marked as impersonation, it expires in a short time
+// and it is recorded to the impersonation log.
+func (us *UserAdminService) ImpersonateUser(ctx context.Context, req *schema.ImpersonateUserReq) (
+	resp *schema.ImpersonateUserResp, err error) {
+	if req.UserID == req.LoginUserID {
+		return nil, errors.BadRequest(reason.UserImpersonationNotAllowed)
+	}
+	userInfo, exist, err := us.userRepo.GetUserInfo(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	roleID, err := us.userRoleRelService.GetUserRole(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	// the admin can not sign in as another admin
+	if roleID == role.RoleAdminID {
+		return nil, errors.BadRequest(reason.UserImpersonationNotAllowed)
+	}
+
+	expiredAt := time.Now().Add(userImpersonationTime)
+	userCacheInfo := &entity.UserCacheInfo{
+		UserID:                 userInfo.ID,
+		EmailStatus:            userInfo.MailStatus,
+		UserStatus:             userInfo.Status,
+		RoleID:                 roleID,
+		ImpersonatorID:         req.LoginUserID,
+		ImpersonationExpiredAt: expiredAt.Unix(),
+	}
+	resp = &schema.ImpersonateUserResp{
+		UserID:    userInfo.ID,
+		Username:  userInfo.Username,
+		ExpiredAt: expiredAt.Unix(),
+	}
+	resp.AccessToken, resp.VisitToken, err = us.authService.SetUserCacheInfo(ctx, userCacheInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	err = us.userRepo.AddUserImpersonationLog(ctx, &entity.UserImpersonationLog{
+		OperatorUserID: req.LoginUserID,
+		UserID:         userInfo.ID,
+		IP:             req.IP,
+		ExpiredAt:      expiredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("admin %s signed in as user %s", req.LoginUserID, userInfo.ID)
+	return resp, nil
+}
+
+// GetUserImpersonationLogPage get the records of the admins signing in as the users
+func (us *UserAdminService) GetUserImpersonationLogPage(ctx context.Context, req *schema.GetUserImpersonationLogPageReq) (
+	pageModel *pager.PageModel, err error) {
+	impersonationLogs, total, err := us.userRepo.GetUserImpersonationLogPage(ctx, req.Page, req.PageSize, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(impersonationLogs)*2)
+	for _, impersonationLog := range impersonationLogs {
+		userIDs = append(userIDs, impersonationLog.OperatorUserID, impersonationLog.UserID)
+	}
+	userInfoMapping, err := us.userCommonService.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.GetUserImpersonationLogResp, 0, len(impersonationLogs))
+	for _, impersonationLog := range impersonationLogs {
+		resp = append(resp, &schema.GetUserImpersonationLogResp{
+			ID:        impersonationLog.ID,
+			Operator:  userInfoMapping[impersonationLog.OperatorUserID],
+			User:      userInfoMapping[impersonationLog.UserID],
+			IP:        impersonationLog.IP,
+			CreatedAt: impersonationLog.CreatedAt.Unix(),
+			ExpiredAt: impersonationLog.ExpiredAt.Unix(),
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// updateUserContentCount recount the questions and answers of the user
+func (us *UserAdminService) updateUserContentCount(ctx context.Context, userID string) {
+	questionCount, err := us.questionCommonRepo.GetUserQuestionCount(ctx, userID, 0)
+	if err != nil {
+		log.Errorf("get user question count error %v", err)
+	} else if err = us.userCommonService.UpdateQuestionCount(ctx, userID, questionCount); err != nil {
+		log.Errorf("update user question count error %v", err)
+	}
+	answerCount, err := us.answerCommonRepo.GetCountByUserID(ctx, userID)
+	if err != nil {
+		log.Errorf("get user answer count error %v", err)
+	} else if err = us.userCommonService.UpdateAnswerCount(ctx, userID, int(answerCount)); err != nil {
+		log.Errorf("update user answer count error %v", err)
+	}
+}
+
 // EditUserProfile edit user profile
 func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.EditUserProfileReq) (
 	errFields []*validator.FormErrorField, err error) {
@@ -359,6 +598,7 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if !exist {
 		return nil, errors.BadRequest(reason.UserNotFound)
 	}
+	oldUserInfo := userInfo
 
 	if checker.IsInvalidUsername(req.Username) || checker.IsUsersIgnorePath(req.Username) {
 		return append(errFields, &validator.FormErrorField{
@@ -399,6 +639,14 @@ func (us *UserAdminService) EditUserProfile(ctx context.Context, req *schema.Edi
 	if err != nil {
 		return nil, err
 	}
+	if err = us.usernamePolicyService.SaveNameChanges(ctx, req.LoginUserID, oldUserInfo, user); err != nil {
+		log.Errorf("save user name changes failed: %v", err)
+	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserUpdate,
+		UserID:    req.LoginUserID,
+		ObjectID:  user.ID,
+	})
 	return
 }
 
@@ -481,6 +729,10 @@ func (us *UserAdminService) GetUserPage(ctx context.Context, req *schema.GetUser
 			t.SuspendedAt = u.SuspendedAt.Unix()
 		} else if u.MailStatus == entity.EmailStatusToBeVerified {
 			t.Status = constant.UserInactive
+			t.VerificationReminderCount = u.VerificationReminderCount
+			if !u.VerificationRemindedAt.IsZero() {
+				t.VerificationRemindedAt = u.VerificationRemindedAt.Unix()
+			}
 		} else {
 			t.Status = constant.UserNormal
 		}
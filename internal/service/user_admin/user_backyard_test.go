@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/auth"
+	"github.com/apache/incubator-answer/internal/service/legal_hold"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUserAdminRepo struct {
+	UserAdminRepo
+	users     map[string]*entity.User
+	transfers [][]string
+	mergeLogs []*entity.UserMergeLog
+}
+
+func (r *testUserAdminRepo) MergeUser(_ context.Context, mergeLog *entity.UserMergeLog, _ string) error {
+	r.mergeLogs = append(r.mergeLogs, mergeLog)
+	return nil
+}
+
+type testAuthRepo struct {
+	auth.AuthRepo
+	removed []string
+}
+
+func (r *testAuthRepo) RemoveUserTokens(_ context.Context, userID string, _ string) {
+	r.removed = append(r.removed, userID)
+}
+
+type testUserSessionRepo struct {
+	auth.UserSessionRepo
+}
+
+func (r *testUserSessionRepo) RemoveUserSessions(context.Context, string, string) error {
+	return nil
+}
+
+type testLegalHoldRepo struct {
+	legal_hold.LegalHoldRepo
+	held map[string]bool
+}
+
+func (r *testLegalHoldRepo) GetActiveLegalHolds(_ context.Context, objectIDs []string) ([]*entity.LegalHold, error) {
+	var holds []*entity.LegalHold
+	for _, objectID := range objectIDs {
+		if r.held[objectID] {
+			holds = append(holds, &entity.LegalHold{ID: 1, ObjectID: objectID})
+		}
+	}
+	return holds, nil
+}
+
+func (r *testLegalHoldRepo) AddLegalHoldLog(context.Context, *entity.LegalHoldLog) error {
+	return nil
+}
+
+func (r *testUserAdminRepo) GetUserInfo(_ context.Context, userID string) (*entity.User, bool, error) {
+	user, ok := r.users[userID]
+	return user, ok, nil
+}
+
+func (r *testUserAdminRepo) TransferUserContent(_ context.Context, fromUserID, toUserID, objectID string) error {
+	r.transfers = append(r.transfers, []string{fromUserID, toUserID, objectID})
+	return nil
+}
+
+// newTestUserAdminService the user counts refreshed by the service are recorded in the returned maps
+func newTestUserAdminService(ctl *gomock.Controller, userAdminRepo *testUserAdminRepo) (
+	us *UserAdminService, questionCounts map[string]int64, answerCounts map[string]int) {
+	questionCounts, answerCounts = make(map[string]int64), make(map[string]int)
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().UpdateQuestionCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string, count int64) error {
+			questionCounts[userID] = count
+			return nil
+		})
+	userRepo.EXPECT().UpdateAnswerCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string, count int) error {
+			answerCounts[userID] = count
+			return nil
+		})
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	questionRepo.EXPECT().GetUserQuestionCount(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string, _ int) (int64, error) {
+			return map[string]int64{"2": 3}[userID], nil
+		})
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	answerRepo.EXPECT().GetCountByUserID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userID string) (int64, error) {
+			return map[string]int64{"2": 5}[userID], nil
+		})
+	us = NewUserAdminService(userAdminRepo, nil, nil, usercommon.NewUserCommon(userRepo, nil, nil, nil),
+		nil, nil, nil, questionRepo, answerRepo, nil, nil, nil, nil)
+	return us, questionCounts, answerCounts
+}
+
+func TestTransferUserContent(t *testing.T) {
+	userAdminRepo := &testUserAdminRepo{users: map[string]*entity.User{
+		"1": {ID: "1", Status: entity.UserStatusAvailable},
+		"2": {ID: "2", Status: entity.UserStatusAvailable},
+		"3": {ID: "3", Status: entity.UserStatusDeleted},
+	}}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, questionCounts, answerCounts := newTestUserAdminService(ctl, userAdminRepo)
+
+	tests := []struct {
+		name    string
+		req     *schema.TransferUserContentReq
+		wantErr bool
+	}{
+		{"same user", &schema.TransferUserContentReq{FromUserID: "1", ToUserID: "1"}, true},
+		{"from user not found", &schema.TransferUserContentReq{FromUserID: "4", ToUserID: "2"}, true},
+		{"to user not found", &schema.TransferUserContentReq{FromUserID: "1", ToUserID: "4"}, true},
+		{"to user deleted", &schema.TransferUserContentReq{FromUserID: "1", ToUserID: "3"}, true},
+		// the content of the deleted user can be transferred
+		{"from user deleted", &schema.TransferUserContentReq{FromUserID: "3", ToUserID: "2"}, false},
+		{"one object", &schema.TransferUserContentReq{FromUserID: "1", ToUserID: "2",
+			ObjectID: "10020000000000001"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := us.TransferUserContent(context.TODO(), tt.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+
+	assert.Equal(t, [][]string{{"3", "2", ""}, {"1", "2", "10020000000000001"}}, userAdminRepo.transfers)
+	// the counts of both users are refreshed
+	assert.Equal(t, map[string]int64{"1": 0, "2": 3, "3": 0}, questionCounts)
+	assert.Equal(t, map[string]int{"1": 0, "2": 5, "3": 0}, answerCounts)
+}
+
+func TestMergeUser(t *testing.T) {
+	userAdminRepo := &testUserAdminRepo{users: map[string]*entity.User{
+		"1": {ID: "1", Status: entity.UserStatusAvailable},
+		"2": {ID: "2", Username: "duplicate", Status: entity.UserStatusAvailable},
+		"3": {ID: "3", Status: entity.UserStatusDeleted},
+		"4": {ID: "4", Status: entity.UserStatusAvailable},
+	}}
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	authRepo := &testAuthRepo{}
+	eventQueue := mock.NewMockEventQueueService(ctl)
+	// only the merged duplicate user is deleted
+	eventQueue.EXPECT().Send(gomock.Any(), &schema.EventMsg{EventType: constant.EventUserDelete, UserID: "9",
+		ObjectID: "2"})
+	us, _, answerCounts := newTestUserAdminService(ctl, userAdminRepo)
+	us.authService = auth.NewAuthService(authRepo, &testUserSessionRepo{})
+	us.eventQueueService = eventQueue
+	us.legalHoldService = legal_hold.NewLegalHoldService(&testLegalHoldRepo{held: map[string]bool{"4": true}},
+		nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		req       *schema.MergeUserReq
+		wantError string
+	}{
+		{"same user", &schema.MergeUserReq{PrimaryUserID: "1", DuplicateUserID: "1", LoginUserID: "9"},
+			reason.UserMergeSameUser},
+		{"merge self", &schema.MergeUserReq{PrimaryUserID: "1", DuplicateUserID: "9", LoginUserID: "9"},
+			reason.AdminCannotModifySelfStatus},
+		{"primary user deleted", &schema.MergeUserReq{PrimaryUserID: "3", DuplicateUserID: "2", LoginUserID: "9"},
+			reason.UserNotFound},
+		{"duplicate user deleted", &schema.MergeUserReq{PrimaryUserID: "1", DuplicateUserID: "3", LoginUserID: "9"},
+			reason.UserNotFound},
+		{"duplicate user held", &schema.MergeUserReq{PrimaryUserID: "1", DuplicateUserID: "4", LoginUserID: "9"},
+			reason.LegalHoldContentFrozen},
+		{"merged", &schema.MergeUserReq{PrimaryUserID: "1", DuplicateUserID: "2", LoginUserID: "9"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := us.MergeUser(context.TODO(), tt.req)
+			if len(tt.wantError) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Equal(t, tt.wantError, err.(*errors.Error).Reason)
+			}
+		})
+	}
+
+	assert.Equal(t, []*entity.UserMergeLog{{PrimaryUserID: "1", DuplicateUserID: "2", OperatorUserID: "9",
+		DuplicateUsername: "duplicate"}}, userAdminRepo.mergeLogs)
+	assert.Equal(t, []string{"2"}, authRepo.removed)
+	assert.Equal(t, map[string]int{"1": 0, "2": 5}, answerCounts)
+}
@@ -22,6 +22,7 @@ package usercommon
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-answer/internal/base/constant"
 	"github.com/apache/incubator-answer/pkg/converter"
@@ -36,9 +37,11 @@ import (
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/random"
 	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/i18n"
 	"github.com/segmentfault/pacman/log"
 )
 
+//go:generate mockgen -source=./user.go -destination=../mock/user_repo_mock.go -package=mock
 type UserRepo interface {
 	AddUser(ctx context.Context, user *entity.User) (err error)
 	IncreaseAnswerCount(ctx context.Context, userID string, amount int) (err error)
@@ -49,7 +52,11 @@ type UserRepo interface {
 	UpdateEmailStatus(ctx context.Context, userID string, emailStatus int) error
 	UpdateNoticeStatus(ctx context.Context, userID string, noticeStatus int) error
 	UpdateEmail(ctx context.Context, userID, email string) error
+	GetUnverifiedUsersToRemind(ctx context.Context, remindedBefore time.Time, maxReminderCount, limit int) (
+		userList []*entity.User, err error)
+	UpdateVerificationReminded(ctx context.Context, userID string) (err error)
 	UpdateUserInterface(ctx context.Context, userID, language, colorSchema string) (err error)
+	UpdateUserPreferences(ctx context.Context, userID, language, timeZone, dateFormat string) (err error)
 	UpdatePass(ctx context.Context, userID, pass string) error
 	UpdateInfo(ctx context.Context, userInfo *entity.User) (err error)
 	UpdateUserProfile(ctx context.Context, userInfo *entity.User) (err error)
@@ -150,14 +157,50 @@ func (us *UserCommon) BatchUserBasicInfoByID(ctx context.Context, userIDs []stri
 		return userMap, err
 	}
 	avatarMapping := us.siteInfoCommonService.FormatListAvatar(ctx, userList)
+	siteWrite, err := us.siteInfoCommonService.GetSiteWrite(ctx)
+	if err != nil {
+		log.Error(err)
+	}
+	now := time.Now()
 	for _, user := range userList {
 		info := us.FormatUserBasicInfo(ctx, user)
 		info.Avatar = avatarMapping[user.ID].GetURL()
+		info.NewContributor = siteWrite != nil && IsNewContributor(siteWrite, user, now)
 		userMap[user.ID] = info
 	}
 	return userMap, nil
 }
 
+// WithUserLocale the content generated for the user, such as emails, is rendered in the
+// language, time zone and date format of the user
+func WithUserLocale(ctx context.Context, userInfo *entity.User) context.Context {
+	if len(userInfo.Language) > 0 {
+		ctx = context.WithValue(ctx, constant.AcceptLanguageFlag, i18n.Language(userInfo.Language))
+	}
+	if len(userInfo.TimeZone) > 0 {
+		ctx = context.WithValue(ctx, constant.TimeZoneFlag, userInfo.TimeZone)
+	}
+	if len(userInfo.DateFormat) > 0 {
+		ctx = context.WithValue(ctx, constant.DateFormatFlag, userInfo.DateFormat)
+	}
+	return ctx
+}
+
+// IsNewContributor the user with fewer posts than the threshold is the new contributor within the days since sign-up
+func IsNewContributor(siteWrite *schema.SiteWriteResp, userInfo *entity.User, now time.Time) bool {
+	if siteWrite.NewContributorPostThreshold <= 0 || userInfo.Status == entity.UserStatusDeleted {
+		return false
+	}
+	if userInfo.QuestionCount+userInfo.AnswerCount >= siteWrite.NewContributorPostThreshold {
+		return false
+	}
+	days := siteWrite.NewContributorDays
+	if days <= 0 {
+		days = schema.DefaultNewContributorDays
+	}
+	return userInfo.CreatedAt.AddDate(0, 0, days).After(now)
+}
+
 // FormatUserBasicInfo format user basic info
 func (us *UserCommon) FormatUserBasicInfo(ctx context.Context, userInfo *entity.User) *schema.UserBasicInfo {
 	userBasicInfo := &schema.UserBasicInfo{}
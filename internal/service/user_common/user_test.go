@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package usercommon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNewContributor(t *testing.T) {
+	now := time.Now()
+	siteWrite := &schema.SiteWriteResp{NewContributorPostThreshold: 3, NewContributorDays: 7}
+	newUser := &entity.User{CreatedAt: now.AddDate(0, 0, -1), QuestionCount: 1, AnswerCount: 1}
+	assert.True(t, IsNewContributor(siteWrite, newUser, now))
+
+	// enough posts
+	assert.False(t, IsNewContributor(siteWrite, &entity.User{CreatedAt: now, QuestionCount: 2, AnswerCount: 1}, now))
+	// signed up long ago
+	assert.False(t, IsNewContributor(siteWrite, &entity.User{CreatedAt: now.AddDate(0, 0, -8)}, now))
+	// the default days are used
+	siteWrite.NewContributorDays = 0
+	assert.True(t, IsNewContributor(siteWrite, &entity.User{CreatedAt: now.AddDate(0, 0, -8)}, now))
+	// disabled
+	assert.False(t, IsNewContributor(&schema.SiteWriteResp{}, newUser, now))
+}
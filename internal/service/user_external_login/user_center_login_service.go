@@ -31,6 +31,7 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
 	"github.com/apache/incubator-answer/pkg/checker"
@@ -47,6 +48,7 @@ type UserCenterLoginService struct {
 	userCommonService     *usercommon.UserCommon
 	userActivity          activity.UserActiveActivityRepo
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService
+	eventQueueService     event_queue.EventQueueService
 }
 
 // NewUserCenterLoginService new user external login service
@@ -56,6 +58,7 @@ func NewUserCenterLoginService(
 	userExternalLoginRepo UserExternalLoginRepo,
 	userActivity activity.UserActiveActivityRepo,
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
+	eventQueueService event_queue.EventQueueService,
 ) *UserCenterLoginService {
 	return &UserCenterLoginService{
 		userRepo:              userRepo,
@@ -63,6 +66,7 @@ func NewUserCenterLoginService(
 		userExternalLoginRepo: userExternalLoginRepo,
 		userActivity:          userActivity,
 		siteInfoCommonService: siteInfoCommonService,
+		eventQueueService:     eventQueueService,
 	}
 }
 
@@ -166,6 +170,11 @@ func (us *UserCenterLoginService) registerNewUser(ctx context.Context, provider
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserRegister,
+		UserID:    userInfo.ID,
+		ObjectID:  userInfo.ID,
+	})
 
 	metaInfo, _ := json.Marshal(basicUserInfo)
 	newExternalUserInfo := &entity.UserExternalLogin{
@@ -32,6 +32,8 @@ import (
 	"github.com/apache/incubator-answer/internal/entity"
 	"github.com/apache/incubator-answer/internal/schema"
 	"github.com/apache/incubator-answer/internal/service/activity"
+	"github.com/apache/incubator-answer/internal/service/email_domain"
+	"github.com/apache/incubator-answer/internal/service/event_queue"
 	"github.com/apache/incubator-answer/internal/service/export"
 	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
 	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
@@ -65,6 +67,8 @@ type UserExternalLoginService struct {
 	siteInfoCommonService         siteinfo_common.SiteInfoCommonService
 	userActivity                  activity.UserActiveActivityRepo
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService
+	eventQueueService             event_queue.EventQueueService
+	emailDomainService            *email_domain.EmailDomainService
 }
 
 // NewUserExternalLoginService new user external login service
@@ -76,6 +80,8 @@ func NewUserExternalLoginService(
 	siteInfoCommonService siteinfo_common.SiteInfoCommonService,
 	userActivity activity.UserActiveActivityRepo,
 	userNotificationConfigService *user_notification_config.UserNotificationConfigService,
+	eventQueueService event_queue.EventQueueService,
+	emailDomainService *email_domain.EmailDomainService,
 ) *UserExternalLoginService {
 	return &UserExternalLoginService{
 		userRepo:                      userRepo,
@@ -85,6 +91,8 @@ func NewUserExternalLoginService(
 		siteInfoCommonService:         siteInfoCommonService,
 		userActivity:                  userActivity,
 		userNotificationConfigService: userNotificationConfigService,
+		eventQueueService:             eventQueueService,
+		emailDomainService:            emailDomainService,
 	}
 }
 
@@ -153,6 +161,24 @@ func (us *UserExternalLoginService) ExternalLogin(
 	}
 	// if user is not a member, register a new user
 	if !exist {
+		// the invite-only site does not allow new user register via external login
+		if siteInfo.InviteOnly {
+			return &schema.UserExternalLoginResp{
+				ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+				ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), reason.InvitationRequired),
+			}, nil
+		}
+		errFields, err := us.emailDomainService.CheckSignupEmail(ctx, externalUserInfo.Email, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(errFields) > 0 {
+			log.Debugf("email domain blocked: %s", externalUserInfo.Email)
+			return &schema.UserExternalLoginResp{
+				ErrTitle: translator.Tr(handler.GetLangByCtx(ctx), reason.UserAccessDenied),
+				ErrMsg:   translator.Tr(handler.GetLangByCtx(ctx), errFields[0].ErrorMsg),
+			}, nil
+		}
 		oldUserInfo, err = us.registerNewUser(ctx, externalUserInfo)
 		if err != nil {
 			return nil, err
@@ -210,6 +236,11 @@ func (us *UserExternalLoginService) registerNewUser(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	us.eventQueueService.Send(ctx, &schema.EventMsg{
+		EventType: constant.EventUserRegister,
+		UserID:    userInfo.ID,
+		ObjectID:  userInfo.ID,
+	})
 	return userInfo, nil
 }
 
@@ -299,6 +330,13 @@ func (us *UserExternalLoginService) ExternalLoginBindingUserSendEmail(
 	}
 
 	if !exist {
+		siteLogin, err := us.siteInfoCommonService.GetSiteLogin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if siteLogin.InviteOnly {
+			return nil, errors.BadRequest(reason.InvitationRequired)
+		}
 		externalLoginInfo.Email = req.Email
 		userInfo, err = us.registerNewUser(ctx, externalLoginInfo)
 		if err != nil {
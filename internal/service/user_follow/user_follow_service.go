@@ -0,0 +1,277 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_follow
+
+import (
+	"context"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	answercommon "github.com/apache/incubator-answer/internal/service/answer_common"
+	questioncommon "github.com/apache/incubator-answer/internal/service/question_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/htmltext"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserFollowRepo user follow repository
+type UserFollowRepo interface {
+	Follow(ctx context.Context, userID, followUserID string) (err error)
+	Unfollow(ctx context.Context, userID, followUserID string) (err error)
+	IsFollowing(ctx context.Context, userID, followUserID string) (following bool, err error)
+	CountFollowers(ctx context.Context, userID string) (count int64, err error)
+	CountFollowing(ctx context.Context, userID string) (count int64, err error)
+	GetFollowerPage(ctx context.Context, userID string, page, pageSize int) (
+		follows []*entity.UserFollow, total int64, err error)
+	GetFollowingPage(ctx context.Context, userID string, page, pageSize int) (
+		follows []*entity.UserFollow, total int64, err error)
+	GetFollowerIDs(ctx context.Context, userID string) (followerIDs []string, err error)
+	GetFollowingActivityPage(ctx context.Context, userID string, askedType, answeredType int, page, pageSize int) (
+		activities []*entity.Activity, total int64, err error)
+}
+
+// UserFollowService user follow service
+type UserFollowService struct {
+	userFollowRepo UserFollowRepo
+	userCommon     *usercommon.UserCommon
+	activityRepo   activity_common.ActivityRepo
+	questionRepo   questioncommon.QuestionRepo
+	answerRepo     answercommon.AnswerRepo
+}
+
+// NewUserFollowService new user follow service
+func NewUserFollowService(
+	userFollowRepo UserFollowRepo,
+	userCommon *usercommon.UserCommon,
+	activityRepo activity_common.ActivityRepo,
+	questionRepo questioncommon.QuestionRepo,
+	answerRepo answercommon.AnswerRepo,
+) *UserFollowService {
+	return &UserFollowService{
+		userFollowRepo: userFollowRepo,
+		userCommon:     userCommon,
+		activityRepo:   activityRepo,
+		questionRepo:   questionRepo,
+		answerRepo:     answerRepo,
+	}
+}
+
+// FollowUser follow user or cancel follow
+func (us *UserFollowService) FollowUser(ctx context.Context, req *schema.FollowUserReq) (
+	resp *schema.FollowUserResp, err error) {
+	userInfo, exist, err := us.userCommon.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist || userInfo.Status == entity.UserStatusDeleted {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	if userInfo.ID == req.UserID {
+		return nil, errors.BadRequest(reason.UserCannotFollowYourself)
+	}
+
+	if req.IsCancel {
+		err = us.userFollowRepo.Unfollow(ctx, req.UserID, userInfo.ID)
+	} else {
+		err = us.userFollowRepo.Follow(ctx, req.UserID, userInfo.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp = &schema.FollowUserResp{IsFollowed: !req.IsCancel}
+	resp.FollowerCount, err = us.userFollowRepo.CountFollowers(ctx, userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetFollowStats get the follower count and following count of user,
+// and whether the login user is following this user
+func (us *UserFollowService) GetFollowStats(ctx context.Context, userID, loginUserID string) (
+	followerCount, followingCount int64, isFollowed bool, err error) {
+	followerCount, err = us.userFollowRepo.CountFollowers(ctx, userID)
+	if err != nil {
+		return
+	}
+	followingCount, err = us.userFollowRepo.CountFollowing(ctx, userID)
+	if err != nil {
+		return
+	}
+	if len(loginUserID) > 0 && loginUserID != userID {
+		isFollowed, err = us.userFollowRepo.IsFollowing(ctx, loginUserID, userID)
+	}
+	return
+}
+
+// GetFollowerPage get the followers of user
+func (us *UserFollowService) GetFollowerPage(ctx context.Context, req *schema.GetUserFollowPageReq) (
+	pageModel *pager.PageModel, err error) {
+	userInfo, exist, err := us.userCommon.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	follows, total, err := us.userFollowRepo.GetFollowerPage(ctx, userInfo.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		userIDs = append(userIDs, follow.UserID)
+	}
+	return us.formatUserPage(ctx, userIDs, total)
+}
+
+// GetFollowingPage get the users followed by user
+func (us *UserFollowService) GetFollowingPage(ctx context.Context, req *schema.GetUserFollowPageReq) (
+	pageModel *pager.PageModel, err error) {
+	userInfo, exist, err := us.userCommon.GetByUsername(ctx, req.Username)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, errors.BadRequest(reason.UserNotFound)
+	}
+	follows, total, err := us.userFollowRepo.GetFollowingPage(ctx, userInfo.ID, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(follows))
+	for _, follow := range follows {
+		userIDs = append(userIDs, follow.FollowUserID)
+	}
+	return us.formatUserPage(ctx, userIDs, total)
+}
+
+func (us *UserFollowService) formatUserPage(ctx context.Context, userIDs []string, total int64) (
+	pageModel *pager.PageModel, err error) {
+	userMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*schema.UserBasicInfo, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if userInfo, ok := userMapping[userID]; ok {
+			users = append(users, userInfo)
+		}
+	}
+	return pager.NewPageModel(total, users), nil
+}
+
+// GetFollowingFeed get the recent questions and answers posted by the users followed by the login user
+func (us *UserFollowService) GetFollowingFeed(ctx context.Context, req *schema.GetFollowingFeedReq) (
+	pageModel *pager.PageModel, err error) {
+	askedType, err := us.activityRepo.GetActivityTypeByConfigKey(ctx, string(constant.ActQuestionAsked))
+	if err != nil {
+		return nil, err
+	}
+	answeredType, err := us.activityRepo.GetActivityTypeByConfigKey(ctx, string(constant.ActAnswerAnswered))
+	if err != nil {
+		return nil, err
+	}
+	activities, total, err := us.userFollowRepo.GetFollowingActivityPage(ctx, req.UserID,
+		askedType, answeredType, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// the id of answer and question may be encoded as short id by the repositories, so always compare the decoded one
+	answerIDs := make([]string, 0)
+	questionIDs := make([]string, 0)
+	userIDs := make([]string, 0)
+	for _, act := range activities {
+		userIDs = append(userIDs, act.UserID)
+		if act.ActivityType == askedType {
+			questionIDs = append(questionIDs, uid.DeShortID(act.ObjectID))
+		} else {
+			answerIDs = append(answerIDs, uid.DeShortID(act.ObjectID))
+		}
+	}
+	answerMapping := make(map[string]*entity.Answer)
+	if len(answerIDs) > 0 {
+		answers, err := us.answerRepo.GetAnswersByIDs(ctx, answerIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, answer := range answers {
+			answerMapping[uid.DeShortID(answer.ID)] = answer
+			questionIDs = append(questionIDs, uid.DeShortID(answer.QuestionID))
+		}
+	}
+	questionMapping := make(map[string]*entity.Question)
+	if len(questionIDs) > 0 {
+		questions, err := us.questionRepo.FindByID(ctx, questionIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, question := range questions {
+			questionMapping[uid.DeShortID(question.ID)] = question
+		}
+	}
+	userMapping, err := us.userCommon.BatchUserBasicInfoByID(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	feed := make([]*schema.FollowingFeedItem, 0, len(activities))
+	for _, act := range activities {
+		item := &schema.FollowingFeedItem{
+			CreatedAt: act.CreatedAt.Unix(),
+			UserInfo:  userMapping[act.UserID],
+		}
+		if act.ActivityType == askedType {
+			question, ok := questionMapping[uid.DeShortID(act.ObjectID)]
+			if !ok {
+				continue
+			}
+			item.ObjectType = constant.QuestionObjectType
+			item.ObjectID = question.ID
+			item.QuestionID = question.ID
+			item.Title = question.Title
+			item.Excerpt = htmltext.FetchExcerpt(question.ParsedText, "...", 240)
+		} else {
+			answer, ok := answerMapping[uid.DeShortID(act.ObjectID)]
+			if !ok {
+				continue
+			}
+			question, ok := questionMapping[uid.DeShortID(answer.QuestionID)]
+			if !ok {
+				continue
+			}
+			item.ObjectType = constant.AnswerObjectType
+			item.ObjectID = answer.ID
+			item.QuestionID = question.ID
+			item.Title = question.Title
+			item.Excerpt = htmltext.FetchExcerpt(answer.ParsedText, "...", 240)
+		}
+		item.UrlTitle = htmltext.UrlTitle(item.Title)
+		feed = append(feed, item)
+	}
+	return pager.NewPageModel(total, feed), nil
+}
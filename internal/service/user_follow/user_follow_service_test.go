@@ -0,0 +1,239 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_follow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/uid"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAskedType    = 1
+	testAnsweredType = 2
+)
+
+type testUserFollowRepo struct {
+	UserFollowRepo
+	following  map[string]map[string]bool
+	activities []*entity.Activity
+}
+
+func (r *testUserFollowRepo) Follow(_ context.Context, userID, followUserID string) error {
+	if r.following[userID] == nil {
+		r.following[userID] = make(map[string]bool)
+	}
+	r.following[userID][followUserID] = true
+	return nil
+}
+
+func (r *testUserFollowRepo) Unfollow(_ context.Context, userID, followUserID string) error {
+	delete(r.following[userID], followUserID)
+	return nil
+}
+
+func (r *testUserFollowRepo) IsFollowing(_ context.Context, userID, followUserID string) (bool, error) {
+	return r.following[userID][followUserID], nil
+}
+
+func (r *testUserFollowRepo) CountFollowers(_ context.Context, userID string) (count int64, err error) {
+	for _, following := range r.following {
+		if following[userID] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *testUserFollowRepo) CountFollowing(_ context.Context, userID string) (int64, error) {
+	return int64(len(r.following[userID])), nil
+}
+
+func (r *testUserFollowRepo) GetFollowingActivityPage(_ context.Context, _ string, _, _ int, _, _ int) (
+	[]*entity.Activity, int64, error) {
+	return r.activities, int64(len(r.activities)), nil
+}
+
+type testActivityRepo struct {
+	activity_common.ActivityRepo
+}
+
+func (r *testActivityRepo) GetActivityTypeByConfigKey(_ context.Context, configKey string) (int, error) {
+	if configKey == string(constant.ActQuestionAsked) {
+		return testAskedType, nil
+	}
+	return testAnsweredType, nil
+}
+
+var testUsers = map[string]*entity.User{
+	"1": {ID: "1", Username: "alice", Status: entity.UserStatusAvailable},
+	"2": {ID: "2", Username: "bob", Status: entity.UserStatusAvailable},
+	"3": {ID: "3", Username: "carol", Status: entity.UserStatusDeleted},
+}
+
+func newTestUserFollowService(ctl *gomock.Controller) (*UserFollowService, *testUserFollowRepo,
+	*mock.MockQuestionRepo, *mock.MockAnswerRepo) {
+	userFollowRepo := &testUserFollowRepo{following: make(map[string]map[string]bool)}
+	userRepo := mock.NewMockUserRepo(ctl)
+	userRepo.EXPECT().GetByUsername(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, username string) (*entity.User, bool, error) {
+			for _, user := range testUsers {
+				if user.Username == username {
+					return user, true, nil
+				}
+			}
+			return nil, false, nil
+		})
+	userRepo.EXPECT().BatchGetByID(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, ids []string) ([]*entity.User, error) {
+			users := make([]*entity.User, 0, len(ids))
+			for _, id := range ids {
+				if user, ok := testUsers[id]; ok {
+					users = append(users, user)
+				}
+			}
+			return users, nil
+		})
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteWrite(gomock.Any()).AnyTimes().Return(&schema.SiteWriteResp{}, nil)
+	siteInfoService.EXPECT().FormatListAvatar(gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, userList []*entity.User) map[string]*schema.AvatarInfo {
+			mapping := make(map[string]*schema.AvatarInfo)
+			for _, user := range userList {
+				mapping[user.ID] = &schema.AvatarInfo{}
+			}
+			return mapping
+		})
+	questionRepo := mock.NewMockQuestionRepo(ctl)
+	answerRepo := mock.NewMockAnswerRepo(ctl)
+	us := NewUserFollowService(userFollowRepo, usercommon.NewUserCommon(userRepo, nil, nil, siteInfoService),
+		&testActivityRepo{}, questionRepo, answerRepo)
+	return us, userFollowRepo, questionRepo, answerRepo
+}
+
+func TestFollowUser(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, _, _, _ := newTestUserFollowService(ctl)
+	ctx := context.TODO()
+
+	cases := []struct {
+		name     string
+		username string
+		reason   string
+	}{
+		{"missing user", "dave", reason.UserNotFound},
+		{"deleted user", "carol", reason.UserNotFound},
+		{"yourself", "alice", reason.UserCannotFollowYourself},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := us.FollowUser(ctx, &schema.FollowUserReq{Username: c.username, UserID: "1"})
+			require.Error(t, err)
+			assert.Equal(t, c.reason, err.(*errors.Error).Reason)
+		})
+	}
+
+	resp, err := us.FollowUser(ctx, &schema.FollowUserReq{Username: "bob", UserID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, &schema.FollowUserResp{FollowerCount: 1, IsFollowed: true}, resp)
+
+	resp, err = us.FollowUser(ctx, &schema.FollowUserReq{Username: "bob", UserID: "1", IsCancel: true})
+	require.NoError(t, err)
+	assert.Equal(t, &schema.FollowUserResp{FollowerCount: 0, IsFollowed: false}, resp)
+}
+
+func TestGetFollowStats(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, userFollowRepo, _, _ := newTestUserFollowService(ctl)
+	ctx := context.TODO()
+	userFollowRepo.following = map[string]map[string]bool{
+		"1": {"2": true},
+		"2": {"1": true, "3": true},
+	}
+
+	followerCount, followingCount, isFollowed, err := us.GetFollowStats(ctx, "2", "1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), followerCount)
+	assert.Equal(t, int64(2), followingCount)
+	assert.True(t, isFollowed)
+
+	// the user does not follow themselves
+	_, _, isFollowed, err = us.GetFollowStats(ctx, "2", "2")
+	require.NoError(t, err)
+	assert.False(t, isFollowed)
+
+	_, _, isFollowed, err = us.GetFollowStats(ctx, "1", "")
+	require.NoError(t, err)
+	assert.False(t, isFollowed)
+}
+
+func TestGetFollowingFeed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, userFollowRepo, questionRepo, answerRepo := newTestUserFollowService(ctl)
+	ctx := context.WithValue(context.TODO(), constant.ShortIDFlag, true)
+	now := time.Now()
+	questionID, answerID := uid.EnShortID("10010000000000001"), uid.EnShortID("10020000000000001")
+	userFollowRepo.activities = []*entity.Activity{
+		{UserID: "2", ObjectID: answerID, ActivityType: testAnsweredType, CreatedAt: now},
+		{UserID: "2", ObjectID: "10010000000000001", ActivityType: testAskedType, CreatedAt: now},
+	}
+	// the repositories return the short ids when they are enabled
+	answerRepo.EXPECT().GetAnswersByIDs(gomock.Any(), []string{"10020000000000001"}).Return([]*entity.Answer{
+		{ID: answerID, QuestionID: questionID, ParsedText: "<p>answer</p>", Status: entity.AnswerStatusAvailable},
+	}, nil)
+	questionRepo.EXPECT().FindByID(gomock.Any(), gomock.Any()).Return([]*entity.Question{
+		{ID: questionID, Title: "Shown question", ParsedText: "<p>question</p>",
+			Status: entity.QuestionStatusAvailable, Show: entity.QuestionShow},
+	}, nil)
+
+	pageModel, err := us.GetFollowingFeed(ctx, &schema.GetFollowingFeedReq{UserID: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), pageModel.Count)
+	feed := pageModel.List.([]*schema.FollowingFeedItem)
+	require.Len(t, feed, 2)
+
+	assert.Equal(t, constant.AnswerObjectType, feed[0].ObjectType)
+	assert.Equal(t, answerID, feed[0].ObjectID)
+	assert.Equal(t, questionID, feed[0].QuestionID)
+	assert.Equal(t, "Shown question", feed[0].Title)
+	assert.Equal(t, "answer", feed[0].Excerpt)
+	assert.Equal(t, "bob", feed[0].UserInfo.Username)
+
+	assert.Equal(t, constant.QuestionObjectType, feed[1].ObjectType)
+	assert.Equal(t, questionID, feed[1].ObjectID)
+	assert.Equal(t, questionID, feed[1].QuestionID)
+	assert.Equal(t, "question", feed[1].Excerpt)
+}
@@ -0,0 +1,228 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_onboarding
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/activity_common"
+	"github.com/apache/incubator-answer/internal/service/config"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/segmentfault/pacman/log"
+)
+
+// UserOnboarded the activity config key of the reputation bonus for completing the onboarding
+const UserOnboarded = "user.onboarded"
+
+// UserOnboardingRepo user onboarding repository
+type UserOnboardingRepo interface {
+	GetOnboarding(ctx context.Context, userID string) (onboarding *entity.UserOnboarding, exist bool, err error)
+	GetOrAddOnboarding(ctx context.Context, userID string) (onboarding *entity.UserOnboarding, err error)
+	UpdateOnboardingSteps(ctx context.Context, onboarding *entity.UserOnboarding) (err error)
+	// CompleteOnboarding mark the onboarding as completed and grant the reputation bonus only once,
+	// completed is false if it has been completed before
+	CompleteOnboarding(ctx context.Context, userID string, completedAt time.Time) (completed bool, err error)
+}
+
+// UserOnboardingService user onboarding service
+type UserOnboardingService struct {
+	userOnboardingRepo UserOnboardingRepo
+	userRepo           usercommon.UserRepo
+	followRepo         activity_common.FollowRepo
+	configService      *config.ConfigService
+}
+
+// NewUserOnboardingService new user onboarding service
+func NewUserOnboardingService(
+	userOnboardingRepo UserOnboardingRepo,
+	userRepo usercommon.UserRepo,
+	followRepo activity_common.FollowRepo,
+	configService *config.ConfigService,
+) *UserOnboardingService {
+	return &UserOnboardingService{
+		userOnboardingRepo: userOnboardingRepo,
+		userRepo:           userRepo,
+		followRepo:         followRepo,
+		configService:      configService,
+	}
+}
+
+// GetUserOnboarding get the onboarding progress of the user,
+// the steps are checked against the current state of the user every time it is polled
+func (us *UserOnboardingService) GetUserOnboarding(ctx context.Context, req *schema.GetUserOnboardingReq) (
+	resp *schema.UserOnboardingResp, err error) {
+	onboarding, err := us.userOnboardingRepo.GetOrAddOnboarding(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err = us.refreshOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return us.formatOnboarding(ctx, onboarding), nil
+}
+
+// TakeOnboardingTour mark the tour as taken
+func (us *UserOnboardingService) TakeOnboardingTour(ctx context.Context, req *schema.TakeOnboardingTourReq) (
+	resp *schema.UserOnboardingResp, err error) {
+	onboarding, err := us.userOnboardingRepo.GetOrAddOnboarding(ctx, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.TourTakenAt.IsZero() {
+		onboarding.TourTakenAt = time.Now()
+		if err = us.userOnboardingRepo.UpdateOnboardingSteps(ctx, onboarding); err != nil {
+			return nil, err
+		}
+	}
+	if err = us.refreshOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return us.formatOnboarding(ctx, onboarding), nil
+}
+
+// HasOnboardingBadge whether the user has completed the onboarding and been awarded the badge
+func (us *UserOnboardingService) HasOnboardingBadge(ctx context.Context, userID string) (has bool, err error) {
+	onboarding, exist, err := us.userOnboardingRepo.GetOnboarding(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return exist && !onboarding.CompletedAt.IsZero(), nil
+}
+
+// refreshOnboarding record the steps completed since the last time,
+// a completed step is never reverted, e.g. unfollowing the tags later does not matter
+func (us *UserOnboardingService) refreshOnboarding(ctx context.Context, onboarding *entity.UserOnboarding) (err error) {
+	if !onboarding.CompletedAt.IsZero() {
+		return nil
+	}
+	userInfo, exist, err := us.userRepo.GetByUserID(ctx, onboarding.UserID)
+	if err != nil {
+		return err
+	}
+	if !exist {
+		return errors.BadRequest(reason.UserNotFound)
+	}
+
+	now := time.Now()
+	changed := false
+	if onboarding.EmailVerifiedAt.IsZero() && userInfo.MailStatus == entity.EmailStatusAvailable {
+		onboarding.EmailVerifiedAt, changed = now, true
+	}
+	if onboarding.AvatarSetAt.IsZero() && hasAvatar(userInfo.Avatar) {
+		onboarding.AvatarSetAt, changed = now, true
+	}
+	if onboarding.TagsFollowedAt.IsZero() {
+		tagIDs, err := us.followRepo.GetFollowIDs(ctx, onboarding.UserID, entity.Tag{}.TableName())
+		if err != nil {
+			return err
+		}
+		if len(tagIDs) >= schema.OnboardingFollowTagAmount {
+			onboarding.TagsFollowedAt, changed = now, true
+		}
+	}
+	if onboarding.FirstPostAt.IsZero() && userInfo.QuestionCount+userInfo.AnswerCount > 0 {
+		onboarding.FirstPostAt, changed = now, true
+	}
+	if changed {
+		if err = us.userOnboardingRepo.UpdateOnboardingSteps(ctx, onboarding); err != nil {
+			return err
+		}
+	}
+
+	for _, step := range onboardingSteps(onboarding) {
+		if !step.Completed {
+			return nil
+		}
+	}
+	completed, err := us.userOnboardingRepo.CompleteOnboarding(ctx, onboarding.UserID, now)
+	if err != nil {
+		return err
+	}
+	if completed {
+		log.Infof("user %s completed the onboarding", onboarding.UserID)
+	}
+	onboarding.CompletedAt = now
+	return nil
+}
+
+func (us *UserOnboardingService) formatOnboarding(ctx context.Context, onboarding *entity.UserOnboarding) (
+	resp *schema.UserOnboardingResp) {
+	resp = &schema.UserOnboardingResp{
+		Steps:     onboardingSteps(onboarding),
+		Completed: !onboarding.CompletedAt.IsZero(),
+	}
+	for _, step := range resp.Steps {
+		if step.Completed {
+			resp.CompletedAmount++
+		}
+	}
+	if resp.Completed {
+		resp.CompletedAt = onboarding.CompletedAt.Unix()
+	}
+	cfg, err := us.configService.GetConfigByKey(ctx, UserOnboarded)
+	if err != nil {
+		log.Error(err)
+	} else {
+		resp.RankBonus = cfg.GetIntValue()
+	}
+	return resp
+}
+
+// onboardingSteps the steps of the onboarding checklist in order
+func onboardingSteps(onboarding *entity.UserOnboarding) []*schema.UserOnboardingStep {
+	steps := []struct {
+		key         string
+		completedAt time.Time
+	}{
+		{key: schema.OnboardingStepVerifyEmail, completedAt: onboarding.EmailVerifiedAt},
+		{key: schema.OnboardingStepSetAvatar, completedAt: onboarding.AvatarSetAt},
+		{key: schema.OnboardingStepFollowTags, completedAt: onboarding.TagsFollowedAt},
+		{key: schema.OnboardingStepFirstPost, completedAt: onboarding.FirstPostAt},
+		{key: schema.OnboardingStepTakeTour, completedAt: onboarding.TourTakenAt},
+	}
+	resp := make([]*schema.UserOnboardingStep, 0, len(steps))
+	for _, step := range steps {
+		item := &schema.UserOnboardingStep{Key: step.key, Completed: !step.completedAt.IsZero()}
+		if item.Completed {
+			item.CompletedAt = step.completedAt.Unix()
+		}
+		resp = append(resp, item)
+	}
+	return resp
+}
+
+// hasAvatar whether the user chooses the gravatar or uploads an avatar instead of the default one
+func hasAvatar(avatar string) bool {
+	if len(avatar) == 0 {
+		return false
+	}
+	avatarInfo := &schema.AvatarInfo{}
+	if err := json.Unmarshal([]byte(avatar), avatarInfo); err != nil {
+		// the avatar saved by the old version is the url of the uploaded image
+		return true
+	}
+	return len(avatarInfo.GetURL()) > 0
+}
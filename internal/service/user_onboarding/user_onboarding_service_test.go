@@ -0,0 +1,51 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package user_onboarding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasAvatar(t *testing.T) {
+	assert.False(t, hasAvatar(""))
+	assert.False(t, hasAvatar(`{"type":"default"}`))
+	assert.True(t, hasAvatar(`{"type":"gravatar","gravatar":"https://www.gravatar.com/avatar/1"}`))
+	assert.True(t, hasAvatar(`{"type":"custom","custom":"/uploads/avatar/1.png"}`))
+	assert.False(t, hasAvatar(`{"type":"custom","custom":""}`))
+	assert.True(t, hasAvatar("/uploads/avatar/1.png"))
+}
+
+func TestOnboardingSteps(t *testing.T) {
+	now := time.Now()
+	steps := onboardingSteps(&entity.UserOnboarding{EmailVerifiedAt: now, TourTakenAt: now})
+	assert.Len(t, steps, 5)
+	assert.Equal(t, schema.OnboardingStepVerifyEmail, steps[0].Key)
+	assert.True(t, steps[0].Completed)
+	assert.Equal(t, now.Unix(), steps[0].CompletedAt)
+	assert.False(t, steps[1].Completed)
+	assert.Zero(t, steps[1].CompletedAt)
+	assert.Equal(t, schema.OnboardingStepTakeTour, steps[4].Key)
+	assert.True(t, steps[4].Completed)
+}
@@ -0,0 +1,380 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package username_policy
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/incubator-answer/internal/base/constant"
+	"github.com/apache/incubator-answer/internal/base/handler"
+	"github.com/apache/incubator-answer/internal/base/pager"
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/base/translator"
+	"github.com/apache/incubator-answer/internal/base/validator"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/role"
+	"github.com/apache/incubator-answer/internal/service/siteinfo_common"
+	usercommon "github.com/apache/incubator-answer/internal/service/user_common"
+	"github.com/apache/incubator-answer/pkg/checker"
+	"github.com/segmentfault/pacman/errors"
+)
+
+// UserNameChangeRepo user name change repository
+type UserNameChangeRepo interface {
+	AddUserNameChanges(ctx context.Context, changes []*entity.UserNameChange) (err error)
+	CountUserSelfNameChanges(ctx context.Context, userID string, since time.Time) (count int64, err error)
+	GetUserNameChangesSince(ctx context.Context, userID string, since time.Time) (changes []*entity.UserNameChange, err error)
+	GetUserNameChangePage(ctx context.Context, page, pageSize int, cond *entity.UserNameChange) (
+		changes []*entity.UserNameChange, total int64, err error)
+}
+
+// UsernameHistoryRepo username history repository
+type UsernameHistoryRepo interface {
+	SaveUsernameHistory(ctx context.Context, userID, oldUsername, newUsername string) (err error)
+	GetUsernameHistory(ctx context.Context, username string) (history *entity.UsernameHistory, exist bool, err error)
+}
+
+var usernameCharsetRegs = map[string]*regexp.Regexp{
+	schema.UsernameCharsetStandard:     regexp.MustCompile(`^[a-z0-9._-]+$`),
+	schema.UsernameCharsetAlphanumeric: regexp.MustCompile(`^[a-z0-9]+$`),
+}
+
+// UsernamePolicyService the rules of the usernames and the display names, and the history of the usernames
+type UsernamePolicyService struct {
+	usernameHistoryRepo UsernameHistoryRepo
+	userNameChangeRepo  UserNameChangeRepo
+	userRepo            usercommon.UserRepo
+	userRoleRelService  *role.UserRoleRelService
+	siteInfoRepo        siteinfo_common.SiteInfoRepo
+	siteInfoService     siteinfo_common.SiteInfoCommonService
+}
+
+// NewUsernamePolicyService new username policy service
+func NewUsernamePolicyService(
+	usernameHistoryRepo UsernameHistoryRepo,
+	userNameChangeRepo UserNameChangeRepo,
+	userRepo usercommon.UserRepo,
+	userRoleRelService *role.UserRoleRelService,
+	siteInfoRepo siteinfo_common.SiteInfoRepo,
+	siteInfoService siteinfo_common.SiteInfoCommonService,
+) *UsernamePolicyService {
+	return &UsernamePolicyService{
+		usernameHistoryRepo: usernameHistoryRepo,
+		userNameChangeRepo:  userNameChangeRepo,
+		userRepo:            userRepo,
+		userRoleRelService:  userRoleRelService,
+		siteInfoRepo:        siteInfoRepo,
+		siteInfoService:     siteInfoService,
+	}
+}
+
+// GetUsernamePolicy get the username policy, the default policy is used before the admin changes it
+func (us *UsernamePolicyService) GetUsernamePolicy(ctx context.Context) (resp *schema.SiteUsernamePolicyReq, err error) {
+	resp = schema.DefaultUsernamePolicy()
+	if err = us.siteInfoService.GetSiteInfoByType(ctx, constant.SiteTypeUsernamePolicy, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdateUsernamePolicy update the username policy
+func (us *UsernamePolicyService) UpdateUsernamePolicy(ctx context.Context, req *schema.SiteUsernamePolicyReq) (err error) {
+	if req.MinLength > req.MaxLength {
+		return errors.BadRequest(reason.UsernamePolicyInvalid)
+	}
+	reservedNames := make([]string, 0, len(req.ReservedNames))
+	for _, name := range req.ReservedNames {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			reservedNames = append(reservedNames, name)
+		}
+	}
+	req.ReservedNames = reservedNames
+	content, _ := json.Marshal(req)
+	data := &entity.SiteInfo{
+		Type:    constant.SiteTypeUsernamePolicy,
+		Content: string(content),
+	}
+	return us.siteInfoRepo.SaveByType(ctx, constant.SiteTypeUsernamePolicy, data)
+}
+
+// CheckUsername check the username which the user signs up or renames to. The username which is used
+// by other users before is not allowed either, because its profile page is redirected to them.
+func (us *UsernamePolicyService) CheckUsername(ctx context.Context, field, username, userID string) (
+	errFields []*validator.FormErrorField, err error) {
+	policy, err := us.GetUsernamePolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	errReason, errMsg := "", ""
+	length := len([]rune(username))
+	switch {
+	case length < policy.MinLength || length > policy.MaxLength:
+		errReason = reason.UsernameLengthInvalid
+		errMsg = translator.TrWithData(handler.GetLangByCtx(ctx), errReason,
+			&schema.UsernameLengthTrTplData{Min: policy.MinLength, Max: policy.MaxLength})
+	case !usernameCharsetRegs[policy.Charset].MatchString(username):
+		errReason = reason.UsernameCharsetInvalid
+	case checker.IsReservedUsername(username) || isReservedName(username, policy.ReservedNames):
+		errReason = reason.UsernameReserved
+	}
+	if len(errReason) == 0 {
+		history, exist, err := us.usernameHistoryRepo.GetUsernameHistory(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+		if exist && history.UserID != userID {
+			errReason = reason.UsernameDuplicate
+		}
+	}
+	if len(errReason) == 0 && policy.CheckImpersonation {
+		impersonated, err := us.isImpersonation(ctx, username, userID)
+		if err != nil {
+			return nil, err
+		}
+		if impersonated {
+			errReason = reason.UsernameImpersonation
+		}
+	}
+	if len(errReason) == 0 {
+		return nil, nil
+	}
+	if len(errMsg) == 0 {
+		errMsg = translator.Tr(handler.GetLangByCtx(ctx), errReason)
+	}
+	errFields = append(errFields, &validator.FormErrorField{ErrorField: field, ErrorMsg: errMsg})
+	return errFields, errors.BadRequest(errReason).WithMsg(errMsg)
+}
+
+// CheckDisplayName check whether the display name impersonates the staff
+func (us *UsernamePolicyService) CheckDisplayName(ctx context.Context, field, displayName, userID string) (
+	errFields []*validator.FormErrorField, err error) {
+	policy, err := us.GetUsernamePolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.CheckImpersonation {
+		return nil, nil
+	}
+	impersonated, err := us.isImpersonation(ctx, displayName, userID)
+	if err != nil || !impersonated {
+		return nil, err
+	}
+	errMsg := translator.Tr(handler.GetLangByCtx(ctx), reason.DisplayNameImpersonation)
+	errFields = append(errFields, &validator.FormErrorField{ErrorField: field, ErrorMsg: errMsg})
+	return errFields, errors.BadRequest(reason.DisplayNameImpersonation)
+}
+
+// CheckChangeLimit check whether the user changes the username or the display name too often
+func (us *UsernamePolicyService) CheckChangeLimit(ctx context.Context, field, userID string) (
+	errFields []*validator.FormErrorField, err error) {
+	policy, err := us.GetUsernamePolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy.ChangeLimit <= 0 || policy.ChangeLimitDays <= 0 {
+		return nil, nil
+	}
+	since := time.Now().AddDate(0, 0, -policy.ChangeLimitDays)
+	count, err := us.userNameChangeRepo.CountUserSelfNameChanges(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	if count < int64(policy.ChangeLimit) {
+		return nil, nil
+	}
+	errMsg := translator.TrWithData(handler.GetLangByCtx(ctx), reason.NameChangeTooFrequent,
+		&schema.NameChangeLimitTrTplData{Limit: policy.ChangeLimit, Days: policy.ChangeLimitDays})
+	errFields = append(errFields, &validator.FormErrorField{ErrorField: field, ErrorMsg: errMsg})
+	return errFields, errors.BadRequest(reason.NameChangeTooFrequent).WithMsg(errMsg)
+}
+
+// SaveNameChanges record the changes of the username and the display name, the old username is kept
+// so the old profile url is redirected to the user
+func (us *UsernamePolicyService) SaveNameChanges(ctx context.Context, operatorUserID string,
+	oldUser, newUser *entity.User) (err error) {
+	changes := make([]*entity.UserNameChange, 0, 2)
+	if oldUser.Username != newUser.Username {
+		changes = append(changes, &entity.UserNameChange{
+			UserID:         oldUser.ID,
+			Field:          entity.UserNameChangeFieldUsername,
+			OldName:        oldUser.Username,
+			NewName:        newUser.Username,
+			OperatorUserID: operatorUserID,
+		})
+	}
+	if oldUser.DisplayName != newUser.DisplayName {
+		changes = append(changes, &entity.UserNameChange{
+			UserID:         oldUser.ID,
+			Field:          entity.UserNameChangeFieldDisplayName,
+			OldName:        oldUser.DisplayName,
+			NewName:        newUser.DisplayName,
+			OperatorUserID: operatorUserID,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err = us.userNameChangeRepo.AddUserNameChanges(ctx, changes); err != nil {
+		return err
+	}
+	if len(oldUser.Username) == 0 || oldUser.Username == newUser.Username {
+		return nil
+	}
+	return us.usernameHistoryRepo.SaveUsernameHistory(ctx, oldUser.ID, oldUser.Username, newUser.Username)
+}
+
+// GetFormerNames get the names which the user used in the recent days set by the policy
+func (us *UsernamePolicyService) GetFormerNames(ctx context.Context, user *entity.User) (formerNames []string, err error) {
+	formerNames = make([]string, 0)
+	policy, err := us.GetUsernamePolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if policy.FormerNameDays <= 0 {
+		return formerNames, nil
+	}
+	since := time.Now().AddDate(0, 0, -policy.FormerNameDays)
+	changes, err := us.userNameChangeRepo.GetUserNameChangesSince(ctx, user.ID, since)
+	if err != nil {
+		return nil, err
+	}
+	exist := map[string]bool{user.Username: true, user.DisplayName: true}
+	for _, change := range changes {
+		if len(change.OldName) == 0 || exist[change.OldName] {
+			continue
+		}
+		exist[change.OldName] = true
+		formerNames = append(formerNames, change.OldName)
+	}
+	return formerNames, nil
+}
+
+// GetNameChangePage get the changes of the usernames and the display names
+func (us *UsernamePolicyService) GetNameChangePage(ctx context.Context, req *schema.GetNameChangePageReq) (
+	pageModel *pager.PageModel, err error) {
+	cond := &entity.UserNameChange{}
+	if len(req.Username) > 0 {
+		user, exist, err := us.userRepo.GetByUsername(ctx, req.Username)
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			cond.UserID = user.ID
+		} else if userID, renamed, err := us.GetRenamedUserID(ctx, req.Username); err != nil {
+			return nil, err
+		} else if renamed {
+			cond.UserID = userID
+		} else {
+			return pager.NewPageModel(0, make([]*schema.NameChangeResp, 0)), nil
+		}
+	}
+	changes, total, err := us.userNameChangeRepo.GetUserNameChangePage(ctx, req.Page, req.PageSize, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*schema.NameChangeResp, 0, len(changes))
+	for _, change := range changes {
+		resp = append(resp, &schema.NameChangeResp{
+			ID:             change.ID,
+			CreatedAt:      change.CreatedAt.Unix(),
+			UserID:         change.UserID,
+			Field:          change.Field,
+			OldName:        change.OldName,
+			NewName:        change.NewName,
+			OperatorUserID: change.OperatorUserID,
+		})
+	}
+	return pager.NewPageModel(total, resp), nil
+}
+
+// GetRenamedUserID get the user who used the username before
+func (us *UsernamePolicyService) GetRenamedUserID(ctx context.Context, username string) (
+	userID string, exist bool, err error) {
+	history, exist, err := us.usernameHistoryRepo.GetUsernameHistory(ctx, username)
+	if err != nil || !exist {
+		return "", false, err
+	}
+	return history.UserID, true, nil
+}
+
+// isImpersonation check whether the name looks the same as the username or the display name of the staff
+func (us *UsernamePolicyService) isImpersonation(ctx context.Context, name, userID string) (bool, error) {
+	skeleton := nameSkeleton(name)
+	if len(skeleton) < 3 {
+		return false, nil
+	}
+	rels, err := us.userRoleRelService.GetUserByRoleID(ctx, []int{role.RoleAdminID, role.RoleModeratorID})
+	if err != nil {
+		return false, err
+	}
+	staffIDs := make([]string, 0, len(rels))
+	for _, rel := range rels {
+		if rel.UserID != userID {
+			staffIDs = append(staffIDs, rel.UserID)
+		}
+	}
+	if len(staffIDs) == 0 {
+		return false, nil
+	}
+	staff, err := us.userRepo.BatchGetByID(ctx, staffIDs)
+	if err != nil {
+		return false, err
+	}
+	for _, user := range staff {
+		if nameSkeleton(user.Username) == skeleton || nameSkeleton(user.DisplayName) == skeleton {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isReservedName(username string, reservedNames []string) bool {
+	skeleton := nameSkeleton(username)
+	for _, name := range reservedNames {
+		if nameSkeleton(name) == skeleton {
+			return true
+		}
+	}
+	return false
+}
+
+// lookAlikes the characters which are often used to imitate the letters
+var lookAlikes = strings.NewReplacer("0", "o", "1", "l", "i", "l", "3", "e", "4", "a", "5", "s", "7", "t",
+	"rn", "m", "vv", "w")
+
+// nameSkeleton the form of the name used to compare the names which look the same, the case, the spaces,
+// the separators and the look-alike characters are ignored
+func nameSkeleton(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return lookAlikes.Replace(b.String())
+}
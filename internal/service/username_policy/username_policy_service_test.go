@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package username_policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-answer/internal/base/reason"
+	"github.com/apache/incubator-answer/internal/entity"
+	"github.com/apache/incubator-answer/internal/schema"
+	"github.com/apache/incubator-answer/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/segmentfault/pacman/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testUserNameChangeRepo struct {
+	UserNameChangeRepo
+	changes []*entity.UserNameChange
+}
+
+func (r *testUserNameChangeRepo) AddUserNameChanges(_ context.Context, changes []*entity.UserNameChange) error {
+	r.changes = append(r.changes, changes...)
+	return nil
+}
+
+func (r *testUserNameChangeRepo) CountUserSelfNameChanges(_ context.Context, userID string, since time.Time) (
+	count int64, err error) {
+	for _, change := range r.changes {
+		if change.UserID == userID && change.OperatorUserID == userID && !change.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *testUserNameChangeRepo) GetUserNameChangesSince(_ context.Context, userID string, since time.Time) (
+	changes []*entity.UserNameChange, err error) {
+	for i := len(r.changes) - 1; i >= 0; i-- {
+		if r.changes[i].UserID == userID && !r.changes[i].CreatedAt.Before(since) {
+			changes = append(changes, r.changes[i])
+		}
+	}
+	return changes, nil
+}
+
+type testUsernameHistoryRepo struct {
+	UsernameHistoryRepo
+	history map[string]string
+}
+
+func (r *testUsernameHistoryRepo) SaveUsernameHistory(_ context.Context, userID, oldUsername, _ string) error {
+	r.history[oldUsername] = userID
+	return nil
+}
+
+func newTestUsernamePolicyService(ctl *gomock.Controller, policy *schema.SiteUsernamePolicyReq) (
+	*UsernamePolicyService, *testUserNameChangeRepo, *testUsernameHistoryRepo) {
+	changeRepo := &testUserNameChangeRepo{}
+	historyRepo := &testUsernameHistoryRepo{history: make(map[string]string)}
+	siteInfoService := mock.NewMockSiteInfoCommonService(ctl)
+	siteInfoService.EXPECT().GetSiteInfoByType(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(_ context.Context, _ string, resp interface{}) error {
+			*resp.(*schema.SiteUsernamePolicyReq) = *policy
+			return nil
+		})
+	us := NewUsernamePolicyService(historyRepo, changeRepo, nil, nil, nil, siteInfoService)
+	return us, changeRepo, historyRepo
+}
+
+func TestNameSkeleton(t *testing.T) {
+	assert.Equal(t, nameSkeleton("admin"), nameSkeleton("Adm1n"))
+	assert.Equal(t, nameSkeleton("support"), nameSkeleton("Sup-p0rt"))
+	assert.Equal(t, nameSkeleton("John Smith"), nameSkeleton("john_smith"))
+	assert.Equal(t, nameSkeleton("mary"), nameSkeleton("rnary"))
+	assert.NotEqual(t, nameSkeleton("admin"), nameSkeleton("admins"))
+}
+
+func TestIsReservedName(t *testing.T) {
+	reservedNames := []string{"support", "help-desk"}
+	assert.True(t, isReservedName("supp0rt", reservedNames))
+	assert.True(t, isReservedName("helpdesk", reservedNames))
+	assert.False(t, isReservedName("supporter", reservedNames))
+}
+
+func TestCheckChangeLimit(t *testing.T) {
+	policy := schema.DefaultUsernamePolicy()
+	policy.ChangeLimit, policy.ChangeLimitDays = 2, 30
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, changeRepo, _ := newTestUsernamePolicyService(ctl, policy)
+	ctx := context.TODO()
+	now := time.Now()
+	changeRepo.changes = []*entity.UserNameChange{
+		{UserID: "1", OperatorUserID: "1", CreatedAt: now.AddDate(0, 0, -40)},
+		{UserID: "1", OperatorUserID: "1", CreatedAt: now.AddDate(0, 0, -10)},
+		// the change made by the admin is not counted
+		{UserID: "1", OperatorUserID: "2", CreatedAt: now.AddDate(0, 0, -5)},
+	}
+
+	errFields, err := us.CheckChangeLimit(ctx, "username", "1")
+	require.NoError(t, err)
+	assert.Empty(t, errFields)
+
+	changeRepo.changes = append(changeRepo.changes,
+		&entity.UserNameChange{UserID: "1", OperatorUserID: "1", CreatedAt: now.AddDate(0, 0, -1)})
+	errFields, err = us.CheckChangeLimit(ctx, "username", "1")
+	require.Error(t, err)
+	assert.Equal(t, reason.NameChangeTooFrequent, err.(*errors.Error).Reason)
+	require.Len(t, errFields, 1)
+	assert.Equal(t, "username", errFields[0].ErrorField)
+
+	// the limit is turned off
+	policy.ChangeLimit = 0
+	errFields, err = us.CheckChangeLimit(ctx, "username", "1")
+	require.NoError(t, err)
+	assert.Empty(t, errFields)
+}
+
+func TestSaveNameChanges(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, changeRepo, historyRepo := newTestUsernamePolicyService(ctl, schema.DefaultUsernamePolicy())
+	ctx := context.TODO()
+	oldUser := &entity.User{ID: "1", Username: "old_name", DisplayName: "Old Name"}
+
+	// nothing is changed
+	require.NoError(t, us.SaveNameChanges(ctx, "1", oldUser, &entity.User{ID: "1", Username: "old_name",
+		DisplayName: "Old Name"}))
+	assert.Empty(t, changeRepo.changes)
+	assert.Empty(t, historyRepo.history)
+
+	// only the display name is changed, the username history is not saved
+	require.NoError(t, us.SaveNameChanges(ctx, "1", oldUser, &entity.User{ID: "1", Username: "old_name",
+		DisplayName: "New Name"}))
+	require.Len(t, changeRepo.changes, 1)
+	assert.Equal(t, entity.UserNameChangeFieldDisplayName, changeRepo.changes[0].Field)
+	assert.Empty(t, historyRepo.history)
+
+	// the admin renames both names
+	changeRepo.changes = nil
+	require.NoError(t, us.SaveNameChanges(ctx, "2", oldUser, &entity.User{ID: "1", Username: "new_name",
+		DisplayName: "New Name"}))
+	require.Len(t, changeRepo.changes, 2)
+	assert.Equal(t, &entity.UserNameChange{UserID: "1", Field: entity.UserNameChangeFieldUsername,
+		OldName: "old_name", NewName: "new_name", OperatorUserID: "2"}, changeRepo.changes[0])
+	assert.Equal(t, &entity.UserNameChange{UserID: "1", Field: entity.UserNameChangeFieldDisplayName,
+		OldName: "Old Name", NewName: "New Name", OperatorUserID: "2"}, changeRepo.changes[1])
+	assert.Equal(t, map[string]string{"old_name": "1"}, historyRepo.history)
+}
+
+func TestGetFormerNames(t *testing.T) {
+	policy := schema.DefaultUsernamePolicy()
+	policy.FormerNameDays = 30
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	us, changeRepo, _ := newTestUsernamePolicyService(ctl, policy)
+	ctx := context.TODO()
+	now := time.Now()
+	user := &entity.User{ID: "1", Username: "current", DisplayName: "Current"}
+	changeRepo.changes = []*entity.UserNameChange{
+		{UserID: "1", OldName: "too_old", CreatedAt: now.AddDate(0, 0, -40)},
+		{UserID: "1", OldName: "first", CreatedAt: now.AddDate(0, 0, -20)},
+		{UserID: "1", OldName: "", CreatedAt: now.AddDate(0, 0, -15)},
+		{UserID: "2", OldName: "other", CreatedAt: now.AddDate(0, 0, -10)},
+		// the user changed back to the current name
+		{UserID: "1", OldName: "Current", CreatedAt: now.AddDate(0, 0, -5)},
+		{UserID: "1", OldName: "second", CreatedAt: now.AddDate(0, 0, -2)},
+		{UserID: "1", OldName: "first", CreatedAt: now.AddDate(0, 0, -1)},
+	}
+
+	formerNames, err := us.GetFormerNames(ctx, user)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, formerNames)
+
+	// the former names are not shown
+	policy.FormerNameDays = 0
+	formerNames, err = us.GetFormerNames(ctx, user)
+	require.NoError(t, err)
+	assert.Empty(t, formerNames)
+}
@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-answer/configs"
+	"github.com/apache/incubator-answer/internal/cli"
+	"github.com/apache/incubator-answer/pkg/dir"
+)
+
+var (
+	disposableEmailDomainMapping = make(map[string]bool)
+	disposableEmailDomainInit    sync.Once
+)
+
+func initDisposableEmailDomain() {
+	disposableEmailDomainsFilePath := filepath.Join(cli.ConfigFileDir, cli.DefaultDisposableEmailDomainsFileName)
+	if dir.CheckFileExist(disposableEmailDomainsFilePath) {
+		// if disposable email domains file exists, read it and replace configuration
+		disposableEmailDomainsFile, err := os.ReadFile(disposableEmailDomainsFilePath)
+		if err == nil {
+			configs.DisposableEmailDomains = disposableEmailDomainsFile
+		}
+	}
+	var domains []string
+	_ = json.Unmarshal(configs.DisposableEmailDomains, &domains)
+	for _, domain := range domains {
+		disposableEmailDomainMapping[domain] = true
+	}
+}
+
+// IsDisposableEmail checks whether the email is provided by a known disposable email service
+func IsDisposableEmail(email string) bool {
+	disposableEmailDomainInit.Do(initDisposableEmailDomain)
+	domain := EmailDomain(email)
+	for len(domain) > 0 {
+		if disposableEmailDomainMapping[domain] {
+			return true
+		}
+		idx := strings.Index(domain, ".")
+		if idx < 0 {
+			break
+		}
+		domain = domain[idx+1:]
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDisposableEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{"user@10minutemail.com", true},
+		{"user@YOPmail.net", true},
+		// the subdomains of the disposable email providers are disposable as well
+		{"user@inbox.yopmail.net", true},
+		{"user@example.com", false},
+		{"user@notyopmail.net", false},
+		{"user", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsDisposableEmail(tt.email), tt.email)
+	}
+}
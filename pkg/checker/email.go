@@ -34,3 +34,30 @@ func EmailInAllowEmailDomain(email string, allowEmailDomains []string) bool {
 
 	return false
 }
+
+// EmailInBlockedEmailDomain checks whether the email domain or its parent domain is blocked
+func EmailInBlockedEmailDomain(email string, blockedEmailDomains []string) bool {
+	domain := EmailDomain(email)
+	if len(domain) == 0 {
+		return false
+	}
+	for _, blocked := range blockedEmailDomains {
+		blocked = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(blocked), "@"))
+		if len(blocked) == 0 {
+			continue
+		}
+		if domain == blocked || strings.HasSuffix(domain, "."+blocked) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailDomain get the lower case domain of the email
+func EmailDomain(email string) string {
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[idx+1:]))
+}
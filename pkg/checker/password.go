@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
@@ -35,6 +36,27 @@ const (
 
 const (
 	PasswordCannotContainSpaces = "error.password.space_invalid"
+	PasswordTooShort            = "error.password.too_short"
+	PasswordNeedUppercase       = "error.password.need_uppercase"
+	PasswordNeedLowercase       = "error.password.need_lowercase"
+	PasswordNeedNumber          = "error.password.need_number"
+	PasswordNeedSymbol          = "error.password.need_symbol"
+)
+
+// PasswordPolicy the rules configured by admin that the password must satisfy
+type PasswordPolicy struct {
+	MinLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireNumber    bool
+	RequireSymbol    bool
+}
+
+var (
+	passwordNumberRegexp    = regexp.MustCompile(`[0-9]+`)
+	passwordLowercaseRegexp = regexp.MustCompile(`[a-z]+`)
+	passwordUppercaseRegexp = regexp.MustCompile(`[A-Z]+`)
+	passwordSymbolRegexp    = regexp.MustCompile(`[~!@#$%^&*?_-]+`)
 )
 
 // CheckPassword checks the password strength
@@ -64,3 +86,26 @@ func CheckPassword(password string) error {
 	}
 	return nil
 }
+
+// CheckPasswordPolicy checks the password satisfies the policy, the error message is the reason of the first failed rule
+func CheckPasswordPolicy(password string, policy PasswordPolicy) error {
+	if err := CheckPassword(password); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(password) < policy.MinLength {
+		return fmt.Errorf(PasswordTooShort)
+	}
+	if policy.RequireUppercase && !passwordUppercaseRegexp.MatchString(password) {
+		return fmt.Errorf(PasswordNeedUppercase)
+	}
+	if policy.RequireLowercase && !passwordLowercaseRegexp.MatchString(password) {
+		return fmt.Errorf(PasswordNeedLowercase)
+	}
+	if policy.RequireNumber && !passwordNumberRegexp.MatchString(password) {
+		return fmt.Errorf(PasswordNeedNumber)
+	}
+	if policy.RequireSymbol && !passwordSymbolRegexp.MatchString(password) {
+		return fmt.Errorf(PasswordNeedSymbol)
+	}
+	return nil
+}
@@ -20,10 +20,14 @@
 package checker
 
 import (
+	"net"
 	"net/url"
 	"strings"
 )
 
+// carrierGradeNAT shared address space (RFC 6598), it is not covered by net.IP.IsPrivate
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
 func IsURL(str string) bool {
 	s := strings.ToLower(str)
 
@@ -41,3 +45,16 @@ func IsURL(str string) bool {
 	}
 	return u.Scheme == "http" || u.Scheme == "https"
 }
+
+// IsPublicIP whether the ip is a public unicast address, the loopback, private, link-local
+// and other special addresses are not allowed to be requested by the server
+func IsPublicIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	return !carrierGradeNAT.Contains(ip)
+}
@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package delta
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// BlockSize the number of the runes of the block which is looked up in the base text,
+// only the matches not shorter than the block are copied from the base text
+const BlockSize = 16
+
+// ErrInvalidDelta the delta is broken or does not match the base text
+var ErrInvalidDelta = errors.New("invalid delta")
+
+// Encode describe the target text by the instructions copying the runes from the base text
+// and inserting the new runes, the copy instruction is "C<offset>,<length>;" and the insert
+// instruction is "I<length>:<runes>", the offsets and the lengths are counted in runes.
+func Encode(base, target string) string {
+	baseRunes, targetRunes := []rune(base), []rune(target)
+	index := make(map[string]int)
+	for i := 0; i+BlockSize <= len(baseRunes); i += BlockSize {
+		block := string(baseRunes[i : i+BlockSize])
+		if _, ok := index[block]; !ok {
+			index[block] = i
+		}
+	}
+
+	var builder strings.Builder
+	literalStart := 0
+	for i := 0; i+BlockSize <= len(targetRunes); {
+		offset, ok := index[string(targetRunes[i:i+BlockSize])]
+		if !ok {
+			i++
+			continue
+		}
+		// extend the match backward into the pending literal and forward as far as possible
+		start := i
+		for start > literalStart && offset > 0 && targetRunes[start-1] == baseRunes[offset-1] {
+			start--
+			offset--
+		}
+		end := i + BlockSize
+		baseEnd := offset + end - start
+		for end < len(targetRunes) && baseEnd < len(baseRunes) && targetRunes[end] == baseRunes[baseEnd] {
+			end++
+			baseEnd++
+		}
+		writeInsert(&builder, targetRunes[literalStart:start])
+		builder.WriteString("C" + strconv.Itoa(offset) + "," + strconv.Itoa(end-start) + ";")
+		i, literalStart = end, end
+	}
+	writeInsert(&builder, targetRunes[literalStart:])
+	return builder.String()
+}
+
+// Decode restore the target text from the base text and the delta
+func Decode(base, delta string) (string, error) {
+	baseRunes, deltaRunes := []rune(base), []rune(delta)
+	var builder strings.Builder
+	for i := 0; i < len(deltaRunes); {
+		op := deltaRunes[i]
+		i++
+		switch op {
+		case 'C':
+			offset, next, err := readInt(deltaRunes, i, ',')
+			if err != nil {
+				return "", err
+			}
+			length, next, err := readInt(deltaRunes, next, ';')
+			if err != nil {
+				return "", err
+			}
+			if offset+length > len(baseRunes) {
+				return "", ErrInvalidDelta
+			}
+			builder.WriteString(string(baseRunes[offset : offset+length]))
+			i = next
+		case 'I':
+			length, next, err := readInt(deltaRunes, i, ':')
+			if err != nil {
+				return "", err
+			}
+			if next+length > len(deltaRunes) {
+				return "", ErrInvalidDelta
+			}
+			builder.WriteString(string(deltaRunes[next : next+length]))
+			i = next + length
+		default:
+			return "", ErrInvalidDelta
+		}
+	}
+	return builder.String(), nil
+}
+
+func writeInsert(builder *strings.Builder, runes []rune) {
+	if len(runes) == 0 {
+		return
+	}
+	builder.WriteString("I" + strconv.Itoa(len(runes)) + ":")
+	builder.WriteString(string(runes))
+}
+
+// readInt read the number from the start position until the separator,
+// it returns the position after the separator
+func readInt(runes []rune, start int, separator rune) (num, next int, err error) {
+	end := start
+	for end < len(runes) && runes[end] != separator {
+		end++
+	}
+	if end == len(runes) {
+		return 0, 0, ErrInvalidDelta
+	}
+	num, err = strconv.Atoi(string(runes[start:end]))
+	if err != nil || num < 0 {
+		return 0, 0, ErrInvalidDelta
+	}
+	return num, end + 1, nil
+}
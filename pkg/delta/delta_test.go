@@ -0,0 +1,64 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package delta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	paragraph := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+	cases := []struct {
+		name, base, target string
+	}{
+		{"empty", "", ""},
+		{"empty base", "", paragraph},
+		{"empty target", paragraph, ""},
+		{"same", paragraph, paragraph},
+		{"edited in the middle", paragraph, paragraph[:300] + "a new sentence is added here." + paragraph[300:]},
+		{"edited in several places", "title: " + paragraph, "new title: " + paragraph[:100] + "fixed" + paragraph[120:] + "!"},
+		{"multibyte runes", strings.Repeat("你好，世界。这是一个测试。", 10), strings.Repeat("你好，世界。这是一个测试。", 5) + "修改" + strings.Repeat("你好，世界。这是一个测试。", 5)},
+		{"separators in literal", "C1,2;I3:", "C1,2;I3: and more C0,9;"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			delta := Encode(c.base, c.target)
+			decoded, err := Decode(c.base, delta)
+			assert.NoError(t, err)
+			assert.Equal(t, c.target, decoded)
+		})
+	}
+}
+
+func TestEncodeShorterForSimilarText(t *testing.T) {
+	paragraph := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+	target := paragraph[:300] + "a new sentence is added here." + paragraph[300:]
+	assert.Less(t, len(Encode(paragraph, target)), len(target)/4)
+}
+
+func TestDecodeInvalidDelta(t *testing.T) {
+	for _, delta := range []string{"X", "C0,100;", "C1", "I10:abc", "I-1:", "Cx,1;"} {
+		_, err := Decode("base text", delta)
+		assert.ErrorIs(t, err, ErrInvalidDelta, delta)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Package grapheme count the user-perceived characters of the text, it is a simplified version of the
+// grapheme cluster boundaries in Unicode Standard Annex #29 which covers the combining marks, the emoji
+// sequences, the flags and the conjoining Hangul jamo.
+package grapheme
+
+import "unicode"
+
+const (
+	zeroWidthJoiner = '\u200d'
+	carriageReturn  = '\r'
+	lineFeed        = '\n'
+)
+
+type hangulType int
+
+const (
+	hangulNone hangulType = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+// Count count the user-perceived characters of the text, e.g. "e" with the combining acute accent, the emoji
+// with the skin tone, the family emoji joined by the zero width joiners, the flag and the Hangul syllable
+// written in the conjoining jamo are all counted as one character. Each CJK ideograph is one character.
+func Count(s string) (count int) {
+	var (
+		prev          rune = -1
+		prevHangul         = hangulNone
+		joined             = false
+		oddIndicators      = false
+	)
+	for _, r := range s {
+		currentHangul := getHangulType(r)
+		switch {
+		case prev < 0:
+			count++
+		case prev == carriageReturn && r == lineFeed:
+		case isExtend(r) || r == zeroWidthJoiner:
+		case joined && isPictographic(r):
+		case isRegionalIndicator(r) && oddIndicators:
+		case joinsHangul(prevHangul, currentHangul):
+		default:
+			count++
+		}
+
+		joined = r == zeroWidthJoiner
+		if isRegionalIndicator(r) {
+			oddIndicators = !oddIndicators
+		} else {
+			oddIndicators = false
+		}
+		if !isExtend(r) {
+			prevHangul = currentHangul
+		}
+		prev = r
+	}
+	return count
+}
+
+// isExtend the characters extending the one before them
+func isExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc) ||
+		r == '\u200c' || // zero width non-joiner
+		(r >= 0x1f3fb && r <= 0x1f3ff) || // emoji modifiers
+		(r >= 0xe0020 && r <= 0xe007f) // tags
+}
+
+// isPictographic the characters which are joined into the emoji sequence after the zero width joiner
+func isPictographic(r rune) bool {
+	return unicode.Is(unicode.So, r) || (r >= 0x1f000 && r <= 0x1faff) || (r >= 0x2600 && r <= 0x27bf)
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
+
+func getHangulType(r rune) hangulType {
+	switch {
+	case (r >= 0x1100 && r <= 0x115f) || (r >= 0xa960 && r <= 0xa97c):
+		return hangulL
+	case (r >= 0x1160 && r <= 0x11a7) || (r >= 0xd7b0 && r <= 0xd7c6):
+		return hangulV
+	case (r >= 0x11a8 && r <= 0x11ff) || (r >= 0xd7cb && r <= 0xd7fb):
+		return hangulT
+	case r >= 0xac00 && r <= 0xd7a3:
+		if (r-0xac00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+// joinsHangul whether the jamo continues the Hangul syllable before it
+func joinsHangul(prev, current hangulType) bool {
+	switch prev {
+	case hangulL:
+		return current == hangulL || current == hangulV || current == hangulLV || current == hangulLVT
+	case hangulLV, hangulV:
+		return current == hangulV || current == hangulT
+	case hangulLVT, hangulT:
+		return current == hangulT
+	}
+	return false
+}
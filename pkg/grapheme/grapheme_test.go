@@ -0,0 +1,56 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package grapheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCount(t *testing.T) {
+	cases := map[string]int{
+		"":      0,
+		"hello": 5,
+		"你好，世界": 5,
+		"こんにちは": 5,
+		"한국어":   3,
+		// 한 written in the conjoining jamo
+		"\u1112\u1161\u11ab": 1,
+		// e with the combining acute accent
+		"e\u0301": 1,
+		// thumbs up with the skin tone
+		"\U0001f44d\U0001f3fd": 1,
+		// family joined by the zero width joiners
+		"\U0001f468\u200d\U0001f469\u200d\U0001f467": 1,
+		// the flags of China and Japan, and an unpaired regional indicator
+		"\U0001f1e8\U0001f1f3\U0001f1ef\U0001f1f5": 2,
+		"\U0001f1e8\U0001f1f3\U0001f1ef":           2,
+		// smiling face with the variation selector
+		"\u263a\ufe0f": 1,
+		"a\r\nb":       3,
+		"\u0301a":      2,
+		"x\u200dy":     2,
+		"日本語 title":    9,
+	}
+	for s, want := range cases {
+		assert.Equal(t, want, Count(s), s)
+	}
+}
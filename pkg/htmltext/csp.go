@@ -0,0 +1,59 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package htmltext
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	openTagRe            = regexp.MustCompile(`(?s)<[a-zA-Z][^>]*>`)
+	inlineEventHandlerRe = regexp.MustCompile(`(?i)\s+on[a-z]+\s*=\s*("[^"]*"|'[^']*'|[^\s>]+)`)
+	javascriptURLRe      = regexp.MustCompile(`(?i)\s+(href|src|action|formaction|xlink:href)\s*=\s*("\s*javascript:[^"]*"|'\s*javascript:[^']*'|javascript:[^\s>]+)`)
+	scriptOpenTagRe      = regexp.MustCompile(`(?i)<script\b`)
+	closeTagRe           = regexp.MustCompile(`(?i)</(script|style)`)
+)
+
+// StripInlineEventHandlers removes the inline event handlers (e.g. onclick) and javascript: URLs from the html.
+// These scripts can't be allowed by the nonce of Content-Security-Policy, so they never run when CSP is enabled.
+func StripInlineEventHandlers(html string) string {
+	return openTagRe.ReplaceAllStringFunc(html, func(tag string) string {
+		tag = inlineEventHandlerRe.ReplaceAllString(tag, "")
+		return javascriptURLRe.ReplaceAllString(tag, "")
+	})
+}
+
+// AddScriptNonce adds the nonce attribute to all script tags in the html
+func AddScriptNonce(html, nonce string) string {
+	if len(nonce) == 0 {
+		return html
+	}
+	return scriptOpenTagRe.ReplaceAllString(html, `<script nonce="`+nonce+`"`)
+}
+
+// EscapeCloseTag escapes the closing script and style tags in the css or javascript code,
+// so that the code can't break out of the tag which it is embedded in.
+func EscapeCloseTag(code string) string {
+	if !strings.Contains(code, "</") {
+		return code
+	}
+	return closeTagRe.ReplaceAllString(code, `<\/$1`)
+}
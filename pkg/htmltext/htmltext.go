@@ -30,6 +30,7 @@ import (
 	"github.com/Chain-Zhang/pinyin"
 	"github.com/Machiel/slugify"
 	strip "github.com/grokify/html-strip-tags-go"
+	"golang.org/x/net/html"
 
 	"github.com/apache/incubator-answer/pkg/checker"
 	"github.com/apache/incubator-answer/pkg/converter"
@@ -219,3 +220,116 @@ func GetPicByUrl(Url string) string {
 	}
 	return string(pix)
 }
+
+// ExternalLinks get the distinct http(s) links in the html which are not pointed to the site host,
+// at most limit links are returned in the order they appear
+func ExternalLinks(content, siteHost string, limit int) (links []string) {
+	links = make([]string, 0)
+	seen := make(map[string]bool)
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for len(links) < limit {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		if tokenType != html.StartTagToken {
+			continue
+		}
+		token := tokenizer.Token()
+		if token.Data != "a" {
+			continue
+		}
+		for _, attr := range token.Attr {
+			if attr.Key != "href" || !checker.IsURL(attr.Val) || seen[attr.Val] {
+				continue
+			}
+			u, err := url.Parse(attr.Val)
+			if err != nil || len(u.Hostname()) == 0 || strings.EqualFold(u.Hostname(), siteHost) {
+				continue
+			}
+			seen[attr.Val] = true
+			links = append(links, attr.Val)
+		}
+	}
+	return links
+}
+
+// MarkDeadLinks add the data-dead-link attribute to the anchors whose href is in the dead links,
+// the other parts of the html are kept as they are
+func MarkDeadLinks(content string, deadLinks []string) string {
+	if len(deadLinks) == 0 {
+		return content
+	}
+	dead := make(map[string]bool, len(deadLinks))
+	for _, link := range deadLinks {
+		dead[link] = true
+	}
+	var builder strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := tokenizer.Raw()
+		if tokenType != html.StartTagToken {
+			builder.Write(raw)
+			continue
+		}
+		token := tokenizer.Token()
+		marked := false
+		for _, attr := range token.Attr {
+			if attr.Key == "href" && dead[attr.Val] {
+				marked = true
+				break
+			}
+		}
+		if token.Data != "a" || !marked {
+			builder.Write(raw)
+			continue
+		}
+		token.Attr = append(token.Attr, html.Attribute{Key: "data-dead-link", Val: "true"})
+		builder.WriteString(token.String())
+	}
+	return builder.String()
+}
+
+// ReplaceImageSources replace the src of each image in the html with the result of replace,
+// the src is kept if the result is the same, the other parts of the html are kept as they are
+func ReplaceImageSources(content string, replace func(src string) string) string {
+	if !strings.Contains(content, "<img") {
+		return content
+	}
+	var builder strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			break
+		}
+		raw := tokenizer.Raw()
+		if tokenType != html.StartTagToken && tokenType != html.SelfClosingTagToken {
+			builder.Write(raw)
+			continue
+		}
+		token := tokenizer.Token()
+		replaced := false
+		if token.Data == "img" {
+			for i, attr := range token.Attr {
+				if attr.Key != "src" {
+					continue
+				}
+				if src := replace(attr.Val); src != attr.Val {
+					token.Attr[i].Val = src
+					replaced = true
+				}
+			}
+		}
+		if !replaced {
+			builder.Write(raw)
+			continue
+		}
+		builder.WriteString(token.String())
+	}
+	return builder.String()
+}
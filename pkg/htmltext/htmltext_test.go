@@ -21,6 +21,8 @@ package htmltext
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -204,3 +206,53 @@ func TestFetchMatchedExcerpt(t *testing.T) {
 	actual = FetchMatchedExcerpt(html, []string{"中文", "😂"}, "...", 6)
 	assert.Equal(t, expected, actual)
 }
+
+func TestStripInlineEventHandlers(t *testing.T) {
+	html := `<a href="javascript:alert(1)" onclick="alert(2)" class="link">on click</a><img src=x onerror=alert(3)>`
+	assert.Equal(t, `<a class="link">on click</a><img src=x>`, StripInlineEventHandlers(html))
+
+	html = `<script src="https://example.com/analytics.js"></script>`
+	assert.Equal(t, html, StripInlineEventHandlers(html))
+}
+
+func TestAddScriptNonce(t *testing.T) {
+	html := `<script src="/a.js"></script><SCRIPT>run()</SCRIPT><scripts></scripts>`
+	assert.Equal(t, `<script nonce="abc" src="/a.js"></script><script nonce="abc">run()</SCRIPT><scripts></scripts>`,
+		AddScriptNonce(html, "abc"))
+	assert.Equal(t, html, AddScriptNonce(html, ""))
+}
+
+func TestEscapeCloseTag(t *testing.T) {
+	assert.Equal(t, `var s = "<\/script><script>alert(1)<\/SCRIPT>"`,
+		EscapeCloseTag(`var s = "</script><script>alert(1)</SCRIPT>"`))
+}
+
+func TestExternalLinks(t *testing.T) {
+	content := `<p><a href="https://example.com/a">a</a> <a href="/questions/1">b</a>
+<a href="https://answer.dev/questions/1">c</a> <a href="https://example.com/a">d</a>
+<a href="javascript:alert(1)">e</a> <a href="http://go.dev">f</a> <a href="https://github.com">g</a></p>`
+	assert.Equal(t, []string{"https://example.com/a", "http://go.dev"}, ExternalLinks(content, "answer.dev", 2))
+	assert.Equal(t, []string{"https://example.com/a", "http://go.dev", "https://github.com"},
+		ExternalLinks(content, "answer.dev", 5))
+	assert.Empty(t, ExternalLinks("<p>no link</p>", "answer.dev", 5))
+}
+
+func TestMarkDeadLinks(t *testing.T) {
+	content := `<p>See <a href="https://example.com/gone" rel="nofollow">this</a> and <a href="https://go.dev">go</a></p>`
+	assert.Equal(t, `<p>See <a href="https://example.com/gone" rel="nofollow" data-dead-link="true">this</a> and <a href="https://go.dev">go</a></p>`,
+		MarkDeadLinks(content, []string{"https://example.com/gone"}))
+	assert.Equal(t, content, MarkDeadLinks(content, nil))
+}
+
+func TestReplaceImageSources(t *testing.T) {
+	content := `<p><img src="http://example.com/a.png" alt="a"/> and <img src="/uploads/b.png"></p>`
+	replace := func(src string) string {
+		if strings.HasPrefix(src, "http://") {
+			return "/proxy?url=" + url.QueryEscape(src)
+		}
+		return src
+	}
+	assert.Equal(t, `<p><img src="/proxy?url=http%3A%2F%2Fexample.com%2Fa.png" alt="a"/> and <img src="/uploads/b.png"></p>`,
+		ReplaceImageSources(content, replace))
+	assert.Equal(t, "<p>no image</p>", ReplaceImageSources("<p>no image</p>", replace))
+}
@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package minhash
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// ShingleSize the number of the runes in each shingle
+	ShingleSize = 5
+	// SignatureSize the number of the hash functions of the signature
+	SignatureSize = 128
+)
+
+// Signature the minhash signature of the text, the similarity of two texts is estimated by
+// comparing their signatures instead of all the shingles
+type Signature []uint64
+
+// Normalize lower the text and collapse all the punctuations and spaces into a single space,
+// so the formatting differences are ignored
+func Normalize(text string) string {
+	var builder strings.Builder
+	space := true
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			builder.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+// Shingles the hashes of all the distinct rune shingles of the normalized text
+func Shingles(text string) map[uint64]struct{} {
+	shingles := make(map[uint64]struct{})
+	runes := []rune(Normalize(text))
+	for i := 0; i+ShingleSize <= len(runes); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(string(runes[i : i+ShingleSize])))
+		shingles[h.Sum64()] = struct{}{}
+	}
+	return shingles
+}
+
+// NewSignature compute the minhash signature of the shingles, nil is returned if there is no shingle
+func NewSignature(shingles map[uint64]struct{}) Signature {
+	if len(shingles) == 0 {
+		return nil
+	}
+	signature := make(Signature, SignatureSize)
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+	for shingle := range shingles {
+		for i := range signature {
+			if h := mix(shingle + uint64(i)*0x9e3779b97f4a7c15); h < signature[i] {
+				signature[i] = h
+			}
+		}
+	}
+	return signature
+}
+
+// Similarity the estimated jaccard similarity of the two signatures, between 0 and 1
+func (s Signature) Similarity(other Signature) float64 {
+	if len(s) == 0 || len(s) != len(other) {
+		return 0
+	}
+	same := 0
+	for i := range s {
+		if s[i] == other[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(s))
+}
+
+// mix the splitmix64 finalizer, each seed makes a different hash function
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
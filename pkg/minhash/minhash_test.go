@@ -0,0 +1,46 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package minhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "hello world 42", Normalize("  Hello,   World!\n42 "))
+	assert.Equal(t, "", Normalize("!!!"))
+}
+
+func TestSimilarity(t *testing.T) {
+	text := "You can use the context package to cancel the goroutine when the request is finished."
+	same := NewSignature(Shingles(text))
+	assert.Equal(t, 1.0, same.Similarity(NewSignature(Shingles(text))))
+
+	edited := NewSignature(Shingles("You can use the context package to cancel the goroutine, when the request is done!"))
+	assert.Greater(t, same.Similarity(edited), 0.6)
+
+	other := NewSignature(Shingles("Try restarting the database server and check the connection settings in config.yaml."))
+	assert.Less(t, same.Similarity(other), 0.2)
+
+	assert.Nil(t, NewSignature(Shingles("hi")))
+	assert.Equal(t, 0.0, same.Similarity(nil))
+}
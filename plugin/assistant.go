@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+type Assistant interface {
+	Base
+	// Generate the text for the request, e.g. the answer draft of the question or the summary of the question.
+	// The generated text is a suggestion, it is shown to the user and never saved directly.
+	Generate(req *AssistantRequest) (resp *AssistantResponse, err error)
+}
+
+type AssistantAction string
+
+const (
+	AssistantActionAnswerDraft     AssistantAction = "answer_draft"
+	AssistantActionQuestionSummary AssistantAction = "question_summary"
+)
+
+// AssistantRequest is a struct that contains the content to be generated from
+type AssistantRequest struct {
+	// The action of the request, e.g. answer_draft, question_summary
+	Action AssistantAction
+	// The title of the question
+	Title string
+	// The plain text of the question
+	Content string
+	// The plain text of the answers of the question, only available for the summary
+	Answers []string
+	// The site language. e.g. en_US, the text should be generated in this language
+	Language string
+	// The user who requests the generation
+	UserID string
+}
+
+// AssistantResponse is a struct that contains the generated text
+type AssistantResponse struct {
+	// The generated markdown text
+	Content string
+	// The model which generates the text
+	Model string
+	// The token usage of the generation, they are recorded for the quota tracking
+	PromptTokens     int
+	CompletionTokens int
+}
+
+var (
+	// CallAssistant is a function that calls all registered assistants
+	CallAssistant,
+	registerAssistant = MakePlugin[Assistant](false)
+)
@@ -118,6 +118,31 @@ type Config interface {
 	ConfigReceiver(config []byte) error
 }
 
+// ConfigValidator is an optional interface for the Config plugin.
+// If implemented, the config is validated before it is saved.
+type ConfigValidator interface {
+	// ValidateConfig validates the config data which is encoded in JSON format.
+	// The config will not be saved if any error is returned.
+	ValidateConfig(config []byte) (errors []*ConfigFieldError)
+}
+
+// ConfigChangeNotifier is an optional interface for the Config plugin.
+// If implemented, it is notified after the new config is saved, so the plugin can re-init itself
+// (e.g. reconnect to the external API) without restarting Answer.
+type ConfigChangeNotifier interface {
+	// ConfigChanged is called after the ConfigReceiver receives the new config and the config is saved.
+	// The oldConfig is empty if the plugin has never been configured.
+	ConfigChanged(oldConfig, newConfig []byte) error
+}
+
+// ConfigFieldError is the validation error of the config field
+type ConfigFieldError struct {
+	// Name is the name of the config field
+	Name string
+	// Message will be shown to the user below the field
+	Message Translator
+}
+
 var (
 	// CallConfig is a function that calls all registered config plugins
 	CallConfig,
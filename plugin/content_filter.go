@@ -0,0 +1,70 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+type ContentFilter interface {
+	Base
+	// FilterPriority the content filters are executed in ascending order of the priority
+	FilterPriority() int
+	// FilterContent inspect the content before it is saved. The filter can modify the title, content
+	// and tags of the content directly, or reject the content by returning a rejected result.
+	FilterContent(content *FilterContent) (result *FilterResult)
+}
+
+type FilterAction string
+
+const (
+	FilterActionAdd    FilterAction = "add"
+	FilterActionUpdate FilterAction = "update"
+)
+
+// FilterContent is a struct that contains the content to be saved
+type FilterContent struct {
+	// The type of the content, e.g. question, answer, comment
+	ObjectType string
+	// The action of the content, add or update
+	Action FilterAction
+	// The id of the content, only available when updating
+	ObjectID string
+	// The title of the content, only available for the question
+	Title string
+	// The original markdown text of the content, always available
+	Content string
+	// The slug names of the tags, only available for the question
+	Tags []string
+	// The user who is saving the content
+	UserID string
+	// The language of the request. e.g. en_US
+	Language string
+}
+
+// FilterResult is a struct that contains the result of a content filter
+type FilterResult struct {
+	// If the content is rejected, the content will not be saved
+	Rejected bool
+	// The reason for rejection, it will be shown to the user
+	Reason string
+}
+
+var (
+	// CallContentFilter is a function that calls all registered content filters
+	CallContentFilter,
+	registerContentFilter = MakePlugin[ContentFilter](false)
+)
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import "context"
+
+type Embedder interface {
+	Base
+	// Embed converts the texts into vectors, the vectors are in the same order as the texts.
+	// The vectors of the different models can not be compared, so the model should be returned.
+	Embed(ctx context.Context, req *EmbeddingRequest) (resp *EmbeddingResponse, err error)
+}
+
+// EmbeddingRequest is a struct that contains the texts to be embedded
+type EmbeddingRequest struct {
+	// The plain texts, e.g. the title and the content of the question
+	Texts []string
+}
+
+// EmbeddingResponse is a struct that contains the vectors of the texts
+type EmbeddingResponse struct {
+	// The vectors in the same order as the texts
+	Vectors [][]float32
+	// The model which generates the vectors, all the contents are embedded again after it is changed
+	Model string
+}
+
+type VectorStore interface {
+	Base
+	// UpsertVectors adds the vectors or replaces the existing ones with the same object id
+	UpsertVectors(ctx context.Context, records []*VectorRecord) (err error)
+	// DeleteVectors deletes the vectors of the objects, all the vectors are deleted if the object ids are empty
+	DeleteVectors(ctx context.Context, objectIDs []string) (err error)
+	// QueryVectors returns the most similar vectors of the model, the most similar one first
+	QueryVectors(ctx context.Context, model string, vector []float32, limit int) (matches []*VectorMatch, err error)
+}
+
+// VectorRecord is a struct that contains the vector of the question or answer
+type VectorRecord struct {
+	ObjectID string
+	// ObjectType content type, example: "answer", "question"
+	ObjectType string
+	QuestionID string
+	Model      string
+	Vector     []float32
+}
+
+// VectorMatch is a struct that contains the similar object and its similarity
+type VectorMatch struct {
+	ObjectID   string
+	ObjectType string
+	// Score the cosine similarity, the larger the more similar
+	Score float64
+}
+
+var (
+	// CallEmbedder is a function that calls all registered embedders
+	CallEmbedder,
+	registerEmbedder = MakePlugin[Embedder](false)
+
+	// CallVectorStore is a function that calls all registered vector stores
+	CallVectorStore,
+	registerVectorStore = MakePlugin[VectorStore](false)
+)
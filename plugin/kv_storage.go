@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+	"fmt"
+)
+
+// KVStorage is implemented by the plugins that want to persist data in the namespaced key-value store
+type KVStorage interface {
+	Base
+
+	// SetOperator receives the operator of the key-value store, it calls when the plugin is initialized.
+	// The data of each plugin is isolated by the slug name of the plugin.
+	SetOperator(operator *KVOperator)
+}
+
+// KVStorageBackend is the persistence of the key-value store, it is provided by Answer
+type KVStorageBackend interface {
+	GetValue(ctx context.Context, pluginSlugName, group, key string) (value string, exist bool, err error)
+	GetValuesByGroup(ctx context.Context, pluginSlugName, group string) (values map[string]string, err error)
+	SetValue(ctx context.Context, pluginSlugName, group, key, value string) (err error)
+	DelValue(ctx context.Context, pluginSlugName, group, key string) (err error)
+}
+
+// KVOperator operates the key-value data of one plugin
+type KVOperator struct {
+	pluginSlugName string
+	backend        KVStorageBackend
+}
+
+// NewKVOperator creates the key-value operator of the plugin
+func NewKVOperator(pluginSlugName string, backend KVStorageBackend) *KVOperator {
+	return &KVOperator{pluginSlugName: pluginSlugName, backend: backend}
+}
+
+// Get returns the value of the key in the group, the group can be empty
+func (kv *KVOperator) Get(ctx context.Context, group, key string) (value string, exist bool, err error) {
+	if len(key) == 0 {
+		return "", false, fmt.Errorf("key is required")
+	}
+	return kv.backend.GetValue(ctx, kv.pluginSlugName, group, key)
+}
+
+// GetByGroup returns all the key-value pairs in the group
+func (kv *KVOperator) GetByGroup(ctx context.Context, group string) (values map[string]string, err error) {
+	return kv.backend.GetValuesByGroup(ctx, kv.pluginSlugName, group)
+}
+
+// Set saves the value of the key in the group, the old value will be overwritten
+func (kv *KVOperator) Set(ctx context.Context, group, key, value string) (err error) {
+	if len(key) == 0 {
+		return fmt.Errorf("key is required")
+	}
+	return kv.backend.SetValue(ctx, kv.pluginSlugName, group, key, value)
+}
+
+// Del deletes the key in the group. If the key is empty, all the keys in the group will be deleted.
+func (kv *KVOperator) Del(ctx context.Context, group, key string) (err error) {
+	return kv.backend.DelValue(ctx, kv.pluginSlugName, group, key)
+}
+
+var (
+	// CallKVStorage is a function that calls all registered key-value storage plugins
+	CallKVStorage,
+	registerKVStorage = MakePlugin[KVStorage](true)
+)
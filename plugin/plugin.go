@@ -67,6 +67,22 @@ func Register(p Base) {
 		registerFilter(p.(Filter))
 	}
 
+	if _, ok := p.(ContentFilter); ok {
+		registerContentFilter(p.(ContentFilter))
+	}
+
+	if _, ok := p.(Scheduler); ok {
+		registerScheduler(p.(Scheduler))
+	}
+
+	if _, ok := p.(KVStorage); ok {
+		registerKVStorage(p.(KVStorage))
+	}
+
+	if _, ok := p.(TableStorage); ok {
+		registerTableStorage(p.(TableStorage))
+	}
+
 	if _, ok := p.(Storage); ok {
 		registerStorage(p.(Storage))
 	}
@@ -106,6 +122,18 @@ func Register(p Base) {
 	if _, ok := p.(CDN); ok {
 		registerCDN(p.(CDN))
 	}
+
+	if _, ok := p.(Assistant); ok {
+		registerAssistant(p.(Assistant))
+	}
+
+	if _, ok := p.(Embedder); ok {
+		registerEmbedder(p.(Embedder))
+	}
+
+	if _, ok := p.(VectorStore); ok {
+		registerVectorStore(p.(VectorStore))
+	}
 }
 
 type Stack[T Base] struct {
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import "context"
+
+type Scheduler interface {
+	Base
+
+	// ScheduledJobs returns the jobs that should be run by the core scheduler
+	ScheduledJobs() []*ScheduledJob
+}
+
+// ScheduledJob is a job run periodically by the core scheduler
+type ScheduledJob struct {
+	// Name is the unique name of the job in the plugin, e.g. weekly_digest
+	Name string
+	// Title is shown in the admin plugin page
+	Title Translator
+	// Schedule is a standard cron expression, e.g. "0 */1 * * *", descriptors like "@every 10m" are also supported
+	Schedule string
+	// Handler is called when the job is triggered. The returned error is recorded as the last run status.
+	Handler func(ctx context.Context) error
+}
+
+var (
+	// CallScheduler is a function that calls all registered scheduler plugins
+	CallScheduler,
+	registerScheduler = MakePlugin[Scheduler](false)
+)
@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package plugin
+
+import (
+	"context"
+
+	"xorm.io/xorm"
+)
+
+// TableStorage is implemented by the plugins that manage their own database tables
+type TableStorage interface {
+	Base
+
+	// Tables returns the xorm beans of the plugin tables. The tables are synced when Answer starts.
+	// We recommend to prefix the table name with the slug name of the plugin to avoid conflicts.
+	Tables() []any
+	// TableMigrations returns the migrations of the plugin tables in ascending order of the version.
+	// Only the migrations with version greater than the last migrated version will be executed.
+	TableMigrations() []*TableMigration
+	// TableReceiver receives the database engine after the tables are synced and migrated.
+	TableReceiver(engine *xorm.Engine)
+}
+
+// TableMigration is a migration of the plugin tables
+type TableMigration struct {
+	// Version must be greater than 0 and increase with each migration
+	Version     int
+	Description string
+	Migrate     func(ctx context.Context, engine *xorm.Engine) error
+}
+
+var (
+	// CallTableStorage is a function that calls all registered table storage plugins
+	CallTableStorage,
+	registerTableStorage = MakePlugin[TableStorage](true)
+)
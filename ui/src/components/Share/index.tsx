@@ -42,9 +42,7 @@ const Index: FC<IProps> = ({ type, qid, aid, title }) => {
   const [canSystemShare, setSystemShareState] = useState(false);
   const { t } = useTranslation();
   let baseUrl =
-    type === 'question'
-      ? `${BASE_ORIGIN}/questions/${qid}`
-      : `${BASE_ORIGIN}/questions/${qid}/${aid}`;
+    type === 'question' ? `${BASE_ORIGIN}/q/${qid}` : `${BASE_ORIGIN}/a/${aid}`;
   if (user.id) {
     baseUrl = `${baseUrl}?shareUserId=${user.username}`;
   }